@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	apiAliasPrefix   = []byte("api")
+	chainAliasPrefix = []byte("chain")
+)
+
+// aliasStore persists the aliases added through the admin API so that they
+// can be re-applied when the node restarts.
+type aliasStore struct {
+	// alias -> endpoint
+	apiDB database.Database
+	// alias -> chainID
+	chainDB database.Database
+}
+
+func newAliasStore(db database.Database) *aliasStore {
+	return &aliasStore{
+		apiDB:   prefixdb.New(apiAliasPrefix, db),
+		chainDB: prefixdb.New(chainAliasPrefix, db),
+	}
+}
+
+func (s *aliasStore) putAPIAlias(endpoint, alias string) error {
+	return s.apiDB.Put([]byte(alias), []byte(endpoint))
+}
+
+func (s *aliasStore) putChainAlias(chainID ids.ID, alias string) error {
+	return s.chainDB.Put([]byte(alias), chainID[:])
+}
+
+// deleteAPIAlias removes [alias] from the store. Returns false if [alias] was
+// not previously persisted.
+func (s *aliasStore) deleteAPIAlias(alias string) (bool, error) {
+	return deleteIfExists(s.apiDB, []byte(alias))
+}
+
+// deleteChainAlias removes [alias] from the store. Returns false if [alias] was
+// not previously persisted.
+func (s *aliasStore) deleteChainAlias(alias string) (bool, error) {
+	return deleteIfExists(s.chainDB, []byte(alias))
+}
+
+// apiAliases returns the persisted aliases of each endpoint.
+func (s *aliasStore) apiAliases() (map[string][]string, error) {
+	it := s.apiDB.NewIterator()
+	defer it.Release()
+
+	aliases := make(map[string][]string)
+	for it.Next() {
+		endpoint := string(it.Value())
+		aliases[endpoint] = append(aliases[endpoint], string(it.Key()))
+	}
+	return aliases, it.Error()
+}
+
+// chainAliases returns the persisted aliases of each chain.
+func (s *aliasStore) chainAliases() (map[ids.ID][]string, error) {
+	it := s.chainDB.NewIterator()
+	defer it.Release()
+
+	aliases := make(map[ids.ID][]string)
+	for it.Next() {
+		chainID, err := ids.ToID(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		aliases[chainID] = append(aliases[chainID], string(it.Key()))
+	}
+	return aliases, it.Error()
+}
+
+// PersistedAliases returns the API and chain aliases that were added through
+// the admin API and persisted into [db].
+func PersistedAliases(db database.Database) (map[string][]string, map[ids.ID][]string, error) {
+	s := newAliasStore(db)
+	apiAliases, err := s.apiAliases()
+	if err != nil {
+		return nil, nil, err
+	}
+	chainAliases, err := s.chainAliases()
+	return apiAliases, chainAliases, err
+}
+
+func deleteIfExists(db database.Database, key []byte) (bool, error) {
+	has, err := db.Has(key)
+	if err != nil || !has {
+		return false, err
+	}
+	return true, db.Delete(key)
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type testChainManager struct {
+	chains.Manager
+	removedAliases []string
+}
+
+func (m *testChainManager) RemoveAlias(alias string) {
+	m.removedAliases = append(m.removedAliases, alias)
+}
+
+func TestPersistedAliases(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	s := newAliasStore(db)
+
+	chainID := ids.GenerateTestID()
+	require.NoError(s.putAPIAlias("endpoint", "alias1"))
+	require.NoError(s.putAPIAlias("endpoint", "alias2"))
+	require.NoError(s.putChainAlias(chainID, "chain-alias"))
+
+	apiAliases, chainAliases, err := PersistedAliases(db)
+	require.NoError(err)
+	require.Equal(map[string][]string{
+		"endpoint": {"alias1", "alias2"},
+	}, apiAliases)
+	require.Equal(map[ids.ID][]string{
+		chainID: {"chain-alias"},
+	}, chainAliases)
+
+	removed, err := s.deleteAPIAlias("alias1")
+	require.NoError(err)
+	require.True(removed)
+
+	removed, err = s.deleteAPIAlias("alias1")
+	require.NoError(err)
+	require.False(removed)
+
+	removed, err = s.deleteChainAlias("chain-alias")
+	require.NoError(err)
+	require.True(removed)
+
+	apiAliases, chainAliases, err = PersistedAliases(db)
+	require.NoError(err)
+	require.Equal(map[string][]string{
+		"endpoint": {"alias2"},
+	}, apiAliases)
+	require.Empty(chainAliases)
+}
+
+func TestListAndRemoveAliases(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	s := newAliasStore(memdb.New())
+	chainManager := &testChainManager{Manager: chains.TestManager}
+	httpServer := server.NewMockServer(ctrl)
+	admin := &Admin{
+		Config: Config{
+			Log:          logging.NoLog{},
+			ChainManager: chainManager,
+			HTTPServer:   httpServer,
+		},
+		aliases: s,
+	}
+
+	chainID := ids.GenerateTestID()
+	require.NoError(s.putAPIAlias("endpoint", "alias"))
+	require.NoError(s.putChainAlias(chainID, "chain-alias"))
+
+	reply := ListAliasesReply{}
+	require.NoError(admin.ListAliases(&http.Request{}, nil, &reply))
+	require.Equal(map[string][]string{
+		"endpoint": {"alias"},
+	}, reply.APIAliases)
+	require.Equal(map[ids.ID][]string{
+		chainID: {"chain-alias"},
+	}, reply.ChainAliases)
+
+	// Removed aliases stop resolving immediately.
+	httpServer.EXPECT().RemoveAliasesWithReadLock("alias").Return(nil)
+	require.NoError(admin.RemoveAlias(&http.Request{}, &RemoveAliasArgs{Alias: "alias"}, &api.EmptyReply{}))
+	err := admin.RemoveAlias(&http.Request{}, &RemoveAliasArgs{Alias: "alias"}, &api.EmptyReply{})
+	require.ErrorIs(err, errAliasNotFound)
+
+	httpServer.EXPECT().RemoveAliasesWithReadLock("bc/chain-alias").Return(nil)
+	require.NoError(admin.RemoveChainAlias(&http.Request{}, &RemoveAliasArgs{Alias: "chain-alias"}, &api.EmptyReply{}))
+	require.Equal([]string{"chain-alias"}, chainManager.removedAliases)
+	err = admin.RemoveChainAlias(&http.Request{}, &RemoveAliasArgs{Alias: "chain-alias"}, &api.EmptyReply{})
+	require.ErrorIs(err, errAliasNotFound)
+
+	reply = ListAliasesReply{}
+	require.NoError(admin.ListAliases(&http.Request{}, nil, &reply))
+	require.Empty(reply.APIAliases)
+	require.Empty(reply.ChainAliases)
+}
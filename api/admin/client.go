@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/capture"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
@@ -23,11 +24,23 @@ type Client interface {
 	Alias(ctx context.Context, endpoint string, alias string, options ...rpc.Option) error
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) error
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
+	ListAliases(context.Context, ...rpc.Option) (map[string][]string, map[ids.ID][]string, error)
+	RemoveAlias(ctx context.Context, alias string, options ...rpc.Option) error
+	RemoveChainAlias(ctx context.Context, alias string, options ...rpc.Option) error
 	Stacktrace(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	StartMessageCapture(ctx context.Context, args *StartMessageCaptureArgs, options ...rpc.Option) (string, error)
+	StopMessageCapture(context.Context, ...rpc.Option) error
+	GetCapturedMessages(context.Context, ...rpc.Option) (bool, []capture.Record, error)
+	SetValidatorWeights(ctx context.Context, subnetID ids.ID, validators []ValidatorWeight, options ...rpc.Option) error
+	GetStakingCertificates(context.Context, ...rpc.Option) (*StakingCertificatesReply, error)
+	SwitchStakingCertificate(context.Context, ...rpc.Option) (*StakingCertificatesReply, error)
+	CollectSharedMemoryGarbage(context.Context, ...rpc.Option) (*CollectSharedMemoryGarbageReply, error)
+	GetPeerReputations(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) ([]PeerReputation, error)
+	ResetPeerReputations(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) error
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -80,6 +93,24 @@ func (c *client) GetChainAliases(ctx context.Context, chain string, options ...r
 	return res.Aliases, err
 }
 
+func (c *client) ListAliases(ctx context.Context, options ...rpc.Option) (map[string][]string, map[ids.ID][]string, error) {
+	res := &ListAliasesReply{}
+	err := c.requester.SendRequest(ctx, "admin.listAliases", struct{}{}, res, options...)
+	return res.APIAliases, res.ChainAliases, err
+}
+
+func (c *client) RemoveAlias(ctx context.Context, alias string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.removeAlias", &RemoveAliasArgs{
+		Alias: alias,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) RemoveChainAlias(ctx context.Context, alias string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.removeChainAlias", &RemoveAliasArgs{
+		Alias: alias,
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) Stacktrace(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "admin.stacktrace", struct{}{}, &api.EmptyReply{}, options...)
 }
@@ -138,3 +169,58 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "admin.getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) StartMessageCapture(ctx context.Context, args *StartMessageCaptureArgs, options ...rpc.Option) (string, error) {
+	res := &StartMessageCaptureReply{}
+	err := c.requester.SendRequest(ctx, "admin.startMessageCapture", args, res, options...)
+	return res.Path, err
+}
+
+func (c *client) StopMessageCapture(ctx context.Context, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.stopMessageCapture", struct{}{}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetCapturedMessages(ctx context.Context, options ...rpc.Option) (bool, []capture.Record, error) {
+	res := &GetCapturedMessagesReply{}
+	err := c.requester.SendRequest(ctx, "admin.getCapturedMessages", struct{}{}, res, options...)
+	return res.Capturing, res.Records, err
+}
+
+func (c *client) SetValidatorWeights(ctx context.Context, subnetID ids.ID, validators []ValidatorWeight, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.setValidatorWeights", &SetValidatorWeightsArgs{
+		SubnetID:   subnetID,
+		Validators: validators,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetStakingCertificates(ctx context.Context, options ...rpc.Option) (*StakingCertificatesReply, error) {
+	res := &StakingCertificatesReply{}
+	err := c.requester.SendRequest(ctx, "admin.getStakingCertificates", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) SwitchStakingCertificate(ctx context.Context, options ...rpc.Option) (*StakingCertificatesReply, error) {
+	res := &StakingCertificatesReply{}
+	err := c.requester.SendRequest(ctx, "admin.switchStakingCertificate", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) CollectSharedMemoryGarbage(ctx context.Context, options ...rpc.Option) (*CollectSharedMemoryGarbageReply, error) {
+	res := &CollectSharedMemoryGarbageReply{}
+	err := c.requester.SendRequest(ctx, "admin.collectSharedMemoryGarbage", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) GetPeerReputations(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) ([]PeerReputation, error) {
+	res := &GetPeerReputationsReply{}
+	err := c.requester.SendRequest(ctx, "admin.getPeerReputations", &GetPeerReputationsArgs{
+		NodeIDs: nodeIDs,
+	}, res, options...)
+	return res.Reputations, err
+}
+
+func (c *client) ResetPeerReputations(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.resetPeerReputations", &ResetPeerReputationsArgs{
+		NodeIDs: nodeIDs,
+	}, &api.EmptyReply{}, options...)
+}
@@ -60,6 +60,9 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *GetChainAliasesReply:
 		response := mc.response.(*GetChainAliasesReply)
 		*p = *response
+	case *ListAliasesReply:
+		response := mc.response.(*ListAliasesReply)
+		*p = *response
 	case *LoadVMsReply:
 		response := mc.response.(*LoadVMsReply)
 		*p = *response
@@ -168,6 +171,58 @@ func TestGetChainAliases(t *testing.T) {
 	})
 }
 
+func TestListAliases(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedAPIAliases := map[string][]string{
+			"endpoint": {"alias1", "alias2"},
+		}
+		expectedChainAliases := map[ids.ID][]string{
+			ids.GenerateTestID(): {"chain-alias"},
+		}
+		mockClient := client{requester: NewMockClient(&ListAliasesReply{
+			APIAliases:   expectedAPIAliases,
+			ChainAliases: expectedChainAliases,
+		}, nil)}
+
+		apiAliases, chainAliases, err := mockClient.ListAliases(context.Background())
+		require.NoError(err)
+		require.Equal(expectedAPIAliases, apiAliases)
+		require.Equal(expectedChainAliases, chainAliases)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ListAliasesReply{}, errTest)}
+		_, _, err := mockClient.ListAliases(context.Background())
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestRemoveAlias(t *testing.T) {
+	require := require.New(t)
+
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.RemoveAlias(context.Background(), "alias")
+		require.ErrorIs(err, test.Err)
+	}
+}
+
+func TestRemoveChainAlias(t *testing.T) {
+	require := require.New(t)
+
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.RemoveChainAlias(context.Background(), "chain-alias")
+		require.ErrorIs(err, test.Err)
+	}
+}
+
 func TestStacktrace(t *testing.T) {
 	require := require.New(t)
 
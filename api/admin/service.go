@@ -4,19 +4,28 @@
 package admin
 
 import (
-	"errors"
+	"fmt"
 	"net/http"
 	"path"
+	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/rpcerror"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/capture"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -35,8 +44,17 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong  = rpcerror.New(rpcerror.InvalidArgument, "alias length is too long")
+	errAliasNotFound = rpcerror.New(rpcerror.NotFound, "alias was not added through the admin API")
+	errNoLogLevel    = rpcerror.New(rpcerror.InvalidArgument, "need to specify either displayLevel or logLevel")
+
+	errPublicNetwork = rpcerror.New(rpcerror.PermissionDenied, "not supported on public networks")
+
+	errNoStandbyStakingCert = rpcerror.New(rpcerror.FailedPrecondition, "no standby staking certificate is configured")
+
+	errSharedMemoryGCDisabled = rpcerror.New(rpcerror.FailedPrecondition, "shared memory garbage collection is disabled")
+
+	errReputationsDisabled = rpcerror.New(rpcerror.FailedPrecondition, "peer reputations are disabled")
 )
 
 type Config struct {
@@ -48,12 +66,37 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	// DB is used to persist the aliases added through the API.
+	DB database.Database
+	// Capturer records p2p messages when requested through the API.
+	Capturer capture.Capturer
+	// NetworkID and Validators are used to modify the validator sets of test
+	// networks.
+	NetworkID  uint32
+	Validators validators.Manager
+	// NodeID is the ID the node is running with.
+	NodeID ids.NodeID
+	// StakingKeyPair and StandbyStakingKeyPair are the staking key pairs that
+	// are switched through the API. If StandbyStakingKeyPair is the zero
+	// value, they can't be switched.
+	StakingKeyPair        staking.KeyPairPaths
+	StandbyStakingKeyPair staking.KeyPairPaths
+	// SharedMemoryGC garbage collects shared memory when requested through
+	// the API. If nil, shared memory garbage collection is disabled.
+	SharedMemoryGC *atomic.GarbageCollector
+	// Reputations records the misbehaviors of peers. If nil, peer
+	// reputations are disabled.
+	Reputations reputation.Store
 }
 
 // Admin is the API service for node admin management
 type Admin struct {
 	Config
 	profiler profiler.Profiler
+	aliases  *aliasStore
+
+	// stakingLock is held while the staking key pairs are read or switched.
+	stakingLock sync.Mutex
 }
 
 // NewService returns a new admin API service.
@@ -66,6 +109,7 @@ func NewService(config Config) (*common.HTTPHandler, error) {
 	if err := newServer.RegisterService(&Admin{
 		Config:   config,
 		profiler: profiler.New(config.ProfileDir),
+		aliases:  newAliasStore(config.DB),
 	}, "admin"); err != nil {
 		return nil, err
 	}
@@ -131,7 +175,10 @@ func (a *Admin) Alias(_ *http.Request, args *AliasArgs, _ *api.EmptyReply) error
 		return errAliasTooLong
 	}
 
-	return a.HTTPServer.AddAliasesWithReadLock(args.Endpoint, args.Alias)
+	if err := a.HTTPServer.AddAliasesWithReadLock(args.Endpoint, args.Alias); err != nil {
+		return err
+	}
+	return a.aliases.putAPIAlias(args.Endpoint, args.Alias)
 }
 
 // AliasChainArgs are the arguments for calling AliasChain
@@ -163,7 +210,80 @@ func (a *Admin) AliasChain(_ *http.Request, args *AliasChainArgs, _ *api.EmptyRe
 
 	endpoint := path.Join(constants.ChainAliasPrefix, chainID.String())
 	alias := path.Join(constants.ChainAliasPrefix, args.Alias)
-	return a.HTTPServer.AddAliasesWithReadLock(endpoint, alias)
+	if err := a.HTTPServer.AddAliasesWithReadLock(endpoint, alias); err != nil {
+		return err
+	}
+	return a.aliases.putChainAlias(chainID, args.Alias)
+}
+
+// ListAliasesReply are the aliases that were added through the admin API
+type ListAliasesReply struct {
+	// Endpoint -> aliases
+	APIAliases map[string][]string `json:"apiAliases"`
+	// ChainID -> aliases
+	ChainAliases map[ids.ID][]string `json:"chainAliases"`
+}
+
+// ListAliases returns the aliases that were added through the admin API. These
+// aliases are re-applied when the node restarts.
+func (a *Admin) ListAliases(_ *http.Request, _ *struct{}, reply *ListAliasesReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "listAliases"),
+	)
+
+	var err error
+	reply.APIAliases, err = a.aliases.apiAliases()
+	if err != nil {
+		return err
+	}
+	reply.ChainAliases, err = a.aliases.chainAliases()
+	return err
+}
+
+// RemoveAliasArgs are the arguments for calling RemoveAlias and
+// RemoveChainAlias
+type RemoveAliasArgs struct {
+	Alias string `json:"alias"`
+}
+
+// RemoveAlias removes an HTTP endpoint alias that was added through Alias.
+func (a *Admin) RemoveAlias(_ *http.Request, args *RemoveAliasArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "removeAlias"),
+		logging.UserString("alias", args.Alias),
+	)
+
+	removed, err := a.aliases.deleteAPIAlias(args.Alias)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("%w: %q", errAliasNotFound, args.Alias)
+	}
+	return a.HTTPServer.RemoveAliasesWithReadLock(args.Alias)
+}
+
+// RemoveChainAlias removes a chain alias that was added through AliasChain.
+func (a *Admin) RemoveChainAlias(_ *http.Request, args *RemoveAliasArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "removeChainAlias"),
+		logging.UserString("alias", args.Alias),
+	)
+
+	removed, err := a.aliases.deleteChainAlias(args.Alias)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("%w: %q", errAliasNotFound, args.Alias)
+	}
+
+	a.ChainManager.RemoveAlias(args.Alias)
+	alias := path.Join(constants.ChainAliasPrefix, args.Alias)
+	return a.HTTPServer.RemoveAliasesWithReadLock(alias)
 }
 
 // GetChainAliasesArgs are the arguments for calling GetChainAliases
@@ -345,3 +465,353 @@ func (a *Admin) LoadVMs(r *http.Request, _ *struct{}, reply *LoadVMsReply) error
 	reply.NewVMs, err = ids.GetRelevantAliases(a.VMManager, loadedVMs)
 	return err
 }
+
+// See StartMessageCapture
+type StartMessageCaptureArgs struct {
+	// If non-empty, only messages exchanged with this peer are captured.
+	NodeID string `json:"nodeID"`
+	// If non-empty, only messages of this chain are captured. May be an
+	// alias.
+	Chain          string      `json:"chain"`
+	MaxRecords     json.Uint32 `json:"maxRecords"`
+	MaxPayloadSize json.Uint32 `json:"maxPayloadSize"`
+	WriteToFile    bool        `json:"writeToFile"`
+}
+
+// See StartMessageCapture
+type StartMessageCaptureReply struct {
+	// Path of the file records are written to, if any
+	Path string `json:"path,omitempty"`
+}
+
+// StartMessageCapture starts recording the p2p messages that match [args].
+func (a *Admin) StartMessageCapture(_ *http.Request, args *StartMessageCaptureArgs, reply *StartMessageCaptureReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "startMessageCapture"),
+		logging.UserString("nodeID", args.NodeID),
+		logging.UserString("chain", args.Chain),
+	)
+
+	config := capture.Config{
+		MaxRecords:     int(args.MaxRecords),
+		MaxPayloadSize: int(args.MaxPayloadSize),
+		WriteToFile:    args.WriteToFile,
+	}
+	if len(args.NodeID) > 0 {
+		nodeID, err := ids.NodeIDFromString(args.NodeID)
+		if err != nil {
+			return err
+		}
+		config.NodeID = nodeID
+	}
+	if len(args.Chain) > 0 {
+		chainID, err := a.ChainManager.Lookup(args.Chain)
+		if err != nil {
+			return err
+		}
+		config.ChainID = chainID
+	}
+
+	var err error
+	reply.Path, err = a.Capturer.Start(config)
+	return err
+}
+
+// StopMessageCapture stops recording p2p messages. The recorded messages
+// remain available until the next capture is started.
+func (a *Admin) StopMessageCapture(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "stopMessageCapture"),
+	)
+
+	return a.Capturer.Stop()
+}
+
+// See GetCapturedMessages
+type GetCapturedMessagesReply struct {
+	Capturing bool             `json:"capturing"`
+	Records   []capture.Record `json:"records"`
+}
+
+// GetCapturedMessages returns the p2p messages recorded by the current or
+// last capture, from oldest to newest.
+func (a *Admin) GetCapturedMessages(_ *http.Request, _ *struct{}, reply *GetCapturedMessagesReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getCapturedMessages"),
+	)
+
+	reply.Capturing = a.Capturer.Capturing()
+	reply.Records = a.Capturer.Records()
+	return nil
+}
+
+// ValidatorWeight is the weight of a validator
+type ValidatorWeight struct {
+	NodeID ids.NodeID  `json:"nodeID"`
+	Weight json.Uint64 `json:"weight"`
+}
+
+// SetValidatorWeightsArgs are the arguments for calling SetValidatorWeights
+type SetValidatorWeightsArgs struct {
+	// if omitted, defaults to primary network
+	SubnetID   ids.ID            `json:"subnetID"`
+	Validators []ValidatorWeight `json:"validators"`
+}
+
+// SetValidatorWeights sets the weights of validators in this node's validator
+// set of a subnet, without issuing any staking transactions. A weight of 0
+// removes the validator.
+//
+// This is only intended to quickly test consensus behavior on local networks,
+// so it is disabled on the public networks. The P-chain isn't aware of these
+// changes, so they may conflict with staking transactions accepted afterwards.
+func (a *Admin) SetValidatorWeights(_ *http.Request, args *SetValidatorWeightsArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "setValidatorWeights"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Int("numValidators", len(args.Validators)),
+	)
+
+	if a.NetworkID == constants.MainnetID || a.NetworkID == constants.FujiID {
+		return fmt.Errorf("%w: %s", errPublicNetwork, constants.NetworkName(a.NetworkID))
+	}
+
+	for _, vdr := range args.Validators {
+		if err := validators.SetWeight(a.Validators, args.SubnetID, vdr.NodeID, uint64(vdr.Weight)); err != nil {
+			return fmt.Errorf("couldn't set weight of %s: %w", vdr.NodeID, err)
+		}
+	}
+
+	a.Log.Warn("validator weights were set through the admin API",
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Int("numValidators", len(args.Validators)),
+	)
+	return nil
+}
+
+// StakingCertificatesReply describes the staking certificates of the node.
+type StakingCertificatesReply struct {
+	// NodeID is the ID the node is running with.
+	NodeID ids.NodeID `json:"nodeID"`
+	// NextNodeID is the ID the node will run with once it restarts.
+	NextNodeID ids.NodeID `json:"nextNodeID"`
+	// StandbyNodeID is the ID of the standby staking certificate.
+	StandbyNodeID ids.NodeID `json:"standbyNodeID"`
+	// RestartRequired is true if the node must be restarted to run with
+	// NextNodeID.
+	RestartRequired bool `json:"restartRequired"`
+}
+
+// GetStakingCertificates returns the node IDs of the active and standby
+// staking certificates.
+func (a *Admin) GetStakingCertificates(_ *http.Request, _ *struct{}, reply *StakingCertificatesReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getStakingCertificates"),
+	)
+
+	a.stakingLock.Lock()
+	defer a.stakingLock.Unlock()
+
+	return a.getStakingCertificates(reply)
+}
+
+// SwitchStakingCertificate swaps the active and standby staking certificates,
+// so that the node runs with the standby certificate once it restarts. This is
+// intended to respond to the compromise of the active staking key. Calling
+// SwitchStakingCertificate again before restarting reverts the switch.
+//
+// The node ID changes along with the certificate. The P-chain can't rotate the
+// node ID of a validator, so the standby node ID must be registered as a
+// validator separately.
+func (a *Admin) SwitchStakingCertificate(_ *http.Request, _ *struct{}, reply *StakingCertificatesReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "switchStakingCertificate"),
+	)
+
+	a.stakingLock.Lock()
+	defer a.stakingLock.Unlock()
+
+	if a.StandbyStakingKeyPair == (staking.KeyPairPaths{}) {
+		return errNoStandbyStakingCert
+	}
+	if err := staking.SwitchKeyPairs(a.StakingKeyPair, a.StandbyStakingKeyPair); err != nil {
+		return fmt.Errorf("couldn't switch staking certificates: %w", err)
+	}
+	if err := a.getStakingCertificates(reply); err != nil {
+		return err
+	}
+
+	a.Log.Warn("staking certificates were switched through the admin API",
+		zap.Stringer("nodeID", reply.NodeID),
+		zap.Stringer("nextNodeID", reply.NextNodeID),
+		zap.Bool("restartRequired", reply.RestartRequired),
+	)
+	return nil
+}
+
+// Assumes [a.stakingLock] is held.
+func (a *Admin) getStakingCertificates(reply *StakingCertificatesReply) error {
+	if a.StandbyStakingKeyPair == (staking.KeyPairPaths{}) {
+		return errNoStandbyStakingCert
+	}
+
+	activeCert, err := a.StakingKeyPair.Load()
+	if err != nil {
+		return fmt.Errorf("couldn't load staking certificate: %w", err)
+	}
+	standbyCert, err := a.StandbyStakingKeyPair.Load()
+	if err != nil {
+		return fmt.Errorf("couldn't load standby staking certificate: %w", err)
+	}
+
+	reply.NodeID = a.NodeID
+	reply.NextNodeID = ids.NodeIDFromCert(activeCert)
+	reply.StandbyNodeID = ids.NodeIDFromCert(standbyCert)
+	reply.RestartRequired = reply.NextNodeID != a.NodeID
+	return nil
+}
+
+// CollectSharedMemoryGarbageReply describes the outcome of a shared memory
+// garbage collection pass.
+type CollectSharedMemoryGarbageReply struct {
+	// NumRemoved is the number of expired records that were deleted.
+	NumRemoved json.Uint64 `json:"numRemoved"`
+	// ReclaimedBytes is the number of bytes of keys and values that were
+	// deleted.
+	ReclaimedBytes json.Uint64 `json:"reclaimedBytes"`
+}
+
+// CollectSharedMemoryGarbage deletes the shared memory records that are older
+// than the configured retention, without waiting for the next periodic pass.
+func (a *Admin) CollectSharedMemoryGarbage(_ *http.Request, _ *struct{}, reply *CollectSharedMemoryGarbageReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "collectSharedMemoryGarbage"),
+	)
+
+	if a.SharedMemoryGC == nil {
+		return errSharedMemoryGCDisabled
+	}
+	result, err := a.SharedMemoryGC.Collect()
+	reply.NumRemoved = json.Uint64(result.NumRemoved)
+	reply.ReclaimedBytes = json.Uint64(result.NumBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't garbage collect shared memory: %w", err)
+	}
+	return nil
+}
+
+// PeerReputation is the record of the misbehaviors of a peer.
+type PeerReputation struct {
+	NodeID            ids.NodeID  `json:"nodeID"`
+	HandshakeFailures json.Uint64 `json:"handshakeFailures"`
+	InvalidMessages   json.Uint64 `json:"invalidMessages"`
+	Benchings         json.Uint64 `json:"benchings"`
+	// Penalty is the sum of the penalties of the misbehaviors, decayed to
+	// now.
+	Penalty         json.Float64 `json:"penalty"`
+	LastMisbehavior time.Time    `json:"lastMisbehavior"`
+	// Allowed is false if inbound connections from the peer are dropped and
+	// the peer isn't gossiped to.
+	Allowed bool `json:"allowed"`
+}
+
+func (r PeerReputation) Less(other PeerReputation) bool {
+	return r.NodeID.Less(other.NodeID)
+}
+
+// GetPeerReputationsArgs are the arguments for calling GetPeerReputations
+type GetPeerReputationsArgs struct {
+	// if omitted, the reputations of all peers are returned
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+}
+
+// GetPeerReputationsReply is the response from calling GetPeerReputations
+type GetPeerReputationsReply struct {
+	Reputations []PeerReputation `json:"reputations"`
+}
+
+// GetPeerReputations returns the reputations of the peers whose misbehaviors
+// were recorded, sorted by node ID. Peers without recorded misbehaviors are
+// omitted.
+func (a *Admin) GetPeerReputations(_ *http.Request, args *GetPeerReputationsArgs, reply *GetPeerReputationsReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getPeerReputations"),
+		zap.Int("numNodeIDs", len(args.NodeIDs)),
+	)
+
+	if a.Reputations == nil {
+		return errReputationsDisabled
+	}
+
+	reputations := a.Reputations.GetAll()
+	if len(args.NodeIDs) > 0 {
+		requested := make(map[ids.NodeID]reputation.Reputation, len(args.NodeIDs))
+		for _, nodeID := range args.NodeIDs {
+			if r, ok := reputations[nodeID]; ok {
+				requested[nodeID] = r
+			}
+		}
+		reputations = requested
+	}
+
+	reply.Reputations = make([]PeerReputation, 0, len(reputations))
+	for nodeID, r := range reputations {
+		reply.Reputations = append(reply.Reputations, PeerReputation{
+			NodeID:            nodeID,
+			HandshakeFailures: json.Uint64(r.HandshakeFailures),
+			InvalidMessages:   json.Uint64(r.InvalidMessages),
+			Benchings:         json.Uint64(r.Benchings),
+			Penalty:           json.Float64(r.Penalty),
+			LastMisbehavior:   r.LastMisbehavior,
+			Allowed:           a.Reputations.Allowed(nodeID),
+		})
+	}
+	utils.Sort(reply.Reputations)
+	return nil
+}
+
+// ResetPeerReputationsArgs are the arguments for calling ResetPeerReputations
+type ResetPeerReputationsArgs struct {
+	// if omitted, the reputations of all peers are reset
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+}
+
+// ResetPeerReputations forgets the recorded misbehaviors of peers, so that
+// they are allowed to connect and are gossiped to again.
+func (a *Admin) ResetPeerReputations(_ *http.Request, args *ResetPeerReputationsArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "resetPeerReputations"),
+		zap.Int("numNodeIDs", len(args.NodeIDs)),
+	)
+
+	if a.Reputations == nil {
+		return errReputationsDisabled
+	}
+
+	if len(args.NodeIDs) == 0 {
+		if err := a.Reputations.ResetAll(); err != nil {
+			return fmt.Errorf("couldn't reset peer reputations: %w", err)
+		}
+		a.Log.Info("all peer reputations were reset through the admin API")
+		return nil
+	}
+
+	for _, nodeID := range args.NodeIDs {
+		if err := a.Reputations.Reset(nodeID); err != nil {
+			return fmt.Errorf("couldn't reset reputation of %s: %w", nodeID, err)
+		}
+	}
+	a.Log.Info("peer reputations were reset through the admin API",
+		zap.Int("numNodeIDs", len(args.NodeIDs)),
+	)
+	return nil
+}
@@ -5,13 +5,25 @@ package admin
 
 import (
 	"net/http"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stretchr/testify/require"
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/reputation"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/registry"
@@ -117,3 +129,236 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 	err := resources.admin.LoadVMs(&http.Request{}, nil, &reply)
 	require.ErrorIs(err, errTest)
 }
+
+func TestSetValidatorWeights(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+
+	tests := []struct {
+		name           string
+		networkID      uint32
+		subnetID       ids.ID
+		expectedErr    error
+		expectedWeight uint64
+	}{
+		{
+			name:           "local network",
+			networkID:      constants.LocalID,
+			subnetID:       subnetID,
+			expectedWeight: 5,
+		},
+		{
+			name:        "mainnet",
+			networkID:   constants.MainnetID,
+			subnetID:    subnetID,
+			expectedErr: errPublicNetwork,
+		},
+		{
+			name:        "fuji",
+			networkID:   constants.FujiID,
+			subnetID:    subnetID,
+			expectedErr: errPublicNetwork,
+		},
+		{
+			name:        "unknown subnet",
+			networkID:   constants.LocalID,
+			subnetID:    ids.GenerateTestID(),
+			expectedErr: validators.ErrMissingValidators,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vdrs := validators.NewSet()
+			manager := validators.NewManager()
+			manager.Add(subnetID, vdrs)
+
+			admin := &Admin{Config: Config{
+				Log:        logging.NoLog{},
+				NetworkID:  test.networkID,
+				Validators: manager,
+			}}
+			err := admin.SetValidatorWeights(nil, &SetValidatorWeightsArgs{
+				SubnetID: test.subnetID,
+				Validators: []ValidatorWeight{
+					{
+						NodeID: nodeID,
+						Weight: json.Uint64(test.expectedWeight),
+					},
+				},
+			}, nil)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedWeight, vdrs.GetWeight(nodeID))
+		})
+	}
+}
+
+func TestSwitchStakingCertificateNoStandby(t *testing.T) {
+	admin := &Admin{Config: Config{
+		Log: logging.NoLog{},
+	}}
+
+	reply := StakingCertificatesReply{}
+	err := admin.SwitchStakingCertificate(nil, nil, &reply)
+	require.ErrorIs(t, err, errNoStandbyStakingCert)
+}
+
+func TestSwitchStakingCertificate(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	active := staking.KeyPairPaths{
+		KeyPath:  filepath.Join(dir, "staker.key"),
+		CertPath: filepath.Join(dir, "staker.crt"),
+	}
+	standby := staking.KeyPairPaths{
+		KeyPath:  filepath.Join(dir, "standby.key"),
+		CertPath: filepath.Join(dir, "standby.crt"),
+	}
+	require.NoError(staking.InitNodeStakingKeyPair(active.KeyPath, active.CertPath))
+	require.NoError(staking.InitNodeStakingKeyPair(standby.KeyPath, standby.CertPath))
+
+	activeCert, err := active.Load()
+	require.NoError(err)
+	standbyCert, err := standby.Load()
+	require.NoError(err)
+	activeNodeID := ids.NodeIDFromCert(activeCert)
+	standbyNodeID := ids.NodeIDFromCert(standbyCert)
+
+	admin := &Admin{Config: Config{
+		Log:                   logging.NoLog{},
+		NodeID:                activeNodeID,
+		StakingKeyPair:        active,
+		StandbyStakingKeyPair: standby,
+	}}
+
+	reply := StakingCertificatesReply{}
+	require.NoError(admin.GetStakingCertificates(nil, nil, &reply))
+	require.Equal(StakingCertificatesReply{
+		NodeID:          activeNodeID,
+		NextNodeID:      activeNodeID,
+		StandbyNodeID:   standbyNodeID,
+		RestartRequired: false,
+	}, reply)
+
+	reply = StakingCertificatesReply{}
+	require.NoError(admin.SwitchStakingCertificate(nil, nil, &reply))
+	require.Equal(StakingCertificatesReply{
+		NodeID:          activeNodeID,
+		NextNodeID:      standbyNodeID,
+		StandbyNodeID:   activeNodeID,
+		RestartRequired: true,
+	}, reply)
+
+	// Switching again before restarting reverts the switch.
+	reply = StakingCertificatesReply{}
+	require.NoError(admin.SwitchStakingCertificate(nil, nil, &reply))
+	require.Equal(StakingCertificatesReply{
+		NodeID:          activeNodeID,
+		NextNodeID:      activeNodeID,
+		StandbyNodeID:   standbyNodeID,
+		RestartRequired: false,
+	}, reply)
+}
+
+func TestCollectSharedMemoryGarbageDisabled(t *testing.T) {
+	admin := &Admin{Config: Config{
+		Log: logging.NoLog{},
+	}}
+
+	reply := CollectSharedMemoryGarbageReply{}
+	err := admin.CollectSharedMemoryGarbage(nil, nil, &reply)
+	require.ErrorIs(t, err, errSharedMemoryGCDisabled)
+}
+
+func TestCollectSharedMemoryGarbage(t *testing.T) {
+	require := require.New(t)
+
+	gc, err := atomic.NewGarbageCollector(
+		logging.NoLog{},
+		atomic.NewMemory(memdb.New()),
+		atomic.GCConfig{Retention: time.Hour},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	admin := &Admin{Config: Config{
+		Log:            logging.NoLog{},
+		SharedMemoryGC: gc,
+	}}
+
+	reply := CollectSharedMemoryGarbageReply{}
+	require.NoError(admin.CollectSharedMemoryGarbage(nil, nil, &reply))
+	require.Equal(CollectSharedMemoryGarbageReply{}, reply)
+}
+
+func TestPeerReputationsDisabled(t *testing.T) {
+	require := require.New(t)
+
+	admin := &Admin{Config: Config{
+		Log: logging.NoLog{},
+	}}
+
+	err := admin.GetPeerReputations(nil, &GetPeerReputationsArgs{}, &GetPeerReputationsReply{})
+	require.ErrorIs(err, errReputationsDisabled)
+
+	err = admin.ResetPeerReputations(nil, &ResetPeerReputationsArgs{}, &api.EmptyReply{})
+	require.ErrorIs(err, errReputationsDisabled)
+}
+
+func TestPeerReputations(t *testing.T) {
+	require := require.New(t)
+
+	reputations, err := reputation.NewStore(
+		reputation.Config{
+			Enabled:                 true,
+			HandshakeFailurePenalty: 1,
+			InvalidMessagePenalty:   1,
+			BenchedPenalty:          1,
+			PenaltyHalflife:         time.Hour,
+			MaxPenalty:              1.5,
+		},
+		memdb.New(),
+		logging.NoLog{},
+	)
+	require.NoError(err)
+
+	admin := &Admin{Config: Config{
+		Log:         logging.NoLog{},
+		Reputations: reputations,
+	}}
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	reputations.RecordHandshakeFailure(nodeID0)
+	reputations.RecordInvalidMessage(nodeID0)
+	reputations.RecordBenched(nodeID1)
+
+	reply := GetPeerReputationsReply{}
+	require.NoError(admin.GetPeerReputations(nil, &GetPeerReputationsArgs{}, &reply))
+	require.Len(reply.Reputations, 2)
+	require.True(reply.Reputations[0].NodeID.Less(reply.Reputations[1].NodeID))
+
+	reply = GetPeerReputationsReply{}
+	require.NoError(admin.GetPeerReputations(nil, &GetPeerReputationsArgs{
+		NodeIDs: []ids.NodeID{nodeID0, ids.GenerateTestNodeID()},
+	}, &reply))
+	require.Len(reply.Reputations, 1)
+	peerReputation := reply.Reputations[0]
+	require.Equal(nodeID0, peerReputation.NodeID)
+	require.Equal(json.Uint64(1), peerReputation.HandshakeFailures)
+	require.Equal(json.Uint64(1), peerReputation.InvalidMessages)
+	require.Zero(peerReputation.Benchings)
+	require.False(peerReputation.Allowed)
+
+	require.NoError(admin.ResetPeerReputations(nil, &ResetPeerReputationsArgs{
+		NodeIDs: []ids.NodeID{nodeID0},
+	}, &api.EmptyReply{}))
+	require.True(reputations.Allowed(nodeID0))
+	require.Len(reputations.GetAll(), 1)
+
+	require.NoError(admin.ResetPeerReputations(nil, &ResetPeerReputationsArgs{}, &api.EmptyReply{}))
+	require.Empty(reputations.GetAll())
+}
@@ -18,10 +18,10 @@ import (
 
 	"github.com/gorilla/rpc/v2"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/password"
-	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
@@ -52,6 +52,8 @@ var (
 	errSamePassword                = errors.New("new password can't be same as old password")
 	errNoEndpoints                 = errors.New("must name at least one endpoint")
 	errTooManyEndpoints            = fmt.Errorf("can only name at most %d endpoints", maxEndpoints)
+	errNoTokenID                   = errors.New("token ID not provided")
+	errUnknownTokenID              = errors.New("unknown token ID")
 
 	_ Auth = (*auth)(nil)
 )
@@ -69,6 +71,21 @@ type Auth interface {
 	// re-used before previously revoked tokens have expired.
 	RevokeToken(pw, token string) error
 
+	// Revokes the token with ID [id]. The token must have been issued under the
+	// current password and must not have expired.
+	RevokeTokenByID(pw, id string) error
+
+	// Revokes every token issued under the current password.
+	RevokeAllTokens(pw string) error
+
+	// Revokes [token] and returns a new token that allows access to the same
+	// endpoints for [duration].
+	RotateToken(pw, token string, duration time.Duration) (string, error)
+
+	// Returns the tokens issued under the current password that haven't
+	// expired, including the revoked ones.
+	ListTokens(pw string) ([]TokenInfo, error)
+
 	// Authenticates [token] for access to [url].
 	AuthenticateToken(token, url string) error
 
@@ -98,43 +115,63 @@ type auth struct {
 	lock sync.RWMutex
 	// Can be changed via API call.
 	password password.Hash
-	// Set of token IDs that have been revoked
-	revoked set.Set[string]
+	// Tokens issued under the current password
+	tokens *tokenStore
 }
 
-func New(log logging.Logger, endpoint, pw string) (Auth, error) {
-	a := &auth{
-		log:      log,
-		endpoint: endpoint,
+// New returns an Auth that persists the tokens it issues to [db].
+func New(log logging.Logger, endpoint, pw string, db database.Database) (Auth, error) {
+	var hash password.Hash
+	if err := hash.Set(pw); err != nil {
+		return nil, err
 	}
-	return a, a.password.Set(pw)
+	return NewFromHash(log, endpoint, hash, db)
 }
 
-func NewFromHash(log logging.Logger, endpoint string, pw password.Hash) Auth {
-	return &auth{
+func NewFromHash(log logging.Logger, endpoint string, pw password.Hash, db database.Database) (Auth, error) {
+	tokens, err := newTokenStore(db)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load auth tokens: %w", err)
+	}
+	a := &auth{
 		log:      log,
 		endpoint: endpoint,
 		password: pw,
+		tokens:   tokens,
 	}
+	return a, tokens.prune(a.clock.Time())
 }
 
 func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (string, error) {
+	token, _, err := a.issueToken(pw, duration, endpoints)
+	return token, err
+}
+
+// issueToken returns a new token and its description.
+func (a *auth) issueToken(pw string, duration time.Duration, endpoints []string) (string, *TokenInfo, error) {
 	if pw == "" {
-		return "", password.ErrEmptyPassword
+		return "", nil, password.ErrEmptyPassword
 	}
 	if l := len(endpoints); l == 0 {
-		return "", errNoEndpoints
+		return "", nil, errNoEndpoints
 	} else if l > maxEndpoints {
-		return "", errTooManyEndpoints
+		return "", nil, errTooManyEndpoints
 	}
 
-	a.lock.RLock()
-	defer a.lock.RUnlock()
+	a.lock.Lock()
+	defer a.lock.Unlock()
 
 	if !a.password.Check(pw) {
-		return "", errWrongPassword
+		return "", nil, errWrongPassword
+	}
+	if err := a.tokens.prune(a.clock.Time()); err != nil {
+		return "", nil, err
 	}
+	return a.newToken(duration, endpoints)
+}
 
+// newToken assumes [a.lock] is held and that the password has been checked.
+func (a *auth) newToken(duration time.Duration, endpoints []string) (string, *TokenInfo, error) {
 	canAccessAll := false
 	for _, endpoint := range endpoints {
 		if endpoint == "*" {
@@ -145,13 +182,15 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 
 	idBytes := [tokenIDByteLen]byte{}
 	if _, err := rand.Read(idBytes[:]); err != nil {
-		return "", fmt.Errorf("failed to generate the unique token ID due to %w", err)
+		return "", nil, fmt.Errorf("failed to generate the unique token ID due to %w", err)
 	}
 	id := base64.RawURLEncoding.EncodeToString(idBytes[:])
 
+	now := a.clock.Time()
 	claims := endpointClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(a.clock.Time().Add(duration)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(now),
 			ID:        id,
 		},
 	}
@@ -161,7 +200,13 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 		claims.Endpoints = endpoints
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
-	return token.SignedString(a.password.Password[:]) // Sign the token and return its string repr.
+	tokenStr, err := token.SignedString(a.password.Password[:]) // Sign the token and return its string repr.
+	if err != nil {
+		return "", nil, err
+	}
+
+	info := newTokenInfo(&claims)
+	return tokenStr, info, a.tokens.put(info)
 }
 
 func (a *auth) RevokeToken(tokenStr, pw string) error {
@@ -179,23 +224,100 @@ func (a *auth) RevokeToken(tokenStr, pw string) error {
 		return errWrongPassword
 	}
 
-	// See if token is well-formed and signature is right
-	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
-	if err != nil {
+	claims, err := a.parseToken(tokenStr)
+	if err != nil || claims == nil {
 		return err
 	}
+	return a.revoke(claims)
+}
 
-	// If the token isn't valid, it has essentially already been revoked.
-	if !token.Valid {
-		return nil
+func (a *auth) RevokeTokenByID(pw, id string) error {
+	if id == "" {
+		return errNoTokenID
+	}
+	if pw == "" {
+		return password.ErrEmptyPassword
 	}
 
-	claims, ok := token.Claims.(*endpointClaims)
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return errWrongPassword
+	}
+	if err := a.tokens.prune(a.clock.Time()); err != nil {
+		return err
+	}
+
+	info, ok := a.tokens.get(id)
 	if !ok {
-		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+		return fmt.Errorf("%w: %q", errUnknownTokenID, id)
 	}
-	a.revoked.Add(claims.ID)
-	return nil
+	return a.tokens.revoke(info)
+}
+
+func (a *auth) RevokeAllTokens(pw string) error {
+	if pw == "" {
+		return password.ErrEmptyPassword
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return errWrongPassword
+	}
+	if err := a.tokens.prune(a.clock.Time()); err != nil {
+		return err
+	}
+	return a.tokens.revokeAll()
+}
+
+func (a *auth) RotateToken(pw, tokenStr string, duration time.Duration) (string, error) {
+	if tokenStr == "" {
+		return "", errNoToken
+	}
+	if pw == "" {
+		return "", password.ErrEmptyPassword
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return "", errWrongPassword
+	}
+
+	claims, err := a.parseToken(tokenStr)
+	if err != nil {
+		return "", err
+	}
+	if claims == nil || a.tokens.isRevoked(claims.ID) {
+		return "", errTokenRevoked
+	}
+	if err := a.revoke(claims); err != nil {
+		return "", err
+	}
+
+	newTokenStr, _, err := a.newToken(duration, claims.Endpoints)
+	return newTokenStr, err
+}
+
+func (a *auth) ListTokens(pw string) ([]TokenInfo, error) {
+	if pw == "" {
+		return nil, password.ErrEmptyPassword
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return nil, errWrongPassword
+	}
+	if err := a.tokens.prune(a.clock.Time()); err != nil {
+		return nil, err
+	}
+	return a.tokens.list(), nil
 }
 
 func (a *auth) AuthenticateToken(tokenStr, url string) error {
@@ -215,8 +337,7 @@ func (a *auth) AuthenticateToken(tokenStr, url string) error {
 		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
 	}
 
-	_, revoked := a.revoked[claims.ID]
-	if revoked {
+	if a.tokens.isRevoked(claims.ID) {
 		return errTokenRevoked
 	}
 
@@ -246,10 +367,9 @@ func (a *auth) ChangePassword(oldPW, newPW string) error {
 		return err
 	}
 
-	// All the revoked tokens are now invalid; no need to mark specifically as
+	// All the issued tokens are now invalid; no need to mark specifically as
 	// revoked.
-	a.revoked.Clear()
-	return nil
+	return a.tokens.clear()
 }
 
 func (a *auth) CreateHandler() (http.Handler, error) {
@@ -295,6 +415,39 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 	})
 }
 
+// parseToken returns the claims of [tokenStr]. If the token isn't valid, it
+// has essentially already been revoked, so nil is returned.
+//
+// Assumes [a.lock] is held.
+func (a *auth) parseToken(tokenStr string) (*endpointClaims, error) {
+	// See if token is well-formed and signature is right
+	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, nil
+	}
+
+	claims, ok := token.Claims.(*endpointClaims)
+	if !ok {
+		return nil, fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+	}
+	return claims, nil
+}
+
+// revoke marks the token described by [claims] as revoked. Tokens that were
+// issued before the node started tracking them are tracked from now on.
+//
+// Assumes [a.lock] is held.
+func (a *auth) revoke(claims *endpointClaims) error {
+	info, ok := a.tokens.get(claims.ID)
+	if !ok {
+		info = newTokenInfo(claims)
+	}
+	return a.tokens.revoke(info)
+}
+
 // getTokenKey returns the key to use when making and parsing tokens
 func (a *auth) getTokenKey(t *jwt.Token) (interface{}, error) {
 	if t.Method != jwt.SigningMethodHS256 {
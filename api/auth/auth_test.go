@@ -18,6 +18,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/password"
 )
@@ -35,13 +37,19 @@ func init() {
 	}
 }
 
+func newTestAuth(t *testing.T, db database.Database) *auth {
+	a, err := NewFromHash(logging.NoLog{}, "auth", hashedPassword, db)
+	require.NoError(t, err)
+	return a.(*auth)
+}
+
 // Always returns 200 (http.StatusOK)
 var dummyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
 
 func TestNewTokenWrongPassword(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t, memdb.New())
 
 	_, err := auth.NewToken("", defaultTokenLifespan, []string{"endpoint1, endpoint2"})
 	require.ErrorIs(err, password.ErrEmptyPassword)
@@ -53,7 +61,7 @@ func TestNewTokenWrongPassword(t *testing.T) {
 func TestNewTokenHappyPath(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t, memdb.New())
 
 	now := time.Now()
 	auth.clock.Set(now)
@@ -82,7 +90,7 @@ func TestNewTokenHappyPath(t *testing.T) {
 func TestTokenHasWrongSig(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t, memdb.New())
 
 	// Make a token
 	endpoints := []string{"endpoint1", "endpoint2", "endpoint3"}
@@ -109,7 +117,7 @@ func TestTokenHasWrongSig(t *testing.T) {
 func TestChangePassword(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t, memdb.New())
 
 	password2 := "fejhkefjhefjhefhje" // #nosec G101
 	var err error
@@ -137,7 +145,7 @@ func TestChangePassword(t *testing.T) {
 func TestRevokeToken(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
@@ -145,13 +153,157 @@ func TestRevokeToken(t *testing.T) {
 	require.NoError(err)
 
 	require.NoError(auth.RevokeToken(tokenStr, testPassword))
-	require.Len(auth.revoked, 1)
+
+	tokens, err := auth.ListTokens(testPassword)
+	require.NoError(err)
+	require.Len(tokens, 1)
+	require.True(tokens[0].Revoked)
+}
+
+func TestRevokeTokenByID(t *testing.T) {
+	require := require.New(t)
+
+	auth := newTestAuth(t, memdb.New())
+
+	endpoints := []string{"/ext/info"}
+	tokenStr, info, err := auth.issueToken(testPassword, defaultTokenLifespan, endpoints)
+	require.NoError(err)
+	otherTokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	require.NoError(err)
+
+	err = auth.RevokeTokenByID("notThePassword", info.ID)
+	require.ErrorIs(err, errWrongPassword)
+
+	err = auth.RevokeTokenByID(testPassword, "unknown")
+	require.ErrorIs(err, errUnknownTokenID)
+
+	require.NoError(auth.RevokeTokenByID(testPassword, info.ID))
+
+	err = auth.AuthenticateToken(tokenStr, "/ext/info")
+	require.ErrorIs(err, errTokenRevoked)
+	require.NoError(auth.AuthenticateToken(otherTokenStr, "/ext/info"))
+}
+
+func TestRevokeAllTokens(t *testing.T) {
+	require := require.New(t)
+
+	auth := newTestAuth(t, memdb.New())
+
+	endpoints := []string{"/ext/info"}
+	tokenStr1, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	require.NoError(err)
+	tokenStr2, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	require.NoError(err)
+
+	require.NoError(auth.RevokeAllTokens(testPassword))
+
+	err = auth.AuthenticateToken(tokenStr1, "/ext/info")
+	require.ErrorIs(err, errTokenRevoked)
+	err = auth.AuthenticateToken(tokenStr2, "/ext/info")
+	require.ErrorIs(err, errTokenRevoked)
+
+	// Tokens issued afterwards are unaffected.
+	tokenStr3, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	require.NoError(err)
+	require.NoError(auth.AuthenticateToken(tokenStr3, "/ext/info"))
+}
+
+func TestRotateToken(t *testing.T) {
+	require := require.New(t)
+
+	auth := newTestAuth(t, memdb.New())
+
+	endpoints := []string{"/ext/info", "/ext/bc/X"}
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	require.NoError(err)
+
+	newTokenStr, err := auth.RotateToken(testPassword, tokenStr, defaultTokenLifespan)
+	require.NoError(err)
+
+	err = auth.AuthenticateToken(tokenStr, "/ext/info")
+	require.ErrorIs(err, errTokenRevoked)
+	for _, endpoint := range endpoints {
+		require.NoError(auth.AuthenticateToken(newTokenStr, endpoint))
+	}
+
+	// A revoked token can't be rotated again.
+	_, err = auth.RotateToken(testPassword, tokenStr, defaultTokenLifespan)
+	require.ErrorIs(err, errTokenRevoked)
+}
+
+func TestListTokensPrunesExpired(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	auth := newTestAuth(t, db)
+
+	now := time.Now()
+	auth.clock.Set(now)
+
+	_, err := auth.NewToken(testPassword, time.Hour, []string{"/ext/info"})
+	require.NoError(err)
+	_, info, err := auth.issueToken(testPassword, 2*time.Hour, []string{"/ext/bc/X"})
+	require.NoError(err)
+
+	tokens, err := auth.ListTokens(testPassword)
+	require.NoError(err)
+	require.Len(tokens, 2)
+
+	auth.clock.Set(now.Add(time.Hour))
+
+	tokens, err = auth.ListTokens(testPassword)
+	require.NoError(err)
+	require.Equal([]TokenInfo{*info}, tokens)
+
+	// The expired token is removed from the database as well.
+	count, err := database.Count(db)
+	require.NoError(err)
+	require.Equal(1, count)
+}
+
+func TestRevocationPersisted(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	auth := newTestAuth(t, db)
+
+	endpoints := []string{"/ext/info"}
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	require.NoError(err)
+	require.NoError(auth.RevokeToken(tokenStr, testPassword))
+
+	// Simulate a restart
+	auth = newTestAuth(t, db)
+
+	err = auth.AuthenticateToken(tokenStr, "/ext/info")
+	require.ErrorIs(err, errTokenRevoked)
+}
+
+func TestChangePasswordClearsTokens(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	auth := newTestAuth(t, db)
+
+	_, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"/ext/info"})
+	require.NoError(err)
+
+	password2 := "fejhkefjhefjhefhje" // #nosec G101
+	require.NoError(auth.ChangePassword(testPassword, password2))
+
+	tokens, err := auth.ListTokens(password2)
+	require.NoError(err)
+	require.Empty(tokens)
+
+	isEmpty, err := database.IsEmpty(db)
+	require.NoError(err)
+	require.True(isEmpty)
 }
 
 func TestWrapHandlerHappyPath(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
@@ -172,7 +324,7 @@ func TestWrapHandlerHappyPath(t *testing.T) {
 func TestWrapHandlerRevokedToken(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
@@ -197,7 +349,7 @@ func TestWrapHandlerRevokedToken(t *testing.T) {
 func TestWrapHandlerExpiredToken(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t, memdb.New())
 
 	auth.clock.Set(time.Now().Add(-2 * defaultTokenLifespan))
 
@@ -222,7 +374,7 @@ func TestWrapHandlerExpiredToken(t *testing.T) {
 func TestWrapHandlerNoAuthToken(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t, memdb.New())
 
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -239,7 +391,7 @@ func TestWrapHandlerNoAuthToken(t *testing.T) {
 func TestWrapHandlerUnauthorizedEndpoint(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info"}
@@ -263,7 +415,7 @@ func TestWrapHandlerUnauthorizedEndpoint(t *testing.T) {
 func TestWrapHandlerAuthEndpoint(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics", "", "/foo", "/ext/info/foo"}
@@ -281,7 +433,7 @@ func TestWrapHandlerAuthEndpoint(t *testing.T) {
 func TestWrapHandlerAccessAll(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t, memdb.New())
 
 	// Make a token that allows access to all endpoints
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics", "", "/foo", "/ext/foo/info"}
@@ -310,7 +462,7 @@ func TestWriteUnauthorizedResponse(t *testing.T) {
 func TestWrapHandlerMutatedRevokedToken(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
@@ -333,7 +485,7 @@ func TestWrapHandlerMutatedRevokedToken(t *testing.T) {
 func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
 	require := require.New(t)
 
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t, memdb.New())
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
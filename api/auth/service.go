@@ -34,15 +34,25 @@ type Token struct {
 	Token string `json:"token"` // The new token. Expires in [TokenLifespan].
 }
 
-func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *Token) error {
+type NewTokenReply struct {
+	Token
+	// ID of the new token, used to revoke it without knowing the token.
+	ID string `json:"id"`
+}
+
+func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *NewTokenReply) error {
 	s.auth.log.Debug("API called",
 		zap.String("service", "auth"),
 		zap.String("method", "newToken"),
 	)
 
-	var err error
-	reply.Token, err = s.auth.NewToken(args.Password.Password, defaultTokenLifespan, args.Endpoints)
-	return err
+	token, info, err := s.auth.issueToken(args.Password.Password, defaultTokenLifespan, args.Endpoints)
+	if err != nil {
+		return err
+	}
+	reply.Token.Token = token
+	reply.ID = info.ID
+	return nil
 }
 
 type RevokeTokenArgs struct {
@@ -59,6 +69,62 @@ func (s *Service) RevokeToken(_ *http.Request, args *RevokeTokenArgs, _ *api.Emp
 	return s.auth.RevokeToken(args.Token.Token, args.Password.Password)
 }
 
+type RevokeTokenByIDArgs struct {
+	Password
+	ID string `json:"id"` // ID of the token to revoke
+}
+
+func (s *Service) RevokeTokenByID(_ *http.Request, args *RevokeTokenByIDArgs, _ *api.EmptyReply) error {
+	s.auth.log.Debug("API called",
+		zap.String("service", "auth"),
+		zap.String("method", "revokeTokenByID"),
+	)
+
+	return s.auth.RevokeTokenByID(args.Password.Password, args.ID)
+}
+
+func (s *Service) RevokeAllTokens(_ *http.Request, args *Password, _ *api.EmptyReply) error {
+	s.auth.log.Debug("API called",
+		zap.String("service", "auth"),
+		zap.String("method", "revokeAllTokens"),
+	)
+
+	return s.auth.RevokeAllTokens(args.Password)
+}
+
+type RotateTokenArgs struct {
+	Password
+	Token
+}
+
+// RotateToken revokes the provided token and returns a new token that allows
+// access to the same endpoints.
+func (s *Service) RotateToken(_ *http.Request, args *RotateTokenArgs, reply *Token) error {
+	s.auth.log.Debug("API called",
+		zap.String("service", "auth"),
+		zap.String("method", "rotateToken"),
+	)
+
+	var err error
+	reply.Token, err = s.auth.RotateToken(args.Password.Password, args.Token.Token, defaultTokenLifespan)
+	return err
+}
+
+type ListTokensReply struct {
+	Tokens []TokenInfo `json:"tokens"`
+}
+
+func (s *Service) ListTokens(_ *http.Request, args *Password, reply *ListTokensReply) error {
+	s.auth.log.Debug("API called",
+		zap.String("service", "auth"),
+		zap.String("method", "listTokens"),
+	)
+
+	var err error
+	reply.Tokens, err = s.auth.ListTokens(args.Password)
+	return err
+}
+
 type ChangePasswordArgs struct {
 	OldPassword string `json:"oldPassword"` // Current authorization password
 	NewPassword string `json:"newPassword"` // New authorization password
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// TokenInfo describes a token issued by the auth API.
+type TokenInfo struct {
+	ID        string    `json:"id"`
+	Endpoints []string  `json:"endpoints"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Revoked   bool      `json:"revoked"`
+}
+
+func newTokenInfo(claims *endpointClaims) *TokenInfo {
+	info := &TokenInfo{
+		ID:        claims.ID,
+		Endpoints: claims.Endpoints,
+	}
+	if claims.IssuedAt != nil {
+		info.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		info.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return info
+}
+
+// tokenStore tracks the tokens issued under the current password. Every
+// change is written to [db] so that revocations survive restarts.
+type tokenStore struct {
+	// token ID -> token info
+	db     database.Database
+	tokens map[string]*TokenInfo
+}
+
+func newTokenStore(db database.Database) (*tokenStore, error) {
+	s := &tokenStore{
+		db:     db,
+		tokens: make(map[string]*TokenInfo),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		info := &TokenInfo{}
+		if err := json.Unmarshal(it.Value(), info); err != nil {
+			return nil, err
+		}
+		s.tokens[info.ID] = info
+	}
+	return s, it.Error()
+}
+
+func (s *tokenStore) put(info *TokenInfo) error {
+	infoBytes, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	if err := s.db.Put([]byte(info.ID), infoBytes); err != nil {
+		return err
+	}
+	s.tokens[info.ID] = info
+	return nil
+}
+
+func (s *tokenStore) get(id string) (*TokenInfo, bool) {
+	info, ok := s.tokens[id]
+	return info, ok
+}
+
+func (s *tokenStore) isRevoked(id string) bool {
+	info, ok := s.tokens[id]
+	return ok && info.Revoked
+}
+
+// revoke marks [info] as revoked. If [info] isn't tracked yet, it is added.
+func (s *tokenStore) revoke(info *TokenInfo) error {
+	revoked := *info
+	revoked.Revoked = true
+	return s.put(&revoked)
+}
+
+// revokeAll revokes every tracked token that hasn't expired.
+func (s *tokenStore) revokeAll() error {
+	for _, info := range s.tokens {
+		if info.Revoked {
+			continue
+		}
+		if err := s.revoke(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// list returns the tracked tokens.
+func (s *tokenStore) list() []TokenInfo {
+	infos := make([]TokenInfo, 0, len(s.tokens))
+	for _, info := range s.tokens {
+		infos = append(infos, *info)
+	}
+	return infos
+}
+
+// prune removes the tokens that expired at or before [now]. Expired tokens
+// are rejected regardless of whether they were revoked, so there is no need
+// to remember them.
+func (s *tokenStore) prune(now time.Time) error {
+	for id, info := range s.tokens {
+		if info.ExpiresAt.After(now) {
+			continue
+		}
+		if err := s.db.Delete([]byte(id)); err != nil {
+			return err
+		}
+		delete(s.tokens, id)
+	}
+	return nil
+}
+
+// clear removes all the tracked tokens.
+func (s *tokenStore) clear() error {
+	for id := range s.tokens {
+		if err := s.db.Delete([]byte(id)); err != nil {
+			return err
+		}
+		delete(s.tokens, id)
+	}
+	return nil
+}
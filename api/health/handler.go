@@ -52,8 +52,12 @@ func NewGetHandler(reporter func(tags ...string) (map[string]Result, bool)) http
 		// Make sure the content type is set before writing the header.
 		w.Header().Set("Content-Type", "application/json")
 
-		tags := r.URL.Query()["tag"]
+		query := r.URL.Query()
+		tags := query["tag"]
 		checks, healthy := reporter(tags...)
+		if query.Get("history") != "true" {
+			checks = withoutHistory(checks)
+		}
 		if !healthy {
 			// If a health check has failed, we should return a 503.
 			w.WriteHeader(http.StatusServiceUnavailable)
@@ -36,11 +36,27 @@ type Health interface {
 	// Repeated calls to Start will be no-ops.
 	Start(ctx context.Context, freq time.Duration)
 
+	// Refresh runs the health checks as soon as possible rather than waiting
+	// for the next period.
+	Refresh()
+
 	// Stop running periodic health checks. Stop should only be called after
 	// Start. Once Stop returns, no more health checks will be executed.
 	Stop()
 }
 
+// RefreshOn refreshes [h] every time an event is received on [events] until
+// [events] is closed.
+//
+// This allows checks that depend on the state of other components, such as
+// whether the chains are bootstrapped, to be reported as soon as that state
+// changes.
+func RefreshOn[T any](h Health, events <-chan T) {
+	for range events {
+		h.Refresh()
+	}
+}
+
 // Registerer defines how to register new components to check the health of.
 type Registerer interface {
 	RegisterReadinessCheck(name string, checker Checker, tags ...string) error
@@ -133,6 +149,12 @@ func (h *health) Start(ctx context.Context, freq time.Duration) {
 	h.liveness.Start(ctx, freq)
 }
 
+func (h *health) Refresh() {
+	h.readiness.Refresh()
+	h.health.Refresh()
+	h.liveness.Refresh()
+}
+
 func (h *health) Stop() {
 	h.readiness.Stop()
 	h.health.Stop()
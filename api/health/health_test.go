@@ -227,6 +227,39 @@ func TestPassingThenFailingChecks(t *testing.T) {
 	}
 }
 
+func TestRefreshOn(t *testing.T) {
+	require := require.New(t)
+
+	var shouldCheckErr utils.Atomic[bool]
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		if shouldCheckErr.Get() {
+			return errUnhealthy.Error(), errUnhealthy
+		}
+		return "", nil
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	require.NoError(err)
+
+	require.NoError(h.RegisterHealthCheck("check", check))
+
+	// The checks are only run periodically once the test has timed out, so
+	// only a refresh can update the result.
+	h.Start(context.Background(), time.Hour)
+	defer h.Stop()
+
+	awaitHealthy(t, h, true)
+
+	events := make(chan struct{})
+	go RefreshOn[struct{}](h, events)
+	defer close(events)
+
+	shouldCheckErr.Set(true)
+	events <- struct{}{}
+
+	awaitHealthy(t, h, false)
+}
+
 func TestDeadlockRegression(t *testing.T) {
 	require := require.New(t)
 
@@ -391,3 +424,86 @@ func TestTags(t *testing.T) {
 		require.False(health)
 	}
 }
+
+func TestHistoryAndFlapDetection(t *testing.T) {
+	require := require.New(t)
+
+	w, err := newWorker(logging.NoLog{}, "health", prometheus.NewRegistry())
+	require.NoError(err)
+
+	var passing utils.Atomic[bool]
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		if passing.Get() {
+			return "", nil
+		}
+		return "", errUnhealthy
+	})
+	require.NoError(w.RegisterCheck("check", check))
+
+	// Running a failing check doesn't transition it, because checks are
+	// initially failing.
+	w.runChecks(context.Background())
+	results, healthy := w.Results()
+	require.False(healthy)
+	require.Empty(results["check"].History)
+
+	for i := 0; i < flapThreshold-1; i++ {
+		passing.Set(!passing.Get())
+		w.runChecks(context.Background())
+
+		results, healthy = w.Results()
+		result := results["check"]
+		require.Equal(passing.Get(), healthy)
+		require.False(result.Degraded)
+		require.Len(result.History, i+1)
+		require.Equal(passing.Get(), result.History[i].Passing)
+	}
+
+	// The check starts flapping.
+	passing.Set(false)
+	w.runChecks(context.Background())
+
+	results, healthy = w.Results()
+	result := results["check"]
+	require.False(healthy)
+	require.True(result.Degraded)
+	require.Equal(errUnhealthy.Error(), *result.Error)
+	require.Len(result.History, flapThreshold)
+
+	// The flapping check is reported as failing even though it passed.
+	passing.Set(true)
+	w.runChecks(context.Background())
+
+	results, healthy = w.Results()
+	result = results["check"]
+	require.False(healthy)
+	require.True(result.Degraded)
+	require.Equal(errFlapping.Error(), *result.Error)
+	require.Len(result.History, flapThreshold+1)
+}
+
+func TestAppendTransition(t *testing.T) {
+	require := require.New(t)
+
+	var history []Transition
+	for i := 0; i < maxHistory+1; i++ {
+		history = appendTransition(history, Transition{
+			Passing: i%2 == 0,
+		})
+	}
+	require.Len(history, maxHistory)
+	// The oldest transition was dropped.
+	require.False(history[0].Passing)
+	require.True(history[maxHistory-1].Passing)
+}
+
+func TestIsFlapping(t *testing.T) {
+	now := time.Now()
+	history := make([]Transition, flapThreshold)
+	for i := range history {
+		history[i].Timestamp = now
+	}
+	require.True(t, isFlapping(history, now))
+	require.False(t, isFlapping(history[1:], now))
+	require.False(t, isFlapping(history, now.Add(flapWindow)))
+}
@@ -35,4 +35,44 @@ type Result struct {
 
 	// TimeOfFirstFailure of the HealthCheck,
 	TimeOfFirstFailure *time.Time `json:"timeOfFirstFailure,omitempty"`
+
+	// Degraded is true if the HealthCheck has been flapping between passing
+	// and failing. A degraded HealthCheck is reported as failing until it
+	// stops flapping.
+	Degraded bool `json:"degraded,omitempty"`
+
+	// History of the most recent transitions of the HealthCheck between
+	// passing and failing, from oldest to newest. Only reported if requested.
+	History []Transition `json:"history,omitempty"`
+}
+
+// Transition describes a HealthCheck starting to pass or fail.
+type Transition struct {
+	// Timestamp of the HealthCheck that transitioned.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Passing is true if the HealthCheck started passing.
+	Passing bool `json:"passing"`
+
+	// Error returned by the HealthCheck if it started failing.
+	Error *string `json:"error,omitempty"`
+}
+
+// passing returns true if the last run of the HealthCheck passed, regardless
+// of whether it is degraded.
+func (r *Result) passing() bool {
+	if len(r.History) == 0 {
+		// HealthChecks are failing until they are run.
+		return false
+	}
+	return r.History[len(r.History)-1].Passing
+}
+
+// withoutHistory returns [results] with the history of each result removed.
+func withoutHistory(results map[string]Result) map[string]Result {
+	for name, result := range results {
+		result.History = nil
+		results[name] = result
+	}
+	return results
 }
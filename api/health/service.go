@@ -25,6 +25,8 @@ type APIReply struct {
 // APIArgs is the arguments for Readiness, Health, and Liveness.
 type APIArgs struct {
 	Tags []string `json:"tags"`
+	// If true, the recent transitions of each check are included.
+	History bool `json:"history"`
 }
 
 // Readiness returns if the node has finished initialization
@@ -35,6 +37,9 @@ func (s *Service) Readiness(_ *http.Request, args *APIArgs, reply *APIReply) err
 		zap.Strings("tags", args.Tags),
 	)
 	reply.Checks, reply.Healthy = s.health.Readiness(args.Tags...)
+	if !args.History {
+		reply.Checks = withoutHistory(reply.Checks)
+	}
 	return nil
 }
 
@@ -47,6 +52,9 @@ func (s *Service) Health(_ *http.Request, args *APIArgs, reply *APIReply) error
 	)
 
 	reply.Checks, reply.Healthy = s.health.Health(args.Tags...)
+	if !args.History {
+		reply.Checks = withoutHistory(reply.Checks)
+	}
 	return nil
 }
 
@@ -58,5 +66,8 @@ func (s *Service) Liveness(_ *http.Request, args *APIArgs, reply *APIReply) erro
 		zap.Strings("tags", args.Tags),
 	)
 	reply.Checks, reply.Healthy = s.health.Liveness(args.Tags...)
+	if !args.History {
+		reply.Checks = withoutHistory(reply.Checks)
+	}
 	return nil
 }
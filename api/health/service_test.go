@@ -102,8 +102,18 @@ func TestServiceResponses(t *testing.T) {
 		require.Equal("", result.Details)
 		require.Nil(result.Error)
 		require.Zero(result.ContiguousFailures)
+		require.Empty(result.History)
 		require.True(reply.Healthy)
 	}
+
+	{
+		reply := APIReply{}
+		require.NoError(s.Liveness(nil, &APIArgs{History: true}, &reply))
+
+		result := reply.Checks["check"]
+		require.Len(result.History, 1)
+		require.True(result.History[0].Passing)
+	}
 }
 
 func TestServiceTagResponse(t *testing.T) {
@@ -22,11 +22,21 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
+const (
+	// Number of transitions kept in the history of each check.
+	maxHistory = 32
+	// A check is flapping if it transitioned at least [flapThreshold] times
+	// within [flapWindow].
+	flapThreshold = 4
+	flapWindow    = 5 * time.Minute
+)
+
 var (
 	allTags = []string{AllTag}
 
 	errRestrictedTag  = errors.New("restricted tag")
 	errDuplicateCheck = errors.New("duplicated check")
+	errFlapping       = errors.New("check is flapping between passing and failing")
 )
 
 type worker struct {
@@ -41,6 +51,9 @@ type worker struct {
 	numFailingApplicationChecks int
 	tags                        map[string]set.Set[string] // tag -> set of check names
 
+	// Signals that the checks should be run before the next period.
+	refresh chan struct{}
+
 	startOnce sync.Once
 	closeOnce sync.Once
 	wg        sync.WaitGroup
@@ -65,6 +78,7 @@ func newWorker(
 		metrics:   metrics,
 		checks:    make(map[string]*taggedChecker),
 		results:   make(map[string]Result),
+		refresh:   make(chan struct{}, 1),
 		closer:    make(chan struct{}),
 		tags:      make(map[string]set.Set[string]),
 	}, err
@@ -181,6 +195,8 @@ func (w *worker) Start(ctx context.Context, freq time.Duration) {
 				select {
 				case <-ticker.C:
 					w.runChecks(detachedCtx)
+				case <-w.refresh:
+					w.runChecks(detachedCtx)
 				case <-w.closer:
 					return
 				}
@@ -189,6 +205,15 @@ func (w *worker) Start(ctx context.Context, freq time.Duration) {
 	})
 }
 
+// Refresh runs the checks as soon as possible. If a refresh is already
+// pending, this is a no-op.
+func (w *worker) Refresh() {
+	select {
+	case w.refresh <- struct{}{}:
+	default:
+	}
+}
+
 func (w *worker) Stop() {
 	w.closeOnce.Do(func() {
 		close(w.closer)
@@ -233,6 +258,36 @@ func (w *worker) runCheck(ctx context.Context, wg *sync.WaitGroup, name string,
 	w.resultsLock.Lock()
 	defer w.resultsLock.Unlock()
 	prevResult := w.results[name]
+
+	result.History = prevResult.History
+	if passing := err == nil; passing != prevResult.passing() {
+		transition := Transition{
+			Timestamp: end,
+			Passing:   passing,
+		}
+		if err != nil {
+			errString := err.Error()
+			transition.Error = &errString
+		}
+		result.History = appendTransition(prevResult.History, transition)
+	}
+
+	// A flapping check is reported as failing, rather than toggling between
+	// passing and failing.
+	result.Degraded = isFlapping(result.History, end)
+	if result.Degraded {
+		if !prevResult.Degraded {
+			w.log.Warn("check started flapping",
+				zap.String("namespace", w.namespace),
+				zap.String("name", name),
+				zap.Strings("tags", check.tags),
+			)
+		}
+		if err == nil {
+			err = errFlapping
+		}
+	}
+
 	if err != nil {
 		errString := err.Error()
 		result.Error = &errString
@@ -307,3 +362,25 @@ func (w *worker) updateMetrics(tc *taggedChecker, healthy bool, register bool) {
 		}
 	}
 }
+
+// appendTransition returns [history] with [transition] appended, dropping the
+// oldest transitions to keep at most [maxHistory] transitions. A new slice is
+// always returned because [history] may be shared with reported results.
+func appendTransition(history []Transition, transition Transition) []Transition {
+	if len(history) >= maxHistory {
+		history = history[len(history)-maxHistory+1:]
+	}
+	newHistory := make([]Transition, len(history), len(history)+1)
+	copy(newHistory, history)
+	return append(newHistory, transition)
+}
+
+// isFlapping returns true if [history] contains at least [flapThreshold]
+// transitions within [flapWindow] of [now].
+func isFlapping(history []Transition, now time.Time) bool {
+	if len(history) < flapThreshold {
+		return false
+	}
+	windowStart := now.Add(-flapWindow)
+	return history[len(history)-flapThreshold].Timestamp.After(windowStart)
+}
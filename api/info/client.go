@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 )
@@ -24,10 +25,16 @@ type Client interface {
 	GetNetworkName(context.Context, ...rpc.Option) (string, error)
 	GetBlockchainID(context.Context, string, ...rpc.Option) (ids.ID, error)
 	Peers(context.Context, ...rpc.Option) ([]Peer, error)
+	GetBenchedPeers(context.Context, ...rpc.Option) ([]benchlist.BenchedPeer, error)
+	GetResourceLimits(context.Context, ...rpc.Option) (*GetResourceLimitsReply, error)
+	GetNetworkTime(context.Context, ...rpc.Option) (*GetNetworkTimeReply, error)
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Uptime(context.Context, ids.ID, ...rpc.Option) (*UptimeResponse, error)
+	CheckSubnetConnectivity(context.Context, ids.ID, ...rpc.Option) (*CheckSubnetConnectivityReply, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
+	GetChains(context.Context, ...rpc.Option) ([]Chain, error)
+	GetChainStats(ctx context.Context, chain string, window time.Duration, options ...rpc.Option) (*GetChainStatsReply, error)
 }
 
 // Client implementation for an Info API Client
@@ -86,6 +93,24 @@ func (c *client) Peers(ctx context.Context, options ...rpc.Option) ([]Peer, erro
 	return res.Peers, err
 }
 
+func (c *client) GetBenchedPeers(ctx context.Context, options ...rpc.Option) ([]benchlist.BenchedPeer, error) {
+	res := &GetBenchedPeersReply{}
+	err := c.requester.SendRequest(ctx, "info.getBenchedPeers", struct{}{}, res, options...)
+	return res.Peers, err
+}
+
+func (c *client) GetResourceLimits(ctx context.Context, options ...rpc.Option) (*GetResourceLimitsReply, error) {
+	res := &GetResourceLimitsReply{}
+	err := c.requester.SendRequest(ctx, "info.getResourceLimits", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) GetNetworkTime(ctx context.Context, options ...rpc.Option) (*GetNetworkTimeReply, error) {
+	res := &GetNetworkTimeReply{}
+	err := c.requester.SendRequest(ctx, "info.getNetworkTime", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) IsBootstrapped(ctx context.Context, chainID string, options ...rpc.Option) (bool, error) {
 	res := &IsBootstrappedResponse{}
 	err := c.requester.SendRequest(ctx, "info.isBootstrapped", &IsBootstrappedArgs{
@@ -108,12 +133,35 @@ func (c *client) Uptime(ctx context.Context, subnetID ids.ID, options ...rpc.Opt
 	return res, err
 }
 
+func (c *client) CheckSubnetConnectivity(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (*CheckSubnetConnectivityReply, error) {
+	res := &CheckSubnetConnectivityReply{}
+	err := c.requester.SendRequest(ctx, "info.checkSubnetConnectivity", &CheckSubnetConnectivityArgs{
+		SubnetID: subnetID,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID][]string, error) {
 	res := &GetVMsReply{}
 	err := c.requester.SendRequest(ctx, "info.getVMs", struct{}{}, res, options...)
 	return res.VMs, err
 }
 
+func (c *client) GetChains(ctx context.Context, options ...rpc.Option) ([]Chain, error) {
+	res := &GetChainsReply{}
+	err := c.requester.SendRequest(ctx, "info.getChains", struct{}{}, res, options...)
+	return res.Chains, err
+}
+
+func (c *client) GetChainStats(ctx context.Context, chain string, window time.Duration, options ...rpc.Option) (*GetChainStatsReply, error) {
+	res := &GetChainStatsReply{}
+	err := c.requester.SendRequest(ctx, "info.getChainStats", &GetChainStatsArgs{
+		Chain:  chain,
+		Window: window,
+	}, res, options...)
+	return res, err
+}
+
 // AwaitBootstrapped polls the node every [freq] to check if [chainID] has
 // finished bootstrapping. Returns true once [chainID] reports that it has
 // finished bootstrapping.
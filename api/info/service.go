@@ -4,18 +4,22 @@
 package info
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"net/http"
+	"runtime"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/api/rpcerror"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -23,12 +27,26 @@ import (
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 )
 
-var errNoChainProvided = errors.New("argument 'chain' not given")
+// checkConnectivityTimeout bounds how long validators are given to respond to
+// the pings sent by CheckSubnetConnectivity.
+const checkConnectivityTimeout = 10 * time.Second
+
+// defaultChainStatsWindow is the window used by GetChainStats if none is
+// provided.
+const defaultChainStatsWindow = time.Minute
+
+var (
+	errNoChainProvided = rpcerror.New(rpcerror.InvalidArgument, "argument 'chain' not given")
+	errNegativeWindow  = rpcerror.New(rpcerror.InvalidArgument, "argument 'window' must not be negative")
+	errWindowTooLong   = rpcerror.New(rpcerror.InvalidArgument, fmt.Sprintf("argument 'window' must not exceed %s", chains.MaxChainStatsWindow))
+)
 
 // Info is the API service for unprivileged info on a node
 type Info struct {
@@ -56,7 +74,9 @@ type Parameters struct {
 	AddPrimaryNetworkDelegatorFee uint64
 	AddSubnetValidatorFee         uint64
 	AddSubnetDelegatorFee         uint64
+	MinOutputAmount               uint64
 	VMManager                     vms.Manager
+	ResourceLimits                resource.Limits
 }
 
 // NewService returns a new admin API service
@@ -249,6 +269,78 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 	return nil
 }
 
+// GetBenchedPeersReply are the results from calling GetBenchedPeers
+type GetBenchedPeersReply struct {
+	// Each element is a node that is benched on a chain
+	Peers []benchlist.BenchedPeer `json:"peers"`
+}
+
+// GetBenchedPeers returns the nodes that are benched on each chain, why they
+// are benched and until when
+func (i *Info) GetBenchedPeers(_ *http.Request, _ *struct{}, reply *GetBenchedPeersReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getBenchedPeers"),
+	)
+
+	reply.Peers = i.benchlist.Benched()
+	return nil
+}
+
+// GetResourceLimitsReply are the results from calling GetResourceLimits
+type GetResourceLimitsReply struct {
+	Limits resource.Limits `json:"limits"`
+	// CPUs is the number of CPUs available to the node
+	CPUs float64 `json:"cpus"`
+	// Memory is the number of bytes of memory available to the node
+	Memory json.Uint64 `json:"memory"`
+	// GOMAXPROCS is the number of threads that may execute Go code at once
+	GOMAXPROCS int `json:"gomaxprocs"`
+}
+
+// GetResourceLimits returns the CPU and memory available to the node, which
+// may be limited by the cgroup of the container the node is running in
+func (i *Info) GetResourceLimits(_ *http.Request, _ *struct{}, reply *GetResourceLimitsReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getResourceLimits"),
+	)
+
+	reply.Limits = i.ResourceLimits
+	reply.CPUs = i.ResourceLimits.CPUs()
+	reply.Memory = json.Uint64(i.ResourceLimits.Memory())
+	reply.GOMAXPROCS = runtime.GOMAXPROCS(0)
+	return nil
+}
+
+// GetNetworkTimeReply are the results from calling GetNetworkTime
+type GetNetworkTimeReply struct {
+	// LocalTime is the time according to this node's clock
+	LocalTime time.Time `json:"localTime"`
+	// NetworkTime is the time estimated from the clocks of the connected validators
+	NetworkTime time.Time `json:"networkTime"`
+	// Offset is how far the network time is ahead of the local time
+	Offset time.Duration `json:"offset"`
+	// NumSamples is the number of validators the network time was estimated from
+	NumSamples json.Uint32 `json:"numSamples"`
+}
+
+// GetNetworkTime returns the network time estimated from the clock offsets
+// that peers reported during the handshake
+func (i *Info) GetNetworkTime(_ *http.Request, _ *struct{}, reply *GetNetworkTimeReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getNetworkTime"),
+	)
+
+	offset, numSamples := i.networking.ClockOffset()
+	reply.LocalTime = time.Now()
+	reply.NetworkTime = reply.LocalTime.Add(offset)
+	reply.Offset = offset
+	reply.NumSamples = json.Uint32(numSamples)
+	return nil
+}
+
 // IsBootstrappedArgs are the arguments for calling IsBootstrapped
 type IsBootstrappedArgs struct {
 	// Alias of the chain
@@ -297,6 +389,22 @@ type UptimeResponse struct {
 	// counted (40*weight) in WeightedAveragePercentage but not in
 	// RewardingStakePercentage since 40 < 85
 	WeightedAveragePercentage json.Float64 `json:"weightedAveragePercentage"`
+
+	// ReportingStakePercentage is the percent of stake whose validators
+	// reported the uptime they perceive for this node. The uptimes of
+	// connected validators that haven't reported yet are assumed to be 0.
+	ReportingStakePercentage json.Float64 `json:"reportingStakePercentage"`
+
+	// LocalUptimePercentage is the percent of its staking period that this
+	// node has been online for, as calculated by this node. Omitted if this
+	// node can't calculate its uptime yet, such as while the P-chain is
+	// bootstrapping.
+	LocalUptimePercentage *json.Float64 `json:"localUptimePercentage,omitempty"`
+
+	// MaintenanceWindow is how long this node can be offline, starting now,
+	// before its uptime over its whole staking period drops below the uptime
+	// requirement. Omitted along with LocalUptimePercentage.
+	MaintenanceWindow *time.Duration `json:"maintenanceWindow,omitempty"`
 }
 
 type UptimeRequest struct {
@@ -316,6 +424,58 @@ func (i *Info) Uptime(_ *http.Request, args *UptimeRequest, reply *UptimeRespons
 	}
 	reply.WeightedAveragePercentage = json.Float64(result.WeightedAveragePercentage)
 	reply.RewardingStakePercentage = json.Float64(result.RewardingStakePercentage)
+	reply.ReportingStakePercentage = json.Float64(result.ReportingStakePercentage)
+	if result.Local != nil {
+		localUptimePercentage := json.Float64(result.Local.Percentage)
+		reply.LocalUptimePercentage = &localUptimePercentage
+		reply.MaintenanceWindow = &result.Local.TimeToThreshold
+	}
+	return nil
+}
+
+type CheckSubnetConnectivityArgs struct {
+	// if omitted, defaults to primary network
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// CheckSubnetConnectivityReply are the results from calling
+// CheckSubnetConnectivity
+type CheckSubnetConnectivityReply struct {
+	// ReachableStakePercentage is the percentage of the stake of the other
+	// validators of the subnet that responded to a ping.
+	ReachableStakePercentage json.Float64 `json:"reachableStakePercentage"`
+	// Validators are the other validators of the subnet, heaviest first.
+	Validators []network.ValidatorConnectivity `json:"validators"`
+}
+
+// CheckSubnetConnectivity pings the current validators of a subnet and reports
+// whether each of them is reachable from this node.
+func (i *Info) CheckSubnetConnectivity(r *http.Request, args *CheckSubnetConnectivityArgs, reply *CheckSubnetConnectivityReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "checkSubnetConnectivity"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	ctx, cancel := context.WithTimeout(r.Context(), checkConnectivityTimeout)
+	defer cancel()
+
+	validators, err := i.networking.SubnetConnectivity(ctx, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't check subnet connectivity: %w", err)
+	}
+
+	var totalWeight, reachableWeight uint64
+	for _, vdr := range validators {
+		totalWeight += uint64(vdr.Weight)
+		if vdr.Connected && vdr.Error == "" {
+			reachableWeight += uint64(vdr.Weight)
+		}
+	}
+	if totalWeight > 0 {
+		reply.ReachableStakePercentage = json.Float64(100 * float64(reachableWeight) / float64(totalWeight))
+	}
+	reply.Validators = validators
 	return nil
 }
 
@@ -329,6 +489,7 @@ type GetTxFeeResponse struct {
 	AddPrimaryNetworkDelegatorFee json.Uint64 `json:"addPrimaryNetworkDelegatorFee"`
 	AddSubnetValidatorFee         json.Uint64 `json:"addSubnetValidatorFee"`
 	AddSubnetDelegatorFee         json.Uint64 `json:"addSubnetDelegatorFee"`
+	MinOutputAmount               json.Uint64 `json:"minOutputAmount"`
 }
 
 // GetTxFee returns the transaction fee in nAVAX.
@@ -347,6 +508,7 @@ func (i *Info) GetTxFee(_ *http.Request, _ *struct{}, reply *GetTxFeeResponse) e
 	reply.AddPrimaryNetworkDelegatorFee = json.Uint64(i.AddPrimaryNetworkDelegatorFee)
 	reply.AddSubnetValidatorFee = json.Uint64(i.AddSubnetValidatorFee)
 	reply.AddSubnetDelegatorFee = json.Uint64(i.AddSubnetDelegatorFee)
+	reply.MinOutputAmount = json.Uint64(i.MinOutputAmount)
 	return nil
 }
 
@@ -371,3 +533,164 @@ func (i *Info) GetVMs(_ *http.Request, _ *struct{}, reply *GetVMsReply) error {
 	reply.VMs, err = ids.GetRelevantAliases(i.VMManager, vmIDs)
 	return err
 }
+
+// GetChainsReply contains the response metadata for GetChains
+type GetChainsReply struct {
+	Chains []Chain `json:"chains"`
+}
+
+// Chain describes a chain run by the node
+type Chain struct {
+	ID       ids.ID `json:"id"`
+	Alias    string `json:"alias"`
+	SubnetID ids.ID `json:"subnetID"`
+	VMID     ids.ID `json:"vmID"`
+	// VMAliases are the aliases of the VM, excluding its ID.
+	VMAliases []string `json:"vmAliases"`
+	// State of the consensus engine of the chain.
+	State          string `json:"state"`
+	IsBootstrapped bool   `json:"isBootstrapped"`
+	// LastAcceptedHeight is omitted if the chain isn't run by the Snowman
+	// engine yet.
+	LastAcceptedHeight *json.Uint64 `json:"lastAcceptedHeight,omitempty"`
+	// DataDirSize is the number of bytes stored in the data directory of the
+	// chain. It doesn't include the state stored in the database of the node.
+	DataDirSize json.Uint64 `json:"dataDirSize"`
+}
+
+// GetChains lists the chains run by the node
+func (i *Info) GetChains(r *http.Request, _ *struct{}, reply *GetChainsReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getChains"),
+	)
+
+	chainInfos := i.chainManager.Chains(r.Context())
+	vmIDs := set.NewSet[ids.ID](len(chainInfos))
+	for _, chainInfo := range chainInfos {
+		vmIDs.Add(chainInfo.VMID)
+	}
+	vmAliases, err := ids.GetRelevantAliases(i.VMManager, vmIDs.List())
+	if err != nil {
+		return err
+	}
+
+	reply.Chains = make([]Chain, len(chainInfos))
+	for j, chainInfo := range chainInfos {
+		chain := Chain{
+			ID:             chainInfo.ID,
+			Alias:          chainInfo.Alias,
+			SubnetID:       chainInfo.SubnetID,
+			VMID:           chainInfo.VMID,
+			VMAliases:      vmAliases[chainInfo.VMID],
+			State:          chainInfo.State.String(),
+			IsBootstrapped: chainInfo.State == snow.NormalOp,
+			DataDirSize:    json.Uint64(chainInfo.DataDirSize),
+		}
+		if chainInfo.LastAcceptedHeight != nil {
+			height := json.Uint64(*chainInfo.LastAcceptedHeight)
+			chain.LastAcceptedHeight = &height
+		}
+		reply.Chains[j] = chain
+	}
+	return nil
+}
+
+// GetChainStatsArgs are the arguments for calling GetChainStats
+type GetChainStatsArgs struct {
+	// Chain is the ID or alias of the chain.
+	Chain string `json:"chain"`
+	// Window is how far back from now accepted blocks are considered. Defaults
+	// to one minute if omitted, and must not exceed one hour.
+	Window time.Duration `json:"window"`
+}
+
+// GetChainStatsReply are the results from calling GetChainStats
+type GetChainStatsReply struct {
+	ChainID ids.ID        `json:"chainID"`
+	Window  time.Duration `json:"window"`
+	// NumBlocks is the number of blocks accepted in the window.
+	NumBlocks json.Uint64 `json:"numBlocks"`
+	// NumBytes is the total size of the blocks accepted in the window.
+	NumBytes json.Uint64 `json:"numBytes"`
+	// NumTxs is the number of transactions in the blocks accepted in the
+	// window. Omitted if the VM doesn't report the transactions in its blocks.
+	NumTxs *json.Uint64 `json:"numTxs,omitempty"`
+	// StartHeight and EndHeight are the heights of the first and last blocks
+	// accepted in the window. Omitted if no blocks were accepted.
+	StartHeight *json.Uint64 `json:"startHeight,omitempty"`
+	EndHeight   *json.Uint64 `json:"endHeight,omitempty"`
+	// BlocksPerSecond and BytesPerSecond are the rates at which blocks and
+	// block bytes were accepted over the window.
+	BlocksPerSecond json.Float64 `json:"blocksPerSecond"`
+	BytesPerSecond  json.Float64 `json:"bytesPerSecond"`
+	// TxsPerSecond is the rate at which transactions were accepted over the
+	// window. Omitted if NumTxs is omitted.
+	TxsPerSecond *json.Float64 `json:"txsPerSecond,omitempty"`
+	// AverageBlockInterval is the average time between the timestamps of
+	// consecutive blocks accepted in the window.
+	AverageBlockInterval time.Duration `json:"averageBlockInterval"`
+	// LastBlockTime is the timestamp of the last accepted block. Omitted if no
+	// blocks were accepted in the window.
+	LastBlockTime *time.Time `json:"lastBlockTime,omitempty"`
+	// Truncated is true if too many blocks were accepted in the window to be
+	// retained, in which case the stats only describe the most recent blocks.
+	Truncated bool `json:"truncated"`
+}
+
+// GetChainStats reports the throughput of a chain over a recent window of
+// time, computed from the blocks the node accepted.
+func (i *Info) GetChainStats(r *http.Request, args *GetChainStatsArgs, reply *GetChainStatsReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getChainStats"),
+		logging.UserString("chain", args.Chain),
+		zap.Duration("window", args.Window),
+	)
+
+	if args.Chain == "" {
+		return errNoChainProvided
+	}
+	window := args.Window
+	switch {
+	case window < 0:
+		return errNegativeWindow
+	case window == 0:
+		window = defaultChainStatsWindow
+	case window > chains.MaxChainStatsWindow:
+		return errWindowTooLong
+	}
+
+	chainID, err := i.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return fmt.Errorf("there is no chain with alias/ID '%s'", args.Chain)
+	}
+	stats, err := i.chainManager.ChainStats(r.Context(), chainID, window)
+	if err != nil {
+		return err
+	}
+
+	reply.ChainID = chainID
+	reply.Window = window
+	reply.NumBlocks = json.Uint64(stats.NumBlocks)
+	reply.NumBytes = json.Uint64(stats.NumBytes)
+	reply.BlocksPerSecond = json.Float64(stats.BlocksPerSecond(window))
+	reply.BytesPerSecond = json.Float64(stats.BytesPerSecond(window))
+	reply.AverageBlockInterval = stats.AverageBlockInterval()
+	reply.Truncated = stats.Truncated
+	if stats.TxsKnown {
+		numTxs := json.Uint64(stats.NumTxs)
+		txsPerSecond := json.Float64(stats.TxsPerSecond(window))
+		reply.NumTxs = &numTxs
+		reply.TxsPerSecond = &txsPerSecond
+	}
+	if stats.NumBlocks > 0 {
+		startHeight := json.Uint64(stats.StartHeight)
+		endHeight := json.Uint64(stats.EndHeight)
+		lastBlockTime := stats.EndTime
+		reply.StartHeight = &startHeight
+		reply.EndHeight = &endHeight
+		reply.LastBlockTime = &lastBlockTime
+	}
+	return nil
+}
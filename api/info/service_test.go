@@ -4,14 +4,21 @@
 package info
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms"
 )
@@ -102,3 +109,173 @@ func TestGetVMsGetAliasesFails(t *testing.T) {
 	err := resources.info.GetVMs(nil, nil, &reply)
 	require.ErrorIs(t, err, errTest)
 }
+
+type testChainManager struct {
+	chains.Manager
+	chains []chains.ChainInfo
+	stats  chains.ChainStats
+	window time.Duration
+}
+
+func (m *testChainManager) Chains(context.Context) []chains.ChainInfo {
+	return m.chains
+}
+
+func (*testChainManager) Lookup(alias string) (ids.ID, error) {
+	return ids.FromString(alias)
+}
+
+func (m *testChainManager) ChainStats(_ context.Context, _ ids.ID, window time.Duration) (chains.ChainStats, error) {
+	m.window = window
+	return m.stats, nil
+}
+
+func TestGetChains(t *testing.T) {
+	require := require.New(t)
+
+	resources := initGetVMsTest(t)
+
+	var (
+		vmID     = ids.GenerateTestID()
+		subnetID = ids.GenerateTestID()
+		height   = uint64(10)
+
+		bootstrapped = chains.ChainInfo{
+			ID:                 ids.GenerateTestID(),
+			Alias:              "bootstrapped",
+			SubnetID:           subnetID,
+			VMID:               vmID,
+			State:              snow.NormalOp,
+			LastAcceptedHeight: &height,
+			DataDirSize:        100,
+		}
+		bootstrapping = chains.ChainInfo{
+			ID:       ids.GenerateTestID(),
+			Alias:    "bootstrapping",
+			SubnetID: subnetID,
+			VMID:     vmID,
+			State:    snow.Bootstrapping,
+		}
+	)
+	resources.info.chainManager = &testChainManager{
+		chains: []chains.ChainInfo{bootstrapped, bootstrapping},
+	}
+
+	resources.mockLog.EXPECT().Debug(gomock.Any(), gomock.Any()).Times(1)
+	resources.mockVMManager.EXPECT().Aliases(vmID).Times(1).Return([]string{vmID.String(), "vm"}, nil)
+
+	reply := GetChainsReply{}
+	require.NoError(resources.info.GetChains(httptest.NewRequest(http.MethodPost, "/", nil), nil, &reply))
+
+	expectedHeight := json.Uint64(height)
+	require.Equal([]Chain{
+		{
+			ID:                 bootstrapped.ID,
+			Alias:              "bootstrapped",
+			SubnetID:           subnetID,
+			VMID:               vmID,
+			VMAliases:          []string{"vm"},
+			State:              snow.NormalOp.String(),
+			IsBootstrapped:     true,
+			LastAcceptedHeight: &expectedHeight,
+			DataDirSize:        100,
+		},
+		{
+			ID:        bootstrapping.ID,
+			Alias:     "bootstrapping",
+			SubnetID:  subnetID,
+			VMID:      vmID,
+			VMAliases: []string{"vm"},
+			State:     snow.Bootstrapping.String(),
+		},
+	}, reply.Chains)
+}
+
+func TestGetChainStats(t *testing.T) {
+	require := require.New(t)
+
+	resources := initGetVMsTest(t)
+
+	var (
+		chainID = ids.GenerateTestID()
+		endTime = time.Unix(1_000_000, 0)
+		manager = &testChainManager{
+			stats: chains.ChainStats{
+				NumBlocks:   31,
+				NumBytes:    6_000,
+				NumTxs:      120,
+				TxsKnown:    true,
+				StartHeight: 70,
+				EndHeight:   100,
+				StartTime:   endTime.Add(-60 * time.Second),
+				EndTime:     endTime,
+			},
+		}
+	)
+	resources.info.chainManager = manager
+
+	resources.mockLog.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	reply := GetChainStatsReply{}
+	require.NoError(resources.info.GetChainStats(
+		httptest.NewRequest(http.MethodPost, "/", nil),
+		&GetChainStatsArgs{Chain: chainID.String()},
+		&reply,
+	))
+	require.Equal(defaultChainStatsWindow, manager.window)
+
+	numTxs := json.Uint64(120)
+	txsPerSecond := json.Float64(2)
+	startHeight := json.Uint64(70)
+	endHeight := json.Uint64(100)
+	require.Equal(GetChainStatsReply{
+		ChainID:              chainID,
+		Window:               time.Minute,
+		NumBlocks:            31,
+		NumBytes:             6_000,
+		NumTxs:               &numTxs,
+		StartHeight:          &startHeight,
+		EndHeight:            &endHeight,
+		BlocksPerSecond:      json.Float64(31.0 / 60),
+		BytesPerSecond:       100,
+		TxsPerSecond:         &txsPerSecond,
+		AverageBlockInterval: 2 * time.Second,
+		LastBlockTime:        &endTime,
+	}, reply)
+
+	manager.stats = chains.ChainStats{}
+	reply = GetChainStatsReply{}
+	require.NoError(resources.info.GetChainStats(
+		httptest.NewRequest(http.MethodPost, "/", nil),
+		&GetChainStatsArgs{
+			Chain:  chainID.String(),
+			Window: time.Hour,
+		},
+		&reply,
+	))
+	require.Equal(time.Hour, manager.window)
+	require.Zero(reply.NumBlocks)
+	require.Nil(reply.NumTxs)
+	require.Nil(reply.EndHeight)
+	require.Nil(reply.LastBlockTime)
+
+	err := resources.info.GetChainStats(
+		httptest.NewRequest(http.MethodPost, "/", nil),
+		&GetChainStatsArgs{
+			Chain:  chainID.String(),
+			Window: -time.Second,
+		},
+		&reply,
+	)
+	require.ErrorIs(err, errNegativeWindow)
+
+	err = resources.info.GetChainStats(
+		httptest.NewRequest(http.MethodPost, "/", nil),
+		&GetChainStatsArgs{
+			Chain:  chainID.String(),
+			Window: chains.MaxChainStatsWindow + time.Second,
+		},
+		&reply,
+	)
+	require.ErrorIs(err, errWindowTooLong)
+}
@@ -23,6 +23,10 @@ type BlockchainKeystore interface {
 	// values. This Database will not perform any encrypting or decrypting of
 	// values and is not recommended to be used when implementing a VM.
 	GetRawDatabase(username, password string) (database.Database, error)
+
+	// Signer returns the signing plugin that holds the keys of users outside
+	// of the keystore, or nil if there isn't one.
+	Signer() Signer
 }
 
 type blockchainKeystore struct {
@@ -49,3 +53,7 @@ func (bks *blockchainKeystore) GetRawDatabase(username, password string) (databa
 
 	return bks.ks.GetRawDatabase(bks.blockchainID, username, password)
 }
+
+func (bks *blockchainKeystore) Signer() Signer {
+	return bks.ks.signer
+}
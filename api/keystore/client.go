@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
@@ -27,6 +28,9 @@ type Client interface {
 	ImportUser(ctx context.Context, importTo api.UserPass, exportedUser []byte, options ...rpc.Option) error
 	// Delete the given user
 	DeleteUser(context.Context, api.UserPass, ...rpc.Option) error
+	// Import the keys that the given user stores for [blockchainIDs] into the
+	// signing plugin of the node
+	MigrateUser(ctx context.Context, user api.UserPass, blockchainIDs []ids.ID, options ...rpc.Option) ([]ids.ShortID, error)
 }
 
 // Client implementation for Avalanche Keystore API Endpoint
@@ -79,3 +83,17 @@ func (c *client) ImportUser(ctx context.Context, user api.UserPass, account []by
 func (c *client) DeleteUser(ctx context.Context, user api.UserPass, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "keystore.deleteUser", &user, &api.EmptyReply{}, options...)
 }
+
+func (c *client) MigrateUser(
+	ctx context.Context,
+	user api.UserPass,
+	blockchainIDs []ids.ID,
+	options ...rpc.Option,
+) ([]ids.ShortID, error) {
+	res := &MigrateUserReply{}
+	err := c.requester.SendRequest(ctx, "keystore.migrateUser", &MigrateUserArgs{
+		UserPass:      user,
+		BlockchainIDs: blockchainIDs,
+	}, res, options...)
+	return res.Addresses, err
+}
@@ -21,12 +21,21 @@ var _ keystore.BlockchainKeystore = (*Client)(nil)
 // Client is a snow.Keystore that talks over RPC.
 type Client struct {
 	client keystorepb.KeystoreClient
+	signer keystore.Signer
 }
 
 // NewClient returns a keystore instance connected to a remote keystore instance
 func NewClient(client keystorepb.KeystoreClient) *Client {
+	return NewClientWithSigner(client, nil)
+}
+
+// NewClientWithSigner returns a keystore instance connected to a remote
+// keystore instance whose signing plugin is reached through [signer], which
+// may be nil.
+func NewClientWithSigner(client keystorepb.KeystoreClient, signer keystore.Signer) *Client {
 	return &Client{
 		client: client,
+		signer: signer,
 	}
 }
 
@@ -55,3 +64,7 @@ func (c *Client) GetRawDatabase(username, password string) (database.Database, e
 	dbClient := rpcdb.NewClient(rpcdbpb.NewDatabaseClient(clientConn))
 	return dbClient, err
 }
+
+func (c *Client) Signer() keystore.Signer {
+	return c.signer
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gsigner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+)
+
+// unixSocketPrefix is the prefix of endpoints that are unix sockets
+const unixSocketPrefix = "unix:"
+
+var errInvalidCAFile = errors.New("no certificates found in CA file")
+
+// Dial connects to the signing plugin at [endpoint]. Passwords and private
+// keys are sent to the plugin, so unless [endpoint] is a local unix socket,
+// such as "unix:///run/signer.sock", the connection is secured with TLS. The
+// plugin's certificate is verified against the certificate authorities in
+// [caFile] or, if [caFile] is empty, against the system's.
+func Dial(endpoint string, caFile string) (*grpc.ClientConn, error) {
+	if strings.HasPrefix(endpoint, unixSocketPrefix) {
+		return grpcutils.Dial(endpoint)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if caFile != "" {
+		caBytes, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read CA file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caBytes) {
+			return nil, fmt.Errorf("%w: %s", errInvalidCAFile, caFile)
+		}
+	}
+	return grpcutils.Dial(
+		endpoint,
+		grpcutils.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+	)
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gsigner
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/ids"
+
+	signerpb "github.com/ava-labs/avalanchego/proto/pb/signer"
+)
+
+var _ keystore.Signer = (*Client)(nil)
+
+// Client is a keystore.Signer that talks over RPC.
+type Client struct {
+	client signerpb.SignerClient
+}
+
+// NewClient returns a signer connected to a remote signing plugin
+func NewClient(client signerpb.SignerClient) *Client {
+	return &Client{
+		client: client,
+	}
+}
+
+func (c *Client) Addresses(ctx context.Context, username, password string) ([]ids.ShortID, error) {
+	resp, err := c.client.Addresses(ctx, &signerpb.AddressesRequest{
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]ids.ShortID, len(resp.Addresses))
+	for i, addrBytes := range resp.Addresses {
+		addrs[i], err = ids.ToShortID(addrBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return addrs, nil
+}
+
+func (c *Client) SignHash(
+	ctx context.Context,
+	username string,
+	password string,
+	address ids.ShortID,
+	hash []byte,
+) ([]byte, error) {
+	resp, err := c.client.SignHash(ctx, &signerpb.SignHashRequest{
+		Username: username,
+		Password: password,
+		Address:  address[:],
+		Hash:     hash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+func (c *Client) ImportKey(ctx context.Context, username, password string, privateKey []byte) (ids.ShortID, error) {
+	resp, err := c.client.ImportKey(ctx, &signerpb.ImportKeyRequest{
+		Username:   username,
+		Password:   password,
+		PrivateKey: privateKey,
+	})
+	if err != nil {
+		return ids.ShortEmpty, err
+	}
+	return ids.ToShortID(resp.Address)
+}
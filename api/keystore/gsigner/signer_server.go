@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gsigner
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/ids"
+
+	signerpb "github.com/ava-labs/avalanchego/proto/pb/signer"
+)
+
+var _ signerpb.SignerServer = (*Server)(nil)
+
+// Server is a keystore.Signer that is managed over RPC. Signing plugins can
+// serve their keystore.Signer implementation with it.
+type Server struct {
+	signerpb.UnsafeSignerServer
+	signer keystore.Signer
+}
+
+// NewServer returns a signing plugin server backed by [signer]
+func NewServer(signer keystore.Signer) *Server {
+	return &Server{
+		signer: signer,
+	}
+}
+
+func (s *Server) Addresses(
+	ctx context.Context,
+	req *signerpb.AddressesRequest,
+) (*signerpb.AddressesResponse, error) {
+	addrs, err := s.signer.Addresses(ctx, req.Username, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	addrsBytes := make([][]byte, len(addrs))
+	for i, addr := range addrs {
+		addrsBytes[i] = addr.Bytes()
+	}
+	return &signerpb.AddressesResponse{Addresses: addrsBytes}, nil
+}
+
+func (s *Server) SignHash(
+	ctx context.Context,
+	req *signerpb.SignHashRequest,
+) (*signerpb.SignHashResponse, error) {
+	addr, err := ids.ToShortID(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := s.signer.SignHash(ctx, req.Username, req.Password, addr, req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return &signerpb.SignHashResponse{Signature: sig}, nil
+}
+
+func (s *Server) ImportKey(
+	ctx context.Context,
+	req *signerpb.ImportKeyRequest,
+) (*signerpb.ImportKeyResponse, error) {
+	addr, err := s.signer.ImportKey(ctx, req.Username, req.Password, req.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &signerpb.ImportKeyResponse{Address: addr.Bytes()}, nil
+}
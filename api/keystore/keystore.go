@@ -4,6 +4,7 @@
 package keystore
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -17,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/password"
@@ -33,10 +35,14 @@ var (
 	errUserAlreadyExists = errors.New("user already exists")
 	errIncorrectPassword = errors.New("incorrect password")
 	errNonexistentUser   = errors.New("user doesn't exist")
+	errNoSigner          = errors.New("no signer configured")
+	errUnexpectedAddress = errors.New("signer returned an unexpected address")
 
 	usersPrefix = []byte("users")
 	bcsPrefix   = []byte("bcs")
 
+	secp256k1Factory secp256k1.Factory
+
 	_ Keystore = (*keystore)(nil)
 )
 
@@ -76,6 +82,15 @@ type Keystore interface {
 	// with encrypted database values.
 	ExportUser(username, pw string) ([]byte, error)
 
+	// Signer returns the signing plugin that holds the keys of users outside
+	// of the node, or nil if no plugin is configured.
+	Signer() Signer
+
+	// MigrateUser imports the private keys that [username] stores for
+	// [blockchainIDs] into the signing plugin and returns their addresses.
+	// The keys are not removed from the keystore.
+	MigrateUser(ctx context.Context, username, pw string, blockchainIDs []ids.ID) ([]ids.ShortID, error)
+
 	// Get the password that is used by [username]. If [username] doesn't exist,
 	// no error is returned and a nil password hash is returned.
 	getPassword(username string) (*password.Hash, error)
@@ -93,8 +108,9 @@ type user struct {
 }
 
 type keystore struct {
-	lock sync.Mutex
-	log  logging.Logger
+	lock   sync.Mutex
+	log    logging.Logger
+	signer Signer
 
 	// Key: username
 	// Value: The hash of that user's password
@@ -112,10 +128,18 @@ type keystore struct {
 	//          BID  BID  BID
 }
 
+// New returns a keystore stored in [dbManager]
 func New(log logging.Logger, dbManager manager.Manager) Keystore {
+	return NewWithSigner(log, dbManager, nil)
+}
+
+// NewWithSigner returns a keystore stored in [dbManager]. [signer] is the
+// signing plugin that users can be migrated to, and may be nil.
+func NewWithSigner(log logging.Logger, dbManager manager.Manager, signer Signer) Keystore {
 	currentDB := dbManager.Current()
 	return &keystore{
 		log:                log,
+		signer:             signer,
 		usernameToPassword: make(map[string]*password.Hash),
 		userDB:             prefixdb.New(usersPrefix, currentDB.Database),
 		bcDB:               prefixdb.New(bcsPrefix, currentDB.Database),
@@ -367,6 +391,75 @@ func (ks *keystore) ExportUser(username, pw string) ([]byte, error) {
 	return c.Marshal(codecVersion, &userData)
 }
 
+func (ks *keystore) Signer() Signer {
+	return ks.signer
+}
+
+func (ks *keystore) MigrateUser(
+	ctx context.Context,
+	username string,
+	pw string,
+	blockchainIDs []ids.ID,
+) ([]ids.ShortID, error) {
+	if ks.signer == nil {
+		return nil, errNoSigner
+	}
+
+	var addrs []ids.ShortID
+	for _, blockchainID := range blockchainIDs {
+		db, err := ks.GetDatabase(blockchainID, username, pw)
+		if err != nil {
+			return nil, err
+		}
+
+		chainAddrs, err := ks.migrateKeys(ctx, username, pw, db)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't migrate keys of chain %s: %w", blockchainID, err)
+		}
+		addrs = append(addrs, chainAddrs...)
+	}
+	return addrs, nil
+}
+
+// migrateKeys imports the private keys in [db] into the signer. VMs store the
+// keys of a user under the address that the key controls, so any other value
+// is skipped.
+func (ks *keystore) migrateKeys(
+	ctx context.Context,
+	username string,
+	pw string,
+	db database.Database,
+) ([]ids.ShortID, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var addrs []ids.ShortID
+	for it.Next() {
+		addr, err := ids.ToShortID(it.Key())
+		if err != nil {
+			continue
+		}
+		sk, err := secp256k1Factory.ToPrivateKey(it.Value())
+		if err != nil || sk.Address() != addr {
+			continue
+		}
+
+		importedAddr, err := ks.signer.ImportKey(ctx, username, pw, sk.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if importedAddr != addr {
+			return nil, fmt.Errorf("%w: expected %s but got %s",
+				errUnexpectedAddress,
+				addr,
+				importedAddr,
+			)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, it.Error()
+}
+
 func (ks *keystore) getPassword(username string) (*password.Hash, error) {
 	// If the user is already in memory, return it
 	passwordHash, exists := ks.usernameToPassword[username]
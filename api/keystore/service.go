@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/version"
@@ -116,6 +117,32 @@ func (s *service) ExportUser(_ *http.Request, args *ExportUserArgs, reply *Expor
 	return nil
 }
 
+type MigrateUserArgs struct {
+	// The username and password of the user being migrated
+	api.UserPass
+	// The chains whose keys are migrated
+	BlockchainIDs []ids.ID `json:"blockchainIDs"`
+}
+
+type MigrateUserReply struct {
+	// The addresses of the keys that were imported into the signer
+	Addresses []ids.ShortID `json:"addresses"`
+}
+
+// MigrateUser imports the private keys of a user into the signing plugin of
+// the node.
+func (s *service) MigrateUser(r *http.Request, args *MigrateUserArgs, reply *MigrateUserReply) error {
+	s.ks.log.Warn("deprecated API called",
+		zap.String("service", "keystore"),
+		zap.String("method", "migrateUser"),
+		logging.UserString("username", args.Username),
+	)
+
+	var err error
+	reply.Addresses, err = s.ks.MigrateUser(r.Context(), args.Username, args.Password, args.BlockchainIDs)
+	return err
+}
+
 // CreateTestKeystore returns a new keystore that can be utilized for testing
 func CreateTestKeystore() (Keystore, error) {
 	dbManager, err := manager.NewManagerFromDBs([]*manager.VersionedDatabase{
@@ -4,6 +4,7 @@
 package keystore
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -11,9 +12,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/password"
+	"github.com/ava-labs/avalanchego/version"
 )
 
 // strongPassword defines a password used for the following tests that
@@ -346,3 +350,76 @@ func TestServiceDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+type testSigner struct {
+	keys map[ids.ShortID][]byte
+}
+
+func (*testSigner) Addresses(context.Context, string, string) ([]ids.ShortID, error) {
+	return nil, nil
+}
+
+func (*testSigner) SignHash(context.Context, string, string, ids.ShortID, []byte) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *testSigner) ImportKey(_ context.Context, _, _ string, privateKey []byte) (ids.ShortID, error) {
+	sk, err := secp256k1Factory.ToPrivateKey(privateKey)
+	if err != nil {
+		return ids.ShortEmpty, err
+	}
+	s.keys[sk.Address()] = privateKey
+	return sk.Address(), nil
+}
+
+func TestServiceMigrateUser(t *testing.T) {
+	require := require.New(t)
+
+	signer := &testSigner{
+		keys: make(map[ids.ShortID][]byte),
+	}
+	ks := NewWithSigner(logging.NoLog{}, manager.NewMemDB(version.Semantic1_0_0), signer)
+	s := service{ks: ks.(*keystore)}
+
+	require.NoError(s.CreateUser(nil, &api.UserPass{
+		Username: "bob",
+		Password: strongPassword,
+	}, &api.EmptyReply{}))
+
+	sk, err := secp256k1Factory.NewPrivateKey()
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	db, err := ks.GetDatabase(chainID, "bob", strongPassword)
+	require.NoError(err)
+	addr := sk.Address()
+	require.NoError(db.Put(addr[:], sk.Bytes()))
+	require.NoError(db.Put(ids.Empty[:], []byte("addresses")))
+
+	// Values that aren't keys of their address are skipped
+	otherAddr := ids.GenerateTestShortID()
+	require.NoError(db.Put(otherAddr[:], sk.Bytes()))
+
+	_, err = ks.MigrateUser(context.Background(), "bob", "wrong password", []ids.ID{chainID})
+	require.ErrorIs(err, errIncorrectPassword)
+
+	addrs, err := ks.MigrateUser(context.Background(), "bob", strongPassword, []ids.ID{chainID})
+	require.NoError(err)
+	require.Equal([]ids.ShortID{addr}, addrs)
+	require.Equal(map[ids.ShortID][]byte{addr: sk.Bytes()}, signer.keys)
+
+	// The keys remain in the keystore
+	val, err := db.Get(addr[:])
+	require.NoError(err)
+	require.Equal(sk.Bytes(), val)
+}
+
+func TestServiceMigrateUserNoSigner(t *testing.T) {
+	require := require.New(t)
+
+	ks, err := CreateTestKeystore()
+	require.NoError(err)
+
+	_, err = ks.MigrateUser(context.Background(), "bob", strongPassword, nil)
+	require.ErrorIs(err, errNoSigner)
+}
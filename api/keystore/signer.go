@@ -0,0 +1,25 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// Signer holds the keys of keystore users outside of the node, for example in
+// Vault or a KMS. API methods that need the keys of a user can sign with a
+// Signer rather than reading the keys out of the keystore.
+type Signer interface {
+	// Addresses returns the addresses of the keys held for [username].
+	Addresses(ctx context.Context, username, password string) ([]ids.ShortID, error)
+
+	// SignHash signs [hash] with the key of [username] that controls
+	// [address].
+	SignHash(ctx context.Context, username, password string, address ids.ShortID, hash []byte) ([]byte, error)
+
+	// ImportKey stores [privateKey] for [username] and returns its address.
+	ImportKey(ctx context.Context, username, password string, privateKey []byte) (ids.ShortID, error)
+}
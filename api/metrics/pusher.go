@@ -0,0 +1,396 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+
+	"go.uber.org/zap"
+
+	"google.golang.org/protobuf/proto"
+
+	dto "github.com/prometheus/client_model/go"
+	collectorpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	PushGateway PushType = iota + 1
+	OTLP
+
+	// pushJob is the job that metrics are grouped under when they are pushed
+	// to a Prometheus push gateway.
+	pushJob = constants.PlatformName
+	// serviceNameAttribute is the OTLP resource attribute identifying the
+	// service that produced the metrics.
+	serviceNameAttribute = "service.name"
+	otlpContentType      = "application/x-protobuf"
+)
+
+var (
+	_ Pusher = (*pusher)(nil)
+
+	errUnknownPushType   = errors.New("unknown push type")
+	errUnexpectedStatus  = errors.New("unexpected status code")
+	errPushEndpointEmpty = errors.New("push endpoint is empty")
+	errNonPositiveFreq   = errors.New("push frequency must be > 0")
+)
+
+func PushTypeFromString(pushTypeStr string) (PushType, error) {
+	switch strings.ToLower(pushTypeStr) {
+	case PushGateway.String():
+		return PushGateway, nil
+	case OTLP.String():
+		return OTLP, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", errUnknownPushType, pushTypeStr)
+	}
+}
+
+// PushType is the protocol used to push metrics.
+type PushType byte
+
+func (t PushType) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+func (t PushType) String() string {
+	switch t {
+	case PushGateway:
+		return "pushgateway"
+	case OTLP:
+		return "otlp"
+	default:
+		return "unknown"
+	}
+}
+
+// PushConfig configures the periodic push of metrics to a collector, for
+// nodes that can't be scraped.
+type PushConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Type is the protocol that metrics are pushed with.
+	Type PushType `json:"type"`
+
+	// Endpoint is the URL that metrics are pushed to. For a push gateway, this
+	// is the URL of the gateway. For OTLP, this is the full URL of the
+	// metrics endpoint of the collector, e.g. http://localhost:4318/v1/metrics.
+	Endpoint string `json:"endpoint"`
+
+	// Frequency is the time between pushes.
+	Frequency time.Duration `json:"frequency"`
+
+	// Labels are attached to every pushed metric. For a push gateway, they are
+	// used as the grouping key. For OTLP, they are resource attributes.
+	Labels map[string]string `json:"labels"`
+}
+
+func (c PushConfig) Verify() error {
+	if !c.Enabled {
+		return nil
+	}
+	switch {
+	case c.Type != PushGateway && c.Type != OTLP:
+		return fmt.Errorf("%w: %d", errUnknownPushType, c.Type)
+	case c.Endpoint == "":
+		return errPushEndpointEmpty
+	case c.Frequency <= 0:
+		return errNonPositiveFreq
+	default:
+		return nil
+	}
+}
+
+// Pusher periodically pushes the metrics of a gatherer.
+type Pusher interface {
+	// Push the current metrics once.
+	Push(ctx context.Context) error
+
+	// Dispatch periodically pushes metrics until Shutdown is called.
+	Dispatch()
+	Shutdown()
+}
+
+type pusher struct {
+	log      logging.Logger
+	config   PushConfig
+	gatherer prometheus.Gatherer
+	client   *http.Client
+
+	// Used as the start time of cumulative OTLP metrics.
+	startTime time.Time
+
+	// Dispatch returns when closer is closed
+	closer chan struct{}
+}
+
+func NewPusher(log logging.Logger, config PushConfig, gatherer prometheus.Gatherer) Pusher {
+	return &pusher{
+		log:      log,
+		config:   config,
+		gatherer: gatherer,
+		client: &http.Client{
+			Timeout: config.Frequency,
+		},
+		startTime: time.Now(),
+		closer:    make(chan struct{}),
+	}
+}
+
+func (p *pusher) Push(ctx context.Context) error {
+	switch p.config.Type {
+	case PushGateway:
+		return p.pushGateway(ctx)
+	case OTLP:
+		return p.pushOTLP(ctx)
+	default:
+		return fmt.Errorf("%w: %d", errUnknownPushType, p.config.Type)
+	}
+}
+
+func (p *pusher) Dispatch() {
+	t := time.NewTicker(p.config.Frequency)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-p.closer:
+			return
+		case <-t.C:
+			if err := p.Push(context.Background()); err != nil {
+				p.log.Warn("failed to push metrics",
+					zap.Stringer("type", p.config.Type),
+					zap.String("endpoint", p.config.Endpoint),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+func (p *pusher) Shutdown() {
+	close(p.closer)
+}
+
+func (p *pusher) pushGateway(ctx context.Context) error {
+	gatewayPusher := push.New(p.config.Endpoint, pushJob).
+		Gatherer(p.gatherer).
+		Client(p.client)
+	for name, value := range p.config.Labels {
+		gatewayPusher = gatewayPusher.Grouping(name, value)
+	}
+	return gatewayPusher.PushContext(ctx)
+}
+
+func (p *pusher) pushOTLP(ctx context.Context) error {
+	families, err := p.gatherer.Gather()
+	if err != nil {
+		return err
+	}
+
+	request := &collectorpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: &resourcepb.Resource{
+				Attributes: p.resourceAttributes(),
+			},
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Scope: &commonpb.InstrumentationScope{
+					Name: constants.PlatformName,
+				},
+				Metrics: toOTLP(families, p.startTime, time.Now()),
+			}},
+		}},
+	}
+	body, err := proto.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", otlpContentType)
+
+	response, err := p.client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%w: %d", errUnexpectedStatus, response.StatusCode)
+	}
+	return nil
+}
+
+func (p *pusher) resourceAttributes() []*commonpb.KeyValue {
+	labels := make(map[string]string, len(p.config.Labels)+1)
+	labels[serviceNameAttribute] = constants.PlatformName
+	for name, value := range p.config.Labels {
+		labels[name] = value
+	}
+	return toAttributes(labels)
+}
+
+// toOTLP converts Prometheus metric families into OTLP metrics. Counters,
+// histograms, and summaries are reported as cumulative since [startTime].
+func toOTLP(families []*dto.MetricFamily, startTime, now time.Time) []*metricspb.Metric {
+	var (
+		start   = uint64(startTime.UnixNano())
+		ts      = uint64(now.UnixNano())
+		metrics = make([]*metricspb.Metric, 0, len(families))
+	)
+	for _, family := range families {
+		metric := &metricspb.Metric{
+			Name:        family.GetName(),
+			Description: family.GetHelp(),
+		}
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			sum := &metricspb.Sum{
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				IsMonotonic:            true,
+			}
+			for _, m := range family.Metric {
+				sum.DataPoints = append(sum.DataPoints, &metricspb.NumberDataPoint{
+					Attributes:        labelsToAttributes(m.Label),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      ts,
+					Value: &metricspb.NumberDataPoint_AsDouble{
+						AsDouble: m.GetCounter().GetValue(),
+					},
+				})
+			}
+			metric.Data = &metricspb.Metric_Sum{Sum: sum}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			gauge := &metricspb.Gauge{}
+			for _, m := range family.Metric {
+				value := m.GetGauge().GetValue()
+				if m.Untyped != nil {
+					value = m.GetUntyped().GetValue()
+				}
+				gauge.DataPoints = append(gauge.DataPoints, &metricspb.NumberDataPoint{
+					Attributes:   labelsToAttributes(m.Label),
+					TimeUnixNano: ts,
+					Value: &metricspb.NumberDataPoint_AsDouble{
+						AsDouble: value,
+					},
+				})
+			}
+			metric.Data = &metricspb.Metric_Gauge{Gauge: gauge}
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			histogram := &metricspb.Histogram{
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			}
+			for _, m := range family.Metric {
+				histogram.DataPoints = append(histogram.DataPoints, toHistogramDataPoint(m, start, ts))
+			}
+			metric.Data = &metricspb.Metric_Histogram{Histogram: histogram}
+		case dto.MetricType_SUMMARY:
+			summary := &metricspb.Summary{}
+			for _, m := range family.Metric {
+				s := m.GetSummary()
+				dataPoint := &metricspb.SummaryDataPoint{
+					Attributes:        labelsToAttributes(m.Label),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      ts,
+					Count:             s.GetSampleCount(),
+					Sum:               s.GetSampleSum(),
+				}
+				for _, q := range s.Quantile {
+					dataPoint.QuantileValues = append(dataPoint.QuantileValues, &metricspb.SummaryDataPoint_ValueAtQuantile{
+						Quantile: q.GetQuantile(),
+						Value:    q.GetValue(),
+					})
+				}
+				summary.DataPoints = append(summary.DataPoints, dataPoint)
+			}
+			metric.Data = &metricspb.Metric_Summary{Summary: summary}
+		default:
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// toHistogramDataPoint converts a Prometheus histogram, whose bucket counts
+// are cumulative, into an OTLP histogram, whose bucket counts aren't.
+func toHistogramDataPoint(m *dto.Metric, start, ts uint64) *metricspb.HistogramDataPoint {
+	var (
+		h         = m.GetHistogram()
+		sum       = h.GetSampleSum()
+		dataPoint = &metricspb.HistogramDataPoint{
+			Attributes:        labelsToAttributes(m.Label),
+			StartTimeUnixNano: start,
+			TimeUnixNano:      ts,
+			Count:             h.GetSampleCount(),
+			Sum:               &sum,
+		}
+		previousCount uint64
+	)
+	for _, bucket := range h.Bucket {
+		count := bucket.GetCumulativeCount()
+		dataPoint.ExplicitBounds = append(dataPoint.ExplicitBounds, bucket.GetUpperBound())
+		dataPoint.BucketCounts = append(dataPoint.BucketCounts, count-previousCount)
+		previousCount = count
+	}
+	// OTLP histograms have an implicit +Inf bucket.
+	dataPoint.BucketCounts = append(dataPoint.BucketCounts, dataPoint.Count-previousCount)
+	return dataPoint
+}
+
+func labelsToAttributes(labels []*dto.LabelPair) []*commonpb.KeyValue {
+	attributes := make([]*commonpb.KeyValue, 0, len(labels))
+	for _, label := range labels {
+		attributes = append(attributes, stringAttribute(label.GetName(), label.GetValue()))
+	}
+	return attributes
+}
+
+// toAttributes returns [labels] as attributes, sorted by name.
+func toAttributes(labels map[string]string) []*commonpb.KeyValue {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attributes := make([]*commonpb.KeyValue, 0, len(labels))
+	for _, name := range names {
+		attributes = append(attributes, stringAttribute(name, labels[name]))
+	}
+	return attributes
+}
+
+func stringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key: key,
+		Value: &commonpb.AnyValue{
+			Value: &commonpb.AnyValue_StringValue{
+				StringValue: value,
+			},
+		},
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/protobuf/proto"
+
+	collectorpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestGatherer(t *testing.T) prometheus.Gatherer {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "counter",
+		Help: "help",
+	})
+	counter.Add(2)
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "histogram",
+		Help:    "help",
+		Buckets: []float64{1, 10},
+	})
+	histogram.Observe(0.5)
+	histogram.Observe(5)
+	histogram.Observe(50)
+	require.NoError(registry.Register(counter))
+	require.NoError(registry.Register(histogram))
+	return registry
+}
+
+func TestPushTypeFromString(t *testing.T) {
+	require := require.New(t)
+
+	for _, pushType := range []PushType{PushGateway, OTLP} {
+		parsed, err := PushTypeFromString(pushType.String())
+		require.NoError(err)
+		require.Equal(pushType, parsed)
+	}
+
+	_, err := PushTypeFromString("remote-write")
+	require.ErrorIs(err, errUnknownPushType)
+}
+
+func TestPushConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      PushConfig
+		expectedErr error
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "valid",
+			config: PushConfig{
+				Enabled:   true,
+				Type:      OTLP,
+				Endpoint:  "http://localhost:4318/v1/metrics",
+				Frequency: time.Second,
+			},
+		},
+		{
+			name: "unknown type",
+			config: PushConfig{
+				Enabled:   true,
+				Endpoint:  "http://localhost:9091",
+				Frequency: time.Second,
+			},
+			expectedErr: errUnknownPushType,
+		},
+		{
+			name: "empty endpoint",
+			config: PushConfig{
+				Enabled:   true,
+				Type:      PushGateway,
+				Frequency: time.Second,
+			},
+			expectedErr: errPushEndpointEmpty,
+		},
+		{
+			name: "zero frequency",
+			config: PushConfig{
+				Enabled:  true,
+				Type:     PushGateway,
+				Endpoint: "http://localhost:9091",
+			},
+			expectedErr: errNonPositiveFreq,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestPushGateway(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		method string
+		path   string
+		body   []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := NewPusher(logging.NoLog{}, PushConfig{
+		Enabled:   true,
+		Type:      PushGateway,
+		Endpoint:  server.URL,
+		Frequency: time.Second,
+		Labels: map[string]string{
+			"node": "node1",
+		},
+	}, newTestGatherer(t))
+	require.NoError(p.Push(context.Background()))
+
+	require.Equal(http.MethodPut, method)
+	require.Equal("/metrics/job/avalanche/node/node1", path)
+	require.NotEmpty(body)
+}
+
+func TestPushOTLP(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		contentType string
+		request     collectorpb.ExportMetricsServiceRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		if err := proto.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := NewPusher(logging.NoLog{}, PushConfig{
+		Enabled:   true,
+		Type:      OTLP,
+		Endpoint:  server.URL,
+		Frequency: time.Second,
+		Labels: map[string]string{
+			"node": "node1",
+		},
+	}, newTestGatherer(t))
+	require.NoError(p.Push(context.Background()))
+	require.Equal(otlpContentType, contentType)
+
+	require.Len(request.ResourceMetrics, 1)
+	resourceMetrics := request.ResourceMetrics[0]
+	attributes := make(map[string]string)
+	for _, attribute := range resourceMetrics.Resource.Attributes {
+		attributes[attribute.Key] = attribute.Value.GetStringValue()
+	}
+	require.Equal(map[string]string{
+		"node":               "node1",
+		serviceNameAttribute: "avalanche",
+	}, attributes)
+
+	require.Len(resourceMetrics.ScopeMetrics, 1)
+	metrics := resourceMetrics.ScopeMetrics[0].Metrics
+	require.Len(metrics, 2)
+
+	require.Equal("counter", metrics[0].Name)
+	sum := metrics[0].GetSum()
+	require.True(sum.IsMonotonic)
+	require.Len(sum.DataPoints, 1)
+	require.Equal(2.0, sum.DataPoints[0].GetAsDouble())
+
+	require.Equal("histogram", metrics[1].Name)
+	histogram := metrics[1].GetHistogram()
+	require.Equal(metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, histogram.AggregationTemporality)
+	require.Len(histogram.DataPoints, 1)
+	dataPoint := histogram.DataPoints[0]
+	require.Equal(uint64(3), dataPoint.Count)
+	require.Equal(55.5, dataPoint.GetSum())
+	require.Equal([]float64{1, 10}, dataPoint.ExplicitBounds)
+	require.Equal([]uint64{1, 1, 1}, dataPoint.BucketCounts)
+}
+
+func TestPushUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	p := NewPusher(logging.NoLog{}, PushConfig{
+		Enabled:   true,
+		Type:      OTLP,
+		Endpoint:  server.URL,
+		Frequency: time.Second,
+	}, newTestGatherer(t))
+	err := p.Push(context.Background())
+	require.ErrorIs(t, err, errUnexpectedStatus)
+}
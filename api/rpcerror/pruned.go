@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcerror
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/rpc/v2/json2"
+)
+
+var _ error = (*PrunedError)(nil)
+
+// Retention describes the historical data kept by a node.
+type Retention struct {
+	// MinHeight is the lowest height whose data is still kept. Data below it
+	// was pruned.
+	MinHeight uint64 `json:"minHeight"`
+	// NumHistoricalBlocks is the number of blocks kept below the last accepted
+	// block. If 0, no blocks are pruned.
+	NumHistoricalBlocks uint64 `json:"numHistoricalBlocks"`
+}
+
+// PrunedError reports that the data at [Height] was pruned by the retention
+// policy of the node.
+type PrunedError struct {
+	Height    uint64
+	Retention Retention
+	// Err is the error that would be reported if the node didn't know that the
+	// data was pruned, such as database.ErrNotFound. Callers that don't
+	// distinguish pruned data from missing data can keep matching it.
+	Err error
+}
+
+func (e *PrunedError) Error() string {
+	return fmt.Sprintf("height %d was pruned: only heights >= %d are kept",
+		e.Height,
+		e.Retention.MinHeight,
+	)
+}
+
+func (e *PrunedError) Unwrap() error {
+	return e.Err
+}
+
+// RetentionOf returns the retention policy of the node that reported [err] if
+// [err] is a Pruned error.
+func RetentionOf(err error) (Retention, bool) {
+	var prunedErr *PrunedError
+	if errors.As(err, &prunedErr) {
+		return prunedErr.Retention, true
+	}
+
+	var jsonErr *json2.Error
+	if errors.As(err, &jsonErr) {
+		data, ok := parseData(jsonErr.Data)
+		if ok && data.Retention != nil {
+			return *data.Retention, true
+		}
+	}
+	return Retention{}, false
+}
@@ -0,0 +1,230 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package rpcerror defines the error codes reported by the JSON-RPC APIs.
+//
+// Errors returned by the APIs include a [Data] in the data field of the
+// JSON-RPC error. Unlike the error message, the codes are stable, so clients
+// should match errors using [CodeOf] or [Is] rather than by parsing the
+// message.
+package rpcerror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+// The numeric values of the codes must never change.
+const (
+	// Unknown is the code of errors that haven't been classified.
+	Unknown Code = iota
+	// Internal means that the node failed to handle a valid request.
+	Internal
+	// InvalidArgument means that the request is malformed or an argument is
+	// invalid, regardless of the state of the node.
+	InvalidArgument
+	// NotFound means that a requested entity, such as a transaction or a
+	// chain, doesn't exist.
+	NotFound
+	// AlreadyExists means that the entity the request attempted to create
+	// already exists.
+	AlreadyExists
+	// FailedPrecondition means that the node isn't in the state required to
+	// handle the request, such as an index being disabled.
+	FailedPrecondition
+	// Unavailable means that the request may succeed if it is retried later,
+	// such as when a chain is bootstrapping.
+	Unavailable
+	// PermissionDenied means that the node refuses to handle the request.
+	PermissionDenied
+	// InsufficientFunds means that the addresses used to issue a transaction
+	// can't pay for it.
+	InsufficientFunds
+	// Pruned means that the requested data existed but was deleted by the
+	// node's retention policy. Unlike NotFound, the request may succeed on an
+	// archive node. The retention policy is reported in [Data.Retention].
+	Pruned
+)
+
+var (
+	_ error = (*Error)(nil)
+
+	codeToName = map[Code]string{
+		Unknown:            "UNKNOWN",
+		Internal:           "INTERNAL",
+		InvalidArgument:    "INVALID_ARGUMENT",
+		NotFound:           "NOT_FOUND",
+		AlreadyExists:      "ALREADY_EXISTS",
+		FailedPrecondition: "FAILED_PRECONDITION",
+		Unavailable:        "UNAVAILABLE",
+		PermissionDenied:   "PERMISSION_DENIED",
+		InsufficientFunds:  "INSUFFICIENT_FUNDS",
+		Pruned:             "PRUNED",
+	}
+
+	// wellKnownErrors are errors returned by the APIs that are defined in
+	// packages that can't depend on this package.
+	wellKnownErrors = map[error]Code{
+		context.Canceled:         Unavailable,
+		context.DeadlineExceeded: Unavailable,
+		database.ErrClosed:       Unavailable,
+		database.ErrNotFound:     NotFound,
+	}
+
+	// statusCodes classifies the requests rejected by the node before they
+	// reached an API.
+	statusCodes = map[int]Code{
+		http.StatusUnauthorized:       PermissionDenied,
+		http.StatusForbidden:          PermissionDenied,
+		http.StatusNotFound:           NotFound,
+		http.StatusTooManyRequests:    Unavailable,
+		http.StatusServiceUnavailable: Unavailable,
+	}
+)
+
+// Code classifies an error returned by the APIs.
+type Code uint32
+
+func (c Code) String() string {
+	if name, ok := codeToName[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Code(%d)", uint32(c))
+}
+
+// Data is the data field of the JSON-RPC errors returned by the APIs.
+type Data struct {
+	Code Code `json:"code"`
+	// Name is the string representation of [Code].
+	Name string `json:"name"`
+	// Retention is the retention policy of the node if [Code] is Pruned.
+	Retention *Retention `json:"retention,omitempty"`
+}
+
+// Error attaches a code to an error.
+type Error struct {
+	Code Code
+	Err  error
+}
+
+// New returns an error with message [text] classified by [code].
+func New(code Code, text string) error {
+	return &Error{
+		Code: code,
+		Err:  errors.New(text),
+	}
+}
+
+// Wrap classifies [err] by [code]. Returns nil if [err] is nil.
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{
+		Code: code,
+		Err:  err,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// CodeOf returns the code of [err].
+//
+// If [err] was returned by an API client, the code is the one reported by the
+// node.
+func CodeOf(err error) Code {
+	if err == nil {
+		return Unknown
+	}
+
+	// Pruned errors wrap the error that would be reported if the node hadn't
+	// kept track of what it pruned, so they must be checked first.
+	var prunedErr *PrunedError
+	if errors.As(err, &prunedErr) {
+		return Pruned
+	}
+
+	var codedErr *Error
+	if errors.As(err, &codedErr) {
+		return codedErr.Code
+	}
+
+	var jsonErr *json2.Error
+	if errors.As(err, &jsonErr) {
+		if data, ok := parseData(jsonErr.Data); ok {
+			return data.Code
+		}
+		return Unknown
+	}
+
+	var responseErr *rpc.ResponseError
+	if errors.As(err, &responseErr) {
+		if code, ok := statusCodes[responseErr.StatusCode]; ok {
+			return code
+		}
+	}
+
+	for wellKnownErr, code := range wellKnownErrors {
+		if errors.Is(err, wellKnownErr) {
+			return code
+		}
+	}
+	return Unknown
+}
+
+// Is returns true if [err] is classified by [code].
+func Is(err error, code Code) bool {
+	return err != nil && CodeOf(err) == code
+}
+
+// ToJSONError converts [err] into the JSON-RPC error returned by the APIs.
+func ToJSONError(err error) error {
+	var jsonErr *json2.Error
+	if errors.As(err, &jsonErr) {
+		return jsonErr
+	}
+
+	code := CodeOf(err)
+	data := Data{
+		Code: code,
+		Name: code.String(),
+	}
+	if retention, ok := RetentionOf(err); ok {
+		data.Retention = &retention
+	}
+	return &json2.Error{
+		Code:    json2.E_SERVER,
+		Message: err.Error(),
+		Data:    data,
+	}
+}
+
+// parseData parses the data field of a JSON-RPC error received by a client.
+func parseData(rawData interface{}) (Data, bool) {
+	if rawData == nil {
+		return Data{}, false
+	}
+	dataBytes, err := json.Marshal(rawData)
+	if err != nil {
+		return Data{}, false
+	}
+	var data Data
+	if err := json.Unmarshal(dataBytes, &data); err != nil {
+		return Data{}, false
+	}
+	return data, true
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcerror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	avarpc "github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var errTest = New(NotFound, "test error")
+
+type testService struct {
+	err error
+}
+
+func (s *testService) Fail(*http.Request, *struct{}, *struct{}) error {
+	return s.err
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code Code
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			code: Unknown,
+		},
+		{
+			name: "unclassified",
+			err:  errors.New("unclassified"),
+			code: Unknown,
+		},
+		{
+			name: "classified",
+			err:  errTest,
+			code: NotFound,
+		},
+		{
+			name: "wrapped",
+			err:  fmt.Errorf("%w: with context", errTest),
+			code: NotFound,
+		},
+		{
+			name: "reclassified",
+			err:  Wrap(Internal, errTest),
+			code: Internal,
+		},
+		{
+			name: "well known",
+			err:  fmt.Errorf("failed: %w", context.DeadlineExceeded),
+			code: Unavailable,
+		},
+		{
+			name: "database error",
+			err:  fmt.Errorf("couldn't get tx: %w", database.ErrNotFound),
+			code: NotFound,
+		},
+		{
+			name: "json error with data",
+			err: &json2.Error{
+				Code:    json2.E_SERVER,
+				Message: "not enough funds",
+				Data: map[string]interface{}{
+					"code": float64(InsufficientFunds),
+					"name": InsufficientFunds.String(),
+				},
+			},
+			code: InsufficientFunds,
+		},
+		{
+			name: "pruned",
+			err: fmt.Errorf("failed: %w", &PrunedError{
+				Height: 5,
+				Err:    errTest,
+			}),
+			code: Pruned,
+		},
+		{
+			name: "json error without data",
+			err: &json2.Error{
+				Code:    json2.E_SERVER,
+				Message: "old node",
+			},
+			code: Unknown,
+		},
+		{
+			name: "rejected before reaching the API",
+			err: &avarpc.ResponseError{
+				StatusCode: http.StatusServiceUnavailable,
+				Message:    avarpc.NotBootstrappedMessage,
+			},
+			code: Unavailable,
+		},
+		{
+			name: "unclassified status code",
+			err: &avarpc.ResponseError{
+				StatusCode: http.StatusTeapot,
+			},
+			code: Unknown,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.code, CodeOf(test.err))
+		})
+	}
+}
+
+func TestIs(t *testing.T) {
+	require := require.New(t)
+
+	require.True(Is(errTest, NotFound))
+	require.False(Is(errTest, Internal))
+	require.False(Is(nil, Unknown))
+	require.True(Is(errors.New("unclassified"), Unknown))
+}
+
+func TestCodeString(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("NOT_FOUND", NotFound.String())
+	require.Equal("Code(1000)", Code(1000).String())
+}
+
+func TestWrapNil(t *testing.T) {
+	require.NoError(t, Wrap(Internal, nil))
+}
+
+func TestToJSONError(t *testing.T) {
+	require := require.New(t)
+
+	err := ToJSONError(fmt.Errorf("%w: with context", errTest))
+	jsonErr := &json2.Error{}
+	require.ErrorAs(err, &jsonErr)
+	require.Equal(json2.E_SERVER, jsonErr.Code)
+	require.Equal("test error: with context", jsonErr.Message)
+	require.Equal(Data{Code: NotFound, Name: "NOT_FOUND"}, jsonErr.Data)
+
+	// Errors that are already JSON-RPC errors are returned unmodified.
+	require.Equal(jsonErr, ToJSONError(jsonErr))
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	service := &testService{}
+	server := rpc.NewServer()
+	server.RegisterCodec(json2.NewCustomCodecWithErrorMapper(rpc.DefaultEncoderSelector, ToJSONError), "application/json")
+	require.NoError(server.RegisterService(service, "test"))
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	uri, err := url.Parse(httpServer.URL)
+	require.NoError(err)
+
+	service.err = fmt.Errorf("%w: with context", errTest)
+	err = avarpc.SendJSONRequest(context.Background(), uri, "test.Fail", &struct{}{}, &struct{}{})
+	require.True(Is(err, NotFound))
+
+	service.err = errors.New("unclassified")
+	err = avarpc.SendJSONRequest(context.Background(), uri, "test.Fail", &struct{}{}, &struct{}{})
+	require.Error(err) //nolint:forbidigo // the error is decoded from the response
+	require.Equal(Unknown, CodeOf(err))
+}
+
+func TestPrunedRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	service := &testService{}
+	server := rpc.NewServer()
+	server.RegisterCodec(json2.NewCustomCodecWithErrorMapper(rpc.DefaultEncoderSelector, ToJSONError), "application/json")
+	require.NoError(server.RegisterService(service, "test"))
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	uri, err := url.Parse(httpServer.URL)
+	require.NoError(err)
+
+	retention := Retention{
+		MinHeight:           100,
+		NumHistoricalBlocks: 50,
+	}
+	service.err = fmt.Errorf("couldn't get block: %w", &PrunedError{
+		Height:    10,
+		Retention: retention,
+		Err:       errTest,
+	})
+	require.ErrorIs(service.err, errTest)
+
+	err = avarpc.SendJSONRequest(context.Background(), uri, "test.Fail", &struct{}{}, &struct{}{})
+	require.True(Is(err, Pruned))
+	gotRetention, ok := RetentionOf(err)
+	require.True(ok)
+	require.Equal(retention, gotRetention)
+
+	// Other errors don't report a retention policy.
+	service.err = errTest
+	err = avarpc.SendJSONRequest(context.Background(), uri, "test.Fail", &struct{}{}, &struct{}{})
+	require.True(Is(err, NotFound))
+	_, ok = RetentionOf(err)
+	require.False(ok)
+}
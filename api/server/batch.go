@@ -0,0 +1,223 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// Error codes defined by the JSON-RPC 2.0 specification.
+	jsonRPCInvalidRequest = -32600
+	jsonRPCInternalError  = -32603
+
+	jsonRPCVersion = "2.0"
+)
+
+var (
+	_ http.Handler        = (*batchHandler)(nil)
+	_ http.ResponseWriter = (*responseBuffer)(nil)
+)
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonRPCErrorResponse struct {
+	Version string           `json:"jsonrpc"`
+	Error   jsonRPCError     `json:"error"`
+	ID      *json.RawMessage `json:"id"`
+}
+
+// batchHandler splits JSON-RPC batch requests into their individual requests,
+// passes each of them to the wrapped handler, and combines the responses into
+// a single batch response. Requests that aren't batches are passed through
+// unmodified.
+type batchHandler struct {
+	handler      http.Handler
+	maxBatchSize int
+}
+
+// newBatchHandler wraps [handler] to support JSON-RPC batches of at most
+// [maxBatchSize] requests. If [maxBatchSize] is 0, [handler] is returned.
+func newBatchHandler(handler http.Handler, maxBatchSize int) http.Handler {
+	if maxBatchSize <= 0 {
+		return handler
+	}
+	return &batchHandler{
+		handler:      handler,
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+func (h *batchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	// Only the beginning of the body is inspected so that requests that
+	// aren't batches don't need to be buffered.
+	reader := bufio.NewReader(r.Body)
+	r.Body = readCloser{
+		Reader: reader,
+		Closer: r.Body,
+	}
+	if !isBatch(reader) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, fmt.Sprintf("failed to read request body: %s", err), status)
+		return
+	}
+
+	var requests []json.RawMessage
+	if err := json.Unmarshal(body, &requests); err != nil {
+		writeJSONRPCError(w, nil, jsonRPCInvalidRequest, fmt.Sprintf("invalid batch: %s", err))
+		return
+	}
+	switch {
+	case len(requests) == 0:
+		writeJSONRPCError(w, nil, jsonRPCInvalidRequest, "empty batch")
+		return
+	case len(requests) > h.maxBatchSize:
+		writeJSONRPCError(
+			w,
+			nil,
+			jsonRPCInvalidRequest,
+			fmt.Sprintf("batch contains %d requests which exceeds the maximum of %d", len(requests), h.maxBatchSize),
+		)
+		return
+	}
+
+	responses := make([]json.RawMessage, len(requests))
+	for i, request := range requests {
+		responses[i] = h.serveRequest(r, request)
+	}
+
+	responseBytes, err := json.Marshal(responses)
+	if err != nil {
+		writeJSONRPCError(w, nil, jsonRPCInternalError, fmt.Sprintf("failed to marshal batch response: %s", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(responseBytes)
+}
+
+// serveRequest passes a single request of a batch to the wrapped handler and
+// returns its response. Any failure is reported as a JSON-RPC error of the
+// request so that the other requests in the batch are unaffected.
+func (h *batchHandler) serveRequest(r *http.Request, request json.RawMessage) json.RawMessage {
+	var header struct {
+		ID *json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(request, &header); err != nil {
+		return newJSONRPCError(nil, jsonRPCInvalidRequest, fmt.Sprintf("invalid request: %s", err))
+	}
+
+	itemRequest := r.Clone(r.Context())
+	itemRequest.Body = io.NopCloser(bytes.NewReader(request))
+	itemRequest.ContentLength = int64(len(request))
+
+	response := newResponseBuffer()
+	h.handler.ServeHTTP(response, itemRequest)
+
+	responseBytes := bytes.TrimSpace(response.body.Bytes())
+	if len(responseBytes) != 0 && json.Valid(responseBytes) {
+		return responseBytes
+	}
+
+	// The handler didn't reply with a JSON-RPC response, for example because
+	// a middleware rejected the request.
+	message := strings.TrimSpace(string(responseBytes))
+	if message == "" {
+		message = http.StatusText(response.status)
+	}
+	return newJSONRPCError(header.ID, jsonRPCInternalError, message)
+}
+
+// isBatch returns true if the JSON value that [reader] starts with is an
+// array. No bytes are consumed from [reader].
+func isBatch(reader *bufio.Reader) bool {
+	for i := 1; ; i++ {
+		prefix, err := reader.Peek(i)
+		if err != nil {
+			return false
+		}
+		switch prefix[i-1] {
+		case ' ', '\t', '\r', '\n':
+		case '[':
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+func newJSONRPCError(id *json.RawMessage, code int, message string) json.RawMessage {
+	// Marshalling this struct can't fail.
+	response, _ := json.Marshal(jsonRPCErrorResponse{
+		Version: jsonRPCVersion,
+		Error: jsonRPCError{
+			Code:    code,
+			Message: message,
+		},
+		ID: id,
+	})
+	return response
+}
+
+func writeJSONRPCError(w http.ResponseWriter, id *json.RawMessage, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = w.Write(newJSONRPCError(id, code, message))
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// responseBuffer records the response of a single request of a batch.
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseBuffer() *responseBuffer {
+	return &responseBuffer{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (b *responseBuffer) Header() http.Header {
+	return b.header
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+func (b *responseBuffer) WriteHeader(status int) {
+	b.status = status
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/stretchr/testify/require"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var errOdd = errors.New("odd")
+
+type echoService struct{}
+
+type EchoArgs struct {
+	Value int `json:"value"`
+}
+
+type EchoReply struct {
+	Value int `json:"value"`
+}
+
+func (*echoService) Echo(_ *http.Request, args *EchoArgs, reply *EchoReply) error {
+	if args.Value%2 == 1 {
+		return errOdd
+	}
+	reply.Value = args.Value
+	return nil
+}
+
+func newEchoHandler(t *testing.T, maxBatchSize int) http.Handler {
+	server := rpc.NewServer()
+	server.RegisterCodec(avajson.NewCodec(), "application/json")
+	require.NoError(t, server.RegisterService(&echoService{}, "echo"))
+	return newBatchHandler(server, maxBatchSize)
+}
+
+func serveJSON(handler http.Handler, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func TestBatchHandler(t *testing.T) {
+	require := require.New(t)
+
+	handler := newEchoHandler(t, 3)
+
+	w := serveJSON(handler, ` [
+		{"jsonrpc":"2.0","method":"echo.echo","params":{"value":2},"id":1},
+		{"jsonrpc":"2.0","method":"echo.echo","params":{"value":3},"id":2},
+		5
+	]`)
+	require.Equal(http.StatusOK, w.Code)
+
+	var responses []struct {
+		Result *EchoReply      `json:"result"`
+		Error  *jsonRPCError   `json:"error"`
+		ID     json.RawMessage `json:"id"`
+	}
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &responses))
+	require.Len(responses, 3)
+
+	require.Nil(responses[0].Error)
+	require.Equal(&EchoReply{Value: 2}, responses[0].Result)
+	require.Equal(json.RawMessage("1"), responses[0].ID)
+
+	// Errors are reported per request.
+	require.Nil(responses[1].Result)
+	require.NotNil(responses[1].Error)
+	require.Contains(responses[1].Error.Message, errOdd.Error())
+	require.Equal(json.RawMessage("2"), responses[1].ID)
+
+	require.Nil(responses[2].Result)
+	require.NotNil(responses[2].Error)
+	require.Equal(jsonRPCInvalidRequest, responses[2].Error.Code)
+}
+
+func TestBatchHandlerNotBatch(t *testing.T) {
+	require := require.New(t)
+
+	handler := newEchoHandler(t, 3)
+
+	w := serveJSON(handler, `{"jsonrpc":"2.0","method":"echo.echo","params":{"value":4},"id":1}`)
+	require.Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		Result EchoReply `json:"result"`
+	}
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(4, response.Result.Value)
+}
+
+func TestBatchHandlerInvalidBatch(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "empty",
+			body: `[]`,
+		},
+		{
+			name: "too large",
+			body: `[1, 2, 3, 4]`,
+		},
+		{
+			name: "malformed",
+			body: `[{"jsonrpc":"2.0"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			w := serveJSON(newEchoHandler(t, 3), test.body)
+			require.Equal(http.StatusBadRequest, w.Code)
+
+			var response jsonRPCErrorResponse
+			require.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+			require.Equal(jsonRPCInvalidRequest, response.Error.Code)
+		})
+	}
+}
+
+func TestBatchHandlerNonJSONResponse(t *testing.T) {
+	require := require.New(t)
+
+	handler := newBatchHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "rejected", http.StatusServiceUnavailable)
+		}),
+		1,
+	)
+
+	w := serveJSON(handler, `[{"jsonrpc":"2.0","method":"echo.echo","id":"a"}]`)
+	require.Equal(http.StatusOK, w.Code)
+
+	var responses []jsonRPCErrorResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &responses))
+	require.Len(responses, 1)
+	require.Equal(jsonRPCInternalError, responses[0].Error.Code)
+	require.Equal("rejected", responses[0].Error.Message)
+	require.NotNil(responses[0].ID)
+	require.Equal(json.RawMessage(`"a"`), *responses[0].ID)
+}
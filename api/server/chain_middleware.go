@@ -0,0 +1,309 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+const (
+	requestIDHeader = rpc.RequestIDHeader
+	requestIDLen    = 8
+
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+	redactedAuthToken   = "<redacted>"
+)
+
+var (
+	errEmptyAuthToken         = errors.New("auth tokens must be non-empty")
+	errInvalidRateLimit       = errors.New("rate limit must be positive")
+	errNegativeRateLimitBurst = errors.New("rate limit burst must be non-negative")
+
+	_ http.ResponseWriter = (*statusRecorder)(nil)
+	_ http.Flusher        = (*statusRecorder)(nil)
+	_ http.Hijacker       = (*statusRecorder)(nil)
+)
+
+// Middleware wraps an http.Handler.
+type Middleware func(http.Handler) http.Handler
+
+// ChainMiddlewareConfig specifies the middlewares that are applied to the API
+// handlers of a chain.
+type ChainMiddlewareConfig struct {
+	// LogRequests logs every request to the chain along with a request ID.
+	// The request ID is also returned in the X-Request-Id header.
+	LogRequests bool `json:"logRequests"`
+	// AuthTokens, if non-empty, requires requests to the chain to provide
+	// one of the tokens as a bearer token in the Authorization header.
+	AuthTokens []string `json:"authTokens"`
+	// MethodRateLimits maps JSON-RPC methods, such as "avm.getUTXOs", to the
+	// rate at which they can be called.
+	MethodRateLimits map[string]RateLimit `json:"methodRateLimits"`
+}
+
+// MarshalJSON redacts the auth tokens so that the config can be logged.
+func (c ChainMiddlewareConfig) MarshalJSON() ([]byte, error) {
+	type config ChainMiddlewareConfig
+	redacted := config(c)
+	if len(c.AuthTokens) > 0 {
+		redacted.AuthTokens = make([]string, len(c.AuthTokens))
+		for i := range redacted.AuthTokens {
+			redacted.AuthTokens[i] = redactedAuthToken
+		}
+	}
+	return json.Marshal(redacted)
+}
+
+// RateLimit is a token bucket rate limit.
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which requests are allowed.
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	// Burst is the maximum number of requests allowed at once. If 0, it
+	// defaults to 1.
+	Burst int `json:"burst"`
+}
+
+// VerifyChainMiddlewares returns an error if [configs] can't be applied by the
+// server.
+func VerifyChainMiddlewares(configs map[string]ChainMiddlewareConfig) error {
+	for chain, config := range configs {
+		for _, token := range config.AuthTokens {
+			if token == "" {
+				return fmt.Errorf("%w: %q", errEmptyAuthToken, chain)
+			}
+		}
+		for method, limit := range config.MethodRateLimits {
+			if limit.RequestsPerSecond <= 0 {
+				return fmt.Errorf("%w: %q %q", errInvalidRateLimit, chain, method)
+			}
+			if limit.Burst < 0 {
+				return fmt.Errorf("%w: %q %q", errNegativeRateLimitBurst, chain, method)
+			}
+		}
+	}
+	return nil
+}
+
+// newChainMiddlewares returns the middlewares specified by [config] in the
+// order they should be applied, from outermost to innermost.
+func newChainMiddlewares(
+	log logging.Logger,
+	chainName string,
+	config ChainMiddlewareConfig,
+) []Middleware {
+	var middlewares []Middleware
+	if config.LogRequests {
+		middlewares = append(middlewares, func(h http.Handler) http.Handler {
+			return &requestLogger{
+				log:       log,
+				chainName: chainName,
+				handler:   h,
+			}
+		})
+	}
+	if len(config.AuthTokens) > 0 {
+		middlewares = append(middlewares, func(h http.Handler) http.Handler {
+			return &tokenAuthenticator{
+				tokens:  config.AuthTokens,
+				handler: h,
+			}
+		})
+	}
+	if len(config.MethodRateLimits) > 0 {
+		limiters := make(map[string]*rate.Limiter, len(config.MethodRateLimits))
+		for method, limit := range config.MethodRateLimits {
+			burst := limit.Burst
+			if burst == 0 {
+				burst = 1
+			}
+			limiters[method] = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst)
+		}
+		middlewares = append(middlewares, func(h http.Handler) http.Handler {
+			return &methodRateLimiter{
+				limiters: limiters,
+				handler:  h,
+			}
+		})
+	}
+	return middlewares
+}
+
+// applyMiddlewares wraps [handler] with [middlewares] such that the first
+// middleware is the first to handle a request.
+func applyMiddlewares(handler http.Handler, middlewares []Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+type requestLogger struct {
+	log       logging.Logger
+	chainName string
+	handler   http.Handler
+}
+
+func (l *requestLogger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestID := r.Header.Get(requestIDHeader)
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	w.Header().Set(requestIDHeader, requestID)
+
+	method := jsonRPCMethod(r)
+	recorder := &statusRecorder{
+		ResponseWriter: w,
+		status:         http.StatusOK,
+	}
+	start := time.Now()
+	l.handler.ServeHTTP(recorder, r)
+
+	l.log.Info("handled API request",
+		zap.String("requestID", requestID),
+		zap.String("chain", l.chainName),
+		zap.String("path", r.URL.Path),
+		zap.String("method", method),
+		zap.String("remoteAddr", r.RemoteAddr),
+		zap.Int("status", recorder.status),
+		zap.Duration("duration", time.Since(start)),
+	)
+}
+
+type tokenAuthenticator struct {
+	tokens  []string
+	handler http.Handler
+}
+
+func (a *tokenAuthenticator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get(authorizationHeader), bearerPrefix)
+	if !ok || !a.allowed(token) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "missing or invalid auth token", http.StatusUnauthorized)
+		return
+	}
+	a.handler.ServeHTTP(w, r)
+}
+
+func (a *tokenAuthenticator) allowed(token string) bool {
+	allowed := false
+	for _, t := range a.tokens {
+		// Every token is compared to avoid leaking which one matched.
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			allowed = true
+		}
+	}
+	return allowed
+}
+
+type methodRateLimiter struct {
+	limiters map[string]*rate.Limiter
+	handler  http.Handler
+}
+
+func (l *methodRateLimiter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := jsonRPCMethod(r)
+	if limiter, ok := l.limiters[method]; ok && !limiter.Allow() {
+		http.Error(w, fmt.Sprintf("rate limit exceeded for %s", method), http.StatusTooManyRequests)
+		return
+	}
+	l.handler.ServeHTTP(w, r)
+}
+
+// jsonRPCRequest is the part of a JSON-RPC request inspected by the
+// middlewares.
+type jsonRPCRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// jsonRPCMethod returns the JSON-RPC method called by [r], or the empty string
+// if it can't be determined. The body of [r] is replaced so that it can still
+// be read by the handler.
+func jsonRPCMethod(r *http.Request) string {
+	return readJSONRPCRequest(r).Method
+}
+
+// readJSONRPCRequest returns the JSON-RPC request sent by [r], which is empty
+// if it can't be parsed. The body of [r] is replaced so that it can still be
+// read by the handler.
+func readJSONRPCRequest(r *http.Request) jsonRPCRequest {
+	var request jsonRPCRequest
+	if r.Method != http.MethodPost || r.Body == nil {
+		return request
+	}
+
+	// The body is fully read, rather than only a prefix of it, so that the
+	// method can't be hidden from rate limiting by padding the request.
+	body, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return request
+	}
+
+	if err := json.Unmarshal(body, &request); err != nil {
+		return jsonRPCRequest{}
+	}
+	return request
+}
+
+func newRequestID() string {
+	var b [requestIDLen]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// statusRecorder records the status code and the number of body bytes written
+// to the wrapped ResponseWriter.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.size += n
+	return n, err
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	r.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
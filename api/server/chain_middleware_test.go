@@ -0,0 +1,195 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newMiddlewareHandler(t *testing.T, config ChainMiddlewareConfig) http.Handler {
+	require.NoError(t, VerifyChainMiddlewares(map[string]ChainMiddlewareConfig{
+		"X": config,
+	}))
+	middlewares := newChainMiddlewares(logging.NoLog{}, "X", config)
+	return applyMiddlewares(newEchoHandler(t, 0), middlewares)
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	handler := newMiddlewareHandler(t, ChainMiddlewareConfig{
+		AuthTokens: []string{"token1", "token2"},
+	})
+
+	tests := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+	}{
+		{
+			name:           "missing",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "not bearer",
+			authorization:  "token1",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "invalid",
+			authorization:  "Bearer token3",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "first token",
+			authorization:  "Bearer token1",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "second token",
+			authorization:  "Bearer token2",
+			expectedStatus: http.StatusOK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/",
+				strings.NewReader(`{"jsonrpc":"2.0","method":"echo.echo","params":{"value":2},"id":1}`),
+			)
+			r.Header.Set("Content-Type", "application/json")
+			if test.authorization != "" {
+				r.Header.Set(authorizationHeader, test.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			require.Equal(t, test.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestChainMiddlewareConfigMarshalRedactsAuthTokens(t *testing.T) {
+	require := require.New(t)
+
+	configBytes, err := json.Marshal(ChainMiddlewareConfig{
+		AuthTokens: []string{"token1", "token2"},
+	})
+	require.NoError(err)
+	require.NotContains(string(configBytes), "token1")
+	require.NotContains(string(configBytes), "token2")
+
+	// Parsing the config isn't affected by the redaction.
+	var config ChainMiddlewareConfig
+	require.NoError(json.Unmarshal([]byte(`{"authTokens":["token1"]}`), &config))
+	require.Equal([]string{"token1"}, config.AuthTokens)
+}
+
+func TestMethodRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	handler := newMiddlewareHandler(t, ChainMiddlewareConfig{
+		MethodRateLimits: map[string]RateLimit{
+			"echo.echo": {
+				RequestsPerSecond: 0.001,
+				Burst:             2,
+			},
+		},
+	})
+
+	const request = `{"jsonrpc":"2.0","method":"echo.echo","params":{"value":2},"id":1}`
+	for i := 0; i < 2; i++ {
+		w := serveJSON(handler, request)
+		require.Equal(http.StatusOK, w.Code)
+		require.Contains(w.Body.String(), `"value":2`)
+	}
+	w := serveJSON(handler, request)
+	require.Equal(http.StatusTooManyRequests, w.Code)
+
+	// Methods without a configured limit aren't limited.
+	w = serveJSON(handler, `{"jsonrpc":"2.0","method":"echo.unknown","id":1}`)
+	require.Equal(http.StatusOK, w.Code)
+}
+
+func TestRequestLogger(t *testing.T) {
+	require := require.New(t)
+
+	handler := newMiddlewareHandler(t, ChainMiddlewareConfig{
+		LogRequests: true,
+	})
+
+	w := serveJSON(handler, `{"jsonrpc":"2.0","method":"echo.echo","params":{"value":2},"id":1}`)
+	require.Equal(http.StatusOK, w.Code)
+	require.Len(w.Header().Get(requestIDHeader), 2*requestIDLen)
+
+	// A provided request ID is propagated.
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(requestIDHeader, "abc")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal("abc", w.Header().Get(requestIDHeader))
+}
+
+func TestVerifyChainMiddlewares(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      ChainMiddlewareConfig
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: ChainMiddlewareConfig{
+				LogRequests: true,
+				AuthTokens:  []string{"token"},
+				MethodRateLimits: map[string]RateLimit{
+					"avm.getUTXOs": {
+						RequestsPerSecond: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "empty auth token",
+			config: ChainMiddlewareConfig{
+				AuthTokens: []string{""},
+			},
+			expectedErr: errEmptyAuthToken,
+		},
+		{
+			name: "zero rate",
+			config: ChainMiddlewareConfig{
+				MethodRateLimits: map[string]RateLimit{
+					"avm.getUTXOs": {},
+				},
+			},
+			expectedErr: errInvalidRateLimit,
+		},
+		{
+			name: "negative burst",
+			config: ChainMiddlewareConfig{
+				MethodRateLimits: map[string]RateLimit{
+					"avm.getUTXOs": {
+						RequestsPerSecond: 1,
+						Burst:             -1,
+					},
+				},
+			},
+			expectedErr: errNegativeRateLimitBurst,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyChainMiddlewares(map[string]ChainMiddlewareConfig{
+				"X": test.config,
+			})
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	runtimemetrics "runtime/metrics"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+const (
+	overloaded = "overloaded"
+
+	totalMemoryMetric    = "/memory/classes/total:bytes"
+	releasedMemoryMetric = "/memory/classes/heap/released:bytes"
+)
+
+var (
+	errNegativeMaxCPUUsage = errors.New("max CPU usage must be non-negative")
+	errNegativeRetryAfter  = errors.New("retry after must be non-negative")
+
+	// protectedRoutes are never shed so that the node can be monitored while
+	// it is overloaded.
+	protectedRoutes = []string{
+		"/ext/health",
+		"/ext/metrics",
+	}
+
+	_ http.Handler = (*loadShedder)(nil)
+)
+
+// LoadSheddingConfig specifies which requests are rejected while the node is
+// using too many resources.
+type LoadSheddingConfig struct {
+	// MaxCPUUsage is the number of CPU cores the node can use before requests
+	// to [Routes] are rejected. If 0, the CPU usage isn't considered.
+	MaxCPUUsage float64 `json:"maxCPUUsage"`
+	// MaxMemoryUsage is the number of bytes of memory the node can use before
+	// requests to [Routes] are rejected. If 0, the memory usage isn't
+	// considered.
+	MaxMemoryUsage uint64 `json:"maxMemoryUsage"`
+	// Routes are the path prefixes, such as "/ext/bc/X", of the low priority
+	// routes whose requests are rejected. Requests to the health and metrics
+	// APIs are never rejected.
+	Routes []string `json:"routes"`
+	// RetryAfter is how long clients are told to wait before retrying a
+	// rejected request.
+	RetryAfter time.Duration `json:"retryAfter"`
+}
+
+// Enabled returns true if any request can be rejected.
+func (c *LoadSheddingConfig) Enabled() bool {
+	return len(c.Routes) > 0 && (c.MaxCPUUsage > 0 || c.MaxMemoryUsage > 0)
+}
+
+// Verify returns an error if [c] can't be applied by the server.
+func (c *LoadSheddingConfig) Verify() error {
+	if c.MaxCPUUsage < 0 {
+		return errNegativeMaxCPUUsage
+	}
+	if c.RetryAfter < 0 {
+		return errNegativeRetryAfter
+	}
+	for _, route := range c.Routes {
+		if !strings.HasPrefix(route, "/") {
+			return fmt.Errorf("%w: %q", errInvalidRoute, route)
+		}
+	}
+	return nil
+}
+
+// loadShedder rejects requests to low priority routes while the node is using
+// more resources than allowed.
+type loadShedder struct {
+	handler http.Handler
+	router  *router
+
+	routes         []string
+	maxCPUUsage    float64
+	maxMemoryUsage uint64
+	// retryAfter is the value of the Retry-After header, in seconds
+	retryAfter string
+
+	cpu         resource.CPUUser
+	memoryUsage func() uint64
+	rejected    prometheus.Counter
+}
+
+func newLoadShedder(
+	handler http.Handler,
+	router *router,
+	config LoadSheddingConfig,
+	cpu resource.CPUUser,
+	rejected *prometheus.CounterVec,
+) *loadShedder {
+	routes := make([]string, len(config.Routes))
+	for i, route := range config.Routes {
+		routes[i] = strings.TrimSuffix(route, "/")
+	}
+	// Retry-After must be a whole number of seconds. Clients are told to wait
+	// at least a second so that they don't retry immediately.
+	retryAfter := math.Max(1, math.Ceil(config.RetryAfter.Seconds()))
+	return &loadShedder{
+		handler:        handler,
+		router:         router,
+		routes:         routes,
+		maxCPUUsage:    config.MaxCPUUsage,
+		maxMemoryUsage: config.MaxMemoryUsage,
+		retryAfter:     strconv.FormatFloat(retryAfter, 'f', 0, 64),
+		cpu:            cpu,
+		memoryUsage:    memoryUsage,
+		rejected:       rejected.WithLabelValues(overloaded),
+	}
+}
+
+func (l *loadShedder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if l.lowPriority(r.URL.Path) && l.overloaded() {
+		l.rejected.Inc()
+		w.Header().Set("Retry-After", l.retryAfter)
+		http.Error(w, rpc.OverloadedMessage, http.StatusServiceUnavailable)
+		return
+	}
+	l.handler.ServeHTTP(w, r)
+}
+
+// lowPriority returns true if requests to [path] can be shed. The routes are
+// matched against [path] and all of its aliases.
+func (l *loadShedder) lowPriority(path string) bool {
+	paths := l.router.equivalentPaths(path)
+	for _, route := range protectedRoutes {
+		for _, path := range paths {
+			if matchesRoute(path, route) {
+				return false
+			}
+		}
+	}
+	for _, route := range l.routes {
+		for _, path := range paths {
+			if matchesRoute(path, route) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// overloaded returns true if the node is using more resources than allowed.
+func (l *loadShedder) overloaded() bool {
+	if l.maxCPUUsage > 0 && l.cpu.CPUUsage() > l.maxCPUUsage {
+		return true
+	}
+	return l.maxMemoryUsage > 0 && l.memoryUsage() > l.maxMemoryUsage
+}
+
+// matchesRoute returns true if [path] is [route] or a sub-path of [route].
+func matchesRoute(path, route string) bool {
+	return path == route || strings.HasPrefix(path, route+"/")
+}
+
+// memoryUsage returns the number of bytes of memory mapped by the Go runtime
+// that haven't been returned to the OS.
+func memoryUsage() uint64 {
+	samples := []runtimemetrics.Sample{
+		{Name: totalMemoryMetric},
+		{Name: releasedMemoryMetric},
+	}
+	runtimemetrics.Read(samples)
+	return samples[0].Value.Uint64() - samples[1].Value.Uint64()
+}
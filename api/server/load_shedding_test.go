@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCPUUser float64
+
+func (u testCPUUser) CPUUsage() float64 {
+	return float64(u)
+}
+
+func TestLoadShedder(t *testing.T) {
+	config := LoadSheddingConfig{
+		MaxCPUUsage:    2,
+		MaxMemoryUsage: 1024,
+		Routes:         []string{"/ext/bc/X/", "/ext"},
+		RetryAfter:     1500 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		cpuUsage       float64
+		memoryUsage    uint64
+		expectedStatus int
+	}{
+		{
+			name:           "not overloaded",
+			path:           "/ext/bc/X",
+			cpuUsage:       2,
+			memoryUsage:    1024,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "cpu overloaded",
+			path:           "/ext/bc/X",
+			cpuUsage:       2.5,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "memory overloaded",
+			path:           "/ext/bc/X/events",
+			memoryUsage:    1025,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "health isn't shed",
+			path:           "/ext/health/readiness",
+			cpuUsage:       2.5,
+			memoryUsage:    1025,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "metrics isn't shed",
+			path:           "/ext/metrics",
+			cpuUsage:       2.5,
+			memoryUsage:    1025,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "route isn't low priority",
+			path:           "/other",
+			cpuUsage:       2.5,
+			memoryUsage:    1025,
+			expectedStatus: http.StatusOK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			rejected := prometheus.NewCounterVec(prometheus.CounterOpts{}, []string{"reason"})
+			handler := &testHandler{}
+			h := newLoadShedder(
+				handler,
+				newRouter(),
+				config,
+				testCPUUser(test.cpuUsage),
+				rejected,
+			)
+			h.memoryUsage = func() uint64 {
+				return test.memoryUsage
+			}
+
+			r := httptest.NewRequest(http.MethodPost, test.path, nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			require.Equal(test.expectedStatus, w.Code)
+			require.Equal(test.expectedStatus == http.StatusOK, handler.called)
+			if test.expectedStatus == http.StatusServiceUnavailable {
+				require.Equal("2", w.Header().Get("Retry-After"))
+				require.Equal(1.0, testutil.ToFloat64(rejected.WithLabelValues(overloaded)))
+			}
+		})
+	}
+}
+
+func TestLoadSheddingConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      LoadSheddingConfig
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: LoadSheddingConfig{
+				MaxCPUUsage: 1,
+				Routes:      []string{"/ext/bc/X"},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "negative max cpu usage",
+			config: LoadSheddingConfig{
+				MaxCPUUsage: -1,
+			},
+			expectedErr: errNegativeMaxCPUUsage,
+		},
+		{
+			name: "negative retry after",
+			config: LoadSheddingConfig{
+				RetryAfter: -1,
+			},
+			expectedErr: errNegativeRetryAfter,
+		},
+		{
+			name: "invalid route",
+			config: LoadSheddingConfig{
+				Routes: []string{"ext/bc/X"},
+			},
+			expectedErr: errInvalidRoute,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestMemoryUsage(t *testing.T) {
+	require.Positive(t, memoryUsage())
+}
+
+func TestLoadShedderAliases(t *testing.T) {
+	require := require.New(t)
+
+	router := newRouter()
+	require.NoError(router.AddAlias(testChainRoute, "/ext/bc/X"))
+
+	config := LoadSheddingConfig{
+		MaxCPUUsage: 2,
+		Routes:      []string{"/ext/bc/X"},
+	}
+	rejected := prometheus.NewCounterVec(prometheus.CounterOpts{}, []string{"reason"})
+	h := newLoadShedder(
+		&testHandler{},
+		router,
+		config,
+		testCPUUser(2.5),
+		rejected,
+	)
+
+	// Requests to the chain ID are shed like requests to its alias.
+	r := httptest.NewRequest(http.MethodPost, testChainRoute+"/rpc", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(http.StatusServiceUnavailable, w.Code)
+}
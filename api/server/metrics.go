@@ -4,7 +4,9 @@
 package server
 
 import (
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -16,6 +18,13 @@ type metrics struct {
 	numProcessing *prometheus.GaugeVec
 	numCalls      *prometheus.CounterVec
 	totalDuration *prometheus.GaugeVec
+	numRejected   *prometheus.CounterVec
+
+	// Metrics of the routes registered by chains, labeled by chain and route
+	routeCalls         *prometheus.CounterVec
+	routeDuration      *prometheus.CounterVec
+	routeRequestBytes  *prometheus.CounterVec
+	routeResponseBytes *prometheus.CounterVec
 }
 
 func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
@@ -44,6 +53,46 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 			},
 			[]string{"base"},
 		),
+		numRejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "calls_rejected",
+				Help:      "The number of calls this API has rejected due to the route's policy or resource pressure",
+			},
+			[]string{"reason"},
+		),
+		routeCalls: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "route_calls",
+				Help:      "The number of requests a chain's route has handled, by response status",
+			},
+			[]string{"chain", "route", "status"},
+		),
+		routeDuration: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "route_calls_duration",
+				Help:      "The total amount of time, in nanoseconds, a chain's route has spent handling requests",
+			},
+			[]string{"chain", "route"},
+		),
+		routeRequestBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "route_request_bytes",
+				Help:      "The total size, in bytes, of the request bodies a chain's route has read",
+			},
+			[]string{"chain", "route"},
+		),
+		routeResponseBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "route_response_bytes",
+				Help:      "The total size, in bytes, of the response bodies a chain's route has written",
+			},
+			[]string{"chain", "route"},
+		),
 	}
 
 	errs := wrappers.Errs{}
@@ -51,6 +100,11 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 		registerer.Register(m.numProcessing),
 		registerer.Register(m.numCalls),
 		registerer.Register(m.totalDuration),
+		registerer.Register(m.numRejected),
+		registerer.Register(m.routeCalls),
+		registerer.Register(m.routeDuration),
+		registerer.Register(m.routeRequestBytes),
+		registerer.Register(m.routeResponseBytes),
 	)
 	return m, errs.Err
 }
@@ -72,3 +126,44 @@ func (m *metrics) wrapHandler(chainName string, handler http.Handler) http.Handl
 		handler.ServeHTTP(w, r)
 	})
 }
+
+// wrapRoute records the latency, size and status of the requests handled by
+// [handler], which is registered by [chainName] at [route].
+func (m *metrics) wrapRoute(chainName, route string, handler http.Handler) http.Handler {
+	if route == "" {
+		route = "/"
+	}
+	duration := m.routeDuration.WithLabelValues(chainName, route)
+	requestBytes := m.routeRequestBytes.WithLabelValues(chainName, route)
+	responseBytes := m.routeResponseBytes.WithLabelValues(chainName, route)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := &countingReader{ReadCloser: r.Body}
+		r.Body = body
+		recorder := &statusRecorder{
+			ResponseWriter: w,
+			status:         http.StatusOK,
+		}
+		startTime := time.Now()
+
+		defer func() {
+			duration.Add(float64(time.Since(startTime)))
+			requestBytes.Add(float64(body.size))
+			responseBytes.Add(float64(recorder.size))
+			m.routeCalls.WithLabelValues(chainName, route, strconv.Itoa(recorder.status)).Inc()
+		}()
+
+		handler.ServeHTTP(recorder, r)
+	})
+}
+
+// countingReader counts the bytes read from the wrapped request body.
+type countingReader struct {
+	io.ReadCloser
+	size int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.size += n
+	return n, err
+}
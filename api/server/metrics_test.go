@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapRoute(t *testing.T) {
+	require := require.New(t)
+
+	m, err := newMetrics("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	const (
+		requestBody  = "request"
+		responseBody = "a longer response"
+	)
+	handler := m.wrapRoute("X", "", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(err)
+		require.Equal(requestBody, string(body))
+
+		w.WriteHeader(http.StatusTeapot)
+		_, err = w.Write([]byte(responseBody))
+		require.NoError(err)
+	}))
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(requestBody))
+		handler.ServeHTTP(w, r)
+		require.Equal(http.StatusTeapot, w.Code)
+	}
+
+	require.Equal(2.0, testutil.ToFloat64(m.routeCalls.WithLabelValues("X", "/", "418")))
+	require.Equal(0.0, testutil.ToFloat64(m.routeCalls.WithLabelValues("X", "/", "200")))
+	require.Equal(float64(2*len(requestBody)), testutil.ToFloat64(m.routeRequestBytes.WithLabelValues("X", "/")))
+	require.Equal(float64(2*len(responseBody)), testutil.ToFloat64(m.routeResponseBytes.WithLabelValues("X", "/")))
+	require.Positive(testutil.ToFloat64(m.routeDuration.WithLabelValues("X", "/")))
+}
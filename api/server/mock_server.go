@@ -131,6 +131,24 @@ func (mr *MockServerMockRecorder) RegisterChain(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterChain", reflect.TypeOf((*MockServer)(nil).RegisterChain), arg0, arg1, arg2)
 }
 
+// RemoveAliasesWithReadLock mocks base method.
+func (m *MockServer) RemoveAliasesWithReadLock(arg0 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveAliasesWithReadLock", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAliasesWithReadLock indicates an expected call of RemoveAliasesWithReadLock.
+func (mr *MockServerMockRecorder) RemoveAliasesWithReadLock(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAliasesWithReadLock", reflect.TypeOf((*MockServer)(nil).RemoveAliasesWithReadLock), arg0...)
+}
+
 // Shutdown mocks base method.
 func (m *MockServer) Shutdown() error {
 	m.ctrl.T.Helper()
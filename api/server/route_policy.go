@@ -0,0 +1,229 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rs/cors"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	originNotAllowed    = "origin_not_allowed"
+	requestBodyTooLarge = "request_body_too_large"
+)
+
+var (
+	errInvalidRoute               = errors.New("route must start with /")
+	errNegativeMaxRequestBodySize = errors.New("max request body size must be non-negative")
+
+	_ http.Handler  = (*policyHandler)(nil)
+	_ io.ReadCloser = (*limitedBody)(nil)
+)
+
+// RoutePolicy overrides the server-wide request restrictions for requests
+// whose path is prefixed by a route.
+type RoutePolicy struct {
+	// AllowedOrigins are the origins that are allowed to make cross-origin
+	// requests to the route. If nil, the server's allowed origins are used.
+	AllowedOrigins []string `json:"allowedOrigins"`
+	// MaxRequestBodySize is the maximum size, in bytes, of a request body sent
+	// to the route. If 0, the server's max request body size is used.
+	MaxRequestBodySize int64 `json:"maxRequestBodySize"`
+}
+
+// VerifyRoutePolicies returns an error if [policies] can't be applied by the
+// server.
+func VerifyRoutePolicies(policies map[string]RoutePolicy) error {
+	for route, policy := range policies {
+		if !strings.HasPrefix(route, "/") {
+			return fmt.Errorf("%w: %q", errInvalidRoute, route)
+		}
+		if policy.MaxRequestBodySize < 0 {
+			return fmt.Errorf("%w: %q", errNegativeMaxRequestBodySize, route)
+		}
+	}
+	return nil
+}
+
+// policy is the set of restrictions applied to a request.
+type policy struct {
+	// route is the path prefix this policy applies to. It is empty for the
+	// server-wide policy.
+	route              string
+	origins            originMatcher
+	maxRequestBodySize int64
+	// handler applies the CORS headers before calling the wrapped handler
+	handler http.Handler
+}
+
+// policyHandler applies the CORS policy and request body size limit of the
+// most specific route matching a request, or any of the aliases of its path.
+type policyHandler struct {
+	router *router
+	// routes are sorted from the most specific to the least specific
+	routes        []*policy
+	defaultPolicy *policy
+	rejected      *prometheus.CounterVec
+}
+
+func newPolicyHandler(
+	handler http.Handler,
+	router *router,
+	allowedOrigins []string,
+	maxRequestBodySize int64,
+	routes map[string]RoutePolicy,
+	rejected *prometheus.CounterVec,
+) http.Handler {
+	newPolicy := func(route string, allowedOrigins []string, maxRequestBodySize int64) *policy {
+		return &policy{
+			route:              route,
+			origins:            newOriginMatcher(allowedOrigins),
+			maxRequestBodySize: maxRequestBodySize,
+			handler: cors.New(cors.Options{
+				AllowedOrigins:   allowedOrigins,
+				AllowCredentials: true,
+			}).Handler(handler),
+		}
+	}
+
+	h := &policyHandler{
+		router:        router,
+		routes:        make([]*policy, 0, len(routes)),
+		defaultPolicy: newPolicy("", allowedOrigins, maxRequestBodySize),
+		rejected:      rejected,
+	}
+	for route, routePolicy := range routes {
+		origins := routePolicy.AllowedOrigins
+		if origins == nil {
+			origins = allowedOrigins
+		}
+		maxSize := routePolicy.MaxRequestBodySize
+		if maxSize == 0 {
+			maxSize = maxRequestBodySize
+		}
+		h.routes = append(h.routes, newPolicy(strings.TrimSuffix(route, "/"), origins, maxSize))
+	}
+	slices.SortFunc(h.routes, func(a, b *policy) int {
+		return len(b.route) - len(a.route)
+	})
+	return h
+}
+
+func (h *policyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := h.policy(r.URL.Path)
+
+	if origin := r.Header.Get("Origin"); origin != "" && !p.origins.allowed(origin) {
+		h.rejected.WithLabelValues(originNotAllowed).Inc()
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	if p.maxRequestBodySize > 0 {
+		if r.ContentLength > p.maxRequestBodySize {
+			h.rejected.WithLabelValues(requestBodyTooLarge).Inc()
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = &limitedBody{
+			ReadCloser: http.MaxBytesReader(w, r.Body, p.maxRequestBodySize),
+			onExceeded: h.rejected.WithLabelValues(requestBodyTooLarge).Inc,
+		}
+	}
+
+	p.handler.ServeHTTP(w, r)
+}
+
+// policy returns the policy of the most specific route that matches [path] or
+// one of its aliases.
+func (h *policyHandler) policy(path string) *policy {
+	paths := h.router.equivalentPaths(path)
+	for _, p := range h.routes {
+		for _, path := range paths {
+			if matchesRoute(path, p.route) {
+				return p
+			}
+		}
+	}
+	return h.defaultPolicy
+}
+
+// limitedBody reports when a request body was larger than allowed. This
+// happens when the request didn't specify a content length.
+type limitedBody struct {
+	io.ReadCloser
+	onExceeded func()
+	exceeded   bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if !b.exceeded && errors.As(err, &maxBytesErr) {
+		b.exceeded = true
+		b.onExceeded()
+	}
+	return n, err
+}
+
+type wildcardOrigin struct {
+	prefix string
+	suffix string
+}
+
+// originMatcher matches origins the same way as the CORS handler. Origins may
+// contain a single wildcard.
+type originMatcher struct {
+	allowAll  bool
+	origins   []string
+	wildcards []wildcardOrigin
+}
+
+func newOriginMatcher(allowedOrigins []string) originMatcher {
+	m := originMatcher{
+		allowAll: len(allowedOrigins) == 0,
+	}
+	for _, origin := range allowedOrigins {
+		origin = strings.ToLower(origin)
+		if origin == wildcard {
+			m.allowAll = true
+			break
+		}
+		if i := strings.IndexByte(origin, '*'); i >= 0 {
+			m.wildcards = append(m.wildcards, wildcardOrigin{
+				prefix: origin[:i],
+				suffix: origin[i+1:],
+			})
+			continue
+		}
+		m.origins = append(m.origins, origin)
+	}
+	return m
+}
+
+func (m originMatcher) allowed(origin string) bool {
+	if m.allowAll {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if slices.Contains(m.origins, origin) {
+		return true
+	}
+	for _, w := range m.wildcards {
+		if len(origin) >= len(w.prefix)+len(w.suffix) &&
+			strings.HasPrefix(origin, w.prefix) &&
+			strings.HasSuffix(origin, w.suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testChainRoute = "/ext/bc/2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM"
+
+func TestPolicyHandler(t *testing.T) {
+	router := newRouter()
+	require.NoError(t, router.AddAlias(testChainRoute, "/ext/bc/X"))
+
+	routes := map[string]RoutePolicy{
+		"/ext/bc/X": {
+			AllowedOrigins: []string{"https://*.avax.network"},
+		},
+		"/ext/bc/X/events": {
+			MaxRequestBodySize: 4,
+		},
+		"/ext/info/": {
+			AllowedOrigins:     []string{"https://wallet.example.com"},
+			MaxRequestBodySize: 16,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		origin         string
+		body           []byte
+		expectedStatus int
+		expectedReason string
+	}{
+		{
+			name:           "default policy",
+			path:           "/ext/health",
+			origin:         "https://evil.com",
+			body:           bytes.Repeat([]byte{0}, 8),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "default policy body too large",
+			path:           "/ext/health",
+			body:           bytes.Repeat([]byte{0}, 9),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedReason: requestBodyTooLarge,
+		},
+		{
+			name:           "wildcard origin allowed",
+			path:           "/ext/bc/X",
+			origin:         "https://explorer.avax.network",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "origin not allowed",
+			path:           "/ext/bc/X",
+			origin:         "https://evil.com",
+			expectedStatus: http.StatusForbidden,
+			expectedReason: originNotAllowed,
+		},
+		{
+			name:           "no origin",
+			path:           "/ext/bc/X",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "alias route applies to the chain ID",
+			path:           testChainRoute,
+			origin:         "https://evil.com",
+			expectedStatus: http.StatusForbidden,
+			expectedReason: originNotAllowed,
+		},
+		{
+			name:           "most specific alias route applies to the chain ID",
+			path:           testChainRoute + "/events",
+			origin:         "https://evil.com",
+			body:           bytes.Repeat([]byte{0}, 5),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedReason: requestBodyTooLarge,
+		},
+		{
+			name:           "route prefix must match a full path segment",
+			path:           "/ext/bc/XYZ",
+			origin:         "https://evil.com",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "most specific route applies",
+			path:           "/ext/bc/X/events",
+			origin:         "https://evil.com",
+			body:           bytes.Repeat([]byte{0}, 5),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedReason: requestBodyTooLarge,
+		},
+		{
+			name:           "route with trailing slash",
+			path:           "/ext/info",
+			origin:         "https://wallet.example.com",
+			body:           bytes.Repeat([]byte{0}, 16),
+			expectedStatus: http.StatusOK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			rejected := prometheus.NewCounterVec(prometheus.CounterOpts{}, []string{"reason"})
+			handler := &testHandler{}
+			h := newPolicyHandler(
+				handler,
+				router,
+				[]string{"*"},
+				8,
+				routes,
+				rejected,
+			)
+
+			r := httptest.NewRequest(http.MethodPost, test.path, bytes.NewReader(test.body))
+			if test.origin != "" {
+				r.Header.Set("Origin", test.origin)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			require.Equal(test.expectedStatus, w.Code)
+			require.Equal(test.expectedStatus == http.StatusOK, handler.called)
+			if test.expectedReason != "" {
+				require.Equal(1.0, testutil.ToFloat64(rejected.WithLabelValues(test.expectedReason)))
+			}
+		})
+	}
+}
+
+func TestPolicyHandlerUnknownContentLength(t *testing.T) {
+	require := require.New(t)
+
+	rejected := prometheus.NewCounterVec(prometheus.CounterOpts{}, []string{"reason"})
+	var readErr error
+	h := newPolicyHandler(
+		http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			_, readErr = io.ReadAll(r.Body)
+		}),
+		newRouter(),
+		[]string{"*"},
+		8,
+		nil,
+		rejected,
+	)
+
+	r := httptest.NewRequest(http.MethodPost, "/ext/health", bytes.NewReader(bytes.Repeat([]byte{0}, 9)))
+	r.ContentLength = -1
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	var maxBytesErr *http.MaxBytesError
+	require.ErrorAs(readErr, &maxBytesErr)
+	require.Equal(1.0, testutil.ToFloat64(rejected.WithLabelValues(requestBodyTooLarge)))
+}
+
+func TestVerifyRoutePolicies(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(VerifyRoutePolicies(map[string]RoutePolicy{
+		"/ext/bc/X": {MaxRequestBodySize: 1},
+	}))
+
+	err := VerifyRoutePolicies(map[string]RoutePolicy{
+		"ext/bc/X": {},
+	})
+	require.ErrorIs(err, errInvalidRoute)
+
+	err = VerifyRoutePolicies(map[string]RoutePolicy{
+		"/ext/bc/X": {MaxRequestBodySize: -1},
+	})
+	require.ErrorIs(err, errNegativeMaxRequestBodySize)
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
@@ -46,6 +48,32 @@ func (r *router) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	r.router.ServeHTTP(writer, request)
 }
 
+// equivalentPaths returns [path] and the paths that reach the same handler
+// through the aliases of the route [path] is under. For example, if
+// "/ext/bc/X" is an alias of "/ext/bc/<chainID>", then both
+// "/ext/bc/X/rpc" and "/ext/bc/<chainID>/rpc" are returned for either path.
+func (r *router) equivalentPaths(path string) []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for base, aliases := range r.aliases {
+		routes := append([]string{base}, aliases...)
+		for _, route := range routes {
+			if !matchesRoute(path, route) {
+				continue
+			}
+
+			subPath := path[len(route):]
+			paths := make([]string, len(routes))
+			for i, route := range routes {
+				paths[i] = route + subPath
+			}
+			return paths
+		}
+	}
+	return []string{path}
+}
+
 func (r *router) GetHandler(base, endpoint string) (http.Handler, error) {
 	r.routeLock.Lock()
 	defer r.routeLock.Unlock()
@@ -139,3 +167,45 @@ func (r *router) AddAlias(base string, aliases ...string) error {
 	}
 	return err
 }
+
+// RemoveAlias removes [aliases] and the routes that were added through them.
+// Aliases that don't exist are ignored.
+func (r *router) RemoveAlias(aliases ...string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.routeLock.Lock()
+	defer r.routeLock.Unlock()
+
+	removed := false
+	for base, baseAliases := range r.aliases {
+		remaining := baseAliases[:0]
+		for _, alias := range baseAliases {
+			if !slices.Contains(aliases, alias) {
+				remaining = append(remaining, alias)
+				continue
+			}
+			r.reservedRoutes.Remove(alias)
+			delete(r.routes, alias)
+			removed = true
+		}
+		r.aliases[base] = remaining
+	}
+	if !removed {
+		return nil
+	}
+
+	// Routes can't be removed from a mux.Router, so it is rebuilt from the
+	// remaining routes.
+	r.router = mux.NewRouter()
+	for base, endpoints := range r.routes {
+		for endpoint, handler := range endpoints {
+			url := base + endpoint
+			route := r.router.Handle(url, handler)
+			if route == nil {
+				return fmt.Errorf("failed to create new route for %s", url)
+			}
+			route.Name(url)
+		}
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ package server
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -58,3 +59,29 @@ func TestBlock(t *testing.T) {
 	err := r.AddRouter("1", "", handler1)
 	require.ErrorIs(err, errAlreadyReserved)
 }
+
+func TestRemoveAlias(t *testing.T) {
+	require := require.New(t)
+	r := newRouter()
+
+	handler1 := &testHandler{}
+	require.NoError(r.AddRouter("/1", "/rpc", handler1))
+	require.NoError(r.AddAlias("/1", "/2", "/3"))
+
+	require.NoError(r.RemoveAlias("/2", "/4"))
+
+	_, err := r.GetHandler("/2", "/rpc")
+	require.ErrorIs(err, errUnknownBaseURL)
+	handler, err := r.GetHandler("/3", "/rpc")
+	require.NoError(err)
+	require.Equal(handler1, handler)
+
+	// The removed alias is no longer served.
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/2/rpc", nil))
+	require.False(handler1.called)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/3/rpc", nil))
+	require.True(handler1.called)
+
+	// The alias can be reused once removed.
+	require.NoError(r.AddAlias("/1", "/2"))
+}
@@ -18,20 +18,28 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/rs/cors"
-
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/finality"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/version"
 )
 
-const baseURL = "/ext"
+const (
+	baseURL = "/ext"
+
+	// finalityEndpoint serves the finality certificates of chains that have
+	// them enabled.
+	finalityEndpoint = "/finality"
+)
 
 var (
 	errUnknownLockOption = errors.New("invalid lock options")
@@ -56,6 +64,10 @@ type PathAdderWithReadLock interface {
 	// AddAliasesWithReadLock registers aliases to the server assuming the http read
 	// lock is currently held.
 	AddAliasesWithReadLock(endpoint string, aliases ...string) error
+
+	// RemoveAliasesWithReadLock removes aliases from the server assuming the
+	// http read lock is currently held.
+	RemoveAliasesWithReadLock(aliases ...string) error
 }
 
 // Server maintains the HTTP router
@@ -77,6 +89,27 @@ type HTTPConfig struct {
 	ReadHeaderTimeout time.Duration `json:"readHeaderTimeout"`
 	WriteTimeout      time.Duration `json:"writeHeaderTimeout"`
 	IdleTimeout       time.Duration `json:"idleTimeout"`
+
+	// MaxRequestBodySize is the maximum size, in bytes, of a request body. If
+	// 0, the size of request bodies isn't limited.
+	MaxRequestBodySize int64 `json:"maxRequestBodySize"`
+	// RoutePolicies maps path prefixes, such as "/ext/bc/X", to the policy
+	// applied to requests to them. If multiple routes match a request, the
+	// longest one is used.
+	RoutePolicies map[string]RoutePolicy `json:"routePolicies"`
+	// MaxBatchSize is the maximum number of requests in a JSON-RPC batch. If
+	// 0, batches are passed to the API handlers unmodified. Batches sent to
+	// the C-Chain are always passed unmodified, as it handles them natively.
+	MaxBatchSize int `json:"maxBatchSize"`
+	// ChainMiddlewares maps chain IDs or aliases, such as "X", to the
+	// middlewares applied to the API handlers of the chain.
+	ChainMiddlewares map[string]ChainMiddlewareConfig `json:"chainMiddlewares"`
+	// SlowRequestLog specifies the requests that are logged to a dedicated
+	// log if they take too long to be handled.
+	SlowRequestLog SlowRequestLogConfig `json:"slowRequestLog"`
+	// LoadShedding specifies the requests that are rejected while the node is
+	// using too many resources.
+	LoadShedding LoadSheddingConfig `json:"loadShedding"`
 }
 
 type server struct {
@@ -92,6 +125,14 @@ type server struct {
 
 	metrics *metrics
 
+	// maxBatchSize is the maximum number of requests in a JSON-RPC batch
+	maxBatchSize int
+	// chainMiddlewares maps chain IDs or aliases to their middleware config
+	chainMiddlewares map[string]ChainMiddlewareConfig
+	// slowRequests logs slow requests. Nil if the slow request log is
+	// disabled.
+	slowRequests *slowRequestLogger
+
 	// Maps endpoints to handlers
 	router *router
 
@@ -115,6 +156,7 @@ func New(
 	registerer prometheus.Registerer,
 	httpConfig HTTPConfig,
 	allowedHosts []string,
+	cpu resource.CPUUser,
 	wrappers ...Wrapper,
 ) (Server, error) {
 	m, err := newMetrics(namespace, registerer)
@@ -122,17 +164,40 @@ func New(
 		return nil, err
 	}
 
+	var slowRequests *slowRequestLogger
+	if httpConfig.SlowRequestLog.Enabled() {
+		slowRequestLog, err := factory.Make(SlowRequestLogName)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create slow request log: %w", err)
+		}
+		slowRequests = newSlowRequestLogger(slowRequestLog, httpConfig.SlowRequestLog)
+	}
+
 	router := newRouter()
 	allowedHostsHandler := filterInvalidHosts(router, allowedHosts)
-	corsHandler := cors.New(cors.Options{
-		AllowedOrigins:   allowedOrigins,
-		AllowCredentials: true,
-	}).Handler(allowedHostsHandler)
-	gzipHandler := gziphandler.GzipHandler(corsHandler)
+	policyHandler := newPolicyHandler(
+		allowedHostsHandler,
+		router,
+		allowedOrigins,
+		httpConfig.MaxRequestBodySize,
+		httpConfig.RoutePolicies,
+		m.numRejected,
+	)
+	if httpConfig.LoadShedding.Enabled() {
+		policyHandler = newLoadShedder(
+			policyHandler,
+			router,
+			httpConfig.LoadShedding,
+			cpu,
+			m.numRejected,
+		)
+	}
+	gzipHandler := gziphandler.GzipHandler(policyHandler)
 	var handler http.Handler = http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
-			// Attach this node's ID as a header
+			// Attach this node's ID and version as headers
 			w.Header().Set("node-id", nodeID.String())
+			w.Header().Set(rpc.NodeVersionHeader, version.CurrentApp.String())
 			gzipHandler.ServeHTTP(w, r)
 		},
 	)
@@ -146,13 +211,16 @@ func New(
 	)
 
 	return &server{
-		log:             log,
-		factory:         factory,
-		shutdownTimeout: shutdownTimeout,
-		tracingEnabled:  tracingEnabled,
-		tracer:          tracer,
-		metrics:         m,
-		router:          router,
+		log:              log,
+		factory:          factory,
+		shutdownTimeout:  shutdownTimeout,
+		tracingEnabled:   tracingEnabled,
+		tracer:           tracer,
+		metrics:          m,
+		maxBatchSize:     httpConfig.MaxBatchSize,
+		chainMiddlewares: httpConfig.ChainMiddlewares,
+		slowRequests:     slowRequests,
+		router:           router,
 		srv: &http.Server{
 			Handler:           handler,
 			ReadTimeout:       httpConfig.ReadTimeout,
@@ -191,6 +259,8 @@ func (s *server) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 	// all subroutes to a chain begin with "bc/<the chain's ID>"
 	defaultEndpoint := path.Join(constants.ChainAliasPrefix, ctx.ChainID.String())
 
+	middlewares := s.getChainMiddlewares(chainName, ctx)
+
 	// Register each endpoint
 	for extension, handler := range handlers {
 		// Validate that the route being added is valid
@@ -203,15 +273,66 @@ func (s *server) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 			)
 			continue
 		}
-		if err := s.addChainRoute(chainName, handler, ctx, defaultEndpoint, extension); err != nil {
+		if err := s.addChainRoute(chainName, handler, ctx, defaultEndpoint, extension, middlewares); err != nil {
 			s.log.Error("error adding route",
 				zap.Error(err),
 			)
 		}
 	}
+
+	if ctx.Finality == nil {
+		return
+	}
+	finalityHandler, err := finality.NewHandler(ctx.Log, ctx.Finality)
+	if err != nil {
+		s.log.Error("failed to create finality handler",
+			zap.String("chainName", chainName),
+			zap.Error(err),
+		)
+		return
+	}
+	handler := &common.HTTPHandler{
+		LockOptions: common.NoLock,
+		Handler:     finalityHandler,
+	}
+	if err := s.addChainRoute(chainName, handler, ctx, defaultEndpoint, finalityEndpoint, middlewares); err != nil {
+		s.log.Error("error adding route",
+			zap.Error(err),
+		)
+	}
+}
+
+// getChainMiddlewares returns the middlewares configured for the chain. The
+// chain's ID takes precedence over its aliases.
+func (s *server) getChainMiddlewares(chainName string, ctx *snow.ConsensusContext) []Middleware {
+	if len(s.chainMiddlewares) == 0 {
+		return nil
+	}
+
+	// The error is ignored because a chain without aliases can still be
+	// configured by its ID.
+	aliases, _ := ctx.BCLookup.Aliases(ctx.ChainID)
+	for _, name := range append([]string{ctx.ChainID.String()}, aliases...) {
+		config, ok := s.chainMiddlewares[name]
+		if !ok {
+			continue
+		}
+		s.log.Info("applying chain API middlewares",
+			zap.String("chainName", chainName),
+			zap.String("configuredAs", name),
+		)
+		return newChainMiddlewares(s.log, chainName, config)
+	}
+	return nil
 }
 
-func (s *server) addChainRoute(chainName string, handler *common.HTTPHandler, ctx *snow.ConsensusContext, base, endpoint string) error {
+func (s *server) addChainRoute(
+	chainName string,
+	handler *common.HTTPHandler,
+	ctx *snow.ConsensusContext,
+	base, endpoint string,
+	middlewares []Middleware,
+) error {
 	url := fmt.Sprintf("%s/%s", baseURL, base)
 	s.log.Info("adding route",
 		zap.String("url", url),
@@ -236,7 +357,19 @@ func (s *server) addChainRoute(chainName string, handler *common.HTTPHandler, ct
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	h = rejectMiddleware(h, ctx)
+	// Apply the configured middlewares before the chain's lock is grabbed so
+	// that rejected requests don't contend for it
+	h = applyMiddlewares(h, middlewares)
+	h = s.slowRequests.wrapHandler(chainName, h)
 	h = s.metrics.wrapHandler(chainName, h)
+	// The C-Chain serves JSON-RPC batches itself, and enforces its own limits
+	// on them, so its batches aren't split.
+	if ctx.ChainID != ctx.CChainID {
+		h = newBatchHandler(h, s.maxBatchSize)
+	}
+	// Apply the route's metrics last so that they account for the whole
+	// request, including every call of a batch
+	h = s.metrics.wrapRoute(chainName, endpoint, h)
 	return s.router.AddRouter(url, endpoint, h)
 }
 
@@ -275,7 +408,9 @@ func (s *server) addRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base,
 	if err != nil {
 		return err
 	}
+	h = s.slowRequests.wrapHandler(base, h)
 	h = s.metrics.wrapHandler(base, h)
+	h = newBatchHandler(h, s.maxBatchSize)
 	return s.router.AddRouter(url, endpoint, h)
 }
 
@@ -324,7 +459,7 @@ func lockMiddleware(
 func rejectMiddleware(handler http.Handler, ctx *snow.ConsensusContext) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { // If chain isn't done bootstrapping, ignore API calls
 		if ctx.State.Get().State != snow.NormalOp {
-			http.Error(w, "API call rejected because chain is not done bootstrapping", http.StatusServiceUnavailable)
+			http.Error(w, rpc.NotBootstrappedMessage, http.StatusServiceUnavailable)
 		} else {
 			handler.ServeHTTP(w, r)
 		}
@@ -350,6 +485,24 @@ func (s *server) AddAliasesWithReadLock(endpoint string, aliases ...string) erro
 	return s.AddAliases(endpoint, aliases...)
 }
 
+func (s *server) RemoveAliases(aliases ...string) error {
+	endpoints := make([]string, len(aliases))
+	for i, alias := range aliases {
+		endpoints[i] = fmt.Sprintf("%s/%s", baseURL, alias)
+	}
+	return s.router.RemoveAlias(endpoints...)
+}
+
+func (s *server) RemoveAliasesWithReadLock(aliases ...string) error {
+	// This is safe, as the read lock doesn't actually need to be held once the
+	// http handler is called. However, it is unlocked later, so this function
+	// must end with the lock held.
+	s.router.lock.RUnlock()
+	defer s.router.lock.RLock()
+
+	return s.RemoveAliases(aliases...)
+}
+
 func (s *server) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	err := s.srv.Shutdown(ctx)
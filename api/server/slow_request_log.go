@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// SlowRequestLogName is the name of the log slow requests are written to.
+const SlowRequestLogName = "slow-requests"
+
+var errNegativeSlowRequestThreshold = errors.New("slow request threshold must be non-negative")
+
+// SlowRequestLogConfig specifies which requests are logged to the slow request
+// log.
+type SlowRequestLogConfig struct {
+	// Threshold is the duration after which a request is logged. If 0, only
+	// the requests with an entry in Thresholds are logged.
+	Threshold time.Duration `json:"threshold"`
+	// Thresholds overrides Threshold for calls to a JSON-RPC method, such as
+	// "avm.getUTXOs", or requests to a route, such as "/ext/bc/X". Method
+	// thresholds take precedence over route thresholds. If multiple routes
+	// match a request, the longest one is used. A threshold of 0 disables the
+	// log for the method or route.
+	Thresholds map[string]time.Duration `json:"thresholds"`
+}
+
+// Enabled returns true if any request can be logged.
+func (c *SlowRequestLogConfig) Enabled() bool {
+	return c.Threshold > 0 || len(c.Thresholds) > 0
+}
+
+// Verify returns an error if [c] can't be applied by the server.
+func (c *SlowRequestLogConfig) Verify() error {
+	if c.Threshold < 0 {
+		return errNegativeSlowRequestThreshold
+	}
+	for key, threshold := range c.Thresholds {
+		if threshold < 0 {
+			return fmt.Errorf("%w: %q", errNegativeSlowRequestThreshold, key)
+		}
+	}
+	return nil
+}
+
+// slowRequestLogger logs the requests that took longer than their threshold
+// to be handled.
+type slowRequestLogger struct {
+	log              logging.Logger
+	threshold        time.Duration
+	methodThresholds map[string]time.Duration
+	routeThresholds  map[string]time.Duration
+	// routes are sorted from the most specific to the least specific
+	routes []string
+}
+
+func newSlowRequestLogger(log logging.Logger, config SlowRequestLogConfig) *slowRequestLogger {
+	l := &slowRequestLogger{
+		log:              log,
+		threshold:        config.Threshold,
+		methodThresholds: make(map[string]time.Duration),
+		routeThresholds:  make(map[string]time.Duration),
+	}
+	for key, threshold := range config.Thresholds {
+		if !strings.HasPrefix(key, "/") {
+			l.methodThresholds[key] = threshold
+			continue
+		}
+		route := strings.TrimSuffix(key, "/")
+		l.routeThresholds[route] = threshold
+	}
+	l.routes = maps.Keys(l.routeThresholds)
+	slices.SortFunc(l.routes, func(a, b string) int {
+		return len(b) - len(a)
+	})
+	return l
+}
+
+// wrapHandler logs the requests handled by [handler] that are too slow. If
+// [l] is nil, [handler] is returned unmodified.
+func (l *slowRequestLogger) wrapHandler(chainName string, handler http.Handler) http.Handler {
+	if l == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := readJSONRPCRequest(r)
+		recorder := &statusRecorder{
+			ResponseWriter: w,
+			status:         http.StatusOK,
+		}
+		start := time.Now()
+		handler.ServeHTTP(recorder, r)
+		duration := time.Since(start)
+
+		threshold := l.getThreshold(r.URL.Path, request.Method)
+		if threshold == 0 || duration < threshold {
+			return
+		}
+
+		l.log.Warn("slow API request",
+			zap.String("chain", chainName),
+			zap.String("path", r.URL.Path),
+			zap.String("method", request.Method),
+			zap.Int("paramsSize", len(request.Params)),
+			zap.String("params", summarizeParams(request.Params)),
+			zap.String("remoteAddr", r.RemoteAddr),
+			zap.Int("status", recorder.status),
+			zap.Duration("duration", duration),
+			zap.Duration("threshold", threshold),
+		)
+	})
+}
+
+// getThreshold returns the threshold of a call to [method] on [path]. Returns
+// 0 if the request shouldn't be logged.
+func (l *slowRequestLogger) getThreshold(path, method string) time.Duration {
+	if threshold, ok := l.methodThresholds[method]; ok {
+		return threshold
+	}
+	for _, route := range l.routes {
+		if path == route || strings.HasPrefix(path, route+"/") {
+			return l.routeThresholds[route]
+		}
+	}
+	return l.threshold
+}
+
+// summarizeParams returns the shape of [params]: the names of the fields of
+// objects, sorted by name, the lengths of arrays and the types of the other
+// values. Param values may be sensitive, so they are never included.
+func summarizeParams(params json.RawMessage) string {
+	if len(params) == 0 {
+		return ""
+	}
+
+	var args []interface{}
+	if err := json.Unmarshal(params, &args); err != nil {
+		// JSON-RPC params are either an array or an object. A single object
+		// is the common case for the APIs of the node.
+		args = []interface{}{nil}
+		if err := json.Unmarshal(params, &args[0]); err != nil {
+			return "<unparsable>"
+		}
+	}
+
+	summaries := make([]string, len(args))
+	for i, arg := range args {
+		summaries[i] = summarizeParam(arg)
+	}
+	return strings.Join(summaries, ", ")
+}
+
+func summarizeParam(value interface{}) string {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		fields := maps.Keys(value)
+		sort.Strings(fields)
+		summaries := make([]string, len(fields))
+		for i, field := range fields {
+			summaries[i] = field + ": " + summarizeParam(value[field])
+		}
+		return "{" + strings.Join(summaries, ", ") + "}"
+	case []interface{}:
+		return fmt.Sprintf("[%d items]", len(value))
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestSlowRequestLoggerGetThreshold(t *testing.T) {
+	l := newSlowRequestLogger(logging.NoLog{}, SlowRequestLogConfig{
+		Threshold: time.Second,
+		Thresholds: map[string]time.Duration{
+			"avm.getUTXOs":      2 * time.Second,
+			"/ext/bc/X":         3 * time.Second,
+			"/ext/bc/X/events/": 4 * time.Second,
+			"/ext/info":         0,
+		},
+	})
+
+	tests := []struct {
+		name              string
+		path              string
+		method            string
+		expectedThreshold time.Duration
+	}{
+		{
+			name:              "default",
+			path:              "/ext/bc/P",
+			method:            "platform.getHeight",
+			expectedThreshold: time.Second,
+		},
+		{
+			name:              "method",
+			path:              "/ext/bc/X",
+			method:            "avm.getUTXOs",
+			expectedThreshold: 2 * time.Second,
+		},
+		{
+			name:              "route",
+			path:              "/ext/bc/X",
+			method:            "avm.getTx",
+			expectedThreshold: 3 * time.Second,
+		},
+		{
+			name:              "longest route",
+			path:              "/ext/bc/X/events",
+			expectedThreshold: 4 * time.Second,
+		},
+		{
+			name:              "route prefix isn't a path prefix",
+			path:              "/ext/bc/XYZ",
+			expectedThreshold: time.Second,
+		},
+		{
+			name:              "disabled route",
+			path:              "/ext/info",
+			method:            "info.getNodeID",
+			expectedThreshold: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedThreshold, l.getThreshold(test.path, test.method))
+		})
+	}
+}
+
+func TestSlowRequestLoggerWrapHandler(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	log := logging.NewMockLogger(ctrl)
+	l := newSlowRequestLogger(log, SlowRequestLogConfig{
+		Thresholds: map[string]time.Duration{
+			"echo.echo": time.Nanosecond,
+		},
+	})
+	handler := l.wrapHandler("X", newEchoHandler(t, 0))
+
+	// Requests without a threshold aren't logged.
+	w := serveJSON(handler, `{"jsonrpc":"2.0","method":"echo.unknown","id":1}`)
+	require.Equal(http.StatusOK, w.Code)
+
+	log.EXPECT().Warn("slow API request", gomock.Any()).Times(1)
+	w = serveJSON(handler, `{"jsonrpc":"2.0","method":"echo.echo","params":{"value":2},"id":1}`)
+	require.Equal(http.StatusOK, w.Code)
+	require.Contains(w.Body.String(), `"result":{"value":2}`)
+
+	// The slow request log is disabled.
+	l = nil
+	handler = newEchoHandler(t, 0)
+	require.Equal(handler, l.wrapHandler("X", handler))
+}
+
+func TestSummarizeParams(t *testing.T) {
+	tests := []struct {
+		name            string
+		params          string
+		expectedSummary string
+	}{
+		{
+			name:            "no params",
+			params:          "",
+			expectedSummary: "",
+		},
+		{
+			name:            "object",
+			params:          `{"txID":"abc","limit":10,"addresses":["a","b"],"encoding":null,"verbose":true}`,
+			expectedSummary: `{addresses: [2 items], encoding: null, limit: number, txID: string, verbose: bool}`,
+		},
+		{
+			name:            "nested object",
+			params:          `{"username":"bob","password":"hunter2","user":{"authToken":"t"}}`,
+			expectedSummary: `{password: string, user: {authToken: string}, username: string}`,
+		},
+		{
+			name:            "array",
+			params:          `["abc",{"id":1}]`,
+			expectedSummary: `string, {id: number}`,
+		},
+		{
+			name:            "unparsable",
+			params:          `{`,
+			expectedSummary: "<unparsable>",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedSummary, summarizeParams(json.RawMessage(test.params)))
+		})
+	}
+}
+
+func TestVerifySlowRequestLogConfig(t *testing.T) {
+	require := require.New(t)
+
+	config := SlowRequestLogConfig{}
+	require.False(config.Enabled())
+	require.NoError(config.Verify())
+
+	config.Threshold = -time.Second
+	err := config.Verify()
+	require.ErrorIs(err, errNegativeSlowRequestThreshold)
+
+	config = SlowRequestLogConfig{
+		Thresholds: map[string]time.Duration{
+			"/ext/bc/X": -time.Second,
+		},
+	}
+	require.True(config.Enabled())
+	err = config.Verify()
+	require.ErrorIs(err, errNegativeSlowRequestThreshold)
+}
@@ -176,6 +176,7 @@ func (a *app) Start() error {
 	}
 
 	mapper := nat.NewPortMapper(log, a.config.Nat)
+	a.config.PortMapper = mapper
 
 	// Open staking port we want for NAT traversal to have the external port
 	// (config.IP.Port) to connect to our internal listening port
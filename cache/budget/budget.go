@@ -0,0 +1,336 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package budget
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// minCacheSize is the smallest capacity a cache is ever resized to.
+	minCacheSize = 64 * units.KiB
+
+	// maxReservedPortion is the largest portion of the budget that can be
+	// reserved by caches that can't be resized.
+	maxReservedPortion = 0.5
+
+	// fullThreshold is the portion of its capacity a cache must fill to be
+	// considered limited by its capacity. Caches filled less than this give up
+	// part of their capacity.
+	fullThreshold = 0.9
+
+	// minDemandPortion bounds how much a cache can shrink relative to the
+	// share of the budget it would get without adapting to its usage.
+	minDemandPortion = 0.1
+
+	// minScale is the smallest portion of the budget that is allocated while
+	// the garbage collector is under pressure.
+	minScale = 0.5
+	// scaleDownFactor is applied to the allocated portion of the budget every
+	// rebalance during which the garbage collector is under pressure.
+	scaleDownFactor = 0.9
+	// scaleUpStep is added to the allocated portion of the budget every
+	// rebalance during which the garbage collector isn't under pressure.
+	scaleUpStep = 0.05
+)
+
+var (
+	errDuplicateCache = errors.New("duplicate cache")
+	errNoBudget       = errors.New("budget must be positive")
+)
+
+type Config struct {
+	// Size is the number of bytes shared by all of the caches of the node.
+	Size uint64 `json:"size"`
+	// RebalanceFrequency is how often the capacity of the caches is adjusted
+	// to their usage. If 0, the caches keep the share of the budget they were
+	// initially given.
+	RebalanceFrequency time.Duration `json:"rebalanceFrequency"`
+	// MaxGCCPUFraction is the portion of CPU time spent by the garbage
+	// collector above which the caches are shrunk to relieve memory pressure.
+	MaxGCCPUFraction float64 `json:"maxGCCPUFraction"`
+}
+
+// resizable is a cache whose capacity is managed by a Manager.
+type resizable interface {
+	Resize(maxSize int)
+	PortionFilled() float64
+	// stats returns the number of hits and misses of the cache since its
+	// creation.
+	stats() (hits uint64, misses uint64)
+}
+
+type entry struct {
+	name string
+	// weight is the capacity the cache would have without a budget. The
+	// budget is split between the caches proportionally to their weight until
+	// their usage is known.
+	weight float64
+	// demand is the weight of the cache adjusted to its recent usage.
+	demand float64
+	cache  resizable
+
+	lastHits   uint64
+	lastMisses uint64
+}
+
+// Manager allocates the capacity of caches from a single memory budget.
+//
+// Caches are initially given a share of the budget proportional to the
+// capacity they would have without a budget. Every rebalance, caches that are
+// filled to capacity and miss often are grown at the expense of caches that
+// don't use their capacity. The whole budget is shrunk while the garbage
+// collector is under pressure.
+type Manager struct {
+	log     logging.Logger
+	config  Config
+	metrics *metrics
+	// gcCPUFraction returns the portion of CPU time spent by the garbage
+	// collector since it was last called.
+	gcCPUFraction func() float64
+
+	lock     sync.Mutex
+	names    map[string]struct{}
+	caches   []*entry
+	reserved uint64
+	// scale is the portion of the unreserved budget that is allocated to the
+	// caches.
+	scale float64
+
+	closeOnce sync.Once
+	onClose   chan struct{}
+}
+
+// New returns a manager of [config.Size] bytes that rebalances the caches
+// every [config.RebalanceFrequency] until it is shut down.
+func New(
+	log logging.Logger,
+	namespace string,
+	registerer prometheus.Registerer,
+	config Config,
+) (*Manager, error) {
+	if config.Size == 0 {
+		return nil, errNoBudget
+	}
+	metrics, err := newMetrics(namespace, registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{
+		log:           log,
+		config:        config,
+		metrics:       metrics,
+		gcCPUFraction: newGCMonitor().CPUFraction,
+		names:         make(map[string]struct{}),
+		scale:         1,
+		onClose:       make(chan struct{}),
+	}
+	m.metrics.available.Set(float64(config.Size))
+	if config.RebalanceFrequency > 0 {
+		go log.RecoverAndPanic(m.dispatch)
+	}
+	return m, nil
+}
+
+// Reserve takes up to [size] bytes off the budget for a cache named [name]
+// that can't be resized, and returns the number of bytes reserved.
+//
+// At most half of the budget can be reserved.
+func (m *Manager) Reserve(name string, size uint64) (uint64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.addName(name); err != nil {
+		return 0, err
+	}
+
+	maxReserved := uint64(float64(m.config.Size) * maxReservedPortion)
+	if remaining := maxReserved - m.reserved; size > remaining {
+		size = remaining
+	}
+	m.reserved += size
+	m.metrics.size.WithLabelValues(name).Set(float64(size))
+
+	m.allocate()
+	return size, nil
+}
+
+// Shutdown stops rebalancing the caches. The caches keep their current
+// capacity.
+func (m *Manager) Shutdown() {
+	m.closeOnce.Do(func() {
+		close(m.onClose)
+	})
+}
+
+// register adds [cache], named [name], to the caches sharing the budget and
+// resizes all of the caches accordingly.
+func (m *Manager) register(name string, weight int, cache resizable) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.addName(name); err != nil {
+		return err
+	}
+
+	m.caches = append(m.caches, &entry{
+		name:   name,
+		weight: float64(weight),
+		demand: float64(weight),
+		cache:  cache,
+	})
+	m.normalizeDemands()
+	m.allocate()
+	return nil
+}
+
+// Unregister removes the cache named [name] from the caches sharing the
+// budget, and gives its share of the budget to the remaining caches. The
+// cache keeps its current capacity. Unregister should be called once the
+// owner of the cache stops using it, such as when its chain is shut down.
+//
+// If there isn't a resizable cache named [name], Unregister does nothing.
+func (m *Manager) Unregister(name string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for i, e := range m.caches {
+		if e.name != name {
+			continue
+		}
+
+		lastIndex := len(m.caches) - 1
+		m.caches[i] = m.caches[lastIndex]
+		m.caches[lastIndex] = nil
+		m.caches = m.caches[:lastIndex]
+		delete(m.names, name)
+		m.metrics.size.DeleteLabelValues(name)
+
+		m.normalizeDemands()
+		m.allocate()
+		return
+	}
+}
+
+func (m *Manager) addName(name string) error {
+	if _, ok := m.names[name]; ok {
+		return fmt.Errorf("%w: %s", errDuplicateCache, name)
+	}
+	m.names[name] = struct{}{}
+	return nil
+}
+
+func (m *Manager) dispatch() {
+	ticker := time.NewTicker(m.config.RebalanceFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.rebalance()
+		case <-m.onClose:
+			return
+		}
+	}
+}
+
+// rebalance adjusts the share of the budget of every cache to its usage since
+// the last rebalance, and the size of the budget to the pressure on the
+// garbage collector.
+func (m *Manager) rebalance() {
+	gcCPUFraction := m.gcCPUFraction()
+	m.metrics.gcCPUFraction.Set(gcCPUFraction)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.config.MaxGCCPUFraction > 0 && gcCPUFraction > m.config.MaxGCCPUFraction {
+		m.scale = math.Max(m.scale*scaleDownFactor, minScale)
+		m.log.Debug("shrinking cache budget due to GC pressure",
+			zap.Float64("gcCPUFraction", gcCPUFraction),
+			zap.Float64("scale", m.scale),
+		)
+	} else {
+		m.scale = math.Min(m.scale+scaleUpStep, 1)
+	}
+
+	for _, e := range m.caches {
+		hits, misses := e.cache.stats()
+		newHits := hits - e.lastHits
+		newMisses := misses - e.lastMisses
+		e.lastHits = hits
+		e.lastMisses = misses
+
+		// Without any requests, there is nothing to adapt to.
+		requests := newHits + newMisses
+		if requests == 0 {
+			continue
+		}
+
+		var target float64
+		if portionFilled := e.cache.PortionFilled(); portionFilled < fullThreshold {
+			// The cache doesn't use its capacity, so it gives up the part of
+			// it that it doesn't need.
+			target = e.demand * portionFilled / fullThreshold
+		} else {
+			// The cache is limited by its capacity, so it grows with the
+			// portion of requests it couldn't serve.
+			target = e.demand * (1 + float64(newMisses)/float64(requests))
+		}
+		// Smooth the demand to avoid resizing the caches back and forth.
+		e.demand = math.Max((e.demand+target)/2, e.weight*minDemandPortion)
+	}
+	m.normalizeDemands()
+	m.allocate()
+}
+
+// normalizeDemands scales the demands of the caches so that they sum to the
+// sum of their weights, which keeps them bounded across rebalances.
+func (m *Manager) normalizeDemands() {
+	var totalWeight, totalDemand float64
+	for _, e := range m.caches {
+		totalWeight += e.weight
+		totalDemand += e.demand
+	}
+	if totalDemand == 0 {
+		return
+	}
+	for _, e := range m.caches {
+		e.demand *= totalWeight / totalDemand
+	}
+}
+
+// allocate resizes every cache to its share of the budget.
+//
+// Assumes [m.lock] is held.
+func (m *Manager) allocate() {
+	available := float64(m.config.Size-m.reserved) * m.scale
+	m.metrics.available.Set(available)
+
+	var totalDemand float64
+	for _, e := range m.caches {
+		totalDemand += e.demand
+	}
+	if totalDemand == 0 {
+		return
+	}
+
+	for _, e := range m.caches {
+		size := math.Max(int(available*e.demand/totalDemand), minCacheSize)
+		e.cache.Resize(size)
+		m.metrics.size.WithLabelValues(e.name).Set(float64(size))
+	}
+}
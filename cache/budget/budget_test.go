@@ -0,0 +1,214 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package budget
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+var _ resizable = (*testCache)(nil)
+
+// testCache records the capacity it is given and reports the usage set by
+// the test.
+type testCache struct {
+	maxSize       int
+	portionFilled float64
+	hits          uint64
+	misses        uint64
+}
+
+func (c *testCache) Resize(maxSize int) {
+	c.maxSize = maxSize
+}
+
+func (c *testCache) PortionFilled() float64 {
+	return c.portionFilled
+}
+
+func (c *testCache) stats() (uint64, uint64) {
+	return c.hits, c.misses
+}
+
+func newTestManager(t *testing.T, size uint64) *Manager {
+	m, err := New(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		Config{
+			Size:             size,
+			MaxGCCPUFraction: .1,
+		},
+	)
+	require.NoError(t, err)
+	m.gcCPUFraction = func() float64 { return 0 }
+	return m
+}
+
+func TestNewRequiresBudget(t *testing.T) {
+	_, err := New(logging.NoLog{}, "", prometheus.NewRegistry(), Config{})
+	require.ErrorIs(t, err, errNoBudget)
+}
+
+func TestSplitByWeight(t *testing.T) {
+	require := require.New(t)
+
+	m := newTestManager(t, 16*units.MiB)
+
+	c0 := &testCache{}
+	require.NoError(m.register("c0", units.MiB, c0))
+	require.Equal(16*units.MiB, c0.maxSize)
+
+	c1 := &testCache{}
+	require.NoError(m.register("c1", 3*units.MiB, c1))
+	require.Equal(4*units.MiB, c0.maxSize)
+	require.Equal(12*units.MiB, c1.maxSize)
+
+	err := m.register("c1", units.MiB, &testCache{})
+	require.ErrorIs(err, errDuplicateCache)
+}
+
+func TestUnregister(t *testing.T) {
+	require := require.New(t)
+
+	m := newTestManager(t, 16*units.MiB)
+
+	c0 := &testCache{}
+	require.NoError(m.register("c0", units.MiB, c0))
+	c1 := &testCache{}
+	require.NoError(m.register("c1", 3*units.MiB, c1))
+	require.Equal(4*units.MiB, c0.maxSize)
+
+	// The share of [c1] is given back to [c0].
+	m.Unregister("c1")
+	require.Equal(16*units.MiB, c0.maxSize)
+	require.Equal(12*units.MiB, c1.maxSize)
+
+	// Unknown and reserved caches are ignored.
+	m.Unregister("c1")
+	_, err := m.Reserve("db", 4*units.MiB)
+	require.NoError(err)
+	m.Unregister("db")
+	require.Equal(12*units.MiB, c0.maxSize)
+
+	// Once unregistered, the name can be used by a new cache.
+	require.NoError(m.register("c1", units.MiB, c1))
+	require.Equal(6*units.MiB, c0.maxSize)
+	require.Equal(6*units.MiB, c1.maxSize)
+}
+
+func TestReserve(t *testing.T) {
+	require := require.New(t)
+
+	m := newTestManager(t, 16*units.MiB)
+
+	c := &testCache{}
+	require.NoError(m.register("c", units.MiB, c))
+
+	reserved, err := m.Reserve("db", 4*units.MiB)
+	require.NoError(err)
+	require.Equal(uint64(4*units.MiB), reserved)
+	require.Equal(12*units.MiB, c.maxSize)
+
+	// At most half of the budget can be reserved.
+	reserved, err = m.Reserve("other", 8*units.MiB)
+	require.NoError(err)
+	require.Equal(uint64(4*units.MiB), reserved)
+	require.Equal(8*units.MiB, c.maxSize)
+
+	_, err = m.Reserve("db", units.MiB)
+	require.ErrorIs(err, errDuplicateCache)
+}
+
+func TestRebalance(t *testing.T) {
+	require := require.New(t)
+
+	m := newTestManager(t, 16*units.MiB)
+
+	busy := &testCache{}
+	require.NoError(m.register("busy", units.MiB, busy))
+	idle := &testCache{}
+	require.NoError(m.register("idle", units.MiB, idle))
+	unused := &testCache{}
+	require.NoError(m.register("unused", 2*units.MiB, unused))
+	require.Equal(4*units.MiB, busy.maxSize)
+	require.Equal(4*units.MiB, idle.maxSize)
+	require.Equal(8*units.MiB, unused.maxSize)
+
+	// [busy] is full and misses often, [idle] is barely used and [unused]
+	// isn't requested at all.
+	for i := 0; i < 5; i++ {
+		busy.portionFilled = 1
+		busy.hits += 10
+		busy.misses += 90
+		idle.portionFilled = .1
+		idle.hits += 100
+
+		m.rebalance()
+	}
+	require.Greater(busy.maxSize, 4*units.MiB)
+	require.Less(idle.maxSize, 4*units.MiB)
+	require.LessOrEqual(busy.maxSize+idle.maxSize+unused.maxSize, 16*units.MiB)
+
+	require.GreaterOrEqual(idle.maxSize, minCacheSize)
+}
+
+func TestRebalanceGCPressure(t *testing.T) {
+	require := require.New(t)
+
+	m := newTestManager(t, 16*units.MiB)
+
+	c := &testCache{}
+	require.NoError(m.register("c", units.MiB, c))
+
+	m.gcCPUFraction = func() float64 { return .5 }
+	for i := 0; i < 100; i++ {
+		m.rebalance()
+	}
+	require.Equal(8*units.MiB, c.maxSize)
+
+	m.gcCPUFraction = func() float64 { return 0 }
+	for i := 0; i < 100; i++ {
+		m.rebalance()
+	}
+	require.Equal(16*units.MiB, c.maxSize)
+}
+
+func TestNewSizedLRU(t *testing.T) {
+	require := require.New(t)
+
+	// Without a budget, the cache has its default size.
+	c, err := NewSizedLRU[ids.ID, int64](nil, "", cache.TestIntSize, cache.TestIntSizeFunc)
+	require.NoError(err)
+	cache.TestBasic(t, c)
+
+	// With a budget, the cache is given the whole budget.
+	m := newTestManager(t, units.MiB)
+	c, err = NewSizedLRU[ids.ID, int64](m, "c", cache.TestIntSize, cache.TestIntSizeFunc)
+	require.NoError(err)
+
+	id1 := ids.GenerateTestID()
+	id2 := ids.GenerateTestID()
+	c.Put(id1, 1)
+	c.Put(id2, 2)
+	require.Equal(2, c.Len())
+	require.Equal(float64(2*cache.TestIntSize)/units.MiB, c.PortionFilled())
+
+	_, ok := c.Get(id1)
+	require.True(ok)
+	_, ok = c.Get(ids.GenerateTestID())
+	require.False(ok)
+
+	hits, misses := c.(*sizedLRU[ids.ID, int64]).stats()
+	require.Equal(uint64(1), hits)
+	require.Equal(uint64(1), misses)
+}
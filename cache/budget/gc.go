@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package budget
+
+import (
+	runtimemetrics "runtime/metrics"
+)
+
+const (
+	gcCPUMetric    = "/cpu/classes/gc/total:cpu-seconds"
+	totalCPUMetric = "/cpu/classes/total:cpu-seconds"
+)
+
+// gcMonitor measures the portion of CPU time spent by the garbage collector.
+type gcMonitor struct {
+	samples  []runtimemetrics.Sample
+	lastGC   float64
+	lastUsed float64
+}
+
+func newGCMonitor() *gcMonitor {
+	g := &gcMonitor{
+		samples: []runtimemetrics.Sample{
+			{Name: gcCPUMetric},
+			{Name: totalCPUMetric},
+		},
+	}
+	g.lastGC, g.lastUsed = g.read()
+	return g
+}
+
+// CPUFraction returns the portion of CPU time spent by the garbage collector
+// since the last call.
+//
+// Not safe for concurrent use.
+func (g *gcMonitor) CPUFraction() float64 {
+	gc, used := g.read()
+	gcDelta := gc - g.lastGC
+	usedDelta := used - g.lastUsed
+	g.lastGC, g.lastUsed = gc, used
+	if usedDelta <= 0 {
+		return 0
+	}
+	return gcDelta / usedDelta
+}
+
+// read returns the CPU time spent by the garbage collector and the CPU time
+// available to the process. Both are 0 if the runtime doesn't report them.
+func (g *gcMonitor) read() (float64, float64) {
+	runtimemetrics.Read(g.samples)
+	if g.samples[0].Value.Kind() != runtimemetrics.KindFloat64 ||
+		g.samples[1].Value.Kind() != runtimemetrics.KindFloat64 {
+		return 0, 0
+	}
+	return g.samples[0].Value.Float64(), g.samples[1].Value.Float64()
+}
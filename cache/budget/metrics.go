@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package budget
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type metrics struct {
+	available     prometheus.Gauge
+	gcCPUFraction prometheus.Gauge
+	size          *prometheus.GaugeVec
+}
+
+func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		available: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "available",
+			Help:      "number of bytes of the budget allocated to resizable caches",
+		}),
+		gcCPUFraction: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "gc_cpu_fraction",
+			Help:      "portion of CPU time spent by the garbage collector since the last rebalance",
+		}),
+		size: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "size",
+				Help:      "number of bytes of the budget allocated to a cache",
+			},
+			[]string{"cache"},
+		),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.available),
+		registerer.Register(m.gcCPUFraction),
+		registerer.Register(m.size),
+	)
+	return m, errs.Err
+}
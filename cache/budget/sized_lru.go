@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package budget
+
+import (
+	"sync/atomic"
+
+	"github.com/ava-labs/avalanchego/cache"
+)
+
+var _ resizable = (*sizedLRU[struct{}, any])(nil)
+
+// sizedLRU is a sized LRU cache that counts its hits and misses so that its
+// capacity can be adjusted to its usage.
+type sizedLRU[K comparable, V any] struct {
+	cache.Resizable[K, V]
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewSizedLRU returns a sized LRU cache, named [name], whose capacity is
+// allocated by [m]. [defaultSize] is the capacity of the cache if [m] is nil,
+// and otherwise the weight of the cache when splitting the budget of [m].
+func NewSizedLRU[K comparable, V any](
+	m *Manager,
+	name string,
+	defaultSize int,
+	size func(K, V) int,
+) (cache.Cacher[K, V], error) {
+	if m == nil {
+		return cache.NewSizedLRU[K, V](defaultSize, size), nil
+	}
+
+	c := &sizedLRU[K, V]{
+		Resizable: cache.NewResizableSizedLRU[K, V](defaultSize, size),
+	}
+	return c, m.register(name, defaultSize, c)
+}
+
+func (c *sizedLRU[K, V]) Get(key K) (V, bool) {
+	value, ok := c.Resizable.Get(key)
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return value, ok
+}
+
+func (c *sizedLRU[_, _]) stats() (uint64, uint64) {
+	return c.hits.Load(), c.misses.Load()
+}
@@ -26,6 +26,15 @@ type Cacher[K comparable, V any] interface {
 	PortionFilled() float64
 }
 
+// Resizable is a cache whose capacity can be changed after it is created.
+type Resizable[K comparable, V any] interface {
+	Cacher[K, V]
+
+	// Resize sets the capacity of the cache. If the cache holds more than the
+	// new capacity, elements will be evicted.
+	Resize(maxSize int)
+}
+
 // Evictable allows the object to be notified when it is evicted
 type Evictable[K comparable] interface {
 	Key() K
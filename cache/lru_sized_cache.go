@@ -10,7 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
 )
 
-var _ Cacher[struct{}, any] = (*sizedLRU[struct{}, any])(nil)
+var _ Resizable[struct{}, any] = (*sizedLRU[struct{}, any])(nil)
 
 // sizedLRU is a key value store with bounded size. If the size is attempted to
 // be exceeded, then elements are removed from the cache until the bound is
@@ -24,6 +24,12 @@ type sizedLRU[K comparable, V any] struct {
 }
 
 func NewSizedLRU[K comparable, V any](maxSize int, size func(K, V) int) Cacher[K, V] {
+	return NewResizableSizedLRU(maxSize, size)
+}
+
+// NewResizableSizedLRU returns a sized LRU cache whose capacity can be changed
+// after it is created.
+func NewResizableSizedLRU[K comparable, V any](maxSize int, size func(K, V) int) Resizable[K, V] {
 	return &sizedLRU[K, V]{
 		elements: linkedhashmap.New[K, V](),
 		maxSize:  maxSize,
@@ -73,6 +79,13 @@ func (c *sizedLRU[_, _]) PortionFilled() float64 {
 	return c.portionFilled()
 }
 
+func (c *sizedLRU[_, _]) Resize(maxSize int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.resize(maxSize)
+}
+
 func (c *sizedLRU[K, V]) put(key K, value V) {
 	newEntrySize := c.size(key, value)
 	if newEntrySize > c.maxSize {
@@ -85,11 +98,7 @@ func (c *sizedLRU[K, V]) put(key K, value V) {
 	}
 
 	// Remove elements until the size of elements in the cache <= [c.maxSize].
-	for c.currentSize > c.maxSize-newEntrySize {
-		oldestKey, oldestValue, _ := c.elements.Oldest()
-		c.elements.Delete(oldestKey)
-		c.currentSize -= c.size(oldestKey, oldestValue)
-	}
+	c.evictUntil(c.maxSize - newEntrySize)
 
 	c.elements.Put(key, value)
 	c.currentSize += newEntrySize
@@ -112,6 +121,21 @@ func (c *sizedLRU[K, _]) evict(key K) {
 	}
 }
 
+func (c *sizedLRU[_, _]) resize(maxSize int) {
+	c.maxSize = maxSize
+	c.evictUntil(maxSize)
+}
+
+// evictUntil removes the least recently used elements until the size of the
+// elements in the cache is at most [size].
+func (c *sizedLRU[_, _]) evictUntil(size int) {
+	for c.currentSize > size {
+		oldestKey, oldestValue, _ := c.elements.Oldest()
+		c.elements.Delete(oldestKey)
+		c.currentSize -= c.size(oldestKey, oldestValue)
+	}
+}
+
 func (c *sizedLRU[K, V]) flush() {
 	c.elements = linkedhashmap.New[K, V]()
 	c.currentSize = 0
@@ -50,3 +50,36 @@ func TestSizedLRUWrongKeyEvictionRegression(t *testing.T) {
 	_, ok = cache.Get("dd")
 	require.True(ok)
 }
+
+func TestSizedLRUResize(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewResizableSizedLRU[ids.ID, int64](3*TestIntSize, TestIntSizeFunc)
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+	id3 := ids.ID{3}
+	cache.Put(id1, 1)
+	cache.Put(id2, 2)
+	cache.Put(id3, 3)
+
+	// Mark [id1] as the most recently used element.
+	_, ok := cache.Get(id1)
+	require.True(ok)
+
+	cache.Resize(2 * TestIntSize)
+	require.Equal(2, cache.Len())
+	require.Equal(1.0, cache.PortionFilled())
+
+	_, ok = cache.Get(id2)
+	require.False(ok)
+	_, ok = cache.Get(id3)
+	require.True(ok)
+	_, ok = cache.Get(id1)
+	require.True(ok)
+
+	cache.Resize(4 * TestIntSize)
+	require.Equal(0.5, cache.PortionFilled())
+	cache.Put(id2, 2)
+	require.Equal(3, cache.Len())
+}
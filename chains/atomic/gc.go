@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package atomic
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var errNoGCRetention = errors.New("shared memory garbage collection retention must be positive")
+
+type GCConfig struct {
+	// Retention is how long a value that was removed before being added is
+	// remembered as removed. Once the retention has passed, the value can be
+	// added again, so it must exceed the time it takes for every chain that
+	// writes to shared memory to process its blocks. If 0, garbage collection
+	// is disabled.
+	Retention time.Duration `json:"retention"`
+	// Frequency is how often garbage collection runs. If 0, garbage
+	// collection only runs when requested.
+	Frequency time.Duration `json:"frequency"`
+}
+
+// GCResult describes the outcome of a garbage collection pass.
+type GCResult struct {
+	// NumRemoved is the number of removal markers that were deleted.
+	NumRemoved int
+	// NumBytes is the number of bytes of keys and values that were deleted.
+	NumBytes uint64
+}
+
+type gcMetrics struct {
+	numRuns    prometheus.Counter
+	numRemoved prometheus.Counter
+	numBytes   prometheus.Counter
+}
+
+func newGCMetrics(namespace string, registerer prometheus.Registerer) (*gcMetrics, error) {
+	m := &gcMetrics{
+		numRuns: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "gc_runs",
+			Help:      "number of shared memory garbage collection passes",
+		}),
+		numRemoved: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "gc_removed",
+			Help:      "number of expired removal markers deleted from shared memory",
+		}),
+		numBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "gc_reclaimed_bytes",
+			Help:      "number of bytes of keys and values reclaimed from shared memory",
+		}),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.numRuns),
+		registerer.Register(m.numRemoved),
+		registerer.Register(m.numBytes),
+	)
+	return m, errs.Err
+}
+
+// GarbageCollector deletes the records of shared memory that are no longer
+// needed.
+//
+// When a value is removed from shared memory before it is added, a marker is
+// written so that the value is discarded once it is added. If the value is
+// never added, for example because the chain that adds it isn't tracked, the
+// marker is kept forever. GarbageCollector deletes the markers that are older
+// than the configured retention.
+type GarbageCollector struct {
+	log     logging.Logger
+	memory  *Memory
+	config  GCConfig
+	metrics *gcMetrics
+
+	// lock ensures that a single pass runs at a time.
+	lock sync.Mutex
+
+	closeOnce sync.Once
+	onClose   chan struct{}
+}
+
+func NewGarbageCollector(
+	log logging.Logger,
+	memory *Memory,
+	config GCConfig,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*GarbageCollector, error) {
+	if config.Retention <= 0 {
+		return nil, errNoGCRetention
+	}
+	metrics, err := newGCMetrics(namespace, registerer)
+	if err != nil {
+		return nil, err
+	}
+	return &GarbageCollector{
+		log:     log,
+		memory:  memory,
+		config:  config,
+		metrics: metrics,
+		onClose: make(chan struct{}),
+	}, nil
+}
+
+// Dispatch runs garbage collection every [config.Frequency] until Shutdown is
+// called. If the frequency is 0, Dispatch returns immediately.
+func (gc *GarbageCollector) Dispatch() {
+	if gc.config.Frequency <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(gc.config.Frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := gc.Collect(); err != nil {
+				gc.log.Error("failed to garbage collect shared memory",
+					zap.Error(err),
+				)
+			}
+		case <-gc.onClose:
+			return
+		}
+	}
+}
+
+// Collect deletes the removal markers that are older than the retention.
+func (gc *GarbageCollector) Collect() (GCResult, error) {
+	gc.lock.Lock()
+	defer gc.lock.Unlock()
+
+	start := time.Now()
+	cutoff := gc.memory.clock.Time().Add(-gc.config.Retention)
+	numRemoved, numBytes, err := gc.memory.collect(cutoff)
+	gc.metrics.numRuns.Inc()
+	gc.metrics.numRemoved.Add(float64(numRemoved))
+	gc.metrics.numBytes.Add(float64(numBytes))
+	result := GCResult{
+		NumRemoved: numRemoved,
+		NumBytes:   numBytes,
+	}
+	if err != nil {
+		return result, err
+	}
+
+	gc.log.Info("garbage collected shared memory",
+		zap.Int("numRemoved", numRemoved),
+		zap.Uint64("numBytes", numBytes),
+		zap.Duration("duration", time.Since(start)),
+	)
+	return result, nil
+}
+
+// Shutdown stops the periodic garbage collection.
+func (gc *GarbageCollector) Shutdown() {
+	gc.closeOnce.Do(func() {
+		close(gc.onClose)
+	})
+}
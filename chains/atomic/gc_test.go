@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package atomic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestGarbageCollectorRemovesExpiredTombstones(t *testing.T) {
+	require := require.New(t)
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+	m := NewMemory(prefixdb.New([]byte{0}, memdb.New()))
+	start := time.Now()
+	m.clock.Set(start)
+	sm0 := m.NewSharedMemory(chainID0)
+	sm1 := m.NewSharedMemory(chainID1)
+
+	const retention = time.Hour
+	gc, err := NewGarbageCollector(
+		logging.NoLog{},
+		m,
+		GCConfig{Retention: retention},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	// Chain 1 consumes values that chain 0 hasn't added yet.
+	require.NoError(sm1.Apply(map[ids.ID]*Requests{chainID0: {
+		RemoveRequests: [][]byte{{0}, {1}},
+	}}))
+
+	// The addition of a value cancels its marker.
+	require.NoError(sm0.Apply(map[ids.ID]*Requests{chainID1: {PutRequests: []*Element{{
+		Key:   []byte{0},
+		Value: []byte{0},
+	}}}}))
+
+	// The remaining marker isn't collected before the retention passes.
+	m.clock.Set(start.Add(retention))
+	result, err := gc.Collect()
+	require.NoError(err)
+	require.Zero(result.NumRemoved)
+	require.Zero(result.NumBytes)
+
+	m.clock.Set(start.Add(retention + time.Second))
+	result, err = gc.Collect()
+	require.NoError(err)
+	require.Equal(1, result.NumRemoved)
+	require.Positive(result.NumBytes)
+
+	// Nothing remains to be collected.
+	result, err = gc.Collect()
+	require.NoError(err)
+	require.Zero(result.NumRemoved)
+
+	// Without its marker, the value is kept once it's added.
+	require.NoError(sm0.Apply(map[ids.ID]*Requests{chainID1: {PutRequests: []*Element{{
+		Key:   []byte{1},
+		Value: []byte{1},
+	}}}}))
+	values, err := sm1.Get(chainID0, [][]byte{{1}})
+	require.NoError(err)
+	require.Equal([][]byte{{1}}, values)
+
+	_, err = sm1.Get(chainID0, [][]byte{{0}})
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestNewGarbageCollectorRequiresRetention(t *testing.T) {
+	_, err := NewGarbageCollector(
+		logging.NoLog{},
+		NewMemory(memdb.New()),
+		GCConfig{},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.ErrorIs(t, err, errNoGCRetention)
+}
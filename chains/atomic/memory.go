@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
 type rcLock struct {
@@ -29,6 +30,9 @@ type Memory struct {
 	lock  sync.Mutex
 	locks map[ids.ID]*rcLock
 	db    database.Database
+
+	// clock is used to record when values are removed before being added.
+	clock mockable.Clock
 }
 
 func NewMemory(db database.Database) *Memory {
@@ -52,6 +52,13 @@ func (p *prefixes) getValueDB(myChainID, peerChainID ids.ID, db database.Databas
 	return prefixdb.New(p.largerValuePrefix, db)
 }
 
+func (p *prefixes) getValuePrefix(myChainID, peerChainID ids.ID) []byte {
+	if bytes.Compare(myChainID[:], peerChainID[:]) == -1 {
+		return p.smallerValuePrefix
+	}
+	return p.largerValuePrefix
+}
+
 func (p *prefixes) getValueAndIndexDB(myChainID, peerChainID ids.ID, db database.Database) (database.Database, database.Database) {
 	var valueDB, indexDB database.Database
 	if bytes.Compare(myChainID[:], peerChainID[:]) == -1 {
@@ -128,6 +128,8 @@ func (sm *sharedMemory) Apply(requests map[ids.ID]*Requests, batches ...database
 
 	// Make sure all operations are committed atomically
 	vdb := versiondb.New(sm.m.db)
+	tombstoneDB := newTombstoneDB(vdb)
+	now := sm.m.clock.Time()
 
 	for _, sharedID := range sharedIDs {
 		req := sharedOperations[sharedID]
@@ -139,6 +141,11 @@ func (sm *sharedMemory) Apply(requests map[ids.ID]*Requests, batches ...database
 
 		// Perform any remove requests on the inbound database
 		s.valueDB, s.indexDB = inbound.getValueAndIndexDB(sm.thisChainID, req.peerChainID, db)
+		s.tombstones = &tombstoneIndex{
+			db:     tombstoneDB,
+			prefix: tombstonePrefix(sharedID, inbound.getValuePrefix(sm.thisChainID, req.peerChainID)),
+			now:    now,
+		}
 		for _, removeRequest := range req.RemoveRequests {
 			if err := s.RemoveValue(removeRequest); err != nil {
 				return err
@@ -147,6 +154,11 @@ func (sm *sharedMemory) Apply(requests map[ids.ID]*Requests, batches ...database
 
 		// Add Put requests to the outbound database.
 		s.valueDB, s.indexDB = outbound.getValueAndIndexDB(sm.thisChainID, req.peerChainID, db)
+		s.tombstones = &tombstoneIndex{
+			db:     tombstoneDB,
+			prefix: tombstonePrefix(sharedID, outbound.getValuePrefix(sm.thisChainID, req.peerChainID)),
+			now:    now,
+		}
 		for _, putRequest := range req.PutRequests {
 			if err := s.SetValue(putRequest); err != nil {
 				return err
@@ -46,6 +46,10 @@ type state struct {
 	// The linkeddb contains the keys that the trait maps to as the key and map
 	// to nil values.
 	indexDB database.Database
+
+	// tombstones, if non-nil, records when keys are marked as removed before
+	// they are added, so that the markers can be garbage collected.
+	tombstones *tombstoneIndex
 }
 
 // Value returns the Element associated with [key].
@@ -82,6 +86,9 @@ func (s *state) SetValue(e *Element) error {
 		if !value.Present {
 			// This was previously optimistically deleted from the database, so
 			// it should be immediately removed.
+			if err := s.tombstones.remove(e.Key); err != nil {
+				return err
+			}
 			return s.valueDB.Delete(e.Key)
 		}
 
@@ -155,6 +162,9 @@ func (s *state) RemoveValue(key []byte) error {
 		if err != nil {
 			return err
 		}
+		if err := s.tombstones.add(key); err != nil {
+			return err
+		}
 		return s.valueDB.Put(key, valueBytes)
 	}
 
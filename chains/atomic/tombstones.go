@@ -0,0 +1,204 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package atomic
+
+import (
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// tombstoneDBPrefix is the prefix of the database that records when values
+// were removed before being added. The prefix is hashed by prefixdb, so it
+// can't collide with the prefixes of the shared databases.
+var tombstoneDBPrefix = []byte("tombstones")
+
+// newTombstoneDB returns the database that records the removal markers of
+// shared memory. [db] must be a versiondb on top of the database of the
+// shared memory, so that the keys are consistent with the shared databases.
+func newTombstoneDB(db *versiondb.Database) database.Database {
+	return prefixdb.New(tombstoneDBPrefix, db)
+}
+
+// tombstonePrefix returns the prefix of the keys of the removal markers of the
+// value database identified by [valuePrefix] in the shared database of
+// [sharedID].
+func tombstonePrefix(sharedID ids.ID, valuePrefix []byte) []byte {
+	prefix := make([]byte, 0, ids.IDLen+len(valuePrefix))
+	prefix = append(prefix, sharedID[:]...)
+	return append(prefix, valuePrefix...)
+}
+
+// tombstoneIndex records the time at which keys of a value database were
+// marked as removed before being added.
+//
+// Markers written before the index was introduced aren't recorded, so they
+// are never garbage collected.
+type tombstoneIndex struct {
+	db     database.Database
+	prefix []byte
+	now    time.Time
+}
+
+func (t *tombstoneIndex) key(key []byte) []byte {
+	indexKey := make([]byte, 0, len(t.prefix)+len(key))
+	indexKey = append(indexKey, t.prefix...)
+	return append(indexKey, key...)
+}
+
+// add records that [key] was marked as removed. If [t] is nil, this is a
+// noop.
+func (t *tombstoneIndex) add(key []byte) error {
+	if t == nil {
+		return nil
+	}
+	return database.PutTimestamp(t.db, t.key(key), t.now)
+}
+
+// remove records that the marker of [key] was deleted. If [t] is nil, this is
+// a noop.
+func (t *tombstoneIndex) remove(key []byte) error {
+	if t == nil {
+		return nil
+	}
+	return t.db.Delete(t.key(key))
+}
+
+// expiredTombstone is a removal marker that was recorded before the cutoff of
+// a garbage collection pass.
+type expiredTombstone struct {
+	valuePrefix []byte
+	key         []byte
+}
+
+// collect deletes the removal markers that were recorded before [cutoff], and
+// returns the number of deleted markers along with the number of bytes of keys
+// and values they occupied.
+//
+// A marker that is deleted no longer prevents the value it refers to from
+// being added, so the value becomes spendable again if it is added after the
+// marker is deleted.
+func (m *Memory) collect(cutoff time.Time) (int, uint64, error) {
+	expired, err := m.expiredTombstones(cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		numRemoved int
+		numBytes   uint64
+	)
+	for sharedID, tombstones := range expired {
+		removed, bytes, err := m.collectShared(sharedID, cutoff, tombstones)
+		if err != nil {
+			return numRemoved, numBytes, err
+		}
+		numRemoved += removed
+		numBytes += bytes
+	}
+	return numRemoved, numBytes, nil
+}
+
+// expiredTombstones returns the removal markers recorded before [cutoff],
+// grouped by the shared database they belong to.
+func (m *Memory) expiredTombstones(cutoff time.Time) (map[ids.ID][]expiredTombstone, error) {
+	tombstoneDB := newTombstoneDB(versiondb.New(m.db))
+	it := tombstoneDB.NewIterator()
+	defer it.Release()
+
+	expired := make(map[ids.ID][]expiredTombstone)
+	for it.Next() {
+		// The iterator may reuse the key, so it's copied before being
+		// retained.
+		indexKey := slices.Clone(it.Key())
+		if len(indexKey) <= ids.IDLen {
+			continue
+		}
+		removedAt, err := database.ParseTimestamp(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		if !removedAt.Before(cutoff) {
+			continue
+		}
+
+		sharedID, err := ids.ToID(indexKey[:ids.IDLen])
+		if err != nil {
+			return nil, err
+		}
+		// Value prefixes are a single byte.
+		expired[sharedID] = append(expired[sharedID], expiredTombstone{
+			valuePrefix: indexKey[ids.IDLen : ids.IDLen+1],
+			key:         indexKey[ids.IDLen+1:],
+		})
+	}
+	return expired, it.Error()
+}
+
+// collectShared deletes [tombstones] from the shared database of [sharedID],
+// unless they were cancelled or rewritten since they were found to be expired.
+func (m *Memory) collectShared(sharedID ids.ID, cutoff time.Time, tombstones []expiredTombstone) (int, uint64, error) {
+	vdb := versiondb.New(m.db)
+	db := m.GetSharedDatabase(vdb, sharedID)
+	defer m.ReleaseSharedDatabase(sharedID)
+
+	tombstoneDB := newTombstoneDB(vdb)
+
+	var (
+		numRemoved int
+		numBytes   uint64
+	)
+	for _, tombstone := range tombstones {
+		index := tombstoneIndex{
+			db:     tombstoneDB,
+			prefix: tombstonePrefix(sharedID, tombstone.valuePrefix),
+		}
+		indexKey := index.key(tombstone.key)
+		timestampBytes, err := tombstoneDB.Get(indexKey)
+		if err == database.ErrNotFound {
+			// The marker was cancelled by the addition of its value.
+			continue
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		removedAt, err := database.ParseTimestamp(timestampBytes)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !removedAt.Before(cutoff) {
+			continue
+		}
+
+		valueDB := prefixdb.New(tombstone.valuePrefix, db)
+		s := state{valueDB: valueDB}
+		value, err := s.loadValue(tombstone.key)
+		switch {
+		case err == database.ErrNotFound:
+			// The marker was already deleted, only its record remains.
+		case err != nil:
+			return 0, 0, err
+		case !value.Present:
+			if err := valueDB.Delete(tombstone.key); err != nil {
+				return 0, 0, err
+			}
+			valueBytes, err := codecManager.Marshal(codecVersion, value)
+			if err != nil {
+				return 0, 0, err
+			}
+			numRemoved++
+			numBytes += uint64(len(tombstone.key) + len(valueBytes) + len(indexKey) + len(timestampBytes))
+		}
+		if err := index.remove(tombstone.key); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return numRemoved, numBytes, vdb.Commit()
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/event"
+)
+
+var _ common.BootstrapTracker = (*bootstrapPublisher)(nil)
+
+// bootstrapPublisher publishes an [event.ChainBootstrapped] event the first
+// time its chain reports that it finished bootstrapping.
+//
+// A chain may report that it finished bootstrapping multiple times while it
+// waits for the other chains of its subnet, so only the first report is
+// published.
+type bootstrapPublisher struct {
+	common.BootstrapTracker
+
+	event event.ChainBootstrapped
+	bus   event.Bus[event.ChainBootstrapped]
+	once  sync.Once
+}
+
+func newBootstrapPublisher(
+	tracker common.BootstrapTracker,
+	name string,
+	chainID ids.ID,
+	subnetID ids.ID,
+	bus event.Bus[event.ChainBootstrapped],
+) common.BootstrapTracker {
+	if bus == nil {
+		return tracker
+	}
+	return &bootstrapPublisher{
+		BootstrapTracker: tracker,
+		event: event.ChainBootstrapped{
+			Name:     name,
+			ChainID:  chainID,
+			SubnetID: subnetID,
+		},
+		bus: bus,
+	}
+}
+
+func (b *bootstrapPublisher) Bootstrapped(chainID ids.ID) {
+	b.BootstrapTracker.Bootstrapped(chainID)
+	b.once.Do(func() {
+		b.bus.Publish(b.event)
+	})
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+// dataDirSizeRefreshInterval is the minimum time between walks of the data
+// directory of a chain.
+const dataDirSizeRefreshInterval = time.Minute
+
+// ChainInfo describes a chain created by this node.
+type ChainInfo struct {
+	ID       ids.ID
+	Alias    string
+	SubnetID ids.ID
+	VMID     ids.ID
+	// State is the state of the consensus engine of the chain.
+	State snow.State
+	// LastAcceptedHeight is nil if the chain isn't run by the Snowman engine
+	// yet, or if the height couldn't be fetched from the VM.
+	LastAcceptedHeight *uint64
+	// DataDirSize is the number of bytes stored in the data directory of the
+	// chain. It doesn't include the state the chain stores in the database of
+	// the node, which is shared by all the chains. The size is computed in the
+	// background, so it may be up to [dataDirSizeRefreshInterval] stale.
+	DataDirSize uint64
+}
+
+func (m *manager) Chains(ctx context.Context) []ChainInfo {
+	m.chainsLock.Lock()
+	chains := maps.Values(m.chains)
+	m.chainsLock.Unlock()
+
+	infos := make([]ChainInfo, len(chains))
+	for i, chain := range chains {
+		infos[i] = m.chainInfo(ctx, chain)
+	}
+	slices.SortFunc(infos, func(a, b ChainInfo) int {
+		switch {
+		case a.ID.Less(b.ID):
+			return -1
+		case b.ID.Less(a.ID):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return infos
+}
+
+func (m *manager) chainInfo(ctx context.Context, chain *chain) ChainInfo {
+	chainID := chain.Context.ChainID
+	state := chain.Context.State.Get()
+	info := ChainInfo{
+		ID:       chainID,
+		Alias:    m.PrimaryAliasOrDefault(chainID),
+		SubnetID: chain.Context.SubnetID,
+		VMID:     chain.VMID,
+		State:    state.State,
+	}
+
+	if state.Type == p2p.EngineType_ENGINE_TYPE_SNOWMAN {
+		chain.Context.Lock.Lock()
+		height, err := lastAcceptedHeight(ctx, chain.ChainVM)
+		chain.Context.Lock.Unlock()
+		if err != nil {
+			m.Log.Debug("failed to fetch last accepted height",
+				zap.Stringer("chainID", chainID),
+				zap.Error(err),
+			)
+		} else {
+			info.LastAcceptedHeight = &height
+		}
+	}
+
+	info.DataDirSize = chain.DataDirSize.Size()
+	return info
+}
+
+// Assumes the context lock of the chain run by [vm] is held.
+func lastAcceptedHeight(ctx context.Context, vm block.ChainVM) (uint64, error) {
+	blkID, err := vm.LastAccepted(ctx)
+	if err != nil {
+		return 0, err
+	}
+	blk, err := vm.GetBlock(ctx, blkID)
+	if err != nil {
+		return 0, err
+	}
+	return blk.Height(), nil
+}
+
+// dirSizeCache reports the size of a directory without walking it on every
+// call. The directory is walked in the background at most once every
+// [dataDirSizeRefreshInterval].
+type dirSizeCache struct {
+	log   logging.Logger
+	dir   string
+	clock mockable.Clock
+
+	lock        sync.Mutex
+	size        uint64
+	lastRefresh time.Time
+	refreshing  bool
+}
+
+// newDirSizeCache returns a cache of the size of [dir], which starts walking
+// [dir] immediately.
+func newDirSizeCache(log logging.Logger, dir string) *dirSizeCache {
+	c := &dirSizeCache{
+		log:        log,
+		dir:        dir,
+		refreshing: true,
+	}
+	go c.refresh()
+	return c
+}
+
+// Size returns the last computed size of the directory, which is 0 until the
+// first walk of the directory completes. If the size is stale, it is
+// recomputed in the background.
+func (c *dirSizeCache) Size() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.refreshing && c.clock.Time().Sub(c.lastRefresh) >= dataDirSizeRefreshInterval {
+		c.refreshing = true
+		go c.refresh()
+	}
+	return c.size
+}
+
+func (c *dirSizeCache) refresh() {
+	size, err := dirSize(c.dir)
+	if err != nil {
+		c.log.Debug("failed to calculate directory size",
+			zap.String("dir", c.dir),
+			zap.Error(err),
+		)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.size = size
+	c.lastRefresh = c.clock.Time()
+	c.refreshing = false
+}
+
+// dirSize returns the number of bytes stored in the regular files under [dir].
+func dirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/buffer"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+const (
+	// MaxChainStatsWindow is the longest window the stats of a chain can be
+	// reported for.
+	MaxChainStatsWindow = time.Hour
+
+	// maxChainStatsBlocks bounds the number of accepted blocks that are
+	// retained to compute the stats of a chain.
+	maxChainStatsBlocks = 10_000
+
+	chainStatsAcceptorName = "stats"
+)
+
+var (
+	_ snow.Acceptor = (*chainStatsTracker)(nil)
+
+	errUnknownChain    = errors.New("unknown chain")
+	errNotSnowmanChain = errors.New("chain isn't run by the Snowman engine")
+)
+
+// ChainStats describes the blocks a chain accepted during a window of time
+// ending now.
+type ChainStats struct {
+	// NumBlocks is the number of blocks in the window.
+	NumBlocks uint64
+	// NumBytes is the total size of the blocks in the window. As the size of
+	// a block grows with the work needed to execute it, this is a VM-agnostic
+	// equivalent of the gas used by the chain.
+	NumBytes uint64
+	// NumTxs is the number of transactions in the blocks in the window. Only
+	// meaningful if TxsKnown is true.
+	NumTxs uint64
+	// TxsKnown is false if any block in the window doesn't report the number
+	// of transactions it contains.
+	TxsKnown bool
+	// StartHeight and EndHeight are the heights of the first and last blocks
+	// in the window. Both are 0 if the window is empty.
+	StartHeight uint64
+	EndHeight   uint64
+	// StartTime and EndTime are the timestamps of the first and last blocks in
+	// the window.
+	StartTime time.Time
+	EndTime   time.Time
+	// Truncated is true if more than [maxChainStatsBlocks] blocks were
+	// accepted in the window, in which case only the most recent blocks are
+	// described.
+	Truncated bool
+}
+
+// BlocksPerSecond is the rate at which blocks were accepted over the window.
+func (s *ChainStats) BlocksPerSecond(window time.Duration) float64 {
+	return perSecond(s.NumBlocks, window)
+}
+
+// BytesPerSecond is the rate at which block bytes were accepted over the
+// window.
+func (s *ChainStats) BytesPerSecond(window time.Duration) float64 {
+	return perSecond(s.NumBytes, window)
+}
+
+// TxsPerSecond is the rate at which transactions were accepted over the
+// window.
+func (s *ChainStats) TxsPerSecond(window time.Duration) float64 {
+	return perSecond(s.NumTxs, window)
+}
+
+// AverageBlockInterval is the average time between the timestamps of
+// consecutive blocks in the window. Returns 0 if the window contains less than
+// 2 blocks.
+func (s *ChainStats) AverageBlockInterval() time.Duration {
+	if s.NumBlocks < 2 {
+		return 0
+	}
+	return s.EndTime.Sub(s.StartTime) / time.Duration(s.NumBlocks-1)
+}
+
+func perSecond(n uint64, window time.Duration) float64 {
+	if window <= 0 {
+		return 0
+	}
+	return float64(n) / window.Seconds()
+}
+
+func (m *manager) ChainStats(_ context.Context, chainID ids.ID, window time.Duration) (ChainStats, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return ChainStats{}, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	if chain.Context.State.Get().Type != p2p.EngineType_ENGINE_TYPE_SNOWMAN {
+		return ChainStats{}, fmt.Errorf("%w: %s", errNotSnowmanChain, chainID)
+	}
+	return chain.Stats.Stats(window), nil
+}
+
+type acceptedBlock struct {
+	acceptedAt time.Time
+	height     uint64
+	timestamp  time.Time
+	numBytes   int
+	numTxs     int
+	txsKnown   bool
+}
+
+// chainStatsTracker records the blocks of a chain as they are accepted, so that
+// the stats of the chain can be reported without reading its blocks or
+// holding its context lock.
+//
+// Only blocks accepted within the last [MaxChainStatsWindow] are retained, up
+// to [maxChainStatsBlocks] of them.
+type chainStatsTracker struct {
+	vm    block.ChainVM
+	clock mockable.Clock
+
+	lock sync.Mutex
+	// blocks accepted in the last [MaxChainStatsWindow], from oldest to newest
+	blocks buffer.Deque[acceptedBlock]
+	// acceptance time of the last block that was dropped from [blocks]
+	lastDropped time.Time
+}
+
+func newChainStatsTracker(vm block.ChainVM) *chainStatsTracker {
+	return &chainStatsTracker{
+		vm:     vm,
+		blocks: buffer.NewUnboundedDeque[acceptedBlock](0),
+	}
+}
+
+// Accept records the accepted block [blkID].
+//
+// Assumes the context lock of the chain is held.
+func (t *chainStatsTracker) Accept(_ *snow.ConsensusContext, _ ids.ID, blkBytes []byte) error {
+	blk, err := t.vm.ParseBlock(context.TODO(), blkBytes)
+	if err != nil {
+		return err
+	}
+	numTxs, txsKnown := block.NumTxs(blk)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.clock.Time()
+	t.blocks.PushRight(acceptedBlock{
+		acceptedAt: now,
+		height:     blk.Height(),
+		timestamp:  blk.Timestamp(),
+		numBytes:   len(blkBytes),
+		numTxs:     numTxs,
+		txsKnown:   txsKnown,
+	})
+	t.prune(now)
+	return nil
+}
+
+// Stats describes the blocks accepted in the [window] ending now. [window]
+// should not exceed [MaxChainStatsWindow].
+func (t *chainStatsTracker) Stats(window time.Duration) ChainStats {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.clock.Time()
+	t.prune(now)
+
+	start := now.Add(-window)
+	stats := ChainStats{
+		TxsKnown:  true,
+		Truncated: !t.lastDropped.IsZero() && !t.lastDropped.Before(start),
+	}
+	for i := t.blocks.Len() - 1; i >= 0; i-- {
+		blk, _ := t.blocks.Index(i)
+		if blk.acceptedAt.Before(start) {
+			break
+		}
+
+		if stats.NumBlocks == 0 {
+			stats.EndHeight = blk.height
+			stats.EndTime = blk.timestamp
+		}
+		stats.NumBlocks++
+		stats.NumBytes += uint64(blk.numBytes)
+		stats.NumTxs += uint64(blk.numTxs)
+		stats.TxsKnown = stats.TxsKnown && blk.txsKnown
+		stats.StartHeight = blk.height
+		stats.StartTime = blk.timestamp
+	}
+	return stats
+}
+
+// prune drops the blocks that were accepted before the longest window, or that
+// exceed [maxChainStatsBlocks].
+//
+// Assumes [t.lock] is held.
+func (t *chainStatsTracker) prune(now time.Time) {
+	start := now.Add(-MaxChainStatsWindow)
+	for {
+		blk, ok := t.blocks.PeekLeft()
+		if !ok || (t.blocks.Len() <= maxChainStatsBlocks && !blk.acceptedAt.Before(start)) {
+			return
+		}
+		_, _ = t.blocks.PopLeft()
+		t.lastDropped = blk.acceptedAt
+	}
+}
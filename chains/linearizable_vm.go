@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
@@ -17,8 +18,9 @@ import (
 )
 
 var (
-	_ vertex.LinearizableVM = (*initializeOnLinearizeVM)(nil)
-	_ block.ChainVM         = (*linearizeOnInitializeVM)(nil)
+	_ vertex.LinearizableVM    = (*initializeOnLinearizeVM)(nil)
+	_ block.ChainVM            = (*linearizeOnInitializeVM)(nil)
+	_ block.PreVerifierChainVM = (*linearizeOnInitializeVM)(nil)
 )
 
 // initializeOnLinearizeVM transforms the consensus engine's call to Linearize
@@ -84,3 +86,11 @@ func (vm *linearizeOnInitializeVM) Initialize(
 ) error {
 	return vm.Linearize(ctx, vm.stopVertexID, toEngine)
 }
+
+func (vm *linearizeOnInitializeVM) PreVerifyBlock(ctx context.Context, blk snowman.Block) error {
+	preVerifierVM, ok := vm.LinearizableVMWithEngine.(block.PreVerifierChainVM)
+	if !ok {
+		return block.ErrPreVerifierVMNotImplemented
+	}
+	return preVerifierVM.PreVerifyBlock(ctx, blk)
+}
@@ -22,7 +22,10 @@ import (
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/cache/budget"
 	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/objectstore"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
@@ -36,10 +39,12 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common/tracker"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/syncer"
+	"github.com/ava-labs/avalanchego/snow/finality"
 	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/snow/networking/watchdog"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/subnets"
@@ -47,15 +52,19 @@ import (
 	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/event"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/metervm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/proposervm"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 	"github.com/ava-labs/avalanchego/vms/tracedvm"
+	"github.com/ava-labs/avalanchego/vms/walvm"
 
 	dbManager "github.com/ava-labs/avalanchego/database/manager"
 	timetracker "github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -73,12 +82,25 @@ import (
 const (
 	defaultChannelSize = 1
 	initialQueueSize   = 3
+
+	// Frequency to check if the bootstrap dependencies of a queued chain
+	// have finished bootstrapping
+	dependencyCheckFrequency = time.Second
+	// Frequency to warn about a chain that is still waiting for its bootstrap
+	// dependencies
+	dependencyWarnFrequency = time.Minute
+
+	// Size of the cache of blocks fetched from a proposervm archive
+	proposerArchiveCacheSize = 64 * units.MiB
 )
 
 var (
 	// Commonly shared VM DB prefix
 	vmDBPrefix = []byte("vm")
 
+	// Finality certificates prefix
+	finalityDBPrefix = []byte("finality")
+
 	// Bootstrapping prefixes for LinearizableVMs
 	vertexDBPrefix              = []byte("vertex")
 	vertexBootstrappingDBPrefix = []byte("vertex_bs")
@@ -93,6 +115,8 @@ var (
 	errNotBootstrapped         = errors.New("subnets not bootstrapped")
 	errNoPrimaryNetworkConfig  = errors.New("no subnet config for primary network found")
 	errPartialSyncAsAValidator = errors.New("partial sync should not be configured for a validator")
+	errUntrackedDependencies   = errors.New("bootstrap dependencies aren't chains of a tracked subnet")
+	errDependenciesTimeout     = errors.New("bootstrap dependencies didn't finish bootstrapping")
 
 	_ Manager = (*manager)(nil)
 )
@@ -111,7 +135,9 @@ type Manager interface {
 
 	// Queues a chain to be created in the future after chain creator is unblocked.
 	// This is only called from the P-chain thread to create other chains
-	// Queued chains are created only after P-chain is bootstrapped.
+	// Queued chains are created only after P-chain is bootstrapped, and after
+	// all of the chain's bootstrap dependencies declared in its subnet config
+	// are bootstrapped.
 	// This assumes only chains in tracked subnets are queued.
 	QueueChainCreation(ChainParameters)
 
@@ -128,6 +154,15 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Chains returns a description of every chain created by this node,
+	// ordered by ID.
+	Chains(context.Context) []ChainInfo
+
+	// ChainStats describes the blocks accepted by the chain in the [window]
+	// before now, which should not exceed [MaxChainStatsWindow]. Returns an
+	// error if the chain doesn't exist or isn't run by the Snowman engine.
+	ChainStats(ctx context.Context, chainID ids.ID, window time.Duration) (ChainStats, error)
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
@@ -157,6 +192,16 @@ type chain struct {
 	VM      common.VM
 	Handler handler.Handler
 	Beacons validators.Set
+
+	VMID ids.ID
+	// DataDirSize tracks the size of the directory the chain stores its
+	// files in.
+	DataDirSize *dirSizeCache
+	// ChainVM is the VM run by the Snowman engine of the chain. For chains
+	// that start as a DAG, it can only be used once the DAG is linearized.
+	ChainVM block.ChainVM
+	// Stats records the blocks accepted by the Snowman engine of the chain.
+	Stats *chainStatsTracker
 }
 
 // ChainConfig is configuration settings for the current execution.
@@ -201,6 +246,9 @@ type ManagerConfig struct {
 	RetryBootstrapWarnFrequency int                       // Max number of times to retry bootstrap before warning the node operator
 	SubnetConfigs               map[ids.ID]subnets.Config // ID -> SubnetConfig
 	ChainConfigs                map[string]ChainConfig    // alias -> ChainConfig
+	// alias -> genesis bytes that replace the genesis of a chain that isn't on
+	// the primary network
+	ChainGenesisOverrides map[string][]byte
 	// ShutdownNodeFunc allows the chain manager to issue a request to shutdown the node
 	ShutdownNodeFunc func(exitCode int)
 	MeterVMEnabled   bool // Should each VM be wrapped with a MeterVM
@@ -217,6 +265,13 @@ type ManagerConfig struct {
 	// This node will only consider the first [AncestorsMaxContainersReceived]
 	// containers in an ancestors message it receives.
 	BootstrapAncestorsMaxContainersReceived int
+	// Number of goroutines that pre-verify blocks ahead of their execution
+	// during bootstrapping.
+	BootstrapExecutionWorkers int
+	// If true, chains of Subnets other than the Primary Network are only
+	// created once the Primary Network has finished bootstrapping, so that the
+	// Primary Network doesn't share bandwidth with them while bootstrapping.
+	BootstrapPrioritizePrimaryNetwork bool
 
 	ApricotPhase4Time            time.Time
 	ApricotPhase4MinPChainHeight uint64
@@ -224,9 +279,24 @@ type ManagerConfig struct {
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker timetracker.ResourceTracker
 
+	// Detects chains that have stalled.
+	Watchdog watchdog.Watchdog
+
+	// Notified when each chain finishes bootstrapping. If nil, nothing is
+	// notified.
+	ChainBootstrapped event.Bus[event.ChainBootstrapped]
+
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
+	// Should each snowman chain write the blocks it accepts to a write-ahead
+	// log in its data directory
+	AcceptWALEnabled bool
+
+	// Allocates the capacity of the block and UTXO caches of each chain from
+	// the node's cache memory budget. If nil, the caches are sized
+	// independently.
+	CacheBudget *budget.Manager
 }
 
 type manager struct {
@@ -239,10 +309,23 @@ type manager struct {
 	stakingCert   *staking.Certificate
 
 	// Those notified when a chain is created
-	registrants []Registrant
+	registrantsLock sync.Mutex
+	registrants     []Registrant
 
 	// queue that holds chain create requests
 	chainsQueue buffer.BlockingDeque[ChainParameters]
+	// Chains whose dependencies have bootstrapped are moved from
+	// [chainsQueue] to the queue of their Subnet. Each Subnet's queue is
+	// processed by its own goroutine, so that Subnets are created and
+	// bootstrapped in parallel.
+	//
+	// Key: Subnet's ID
+	// Value: Chains of the Subnet waiting to be created
+	subnetQueuesLock sync.Mutex
+	subnetQueues     map[ids.ID]buffer.BlockingDeque[ChainParameters]
+	// Chains that were added to a Subnet, whether or not they were created.
+	// Protected by [subnetsLock].
+	stagedChains set.Set[ids.ID]
 	// unblocks chain creator to start processing the queue
 	unblockChainCreatorCh  chan struct{}
 	chainCreatorShutdownCh chan struct{}
@@ -255,7 +338,7 @@ type manager struct {
 	chainsLock sync.Mutex
 	// Key: Chain's ID
 	// Value: The chain
-	chains map[ids.ID]handler.Handler
+	chains map[ids.ID]*chain
 
 	// snowman++ related interface to allow validators retrieval
 	validatorState validators.State
@@ -269,8 +352,9 @@ func New(config *ManagerConfig) Manager {
 		stakingSigner:          config.StakingTLSCert.PrivateKey.(crypto.Signer),
 		stakingCert:            staking.CertificateFromX509(config.StakingTLSCert.Leaf),
 		subnets:                make(map[ids.ID]subnets.Subnet),
-		chains:                 make(map[ids.ID]handler.Handler),
+		chains:                 make(map[ids.ID]*chain),
 		chainsQueue:            buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
+		subnetQueues:           make(map[ids.ID]buffer.BlockingDeque[ChainParameters]),
 		unblockChainCreatorCh:  make(chan struct{}),
 		chainCreatorShutdownCh: make(chan struct{}),
 	}
@@ -297,6 +381,7 @@ func (m *manager) QueueChainCreation(chainParams ChainParameters) {
 		m.subnets[chainParams.SubnetID] = sb
 	}
 	addedChain := sb.AddChain(chainParams.ID)
+	m.stagedChains.Add(chainParams.ID)
 	m.subnetsLock.Unlock()
 
 	if !addedChain {
@@ -341,53 +426,12 @@ func (m *manager) createChain(chainParams ChainParameters) {
 	// upon start is dropped.
 	chain, err := m.buildChain(chainParams, sb)
 	if err != nil {
-		if m.CriticalChains.Contains(chainParams.ID) {
-			// Shut down if we fail to create a required chain (i.e. X, P or C)
-			m.Log.Fatal("error creating required chain",
-				zap.Stringer("subnetID", chainParams.SubnetID),
-				zap.Stringer("chainID", chainParams.ID),
-				zap.Stringer("vmID", chainParams.VMID),
-				zap.Error(err),
-			)
-			go m.ShutdownNodeFunc(1)
-			return
-		}
-
-		chainAlias := m.PrimaryAliasOrDefault(chainParams.ID)
-		m.Log.Error("error creating chain",
-			zap.Stringer("subnetID", chainParams.SubnetID),
-			zap.Stringer("chainID", chainParams.ID),
-			zap.String("chainAlias", chainAlias),
-			zap.Stringer("vmID", chainParams.VMID),
-			zap.Error(err),
-		)
-
-		// Register the health check for this chain regardless of if it was
-		// created or not. This attempts to notify the node operator that their
-		// node may not be properly validating the subnet they expect to be
-		// validating.
-		healthCheckErr := fmt.Errorf("failed to create chain on subnet: %s", chainParams.SubnetID)
-		err := m.Health.RegisterHealthCheck(
-			chainAlias,
-			health.CheckerFunc(func(context.Context) (interface{}, error) {
-				return nil, healthCheckErr
-			}),
-			chainParams.SubnetID.String(),
-		)
-		if err != nil {
-			m.Log.Error("failed to register failing health check",
-				zap.Stringer("subnetID", chainParams.SubnetID),
-				zap.Stringer("chainID", chainParams.ID),
-				zap.String("chainAlias", chainAlias),
-				zap.Stringer("vmID", chainParams.VMID),
-				zap.Error(err),
-			)
-		}
+		m.failChainCreation(chainParams, err)
 		return
 	}
 
 	m.chainsLock.Lock()
-	m.chains[chainParams.ID] = chain.Handler
+	m.chains[chainParams.ID] = chain
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -425,6 +469,60 @@ func (m *manager) createChain(chainParams ChainParameters) {
 	chain.Handler.Start(context.TODO(), !m.CriticalChains.Contains(chainParams.ID))
 }
 
+// failChainCreation reports that the chain couldn't be created.
+func (m *manager) failChainCreation(chainParams ChainParameters, err error) {
+	m.subnetsLock.RLock()
+	sb := m.subnets[chainParams.SubnetID]
+	m.subnetsLock.RUnlock()
+
+	// The chain will never finish bootstrapping, so its slot must be released
+	// for the remaining chains of the Subnet.
+	sb.ReleaseBootstrapSlot(chainParams.ID)
+
+	if m.CriticalChains.Contains(chainParams.ID) {
+		// Shut down if we fail to create a required chain (i.e. X, P or C)
+		m.Log.Fatal("error creating required chain",
+			zap.Stringer("subnetID", chainParams.SubnetID),
+			zap.Stringer("chainID", chainParams.ID),
+			zap.Stringer("vmID", chainParams.VMID),
+			zap.Error(err),
+		)
+		go m.ShutdownNodeFunc(1)
+		return
+	}
+
+	chainAlias := m.PrimaryAliasOrDefault(chainParams.ID)
+	m.Log.Error("error creating chain",
+		zap.Stringer("subnetID", chainParams.SubnetID),
+		zap.Stringer("chainID", chainParams.ID),
+		zap.String("chainAlias", chainAlias),
+		zap.Stringer("vmID", chainParams.VMID),
+		zap.Error(err),
+	)
+
+	// Register the health check for this chain regardless of if it was
+	// created or not. This attempts to notify the node operator that their
+	// node may not be properly validating the subnet they expect to be
+	// validating.
+	healthCheckErr := fmt.Errorf("failed to create chain on subnet: %s", chainParams.SubnetID)
+	err = m.Health.RegisterHealthCheck(
+		chainAlias,
+		health.CheckerFunc(func(context.Context) (interface{}, error) {
+			return nil, healthCheckErr
+		}),
+		chainParams.SubnetID.String(),
+	)
+	if err != nil {
+		m.Log.Error("failed to register failing health check",
+			zap.Stringer("subnetID", chainParams.SubnetID),
+			zap.Stringer("chainID", chainParams.ID),
+			zap.String("chainAlias", chainAlias),
+			zap.Stringer("vmID", chainParams.VMID),
+			zap.Error(err),
+		)
+	}
+}
+
 // Create a chain
 func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*chain, error) {
 	if chainParams.ID != constants.PlatformChainID && chainParams.VMID == constants.PlatformVMID {
@@ -487,6 +585,9 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 
 			ValidatorState: m.validatorState,
 			ChainDataDir:   chainDataDir,
+			CacheBudget:    m.CacheBudget,
+
+			Limits: sb.Config().Limits,
 		},
 		BlockAcceptor:       m.BlockAcceptorGroup,
 		TxAcceptor:          m.TxAcceptorGroup,
@@ -528,23 +629,34 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		}
 	}
 
-	var vdrs validators.Set // Validators validating this blockchain
-	var hasValidators bool
-	if m.SybilProtectionEnabled {
+	// Validators validating this blockchain
+	vdrs, hasValidators, err := m.newValidatorOverrideSet(chainParams.SubnetID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't pin validator set of subnet with ID %s: %w", chainParams.SubnetID, err)
+	}
+	switch {
+	case hasValidators:
+		// The validator set of the subnet is pinned by its config.
+	case m.SybilProtectionEnabled:
 		vdrs, hasValidators = m.Validators.Get(chainParams.SubnetID)
-	} else { // Sybil protection is disabled. Every peer validates every subnet.
+	default: // Sybil protection is disabled. Every peer validates every subnet.
 		vdrs, hasValidators = m.Validators.Get(constants.PrimaryNetworkID)
 	}
 	if !hasValidators {
 		return nil, fmt.Errorf("couldn't get validator set of subnet with ID %s. The subnet may not exist", chainParams.SubnetID)
 	}
 
+	genesisData, err := m.getGenesisData(chainParams)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching genesis override: %w", err)
+	}
+
 	var chain *chain
 	switch vm := vm.(type) {
 	case vertex.LinearizableVMWithEngine:
 		chain, err = m.createAvalancheChain(
 			ctx,
-			chainParams.GenesisData,
+			genesisData,
 			vdrs,
 			vm,
 			fxs,
@@ -561,7 +673,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 
 		chain, err = m.createSnowmanChain(
 			ctx,
-			chainParams.GenesisData,
+			genesisData,
 			vdrs,
 			beacons,
 			vm,
@@ -580,10 +692,15 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		return nil, err
 	}
 
+	chain.VMID = chainParams.VMID
+	chain.DataDirSize = newDirSizeCache(m.Log, chainDataDir)
 	return chain, nil
 }
 
 func (m *manager) AddRegistrant(r Registrant) {
+	m.registrantsLock.Lock()
+	defer m.registrantsLock.Unlock()
+
 	m.registrants = append(m.registrants, r)
 }
 
@@ -617,6 +734,11 @@ func (m *manager) createAvalancheChain(
 	txBootstrappingDB := prefixdb.New(txBootstrappingDBPrefix, db.Database)
 	blockBootstrappingDB := prefixdb.New(blockBootstrappingDBPrefix, db.Database)
 
+	ctx.Finality, err = m.newFinalityCertifier(ctx, db.Database, sb)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize finality certifier: %w", err)
+	}
+
 	vtxBlocker, err := queue.NewWithMissing(vertexBootstrappingDB, "vtx", ctx.AvalancheRegisterer)
 	if err != nil {
 		return nil, err
@@ -639,6 +761,8 @@ func (m *manager) createAvalancheChain(
 		m.TimeoutManager,
 		p2p.EngineType_ENGINE_TYPE_AVALANCHE,
 		sb,
+		vdrs,
+		nil,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize avalanche sender: %w", err)
@@ -658,6 +782,23 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("problem initializing event dispatcher: %w", err)
 	}
 
+	err = m.VertexAcceptorGroup.RegisterAcceptor(
+		ctx.ChainID,
+		"watchdog",
+		m.Watchdog,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't register watchdog acceptor: %w", err)
+	}
+
+	// Delivers queries from the snowman engine to this node directly to the
+	// chain's handler
+	loopback, err := sender.NewLoopback(ctx, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize loopback: %w", err)
+	}
+
 	// Passes messages from the snowman engines to the network
 	snowmanMessageSender, err := sender.New(
 		ctx,
@@ -667,6 +808,8 @@ func (m *manager) createAvalancheChain(
 		m.TimeoutManager,
 		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
 		sb,
+		vdrs,
+		loopback,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize avalanche sender: %w", err)
@@ -686,6 +829,16 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("problem initializing event dispatcher: %w", err)
 	}
 
+	err = m.BlockAcceptorGroup.RegisterAcceptor(
+		ctx.ChainID,
+		"watchdog",
+		m.Watchdog,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't register watchdog acceptor: %w", err)
+	}
+
 	chainConfig, err := m.getChainConfig(ctx.ChainID)
 	if err != nil {
 		return nil, fmt.Errorf("error while fetching chain config: %w", err)
@@ -754,16 +907,33 @@ func (m *manager) createAvalancheChain(
 	var (
 		minBlockDelay       = proposervm.DefaultMinBlockDelay
 		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		windowerConfig      = proposer.DefaultConfig
+		archive             objectstore.Store
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		if subnetCfg.ProposerMaxWindows > 0 {
+			windowerConfig.MaxWindows = subnetCfg.ProposerMaxWindows
+		}
+		if subnetCfg.ProposerWindowDuration > 0 {
+			windowerConfig.WindowDuration = subnetCfg.ProposerWindowDuration
+		}
+		if subnetCfg.ProposerArchiveURL != "" {
+			archive, err = newProposerArchive(subnetCfg.ProposerArchiveURL)
+			if err != nil {
+				return nil, fmt.Errorf("error while creating proposervm archive: %w", err)
+			}
+		}
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.ApricotPhase4Time),
 		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
 		zap.Duration("minBlockDelay", minBlockDelay),
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
+		zap.Int("maxProposerWindows", windowerConfig.MaxWindows),
+		zap.Duration("proposerWindowDuration", windowerConfig.WindowDuration),
+		zap.Bool("archivingBlocks", archive != nil),
 	)
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
@@ -784,10 +954,16 @@ func (m *manager) createAvalancheChain(
 		m.ApricotPhase4MinPChainHeight,
 		minBlockDelay,
 		numHistoricalBlocks,
+		windowerConfig,
+		archive,
 		m.stakingSigner,
 		m.stakingCert,
+		m.CacheBudget,
 	)
 
+	if m.AcceptWALEnabled {
+		vmWrappingProposerVM = walvm.NewBlockVM(vmWrappingProposerVM)
+	}
 	if m.MeterVMEnabled {
 		vmWrappingProposerVM = metervm.NewBlockVM(vmWrappingProposerVM)
 	}
@@ -795,6 +971,17 @@ func (m *manager) createAvalancheChain(
 		vmWrappingProposerVM = tracedvm.NewBlockVM(vmWrappingProposerVM, "proposervm", m.Tracer)
 	}
 
+	stats := newChainStatsTracker(vmWrappingProposerVM)
+	err = m.BlockAcceptorGroup.RegisterAcceptor(
+		ctx.ChainID,
+		chainStatsAcceptorName,
+		stats,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't register stats acceptor: %w", err)
+	}
+
 	// Note: linearizableVM is the VM that the Avalanche engines should be
 	// using.
 	linearizableVM := &initializeOnLinearizeVM{
@@ -827,6 +1014,12 @@ func (m *manager) createAvalancheChain(
 	}
 	vdrs.RegisterCallbackListener(connectedValidators)
 
+	appRequestPolicy, err := getAppRequestPolicy(vm)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AppRequest policy: %w", err)
+	}
+	ctx.AppRequestPolicy.Set(appRequestPolicy)
+
 	// Asynchronously passes messages from the network to the consensus engine
 	h, err := handler.New(
 		ctx,
@@ -834,6 +1027,7 @@ func (m *manager) createAvalancheChain(
 		msgChan,
 		m.AcceptedFrontierGossipFrequency,
 		m.ConsensusAppConcurrency,
+		getAppConcurrency(vm),
 		m.ResourceTracker,
 		validators.UnhandledSubnetConnector, // avalanche chains don't use subnet connector
 		sb,
@@ -842,6 +1036,15 @@ func (m *manager) createAvalancheChain(
 	if err != nil {
 		return nil, fmt.Errorf("error initializing network handler: %w", err)
 	}
+	loopback.SetHandler(h)
+
+	bootstrapTracker := newBootstrapPublisher(
+		sb,
+		chainAlias,
+		ctx.ChainID,
+		ctx.SubnetID,
+		m.ChainBootstrapped,
+	)
 
 	connectedBeacons := tracker.NewPeers()
 	startupTracker := tracker.NewStartup(connectedBeacons, (3*bootstrapWeight+3)/4)
@@ -854,7 +1057,7 @@ func (m *manager) createAvalancheChain(
 		Alpha:                          bootstrapWeight/2 + 1, // must be > 50%
 		StartupTracker:                 startupTracker,
 		Sender:                         snowmanMessageSender,
-		BootstrapTracker:               sb,
+		BootstrapTracker:               bootstrapTracker,
 		Timer:                          h,
 		RetryBootstrap:                 m.RetryBootstrap,
 		RetryBootstrapWarnFrequency:    m.RetryBootstrapWarnFrequency,
@@ -895,10 +1098,11 @@ func (m *manager) createAvalancheChain(
 
 	// create bootstrap gear
 	bootstrapCfg := smbootstrap.Config{
-		Config:        snowmanCommonCfg,
-		AllGetsServer: snowGetHandler,
-		Blocked:       blockBlocker,
-		VM:            vmWrappingProposerVM,
+		Config:           snowmanCommonCfg,
+		AllGetsServer:    snowGetHandler,
+		Blocked:          blockBlocker,
+		VM:               vmWrappingProposerVM,
+		ExecutionWorkers: m.BootstrapExecutionWorkers,
 	}
 	snowmanBootstrapper, err := smbootstrap.New(
 		bootstrapCfg,
@@ -919,7 +1123,7 @@ func (m *manager) createAvalancheChain(
 		StartupTracker:                 startupTracker,
 		Alpha:                          bootstrapWeight/2 + 1, // must be > 50%
 		Sender:                         avalancheMessageSender,
-		BootstrapTracker:               sb,
+		BootstrapTracker:               bootstrapTracker,
 		Timer:                          h,
 		RetryBootstrap:                 m.RetryBootstrap,
 		RetryBootstrapWarnFrequency:    m.RetryBootstrapWarnFrequency,
@@ -983,11 +1187,16 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
 	}
 
+	// Monitor the chain for stalls
+	m.Watchdog.Track(h)
+
 	return &chain{
 		Name:    chainAlias,
 		Context: ctx,
 		VM:      dagVM,
 		Handler: h,
+		ChainVM: vmWrappingProposerVM,
+		Stats:   stats,
 	}, nil
 }
 
@@ -1009,6 +1218,10 @@ func (m *manager) createSnowmanChain(
 		State: snow.Initializing,
 	})
 
+	// The VM is wrapped below, so the App message concurrency it requests must
+	// be looked up on the VM itself.
+	innerVM := vm
+
 	meterDBManager, err := m.DBManager.NewMeterDBManager("db", ctx.Registerer)
 	if err != nil {
 		return nil, err
@@ -1024,6 +1237,13 @@ func (m *manager) createSnowmanChain(
 		return nil, err
 	}
 
+	// Delivers queries from the consensus engine to this node directly to the
+	// chain's handler
+	loopback, err := sender.NewLoopback(ctx, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize loopback: %w", err)
+	}
+
 	// Passes messages from the consensus engine to the network
 	messageSender, err := sender.New(
 		ctx,
@@ -1033,6 +1253,8 @@ func (m *manager) createSnowmanChain(
 		m.TimeoutManager,
 		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
 		sb,
+		vdrs,
+		loopback,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
@@ -1052,6 +1274,16 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("problem initializing event dispatcher: %w", err)
 	}
 
+	err = m.BlockAcceptorGroup.RegisterAcceptor(
+		ctx.ChainID,
+		"watchdog",
+		m.Watchdog,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't register watchdog acceptor: %w", err)
+	}
+
 	var (
 		bootstrapFunc   func()
 		subnetConnector = validators.UnhandledSubnetConnector
@@ -1085,6 +1317,28 @@ func (m *manager) createSnowmanChain(
 			ctx.ValidatorState = validators.NewNoValidatorsState(ctx.ValidatorState)
 		}
 
+		// Pin the validator sets of the subnets whose configs override them.
+		m.validatorState, err = subnets.NewValidatorOverrideState(m.validatorState, m.SubnetConfigs)
+		if err != nil {
+			return nil, err
+		}
+		ctx.ValidatorState, err = subnets.NewValidatorOverrideState(ctx.ValidatorState, m.SubnetConfigs)
+		if err != nil {
+			return nil, err
+		}
+
+		// Cache the canonical validator sets that other chains use to verify
+		// warp messages.
+		m.validatorState, err = warp.NewCachedValidatorState(
+			m.validatorState,
+			warp.DefaultValidatorSetCacheSize,
+			"",
+			ctx.Registerer,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't initialize warp validator set cache: %w", err)
+		}
+
 		// Set this func only for platform
 		//
 		// The snowman bootstrapper ensures this function is only executed once, so
@@ -1100,6 +1354,11 @@ func (m *manager) createSnowmanChain(
 		}
 	}
 
+	ctx.Finality, err = m.newFinalityCertifier(ctx, db.Database, sb)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize finality certifier: %w", err)
+	}
+
 	// Initialize the ProposerVM and the vm wrapped inside it
 	chainConfig, err := m.getChainConfig(ctx.ChainID)
 	if err != nil {
@@ -1109,16 +1368,33 @@ func (m *manager) createSnowmanChain(
 	var (
 		minBlockDelay       = proposervm.DefaultMinBlockDelay
 		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		windowerConfig      = proposer.DefaultConfig
+		archive             objectstore.Store
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		if subnetCfg.ProposerMaxWindows > 0 {
+			windowerConfig.MaxWindows = subnetCfg.ProposerMaxWindows
+		}
+		if subnetCfg.ProposerWindowDuration > 0 {
+			windowerConfig.WindowDuration = subnetCfg.ProposerWindowDuration
+		}
+		if subnetCfg.ProposerArchiveURL != "" {
+			archive, err = newProposerArchive(subnetCfg.ProposerArchiveURL)
+			if err != nil {
+				return nil, fmt.Errorf("error while creating proposervm archive: %w", err)
+			}
+		}
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.ApricotPhase4Time),
 		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
 		zap.Duration("minBlockDelay", minBlockDelay),
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
+		zap.Int("maxProposerWindows", windowerConfig.MaxWindows),
+		zap.Duration("proposerWindowDuration", windowerConfig.WindowDuration),
+		zap.Bool("archivingBlocks", archive != nil),
 	)
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
@@ -1132,10 +1408,16 @@ func (m *manager) createSnowmanChain(
 		m.ApricotPhase4MinPChainHeight,
 		minBlockDelay,
 		numHistoricalBlocks,
+		windowerConfig,
+		archive,
 		m.stakingSigner,
 		m.stakingCert,
+		m.CacheBudget,
 	)
 
+	if m.AcceptWALEnabled {
+		vm = walvm.NewBlockVM(vm)
+	}
 	if m.MeterVMEnabled {
 		vm = metervm.NewBlockVM(vm)
 	}
@@ -1143,6 +1425,17 @@ func (m *manager) createSnowmanChain(
 		vm = tracedvm.NewBlockVM(vm, "proposervm", m.Tracer)
 	}
 
+	stats := newChainStatsTracker(vm)
+	err = m.BlockAcceptorGroup.RegisterAcceptor(
+		ctx.ChainID,
+		chainStatsAcceptorName,
+		stats,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't register stats acceptor: %w", err)
+	}
+
 	// The channel through which a VM may send messages to the consensus engine
 	// VM uses this channel to notify engine that a block is ready to be made
 	msgChan := make(chan common.Message, defaultChannelSize)
@@ -1175,6 +1468,12 @@ func (m *manager) createSnowmanChain(
 	}
 	vdrs.RegisterCallbackListener(connectedValidators)
 
+	appRequestPolicy, err := getAppRequestPolicy(innerVM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AppRequest policy: %w", err)
+	}
+	ctx.AppRequestPolicy.Set(appRequestPolicy)
+
 	// Asynchronously passes messages from the network to the consensus engine
 	h, err := handler.New(
 		ctx,
@@ -1182,6 +1481,7 @@ func (m *manager) createSnowmanChain(
 		msgChan,
 		m.AcceptedFrontierGossipFrequency,
 		m.ConsensusAppConcurrency,
+		getAppConcurrency(innerVM),
 		m.ResourceTracker,
 		subnetConnector,
 		sb,
@@ -1190,6 +1490,15 @@ func (m *manager) createSnowmanChain(
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize message handler: %w", err)
 	}
+	loopback.SetHandler(h)
+
+	bootstrapTracker := newBootstrapPublisher(
+		sb,
+		chainAlias,
+		ctx.ChainID,
+		ctx.SubnetID,
+		m.ChainBootstrapped,
+	)
 
 	connectedBeacons := tracker.NewPeers()
 	startupTracker := tracker.NewStartup(connectedBeacons, (3*bootstrapWeight+3)/4)
@@ -1202,7 +1511,7 @@ func (m *manager) createSnowmanChain(
 		StartupTracker:                 startupTracker,
 		Alpha:                          bootstrapWeight/2 + 1, // must be > 50%
 		Sender:                         messageSender,
-		BootstrapTracker:               sb,
+		BootstrapTracker:               bootstrapTracker,
 		Timer:                          h,
 		RetryBootstrap:                 m.RetryBootstrap,
 		RetryBootstrapWarnFrequency:    m.RetryBootstrapWarnFrequency,
@@ -1245,11 +1554,12 @@ func (m *manager) createSnowmanChain(
 
 	// create bootstrap gear
 	bootstrapCfg := smbootstrap.Config{
-		Config:        commonCfg,
-		AllGetsServer: snowGetHandler,
-		Blocked:       blocked,
-		VM:            vm,
-		Bootstrapped:  bootstrapFunc,
+		Config:           commonCfg,
+		AllGetsServer:    snowGetHandler,
+		Blocked:          blocked,
+		VM:               vm,
+		ExecutionWorkers: m.BootstrapExecutionWorkers,
+		Bootstrapped:     bootstrapFunc,
 	}
 	bootstrapper, err := smbootstrap.New(
 		bootstrapCfg,
@@ -1296,11 +1606,16 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
 	}
 
+	// Monitor the chain for stalls
+	m.Watchdog.Track(h)
+
 	return &chain{
 		Name:    chainAlias,
 		Context: ctx,
 		VM:      vm,
 		Handler: h,
+		ChainVM: vm,
+		Stats:   stats,
 	}, nil
 }
 
@@ -1312,7 +1627,7 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 		return false
 	}
 
-	return chain.Context().State.Get().State == snow.NormalOp
+	return chain.Context.State.Get().State == snow.NormalOp
 }
 
 func (m *manager) subnetsNotBootstrapped() []ids.ID {
@@ -1384,6 +1699,7 @@ func (m *manager) StartChainCreator(platformParams ChainParameters) error {
 	m.subnetsLock.Lock()
 	m.subnets[platformParams.SubnetID] = sb
 	sb.AddChain(platformParams.ID)
+	m.stagedChains.Add(platformParams.ID)
 	m.subnetsLock.Unlock()
 
 	// The P-chain is created synchronously to ensure that `VM.Initialize` has
@@ -1414,15 +1730,192 @@ func (m *manager) dispatchChainCreator() {
 		if !ok { // queue is closed, return directly
 			return
 		}
+
+		// All the chains that existed when the P-chain bootstrapped were
+		// queued before the chain creator was unblocked, so a dependency that
+		// isn't staged by now would never be created.
+		if untracked := m.untrackedDependencies(chainParams); len(untracked) != 0 {
+			m.failChainCreation(
+				chainParams,
+				fmt.Errorf("%w: %s", errUntrackedDependencies, untracked),
+			)
+			continue
+		}
+		if missing := m.missingDependencies(chainParams); len(missing) != 0 {
+			m.Log.Info("delaying chain creation",
+				zap.String("reason", "waiting for dependencies to bootstrap"),
+				zap.Stringer("subnetID", chainParams.SubnetID),
+				zap.Stringer("chainID", chainParams.ID),
+				zap.Stringers("dependencies", missing),
+			)
+			go m.awaitDependencies(chainParams)
+			continue
+		}
+		m.queueSubnetChain(chainParams)
+	}
+}
+
+// queueSubnetChain hands the chain to the goroutine that creates the chains of
+// its Subnet, starting the goroutine if needed.
+func (m *manager) queueSubnetChain(chainParams ChainParameters) {
+	m.subnetQueuesLock.Lock()
+	defer m.subnetQueuesLock.Unlock()
+
+	// The chain creator was shut down.
+	if m.subnetQueues == nil {
+		return
+	}
+
+	queue, ok := m.subnetQueues[chainParams.SubnetID]
+	if !ok {
+		queue = buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize)
+		m.subnetQueues[chainParams.SubnetID] = queue
+		go m.dispatchSubnetChainCreator(chainParams.SubnetID, queue)
+	}
+	if ok := queue.PushRight(chainParams); !ok {
+		m.Log.Warn("skipping chain creation",
+			zap.String("reason", "couldn't enqueue chain"),
+			zap.Stringer("subnetID", chainParams.SubnetID),
+			zap.Stringer("chainID", chainParams.ID),
+			zap.Stringer("vmID", chainParams.VMID),
+		)
+	}
+}
+
+// dispatchSubnetChainCreator creates the chains of the Subnet in the order they
+// are queued, without exceeding the Subnet's bootstrap concurrency.
+func (m *manager) dispatchSubnetChainCreator(subnetID ids.ID, queue buffer.BlockingDeque[ChainParameters]) {
+	m.subnetsLock.RLock()
+	sb := m.subnets[subnetID]
+	primaryNetwork := m.subnets[constants.PrimaryNetworkID]
+	m.subnetsLock.RUnlock()
+
+	if subnetID != constants.PrimaryNetworkID && m.BootstrapPrioritizePrimaryNetwork {
+		m.Log.Info("delaying chain creation",
+			zap.String("reason", "waiting for the primary network to bootstrap"),
+			zap.Stringer("subnetID", subnetID),
+		)
+		select {
+		case <-m.chainCreatorShutdownCh:
+			return
+		case <-primaryNetwork.OnBootstrapCompleted():
+		}
+	}
+
+	for {
+		chainParams, ok := queue.PopLeft()
+		if !ok { // queue is closed, return directly
+			return
+		}
+		if !sb.AcquireBootstrapSlot(chainParams.ID, m.chainCreatorShutdownCh) {
+			return
+		}
 		m.createChain(chainParams)
 	}
 }
 
+// missingDependencies returns the bootstrap dependencies of the chain that
+// haven't finished bootstrapping yet.
+func (m *manager) missingDependencies(chainParams ChainParameters) []ids.ID {
+	sbConfig, ok := m.SubnetConfigs[chainParams.SubnetID]
+	if !ok {
+		return nil
+	}
+
+	var missing []ids.ID
+	for _, dependency := range sbConfig.BootstrapDependencies[chainParams.ID] {
+		if !m.IsBootstrapped(dependency) {
+			missing = append(missing, dependency)
+		}
+	}
+	return missing
+}
+
+// untrackedDependencies returns the bootstrap dependencies of the chain that
+// aren't chains of a tracked Subnet.
+func (m *manager) untrackedDependencies(chainParams ChainParameters) []ids.ID {
+	sbConfig, ok := m.SubnetConfigs[chainParams.SubnetID]
+	if !ok {
+		return nil
+	}
+
+	m.subnetsLock.RLock()
+	defer m.subnetsLock.RUnlock()
+
+	var untracked []ids.ID
+	for _, dependency := range sbConfig.BootstrapDependencies[chainParams.ID] {
+		if !m.stagedChains.Contains(dependency) {
+			untracked = append(untracked, dependency)
+		}
+	}
+	return untracked
+}
+
+// awaitDependencies re-queues the chain once all of its bootstrap dependencies
+// have finished bootstrapping. If they haven't finished bootstrapping within
+// the Subnet's bootstrap dependency timeout, the chain isn't created.
+func (m *manager) awaitDependencies(chainParams ChainParameters) {
+	timeout := m.SubnetConfigs[chainParams.SubnetID].BootstrapDependencyTimeout
+	start := time.Now()
+	lastWarning := start
+
+	ticker := time.NewTicker(dependencyCheckFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.chainCreatorShutdownCh:
+			return
+		case <-ticker.C:
+		}
+
+		if missing := m.missingDependencies(chainParams); len(missing) != 0 {
+			now := time.Now()
+			waited := now.Sub(start)
+			if waited >= timeout {
+				m.failChainCreation(
+					chainParams,
+					fmt.Errorf("%w after %s: %s", errDependenciesTimeout, waited.Round(time.Second), missing),
+				)
+				return
+			}
+			if now.Sub(lastWarning) >= dependencyWarnFrequency {
+				m.Log.Warn("waiting for dependencies to bootstrap",
+					zap.Stringer("subnetID", chainParams.SubnetID),
+					zap.Stringer("chainID", chainParams.ID),
+					zap.Stringers("dependencies", missing),
+					zap.Duration("waited", waited),
+					zap.Duration("timeout", timeout),
+				)
+				lastWarning = now
+			}
+			continue
+		}
+		if ok := m.chainsQueue.PushRight(chainParams); !ok {
+			m.Log.Warn("skipping chain creation",
+				zap.String("reason", "couldn't enqueue chain"),
+				zap.Stringer("subnetID", chainParams.SubnetID),
+				zap.Stringer("chainID", chainParams.ID),
+				zap.Stringer("vmID", chainParams.VMID),
+			)
+		}
+		return
+	}
+}
+
 // Shutdown stops all the chains
 func (m *manager) closeChainCreator() {
 	m.Log.Info("stopping chain creator")
 	m.chainsQueue.Close()
 	close(m.chainCreatorShutdownCh)
+
+	m.subnetQueuesLock.Lock()
+	defer m.subnetQueuesLock.Unlock()
+
+	for _, queue := range m.subnetQueues {
+		queue.Close()
+	}
+	m.subnetQueues = nil
 }
 
 // Shutdown stops all the chains
@@ -1437,9 +1930,59 @@ func (m *manager) LookupVM(alias string) (ids.ID, error) {
 	return m.VMManager.Lookup(alias)
 }
 
+// newValidatorOverrideSet returns the validator set pinned by the config of
+// [subnetID]. Returns false if the validator set of the subnet isn't pinned.
+func (m *manager) newValidatorOverrideSet(subnetID ids.ID) (validators.Set, bool, error) {
+	sbConfig, ok := m.SubnetConfigs[subnetID]
+	if !ok {
+		return nil, false, nil
+	}
+	overrides, ok, err := sbConfig.ValidatorOverrideSet()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	// The pinned validators weren't added by a transaction, so their txIDs
+	// are left empty.
+	vdrs := validators.NewSet()
+	for _, vdr := range overrides {
+		if err := vdrs.Add(vdr.NodeID, vdr.PublicKey, ids.Empty, vdr.Weight); err != nil {
+			return nil, false, err
+		}
+	}
+	return vdrs, true, nil
+}
+
+// newFinalityCertifier returns the finality certifier of the chain, which
+// persists certificates in [db]. Returns nil if finality certificates aren't
+// enabled on the chain's subnet.
+func (m *manager) newFinalityCertifier(
+	ctx *snow.ConsensusContext,
+	db database.Database,
+	sb subnets.Subnet,
+) (finality.Certifier, error) {
+	if !sb.Config().FinalityCertificates {
+		return nil, nil
+	}
+	return finality.NewCertifier(
+		ctx.NetworkID,
+		ctx.ChainID,
+		ctx.SubnetID,
+		m.StakingBLSKey,
+		ctx.ValidatorState,
+		prefixdb.New(finalityDBPrefix, db),
+		ctx.Registerer,
+	)
+}
+
 // Notify registrants [those who want to know about the creation of chains]
 // that the specified chain has been created
 func (m *manager) notifyRegistrants(name string, ctx *snow.ConsensusContext, vm common.VM) {
+	// Chains of different Subnets are created concurrently, so registrants
+	// are notified one chain at a time.
+	m.registrantsLock.Lock()
+	defer m.registrantsLock.Unlock()
+
 	for _, registrant := range m.registrants {
 		registrant.RegisterChain(name, ctx, vm)
 	}
@@ -1447,6 +1990,35 @@ func (m *manager) notifyRegistrants(name string, ctx *snow.ConsensusContext, vm
 
 // getChainConfig returns value of a entry by looking at ID key and alias key
 // it first searches ID key, then falls back to it's corresponding primary alias
+// getGenesisData returns the genesis of the chain described by
+// [chainParams]. The genesis of a chain that isn't on the primary network is
+// replaced if an override was provided for the chain.
+func (m *manager) getGenesisData(chainParams ChainParameters) ([]byte, error) {
+	if chainParams.SubnetID == constants.PrimaryNetworkID {
+		return chainParams.GenesisData, nil
+	}
+
+	names, err := m.Aliases(chainParams.ID)
+	if err != nil {
+		return nil, err
+	}
+	names = append([]string{chainParams.ID.String()}, names...)
+	for _, name := range names {
+		genesisData, ok := m.ManagerConfig.ChainGenesisOverrides[name]
+		if !ok {
+			continue
+		}
+
+		m.Log.Warn("overriding chain genesis",
+			zap.Stringer("subnetID", chainParams.SubnetID),
+			zap.Stringer("chainID", chainParams.ID),
+			zap.String("override", name),
+		)
+		return genesisData, nil
+	}
+	return chainParams.GenesisData, nil
+}
+
 func (m *manager) getChainConfig(id ids.ID) (ChainConfig, error) {
 	if val, ok := m.ManagerConfig.ChainConfigs[id.String()]; ok {
 		return val, nil
@@ -1463,3 +2035,34 @@ func (m *manager) getChainConfig(id ids.ID) (ChainConfig, error) {
 
 	return ChainConfig{}, nil
 }
+
+// newProposerArchive returns the object store at [url] that pruned snowman++
+// blocks are archived to.
+func newProposerArchive(url string) (objectstore.Store, error) {
+	archive, err := objectstore.NewHTTP(url, objectstore.DefaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return objectstore.NewCached(archive, proposerArchiveCacheSize), nil
+}
+
+// getAppConcurrency returns the dedicated App message concurrency limits that
+// [vm] requested, if any.
+func getAppConcurrency(vm common.VM) map[message.Op]int {
+	concurrentVM, ok := vm.(common.ConcurrentAppHandler)
+	if !ok {
+		return nil
+	}
+	return concurrentVM.AppConcurrency()
+}
+
+// getAppRequestPolicy returns the policy that the AppRequests sent to [vm]
+// must satisfy.
+func getAppRequestPolicy(vm common.VM) (snow.AppRequestPolicy, error) {
+	gatedVM, ok := vm.(common.GatedAppHandler)
+	if !ok {
+		return snow.AppRequestPolicy{}, nil
+	}
+	policy := gatedVM.AppRequestPolicy()
+	return policy, policy.Verify()
+}
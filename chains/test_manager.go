@@ -4,6 +4,9 @@
 package chains
 
 import (
+	"context"
+	"time"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 )
@@ -42,6 +45,8 @@ func (testManager) Alias(ids.ID, string) error {
 
 func (testManager) RemoveAliases(ids.ID) {}
 
+func (testManager) RemoveAlias(string) {}
+
 func (testManager) Shutdown() {}
 
 func (testManager) StartChainCreator(ChainParameters) error {
@@ -56,6 +61,14 @@ func (testManager) IsBootstrapped(ids.ID) bool {
 	return false
 }
 
+func (testManager) Chains(context.Context) []ChainInfo {
+	return nil
+}
+
+func (testManager) ChainStats(context.Context, ids.ID, time.Duration) (ChainStats, error) {
+	return ChainStats{}, nil
+}
+
 func (testManager) Lookup(s string) (ids.ID, error) {
 	return ids.FromString(s)
 }
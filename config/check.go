@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/storage"
+)
+
+var (
+	errPortClash               = errors.New("http port is the same as the staking port")
+	errSelfBootstrapper        = errors.New("node is configured as one of its own bootstrappers")
+	errSelfStateSyncer         = errors.New("node is configured as one of its own state sync peers")
+	errUnknownChainConfigAlias = errors.New("chain config isn't for a known chain ID or alias")
+	errConflictingChainAlias   = errors.New("alias is used for multiple chains")
+)
+
+// GetNodeConfigDryRun parses the node config the same way as GetNodeConfig,
+// without persisting anything to disk, and then verifies the constraints
+// checked by CheckNodeConfig.
+//
+// If the staking key, certificate, or signer key don't exist on disk and
+// weren't explicitly provided, ephemeral ones are used rather than generating
+// new ones. As a result, the node ID of the returned config may differ from
+// the node ID that is used when the node is started.
+func GetNodeConfigDryRun(v *viper.Viper) (node.Config, error) {
+	if !v.IsSet(StakingTLSKeyPathKey) && !v.IsSet(StakingCertPathKey) && !v.IsSet(StakingTLSKeyContentKey) {
+		exists, err := filesExist(
+			GetExpandedArg(v, StakingTLSKeyPathKey),
+			GetExpandedArg(v, StakingCertPathKey),
+		)
+		if err != nil {
+			return node.Config{}, err
+		}
+		if !exists {
+			v.Set(StakingEphemeralCertEnabledKey, true)
+		}
+	}
+	if !v.IsSet(StakingSignerKeyPathKey) && !v.IsSet(StakingSignerKeyContentKey) {
+		exists, err := filesExist(GetExpandedArg(v, StakingSignerKeyPathKey))
+		if err != nil {
+			return node.Config{}, err
+		}
+		if !exists {
+			v.Set(StakingEphemeralSignerEnabledKey, true)
+		}
+	}
+
+	nodeConfig, err := GetNodeConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+	return nodeConfig, CheckNodeConfig(&nodeConfig)
+}
+
+// CheckNodeConfig verifies constraints between the fields of [nodeConfig]
+// that aren't verified while the individual fields are parsed.
+func CheckNodeConfig(nodeConfig *node.Config) error {
+	stakingPort := nodeConfig.IPPort.IPPort().Port
+	if nodeConfig.HTTPPort != 0 && nodeConfig.HTTPPort == stakingPort {
+		return fmt.Errorf("%w: %d", errPortClash, stakingPort)
+	}
+
+	if leaf := nodeConfig.StakingTLSCert.Leaf; leaf != nil {
+		nodeID := ids.NodeIDFromCert(staking.CertificateFromX509(leaf))
+		for _, bootstrapper := range nodeConfig.Bootstrappers {
+			if bootstrapper.ID == nodeID {
+				return fmt.Errorf("%w: %s", errSelfBootstrapper, nodeID)
+			}
+		}
+		for _, stateSyncID := range nodeConfig.StateSyncIDs {
+			if stateSyncID == nodeID {
+				return fmt.Errorf("%w: %s", errSelfStateSyncer, nodeID)
+			}
+		}
+	}
+
+	knownAliases := make(map[string]ids.ID)
+	_, genesisChainAliases, err := genesis.Aliases(nodeConfig.GenesisBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't get genesis chain aliases: %w", err)
+	}
+	for _, chainAliases := range []map[ids.ID][]string{genesisChainAliases, nodeConfig.ChainAliases} {
+		for chainID, aliases := range chainAliases {
+			for _, alias := range aliases {
+				if otherChainID, ok := knownAliases[alias]; ok && otherChainID != chainID {
+					return fmt.Errorf("%w: %q is used for %s and %s",
+						errConflictingChainAlias,
+						alias,
+						otherChainID,
+						chainID,
+					)
+				}
+				knownAliases[alias] = chainID
+			}
+		}
+	}
+
+	for alias := range nodeConfig.ChainConfigs {
+		if _, ok := knownAliases[alias]; ok {
+			continue
+		}
+		if _, err := ids.FromString(alias); err != nil {
+			return fmt.Errorf("%w: %q", errUnknownChainConfigAlias, alias)
+		}
+	}
+	return nil
+}
+
+func filesExist(paths ...string) (bool, error) {
+	for _, path := range paths {
+		exists, err := storage.FileExists(path)
+		if err != nil || !exists {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/ips"
+)
+
+func TestCheckNodeConfig(t *testing.T) {
+	genesisBytes, _, err := genesis.FromConfig(&genesis.LocalConfig)
+	require.NoError(t, err)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(t, err)
+	nodeID := ids.NodeIDFromCert(staking.CertificateFromX509(tlsCert.Leaf))
+
+	chainID := ids.GenerateTestID()
+	tests := []struct {
+		name        string
+		modify      func(*node.Config)
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			modify: func(*node.Config) {},
+		},
+		{
+			name: "http port is the staking port",
+			modify: func(c *node.Config) {
+				c.HTTPPort = 9651
+			},
+			expectedErr: errPortClash,
+		},
+		{
+			name: "self bootstrapper",
+			modify: func(c *node.Config) {
+				c.Bootstrappers = []genesis.Bootstrapper{{ID: nodeID}}
+			},
+			expectedErr: errSelfBootstrapper,
+		},
+		{
+			name: "self state syncer",
+			modify: func(c *node.Config) {
+				c.StateSyncIDs = []ids.NodeID{nodeID}
+			},
+			expectedErr: errSelfStateSyncer,
+		},
+		{
+			name: "alias used by a primary network chain",
+			modify: func(c *node.Config) {
+				c.ChainAliases = map[ids.ID][]string{
+					chainID: {"X"},
+				}
+			},
+			expectedErr: errConflictingChainAlias,
+		},
+		{
+			name: "chain config for a custom alias",
+			modify: func(c *node.Config) {
+				c.ChainAliases = map[ids.ID][]string{
+					chainID: {"custom"},
+				}
+				c.ChainConfigs["custom"] = chains.ChainConfig{}
+			},
+		},
+		{
+			name: "chain config for an unknown alias",
+			modify: func(c *node.Config) {
+				c.ChainConfigs["unknown"] = chains.ChainConfig{}
+			},
+			expectedErr: errUnknownChainConfigAlias,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodeConfig := node.Config{
+				HTTPConfig: node.HTTPConfig{
+					HTTPPort: 9650,
+				},
+				IPConfig: node.IPConfig{
+					IPPort: ips.NewDynamicIPPort(net.IPv4zero, 9651),
+				},
+				StakingConfig: node.StakingConfig{
+					StakingTLSCert: *tlsCert,
+				},
+				GenesisBytes: genesisBytes,
+				ChainConfigs: map[string]chains.ChainConfig{
+					"C":              {},
+					chainID.String(): {},
+				},
+			}
+			test.modify(&nodeConfig)
+
+			err := CheckNodeConfig(&nodeConfig)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/base64"
@@ -15,25 +16,35 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 
+	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/cache/budget"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/hooks"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ipcs"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/notify"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/snow/networking/watchdog"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/trace"
@@ -46,11 +57,14 @@ import (
 	"github.com/ava-labs/avalanchego/utils/password"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
+	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/selfcheck"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/storage"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/proposervm"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 )
 
 const (
@@ -58,6 +72,10 @@ const (
 	chainUpgradeFileName = "upgrade"
 	subnetConfigFileExt  = ".json"
 	ipResolutionTimeout  = 30 * time.Second
+
+	// The default cache memory budget of a container is its memory limit
+	// divided by defaultCacheMemoryBudgetDivisor.
+	defaultCacheMemoryBudgetDivisor = 4
 )
 
 var (
@@ -67,6 +85,7 @@ var (
 
 	errSybilProtectionDisabledStakerWeights   = errors.New("sybil protection disabled weights must be positive")
 	errSybilProtectionDisabledOnPublicNetwork = errors.New("sybil protection disabled on public network")
+	errValidatorOverridesOnPublicNetwork      = errors.New("validator overrides on public network")
 	errAuthPasswordTooWeak                    = errors.New("API auth password is not strong enough")
 	errInvalidUptimeRequirement               = errors.New("uptime requirement must be in the range [0, 1]")
 	errMinValidatorStakeAboveMax              = errors.New("minimum validator stake can't be greater than maximum validator stake")
@@ -80,11 +99,16 @@ var (
 	errStakingKeyContentUnset                 = fmt.Errorf("%s key not set but %s set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset                = fmt.Errorf("%s key set but %s not set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errMissingStakingSigningKeyFile           = errors.New("missing staking signing key file")
+	errStakingStandbyPathUnset                = fmt.Errorf("%s and %s must be set together", StakingStandbyTLSKeyPathKey, StakingStandbyCertPathKey)
+	errStakingStandbyRequiresFiles            = fmt.Errorf("%s can't be set with %s", StakingStandbyTLSKeyPathKey, StakingTLSKeyContentKey)
+	errStakingStandbySameAsActive             = errors.New("standby staking certificate is the same as the active staking certificate")
 	errTracingEndpointEmpty                   = fmt.Errorf("%s cannot be empty", TracingEndpointKey)
 	errPluginDirNotADirectory                 = errors.New("plugin dir is not a directory")
 	errCannotReadDirectory                    = errors.New("cannot read directory")
 	errUnmarshalling                          = errors.New("unmarshalling failed")
 	errFileDoesNotExist                       = errors.New("file does not exist")
+	errDuplicateGenesisOverride               = errors.New("multiple genesis overrides for chain")
+	errOpMaxBurstWithoutRate                  = errors.New("op max burst set without a rate")
 )
 
 func getConsensusConfig(v *viper.Viper) snowball.Parameters {
@@ -207,23 +231,52 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		}
 	}
 
+	maxRequestBodySize := v.GetInt64(HTTPMaxRequestBodySizeKey)
+	if maxRequestBodySize < 0 {
+		return node.HTTPConfig{}, fmt.Errorf("%q must be >= 0", HTTPMaxRequestBodySizeKey)
+	}
+	routePolicies, err := getRoutePolicies(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	chainMiddlewares, err := getChainMiddlewares(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	slowRequestLog, err := getSlowRequestLogConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	loadShedding, err := getLoadSheddingConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+
 	config := node.HTTPConfig{
 		HTTPConfig: server.HTTPConfig{
-			ReadTimeout:       v.GetDuration(HTTPReadTimeoutKey),
-			ReadHeaderTimeout: v.GetDuration(HTTPReadHeaderTimeoutKey),
-			WriteTimeout:      v.GetDuration(HTTPWriteTimeoutKey),
-			IdleTimeout:       v.GetDuration(HTTPIdleTimeoutKey),
+			ReadTimeout:        v.GetDuration(HTTPReadTimeoutKey),
+			ReadHeaderTimeout:  v.GetDuration(HTTPReadHeaderTimeoutKey),
+			WriteTimeout:       v.GetDuration(HTTPWriteTimeoutKey),
+			IdleTimeout:        v.GetDuration(HTTPIdleTimeoutKey),
+			MaxRequestBodySize: maxRequestBodySize,
+			RoutePolicies:      routePolicies,
+			MaxBatchSize:       int(v.GetUint(HTTPMaxBatchSizeKey)),
+			ChainMiddlewares:   chainMiddlewares,
+			SlowRequestLog:     slowRequestLog,
+			LoadShedding:       loadShedding,
 		},
 		APIConfig: node.APIConfig{
 			APIIndexerConfig: node.APIIndexerConfig{
 				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
 				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
 			},
-			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
-			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
-			KeystoreAPIEnabled: v.GetBool(KeystoreAPIEnabledKey),
-			MetricsAPIEnabled:  v.GetBool(MetricsAPIEnabledKey),
-			HealthAPIEnabled:   v.GetBool(HealthAPIEnabledKey),
+			AdminAPIEnabled:        v.GetBool(AdminAPIEnabledKey),
+			InfoAPIEnabled:         v.GetBool(InfoAPIEnabledKey),
+			KeystoreAPIEnabled:     v.GetBool(KeystoreAPIEnabledKey),
+			KeystoreSignerEndpoint: v.GetString(KeystoreSignerEndpointKey),
+			KeystoreSignerCAFile:   GetExpandedArg(v, KeystoreSignerCAFileKey),
+			MetricsAPIEnabled:      v.GetBool(MetricsAPIEnabledKey),
+			HealthAPIEnabled:       v.GetBool(HealthAPIEnabledKey),
 		},
 		HTTPHost:           v.GetString(HTTPHostKey),
 		HTTPPort:           uint16(v.GetUint(HTTPPortKey)),
@@ -287,6 +340,46 @@ func getAdaptiveTimeoutConfig(v *viper.Viper) (timer.AdaptiveTimeoutConfig, erro
 	return config, nil
 }
 
+func getInboundOpRateLimits(v *viper.Viper) (map[message.Op]throttling.OpRateLimit, error) {
+	rates := v.GetStringMapString(InboundThrottlerOpRatesKey)
+	maxBursts := v.GetStringMapString(InboundThrottlerOpMaxBurstsKey)
+	for opStr := range maxBursts {
+		if _, ok := rates[opStr]; !ok {
+			return nil, fmt.Errorf("%w: %q", errOpMaxBurstWithoutRate, opStr)
+		}
+	}
+	if len(rates) == 0 {
+		return nil, nil
+	}
+
+	limits := make(map[message.Op]throttling.OpRateLimit, len(rates))
+	for opStr, rateStr := range rates {
+		op, err := message.ExternalOpFromString(opStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, InboundThrottlerOpRatesKey)
+		}
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %q for %q: %w", InboundThrottlerOpRatesKey, opStr, err)
+		}
+		limit := throttling.OpRateLimit{
+			Rate:     rate,
+			MaxBurst: int(math.Ceil(rate)),
+		}
+		if maxBurstStr, ok := maxBursts[opStr]; ok {
+			limit.MaxBurst, err = strconv.Atoi(maxBurstStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %q for %q: %w", InboundThrottlerOpMaxBurstsKey, opStr, err)
+			}
+		}
+		if err := limit.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid rate limit for %q: %w", opStr, err)
+		}
+		limits[op] = limit
+	}
+	return limits, nil
+}
+
 func getGossipConfig(v *viper.Viper) subnets.GossipConfig {
 	return subnets.GossipConfig{
 		AcceptedFrontierValidatorSize:    uint(v.GetUint32(ConsensusGossipAcceptedFrontierValidatorSizeKey)),
@@ -298,6 +391,11 @@ func getGossipConfig(v *viper.Viper) subnets.GossipConfig {
 		AppGossipValidatorSize:           uint(v.GetUint32(AppGossipValidatorSizeKey)),
 		AppGossipNonValidatorSize:        uint(v.GetUint32(AppGossipNonValidatorSizeKey)),
 		AppGossipPeerSize:                uint(v.GetUint32(AppGossipPeerSizeKey)),
+		RedundancyValidatorSize:          uint(v.GetUint32(ConsensusGossipRedundancyValidatorSizeKey)),
+		RedundancyMaxLevel:               uint(v.GetUint32(ConsensusGossipRedundancyMaxLevelKey)),
+		RedundancyHighFailureRate:        v.GetFloat64(ConsensusGossipRedundancyHighFailureRateKey),
+		RedundancyLowFailureRate:         v.GetFloat64(ConsensusGossipRedundancyLowFailureRateKey),
+		RedundancyWindow:                 v.GetDuration(ConsensusGossipRedundancyWindowKey),
 	}
 }
 
@@ -318,6 +416,24 @@ func getNetworkConfig(
 	if err != nil {
 		return network.Config{}, err
 	}
+	compressionTypes, err := compression.TypesFromStrings(v.GetStringSlice(NetworkCompressionTypesKey))
+	if err != nil {
+		return network.Config{}, fmt.Errorf("%w: %s", err, NetworkCompressionTypesKey)
+	}
+
+	opRateLimits, err := getInboundOpRateLimits(v)
+	if err != nil {
+		return network.Config{}, err
+	}
+
+	tlsMinVersion, err := peer.ParseTLSVersion(v.GetString(NetworkTLSMinVersionKey))
+	if err != nil {
+		return network.Config{}, fmt.Errorf("%w: %s", err, NetworkTLSMinVersionKey)
+	}
+	tlsCipherSuites, err := peer.ParseCipherSuites(v.GetStringSlice(NetworkTLSCipherSuitesKey), tlsMinVersion)
+	if err != nil {
+		return network.Config{}, fmt.Errorf("%w: %s", err, NetworkTLSCipherSuitesKey)
+	}
 
 	allowPrivateIPs := !constants.ProductionNetworkIDs.Contains(networkID)
 	if v.IsSet(NetworkAllowPrivateIPsKey) {
@@ -349,6 +465,7 @@ func getNetworkConfig(
 				DiskThrottlerConfig: throttling.SystemThrottlerConfig{
 					MaxRecheckDelay: v.GetDuration(InboundThrottlerDiskMaxRecheckDelayKey),
 				},
+				OpRateLimits: opRateLimits,
 			},
 
 			OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
@@ -374,10 +491,24 @@ func getNetworkConfig(
 		DialerConfig: dialer.Config{
 			ThrottleRps:       v.GetUint32(NetworkOutboundConnectionThrottlingRpsKey),
 			ConnectionTimeout: v.GetDuration(NetworkOutboundConnectionTimeoutKey),
+			FailureThrottlerConfig: throttling.DialFailureThrottlerConfig{
+				Threshold:      int(v.GetUint(NetworkDialFailurePenaltyThresholdKey)),
+				InitialPenalty: v.GetDuration(NetworkDialFailurePenaltyInitialKey),
+				MaxPenalty:     v.GetDuration(NetworkDialFailurePenaltyMaxKey),
+			},
 		},
 
+		MaxConcurrentDials: int(v.GetUint(NetworkOutboundConnectionMaxConcurrentKey)),
+
 		TLSKeyLogFile: v.GetString(NetworkTLSKeyLogFileKey),
 
+		TLSSessionResumptionEnabled:     v.GetBool(NetworkTLSSessionResumptionEnabledKey),
+		TLSSessionTicketKeyRotationFreq: v.GetDuration(NetworkTLSSessionTicketKeyRotationFreqKey),
+		TLSSessionTicketKeys:            v.GetInt(NetworkTLSSessionTicketKeysKey),
+
+		TLSMinVersion:   tlsMinVersion,
+		TLSCipherSuites: tlsCipherSuites,
+
 		TimeoutConfig: network.TimeoutConfig{
 			PingPongTimeout:      v.GetDuration(NetworkPingTimeoutKey),
 			ReadHandshakeTimeout: v.GetDuration(NetworkReadHandshakeTimeoutKey),
@@ -398,6 +529,8 @@ func getNetworkConfig(
 
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
 		CompressionType:              compressionType,
+		CompressionTypes:             compressionTypes,
+		CompressionZstdLevel:         v.GetInt(NetworkCompressionZstdLevelKey),
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
 		AllowPrivateIPs:              allowPrivateIPs,
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
@@ -406,6 +539,20 @@ func getNetworkConfig(
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+		PeerWorkerPoolSize:        int(v.GetUint(NetworkPeerWorkerPoolSizeKey)),
+		ZeroCopyPayloads:          v.GetBool(NetworkZeroCopyPayloadsKey),
+
+		RetiringAnnouncementEnabled: v.GetBool(NetworkRetiringAnnouncementEnabledKey),
+
+		ReputationConfig: reputation.Config{
+			Enabled:                 v.GetBool(NetworkReputationEnabledKey),
+			HandshakeFailurePenalty: v.GetFloat64(NetworkReputationHandshakeFailurePenaltyKey),
+			InvalidMessagePenalty:   v.GetFloat64(NetworkReputationInvalidMessagePenaltyKey),
+			BenchedPenalty:          v.GetFloat64(NetworkReputationBenchedPenaltyKey),
+			PenaltyHalflife:         v.GetDuration(NetworkReputationPenaltyHalflifeKey),
+			MaxPenalty:              v.GetFloat64(NetworkReputationMaxPenaltyKey),
+			MaxTrackedPeers:         v.GetInt(NetworkReputationMaxTrackedPeersKey),
+		},
 	}
 
 	switch {
@@ -419,6 +566,10 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be in [0,1]", NetworkHealthMaxPortionSendQueueFillKey)
 	case config.DialerConfig.ConnectionTimeout < 0:
 		return network.Config{}, fmt.Errorf("%q must be >= 0", NetworkOutboundConnectionTimeoutKey)
+	case config.DialerConfig.FailureThrottlerConfig.InitialPenalty < 0:
+		return network.Config{}, fmt.Errorf("%q must be >= 0", NetworkDialFailurePenaltyInitialKey)
+	case config.DialerConfig.FailureThrottlerConfig.MaxPenalty < config.DialerConfig.FailureThrottlerConfig.InitialPenalty:
+		return network.Config{}, fmt.Errorf("%q must be >= %q", NetworkDialFailurePenaltyMaxKey, NetworkDialFailurePenaltyInitialKey)
 	case config.PeerListGossipFreq < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerListGossipFreqKey)
 	case config.ThrottlerConfig.InboundMsgThrottlerConfig.CPUThrottlerConfig.MaxRecheckDelay < constants.MinInboundThrottlerMaxRecheckDelay:
@@ -431,6 +582,12 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkInitialReconnectDelayKey)
 	case config.MaxReconnectDelay < config.InitialReconnectDelay:
 		return network.Config{}, fmt.Errorf("%s must be >= %s", NetworkMaxReconnectDelayKey, NetworkInitialReconnectDelayKey)
+	case config.TLSSessionResumptionEnabled && config.TLSSessionTicketKeyRotationFreq <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkTLSSessionTicketKeyRotationFreqKey)
+	case config.TLSSessionResumptionEnabled && config.TLSSessionTicketKeys < 1:
+		return network.Config{}, fmt.Errorf("%s must be >= 1", NetworkTLSSessionTicketKeysKey)
+	case config.CompressionZstdLevel < compression.MinZstdLevel || config.CompressionZstdLevel > compression.MaxZstdLevel:
+		return network.Config{}, fmt.Errorf("%s must be in [%d, %d]", NetworkCompressionZstdLevelKey, compression.MinZstdLevel, compression.MaxZstdLevel)
 	case config.PingPongTimeout < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPingTimeoutKey)
 	case config.PingFrequency < 0:
@@ -441,6 +598,52 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.ReputationConfig.HandshakeFailurePenalty < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationHandshakeFailurePenaltyKey)
+	case config.ReputationConfig.InvalidMessagePenalty < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationInvalidMessagePenaltyKey)
+	case config.ReputationConfig.BenchedPenalty < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationBenchedPenaltyKey)
+	case config.ReputationConfig.Enabled && config.ReputationConfig.PenaltyHalflife <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkReputationPenaltyHalflifeKey)
+	case config.ReputationConfig.MaxPenalty < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationMaxPenaltyKey)
+	case config.ReputationConfig.Enabled && config.ReputationConfig.MaxTrackedPeers <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkReputationMaxTrackedPeersKey)
+	}
+	return config, nil
+}
+
+func getCacheBudgetConfig(v *viper.Viper, limits resource.Limits) (budget.Config, error) {
+	config := budget.Config{
+		Size:               v.GetUint64(CacheMemoryBudgetKey),
+		RebalanceFrequency: v.GetDuration(CacheMemoryBudgetRebalanceFrequencyKey),
+		MaxGCCPUFraction:   v.GetFloat64(CacheMemoryBudgetMaxGCCPUFractionKey),
+	}
+	// The independently sized caches are tuned for hosts with plenty of
+	// memory, so a container with a memory limit shares a budget instead.
+	if !v.IsSet(CacheMemoryBudgetKey) && limits.MemoryLimit > 0 {
+		config.Size = limits.Memory() / defaultCacheMemoryBudgetDivisor
+	}
+	switch {
+	case config.RebalanceFrequency < 0:
+		return budget.Config{}, fmt.Errorf("%q must be >= 0", CacheMemoryBudgetRebalanceFrequencyKey)
+	case config.MaxGCCPUFraction < 0 || config.MaxGCCPUFraction > 1:
+		return budget.Config{}, fmt.Errorf("%q must be in the range [0, 1]", CacheMemoryBudgetMaxGCCPUFractionKey)
+	}
+	return config, nil
+}
+
+func getSharedMemoryGCConfig(v *viper.Viper) (atomic.GCConfig, error) {
+	config := atomic.GCConfig{
+		Retention: v.GetDuration(SharedMemoryGCRetentionKey),
+		Frequency: v.GetDuration(SharedMemoryGCFrequencyKey),
+	}
+	switch {
+	case config.Retention < 0:
+		return atomic.GCConfig{}, fmt.Errorf("%q must be >= 0", SharedMemoryGCRetentionKey)
+	case config.Frequency < 0:
+		return atomic.GCConfig{}, fmt.Errorf("%q must be >= 0", SharedMemoryGCFrequencyKey)
 	}
 	return config, nil
 }
@@ -460,7 +663,33 @@ func getBenchlistConfig(v *viper.Viper, consensusParameters snowball.Parameters)
 	case config.MinimumFailingDuration < 0:
 		return benchlist.Config{}, fmt.Errorf("%q must be >= 0", BenchlistMinFailingDurationKey)
 	}
-	return config, nil
+
+	var (
+		benchesBytes []byte
+		err          error
+	)
+	switch {
+	case v.IsSet(BenchlistContentKey):
+		benchesContent := v.GetString(BenchlistContentKey)
+		benchesBytes, err = base64.StdEncoding.DecodeString(benchesContent)
+		if err != nil {
+			return benchlist.Config{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(BenchlistFileKey):
+		path := GetExpandedArg(v, BenchlistFileKey)
+		benchesBytes, err = os.ReadFile(path)
+		if err != nil {
+			return benchlist.Config{}, err
+		}
+	default:
+		return config, nil
+	}
+
+	config.StaticBenches, err = benchlist.ParseStaticBenches(benchesBytes)
+	if err != nil {
+		return benchlist.Config{}, fmt.Errorf("%w on benchlist file: %w", errUnmarshalling, err)
+	}
+	return config, benchlist.VerifyStaticBenches(config.StaticBenches)
 }
 
 func getStateSyncConfig(v *viper.Viper) (node.StateSyncConfig, error) {
@@ -509,6 +738,8 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		BootstrapMaxTimeGetAncestors:            v.GetDuration(BootstrapMaxTimeGetAncestorsKey),
 		BootstrapAncestorsMaxContainersSent:     int(v.GetUint(BootstrapAncestorsMaxContainersSentKey)),
 		BootstrapAncestorsMaxContainersReceived: int(v.GetUint(BootstrapAncestorsMaxContainersReceivedKey)),
+		BootstrapExecutionWorkers:               int(v.GetUint(BootstrapExecutionWorkersKey)),
+		BootstrapPrioritizePrimaryNetwork:       v.GetBool(BootstrapPrioritizePrimaryNetworkKey),
 	}
 
 	// TODO: Add a "BootstrappersKey" flag to more clearly enforce ID and IP
@@ -644,6 +875,21 @@ func getProfilerConfig(v *viper.Viper) (profiler.Config, error) {
 	return config, nil
 }
 
+func getWatchdogConfig(v *viper.Viper) (watchdog.Config, error) {
+	config := watchdog.Config{
+		StallTimeout: v.GetDuration(WatchdogStallTimeoutKey),
+		Frequency:    v.GetDuration(WatchdogFrequencyKey),
+		ProfileDir:   filepath.Join(GetExpandedArg(v, ProfileDirKey), "watchdog"),
+	}
+	switch {
+	case config.StallTimeout < 0:
+		return watchdog.Config{}, fmt.Errorf("%s must be >= 0", WatchdogStallTimeoutKey)
+	case config.Enabled() && config.Frequency <= 0:
+		return watchdog.Config{}, fmt.Errorf("%s must be > 0", WatchdogFrequencyKey)
+	}
+	return config, nil
+}
+
 func getStakingTLSCertFromFlag(v *viper.Viper) (tls.Certificate, error) {
 	stakingKeyRawContent := v.GetString(StakingTLSKeyContentKey)
 	stakingKeyContent, err := base64.StdEncoding.DecodeString(stakingKeyRawContent)
@@ -714,6 +960,28 @@ func getStakingTLSCert(v *viper.Viper) (tls.Certificate, error) {
 	}
 }
 
+// getStakingStandbyKeyPair returns the paths of the standby staking key pair.
+// Returns the zero value if no standby key pair is used.
+func getStakingStandbyKeyPair(v *viper.Viper) (staking.KeyPairPaths, error) {
+	keyPair := staking.KeyPairPaths{
+		KeyPath:  GetExpandedArg(v, StakingStandbyTLSKeyPathKey),
+		CertPath: GetExpandedArg(v, StakingStandbyCertPathKey),
+	}
+	switch {
+	case keyPair.KeyPath == "" && keyPair.CertPath == "":
+		return staking.KeyPairPaths{}, nil
+	case keyPair.KeyPath == "" || keyPair.CertPath == "":
+		return staking.KeyPairPaths{}, errStakingStandbyPathUnset
+	case v.GetBool(StakingEphemeralCertEnabledKey):
+		// An ephemeral identity is never persisted, so it can't be switched.
+		return staking.KeyPairPaths{}, nil
+	case v.IsSet(StakingTLSKeyContentKey) || v.IsSet(StakingCertContentKey):
+		return staking.KeyPairPaths{}, errStakingStandbyRequiresFiles
+	default:
+		return keyPair, nil
+	}
+}
+
 func getStakingSigner(v *viper.Viper) (*bls.SecretKey, error) {
 	if v.GetBool(StakingEphemeralSignerEnabledKey) {
 		key, err := bls.NewSecretKey()
@@ -791,10 +1059,35 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 	}
 
 	var err error
+	config.StakingStandbyKeyPair, err = getStakingStandbyKeyPair(v)
+	if err != nil {
+		return node.StakingConfig{}, err
+	}
+	activeKeyPair := staking.KeyPairPaths{
+		KeyPath:  config.StakingKeyPath,
+		CertPath: config.StakingCertPath,
+	}
+	if config.StakingStandbyKeyPair != (staking.KeyPairPaths{}) {
+		// Complete or discard a switch to the standby key pair that was
+		// interrupted before the node restarted.
+		if err := staking.RecoverKeyPairSwitch(activeKeyPair, config.StakingStandbyKeyPair); err != nil {
+			return node.StakingConfig{}, fmt.Errorf("couldn't recover staking key pair switch: %w", err)
+		}
+	}
+
 	config.StakingTLSCert, err = getStakingTLSCert(v)
 	if err != nil {
 		return node.StakingConfig{}, err
 	}
+	if config.StakingStandbyKeyPair != (staking.KeyPairPaths{}) {
+		standbyCert, err := config.StakingStandbyKeyPair.Load()
+		if err != nil {
+			return node.StakingConfig{}, fmt.Errorf("couldn't read standby staking certificate: %w", err)
+		}
+		if bytes.Equal(standbyCert.Raw, config.StakingTLSCert.Leaf.Raw) {
+			return node.StakingConfig{}, errStakingStandbySameAsActive
+		}
+	}
 	config.StakingSigningKey, err = getStakingSigner(v)
 	if err != nil {
 		return node.StakingConfig{}, err
@@ -847,6 +1140,7 @@ func getTxFeeConfig(v *viper.Viper, networkID uint32) genesis.TxFeeConfig {
 			AddPrimaryNetworkDelegatorFee: v.GetUint64(AddPrimaryNetworkDelegatorFeeKey),
 			AddSubnetValidatorFee:         v.GetUint64(AddSubnetValidatorFeeKey),
 			AddSubnetDelegatorFee:         v.GetUint64(AddSubnetDelegatorFeeKey),
+			MinOutputAmount:               v.GetUint64(MinOutputAmountKey),
 		}
 	}
 	return genesis.GetTxFeeConfig(networkID)
@@ -1027,10 +1321,58 @@ func getChainConfigsFromDir(v *viper.Viper) (map[string]chains.ChainConfig, erro
 
 // getChainConfigs reads & puts chainConfigs to node config
 func getChainConfigs(v *viper.Viper) (map[string]chains.ChainConfig, error) {
+	var (
+		chainConfigs map[string]chains.ChainConfig
+		err          error
+	)
 	if v.IsSet(ChainConfigContentKey) {
-		return getChainConfigsFromFlag(v)
+		chainConfigs, err = getChainConfigsFromFlag(v)
+	} else {
+		chainConfigs, err = getChainConfigsFromDir(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return chainConfigs, getInterpolator(v).interpolateChainConfigs(chainConfigs)
+}
+
+// getChainGenesisOverrides reads the genesis overrides of chains. Overrides
+// are keyed by the name of their file, without its extension, which is
+// expected to be a chainID or an alias of the chain.
+func getChainGenesisOverrides(v *viper.Viper) (map[string][]byte, error) {
+	overrides := make(map[string][]byte)
+	if !v.IsSet(ChainGenesisOverrideDirKey) {
+		return overrides, nil
+	}
+
+	overridePath, err := getPathFromDirKey(v, ChainGenesisOverrideDirKey)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := os.ReadDir(overridePath)
+	if err != nil {
+		return nil, err
 	}
-	return getChainConfigsFromDir(v)
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		// chaingenesisoverridedir/chainId.*
+		fileName := file.Name()
+		chain := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+		if _, ok := overrides[chain]; ok {
+			return nil, fmt.Errorf("%w %q", errDuplicateGenesisOverride, chain)
+		}
+
+		genesisBytes, err := os.ReadFile(filepath.Join(overridePath, fileName))
+		if err != nil {
+			return nil, err
+		}
+		overrides[chain] = genesisBytes
+	}
+	return overrides, nil
 }
 
 // ReadsChainConfigs reads chain config files from static directories and returns map with contents,
@@ -1086,6 +1428,10 @@ func getSubnetConfigsFromFlags(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]s
 	if err != nil {
 		return nil, fmt.Errorf("unable to decode base64 content: %w", err)
 	}
+	subnetConfigContent, err = getInterpolator(v).Interpolate(subnetConfigContent)
+	if err != nil {
+		return nil, fmt.Errorf("unable to interpolate subnet configs: %w", err)
+	}
 
 	// partially parse configs to be filled by defaults later
 	subnetConfigs := make(map[ids.ID]json.RawMessage, len(subnetIDs))
@@ -1124,6 +1470,8 @@ func getSubnetConfigsFromDir(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]sub
 		return subnetConfigs, nil
 	}
 
+	interpolator := getInterpolator(v)
+
 	// reads subnet config files from a path and given subnetIDs and returns a map.
 	for _, subnetID := range subnetIDs {
 		filePath := filepath.Join(subnetConfigPath, subnetID.String()+subnetConfigFileExt)
@@ -1143,6 +1491,10 @@ func getSubnetConfigsFromDir(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]sub
 		if err != nil {
 			return nil, err
 		}
+		file, err = interpolator.Interpolate(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to interpolate %q: %w", filePath, err)
+		}
 
 		config := getDefaultSubnetConfig(v)
 		if err := json.Unmarshal(file, &config); err != nil {
@@ -1166,6 +1518,9 @@ func getDefaultSubnetConfig(v *viper.Viper) subnets.Config {
 		GossipConfig:                getGossipConfig(v),
 		ProposerMinBlockDelay:       proposervm.DefaultMinBlockDelay,
 		ProposerNumHistoricalBlocks: proposervm.DefaultNumHistoricalBlocks,
+		ProposerMaxWindows:          proposer.MaxWindows,
+		ProposerWindowDuration:      proposer.WindowDuration,
+		BootstrapDependencyTimeout:  subnets.DefaultBootstrapDependencyTimeout,
 	}
 }
 
@@ -1251,6 +1606,188 @@ func getTraceConfig(v *viper.Viper) (trace.Config, error) {
 	}, nil
 }
 
+func getMetricsPushConfig(v *viper.Viper) (metrics.PushConfig, error) {
+	if !v.GetBool(MetricsPushEnabledKey) {
+		return metrics.PushConfig{}, nil
+	}
+
+	pushType, err := metrics.PushTypeFromString(v.GetString(MetricsPushTypeKey))
+	if err != nil {
+		return metrics.PushConfig{}, err
+	}
+
+	config := metrics.PushConfig{
+		Enabled:   true,
+		Type:      pushType,
+		Endpoint:  v.GetString(MetricsPushEndpointKey),
+		Frequency: v.GetDuration(MetricsPushFrequencyKey),
+		Labels:    v.GetStringMapString(MetricsPushLabelsKey),
+	}
+	if err := config.Verify(); err != nil {
+		return metrics.PushConfig{}, fmt.Errorf("invalid metrics push config: %w", err)
+	}
+	return config, nil
+}
+
+func getRoutePolicies(v *viper.Viper) (map[string]server.RoutePolicy, error) {
+	var (
+		policiesBytes []byte
+		err           error
+	)
+	if v.IsSet(HTTPRoutePoliciesContentKey) {
+		policiesContent := v.GetString(HTTPRoutePoliciesContentKey)
+		policiesBytes, err = base64.StdEncoding.DecodeString(policiesContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	} else if v.IsSet(HTTPRoutePoliciesFileKey) {
+		path := GetExpandedArg(v, HTTPRoutePoliciesFileKey)
+		policiesBytes, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(policiesBytes) == 0 {
+		return nil, nil
+	}
+
+	var policies map[string]server.RoutePolicy
+	if err := json.Unmarshal(policiesBytes, &policies); err != nil {
+		return nil, fmt.Errorf("%w on route policies: %w", errUnmarshalling, err)
+	}
+	return policies, server.VerifyRoutePolicies(policies)
+}
+
+func getSlowRequestLogConfig(v *viper.Viper) (server.SlowRequestLogConfig, error) {
+	config := server.SlowRequestLogConfig{
+		Threshold: v.GetDuration(HTTPSlowRequestThresholdKey),
+	}
+	thresholds := v.GetStringMapString(HTTPSlowRequestThresholdsKey)
+	if len(thresholds) > 0 {
+		config.Thresholds = make(map[string]time.Duration, len(thresholds))
+	}
+	for key, thresholdStr := range thresholds {
+		threshold, err := time.ParseDuration(thresholdStr)
+		if err != nil {
+			return server.SlowRequestLogConfig{}, fmt.Errorf("invalid %q for %q: %w", HTTPSlowRequestThresholdsKey, key, err)
+		}
+		config.Thresholds[key] = threshold
+	}
+	if err := config.Verify(); err != nil {
+		return server.SlowRequestLogConfig{}, fmt.Errorf("invalid slow request log config: %w", err)
+	}
+	return config, nil
+}
+
+func getLoadSheddingConfig(v *viper.Viper) (server.LoadSheddingConfig, error) {
+	config := server.LoadSheddingConfig{
+		MaxCPUUsage:    v.GetFloat64(HTTPLoadSheddingMaxCPUUsageKey),
+		MaxMemoryUsage: v.GetUint64(HTTPLoadSheddingMaxMemoryUsageKey),
+		Routes:         v.GetStringSlice(HTTPLoadSheddingRoutesKey),
+		RetryAfter:     v.GetDuration(HTTPLoadSheddingRetryAfterKey),
+	}
+	if err := config.Verify(); err != nil {
+		return server.LoadSheddingConfig{}, fmt.Errorf("invalid load shedding config: %w", err)
+	}
+	return config, nil
+}
+
+func getChainMiddlewares(v *viper.Viper) (map[string]server.ChainMiddlewareConfig, error) {
+	var (
+		middlewaresBytes []byte
+		err              error
+	)
+	if v.IsSet(HTTPChainMiddlewaresContentKey) {
+		middlewaresContent := v.GetString(HTTPChainMiddlewaresContentKey)
+		middlewaresBytes, err = base64.StdEncoding.DecodeString(middlewaresContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	} else if v.IsSet(HTTPChainMiddlewaresFileKey) {
+		path := GetExpandedArg(v, HTTPChainMiddlewaresFileKey)
+		middlewaresBytes, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(middlewaresBytes) == 0 {
+		return nil, nil
+	}
+
+	var middlewares map[string]server.ChainMiddlewareConfig
+	if err := json.Unmarshal(middlewaresBytes, &middlewares); err != nil {
+		return nil, fmt.Errorf("%w on chain middlewares: %w", errUnmarshalling, err)
+	}
+	return middlewares, server.VerifyChainMiddlewares(middlewares)
+}
+
+func getWebhookConfig(v *viper.Viper) (notify.Config, error) {
+	var (
+		configBytes []byte
+		err         error
+	)
+	if v.IsSet(WebhookConfigContentKey) {
+		configContent := v.GetString(WebhookConfigContentKey)
+		configBytes, err = base64.StdEncoding.DecodeString(configContent)
+		if err != nil {
+			return notify.Config{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	} else if v.IsSet(WebhookConfigFileKey) {
+		path := GetExpandedArg(v, WebhookConfigFileKey)
+		configBytes, err = os.ReadFile(path)
+		if err != nil {
+			return notify.Config{}, err
+		}
+	}
+
+	config := notify.Config{
+		CheckFrequency:       v.GetDuration(WebhookCheckFrequencyKey),
+		RequestTimeout:       v.GetDuration(WebhookRequestTimeoutKey),
+		ValidationEndWarning: v.GetDuration(WebhookValidationEndWarningKey),
+		ChainStallDuration:   v.GetDuration(WebhookChainStallDurationKey),
+		BenchedDuration:      v.GetDuration(WebhookBenchedDurationKey),
+	}
+	if len(configBytes) != 0 {
+		config.Endpoints, err = notify.ParseEndpoints(configBytes)
+		if err != nil {
+			return notify.Config{}, fmt.Errorf("%w on webhook config: %w", errUnmarshalling, err)
+		}
+	}
+	if config.Enabled() && config.CheckFrequency <= 0 {
+		return notify.Config{}, fmt.Errorf("%q must be > 0", WebhookCheckFrequencyKey)
+	}
+	return config, config.Verify()
+}
+
+func getAcceptorHooksConfigs(v *viper.Viper) ([]hooks.Config, error) {
+	var (
+		configBytes []byte
+		err         error
+	)
+	switch {
+	case v.IsSet(AcceptorHooksConfigContentKey):
+		configContent := v.GetString(AcceptorHooksConfigContentKey)
+		configBytes, err = base64.StdEncoding.DecodeString(configContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(AcceptorHooksConfigFileKey):
+		path := GetExpandedArg(v, AcceptorHooksConfigFileKey)
+		configBytes, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	configs, err := hooks.ParseConfigs(configBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w on acceptor hooks config: %w", errUnmarshalling, err)
+	}
+	return configs, hooks.VerifyConfigs(configs)
+}
+
 // Returns the path to the directory that contains VM binaries.
 func getPluginDir(v *viper.Viper) (string, error) {
 	pluginDir := GetExpandedString(v, v.GetString(PluginDirKey))
@@ -1395,6 +1932,16 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, fmt.Errorf("invalid consensus parameters: %w", err)
 	}
 	subnetConfigs[constants.PrimaryNetworkID] = primaryNetworkConfig
+	if err := subnets.VerifyBootstrapDependencies(subnetConfigs); err != nil {
+		return node.Config{}, fmt.Errorf("invalid subnet configs: %w", err)
+	}
+	if nodeConfig.NetworkID == constants.MainnetID || nodeConfig.NetworkID == constants.FujiID {
+		for subnetID, subnetConfig := range subnetConfigs {
+			if len(subnetConfig.ValidatorOverrides) > 0 {
+				return node.Config{}, fmt.Errorf("%w: %s", errValidatorOverridesOnPublicNetwork, subnetID)
+			}
+		}
+	}
 
 	nodeConfig.SubnetConfigs = subnetConfigs
 
@@ -1407,6 +1954,26 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	// File Descriptor Limit
 	nodeConfig.FdLimit = v.GetUint64(FdLimitKey)
 
+	// Startup Self-Check
+	nodeConfig.SelfCheckConfig = selfcheck.Config{
+		Enabled:     v.GetBool(StartupSelfCheckKey),
+		FailOnError: v.GetBool(StartupSelfCheckFailOnErrorKey),
+	}
+
+	// Resource Limits
+	nodeConfig.ResourceLimits = resource.DetectLimits()
+
+	// Cache Memory Budget
+	nodeConfig.CacheBudgetConfig, err = getCacheBudgetConfig(v, nodeConfig.ResourceLimits)
+	if err != nil {
+		return node.Config{}, err
+	}
+
+	nodeConfig.SharedMemoryGCConfig, err = getSharedMemoryGCConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	// Tx Fee
 	nodeConfig.TxFeeConfig = getTxFeeConfig(v, nodeConfig.NetworkID)
 
@@ -1435,12 +2002,24 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, fmt.Errorf("couldn't read chain configs: %w", err)
 	}
 
+	// Chain Genesis Overrides
+	nodeConfig.ChainGenesisOverrides, err = getChainGenesisOverrides(v)
+	if err != nil {
+		return node.Config{}, fmt.Errorf("couldn't read chain genesis overrides: %w", err)
+	}
+
 	// Profiler
 	nodeConfig.ProfilerConfig, err = getProfilerConfig(v)
 	if err != nil {
 		return node.Config{}, err
 	}
 
+	// Watchdog
+	nodeConfig.WatchdogConfig, err = getWatchdogConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	// VM Aliases
 	nodeConfig.VMAliaser, err = getVMAliaser(v)
 	if err != nil {
@@ -1477,21 +2056,53 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	nodeConfig.MetricsPushConfig, err = getMetricsPushConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
+	nodeConfig.WebhookConfig, err = getWebhookConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
+	nodeConfig.AcceptorHooks, err = getAcceptorHooksConfigs(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	nodeConfig.ChainDataDir = GetExpandedArg(v, ChainDataDirKey)
+	nodeConfig.ChainAcceptWALEnabled = v.GetBool(ChainAcceptWALEnabledKey)
 
 	nodeConfig.ProcessContextFilePath = GetExpandedArg(v, ProcessContextFileKey)
 
-	nodeConfig.ProvidedFlags = providedFlags(v)
-	return nodeConfig, nil
+	nodeConfig.ProvidedFlags, err = providedFlags(v)
+	return nodeConfig, err
 }
 
-func providedFlags(v *viper.Viper) map[string]interface{} {
+// providedFlags returns the flags set by the user. If interpolation is
+// enabled, values in the config are reported before interpolation so that the
+// secrets they reference aren't logged.
+func providedFlags(v *viper.Viper) (map[string]interface{}, error) {
+	var raw *viper.Viper
+	if v.GetBool(ConfigInterpolationEnabledKey) {
+		var err error
+		raw, err = uninterpolatedConfig(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	settings := v.AllSettings()
 	customSettings := make(map[string]interface{}, len(settings))
 	for key, val := range settings {
-		if v.IsSet(key) {
-			customSettings[key] = val
+		if !v.IsSet(key) {
+			continue
+		}
+		if raw != nil && raw.InConfig(key) {
+			val = raw.Get(key)
 		}
+		customSettings[key] = val
 	}
-	return customSettings
+	return customSettings, nil
 }
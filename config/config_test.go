@@ -19,8 +19,12 @@ import (
 
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 func TestGetChainConfigsFromFiles(t *testing.T) {
@@ -151,6 +155,67 @@ func TestGetChainConfigsDirNotExist(t *testing.T) {
 	}
 }
 
+func TestGetChainGenesisOverrides(t *testing.T) {
+	tests := map[string]struct {
+		files       map[string]string
+		expectedErr error
+		expected    map[string][]byte
+	}{
+		"no overrides": {
+			files:    map[string]string{},
+			expected: map[string][]byte{},
+		},
+		"valid overrides": {
+			files: map[string]string{
+				"2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm.json": "hello",
+				"mychain.bin": "world",
+			},
+			expected: map[string][]byte{
+				"2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm": []byte("hello"),
+				"mychain": []byte("world"),
+			},
+		},
+		"duplicate overrides": {
+			files: map[string]string{
+				"mychain.json": "hello",
+				"mychain.bin":  "world",
+			},
+			expectedErr: errDuplicateGenesisOverride,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			root := t.TempDir()
+			overrideDir := filepath.Join(root, "gdir")
+			configJSON := fmt.Sprintf(`{%q: %q}`, ChainGenesisOverrideDirKey, overrideDir)
+			configFile := setupConfigJSON(t, root, configJSON)
+
+			require.NoError(os.MkdirAll(overrideDir, 0o700))
+			for fileName, value := range test.files {
+				setupFile(t, overrideDir, fileName, value)
+			}
+			v := setupViper(configFile)
+
+			overrides, err := getChainGenesisOverrides(v)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, overrides)
+		})
+	}
+}
+
+func TestGetChainGenesisOverridesDirNotExist(t *testing.T) {
+	require := require.New(t)
+	root := t.TempDir()
+	configJSON := fmt.Sprintf(`{%q: %q}`, ChainGenesisOverrideDirKey, filepath.Join(root, "gdir"))
+	configFile := setupConfigJSON(t, root, configJSON)
+	v := setupViper(configFile)
+
+	_, err := getChainGenesisOverrides(v)
+	require.ErrorIs(err, errCannotReadDirectory)
+}
+
 func TestSetChainConfigDefaultDir(t *testing.T) {
 	require := require.New(t)
 	root := t.TempDir()
@@ -553,6 +618,137 @@ func TestGetSubnetConfigsFromFlags(t *testing.T) {
 	}
 }
 
+func TestGetCacheBudgetConfig(t *testing.T) {
+	containerLimits := resource.Limits{
+		CgroupVersion: 2,
+		HostCPUs:      16,
+		HostMemory:    64 * units.GiB,
+		MemoryLimit:   8 * units.GiB,
+	}
+	tests := []struct {
+		name           string
+		providedBudget bool
+		budget         uint64
+		limits         resource.Limits
+		expectedSize   uint64
+	}{
+		{
+			name: "no memory limit",
+			limits: resource.Limits{
+				HostCPUs:   16,
+				HostMemory: 64 * units.GiB,
+			},
+			expectedSize: 0,
+		},
+		{
+			name:         "memory limit",
+			limits:       containerLimits,
+			expectedSize: 2 * units.GiB,
+		},
+		{
+			name:           "provided budget",
+			providedBudget: true,
+			budget:         units.GiB,
+			limits:         containerLimits,
+			expectedSize:   units.GiB,
+		},
+		{
+			name:           "provided budget disabled",
+			providedBudget: true,
+			budget:         0,
+			limits:         containerLimits,
+			expectedSize:   0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			if test.providedBudget {
+				v.Set(CacheMemoryBudgetKey, test.budget)
+			}
+
+			config, err := getCacheBudgetConfig(v, test.limits)
+			require.NoError(err)
+			require.Equal(test.expectedSize, config.Size)
+		})
+	}
+}
+
+func TestGetInboundOpRateLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		rates          map[string]string
+		maxBursts      map[string]string
+		expectedLimits map[message.Op]throttling.OpRateLimit
+		expectedErr    error
+	}{
+		{
+			name: "no limits",
+		},
+		{
+			name: "default max burst",
+			rates: map[string]string{
+				"pull_query": "2.5",
+			},
+			expectedLimits: map[message.Op]throttling.OpRateLimit{
+				message.PullQueryOp: {
+					Rate:     2.5,
+					MaxBurst: 3,
+				},
+			},
+		},
+		{
+			name: "provided max burst",
+			rates: map[string]string{
+				"pull_query":  "10",
+				"app_request": "0.5",
+			},
+			maxBursts: map[string]string{
+				"app_request": "5",
+			},
+			expectedLimits: map[message.Op]throttling.OpRateLimit{
+				message.PullQueryOp: {
+					Rate:     10,
+					MaxBurst: 10,
+				},
+				message.AppRequestOp: {
+					Rate:     0.5,
+					MaxBurst: 5,
+				},
+			},
+		},
+		{
+			name: "max burst without rate",
+			rates: map[string]string{
+				"pull_query": "10",
+			},
+			maxBursts: map[string]string{
+				"push_query": "5",
+			},
+			expectedErr: errOpMaxBurstWithoutRate,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			if test.rates != nil {
+				v.Set(InboundThrottlerOpRatesKey, test.rates)
+			}
+			if test.maxBursts != nil {
+				v.Set(InboundThrottlerOpMaxBurstsKey, test.maxBursts)
+			}
+
+			limits, err := getInboundOpRateLimits(v)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedLimits, limits)
+		})
+	}
+}
+
 // setups config json file and writes content
 func setupConfigJSON(t *testing.T, rootPath string, value string) string {
 	configFilePath := filepath.Join(rootPath, "config.json")
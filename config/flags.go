@@ -7,12 +7,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"runtime"
 	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/genesis"
@@ -20,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/utils/ulimit"
 	"github.com/ava-labs/avalanchego/utils/units"
 )
@@ -74,6 +76,13 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(DataDirKey, defaultDataDir, "Sets the base data directory where default sub-directories will be placed unless otherwise specified.")
 	// System
 	fs.Uint64(FdLimitKey, ulimit.DefaultFDLimit, "Attempts to raise the process file descriptor limit to at least this value and error if the value is above the system max")
+	fs.Bool(StartupSelfCheckKey, false, "If true, checks the disk, clock, open files limit, crypto performance and database before joining the network and reports any problems found")
+	fs.Bool(StartupSelfCheckFailOnErrorKey, false, fmt.Sprintf("If true, refuses to start if a startup self-check fails. Ignored if %s is false", StartupSelfCheckKey))
+	fs.Uint64(CacheMemoryBudgetKey, 0, "Number of bytes shared by the database block cache and the block and UTXO caches of every chain. If 0, every cache is sized independently. If not provided and the node is in a container with a memory limit, a quarter of the limit is used")
+	fs.Duration(CacheMemoryBudgetRebalanceFrequencyKey, 30*time.Second, fmt.Sprintf("Frequency at which the caches sharing the %s are resized based on their hit rates. If 0, the caches are never resized", CacheMemoryBudgetKey))
+	fs.Float64(CacheMemoryBudgetMaxGCCPUFractionKey, 0.1, fmt.Sprintf("Portion of CPU time spent by the garbage collector above which the caches sharing the %s are shrunk. If 0, the caches are never shrunk due to GC pressure", CacheMemoryBudgetKey))
+	fs.Duration(SharedMemoryGCRetentionKey, 0, "Duration after which a shared memory value that was consumed before being produced can be produced again. Must exceed the time it takes every chain to process its blocks. If 0, shared memory isn't garbage collected")
+	fs.Duration(SharedMemoryGCFrequencyKey, time.Hour, fmt.Sprintf("Frequency at which shared memory is garbage collected. If 0, shared memory is only garbage collected through the admin API. Ignored if %s is 0", SharedMemoryGCRetentionKey))
 
 	// Plugin directory
 	fs.String(PluginDirKey, defaultPluginDir, "Path to the plugin directory")
@@ -82,6 +91,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(ConfigFileKey, "", fmt.Sprintf("Specifies a config file. Ignored if %s is specified", ConfigContentKey))
 	fs.String(ConfigContentKey, "", "Specifies base64 encoded config content")
 	fs.String(ConfigContentTypeKey, "json", "Specifies the format of the base64 encoded config content. Available values: 'json', 'yaml', 'toml'")
+	fs.Bool(ConfigInterpolationEnabledKey, false, "If true, ${VAR} references in the node, chain, and subnet configs are replaced by the value of the environment variable VAR, ${file:path} references by the contents of the file at path, and ${exec:command} references by the output of command. $${ is replaced by a literal ${. Only applies to the config file if set with a flag or environment variable")
+	fs.Bool(ConfigSecretExecEnabledKey, false, fmt.Sprintf("If true, ${exec:command} references are allowed when %s is set. Only applies to the config file if set with a flag or environment variable", ConfigInterpolationEnabledKey))
 
 	// Genesis
 	fs.String(GenesisFileKey, "", fmt.Sprintf("Specifies a genesis config file path. Ignored when running standard networks or if %s is specified",
@@ -101,6 +112,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint64(AddPrimaryNetworkDelegatorFeeKey, genesis.LocalParams.AddPrimaryNetworkDelegatorFee, "Transaction fee, in nAVAX, for transactions that add new primary network delegators")
 	fs.Uint64(AddSubnetValidatorFeeKey, genesis.LocalParams.AddSubnetValidatorFee, "Transaction fee, in nAVAX, for transactions that add new subnet validators")
 	fs.Uint64(AddSubnetDelegatorFeeKey, genesis.LocalParams.AddSubnetDelegatorFee, "Transaction fee, in nAVAX, for transactions that add new subnet delegators")
+	fs.Uint64(MinOutputAmountKey, genesis.LocalParams.MinOutputAmount, "Minimum amount, in nAVAX, of each AVAX output on the X-chain once the dust policy is activated. If 0, outputs of any amount are allowed")
 
 	// Database
 	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Should be one of {%s, %s}", leveldb.Name, memdb.Name))
@@ -137,6 +149,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Outbound Connection Throttling
 	fs.Uint(NetworkOutboundConnectionThrottlingRpsKey, constants.DefaultOutboundConnectionThrottlingRps, "Make at most this number of outgoing peer connection attempts per second")
 	fs.Duration(NetworkOutboundConnectionTimeoutKey, constants.DefaultOutboundConnectionTimeout, "Timeout when dialing a peer")
+	fs.Uint(NetworkOutboundConnectionMaxConcurrentKey, constants.DefaultOutboundConnectionMaxConcurrent, "Maximum number of outgoing peer connection attempts in progress at once. Once reached, peers with more stake are dialed first. If 0, connection attempts are never delayed")
+	fs.Uint(NetworkDialFailurePenaltyThresholdKey, constants.DefaultDialFailurePenaltyThreshold, "Number of consecutive failed dials of an address after which the address is backed off. If 0, addresses are never backed off")
+	fs.Duration(NetworkDialFailurePenaltyInitialKey, constants.DefaultDialFailurePenaltyInitial, "Duration an address is backed off for once it reached the failure penalty threshold. Doubles with every subsequent failure")
+	fs.Duration(NetworkDialFailurePenaltyMaxKey, constants.DefaultDialFailurePenaltyMax, "Maximum duration an address that repeatedly failed to be dialed is backed off for")
 	// Timeouts
 	fs.Duration(NetworkInitialTimeoutKey, constants.DefaultNetworkInitialTimeout, "Initial timeout value of the adaptive timeout manager")
 	fs.Duration(NetworkMinimumTimeoutKey, constants.DefaultNetworkMinimumTimeout, "Minimum timeout value of the adaptive timeout manager")
@@ -149,6 +165,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
 	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages. Must be one of [%s, %s, %s]", compression.TypeGzip, compression.TypeZstd, compression.TypeNone))
+	fs.StringSlice(NetworkCompressionTypesKey, nil, fmt.Sprintf("Compression types accepted from peers, in order of preference. Messages sent to a peer are compressed with the first type the peer also accepts. Peers that don't report the types they accept are sent messages compressed with %s. If empty, only %s is accepted", NetworkCompressionTypeKey, NetworkCompressionTypeKey))
+	fs.Int(NetworkCompressionZstdLevelKey, constants.DefaultNetworkCompressionZstdLevel, fmt.Sprintf("Level outbound messages are compressed with when using zstd. Must be in [%d, %d]. Higher levels compress more, but are slower", compression.MinZstdLevel, compression.MaxZstdLevel))
 
 	fs.Duration(NetworkMaxClockDifferenceKey, constants.DefaultNetworkMaxClockDifference, "Max allowed clock difference value between this node and peers")
 	// Note: The default value is set to false here because the default
@@ -158,6 +176,16 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(NetworkRequireValidatorToConnectKey, constants.DefaultNetworkRequireValidatorToConnect, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, constants.DefaultNetworkPeerReadBufferSize, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, constants.DefaultNetworkPeerWriteBufferSize, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Uint(NetworkPeerWorkerPoolSizeKey, constants.DefaultNetworkPeerWorkerPoolSize, "Number of goroutines shared by all peers to send pings and gossip peer lists. If 0, each peer uses a dedicated goroutine. Peers always read and write messages on dedicated goroutines")
+	fs.Bool(NetworkZeroCopyPayloadsKey, constants.DefaultNetworkZeroCopyPayloads, "If true, the payloads of large inbound messages are passed to the chains without being copied. If false, every inbound message is copied out of the buffer it was read into")
+	fs.Bool(NetworkRetiringAnnouncementEnabledKey, constants.DefaultNetworkRetiringAnnouncementEnabled, "If true, this node announces to its peers that it is shutting down, so that they stop sending it requests and don't benchlist it. Peers running an older version ignore the announcement")
+	fs.Bool(NetworkReputationEnabledKey, constants.DefaultNetworkReputationEnabled, "If true, the handshake failures, invalid messages and benchings of peers are recorded into the database")
+	fs.Float64(NetworkReputationHandshakeFailurePenaltyKey, constants.DefaultNetworkReputationHandshakeFailurePenalty, "Penalty added to the reputation of a peer every time it fails the handshake")
+	fs.Float64(NetworkReputationInvalidMessagePenaltyKey, constants.DefaultNetworkReputationInvalidMessagePenalty, "Penalty added to the reputation of a peer every time it sends a message with an invalid field")
+	fs.Float64(NetworkReputationBenchedPenaltyKey, constants.DefaultNetworkReputationBenchedPenalty, "Penalty added to the reputation of a peer every time it is benched on a chain")
+	fs.Duration(NetworkReputationPenaltyHalflifeKey, constants.DefaultNetworkReputationPenaltyHalflife, "Duration after which the penalty of a peer that stopped misbehaving is halved. Must be > 0")
+	fs.Float64(NetworkReputationMaxPenaltyKey, constants.DefaultNetworkReputationMaxPenalty, "Penalty above which inbound connections from a peer are dropped and the peer isn't gossiped to. If 0, peers are never dropped because of their reputation")
+	fs.Int(NetworkReputationMaxTrackedPeersKey, constants.DefaultNetworkReputationMaxTrackedPeers, "Maximum number of peers whose reputation is recorded. Once reached, the reputation with the lowest penalty is forgotten to record a new peer. Must be > 0")
 
 	fs.Bool(NetworkTCPProxyEnabledKey, constants.DefaultNetworkTCPProxyEnabled, "Require all P2P connections to be initiated with a TCP proxy header")
 	// The PROXY protocol specification recommends setting this value to be at
@@ -168,11 +196,18 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkTCPProxyReadTimeoutKey, constants.DefaultNetworkTCPProxyReadTimeout, "Maximum duration to wait for a TCP proxy header")
 
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
+	fs.Bool(NetworkTLSSessionResumptionEnabledKey, constants.DefaultNetworkTLSSessionResumptionEnabled, "If true, reconnecting peers can resume a previous TLS session rather than performing a full handshake")
+	fs.Duration(NetworkTLSSessionTicketKeyRotationFreqKey, constants.DefaultNetworkTLSSessionTicketKeyRotationFreq, "Frequency to generate a new key to encrypt TLS session tickets")
+	fs.Int(NetworkTLSSessionTicketKeysKey, constants.DefaultNetworkTLSSessionTicketKeys, fmt.Sprintf("Number of TLS session ticket keys to retain. Sessions can be resumed for up to this many multiples of %s", NetworkTLSSessionTicketKeyRotationFreqKey))
+	fs.String(NetworkTLSMinVersionKey, constants.DefaultNetworkTLSMinVersion, "Minimum TLS version accepted from peers. Must be one of {1.2, 1.3}. If 1.3, only TLS 1.3 is accepted")
+	fs.StringSlice(NetworkTLSCipherSuitesKey, nil, "List of the names of the cipher suites accepted from peers, such as TLS_AES_128_GCM_SHA256. Must include at least one TLS 1.3 cipher suite. If empty, all the secure cipher suites are accepted")
 
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, constants.DefaultBenchlistFailThreshold, "Number of consecutive failed queries before benchlisting a node")
 	fs.Duration(BenchlistDurationKey, constants.DefaultBenchlistDuration, "Max amount of time a peer is benchlisted after surpassing the threshold")
 	fs.Duration(BenchlistMinFailingDurationKey, constants.DefaultBenchlistMinFailingDuration, "Minimum amount of time messages to a peer must be failing before the peer is benched")
+	fs.String(BenchlistFileKey, "", fmt.Sprintf("Specifies a JSON file that lists known-bad peers to bench at startup. Ignored if %s is specified", BenchlistContentKey))
+	fs.String(BenchlistContentKey, "", "Specifies base64 encoded benchlist file content")
 
 	// Router
 	fs.Duration(ConsensusAcceptedFrontierGossipFrequencyKey, constants.DefaultAcceptedFrontierGossipFrequency, "Frequency of gossiping accepted frontiers")
@@ -187,6 +222,11 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint(AppGossipValidatorSizeKey, constants.DefaultAppGossipValidatorSize, "Number of validators to gossip an AppGossip message to")
 	fs.Uint(AppGossipNonValidatorSizeKey, constants.DefaultAppGossipNonValidatorSize, "Number of non-validators to gossip an AppGossip message to")
 	fs.Uint(AppGossipPeerSizeKey, constants.DefaultAppGossipPeerSize, "Number of peers (which may be validators or non-validators) to gossip an AppGossip message to")
+	fs.Uint(ConsensusGossipRedundancyValidatorSizeKey, constants.DefaultConsensusGossipRedundancyValidatorSize, "Number of additional validators to gossip containers to for each redundancy level reached while queries are failing. If 0, the number of validators gossiped to is never increased")
+	fs.Uint(ConsensusGossipRedundancyMaxLevelKey, constants.DefaultConsensusGossipRedundancyMaxLevel, "Maximum redundancy level reached while queries are failing")
+	fs.Float64(ConsensusGossipRedundancyHighFailureRateKey, constants.DefaultConsensusGossipRedundancyHighFailureRate, "Rate of failed queries at or above which the redundancy level is increased")
+	fs.Float64(ConsensusGossipRedundancyLowFailureRateKey, constants.DefaultConsensusGossipRedundancyLowFailureRate, "Rate of failed queries at or below which the redundancy level is decreased")
+	fs.Duration(ConsensusGossipRedundancyWindowKey, constants.DefaultConsensusGossipRedundancyWindow, "Period over which the rate of failed queries is measured before the redundancy level is updated")
 
 	// Inbound Throttling
 	fs.Uint64(InboundThrottlerAtLargeAllocSizeKey, constants.DefaultInboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in inbound message throttler")
@@ -197,6 +237,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint64(InboundThrottlerBandwidthMaxBurstSizeKey, constants.DefaultInboundThrottlerBandwidthMaxBurstSize, "Max inbound bandwidth a node can use at once. Must be at least the max message size. See BandwidthThrottler")
 	fs.Duration(InboundThrottlerCPUMaxRecheckDelayKey, constants.DefaultInboundThrottlerCPUMaxRecheckDelay, "In the CPU-based network throttler, check at least this often whether the node's CPU usage has fallen to an acceptable level")
 	fs.Duration(InboundThrottlerDiskMaxRecheckDelayKey, constants.DefaultInboundThrottlerDiskMaxRecheckDelay, "In the disk-based network throttler, check at least this often whether the node's disk usage has fallen to an acceptable level")
+	fs.StringToString(InboundThrottlerOpRatesKey, map[string]string{}, "Max average number of messages of an op, such as pull_query, a peer may send per second. Messages over the limit are dropped. Ops without a rate aren't rate-limited")
+	fs.StringToString(InboundThrottlerOpMaxBurstsKey, map[string]string{}, fmt.Sprintf("Max number of messages of an op a peer may send at once. Defaults to the rate of the op in %s, rounded up", InboundThrottlerOpRatesKey))
 
 	// Outbound Throttling
 	fs.Uint64(OutboundThrottlerAtLargeAllocSizeKey, constants.DefaultOutboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in outbound message throttler")
@@ -219,6 +261,18 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(HTTPReadHeaderTimeoutKey, 30*time.Second, fmt.Sprintf("Maximum duration to read request headers. The connection's read deadline is reset after reading the headers. If %s is zero, the value of %s is used. If both are zero, there is no timeout.", HTTPReadHeaderTimeoutKey, HTTPReadTimeoutKey))
 	fs.Duration(HTTPWriteTimeoutKey, 30*time.Second, "Maximum duration before timing out writes of the response. It is reset whenever a new request's header is read. A zero or negative value means there will be no timeout.")
 	fs.Duration(HTTPIdleTimeoutKey, 120*time.Second, fmt.Sprintf("Maximum duration to wait for the next request when keep-alives are enabled. If %s is zero, the value of %s is used. If both are zero, there is no timeout.", HTTPIdleTimeoutKey, HTTPReadTimeoutKey))
+	fs.Int64(HTTPMaxRequestBodySizeKey, 0, "Maximum size, in bytes, of an API request body. Requests with larger bodies will receive a 413 error code. If 0, the size of request bodies isn't limited")
+	fs.Uint(HTTPMaxBatchSizeKey, 1000, "Maximum number of requests in a JSON-RPC batch. Larger batches will receive an invalid request error. If 0, batches are passed to the API handlers unmodified. C-Chain batches are always passed unmodified, as the C-Chain handles them natively")
+	fs.String(HTTPRoutePoliciesFileKey, "", fmt.Sprintf("Specifies a JSON file that maps API routes to the allowed origins and max request body size of requests to them. Ignored if %s is specified", HTTPRoutePoliciesContentKey))
+	fs.String(HTTPRoutePoliciesContentKey, "", "Specifies base64 encoded API route policies content")
+	fs.String(HTTPChainMiddlewaresFileKey, "", fmt.Sprintf("Specifies a JSON file that maps chain IDs or aliases to the request logging, auth token, and method rate limit middlewares applied to their APIs. Ignored if %s is specified", HTTPChainMiddlewaresContentKey))
+	fs.String(HTTPChainMiddlewaresContentKey, "", "Specifies base64 encoded chain API middlewares content")
+	fs.Duration(HTTPSlowRequestThresholdKey, 0, fmt.Sprintf("API requests that take at least this long to be handled are written to the %s log. If 0, only the requests with an entry in %s are logged", server.SlowRequestLogName, HTTPSlowRequestThresholdsKey))
+	fs.StringToString(HTTPSlowRequestThresholdsKey, map[string]string{}, fmt.Sprintf("Overrides %s for calls to JSON-RPC methods, such as avm.getUTXOs, or requests to API routes, such as /ext/bc/X", HTTPSlowRequestThresholdKey))
+	fs.Float64(HTTPLoadSheddingMaxCPUUsageKey, 0, fmt.Sprintf("Number of CPU cores the node can use before API requests to %s receive a 503 error code. If 0, the CPU usage isn't considered", HTTPLoadSheddingRoutesKey))
+	fs.Uint64(HTTPLoadSheddingMaxMemoryUsageKey, 0, fmt.Sprintf("Number of bytes of memory the node can use before API requests to %s receive a 503 error code. If 0, the memory usage isn't considered", HTTPLoadSheddingRoutesKey))
+	fs.StringSlice(HTTPLoadSheddingRoutesKey, nil, "Low priority API routes, such as /ext/bc/X, whose requests are rejected while the node is overloaded. Requests to the health and metrics APIs are never rejected")
+	fs.Duration(HTTPLoadSheddingRetryAfterKey, 5*time.Second, "Duration clients are told to wait before retrying API requests rejected while the node is overloaded")
 	fs.Bool(APIAuthRequiredKey, false, "Require authorization token to call HTTP APIs")
 	fs.String(APIAuthPasswordFileKey, "",
 		fmt.Sprintf("Password file used to initially create/validate API authorization tokens. Ignored if %s is specified. Leading and trailing whitespace is removed from the password. Can be changed via API call",
@@ -229,6 +283,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(AdminAPIEnabledKey, false, "If true, this node exposes the Admin API")
 	fs.Bool(InfoAPIEnabledKey, true, "If true, this node exposes the Info API")
 	fs.Bool(KeystoreAPIEnabledKey, false, "If true, this node exposes the Keystore API")
+	fs.String(KeystoreSignerEndpointKey, "", "Address of the gRPC signing plugin that holds the keys of keystore users. Unless the address is a unix socket (unix:///path), the plugin must serve TLS. If empty, keystore users can't be migrated to a signing plugin")
+	fs.String(KeystoreSignerCAFileKey, "", fmt.Sprintf("Path to the PEM encoded certificate authorities that sign the TLS certificate of the signing plugin. If empty, the system's certificate authorities are used. Ignored if %s is a unix socket", KeystoreSignerEndpointKey))
 	fs.Bool(MetricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
 	fs.Bool(HealthAPIEnabledKey, true, "If true, this node exposes the Health API")
 	fs.Bool(IpcAPIEnabledKey, false, "If true, IPCs can be opened")
@@ -255,6 +311,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(StakingTLSKeyContentKey, "", "Specifies base64 encoded TLS private key for staking")
 	fs.String(StakingCertPathKey, defaultStakingCertPath, fmt.Sprintf("Path to the TLS certificate for staking. Ignored if %s is specified", StakingCertContentKey))
 	fs.String(StakingCertContentKey, "", "Specifies base64 encoded TLS certificate for staking")
+	fs.String(StakingStandbyTLSKeyPathKey, "", fmt.Sprintf("Path to the standby TLS private key for staking, which the node can be switched to through the admin API. Must be specified with %s. Ignored if %s is set", StakingStandbyCertPathKey, StakingEphemeralCertEnabledKey))
+	fs.String(StakingStandbyCertPathKey, "", fmt.Sprintf("Path to the standby TLS certificate for staking, which the node can be switched to through the admin API. Must be specified with %s. Ignored if %s is set", StakingStandbyTLSKeyPathKey, StakingEphemeralCertEnabledKey))
 	fs.Bool(StakingEphemeralSignerEnabledKey, false, "If true, the node uses an ephemeral staking signer key")
 	fs.String(StakingSignerKeyPathKey, defaultStakingSignerKeyPath, fmt.Sprintf("Path to the signer private key for staking. Ignored if %s is specified", StakingSignerKeyContentKey))
 	fs.String(StakingSignerKeyContentKey, "", "Specifies base64 encoded signer private key for staking")
@@ -296,6 +354,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(BootstrapMaxTimeGetAncestorsKey, 50*time.Millisecond, "Max Time to spend fetching a container and its ancestors when responding to a GetAncestors")
 	fs.Uint(BootstrapAncestorsMaxContainersSentKey, 2000, "Max number of containers in an Ancestors message sent by this node")
 	fs.Uint(BootstrapAncestorsMaxContainersReceivedKey, 2000, "This node reads at most this many containers from an incoming Ancestors message")
+	fs.Uint(BootstrapExecutionWorkersKey, 4, "Number of goroutines that pre-verify blocks ahead of their execution during bootstrapping, for VMs that support it. If 0, blocks are only verified when they are executed")
+	fs.Bool(BootstrapPrioritizePrimaryNetworkKey, false, "If true, chains of subnets other than the primary network are only created once the primary network has finished bootstrapping. Otherwise, subnets bootstrap in parallel with the primary network")
 
 	// Consensus
 	fs.Int(SnowSampleSizeKey, snowball.DefaultParameters.K, "Number of nodes to query for each network poll")
@@ -315,6 +375,11 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Metrics
 	fs.Bool(MeterVMsEnabledKey, true, "Enable Meter VMs to track VM performance with more granularity")
 	fs.Duration(UptimeMetricFreqKey, 30*time.Second, "Frequency of renewing this node's average uptime metric")
+	fs.Bool(MetricsPushEnabledKey, false, "If true, periodically push metrics to a collector. Useful for nodes that can't be scraped")
+	fs.String(MetricsPushTypeKey, metrics.PushGateway.String(), fmt.Sprintf("Protocol to push metrics with. Options are [%s, %s]", metrics.PushGateway, metrics.OTLP))
+	fs.String(MetricsPushEndpointKey, "", "The URL to push metrics to. For OTLP, this is the full URL of the collector's metrics endpoint, e.g. http://localhost:4318/v1/metrics")
+	fs.Duration(MetricsPushFrequencyKey, 15*time.Second, "Frequency of pushing metrics")
+	fs.StringToString(MetricsPushLabelsKey, map[string]string{}, "Labels attached to every pushed metric. For a push gateway, these are the grouping key")
 
 	// IPC
 	fs.String(IpcsChainIDsKey, "", "Comma separated list of chain ids to add to the IPC engine. Example: 11111111111111111111111111111111LpoYY,4R5p2RXDGLqaifZE4hHWH9owe34pfoBULn1DrQTWivjg8o4aH")
@@ -327,11 +392,13 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
 	fs.String(ChainConfigContentKey, "", "Specifies base64 encoded chains configurations")
+	fs.String(ChainGenesisOverrideDirKey, "", "Directory of files that replace the genesis of chains that aren't on the primary network. The genesis of a chain is read from the file named after its ID or alias, with any extension")
 	fs.String(SubnetConfigDirKey, defaultSubnetConfigDir, fmt.Sprintf("Subnet specific configurations parent directory. Ignored if %s is specified", SubnetConfigContentKey))
 	fs.String(SubnetConfigContentKey, "", "Specifies base64 encoded subnets configurations")
 
 	// Chain Data Directory
 	fs.String(ChainDataDirKey, defaultChainDataDir, "Chain specific data directory")
+	fs.Bool(ChainAcceptWALEnabledKey, false, "If true, each snowman chain durably writes a block to a write-ahead log in its data directory before accepting it, so that a block accepted by consensus isn't lost if the node crashes before the VM commits it")
 
 	// Profiles
 	fs.String(ProfileDirKey, defaultProfileDir, "Path to the profile directory")
@@ -339,6 +406,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(ProfileContinuousFreqKey, 15*time.Minute, "How frequently to rotate performance profiles")
 	fs.Int(ProfileContinuousMaxFilesKey, 5, "Maximum number of historical profiles to keep")
 
+	// Watchdog
+	fs.Duration(WatchdogStallTimeoutKey, 5*time.Minute, fmt.Sprintf("Time a chain may spend handling a single message, or not accepting containers while its peers do, before it is considered stalled. When a chain stalls, goroutine and lock profiles are written to %s/watchdog and the node reports unhealthy. If 0, stalls aren't detected", ProfileDirKey))
+	fs.Duration(WatchdogFrequencyKey, 30*time.Second, "Time between checks for stalled chains")
+
 	// Aliasing
 	fs.String(VMAliasesFileKey, defaultVMAliasFilePath, fmt.Sprintf("Specifies a JSON file that maps vmIDs with custom aliases. Ignored if %s is specified", VMAliasesContentKey))
 	fs.String(VMAliasesContentKey, "", "Specifies base64 encoded maps vmIDs with custom aliases")
@@ -358,9 +429,13 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint64(SystemTrackerWarningThresholdAvailableDiskSpaceKey, units.GiB, fmt.Sprintf("Warning threshold for the number of available bytes on disk, under which the node will be considered unhealthy.  Must be >= [%s]", SystemTrackerRequiredAvailableDiskSpaceKey))
 
 	// CPU management
-	fs.Float64(CPUVdrAllocKey, float64(runtime.NumCPU()), "Maximum number of CPUs to allocate for use by validators. Value should be in range [0, total core count]")
-	fs.Float64(CPUMaxNonVdrUsageKey, .8*float64(runtime.NumCPU()), "Number of CPUs that if fully utilized, will rate limit all non-validators. Value should be in range [0, total core count]")
-	fs.Float64(CPUMaxNonVdrNodeUsageKey, float64(runtime.NumCPU())/8, "Maximum number of CPUs that a non-validator can utilize. Value should be in range [0, total core count]")
+	//
+	// Inside a container, the defaults are based on the CPU quota of the
+	// container rather than the number of CPUs of the host.
+	numCPUs := resource.DetectLimits().CPUs()
+	fs.Float64(CPUVdrAllocKey, numCPUs, "Maximum number of CPUs to allocate for use by validators. Value should be in range [0, available core count]")
+	fs.Float64(CPUMaxNonVdrUsageKey, .8*numCPUs, "Number of CPUs that if fully utilized, will rate limit all non-validators. Value should be in range [0, available core count]")
+	fs.Float64(CPUMaxNonVdrNodeUsageKey, numCPUs/8, "Maximum number of CPUs that a non-validator can utilize. Value should be in range [0, available core count]")
 
 	// Disk management
 	fs.Float64(DiskVdrAllocKey, 1000*units.GiB, "Maximum number of disk reads/writes per second to allocate for use by validators. Must be > 0")
@@ -376,6 +451,19 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.StringToString(TracingHeadersKey, map[string]string{}, "The headers to provide the trace indexer")
 
 	fs.String(ProcessContextFileKey, defaultProcessContextPath, "The path to write process context to (including PID, API URI, and staking address).")
+
+	// Webhook notifications
+	fs.String(WebhookConfigFileKey, "", fmt.Sprintf("Specifies a JSON file that lists the endpoints to send webhook notifications to. Ignored if %s is specified", WebhookConfigContentKey))
+	fs.String(WebhookConfigContentKey, "", "Specifies base64 encoded webhook config content")
+
+	// Acceptor hooks
+	fs.String(AcceptorHooksConfigFileKey, "", fmt.Sprintf("Specifies a JSON file that lists the acceptor hooks to enable. Ignored if %s is specified", AcceptorHooksConfigContentKey))
+	fs.String(AcceptorHooksConfigContentKey, "", "Specifies base64 encoded acceptor hooks config content")
+	fs.Duration(WebhookCheckFrequencyKey, time.Minute, "Frequency to check for conditions that webhook notifications are sent for")
+	fs.Duration(WebhookRequestTimeoutKey, 10*time.Second, "Timeout for a single webhook request")
+	fs.Duration(WebhookValidationEndWarningKey, 7*24*time.Hour, "Duration before the end of this node's validation period to send a webhook notification")
+	fs.Duration(WebhookChainStallDurationKey, 5*time.Minute, "Duration a chain must be unhealthy before a webhook notification is sent")
+	fs.Duration(WebhookBenchedDurationKey, 5*time.Minute, "Duration a peer must stop querying this node on a chain, while other peers keep querying it, before a webhook notification that the peer benched this node is sent")
 }
 
 // BuildFlagSet returns a complete set of flags for avalanchego
@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ava-labs/avalanchego/chains"
+)
+
+const (
+	envSecretProvider  = "env"
+	fileSecretProvider = "file"
+	execSecretProvider = "exec"
+
+	secretExecTimeout = 10 * time.Second
+)
+
+var (
+	errUnterminatedReference  = errors.New("unterminated reference")
+	errEmptyReference         = errors.New("empty reference")
+	errUndefinedEnvVar        = errors.New("undefined environment variable")
+	errUnknownSecretProvider  = errors.New("unknown secret provider")
+	errSecretProviderDisabled = errors.New("secret provider is disabled")
+)
+
+// secretProvider returns the value referenced by [ref].
+type secretProvider func(ref string) (string, error)
+
+// interpolator replaces references in config contents with the value they
+// reference. A reference is either ${NAME}, which is replaced by the value of
+// the environment variable NAME, or ${provider:ref}, which is replaced by the
+// value returned by the named secret provider. $${ is replaced by a literal ${.
+//
+// Values are inserted verbatim, so they must be valid where they are
+// referenced in the config, e.g. they can't contain unescaped quotes when
+// referenced inside of a JSON string.
+type interpolator struct {
+	// If false, config contents are returned unmodified.
+	enabled   bool
+	providers map[string]secretProvider
+}
+
+func newInterpolator(enabled bool, execEnabled bool) *interpolator {
+	i := &interpolator{
+		enabled: enabled,
+		providers: map[string]secretProvider{
+			envSecretProvider:  getEnvSecret,
+			fileSecretProvider: getFileSecret,
+		},
+	}
+	if execEnabled {
+		i.providers[execSecretProvider] = getExecSecret
+	}
+	return i
+}
+
+func getInterpolator(v *viper.Viper) *interpolator {
+	return newInterpolator(
+		v.GetBool(ConfigInterpolationEnabledKey),
+		v.GetBool(ConfigSecretExecEnabledKey),
+	)
+}
+
+// Interpolate returns [content] with all of its references replaced.
+func (i *interpolator) Interpolate(content []byte) ([]byte, error) {
+	if !i.enabled || !bytes.Contains(content, []byte("${")) {
+		return content, nil
+	}
+
+	var (
+		remaining = string(content)
+		sb        strings.Builder
+	)
+	sb.Grow(len(remaining))
+	for {
+		start := strings.Index(remaining, "${")
+		if start == -1 {
+			sb.WriteString(remaining)
+			return []byte(sb.String()), nil
+		}
+
+		// $${ escapes a literal ${
+		if start > 0 && remaining[start-1] == '$' {
+			sb.WriteString(remaining[:start-1])
+			sb.WriteString("${")
+			remaining = remaining[start+2:]
+			continue
+		}
+
+		sb.WriteString(remaining[:start])
+		remaining = remaining[start+2:]
+		end := strings.IndexByte(remaining, '}')
+		if end == -1 {
+			return nil, fmt.Errorf("%w at offset %d", errUnterminatedReference, len(content)-len(remaining)-2)
+		}
+
+		value, err := i.resolve(remaining[:end])
+		if err != nil {
+			return nil, err
+		}
+		sb.WriteString(value)
+		remaining = remaining[end+1:]
+	}
+}
+
+// interpolateChainConfigs interpolates the config and upgrade bytes of every
+// chain in [chainConfigs] in place.
+func (i *interpolator) interpolateChainConfigs(chainConfigs map[string]chains.ChainConfig) error {
+	for chain, chainConfig := range chainConfigs {
+		config, err := i.Interpolate(chainConfig.Config)
+		if err != nil {
+			return fmt.Errorf("couldn't interpolate config of chain %q: %w", chain, err)
+		}
+		upgrade, err := i.Interpolate(chainConfig.Upgrade)
+		if err != nil {
+			return fmt.Errorf("couldn't interpolate upgrade of chain %q: %w", chain, err)
+		}
+		chainConfigs[chain] = chains.ChainConfig{
+			Config:  config,
+			Upgrade: upgrade,
+		}
+	}
+	return nil
+}
+
+func (i *interpolator) resolve(reference string) (string, error) {
+	providerName, ref, ok := strings.Cut(reference, ":")
+	if !ok {
+		providerName, ref = envSecretProvider, reference
+	}
+	if ref == "" {
+		return "", fmt.Errorf("%w: ${%s}", errEmptyReference, reference)
+	}
+
+	provider, ok := i.providers[providerName]
+	switch {
+	case ok:
+	case providerName == execSecretProvider:
+		return "", fmt.Errorf("%w: %q must be enabled with %s", errSecretProviderDisabled, providerName, ConfigSecretExecEnabledKey)
+	default:
+		return "", fmt.Errorf("%w: %q", errUnknownSecretProvider, providerName)
+	}
+
+	value, err := provider(ref)
+	if err != nil {
+		return "", fmt.Errorf("couldn't resolve ${%s}: %w", reference, err)
+	}
+	return value, nil
+}
+
+func getEnvSecret(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errUndefinedEnvVar, name)
+	}
+	return value, nil
+}
+
+// getFileSecret returns the contents of the file at [path], without trailing
+// newlines.
+func getFileSecret(path string) (string, error) {
+	value, err := os.ReadFile(os.ExpandEnv(path))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(value), "\r\n"), nil
+}
+
+// getExecSecret returns the output of [command], without trailing newlines.
+// The command is split on whitespace and isn't run in a shell.
+func getExecSecret(command string) (string, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return "", errEmptyReference
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretExecTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) // #nosec G204
+	cmd.Stderr = os.Stderr
+	value, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(value), "\r\n"), nil
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/chains"
+)
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("AVAGO_TEST_SECRET", "secret")
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file secret\n"), 0o600))
+
+	tests := []struct {
+		name        string
+		execEnabled bool
+		content     string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "no references",
+			content:  `{"a": "$b", "c": "{d}"}`,
+			expected: `{"a": "$b", "c": "{d}"}`,
+		},
+		{
+			name:     "env",
+			content:  `{"token": "${AVAGO_TEST_SECRET}"}`,
+			expected: `{"token": "secret"}`,
+		},
+		{
+			name:     "env provider",
+			content:  `${env:AVAGO_TEST_SECRET}-${AVAGO_TEST_SECRET}`,
+			expected: `secret-secret`,
+		},
+		{
+			name:     "file",
+			content:  fmt.Sprintf(`{"token": "${file:%s}"}`, secretFile),
+			expected: `{"token": "file secret"}`,
+		},
+		{
+			name:        "exec",
+			execEnabled: true,
+			content:     `{"token": "${exec:echo exec secret}"}`,
+			expected:    `{"token": "exec secret"}`,
+		},
+		{
+			name:     "escaped",
+			content:  `{"a": "$${AVAGO_TEST_SECRET}", "b": "$$${AVAGO_TEST_SECRET}"}`,
+			expected: `{"a": "${AVAGO_TEST_SECRET}", "b": "$${AVAGO_TEST_SECRET}"}`,
+		},
+		{
+			name:        "undefined env",
+			content:     `${AVAGO_TEST_UNDEFINED}`,
+			expectedErr: errUndefinedEnvVar,
+		},
+		{
+			name:        "unterminated",
+			content:     `"token": "${AVAGO_TEST_SECRET"`,
+			expectedErr: errUnterminatedReference,
+		},
+		{
+			name:        "empty",
+			content:     `${}`,
+			expectedErr: errEmptyReference,
+		},
+		{
+			name:        "unknown provider",
+			content:     `${vault:secret}`,
+			expectedErr: errUnknownSecretProvider,
+		},
+		{
+			name:        "exec disabled",
+			content:     `${exec:echo secret}`,
+			expectedErr: errSecretProviderDisabled,
+		},
+		{
+			name:        "missing file",
+			content:     fmt.Sprintf(`${file:%s}`, filepath.Join(t.TempDir(), "missing")),
+			expectedErr: os.ErrNotExist,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			i := newInterpolator(true, test.execEnabled)
+			content, err := i.Interpolate([]byte(test.content))
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Equal(test.expected, string(content))
+			}
+		})
+	}
+}
+
+func TestInterpolateDisabled(t *testing.T) {
+	content := []byte(`{"token": "${AVAGO_TEST_UNDEFINED}"}`)
+	interpolated, err := newInterpolator(false, true).Interpolate(content)
+	require.NoError(t, err)
+	require.Equal(t, content, interpolated)
+}
+
+func TestBuildViperInterpolation(t *testing.T) {
+	t.Setenv("AVAGO_TEST_NETWORK", "local")
+
+	configJSON := fmt.Sprintf(`{%q: "${AVAGO_TEST_NETWORK}"}`, NetworkNameKey)
+	tests := []struct {
+		name     string
+		args     func(t *testing.T) []string
+		expected string
+	}{
+		{
+			name: "config file",
+			args: func(t *testing.T) []string {
+				configFile := setupConfigJSON(t, t.TempDir(), configJSON)
+				return []string{
+					"--" + ConfigFileKey, configFile,
+					"--" + ConfigInterpolationEnabledKey,
+				}
+			},
+			expected: "local",
+		},
+		{
+			name: "config content",
+			args: func(*testing.T) []string {
+				return []string{
+					"--" + ConfigContentKey, base64.StdEncoding.EncodeToString([]byte(configJSON)),
+					"--" + ConfigInterpolationEnabledKey,
+				}
+			},
+			expected: "local",
+		},
+		{
+			name: "disabled",
+			args: func(t *testing.T) []string {
+				configFile := setupConfigJSON(t, t.TempDir(), configJSON)
+				return []string{
+					"--" + ConfigFileKey, configFile,
+				}
+			},
+			expected: "${AVAGO_TEST_NETWORK}",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			v, err := BuildViper(BuildFlagSet(), test.args(t))
+			require.NoError(err)
+			require.Equal(test.expected, v.GetString(NetworkNameKey))
+		})
+	}
+}
+
+func TestGetChainConfigsInterpolation(t *testing.T) {
+	require := require.New(t)
+
+	t.Setenv("AVAGO_TEST_SECRET", "secret")
+
+	root := t.TempDir()
+	configJSON := fmt.Sprintf(`{%q: %q, %q: true}`, ChainConfigDirKey, root, ConfigInterpolationEnabledKey)
+	configFile := setupConfigJSON(t, root, configJSON)
+	setupFile(t, filepath.Join(root, "C"), chainConfigFileName+".json", `{"token": "${AVAGO_TEST_SECRET}"}`)
+
+	v := setupViper(configFile)
+	chainConfigs, err := getChainConfigs(v)
+	require.NoError(err)
+	require.Equal(
+		map[string]chains.ChainConfig{
+			"C": {
+				Config: []byte(`{"token": "secret"}`),
+			},
+		},
+		chainConfigs,
+	)
+}
+
+func TestProvidedFlagsUninterpolated(t *testing.T) {
+	require := require.New(t)
+
+	t.Setenv("AVAGO_TEST_NETWORK", "local")
+
+	configJSON := fmt.Sprintf(`{%q: "${AVAGO_TEST_NETWORK}"}`, NetworkNameKey)
+	configFile := setupConfigJSON(t, t.TempDir(), configJSON)
+	v, err := BuildViper(BuildFlagSet(), []string{
+		"--" + ConfigFileKey, configFile,
+		"--" + ConfigInterpolationEnabledKey,
+	})
+	require.NoError(err)
+	require.Equal("local", v.GetString(NetworkNameKey))
+
+	flags, err := providedFlags(v)
+	require.NoError(err)
+	require.Equal("${AVAGO_TEST_NETWORK}", flags[NetworkNameKey])
+	require.Equal(true, flags[ConfigInterpolationEnabledKey])
+}
@@ -9,6 +9,8 @@ const (
 	ConfigFileKey                                      = "config-file"
 	ConfigContentKey                                   = "config-file-content"
 	ConfigContentTypeKey                               = "config-file-content-type"
+	ConfigInterpolationEnabledKey                      = "config-interpolation-enabled"
+	ConfigSecretExecEnabledKey                         = "config-secret-exec-enabled"
 	VersionKey                                         = "version"
 	GenesisFileKey                                     = "genesis-file"
 	GenesisFileContentKey                              = "genesis-file-content"
@@ -22,6 +24,7 @@ const (
 	AddPrimaryNetworkDelegatorFeeKey                   = "add-primary-network-delegator-fee"
 	AddSubnetValidatorFeeKey                           = "add-subnet-validator-fee"
 	AddSubnetDelegatorFeeKey                           = "add-subnet-delegator-fee"
+	MinOutputAmountKey                                 = "min-output-amount"
 	UptimeRequirementKey                               = "uptime-requirement"
 	MinValidatorStakeKey                               = "min-validator-stake"
 	MaxValidatorStakeKey                               = "max-validator-stake"
@@ -55,6 +58,18 @@ const (
 	HTTPReadHeaderTimeoutKey                           = "http-read-header-timeout"
 	HTTPWriteTimeoutKey                                = "http-write-timeout"
 	HTTPIdleTimeoutKey                                 = "http-idle-timeout"
+	HTTPMaxRequestBodySizeKey                          = "http-max-request-body-size"
+	HTTPMaxBatchSizeKey                                = "http-max-batch-size"
+	HTTPRoutePoliciesFileKey                           = "http-route-policies-file"
+	HTTPRoutePoliciesContentKey                        = "http-route-policies-file-content"
+	HTTPChainMiddlewaresFileKey                        = "http-chain-middlewares-file"
+	HTTPChainMiddlewaresContentKey                     = "http-chain-middlewares-file-content"
+	HTTPSlowRequestThresholdKey                        = "http-slow-request-threshold"
+	HTTPSlowRequestThresholdsKey                       = "http-slow-request-thresholds"
+	HTTPLoadSheddingMaxCPUUsageKey                     = "http-load-shedding-max-cpu-usage"
+	HTTPLoadSheddingMaxMemoryUsageKey                  = "http-load-shedding-max-memory-usage"
+	HTTPLoadSheddingRoutesKey                          = "http-load-shedding-routes"
+	HTTPLoadSheddingRetryAfterKey                      = "http-load-shedding-retry-after"
 	APIAuthRequiredKey                                 = "api-auth-required"
 	APIAuthPasswordKey                                 = "api-auth-password"
 	APIAuthPasswordFileKey                             = "api-auth-password-file"
@@ -69,6 +84,8 @@ const (
 	StakingTLSKeyContentKey                            = "staking-tls-key-file-content"
 	StakingCertPathKey                                 = "staking-tls-cert-file"
 	StakingCertContentKey                              = "staking-tls-cert-file-content"
+	StakingStandbyTLSKeyPathKey                        = "staking-standby-tls-key-file"
+	StakingStandbyCertPathKey                          = "staking-standby-tls-cert-file"
 	StakingEphemeralSignerEnabledKey                   = "staking-ephemeral-signer-enabled"
 	StakingSignerKeyPathKey                            = "staking-signer-key-file"
 	StakingSignerKeyContentKey                         = "staking-signer-key-file-content"
@@ -97,21 +114,44 @@ const (
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
 	NetworkCompressionTypeKey                          = "network-compression-type"
+	NetworkCompressionTypesKey                         = "network-compression-types"
+	NetworkCompressionZstdLevelKey                     = "network-compression-zstd-level"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkPeerWorkerPoolSizeKey                       = "network-peer-worker-pool-size"
+	NetworkZeroCopyPayloadsKey                         = "network-zero-copy-payloads"
+	NetworkRetiringAnnouncementEnabledKey              = "network-retiring-announcement-enabled"
+	NetworkReputationEnabledKey                        = "network-reputation-enabled"
+	NetworkReputationHandshakeFailurePenaltyKey        = "network-reputation-handshake-failure-penalty"
+	NetworkReputationInvalidMessagePenaltyKey          = "network-reputation-invalid-message-penalty"
+	NetworkReputationBenchedPenaltyKey                 = "network-reputation-benched-penalty"
+	NetworkReputationPenaltyHalflifeKey                = "network-reputation-penalty-halflife"
+	NetworkReputationMaxPenaltyKey                     = "network-reputation-max-penalty"
+	NetworkReputationMaxTrackedPeersKey                = "network-reputation-max-tracked-peers"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
+	NetworkTLSSessionResumptionEnabledKey              = "network-tls-session-resumption-enabled"
+	NetworkTLSSessionTicketKeyRotationFreqKey          = "network-tls-session-ticket-key-rotation-frequency"
+	NetworkTLSSessionTicketKeysKey                     = "network-tls-session-ticket-keys"
+	NetworkTLSMinVersionKey                            = "network-tls-min-version"
+	NetworkTLSCipherSuitesKey                          = "network-tls-cipher-suites"
 	NetworkInboundConnUpgradeThrottlerCooldownKey      = "network-inbound-connection-throttling-cooldown"
 	NetworkInboundThrottlerMaxConnsPerSecKey           = "network-inbound-connection-throttling-max-conns-per-sec"
 	NetworkOutboundConnectionThrottlingRpsKey          = "network-outbound-connection-throttling-rps"
 	NetworkOutboundConnectionTimeoutKey                = "network-outbound-connection-timeout"
+	NetworkOutboundConnectionMaxConcurrentKey          = "network-outbound-connection-max-concurrent"
+	NetworkDialFailurePenaltyThresholdKey              = "network-dial-failure-penalty-threshold"
+	NetworkDialFailurePenaltyInitialKey                = "network-dial-failure-penalty-initial"
+	NetworkDialFailurePenaltyMaxKey                    = "network-dial-failure-penalty-max"
 	BenchlistFailThresholdKey                          = "benchlist-fail-threshold"
 	BenchlistDurationKey                               = "benchlist-duration"
 	BenchlistMinFailingDurationKey                     = "benchlist-min-failing-duration"
+	BenchlistFileKey                                   = "benchlist-file"
+	BenchlistContentKey                                = "benchlist-file-content"
 	LogsDirKey                                         = "log-dir"
 	LogLevelKey                                        = "log-level"
 	LogDisplayLevelKey                                 = "log-display-level"
@@ -134,7 +174,14 @@ const (
 	AdminAPIEnabledKey                                 = "api-admin-enabled"
 	InfoAPIEnabledKey                                  = "api-info-enabled"
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
+	KeystoreSignerEndpointKey                          = "api-keystore-signer-endpoint"
+	KeystoreSignerCAFileKey                            = "api-keystore-signer-ca-file"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
+	MetricsPushEnabledKey                              = "metrics-push-enabled"
+	MetricsPushTypeKey                                 = "metrics-push-type"
+	MetricsPushEndpointKey                             = "metrics-push-endpoint"
+	MetricsPushFrequencyKey                            = "metrics-push-frequency"
+	MetricsPushLabelsKey                               = "metrics-push-labels"
 	HealthAPIEnabledKey                                = "api-health-enabled"
 	IpcAPIEnabledKey                                   = "api-ipcs-enabled"
 	IpcsChainIDsKey                                    = "ipcs-chain-ids"
@@ -151,9 +198,21 @@ const (
 	AppGossipValidatorSizeKey                          = "consensus-app-gossip-validator-size"
 	AppGossipNonValidatorSizeKey                       = "consensus-app-gossip-non-validator-size"
 	AppGossipPeerSizeKey                               = "consensus-app-gossip-peer-size"
+	ConsensusGossipRedundancyValidatorSizeKey          = "consensus-gossip-redundancy-validator-size"
+	ConsensusGossipRedundancyMaxLevelKey               = "consensus-gossip-redundancy-max-level"
+	ConsensusGossipRedundancyHighFailureRateKey        = "consensus-gossip-redundancy-high-failure-rate"
+	ConsensusGossipRedundancyLowFailureRateKey         = "consensus-gossip-redundancy-low-failure-rate"
+	ConsensusGossipRedundancyWindowKey                 = "consensus-gossip-redundancy-window"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
 	ProposerVMUseCurrentHeightKey                      = "proposervm-use-current-height"
 	FdLimitKey                                         = "fd-limit"
+	StartupSelfCheckKey                                = "startup-selfcheck"
+	StartupSelfCheckFailOnErrorKey                     = "startup-selfcheck-fail-on-error"
+	CacheMemoryBudgetKey                               = "cache-memory-budget"
+	CacheMemoryBudgetRebalanceFrequencyKey             = "cache-memory-budget-rebalance-frequency"
+	CacheMemoryBudgetMaxGCCPUFractionKey               = "cache-memory-budget-max-gc-cpu-fraction"
+	SharedMemoryGCRetentionKey                         = "shared-memory-gc-retention"
+	SharedMemoryGCFrequencyKey                         = "shared-memory-gc-frequency"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
@@ -167,15 +226,21 @@ const (
 	BootstrapMaxTimeGetAncestorsKey                    = "bootstrap-max-time-get-ancestors"
 	BootstrapAncestorsMaxContainersSentKey             = "bootstrap-ancestors-max-containers-sent"
 	BootstrapAncestorsMaxContainersReceivedKey         = "bootstrap-ancestors-max-containers-received"
+	BootstrapExecutionWorkersKey                       = "bootstrap-execution-workers"
+	BootstrapPrioritizePrimaryNetworkKey               = "bootstrap-prioritize-primary-network"
 	ChainDataDirKey                                    = "chain-data-dir"
+	ChainAcceptWALEnabledKey                           = "chain-accept-wal-enabled"
 	ChainConfigDirKey                                  = "chain-config-dir"
 	ChainConfigContentKey                              = "chain-config-content"
+	ChainGenesisOverrideDirKey                         = "chain-genesis-override-dir"
 	SubnetConfigDirKey                                 = "subnet-config-dir"
 	SubnetConfigContentKey                             = "subnet-config-content"
 	ProfileDirKey                                      = "profile-dir"
 	ProfileContinuousEnabledKey                        = "profile-continuous-enabled"
 	ProfileContinuousFreqKey                           = "profile-continuous-freq"
 	ProfileContinuousMaxFilesKey                       = "profile-continuous-max-files"
+	WatchdogStallTimeoutKey                            = "watchdog-stall-timeout"
+	WatchdogFrequencyKey                               = "watchdog-frequency"
 	InboundThrottlerAtLargeAllocSizeKey                = "throttler-inbound-at-large-alloc-size"
 	InboundThrottlerVdrAllocSizeKey                    = "throttler-inbound-validator-alloc-size"
 	InboundThrottlerNodeMaxAtLargeBytesKey             = "throttler-inbound-node-max-at-large-bytes"
@@ -184,6 +249,8 @@ const (
 	InboundThrottlerBandwidthMaxBurstSizeKey           = "throttler-inbound-bandwidth-max-burst-size"
 	InboundThrottlerCPUMaxRecheckDelayKey              = "throttler-inbound-cpu-max-recheck-delay"
 	InboundThrottlerDiskMaxRecheckDelayKey             = "throttler-inbound-disk-max-recheck-delay"
+	InboundThrottlerOpRatesKey                         = "throttler-inbound-op-rates"
+	InboundThrottlerOpMaxBurstsKey                     = "throttler-inbound-op-max-bursts"
 	CPUVdrAllocKey                                     = "throttler-inbound-cpu-validator-alloc"
 	CPUMaxNonVdrUsageKey                               = "throttler-inbound-cpu-max-non-validator-usage"
 	CPUMaxNonVdrNodeUsageKey                           = "throttler-inbound-cpu-max-non-validator-node-usage"
@@ -211,4 +278,13 @@ const (
 	TracingExporterTypeKey                             = "tracing-exporter-type"
 	TracingHeadersKey                                  = "tracing-headers"
 	ProcessContextFileKey                              = "process-context-file"
+	WebhookConfigFileKey                               = "webhook-config-file"
+	WebhookConfigContentKey                            = "webhook-config-file-content"
+	AcceptorHooksConfigFileKey                         = "acceptor-hooks-config-file"
+	AcceptorHooksConfigContentKey                      = "acceptor-hooks-config-file-content"
+	WebhookCheckFrequencyKey                           = "webhook-check-frequency"
+	WebhookRequestTimeoutKey                           = "webhook-request-timeout"
+	WebhookValidationEndWarningKey                     = "webhook-validation-end-warning"
+	WebhookChainStallDurationKey                       = "webhook-chain-stall-duration"
+	WebhookBenchedDurationKey                          = "webhook-benched-duration"
 )
@@ -33,31 +33,61 @@ func BuildViper(fs *pflag.FlagSet, args []string) (*viper.Viper, error) {
 		return nil, err
 	}
 
-	// load node configs from flags or file, depending on which flags are set
+	// Interpolation must be configured before the config is read, so it can
+	// only be enabled with flags or environment variables.
+	if err := readConfig(v, v, getInterpolator(v)); err != nil {
+		return nil, err
+	}
+
+	// Config deprecations must be after v.ReadInConfig
+	deprecateConfigs(v, os.Stdout)
+	return v, nil
+}
+
+// readConfig reads the node config specified by the flags in [v] into [dst],
+// depending on which flags are set.
+func readConfig(v *viper.Viper, dst *viper.Viper, interpolator *interpolator) error {
 	switch {
 	case v.IsSet(ConfigContentKey):
 		configContentB64 := v.GetString(ConfigContentKey)
 		configBytes, err := base64.StdEncoding.DecodeString(configContentB64)
 		if err != nil {
-			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+			return fmt.Errorf("unable to decode base64 content: %w", err)
 		}
-
-		v.SetConfigType(v.GetString(ConfigContentTypeKey))
-		if err := v.ReadConfig(bytes.NewBuffer(configBytes)); err != nil {
-			return nil, err
+		configBytes, err = interpolator.Interpolate(configBytes)
+		if err != nil {
+			return fmt.Errorf("unable to interpolate config content: %w", err)
 		}
 
+		dst.SetConfigType(v.GetString(ConfigContentTypeKey))
+		return dst.ReadConfig(bytes.NewBuffer(configBytes))
+
 	case v.IsSet(ConfigFileKey):
 		filename := GetExpandedArg(v, ConfigFileKey)
-		v.SetConfigFile(filename)
-		if err := v.ReadInConfig(); err != nil {
-			return nil, err
+		dst.SetConfigFile(filename)
+		if !interpolator.enabled {
+			return dst.ReadInConfig()
+		}
+
+		configBytes, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		configBytes, err = interpolator.Interpolate(configBytes)
+		if err != nil {
+			return fmt.Errorf("unable to interpolate config file %q: %w", filename, err)
 		}
+		return dst.ReadConfig(bytes.NewBuffer(configBytes))
 	}
+	return nil
+}
 
-	// Config deprecations must be after v.ReadInConfig
-	deprecateConfigs(v, os.Stdout)
-	return v, nil
+// uninterpolatedConfig returns the node config specified by the flags in [v]
+// without any of its references replaced. Values read from references may be
+// secrets, so this is the config that is safe to log.
+func uninterpolatedConfig(v *viper.Viper) (*viper.Viper, error) {
+	raw := viper.New()
+	return raw, readConfig(v, raw, newInterpolator(false, false))
 }
 
 func deprecateConfigs(v *viper.Viper, output io.Writer) {
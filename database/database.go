@@ -8,9 +8,8 @@
 package database
 
 import (
+	"context"
 	"io"
-
-	"github.com/ava-labs/avalanchego/api/health"
 )
 
 // KeyValueReader wraps the Has and Get method of a backing data store.
@@ -92,5 +91,9 @@ type Database interface {
 	Iteratee
 	Compacter
 	io.Closer
-	health.Checker
+
+	// HealthCheck returns health check results and, if not healthy, a non-nil
+	// error. It matches the health.Checker interface, which isn't embedded so
+	// that this package doesn't depend on the APIs.
+	HealthCheck(context.Context) (interface{}, error)
 }
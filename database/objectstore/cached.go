@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package objectstore
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var _ Store = (*cachedStore)(nil)
+
+// cachedStore keeps recently read objects in memory so that repeated reads of
+// the same object don't go to the remote store. Written objects aren't cached,
+// as objects are typically written once and rarely read back.
+type cachedStore struct {
+	store Store
+	cache cache.Cacher[string, []byte]
+}
+
+// NewCached returns [store] with a read-through cache of at most [maxSize]
+// bytes in front of it.
+func NewCached(store Store, maxSize int) Store {
+	return &cachedStore{
+		store: store,
+		cache: cache.NewSizedLRU[string, []byte](maxSize, cachedObjectSize),
+	}
+}
+
+func (s *cachedStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if value, ok := s.cache.Get(key); ok {
+		return value, nil
+	}
+
+	value, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Put(key, value)
+	return value, nil
+}
+
+func (s *cachedStore) Put(ctx context.Context, key string, value []byte) error {
+	s.cache.Evict(key)
+	return s.store.Put(ctx, key, value)
+}
+
+func cachedObjectSize(key string, value []byte) int {
+	return len(key) + len(value) + 2*constants.PointerOverhead
+}
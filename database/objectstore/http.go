@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+const (
+	// DefaultTimeout is the default timeout of requests to an HTTP store.
+	DefaultTimeout = 30 * time.Second
+
+	contentType = "application/octet-stream"
+)
+
+var (
+	_ Store = (*httpStore)(nil)
+
+	errUnexpectedStatus = errors.New("unexpected status code")
+	errInvalidScheme    = errors.New("url scheme must be http or https")
+)
+
+// httpStore stores objects with plain HTTP GET and PUT requests. This is
+// supported by S3 compatible stores and by most static file servers.
+type httpStore struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTP returns a store that reads and writes the object stored under a key
+// at [baseURL]/key. For S3 compatible stores, [baseURL] is the URL of the
+// bucket, optionally followed by a prefix. Authentication is expected to be
+// handled by the endpoint, for example by a bucket policy or a signing proxy.
+func NewHTTP(baseURL string, timeout time.Duration) (Store, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %q", errInvalidScheme, u.Scheme)
+	}
+	return &httpStore{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}, nil
+}
+
+func (s *httpStore) Get(ctx context.Context, key string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url(key), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(response.Body)
+	case http.StatusNotFound:
+		return nil, database.ErrNotFound
+	default:
+		// Drain the body so that the connection can be reused.
+		_, _ = io.Copy(io.Discard, response.Body)
+		return nil, fmt.Errorf("%w: %d", errUnexpectedStatus, response.StatusCode)
+	}
+}
+
+func (s *httpStore) Put(ctx context.Context, key string, value []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, s.url(key), bytes.NewReader(value))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", contentType)
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%w: %d", errUnexpectedStatus, response.StatusCode)
+	}
+	return nil
+}
+
+func (s *httpStore) url(key string) string {
+	return s.baseURL + "/" + key
+}
@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package objectstore provides remote storage of immutable objects, such as
+// archived blocks, that are too old to be worth keeping on local disk.
+package objectstore
+
+import "context"
+
+// Store is a remote store of immutable objects. Objects are only ever written
+// once, so implementations may cache them indefinitely.
+type Store interface {
+	// Get returns the object stored under [key]. If there is no such object,
+	// database.ErrNotFound is returned.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Put stores [value] under [key]. If an object is already stored under
+	// [key], it is overwritten.
+	Put(ctx context.Context, key string, value []byte) error
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package objectstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+// testServer serves objects the way an S3 compatible store does.
+type testServer struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+	gets    int
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		s.gets++
+		value, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(value)
+	case http.MethodPut:
+		value, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.objects[r.URL.Path] = value
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestStore(t *testing.T, prefix string) (*testServer, Store) {
+	server := &testServer{
+		objects: make(map[string][]byte),
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	store, err := NewHTTP(httpServer.URL+prefix, DefaultTimeout)
+	require.NoError(t, err)
+	return server, store
+}
+
+func TestHTTPStore(t *testing.T) {
+	require := require.New(t)
+
+	server, store := newTestStore(t, "/bucket/")
+	ctx := context.Background()
+
+	_, err := store.Get(ctx, "key")
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(store.Put(ctx, "key", []byte("value")))
+	require.Equal([]byte("value"), server.objects["/bucket/key"])
+
+	value, err := store.Get(ctx, "key")
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+}
+
+func TestHTTPStoreUnexpectedStatus(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer httpServer.Close()
+
+	require := require.New(t)
+
+	store, err := NewHTTP(httpServer.URL, DefaultTimeout)
+	require.NoError(err)
+
+	_, err = store.Get(context.Background(), "key")
+	require.ErrorIs(err, errUnexpectedStatus)
+
+	err = store.Put(context.Background(), "key", []byte("value"))
+	require.ErrorIs(err, errUnexpectedStatus)
+}
+
+func TestNewHTTPInvalidScheme(t *testing.T) {
+	_, err := NewHTTP("s3://bucket", DefaultTimeout)
+	require.ErrorIs(t, err, errInvalidScheme)
+}
+
+func TestCachedStore(t *testing.T) {
+	require := require.New(t)
+
+	server, httpStore := newTestStore(t, "")
+	store := NewCached(httpStore, units.MiB)
+	ctx := context.Background()
+
+	// Misses aren't cached.
+	_, err := store.Get(ctx, "key")
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = store.Get(ctx, "key")
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Equal(2, server.gets)
+
+	require.NoError(store.Put(ctx, "key", []byte("value")))
+
+	// Only the first read goes to the remote store.
+	for i := 0; i < 2; i++ {
+		value, err := store.Get(ctx, "key")
+		require.NoError(err)
+		require.Equal([]byte("value"), value)
+	}
+	require.Equal(3, server.gets)
+
+	// Overwriting the object evicts it from the cache.
+	require.NoError(store.Put(ctx, "key", []byte("other value")))
+	value, err := store.Get(ctx, "key")
+	require.NoError(err)
+	require.Equal([]byte("other value"), value)
+	require.Equal(4, server.gets)
+}
@@ -6,8 +6,12 @@ package rpcdb
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"sync"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -57,6 +61,46 @@ func (db *DatabaseClient) Get(key []byte) ([]byte, error) {
 	return resp.Value, errEnumToError[resp.Err]
 }
 
+// GetMany returns the values mapped to [keys] using a single round trip. The
+// returned values and errors are in the same order as [keys]. If a key isn't
+// in the database, its error is [database.ErrNotFound].
+//
+// If the server doesn't support fetching multiple keys at once, the keys are
+// fetched one at a time.
+func (db *DatabaseClient) GetMany(keys [][]byte) ([][]byte, []error, error) {
+	resp, err := db.client.GetMany(context.Background(), &rpcdbpb.GetManyRequest{
+		Keys: keys,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return db.getEach(keys)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make([][]byte, len(keys))
+	errs := make([]error, len(keys))
+	for i, value := range resp.Values {
+		values[i] = value.Value
+		errs[i] = errEnumToError[value.Err]
+	}
+	return values, errs, nil
+}
+
+func (db *DatabaseClient) getEach(keys [][]byte) ([][]byte, []error, error) {
+	values := make([][]byte, len(keys))
+	errs := make([]error, len(keys))
+	for i, key := range keys {
+		value, err := db.Get(key)
+		if errorToRPCError(err) != nil {
+			return nil, nil, err
+		}
+		values[i] = value
+		errs[i] = err
+	}
+	return values, errs, nil
+}
+
 // Put attempts to set the value this key maps to
 func (db *DatabaseClient) Put(key, value []byte) error {
 	resp, err := db.client.Put(context.Background(), &rpcdbpb.PutRequest{
@@ -231,22 +275,26 @@ func (it *iterator) fetch() {
 		close(it.onClosed)
 	}()
 
+	// Cancelling the stream before the iterator is released stops the server
+	// from reading batches that will never be consumed.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nextBatch := it.batches(ctx)
 	for {
-		resp, err := it.db.client.IteratorNext(context.Background(), &rpcdbpb.IteratorNextRequest{
-			Id: it.id,
-		})
+		data, err := nextBatch()
 		if err != nil {
 			it.setError(err)
 			return
 		}
 
-		if len(resp.Data) == 0 {
+		if len(data) == 0 {
 			return
 		}
 
 		for {
 			select {
-			case it.fetchedData <- resp.Data:
+			case it.fetchedData <- data:
 			case onUpdated := <-it.reqUpdateError:
 				it.updateError()
 				close(onUpdated)
@@ -259,6 +307,44 @@ func (it *iterator) fetch() {
 	}
 }
 
+// batches returns a function that returns the next batch of the iterator.
+// Batches are streamed from the server so that the next batch is usually
+// already available once the current batch has been consumed. If the server
+// doesn't support streaming, each batch is requested separately.
+func (it *iterator) batches(ctx context.Context) func() ([]*rpcdbpb.PutRequest, error) {
+	stream, err := it.db.client.IteratorStream(ctx, &rpcdbpb.IteratorStreamRequest{
+		Id: it.id,
+	})
+	if err != nil {
+		return func() ([]*rpcdbpb.PutRequest, error) {
+			return nil, err
+		}
+	}
+
+	return func() ([]*rpcdbpb.PutRequest, error) {
+		if stream != nil {
+			resp, err := stream.Recv()
+			switch {
+			case err == nil:
+				return resp.Data, nil
+			case errors.Is(err, io.EOF):
+				return nil, nil
+			case status.Code(err) != codes.Unimplemented:
+				return nil, err
+			}
+			stream = nil
+		}
+
+		resp, err := it.db.client.IteratorNext(ctx, &rpcdbpb.IteratorNextRequest{
+			Id: it.id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	}
+}
+
 // Next attempts to move the iterator to the next element and returns if this
 // succeeded
 func (it *iterator) Next() bool {
@@ -34,14 +34,22 @@ type DatabaseServer struct {
 	// invariant.
 	iteratorLock   sync.RWMutex
 	nextIteratorID uint64
-	iterators      map[uint64]database.Iterator
+	iterators      map[uint64]*serverIterator
+}
+
+// serverIterator guards an iterator that may be streamed to the client while
+// the client concurrently requests its error or releases it.
+type serverIterator struct {
+	lock     sync.Mutex
+	it       database.Iterator
+	released bool
 }
 
 // NewServer returns a database instance that is managed remotely
 func NewServer(db database.Database) *DatabaseServer {
 	return &DatabaseServer{
 		db:        db,
-		iterators: make(map[uint64]database.Iterator),
+		iterators: make(map[uint64]*serverIterator),
 	}
 }
 
@@ -63,6 +71,23 @@ func (db *DatabaseServer) Get(_ context.Context, req *rpcdbpb.GetRequest) (*rpcd
 	}, errorToRPCError(err)
 }
 
+// GetMany delegates the Get calls to the managed database and returns the
+// results in the order of the requested keys
+func (db *DatabaseServer) GetMany(_ context.Context, req *rpcdbpb.GetManyRequest) (*rpcdbpb.GetManyResponse, error) {
+	values := make([]*rpcdbpb.GetResponse, len(req.Keys))
+	for i, key := range req.Keys {
+		value, err := db.db.Get(key)
+		if err := errorToRPCError(err); err != nil {
+			return nil, err
+		}
+		values[i] = &rpcdbpb.GetResponse{
+			Value: value,
+			Err:   errorToErrEnum[err],
+		}
+	}
+	return &rpcdbpb.GetManyResponse{Values: values}, nil
+}
+
 // Put delegates the Put call to the managed database and returns the result
 func (db *DatabaseServer) Put(_ context.Context, req *rpcdbpb.PutRequest) (*rpcdbpb.PutResponse, error) {
 	err := db.db.Put(req.Key, req.Value)
@@ -136,47 +161,52 @@ func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *
 	defer db.iteratorLock.Unlock()
 
 	id := db.nextIteratorID
-	db.iterators[id] = it
+	db.iterators[id] = &serverIterator{it: it}
 	db.nextIteratorID++
 	return &rpcdbpb.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
 }
 
 // IteratorNext attempts to call next on the requested iterator
 func (db *DatabaseServer) IteratorNext(_ context.Context, req *rpcdbpb.IteratorNextRequest) (*rpcdbpb.IteratorNextResponse, error) {
-	db.iteratorLock.RLock()
-	it, exists := db.iterators[req.Id]
-	db.iteratorLock.RUnlock()
+	it, exists := db.getIterator(req.Id)
 	if !exists {
 		return nil, errUnknownIterator
 	}
+	return &rpcdbpb.IteratorNextResponse{Data: it.nextBatch()}, nil
+}
 
-	var (
-		size int
-		data []*rpcdbpb.PutRequest
-	)
-	for size < iterationBatchSize && it.Next() {
-		key := it.Key()
-		value := it.Value()
-		size += len(key) + len(value)
-
-		data = append(data, &rpcdbpb.PutRequest{
-			Key:   key,
-			Value: value,
-		})
+// IteratorStream sends batches of the requested iterator until it is
+// exhausted. The next batch is read while the previous batch is in flight, so
+// the client doesn't need to wait for a round trip per batch.
+//
+// The stream ends with an empty batch once the iterator is exhausted.
+func (db *DatabaseServer) IteratorStream(req *rpcdbpb.IteratorStreamRequest, stream rpcdbpb.Database_IteratorStreamServer) error {
+	it, exists := db.getIterator(req.Id)
+	if !exists {
+		return errUnknownIterator
 	}
 
-	return &rpcdbpb.IteratorNextResponse{Data: data}, nil
+	for {
+		data := it.nextBatch()
+		if err := stream.Send(&rpcdbpb.IteratorNextResponse{Data: data}); err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			return nil
+		}
+	}
 }
 
 // IteratorError attempts to report any errors that occurred during iteration
 func (db *DatabaseServer) IteratorError(_ context.Context, req *rpcdbpb.IteratorErrorRequest) (*rpcdbpb.IteratorErrorResponse, error) {
-	db.iteratorLock.RLock()
-	it, exists := db.iterators[req.Id]
-	db.iteratorLock.RUnlock()
+	it, exists := db.getIterator(req.Id)
 	if !exists {
 		return nil, errUnknownIterator
 	}
-	err := it.Error()
+
+	it.lock.Lock()
+	err := it.it.Error()
+	it.lock.Unlock()
 	return &rpcdbpb.IteratorErrorResponse{Err: errorToErrEnum[err]}, errorToRPCError(err)
 }
 
@@ -191,7 +221,46 @@ func (db *DatabaseServer) IteratorRelease(_ context.Context, req *rpcdbpb.Iterat
 	delete(db.iterators, req.Id)
 	db.iteratorLock.Unlock()
 
-	err := it.Error()
-	it.Release()
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	err := it.it.Error()
+	it.it.Release()
+	it.released = true
 	return &rpcdbpb.IteratorReleaseResponse{Err: errorToErrEnum[err]}, errorToRPCError(err)
 }
+
+func (db *DatabaseServer) getIterator(id uint64) (*serverIterator, bool) {
+	db.iteratorLock.RLock()
+	defer db.iteratorLock.RUnlock()
+
+	it, exists := db.iterators[id]
+	return it, exists
+}
+
+// nextBatch returns the next [iterationBatchSize] bytes of key-value pairs.
+// Returns an empty batch if the iterator is exhausted or was released.
+func (it *serverIterator) nextBatch() []*rpcdbpb.PutRequest {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	if it.released {
+		return nil
+	}
+
+	var (
+		size int
+		data []*rpcdbpb.PutRequest
+	)
+	for size < iterationBatchSize && it.it.Next() {
+		key := it.it.Key()
+		value := it.it.Value()
+		size += len(key) + len(value)
+
+		data = append(data, &rpcdbpb.PutRequest{
+			Key:   key,
+			Value: value,
+		})
+	}
+	return data
+}
@@ -5,18 +5,37 @@ package rpcdb
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/corruptabledb"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
 
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
 )
 
+// legacyServer doesn't support the batched APIs, like servers that predate
+// them.
+type legacyServer struct {
+	*DatabaseServer
+}
+
+func (*legacyServer) GetMany(context.Context, *rpcdbpb.GetManyRequest) (*rpcdbpb.GetManyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "GetMany")
+}
+
+func (*legacyServer) IteratorStream(*rpcdbpb.IteratorStreamRequest, rpcdbpb.Database_IteratorStreamServer) error {
+	return status.Error(codes.Unimplemented, "IteratorStream")
+}
+
 type testDatabase struct {
 	client  *DatabaseClient
 	server  *memdb.Database
@@ -24,6 +43,18 @@ type testDatabase struct {
 }
 
 func setupDB(t testing.TB) *testDatabase {
+	return setupDBWithServer(t, func(server *DatabaseServer) rpcdbpb.DatabaseServer {
+		return server
+	})
+}
+
+func setupLegacyDB(t testing.TB) *testDatabase {
+	return setupDBWithServer(t, func(server *DatabaseServer) rpcdbpb.DatabaseServer {
+		return &legacyServer{DatabaseServer: server}
+	})
+}
+
+func setupDBWithServer(t testing.TB, wrap func(*DatabaseServer) rpcdbpb.DatabaseServer) *testDatabase {
 	require := require.New(t)
 
 	db := &testDatabase{
@@ -35,7 +66,7 @@ func setupDB(t testing.TB) *testDatabase {
 	serverCloser := grpcutils.ServerCloser{}
 
 	server := grpcutils.NewServer()
-	rpcdbpb.RegisterDatabaseServer(server, NewServer(db.server))
+	rpcdbpb.RegisterDatabaseServer(server, wrap(NewServer(db.server)))
 	serverCloser.Add(server)
 
 	go grpcutils.Serve(listener, server)
@@ -61,6 +92,78 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestInterfaceLegacyServer(t *testing.T) {
+	for _, test := range database.Tests {
+		db := setupLegacyDB(t)
+		test(t, db.client)
+
+		db.closeFn()
+	}
+}
+
+func TestGetMany(t *testing.T) {
+	for name, setup := range map[string]func(testing.TB) *testDatabase{
+		"batched": setupDB,
+		"legacy":  setupLegacyDB,
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := setup(t)
+			defer db.closeFn()
+
+			require.NoError(db.server.Put([]byte("key1"), []byte("value1")))
+			require.NoError(db.server.Put([]byte("key2"), []byte("value2")))
+
+			values, errs, err := db.client.GetMany([][]byte{
+				[]byte("key2"),
+				[]byte("missing"),
+				[]byte("key1"),
+			})
+			require.NoError(err)
+			require.Equal([][]byte{[]byte("value2"), nil, []byte("value1")}, values)
+			require.Len(errs, 3)
+			require.NoError(errs[0])
+			require.ErrorIs(errs[1], database.ErrNotFound)
+			require.NoError(errs[2])
+
+			require.NoError(db.server.Close())
+			_, errs, err = db.client.GetMany([][]byte{[]byte("key1")})
+			require.NoError(err)
+			require.ErrorIs(errs[0], database.ErrClosed)
+		})
+	}
+}
+
+// Tests that releasing an iterator before it is exhausted cancels the stream
+// of batches and releases the iterator on the server.
+func TestIteratorStreamRelease(t *testing.T) {
+	require := require.New(t)
+
+	db := setupDB(t)
+	defer db.closeFn()
+
+	// Write enough data for the iterator to be split across many batches.
+	value := utils.RandomBytes(iterationBatchSize / 4)
+	for i := 0; i < 64; i++ {
+		require.NoError(db.server.Put([]byte(fmt.Sprintf("key%02d", i)), value))
+	}
+
+	it := db.client.NewIterator()
+	require.True(it.Next())
+	require.Equal([]byte("key00"), it.Key())
+	require.Equal(value, it.Value())
+	it.Release()
+	require.NoError(it.Error())
+
+	// The iterator should no longer exist on the server.
+	_, err := db.client.client.IteratorError(context.Background(), &rpcdbpb.IteratorErrorRequest{
+		Id: 0,
+	})
+	require.Error(err) //nolint:forbidigo
+	require.Contains(err.Error(), errUnknownIterator.Error())
+}
+
 func FuzzKeyValue(f *testing.F) {
 	db := setupDB(f)
 	database.FuzzKeyValue(f, db.client)
@@ -86,6 +189,67 @@ func BenchmarkInterface(b *testing.B) {
 	}
 }
 
+// BenchmarkIteratePrefix compares iterating over a large prefix by streaming
+// batches against requesting each batch separately.
+func BenchmarkIteratePrefix(b *testing.B) {
+	for name, setup := range map[string]func(testing.TB) *testDatabase{
+		"streamed":  setupDB,
+		"requested": setupLegacyDB,
+	} {
+		for _, numEntries := range []int{1_000, 10_000} {
+			b.Run(fmt.Sprintf("%s_%d_entries", name, numEntries), func(b *testing.B) {
+				db := setup(b)
+				defer db.closeFn()
+
+				value := utils.RandomBytes(1024)
+				for i := 0; i < numEntries; i++ {
+					require.NoError(b, db.server.Put([]byte(fmt.Sprintf("prefix%08d", i)), value))
+				}
+
+				b.ResetTimer()
+				for n := 0; n < b.N; n++ {
+					it := db.client.NewIteratorWithPrefix([]byte("prefix"))
+					count := 0
+					for it.Next() {
+						count++
+					}
+					require.NoError(b, it.Error())
+					require.Equal(b, numEntries, count)
+					it.Release()
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkGetMany compares fetching many keys at once against fetching each
+// key separately.
+func BenchmarkGetMany(b *testing.B) {
+	for name, setup := range map[string]func(testing.TB) *testDatabase{
+		"batched":  setupDB,
+		"separate": setupLegacyDB,
+	} {
+		for _, numKeys := range []int{10, 100} {
+			b.Run(fmt.Sprintf("%s_%d_keys", name, numKeys), func(b *testing.B) {
+				db := setup(b)
+				defer db.closeFn()
+
+				keys := make([][]byte, numKeys)
+				for i := range keys {
+					keys[i] = utils.RandomBytes(32)
+					require.NoError(b, db.server.Put(keys[i], utils.RandomBytes(256)))
+				}
+
+				b.ResetTimer()
+				for n := 0; n < b.N; n++ {
+					_, _, err := db.client.GetMany(keys)
+					require.NoError(b, err)
+				}
+			})
+		}
+	}
+}
+
 func TestHealthCheck(t *testing.T) {
 	scenarios := []struct {
 		name         string
@@ -52,6 +52,9 @@ type TxFeeConfig struct {
 	AddSubnetValidatorFee uint64 `json:"addSubnetValidatorFee"`
 	// Transaction fee for adding a subnet delegator
 	AddSubnetDelegatorFee uint64 `json:"addSubnetDelegatorFee"`
+	// Minimum amount of AVAX held by each X-chain output once the dust policy
+	// is activated. If 0, outputs of any amount are allowed.
+	MinOutputAmount uint64 `json:"minOutputAmount"`
 }
 
 type Params struct {
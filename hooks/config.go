@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package hooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// DefaultQueueSize is the number of accepted containers that are buffered
+// for a hook if no queue size is configured.
+const DefaultQueueSize = 1024
+
+var (
+	errMissingName       = errors.New("missing hook name")
+	errDuplicateName     = errors.New("hook enabled multiple times")
+	errNegativeQueueSize = errors.New("hook queue size must be non-negative")
+)
+
+type Config struct {
+	// Name of the registered hook.
+	Name string `json:"name"`
+
+	// ChainIDs the hook receives accepted containers from. If empty, the hook
+	// receives the accepted containers of every chain.
+	ChainIDs []ids.ID `json:"chainIDs"`
+
+	// QueueSize is the number of accepted containers that can be waiting to
+	// be handled by the hook. If 0, [DefaultQueueSize] is used.
+	QueueSize int `json:"queueSize"`
+
+	// BlockOnFull applies backpressure to consensus when the queue is full by
+	// waiting for the hook to catch up before finishing acceptance. If false,
+	// containers that don't fit in the queue are dropped.
+	BlockOnFull bool `json:"blockOnFull"`
+
+	// Config is passed to the hook's factory.
+	//
+	// Config is never marshalled, because it may contain credentials that
+	// shouldn't be included when the node config is logged.
+	Config []byte `json:"-"`
+}
+
+// configJSON is the format of a hook in the acceptor hooks config file.
+type configJSON struct {
+	Name        string          `json:"name"`
+	ChainIDs    []ids.ID        `json:"chainIDs"`
+	QueueSize   int             `json:"queueSize"`
+	BlockOnFull bool            `json:"blockOnFull"`
+	Config      json.RawMessage `json:"config"`
+}
+
+// ParseConfigs parses the acceptor hooks config file.
+func ParseConfigs(configBytes []byte) ([]Config, error) {
+	var parsed struct {
+		Hooks []configJSON `json:"hooks"`
+	}
+	if err := json.Unmarshal(configBytes, &parsed); err != nil {
+		return nil, err
+	}
+
+	configs := make([]Config, len(parsed.Hooks))
+	for i, h := range parsed.Hooks {
+		configs[i] = Config{
+			Name:        h.Name,
+			ChainIDs:    h.ChainIDs,
+			QueueSize:   h.QueueSize,
+			BlockOnFull: h.BlockOnFull,
+			Config:      h.Config,
+		}
+	}
+	return configs, nil
+}
+
+// VerifyConfigs verifies that [configs] are well formed. It doesn't verify
+// that the hooks are registered, because hooks may be registered after the
+// config is parsed.
+func VerifyConfigs(configs []Config) error {
+	names := make(map[string]struct{}, len(configs))
+	for _, config := range configs {
+		if config.Name == "" {
+			return errMissingName
+		}
+		if _, ok := names[config.Name]; ok {
+			return fmt.Errorf("%w: %q", errDuplicateName, config.Name)
+		}
+		names[config.Name] = struct{}{}
+
+		if config.QueueSize < 0 {
+			return fmt.Errorf("%w: %q has queue size %d", errNegativeQueueSize, config.Name, config.QueueSize)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package hooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigs(t *testing.T) {
+	require := require.New(t)
+
+	configs, err := ParseConfigs([]byte(`{
+		"hooks": [
+			{
+				"name": "kafka",
+				"queueSize": 16,
+				"blockOnFull": true,
+				"config": {"brokers": ["localhost:9092"]}
+			}
+		]
+	}`))
+	require.NoError(err)
+	require.Equal([]Config{{
+		Name:        "kafka",
+		QueueSize:   16,
+		BlockOnFull: true,
+		Config:      []byte(`{"brokers": ["localhost:9092"]}`),
+	}}, configs)
+	require.NoError(VerifyConfigs(configs))
+}
+
+func TestVerifyConfigs(t *testing.T) {
+	tests := []struct {
+		name        string
+		configs     []Config
+		expectedErr error
+	}{
+		{
+			name:        "missing name",
+			configs:     []Config{{}},
+			expectedErr: errMissingName,
+		},
+		{
+			name:        "duplicate name",
+			configs:     []Config{{Name: "a"}, {Name: "a"}},
+			expectedErr: errDuplicateName,
+		},
+		{
+			name:        "negative queue size",
+			configs:     []Config{{Name: "a", QueueSize: -1}},
+			expectedErr: errNegativeQueueSize,
+		},
+		{
+			name:    "valid",
+			configs: []Config{{Name: "a"}, {Name: "b", QueueSize: 1}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyConfigs(test.configs)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
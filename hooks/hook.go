@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package hooks allows operators to receive every container accepted by the
+// node without modifying the node itself.
+//
+// A hook is compiled into the node by adding a package that calls [Register]
+// from its init function and blank importing that package from main:
+//
+//	func init() {
+//		hooks.Register("my-pipeline", func(log logging.Logger, config []byte) (hooks.Hook, error) {
+//			return newPipeline(log, config)
+//		})
+//	}
+//
+// The hook is then enabled by listing it in the acceptor hooks config.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	Block ContainerType = iota + 1
+	Tx
+	Vertex
+)
+
+var (
+	errDuplicateHook = errors.New("duplicate hook")
+	errUnknownHook   = errors.New("unknown hook")
+
+	factoriesLock sync.RWMutex
+	factories     = make(map[string]Factory)
+)
+
+// ContainerType is the kind of container that was accepted.
+type ContainerType byte
+
+func (t ContainerType) String() string {
+	switch t {
+	case Block:
+		return "block"
+	case Tx:
+		return "tx"
+	case Vertex:
+		return "vertex"
+	default:
+		return "unknown"
+	}
+}
+
+// Container is an accepted container.
+type Container struct {
+	ChainID ids.ID
+	Type    ContainerType
+	ID      ids.ID
+	Bytes   []byte
+}
+
+// Hook receives the containers accepted by the node.
+type Hook interface {
+	// Accept is called with every accepted container of the chains the hook
+	// is enabled for. Containers of a chain are provided in the order they
+	// were accepted.
+	//
+	// Accept is never called concurrently.
+	Accept(ctx context.Context, container Container) error
+
+	// Close is called once no more containers will be provided.
+	Close() error
+}
+
+// Factory creates a hook from its [config], as provided in the acceptor
+// hooks config.
+type Factory func(log logging.Logger, config []byte) (Hook, error)
+
+// Register makes [factory] available to be enabled under [name].
+//
+// Register is expected to be called from an init function. If a factory is
+// already registered under [name], Register panics.
+func Register(name string, factory Factory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	if _, ok := factories[name]; ok {
+		panic(fmt.Errorf("%w: %q", errDuplicateHook, name))
+	}
+	factories[name] = factory
+}
+
+func getFactory(name string) (Factory, error) {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownHook, name)
+	}
+	return factory, nil
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package hooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var _ snow.Acceptor = (*acceptor)(nil)
+
+// Manager delivers accepted containers to the enabled hooks.
+type Manager struct {
+	log                 logging.Logger
+	blockAcceptorGroup  snow.AcceptorGroup
+	txAcceptorGroup     snow.AcceptorGroup
+	vertexAcceptorGroup snow.AcceptorGroup
+	dispatchers         []*dispatcher
+}
+
+// NewManager creates the hooks described by [configs]. The hooks start
+// receiving the accepted containers of a chain once the chain is registered.
+func NewManager(
+	log logging.Logger,
+	configs []Config,
+	blockAcceptorGroup snow.AcceptorGroup,
+	txAcceptorGroup snow.AcceptorGroup,
+	vertexAcceptorGroup snow.AcceptorGroup,
+	metricsNamespace string,
+	metricsRegisterer prometheus.Registerer,
+) (*Manager, error) {
+	metrics, err := newMetrics(metricsNamespace, metricsRegisterer)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{
+		log:                 log,
+		blockAcceptorGroup:  blockAcceptorGroup,
+		txAcceptorGroup:     txAcceptorGroup,
+		vertexAcceptorGroup: vertexAcceptorGroup,
+	}
+	for _, config := range configs {
+		factory, err := getFactory(config.Name)
+		if err != nil {
+			m.Shutdown()
+			return nil, err
+		}
+		hook, err := factory(log, config.Config)
+		if err != nil {
+			m.Shutdown()
+			return nil, fmt.Errorf("couldn't create hook %q: %w", config.Name, err)
+		}
+
+		queueSize := config.QueueSize
+		if queueSize == 0 {
+			queueSize = DefaultQueueSize
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		d := &dispatcher{
+			name:        config.Name,
+			log:         log,
+			hook:        hook,
+			chainIDs:    set.Of(config.ChainIDs...),
+			blockOnFull: config.BlockOnFull,
+			queue:       make(chan Container, queueSize),
+			ctx:         ctx,
+			cancel:      cancel,
+			done:        make(chan struct{}),
+			delivered:   metrics.delivered.WithLabelValues(config.Name),
+			dropped:     metrics.dropped.WithLabelValues(config.Name),
+			failed:      metrics.failed.WithLabelValues(config.Name),
+			queued:      metrics.queued.WithLabelValues(config.Name),
+		}
+		go d.run()
+		m.dispatchers = append(m.dispatchers, d)
+	}
+	return m, nil
+}
+
+// RegisterChain starts delivering the containers accepted on the chain to
+// the hooks that are enabled for it.
+func (m *Manager) RegisterChain(chainName string, ctx *snow.ConsensusContext, _ common.VM) {
+	for _, d := range m.dispatchers {
+		if d.chainIDs.Len() != 0 && !d.chainIDs.Contains(ctx.ChainID) {
+			continue
+		}
+
+		acceptorName := "hook-" + d.name
+		groups := []struct {
+			group snow.AcceptorGroup
+			typ   ContainerType
+		}{
+			{group: m.blockAcceptorGroup, typ: Block},
+			{group: m.txAcceptorGroup, typ: Tx},
+			{group: m.vertexAcceptorGroup, typ: Vertex},
+		}
+		for _, g := range groups {
+			a := &acceptor{
+				dispatcher: d,
+				typ:        g.typ,
+			}
+			if err := g.group.RegisterAcceptor(ctx.ChainID, acceptorName, a, false); err != nil {
+				m.log.Error("failed to register hook",
+					zap.String("hook", d.name),
+					zap.String("chainName", chainName),
+					zap.Stringer("chainID", ctx.ChainID),
+					zap.Stringer("containerType", g.typ),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+// Shutdown stops delivering containers and closes the hooks. Containers that
+// are still queued are dropped.
+func (m *Manager) Shutdown() {
+	for _, d := range m.dispatchers {
+		d.shutdown()
+	}
+}
+
+type acceptor struct {
+	dispatcher *dispatcher
+	typ        ContainerType
+}
+
+func (a *acceptor) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	a.dispatcher.enqueue(Container{
+		ChainID: ctx.ChainID,
+		Type:    a.typ,
+		ID:      containerID,
+		Bytes:   container,
+	})
+	return nil
+}
+
+type dispatcher struct {
+	name        string
+	log         logging.Logger
+	hook        Hook
+	chainIDs    set.Set[ids.ID]
+	blockOnFull bool
+	queue       chan Container
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	delivered prometheus.Counter
+	dropped   prometheus.Counter
+	failed    prometheus.Counter
+	queued    prometheus.Gauge
+}
+
+func (d *dispatcher) enqueue(container Container) {
+	if d.blockOnFull {
+		select {
+		case d.queue <- container:
+			d.queued.Inc()
+		case <-d.ctx.Done():
+		}
+		return
+	}
+
+	select {
+	case d.queue <- container:
+		d.queued.Inc()
+	default:
+		d.dropped.Inc()
+		d.log.Debug("dropping accepted container",
+			zap.String("reason", "hook queue is full"),
+			zap.String("hook", d.name),
+			zap.Stringer("chainID", container.ChainID),
+			zap.Stringer("containerID", container.ID),
+		)
+	}
+}
+
+func (d *dispatcher) run() {
+	defer close(d.done)
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case container := <-d.queue:
+			d.queued.Dec()
+			if err := d.hook.Accept(d.ctx, container); err != nil {
+				d.failed.Inc()
+				d.log.Warn("hook failed to handle accepted container",
+					zap.String("hook", d.name),
+					zap.Stringer("chainID", container.ChainID),
+					zap.Stringer("containerType", container.Type),
+					zap.Stringer("containerID", container.ID),
+					zap.Error(err),
+				)
+				continue
+			}
+			d.delivered.Inc()
+		}
+	}
+}
+
+func (d *dispatcher) shutdown() {
+	d.cancel()
+	<-d.done
+
+	if err := d.hook.Close(); err != nil {
+		d.log.Warn("failed to close hook",
+			zap.String("hook", d.name),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type testHook struct {
+	config   []byte
+	accepted chan Container
+	// If non-nil, Accept signals [started] and then blocks until [release]
+	// is closed
+	started chan struct{}
+	release chan struct{}
+	closed  bool
+}
+
+func (h *testHook) Accept(_ context.Context, container Container) error {
+	if h.release != nil {
+		h.started <- struct{}{}
+		<-h.release
+	}
+	h.accepted <- container
+	return nil
+}
+
+func (h *testHook) Close() error {
+	h.closed = true
+	return nil
+}
+
+func registerTestHook(name string, hook *testHook) {
+	Register(name, func(_ logging.Logger, config []byte) (Hook, error) {
+		hook.config = config
+		return hook, nil
+	})
+}
+
+type testGroups struct {
+	block  snow.AcceptorGroup
+	tx     snow.AcceptorGroup
+	vertex snow.AcceptorGroup
+}
+
+func newTestManager(t *testing.T, configs []Config) (*Manager, testGroups) {
+	groups := testGroups{
+		block:  snow.NewAcceptorGroup(logging.NoLog{}),
+		tx:     snow.NewAcceptorGroup(logging.NoLog{}),
+		vertex: snow.NewAcceptorGroup(logging.NoLog{}),
+	}
+	m, err := NewManager(
+		logging.NoLog{},
+		configs,
+		groups.block,
+		groups.tx,
+		groups.vertex,
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	return m, groups
+}
+
+func TestManagerDeliversAcceptedContainers(t *testing.T) {
+	require := require.New(t)
+
+	hook := &testHook{
+		accepted: make(chan Container, 2),
+	}
+	registerTestHook("deliver", hook)
+
+	m, groups := newTestManager(t, []Config{{
+		Name:   "deliver",
+		Config: []byte(`{"key":"value"}`),
+	}})
+	require.Equal([]byte(`{"key":"value"}`), hook.config)
+
+	ctx := snow.DefaultConsensusContextTest()
+	m.RegisterChain("chain", ctx, nil)
+
+	blkID := ids.GenerateTestID()
+	require.NoError(groups.block.Accept(ctx, blkID, []byte{1}))
+	require.Equal(Container{
+		ChainID: ctx.ChainID,
+		Type:    Block,
+		ID:      blkID,
+		Bytes:   []byte{1},
+	}, <-hook.accepted)
+
+	txID := ids.GenerateTestID()
+	require.NoError(groups.tx.Accept(ctx, txID, []byte{2}))
+	require.Equal(Container{
+		ChainID: ctx.ChainID,
+		Type:    Tx,
+		ID:      txID,
+		Bytes:   []byte{2},
+	}, <-hook.accepted)
+
+	m.Shutdown()
+	require.True(hook.closed)
+}
+
+func TestManagerFiltersChains(t *testing.T) {
+	require := require.New(t)
+
+	hook := &testHook{
+		accepted: make(chan Container, 1),
+	}
+	registerTestHook("filter", hook)
+
+	m, groups := newTestManager(t, []Config{{
+		Name:     "filter",
+		ChainIDs: []ids.ID{ids.GenerateTestID()},
+	}})
+	defer m.Shutdown()
+
+	ctx := snow.DefaultConsensusContextTest()
+	m.RegisterChain("chain", ctx, nil)
+
+	require.NoError(groups.block.Accept(ctx, ids.GenerateTestID(), nil))
+	require.Empty(hook.accepted)
+}
+
+func TestManagerDropsWhenFull(t *testing.T) {
+	require := require.New(t)
+
+	hook := &testHook{
+		accepted: make(chan Container, 3),
+		started:  make(chan struct{}, 3),
+		release:  make(chan struct{}),
+	}
+	registerTestHook("drop", hook)
+
+	m, groups := newTestManager(t, []Config{{
+		Name:      "drop",
+		QueueSize: 1,
+	}})
+
+	ctx := snow.DefaultConsensusContextTest()
+	m.RegisterChain("chain", ctx, nil)
+
+	// The first container is being handled by the hook, the second container
+	// fills the queue, and the rest are dropped.
+	require.NoError(groups.block.Accept(ctx, ids.GenerateTestID(), nil))
+	<-hook.started
+	require.NoError(groups.block.Accept(ctx, ids.GenerateTestID(), nil))
+	require.NoError(groups.block.Accept(ctx, ids.GenerateTestID(), nil))
+	require.Len(m.dispatchers[0].queue, 1)
+
+	close(hook.release)
+	<-hook.accepted
+	<-hook.accepted
+	m.Shutdown()
+}
+
+func TestNewManagerUnknownHook(t *testing.T) {
+	_, err := NewManager(
+		logging.NoLog{},
+		[]Config{{Name: "unknown"}},
+		snow.NewAcceptorGroup(logging.NoLog{}),
+		snow.NewAcceptorGroup(logging.NoLog{}),
+		snow.NewAcceptorGroup(logging.NoLog{}),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.ErrorIs(t, err, errUnknownHook)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package hooks
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const hookLabel = "hook"
+
+type metrics struct {
+	delivered *prometheus.CounterVec
+	dropped   *prometheus.CounterVec
+	failed    *prometheus.CounterVec
+	queued    *prometheus.GaugeVec
+}
+
+func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		delivered: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "delivered",
+				Help:      "Number of accepted containers handled by a hook",
+			},
+			[]string{hookLabel},
+		),
+		dropped: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "dropped",
+				Help:      "Number of accepted containers dropped because a hook's queue was full",
+			},
+			[]string{hookLabel},
+		),
+		failed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "failed",
+				Help:      "Number of accepted containers a hook failed to handle",
+			},
+			[]string{hookLabel},
+		),
+		queued: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "queued",
+				Help:      "Number of accepted containers waiting to be handled by a hook",
+			},
+			[]string{hookLabel},
+		),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.delivered),
+		registerer.Register(m.dropped),
+		registerer.Register(m.failed),
+		registerer.Register(m.queued),
+	)
+	return m, errs.Err
+}
@@ -35,6 +35,9 @@ type AliaserWriter interface {
 
 	// RemoveAliases of the provided ID
 	RemoveAliases(id ID)
+
+	// RemoveAlias removes [alias] from the ID it was given to, if any
+	RemoveAlias(alias string)
 }
 
 // Aliaser allows one to give an ID aliases and lookup the aliases given to an
@@ -121,6 +124,31 @@ func (a *aliaser) RemoveAliases(id ID) {
 	}
 }
 
+func (a *aliaser) RemoveAlias(alias string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	id, ok := a.dealias[alias]
+	if !ok {
+		return
+	}
+	delete(a.dealias, alias)
+
+	aliases := a.aliases[id]
+	for i, idAlias := range aliases {
+		if idAlias != alias {
+			continue
+		}
+		aliases = append(aliases[:i:i], aliases[i+1:]...)
+		break
+	}
+	if len(aliases) == 0 {
+		delete(a.aliases, id)
+		return
+	}
+	a.aliases[id] = aliases
+}
+
 // GetRelevantAliases returns the aliases with the redundant identity alias
 // removed (each id is aliased to at least itself).
 func GetRelevantAliases(aliaser Aliaser, ids []ID) (map[ID][]string, error) {
@@ -32,3 +32,26 @@ func TestPrimaryAliasOrDefaultTest(t *testing.T) {
 	expected := "Batman"
 	require.Equal(expected, aliaser.PrimaryAliasOrDefault(id2))
 }
+
+func TestAliaserRemoveSingleAlias(t *testing.T) {
+	require := require.New(t)
+	aliaser := NewAliaser()
+	id := ID{'B', 'r', 'u', 'c', 'e', ' ', 'W', 'a', 'y', 'n', 'e'}
+	require.NoError(aliaser.Alias(id, "Batman"))
+	require.NoError(aliaser.Alias(id, "Dark Knight"))
+
+	aliaser.RemoveAlias("Batman")
+	aliaser.RemoveAlias("Joker")
+
+	_, err := aliaser.Lookup("Batman")
+	require.ErrorIs(err, ErrNoIDWithAlias)
+	aliases, err := aliaser.Aliases(id)
+	require.NoError(err)
+	require.Equal([]string{"Dark Knight"}, aliases)
+
+	aliaser.RemoveAlias("Dark Knight")
+	require.Equal(id.String(), aliaser.PrimaryAliasOrDefault(id))
+
+	// The alias can be given to another ID once removed.
+	require.NoError(aliaser.Alias(ID{1}, "Batman"))
+}
@@ -4,22 +4,37 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 
 	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	"golang.org/x/term"
 
 	"github.com/ava-labs/avalanchego/app"
 	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/node"
 	"github.com/ava-labs/avalanchego/version"
 )
 
+// checkConfigCommand parses and validates the node config, then prints the
+// effective config without starting the node.
+const checkConfigCommand = "check-config"
+
 func main() {
+	args := os.Args[1:]
+	checkConfig := len(args) > 0 && args[0] == checkConfigCommand
+	if checkConfig {
+		args = args[1:]
+	}
+
 	fs := config.BuildFlagSet()
-	v, err := config.BuildViper(fs, os.Args[1:])
+	v, err := config.BuildViper(fs, args)
 
 	if errors.Is(err, pflag.ErrHelp) {
 		os.Exit(0)
@@ -35,6 +50,10 @@ func main() {
 		os.Exit(0)
 	}
 
+	if checkConfig {
+		os.Exit(runCheckConfig(v))
+	}
+
 	nodeConfig, err := config.GetNodeConfig(v)
 	if err != nil {
 		fmt.Printf("couldn't load node config: %s\n", err)
@@ -49,3 +68,27 @@ func main() {
 	exitCode := app.Run(nodeApp)
 	os.Exit(exitCode)
 }
+
+func runCheckConfig(v *viper.Viper) int {
+	nodeConfig, err := config.GetNodeConfigDryRun(v)
+	if err != nil {
+		fmt.Printf("invalid node config: %s\n", err)
+		return 1
+	}
+
+	chainConfigs := maps.Keys(nodeConfig.ChainConfigs)
+	slices.Sort(chainConfigs)
+	configJSON, err := json.MarshalIndent(struct {
+		Config       node.Config `json:"config"`
+		ChainConfigs []string    `json:"chainConfigs"`
+	}{
+		Config:       nodeConfig,
+		ChainConfigs: chainConfigs,
+	}, "", "  ")
+	if err != nil {
+		fmt.Printf("couldn't marshal node config: %s\n", err)
+		return 1
+	}
+	fmt.Println(string(configJSON))
+	return 0
+}
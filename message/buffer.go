@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// minBufferSizeClass is the log2 of the smallest buffer that is pooled.
+	minBufferSizeClass = 10
+	// maxBufferSizeClass is the log2 of the largest buffer that is pooled,
+	// which fits a message of the default maximum size. Larger buffers are
+	// allocated on demand.
+	maxBufferSizeClass = 21
+
+	numBufferSizeClasses = maxBufferSizeClass - minBufferSizeClass + 1
+)
+
+// BufferPool recycles the buffers that inbound messages are read into.
+//
+// Buffers are reference counted. A buffer is returned to the pool once its
+// last reference is released, unless it was detached because its bytes may be
+// referenced after the release.
+type BufferPool struct {
+	pools       [numBufferSizeClasses]sync.Pool
+	outstanding atomic.Int64
+}
+
+func NewBufferPool() *BufferPool {
+	return &BufferPool{}
+}
+
+// Get returns a buffer of [size] bytes with a single reference. The contents
+// of the buffer are undefined.
+func (p *BufferPool) Get(size int) *Buffer {
+	p.outstanding.Add(1)
+
+	class := bufferSizeClass(size)
+	if class >= numBufferSizeClasses {
+		b := &Buffer{
+			pool:  p,
+			bytes: make([]byte, size),
+		}
+		b.refs.Store(1)
+		return b
+	}
+
+	b, ok := p.pools[class].Get().(*Buffer)
+	if !ok {
+		b = &Buffer{
+			pool:  p,
+			bytes: make([]byte, 1<<(class+minBufferSizeClass)),
+		}
+	}
+	b.bytes = b.bytes[:size]
+	b.refs.Store(1)
+	return b
+}
+
+// Outstanding returns the number of buffers that have not been released. This
+// is used to detect leaked references.
+func (p *BufferPool) Outstanding() int {
+	return int(p.outstanding.Load())
+}
+
+func (p *BufferPool) put(b *Buffer) {
+	p.outstanding.Add(-1)
+	if b.detached.Load() {
+		return
+	}
+
+	class := bufferSizeClass(cap(b.bytes))
+	if class >= numBufferSizeClasses || cap(b.bytes) != 1<<(class+minBufferSizeClass) {
+		return
+	}
+	p.pools[class].Put(b)
+}
+
+// bufferSizeClass returns the index of the smallest pool whose buffers hold
+// [size] bytes.
+func bufferSizeClass(size int) int {
+	if size <= 1<<minBufferSizeClass {
+		return 0
+	}
+	return bits.Len(uint(size-1)) - minBufferSizeClass
+}
+
+// Buffer is a reference counted byte slice from a BufferPool.
+type Buffer struct {
+	pool     *BufferPool
+	bytes    []byte
+	refs     atomic.Int32
+	detached atomic.Bool
+}
+
+// Bytes returns the contents of the buffer. The returned slice must not be
+// used after the last reference to the buffer is released, unless the buffer
+// was detached.
+func (b *Buffer) Bytes() []byte {
+	return b.bytes
+}
+
+// Retain adds a reference to the buffer.
+func (b *Buffer) Retain() {
+	if b.refs.Add(1) <= 1 {
+		panic("retained a released buffer")
+	}
+}
+
+// Release removes a reference to the buffer. Once every reference is released,
+// the buffer is returned to its pool.
+func (b *Buffer) Release() {
+	switch refs := b.refs.Add(-1); {
+	case refs == 0:
+		b.pool.put(b)
+	case refs < 0:
+		panic("released a buffer more times than it was retained")
+	}
+}
+
+// Detach marks the contents of the buffer as referenced beyond the lifetime of
+// the buffer, so that they are never overwritten by a future use of the
+// buffer.
+func (b *Buffer) Detach() {
+	b.detached.Store(true)
+}
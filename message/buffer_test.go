@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestBufferSizeClass(t *testing.T) {
+	tests := []struct {
+		size  int
+		class int
+	}{
+		{
+			size:  0,
+			class: 0,
+		},
+		{
+			size:  1 << minBufferSizeClass,
+			class: 0,
+		},
+		{
+			size:  1<<minBufferSizeClass + 1,
+			class: 1,
+		},
+		{
+			size:  constants.DefaultMaxMessageSize,
+			class: numBufferSizeClasses - 1,
+		},
+		{
+			size:  constants.DefaultMaxMessageSize + 1,
+			class: numBufferSizeClasses,
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.class, bufferSizeClass(test.size), "size %d", test.size)
+	}
+}
+
+func TestBufferPool(t *testing.T) {
+	require := require.New(t)
+
+	pool := NewBufferPool()
+	for _, size := range []int{0, 100, 5000, constants.DefaultMaxMessageSize + 1} {
+		buf := pool.Get(size)
+		require.Len(buf.Bytes(), size)
+		require.Equal(1, pool.Outstanding())
+
+		buf.Retain()
+		buf.Release()
+		require.Equal(1, pool.Outstanding())
+
+		buf.Release()
+		require.Zero(pool.Outstanding())
+	}
+}
+
+func TestBufferReleasedTooManyTimes(t *testing.T) {
+	pool := NewBufferPool()
+	buf := pool.Get(100)
+	buf.Release()
+
+	require.Panics(t, buf.Release)
+	require.Panics(t, buf.Retain)
+}
+
+func TestBufferDetached(t *testing.T) {
+	require := require.New(t)
+
+	pool := NewBufferPool()
+	buf := pool.Get(100)
+	buf.Detach()
+	bytes := buf.Bytes()
+	bytes[0] = 1
+	buf.Release()
+	require.Zero(pool.Outstanding())
+
+	// A detached buffer is never handed out again.
+	for i := 0; i < 100; i++ {
+		next := pool.Get(100)
+		require.NotSame(buf, next)
+		next.Bytes()[0] = 2
+		next.Release()
+	}
+	require.Equal(byte(1), bytes[0])
+}
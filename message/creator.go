@@ -30,6 +30,7 @@ func NewCreator(
 	metrics prometheus.Registerer,
 	parentNamespace string,
 	compressionType compression.Type,
+	zstdLevel int,
 	maxMessageTimeout time.Duration,
 ) (Creator, error) {
 	namespace := fmt.Sprintf("%s_codec", parentNamespace)
@@ -37,6 +38,7 @@ func NewCreator(
 		log,
 		namespace,
 		metrics,
+		zstdLevel,
 		maxMessageTimeout,
 	)
 	if err != nil {
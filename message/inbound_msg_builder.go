@@ -20,6 +20,15 @@ type InboundMsgBuilder interface {
 		nodeID ids.NodeID,
 		onFinishedHandling func(),
 	) (InboundMessage, error)
+
+	// ParseBuffer reads [buf] as InboundMessage without copying its payload.
+	// The caller keeps its reference to [buf]. If the message references
+	// [buf], it holds its own reference until OnFinishedHandling is called.
+	ParseBuffer(
+		buf *Buffer,
+		nodeID ids.NodeID,
+		onFinishedHandling func(),
+	) (InboundMessage, error)
 }
 
 type inMsgBuilder struct {
@@ -36,6 +45,28 @@ func (b *inMsgBuilder) Parse(bytes []byte, nodeID ids.NodeID, onFinishedHandling
 	return b.builder.parseInbound(bytes, nodeID, onFinishedHandling)
 }
 
+func (b *inMsgBuilder) ParseBuffer(buf *Buffer, nodeID ids.NodeID, onFinishedHandling func()) (InboundMessage, error) {
+	msg, aliased, err := b.builder.parseInboundZeroCopy(buf.Bytes(), nodeID, onFinishedHandling, true)
+	if err != nil {
+		return nil, err
+	}
+	if !aliased {
+		return msg, nil
+	}
+
+	// The payload may be retained by the message handler, so the buffer must
+	// never be reused.
+	buf.Detach()
+	buf.Retain()
+	msg.onFinishedHandling = func() {
+		buf.Release()
+		if onFinishedHandling != nil {
+			onFinishedHandling()
+		}
+	}
+	return msg, nil
+}
+
 func InboundGetStateSummaryFrontier(
 	chainID ids.ID,
 	requestID uint32,
@@ -243,16 +274,18 @@ func InboundChits(
 	requestID uint32,
 	preferredID ids.ID,
 	acceptedID ids.ID,
+	acceptedSignature []byte,
 	nodeID ids.NodeID,
 ) InboundMessage {
 	return &inboundMessage{
 		nodeID: nodeID,
 		op:     ChitsOp,
 		message: &p2p.Chits{
-			ChainId:     chainID[:],
-			RequestId:   requestID,
-			PreferredId: preferredID[:],
-			AcceptedId:  acceptedID[:],
+			ChainId:           chainID[:],
+			RequestId:         requestID,
+			PreferredId:       preferredID[:],
+			AcceptedId:        acceptedID[:],
+			AcceptedSignature: acceptedSignature,
 		},
 		expiration: mockable.MaxTime,
 	}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
@@ -25,6 +26,7 @@ func Test_newMsgBuilder(t *testing.T) {
 		logging.NoLog{},
 		"test",
 		prometheus.NewRegistry(),
+		compression.DefaultZstdLevel,
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -319,11 +321,13 @@ func TestInboundMsgBuilder(t *testing.T) {
 		func(t *testing.T) {
 			require := require.New(t)
 
+			acceptedSignature := []byte{1, 2, 3}
 			msg := InboundChits(
 				chainID,
 				requestID,
 				containerIDs[0],
 				acceptedContainerIDs[0],
+				acceptedSignature,
 				nodeID,
 			)
 
@@ -336,6 +340,7 @@ func TestInboundMsgBuilder(t *testing.T) {
 			require.Equal(requestID, innerMsg.RequestId)
 			require.Equal(containerIDs[0][:], innerMsg.PreferredId)
 			require.Equal(acceptedContainerIDs[0][:], innerMsg.AcceptedId)
+			require.Equal(acceptedSignature, innerMsg.AcceptedSignature)
 		},
 	)
 
@@ -6,6 +6,7 @@ package message
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -13,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
@@ -50,6 +52,9 @@ type InboundMessage interface {
 	// BytesSavedCompression returns the number of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression() int
+	// NumBytes returns the number of bytes this message was sent with over the
+	// network. Returns 0 if the message was created locally.
+	NumBytes() int
 }
 
 type inboundMessage struct {
@@ -59,6 +64,7 @@ type inboundMessage struct {
 	expiration            time.Time
 	onFinishedHandling    func()
 	bytesSavedCompression int
+	numBytes              int
 }
 
 func (m *inboundMessage) NodeID() ids.NodeID {
@@ -87,6 +93,10 @@ func (m *inboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
 
+func (m *inboundMessage) NumBytes() int {
+	return m.numBytes
+}
+
 func (m *inboundMessage) String() string {
 	return fmt.Sprintf("%s Op: %s Message: %s",
 		m.nodeID, m.op, m.message)
@@ -112,6 +122,13 @@ type outboundMessage struct {
 	op                    Op
 	bytes                 []byte
 	bytesSavedCompression int
+
+	// recompressedLock is held while the message is recompressed, so that
+	// concurrent senders wait for the first compression rather than
+	// repeating it.
+	recompressedLock sync.Mutex
+	// compression type -> this message compressed with that type
+	recompressed map[compression.Type]*outboundMessage
 }
 
 func (m *outboundMessage) BypassThrottling() bool {
@@ -149,13 +166,14 @@ func newMsgBuilder(
 	log logging.Logger,
 	namespace string,
 	metrics prometheus.Registerer,
+	zstdLevel int,
 	maxMessageTimeout time.Duration,
 ) (*msgBuilder, error) {
 	gzipCompressor, err := compression.NewGzipCompressor(constants.DefaultMaxMessageSize)
 	if err != nil {
 		return nil, err
 	}
-	zstdCompressor, err := compression.NewZstdCompressor(constants.DefaultMaxMessageSize)
+	zstdCompressor, err := compression.NewZstdCompressorWithLevel(constants.DefaultMaxMessageSize, zstdLevel)
 	if err != nil {
 		return nil, err
 	}
@@ -282,10 +300,51 @@ func (mb *msgBuilder) marshal(
 	return compressedMsgBytes, bytesSaved, op, nil
 }
 
-func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
+// recompress returns [msg] compressed with [compressionType]. If [msg] isn't
+// compressed, or is already compressed with [compressionType], [msg] is
+// returned. Otherwise, the recompressed message is cached on [msg].
+func (mb *msgBuilder) recompress(msg *outboundMessage, compressionType compression.Type) (*outboundMessage, error) {
+	current := compressionTypeOf(msg.bytes)
+	if current == compression.TypeNone || current == compressionType {
+		return msg, nil
+	}
+
+	msg.recompressedLock.Lock()
+	defer msg.recompressedLock.Unlock()
+
+	if recompressed, ok := msg.recompressed[compressionType]; ok {
+		return recompressed, nil
+	}
+
+	m, _, _, _, err := mb.unmarshal(msg.bytes, false)
+	if err != nil {
+		return nil, err
+	}
+	recompressed, err := mb.createOutbound(m, compressionType, msg.bypassThrottling)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.recompressed == nil {
+		msg.recompressed = make(map[compression.Type]*outboundMessage)
+	}
+	msg.recompressed[compressionType] = recompressed
+	return recompressed, nil
+}
+
+// unmarshal decodes [b]. If [zeroCopy] is true, the payload of the returned
+// message may reference [b], in which case aliased is true.
+func (mb *msgBuilder) unmarshal(b []byte, zeroCopy bool) (*p2p.Message, int, Op, bool, error) {
+	if zeroCopy {
+		if m, ok := unmarshalZeroCopy(b); ok {
+			op, err := ToOp(m)
+			return m, 0, op, true, err
+		}
+	}
+
 	m := new(p2p.Message)
 	if err := proto.Unmarshal(b, m); err != nil {
-		return nil, 0, 0, err
+		return nil, 0, 0, false, err
 	}
 
 	// Figure out what compression type, if any, was used to compress the message.
@@ -308,26 +367,38 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 	default:
 		// The message wasn't compressed
 		op, err := ToOp(m)
-		return m, 0, op, err
+		return m, 0, op, false, err
 	}
 
 	startTime := time.Now()
 
 	decompressed, err := compressor.Decompress(compressedBytes)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, 0, false, err
 	}
 	bytesSavedCompression := len(decompressed) - len(compressedBytes)
 
-	if err := proto.Unmarshal(decompressed, m); err != nil {
-		return nil, 0, 0, err
+	// [decompressed] isn't referenced by anyone else, so the message doesn't
+	// alias [b] even if it references [decompressed].
+	parsed := false
+	if zeroCopy {
+		var decompressedMsg *p2p.Message
+		decompressedMsg, parsed = unmarshalZeroCopy(decompressed)
+		if parsed {
+			m = decompressedMsg
+		}
+	}
+	if !parsed {
+		if err := proto.Unmarshal(decompressed, m); err != nil {
+			return nil, 0, 0, false, err
+		}
 	}
 	decompressTook := time.Since(startTime)
 
 	// Record decompression time metric
 	op, err := ToOp(m)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, 0, false, err
 	}
 	if decompressTimeMetric, ok := opToDecompressTimeMetrics[op]; ok {
 		decompressTimeMetric.Observe(float64(decompressTook))
@@ -338,7 +409,7 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 		)
 	}
 
-	return m, bytesSavedCompression, op, nil
+	return m, bytesSavedCompression, op, false, nil
 }
 
 func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression.Type, bypassThrottling bool) (*outboundMessage, error) {
@@ -360,14 +431,29 @@ func (mb *msgBuilder) parseInbound(
 	nodeID ids.NodeID,
 	onFinishedHandling func(),
 ) (*inboundMessage, error) {
-	m, bytesSavedCompression, op, err := mb.unmarshal(bytes)
+	msg, _, err := mb.parseInboundZeroCopy(bytes, nodeID, onFinishedHandling, false)
+	return msg, err
+}
+
+// parseInboundZeroCopy parses [bytes]. If [zeroCopy] is true, the payload of
+// the returned message may reference [bytes], in which case aliased is true.
+func (mb *msgBuilder) parseInboundZeroCopy(
+	bytes []byte,
+	nodeID ids.NodeID,
+	onFinishedHandling func(),
+	zeroCopy bool,
+) (*inboundMessage, bool, error) {
+	m, bytesSavedCompression, op, aliased, err := mb.unmarshal(bytes, zeroCopy)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	msg, err := Unwrap(m)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	if err := validate(op, msg.(protoreflect.ProtoMessage)); err != nil {
+		return nil, false, err
 	}
 
 	expiration := mockable.MaxTime
@@ -383,5 +469,6 @@ func (mb *msgBuilder) parseInbound(
 		expiration:            expiration,
 		onFinishedHandling:    onFinishedHandling,
 		bytesSavedCompression: bytesSavedCompression,
-	}, nil
+		numBytes:              len(bytes),
+	}, aliased, nil
 }
@@ -64,7 +64,7 @@ func BenchmarkMarshalVersion(b *testing.B) {
 
 	useBuilder := os.Getenv("USE_BUILDER") != ""
 
-	codec, err := newMsgBuilder(logging.NoLog{}, "", prometheus.NewRegistry(), 10*time.Second)
+	codec, err := newMsgBuilder(logging.NoLog{}, "", prometheus.NewRegistry(), compression.DefaultZstdLevel, 10*time.Second)
 	require.NoError(err)
 
 	b.Logf("proto length %d-byte (use builder %v)", msgLen, useBuilder)
@@ -121,7 +121,7 @@ func BenchmarkUnmarshalVersion(b *testing.B) {
 	require.NoError(err)
 
 	useBuilder := os.Getenv("USE_BUILDER") != ""
-	codec, err := newMsgBuilder(logging.NoLog{}, "", prometheus.NewRegistry(), 10*time.Second)
+	codec, err := newMsgBuilder(logging.NoLog{}, "", prometheus.NewRegistry(), compression.DefaultZstdLevel, 10*time.Second)
 	require.NoError(err)
 
 	b.StartTimer()
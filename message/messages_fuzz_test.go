@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func FuzzParseInbound(f *testing.F) {
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		compression.DefaultZstdLevel,
+		10*time.Second,
+	)
+	require.NoError(f, err)
+
+	// Seed the corpus with the messages that are most commonly sent over the
+	// network, with each supported compression type.
+	chainID := ids.GenerateTestID()
+	container := bytes.Repeat([]byte{1}, 128)
+	for _, compressionType := range []compression.Type{
+		compression.TypeNone,
+		compression.TypeGzip,
+		compression.TypeZstd,
+	} {
+		builder := newOutboundBuilder(compressionType, mb)
+		for _, build := range []func() (OutboundMessage, error){
+			func() (OutboundMessage, error) {
+				return builder.Version(
+					constants.UnitTestID,
+					uint64(time.Now().Unix()),
+					ips.IPPort{IP: []byte{127, 0, 0, 1}, Port: 9651},
+					"avalanche/1.10.0",
+					uint64(time.Now().Unix()),
+					container,
+					[]ids.ID{ids.GenerateTestID()},
+					[]compression.Type{compression.TypeZstd, compression.TypeNone},
+				)
+			},
+			func() (OutboundMessage, error) {
+				return builder.Ping(100, []*p2p.SubnetUptime{{
+					SubnetId: chainID[:],
+					Uptime:   80,
+				}})
+			},
+			func() (OutboundMessage, error) {
+				return builder.Get(chainID, 1, time.Second, ids.GenerateTestID(), p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+			},
+			func() (OutboundMessage, error) {
+				return builder.Put(chainID, 1, container, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+			},
+			func() (OutboundMessage, error) {
+				return builder.PushQuery(chainID, 1, time.Second, container, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+			},
+			func() (OutboundMessage, error) {
+				return builder.AppRequest(chainID, 1, time.Second, container, 0)
+			},
+		} {
+			msg, err := build()
+			require.NoError(f, err)
+			f.Add(msg.Bytes())
+		}
+	}
+
+	nodeID := ids.GenerateTestNodeID()
+	f.Fuzz(func(t *testing.T, msgBytes []byte) {
+		msg, err := mb.parseInbound(msgBytes, nodeID, nil)
+		if err != nil {
+			return
+		}
+
+		// Accessing the fields of any successfully parsed message must not
+		// panic.
+		inner := msg.Message()
+		if _, ok := inner.(chainIDGetter); ok {
+			// The schema guarantees that chain IDs are well formed.
+			_, err := GetChainID(inner)
+			require.NoError(t, err)
+		}
+		_, _ = GetSourceChainID(inner)
+		_, _ = GetRequestID(inner)
+		_, _ = GetEngineType(inner)
+		_ = msg.String()
+	})
+}
+
+func FuzzValidate(f *testing.F) {
+	chainID := ids.GenerateTestID()
+	for _, m := range []*p2p.Message{
+		{Message: &p2p.Message_Chits{Chits: &p2p.Chits{
+			ChainId:     chainID[:],
+			PreferredId: chainID[:],
+			AcceptedId:  chainID[:],
+		}}},
+		{Message: &p2p.Message_Accepted_{Accepted_: &p2p.Accepted{
+			ChainId:      chainID[:],
+			ContainerIds: [][]byte{chainID[:]},
+		}}},
+		{Message: &p2p.Message_PeerList{PeerList: &p2p.PeerList{
+			ClaimedIpPorts: []*p2p.ClaimedIpPort{{
+				IpAddr: []byte(net.IPv6zero),
+				TxId:   chainID[:],
+			}},
+		}}},
+	} {
+		b, err := proto.Marshal(m)
+		require.NoError(f, err)
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		m := &p2p.Message{}
+		if err := proto.Unmarshal(b, m); err != nil {
+			return
+		}
+		op, err := ToOp(m)
+		if err != nil {
+			return
+		}
+		msg, err := Unwrap(m)
+		if err != nil {
+			return
+		}
+		// Validating any message must not panic, and a valid message must
+		// remain valid after being re-encoded.
+		protoMsg := msg.(proto.Message)
+		if validate(op, protoMsg) != nil {
+			return
+		}
+		reencoded, err := proto.Marshal(m)
+		require.NoError(t, err)
+
+		parsed := &p2p.Message{}
+		require.NoError(t, proto.Unmarshal(reencoded, parsed))
+		parsedMsg, err := Unwrap(parsed)
+		require.NoError(t, err)
+		require.NoError(t, validate(op, parsedMsg.(proto.Message)))
+	})
+}
@@ -5,6 +5,7 @@ package message
 
 import (
 	"bytes"
+	"fmt"
 	"net"
 	"testing"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
@@ -29,6 +31,7 @@ func TestMessage(t *testing.T) {
 		logging.NoLog{},
 		"test",
 		prometheus.NewRegistry(),
+		compression.DefaultZstdLevel,
 		5*time.Second,
 	)
 	require.NoError(t, err)
@@ -155,6 +158,7 @@ func TestMessage(t *testing.T) {
 								IpPort:          10,
 								Timestamp:       1,
 								Signature:       []byte{0},
+								TxId:            testID[:],
 							},
 						},
 					},
@@ -177,6 +181,7 @@ func TestMessage(t *testing.T) {
 								IpPort:          9651,
 								Timestamp:       uint64(nowUnix),
 								Signature:       compressibleContainers[0],
+								TxId:            testID[:],
 							},
 						},
 					},
@@ -199,6 +204,7 @@ func TestMessage(t *testing.T) {
 								IpPort:          9651,
 								Timestamp:       uint64(nowUnix),
 								Signature:       compressibleContainers[0],
+								TxId:            testID[:],
 							},
 						},
 					},
@@ -227,6 +233,21 @@ func TestMessage(t *testing.T) {
 			bypassThrottling: false,
 			bytesSaved:       false,
 		},
+		{
+			desc: "retiring message with no compression",
+			op:   RetiringOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_Retiring{
+					Retiring: &p2p.Retiring{
+						Timestamp: uint64(nowUnix),
+						Signature: []byte{'y', 'e', 'e', 't'},
+					},
+				},
+			},
+			compressionType:  compression.TypeNone,
+			bypassThrottling: true,
+			bytesSaved:       false,
+		},
 		{
 			desc: "get_state_summary_frontier message with no compression",
 			op:   GetStateSummaryFrontierOp,
@@ -673,6 +694,25 @@ func TestMessage(t *testing.T) {
 						ChainId:     testID[:],
 						RequestId:   1,
 						PreferredId: testID[:],
+						AcceptedId:  testID[:],
+					},
+				},
+			},
+			compressionType:  compression.TypeNone,
+			bypassThrottling: true,
+			bytesSaved:       false,
+		},
+		{
+			desc: "chits message with accepted signature",
+			op:   ChitsOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_Chits{
+					Chits: &p2p.Chits{
+						ChainId:           testID[:],
+						RequestId:         1,
+						PreferredId:       testID[:],
+						AcceptedId:        testID[:],
+						AcceptedSignature: make([]byte, bls.SignatureLen),
 					},
 				},
 			},
@@ -846,6 +886,64 @@ func TestMessage(t *testing.T) {
 	}
 }
 
+func TestRecompress(t *testing.T) {
+	t.Parallel()
+
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		compression.DefaultZstdLevel,
+		5*time.Second,
+	)
+	require.NoError(t, err)
+
+	chainID := ids.GenerateTestID()
+	msg := &p2p.Message{
+		Message: &p2p.Message_AppGossip{
+			AppGossip: &p2p.AppGossip{
+				ChainId:  chainID[:],
+				AppBytes: bytes.Repeat([]byte{1}, 1024),
+			},
+		},
+	}
+	compressionTypes := []compression.Type{
+		compression.TypeNone,
+		compression.TypeGzip,
+		compression.TypeZstd,
+	}
+	for _, from := range compressionTypes {
+		for _, to := range compressionTypes {
+			t.Run(fmt.Sprintf("%s to %s", from, to), func(t *testing.T) {
+				require := require.New(t)
+
+				encodedMsg, err := mb.createOutbound(msg, from, false)
+				require.NoError(err)
+
+				recompressed, err := mb.recompress(encodedMsg, to)
+				require.NoError(err)
+				if from == compression.TypeNone || from == to {
+					// Uncompressed messages are never compressed.
+					require.Same(encodedMsg, recompressed)
+				} else {
+					require.Equal(to, compressionTypeOf(recompressed.Bytes()))
+
+					// The message is only compressed once per compression
+					// type.
+					cached, err := mb.recompress(encodedMsg, to)
+					require.NoError(err)
+					require.Same(recompressed, cached)
+				}
+
+				parsedMsg, err := mb.parseInbound(recompressed.Bytes(), ids.EmptyNodeID, func() {})
+				require.NoError(err)
+				require.Equal(AppGossipOp, parsedMsg.Op())
+				require.Equal(msg.GetAppGossip().AppBytes, parsedMsg.Message().(*p2p.AppGossip).AppBytes)
+			})
+		}
+	}
+}
+
 // Tests the Stringer interface on inbound messages
 func TestInboundMessageToString(t *testing.T) {
 	t.Parallel()
@@ -856,6 +954,7 @@ func TestInboundMessageToString(t *testing.T) {
 		logging.NoLog{},
 		"test",
 		prometheus.NewRegistry(),
+		compression.DefaultZstdLevel,
 		5*time.Second,
 	)
 	require.NoError(err)
@@ -889,6 +988,7 @@ func TestEmptyInboundMessage(t *testing.T) {
 		logging.NoLog{},
 		"test",
 		prometheus.NewRegistry(),
+		compression.DefaultZstdLevel,
 		5*time.Second,
 	)
 	require.NoError(err)
@@ -910,6 +1010,7 @@ func TestNilInboundMessage(t *testing.T) {
 		logging.NoLog{},
 		"test",
 		prometheus.NewRegistry(),
+		compression.DefaultZstdLevel,
 		5*time.Second,
 	)
 	require.NoError(err)
@@ -24,6 +24,7 @@ const (
 	VersionOp
 	PeerListOp
 	PeerListAckOp
+	RetiringOp
 	// State sync:
 	GetStateSummaryFrontierOp
 	GetStateSummaryFrontierFailedOp
@@ -74,6 +75,7 @@ var (
 		VersionOp,
 		PeerListOp,
 		PeerListAckOp,
+		RetiringOp,
 	}
 
 	// List of all consensus request message types
@@ -215,6 +217,8 @@ func (op Op) String() string {
 		return "peerlist"
 	case PeerListAckOp:
 		return "peerlist_ack"
+	case RetiringOp:
+		return "retiring"
 	// State sync
 	case GetStateSummaryFrontierOp:
 		return "get_state_summary_frontier"
@@ -296,6 +300,17 @@ func (op Op) String() string {
 	}
 }
 
+// ExternalOpFromString returns the op of the messages sent over the network
+// whose String() is [s].
+func ExternalOpFromString(s string) (Op, error) {
+	for _, op := range ExternalOps {
+		if op.String() == s {
+			return op, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", errUnknownMessageType, s)
+}
+
 func Unwrap(m *p2p.Message) (fmt.Stringer, error) {
 	switch msg := m.GetMessage().(type) {
 	// Handshake:
@@ -309,6 +324,8 @@ func Unwrap(m *p2p.Message) (fmt.Stringer, error) {
 		return msg.PeerList, nil
 	case *p2p.Message_PeerListAck:
 		return msg.PeerListAck, nil
+	case *p2p.Message_Retiring:
+		return msg.Retiring, nil
 	// State sync:
 	case *p2p.Message_GetStateSummaryFrontier:
 		return msg.GetStateSummaryFrontier, nil
@@ -366,6 +383,8 @@ func ToOp(m *p2p.Message) (Op, error) {
 		return PeerListOp, nil
 	case *p2p.Message_PeerListAck:
 		return PeerListAckOp, nil
+	case *p2p.Message_Retiring:
+		return RetiringOp, nil
 	case *p2p.Message_GetStateSummaryFrontier:
 		return GetStateSummaryFrontierOp, nil
 	case *p2p.Message_StateSummaryFrontier_:
@@ -26,6 +26,7 @@ type OutboundMsgBuilder interface {
 		myVersionTime uint64,
 		sig []byte,
 		trackedSubnets []ids.ID,
+		compressionTypes []compression.Type,
 	) (OutboundMessage, error)
 
 	PeerList(
@@ -37,6 +38,11 @@ type OutboundMsgBuilder interface {
 		peerAcks []*p2p.PeerAck,
 	) (OutboundMessage, error)
 
+	Retiring(
+		timestamp uint64,
+		sig []byte,
+	) (OutboundMessage, error)
+
 	Ping(
 		primaryUptime uint32,
 		subnetUptimes []*p2p.SubnetUptime,
@@ -149,6 +155,7 @@ type OutboundMsgBuilder interface {
 		requestID uint32,
 		preferredID ids.ID,
 		acceptedID ids.ID,
+		acceptedSignature []byte,
 	) (OutboundMessage, error)
 
 	AppRequest(
@@ -156,6 +163,7 @@ type OutboundMsgBuilder interface {
 		requestID uint32,
 		deadline time.Duration,
 		msg []byte,
+		puzzleNonce uint64,
 	) (OutboundMessage, error)
 
 	AppResponse(
@@ -168,6 +176,15 @@ type OutboundMsgBuilder interface {
 		chainID ids.ID,
 		msg []byte,
 	) (OutboundMessage, error)
+
+	// Recompress returns [msg] compressed with [compressionType]. If [msg]
+	// isn't compressed, is already compressed with [compressionType], or
+	// wasn't created by this builder, [msg] is returned. The result is cached
+	// on [msg], so [msg] is compressed at most once per compression type.
+	Recompress(
+		msg OutboundMessage,
+		compressionType compression.Type,
+	) (OutboundMessage, error)
 }
 
 type outMsgBuilder struct {
@@ -229,21 +246,27 @@ func (b *outMsgBuilder) Version(
 	myVersionTime uint64,
 	sig []byte,
 	trackedSubnets []ids.ID,
+	compressionTypes []compression.Type,
 ) (OutboundMessage, error) {
 	subnetIDBytes := make([][]byte, len(trackedSubnets))
 	encodeIDs(trackedSubnets, subnetIDBytes)
+	compressionTypeStrs := make([]string, len(compressionTypes))
+	for i, compressionType := range compressionTypes {
+		compressionTypeStrs[i] = compressionType.String()
+	}
 	return b.builder.createOutbound(
 		&p2p.Message{
 			Message: &p2p.Message_Version{
 				Version: &p2p.Version{
-					NetworkId:      networkID,
-					MyTime:         myTime,
-					IpAddr:         ip.IP.To16(),
-					IpPort:         uint32(ip.Port),
-					MyVersion:      myVersion,
-					MyVersionTime:  myVersionTime,
-					Sig:            sig,
-					TrackedSubnets: subnetIDBytes,
+					NetworkId:        networkID,
+					MyTime:           myTime,
+					IpAddr:           ip.IP.To16(),
+					IpPort:           uint32(ip.Port),
+					MyVersion:        myVersion,
+					MyVersionTime:    myVersionTime,
+					Sig:              sig,
+					TrackedSubnets:   subnetIDBytes,
+					CompressionTypes: compressionTypeStrs,
 				},
 			},
 		},
@@ -291,6 +314,21 @@ func (b *outMsgBuilder) PeerListAck(peerAcks []*p2p.PeerAck) (OutboundMessage, e
 	)
 }
 
+func (b *outMsgBuilder) Retiring(timestamp uint64, sig []byte) (OutboundMessage, error) {
+	return b.builder.createOutbound(
+		&p2p.Message{
+			Message: &p2p.Message_Retiring{
+				Retiring: &p2p.Retiring{
+					Timestamp: timestamp,
+					Signature: sig,
+				},
+			},
+		},
+		compression.TypeNone,
+		true,
+	)
+}
+
 func (b *outMsgBuilder) GetStateSummaryFrontier(
 	chainID ids.ID,
 	requestID uint32,
@@ -608,15 +646,17 @@ func (b *outMsgBuilder) Chits(
 	requestID uint32,
 	preferredID ids.ID,
 	acceptedID ids.ID,
+	acceptedSignature []byte,
 ) (OutboundMessage, error) {
 	return b.builder.createOutbound(
 		&p2p.Message{
 			Message: &p2p.Message_Chits{
 				Chits: &p2p.Chits{
-					ChainId:     chainID[:],
-					RequestId:   requestID,
-					PreferredId: preferredID[:],
-					AcceptedId:  acceptedID[:],
+					ChainId:           chainID[:],
+					RequestId:         requestID,
+					PreferredId:       preferredID[:],
+					AcceptedId:        acceptedID[:],
+					AcceptedSignature: acceptedSignature,
 				},
 			},
 		},
@@ -630,15 +670,17 @@ func (b *outMsgBuilder) AppRequest(
 	requestID uint32,
 	deadline time.Duration,
 	msg []byte,
+	puzzleNonce uint64,
 ) (OutboundMessage, error) {
 	return b.builder.createOutbound(
 		&p2p.Message{
 			Message: &p2p.Message_AppRequest{
 				AppRequest: &p2p.AppRequest{
-					ChainId:   chainID[:],
-					RequestId: requestID,
-					Deadline:  uint64(deadline),
-					AppBytes:  msg,
+					ChainId:     chainID[:],
+					RequestId:   requestID,
+					Deadline:    uint64(deadline),
+					AppBytes:    msg,
+					PuzzleNonce: puzzleNonce,
 				},
 			},
 		},
@@ -677,3 +719,11 @@ func (b *outMsgBuilder) AppGossip(chainID ids.ID, msg []byte) (OutboundMessage,
 		false,
 	)
 }
+
+func (b *outMsgBuilder) Recompress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error) {
+	outMsg, ok := msg.(*outboundMessage)
+	if !ok {
+		return msg, nil
+	}
+	return b.builder.recompress(outMsg, compressionType)
+}
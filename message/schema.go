@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+// The limits below are well above what honest peers send. They only exist to
+// bound the work and memory spent on a message before it is handled.
+const (
+	idLen = hashing.HashLen
+
+	// maxIDs is the maximum number of IDs in a list of containers or state
+	// summaries.
+	maxIDs = 4096
+	// maxHeights is the maximum number of heights requested in a
+	// GetAcceptedStateSummary message.
+	maxHeights = 4096
+	// maxSubnets is the maximum number of subnets tracked by, or reported
+	// uptimes of, a peer.
+	maxSubnets = 4096
+	// maxVersionLen is the maximum length of a peer's version string.
+	maxVersionLen = 256
+	// maxStakingSignatureLen is the maximum length of a signature made with a
+	// staking key.
+	maxStakingSignatureLen = 1 * units.KiB
+)
+
+var errInvalidField = errors.New("invalid field")
+
+// fieldSchema constrains a field of an inbound message. Fields that aren't
+// constrained are only bounded by the maximum size of a message.
+type fieldSchema struct {
+	// name of the field in the protobuf definition.
+	name protoreflect.Name
+	// len, if non-zero, is the exact length of the value of a bytes field, or
+	// of each element of a repeated bytes field.
+	len int
+	// optional allows a field with an exact [len] to be empty.
+	optional bool
+	// maxLen, if non-zero, bounds the length of the value of a bytes or string
+	// field, or of each element of a repeated one.
+	maxLen int
+	// maxCount, if non-zero, bounds the number of elements of a repeated
+	// field.
+	maxCount int
+	// fields constrain each element of a message field.
+	fields []fieldSchema
+}
+
+var (
+	chainIDField = fieldSchema{name: "chain_id", len: idLen}
+	ipField      = fieldSchema{name: "ip_addr", len: net.IPv6len}
+	uptimesField = fieldSchema{
+		name:     "subnet_uptimes",
+		maxCount: maxSubnets,
+		fields: []fieldSchema{
+			{name: "subnet_id", len: idLen},
+		},
+	}
+
+	// schemas is the single definition of the limits enforced on the fields
+	// of every message that can be received from a peer.
+	schemas = map[Op][]fieldSchema{
+		// Handshake:
+		PingOp: {uptimesField},
+		PongOp: {uptimesField},
+		VersionOp: {
+			ipField,
+			{name: "my_version", maxLen: maxVersionLen},
+			{name: "sig", maxLen: maxStakingSignatureLen},
+			{name: "tracked_subnets", len: idLen, maxCount: maxSubnets},
+		},
+		PeerListOp: {
+			{
+				name: "claimed_ip_ports",
+				fields: []fieldSchema{
+					{name: "x509_certificate", maxLen: staking.MaxCertificateLen},
+					ipField,
+					{name: "signature", maxLen: maxStakingSignatureLen},
+					{name: "tx_id", len: idLen},
+				},
+			},
+		},
+		PeerListAckOp: {
+			{
+				name: "peer_acks",
+				fields: []fieldSchema{
+					{name: "tx_id", len: idLen},
+				},
+			},
+		},
+		RetiringOp: {
+			{name: "signature", maxLen: maxStakingSignatureLen},
+		},
+		// State sync:
+		GetStateSummaryFrontierOp: {chainIDField},
+		StateSummaryFrontierOp:    {chainIDField},
+		GetAcceptedStateSummaryOp: {
+			chainIDField,
+			{name: "heights", maxCount: maxHeights},
+		},
+		AcceptedStateSummaryOp: {
+			chainIDField,
+			{name: "summary_ids", len: idLen, maxCount: maxIDs},
+		},
+		// Bootstrapping:
+		GetAcceptedFrontierOp: {chainIDField},
+		AcceptedFrontierOp: {
+			chainIDField,
+			{name: "container_id", len: idLen},
+		},
+		GetAcceptedOp: {
+			chainIDField,
+			{name: "container_ids", len: idLen, maxCount: maxIDs},
+		},
+		AcceptedOp: {
+			chainIDField,
+			{name: "container_ids", len: idLen, maxCount: maxIDs},
+		},
+		GetAncestorsOp: {
+			chainIDField,
+			{name: "container_id", len: idLen},
+		},
+		// The number of containers in an Ancestors message is configured by
+		// the sender, so it is only bounded by the size of the message.
+		AncestorsOp: {chainIDField},
+		// Consensus:
+		GetOp: {
+			chainIDField,
+			{name: "container_id", len: idLen},
+		},
+		PutOp:       {chainIDField},
+		PushQueryOp: {chainIDField},
+		PullQueryOp: {
+			chainIDField,
+			{name: "container_id", len: idLen},
+		},
+		ChitsOp: {
+			chainIDField,
+			{name: "preferred_id", len: idLen},
+			{name: "accepted_id", len: idLen},
+			{name: "accepted_signature", len: bls.SignatureLen, optional: true},
+		},
+		// Application:
+		AppRequestOp:  {chainIDField},
+		AppResponseOp: {chainIDField},
+		AppGossipOp:   {chainIDField},
+	}
+)
+
+// validate checks that [msg], which was received as an [op] message, satisfies
+// the schema of [op].
+func validate(op Op, msg protoreflect.ProtoMessage) error {
+	return validateFields(msg.ProtoReflect(), schemas[op])
+}
+
+func validateFields(msg protoreflect.Message, fields []fieldSchema) error {
+	descriptors := msg.Descriptor().Fields()
+	for _, f := range fields {
+		descriptor := descriptors.ByName(f.name)
+		value := msg.Get(descriptor)
+		if !descriptor.IsList() {
+			if err := validateValue(descriptor, value, f); err != nil {
+				return err
+			}
+			continue
+		}
+
+		list := value.List()
+		if f.maxCount != 0 && list.Len() > f.maxCount {
+			return fmt.Errorf("%w: %s has %d elements, more than the maximum %d",
+				errInvalidField,
+				descriptor.FullName(),
+				list.Len(),
+				f.maxCount,
+			)
+		}
+		for i := 0; i < list.Len(); i++ {
+			if err := validateValue(descriptor, list.Get(i), f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateValue(
+	descriptor protoreflect.FieldDescriptor,
+	value protoreflect.Value,
+	f fieldSchema,
+) error {
+	var length int
+	switch descriptor.Kind() {
+	case protoreflect.BytesKind:
+		length = len(value.Bytes())
+	case protoreflect.StringKind:
+		length = len(value.String())
+	case protoreflect.MessageKind:
+		return validateFields(value.Message(), f.fields)
+	default:
+		return nil
+	}
+
+	switch {
+	case f.len != 0 && length != f.len && !(f.optional && length == 0):
+		return fmt.Errorf("%w: %s has length %d, expected %d",
+			errInvalidField,
+			descriptor.FullName(),
+			length,
+			f.len,
+		)
+	case f.maxLen != 0 && length > f.maxLen:
+		return fmt.Errorf("%w: %s has length %d, more than the maximum %d",
+			errInvalidField,
+			descriptor.FullName(),
+			length,
+			f.maxLen,
+		)
+	}
+	return nil
+}
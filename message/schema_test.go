@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+// Every message that can be received from a peer must have a schema, and every
+// field of a schema must exist in the protobuf definition of the message.
+func TestSchemasMatchProtobuf(t *testing.T) {
+	require := require.New(t)
+
+	oneof := (&p2p.Message{}).ProtoReflect().Descriptor().Oneofs().ByName("message")
+	fields := oneof.Fields()
+	numOps := 0
+	for i := 0; i < fields.Len(); i++ {
+		descriptor := fields.Get(i)
+		if descriptor.Kind() != protoreflect.MessageKind {
+			// Compressed messages are validated once decompressed.
+			continue
+		}
+
+		m := &p2p.Message{}
+		reflected := m.ProtoReflect()
+		reflected.Set(descriptor, reflected.NewField(descriptor))
+		op, err := ToOp(m)
+		require.NoError(err)
+
+		schema, ok := schemas[op]
+		require.True(ok, "missing schema for %s", op)
+		requireSchemaMatches(t, descriptor.Message(), schema)
+		numOps++
+	}
+	require.Len(schemas, numOps)
+}
+
+func requireSchemaMatches(t *testing.T, msg protoreflect.MessageDescriptor, schema []fieldSchema) {
+	require := require.New(t)
+
+	for _, f := range schema {
+		descriptor := msg.Fields().ByName(f.name)
+		require.NotNil(descriptor, "%s has no field %s", msg.FullName(), f.name)
+
+		if f.maxCount != 0 {
+			require.True(descriptor.IsList(), "%s isn't repeated", descriptor.FullName())
+		}
+		switch descriptor.Kind() {
+		case protoreflect.MessageKind:
+			require.Zero(f.len)
+			require.Zero(f.maxLen)
+			requireSchemaMatches(t, descriptor.Message(), f.fields)
+		case protoreflect.BytesKind, protoreflect.StringKind:
+			require.Empty(f.fields)
+		default:
+			require.Zero(f.len)
+			require.Zero(f.maxLen)
+			require.Empty(f.fields)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	tests := []struct {
+		name        string
+		op          Op
+		msg         proto.Message
+		expectedErr error
+	}{
+		{
+			name: "valid chits",
+			op:   ChitsOp,
+			msg: &p2p.Chits{
+				ChainId:     chainID[:],
+				PreferredId: chainID[:],
+				AcceptedId:  chainID[:],
+			},
+		},
+		{
+			name: "valid chits with signature",
+			op:   ChitsOp,
+			msg: &p2p.Chits{
+				ChainId:           chainID[:],
+				PreferredId:       chainID[:],
+				AcceptedId:        chainID[:],
+				AcceptedSignature: make([]byte, bls.SignatureLen),
+			},
+		},
+		{
+			name: "chits with short signature",
+			op:   ChitsOp,
+			msg: &p2p.Chits{
+				ChainId:           chainID[:],
+				PreferredId:       chainID[:],
+				AcceptedId:        chainID[:],
+				AcceptedSignature: make([]byte, bls.SignatureLen-1),
+			},
+			expectedErr: errInvalidField,
+		},
+		{
+			name: "missing chain ID",
+			op:   AppGossipOp,
+			msg: &p2p.AppGossip{
+				AppBytes: []byte{1},
+			},
+			expectedErr: errInvalidField,
+		},
+		{
+			name: "too many container IDs",
+			op:   GetAcceptedOp,
+			msg: &p2p.GetAccepted{
+				ChainId:      chainID[:],
+				ContainerIds: make([][]byte, maxIDs+1),
+			},
+			expectedErr: errInvalidField,
+		},
+		{
+			name: "invalid container ID",
+			op:   AcceptedOp,
+			msg: &p2p.Accepted{
+				ChainId:      chainID[:],
+				ContainerIds: [][]byte{chainID[:], {1}},
+			},
+			expectedErr: errInvalidField,
+		},
+		{
+			name: "version too long",
+			op:   VersionOp,
+			msg: &p2p.Version{
+				IpAddr:    net.IPv6zero,
+				MyVersion: string(bytes.Repeat([]byte{'a'}, maxVersionLen+1)),
+			},
+			expectedErr: errInvalidField,
+		},
+		{
+			name: "invalid claimed IP",
+			op:   PeerListOp,
+			msg: &p2p.PeerList{
+				ClaimedIpPorts: []*p2p.ClaimedIpPort{
+					{
+						IpAddr: net.IPv6zero,
+						TxId:   chainID[:],
+					},
+					{
+						IpAddr: net.IPv4zero.To4(),
+						TxId:   chainID[:],
+					},
+				},
+			},
+			expectedErr: errInvalidField,
+		},
+		{
+			name: "invalid subnet uptime",
+			op:   PingOp,
+			msg: &p2p.Ping{
+				SubnetUptimes: []*p2p.SubnetUptime{
+					{
+						SubnetId: []byte{1},
+					},
+				},
+			},
+			expectedErr: errInvalidField,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validate(test.op, test.msg)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils/compression"
+)
+
+// Field numbers of the messages that can be decoded without copying their
+// payloads. These must match proto/p2p/p2p.proto.
+const (
+	putFieldNum         protowire.Number = 26
+	pushQueryFieldNum   protowire.Number = 27
+	appRequestFieldNum  protowire.Number = 30
+	appResponseFieldNum protowire.Number = 31
+	appGossipFieldNum   protowire.Number = 32
+)
+
+// Field numbers of the compressed messages. These must match
+// proto/p2p/p2p.proto.
+const (
+	compressedGzipFieldNum protowire.Number = 1
+	compressedZstdFieldNum protowire.Number = 2
+)
+
+// compressionTypeOf returns the compression type of the encoded message [b],
+// without decoding it.
+func compressionTypeOf(b []byte) compression.Type {
+	num, typ, n := protowire.ConsumeTag(b)
+	if n < 0 || typ != protowire.BytesType {
+		return compression.TypeNone
+	}
+	switch num {
+	case compressedGzipFieldNum:
+		return compression.TypeGzip
+	case compressedZstdFieldNum:
+		return compression.TypeZstd
+	default:
+		return compression.TypeNone
+	}
+}
+
+// field is a field of an encoded protobuf message. Only varint and length
+// delimited fields are supported.
+type field struct {
+	num    protowire.Number
+	typ    protowire.Type
+	varint uint64
+	bytes  []byte
+}
+
+// unmarshalZeroCopy decodes [b] without copying the byte fields of the message
+// out of [b]. Only the messages that carry large payloads are supported. If ok
+// is false, [b] must be decoded with proto.Unmarshal, which reports any
+// encoding error.
+func unmarshalZeroCopy(b []byte) (*p2p.Message, bool) {
+	num, typ, n := protowire.ConsumeTag(b)
+	if n < 0 || typ != protowire.BytesType {
+		return nil, false
+	}
+	b = b[n:]
+	v, n := protowire.ConsumeBytes(b)
+	if n < 0 || n != len(b) {
+		// Messages with multiple fields are decoded with proto.Unmarshal to
+		// preserve its merge semantics.
+		return nil, false
+	}
+
+	var ok bool
+	switch num {
+	case putFieldNum:
+		m := &p2p.Put{}
+		ok = consumeFields(v, func(f field) bool {
+			switch {
+			case f.num == 1 && f.typ == protowire.BytesType:
+				m.ChainId = f.bytes
+			case f.num == 2 && f.typ == protowire.VarintType:
+				m.RequestId = uint32(f.varint)
+			case f.num == 3 && f.typ == protowire.BytesType:
+				m.Container = f.bytes
+			case f.num == 4 && f.typ == protowire.VarintType:
+				m.EngineType = p2p.EngineType(int32(f.varint))
+			default:
+				return false
+			}
+			return true
+		})
+		return &p2p.Message{Message: &p2p.Message_Put{Put: m}}, ok
+	case pushQueryFieldNum:
+		m := &p2p.PushQuery{}
+		ok = consumeFields(v, func(f field) bool {
+			switch {
+			case f.num == 1 && f.typ == protowire.BytesType:
+				m.ChainId = f.bytes
+			case f.num == 2 && f.typ == protowire.VarintType:
+				m.RequestId = uint32(f.varint)
+			case f.num == 3 && f.typ == protowire.VarintType:
+				m.Deadline = f.varint
+			case f.num == 4 && f.typ == protowire.BytesType:
+				m.Container = f.bytes
+			case f.num == 5 && f.typ == protowire.VarintType:
+				m.EngineType = p2p.EngineType(int32(f.varint))
+			default:
+				return false
+			}
+			return true
+		})
+		return &p2p.Message{Message: &p2p.Message_PushQuery{PushQuery: m}}, ok
+	case appRequestFieldNum:
+		m := &p2p.AppRequest{}
+		ok = consumeFields(v, func(f field) bool {
+			switch {
+			case f.num == 1 && f.typ == protowire.BytesType:
+				m.ChainId = f.bytes
+			case f.num == 2 && f.typ == protowire.VarintType:
+				m.RequestId = uint32(f.varint)
+			case f.num == 3 && f.typ == protowire.VarintType:
+				m.Deadline = f.varint
+			case f.num == 4 && f.typ == protowire.BytesType:
+				m.AppBytes = f.bytes
+			case f.num == 5 && f.typ == protowire.VarintType:
+				m.PuzzleNonce = f.varint
+			default:
+				return false
+			}
+			return true
+		})
+		return &p2p.Message{Message: &p2p.Message_AppRequest{AppRequest: m}}, ok
+	case appResponseFieldNum:
+		m := &p2p.AppResponse{}
+		ok = consumeFields(v, func(f field) bool {
+			switch {
+			case f.num == 1 && f.typ == protowire.BytesType:
+				m.ChainId = f.bytes
+			case f.num == 2 && f.typ == protowire.VarintType:
+				m.RequestId = uint32(f.varint)
+			case f.num == 3 && f.typ == protowire.BytesType:
+				m.AppBytes = f.bytes
+			default:
+				return false
+			}
+			return true
+		})
+		return &p2p.Message{Message: &p2p.Message_AppResponse{AppResponse: m}}, ok
+	case appGossipFieldNum:
+		m := &p2p.AppGossip{}
+		ok = consumeFields(v, func(f field) bool {
+			switch {
+			case f.num == 1 && f.typ == protowire.BytesType:
+				m.ChainId = f.bytes
+			case f.num == 2 && f.typ == protowire.BytesType:
+				m.AppBytes = f.bytes
+			default:
+				return false
+			}
+			return true
+		})
+		return &p2p.Message{Message: &p2p.Message_AppGossip{AppGossip: m}}, ok
+	default:
+		return nil, false
+	}
+}
+
+// consumeFields calls [onField] with every field of the encoded message [b].
+// Returns false if [b] is malformed, contains an unsupported field type, or if
+// [onField] returns false.
+func consumeFields(b []byte, onField func(field) bool) bool {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return false
+		}
+		b = b[n:]
+
+		f := field{
+			num: num,
+			typ: typ,
+		}
+		switch typ {
+		case protowire.VarintType:
+			f.varint, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(b)
+			// Limit the capacity so that appending to the field can't
+			// overwrite the rest of the message.
+			f.bytes = f.bytes[:len(f.bytes):len(f.bytes)]
+		default:
+			return false
+		}
+		if n < 0 || !onField(f) {
+			return false
+		}
+		b = b[n:]
+	}
+	return true
+}
@@ -0,0 +1,283 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func zeroCopyTestMessages() []*p2p.Message {
+	chainID := ids.GenerateTestID()
+	payload := bytes.Repeat([]byte{1}, 128)
+	return []*p2p.Message{
+		{
+			Message: &p2p.Message_Put{
+				Put: &p2p.Put{
+					ChainId:    chainID[:],
+					RequestId:  1,
+					Container:  payload,
+					EngineType: p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				},
+			},
+		},
+		{
+			Message: &p2p.Message_PushQuery{
+				PushQuery: &p2p.PushQuery{
+					ChainId:    chainID[:],
+					RequestId:  2,
+					Deadline:   uint64(time.Second),
+					Container:  payload,
+					EngineType: p2p.EngineType_ENGINE_TYPE_AVALANCHE,
+				},
+			},
+		},
+		{
+			Message: &p2p.Message_AppRequest{
+				AppRequest: &p2p.AppRequest{
+					ChainId:     chainID[:],
+					RequestId:   3,
+					Deadline:    uint64(time.Second),
+					AppBytes:    payload,
+					PuzzleNonce: 5,
+				},
+			},
+		},
+		{
+			Message: &p2p.Message_AppResponse{
+				AppResponse: &p2p.AppResponse{
+					ChainId:   chainID[:],
+					RequestId: 4,
+					AppBytes:  payload,
+				},
+			},
+		},
+		{
+			Message: &p2p.Message_AppGossip{
+				AppGossip: &p2p.AppGossip{
+					ChainId:  chainID[:],
+					AppBytes: payload,
+				},
+			},
+		},
+		{
+			Message: &p2p.Message_AppGossip{
+				AppGossip: &p2p.AppGossip{},
+			},
+		},
+	}
+}
+
+func TestUnmarshalZeroCopy(t *testing.T) {
+	for _, msg := range zeroCopyTestMessages() {
+		t.Run(fmt.Sprintf("%T", msg.Message), func(t *testing.T) {
+			require := require.New(t)
+
+			msgBytes, err := proto.Marshal(msg)
+			require.NoError(err)
+
+			parsedMsg, ok := unmarshalZeroCopy(msgBytes)
+			require.True(ok)
+			require.True(proto.Equal(msg, parsedMsg))
+
+			// The payload must reference [msgBytes].
+			payload, err := Unwrap(parsedMsg)
+			require.NoError(err)
+			chainID, err := GetChainID(payload)
+			if err == nil {
+				msgBytes[bytes.Index(msgBytes, chainID[:])]++
+				require.False(proto.Equal(msg, parsedMsg))
+			}
+		})
+	}
+}
+
+func TestUnmarshalZeroCopyUnsupported(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	tests := []struct {
+		name     string
+		msgBytes func() []byte
+	}{
+		{
+			name: "unsupported op",
+			msgBytes: func() []byte {
+				msgBytes, _ := proto.Marshal(&p2p.Message{
+					Message: &p2p.Message_Ping{
+						Ping: &p2p.Ping{Uptime: 100},
+					},
+				})
+				return msgBytes
+			},
+		},
+		{
+			name: "compressed",
+			msgBytes: func() []byte {
+				msgBytes, _ := proto.Marshal(&p2p.Message{
+					Message: &p2p.Message_CompressedZstd{
+						CompressedZstd: []byte{1, 2, 3},
+					},
+				})
+				return msgBytes
+			},
+		},
+		{
+			name: "multiple ops",
+			msgBytes: func() []byte {
+				msgBytes, _ := proto.Marshal(zeroCopyTestMessages()[0])
+				pingBytes, _ := proto.Marshal(&p2p.Message{
+					Message: &p2p.Message_Ping{
+						Ping: &p2p.Ping{Uptime: 100},
+					},
+				})
+				return append(msgBytes, pingBytes...)
+			},
+		},
+		{
+			name: "unknown field",
+			msgBytes: func() []byte {
+				appGossip := protowire.AppendTag(nil, 1, protowire.BytesType)
+				appGossip = protowire.AppendBytes(appGossip, chainID[:])
+				appGossip = protowire.AppendTag(appGossip, 3, protowire.VarintType)
+				appGossip = protowire.AppendVarint(appGossip, 0)
+
+				msgBytes := protowire.AppendTag(nil, appGossipFieldNum, protowire.BytesType)
+				return protowire.AppendBytes(msgBytes, appGossip)
+			},
+		},
+		{
+			name: "truncated",
+			msgBytes: func() []byte {
+				msgBytes, _ := proto.Marshal(zeroCopyTestMessages()[0])
+				return msgBytes[:len(msgBytes)-1]
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, ok := unmarshalZeroCopy(test.msgBytes())
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestParseBuffer(t *testing.T) {
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		compression.DefaultZstdLevel,
+		10*time.Second,
+	)
+	require.NoError(t, err)
+	builder := newInboundBuilder(mb)
+
+	chainID := ids.GenerateTestID()
+	container := bytes.Repeat([]byte{1}, 128)
+	tests := []struct {
+		name            string
+		compressionType compression.Type
+		build           func(OutboundMsgBuilder) (OutboundMessage, error)
+		aliased         bool
+	}{
+		{
+			name:            "put",
+			compressionType: compression.TypeNone,
+			build: func(b OutboundMsgBuilder) (OutboundMessage, error) {
+				return b.Put(chainID, 1, container, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+			},
+			aliased: true,
+		},
+		{
+			name:            "compressed put",
+			compressionType: compression.TypeZstd,
+			build: func(b OutboundMsgBuilder) (OutboundMessage, error) {
+				return b.Put(chainID, 1, container, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+			},
+			aliased: false,
+		},
+		{
+			name:            "ping",
+			compressionType: compression.TypeNone,
+			build: func(b OutboundMsgBuilder) (OutboundMessage, error) {
+				return b.Ping(100, nil)
+			},
+			aliased: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			outMsg, err := test.build(newOutboundBuilder(test.compressionType, mb))
+			require.NoError(err)
+
+			pool := NewBufferPool()
+			buf := pool.Get(len(outMsg.Bytes()))
+			copy(buf.Bytes(), outMsg.Bytes())
+
+			finished := false
+			inMsg, err := builder.ParseBuffer(buf, ids.EmptyNodeID, func() {
+				finished = true
+			})
+			require.NoError(err)
+			require.Equal(outMsg.Op(), inMsg.Op())
+			require.Equal(test.aliased, buf.detached.Load())
+
+			// The message holds its own reference to the buffer if it
+			// references it.
+			buf.Release()
+			if test.aliased {
+				require.Equal(1, pool.Outstanding())
+			} else {
+				require.Zero(pool.Outstanding())
+
+				// Reusing the buffer must not modify the parsed message.
+				expected := inMsg.Message().String()
+				for i := range buf.Bytes() {
+					buf.Bytes()[i] = 0
+				}
+				require.Equal(expected, inMsg.Message().String())
+			}
+
+			inMsg.OnFinishedHandling()
+			require.True(finished)
+			require.Zero(pool.Outstanding())
+		})
+	}
+}
+
+func FuzzUnmarshalZeroCopy(f *testing.F) {
+	for _, msg := range zeroCopyTestMessages() {
+		msgBytes, err := proto.Marshal(msg)
+		require.NoError(f, err)
+		f.Add(msgBytes)
+	}
+
+	f.Fuzz(func(t *testing.T, msgBytes []byte) {
+		msg, ok := unmarshalZeroCopy(msgBytes)
+		if !ok {
+			return
+		}
+
+		// Any message that is decoded without copying must be decoded
+		// identically by proto.Unmarshal.
+		expected := &p2p.Message{}
+		require.NoError(t, proto.Unmarshal(msgBytes, expected))
+		require.True(t, proto.Equal(expected, msg))
+	})
+}
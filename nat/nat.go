@@ -4,12 +4,18 @@
 package nat
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -17,6 +23,15 @@ import (
 const (
 	mapTimeout        = 30 * time.Minute
 	maxRefreshRetries = 3
+
+	// Frequency the port mappings are verified to still be in place
+	defaultVerifyFreq = time.Minute
+)
+
+var (
+	errRouterRebooted     = errors.New("router rebooted")
+	errPortMappingChanged = errors.New("port mapping changed")
+	errPortsNotMapped     = errors.New("ports not mapped")
 )
 
 // Router describes the functionality that a network device must support to be
@@ -26,6 +41,9 @@ type Router interface {
 	SupportsNAT() bool
 	// Map external port [extPort] to internal port [intPort] for [duration]
 	MapPort(intPort, extPort uint16, desc string, duration time.Duration) error
+	// Returns an error if external port [extPort] may no longer be mapped to
+	// internal port [intPort], for instance because the router rebooted
+	VerifyPortMapping(intPort, extPort uint16) error
 	// Undo a port mapping
 	UnmapPort(intPort, extPort uint16) error
 	// Return our external IP
@@ -44,32 +62,66 @@ func GetRouter() Router {
 	return NewNoRouter()
 }
 
+// portMapping is the state of a port mapping, as reported by the health check.
+type portMapping struct {
+	InternalPort uint16 `json:"internalPort"`
+	ExternalPort uint16 `json:"externalPort"`
+	Mapped       bool   `json:"mapped"`
+	// LastMapped is the last time the mapping was created or renewed.
+	LastMapped time.Time `json:"lastMapped"`
+	// LastVerified is the last time the mapping was verified to be in place.
+	LastVerified time.Time `json:"lastVerified"`
+	// TimesLost is the number of times the mapping was found to be lost.
+	TimesLost uint64 `json:"timesLost"`
+	// Error is the reason the mapping was last lost or failed to be created.
+	Error string `json:"error,omitempty"`
+}
+
 // Mapper attempts to open a set of ports on a router
 type Mapper struct {
-	log    logging.Logger
-	r      Router
-	closer chan struct{}
-	wg     sync.WaitGroup
+	log        logging.Logger
+	r          Router
+	verifyFreq time.Duration
+	retryDelay time.Duration
+	closer     chan struct{}
+	wg         sync.WaitGroup
+
+	lock sync.RWMutex
+	// desc -> mapping
+	mappings map[string]*portMapping
 }
 
 // NewPortMapper returns an initialized mapper
-func NewPortMapper(log logging.Logger, r Router) Mapper {
-	return Mapper{
-		log:    log,
-		r:      r,
-		closer: make(chan struct{}),
+func NewPortMapper(log logging.Logger, r Router) *Mapper {
+	return &Mapper{
+		log:        log,
+		r:          r,
+		verifyFreq: defaultVerifyFreq,
+		retryDelay: time.Second,
+		closer:     make(chan struct{}),
+		mappings:   make(map[string]*portMapping),
 	}
 }
 
 // Map external port [extPort] (exposed to the internet) to internal port [intPort] (where our process is listening)
 // and set [ip]. Does this every [updateTime]. [ip] may be nil.
+//
+// The mapping is verified to still be in place every minute, and is
+// re-created, along with [ip], if it was lost.
 func (m *Mapper) Map(intPort, extPort uint16, desc string, ip ips.DynamicIPPort, updateTime time.Duration) {
 	if !m.r.SupportsNAT() {
 		return
 	}
 
+	m.lock.Lock()
+	m.mappings[desc] = &portMapping{
+		InternalPort: intPort,
+		ExternalPort: extPort,
+	}
+	m.lock.Unlock()
+
 	// we attempt a port map, and log an Error if it fails.
-	err := m.retryMapPort(intPort, extPort, desc, mapTimeout)
+	err := m.mapPort(intPort, extPort, desc)
 	if err != nil {
 		m.log.Error("NAT traversal failed",
 			zap.Uint16("externalPort", extPort),
@@ -87,7 +139,27 @@ func (m *Mapper) Map(intPort, extPort uint16, desc string, ip ips.DynamicIPPort,
 	go m.keepPortMapping(intPort, extPort, desc, ip, updateTime)
 }
 
-// Retry port map up to maxRefreshRetries with a 1 second delay
+// mapPort maps the port and records the outcome in the mapping of [desc].
+func (m *Mapper) mapPort(intPort, extPort uint16, desc string) error {
+	err := m.retryMapPort(intPort, extPort, desc, mapTimeout)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mapping := m.mappings[desc]
+	mapping.Mapped = err == nil
+	if err != nil {
+		mapping.Error = err.Error()
+		return err
+	}
+	now := time.Now()
+	mapping.LastMapped = now
+	mapping.LastVerified = now
+	mapping.Error = ""
+	return nil
+}
+
+// Retry port map up to maxRefreshRetries with a [m.retryDelay] delay
 func (m *Mapper) retryMapPort(intPort, extPort uint16, desc string, timeout time.Duration) error {
 	var err error
 	for retryCnt := 0; retryCnt < maxRefreshRetries; retryCnt++ {
@@ -103,18 +175,21 @@ func (m *Mapper) retryMapPort(intPort, extPort uint16, desc string, timeout time
 			zap.Uint16("internalPort", intPort),
 			zap.Error(err),
 		)
-		time.Sleep(1 * time.Second)
+		time.Sleep(m.retryDelay)
 	}
 	return err
 }
 
 // keepPortMapping runs in the background to keep a port mapped. It renews the mapping from [extPort]
-// to [intPort]] every [updateTime]. Updates [ip] every [updateTime].
+// to [intPort]] every [updateTime]. Updates [ip] every [updateTime]. Re-creates the mapping and updates
+// [ip] if the mapping was lost.
 func (m *Mapper) keepPortMapping(intPort, extPort uint16, desc string, ip ips.DynamicIPPort, updateTime time.Duration) {
 	updateTimer := time.NewTimer(updateTime)
+	verifyTicker := time.NewTicker(m.verifyFreq)
 
 	defer func(extPort uint16) {
 		updateTimer.Stop()
+		verifyTicker.Stop()
 
 		m.log.Debug("unmapping port",
 			zap.Uint16("externalPort", extPort),
@@ -134,7 +209,7 @@ func (m *Mapper) keepPortMapping(intPort, extPort uint16, desc string, ip ips.Dy
 	for {
 		select {
 		case <-updateTimer.C:
-			err := m.retryMapPort(intPort, extPort, desc, mapTimeout)
+			err := m.mapPort(intPort, extPort, desc)
 			if err != nil {
 				m.log.Warn("renew NAT traversal failed",
 					zap.Uint16("externalPort", extPort),
@@ -144,12 +219,61 @@ func (m *Mapper) keepPortMapping(intPort, extPort uint16, desc string, ip ips.Dy
 			}
 			m.updateIP(ip)
 			updateTimer.Reset(updateTime)
+		case <-verifyTicker.C:
+			if m.verifyPortMapping(intPort, extPort, desc) {
+				continue
+			}
+			err := m.mapPort(intPort, extPort, desc)
+			if err != nil {
+				m.log.Warn("re-creating lost port mapping failed",
+					zap.Uint16("externalPort", extPort),
+					zap.Uint16("internalPort", intPort),
+					zap.Error(err),
+				)
+			} else {
+				m.log.Info("re-created lost port mapping",
+					zap.Uint16("externalPort", extPort),
+					zap.Uint16("internalPort", intPort),
+				)
+			}
+			// The router may have been assigned a new external IP when it
+			// lost the mapping.
+			m.updateIP(ip)
 		case <-m.closer:
 			return
 		}
 	}
 }
 
+// verifyPortMapping returns false if the mapping of [desc] was lost and must
+// be re-created.
+func (m *Mapper) verifyPortMapping(intPort, extPort uint16, desc string) bool {
+	err := m.r.VerifyPortMapping(intPort, extPort)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mapping := m.mappings[desc]
+	if err == nil {
+		mapping.Mapped = true
+		mapping.LastVerified = time.Now()
+		mapping.Error = ""
+		return true
+	}
+
+	if mapping.Mapped {
+		mapping.TimesLost++
+		m.log.Warn("port mapping was lost",
+			zap.Uint16("externalPort", extPort),
+			zap.Uint16("internalPort", intPort),
+			zap.Error(err),
+		)
+	}
+	mapping.Mapped = false
+	mapping.Error = err.Error()
+	return false
+}
+
 func (m *Mapper) updateIP(ip ips.DynamicIPPort) {
 	if ip == nil {
 		return
@@ -170,6 +294,28 @@ func (m *Mapper) updateIP(ip ips.DynamicIPPort) {
 	}
 }
 
+// HealthCheck reports the state of each port mapping. Returns an error if any
+// of the ports isn't mapped, in which case the node may not be reachable.
+func (m *Mapper) HealthCheck(context.Context) (interface{}, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	details := make(map[string]portMapping, len(m.mappings))
+	unmapped := make([]string, 0, len(m.mappings))
+	for desc, mapping := range m.mappings {
+		details[desc] = *mapping
+		if !mapping.Mapped {
+			unmapped = append(unmapped, desc)
+		}
+	}
+	if len(unmapped) == 0 {
+		return details, nil
+	}
+
+	slices.Sort(unmapped)
+	return details, fmt.Errorf("%w: %s", errPortsNotMapped, strings.Join(unmapped, ", "))
+}
+
 // UnmapAllPorts stops mapping all ports from this mapper and attempts to unmap
 // them.
 func (m *Mapper) UnmapAllPorts() {
@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var (
+	_ Router = (*testRouter)(nil)
+
+	errTest = errors.New("non-nil error")
+)
+
+type testRouter struct {
+	lock        sync.Mutex
+	ip          net.IP
+	mapErr      error
+	verifyErr   error
+	numMapped   int
+	numUnmapped int
+}
+
+func (*testRouter) SupportsNAT() bool {
+	return true
+}
+
+func (r *testRouter) MapPort(uint16, uint16, string, time.Duration) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.mapErr != nil {
+		return r.mapErr
+	}
+	r.numMapped++
+	r.verifyErr = nil
+	return nil
+}
+
+func (r *testRouter) VerifyPortMapping(uint16, uint16) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.verifyErr
+}
+
+func (r *testRouter) UnmapPort(uint16, uint16) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.numUnmapped++
+	return nil
+}
+
+func (r *testRouter) ExternalIP() (net.IP, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.ip, nil
+}
+
+func (r *testRouter) set(f func(r *testRouter)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	f(r)
+}
+
+func (r *testRouter) get(f func(r *testRouter) int) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return f(r)
+}
+
+func newTestMapper(r Router) *Mapper {
+	m := NewPortMapper(logging.NoLog{}, r)
+	m.verifyFreq = 10 * time.Millisecond
+	m.retryDelay = time.Millisecond
+	return m
+}
+
+func TestMapperRemapsLostMapping(t *testing.T) {
+	require := require.New(t)
+
+	r := &testRouter{
+		ip: net.IPv4(1, 2, 3, 4),
+	}
+	m := newTestMapper(r)
+	ip := ips.NewDynamicIPPort(net.IPv4(1, 1, 1, 1), 9651)
+	m.Map(9651, 9651, "staking", ip, time.Hour)
+
+	details, err := m.HealthCheck(context.Background())
+	require.NoError(err)
+	require.True(details.(map[string]portMapping)["staking"].Mapped)
+	require.Equal(1, r.get(func(r *testRouter) int { return r.numMapped }))
+
+	// The router rebooted with a new external IP.
+	r.set(func(r *testRouter) {
+		r.ip = net.IPv4(5, 6, 7, 8)
+		r.verifyErr = errRouterRebooted
+	})
+	require.Eventually(func() bool {
+		return r.get(func(r *testRouter) int { return r.numMapped }) == 2
+	}, time.Second, time.Millisecond)
+	require.Eventually(func() bool {
+		return ip.IPPort().IP.Equal(net.IPv4(5, 6, 7, 8))
+	}, time.Second, time.Millisecond)
+
+	details, err = m.HealthCheck(context.Background())
+	require.NoError(err)
+	mapping := details.(map[string]portMapping)["staking"]
+	require.True(mapping.Mapped)
+	require.Equal(uint64(1), mapping.TimesLost)
+
+	m.UnmapAllPorts()
+	require.Equal(1, r.get(func(r *testRouter) int { return r.numUnmapped }))
+}
+
+func TestMapperHealthCheck(t *testing.T) {
+	require := require.New(t)
+
+	r := &testRouter{
+		mapErr: errTest,
+	}
+	m := newTestMapper(r)
+	m.Map(9650, 9650, "http", nil, time.Hour)
+	m.Map(9651, 9651, "staking", nil, time.Hour)
+
+	details, err := m.HealthCheck(context.Background())
+	require.ErrorIs(err, errPortsNotMapped)
+	require.Equal("ports not mapped: http, staking", err.Error())
+	mapping := details.(map[string]portMapping)["staking"]
+	require.False(mapping.Mapped)
+	require.Equal(errTest.Error(), mapping.Error)
+
+	// Once the router accepts the mappings, they are re-created.
+	r.set(func(r *testRouter) {
+		r.mapErr = nil
+		r.verifyErr = errTest
+	})
+	require.Eventually(func() bool {
+		_, err := m.HealthCheck(context.Background())
+		return err == nil
+	}, time.Second, time.Millisecond)
+
+	m.UnmapAllPorts()
+}
+
+func TestMapperNoNAT(t *testing.T) {
+	require := require.New(t)
+
+	m := newTestMapper(&noRouter{})
+	m.Map(9651, 9651, "staking", nil, time.Hour)
+
+	details, err := m.HealthCheck(context.Background())
+	require.NoError(err)
+	require.Empty(details)
+
+	m.UnmapAllPorts()
+}
@@ -31,6 +31,10 @@ func (noRouter) MapPort(uint16, uint16, string, time.Duration) error {
 	return errNoRouterCantMapPorts
 }
 
+func (noRouter) VerifyPortMapping(uint16, uint16) error {
+	return errNoRouterCantMapPorts
+}
+
 func (noRouter) UnmapPort(uint16, uint16) error {
 	return nil
 }
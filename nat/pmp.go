@@ -5,8 +5,10 @@ package nat
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/jackpal/gateway"
@@ -33,6 +35,13 @@ var (
 // common interface.
 type pmpRouter struct {
 	client *natpmp.Client
+
+	epochLock sync.Mutex
+	// Seconds since the router started, as last reported by the router
+	epoch uint32
+	// Time [epoch] was reported at. Zero if the router didn't report its
+	// epoch yet.
+	epochTime time.Time
 }
 
 func (*pmpRouter) SupportsNAT() bool {
@@ -55,8 +64,52 @@ func (r *pmpRouter) MapPort(
 		return errInvalidLifetime
 	}
 
-	_, err := r.client.AddPortMapping(pmpProtocol, internalPort, externalPort, int(lifetime))
-	return err
+	result, err := r.client.AddPortMapping(pmpProtocol, internalPort, externalPort, int(lifetime))
+	if err != nil {
+		return err
+	}
+	// The port is mapped from now on, so a reboot of the router before now
+	// is irrelevant.
+	_ = r.observeEpoch(result.SecondsSinceStartOfEpoc, time.Now())
+
+	if result.MappedExternalPort != newExternalPort {
+		return fmt.Errorf("%w: router mapped external port %d rather than %d",
+			errPortMappingChanged, result.MappedExternalPort, newExternalPort)
+	}
+	return nil
+}
+
+// NAT-PMP doesn't support querying a mapping. Routers lose their mappings
+// when they reboot, so the mapping is assumed to be in place as long as the
+// router didn't reboot.
+func (r *pmpRouter) VerifyPortMapping(uint16, uint16) error {
+	response, err := r.client.GetExternalAddress()
+	if err != nil {
+		return err
+	}
+	return r.observeEpoch(response.SecondsSinceStartOfEpoc, time.Now())
+}
+
+// observeEpoch records the [epoch] reported by the router at [now]. Returns
+// errRouterRebooted if the router rebooted since it last reported its epoch.
+func (r *pmpRouter) observeEpoch(epoch uint32, now time.Time) error {
+	r.epochLock.Lock()
+	defer r.epochLock.Unlock()
+
+	// As specified in RFC 6886 section 3.6, the router rebooted if its epoch
+	// advanced by less than 7/8 of the local elapsed time, minus 2 seconds.
+	rebooted := false
+	if !r.epochTime.IsZero() {
+		elapsed := now.Sub(r.epochTime).Seconds()
+		rebooted = float64(epoch) < float64(r.epoch)+elapsed*7/8-2
+	}
+	r.epoch = epoch
+	r.epochTime = now
+
+	if rebooted {
+		return errRouterRebooted
+	}
+	return nil
 }
 
 func (r *pmpRouter) UnmapPort(internalPort uint16, _ uint16) error {
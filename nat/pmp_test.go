@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPMPRouterObserveEpoch(t *testing.T) {
+	tests := []struct {
+		name        string
+		epoch       uint32
+		elapsed     time.Duration
+		expectedErr error
+	}{
+		{
+			name:        "advanced with the local clock",
+			epoch:       1_060,
+			elapsed:     time.Minute,
+			expectedErr: nil,
+		},
+		{
+			name:        "advanced slightly slower than the local clock",
+			epoch:       1_053,
+			elapsed:     time.Minute,
+			expectedErr: nil,
+		},
+		{
+			name:        "went backwards",
+			epoch:       30,
+			elapsed:     time.Minute,
+			expectedErr: errRouterRebooted,
+		},
+		{
+			name:        "advanced much slower than the local clock",
+			epoch:       1_010,
+			elapsed:     time.Minute,
+			expectedErr: errRouterRebooted,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r := &pmpRouter{}
+			now := time.Now()
+			require.NoError(r.observeEpoch(1_000, now))
+
+			err := r.observeEpoch(test.epoch, now.Add(test.elapsed))
+			require.ErrorIs(err, test.expectedErr)
+
+			// The next observation is compared against the last one.
+			require.NoError(r.observeEpoch(test.epoch+1, now.Add(test.elapsed+time.Second)))
+		})
+	}
+}
@@ -143,6 +143,22 @@ func (r *upnpRouter) MapPort(
 		ip.String(), true, desc, uint32(lifetime))
 }
 
+func (r *upnpRouter) VerifyPortMapping(intPort, extPort uint16) error {
+	ip, err := r.localIP()
+	if err != nil {
+		return err
+	}
+	mappedPort, mappedClient, enabled, _, _, err := r.client.GetSpecificPortMappingEntry("", extPort, upnpProtocol)
+	if err != nil {
+		return err
+	}
+	if !enabled || mappedPort != intPort || mappedClient != ip.String() {
+		return fmt.Errorf("%w: external port %d is mapped to %s:%d (enabled: %t)",
+			errPortMappingChanged, extPort, mappedClient, mappedPort, enabled)
+	}
+	return nil
+}
+
 func (r *upnpRouter) UnmapPort(_, extPort uint16) error {
 	return r.client.DeletePortMapping("", extPort, upnpProtocol)
 }
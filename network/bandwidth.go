@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const opLabel = "op"
+
+var (
+	_ peer.BandwidthTracker = (*PeerBandwidth)(nil)
+
+	opLabels = []string{opLabel}
+
+	// msgSizeBuckets are the upper bounds, in bytes, of the buckets of the
+	// message size histograms.
+	msgSizeBuckets = []float64{
+		64,
+		256,
+		units.KiB,
+		4 * units.KiB,
+		16 * units.KiB,
+		64 * units.KiB,
+		256 * units.KiB,
+		units.MiB,
+		2 * units.MiB,
+	}
+)
+
+// OpBandwidth is the bandwidth used by the messages of one op.
+type OpBandwidth struct {
+	Messages uint64 `json:"messages"`
+	Bytes    uint64 `json:"bytes"`
+}
+
+// PeerStats is the bandwidth used by a peer since it connected.
+type PeerStats struct {
+	SentBytes     uint64                     `json:"sentBytes"`
+	ReceivedBytes uint64                     `json:"receivedBytes"`
+	Sent          map[message.Op]OpBandwidth `json:"sent"`
+	Received      map[message.Op]OpBandwidth `json:"received"`
+}
+
+// PeerBandwidth tracks the bandwidth used by each connected peer, by message
+// op, so that saturation can be attributed to specific peers and messages.
+type PeerBandwidth struct {
+	sentMsgBytes     *prometheus.HistogramVec
+	receivedMsgBytes *prometheus.HistogramVec
+
+	lock  sync.RWMutex
+	peers map[ids.NodeID]*peerBandwidth
+}
+
+type peerBandwidth struct {
+	lock  sync.Mutex
+	stats PeerStats
+}
+
+func NewPeerBandwidth(namespace string, registerer prometheus.Registerer) (*PeerBandwidth, error) {
+	b := &PeerBandwidth{
+		sentMsgBytes: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "peer_sent_msg_bytes",
+				Help:      "Size of the messages sent to peers (bytes)",
+				Buckets:   msgSizeBuckets,
+			},
+			opLabels,
+		),
+		receivedMsgBytes: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "peer_received_msg_bytes",
+				Help:      "Size of the messages received from peers (bytes)",
+				Buckets:   msgSizeBuckets,
+			},
+			opLabels,
+		),
+		peers: make(map[ids.NodeID]*peerBandwidth),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(b.sentMsgBytes),
+		registerer.Register(b.receivedMsgBytes),
+	)
+	return b, errs.Err
+}
+
+func (b *PeerBandwidth) Sent(nodeID ids.NodeID, op message.Op, bytes int) {
+	b.sentMsgBytes.WithLabelValues(op.String()).Observe(float64(bytes))
+
+	p := b.getOrCreate(nodeID)
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.stats.SentBytes += uint64(bytes)
+	record(p.stats.Sent, op, bytes)
+}
+
+func (b *PeerBandwidth) Received(nodeID ids.NodeID, op message.Op, bytes int) {
+	b.receivedMsgBytes.WithLabelValues(op.String()).Observe(float64(bytes))
+
+	p := b.getOrCreate(nodeID)
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.stats.ReceivedBytes += uint64(bytes)
+	record(p.stats.Received, op, bytes)
+}
+
+// Disconnected stops tracking [nodeID]. Must only be called once no more
+// messages will be sent to or received from [nodeID].
+func (b *PeerBandwidth) Disconnected(nodeID ids.NodeID) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.peers, nodeID)
+}
+
+// PeerStats returns the bandwidth used by [nodeID] since it connected. Returns
+// false if no messages were sent to or received from [nodeID] since it
+// connected.
+func (b *PeerBandwidth) PeerStats(nodeID ids.NodeID) (PeerStats, bool) {
+	b.lock.RLock()
+	p, ok := b.peers[nodeID]
+	b.lock.RUnlock()
+	if !ok {
+		return PeerStats{}, false
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	stats := p.stats
+	stats.Sent = maps.Clone(p.stats.Sent)
+	stats.Received = maps.Clone(p.stats.Received)
+	return stats, true
+}
+
+func (b *PeerBandwidth) getOrCreate(nodeID ids.NodeID) *peerBandwidth {
+	b.lock.RLock()
+	p, ok := b.peers[nodeID]
+	b.lock.RUnlock()
+	if ok {
+		return p
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	p, ok = b.peers[nodeID]
+	if !ok {
+		p = &peerBandwidth{
+			stats: PeerStats{
+				Sent:     make(map[message.Op]OpBandwidth),
+				Received: make(map[message.Op]OpBandwidth),
+			},
+		}
+		b.peers[nodeID] = p
+	}
+	return p
+}
+
+func record(ops map[message.Op]OpBandwidth, op message.Op, bytes int) {
+	bandwidth := ops[op]
+	bandwidth.Messages++
+	bandwidth.Bytes += uint64(bytes)
+	ops[op] = bandwidth
+}
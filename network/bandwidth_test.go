@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+)
+
+func TestPeerBandwidth(t *testing.T) {
+	require := require.New(t)
+
+	bandwidth, err := NewPeerBandwidth("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	_, ok := bandwidth.PeerStats(nodeID0)
+	require.False(ok)
+
+	bandwidth.Sent(nodeID0, message.PingOp, 10)
+	bandwidth.Sent(nodeID0, message.PingOp, 20)
+	bandwidth.Sent(nodeID0, message.AppGossipOp, 100)
+	bandwidth.Received(nodeID0, message.PongOp, 5)
+	bandwidth.Received(nodeID1, message.PongOp, 7)
+
+	stats, ok := bandwidth.PeerStats(nodeID0)
+	require.True(ok)
+	require.Equal(PeerStats{
+		SentBytes:     130,
+		ReceivedBytes: 5,
+		Sent: map[message.Op]OpBandwidth{
+			message.PingOp: {
+				Messages: 2,
+				Bytes:    30,
+			},
+			message.AppGossipOp: {
+				Messages: 1,
+				Bytes:    100,
+			},
+		},
+		Received: map[message.Op]OpBandwidth{
+			message.PongOp: {
+				Messages: 1,
+				Bytes:    5,
+			},
+		},
+	}, stats)
+
+	// The returned stats must not be modified by later messages.
+	bandwidth.Sent(nodeID0, message.PingOp, 10)
+	require.Equal(OpBandwidth{Messages: 2, Bytes: 30}, stats.Sent[message.PingOp])
+
+	bandwidth.Disconnected(nodeID0)
+	_, ok = bandwidth.PeerStats(nodeID0)
+	require.False(ok)
+
+	stats, ok = bandwidth.PeerStats(nodeID1)
+	require.True(ok)
+	require.Equal(uint64(7), stats.ReceivedBytes)
+}
@@ -0,0 +1,358 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/buffer"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	Inbound  Direction = "inbound"
+	Outbound Direction = "outbound"
+
+	DefaultMaxRecords     = 1024
+	DefaultMaxPayloadSize = 256
+
+	// Bounds on the configuration so that capturing can't exhaust the
+	// node's memory.
+	maxMaxRecords     = 64 * 1024
+	maxMaxPayloadSize = 64 * units.KiB
+
+	unknownOp = "unknown"
+)
+
+var (
+	_ Capturer = (*capturer)(nil)
+
+	ErrAlreadyCapturing = errors.New("already capturing messages")
+	ErrNotCapturing     = errors.New("not capturing messages")
+
+	errTooManyRecords  = errors.New("too many records")
+	errPayloadTooLarge = errors.New("payload size too large")
+)
+
+// Direction is the direction a message was sent in, relative to this node.
+type Direction string
+
+// Config specifies which messages are captured and how they are recorded.
+type Config struct {
+	// If non-empty, only messages exchanged with this peer are captured.
+	NodeID ids.NodeID `json:"nodeID"`
+	// If non-empty, only messages of this chain are captured.
+	ChainID ids.ID `json:"chainID"`
+	// Number of records to keep in memory. Once full, the oldest record is
+	// evicted. Defaults to [DefaultMaxRecords].
+	MaxRecords int `json:"maxRecords"`
+	// Number of bytes of each uncompressed message that are recorded.
+	// Defaults to [DefaultMaxPayloadSize].
+	MaxPayloadSize int `json:"maxPayloadSize"`
+	// If true, records are also appended to a file as JSON lines.
+	WriteToFile bool `json:"writeToFile"`
+}
+
+// Record describes a single message sent or received by this node.
+type Record struct {
+	Time      time.Time  `json:"time"`
+	Direction Direction  `json:"direction"`
+	NodeID    ids.NodeID `json:"nodeID"`
+	Op        string     `json:"op"`
+	// Empty if the message isn't specific to a chain.
+	ChainID ids.ID `json:"chainID"`
+	// Number of bytes of the message on the wire.
+	Size int `json:"size"`
+	// Hex encoded prefix of the uncompressed message.
+	Payload   string `json:"payload"`
+	Truncated bool   `json:"truncated"`
+}
+
+// Capturer records p2p messages to help debug the node while it's running.
+//
+// Capturing is disabled until Start is called, in which case Capture returns
+// immediately.
+type Capturer interface {
+	// Start capturing the messages specified by [config]. Records of any
+	// previous capture are dropped.
+	//
+	// Returns the path of the file records are written to, if any.
+	Start(config Config) (string, error)
+
+	// Stop capturing messages. The records remain available until the next
+	// call to Start.
+	Stop() error
+
+	// Capturing returns true if messages are currently being captured.
+	Capturing() bool
+
+	// Records returns the records in memory, from oldest to newest.
+	Records() []Record
+
+	// Capture records [msgBytes] if it matches the current capture.
+	Capture(direction Direction, nodeID ids.NodeID, msgBytes []byte)
+
+	// Shutdown stops any capture in progress.
+	Shutdown()
+}
+
+type capturer struct {
+	log  logging.Logger
+	dir  string
+	gzip compression.Compressor
+	zstd compression.Compressor
+
+	// Allows Capture to return without grabbing the lock when not capturing.
+	capturing utils.Atomic[bool]
+
+	lock    sync.Mutex
+	clock   mockable.Clock
+	config  Config
+	records buffer.Queue[Record]
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// New returns a Capturer that writes capture files to [dir].
+func New(log logging.Logger, dir string) (Capturer, error) {
+	gzipCompressor, err := compression.NewGzipCompressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		return nil, err
+	}
+	zstdCompressor, err := compression.NewZstdCompressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &capturer{
+		log:  log,
+		dir:  dir,
+		gzip: gzipCompressor,
+		zstd: zstdCompressor,
+	}, nil
+}
+
+func (c *capturer) Start(config Config) (string, error) {
+	if config.MaxRecords == 0 {
+		config.MaxRecords = DefaultMaxRecords
+	}
+	if config.MaxPayloadSize == 0 {
+		config.MaxPayloadSize = DefaultMaxPayloadSize
+	}
+	switch {
+	case config.MaxRecords < 0 || config.MaxRecords > maxMaxRecords:
+		return "", fmt.Errorf("%w: %d not in [1, %d]", errTooManyRecords, config.MaxRecords, maxMaxRecords)
+	case config.MaxPayloadSize < 0 || config.MaxPayloadSize > maxMaxPayloadSize:
+		return "", fmt.Errorf("%w: %d not in [1, %d]", errPayloadTooLarge, config.MaxPayloadSize, maxMaxPayloadSize)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.capturing.Get() {
+		return "", ErrAlreadyCapturing
+	}
+
+	records, err := buffer.NewBoundedQueue[Record](config.MaxRecords, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var path string
+	if config.WriteToFile {
+		if err := os.MkdirAll(c.dir, perms.ReadWriteExecute); err != nil {
+			return "", err
+		}
+		path = filepath.Join(c.dir, fmt.Sprintf("%d.jsonl", c.clock.Time().Unix()))
+		file, err := perms.Create(path, perms.ReadWrite)
+		if err != nil {
+			return "", err
+		}
+		c.file = file
+		c.encoder = json.NewEncoder(file)
+	}
+
+	c.config = config
+	c.records = records
+	c.capturing.Set(true)
+
+	c.log.Info("started capturing messages",
+		zap.Stringer("nodeID", config.NodeID),
+		zap.Stringer("chainID", config.ChainID),
+		zap.String("path", path),
+	)
+	return path, nil
+}
+
+func (c *capturer) Stop() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.capturing.Get() {
+		return ErrNotCapturing
+	}
+	c.capturing.Set(false)
+
+	c.log.Info("stopped capturing messages",
+		zap.Int("numRecords", c.records.Len()),
+	)
+	return c.closeFile()
+}
+
+func (c *capturer) Capturing() bool {
+	return c.capturing.Get()
+}
+
+func (c *capturer) Records() []Record {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.records == nil {
+		return nil
+	}
+	return c.records.List()
+}
+
+func (c *capturer) Capture(direction Direction, nodeID ids.NodeID, msgBytes []byte) {
+	if !c.capturing.Get() {
+		return
+	}
+
+	// Decoding is done before grabbing the lock so that peers don't contend
+	// on decompression.
+	op, chainID, payload := c.decode(msgBytes)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Capturing may have been stopped while decoding.
+	if !c.capturing.Get() {
+		return
+	}
+	if c.config.NodeID != ids.EmptyNodeID && c.config.NodeID != nodeID {
+		return
+	}
+	if c.config.ChainID != ids.Empty && c.config.ChainID != chainID {
+		return
+	}
+
+	truncated := len(payload) > c.config.MaxPayloadSize
+	if truncated {
+		payload = payload[:c.config.MaxPayloadSize]
+	}
+	encodedPayload, err := formatting.Encode(formatting.HexNC, payload)
+	if err != nil {
+		// Should never happen
+		encodedPayload = ""
+	}
+
+	record := Record{
+		Time:      c.clock.Time(),
+		Direction: direction,
+		NodeID:    nodeID,
+		Op:        op,
+		ChainID:   chainID,
+		Size:      len(msgBytes),
+		Payload:   encodedPayload,
+		Truncated: truncated,
+	}
+	c.records.Push(record)
+
+	if c.encoder == nil {
+		return
+	}
+	if err := c.encoder.Encode(record); err != nil {
+		c.log.Warn("failed to write message record",
+			zap.Error(err),
+		)
+	}
+}
+
+func (c *capturer) Shutdown() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.capturing.Set(false)
+	if err := c.closeFile(); err != nil {
+		c.log.Warn("failed to close message capture file",
+			zap.Error(err),
+		)
+	}
+}
+
+// Assumes [c.lock] is held.
+func (c *capturer) closeFile() error {
+	if c.file == nil {
+		return nil
+	}
+	err := c.file.Close()
+	c.file = nil
+	c.encoder = nil
+	return err
+}
+
+// decode returns the op, chain, and uncompressed bytes of [msgBytes]. If the
+// message can't be decoded, its raw bytes are returned.
+func (c *capturer) decode(msgBytes []byte) (string, ids.ID, []byte) {
+	m := new(p2p.Message)
+	if err := proto.Unmarshal(msgBytes, m); err != nil {
+		return unknownOp, ids.Empty, msgBytes
+	}
+
+	var (
+		compressor compression.Compressor
+		compressed []byte
+	)
+	switch msg := m.GetMessage().(type) {
+	case *p2p.Message_CompressedGzip:
+		compressor = c.gzip
+		compressed = msg.CompressedGzip
+	case *p2p.Message_CompressedZstd:
+		compressor = c.zstd
+		compressed = msg.CompressedZstd
+	}
+	if compressor != nil {
+		decompressed, err := compressor.Decompress(compressed)
+		if err != nil {
+			return unknownOp, ids.Empty, msgBytes
+		}
+		m = new(p2p.Message)
+		if err := proto.Unmarshal(decompressed, m); err != nil {
+			return unknownOp, ids.Empty, msgBytes
+		}
+		msgBytes = decompressed
+	}
+
+	op, err := message.ToOp(m)
+	if err != nil {
+		return unknownOp, ids.Empty, msgBytes
+	}
+	inner, err := message.Unwrap(m)
+	if err != nil {
+		return op.String(), ids.Empty, msgBytes
+	}
+	// Messages that aren't specific to a chain are recorded with an empty
+	// chainID.
+	chainID, _ := message.GetChainID(inner)
+	return op.String(), chainID, msgBytes
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func pushQueryBytes(t *testing.T, chainID ids.ID) []byte {
+	msgBytes, err := proto.Marshal(&p2p.Message{
+		Message: &p2p.Message_PushQuery{
+			PushQuery: &p2p.PushQuery{
+				ChainId:   chainID[:],
+				Container: []byte("container"),
+			},
+		},
+	})
+	require.NoError(t, err)
+	return msgBytes
+}
+
+func pingBytes(t *testing.T) []byte {
+	msgBytes, err := proto.Marshal(&p2p.Message{
+		Message: &p2p.Message_Ping{
+			Ping: &p2p.Ping{},
+		},
+	})
+	require.NoError(t, err)
+	return msgBytes
+}
+
+func TestCapturerNotCapturing(t *testing.T) {
+	require := require.New(t)
+
+	c, err := New(logging.NoLog{}, t.TempDir())
+	require.NoError(err)
+
+	c.Capture(Inbound, ids.GenerateTestNodeID(), pingBytes(t))
+	require.False(c.Capturing())
+	require.Empty(c.Records())
+	require.ErrorIs(c.Stop(), ErrNotCapturing)
+}
+
+func TestCapturerStart(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "too many records",
+			config: Config{
+				MaxRecords: maxMaxRecords + 1,
+			},
+			expectedErr: errTooManyRecords,
+		},
+		{
+			name: "payload too large",
+			config: Config{
+				MaxPayloadSize: maxMaxPayloadSize + 1,
+			},
+			expectedErr: errPayloadTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			c, err := New(logging.NoLog{}, t.TempDir())
+			require.NoError(err)
+
+			_, err = c.Start(test.config)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedErr == nil, c.Capturing())
+		})
+	}
+}
+
+func TestCapturerFilters(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		nodeID       = ids.GenerateTestNodeID()
+		chainID      = ids.GenerateTestID()
+		otherChainID = ids.GenerateTestID()
+	)
+	c, err := New(logging.NoLog{}, t.TempDir())
+	require.NoError(err)
+
+	_, err = c.Start(Config{
+		NodeID:  nodeID,
+		ChainID: chainID,
+	})
+	require.NoError(err)
+	_, err = c.Start(Config{})
+	require.ErrorIs(err, ErrAlreadyCapturing)
+
+	msgBytes := pushQueryBytes(t, chainID)
+	c.Capture(Outbound, nodeID, msgBytes)
+	c.Capture(Outbound, ids.GenerateTestNodeID(), msgBytes)
+	c.Capture(Inbound, nodeID, pushQueryBytes(t, otherChainID))
+	c.Capture(Inbound, nodeID, pingBytes(t))
+
+	records := c.Records()
+	require.Len(records, 1)
+	record := records[0]
+	require.Equal(Outbound, record.Direction)
+	require.Equal(nodeID, record.NodeID)
+	require.Equal(message.PushQueryOp.String(), record.Op)
+	require.Equal(chainID, record.ChainID)
+	require.Equal(len(msgBytes), record.Size)
+	require.False(record.Truncated)
+
+	require.NoError(c.Stop())
+	c.Capture(Outbound, nodeID, msgBytes)
+	require.Len(c.Records(), 1)
+}
+
+func TestCapturerCompressed(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	c, err := New(logging.NoLog{}, t.TempDir())
+	require.NoError(err)
+	_, err = c.Start(Config{
+		ChainID: chainID,
+	})
+	require.NoError(err)
+
+	compressor, err := compression.NewZstdCompressor(constants.DefaultMaxMessageSize)
+	require.NoError(err)
+	compressed, err := compressor.Compress(pushQueryBytes(t, chainID))
+	require.NoError(err)
+	msgBytes, err := proto.Marshal(&p2p.Message{
+		Message: &p2p.Message_CompressedZstd{
+			CompressedZstd: compressed,
+		},
+	})
+	require.NoError(err)
+
+	c.Capture(Inbound, ids.GenerateTestNodeID(), msgBytes)
+
+	records := c.Records()
+	require.Len(records, 1)
+	require.Equal(message.PushQueryOp.String(), records[0].Op)
+	require.Equal(chainID, records[0].ChainID)
+	require.Equal(len(msgBytes), records[0].Size)
+}
+
+func TestCapturerRingBuffer(t *testing.T) {
+	require := require.New(t)
+
+	c, err := New(logging.NoLog{}, t.TempDir())
+	require.NoError(err)
+	_, err = c.Start(Config{
+		MaxRecords:     2,
+		MaxPayloadSize: 1,
+	})
+	require.NoError(err)
+
+	nodeIDs := []ids.NodeID{
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+	}
+	for _, nodeID := range nodeIDs {
+		c.Capture(Inbound, nodeID, pingBytes(t))
+	}
+
+	records := c.Records()
+	require.Len(records, 2)
+	require.Equal(nodeIDs[1], records[0].NodeID)
+	require.Equal(nodeIDs[2], records[1].NodeID)
+	require.True(records[1].Truncated)
+	require.Equal("0x", records[1].Payload[:2])
+	require.Len(records[1].Payload, 2+2)
+}
+
+func TestCapturerWriteToFile(t *testing.T) {
+	require := require.New(t)
+
+	c, err := New(logging.NoLog{}, t.TempDir())
+	require.NoError(err)
+	path, err := c.Start(Config{
+		WriteToFile: true,
+	})
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	c.Capture(Inbound, nodeID, pingBytes(t))
+	c.Capture(Outbound, nodeID, pingBytes(t))
+	require.NoError(c.Stop())
+
+	file, err := os.Open(path)
+	require.NoError(err)
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		require.NoError(json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(scanner.Err())
+	require.Len(records, 2)
+	require.Equal(Inbound, records[0].Direction)
+	require.Equal(Outbound, records[1].Direction)
+	require.Equal(message.PingOp.String(), records[1].Op)
+}
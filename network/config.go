@@ -9,12 +9,16 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/capture"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/set"
@@ -116,8 +120,34 @@ type Config struct {
 	DialerConfig dialer.Config `json:"dialerConfig"`
 	TLSConfig    *tls.Config   `json:"-"`
 
+	// MaxConcurrentDials is the maximum number of outbound connection
+	// attempts in progress at once. Once reached, the attempts to reach peers
+	// with more stake go first. If 0, attempts are never delayed.
+	MaxConcurrentDials int `json:"maxConcurrentDials"`
+
 	TLSKeyLogFile string `json:"tlsKeyLogFile"`
 
+	// TLSSessionResumptionEnabled allows reconnecting peers to resume a
+	// previous TLS session rather than performing a full handshake.
+	TLSSessionResumptionEnabled bool `json:"tlsSessionResumptionEnabled"`
+
+	// TLSSessionTicketKeyRotationFreq is how often a new key is generated to
+	// encrypt TLS session tickets. Tickets remain valid for
+	// [TLSSessionTicketKeys] rotations.
+	TLSSessionTicketKeyRotationFreq time.Duration `json:"tlsSessionTicketKeyRotationFreq"`
+
+	// TLSSessionTicketKeys is the number of session ticket keys that are
+	// retained to resume previously issued sessions.
+	TLSSessionTicketKeys int `json:"tlsSessionTicketKeys"`
+
+	// TLSMinVersion is the minimum TLS version accepted from peers. If zero,
+	// only TLS 1.3 is accepted.
+	TLSMinVersion uint16 `json:"tlsMinVersion"`
+
+	// TLSCipherSuites are the IDs of the cipher suites accepted from peers. If
+	// empty, all the cipher suites supported by crypto/tls are accepted.
+	TLSCipherSuites []uint16 `json:"tlsCipherSuites"`
+
 	Namespace          string            `json:"namespace"`
 	MyNodeID           ids.NodeID        `json:"myNodeID"`
 	MyIPPort           ips.DynamicIPPort `json:"myIP"`
@@ -127,9 +157,20 @@ type Config struct {
 	AllowPrivateIPs    bool              `json:"allowPrivateIPs"`
 
 	// The compression type to use when compressing outbound messages.
-	// Assumes all peers support this compression type.
+	// Assumes all peers that don't report the compression types they accept
+	// support this compression type.
 	CompressionType compression.Type `json:"compressionType"`
 
+	// CompressionTypes are the compression types accepted from peers, in
+	// order of preference. The messages sent to a peer are compressed with
+	// the first of them that the peer also accepts. If empty, only
+	// [CompressionType] is accepted.
+	CompressionTypes []compression.Type `json:"compressionTypes"`
+
+	// CompressionZstdLevel is the level outbound messages are compressed
+	// with when they are compressed with zstd.
+	CompressionZstdLevel int `json:"compressionZstdLevel"`
+
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
 
@@ -137,6 +178,10 @@ type Config struct {
 	TrackedSubnets set.Set[ids.ID] `json:"-"`
 	Beacons        validators.Set  `json:"-"`
 
+	// TrafficShaping maps Subnets to how their outbound messages are padded
+	// and delayed. Subnets that aren't specified aren't shaped.
+	TrafficShaping map[ids.ID]subnets.TrafficShapingConfig `json:"-"`
+
 	// Validators are the current validators in the Avalanche network
 	Validators validators.Manager `json:"-"`
 
@@ -169,6 +214,15 @@ type Config struct {
 	// (there is one buffer per peer)
 	PeerWriteBufferSize int `json:"peerWriteBufferSize"`
 
+	// Number of goroutines shared by all peers to send pings and gossip peer
+	// lists. If 0, each peer uses a dedicated goroutine. Peers always read and
+	// write messages on dedicated goroutines.
+	PeerWorkerPoolSize int `json:"peerWorkerPoolSize"`
+
+	// If true, the payloads of inbound messages are passed to the chains
+	// without being copied out of the buffer the message was read into.
+	ZeroCopyPayloads bool `json:"zeroCopyPayloads"`
+
 	// Tracks the CPU/disk usage caused by processing messages of each peer.
 	ResourceTracker tracker.ResourceTracker `json:"-"`
 
@@ -182,4 +236,24 @@ type Config struct {
 
 	// Tracks which validators have been sent to which peers
 	GossipTracker peer.GossipTracker `json:"-"`
+
+	// If non-nil, records the messages exchanged with peers when requested
+	// through the admin API.
+	Capturer capture.Capturer `json:"-"`
+
+	// RetiringAnnouncementEnabled makes the node announce to its peers that it
+	// is shutting down, so that they stop relying on it before it disconnects.
+	RetiringAnnouncementEnabled bool `json:"retiringAnnouncementEnabled"`
+
+	// If non-nil, the validators that announced that they are shutting down
+	// are added to RetiringNodes.
+	RetiringNodes *benchlist.RetiringSet `json:"-"`
+
+	// ReputationConfig specifies how the misbehaviors of peers are penalized.
+	ReputationConfig reputation.Config `json:"reputationConfig"`
+
+	// If non-nil, records the misbehaviors of peers. Inbound connections
+	// from peers whose penalty is too high are dropped, and they aren't
+	// gossiped to.
+	Reputations reputation.Store `json:"-"`
 }
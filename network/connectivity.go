@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+var (
+	errNotConnected = errors.New("not connected")
+	errUnknownIP    = errors.New("IP is unknown")
+)
+
+// ValidatorConnectivity describes whether a validator of a subnet is reachable
+// from this node.
+type ValidatorConnectivity struct {
+	NodeID ids.NodeID  `json:"nodeID"`
+	Weight json.Uint64 `json:"weight"`
+	// Connected is true if this node has finished the handshake with the
+	// validator.
+	Connected bool `json:"connected"`
+	// IP is the IP this node is connected, or attempting to connect, to the
+	// validator at. Empty if the IP of the validator isn't known.
+	IP string `json:"ip,omitempty"`
+	// Version is the version the validator reported during the handshake.
+	Version string `json:"version,omitempty"`
+	// TracksSubnet is true if the validator reported tracking the subnet
+	// during the handshake.
+	TracksSubnet bool `json:"tracksSubnet"`
+	// Latency is the round trip time of a ping sent to the validator. Zero if
+	// the validator didn't respond.
+	Latency time.Duration `json:"latency"`
+	// Error describes why the validator couldn't be reached, if it couldn't.
+	Error string `json:"error,omitempty"`
+}
+
+func (n *network) SubnetConnectivity(ctx context.Context, subnetID ids.ID) ([]ValidatorConnectivity, error) {
+	if subnetID != constants.PrimaryNetworkID && !n.config.TrackedSubnets.Contains(subnetID) {
+		return nil, errNotTracked
+	}
+
+	validators, ok := n.config.Validators.Get(subnetID)
+	if !ok {
+		return nil, errSubnetNotExist
+	}
+
+	var (
+		vdrs    = validators.Map()
+		results = make([]ValidatorConnectivity, 0, len(vdrs))
+		// peers[i] is the peer that results[i] describes, or nil if the
+		// validator isn't connected.
+		peers = make([]peer.Peer, 0, len(vdrs))
+	)
+
+	n.peersLock.RLock()
+	for nodeID, vdr := range vdrs {
+		if nodeID == n.config.MyNodeID {
+			continue
+		}
+
+		result := ValidatorConnectivity{
+			NodeID: nodeID,
+			Weight: json.Uint64(vdr.Weight),
+		}
+		p, connected := n.connectedPeers.GetByID(nodeID)
+		switch {
+		case connected:
+			result.Connected = true
+			if ip := p.IP(); !ip.IsZero() {
+				result.IP = ip.IPPort.String()
+			}
+			result.Version = p.Version().String()
+			trackedSubnets := p.TrackedSubnets()
+			result.TracksSubnet = subnetID == constants.PrimaryNetworkID || trackedSubnets.Contains(subnetID)
+		case n.peerIPs[nodeID] != nil:
+			result.IP = n.peerIPs[nodeID].IPPort.String()
+			result.Error = errNotConnected.Error()
+		default:
+			result.Error = errUnknownIP.Error()
+		}
+		results = append(results, result)
+		peers = append(peers, p)
+	}
+	n.peersLock.RUnlock()
+
+	// Ping all the connected validators at once so that slow validators don't
+	// delay the results of the others.
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		if p == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *ValidatorConnectivity, p peer.Peer) {
+			defer wg.Done()
+
+			latency, err := p.Ping(ctx)
+			if err != nil {
+				result.Error = err.Error()
+				return
+			}
+			result.Latency = latency
+		}(&results[i], p)
+	}
+	wg.Wait()
+
+	// Report the heaviest validators first, as they matter the most for
+	// consensus.
+	slices.SortFunc(results, func(a, b ValidatorConnectivity) int {
+		switch {
+		case a.Weight > b.Weight:
+			return -1
+		case a.Weight < b.Weight:
+			return 1
+		case a.NodeID.Less(b.NodeID):
+			return -1
+		case b.NodeID.Less(a.NodeID):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return results, nil
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+var _ heap.Interface = (*dialQueue)(nil)
+
+// dialScheduler limits the number of outbound connection attempts that are in
+// progress at once. Once the limit is reached, the attempts wait for their turn
+// in order of the stake of the peer being dialed, so that after a mass
+// disconnect the node reconnects to the heaviest validators, and regains
+// quorum, first. Among peers with the same stake, the peer that disconnected
+// most recently goes first, as it is the most likely to still be reachable.
+type dialScheduler struct {
+	// If <= 0, attempts never wait
+	maxActive int
+
+	lock sync.Mutex
+	// Number of attempts in progress
+	active int
+	// Attempts waiting for their turn. Only non-empty if [active] ==
+	// [maxActive].
+	pending dialQueue
+}
+
+// Data about an attempt waiting for its turn
+type dialRequest struct {
+	weight         uint64
+	disconnectedAt time.Time
+	// Closed once the attempt may start
+	ready chan struct{}
+	// Index in the heap, or -1 once popped
+	index int
+}
+
+func newDialScheduler(maxActive int) *dialScheduler {
+	return &dialScheduler{
+		maxActive: maxActive,
+	}
+}
+
+// acquire blocks until an attempt to dial a peer with [weight], which
+// disconnected at [disconnectedAt], may start. [disconnectedAt] is the zero
+// time if the peer was never connected.
+//
+// Returns false if [ctx] is done or [cancel] is closed before the attempt may
+// start. Otherwise, release must be called once the attempt finished.
+func (s *dialScheduler) acquire(
+	ctx context.Context,
+	cancel <-chan struct{},
+	weight uint64,
+	disconnectedAt time.Time,
+) bool {
+	s.lock.Lock()
+	if s.maxActive <= 0 || s.active < s.maxActive {
+		s.active++
+		s.lock.Unlock()
+		return true
+	}
+
+	request := &dialRequest{
+		weight:         weight,
+		disconnectedAt: disconnectedAt,
+		ready:          make(chan struct{}),
+	}
+	heap.Push(&s.pending, request)
+	s.lock.Unlock()
+
+	select {
+	case <-request.ready:
+		return true
+	case <-ctx.Done():
+	case <-cancel:
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if request.index < 0 {
+		// The attempt was granted its turn while it was being canceled, so
+		// the turn is passed on.
+		s.releaseLocked()
+		return false
+	}
+	heap.Remove(&s.pending, request.index)
+	return false
+}
+
+// release marks an attempt that was allowed to start as finished.
+func (s *dialScheduler) release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.releaseLocked()
+}
+
+func (s *dialScheduler) releaseLocked() {
+	if s.pending.Len() == 0 {
+		s.active--
+		return
+	}
+
+	// Hand the turn over to the highest priority waiting attempt.
+	request := heap.Pop(&s.pending).(*dialRequest)
+	close(request.ready)
+}
+
+// Max heap of the attempts waiting for their turn
+type dialQueue []*dialRequest
+
+func (q dialQueue) Len() int {
+	return len(q)
+}
+
+func (q dialQueue) Less(i, j int) bool {
+	if q[i].weight != q[j].weight {
+		return q[i].weight > q[j].weight
+	}
+	return q[i].disconnectedAt.After(q[j].disconnectedAt)
+}
+
+func (q dialQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *dialQueue) Push(x interface{}) {
+	request := x.(*dialRequest)
+	request.index = len(*q)
+	*q = append(*q, request)
+}
+
+func (q *dialQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	request := old[n-1]
+	old[n-1] = nil
+	request.index = -1
+	*q = old[:n-1]
+	return request
+}
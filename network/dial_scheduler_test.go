@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialSchedulerUnlimited(t *testing.T) {
+	require := require.New(t)
+
+	s := newDialScheduler(0)
+	for i := 0; i < 10; i++ {
+		require.True(s.acquire(context.Background(), nil, 0, time.Time{}))
+	}
+	for i := 0; i < 10; i++ {
+		s.release()
+	}
+	require.Zero(s.active)
+}
+
+func TestDialSchedulerPriority(t *testing.T) {
+	require := require.New(t)
+
+	s := newDialScheduler(1)
+	require.True(s.acquire(context.Background(), nil, 0, time.Time{}))
+
+	now := time.Now()
+	requests := []struct {
+		name           string
+		weight         uint64
+		disconnectedAt time.Time
+	}{
+		{
+			name: "never connected non-validator",
+		},
+		{
+			name:           "light validator",
+			weight:         5,
+			disconnectedAt: now,
+		},
+		{
+			name:           "heavy validator disconnected long ago",
+			weight:         10,
+			disconnectedAt: now.Add(-time.Hour),
+		},
+		{
+			name:           "heavy validator disconnected recently",
+			weight:         10,
+			disconnectedAt: now,
+		},
+	}
+
+	started := make(chan string, len(requests))
+	for _, request := range requests {
+		request := request
+		go func() {
+			if s.acquire(context.Background(), nil, request.weight, request.disconnectedAt) {
+				started <- request.name
+			}
+		}()
+	}
+	require.Eventually(func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return s.pending.Len() == len(requests)
+	}, time.Second, time.Millisecond)
+
+	expectedOrder := []string{
+		"heavy validator disconnected recently",
+		"heavy validator disconnected long ago",
+		"light validator",
+		"never connected non-validator",
+	}
+	for _, expected := range expectedOrder {
+		s.release()
+		require.Equal(expected, <-started)
+	}
+	s.release()
+	require.Zero(s.active)
+}
+
+func TestDialSchedulerCancel(t *testing.T) {
+	require := require.New(t)
+
+	s := newDialScheduler(1)
+	require.True(s.acquire(context.Background(), nil, 0, time.Time{}))
+
+	cancel := make(chan struct{})
+	close(cancel)
+	require.False(s.acquire(context.Background(), cancel, 10, time.Time{}))
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+	require.False(s.acquire(ctx, nil, 10, time.Time{}))
+
+	// The canceled attempts no longer wait for their turn.
+	require.Zero(s.pending.Len())
+	s.release()
+	require.Zero(s.active)
+}
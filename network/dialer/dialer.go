@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/network/transport"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -26,15 +27,18 @@ type Dialer interface {
 }
 
 type dialer struct {
-	dialer    net.Dialer
-	log       logging.Logger
-	network   string
-	throttler throttling.DialThrottler
+	transport        transport.Transport
+	timeout          time.Duration
+	log              logging.Logger
+	throttler        throttling.DialThrottler
+	failureThrottler throttling.DialFailureThrottler
 }
 
 type Config struct {
 	ThrottleRps       uint32        `json:"throttleRps"`
 	ConnectionTimeout time.Duration `json:"connectionTimeout"`
+
+	FailureThrottlerConfig throttling.DialFailureThrottlerConfig `json:"failureThrottlerConfig"`
 }
 
 // NewDialer returns a new Dialer that calls net.Dial with the provided network.
@@ -42,7 +46,16 @@ type Config struct {
 // [dialerConfig.connectionTimeout] gives the timeout when dialing an IP.
 // [dialerConfig.throttleRps] gives the max number of outgoing connection attempts/second.
 // If [dialerConfig.throttleRps] == 0, outgoing connections aren't rate-limited.
+// [dialerConfig.FailureThrottlerConfig] configures how destinations that
+// repeatedly fail to be dialed are backed off. Penalized destinations don't
+// consume the outgoing connection attempts of other destinations.
 func NewDialer(network string, dialerConfig Config, log logging.Logger) Dialer {
+	return NewTransportDialer(transport.NewTCP(network), dialerConfig, log)
+}
+
+// NewTransportDialer returns a new Dialer that connects over [t]. The
+// [dialerConfig] is applied as described by NewDialer.
+func NewTransportDialer(t transport.Transport, dialerConfig Config, log logging.Logger) Dialer {
 	var throttler throttling.DialThrottler
 	if dialerConfig.ThrottleRps <= 0 {
 		throttler = throttling.NewNoDialThrottler()
@@ -51,27 +64,45 @@ func NewDialer(network string, dialerConfig Config, log logging.Logger) Dialer {
 	}
 	log.Debug(
 		"creating dialer",
+		zap.String("transport", t.Name()),
 		zap.Uint32("throttleRPS", dialerConfig.ThrottleRps),
 		zap.Duration("dialTimeout", dialerConfig.ConnectionTimeout),
+		zap.Int("failurePenaltyThreshold", dialerConfig.FailureThrottlerConfig.Threshold),
 	)
 	return &dialer{
-		dialer:    net.Dialer{Timeout: dialerConfig.ConnectionTimeout},
-		log:       log,
-		network:   network,
-		throttler: throttler,
+		transport:        t,
+		timeout:          dialerConfig.ConnectionTimeout,
+		log:              log,
+		throttler:        throttler,
+		failureThrottler: throttling.NewDialFailureThrottler(dialerConfig.FailureThrottlerConfig),
 	}
 }
 
 func (d *dialer) Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
+	dest := ip.String()
+	if err := d.failureThrottler.Acquire(dest); err != nil {
+		return nil, err
+	}
 	if err := d.throttler.Acquire(ctx); err != nil {
 		return nil, err
 	}
 	d.log.Verbo("dialing",
 		zap.Stringer("ip", ip),
 	)
-	conn, err := d.dialer.DialContext(ctx, d.network, ip.String())
+	conn, err := d.transport.Dial(ctx, dest, d.timeout)
 	if err != nil {
+		// Giving up on the dial isn't a failure of the destination.
+		if ctx.Err() == nil {
+			if penalty := d.failureThrottler.Failed(dest); penalty > 0 {
+				d.log.Debug("penalizing unreachable destination",
+					zap.Stringer("ip", ip),
+					zap.Duration("penalty", penalty),
+					zap.Error(err),
+				)
+			}
+		}
 		return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
 	}
+	d.failureThrottler.Succeeded(dest)
 	return conn, nil
 }
@@ -8,11 +8,13 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -74,3 +76,40 @@ func TestDialerCancelDial(t *testing.T) {
 	close(done) // stop listener goroutine
 	_ = l.Close()
 }
+
+// Test that destinations that repeatedly fail to be dialed are penalized
+func TestDialerPenalizesUnreachableDestination(t *testing.T) {
+	require := require.New(t)
+
+	// Reserve a port and close the listener so that dials are refused.
+	l, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(err)
+	port, err := strconv.Atoi(strings.Split(l.Addr().String(), ":")[1])
+	require.NoError(err)
+	require.NoError(l.Close())
+
+	deadIP := ips.IPPort{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: uint16(port),
+	}
+
+	dialer := NewDialer(
+		"tcp",
+		Config{
+			ThrottleRps:       10,
+			ConnectionTimeout: 30 * time.Second,
+			FailureThrottlerConfig: throttling.DialFailureThrottlerConfig{
+				Threshold:      1,
+				InitialPenalty: time.Hour,
+				MaxPenalty:     time.Hour,
+			},
+		},
+		logging.NoLog{},
+	)
+
+	_, err = dialer.Dial(context.Background(), deadIP)
+	require.ErrorIs(err, syscall.ECONNREFUSED)
+
+	_, err = dialer.Dial(context.Background(), deadIP)
+	require.ErrorIs(err, throttling.ErrDestinationPenalized)
+}
@@ -29,6 +29,10 @@ type metrics struct {
 	inboundConnRateLimited          prometheus.Counter
 	inboundConnAllowed              prometheus.Counter
 	tlsConnRejected                 prometheus.Counter
+	tlsFullHandshakes               prometheus.Counter
+	tlsResumedHandshakes            prometheus.Counter
+	tlsNegotiatedParameters         *prometheus.CounterVec
+	tlsCipherSuiteRejected          prometheus.Counter
 	numUselessPeerListBytes         prometheus.Counter
 	nodeUptimeWeightedAverage       prometheus.Gauge
 	nodeUptimeRewardingStake        prometheus.Gauge
@@ -101,6 +105,26 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 			Name:      "tls_conn_rejected",
 			Help:      "Times this node rejected a connection due to an unsupported TLS certificate",
 		}),
+		tlsFullHandshakes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tls_full_handshakes",
+			Help:      "Times this node completed a TLS handshake that didn't resume a previous session",
+		}),
+		tlsResumedHandshakes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tls_resumed_handshakes",
+			Help:      "Times this node completed a TLS handshake that resumed a previous session",
+		}),
+		tlsNegotiatedParameters: peer.NewNegotiatedParametersMetric(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tls_negotiated_handshakes",
+			Help:      "Times this node completed a TLS handshake, by negotiated TLS version and cipher suite",
+		}),
+		tlsCipherSuiteRejected: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tls_cipher_suite_rejected",
+			Help:      "Times this node rejected a connection due to a cipher suite that isn't accepted",
+		}),
 		numUselessPeerListBytes: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "num_useless_peerlist_bytes",
@@ -160,6 +184,10 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 		registerer.Register(m.acceptFailed),
 		registerer.Register(m.inboundConnAllowed),
 		registerer.Register(m.tlsConnRejected),
+		registerer.Register(m.tlsFullHandshakes),
+		registerer.Register(m.tlsResumedHandshakes),
+		registerer.Register(m.tlsNegotiatedParameters),
+		registerer.Register(m.tlsCipherSuiteRejected),
 		registerer.Register(m.numUselessPeerListBytes),
 		registerer.Register(m.inboundConnRateLimited),
 		registerer.Register(m.nodeUptimeWeightedAverage),
@@ -34,6 +34,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -74,6 +75,11 @@ type Network interface {
 
 	peer.Network
 
+	// AnnounceRetiring notifies the connected peers that this node is shutting
+	// down, so that they stop relying on it before it disconnects. It is a
+	// no-op if retiring announcements are disabled.
+	AnnounceRetiring()
+
 	// StartClose this network and all existing connections it has. Calling
 	// StartClose multiple times is handled gracefully.
 	StartClose()
@@ -100,6 +106,19 @@ type Network interface {
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
+
+	// SubnetConnectivity pings the current validators of [subnetID] that this
+	// node is connected to, and reports whether each validator is reachable.
+	SubnetConnectivity(ctx context.Context, subnetID ids.ID) ([]ValidatorConnectivity, error)
+
+	// ClockOffset returns the estimated offset of the network time from the
+	// local clock, and the number of connected validators it was estimated from.
+	ClockOffset() (time.Duration, int)
+
+	// PeerStats returns the bandwidth used by [nodeID] since it connected, by
+	// message op. Returns false if no messages were exchanged with [nodeID]
+	// since it connected.
+	PeerStats(nodeID ids.NodeID) (PeerStats, bool)
 }
 
 type UptimeResult struct {
@@ -116,12 +135,36 @@ type UptimeResult struct {
 	// counted (40*weight) in WeightedAveragePercentage but not in
 	// RewardingStakePercentage since 40 < 85
 	WeightedAveragePercentage float64
+
+	// ReportingStakePercentage shows what percent of network stake reported
+	// an uptime for this node. This node's own stake is always included.
+	// Validators that haven't reported an uptime are counted as perceiving
+	// this node to be offline, so the other percentages are pessimistic while
+	// ReportingStakePercentage is low.
+	ReportingStakePercentage float64
+
+	// Local is the uptime of this node as calculated by this node. Nil if this
+	// node can't calculate its uptime yet, such as while the P-chain is
+	// bootstrapping.
+	Local *LocalUptime
+}
+
+type LocalUptime struct {
+	// Percentage is the percent of its staking period that this node has been
+	// online for.
+	Percentage float64
+
+	// TimeToThreshold is how long this node can be offline, starting now,
+	// before its uptime over its whole staking period drops below the uptime
+	// requirement.
+	TimeToThreshold time.Duration
 }
 
 type network struct {
 	config     *Config
 	peerConfig *peer.Config
 	metrics    *metrics
+	bandwidth  *PeerBandwidth
 
 	outboundMsgThrottler throttling.OutboundMsgThrottler
 
@@ -131,10 +174,18 @@ type network struct {
 	listener net.Listener
 	// Makes new outbound connections
 	dialer dialer.Dialer
+	// Orders the outbound connection attempts
+	dialScheduler *dialScheduler
+	// The validators of the primary network, whose stake prioritizes the
+	// outbound connection attempts
+	primaryNetworkValidators validators.Set
 	// Does TLS handshakes for inbound connections
 	serverUpgrader peer.Upgrader
 	// Does TLS handshakes for outbound connections
 	clientUpgrader peer.Upgrader
+	// Rotates the keys used to encrypt TLS session tickets. Nil if session
+	// resumption is disabled.
+	sessionTicketKeys *peer.SessionTicketKeyRotator
 
 	// ensures the close of the network only happens once.
 	closeOnce sync.Once
@@ -251,11 +302,23 @@ func NewNetwork(
 		return nil, fmt.Errorf("initializing network metrics failed with: %w", err)
 	}
 
+	bandwidth, err := NewPeerBandwidth(config.Namespace, metricsRegisterer)
+	if err != nil {
+		return nil, fmt.Errorf("initializing peer bandwidth failed with: %w", err)
+	}
+
+	compressionTypes := config.CompressionTypes
+	if len(compressionTypes) == 0 {
+		compressionTypes = []compression.Type{config.CompressionType}
+	}
+
 	peerConfig := &peer.Config{
-		ReadBufferSize:  config.PeerReadBufferSize,
-		WriteBufferSize: config.PeerWriteBufferSize,
-		Metrics:         peerMetrics,
-		MessageCreator:  msgCreator,
+		ReadBufferSize:   config.PeerReadBufferSize,
+		WriteBufferSize:  config.PeerWriteBufferSize,
+		Metrics:          peerMetrics,
+		MessageCreator:   msgCreator,
+		CompressionTypes: compressionTypes,
+		CompressionType:  config.CompressionType,
 
 		Log:                  log,
 		InboundMsgThrottler:  inboundMsgThrottler,
@@ -271,6 +334,41 @@ func NewNetwork(
 		ResourceTracker:      config.ResourceTracker,
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey),
+		Capturer:             config.Capturer,
+		BufferPool:           message.NewBufferPool(),
+		ZeroCopyPayloads:     config.ZeroCopyPayloads,
+		NetworkClock:         peer.NewNetworkClock(primaryNetworkValidators, config.MaxClockDifference),
+		Bandwidth:            bandwidth,
+		Reputations:          config.Reputations,
+	}
+	if config.PeerWorkerPoolSize > 0 {
+		peerConfig.WorkerPool, err = peer.NewWorkerPool(config.PeerWorkerPoolSize, config.PingFrequency)
+		if err != nil {
+			return nil, fmt.Errorf("initializing peer worker pool failed with: %w", err)
+		}
+	}
+
+	if config.TLSMinVersion != 0 {
+		config.TLSConfig.MinVersion = config.TLSMinVersion
+	}
+	config.TLSConfig.CipherSuites = config.TLSCipherSuites
+
+	var sessionTicketKeys *peer.SessionTicketKeyRotator
+	if config.TLSSessionResumptionEnabled {
+		sessionTicketKeys, err = peer.NewSessionTicketKeyRotator(config.TLSConfig, config.TLSSessionTicketKeys)
+		if err != nil {
+			return nil, fmt.Errorf("initializing session ticket keys failed with: %w", err)
+		}
+	} else {
+		config.TLSConfig.SessionTicketsDisabled = true
+		config.TLSConfig.ClientSessionCache = nil
+	}
+	upgraderMetrics := peer.UpgraderMetrics{
+		InvalidCerts:         metrics.tlsConnRejected,
+		FullHandshakes:       metrics.tlsFullHandshakes,
+		ResumedHandshakes:    metrics.tlsResumedHandshakes,
+		NegotiatedParameters: metrics.tlsNegotiatedParameters,
+		RejectedCipherSuites: metrics.tlsCipherSuiteRejected,
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
@@ -278,13 +376,17 @@ func NewNetwork(
 		config:               config,
 		peerConfig:           peerConfig,
 		metrics:              metrics,
+		bandwidth:            bandwidth,
 		outboundMsgThrottler: outboundMsgThrottler,
 
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
 		listener:                    listener,
 		dialer:                      dialer,
-		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected),
-		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected),
+		dialScheduler:               newDialScheduler(config.MaxConcurrentDials),
+		primaryNetworkValidators:    primaryNetworkValidators,
+		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, upgraderMetrics),
+		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, upgraderMetrics),
+		sessionTicketKeys:           sessionTicketKeys,
 
 		onCloseCtx:       onCloseCtx,
 		onCloseCtxCancel: cancel,
@@ -312,7 +414,7 @@ func (n *network) Send(msg message.OutboundMessage, nodeIDs set.Set[ids.NodeID],
 		msg.Op(),
 		nodeIDs.Len()-len(peers),
 	)
-	return n.send(msg, peers)
+	return n.send(n.shape(msg, subnetID), peers)
 }
 
 func (n *network) Gossip(
@@ -324,7 +426,17 @@ func (n *network) Gossip(
 	allower subnets.Allower,
 ) set.Set[ids.NodeID] {
 	peers := n.samplePeers(subnetID, numValidatorsToSend, numNonValidatorsToSend, numPeersToSend, allower)
-	return n.send(msg, peers)
+	return n.send(n.shape(msg, subnetID), peers)
+}
+
+// shape marks [msg] to be padded and delayed according to the traffic shaping
+// config of [subnetID].
+func (n *network) shape(msg message.OutboundMessage, subnetID ids.ID) message.OutboundMessage {
+	config, ok := n.config.TrafficShaping[subnetID]
+	if !ok {
+		return msg
+	}
+	return peer.NewShapedMessage(msg, config)
 }
 
 // HealthCheck returns information about several network layer health checks.
@@ -453,6 +565,11 @@ func (n *network) Connected(nodeID ids.NodeID) {
 	n.connectedPeers.Add(peer)
 	n.peersLock.Unlock()
 
+	// A node that reconnects is no longer shutting down.
+	if n.config.RetiringNodes != nil {
+		n.config.RetiringNodes.Remove(nodeID)
+	}
+
 	n.metrics.markConnected(peer)
 
 	peerVersion := peer.Version()
@@ -643,6 +760,26 @@ func (n *network) MarkTracked(peerID ids.NodeID, ips []*p2p.PeerAck) error {
 	return nil
 }
 
+// Retiring is called after the peer announced that it is shutting down.
+//
+// Only validators are marked as retiring, as only validators can be benched.
+// The mark is kept after the peer disconnects, so that the requests that fail
+// due to the disconnection aren't held against it, and is removed once the
+// peer reconnects.
+func (n *network) Retiring(nodeID ids.NodeID) {
+	if n.config.RetiringNodes == nil {
+		return
+	}
+	if !validators.Contains(n.config.Validators, constants.PrimaryNetworkID, nodeID) {
+		return
+	}
+
+	n.peerConfig.Log.Info("validator is retiring",
+		zap.Stringer("nodeID", nodeID),
+	)
+	n.config.RetiringNodes.Add(nodeID)
+}
+
 // Disconnected is called after the peer's handling has been shutdown.
 // It is not guaranteed that [Connected] was previously called with [nodeID].
 // It is guaranteed that [Connected] will not be called with [nodeID] after this
@@ -667,6 +804,7 @@ func (n *network) Disconnected(nodeID ids.NodeID) {
 	if connected {
 		n.disconnectedFromConnected(peer, nodeID)
 	}
+	n.bandwidth.Disconnected(nodeID)
 }
 
 func (n *network) Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error) {
@@ -777,7 +915,7 @@ func (n *network) Dispatch() error {
 				zap.Stringer("peerIP", ip),
 			)
 
-			if err := n.upgrade(conn, n.serverUpgrader); err != nil {
+			if err := n.upgrade(conn, n.serverUpgrader, true /*=inbound*/); err != nil {
 				n.peerConfig.Log.Verbo("failed to upgrade connection",
 					zap.String("direction", "inbound"),
 					zap.Error(err),
@@ -899,13 +1037,23 @@ func (n *network) samplePeers(
 	return n.connectedPeers.Sample(
 		numValidatorsToSample+numNonValidatorsToSample+numPeersToSample,
 		func(p peer.Peer) bool {
+			// Don't gossip to peers that are shutting down
+			if p.Retiring() {
+				return false
+			}
+
+			peerID := p.ID()
+			// Don't gossip to peers that misbehaved
+			if n.hasBadReputation(peerID) {
+				return false
+			}
+
 			// Only return peers that are tracking [subnetID]
 			trackedSubnets := p.TrackedSubnets()
 			if subnetID != constants.PrimaryNetworkID && !trackedSubnets.Contains(subnetID) {
 				return false
 			}
 
-			peerID := p.ID()
 			isValidator := subnetValidators.Contains(peerID)
 			// check if the peer is allowed to connect to the subnet
 			if !allower.IsAllowed(peerID, isValidator) {
@@ -988,6 +1136,7 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	if n.wantsConnection(nodeID) {
 		prevIP := n.peerIPs[nodeID]
 		tracked := newTrackedIP(prevIP.IPPort)
+		tracked.disconnectedAt = n.peerConfig.Clock.Time()
 		n.trackedIPs[nodeID] = tracked
 		n.dial(n.onCloseCtx, nodeID, tracked)
 	} else {
@@ -1141,7 +1290,14 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 				continue
 			}
 
+			// Wait for our turn to dial, so that the heaviest validators are
+			// reconnected to first when many peers need to be dialed.
+			weight := n.primaryNetworkValidators.GetWeight(nodeID)
+			if !n.dialScheduler.acquire(ctx, ip.onStopTracking, weight, ip.disconnectedAt) {
+				continue
+			}
 			conn, err := n.dialer.Dial(ctx, ip.ip)
+			n.dialScheduler.release()
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to reach peer, attempting again",
@@ -1156,7 +1312,7 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 				zap.Stringer("peerIP", ip.ip.IP),
 			)
 
-			err = n.upgrade(conn, n.clientUpgrader)
+			err = n.upgrade(conn, n.clientUpgrader, false /*=inbound*/)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to upgrade, attempting again",
@@ -1178,7 +1334,7 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 // If the connection is desired by the node, then the resulting upgraded
 // connection will be used to create a new peer. Otherwise the connection will
 // be immediately closed.
-func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
+func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader, inbound bool) error {
 	upgradeTimeout := n.peerConfig.Clock.Time().Add(n.config.ReadHandshakeTimeout)
 	if err := conn.SetReadDeadline(upgradeTimeout); err != nil {
 		_ = conn.Close()
@@ -1223,6 +1379,16 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		return nil
 	}
 
+	if inbound && n.hasBadReputation(nodeID) {
+		_ = tlsConn.Close()
+		n.peerConfig.Log.Debug(
+			"dropping connection",
+			zap.String("reason", "peer has a bad reputation"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+
 	n.peersLock.Lock()
 	if n.closing {
 		n.peersLock.Unlock()
@@ -1301,6 +1467,52 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	return n.connectedPeers.Info(nodeIDs)
 }
 
+func (n *network) ClockOffset() (time.Duration, int) {
+	return n.peerConfig.NetworkClock.Offset()
+}
+
+func (n *network) PeerStats(nodeID ids.NodeID) (PeerStats, bool) {
+	return n.bandwidth.PeerStats(nodeID)
+}
+
+func (n *network) AnnounceRetiring() {
+	if !n.config.RetiringAnnouncementEnabled {
+		return
+	}
+
+	unsignedRetiring := peer.UnsignedRetiring{
+		Timestamp: n.peerConfig.Clock.Unix(),
+	}
+	signedRetiring, err := unsignedRetiring.Sign(n.config.TLSKey)
+	if err != nil {
+		n.peerConfig.Log.Error("failed to sign retiring announcement",
+			zap.Error(err),
+		)
+		return
+	}
+
+	msg, err := n.peerConfig.MessageCreator.Retiring(
+		signedRetiring.Timestamp,
+		signedRetiring.Signature,
+	)
+	if err != nil {
+		n.peerConfig.Log.Error("failed to create message",
+			zap.Stringer("messageOp", message.RetiringOp),
+			zap.Error(err),
+		)
+		return
+	}
+
+	n.peersLock.RLock()
+	peers := n.connectedPeers.Sample(n.connectedPeers.Len(), peer.NoPrecondition)
+	n.peersLock.RUnlock()
+
+	sentTo := n.send(msg, peers)
+	n.peerConfig.Log.Info("announced that the node is retiring",
+		zap.Int("numPeers", sentTo.Len()),
+	)
+}
+
 func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
@@ -1332,6 +1544,11 @@ func (n *network) StartClose() {
 			peer, _ := n.connectedPeers.GetByIndex(i)
 			peer.StartClose()
 		}
+
+		// The pool will exit once all of the peers using it have closed.
+		if n.peerConfig.WorkerPool != nil {
+			n.peerConfig.WorkerPool.Close()
+		}
 	})
 }
 
@@ -1354,6 +1571,10 @@ func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
 		totalWeight          = float64(validators.Weight())
 		totalWeightedPercent = 100 * float64(myStake)
 		rewardingStake       = float64(myStake)
+		reportingStake       = float64(myStake)
+		// The local uptime is calculated before grabbing [peersLock] as the
+		// calculator grabs the P-chain's lock.
+		localUptime = n.localUptime(subnetID)
 	)
 
 	n.peersLock.RLock()
@@ -1369,12 +1590,14 @@ func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
 			continue
 		}
 
+		weightFloat := float64(weight)
 		observedUptime, exist := peer.ObservedUptime(subnetID)
-		if !exist {
+		if exist {
+			reportingStake += weightFloat
+		} else {
 			observedUptime = 0
 		}
 		percent := float64(observedUptime)
-		weightFloat := float64(weight)
 		totalWeightedPercent += percent * weightFloat
 
 		// if this peer thinks we're above requirement add the weight
@@ -1387,9 +1610,43 @@ func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
 	return UptimeResult{
 		WeightedAveragePercentage: gomath.Abs(totalWeightedPercent / totalWeight),
 		RewardingStakePercentage:  gomath.Abs(100 * rewardingStake / totalWeight),
+		ReportingStakePercentage:  gomath.Abs(100 * reportingStake / totalWeight),
+		Local:                     localUptime,
 	}, nil
 }
 
+// localUptime returns the uptime of this node on [subnetID] as calculated by
+// this node, or nil if it can't be calculated.
+func (n *network) localUptime(subnetID ids.ID) *LocalUptime {
+	percent, err := n.config.UptimeCalculator.CalculateUptimePercent(n.config.MyNodeID, subnetID)
+	if err != nil {
+		n.peerConfig.Log.Debug("failed to calculate local uptime",
+			zap.Stringer("subnetID", subnetID),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	// TODO: use subnet-specific uptime requirements
+	timeToThreshold, err := n.config.UptimeCalculator.CalculateTimeToThreshold(
+		n.config.MyNodeID,
+		subnetID,
+		n.config.UptimeRequirement,
+	)
+	if err != nil {
+		n.peerConfig.Log.Debug("failed to calculate time to uptime threshold",
+			zap.Stringer("subnetID", subnetID),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	return &LocalUptime{
+		Percentage:      100 * percent,
+		TimeToThreshold: timeToThreshold,
+	}
+}
+
 func (n *network) runTimers() {
 	gossipPeerlists := time.NewTicker(n.config.PeerListGossipFreq)
 	updateUptimes := time.NewTicker(n.config.UptimeMetricFreq)
@@ -1398,10 +1655,25 @@ func (n *network) runTimers() {
 		updateUptimes.Stop()
 	}()
 
+	// If session resumption is disabled, [rotateSessionTicketKeys] is nil and
+	// will never fire.
+	var rotateSessionTicketKeys <-chan time.Time
+	if n.sessionTicketKeys != nil {
+		ticker := time.NewTicker(n.config.TLSSessionTicketKeyRotationFreq)
+		defer ticker.Stop()
+		rotateSessionTicketKeys = ticker.C
+	}
+
 	for {
 		select {
 		case <-n.onCloseCtx.Done():
 			return
+		case <-rotateSessionTicketKeys:
+			if err := n.sessionTicketKeys.Rotate(); err != nil {
+				n.peerConfig.Log.Warn("failed to rotate session ticket keys",
+					zap.Error(err),
+				)
+			}
 		case <-gossipPeerlists.C:
 			n.gossipPeerLists()
 		case <-updateUptimes.C:
@@ -1460,3 +1732,12 @@ func (n *network) getLastSent() (time.Time, bool) {
 	}
 	return time.Unix(lastSent, 0), true
 }
+
+// hasBadReputation returns true if [nodeID] misbehaved recently. Current
+// validators are exempt, so that penalties can't isolate this node from
+// consensus.
+func (n *network) hasBadReputation(nodeID ids.NodeID) bool {
+	return n.config.Reputations != nil &&
+		!validators.Contains(n.config.Validators, constants.PrimaryNetworkID, nodeID) &&
+		!n.config.Reputations.Allowed(nodeID)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/rsa"
+	"errors"
 	"net"
 	"sync"
 	"testing"
@@ -16,12 +17,15 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"go.uber.org/mock/gomock"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
@@ -39,6 +43,8 @@ import (
 )
 
 var (
+	errTest = errors.New("non-nil error")
+
 	defaultHealthConfig = HealthConfig{
 		MinConnectedPeers:            1,
 		MaxTimeSinceMsgReceived:      time.Minute,
@@ -189,6 +195,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 		prometheus.NewRegistry(),
 		"",
 		constants.DefaultNetworkCompressionType,
+		constants.DefaultNetworkCompressionZstdLevel,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -632,3 +639,116 @@ func TestDialDeletesNonValidators(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestSubnetConnectivity(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil, nil})
+
+	net0 := networks[0].(*network)
+	_, err := net0.SubnetConnectivity(context.Background(), ids.GenerateTestID())
+	require.ErrorIs(err, errNotTracked)
+
+	// Add a validator that this node has never heard of.
+	unknownNodeID := ids.GenerateTestNodeID()
+	require.NoError(validators.Add(net0.config.Validators, constants.PrimaryNetworkID, unknownNodeID, nil, ids.Empty, 2))
+
+	results, err := net0.SubnetConnectivity(context.Background(), constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Len(results, 3)
+
+	// The heaviest validator should be reported first.
+	require.Equal(unknownNodeID, results[0].NodeID)
+	require.False(results[0].Connected)
+	require.Equal(errUnknownIP.Error(), results[0].Error)
+
+	connected := set.Set[ids.NodeID]{}
+	for _, result := range results[1:] {
+		require.True(result.Connected)
+		require.True(result.TracksSubnet)
+		require.Empty(result.Error)
+		require.NotEmpty(result.Version)
+		require.Positive(result.Latency)
+		connected.Add(result.NodeID)
+	}
+	require.Equal(set.Of(nodeIDs[1:]...), connected)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestAnnounceRetiring(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil, nil})
+
+	net0 := networks[0].(*network)
+	net1 := networks[1].(*network)
+	net2 := networks[2].(*network)
+	net1.config.RetiringNodes = benchlist.NewRetiringSet()
+	net2.config.RetiringNodes = benchlist.NewRetiringSet()
+
+	// Announcements are disabled by default.
+	net0.AnnounceRetiring()
+
+	net0.config.RetiringAnnouncementEnabled = true
+	net1.AnnounceRetiring()
+	net0.AnnounceRetiring()
+
+	require.Eventually(
+		func() bool {
+			return net1.config.RetiringNodes.Contains(nodeIDs[0]) &&
+				net2.config.RetiringNodes.Contains(nodeIDs[0])
+		},
+		10*time.Second,
+		10*time.Millisecond,
+	)
+	require.Equal([]ids.NodeID{nodeIDs[0]}, net2.config.RetiringNodes.List())
+
+	// Retiring peers aren't gossiped to.
+	peers := net1.samplePeers(constants.PrimaryNetworkID, 0, 0, len(nodeIDs), subnets.NoOpAllower)
+	require.Len(peers, 1)
+	require.Equal(nodeIDs[2], peers[0].ID())
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestNodeUptimeLocal(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+
+	net0 := networks[0].(*network)
+	myNodeID := net0.config.MyNodeID
+	calculator := uptime.NewMockCalculator(ctrl)
+	net0.config.UptimeCalculator = calculator
+
+	calculator.EXPECT().CalculateUptimePercent(myNodeID, constants.PrimaryNetworkID).Return(.9, nil)
+	calculator.EXPECT().CalculateTimeToThreshold(myNodeID, constants.PrimaryNetworkID, .8).Return(12*time.Second, nil)
+	result, err := net0.NodeUptime(constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(&LocalUptime{
+		Percentage:      90,
+		TimeToThreshold: 12 * time.Second,
+	}, result.Local)
+
+	// The uptime reported by peers is still returned if the local uptime can't
+	// be calculated.
+	calculator.EXPECT().CalculateUptimePercent(myNodeID, constants.PrimaryNetworkID).Return(0.0, errTest)
+	result, err = net0.NodeUptime(constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Nil(result.Local)
+	require.Positive(result.WeightedAveragePercentage)
+	require.Positive(result.ReportingStakePercentage)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
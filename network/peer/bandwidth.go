@@ -0,0 +1,19 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+)
+
+// BandwidthTracker is notified of the messages sent to and received from
+// peers.
+//
+// [bytes] is the number of bytes the message occupied on the connection,
+// excluding the length prefix but including any padding.
+type BandwidthTracker interface {
+	Sent(nodeID ids.NodeID, op message.Op, bytes int)
+	Received(nodeID ids.NodeID, op message.Op, bytes int)
+}
@@ -8,11 +8,14 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/capture"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -28,6 +31,14 @@ type Config struct {
 	Metrics         *Metrics
 	MessageCreator  message.Creator
 
+	// CompressionTypes are the compression types accepted from this peer, in
+	// order of preference. Messages sent to this peer are compressed with the
+	// first of them that the peer also accepts.
+	CompressionTypes []compression.Type
+	// CompressionType is used to compress the messages sent to this peer if
+	// it doesn't report the compression types it accepts.
+	CompressionType compression.Type
+
 	Log                  logging.Logger
 	InboundMsgThrottler  throttling.InboundMsgThrottler
 	Network              Network
@@ -52,4 +63,32 @@ type Config struct {
 
 	// Signs my IP so I can send my signed IP address in the Version message
 	IPSigner *IPSigner
+
+	// If non-nil, pings and peer list gossip are sent by this pool rather
+	// than by a dedicated goroutine per peer.
+	WorkerPool *WorkerPool
+
+	// If non-nil, messages sent and received by this peer are passed to the
+	// capturer.
+	Capturer capture.Capturer
+
+	// Buffers that inbound messages are read into.
+	BufferPool *message.BufferPool
+
+	// If true, the payloads of inbound messages reference the buffer they
+	// were read into rather than being copied out of it.
+	ZeroCopyPayloads bool
+
+	// If non-nil, the messages sent to and received from this peer are
+	// reported to the bandwidth tracker.
+	Bandwidth BandwidthTracker
+
+	// If non-nil, the clock offsets of peers are recorded in the network
+	// clock, and timestamps are also accepted if they are close to the
+	// estimated network time.
+	NetworkClock *NetworkClock
+
+	// If non-nil, the handshake failures and invalid messages of this peer
+	// are recorded into its reputation.
+	Reputations reputation.Store
 }
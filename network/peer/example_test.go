@@ -9,6 +9,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -44,3 +45,45 @@ func ExampleStartTestPeer() {
 		panic(err)
 	}
 }
+
+type printingMonitorHandler struct{}
+
+func (printingMonitorHandler) PeerList(nodeID ids.NodeID, ips []*ips.ClaimedIPPort) {
+	fmt.Printf("%s gossiped %d peers\n", nodeID, len(ips))
+}
+
+func (printingMonitorHandler) Message(msg message.InboundMessage) {
+	fmt.Printf("handling %s\n", msg.Op())
+}
+
+func ExampleStartMonitor() {
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	peerIP := ips.IPPort{
+		IP:   net.IPv6loopback,
+		Port: 9651,
+	}
+	monitor, err := StartMonitor(
+		ctx,
+		peerIP,
+		constants.LocalID,
+		printingMonitorHandler{},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// The response is passed to the handler.
+	_, err = monitor.RequestAcceptedFrontier(ctx, constants.PlatformChainID, 5*time.Second)
+	if err != nil {
+		panic(err)
+	}
+
+	monitor.StartClose()
+	err = monitor.AwaitClosed(ctx)
+	if err != nil {
+		panic(err)
+	}
+}
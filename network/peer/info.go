@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/json"
 )
 
@@ -20,4 +21,36 @@ type Info struct {
 	ObservedUptime        json.Uint32            `json:"observedUptime"`
 	ObservedSubnetUptimes map[ids.ID]json.Uint32 `json:"observedSubnetUptimes"`
 	TrackedSubnets        []ids.ID               `json:"trackedSubnets"`
+	RTT                   RTTInfo                `json:"rtt"`
+	// ClockOffset is how far the peer's clock was ahead of the local clock
+	// during the handshake.
+	ClockOffset time.Duration `json:"clockOffset"`
+	// TLS is nil if the connection to the peer isn't a TLS connection.
+	TLS *TLSInfo `json:"tls,omitempty"`
+	// Retiring is true if the peer announced that it is shutting down.
+	Retiring bool `json:"retiring"`
+	// Compression is the compression type of the messages sent to the peer.
+	Compression compression.Type `json:"compression"`
+}
+
+// TLSInfo describes the parameters negotiated in the TLS handshake with a
+// peer.
+type TLSInfo struct {
+	Version     string `json:"version"`
+	CipherSuite string `json:"cipherSuite"`
+}
+
+// RTTInfo describes the round trip times of the pings sent to a peer.
+type RTTInfo struct {
+	// LastPong is the time the last pong was received from the peer.
+	LastPong time.Time `json:"lastPong"`
+	// Samples is the number of recent round trip times the percentiles are
+	// calculated over.
+	Samples json.Uint32 `json:"samples"`
+	// Last is the most recent round trip time.
+	Last time.Duration `json:"last"`
+	// P50 is the median of the recent round trip times.
+	P50 time.Duration `json:"p50"`
+	// P95 is the 95th percentile of the recent round trip times.
+	P95 time.Duration `json:"p95"`
 }
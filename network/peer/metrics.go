@@ -16,6 +16,10 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+// rttBuckets are the upper bounds, in seconds, of the buckets of the ping round
+// trip time histogram.
+var rttBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
 type MessageMetrics struct {
 	ReceivedBytes, SentBytes, NumSent, NumFailed, NumReceived prometheus.Counter
 	SavedReceivedBytes, SavedSentBytes                        metric.Averager
@@ -83,7 +87,13 @@ type Metrics struct {
 	Log            logging.Logger
 	ClockSkew      metric.Averager
 	FailedToParse  prometheus.Counter
+	RTT            prometheus.Histogram
 	MessageMetrics map[message.Op]*MessageMetrics
+
+	// PaddingSentBytes is the bandwidth overhead of padding outbound messages
+	PaddingSentBytes prometheus.Counter
+	// SendJitter is the random delay added before sending outbound messages
+	SendJitter metric.Averager
 }
 
 func NewMetrics(
@@ -98,12 +108,25 @@ func NewMetrics(
 			Name:      "msgs_failed_to_parse",
 			Help:      "Number of messages that could not be parsed or were invalidly formed",
 		}),
+		RTT: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "peer_rtt",
+			Help:      "Round trip time of pings sent to peers (s)",
+			Buckets:   rttBuckets,
+		}),
 		MessageMetrics: make(map[message.Op]*MessageMetrics, len(message.ExternalOps)),
+		PaddingSentBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "padding_sent_bytes",
+			Help:      "Number of bytes of padding sent to disguise the size of outbound messages",
+		}),
 	}
 
 	errs := wrappers.Errs{}
 	errs.Add(
 		registerer.Register(m.FailedToParse),
+		registerer.Register(m.RTT),
+		registerer.Register(m.PaddingSentBytes),
 	)
 	for _, op := range message.ExternalOps {
 		m.MessageMetrics[op] = NewMessageMetrics(op, namespace, registerer, &errs)
@@ -116,6 +139,13 @@ func NewMetrics(
 		registerer,
 		&errs,
 	)
+	m.SendJitter = metric.NewAveragerWithErrs(
+		namespace,
+		"send_jitter",
+		"time (in ns) outbound messages were delayed to disguise their timing",
+		registerer,
+		&errs,
+	)
 	return m, errs.Err
 }
 
@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/utils/ips"
+)
+
+var (
+	errSendFailed = errors.New("failed to send message")
+
+	_ Network               = (*monitorNetwork)(nil)
+	_ router.InboundHandler = (*monitorNetwork)(nil)
+)
+
+// MonitorHandler is notified of everything a node sends to a [Monitor].
+type MonitorHandler interface {
+	// PeerList is called with the signed IPs of the validators that [nodeID]
+	// gossiped. The signatures of the IPs have not been verified.
+	PeerList(nodeID ids.NodeID, ips []*ips.ClaimedIPPort)
+
+	// Message is called with every non-handshake message that the node sent,
+	// such as gossip and the responses to requests sent by the monitor. The
+	// message must not be retained after Message returns.
+	Message(msg message.InboundMessage)
+}
+
+// Monitor is a read-only connection to a node. It performs the p2p handshake
+// and receives gossip without participating in consensus, which allows
+// building network crawlers and health monitors without running a full node.
+//
+// A monitor never gossips peers and never responds to requests.
+type Monitor struct {
+	Peer
+
+	mc        message.OutboundMsgBuilder
+	requestID atomic.Uint32
+}
+
+// StartMonitor dials [ip] and returns a monitor once the p2p handshake with the
+// node has finished.
+//
+// This function will generate a new TLS key to use when connecting to the node.
+//
+//   - [ctx] provides a way of canceling the connection request.
+//   - [ip] is the remote that will be dialed to create the connection.
+//   - [networkID] will be sent to the node during the handshake. If the node is
+//     expecting a different [networkID], the handshake will fail and an error
+//     will be returned.
+//   - [handler] will be called with all the gossip received from the node.
+func StartMonitor(
+	ctx context.Context,
+	ip ips.IPPort,
+	networkID uint32,
+	handler MonitorHandler,
+) (*Monitor, error) {
+	mc, err := newPeerMessageCreator()
+	if err != nil {
+		return nil, err
+	}
+
+	network := &monitorNetwork{
+		handler: handler,
+	}
+	peer, err := startPeer(ctx, ip, networkID, network, network, mc)
+	if err != nil {
+		return nil, err
+	}
+	return &Monitor{
+		Peer: peer,
+		mc:   mc,
+	}, nil
+}
+
+// RequestAcceptedFrontier asks the node for its accepted frontier of
+// [chainID]. The response, or nothing if the node doesn't respond within
+// [deadline], is passed to the handler. Returns the ID of the request.
+func (m *Monitor) RequestAcceptedFrontier(
+	ctx context.Context,
+	chainID ids.ID,
+	deadline time.Duration,
+) (uint32, error) {
+	requestID := m.requestID.Add(1)
+	msg, err := m.mc.GetAcceptedFrontier(
+		chainID,
+		requestID,
+		deadline,
+		p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+	)
+	if err != nil {
+		return 0, err
+	}
+	if !m.Send(ctx, msg) {
+		return 0, errSendFailed
+	}
+	return requestID, nil
+}
+
+// monitorNetwork reports the gossip of a single node to a [MonitorHandler]
+// without ever gossiping back.
+type monitorNetwork struct {
+	handler MonitorHandler
+}
+
+func (*monitorNetwork) Connected(ids.NodeID) {}
+
+func (*monitorNetwork) AllowConnection(ids.NodeID) bool {
+	return true
+}
+
+// Track acknowledges all the IPs so that the node doesn't gossip them again
+// unless they change.
+func (n *monitorNetwork) Track(nodeID ids.NodeID, claimedIPs []*ips.ClaimedIPPort) ([]*p2p.PeerAck, error) {
+	n.handler.PeerList(nodeID, claimedIPs)
+
+	peerAcks := make([]*p2p.PeerAck, len(claimedIPs))
+	for i, ip := range claimedIPs {
+		peerAcks[i] = &p2p.PeerAck{
+			TxId:      ip.TxID[:],
+			Timestamp: ip.Timestamp,
+		}
+	}
+	return peerAcks, nil
+}
+
+func (*monitorNetwork) MarkTracked(ids.NodeID, []*p2p.PeerAck) error {
+	return nil
+}
+
+func (*monitorNetwork) Retiring(ids.NodeID) {}
+
+func (*monitorNetwork) Disconnected(ids.NodeID) {}
+
+func (*monitorNetwork) Peers(ids.NodeID) ([]ips.ClaimedIPPort, error) {
+	return nil, nil
+}
+
+func (n *monitorNetwork) HandleInbound(_ context.Context, msg message.InboundMessage) {
+	n.handler.Message(msg)
+	msg.OnFinishedHandling()
+}
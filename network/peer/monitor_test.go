@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/ips"
+)
+
+type testMonitorHandler struct {
+	peerLists map[ids.NodeID][]*ips.ClaimedIPPort
+	messages  []message.InboundMessage
+}
+
+func (h *testMonitorHandler) PeerList(nodeID ids.NodeID, ips []*ips.ClaimedIPPort) {
+	h.peerLists[nodeID] = append(h.peerLists[nodeID], ips...)
+}
+
+func (h *testMonitorHandler) Message(msg message.InboundMessage) {
+	h.messages = append(h.messages, msg)
+}
+
+func TestMonitorNetworkTrack(t *testing.T) {
+	require := require.New(t)
+
+	handler := &testMonitorHandler{
+		peerLists: make(map[ids.NodeID][]*ips.ClaimedIPPort),
+	}
+	network := &monitorNetwork{
+		handler: handler,
+	}
+
+	nodeID := ids.GenerateTestNodeID()
+	claimedIPs := []*ips.ClaimedIPPort{
+		{
+			TxID:      ids.GenerateTestID(),
+			Timestamp: 1,
+		},
+		{
+			TxID:      ids.GenerateTestID(),
+			Timestamp: 2,
+		},
+	}
+	peerAcks, err := network.Track(nodeID, claimedIPs)
+	require.NoError(err)
+	require.Equal(claimedIPs, handler.peerLists[nodeID])
+
+	// Every IP should be acknowledged so that it isn't gossiped again.
+	require.Len(peerAcks, len(claimedIPs))
+	for i, ip := range claimedIPs {
+		require.Equal(ip.TxID[:], peerAcks[i].TxId)
+		require.Equal(ip.Timestamp, peerAcks[i].Timestamp)
+	}
+
+	// The monitor never gossips peers.
+	peers, err := network.Peers(nodeID)
+	require.NoError(err)
+	require.Empty(peers)
+}
+
+func TestMonitorNetworkHandleInbound(t *testing.T) {
+	require := require.New(t)
+
+	handler := &testMonitorHandler{}
+	network := &monitorNetwork{
+		handler: handler,
+	}
+
+	mc := newMessageCreator(t)
+	outboundMsg, err := mc.AppGossip(ids.GenerateTestID(), []byte{1})
+	require.NoError(err)
+
+	finished := false
+	msg, err := mc.Parse(outboundMsg.Bytes(), ids.GenerateTestNodeID(), func() {
+		finished = true
+	})
+	require.NoError(err)
+
+	network.HandleInbound(context.Background(), msg)
+	require.Equal([]message.InboundMessage{msg}, handler.messages)
+	require.True(finished)
+}
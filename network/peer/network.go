@@ -29,6 +29,9 @@ type Network interface {
 	// MarkTracked stops sending gossip about [ips] to [peerID].
 	MarkTracked(peerID ids.NodeID, ips []*p2p.PeerAck) error
 
+	// Retiring is called when the peer announces that it is shutting down.
+	Retiring(peerID ids.NodeID)
+
 	// Disconnected is called when the peer finishes shutting down. It is not
 	// guaranteed that [Connected] was called for the provided peer. However, it
 	// is guaranteed that [Connected] will not be called after [Disconnected]
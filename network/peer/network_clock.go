@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+// minNetworkClockSamples is the minimum number of validators whose clock
+// offsets must be known to estimate the network time. With fewer samples a
+// single validator could shift the estimate arbitrarily.
+const minNetworkClockSamples = 3
+
+// NetworkClock estimates the offset of the network time from the local clock
+// using the clock offsets that peers reported during the handshake.
+//
+// The estimate is the stake-weighted median of the offsets of the connected
+// validators, so it can't be moved by validators holding a minority of the
+// connected stake. Offsets reported by non-validators are ignored, as anyone
+// can connect an arbitrary number of them.
+type NetworkClock struct {
+	vdrs validators.Set
+	// maxOffset bounds the magnitude of the estimated offset.
+	maxOffset time.Duration
+
+	lock sync.RWMutex
+	// nodeID -> peer time - local time
+	offsets map[ids.NodeID]time.Duration
+}
+
+// NewNetworkClock returns a network clock that weighs offsets by the stake
+// of the peer in [vdrs] and whose estimated offset never exceeds [maxOffset]
+// in magnitude.
+func NewNetworkClock(vdrs validators.Set, maxOffset time.Duration) *NetworkClock {
+	return &NetworkClock{
+		vdrs:      vdrs,
+		maxOffset: maxOffset,
+		offsets:   make(map[ids.NodeID]time.Duration),
+	}
+}
+
+// Observe records that the clock of [nodeID] is [offset] ahead of the local
+// clock.
+func (c *NetworkClock) Observe(nodeID ids.NodeID, offset time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.offsets[nodeID] = offset
+}
+
+// Remove stops considering the clock offset of [nodeID].
+func (c *NetworkClock) Remove(nodeID ids.NodeID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.offsets, nodeID)
+}
+
+type weightedOffset struct {
+	offset time.Duration
+	weight uint64
+}
+
+// Offset returns the estimated offset of the network time from the local
+// clock and the number of validators it was estimated from. If too few
+// validators are known, the offset is 0.
+func (c *NetworkClock) Offset() (time.Duration, int) {
+	c.lock.RLock()
+	samples := make([]weightedOffset, 0, len(c.offsets))
+	totalWeight := uint64(0)
+	for nodeID, offset := range c.offsets {
+		weight := c.vdrs.GetWeight(nodeID)
+		if weight == 0 {
+			continue
+		}
+		samples = append(samples, weightedOffset{
+			offset: offset,
+			weight: weight,
+		})
+		totalWeight += weight
+	}
+	c.lock.RUnlock()
+
+	numSamples := len(samples)
+	if numSamples < minNetworkClockSamples {
+		return 0, numSamples
+	}
+
+	slices.SortFunc(samples, func(a, b weightedOffset) int {
+		switch {
+		case a.offset < b.offset:
+			return -1
+		case a.offset > b.offset:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	// The median is the first offset at which the cumulative weight reaches
+	// half of the total weight. If the cumulative weight is exactly half, the
+	// median lies between this offset and the next one.
+	var (
+		median           time.Duration
+		cumulativeWeight uint64
+	)
+	for i, sample := range samples {
+		cumulativeWeight += sample.weight
+		if cumulativeWeight < totalWeight-cumulativeWeight {
+			continue
+		}
+		median = sample.offset
+		if cumulativeWeight == totalWeight-cumulativeWeight {
+			median = (median + samples[i+1].offset) / 2
+		}
+		break
+	}
+
+	switch {
+	case median > c.maxOffset:
+		median = c.maxOffset
+	case median < -c.maxOffset:
+		median = -c.maxOffset
+	}
+	return median, numSamples
+}
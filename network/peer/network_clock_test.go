@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+type clockSample struct {
+	offset time.Duration
+	weight uint64
+}
+
+func TestNetworkClockOffset(t *testing.T) {
+	tests := []struct {
+		name               string
+		samples            []clockSample
+		expectedOffset     time.Duration
+		expectedNumSamples int
+	}{
+		{
+			name:               "no samples",
+			expectedOffset:     0,
+			expectedNumSamples: 0,
+		},
+		{
+			name: "too few samples",
+			samples: []clockSample{
+				{offset: time.Second, weight: 1},
+				{offset: time.Second, weight: 1},
+			},
+			expectedOffset:     0,
+			expectedNumSamples: 2,
+		},
+		{
+			name: "non-validators are ignored",
+			samples: []clockSample{
+				{offset: time.Second, weight: 1},
+				{offset: time.Second, weight: 1},
+				{offset: time.Hour, weight: 0},
+				{offset: time.Hour, weight: 0},
+			},
+			expectedOffset:     0,
+			expectedNumSamples: 2,
+		},
+		{
+			name: "odd number of samples",
+			samples: []clockSample{
+				{offset: -time.Second, weight: 1},
+				{offset: 3 * time.Second, weight: 1},
+				{offset: 2 * time.Second, weight: 1},
+			},
+			expectedOffset:     2 * time.Second,
+			expectedNumSamples: 3,
+		},
+		{
+			name: "even number of samples",
+			samples: []clockSample{
+				{offset: 4 * time.Second, weight: 1},
+				{offset: time.Second, weight: 1},
+				{offset: 2 * time.Second, weight: 1},
+				{offset: -time.Hour, weight: 1},
+			},
+			expectedOffset:     1500 * time.Millisecond,
+			expectedNumSamples: 4,
+		},
+		{
+			name: "outliers are ignored",
+			samples: []clockSample{
+				{offset: time.Hour, weight: 1},
+				{offset: time.Hour, weight: 1},
+				{offset: 0, weight: 1},
+				{offset: 0, weight: 1},
+				{offset: 0, weight: 1},
+			},
+			expectedOffset:     0,
+			expectedNumSamples: 5,
+		},
+		{
+			name: "weighted by stake",
+			samples: []clockSample{
+				{offset: time.Hour, weight: 1},
+				{offset: time.Hour, weight: 1},
+				{offset: time.Hour, weight: 1},
+				{offset: time.Second, weight: 4},
+			},
+			expectedOffset:     time.Second,
+			expectedNumSamples: 4,
+		},
+		{
+			name: "bounded above",
+			samples: []clockSample{
+				{offset: time.Hour, weight: 1},
+				{offset: time.Hour, weight: 1},
+				{offset: time.Hour, weight: 1},
+			},
+			expectedOffset:     time.Minute,
+			expectedNumSamples: 3,
+		},
+		{
+			name: "bounded below",
+			samples: []clockSample{
+				{offset: -time.Hour, weight: 1},
+				{offset: -time.Hour, weight: 1},
+				{offset: -time.Hour, weight: 1},
+			},
+			expectedOffset:     -time.Minute,
+			expectedNumSamples: 3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vdrs := validators.NewSet()
+			c := NewNetworkClock(vdrs, time.Minute)
+			for _, sample := range test.samples {
+				nodeID := ids.GenerateTestNodeID()
+				if sample.weight > 0 {
+					require.NoError(vdrs.Add(nodeID, nil, ids.Empty, sample.weight))
+				}
+				c.Observe(nodeID, sample.offset)
+			}
+
+			offset, numSamples := c.Offset()
+			require.Equal(test.expectedOffset, offset)
+			require.Equal(test.expectedNumSamples, numSamples)
+		})
+	}
+}
+
+func TestNetworkClockObserveAndRemove(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewSet()
+	c := NewNetworkClock(vdrs, time.Minute)
+	nodeIDs := []ids.NodeID{
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+	}
+	for _, nodeID := range nodeIDs {
+		require.NoError(vdrs.Add(nodeID, nil, ids.Empty, 1))
+		c.Observe(nodeID, time.Second)
+	}
+
+	// Observing a peer again replaces its offset.
+	c.Observe(nodeIDs[0], 2*time.Second)
+	c.Observe(nodeIDs[1], 2*time.Second)
+	offset, numSamples := c.Offset()
+	require.Equal(2*time.Second, offset)
+	require.Equal(3, numSamples)
+
+	c.Remove(nodeIDs[0])
+	offset, numSamples = c.Offset()
+	require.Zero(offset)
+	require.Equal(2, numSamples)
+
+	// Peers that stop validating are no longer considered.
+	c.Observe(nodeIDs[0], 2*time.Second)
+	require.NoError(vdrs.RemoveWeight(nodeIDs[2], 1))
+	_, numSamples = c.Offset()
+	require.Equal(2, numSamples)
+}
@@ -6,6 +6,7 @@ package peer
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"errors"
 	"io"
 	"math"
@@ -18,9 +19,11 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/capture"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -30,7 +33,8 @@ import (
 )
 
 var (
-	errClosed = errors.New("closed")
+	errClosed      = errors.New("closed")
+	errPingNotSent = errors.New("ping not sent")
 
 	_ Peer = (*peer)(nil)
 )
@@ -81,11 +85,19 @@ type Peer interface {
 	// [Ready] returns true.
 	ObservedUptime(subnetID ids.ID) (uint32, bool)
 
+	// Retiring returns true if the peer announced that it is shutting down.
+	Retiring() bool
+
 	// Send attempts to send [msg] to the peer. The peer takes ownership of
 	// [msg] for reference counting. This returns false if the message is
 	// guaranteed not to be delivered to the peer.
 	Send(ctx context.Context, msg message.OutboundMessage) bool
 
+	// Ping sends a Ping to the peer and blocks until a Pong is received,
+	// returning the round trip time. It should only be called after [Ready]
+	// returns true.
+	Ping(ctx context.Context) (time.Duration, error)
+
 	// StartSendPeerList attempts to send a PeerList message to this peer on
 	// this peer's gossip routine. It is not guaranteed that a PeerList will be
 	// sent.
@@ -128,6 +140,9 @@ type peer struct {
 	// trackedSubnets is the subset of subnetIDs the peer sent us in the Version
 	// message that we are also tracking.
 	trackedSubnets set.Set[ids.ID]
+	// clockOffset is how far the peer's clock was ahead of ours when we
+	// received the Version message.
+	clockOffset time.Duration
 
 	observedUptimesLock sync.RWMutex
 	// [observedUptimesLock] must be held while accessing [observedUptime]
@@ -146,6 +161,16 @@ type peer struct {
 	// Only modified on the connection's reader routine.
 	finishedHandshake utils.Atomic[bool]
 
+	// True if the peer sent us a valid Retiring message.
+	// Only modified on the connection's reader routine.
+	retiring utils.Atomic[bool]
+
+	// compressionType is the compression type that the messages sent to this
+	// peer are compressed with. Negotiated when the peer's Version message is
+	// received.
+	// Only modified on the connection's reader routine.
+	compressionType utils.Atomic[compression.Type]
+
 	// onFinishHandshake is closed when the peer finishes the p2p handshake.
 	onFinishHandshake chan struct{}
 
@@ -164,9 +189,16 @@ type peer struct {
 	// Must only be accessed atomically
 	lastSent, lastReceived int64
 
+	// rtt tracks the round trip times of the pings sent to this peer
+	rtt rttTracker
+
 	// peerListChan signals that we should attempt to send a PeerList to this
 	// peer
 	peerListChan chan struct{}
+
+	// poolEntry is non-nil if the network messages of this peer are sent by
+	// [Config.WorkerPool] rather than by a dedicated goroutine.
+	poolEntry *poolEntry
 }
 
 // Start a new peer instance.
@@ -195,10 +227,17 @@ func Start(
 		observedUptimes:    make(map[ids.ID]uint32),
 		peerListChan:       make(chan struct{}, 1),
 	}
+	p.compressionType.Set(config.CompressionType)
+
+	if config.WorkerPool != nil {
+		p.poolEntry = config.WorkerPool.register(p.handleNetworkWork)
+	}
 
 	go p.readMessages()
 	go p.writeMessages()
-	go p.sendNetworkMessages()
+	if p.poolEntry == nil {
+		go p.sendNetworkMessages()
+	}
 
 	return p
 }
@@ -262,6 +301,15 @@ func (p *peer) Info() Info {
 		primaryUptime = 0
 	}
 
+	var tlsInfo *TLSInfo
+	if tlsConn, ok := p.conn.(*tls.Conn); ok {
+		state := tlsConn.ConnectionState()
+		tlsInfo = &TLSInfo{
+			Version:     TLSVersionName(state.Version),
+			CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+		}
+	}
+
 	return Info{
 		IP:                    p.conn.RemoteAddr().String(),
 		PublicIP:              publicIPStr,
@@ -272,6 +320,11 @@ func (p *peer) Info() Info {
 		ObservedUptime:        json.Uint32(primaryUptime),
 		ObservedSubnetUptimes: uptimes,
 		TrackedSubnets:        trackedSubnets,
+		RTT:                   p.rtt.info(),
+		ClockOffset:           p.clockOffset,
+		TLS:                   tlsInfo,
+		Retiring:              p.Retiring(),
+		Compression:           p.compressionType.Get(),
 	}
 }
 
@@ -295,11 +348,75 @@ func (p *peer) ObservedUptime(subnetID ids.ID) (uint32, bool) {
 	return uptime, exist
 }
 
+func (p *peer) Retiring() bool {
+	return p.retiring.Get()
+}
+
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
-	return p.messageQueue.Push(ctx, msg)
+	// The message is compressed before it is queued, so that it is throttled
+	// and accounted for with the bytes that are actually sent.
+	compressedMsg, err := p.compress(msg)
+	if err != nil {
+		p.Log.Error("failed to recompress message",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Error(err),
+		)
+		return false
+	}
+	return p.messageQueue.Push(ctx, compressedMsg)
+}
+
+// compress returns [msg] compressed with the compression type negotiated with
+// this peer.
+func (p *peer) compress(msg message.OutboundMessage) (message.OutboundMessage, error) {
+	shaped, isShaped := msg.(*shapedMessage)
+	if isShaped {
+		msg = shaped.OutboundMessage
+	}
+	compressedMsg, err := p.MessageCreator.Recompress(msg, p.compressionType.Get())
+	if err != nil || !isShaped {
+		return compressedMsg, err
+	}
+	return &shapedMessage{
+		OutboundMessage: compressedMsg,
+		config:          shaped.config,
+	}, nil
+}
+
+func (p *peer) Ping(ctx context.Context) (time.Duration, error) {
+	// The waiter is registered before the ping is sent so that the pong can't
+	// be missed.
+	pong := p.rtt.awaitPong()
+
+	primaryUptime, subnetUptimes := p.getUptimes()
+	pingMessage, err := p.MessageCreator.Ping(primaryUptime, subnetUptimes)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := p.Clock.Time()
+	if !p.Send(ctx, pingMessage) {
+		return 0, errPingNotSent
+	}
+	p.rtt.sentPing(sent)
+
+	select {
+	case received := <-pong:
+		return received.Sub(sent), nil
+	case <-p.onClosed:
+		return 0, errClosed
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
 }
 
 func (p *peer) StartSendPeerList() {
+	if p.poolEntry != nil {
+		p.WorkerPool.schedule(p.poolEntry, workPeerList)
+		return
+	}
+
 	select {
 	case p.peerListChan <- struct{}{}:
 	default:
@@ -317,9 +434,34 @@ func (p *peer) StartClose() {
 
 		p.messageQueue.Close()
 		p.onClosingCtxCancel()
+
+		if p.poolEntry != nil {
+			p.WorkerPool.schedule(p.poolEntry, workClose)
+		}
 	})
 }
 
+// failHandshake closes the connection after the peer sent an invalid
+// handshake message. Handshakes rejected because of the peer's network, clock
+// or version only close the connection, as honest peers may be misconfigured
+// or out of date.
+func (p *peer) failHandshake() {
+	if p.Reputations != nil {
+		p.Reputations.RecordHandshakeFailure(p.id)
+	}
+	p.StartClose()
+}
+
+// dropInvalidPeer closes the connection after the peer sent a message with an
+// invalid field. Messages that fail to parse aren't recorded, as they may have
+// been added by newer versions.
+func (p *peer) dropInvalidPeer() {
+	if p.Reputations != nil {
+		p.Reputations.RecordInvalidMessage(p.id)
+	}
+	p.StartClose()
+}
+
 func (p *peer) Closed() bool {
 	select {
 	case _, ok := <-p.onClosed:
@@ -345,6 +487,9 @@ func (p *peer) close() {
 		return
 	}
 
+	if p.NetworkClock != nil {
+		p.NetworkClock.Remove(p.id)
+	}
 	p.Network.Disconnected(p.id)
 	close(p.onClosed)
 }
@@ -428,12 +573,14 @@ func (p *peer) readMessages() {
 		}
 
 		// Read the message
-		msgBytes := make([]byte, msgLen)
+		msgBuf := p.BufferPool.Get(int(msgLen))
+		msgBytes := msgBuf.Bytes()
 		if _, err := io.ReadFull(reader, msgBytes); err != nil {
 			p.Log.Verbo("error reading message",
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
+			msgBuf.Release()
 			onFinishedHandling()
 			return
 		}
@@ -452,7 +599,12 @@ func (p *peer) readMessages() {
 		)
 
 		// Parse the message
-		msg, err := p.MessageCreator.Parse(msgBytes, p.id, onFinishedHandling)
+		var msg message.InboundMessage
+		if p.ZeroCopyPayloads {
+			msg, err = p.MessageCreator.ParseBuffer(msgBuf, p.id, onFinishedHandling)
+		} else {
+			msg, err = p.MessageCreator.Parse(msgBytes, p.id, onFinishedHandling)
+		}
 		if err != nil {
 			p.Log.Verbo("failed to parse message",
 				zap.Stringer("nodeID", p.id),
@@ -463,6 +615,7 @@ func (p *peer) readMessages() {
 			p.Metrics.FailedToParse.Inc()
 
 			// Couldn't parse the message. Read the next one.
+			msgBuf.Release()
 			onFinishedHandling()
 			p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
 			continue
@@ -471,6 +624,28 @@ func (p *peer) readMessages() {
 		now := p.Clock.Time()
 		p.storeLastReceived(now)
 		p.Metrics.Received(msg, msgLen)
+		if p.Bandwidth != nil {
+			p.Bandwidth.Received(p.id, msg.Op(), int(msgLen))
+		}
+		if p.Capturer != nil {
+			p.Capturer.Capture(capture.Inbound, p.id, msgBytes)
+		}
+
+		// [msgBytes] must not be used after this point. If the message
+		// references it, the message holds its own reference.
+		msgBuf.Release()
+
+		if !p.InboundMsgThrottler.AllowOp(p.id, msg.Op()) {
+			p.Log.Debug("dropping message",
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", msg.Op()),
+				zap.String("reason", "exceeded op rate limit"),
+			)
+
+			msg.OnFinishedHandling()
+			p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
+			continue
+		}
 
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
@@ -505,6 +680,7 @@ func (p *peer) writeMessages() {
 		mySignedIP.Timestamp,
 		mySignedIP.Signature,
 		p.MySubnets.List(),
+		p.CompressionTypes,
 	)
 	if err != nil {
 		p.Log.Error("failed to create message",
@@ -520,6 +696,9 @@ func (p *peer) writeMessages() {
 	for {
 		msg, ok := p.messageQueue.PopNow()
 		if ok {
+			if !p.delayMessage(writer, msg) {
+				return
+			}
 			p.writeMessage(writer, msg)
 			continue
 		}
@@ -540,10 +719,42 @@ func (p *peer) writeMessages() {
 			return
 		}
 
+		if !p.delayMessage(writer, msg) {
+			return
+		}
 		p.writeMessage(writer, msg)
 	}
 }
 
+// delayMessage waits for the random send jitter of [msg], if any. The prior
+// messages are flushed first so that they aren't delayed along with [msg].
+// Returns false if the peer started closing or the writer couldn't be flushed.
+func (p *peer) delayMessage(writer *bufio.Writer, msg message.OutboundMessage) bool {
+	delay := jitter(msg)
+	if delay <= 0 {
+		return true
+	}
+
+	if err := writer.Flush(); err != nil {
+		p.Log.Verbo("failed to flush writer",
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return false
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		p.Metrics.SendJitter.Observe(float64(delay))
+		return true
+	case <-p.onClosingCtx.Done():
+		return false
+	}
+}
+
 func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	msgBytes := msg.Bytes()
 	p.Log.Verbo("sending message",
@@ -559,7 +770,8 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 		return
 	}
 
-	msgLen := uint32(len(msgBytes))
+	padding := padding(msg, len(msgBytes))
+	msgLen := uint32(len(msgBytes) + len(padding))
 	msgLenBytes, err := writeMsgLen(msgLen, constants.DefaultMaxMessageSize)
 	if err != nil {
 		p.Log.Verbo("error writing message length",
@@ -570,7 +782,7 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	}
 
 	// Write the message
-	var buf net.Buffers = [][]byte{msgLenBytes[:], msgBytes}
+	var buf net.Buffers = [][]byte{msgLenBytes[:], msgBytes, padding}
 	if _, err := io.CopyN(writer, &buf, int64(wrappers.IntLen+msgLen)); err != nil {
 		p.Log.Verbo("error writing message",
 			zap.Stringer("nodeID", p.id),
@@ -582,6 +794,15 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	now := p.Clock.Time()
 	p.storeLastSent(now)
 	p.Metrics.Sent(msg)
+	if len(padding) > 0 {
+		p.Metrics.PaddingSentBytes.Add(float64(len(padding)))
+	}
+	if p.Bandwidth != nil {
+		p.Bandwidth.Sent(p.id, msg.Op(), int(msgLen))
+	}
+	if p.Capturer != nil {
+		p.Capturer.Capture(capture.Outbound, p.id, msgBytes)
+	}
 }
 
 func (p *peer) sendNetworkMessages() {
@@ -596,76 +817,113 @@ func (p *peer) sendNetworkMessages() {
 	for {
 		select {
 		case <-p.peerListChan:
-			peerIPs, err := p.Config.Network.Peers(p.id)
-			if err != nil {
-				p.Log.Error("failed to get peers to gossip",
-					zap.Stringer("nodeID", p.id),
-					zap.Error(err),
-				)
+			if !p.sendPeerList() {
 				return
 			}
-
-			if len(peerIPs) == 0 {
-				p.Log.Verbo(
-					"skipping peer gossip as there are no unknown peers",
-					zap.Stringer("nodeID", p.id),
-				)
-				continue
-			}
-
-			// Bypass throttling is disabled here to follow the non-handshake
-			// message sending pattern.
-			msg, err := p.Config.MessageCreator.PeerList(peerIPs, false /*=bypassThrottling*/)
-			if err != nil {
-				p.Log.Error("failed to create peer list message",
-					zap.Stringer("nodeID", p.id),
-					zap.Error(err),
-				)
-				continue
-			}
-
-			if !p.Send(p.onClosingCtx, msg) {
-				p.Log.Debug("failed to send peer list",
-					zap.Stringer("nodeID", p.id),
-				)
-			}
 		case <-sendPingsTicker.C:
-			if !p.Network.AllowConnection(p.id) {
-				p.Log.Debug("disconnecting from peer",
-					zap.String("reason", "connection is no longer desired"),
-					zap.Stringer("nodeID", p.id),
-				)
+			if !p.sendPing() {
 				return
 			}
+		case <-p.onClosingCtx.Done():
+			return
+		}
+	}
+}
 
-			if p.finishedHandshake.Get() {
-				if err := p.VersionCompatibility.Compatible(p.version); err != nil {
-					p.Log.Debug("disconnecting from peer",
-						zap.String("reason", "version not compatible"),
-						zap.Stringer("nodeID", p.id),
-						zap.Stringer("peerVersion", p.version),
-						zap.Error(err),
-					)
-					return
-				}
-			}
+// handleNetworkWork performs the work requested by [Config.WorkerPool]. It
+// returns false once the peer has stopped sending network messages.
+func (p *peer) handleNetworkWork(w work) bool {
+	keepRunning := p.onClosingCtx.Err() == nil && w&workClose == 0
+	if keepRunning && w&workPeerList != 0 {
+		keepRunning = p.sendPeerList()
+	}
+	if keepRunning && w&workPing != 0 {
+		keepRunning = p.sendPing()
+	}
+	if !keepRunning {
+		p.StartClose()
+		p.close()
+	}
+	return keepRunning
+}
 
-			primaryUptime, subnetUptimes := p.getUptimes()
-			pingMessage, err := p.MessageCreator.Ping(primaryUptime, subnetUptimes)
-			if err != nil {
-				p.Log.Error("failed to create message",
-					zap.Stringer("messageOp", message.PingOp),
-					zap.Stringer("nodeID", p.id),
-					zap.Error(err),
-				)
-				return
-			}
+// sendPeerList attempts to gossip a PeerList to this peer. Returns false if
+// the peer should be disconnected.
+func (p *peer) sendPeerList() bool {
+	peerIPs, err := p.Config.Network.Peers(p.id)
+	if err != nil {
+		p.Log.Error("failed to get peers to gossip",
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return false
+	}
 
-			p.Send(p.onClosingCtx, pingMessage)
-		case <-p.onClosingCtx.Done():
-			return
+	if len(peerIPs) == 0 {
+		p.Log.Verbo(
+			"skipping peer gossip as there are no unknown peers",
+			zap.Stringer("nodeID", p.id),
+		)
+		return true
+	}
+
+	// Bypass throttling is disabled here to follow the non-handshake message
+	// sending pattern.
+	msg, err := p.Config.MessageCreator.PeerList(peerIPs, false /*=bypassThrottling*/)
+	if err != nil {
+		p.Log.Error("failed to create peer list message",
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return true
+	}
+
+	if !p.Send(p.onClosingCtx, msg) {
+		p.Log.Debug("failed to send peer list",
+			zap.Stringer("nodeID", p.id),
+		)
+	}
+	return true
+}
+
+// sendPing sends a Ping to this peer. Returns false if the peer should be
+// disconnected.
+func (p *peer) sendPing() bool {
+	if !p.Network.AllowConnection(p.id) {
+		p.Log.Debug("disconnecting from peer",
+			zap.String("reason", "connection is no longer desired"),
+			zap.Stringer("nodeID", p.id),
+		)
+		return false
+	}
+
+	if p.finishedHandshake.Get() {
+		if err := p.VersionCompatibility.Compatible(p.version); err != nil {
+			p.Log.Debug("disconnecting from peer",
+				zap.String("reason", "version not compatible"),
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("peerVersion", p.version),
+				zap.Error(err),
+			)
+			return false
 		}
 	}
+
+	primaryUptime, subnetUptimes := p.getUptimes()
+	pingMessage, err := p.MessageCreator.Ping(primaryUptime, subnetUptimes)
+	if err != nil {
+		p.Log.Error("failed to create message",
+			zap.Stringer("messageOp", message.PingOp),
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return false
+	}
+
+	if p.Send(p.onClosingCtx, pingMessage) {
+		p.rtt.sentPing(p.Clock.Time())
+	}
+	return true
 }
 
 func (p *peer) handle(msg message.InboundMessage) {
@@ -690,6 +948,10 @@ func (p *peer) handle(msg message.InboundMessage) {
 		p.handlePeerListAck(m)
 		msg.OnFinishedHandling()
 		return
+	case *p2p.Retiring:
+		p.handleRetiring(m)
+		msg.OnFinishedHandling()
+		return
 	}
 	if !p.finishedHandshake.Get() {
 		p.Log.Debug(
@@ -760,6 +1022,10 @@ func (p *peer) getUptimes() (uint32, []*p2p.SubnetUptime) {
 }
 
 func (p *peer) handlePong(msg *p2p.Pong) {
+	if rtt, ok := p.rtt.receivedPong(p.Clock.Time()); ok {
+		p.Metrics.RTT.Observe(rtt.Seconds())
+	}
+
 	// TODO: Remove once everyone sends uptimes in Ping messages.
 	p.observeUptimes(msg.Uptime, msg.SubnetUptimes)
 }
@@ -779,7 +1045,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 			zap.Stringer("subnetID", constants.PrimaryNetworkID),
 			zap.Uint32("uptime", primaryUptime),
 		)
-		p.StartClose()
+		p.dropInvalidPeer()
 		return
 	}
 	p.observeUptime(constants.PrimaryNetworkID, primaryUptime)
@@ -791,7 +1057,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
-			p.StartClose()
+			p.dropInvalidPeer()
 			return
 		}
 
@@ -800,7 +1066,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 				zap.Stringer("nodeID", p.id),
 				zap.Stringer("subnetID", subnetID),
 			)
-			p.StartClose()
+			p.dropInvalidPeer()
 			return
 		}
 
@@ -811,7 +1077,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 				zap.Stringer("subnetID", subnetID),
 				zap.Uint32("uptime", uptime),
 			)
-			p.StartClose()
+			p.dropInvalidPeer()
 			return
 		}
 		p.observeUptime(subnetID, uptime)
@@ -852,7 +1118,9 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 
 	p.Metrics.ClockSkew.Observe(clockDifference)
 
-	if clockDifference > p.MaxClockDifference.Seconds() {
+	// The peer's time may also be compared against the estimated network time
+	// so that peers aren't rejected only because of the skew of our clock.
+	if math.Abs(p.clockDifference(msg.MyTime)) > p.MaxClockDifference.Seconds() {
 		if p.Beacons.Contains(p.id) {
 			p.Log.Warn("beacon reports out of sync time",
 				zap.Stringer("nodeID", p.id),
@@ -870,6 +1138,11 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 		return
 	}
 
+	p.clockOffset = time.Duration(int64(msg.MyTime)-int64(myTime)) * time.Second
+	if p.NetworkClock != nil {
+		p.NetworkClock.Observe(p.id, p.clockOffset)
+	}
+
 	peerVersion, err := version.ParseApplication(msg.MyVersion)
 	if err != nil {
 		p.Log.Debug("failed to parse peer version",
@@ -908,7 +1181,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 	// Note that it is expected that the [versionTime] can be in the past. We
 	// are just verifying that the claimed signing time isn't too far in the
 	// future here.
-	if float64(msg.MyVersionTime)-float64(myTime) > p.MaxClockDifference.Seconds() {
+	if p.clockDifference(msg.MyVersionTime) > p.MaxClockDifference.Seconds() {
 		p.Log.Debug("peer attempting to connect with version timestamp too far in the future",
 			zap.Stringer("nodeID", p.id),
 			zap.Uint64("versionTime", msg.MyVersionTime),
@@ -925,7 +1198,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
-			p.StartClose()
+			p.failHandshake()
 			return
 		}
 		// add only if we also track this subnet
@@ -934,18 +1207,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 		}
 	}
 
-	// "net.IP" type in Golang is 16-byte
-	if ipLen := len(msg.IpAddr); ipLen != net.IPv6len {
-		p.Log.Debug("message with invalid field",
-			zap.Stringer("nodeID", p.id),
-			zap.Stringer("messageOp", message.VersionOp),
-			zap.String("field", "IP"),
-			zap.Int("ipLen", ipLen),
-		)
-		p.StartClose()
-		return
-	}
-
+	// The length of the IP was checked when the message was parsed.
 	p.ip = &SignedIP{
 		UnsignedIP: UnsignedIP{
 			IPPort: ips.IPPort{
@@ -961,10 +1223,11 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
 		)
-		p.StartClose()
+		p.failHandshake()
 		return
 	}
 
+	p.compressionType.Set(p.negotiateCompression(msg.CompressionTypes))
 	p.gotVersion.Set(true)
 
 	peerIPs, err := p.Network.Peers(p.id)
@@ -995,6 +1258,32 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 	}
 }
 
+// negotiateCompression returns the compression type to send messages to this
+// peer with, given the compression types the peer accepts. Compression types
+// that aren't known are ignored, as they may have been added by newer
+// versions.
+func (p *peer) negotiateCompression(compressionTypeStrs []string) compression.Type {
+	if len(compressionTypeStrs) == 0 {
+		// The peer doesn't negotiate compression, so it is assumed to
+		// support the default compression type.
+		return p.CompressionType
+	}
+
+	accepted := make([]compression.Type, 0, len(compressionTypeStrs))
+	for _, compressionTypeStr := range compressionTypeStrs {
+		compressionType, err := compression.TypeFromString(compressionTypeStr)
+		if err != nil {
+			p.Log.Debug("ignoring unknown compression type",
+				zap.Stringer("nodeID", p.id),
+				zap.String("compressionType", compressionTypeStr),
+			)
+			continue
+		}
+		accepted = append(accepted, compressionType)
+	}
+	return compression.Negotiate(p.CompressionTypes, accepted)
+}
+
 func (p *peer) handlePeerList(msg *p2p.PeerList) {
 	if !p.finishedHandshake.Get() {
 		if !p.gotVersion.Get() {
@@ -1017,19 +1306,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "Cert"),
 				zap.Error(err),
 			)
-			p.StartClose()
-			return
-		}
-
-		// "net.IP" type in Golang is 16-byte
-		if ipLen := len(claimedIPPort.IpAddr); ipLen != net.IPv6len {
-			p.Log.Debug("message with invalid field",
-				zap.Stringer("nodeID", p.id),
-				zap.Stringer("messageOp", message.VersionOp),
-				zap.String("field", "IP"),
-				zap.Int("ipLen", ipLen),
-			)
-			p.StartClose()
+			p.dropInvalidPeer()
 			return
 		}
 
@@ -1041,7 +1318,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "txID"),
 				zap.Error(err),
 			)
-			p.StartClose()
+			p.dropInvalidPeer()
 			return
 		}
 
@@ -1065,7 +1342,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 			zap.String("field", "claimedIP"),
 			zap.Error(err),
 		)
-		p.StartClose()
+		p.dropInvalidPeer()
 		return
 	}
 	if len(trackedPeers) == 0 {
@@ -1101,14 +1378,83 @@ func (p *peer) handlePeerListAck(msg *p2p.PeerListAck) {
 			zap.String("field", "txID"),
 			zap.Error(err),
 		)
-		p.StartClose()
+		p.dropInvalidPeer()
+	}
+}
+
+func (p *peer) handleRetiring(msg *p2p.Retiring) {
+	if !p.finishedHandshake.Get() {
+		p.Log.Debug("dropping message",
+			zap.String("reason", "handshake isn't finished"),
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.RetiringOp),
+		)
+		return
+	}
+
+	// An old announcement may be replayed, so only announcements made around
+	// now are accepted.
+	if math.Abs(p.clockDifference(msg.Timestamp)) > p.MaxClockDifference.Seconds() {
+		p.Log.Debug("message with invalid field",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.RetiringOp),
+			zap.String("field", "Timestamp"),
+			zap.Uint64("timestamp", msg.Timestamp),
+			zap.Uint64("myTime", p.Clock.Unix()),
+		)
+		return
 	}
+
+	signedRetiring := &SignedRetiring{
+		UnsignedRetiring: UnsignedRetiring{
+			Timestamp: msg.Timestamp,
+		},
+		Signature: msg.Signature,
+	}
+	if err := signedRetiring.Verify(p.cert); err != nil {
+		p.Log.Debug("signature verification failed",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.RetiringOp),
+			zap.Error(err),
+		)
+		p.dropInvalidPeer()
+		return
+	}
+
+	if p.retiring.Get() {
+		return
+	}
+	p.retiring.Set(true)
+
+	p.Log.Debug("peer is retiring",
+		zap.Stringer("nodeID", p.id),
+	)
+	p.Network.Retiring(p.id)
 }
 
 func (p *peer) nextTimeout() time.Time {
 	return p.Clock.Time().Add(p.PongTimeout)
 }
 
+// clockDifference returns how many seconds [timestamp] is ahead of the
+// current time. If the network time is estimated, the difference with the
+// network time is returned when it is smaller, so that timestamps near the
+// [MaxClockDifference] boundary aren't rejected only because of the skew of
+// the local clock.
+func (p *peer) clockDifference(timestamp uint64) float64 {
+	difference := float64(timestamp) - float64(p.Clock.Unix())
+	if p.NetworkClock == nil {
+		return difference
+	}
+
+	offset, _ := p.NetworkClock.Offset()
+	networkDifference := difference - offset.Seconds()
+	if math.Abs(networkDifference) < math.Abs(difference) {
+		return networkDifference
+	}
+	return difference
+}
+
 func (p *peer) storeLastSent(time time.Time) {
 	unixTime := time.Unix()
 	atomic.StoreInt64(&p.Config.LastSent, unixTime)
@@ -4,6 +4,7 @@
 package peer
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"net"
@@ -23,6 +24,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -53,6 +56,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 		prometheus.NewRegistry(),
 		"",
 		constants.DefaultNetworkCompressionType,
+		constants.DefaultNetworkCompressionZstdLevel,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -97,6 +101,8 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 	sharedConfig := Config{
 		Metrics:              metrics,
 		MessageCreator:       mc,
+		CompressionTypes:     []compression.Type{constants.DefaultNetworkCompressionType},
+		CompressionType:      constants.DefaultNetworkCompressionType,
 		Log:                  logging.NoLog{},
 		InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
 		VersionCompatibility: version.GetCompatibility(constants.LocalID),
@@ -108,6 +114,7 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 		PongTimeout:          constants.DefaultPingPongTimeout,
 		MaxClockDifference:   time.Minute,
 		ResourceTracker:      resourceTracker,
+		BufferPool:           message.NewBufferPool(),
 	}
 	peerConfig0 := sharedConfig
 	peerConfig1 := sharedConfig
@@ -151,7 +158,10 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 
 func makeTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*testPeer, *testPeer) {
 	rawPeer0, rawPeer1 := makeRawTestPeers(t, trackedSubnets)
+	return startTestPeers(rawPeer0, rawPeer1)
+}
 
+func startTestPeers(rawPeer0 *rawTestPeer, rawPeer1 *rawTestPeer) (*testPeer, *testPeer) {
 	peer0 := &testPeer{
 		Peer: Start(
 			rawPeer0.config,
@@ -262,6 +272,90 @@ func TestSend(t *testing.T) {
 	require.NoError(peer1.AwaitClosed(context.Background()))
 }
 
+func TestSendZeroCopy(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t, set.Set[ids.ID]{})
+	pool := message.NewBufferPool()
+	rawPeer1.config.BufferPool = pool
+	rawPeer1.config.ZeroCopyPayloads = true
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	// Uncompressed messages are the ones whose payloads reference the buffer
+	// they were read into.
+	mc, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeNone,
+		constants.DefaultNetworkCompressionZstdLevel,
+		10*time.Second,
+	)
+	require.NoError(err)
+
+	container := []byte{1, 2, 3}
+	outboundPutMsg, err := mc.Put(ids.Empty, 1, container, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), outboundPutMsg))
+
+	inboundPutMsg := <-rawPeer1.inboundMsgChan
+	require.Equal(message.PutOp, inboundPutMsg.Op())
+	require.Equal(container, inboundPutMsg.Message().(*p2p.Put).Container)
+
+	// The message holds a reference to its buffer until it is handled.
+	require.Positive(pool.Outstanding())
+	inboundPutMsg.OnFinishedHandling()
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+	require.Zero(pool.Outstanding())
+}
+
+func TestPing(t *testing.T) {
+	require := require.New(t)
+
+	peer0, peer1 := makeReadyTestPeers(t, set.Set[ids.ID]{})
+
+	rtt, err := peer0.Ping(context.Background())
+	require.NoError(err)
+	require.GreaterOrEqual(rtt, time.Duration(0))
+	require.Positive(peer0.Info().RTT.Samples)
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+
+	_, err = peer0.Ping(context.Background())
+	require.ErrorIs(err, errPingNotSent)
+}
+
 func TestPingUptimes(t *testing.T) {
 	trackedSubnetID := ids.GenerateTestID()
 	untrackedSubnetID := ids.GenerateTestID()
@@ -377,6 +471,96 @@ func TestPingUptimes(t *testing.T) {
 	}
 }
 
+func TestRetiring(t *testing.T) {
+	require := require.New(t)
+
+	peer0, peer1 := makeReadyTestPeers(t, set.Set[ids.ID]{})
+	mc := newMessageCreator(t)
+	signer := peer0.Peer.(*peer).IPSigner.signer
+	now := uint64(time.Now().Unix())
+
+	// Announcements made too long ago are ignored.
+	staleRetiring := UnsignedRetiring{
+		Timestamp: now - uint64(2*time.Minute/time.Second),
+	}
+	signedRetiring, err := staleRetiring.Sign(signer)
+	require.NoError(err)
+	retiringMsg, err := mc.Retiring(signedRetiring.Timestamp, signedRetiring.Signature)
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), retiringMsg))
+	sendAndFlush(t, peer0, peer1)
+	require.False(peer1.Retiring())
+
+	unsignedRetiring := UnsignedRetiring{
+		Timestamp: now,
+	}
+	signedRetiring, err = unsignedRetiring.Sign(signer)
+	require.NoError(err)
+	retiringMsg, err = mc.Retiring(signedRetiring.Timestamp, signedRetiring.Signature)
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), retiringMsg))
+	sendAndFlush(t, peer0, peer1)
+	require.True(peer1.Retiring())
+	require.True(peer1.Info().Retiring)
+	require.False(peer0.Retiring())
+
+	// Announcements that aren't signed by the peer cause a disconnect.
+	retiringMsg, err = mc.Retiring(now, signedRetiring.Signature)
+	require.NoError(err)
+	require.True(peer1.Send(context.Background(), retiringMsg))
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestRetiringNetworkClock(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t, set.Set[ids.ID]{})
+
+	// The clocks of the other peers of peer1 are a minute ahead of its clock.
+	vdrs := validators.NewSet()
+	require.NoError(vdrs.Add(rawPeer0.nodeID, nil, ids.Empty, 1))
+	networkClock := NewNetworkClock(vdrs, time.Minute)
+	for i := 0; i < minNetworkClockSamples; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		require.NoError(vdrs.Add(nodeID, nil, ids.Empty, 1))
+		networkClock.Observe(nodeID, time.Minute)
+	}
+	rawPeer1.config.NetworkClock = networkClock
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	// The handshake of peer0 was recorded by the network clock.
+	offset, numSamples := networkClock.Offset()
+	require.Equal(time.Minute, offset)
+	require.Equal(minNetworkClockSamples+1, numSamples)
+
+	// The announcement is too far in the future according to the clock of
+	// peer1, but not according to the network time.
+	mc := newMessageCreator(t)
+	signer := peer0.Peer.(*peer).IPSigner.signer
+	unsignedRetiring := UnsignedRetiring{
+		Timestamp: uint64(time.Now().Add(90 * time.Second).Unix()),
+	}
+	signedRetiring, err := unsignedRetiring.Sign(signer)
+	require.NoError(err)
+	retiringMsg, err := mc.Retiring(signedRetiring.Timestamp, signedRetiring.Signature)
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), retiringMsg))
+	sendAndFlush(t, peer0, peer1)
+	require.True(peer1.Retiring())
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+
+	// Peers are no longer considered once they disconnect.
+	_, numSamples = networkClock.Offset()
+	require.Equal(minNetworkClockSamples, numSamples)
+}
+
 // Helper to send a message from sender to receiver and assert that the
 // receiver receives the message. This can be used to test a prior message
 // was handled by the peer.
@@ -389,3 +573,82 @@ func sendAndFlush(t *testing.T, sender *testPeer, receiver *testPeer) {
 	inboundGetMsg := <-receiver.inboundMsgChan
 	require.Equal(t, message.GetOp, inboundGetMsg.Op())
 }
+
+func TestNegotiateCompression(t *testing.T) {
+	tests := []struct {
+		name     string
+		accepted []string
+		expected compression.Type
+	}{
+		{
+			name:     "peer doesn't negotiate",
+			accepted: nil,
+			expected: compression.TypeGzip,
+		},
+		{
+			name:     "first preference accepted",
+			accepted: []string{"none", "zstd"},
+			expected: compression.TypeZstd,
+		},
+		{
+			name:     "unknown types are ignored",
+			accepted: []string{"lz4", "none"},
+			expected: compression.TypeNone,
+		},
+		{
+			name:     "no preference accepted",
+			accepted: []string{"lz4"},
+			expected: compression.TypeNone,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &peer{
+				Config: &Config{
+					CompressionTypes: []compression.Type{compression.TypeZstd, compression.TypeNone},
+					CompressionType:  compression.TypeGzip,
+					Log:              logging.NoLog{},
+				},
+			}
+			require.Equal(t, test.expected, p.negotiateCompression(test.accepted))
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	require := require.New(t)
+
+	mc := newMessageCreator(t)
+	msg, err := mc.AppGossip(ids.Empty, bytes.Repeat([]byte{1}, 1024))
+	require.NoError(err)
+	require.Positive(msg.BytesSavedCompression())
+
+	p := &peer{
+		Config: &Config{
+			MessageCreator: mc,
+		},
+	}
+	p.compressionType.Set(compression.TypeNone)
+
+	// The message is sent with the bytes of the negotiated compression type,
+	// which are computed once.
+	uncompressedMsg, err := p.compress(msg)
+	require.NoError(err)
+	require.Zero(uncompressedMsg.BytesSavedCompression())
+	require.Greater(len(uncompressedMsg.Bytes()), len(msg.Bytes()))
+
+	cachedMsg, err := p.compress(msg)
+	require.NoError(err)
+	require.Same(uncompressedMsg, cachedMsg)
+
+	// Shaped messages remain shaped once compressed.
+	config := subnets.TrafficShapingConfig{
+		PaddingBuckets: []uint32{4096},
+	}
+	shapedMsg, err := p.compress(NewShapedMessage(msg, config))
+	require.NoError(err)
+	require.Equal(&shapedMessage{
+		OutboundMessage: uncompressedMsg,
+		config:          config,
+	}, shapedMsg)
+}
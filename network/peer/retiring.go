@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"crypto"
+	"crypto/rand"
+
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// retiringPrefix is prepended to the signed bytes of a retiring announcement
+// so that its signature can't be mistaken for the signature of an IP.
+var retiringPrefix = []byte("retiring")
+
+// UnsignedRetiring is used by a node to announce that it started shutting
+// down at [Timestamp].
+type UnsignedRetiring struct {
+	Timestamp uint64
+}
+
+// Sign this announcement with the provided signer and return the signed
+// announcement.
+func (r *UnsignedRetiring) Sign(signer crypto.Signer) (*SignedRetiring, error) {
+	sig, err := signer.Sign(
+		rand.Reader,
+		hashing.ComputeHash256(r.bytes()),
+		crypto.SHA256,
+	)
+	return &SignedRetiring{
+		UnsignedRetiring: *r,
+		Signature:        sig,
+	}, err
+}
+
+func (r *UnsignedRetiring) bytes() []byte {
+	p := wrappers.Packer{
+		Bytes: make([]byte, len(retiringPrefix)+wrappers.LongLen),
+	}
+	p.PackFixedBytes(retiringPrefix)
+	p.PackLong(r.Timestamp)
+	return p.Bytes
+}
+
+// SignedRetiring is a wrapper of an UnsignedRetiring with the signature from a
+// signer.
+type SignedRetiring struct {
+	UnsignedRetiring
+	Signature []byte
+}
+
+func (r *SignedRetiring) Verify(cert *staking.Certificate) error {
+	return staking.CheckSignature(
+		cert,
+		r.UnsignedRetiring.bytes(),
+		r.Signature,
+	)
+}
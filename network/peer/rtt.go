@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// maxRTTSamples is the number of most recent round trip times that are used
+// to calculate the latency percentiles of a peer.
+const maxRTTSamples = 32
+
+// rttTracker tracks the round trip times of the pings sent to a peer.
+type rttTracker struct {
+	lock sync.Mutex
+	// pingSent is the time the outstanding ping was sent. If there is no
+	// outstanding ping, it is the zero value.
+	pingSent time.Time
+	// lastPong is the time the last pong was received.
+	lastPong time.Time
+	// samples is a ring buffer of the most recent round trip times.
+	samples []time.Duration
+	// next is the index in [samples] to write the next round trip time to.
+	next int
+	// pongWaiters are notified of the time the next pong is received.
+	pongWaiters []chan time.Time
+}
+
+// sentPing records that a ping was sent at [now].
+func (r *rttTracker) sentPing(now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.pingSent = now
+}
+
+// receivedPong records that a pong was received at [now]. If there was an
+// outstanding ping, the round trip time is recorded and returned.
+func (r *rttTracker) receivedPong(now time.Time) (time.Duration, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.lastPong = now
+	for _, waiter := range r.pongWaiters {
+		waiter <- now
+	}
+	r.pongWaiters = nil
+
+	if r.pingSent.IsZero() {
+		return 0, false
+	}
+
+	rtt := now.Sub(r.pingSent)
+	r.pingSent = time.Time{}
+	if rtt < 0 {
+		return 0, false
+	}
+
+	if len(r.samples) < maxRTTSamples {
+		r.samples = append(r.samples, rtt)
+	} else {
+		r.samples[r.next] = rtt
+	}
+	r.next = (r.next + 1) % maxRTTSamples
+	return rtt, true
+}
+
+// awaitPong returns a channel that is sent the time the next pong is received.
+func (r *rttTracker) awaitPong() <-chan time.Time {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	waiter := make(chan time.Time, 1)
+	r.pongWaiters = append(r.pongWaiters, waiter)
+	return waiter
+}
+
+// info returns the latency statistics of the recorded round trip times.
+func (r *rttTracker) info() RTTInfo {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	info := RTTInfo{
+		LastPong: r.lastPong,
+		Samples:  json.Uint32(len(r.samples)),
+	}
+	if len(r.samples) == 0 {
+		return info
+	}
+
+	lastIndex := (r.next + maxRTTSamples - 1) % maxRTTSamples
+	info.Last = r.samples[lastIndex]
+
+	sorted := slices.Clone(r.samples)
+	slices.Sort(sorted)
+	info.P50 = percentile(sorted, 50)
+	info.P95 = percentile(sorted, 95)
+	return info
+}
+
+// percentile returns the [p]th percentile of [sorted] using the nearest-rank
+// method.
+//
+// Invariant: [sorted] is non-empty and sorted in increasing order.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
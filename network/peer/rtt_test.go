@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRTTTracker(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		r   rttTracker
+		now = time.Unix(1, 0)
+	)
+	require.Zero(r.info())
+
+	// A pong without an outstanding ping shouldn't be recorded
+	_, ok := r.receivedPong(now)
+	require.False(ok)
+	require.Equal(RTTInfo{LastPong: now}, r.info())
+
+	for i := 1; i <= 100; i++ {
+		r.sentPing(now)
+		now = now.Add(time.Duration(i) * time.Millisecond)
+		rtt, ok := r.receivedPong(now)
+		require.True(ok)
+		require.Equal(time.Duration(i)*time.Millisecond, rtt)
+	}
+
+	// Only the last [maxRTTSamples] round trip times should be considered
+	info := r.info()
+	require.Equal(now, info.LastPong)
+	require.Equal(maxRTTSamples, int(info.Samples))
+	require.Equal(100*time.Millisecond, info.Last)
+	require.Equal(84*time.Millisecond, info.P50)
+	require.Equal(99*time.Millisecond, info.P95)
+
+	// A ping should only be matched with a single pong
+	r.sentPing(now)
+	_, ok = r.receivedPong(now.Add(time.Millisecond))
+	require.True(ok)
+	_, ok = r.receivedPong(now.Add(time.Second))
+	require.False(ok)
+}
+
+func TestRTTTrackerAwaitPong(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		r   rttTracker
+		now = time.Unix(1, 0)
+	)
+	first := r.awaitPong()
+	second := r.awaitPong()
+	r.receivedPong(now)
+	require.Equal(now, <-first)
+	require.Equal(now, <-second)
+
+	// Waiters are only notified of a single pong
+	third := r.awaitPong()
+	r.receivedPong(now.Add(time.Second))
+	require.Equal(now.Add(time.Second), <-third)
+	require.Empty(first)
+}
+
+func TestPercentile(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(time.Duration(1), percentile([]time.Duration{1}, 50))
+	require.Equal(time.Duration(1), percentile([]time.Duration{1}, 95))
+	require.Equal(time.Duration(1), percentile([]time.Duration{1, 2}, 50))
+	require.Equal(time.Duration(2), percentile([]time.Duration{1, 2}, 95))
+}
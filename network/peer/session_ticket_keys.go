@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"sync"
+)
+
+var errNoSessionTicketKeys = errors.New("must retain at least one session ticket key")
+
+// SessionTicketKeyRotator manages the keys that are used to encrypt and
+// decrypt the TLS session tickets issued by a tls.Config.
+//
+// New tickets are always encrypted with the most recently generated key.
+// Tickets encrypted with any of the previous [numKeys]-1 keys can still be
+// used to resume a session.
+type SessionTicketKeyRotator struct {
+	config  *tls.Config
+	numKeys int
+
+	lock sync.Mutex
+	// keys[0] is the most recent key
+	keys [][32]byte
+}
+
+// NewSessionTicketKeyRotator sets an initial session ticket key on [config].
+func NewSessionTicketKeyRotator(config *tls.Config, numKeys int) (*SessionTicketKeyRotator, error) {
+	if numKeys < 1 {
+		return nil, errNoSessionTicketKeys
+	}
+
+	r := &SessionTicketKeyRotator{
+		config:  config,
+		numKeys: numKeys,
+		keys:    make([][32]byte, 0, numKeys),
+	}
+	return r, r.Rotate()
+}
+
+// Rotate generates a new session ticket key and drops the oldest key if more
+// than [numKeys] keys would be retained.
+func (r *SessionTicketKeyRotator) Rotate() error {
+	var key [32]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.keys) == r.numKeys {
+		r.keys = r.keys[:r.numKeys-1]
+	}
+	r.keys = append([][32]byte{key}, r.keys...)
+	r.config.SetSessionTicketKeys(r.keys)
+	return nil
+}
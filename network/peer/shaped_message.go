@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"math/rand"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/subnets"
+)
+
+// paddingFieldNumber is the protobuf field number used to pad p2p messages.
+// It isn't defined by p2p.Message, so the padding is ignored when the message
+// is parsed.
+const paddingFieldNumber protowire.Number = 1 << 20
+
+var _ message.OutboundMessage = (*shapedMessage)(nil)
+
+// shapedMessage is an outbound message that is padded and delayed by the peer
+// when it is written to the connection.
+type shapedMessage struct {
+	message.OutboundMessage
+	config subnets.TrafficShapingConfig
+}
+
+// NewShapedMessage returns [msg] marked to be shaped according to [config]
+// when it is sent. If [config] isn't enabled, [msg] is returned.
+func NewShapedMessage(msg message.OutboundMessage, config subnets.TrafficShapingConfig) message.OutboundMessage {
+	if !config.Enabled() {
+		return msg
+	}
+	return &shapedMessage{
+		OutboundMessage: msg,
+		config:          config,
+	}
+}
+
+// jitter returns the random delay to wait before [msg] is written.
+func jitter(msg message.OutboundMessage) time.Duration {
+	shaped, ok := msg.(*shapedMessage)
+	if !ok || shaped.config.MaxSendJitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(shaped.config.MaxSendJitter))) // #nosec G404
+}
+
+// padding returns the bytes to append to [msg], which is [msgLen] bytes long
+// once encoded, so that its length matches one of its padding buckets. If
+// [msg] doesn't need to be padded, nil is returned.
+func padding(msg message.OutboundMessage, msgLen int) []byte {
+	shaped, ok := msg.(*shapedMessage)
+	if !ok {
+		return nil
+	}
+
+	for _, bucket := range shaped.config.PaddingBuckets {
+		if paddingLen, ok := paddingFieldLen(int(bucket) - msgLen); ok {
+			padding := protowire.AppendTag(nil, paddingFieldNumber, protowire.BytesType)
+			padding = protowire.AppendVarint(padding, uint64(paddingLen))
+			return append(padding, make([]byte, paddingLen)...)
+		}
+	}
+	return nil
+}
+
+// paddingFieldLen returns the length of the value of a padding field that is
+// exactly [size] bytes long once encoded. Returns false if no such field
+// exists.
+func paddingFieldLen(size int) (int, bool) {
+	tagLen := protowire.SizeTag(paddingFieldNumber)
+	for valueLen := size - tagLen - 1; valueLen >= 0; valueLen-- {
+		fieldLen := tagLen + protowire.SizeBytes(valueLen)
+		switch {
+		case fieldLen == size:
+			return valueLen, true
+		case fieldLen < size:
+			// Reducing the value length further only shrinks the field.
+			return 0, false
+		}
+	}
+	return 0, false
+}
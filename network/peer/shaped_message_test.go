@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestPadding(t *testing.T) {
+	require := require.New(t)
+
+	mc := newMessageCreator(t)
+	msg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	require.NoError(err)
+	msgLen := len(msg.Bytes())
+
+	// Messages that aren't shaped aren't padded.
+	require.Nil(padding(msg, msgLen))
+
+	// The first bucket is too small to fit the message and the second bucket
+	// is too small to fit the padding field.
+	shapedMsg := NewShapedMessage(msg, subnets.TrafficShapingConfig{
+		PaddingBuckets: []uint32{
+			uint32(msgLen - 1),
+			uint32(msgLen + 1),
+			uint32(msgLen + 200),
+			uint32(msgLen + 1000),
+		},
+	})
+	padding := padding(shapedMsg, msgLen)
+	require.Len(padding, 200)
+
+	// The padded message is still parsed correctly.
+	paddedBytes := append(msg.Bytes(), padding...)
+	inboundMsg, err := mc.Parse(paddedBytes, ids.EmptyNodeID, func() {})
+	require.NoError(err)
+	require.Equal(message.GetOp, inboundMsg.Op())
+}
+
+func TestPaddingFieldLen(t *testing.T) {
+	require := require.New(t)
+
+	for size := 0; size < 1<<15; size++ {
+		valueLen, ok := paddingFieldLen(size)
+		if !ok {
+			continue
+		}
+		field := protowire.AppendTag(nil, paddingFieldNumber, protowire.BytesType)
+		field = protowire.AppendBytes(field, make([]byte, valueLen))
+		require.Len(field, size)
+	}
+}
+
+func TestSendShaped(t *testing.T) {
+	require := require.New(t)
+
+	peer0, peer1 := makeReadyTestPeers(t, set.Set[ids.ID]{})
+	mc := newMessageCreator(t)
+
+	outboundGetMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	require.NoError(err)
+	shapedMsg := NewShapedMessage(outboundGetMsg, subnets.TrafficShapingConfig{
+		PaddingBuckets: []uint32{4096},
+		MaxSendJitter:  time.Millisecond,
+	})
+
+	require.True(peer0.Send(context.Background(), shapedMsg))
+
+	inboundGetMsg := <-peer1.inboundMsgChan
+	require.Equal(message.GetOp, inboundGetMsg.Op())
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
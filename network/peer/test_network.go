@@ -27,6 +27,8 @@ func (testNetwork) MarkTracked(ids.NodeID, []*p2p.PeerAck) error {
 	return nil
 }
 
+func (testNetwork) Retiring(ids.NodeID) {}
+
 func (testNetwork) Disconnected(ids.NodeID) {}
 
 func (testNetwork) Peers(ids.NodeID) ([]ips.ClaimedIPPort, error) {
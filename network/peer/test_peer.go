@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -49,6 +50,24 @@ func StartTestPeer(
 	ip ips.IPPort,
 	networkID uint32,
 	router router.InboundHandler,
+) (Peer, error) {
+	mc, err := newPeerMessageCreator()
+	if err != nil {
+		return nil, err
+	}
+	return startPeer(ctx, ip, networkID, TestNetwork, router, mc)
+}
+
+// startPeer dials [ip] with a new TLS key and finishes the p2p handshake. The
+// returned peer doesn't track any subnets and doesn't throttle inbound or
+// outbound messages.
+func startPeer(
+	ctx context.Context,
+	ip ips.IPPort,
+	networkID uint32,
+	network Network,
+	router router.InboundHandler,
+	mc message.Creator,
 ) (Peer, error) {
 	dialer := net.Dialer{}
 	conn, err := dialer.DialContext(ctx, constants.NetworkType, ip.String())
@@ -64,7 +83,13 @@ func StartTestPeer(
 	tlsConfg := TLSConfig(*tlsCert, nil)
 	clientUpgrader := NewTLSClientUpgrader(
 		tlsConfg,
-		prometheus.NewCounter(prometheus.CounterOpts{}),
+		UpgraderMetrics{
+			InvalidCerts:         prometheus.NewCounter(prometheus.CounterOpts{}),
+			FullHandshakes:       prometheus.NewCounter(prometheus.CounterOpts{}),
+			ResumedHandshakes:    prometheus.NewCounter(prometheus.CounterOpts{}),
+			NegotiatedParameters: NewNegotiatedParametersMetric(prometheus.CounterOpts{}),
+			RejectedCipherSuites: prometheus.NewCounter(prometheus.CounterOpts{}),
+		},
 	)
 
 	peerID, conn, cert, err := clientUpgrader.Upgrade(conn)
@@ -72,17 +97,6 @@ func StartTestPeer(
 		return nil, err
 	}
 
-	mc, err := message.NewCreator(
-		logging.NoLog{},
-		prometheus.NewRegistry(),
-		"",
-		constants.DefaultNetworkCompressionType,
-		10*time.Second,
-	)
-	if err != nil {
-		return nil, err
-	}
-
 	metrics, err := NewMetrics(
 		logging.NoLog{},
 		"",
@@ -109,9 +123,11 @@ func StartTestPeer(
 		&Config{
 			Metrics:              metrics,
 			MessageCreator:       mc,
+			CompressionTypes:     []compression.Type{constants.DefaultNetworkCompressionType},
+			CompressionType:      constants.DefaultNetworkCompressionType,
 			Log:                  logging.NoLog{},
 			InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
-			Network:              TestNetwork,
+			Network:              network,
 			Router:               router,
 			VersionCompatibility: version.GetCompatibility(networkID),
 			MySubnets:            set.Set[ids.ID]{},
@@ -123,6 +139,7 @@ func StartTestPeer(
 			ResourceTracker:      resourceTracker,
 			UptimeCalculator:     uptime.NoOpCalculator,
 			IPSigner:             NewIPSigner(signerIP, tls),
+			BufferPool:           message.NewBufferPool(),
 		},
 		conn,
 		cert,
@@ -135,3 +152,14 @@ func StartTestPeer(
 	)
 	return peer, peer.AwaitReady(ctx)
 }
+
+func newPeerMessageCreator() (message.Creator, error) {
+	return message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		"",
+		constants.DefaultNetworkCompressionType,
+		constants.DefaultNetworkCompressionZstdLevel,
+		10*time.Second,
+	)
+}
@@ -5,12 +5,37 @@ package peer
 
 import (
 	"crypto/tls"
+	"errors"
+	"fmt"
 	"io"
+
+	"golang.org/x/exp/slices"
+)
+
+// tlsSessionCacheSize is the maximum number of sessions, with peers this node
+// dialed, that are cached to be resumed on reconnect.
+const tlsSessionCacheSize = 4096
+
+var (
+	errUnsupportedTLSVersion = errors.New("unsupported TLS version")
+	errUnknownCipherSuite    = errors.New("unknown cipher suite")
+	errCipherSuiteTooOld     = errors.New("cipher suite doesn't support the minimum TLS version")
+	errNoTLS13CipherSuite    = errors.New("at least one TLS 1.3 cipher suite must be accepted")
+	errCipherSuiteNotAllowed = errors.New("negotiated cipher suite isn't accepted")
+
+	tlsVersions = map[string]uint16{
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
 )
 
 // TLSConfig returns the TLS config that will allow secure connections to other
 // peers.
 //
+// Sessions with peers are cached so that reconnects can resume the session
+// rather than performing a full handshake. The peer certificates of a resumed
+// session are the certificates that were provided in the original handshake.
+//
 // It is safe, and typically expected, for [keyLogWriter] to be [nil].
 // [keyLogWriter] should only be enabled for debugging.
 func TLSConfig(cert tls.Certificate, keyLogWriter io.Writer) *tls.Config {
@@ -27,5 +52,72 @@ func TLSConfig(cert tls.Certificate, keyLogWriter io.Writer) *tls.Config {
 		InsecureSkipVerify: true,
 		MinVersion:         tls.VersionTLS13,
 		KeyLogWriter:       keyLogWriter,
+		ClientSessionCache: tls.NewLRUClientSessionCache(tlsSessionCacheSize),
+	}
+}
+
+// ParseTLSVersion returns the TLS version named [name], such as "1.3".
+func ParseTLSVersion(name string) (uint16, error) {
+	version, ok := tlsVersions[name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", errUnsupportedTLSVersion, name)
+	}
+	return version, nil
+}
+
+// TLSVersionName returns the name of [version], such as "1.3".
+func TLSVersionName(version uint16) string {
+	for name, v := range tlsVersions {
+		if v == version {
+			return name
+		}
+	}
+	return fmt.Sprintf("0x%04X", version)
+}
+
+// ParseCipherSuites returns the IDs of the cipher suites named [names] after
+// verifying that they can be negotiated with peers using at least
+// [minVersion].
+//
+// Only the cipher suites that [crypto/tls] considers secure are supported. At
+// least one TLS 1.3 cipher suite must be named, as peers that support TLS 1.3
+// always negotiate it.
+func ParseCipherSuites(names []string, minVersion uint16) ([]uint16, error) {
+	supported := make(map[string]*tls.CipherSuite)
+	for _, suite := range tls.CipherSuites() {
+		supported[suite.Name] = suite
+	}
+
+	var (
+		suiteIDs   = make([]uint16, len(names))
+		supportsV3 bool
+	)
+	for i, name := range names {
+		suite, ok := supported[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errUnknownCipherSuite, name)
+		}
+		maxVersion := slices.Max(suite.SupportedVersions)
+		if maxVersion < minVersion {
+			return nil, fmt.Errorf("%w: %q", errCipherSuiteTooOld, name)
+		}
+		supportsV3 = supportsV3 || maxVersion == tls.VersionTLS13
+		suiteIDs[i] = suite.ID
+	}
+	if len(suiteIDs) > 0 && !supportsV3 {
+		return nil, errNoTLS13CipherSuite
+	}
+	return suiteIDs, nil
+}
+
+// verifyCipherSuite returns an error if [config] restricts the accepted cipher
+// suites and [cipherSuite] isn't one of them.
+//
+// [crypto/tls] only honors [tls.Config.CipherSuites] for TLS 1.2 and earlier,
+// so the TLS 1.3 cipher suites must be verified after the handshake.
+func verifyCipherSuite(config *tls.Config, cipherSuite uint16) error {
+	if len(config.CipherSuites) == 0 || slices.Contains(config.CipherSuites, cipherSuite) {
+		return nil
 	}
+	return fmt.Errorf("%w: %s", errCipherSuiteNotAllowed, tls.CipherSuiteName(cipherSuite))
 }
@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTLSVersion(t *testing.T) {
+	require := require.New(t)
+
+	version, err := ParseTLSVersion("1.3")
+	require.NoError(err)
+	require.Equal(uint16(tls.VersionTLS13), version)
+	require.Equal("1.3", TLSVersionName(version))
+
+	version, err = ParseTLSVersion("1.2")
+	require.NoError(err)
+	require.Equal(uint16(tls.VersionTLS12), version)
+	require.Equal("1.2", TLSVersionName(version))
+
+	_, err = ParseTLSVersion("1.1")
+	require.ErrorIs(err, errUnsupportedTLSVersion)
+
+	require.Equal("0x0302", TLSVersionName(tls.VersionTLS11))
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	tests := []struct {
+		name           string
+		names          []string
+		minVersion     uint16
+		expectedSuites []uint16
+		expectedErr    error
+	}{
+		{
+			name:           "no restriction",
+			names:          nil,
+			minVersion:     tls.VersionTLS13,
+			expectedSuites: []uint16{},
+		},
+		{
+			name:           "TLS 1.3 suites",
+			names:          []string{"TLS_AES_256_GCM_SHA384", "TLS_CHACHA20_POLY1305_SHA256"},
+			minVersion:     tls.VersionTLS13,
+			expectedSuites: []uint16{tls.TLS_AES_256_GCM_SHA384, tls.TLS_CHACHA20_POLY1305_SHA256},
+		},
+		{
+			name:           "TLS 1.2 and TLS 1.3 suites",
+			names:          []string{"TLS_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			minVersion:     tls.VersionTLS12,
+			expectedSuites: []uint16{tls.TLS_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+		},
+		{
+			name:        "unknown suite",
+			names:       []string{"TLS_AES_128_GCM_SHA256", "unknown"},
+			minVersion:  tls.VersionTLS13,
+			expectedErr: errUnknownCipherSuite,
+		},
+		{
+			name:        "insecure suite",
+			names:       []string{"TLS_AES_128_GCM_SHA256", "TLS_RSA_WITH_RC4_128_SHA"},
+			minVersion:  tls.VersionTLS12,
+			expectedErr: errUnknownCipherSuite,
+		},
+		{
+			name:        "TLS 1.2 suite in TLS 1.3 only mode",
+			names:       []string{"TLS_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			minVersion:  tls.VersionTLS13,
+			expectedErr: errCipherSuiteTooOld,
+		},
+		{
+			name:        "no TLS 1.3 suite",
+			names:       []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			minVersion:  tls.VersionTLS12,
+			expectedErr: errNoTLS13CipherSuite,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			suites, err := ParseCipherSuites(test.names, test.minVersion)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Equal(test.expectedSuites, suites)
+			}
+		})
+	}
+}
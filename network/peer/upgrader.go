@@ -14,6 +14,11 @@ import (
 	"github.com/ava-labs/avalanchego/staking"
 )
 
+const (
+	versionLabel     = "version"
+	cipherSuiteLabel = "cipher_suite"
+)
+
 var (
 	errNoCert = errors.New("tls handshake finished with no peer certificate")
 
@@ -26,44 +31,83 @@ type Upgrader interface {
 	Upgrade(net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error)
 }
 
+// UpgraderMetrics are the metrics reported while upgrading connections.
+type UpgraderMetrics struct {
+	// InvalidCerts is incremented when a peer provides an unsupported
+	// certificate.
+	InvalidCerts prometheus.Counter
+	// FullHandshakes is incremented when a handshake that didn't resume a
+	// previous session completes.
+	FullHandshakes prometheus.Counter
+	// ResumedHandshakes is incremented when a handshake that resumed a
+	// previous session completes.
+	ResumedHandshakes prometheus.Counter
+	// NegotiatedParameters is incremented when a handshake completes, labeled
+	// by the negotiated TLS version and cipher suite.
+	NegotiatedParameters *prometheus.CounterVec
+	// RejectedCipherSuites is incremented when a handshake negotiates a cipher
+	// suite that isn't accepted.
+	RejectedCipherSuites prometheus.Counter
+}
+
+// NewNegotiatedParametersMetric returns the metric expected by
+// [UpgraderMetrics.NegotiatedParameters].
+func NewNegotiatedParametersMetric(opts prometheus.CounterOpts) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(opts, []string{versionLabel, cipherSuiteLabel})
+}
+
 type tlsServerUpgrader struct {
-	config       *tls.Config
-	invalidCerts prometheus.Counter
+	config  *tls.Config
+	metrics UpgraderMetrics
 }
 
-func NewTLSServerUpgrader(config *tls.Config, invalidCerts prometheus.Counter) Upgrader {
+func NewTLSServerUpgrader(config *tls.Config, metrics UpgraderMetrics) Upgrader {
 	return &tlsServerUpgrader{
-		config:       config,
-		invalidCerts: invalidCerts,
+		config:  config,
+		metrics: metrics,
 	}
 }
 
 func (t *tlsServerUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
-	return connToIDAndCert(tls.Server(conn, t.config), t.invalidCerts)
+	return connToIDAndCert(tls.Server(conn, t.config), t.config, t.metrics)
 }
 
 type tlsClientUpgrader struct {
-	config       *tls.Config
-	invalidCerts prometheus.Counter
+	config  *tls.Config
+	metrics UpgraderMetrics
 }
 
-func NewTLSClientUpgrader(config *tls.Config, invalidCerts prometheus.Counter) Upgrader {
+func NewTLSClientUpgrader(config *tls.Config, metrics UpgraderMetrics) Upgrader {
 	return &tlsClientUpgrader{
-		config:       config,
-		invalidCerts: invalidCerts,
+		config:  config,
+		metrics: metrics,
 	}
 }
 
 func (t *tlsClientUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
-	return connToIDAndCert(tls.Client(conn, t.config), t.invalidCerts)
+	return connToIDAndCert(tls.Client(conn, t.config), t.config, t.metrics)
 }
 
-func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+func connToIDAndCert(conn *tls.Conn, config *tls.Config, metrics UpgraderMetrics) (ids.NodeID, net.Conn, *staking.Certificate, error) {
 	if err := conn.Handshake(); err != nil {
 		return ids.NodeID{}, nil, nil, err
 	}
 
 	state := conn.ConnectionState()
+	if err := verifyCipherSuite(config, state.CipherSuite); err != nil {
+		metrics.RejectedCipherSuites.Inc()
+		return ids.NodeID{}, nil, nil, err
+	}
+	metrics.NegotiatedParameters.With(prometheus.Labels{
+		versionLabel:     TLSVersionName(state.Version),
+		cipherSuiteLabel: tls.CipherSuiteName(state.CipherSuite),
+	}).Inc()
+	if state.DidResume {
+		metrics.ResumedHandshakes.Inc()
+	} else {
+		metrics.FullHandshakes.Inc()
+	}
+
 	if len(state.PeerCertificates) == 0 {
 		return ids.NodeID{}, nil, nil, errNoCert
 	}
@@ -74,7 +118,7 @@ func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeI
 	// parseable according the staking package's parser.
 	peerCert, err := staking.ParseCertificate(tlsCert.Raw)
 	if err != nil {
-		invalidCerts.Inc()
+		metrics.InvalidCerts.Inc()
 		return ids.NodeID{}, nil, nil, err
 	}
 
@@ -83,7 +127,7 @@ func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeI
 	// prior version using an invalid certificate should not be able to report
 	// healthy.
 	if err := staking.ValidateCertificate(peerCert); err != nil {
-		invalidCerts.Inc()
+		metrics.InvalidCerts.Inc()
 		return ids.NodeID{}, nil, nil, err
 	}
 
@@ -0,0 +1,225 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+)
+
+func newTestUpgraderMetrics() UpgraderMetrics {
+	return UpgraderMetrics{
+		InvalidCerts:         prometheus.NewCounter(prometheus.CounterOpts{}),
+		FullHandshakes:       prometheus.NewCounter(prometheus.CounterOpts{}),
+		ResumedHandshakes:    prometheus.NewCounter(prometheus.CounterOpts{}),
+		NegotiatedParameters: NewNegotiatedParametersMetric(prometheus.CounterOpts{Name: "negotiated_parameters"}),
+		RejectedCipherSuites: prometheus.NewCounter(prometheus.CounterOpts{}),
+	}
+}
+
+func newTestTLSConfig(require *require.Assertions) (*tls.Config, ids.NodeID) {
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	cert := staking.CertificateFromX509(tlsCert.Leaf)
+	return TLSConfig(*tlsCert, nil), ids.NodeIDFromCert(cert)
+}
+
+// upgrade performs a handshake between [client] and [server] over a TCP
+// connection accepted by [listener] and returns the node IDs that each side
+// observed of the other.
+func upgrade(require *require.Assertions, listener net.Listener, client, server Upgrader) (ids.NodeID, ids.NodeID) {
+	type result struct {
+		nodeID ids.NodeID
+		conn   net.Conn
+		err    error
+	}
+	serverResult := make(chan result)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverResult <- result{err: err}
+			return
+		}
+
+		nodeID, conn, _, err := server.Upgrade(conn)
+		if err == nil {
+			// Session tickets are sent after the handshake, so the client
+			// must read from the connection to receive them.
+			_, err = conn.Write([]byte{0})
+		}
+		serverResult <- result{
+			nodeID: nodeID,
+			conn:   conn,
+			err:    err,
+		}
+	}()
+
+	clientConn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(err)
+
+	serverNodeID, conn, _, err := client.Upgrade(clientConn)
+	require.NoError(err)
+	_, err = conn.Read(make([]byte, 1))
+	require.NoError(err)
+
+	res := <-serverResult
+	require.NoError(res.err)
+
+	require.NoError(conn.Close())
+	require.NoError(res.conn.Close())
+	return serverNodeID, res.nodeID
+}
+
+func newTestListener(t *testing.T) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	return listener
+}
+
+func TestUpgraderSessionResumption(t *testing.T) {
+	require := require.New(t)
+
+	clientConfig, clientNodeID := newTestTLSConfig(require)
+	serverConfig, serverNodeID := newTestTLSConfig(require)
+
+	sessionTicketKeys, err := NewSessionTicketKeyRotator(serverConfig, 2)
+	require.NoError(err)
+
+	clientMetrics := newTestUpgraderMetrics()
+	serverMetrics := newTestUpgraderMetrics()
+	client := NewTLSClientUpgrader(clientConfig, clientMetrics)
+	server := NewTLSServerUpgrader(serverConfig, serverMetrics)
+	listener := newTestListener(t)
+
+	for i := 0; i < 3; i++ {
+		observedServerNodeID, observedClientNodeID := upgrade(require, listener, client, server)
+		require.Equal(serverNodeID, observedServerNodeID)
+		require.Equal(clientNodeID, observedClientNodeID)
+	}
+
+	require.Equal(1, int(testutil.ToFloat64(clientMetrics.FullHandshakes)))
+	require.Equal(2, int(testutil.ToFloat64(clientMetrics.ResumedHandshakes)))
+	require.Equal(1, int(testutil.ToFloat64(serverMetrics.FullHandshakes)))
+	require.Equal(2, int(testutil.ToFloat64(serverMetrics.ResumedHandshakes)))
+
+	// Sessions issued with a retained key can still be resumed
+	require.NoError(sessionTicketKeys.Rotate())
+	upgrade(require, listener, client, server)
+	require.Equal(3, int(testutil.ToFloat64(serverMetrics.ResumedHandshakes)))
+
+	// Once all the keys that could have issued the session are dropped, a
+	// full handshake is required
+	require.NoError(sessionTicketKeys.Rotate())
+	require.NoError(sessionTicketKeys.Rotate())
+	upgrade(require, listener, client, server)
+	require.Equal(2, int(testutil.ToFloat64(serverMetrics.FullHandshakes)))
+	require.Equal(3, int(testutil.ToFloat64(serverMetrics.ResumedHandshakes)))
+}
+
+func TestUpgraderSessionResumptionDisabled(t *testing.T) {
+	require := require.New(t)
+
+	clientConfig, _ := newTestTLSConfig(require)
+	serverConfig, _ := newTestTLSConfig(require)
+	serverConfig.SessionTicketsDisabled = true
+
+	clientMetrics := newTestUpgraderMetrics()
+	client := NewTLSClientUpgrader(clientConfig, clientMetrics)
+	server := NewTLSServerUpgrader(serverConfig, newTestUpgraderMetrics())
+	listener := newTestListener(t)
+
+	upgrade(require, listener, client, server)
+	upgrade(require, listener, client, server)
+
+	require.Equal(2, int(testutil.ToFloat64(clientMetrics.FullHandshakes)))
+	require.Zero(testutil.ToFloat64(clientMetrics.ResumedHandshakes))
+}
+
+func TestUpgraderNegotiatedParameters(t *testing.T) {
+	require := require.New(t)
+
+	clientConfig, _ := newTestTLSConfig(require)
+	serverConfig, _ := newTestTLSConfig(require)
+
+	// Allow TLS 1.2 on the server but limit the client to it.
+	serverConfig.MinVersion = tls.VersionTLS12
+	serverConfig.CipherSuites = []uint16{
+		tls.TLS_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	}
+	clientConfig.MinVersion = tls.VersionTLS12
+	clientConfig.MaxVersion = tls.VersionTLS12
+
+	clientMetrics := newTestUpgraderMetrics()
+	client := NewTLSClientUpgrader(clientConfig, clientMetrics)
+	server := NewTLSServerUpgrader(serverConfig, newTestUpgraderMetrics())
+	listener := newTestListener(t)
+
+	upgrade(require, listener, client, server)
+
+	require.Equal(1, testutil.CollectAndCount(clientMetrics.NegotiatedParameters))
+	require.Equal(1, int(testutil.ToFloat64(clientMetrics.NegotiatedParameters.With(prometheus.Labels{
+		versionLabel:     "1.2",
+		cipherSuiteLabel: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	}))))
+}
+
+func TestUpgraderRejectsCipherSuite(t *testing.T) {
+	require := require.New(t)
+
+	clientConfig, _ := newTestTLSConfig(require)
+	serverConfig, _ := newTestTLSConfig(require)
+
+	// crypto/tls never prefers TLS_AES_256_GCM_SHA384, so the negotiated
+	// cipher suite isn't accepted by the server.
+	serverConfig.CipherSuites = []uint16{tls.TLS_AES_256_GCM_SHA384}
+
+	serverMetrics := newTestUpgraderMetrics()
+	client := NewTLSClientUpgrader(clientConfig, newTestUpgraderMetrics())
+	server := NewTLSServerUpgrader(serverConfig, serverMetrics)
+	listener := newTestListener(t)
+
+	serverErr := make(chan error)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+
+		_, _, _, err = server.Upgrade(conn)
+		serverErr <- err
+	}()
+
+	clientConn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(err)
+	defer clientConn.Close()
+
+	// The client may finish the handshake before the server rejects it.
+	_, _, _, _ = client.Upgrade(clientConn)
+
+	err = <-serverErr
+	require.ErrorIs(err, errCipherSuiteNotAllowed)
+	require.Equal(1, int(testutil.ToFloat64(serverMetrics.RejectedCipherSuites)))
+	require.Zero(testutil.CollectAndCount(serverMetrics.NegotiatedParameters))
+}
+
+func TestNewSessionTicketKeyRotatorNoKeys(t *testing.T) {
+	_, err := NewSessionTicketKeyRotator(&tls.Config{}, 0) // #nosec G402
+	require.ErrorIs(t, err, errNoSessionTicketKeys)
+}
@@ -0,0 +1,248 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/buffer"
+)
+
+const (
+	// pingTicksPerFrequency is the number of times per ping frequency that the
+	// worker pool checks which peers are due to be pinged. A larger number
+	// makes the ping interval of each peer more precise at the cost of more
+	// frequent scans.
+	pingTicksPerFrequency = 8
+
+	initialReadyQueueSize = 1024
+)
+
+var (
+	errInvalidWorkerPoolSize = errors.New("worker pool size must be positive")
+	errInvalidPingFrequency  = errors.New("ping frequency must be positive")
+)
+
+type work uint8
+
+const (
+	workPing work = 1 << iota
+	workPeerList
+	workClose
+)
+
+// workHandler performs the requested work on behalf of a peer. It returns
+// false if the peer should no longer be scheduled.
+type workHandler func(w work) bool
+
+type poolEntry struct {
+	handler workHandler
+
+	// The following fields are protected by the lock of the worker pool.
+
+	// pending is the work that has been requested but not yet handed to the
+	// handler.
+	pending work
+	// scheduled is true if the entry is either in the ready queue or
+	// currently being handled by a worker.
+	scheduled bool
+	// removed is true once the handler has returned false.
+	removed  bool
+	nextPing time.Time
+}
+
+// WorkerPool performs the periodic and on-demand network maintenance of peers,
+// such as sending pings and gossiping peer lists, on a bounded number of
+// goroutines rather than on a dedicated goroutine per peer.
+//
+// The work of a peer is never handled concurrently, and a peer is only ever
+// placed in the ready queue once, regardless of how much work it has pending.
+// This ensures that peers are serviced in a round-robin fashion so that a
+// single peer can't starve the others.
+//
+// Only the network maintenance is handled by the pool. Every peer still reads
+// and writes messages on dedicated goroutines, as reading from the connection
+// blocks until the remote peer sends data.
+type WorkerPool struct {
+	pingFrequency time.Duration
+
+	lock sync.Mutex
+	cond *sync.Cond
+	// entries contains all the peers that are currently registered.
+	entries map[*poolEntry]struct{}
+	// ready contains the peers that have pending work.
+	ready  buffer.Deque[*poolEntry]
+	closed bool
+
+	onClose chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewWorkerPool returns a new worker pool running [size] workers that pings
+// each registered peer every [pingFrequency].
+func NewWorkerPool(size int, pingFrequency time.Duration) (*WorkerPool, error) {
+	if size <= 0 {
+		return nil, errInvalidWorkerPoolSize
+	}
+	if pingFrequency <= 0 {
+		return nil, errInvalidPingFrequency
+	}
+
+	wp := &WorkerPool{
+		pingFrequency: pingFrequency,
+		entries:       make(map[*poolEntry]struct{}),
+		ready:         buffer.NewUnboundedDeque[*poolEntry](initialReadyQueueSize),
+		onClose:       make(chan struct{}),
+	}
+	wp.cond = sync.NewCond(&wp.lock)
+
+	wp.wg.Add(size + 1)
+	for i := 0; i < size; i++ {
+		go wp.runWorker()
+	}
+	go wp.runPingTicker()
+	return wp, nil
+}
+
+// register adds [handler] to the pool. Returns nil if the pool is closed.
+func (wp *WorkerPool) register(handler workHandler) *poolEntry {
+	wp.lock.Lock()
+	defer wp.lock.Unlock()
+
+	if wp.closed {
+		return nil
+	}
+
+	e := &poolEntry{
+		handler:  handler,
+		nextPing: time.Now().Add(wp.pingFrequency),
+	}
+	wp.entries[e] = struct{}{}
+	return e
+}
+
+// schedule marks [w] as pending for [e] and places [e] in the ready queue if
+// it isn't already scheduled.
+func (wp *WorkerPool) schedule(e *poolEntry, w work) {
+	wp.lock.Lock()
+	defer wp.lock.Unlock()
+
+	wp.scheduleLocked(e, w)
+}
+
+func (wp *WorkerPool) scheduleLocked(e *poolEntry, w work) {
+	if e.removed {
+		return
+	}
+
+	e.pending |= w
+	if e.scheduled {
+		return
+	}
+	e.scheduled = true
+	wp.ready.PushRight(e)
+	wp.cond.Signal()
+}
+
+// Close stops the pool once all the registered peers have been removed. Peers
+// registered after Close is called must be handled outside of the pool.
+//
+// Close doesn't block.
+func (wp *WorkerPool) Close() {
+	wp.lock.Lock()
+	defer wp.lock.Unlock()
+
+	if wp.closed {
+		return
+	}
+	wp.closed = true
+	close(wp.onClose)
+	wp.cond.Broadcast()
+}
+
+// Wait blocks until all the goroutines of the pool have exited.
+func (wp *WorkerPool) Wait() {
+	wp.wg.Wait()
+}
+
+func (wp *WorkerPool) runWorker() {
+	defer wp.wg.Done()
+
+	wp.lock.Lock()
+	defer wp.lock.Unlock()
+
+	for {
+		e, ok := wp.ready.PopLeft()
+		if !ok {
+			// Once the pool is closed, the workers must keep running until all
+			// the registered peers have been removed so that every peer is
+			// able to finish closing.
+			if wp.closed && len(wp.entries) == 0 {
+				// Wake up any other workers so they can exit as well.
+				wp.cond.Broadcast()
+				return
+			}
+			wp.cond.Wait()
+			continue
+		}
+
+		w := e.pending
+		e.pending = 0
+
+		wp.lock.Unlock()
+		keep := e.handler(w)
+		wp.lock.Lock()
+
+		if !keep {
+			e.removed = true
+			e.scheduled = false
+			delete(wp.entries, e)
+			continue
+		}
+
+		if e.pending == 0 {
+			e.scheduled = false
+			continue
+		}
+
+		// Work was requested while this peer was being handled. Place it at
+		// the back of the queue to give other peers a turn.
+		wp.ready.PushRight(e)
+	}
+}
+
+func (wp *WorkerPool) runPingTicker() {
+	defer wp.wg.Done()
+
+	tickFrequency := wp.pingFrequency / pingTicksPerFrequency
+	if tickFrequency <= 0 {
+		tickFrequency = wp.pingFrequency
+	}
+	ticker := time.NewTicker(tickFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			wp.schedulePings(now)
+		case <-wp.onClose:
+			return
+		}
+	}
+}
+
+func (wp *WorkerPool) schedulePings(now time.Time) {
+	wp.lock.Lock()
+	defer wp.lock.Unlock()
+
+	for e := range wp.entries {
+		if now.Before(e.nextPing) {
+			continue
+		}
+		e.nextPing = now.Add(wp.pingFrequency)
+		wp.scheduleLocked(e, workPing)
+	}
+}
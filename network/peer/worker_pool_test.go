@@ -0,0 +1,309 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestNewWorkerPoolInvalid(t *testing.T) {
+	_, err := NewWorkerPool(0, time.Second)
+	require.ErrorIs(t, err, errInvalidWorkerPoolSize)
+
+	_, err = NewWorkerPool(1, 0)
+	require.ErrorIs(t, err, errInvalidPingFrequency)
+}
+
+func TestWorkerPoolCoalescesWork(t *testing.T) {
+	require := require.New(t)
+
+	wp, err := NewWorkerPool(4, time.Hour)
+	require.NoError(err)
+
+	var (
+		running = make(chan struct{})
+		release = make(chan struct{})
+		handled = make(chan work, 2)
+	)
+	e := wp.register(func(w work) bool {
+		if w&workPeerList != 0 {
+			running <- struct{}{}
+			<-release
+		}
+		handled <- w
+		return w&workClose == 0
+	})
+	require.NotNil(e)
+
+	wp.schedule(e, workPeerList)
+	<-running
+
+	// While the handler is running, new work is only recorded.
+	wp.schedule(e, workPing)
+	wp.schedule(e, workPing)
+	wp.schedule(e, workClose)
+	close(release)
+
+	require.Equal(workPeerList, <-handled)
+	require.Equal(workPing|workClose, <-handled)
+
+	wp.Close()
+	wp.Wait()
+
+	// Work scheduled after the handler was removed is dropped.
+	wp.schedule(e, workPing)
+	require.Empty(handled)
+
+	// Nothing can be registered into a closed pool.
+	require.Nil(wp.register(func(work) bool { return true }))
+}
+
+func TestWorkerPoolFairness(t *testing.T) {
+	require := require.New(t)
+
+	wp, err := NewWorkerPool(1, time.Hour)
+	require.NoError(err)
+
+	var (
+		busy       *poolEntry
+		busyRuns   int
+		quietRanAt = make(chan int, 1)
+		lock       sync.Mutex
+	)
+	// The busy entry always requests more work, which would starve the other
+	// entry if it wasn't placed at the back of the queue.
+	lock.Lock()
+	busy = wp.register(func(w work) bool {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if w&workClose != 0 {
+			return false
+		}
+		busyRuns++
+		wp.schedule(busy, workPeerList)
+		return true
+	})
+	quiet := wp.register(func(w work) bool {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if w&workClose != 0 {
+			return false
+		}
+		quietRanAt <- busyRuns
+		return true
+	})
+	wp.schedule(busy, workPeerList)
+	wp.schedule(quiet, workPeerList)
+	lock.Unlock()
+
+	require.LessOrEqual(<-quietRanAt, 2)
+
+	wp.schedule(busy, workClose)
+	wp.schedule(quiet, workClose)
+	wp.Close()
+	wp.Wait()
+}
+
+func TestWorkerPoolPings(t *testing.T) {
+	require := require.New(t)
+
+	wp, err := NewWorkerPool(1, time.Millisecond)
+	require.NoError(err)
+
+	pinged := make(chan struct{}, 1)
+	e := wp.register(func(w work) bool {
+		if w&workClose != 0 {
+			return false
+		}
+		if w&workPing != 0 {
+			select {
+			case pinged <- struct{}{}:
+			default:
+			}
+		}
+		return true
+	})
+	require.NotNil(e)
+
+	<-pinged
+
+	wp.schedule(e, workClose)
+	wp.Close()
+	wp.Wait()
+}
+
+func TestPeerWithWorkerPool(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t, nil)
+
+	wp, err := NewWorkerPool(1, 10*time.Millisecond)
+	require.NoError(err)
+	rawPeer0.config.WorkerPool = wp
+	rawPeer1.config.WorkerPool = wp
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	// Pings are sent by the pool, so the peers should learn their RTTs.
+	require.Eventually(func() bool {
+		return peer0.Info().RTT.Samples > 0 && peer1.Info().RTT.Samples > 0
+	}, 5*time.Second, time.Millisecond)
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+
+	wp.Close()
+	wp.Wait()
+}
+
+// BenchmarkNetworkMessageScheduling compares running the network maintenance
+// of every peer on a dedicated goroutine against running it on a worker pool.
+// Each iteration requests a unit of work from every peer and waits for all of
+// it to be performed. Both modes also start the reader and writer goroutines
+// that every peer keeps, so the reported goroutine counts are the ones a node
+// with [numPeers] connections would run.
+func BenchmarkNetworkMessageScheduling(b *testing.B) {
+	for _, numPeers := range []int{1_000, 5_000, 10_000} {
+		b.Run(fmt.Sprintf("goroutine_per_peer_%d", numPeers), func(b *testing.B) {
+			benchmarkGoroutinePerPeer(b, numPeers)
+		})
+		b.Run(fmt.Sprintf("worker_pool_%d", numPeers), func(b *testing.B) {
+			benchmarkWorkerPool(b, numPeers)
+		})
+	}
+}
+
+func benchmarkGoroutinePerPeer(b *testing.B, numPeers int) {
+	var (
+		wg      sync.WaitGroup
+		done    = make(chan struct{})
+		signals = make([]chan struct{}, numPeers)
+	)
+	startReadersAndWriters(numPeers, done)
+	for i := range signals {
+		signal := make(chan struct{}, 1)
+		signals[i] = signal
+		go func() {
+			// Mirrors the select loop of a dedicated peer goroutine.
+			ticker := time.NewTicker(time.Hour)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-signal:
+					wg.Done()
+				case <-ticker.C:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	numGoroutines := runtime.NumGoroutine()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		wg.Add(numPeers)
+		for _, signal := range signals {
+			signal <- struct{}{}
+		}
+		wg.Wait()
+	}
+	b.StopTimer()
+	reportGoroutines(b, numGoroutines, numPeers)
+	close(done)
+}
+
+func benchmarkWorkerPool(b *testing.B, numPeers int) {
+	wp, err := NewWorkerPool(runtime.NumCPU(), time.Hour)
+	require.NoError(b, err)
+
+	var (
+		wg      sync.WaitGroup
+		done    = make(chan struct{})
+		entries = make([]*poolEntry, numPeers)
+	)
+	startReadersAndWriters(numPeers, done)
+	for i := range entries {
+		entries[i] = wp.register(func(w work) bool {
+			if w&workClose != 0 {
+				return false
+			}
+			wg.Done()
+			return true
+		})
+	}
+
+	numGoroutines := runtime.NumGoroutine()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		wg.Add(numPeers)
+		for _, e := range entries {
+			wp.schedule(e, workPeerList)
+		}
+		wg.Wait()
+	}
+	b.StopTimer()
+	reportGoroutines(b, numGoroutines, numPeers)
+
+	for _, e := range entries {
+		wp.schedule(e, workClose)
+	}
+	wp.Close()
+	wp.Wait()
+	close(done)
+}
+
+// startReadersAndWriters starts the two goroutines that every peer uses to
+// read and write messages, regardless of how its maintenance is scheduled.
+func startReadersAndWriters(numPeers int, done <-chan struct{}) {
+	for i := 0; i < 2*numPeers; i++ {
+		go func() {
+			<-done
+		}()
+	}
+}
+
+func reportGoroutines(b *testing.B, numGoroutines int, numPeers int) {
+	b.ReportMetric(float64(numGoroutines), "goroutines")
+	b.ReportMetric(float64(numGoroutines)/float64(numPeers), "goroutines/peer")
+}
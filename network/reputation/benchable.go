@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+)
+
+var _ benchlist.Benchable = (*benchable)(nil)
+
+type benchable struct {
+	benchlist.Benchable
+	store Store
+}
+
+// NewBenchable returns a Benchable that forwards to [b] and records in [store]
+// every time a validator is benched.
+func NewBenchable(b benchlist.Benchable, store Store) benchlist.Benchable {
+	return &benchable{
+		Benchable: b,
+		store:     store,
+	}
+}
+
+func (b *benchable) Benched(chainID ids.ID, nodeID ids.NodeID) {
+	b.Benchable.Benched(chainID, nodeID)
+	b.store.RecordBenched(nodeID)
+}
@@ -0,0 +1,329 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"encoding/json"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+// Reputations whose penalty decayed below this value are dropped when the
+// store is loaded and every [Config.PenaltyHalflife], so that the store doesn't
+// grow with every node ID that ever misbehaved.
+const minPenalty = .01
+
+var _ Store = (*store)(nil)
+
+// Config specifies how misbehaviors are penalized.
+type Config struct {
+	// If false, reputations aren't recorded and all peers are allowed.
+	Enabled bool `json:"enabled"`
+
+	// Penalty added every time a peer fails the handshake.
+	HandshakeFailurePenalty float64 `json:"handshakeFailurePenalty"`
+
+	// Penalty added every time a peer sends an invalid message.
+	InvalidMessagePenalty float64 `json:"invalidMessagePenalty"`
+
+	// Penalty added every time a peer is benched on a chain.
+	BenchedPenalty float64 `json:"benchedPenalty"`
+
+	// PenaltyHalflife is the duration after which the penalty of a peer that
+	// stopped misbehaving is halved. Must be > 0.
+	PenaltyHalflife time.Duration `json:"penaltyHalflife"`
+
+	// MaxPenalty is the penalty above which a peer isn't allowed to connect
+	// to this node and isn't gossiped to. If 0, peers are never disallowed.
+	MaxPenalty float64 `json:"maxPenalty"`
+
+	// MaxTrackedPeers is the maximum number of peers whose reputation is
+	// recorded. Once reached, the reputation with the lowest penalty is
+	// forgotten to record the misbehavior of a new peer, so that a peer
+	// rotating its node ID can't grow the store without bound. Must be > 0.
+	MaxTrackedPeers int `json:"maxTrackedPeers"`
+}
+
+// Reputation is the record of the misbehaviors of a peer.
+type Reputation struct {
+	HandshakeFailures uint64 `json:"handshakeFailures"`
+	InvalidMessages   uint64 `json:"invalidMessages"`
+	Benchings         uint64 `json:"benchings"`
+
+	// Penalty is the sum of the penalties of the misbehaviors, decayed to the
+	// time the reputation was read.
+	Penalty float64 `json:"penalty"`
+
+	// LastMisbehavior is the time the last misbehavior was recorded.
+	LastMisbehavior time.Time `json:"lastMisbehavior"`
+}
+
+// Store records the misbehaviors of peers. Reputations are persisted, so that
+// they survive restarts.
+type Store interface {
+	// RecordHandshakeFailure records that [nodeID] failed the handshake.
+	RecordHandshakeFailure(nodeID ids.NodeID)
+
+	// RecordInvalidMessage records that [nodeID] sent an invalid message.
+	RecordInvalidMessage(nodeID ids.NodeID)
+
+	// RecordBenched records that [nodeID] was benched on a chain.
+	RecordBenched(nodeID ids.NodeID)
+
+	// Allowed returns false if the penalty of [nodeID] exceeds the max
+	// penalty.
+	Allowed(nodeID ids.NodeID) bool
+
+	// Get returns the reputation of [nodeID]. Returns false if no
+	// misbehavior of [nodeID] was recorded.
+	Get(nodeID ids.NodeID) (Reputation, bool)
+
+	// GetAll returns the reputations of all the peers whose misbehaviors were
+	// recorded.
+	GetAll() map[ids.NodeID]Reputation
+
+	// Reset forgets the misbehaviors of [nodeID].
+	Reset(nodeID ids.NodeID) error
+
+	// ResetAll forgets the misbehaviors of all peers.
+	ResetAll() error
+}
+
+type store struct {
+	config Config
+	log    logging.Logger
+	clock  mockable.Clock
+
+	lock sync.RWMutex
+	// nodeID -> reputation, with the penalty as of the last misbehavior
+	reputations map[ids.NodeID]*Reputation
+	// nodeID -> serialized reputation
+	db database.Database
+	// the last time the reputations whose penalty decayed away were dropped
+	lastPrune time.Time
+}
+
+// NewStore returns a store that persists reputations into [db], loaded with
+// the reputations previously persisted into [db].
+func NewStore(config Config, db database.Database, log logging.Logger) (Store, error) {
+	s := &store{
+		config:      config,
+		log:         log,
+		reputations: make(map[ids.NodeID]*Reputation),
+		db:          db,
+	}
+	return s, s.load()
+}
+
+func (s *store) load() error {
+	it := s.db.NewIterator()
+	defer it.Release()
+
+	now := s.clock.Time()
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return err
+		}
+		reputation := &Reputation{}
+		if err := json.Unmarshal(it.Value(), reputation); err != nil {
+			return err
+		}
+		if s.decayedPenalty(reputation, now) < minPenalty {
+			if err := s.db.Delete(it.Key()); err != nil {
+				return err
+			}
+			continue
+		}
+		s.reputations[nodeID] = reputation
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	// [MaxTrackedPeers] may have been lowered since the reputations were
+	// persisted.
+	for len(s.reputations) > s.config.MaxTrackedPeers {
+		s.evict(now)
+	}
+	s.lastPrune = now
+	return nil
+}
+
+func (s *store) RecordHandshakeFailure(nodeID ids.NodeID) {
+	s.record(nodeID, s.config.HandshakeFailurePenalty, func(r *Reputation) {
+		r.HandshakeFailures++
+	})
+}
+
+func (s *store) RecordInvalidMessage(nodeID ids.NodeID) {
+	s.record(nodeID, s.config.InvalidMessagePenalty, func(r *Reputation) {
+		r.InvalidMessages++
+	})
+}
+
+func (s *store) RecordBenched(nodeID ids.NodeID) {
+	s.record(nodeID, s.config.BenchedPenalty, func(r *Reputation) {
+		r.Benchings++
+	})
+}
+
+func (s *store) record(nodeID ids.NodeID, penalty float64, count func(*Reputation)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.clock.Time()
+	if now.Sub(s.lastPrune) >= s.config.PenaltyHalflife {
+		s.prune(now)
+	}
+
+	reputation, ok := s.reputations[nodeID]
+	if !ok {
+		if len(s.reputations) >= s.config.MaxTrackedPeers {
+			s.evict(now)
+		}
+		reputation = &Reputation{}
+		s.reputations[nodeID] = reputation
+	}
+	count(reputation)
+	reputation.Penalty = s.decayedPenalty(reputation, now) + penalty
+	reputation.LastMisbehavior = now
+
+	// Failing to persist the reputation only means that it may be lost on
+	// restart, so it doesn't interrupt the handling of the peer.
+	reputationBytes, err := json.Marshal(reputation)
+	if err == nil {
+		err = s.db.Put(nodeID[:], reputationBytes)
+	}
+	if err != nil {
+		s.log.Warn("failed to persist peer reputation",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *store) Allowed(nodeID ids.NodeID) bool {
+	if s.config.MaxPenalty == 0 {
+		return true
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	reputation, ok := s.reputations[nodeID]
+	return !ok || s.decayedPenalty(reputation, s.clock.Time()) <= s.config.MaxPenalty
+}
+
+func (s *store) Get(nodeID ids.NodeID) (Reputation, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	reputation, ok := s.reputations[nodeID]
+	if !ok {
+		return Reputation{}, false
+	}
+	return s.current(reputation, s.clock.Time()), true
+}
+
+func (s *store) GetAll() map[ids.NodeID]Reputation {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	now := s.clock.Time()
+	reputations := make(map[ids.NodeID]Reputation, len(s.reputations))
+	for nodeID, reputation := range s.reputations {
+		reputations[nodeID] = s.current(reputation, now)
+	}
+	return reputations
+}
+
+func (s *store) Reset(nodeID ids.NodeID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.reputations, nodeID)
+	return s.db.Delete(nodeID[:])
+}
+
+func (s *store) ResetAll() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for nodeID := range s.reputations {
+		if err := s.db.Delete(nodeID[:]); err != nil {
+			return err
+		}
+		delete(s.reputations, nodeID)
+	}
+	return nil
+}
+
+// prune forgets the reputations whose penalty decayed away by [now].
+//
+// Assumes [s.lock] is held.
+func (s *store) prune(now time.Time) {
+	for nodeID, reputation := range s.reputations {
+		if s.decayedPenalty(reputation, now) < minPenalty {
+			s.forget(nodeID)
+		}
+	}
+	s.lastPrune = now
+}
+
+// evict forgets the reputation with the lowest penalty at [now]. Peers that
+// repeatedly misbehave have the highest penalties, so they are the last ones
+// to be forgotten.
+//
+// Assumes [s.lock] is held.
+func (s *store) evict(now time.Time) {
+	var (
+		evictedNodeID  ids.NodeID
+		evictedPenalty = math.Inf(1)
+	)
+	for nodeID, reputation := range s.reputations {
+		if penalty := s.decayedPenalty(reputation, now); penalty < evictedPenalty {
+			evictedNodeID = nodeID
+			evictedPenalty = penalty
+		}
+	}
+	s.forget(evictedNodeID)
+}
+
+// forget removes the reputation of [nodeID] from memory and from the database.
+//
+// Assumes [s.lock] is held.
+func (s *store) forget(nodeID ids.NodeID) {
+	delete(s.reputations, nodeID)
+	if err := s.db.Delete(nodeID[:]); err != nil {
+		s.log.Warn("failed to delete peer reputation",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+}
+
+// current returns a copy of [reputation] with its penalty decayed to [now].
+func (s *store) current(reputation *Reputation, now time.Time) Reputation {
+	current := *reputation
+	current.Penalty = s.decayedPenalty(reputation, now)
+	return current
+}
+
+// decayedPenalty returns the penalty of [reputation] decayed to [now].
+func (s *store) decayedPenalty(reputation *Reputation, now time.Time) float64 {
+	elapsed := now.Sub(reputation.LastMisbehavior)
+	if elapsed <= 0 {
+		return reputation.Penalty
+	}
+	return reputation.Penalty * math.Exp2(-float64(elapsed)/float64(s.config.PenaltyHalflife))
+}
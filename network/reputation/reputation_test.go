@@ -0,0 +1,224 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var testConfig = Config{
+	Enabled:                 true,
+	HandshakeFailurePenalty: 4,
+	InvalidMessagePenalty:   2,
+	BenchedPenalty:          1,
+	PenaltyHalflife:         time.Hour,
+	MaxPenalty:              5,
+	MaxTrackedPeers:         10,
+}
+
+func TestStore(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	storeIntf, err := NewStore(testConfig, db, logging.NoLog{})
+	require.NoError(err)
+	s := storeIntf.(*store)
+
+	now := time.Unix(1_000_000, 0).UTC()
+	s.clock.Set(now)
+
+	nodeID := ids.GenerateTestNodeID()
+	_, ok := s.Get(nodeID)
+	require.False(ok)
+	require.True(s.Allowed(nodeID))
+
+	s.RecordHandshakeFailure(nodeID)
+	s.RecordInvalidMessage(nodeID)
+	require.False(s.Allowed(nodeID))
+
+	reputation, ok := s.Get(nodeID)
+	require.True(ok)
+	require.Equal(Reputation{
+		HandshakeFailures: 1,
+		InvalidMessages:   1,
+		Penalty:           6,
+		LastMisbehavior:   now,
+	}, reputation)
+
+	// The penalty decays once the peer stops misbehaving.
+	s.clock.Set(now.Add(time.Hour))
+	require.True(s.Allowed(nodeID))
+
+	s.RecordBenched(nodeID)
+	reputation, ok = s.Get(nodeID)
+	require.True(ok)
+	require.Equal(uint64(1), reputation.Benchings)
+	require.InDelta(4, reputation.Penalty, .001)
+
+	otherNodeID := ids.GenerateTestNodeID()
+	s.RecordInvalidMessage(otherNodeID)
+	require.Len(s.GetAll(), 2)
+
+	require.NoError(s.Reset(nodeID))
+	_, ok = s.Get(nodeID)
+	require.False(ok)
+	require.Len(s.GetAll(), 1)
+
+	require.NoError(s.ResetAll())
+	require.Empty(s.GetAll())
+}
+
+func TestStorePersistence(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	storeIntf, err := NewStore(testConfig, db, logging.NoLog{})
+	require.NoError(err)
+	s := storeIntf.(*store)
+
+	now := time.Unix(1_000_000, 0).UTC()
+	s.clock.Set(now)
+
+	forgivenNodeID := ids.GenerateTestNodeID()
+	s.RecordBenched(forgivenNodeID)
+
+	lastMisbehavior := now.Add(8 * time.Hour)
+	s.clock.Set(lastMisbehavior)
+	misbehavingNodeID := ids.GenerateTestNodeID()
+	s.RecordHandshakeFailure(misbehavingNodeID)
+	s.RecordHandshakeFailure(misbehavingNodeID)
+	s.RecordHandshakeFailure(misbehavingNodeID)
+
+	// Reload the reputations once the penalty of [forgivenNodeID] decayed
+	// away.
+	s = &store{
+		config:      testConfig,
+		log:         logging.NoLog{},
+		reputations: make(map[ids.NodeID]*Reputation),
+		db:          db,
+	}
+	s.clock.Set(now.Add(9 * time.Hour))
+	require.NoError(s.load())
+
+	reputation, ok := s.Get(misbehavingNodeID)
+	require.True(ok)
+	require.Equal(uint64(3), reputation.HandshakeFailures)
+	require.Equal(lastMisbehavior, reputation.LastMisbehavior)
+	require.False(s.Allowed(misbehavingNodeID))
+
+	_, ok = s.Get(forgivenNodeID)
+	require.False(ok)
+	has, err := db.Has(forgivenNodeID[:])
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestStoreMaxTrackedPeers(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig
+	config.MaxTrackedPeers = 2
+	db := memdb.New()
+	storeIntf, err := NewStore(config, db, logging.NoLog{})
+	require.NoError(err)
+	s := storeIntf.(*store)
+
+	misbehavingNodeID := ids.GenerateTestNodeID()
+	s.RecordHandshakeFailure(misbehavingNodeID)
+	s.RecordHandshakeFailure(misbehavingNodeID)
+
+	// Every new peer evicts the reputation with the lowest penalty, so the
+	// peer that misbehaved the most is never forgotten.
+	var lastNodeID ids.NodeID
+	for i := 0; i < 10; i++ {
+		lastNodeID = ids.GenerateTestNodeID()
+		s.RecordHandshakeFailure(lastNodeID)
+		require.Len(s.GetAll(), 2)
+	}
+	_, ok := s.Get(misbehavingNodeID)
+	require.True(ok)
+	_, ok = s.Get(lastNodeID)
+	require.True(ok)
+
+	count, err := database.Count(db)
+	require.NoError(err)
+	require.Equal(2, count)
+}
+
+func TestStorePrune(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	storeIntf, err := NewStore(testConfig, db, logging.NoLog{})
+	require.NoError(err)
+	s := storeIntf.(*store)
+
+	now := time.Unix(1_000_000, 0).UTC()
+	s.clock.Set(now)
+	s.lastPrune = now
+
+	forgivenNodeID := ids.GenerateTestNodeID()
+	s.RecordBenched(forgivenNodeID)
+
+	// Once the penalty of [forgivenNodeID] decayed away, it is dropped the
+	// next time a misbehavior is recorded.
+	s.clock.Set(now.Add(9 * time.Hour))
+	s.RecordInvalidMessage(ids.GenerateTestNodeID())
+
+	_, ok := s.Get(forgivenNodeID)
+	require.False(ok)
+	has, err := db.Has(forgivenNodeID[:])
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestStoreNoMaxPenalty(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig
+	config.MaxPenalty = 0
+	s, err := NewStore(config, memdb.New(), logging.NoLog{})
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 10; i++ {
+		s.RecordHandshakeFailure(nodeID)
+	}
+	require.True(s.Allowed(nodeID))
+}
+
+func TestBenchable(t *testing.T) {
+	require := require.New(t)
+
+	s, err := NewStore(testConfig, memdb.New(), logging.NoLog{})
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	benched := false
+	b := NewBenchable(&benchlist.TestBenchable{
+		T: t,
+		BenchedF: func(benchedChainID ids.ID, benchedNodeID ids.NodeID) {
+			require.Equal(chainID, benchedChainID)
+			require.Equal(nodeID, benchedNodeID)
+			benched = true
+		},
+	}, s)
+
+	b.Benched(chainID, nodeID)
+	require.True(benched)
+
+	reputation, ok := s.Get(nodeID)
+	require.True(ok)
+	require.Equal(uint64(1), reputation.Benchings)
+}
@@ -83,6 +83,7 @@ func NewTestNetwork(
 		metrics,
 		"",
 		constants.DefaultNetworkCompressionType,
+		constants.DefaultNetworkCompressionZstdLevel,
 		constants.DefaultNetworkMaximumInboundTimeout,
 	)
 	if err != nil {
@@ -140,10 +141,15 @@ func NewTestNetwork(
 		ProxyEnabled:           constants.DefaultNetworkTCPProxyEnabled,
 		ProxyReadHeaderTimeout: constants.DefaultNetworkTCPProxyReadTimeout,
 
+		TLSSessionResumptionEnabled:     constants.DefaultNetworkTLSSessionResumptionEnabled,
+		TLSSessionTicketKeyRotationFreq: constants.DefaultNetworkTLSSessionTicketKeyRotationFreq,
+		TLSSessionTicketKeys:            constants.DefaultNetworkTLSSessionTicketKeys,
+
 		DialerConfig: dialer.Config{
 			ThrottleRps:       constants.DefaultOutboundConnectionThrottlingRps,
 			ConnectionTimeout: constants.DefaultOutboundConnectionTimeout,
 		},
+		MaxConcurrentDials: constants.DefaultOutboundConnectionMaxConcurrent,
 
 		TimeoutConfig: TimeoutConfig{
 			PingPongTimeout:      constants.DefaultPingPongTimeout,
@@ -173,6 +179,7 @@ func NewTestNetwork(
 		RequireValidatorToConnect: constants.DefaultNetworkRequireValidatorToConnect,
 		PeerReadBufferSize:        constants.DefaultNetworkPeerReadBufferSize,
 		PeerWriteBufferSize:       constants.DefaultNetworkPeerWriteBufferSize,
+		PeerWorkerPoolSize:        constants.DefaultNetworkPeerWorkerPoolSize,
 	}
 
 	networkConfig.NetworkID = networkID
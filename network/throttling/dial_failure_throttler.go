@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+// maxTrackedDialDestinations bounds the number of destinations whose dial
+// failures are tracked.
+const maxTrackedDialDestinations = 4096
+
+var (
+	_ DialFailureThrottler = (*dialFailureThrottler)(nil)
+	_ DialFailureThrottler = (*noDialFailureThrottler)(nil)
+
+	ErrDestinationPenalized = errors.New("destination is penalized for repeatedly failing to be dialed")
+)
+
+// DialFailureThrottler backs off destinations that repeatedly fail to be
+// dialed, so that unreachable destinations don't consume the dial budget of
+// reachable destinations.
+type DialFailureThrottler interface {
+	// Acquire returns nil if [dest] may be dialed now. If [dest] is currently
+	// penalized, an error wrapping ErrDestinationPenalized is returned.
+	Acquire(dest string) error
+
+	// Succeeded resets the penalty of [dest].
+	Succeeded(dest string)
+
+	// Failed records a failed dial of [dest]. It returns the duration that
+	// [dest] is penalized for, which is 0 if [dest] isn't penalized.
+	Failed(dest string) time.Duration
+}
+
+type DialFailureThrottlerConfig struct {
+	// Number of consecutive failures after which a destination is penalized.
+	// If 0, destinations are never penalized.
+	Threshold int `json:"threshold"`
+	// Penalty applied once [Threshold] consecutive failures occurred. The
+	// penalty doubles with every subsequent failure.
+	InitialPenalty time.Duration `json:"initialPenalty"`
+	// Maximum penalty applied to a destination.
+	MaxPenalty time.Duration `json:"maxPenalty"`
+}
+
+type dialFailures struct {
+	numFailures    int
+	penalizedUntil time.Time
+}
+
+type dialFailureThrottler struct {
+	config DialFailureThrottlerConfig
+	clock  mockable.Clock
+
+	lock         sync.Mutex
+	destinations cache.LRU[string, *dialFailures]
+}
+
+// NewDialFailureThrottler returns a new DialFailureThrottler. If
+// [config.Threshold] is 0, destinations are never penalized.
+func NewDialFailureThrottler(config DialFailureThrottlerConfig) DialFailureThrottler {
+	if config.Threshold <= 0 {
+		return noDialFailureThrottler{}
+	}
+	return &dialFailureThrottler{
+		config: config,
+		destinations: cache.LRU[string, *dialFailures]{
+			Size: maxTrackedDialDestinations,
+		},
+	}
+}
+
+func (t *dialFailureThrottler) Acquire(dest string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	failures, ok := t.destinations.Get(dest)
+	if !ok {
+		return nil
+	}
+	now := t.clock.Time()
+	if !now.Before(failures.penalizedUntil) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s for %s",
+		ErrDestinationPenalized,
+		dest,
+		failures.penalizedUntil.Sub(now),
+	)
+}
+
+func (t *dialFailureThrottler) Succeeded(dest string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.destinations.Evict(dest)
+}
+
+func (t *dialFailureThrottler) Failed(dest string) time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	failures, ok := t.destinations.Get(dest)
+	if !ok {
+		failures = &dialFailures{}
+		t.destinations.Put(dest, failures)
+	}
+	failures.numFailures++
+	if failures.numFailures < t.config.Threshold {
+		return 0
+	}
+
+	penalty := t.config.InitialPenalty
+	for i := t.config.Threshold; i < failures.numFailures && penalty < t.config.MaxPenalty; i++ {
+		penalty *= 2
+	}
+	if penalty > t.config.MaxPenalty {
+		penalty = t.config.MaxPenalty
+	}
+	failures.penalizedUntil = t.clock.Time().Add(penalty)
+	return penalty
+}
+
+type noDialFailureThrottler struct{}
+
+func (noDialFailureThrottler) Acquire(string) error {
+	return nil
+}
+
+func (noDialFailureThrottler) Succeeded(string) {}
+
+func (noDialFailureThrottler) Failed(string) time.Duration {
+	return 0
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialFailureThrottler(t *testing.T) {
+	require := require.New(t)
+
+	throttlerIntf := NewDialFailureThrottler(DialFailureThrottlerConfig{
+		Threshold:      2,
+		InitialPenalty: time.Second,
+		MaxPenalty:     3 * time.Second,
+	})
+	require.IsType(&dialFailureThrottler{}, throttlerIntf)
+	throttler := throttlerIntf.(*dialFailureThrottler)
+
+	now := time.Now()
+	throttler.clock.Set(now)
+
+	const (
+		dest      = "127.0.0.1:9651"
+		otherDest = "127.0.0.1:9652"
+	)
+
+	// Failures below the threshold aren't penalized.
+	require.Zero(throttler.Failed(dest))
+	require.NoError(throttler.Acquire(dest))
+
+	require.Equal(time.Second, throttler.Failed(dest))
+	err := throttler.Acquire(dest)
+	require.ErrorIs(err, ErrDestinationPenalized)
+
+	// Other destinations aren't affected.
+	require.NoError(throttler.Acquire(otherDest))
+
+	// The penalty doubles with every failure, up to the maximum.
+	require.Equal(2*time.Second, throttler.Failed(dest))
+	require.Equal(3*time.Second, throttler.Failed(dest))
+	require.Equal(3*time.Second, throttler.Failed(dest))
+
+	throttler.clock.Set(now.Add(3 * time.Second))
+	require.NoError(throttler.Acquire(dest))
+
+	// A successful dial resets the penalty.
+	throttler.Succeeded(dest)
+	require.Zero(throttler.Failed(dest))
+	require.NoError(throttler.Acquire(dest))
+}
+
+func TestNoDialFailureThrottler(t *testing.T) {
+	require := require.New(t)
+
+	throttler := NewDialFailureThrottler(DialFailureThrottlerConfig{})
+	for i := 0; i < 10; i++ {
+		require.Zero(throttler.Failed("127.0.0.1:9651"))
+	}
+	require.NoError(throttler.Acquire("127.0.0.1:9651"))
+}
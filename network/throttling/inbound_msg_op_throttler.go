@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+)
+
+const opLabel = "op"
+
+var (
+	opLabels = []string{opLabel}
+
+	errNonPositiveOpRate  = errors.New("op rate must be positive")
+	errNonPositiveOpBurst = errors.New("op max burst must be positive")
+)
+
+// See inbound_msg_throttler.go
+
+// OpRateLimit limits the rate at which a peer may send messages of an op.
+// The limit is implemented using a token bucket, where each token is 1
+// message. See https://pkg.go.dev/golang.org/x/time/rate#Limiter
+type OpRateLimit struct {
+	// Rate, in messages per second, at which a peer may send messages of the op
+	Rate float64 `json:"rate"`
+	// Max number of messages of the op a peer may send at once
+	MaxBurst int `json:"maxBurst"`
+}
+
+func (l OpRateLimit) Verify() error {
+	switch {
+	case l.Rate <= 0:
+		return errNonPositiveOpRate
+	case l.MaxBurst <= 0:
+		return errNonPositiveOpBurst
+	default:
+		return nil
+	}
+}
+
+func newInboundMsgOpThrottler(
+	namespace string,
+	registerer prometheus.Registerer,
+	opRateLimits map[message.Op]OpRateLimit,
+) (*inboundMsgOpThrottler, error) {
+	for op, limit := range opRateLimits {
+		if err := limit.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid rate limit for %s: %w", op, err)
+		}
+	}
+
+	t := &inboundMsgOpThrottler{
+		opRateLimits: opRateLimits,
+		limiters:     make(map[ids.NodeID]map[message.Op]*rate.Limiter),
+		dropped: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "op_throttler_inbound_dropped",
+				Help:      "Number of inbound messages dropped because their sender exceeded the rate limit of their op",
+			},
+			opLabels,
+		),
+	}
+	return t, registerer.Register(t.dropped)
+}
+
+// Rate-limits inbound messages based on the number of messages of a given op
+// recently received from a given node, so that a single node can't monopolize
+// the handler of an op. Unlike the other inbound throttlers, the message must
+// have been read to know its op, so messages over the limit are dropped rather
+// than delayed.
+type inboundMsgOpThrottler struct {
+	// Op --> Rate limit applied to each node. Ops without a rate limit aren't
+	// rate-limited.
+	opRateLimits map[message.Op]OpRateLimit
+	dropped      *prometheus.CounterVec
+
+	lock sync.Mutex
+	// Node ID --> Op --> token bucket based rate limiter where each token is a
+	// message of the op. Limiters are created when the node first sends a
+	// message of the op.
+	// Must only be accessed when [lock] is held.
+	limiters map[ids.NodeID]map[message.Op]*rate.Limiter
+}
+
+// Allow returns true if a message of [op] from [nodeID] may be handled, or
+// false if [nodeID] exceeded the rate limit of [op] and the message should be
+// dropped.
+func (t *inboundMsgOpThrottler) Allow(nodeID ids.NodeID, op message.Op) bool {
+	limit, ok := t.opRateLimits[op]
+	if !ok {
+		return true
+	}
+
+	t.lock.Lock()
+	nodeLimiters, ok := t.limiters[nodeID]
+	if !ok {
+		// This should never happen. If it is, the caller is misusing this
+		// struct.
+		t.lock.Unlock()
+		return true
+	}
+	limiter, ok := nodeLimiters[op]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(limit.Rate), limit.MaxBurst)
+		nodeLimiters[op] = limiter
+	}
+	t.lock.Unlock()
+
+	if limiter.Allow() {
+		return true
+	}
+	t.dropped.With(prometheus.Labels{
+		opLabel: op.String(),
+	}).Inc()
+	return false
+}
+
+func (t *inboundMsgOpThrottler) AddNode(nodeID ids.NodeID) {
+	if len(t.opRateLimits) == 0 {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.limiters[nodeID] = make(map[message.Op]*rate.Limiter, len(t.opRateLimits))
+}
+
+func (t *inboundMsgOpThrottler) RemoveNode(nodeID ids.NodeID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.limiters, nodeID)
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+)
+
+func TestInboundMsgOpThrottler(t *testing.T) {
+	require := require.New(t)
+
+	throttler, err := newInboundMsgOpThrottler(
+		"",
+		prometheus.NewRegistry(),
+		map[message.Op]OpRateLimit{
+			message.PullQueryOp: {
+				// Don't refill during the test
+				Rate:     0.0001,
+				MaxBurst: 2,
+			},
+		},
+	)
+	require.NoError(err)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID1)
+	throttler.AddNode(nodeID2)
+
+	// [nodeID1] can send up to MaxBurst messages of the op
+	require.True(throttler.Allow(nodeID1, message.PullQueryOp))
+	require.True(throttler.Allow(nodeID1, message.PullQueryOp))
+	require.False(throttler.Allow(nodeID1, message.PullQueryOp))
+
+	// Ops without a rate limit aren't rate-limited
+	for i := 0; i < 10; i++ {
+		require.True(throttler.Allow(nodeID1, message.PushQueryOp))
+	}
+
+	// Each node has its own limit
+	require.True(throttler.Allow(nodeID2, message.PullQueryOp))
+
+	dropped := throttler.dropped.With(prometheus.Labels{
+		opLabel: message.PullQueryOp.String(),
+	})
+	require.Equal(float64(1), testutil.ToFloat64(dropped))
+
+	// Re-adding a node resets its limits
+	throttler.RemoveNode(nodeID1)
+	require.Len(throttler.limiters, 1)
+	throttler.AddNode(nodeID1)
+	require.True(throttler.Allow(nodeID1, message.PullQueryOp))
+}
+
+func TestInboundMsgOpThrottlerInvalidLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		limit       OpRateLimit
+		expectedErr error
+	}{
+		{
+			name: "zero rate",
+			limit: OpRateLimit{
+				MaxBurst: 1,
+			},
+			expectedErr: errNonPositiveOpRate,
+		},
+		{
+			name: "zero max burst",
+			limit: OpRateLimit{
+				Rate: 1,
+			},
+			expectedErr: errNonPositiveOpBurst,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newInboundMsgOpThrottler(
+				"",
+				prometheus.NewRegistry(),
+				map[message.Op]OpRateLimit{
+					message.PullQueryOp: test.limit,
+				},
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -29,6 +30,13 @@ type InboundMsgThrottler interface {
 	//            given nodeID. Callers must enforce this invariant.
 	Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID) ReleaseFunc
 
+	// Returns true if a message of [op], which was read from [nodeID] after a
+	// call to Acquire, may be handled. Returns false if [nodeID] has recently
+	// sent too many messages of [op], in which case the message should be
+	// dropped.
+	// It's safe for multiple goroutines to concurrently call AllowOp.
+	AllowOp(nodeID ids.NodeID, op message.Op) bool
+
 	// Add a new node to this throttler.
 	// Must be called before Acquire(..., [nodeID]) is called.
 	// RemoveNode([nodeID]) must have been called since the last time
@@ -49,6 +57,9 @@ type InboundMsgThrottlerConfig struct {
 	CPUThrottlerConfig       SystemThrottlerConfig `json:"cpuThrottlerConfig"`
 	DiskThrottlerConfig      SystemThrottlerConfig `json:"diskThrottlerConfig"`
 	MaxProcessingMsgsPerNode uint64                `json:"maxProcessingMsgsPerNode"`
+	// Op --> Rate at which a given node may send messages of the op. Ops
+	// without an entry aren't rate-limited.
+	OpRateLimits map[message.Op]OpRateLimit `json:"opRateLimits"`
 }
 
 // Returns a new, sybil-safe inbound message throttler.
@@ -109,7 +120,16 @@ func NewInboundMsgThrottler(
 	if err != nil {
 		return nil, err
 	}
+	opThrottler, err := newInboundMsgOpThrottler(
+		namespace,
+		registerer,
+		throttlerConfig.OpRateLimits,
+	)
+	if err != nil {
+		return nil, err
+	}
 	return &inboundMsgThrottler{
+		opThrottler:        opThrottler,
 		byteThrottler:      byteThrottler,
 		bufferThrottler:    bufferThrottler,
 		bandwidthThrottler: bandwidthThrottler,
@@ -133,7 +153,13 @@ func NewInboundMsgThrottler(
 // A call to Acquire([msgSize], [nodeID]) blocks until we've secured
 // enough of both these resources to read a message of size [msgSize] from
 // [nodeID].
+//
+// Once a message is read, a call to AllowOp([nodeID], [op]) checks that
+// [nodeID] hasn't exceeded the rate limit of the message's op, if any.
 type inboundMsgThrottler struct {
+	// Rate-limits based on the number of messages of a given op recently
+	// received from a given node.
+	opThrottler *inboundMsgOpThrottler
 	// Rate-limits based on number of messages from a given node that we're
 	// currently processing.
 	bufferThrottler *inboundMsgBufferThrottler
@@ -170,12 +196,18 @@ func (t *inboundMsgThrottler) Acquire(ctx context.Context, msgSize uint64, nodeI
 	}
 }
 
+func (t *inboundMsgThrottler) AllowOp(nodeID ids.NodeID, op message.Op) bool {
+	return t.opThrottler.Allow(nodeID, op)
+}
+
 // See BandwidthThrottler.
 func (t *inboundMsgThrottler) AddNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.AddNode(nodeID)
+	t.opThrottler.AddNode(nodeID)
 }
 
 // See BandwidthThrottler.
 func (t *inboundMsgThrottler) RemoveNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.RemoveNode(nodeID)
+	t.opThrottler.RemoveNode(nodeID)
 }
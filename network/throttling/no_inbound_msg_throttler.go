@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 )
 
 var _ InboundMsgThrottler = (*noInboundMsgThrottler)(nil)
@@ -23,6 +24,10 @@ func (*noInboundMsgThrottler) Acquire(context.Context, uint64, ids.NodeID) Relea
 	return noopRelease
 }
 
+func (*noInboundMsgThrottler) AllowOp(ids.NodeID, message.Op) bool {
+	return true
+}
+
 func (*noInboundMsgThrottler) AddNode(ids.NodeID) {}
 
 func (*noInboundMsgThrottler) RemoveNode(ids.NodeID) {}
@@ -20,6 +20,9 @@ type trackedIP struct {
 	delay     time.Duration
 
 	ip ips.IPPort
+	// Time the node last disconnected from the peer. The zero time if the
+	// node wasn't connected to the peer before it started tracking [ip].
+	disconnectedAt time.Time
 
 	stopTrackingOnce sync.Once
 	onStopTracking   chan struct{}
@@ -37,6 +40,7 @@ func (ip *trackedIP) trackNewIP(newIP ips.IPPort) *trackedIP {
 	return &trackedIP{
 		delay:          ip.getDelay(),
 		ip:             newIP,
+		disconnectedAt: ip.disconnectedAt,
 		onStopTracking: make(chan struct{}),
 	}
 }
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transport
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+const TCP = "tcp"
+
+var _ Transport = (*tcp)(nil)
+
+// Transport establishes the connections between peers. The connections are
+// upgraded with TLS by the network once they are established.
+type Transport interface {
+	// Name returns the name of the transport.
+	Name() string
+
+	// Listen returns a listener accepting connections on [address].
+	Listen(address string) (net.Listener, error)
+
+	// Dial connects to [address]. If [ctx] is canceled or [timeout] elapses
+	// before the connection is established, an error is returned. If
+	// [timeout] is 0, only [ctx] bounds the dial.
+	Dial(ctx context.Context, address string, timeout time.Duration) (net.Conn, error)
+}
+
+type tcp struct {
+	network string
+}
+
+// NewTCP returns a transport that connects peers over TCP.
+// [network] is passed to net.Listen and net.Dial. Should probably be "tcp".
+func NewTCP(network string) Transport {
+	return &tcp{
+		network: network,
+	}
+}
+
+func (*tcp) Name() string {
+	return TCP
+}
+
+func (t *tcp) Listen(address string) (net.Listener, error) {
+	return net.Listen(t.network, address)
+}
+
+func (t *tcp) Dial(ctx context.Context, address string, timeout time.Duration) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: timeout}
+	return dialer.DialContext(ctx, t.network, address)
+}
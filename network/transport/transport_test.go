@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTCP(t *testing.T) {
+	require := require.New(t)
+
+	transport := NewTCP("tcp")
+	listener, err := transport.Listen("127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	accepted := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			err = conn.Close()
+		}
+		accepted <- err
+	}()
+
+	conn, err := transport.Dial(context.Background(), listener.Addr().String(), time.Second)
+	require.NoError(err)
+	require.NoError(conn.Close())
+	require.NoError(<-accepted)
+}
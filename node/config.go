@@ -7,15 +7,22 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/cache/budget"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/hooks"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/notify"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/snow/networking/watchdog"
+	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
@@ -23,6 +30,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/profiler"
+	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/selfcheck"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
@@ -71,6 +80,13 @@ type APIConfig struct {
 	KeystoreAPIEnabled bool `json:"keystoreAPIEnabled"`
 	MetricsAPIEnabled  bool `json:"metricsAPIEnabled"`
 	HealthAPIEnabled   bool `json:"healthAPIEnabled"`
+
+	// Address of the gRPC signing plugin that holds the keys of keystore
+	// users. Empty if no plugin is configured.
+	KeystoreSignerEndpoint string `json:"keystoreSignerEndpoint"`
+	// Path to the certificate authorities of the signing plugin's TLS
+	// certificate. If empty, the system's certificate authorities are used.
+	KeystoreSignerCAFile string `json:"keystoreSignerCAFile"`
 }
 
 type IPConfig struct {
@@ -81,6 +97,9 @@ type IPConfig struct {
 	AttemptedNATTraversal bool `json:"attemptedNATTraversal"`
 	// Tries to perform network address translation
 	Nat nat.Router `json:"-"`
+	// Keeps the ports of the node mapped on [Nat]. If non-nil and [Nat]
+	// supports NAT, the state of the mappings is reported by the health API.
+	PortMapper *nat.Mapper `json:"-"`
 	// The host portion of the address to listen on. The port to
 	// listen on will be sourced from IPPort.
 	//
@@ -99,6 +118,8 @@ type StakingConfig struct {
 	StakingKeyPath                string          `json:"stakingKeyPath"`
 	StakingCertPath               string          `json:"stakingCertPath"`
 	StakingSignerPath             string          `json:"stakingSignerPath"`
+	// StakingStandbyKeyPair is the zero value if no standby key pair is used.
+	StakingStandbyKeyPair staking.KeyPairPaths `json:"stakingStandbyKeyPair"`
 }
 
 type StateSyncConfig struct {
@@ -127,6 +148,14 @@ type BootstrapConfig struct {
 	// ancestors while responding to a GetAncestors message
 	BootstrapMaxTimeGetAncestors time.Duration `json:"bootstrapMaxTimeGetAncestors"`
 
+	// Number of goroutines that pre-verify blocks ahead of their execution
+	// during bootstrapping
+	BootstrapExecutionWorkers int `json:"bootstrapExecutionWorkers"`
+
+	// If true, chains of subnets other than the primary network are only
+	// created once the primary network has finished bootstrapping
+	BootstrapPrioritizePrimaryNetwork bool `json:"bootstrapPrioritizePrimaryNetwork"`
+
 	Bootstrappers []genesis.Bootstrapper `json:"bootstrappers"`
 }
 
@@ -170,6 +199,8 @@ type Config struct {
 
 	ProfilerConfig profiler.Config `json:"profilerConfig"`
 
+	WatchdogConfig watchdog.Config `json:"watchdogConfig"`
+
 	LoggingConfig logging.Config `json:"loggingConfig"`
 
 	PluginDir string `json:"pluginDir"`
@@ -177,6 +208,15 @@ type Config struct {
 	// File Descriptor Limit
 	FdLimit uint64 `json:"fdLimit"`
 
+	SelfCheckConfig selfcheck.Config `json:"selfCheckConfig"`
+
+	// ResourceLimits are the CPU and memory available to the node
+	ResourceLimits resource.Limits `json:"resourceLimits"`
+
+	CacheBudgetConfig budget.Config `json:"cacheBudgetConfig"`
+
+	SharedMemoryGCConfig atomic.GCConfig `json:"sharedMemoryGCConfig"`
+
 	// Metrics
 	MeterVMEnabled bool `json:"meterVMEnabled"`
 
@@ -197,6 +237,9 @@ type Config struct {
 	ChainConfigs map[string]chains.ChainConfig `json:"-"`
 	ChainAliases map[ids.ID][]string           `json:"chainAliases"`
 
+	// Chain alias or ID -> genesis bytes that replace the genesis of the chain
+	ChainGenesisOverrides map[string][]byte `json:"-"`
+
 	VMAliaser ids.Aliaser `json:"-"`
 
 	// Halflife to use for the processing requests tracker.
@@ -225,6 +268,12 @@ type Config struct {
 
 	TraceConfig trace.Config `json:"traceConfig"`
 
+	MetricsPushConfig metrics.PushConfig `json:"metricsPushConfig"`
+
+	WebhookConfig notify.Config `json:"webhookConfig"`
+
+	AcceptorHooks []hooks.Config `json:"acceptorHooks"`
+
 	// See comment on [UseCurrentHeight] in platformvm.Config
 	UseCurrentHeight bool `json:"useCurrentHeight"`
 
@@ -235,6 +284,10 @@ type Config struct {
 	// write arbitrary data.
 	ChainDataDir string `json:"chainDataDir"`
 
+	// ChainAcceptWALEnabled is true if each snowman chain should write the
+	// blocks it accepts to a write-ahead log in its directory.
+	ChainAcceptWALEnabled bool `json:"chainAcceptWALEnabled"`
+
 	// Path to write process context to (including PID, API URI, and
 	// staking address).
 	ProcessContextFilePath string `json:"processContextFilePath"`
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/event"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	blockAcceptedPublisherName = "events"
+
+	// eventsBufferSize is the number of events buffered for each subscriber
+	// of the node's event buses.
+	eventsBufferSize = 1024
+)
+
+var (
+	_ router.Router                  = (*peerEventPublisher)(nil)
+	_ validators.SetCallbackListener = (*validatorEventPublisher)(nil)
+	_ chains.Registrant              = (*blockEventPublisher)(nil)
+	_ snow.Acceptor                  = (*blockEventPublisher)(nil)
+)
+
+// peerEventPublisher publishes the peer connections reported to the router.
+type peerEventPublisher struct {
+	router.Router
+	connected    event.Bus[event.PeerConnected]
+	disconnected event.Bus[event.PeerDisconnected]
+}
+
+func (p *peerEventPublisher) Connected(nodeID ids.NodeID, nodeVersion *version.Application, subnetID ids.ID) {
+	p.Router.Connected(nodeID, nodeVersion, subnetID)
+	p.connected.Publish(event.PeerConnected{
+		NodeID:   nodeID,
+		Version:  nodeVersion,
+		SubnetID: subnetID,
+	})
+}
+
+func (p *peerEventPublisher) Disconnected(nodeID ids.NodeID) {
+	p.Router.Disconnected(nodeID)
+	p.disconnected.Publish(event.PeerDisconnected{
+		NodeID: nodeID,
+	})
+}
+
+// validatorEventPublisher publishes the changes to the validator set of a
+// subnet.
+type validatorEventPublisher struct {
+	subnetID ids.ID
+	bus      event.Bus[event.ValidatorSetChanged]
+}
+
+func (v *validatorEventPublisher) OnValidatorAdded(nodeID ids.NodeID, _ *bls.PublicKey, _ ids.ID, weight uint64) {
+	v.publish(nodeID, weight)
+}
+
+func (v *validatorEventPublisher) OnValidatorRemoved(nodeID ids.NodeID, _ uint64) {
+	v.publish(nodeID, 0)
+}
+
+func (v *validatorEventPublisher) OnValidatorWeightChanged(nodeID ids.NodeID, _, newWeight uint64) {
+	v.publish(nodeID, newWeight)
+}
+
+func (v *validatorEventPublisher) publish(nodeID ids.NodeID, weight uint64) {
+	v.bus.Publish(event.ValidatorSetChanged{
+		SubnetID: v.subnetID,
+		NodeID:   nodeID,
+		Weight:   weight,
+	})
+}
+
+// blockEventPublisher publishes the blocks accepted by every chain once the
+// chain is registered.
+type blockEventPublisher struct {
+	log                logging.Logger
+	blockAcceptorGroup snow.AcceptorGroup
+	bus                event.Bus[event.BlockAccepted]
+}
+
+func (b *blockEventPublisher) RegisterChain(chainName string, ctx *snow.ConsensusContext, _ common.VM) {
+	err := b.blockAcceptorGroup.RegisterAcceptor(ctx.ChainID, blockAcceptedPublisherName, b, false)
+	if err != nil {
+		b.log.Error("failed to register block accepted publisher",
+			zap.String("chain", chainName),
+			zap.Error(err),
+		)
+	}
+}
+
+func (b *blockEventPublisher) Accept(ctx *snow.ConsensusContext, blkID ids.ID, _ []byte) error {
+	b.bus.Publish(event.BlockAccepted{
+		ChainID: ctx.ChainID,
+		BlockID: blkID,
+	})
+	return nil
+}
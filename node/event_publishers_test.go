@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/event"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestPeerEventPublisher(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	subnetID := ids.GenerateTestID()
+
+	mockRouter := router.NewMockRouter(ctrl)
+	mockRouter.EXPECT().Connected(nodeID, version.CurrentApp, subnetID)
+	mockRouter.EXPECT().Disconnected(nodeID)
+
+	buses := event.NewBuses()
+	connected := buses.PeerConnected.Subscribe(1)
+	disconnected := buses.PeerDisconnected.Subscribe(1)
+
+	p := &peerEventPublisher{
+		Router:       mockRouter,
+		connected:    buses.PeerConnected,
+		disconnected: buses.PeerDisconnected,
+	}
+	p.Connected(nodeID, version.CurrentApp, subnetID)
+	p.Disconnected(nodeID)
+
+	require.Equal(
+		event.PeerConnected{
+			NodeID:   nodeID,
+			Version:  version.CurrentApp,
+			SubnetID: subnetID,
+		},
+		<-connected.Events(),
+	)
+	require.Equal(
+		event.PeerDisconnected{
+			NodeID: nodeID,
+		},
+		<-disconnected.Events(),
+	)
+}
+
+func TestValidatorEventPublisher(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	vdrs := validators.NewSet()
+	require.NoError(vdrs.Add(nodeID0, nil, ids.Empty, 1))
+
+	bus := event.NewBus[event.ValidatorSetChanged]()
+	sub := bus.Subscribe(4)
+
+	// Registering the publisher publishes the existing validators.
+	vdrs.RegisterCallbackListener(&validatorEventPublisher{
+		subnetID: constants.PrimaryNetworkID,
+		bus:      bus,
+	})
+	require.NoError(vdrs.Add(nodeID1, nil, ids.Empty, 2))
+	require.NoError(vdrs.AddWeight(nodeID1, 3))
+	require.NoError(vdrs.RemoveWeight(nodeID0, 1))
+
+	expected := []event.ValidatorSetChanged{
+		{SubnetID: constants.PrimaryNetworkID, NodeID: nodeID0, Weight: 1},
+		{SubnetID: constants.PrimaryNetworkID, NodeID: nodeID1, Weight: 2},
+		{SubnetID: constants.PrimaryNetworkID, NodeID: nodeID1, Weight: 5},
+		{SubnetID: constants.PrimaryNetworkID, NodeID: nodeID0, Weight: 0},
+	}
+	for _, e := range expected {
+		require.Equal(e, <-sub.Events())
+	}
+}
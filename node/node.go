@@ -14,10 +14,14 @@ import (
 	"io/fs"
 	"net"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
+	goruntime "runtime"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -31,8 +35,10 @@ import (
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/api/keystore/gsigner"
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/cache/budget"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
@@ -41,27 +47,35 @@ import (
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/hooks"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/indexer"
 	"github.com/ava-labs/avalanchego/ipcs"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/capture"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/network/transport"
+	"github.com/ava-labs/avalanchego/notify"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/snow/networking/watchdog"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/event"
 	"github.com/ava-labs/avalanchego/utils/filesystem"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -70,8 +84,11 @@ import (
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/selfcheck"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/ulimit"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
@@ -85,6 +102,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	ipcsapi "github.com/ava-labs/avalanchego/api/ipcs"
+	signerpb "github.com/ava-labs/avalanchego/proto/pb/signer"
 	avmconfig "github.com/ava-labs/avalanchego/vms/avm/config"
 	platformconfig "github.com/ava-labs/avalanchego/vms/platformvm/config"
 )
@@ -92,9 +110,14 @@ import (
 var (
 	genesisHashKey  = []byte("genesisID")
 	indexerDBPrefix = []byte{0x00}
+	adminDBPrefix   = []byte("admin")
+	authDBPrefix    = []byte("auth")
+
+	reputationDBPrefix = []byte("reputation")
 
-	errInvalidTLSKey = errors.New("invalid TLS key")
-	errShuttingDown  = errors.New("server shutting down")
+	errInvalidTLSKey   = errors.New("invalid TLS key")
+	errShuttingDown    = errors.New("server shutting down")
+	errSelfCheckFailed = errors.New("startup self-check failed")
 )
 
 // Node is an instance of an Avalanche node.
@@ -117,6 +140,9 @@ type Node struct {
 	// Indexes blocks, transactions and blocks
 	indexer indexer.Indexer
 
+	// Delivers accepted containers to the enabled acceptor hooks
+	acceptorHooks *hooks.Manager
+
 	// Handles calls to Keystore API
 	keystore keystore.Keystore
 
@@ -126,6 +152,9 @@ type Node struct {
 	// Monitors node health and runs health checks
 	health health.Health
 
+	// Detects chains that have stalled
+	watchdog watchdog.Watchdog
+
 	// Build and parse messages, for both network layer and chain manager
 	msgCreator message.Creator
 
@@ -135,7 +164,21 @@ type Node struct {
 	// Manages validator benching
 	benchlistManager benchlist.Manager
 
+	// Sends webhook notifications of events operators should be aware of.
+	// Both are nil if no webhook endpoints are configured.
+	notifier      notify.Notifier
+	notifyMonitor notify.Monitor
+	// Wraps the consensus router to detect peers that bench this node. Nil if
+	// no webhook endpoints are configured.
+	benchedCondition *notify.BenchedCondition
+
 	uptimeCalculator uptime.LockedCalculator
+	// Reads the current validators of the P-chain once it is created
+	validatorReader *platformvm.LockedValidatorReader
+
+	// Delivers the events of each component to the components that consume
+	// them
+	events *event.Buses
 
 	// dispatcher for events as they happen in consensus
 	BlockAcceptorGroup  snow.AcceptorGroup
@@ -148,6 +191,12 @@ type Node struct {
 	networkNamespace string
 	Net              network.Network
 
+	// Records p2p messages when requested through the admin API
+	capturer capture.Capturer
+
+	// Records the misbehaviors of peers. Nil if reputations are disabled.
+	reputations reputation.Store
+
 	// The staking address will optionally be written to a process context
 	// file to enable other nodes to be configured to use this node as a
 	// beacon.
@@ -190,6 +239,9 @@ type Node struct {
 	MetricsRegisterer *prometheus.Registry
 	MetricsGatherer   metrics.MultiGatherer
 
+	// Pushes metrics to a collector, if enabled
+	metricsPusher metrics.Pusher
+
 	VMManager vms.Manager
 
 	// VM endpoint registry
@@ -200,6 +252,14 @@ type Node struct {
 
 	resourceManager resource.Manager
 
+	// Allocates the capacity of the node's caches from a single memory
+	// budget, if one is configured.
+	cacheBudget *budget.Manager
+
+	// Deletes expired records from shared memory, if garbage collection is
+	// enabled.
+	sharedMemoryGC *atomic.GarbageCollector
+
 	// Tracks the CPU/disk usage caused by processing
 	// messages of each peer.
 	resourceTracker tracker.ResourceTracker
@@ -243,7 +303,8 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	// 2: https://github.com/golang/go/issues/56998
 	listenAddress := net.JoinHostPort(n.Config.ListenHost, fmt.Sprintf("%d", currentIPPort.Port))
 
-	listener, err := net.Listen(constants.NetworkType, listenAddress)
+	peerTransport := transport.NewTCP(constants.NetworkType)
+	listener, err := peerTransport.Listen(listenAddress)
 	if err != nil {
 		return err
 	}
@@ -285,13 +346,47 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 
 	tlsConfig := peer.TLSConfig(n.Config.StakingTLSCert, n.tlsKeyLogWriterCloser)
 
+	if n.Config.NetworkConfig.ReputationConfig.Enabled {
+		n.reputations, err = reputation.NewStore(
+			n.Config.NetworkConfig.ReputationConfig,
+			prefixdb.New(reputationDBPrefix, n.DB),
+			n.Log,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to load peer reputations: %w", err)
+		}
+	}
+
 	// Configure benchlist
 	n.Config.BenchlistConfig.Validators = n.vdrs
 	n.Config.BenchlistConfig.Benchable = n.Config.ConsensusRouter
+	if n.reputations != nil {
+		n.Config.BenchlistConfig.Benchable = reputation.NewBenchable(n.Config.BenchlistConfig.Benchable, n.reputations)
+	}
 	n.Config.BenchlistConfig.SybilProtectionEnabled = n.Config.SybilProtectionEnabled
+	n.Config.BenchlistConfig.Retiring = benchlist.NewRetiringSet()
 	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
 
 	n.uptimeCalculator = uptime.NewLockedCalculator()
+	uptimeValidators := uptime.NewValidatorTracker()
+	primaryNetVdrs.RegisterCallbackListener(uptimeValidators)
+	uptimeMetrics := uptime.NewMetrics(
+		"uptime",
+		n.uptimeCalculator,
+		constants.PrimaryNetworkID,
+		uptimeValidators.NodeIDs,
+		n.Config.UptimeRequirement,
+	)
+	if err := n.MetricsRegisterer.Register(uptimeMetrics); err != nil {
+		return err
+	}
+
+	// Publish the changes to the primary network validator set. Registering
+	// the listener publishes the validators that are already in the set.
+	primaryNetVdrs.RegisterCallbackListener(&validatorEventPublisher{
+		subnetID: constants.PrimaryNetworkID,
+		bus:      n.events.ValidatorSetChanged,
+	})
 
 	consensusRouter := n.Config.ConsensusRouter
 	if !n.Config.SybilProtectionEnabled {
@@ -318,6 +413,23 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		}
 	}
 
+	consensusRouter = &peerEventPublisher{
+		Router:       consensusRouter,
+		connected:    n.events.PeerConnected,
+		disconnected: n.events.PeerDisconnected,
+	}
+
+	if n.notifier != nil {
+		n.benchedCondition = notify.NewBenchedCondition(
+			consensusRouter,
+			n.ID,
+			primaryNetVdrs,
+			n.Config.WebhookConfig.BenchedDuration,
+			&mockable.Clock{},
+		)
+		consensusRouter = n.benchedCondition
+	}
+
 	numBootstrappers := n.bootstrappers.Len()
 	requiredConns := (3*numBootstrappers + 3) / 4
 
@@ -358,6 +470,11 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		GossipTracker: gossipTracker,
 	})
 
+	n.capturer, err = capture.New(n.Log, filepath.Join(n.Config.LoggingConfig.Directory, "capture"))
+	if err != nil {
+		return err
+	}
+
 	// add node configs to network config
 	n.Config.NetworkConfig.Namespace = n.networkNamespace
 	n.Config.NetworkConfig.MyNodeID = n.ID
@@ -368,12 +485,21 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	n.Config.NetworkConfig.TLSConfig = tlsConfig
 	n.Config.NetworkConfig.TLSKey = tlsKey
 	n.Config.NetworkConfig.TrackedSubnets = n.Config.TrackedSubnets
+	n.Config.NetworkConfig.TrafficShaping = make(map[ids.ID]subnets.TrafficShapingConfig)
+	for subnetID, subnetConfig := range n.Config.SubnetConfigs {
+		if subnetConfig.TrafficShaping.Enabled() {
+			n.Config.NetworkConfig.TrafficShaping[subnetID] = subnetConfig.TrafficShaping
+		}
+	}
 	n.Config.NetworkConfig.UptimeCalculator = n.uptimeCalculator
 	n.Config.NetworkConfig.UptimeRequirement = n.Config.UptimeRequirement
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
 	n.Config.NetworkConfig.GossipTracker = gossipTracker
+	n.Config.NetworkConfig.Capturer = n.capturer
+	n.Config.NetworkConfig.RetiringNodes = n.Config.BenchlistConfig.Retiring
+	n.Config.NetworkConfig.Reputations = n.reputations
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -381,7 +507,7 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		n.MetricsRegisterer,
 		n.Log,
 		listener,
-		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+		dialer.NewTransportDialer(peerTransport, n.Config.NetworkConfig.DialerConfig, n.Log),
 		consensusRouter,
 	)
 
@@ -494,6 +620,35 @@ func (n *Node) Dispatch() error {
  ******************************************************************************
  */
 
+// selfCheckDBKeys is the number of database entries read by the startup
+// self-check.
+const selfCheckDBKeys = 1024
+
+// runSelfCheck runs the startup self-check suite, if enabled. Returns an error
+// if a check failed and the node is configured to refuse to start on failures.
+func (n *Node) runSelfCheck() error {
+	if !n.Config.SelfCheckConfig.Enabled {
+		return nil
+	}
+
+	checks := []selfcheck.Check{
+		selfcheck.NewClockCheck(version.GetCortinaTime(n.Config.NetworkID)),
+		selfcheck.NewFDLimitCheck(ulimit.DefaultFDLimit),
+		selfcheck.NewCryptoCheck(),
+		selfcheck.NewDatabaseCheck(n.DB, selfCheckDBKeys),
+	}
+	if n.Config.DatabaseConfig.Name != memdb.Name {
+		checks = append(checks, selfcheck.NewDiskCheck(n.Config.DatabaseConfig.Path))
+	}
+
+	results := selfcheck.Run(n.Log, checks...)
+	failed := selfcheck.Failed(results)
+	if len(failed) == 0 || !n.Config.SelfCheckConfig.FailOnError {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", errSelfCheckFailed, strings.Join(failed, ", "))
+}
+
 func (n *Node) initDatabase() error {
 	// start the db manager
 	var (
@@ -502,7 +657,12 @@ func (n *Node) initDatabase() error {
 	)
 	switch n.Config.DatabaseConfig.Name {
 	case leveldb.Name:
-		dbManager, err = manager.NewLevelDB(n.Config.DatabaseConfig.Path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
+		var dbConfig []byte
+		dbConfig, err = n.budgetDBBlockCache(n.Config.DatabaseConfig.Config)
+		if err != nil {
+			return err
+		}
+		dbManager, err = manager.NewLevelDB(n.Config.DatabaseConfig.Path, dbConfig, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
 	case memdb.Name:
 		dbManager = manager.NewMemDB(version.CurrentDatabase)
 	default:
@@ -556,6 +716,116 @@ func (n *Node) initDatabase() error {
 	return nil
 }
 
+// initResourceLimits logs the CPU and memory available to the node. The Go
+// runtime doesn't respect the CPU quota of a container, so unless GOMAXPROCS is
+// provided, the number of threads executing Go code is lowered to the quota.
+func (n *Node) initResourceLimits() {
+	limits := n.Config.ResourceLimits
+	n.Log.Info("detected resource limits",
+		zap.Int("cgroupVersion", limits.CgroupVersion),
+		zap.Int("hostCPUs", limits.HostCPUs),
+		zap.Float64("cpuQuota", limits.CPUQuota),
+		zap.Uint64("hostMemory", limits.HostMemory),
+		zap.Uint64("memoryLimit", limits.MemoryLimit),
+	)
+	if limits.CPUQuota == 0 {
+		return
+	}
+
+	var (
+		maxProcs     = limits.GOMAXPROCS()
+		prevMaxProcs = goruntime.GOMAXPROCS(0)
+	)
+	if prevMaxProcs <= maxProcs {
+		return
+	}
+	if _, ok := os.LookupEnv("GOMAXPROCS"); ok {
+		n.Log.Warn("GOMAXPROCS exceeds the CPU quota",
+			zap.Int("gomaxprocs", prevMaxProcs),
+			zap.Int("recommendedGOMAXPROCS", maxProcs),
+		)
+		return
+	}
+
+	goruntime.GOMAXPROCS(maxProcs)
+	n.Log.Info("lowered GOMAXPROCS to the CPU quota",
+		zap.Int("previousGOMAXPROCS", prevMaxProcs),
+		zap.Int("gomaxprocs", maxProcs),
+	)
+}
+
+// dbBlockCacheBudgetPortion is the portion of the cache memory budget given to
+// the block cache of the database, unless its capacity is configured.
+const dbBlockCacheBudgetPortion = 0.25
+
+// initCacheBudget creates the manager of the cache memory budget, if one is
+// configured.
+func (n *Node) initCacheBudget() error {
+	if n.Config.CacheBudgetConfig.Size == 0 {
+		return nil
+	}
+
+	n.Log.Info("initializing cache memory budget",
+		zap.Uint64("size", n.Config.CacheBudgetConfig.Size),
+	)
+	var err error
+	n.cacheBudget, err = budget.New(
+		n.Log,
+		"cache_budget",
+		n.MetricsRegisterer,
+		n.Config.CacheBudgetConfig,
+	)
+	return err
+}
+
+// budgetDBBlockCache returns [dbConfig] with the capacity of the block cache
+// of the database taken from the cache memory budget.
+//
+// If the capacity of the block cache is configured, it is reserved from the
+// budget as is. Otherwise, the block cache is given a portion of the budget.
+func (n *Node) budgetDBBlockCache(dbConfig []byte) ([]byte, error) {
+	if n.cacheBudget == nil {
+		return dbConfig, nil
+	}
+
+	config := make(map[string]json.RawMessage)
+	if len(dbConfig) > 0 {
+		if err := json.Unmarshal(dbConfig, &config); err != nil {
+			return nil, fmt.Errorf("%w: %w", leveldb.ErrInvalidConfig, err)
+		}
+	}
+
+	const blockCacheKey = "blockCacheCapacity"
+	if rawSize, ok := config[blockCacheKey]; ok {
+		var size uint64
+		if err := json.Unmarshal(rawSize, &size); err != nil {
+			return nil, fmt.Errorf("%w: %w", leveldb.ErrInvalidConfig, err)
+		}
+		reserved, err := n.cacheBudget.Reserve("db_block_cache", size)
+		if err != nil {
+			return nil, err
+		}
+		if reserved < size {
+			n.Log.Warn("database block cache exceeds its share of the cache memory budget",
+				zap.Uint64("size", size),
+				zap.Uint64("reserved", reserved),
+			)
+		}
+		return dbConfig, nil
+	}
+
+	size := uint64(float64(n.Config.CacheBudgetConfig.Size) * dbBlockCacheBudgetPortion)
+	reserved, err := n.cacheBudget.Reserve("db_block_cache", size)
+	if err != nil {
+		return nil, err
+	}
+	config[blockCacheKey], err = json.Marshal(reserved)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(config)
+}
+
 // Set the node IDs of the peers this node should first connect to
 func (n *Node) initBootstrappers() error {
 	n.bootstrappers = validators.NewSet()
@@ -631,6 +901,29 @@ func (n *Node) initIndexer() error {
 	return nil
 }
 
+// Initialize [n.acceptorHooks].
+// Should only be called after the acceptor groups and [n.chainManager] are
+// initialized
+func (n *Node) initAcceptorHooks() error {
+	var err error
+	n.acceptorHooks, err = hooks.NewManager(
+		n.Log,
+		n.Config.AcceptorHooks,
+		n.BlockAcceptorGroup,
+		n.TxAcceptorGroup,
+		n.VertexAcceptorGroup,
+		"acceptor_hooks",
+		n.MetricsRegisterer,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Chain manager will notify the hooks when a chain is created
+	n.chainManager.AddRegistrant(n.acceptorHooks)
+	return nil
+}
+
 // Initializes the Platform chain.
 // Its genesis data specifies the other chains that should be created.
 func (n *Node) initChains(genesisBytes []byte) error {
@@ -694,11 +987,17 @@ func (n *Node) initAPIServer() error {
 			n.MetricsRegisterer,
 			n.Config.HTTPConfig.HTTPConfig,
 			n.Config.HTTPAllowedHosts,
+			n.resourceManager,
 		)
 		return err
 	}
 
-	a, err := auth.New(n.Log, "auth", n.Config.APIAuthPassword)
+	a, err := auth.New(
+		n.Log,
+		"auth",
+		n.Config.APIAuthPassword,
+		prefixdb.New(authDBPrefix, n.DB),
+	)
 	if err != nil {
 		return err
 	}
@@ -716,6 +1015,7 @@ func (n *Node) initAPIServer() error {
 		n.MetricsRegisterer,
 		n.Config.HTTPConfig.HTTPConfig,
 		n.Config.HTTPAllowedHosts,
+		n.resourceManager,
 		a,
 	)
 	if err != nil {
@@ -838,22 +1138,36 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		Metrics:                                 n.MetricsGatherer,
 		SubnetConfigs:                           n.Config.SubnetConfigs,
 		ChainConfigs:                            n.Config.ChainConfigs,
+		ChainGenesisOverrides:                   n.Config.ChainGenesisOverrides,
 		AcceptedFrontierGossipFrequency:         n.Config.AcceptedFrontierGossipFrequency,
 		ConsensusAppConcurrency:                 n.Config.ConsensusAppConcurrency,
 		BootstrapMaxTimeGetAncestors:            n.Config.BootstrapMaxTimeGetAncestors,
 		BootstrapAncestorsMaxContainersSent:     n.Config.BootstrapAncestorsMaxContainersSent,
 		BootstrapAncestorsMaxContainersReceived: n.Config.BootstrapAncestorsMaxContainersReceived,
+		BootstrapExecutionWorkers:               n.Config.BootstrapExecutionWorkers,
+		BootstrapPrioritizePrimaryNetwork:       n.Config.BootstrapPrioritizePrimaryNetwork,
 		ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
 		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
 		ResourceTracker:                         n.resourceTracker,
+		Watchdog:                                n.watchdog,
+		ChainBootstrapped:                       n.events.ChainBootstrapped,
 		StateSyncBeacons:                        n.Config.StateSyncIDs,
 		TracingEnabled:                          n.Config.TraceConfig.Enabled,
 		Tracer:                                  n.tracer,
 		ChainDataDir:                            n.Config.ChainDataDir,
+		AcceptWALEnabled:                        n.Config.ChainAcceptWALEnabled,
+		CacheBudget:                             n.cacheBudget,
 	})
 
 	// Notify the API server when new chains are created
 	n.chainManager.AddRegistrant(n.APIServer)
+
+	// Notify the event bus of the blocks accepted by each chain
+	n.chainManager.AddRegistrant(&blockEventPublisher{
+		log:                n.Log,
+		blockAcceptorGroup: n.BlockAcceptorGroup,
+		bus:                n.events.BlockAccepted,
+	})
 	return nil
 }
 
@@ -879,6 +1193,8 @@ func (n *Node) initVMs() error {
 		VMManager:    n.VMManager,
 	})
 
+	n.validatorReader = platformvm.NewLockedValidatorReader()
+
 	// Register the VMs that Avalanche supports
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -913,11 +1229,14 @@ func (n *Node) initVMs() error {
 				CortinaTime:                   version.GetCortinaTime(n.Config.NetworkID),
 				UseCurrentHeight:              n.Config.UseCurrentHeight,
 			},
+			ValidatorReader: n.validatorReader,
 		}),
 		vmRegisterer.Register(context.TODO(), constants.AVMID, &avm.Factory{
 			Config: avmconfig.Config{
 				TxFee:            n.Config.TxFee,
 				CreateAssetTxFee: n.Config.CreateAssetTxFee,
+				MinOutputAmount:  n.Config.MinOutputAmount,
+				DustPolicyTime:   version.GetDustPolicyTime(n.Config.NetworkID),
 			},
 		}),
 		vmRegisterer.Register(context.TODO(), constants.EVMID, &coreth.Factory{}),
@@ -956,10 +1275,32 @@ func (n *Node) initVMs() error {
 }
 
 // initSharedMemory initializes the shared memory for cross chain interation
-func (n *Node) initSharedMemory() {
+func (n *Node) initSharedMemory() error {
 	n.Log.Info("initializing SharedMemory")
 	sharedMemoryDB := prefixdb.New([]byte("shared memory"), n.DB)
 	n.sharedMemory = atomic.NewMemory(sharedMemoryDB)
+
+	if n.Config.SharedMemoryGCConfig.Retention == 0 {
+		return nil
+	}
+
+	n.Log.Info("initializing shared memory garbage collection",
+		zap.Duration("retention", n.Config.SharedMemoryGCConfig.Retention),
+		zap.Duration("frequency", n.Config.SharedMemoryGCConfig.Frequency),
+	)
+	var err error
+	n.sharedMemoryGC, err = atomic.NewGarbageCollector(
+		n.Log,
+		n.sharedMemory,
+		n.Config.SharedMemoryGCConfig,
+		"shared_memory",
+		n.MetricsRegisterer,
+	)
+	if err != nil {
+		return err
+	}
+	go n.Log.RecoverAndPanic(n.sharedMemoryGC.Dispatch)
+	return nil
 }
 
 // initKeystoreAPI initializes the keystore service, which is an on-node wallet.
@@ -967,7 +1308,20 @@ func (n *Node) initSharedMemory() {
 func (n *Node) initKeystoreAPI() error {
 	n.Log.Info("initializing keystore")
 	keystoreDB := n.DBManager.NewPrefixDBManager([]byte("keystore"))
-	n.keystore = keystore.New(n.Log, keystoreDB)
+
+	var keystoreSigner keystore.Signer
+	if n.Config.KeystoreSignerEndpoint != "" {
+		n.Log.Info("connecting to keystore signing plugin",
+			zap.String("endpoint", n.Config.KeystoreSignerEndpoint),
+		)
+		clientConn, err := gsigner.Dial(n.Config.KeystoreSignerEndpoint, n.Config.KeystoreSignerCAFile)
+		if err != nil {
+			return fmt.Errorf("couldn't dial keystore signing plugin: %w", err)
+		}
+		keystoreSigner = gsigner.NewClient(signerpb.NewSignerClient(clientConn))
+	}
+
+	n.keystore = keystore.NewWithSigner(n.Log, keystoreDB, keystoreSigner)
 	keystoreHandler, err := n.keystore.CreateHandler()
 	if err != nil {
 		return err
@@ -984,10 +1338,10 @@ func (n *Node) initKeystoreAPI() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "keystore", "")
 }
 
-// initMetricsAPI initializes the Metrics API
+// initMetricsAPI initializes the Metrics API and the pushing of metrics
 // Assumes n.APIServer is already set
 func (n *Node) initMetricsAPI() error {
-	if !n.Config.MetricsAPIEnabled {
+	if !n.Config.MetricsAPIEnabled && !n.Config.MetricsPushConfig.Enabled {
 		n.Log.Info("skipping metrics API initialization because it has been disabled")
 		return nil
 	}
@@ -1008,6 +1362,20 @@ func (n *Node) initMetricsAPI() error {
 		return err
 	}
 
+	if n.Config.MetricsPushConfig.Enabled {
+		n.Log.Info("initializing metrics pusher",
+			zap.Stringer("type", n.Config.MetricsPushConfig.Type),
+			zap.String("endpoint", n.Config.MetricsPushConfig.Endpoint),
+		)
+		n.metricsPusher = metrics.NewPusher(n.Log, n.Config.MetricsPushConfig, n.MetricsGatherer)
+		go n.Log.RecoverAndPanic(n.metricsPusher.Dispatch)
+	}
+
+	if !n.Config.MetricsAPIEnabled {
+		n.Log.Info("skipping metrics API initialization because it has been disabled")
+		return nil
+	}
+
 	n.Log.Info("initializing metrics API")
 
 	return n.APIServer.AddRoute(
@@ -1042,6 +1410,18 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.VMManager,
 			VMRegistry:   n.VMRegistry,
+			DB:           prefixdb.New(adminDBPrefix, n.DB),
+			Capturer:     n.capturer,
+			NetworkID:    n.Config.NetworkID,
+			Validators:   n.vdrs,
+			NodeID:       n.ID,
+			StakingKeyPair: staking.KeyPairPaths{
+				KeyPath:  n.Config.StakingKeyPath,
+				CertPath: n.Config.StakingCertPath,
+			},
+			StandbyStakingKeyPair: n.Config.StakingStandbyKeyPair,
+			SharedMemoryGC:        n.sharedMemoryGC,
+			Reputations:           n.reputations,
 		},
 	)
 	if err != nil {
@@ -1074,6 +1454,23 @@ func (n *Node) initProfiler() {
 	})
 }
 
+// initWatchdog initializes the detection of stalled chains
+// Assumes n.health is already initialized
+func (n *Node) initWatchdog() error {
+	n.watchdog = watchdog.New(n.Log, n.Config.WatchdogConfig)
+	if !n.Config.WatchdogConfig.Enabled() {
+		n.Log.Info("skipping watchdog initialization because it has been disabled")
+		return nil
+	}
+
+	n.Log.Info("initializing watchdog")
+	if err := n.health.RegisterHealthCheck("watchdog", n.watchdog, health.ApplicationTag); err != nil {
+		return fmt.Errorf("couldn't register watchdog health check: %w", err)
+	}
+	go n.Log.RecoverAndPanic(n.watchdog.Dispatch)
+	return nil
+}
+
 func (n *Node) initInfoAPI() error {
 	if !n.Config.InfoAPIEnabled {
 		n.Log.Info("skipping info API initialization because it has been disabled")
@@ -1098,7 +1495,9 @@ func (n *Node) initInfoAPI() error {
 			AddPrimaryNetworkDelegatorFee: n.Config.AddPrimaryNetworkDelegatorFee,
 			AddSubnetValidatorFee:         n.Config.AddSubnetValidatorFee,
 			AddSubnetDelegatorFee:         n.Config.AddSubnetDelegatorFee,
+			MinOutputAmount:               n.Config.MinOutputAmount,
 			VMManager:                     n.VMManager,
+			ResourceLimits:                n.Config.ResourceLimits,
 		},
 		n.Log,
 		n.chainManager,
@@ -1114,6 +1513,62 @@ func (n *Node) initInfoAPI() error {
 	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "info", "")
 }
 
+// initWebhookNotifier initializes the notifier that sends events to the
+// configured webhook endpoints.
+// Assumes n.Log and n.ID already initialized
+func (n *Node) initWebhookNotifier() {
+	if !n.Config.WebhookConfig.Enabled() {
+		return
+	}
+
+	n.Log.Info("initializing webhook notifier",
+		zap.Int("numEndpoints", len(n.Config.WebhookConfig.Endpoints)),
+	)
+	n.notifier = notify.NewWebhook(n.Log, n.ID, n.Config.WebhookConfig)
+}
+
+// initWebhookMonitor starts checking for the conditions that webhook
+// notifications are sent for.
+// Assumes n.notifier, n.benchedCondition, n.Net, n.health, n.chainManager,
+// n.resourceTracker and n.validatorReader already initialized
+func (n *Node) initWebhookMonitor() error {
+	if n.notifier == nil {
+		return nil
+	}
+
+	n.notifyMonitor = notify.NewMonitor(n.Log, n.notifier)
+
+	clock := &mockable.Clock{}
+	chainStallCondition := notify.NewChainStallCondition(n.health, n.Config.WebhookConfig.ChainStallDuration, clock)
+	n.chainManager.AddRegistrant(chainStallCondition)
+
+	conditions := map[string]notify.Condition{
+		"benched":      n.benchedCondition,
+		"chainStalled": chainStallCondition,
+		"lowDiskSpace": notify.NewLowDiskSpaceCondition(
+			n.resourceTracker.DiskTracker(),
+			n.Config.WarningThresholdAvailableDiskSpace,
+		),
+	}
+	if n.Config.SybilProtectionEnabled {
+		conditions["lowUptime"] = notify.NewLowUptimeCondition(n.Net, n.Config.UptimeRequirement)
+		conditions["validationEnding"] = notify.NewValidationEndingCondition(
+			n.validatorReader,
+			n.ID,
+			n.Config.WebhookConfig.ValidationEndWarning,
+			clock,
+		)
+	}
+	for name, condition := range conditions {
+		if err := n.notifyMonitor.RegisterCondition(name, condition); err != nil {
+			return err
+		}
+	}
+
+	n.notifyMonitor.Start(context.TODO(), n.Config.WebhookConfig.CheckFrequency)
+	return nil
+}
+
 // initHealthAPI initializes the Health API service
 // Assumes n.Log, n.Net, n.APIServer, n.HTTPLog already initialized
 func (n *Node) initHealthAPI() error {
@@ -1123,6 +1578,10 @@ func (n *Node) initHealthAPI() error {
 	}
 	n.health = healthChecker
 
+	// Report the node as ready as soon as its chains finish bootstrapping
+	// rather than at the next health check.
+	go health.RefreshOn(healthChecker, n.events.ChainBootstrapped.Subscribe(eventsBufferSize).Events())
+
 	if !n.Config.HealthAPIEnabled {
 		n.Log.Info("skipping health API initialization because it has been disabled")
 		return nil
@@ -1145,6 +1604,13 @@ func (n *Node) initHealthAPI() error {
 		return fmt.Errorf("couldn't register database health check: %w", err)
 	}
 
+	if n.Config.PortMapper != nil && n.Config.Nat.SupportsNAT() {
+		err = healthChecker.RegisterHealthCheck("nat", n.Config.PortMapper, health.ApplicationTag)
+		if err != nil {
+			return fmt.Errorf("couldn't register NAT health check: %w", err)
+		}
+	}
+
 	diskSpaceCheck := health.CheckerFunc(func(context.Context) (interface{}, error) {
 		// confirm that the node has enough disk space to continue operating
 		// if there is too little disk space remaining, first report unhealthy and then shutdown the node
@@ -1285,6 +1751,34 @@ func (n *Node) initAPIAliases(genesisBytes []byte) error {
 	return nil
 }
 
+// Re-apply the aliases that were previously added through the admin API
+func (n *Node) initAdminAliases() error {
+	n.Log.Info("initializing admin API aliases")
+	apiAliases, chainAliases, err := admin.PersistedAliases(prefixdb.New(adminDBPrefix, n.DB))
+	if err != nil {
+		return err
+	}
+
+	for chainID, aliases := range chainAliases {
+		endpoint := path.Join(constants.ChainAliasPrefix, chainID.String())
+		for _, alias := range aliases {
+			if err := n.chainManager.Alias(chainID, alias); err != nil {
+				return err
+			}
+			if err := n.APIServer.AddAliases(endpoint, path.Join(constants.ChainAliasPrefix, alias)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for url, aliases := range apiAliases {
+		if err := n.APIServer.AddAliases(url, aliases...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Initializes [n.vdrs] and returns the Primary Network validator set.
 func (n *Node) initVdrs() validators.Set {
 	n.vdrs = validators.NewManager()
@@ -1367,6 +1861,8 @@ func (n *Node) Initialize(
 		zap.Reflect("config", n.Config),
 	)
 
+	n.initResourceLimits()
+
 	var err error
 	n.VMFactoryLog, err = logFactory.Make("vm-factory")
 	if err != nil {
@@ -1391,6 +1887,30 @@ func (n *Node) Initialize(
 
 	n.initMetrics()
 
+	// The cache memory budget must be initialized before the database, whose
+	// block cache is allocated from it.
+	if err := n.initCacheBudget(); err != nil {
+		return fmt.Errorf("problem initializing cache memory budget: %w", err)
+	}
+
+	// The database must be initialized before the API server, which persists
+	// the auth tokens it issues.
+	if err := n.initDatabase(); err != nil { // Set up the node's database
+		return fmt.Errorf("problem initializing database: %w", err)
+	}
+
+	// The self-check runs before the node joins the network so that it can
+	// refuse to start in an environment it can't operate correctly in.
+	if err := n.runSelfCheck(); err != nil {
+		return err
+	}
+
+	// The resource manager must be initialized before the API server, which
+	// sheds load based on the node's resource usage.
+	if err := n.initResourceManager(n.MetricsRegisterer); err != nil {
+		return fmt.Errorf("problem initializing resource manager: %w", err)
+	}
+
 	if err := n.initAPIServer(); err != nil { // Start the API Server
 		return fmt.Errorf("couldn't initialize API server: %w", err)
 	}
@@ -1399,15 +1919,13 @@ func (n *Node) Initialize(
 		return fmt.Errorf("couldn't initialize metrics API: %w", err)
 	}
 
-	if err := n.initDatabase(); err != nil { // Set up the node's database
-		return fmt.Errorf("problem initializing database: %w", err)
-	}
-
 	if err := n.initKeystoreAPI(); err != nil { // Start the Keystore API
 		return fmt.Errorf("couldn't initialize keystore API: %w", err)
 	}
 
-	n.initSharedMemory() // Initialize shared memory
+	if err := n.initSharedMemory(); err != nil { // Initialize shared memory
+		return fmt.Errorf("problem initializing shared memory: %w", err)
+	}
 
 	// message.Creator is shared between networking, chainManager and the engine.
 	// It must be initiated before networking (initNetworking), chain manager (initChainManager)
@@ -1419,18 +1937,21 @@ func (n *Node) Initialize(
 		n.MetricsRegisterer,
 		n.networkNamespace,
 		n.Config.NetworkConfig.CompressionType,
+		n.Config.NetworkConfig.CompressionZstdLevel,
 		n.Config.NetworkConfig.MaximumInboundMessageTimeout,
 	)
 	if err != nil {
 		return fmt.Errorf("problem initializing message creator: %w", err)
 	}
 
+	// The event buses must be initialized before the components that publish
+	// to or subscribe to them.
+	n.events = event.NewBuses()
+
 	primaryNetVdrs := n.initVdrs()
-	if err := n.initResourceManager(n.MetricsRegisterer); err != nil {
-		return fmt.Errorf("problem initializing resource manager: %w", err)
-	}
 	n.initCPUTargeter(&config.CPUTargeterConfig, primaryNetVdrs)
 	n.initDiskTargeter(&config.DiskTargeterConfig, primaryNetVdrs)
+	n.initWebhookNotifier()
 	if err := n.initNetworking(primaryNetVdrs); err != nil { // Set up networking layer.
 		return fmt.Errorf("problem initializing networking: %w", err)
 	}
@@ -1446,6 +1967,9 @@ func (n *Node) Initialize(
 	if err := n.addDefaultVMAliases(); err != nil {
 		return fmt.Errorf("couldn't initialize API aliases: %w", err)
 	}
+	if err := n.initWatchdog(); err != nil {
+		return fmt.Errorf("couldn't initialize watchdog: %w", err)
+	}
 	if err := n.initChainManager(n.Config.AvaxAssetID); err != nil { // Set up the chain manager
 		return fmt.Errorf("couldn't initialize chain manager: %w", err)
 	}
@@ -1470,11 +1994,20 @@ func (n *Node) Initialize(
 	if err := n.initAPIAliases(n.Config.GenesisBytes); err != nil {
 		return fmt.Errorf("couldn't initialize API aliases: %w", err)
 	}
+	if err := n.initAdminAliases(); err != nil {
+		return fmt.Errorf("couldn't initialize admin API aliases: %w", err)
+	}
 	if err := n.initIndexer(); err != nil {
 		return fmt.Errorf("couldn't initialize indexer: %w", err)
 	}
+	if err := n.initAcceptorHooks(); err != nil {
+		return fmt.Errorf("couldn't initialize acceptor hooks: %w", err)
+	}
 
 	n.health.Start(context.TODO(), n.Config.HealthCheckFreq)
+	if err := n.initWebhookMonitor(); err != nil {
+		return fmt.Errorf("couldn't initialize webhook monitor: %w", err)
+	}
 	n.initProfiler()
 
 	// Start the Platform chain
@@ -1499,6 +2032,10 @@ func (n *Node) shutdown() {
 		zap.Int("exitCode", n.ExitCode()),
 	)
 
+	if n.Net != nil {
+		// Let the peers stop relying on this node while it shuts down.
+		n.Net.AnnounceRetiring()
+	}
 	if n.health != nil {
 		// Passes if the node is not shutting down
 		shuttingDownCheck := health.CheckerFunc(func(context.Context) (interface{}, error) {
@@ -1520,6 +2057,15 @@ func (n *Node) shutdown() {
 	if n.resourceManager != nil {
 		n.resourceManager.Shutdown()
 	}
+	if n.cacheBudget != nil {
+		n.cacheBudget.Shutdown()
+	}
+	if n.notifyMonitor != nil {
+		n.notifyMonitor.Stop()
+	}
+	if n.notifier != nil {
+		n.notifier.Shutdown()
+	}
 	if n.IPCs != nil {
 		if err := n.IPCs.Shutdown(); err != nil {
 			n.Log.Debug("error during IPC shutdown",
@@ -1530,12 +2076,24 @@ func (n *Node) shutdown() {
 	if n.chainManager != nil {
 		n.chainManager.Shutdown()
 	}
+	if n.sharedMemoryGC != nil {
+		n.sharedMemoryGC.Shutdown()
+	}
 	if n.profiler != nil {
 		n.profiler.Shutdown()
 	}
+	if n.watchdog != nil {
+		n.watchdog.Shutdown()
+	}
+	if n.metricsPusher != nil {
+		n.metricsPusher.Shutdown()
+	}
 	if n.Net != nil {
 		n.Net.StartClose()
 	}
+	if n.capturer != nil {
+		n.capturer.Shutdown()
+	}
 	if err := n.APIServer.Shutdown(); err != nil {
 		n.Log.Debug("error during API shutdown",
 			zap.Error(err),
@@ -1546,6 +2104,12 @@ func (n *Node) shutdown() {
 			zap.Error(err),
 		)
 	}
+	if n.acceptorHooks != nil {
+		n.acceptorHooks.Shutdown()
+	}
+	if n.events != nil {
+		n.events.Close()
+	}
 
 	// Ensure all runtimes are shutdown
 	n.Log.Info("cleaning up plugin runtimes")
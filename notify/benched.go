@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var (
+	_ router.Router = (*BenchedCondition)(nil)
+	_ Condition     = (*BenchedCondition)(nil)
+)
+
+// BenchedCondition reports a [Benched] event when a peer appears to have
+// benched this node on a chain.
+//
+// Peers don't report who they bench, so benching is inferred from the queries
+// that are routed to this node: a peer that benched this node stops querying
+// it. A peer is reported once it stopped querying this node on a chain for at
+// least the benched duration, while other peers kept querying this node on
+// that chain. Only peers that previously queried this node on the chain are
+// reported.
+type BenchedCondition struct {
+	router.Router
+
+	nodeID          ids.NodeID
+	vdrs            validators.Set
+	benchedDuration time.Duration
+	clock           *mockable.Clock
+
+	lock sync.Mutex
+	// chainID -> time this node was last queried on the chain
+	chainLastQueried map[ids.ID]time.Time
+	// nodeID -> chainID -> time this node was last queried by the peer on the
+	// chain
+	peerLastQueried map[ids.NodeID]map[ids.ID]time.Time
+}
+
+// NewBenchedCondition returns a Router that forwards to [r] and records the
+// queries this node receives. [vdrs] is the primary network validator set
+// and [nodeID] is the ID of this node.
+func NewBenchedCondition(
+	r router.Router,
+	nodeID ids.NodeID,
+	vdrs validators.Set,
+	benchedDuration time.Duration,
+	clock *mockable.Clock,
+) *BenchedCondition {
+	return &BenchedCondition{
+		Router:           r,
+		nodeID:           nodeID,
+		vdrs:             vdrs,
+		benchedDuration:  benchedDuration,
+		clock:            clock,
+		chainLastQueried: make(map[ids.ID]time.Time),
+		peerLastQueried:  make(map[ids.NodeID]map[ids.ID]time.Time),
+	}
+}
+
+func (c *BenchedCondition) HandleInbound(ctx context.Context, msg message.InboundMessage) {
+	if op := msg.Op(); op == message.PullQueryOp || op == message.PushQueryOp {
+		c.recordQuery(msg)
+	}
+	c.Router.HandleInbound(ctx, msg)
+}
+
+func (c *BenchedCondition) Disconnected(nodeID ids.NodeID) {
+	c.Router.Disconnected(nodeID)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.peerLastQueried, nodeID)
+}
+
+func (c *BenchedCondition) Check(context.Context) (map[string]Event, error) {
+	// Only validators are queried.
+	if c.vdrs.GetWeight(c.nodeID) == 0 {
+		return nil, nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.clock.Time()
+	events := make(map[string]Event)
+	for nodeID, chains := range c.peerLastQueried {
+		// Peers that stopped validating no longer query this node.
+		if c.vdrs.GetWeight(nodeID) == 0 {
+			continue
+		}
+		for chainID, lastQueried := range chains {
+			// If no peer queried this node recently, the chain is idle rather
+			// than this node being benched.
+			if now.Sub(c.chainLastQueried[chainID]) >= c.benchedDuration {
+				continue
+			}
+
+			notQueriedDuration := now.Sub(lastQueried)
+			if notQueriedDuration < c.benchedDuration {
+				continue
+			}
+			events[nodeID.String()+"-"+chainID.String()] = Event{
+				Type: Benched,
+				Message: fmt.Sprintf(
+					"%s hasn't queried this node on chain %s for %s",
+					nodeID,
+					chainID,
+					notQueriedDuration.Round(time.Second),
+				),
+				Details: map[string]interface{}{
+					"peerNodeID":  nodeID,
+					"chainID":     chainID,
+					"lastQueried": lastQueried.UTC(),
+				},
+			}
+		}
+	}
+	return events, nil
+}
+
+func (c *BenchedCondition) recordQuery(msg message.InboundMessage) {
+	chainID, err := message.GetChainID(msg.Message())
+	if err != nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.clock.Time()
+	nodeID := msg.NodeID()
+	chains, ok := c.peerLastQueried[nodeID]
+	if !ok {
+		chains = make(map[ids.ID]time.Time)
+		c.peerLastQueried[nodeID] = chains
+	}
+	chains[chainID] = now
+	c.chainLastQueried[chainID] = now
+}
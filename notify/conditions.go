@@ -0,0 +1,195 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+var (
+	_ Condition         = (*ChainStallCondition)(nil)
+	_ chains.Registrant = (*ChainStallCondition)(nil)
+)
+
+// UptimeReporter reports the uptime of this node as observed by its peers.
+type UptimeReporter interface {
+	NodeUptime(subnetID ids.ID) (network.UptimeResult, error)
+}
+
+// NewLowUptimeCondition reports a [LowUptime] event when the stake weighted
+// uptime of this node on the primary network, as observed by its peers, is
+// below [requirement].
+func NewLowUptimeCondition(reporter UptimeReporter, requirement float64) Condition {
+	return ConditionFunc(func(context.Context) (map[string]Event, error) {
+		result, err := reporter.NodeUptime(constants.PrimaryNetworkID)
+		if err != nil {
+			return nil, err
+		}
+
+		requiredPercentage := requirement * 100
+		if result.WeightedAveragePercentage >= requiredPercentage {
+			return nil, nil
+		}
+		return map[string]Event{
+			string(LowUptime): {
+				Type: LowUptime,
+				Message: fmt.Sprintf(
+					"observed uptime (%.2f%%) is below the reward requirement (%.2f%%)",
+					result.WeightedAveragePercentage,
+					requiredPercentage,
+				),
+				Details: map[string]interface{}{
+					"weightedAveragePercentage": result.WeightedAveragePercentage,
+					"rewardingStakePercentage":  result.RewardingStakePercentage,
+					"requiredPercentage":        requiredPercentage,
+				},
+			},
+		}, nil
+	})
+}
+
+// NewLowDiskSpaceCondition reports a [LowDiskSpace] event when the available
+// disk space is below [threshold] bytes.
+func NewLowDiskSpaceCondition(diskTracker tracker.DiskTracker, threshold uint64) Condition {
+	return ConditionFunc(func(context.Context) (map[string]Event, error) {
+		availableDiskBytes := diskTracker.AvailableDiskBytes()
+		if availableDiskBytes >= threshold {
+			return nil, nil
+		}
+		return map[string]Event{
+			string(LowDiskSpace): {
+				Type: LowDiskSpace,
+				Message: fmt.Sprintf(
+					"available disk space (%d) is below the warning threshold (%d)",
+					availableDiskBytes,
+					threshold,
+				),
+				Details: map[string]interface{}{
+					"availableDiskBytes": availableDiskBytes,
+					"thresholdDiskBytes": threshold,
+				},
+			},
+		}, nil
+	})
+}
+
+// ValidatorReader reads the current validators of the P-chain.
+type ValidatorReader interface {
+	// GetCurrentValidator returns database.ErrNotFound if [nodeID] isn't a
+	// current validator of [subnetID].
+	GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*state.Staker, error)
+}
+
+// NewValidationEndingCondition reports a [ValidationEnding] event when the
+// primary network validation period of [nodeID] ends within [warning].
+func NewValidationEndingCondition(
+	reader ValidatorReader,
+	nodeID ids.NodeID,
+	warning time.Duration,
+	clock *mockable.Clock,
+) Condition {
+	return ConditionFunc(func(context.Context) (map[string]Event, error) {
+		vdr, err := reader.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+		if err == database.ErrNotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := vdr.EndTime.Sub(clock.Time())
+		if remaining > warning {
+			return nil, nil
+		}
+		return map[string]Event{
+			vdr.TxID.String(): {
+				Type:    ValidationEnding,
+				Message: fmt.Sprintf("validation period ends in %s", remaining.Round(time.Second)),
+				Details: map[string]interface{}{
+					"txID":    vdr.TxID,
+					"endTime": vdr.EndTime.UTC(),
+				},
+			},
+		}, nil
+	})
+}
+
+// ChainStallCondition reports a [ChainStalled] event when the health check of
+// a chain has been failing for at least the stall duration.
+//
+// Chains are tracked once they are registered.
+type ChainStallCondition struct {
+	reporter      health.Reporter
+	stallDuration time.Duration
+	clock         *mockable.Clock
+
+	lock sync.RWMutex
+	// health check name -> chainID
+	chains map[string]ids.ID
+}
+
+func NewChainStallCondition(
+	reporter health.Reporter,
+	stallDuration time.Duration,
+	clock *mockable.Clock,
+) *ChainStallCondition {
+	return &ChainStallCondition{
+		reporter:      reporter,
+		stallDuration: stallDuration,
+		clock:         clock,
+		chains:        make(map[string]ids.ID),
+	}
+}
+
+func (c *ChainStallCondition) RegisterChain(chainName string, ctx *snow.ConsensusContext, _ common.VM) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.chains[chainName] = ctx.ChainID
+}
+
+func (c *ChainStallCondition) Check(context.Context) (map[string]Event, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	results, _ := c.reporter.Health()
+	now := c.clock.Time()
+	events := make(map[string]Event)
+	for chainName, chainID := range c.chains {
+		result, ok := results[chainName]
+		if !ok || result.Error == nil || result.TimeOfFirstFailure == nil {
+			continue
+		}
+
+		unhealthyDuration := now.Sub(*result.TimeOfFirstFailure)
+		if unhealthyDuration < c.stallDuration {
+			continue
+		}
+		events[chainID.String()] = Event{
+			Type:    ChainStalled,
+			Message: fmt.Sprintf("chain %s has been unhealthy for %s", chainName, unhealthyDuration.Round(time.Second)),
+			Details: map[string]interface{}{
+				"chain":   chainName,
+				"chainID": chainID,
+				"error":   *result.Error,
+			},
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+var (
+	errMissingURL     = errors.New("missing webhook url")
+	errInvalidURL     = errors.New("invalid webhook url")
+	errInvalidTimeout = errors.New("webhook request timeout must be positive")
+)
+
+type Config struct {
+	// Endpoints that events are posted to. If empty, no notifications are
+	// sent.
+	Endpoints []EndpointConfig `json:"endpoints"`
+
+	// CheckFrequency is how often the monitored conditions are evaluated.
+	CheckFrequency time.Duration `json:"checkFrequency"`
+
+	// RequestTimeout is the maximum duration of a single webhook request.
+	RequestTimeout time.Duration `json:"requestTimeout"`
+
+	// ValidationEndWarning is how long before the end of this node's
+	// validation period a [ValidationEnding] event is reported.
+	ValidationEndWarning time.Duration `json:"validationEndWarning"`
+
+	// ChainStallDuration is how long a chain must be unhealthy before a
+	// [ChainStalled] event is reported.
+	ChainStallDuration time.Duration `json:"chainStallDuration"`
+
+	// BenchedDuration is how long a peer must stop querying this node on a
+	// chain before a [Benched] event is reported.
+	BenchedDuration time.Duration `json:"benchedDuration"`
+}
+
+type EndpointConfig struct {
+	// URL that events are POSTed to.
+	URL string `json:"url"`
+
+	// Secret used to sign the request body with HMAC-SHA256. If empty, the
+	// requests are not signed.
+	//
+	// The secret is never marshalled so that it isn't included when the node
+	// config is logged.
+	Secret string `json:"-"`
+
+	// Events that should be sent to this endpoint. If empty, all events are
+	// sent.
+	Events []EventType `json:"events"`
+}
+
+// endpointJSON is the format of an endpoint in the webhook config file.
+type endpointJSON struct {
+	URL    string      `json:"url"`
+	Secret string      `json:"secret"`
+	Events []EventType `json:"events"`
+}
+
+// ParseEndpoints parses the endpoints in the webhook config file.
+func ParseEndpoints(configBytes []byte) ([]EndpointConfig, error) {
+	var parsed struct {
+		Endpoints []endpointJSON `json:"endpoints"`
+	}
+	if err := json.Unmarshal(configBytes, &parsed); err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]EndpointConfig, len(parsed.Endpoints))
+	for i, e := range parsed.Endpoints {
+		endpoints[i] = EndpointConfig{
+			URL:    e.URL,
+			Secret: e.Secret,
+			Events: e.Events,
+		}
+	}
+	return endpoints, nil
+}
+
+func (c *Config) Enabled() bool {
+	return len(c.Endpoints) > 0
+}
+
+func (c *Config) Verify() error {
+	if c.Enabled() && c.RequestTimeout <= 0 {
+		return errInvalidTimeout
+	}
+	for i := range c.Endpoints {
+		if err := c.Endpoints[i].Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *EndpointConfig) Verify() error {
+	if c.URL == "" {
+		return errMissingURL
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported scheme %q", errInvalidURL, u.Scheme)
+	}
+	for _, eventType := range c.Events {
+		if err := eventType.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	// Benched is reported when this node appears to be benched by a peer.
+	Benched EventType = "benched"
+	// LowUptime is reported when the uptime of this node, as observed by its
+	// peers, is below the uptime required to be rewarded.
+	LowUptime EventType = "lowUptime"
+	// ValidationEnding is reported when the validation period of this node is
+	// about to end.
+	ValidationEnding EventType = "validationEnding"
+	// ChainStalled is reported when a chain has been unhealthy for an extended
+	// period of time.
+	ChainStalled EventType = "chainStalled"
+	// LowDiskSpace is reported when the available disk space is below the
+	// warning threshold.
+	LowDiskSpace EventType = "lowDiskSpace"
+)
+
+var (
+	EventTypes = []EventType{
+		Benched,
+		LowUptime,
+		ValidationEnding,
+		ChainStalled,
+		LowDiskSpace,
+	}
+
+	errUnknownEventType = errors.New("unknown event type")
+)
+
+type EventType string
+
+func (t EventType) Verify() error {
+	for _, eventType := range EventTypes {
+		if t == eventType {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", errUnknownEventType, t)
+}
+
+// Event is the payload that is sent to the webhook endpoints.
+type Event struct {
+	Type      EventType              `json:"type"`
+	NodeID    ids.NodeID             `json:"nodeID"`
+	Timestamp time.Time              `json:"timestamp"`
+	Message   string                 `json:"message"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	_ Monitor   = (*monitor)(nil)
+	_ Condition = ConditionFunc(nil)
+
+	errDuplicatedCondition = errors.New("duplicated condition")
+)
+
+// Condition is a state of the node that operators should be notified of.
+type Condition interface {
+	// Check returns the events that currently apply, keyed by an identifier
+	// that is stable across checks. An event is only reported the first time
+	// its key is returned, until its key is no longer returned.
+	Check(context.Context) (map[string]Event, error)
+}
+
+type ConditionFunc func(context.Context) (map[string]Event, error)
+
+func (f ConditionFunc) Check(ctx context.Context) (map[string]Event, error) {
+	return f(ctx)
+}
+
+// Monitor periodically checks conditions and reports their events.
+type Monitor interface {
+	// RegisterCondition adds [condition] to the conditions that are checked.
+	RegisterCondition(name string, condition Condition) error
+
+	// Start checking the conditions at the specified frequency.
+	// Repeated calls to Start will be no-ops.
+	Start(ctx context.Context, freq time.Duration)
+
+	// Stop checking the conditions. Stop should only be called after Start.
+	Stop()
+}
+
+type monitor struct {
+	log      logging.Logger
+	notifier Notifier
+
+	lock       sync.Mutex
+	conditions map[string]Condition
+	// condition name -> keys of the events that are currently active
+	active map[string]set.Set[string]
+
+	startOnce sync.Once
+	closeOnce sync.Once
+	closer    chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewMonitor(log logging.Logger, notifier Notifier) Monitor {
+	return &monitor{
+		log:        log,
+		notifier:   notifier,
+		conditions: make(map[string]Condition),
+		active:     make(map[string]set.Set[string]),
+		closer:     make(chan struct{}),
+	}
+}
+
+func (m *monitor) RegisterCondition(name string, condition Condition) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.conditions[name]; ok {
+		return fmt.Errorf("%w: %q", errDuplicatedCondition, name)
+	}
+	m.conditions[name] = condition
+	return nil
+}
+
+func (m *monitor) Start(ctx context.Context, freq time.Duration) {
+	m.startOnce.Do(func() {
+		detachedCtx := utils.Detach(ctx)
+		m.wg.Add(1)
+		go func() {
+			ticker := time.NewTicker(freq)
+			defer func() {
+				ticker.Stop()
+				m.wg.Done()
+			}()
+
+			m.check(detachedCtx)
+			for {
+				select {
+				case <-ticker.C:
+					m.check(detachedCtx)
+				case <-m.closer:
+					return
+				}
+			}
+		}()
+	})
+}
+
+func (m *monitor) Stop() {
+	m.closeOnce.Do(func() {
+		close(m.closer)
+		m.wg.Wait()
+	})
+}
+
+func (m *monitor) check(ctx context.Context) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for name, condition := range m.conditions {
+		events, err := condition.Check(ctx)
+		if err != nil {
+			m.log.Debug("failed to check condition",
+				zap.String("name", name),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		prevActive := m.active[name]
+		active := set.NewSet[string](len(events))
+		for key, event := range events {
+			active.Add(key)
+			if !prevActive.Contains(key) {
+				m.notifier.Notify(event)
+			}
+		}
+		m.active[name] = active
+	}
+}
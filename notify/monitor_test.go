@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+type testNotifier struct {
+	events []Event
+}
+
+func (n *testNotifier) Notify(event Event) {
+	n.events = append(n.events, event)
+}
+
+func (*testNotifier) Shutdown() {}
+
+type testReporter struct {
+	results map[string]health.Result
+}
+
+func (*testReporter) Readiness(...string) (map[string]health.Result, bool) {
+	return nil, true
+}
+
+func (r *testReporter) Health(...string) (map[string]health.Result, bool) {
+	return r.results, false
+}
+
+func (*testReporter) Liveness(...string) (map[string]health.Result, bool) {
+	return nil, true
+}
+
+func TestMonitorOnlyReportsNewEvents(t *testing.T) {
+	require := require.New(t)
+
+	notifier := &testNotifier{}
+	m := NewMonitor(logging.NoLog{}, notifier).(*monitor)
+
+	var active map[string]Event
+	require.NoError(m.RegisterCondition("test", ConditionFunc(func(context.Context) (map[string]Event, error) {
+		return active, nil
+	})))
+
+	err := m.RegisterCondition("test", ConditionFunc(nil))
+	require.ErrorIs(err, errDuplicatedCondition)
+
+	m.check(context.Background())
+	require.Empty(notifier.events)
+
+	active = map[string]Event{
+		"a": {Type: LowDiskSpace},
+	}
+	m.check(context.Background())
+	require.Len(notifier.events, 1)
+
+	// The event is still active, so it shouldn't be reported again
+	m.check(context.Background())
+	require.Len(notifier.events, 1)
+
+	active = map[string]Event{
+		"a": {Type: LowDiskSpace},
+		"b": {Type: LowUptime},
+	}
+	m.check(context.Background())
+	require.Len(notifier.events, 2)
+	require.Equal(LowUptime, notifier.events[1].Type)
+
+	// Once resolved, the event should be reported again if it re-occurs
+	active = nil
+	m.check(context.Background())
+	active = map[string]Event{
+		"a": {Type: LowDiskSpace},
+	}
+	m.check(context.Background())
+	require.Len(notifier.events, 3)
+	require.Equal(LowDiskSpace, notifier.events[2].Type)
+}
+
+func TestChainStallCondition(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	clock := &mockable.Clock{}
+	clock.Set(now)
+
+	errStr := "not healthy"
+	firstFailure := now.Add(-time.Minute)
+	reporter := &testReporter{
+		results: map[string]health.Result{
+			"X": {
+				Error:              &errStr,
+				TimeOfFirstFailure: &firstFailure,
+			},
+			"P": {},
+		},
+	}
+
+	c := NewChainStallCondition(reporter, 2*time.Minute, clock)
+	xChainID := ids.GenerateTestID()
+	c.RegisterChain("X", &snow.ConsensusContext{Context: &snow.Context{ChainID: xChainID}}, nil)
+	c.RegisterChain("P", &snow.ConsensusContext{Context: &snow.Context{ChainID: ids.GenerateTestID()}}, nil)
+
+	events, err := c.Check(context.Background())
+	require.NoError(err)
+	require.Empty(events)
+
+	clock.Set(now.Add(time.Minute))
+	events, err = c.Check(context.Background())
+	require.NoError(err)
+	require.Len(events, 1)
+
+	event := events[xChainID.String()]
+	require.Equal(ChainStalled, event.Type)
+	require.Equal(xChainID, event.Details["chainID"])
+}
+
+func TestBenchedCondition(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	now := time.Now()
+	clock := &mockable.Clock{}
+	clock.Set(now)
+
+	nodeID := ids.GenerateTestNodeID()
+	benchingNodeID := ids.GenerateTestNodeID()
+	queryingNodeID := ids.GenerateTestNodeID()
+	vdrs := validators.NewSet()
+	require.NoError(vdrs.Add(benchingNodeID, nil, ids.Empty, 1))
+	require.NoError(vdrs.Add(queryingNodeID, nil, ids.Empty, 1))
+
+	r := router.NewMockRouter(ctrl)
+	r.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).AnyTimes()
+	c := NewBenchedCondition(r, nodeID, vdrs, 5*time.Minute, clock)
+
+	chainID := ids.GenerateTestID()
+	query := func(nodeID ids.NodeID) {
+		c.HandleInbound(context.Background(), message.InboundPullQuery(
+			chainID,
+			0,
+			time.Second,
+			ids.Empty,
+			nodeID,
+			p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		))
+	}
+	query(benchingNodeID)
+	query(queryingNodeID)
+
+	// Non-validators aren't queried, so they are never reported.
+	clock.Set(now.Add(4 * time.Minute))
+	query(queryingNodeID)
+	clock.Set(now.Add(6 * time.Minute))
+	events, err := c.Check(context.Background())
+	require.NoError(err)
+	require.Empty(events)
+
+	require.NoError(vdrs.Add(nodeID, nil, ids.Empty, 1))
+	events, err = c.Check(context.Background())
+	require.NoError(err)
+	require.Len(events, 1)
+
+	event := events[benchingNodeID.String()+"-"+chainID.String()]
+	require.Equal(Benched, event.Type)
+	require.Equal(benchingNodeID, event.Details["peerNodeID"])
+
+	// Once no peer queries this node, the chain is considered idle.
+	clock.Set(now.Add(10 * time.Minute))
+	events, err = c.Check(context.Background())
+	require.NoError(err)
+	require.Empty(events)
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+const (
+	// EventHeader is the request header that contains the type of the event.
+	EventHeader = "X-Avalanche-Event"
+	// SignatureHeader is the request header that contains the hex encoded
+	// HMAC-SHA256 of the request body, prefixed with "sha256=".
+	SignatureHeader = "X-Avalanche-Signature"
+
+	signaturePrefix = "sha256="
+
+	// maxQueuedEvents is the number of events that can be waiting to be sent
+	// to a single endpoint before new events are dropped.
+	maxQueuedEvents = 64
+)
+
+var _ Notifier = (*webhook)(nil)
+
+// Notifier reports events to operators.
+type Notifier interface {
+	// Notify queues [event] to be sent. Notify never blocks. The node ID and
+	// the timestamp of the event are populated by the Notifier.
+	Notify(event Event)
+
+	// Shutdown stops sending events. Any events that are still queued are
+	// dropped.
+	Shutdown()
+}
+
+type webhook struct {
+	log       logging.Logger
+	nodeID    ids.NodeID
+	clock     mockable.Clock
+	client    *http.Client
+	endpoints []*endpoint
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type endpoint struct {
+	url    string
+	secret []byte
+	// if empty, all events are sent
+	events set.Set[EventType]
+	queue  chan *request
+}
+
+type request struct {
+	eventType EventType
+	body      []byte
+}
+
+// NewWebhook returns a Notifier that POSTs JSON encoded events to the
+// endpoints in [config].
+func NewWebhook(log logging.Logger, nodeID ids.NodeID, config Config) Notifier {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &webhook{
+		log:    log,
+		nodeID: nodeID,
+		client: &http.Client{
+			Timeout: config.RequestTimeout,
+		},
+		endpoints: make([]*endpoint, len(config.Endpoints)),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	for i, endpointConfig := range config.Endpoints {
+		e := &endpoint{
+			url:    endpointConfig.URL,
+			secret: []byte(endpointConfig.Secret),
+			events: set.Of(endpointConfig.Events...),
+			queue:  make(chan *request, maxQueuedEvents),
+		}
+		w.endpoints[i] = e
+
+		w.wg.Add(1)
+		go w.send(e)
+	}
+	return w
+}
+
+func (w *webhook) Notify(event Event) {
+	event.NodeID = w.nodeID
+	event.Timestamp = w.clock.Time().UTC()
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		w.log.Error("failed to marshal event",
+			zap.String("type", string(event.Type)),
+			zap.Error(err),
+		)
+		return
+	}
+
+	req := &request{
+		eventType: event.Type,
+		body:      body,
+	}
+	for _, e := range w.endpoints {
+		if e.events.Len() != 0 && !e.events.Contains(event.Type) {
+			continue
+		}
+
+		select {
+		case e.queue <- req:
+		default:
+			w.log.Warn("dropping webhook event",
+				zap.String("reason", "too many queued events"),
+				zap.String("url", e.url),
+				zap.String("type", string(event.Type)),
+			)
+		}
+	}
+}
+
+func (w *webhook) Shutdown() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *webhook) send(e *endpoint) {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case req := <-e.queue:
+			if err := w.post(e, req); err != nil {
+				w.log.Warn("failed to send webhook event",
+					zap.String("url", e.url),
+					zap.String("type", string(req.eventType)),
+					zap.Error(err),
+				)
+			}
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *webhook) post(e *endpoint, req *request) error {
+	httpReq, err := http.NewRequestWithContext(w.ctx, http.MethodPost, e.url, bytes.NewReader(req.body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(EventHeader, string(req.eventType))
+	if len(e.secret) != 0 {
+		httpReq.Header.Set(SignatureHeader, Sign(e.secret, req.body))
+	}
+
+	resp, err := w.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("received status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the value of the [SignatureHeader] for [body] when signed with
+// [secret].
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, <-chan receivedRequest) {
+	requests := make(chan receivedRequest, maxQueuedEvents)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests <- receivedRequest{
+			header: r.Header,
+			body:   body,
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestWebhookNotify(t *testing.T) {
+	require := require.New(t)
+
+	server, requests := newTestServer(t)
+	nodeID := ids.GenerateTestNodeID()
+	secret := "secret"
+	notifier := NewWebhook(logging.NoLog{}, nodeID, Config{
+		Endpoints: []EndpointConfig{
+			{
+				URL:    server.URL,
+				Secret: secret,
+			},
+		},
+		RequestTimeout: time.Second,
+	})
+	defer notifier.Shutdown()
+
+	notifier.Notify(Event{
+		Type:    LowDiskSpace,
+		Message: "low disk space",
+	})
+
+	req := <-requests
+	require.Equal(string(LowDiskSpace), req.header.Get(EventHeader))
+	require.Equal("application/json", req.header.Get("Content-Type"))
+	require.Equal(Sign([]byte(secret), req.body), req.header.Get(SignatureHeader))
+
+	var event Event
+	require.NoError(json.Unmarshal(req.body, &event))
+	require.Equal(LowDiskSpace, event.Type)
+	require.Equal(nodeID, event.NodeID)
+	require.Equal("low disk space", event.Message)
+}
+
+func TestWebhookEventFilter(t *testing.T) {
+	require := require.New(t)
+
+	filteredServer, filteredRequests := newTestServer(t)
+	allServer, allRequests := newTestServer(t)
+	notifier := NewWebhook(logging.NoLog{}, ids.GenerateTestNodeID(), Config{
+		Endpoints: []EndpointConfig{
+			{
+				URL:    filteredServer.URL,
+				Events: []EventType{ChainStalled},
+			},
+			{
+				URL: allServer.URL,
+			},
+		},
+		RequestTimeout: time.Second,
+	})
+	defer notifier.Shutdown()
+
+	notifier.Notify(Event{Type: LowUptime})
+	notifier.Notify(Event{Type: ChainStalled})
+
+	req := <-filteredRequests
+	require.Equal(string(ChainStalled), req.header.Get(EventHeader))
+	require.Empty(req.header.Get(SignatureHeader))
+
+	req = <-allRequests
+	require.Equal(string(LowUptime), req.header.Get(EventHeader))
+	req = <-allRequests
+	require.Equal(string(ChainStalled), req.header.Get(EventHeader))
+}
+
+func TestParseEndpoints(t *testing.T) {
+	require := require.New(t)
+
+	endpoints, err := ParseEndpoints([]byte(`{
+		"endpoints": [
+			{
+				"url": "https://example.com/hook",
+				"secret": "secret",
+				"events": ["benched", "lowUptime"]
+			}
+		]
+	}`))
+	require.NoError(err)
+	require.Equal([]EndpointConfig{
+		{
+			URL:    "https://example.com/hook",
+			Secret: "secret",
+			Events: []EventType{Benched, LowUptime},
+		},
+	}, endpoints)
+
+	config := Config{
+		Endpoints:      endpoints,
+		RequestTimeout: time.Second,
+	}
+	require.NoError(config.Verify())
+
+	// The secret must never be marshalled
+	configBytes, err := json.Marshal(config)
+	require.NoError(err)
+	require.NotContains(string(configBytes), "secret")
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "missing url",
+			config: Config{
+				Endpoints:      []EndpointConfig{{}},
+				RequestTimeout: time.Second,
+			},
+			expectedErr: errMissingURL,
+		},
+		{
+			name: "invalid scheme",
+			config: Config{
+				Endpoints: []EndpointConfig{{
+					URL: "ftp://example.com",
+				}},
+				RequestTimeout: time.Second,
+			},
+			expectedErr: errInvalidURL,
+		},
+		{
+			name: "unknown event",
+			config: Config{
+				Endpoints: []EndpointConfig{{
+					URL:    "http://example.com",
+					Events: []EventType{"unknown"},
+				}},
+				RequestTimeout: time.Second,
+			},
+			expectedErr: errUnknownEventType,
+		},
+		{
+			name: "invalid timeout",
+			config: Config{
+				Endpoints: []EndpointConfig{{
+					URL: "http://example.com",
+				}},
+			},
+			expectedErr: errInvalidTimeout,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
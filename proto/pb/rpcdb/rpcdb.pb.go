@@ -275,6 +275,101 @@ func (x *GetResponse) GetErr() Error {
 	return Error_ERROR_UNSPECIFIED
 }
 
+type GetManyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *GetManyRequest) Reset() {
+	*x = GetManyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetManyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManyRequest) ProtoMessage() {}
+
+func (x *GetManyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManyRequest.ProtoReflect.Descriptor instead.
+func (*GetManyRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetManyRequest) GetKeys() [][]byte {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type GetManyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// values are returned in the same order as the requested keys.
+	Values []*GetResponse `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *GetManyResponse) Reset() {
+	*x = GetManyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetManyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManyResponse) ProtoMessage() {}
+
+func (x *GetManyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManyResponse.ProtoReflect.Descriptor instead.
+func (*GetManyResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetManyResponse) GetValues() []*GetResponse {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 type PutRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -287,7 +382,7 @@ type PutRequest struct {
 func (x *PutRequest) Reset() {
 	*x = PutRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[4]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -300,7 +395,7 @@ func (x *PutRequest) String() string {
 func (*PutRequest) ProtoMessage() {}
 
 func (x *PutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[4]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -313,7 +408,7 @@ func (x *PutRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutRequest.ProtoReflect.Descriptor instead.
 func (*PutRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{4}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PutRequest) GetKey() []byte {
@@ -341,7 +436,7 @@ type PutResponse struct {
 func (x *PutResponse) Reset() {
 	*x = PutResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[5]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -354,7 +449,7 @@ func (x *PutResponse) String() string {
 func (*PutResponse) ProtoMessage() {}
 
 func (x *PutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[5]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -367,7 +462,7 @@ func (x *PutResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutResponse.ProtoReflect.Descriptor instead.
 func (*PutResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{5}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PutResponse) GetErr() Error {
@@ -388,7 +483,7 @@ type DeleteRequest struct {
 func (x *DeleteRequest) Reset() {
 	*x = DeleteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[6]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -401,7 +496,7 @@ func (x *DeleteRequest) String() string {
 func (*DeleteRequest) ProtoMessage() {}
 
 func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[6]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -414,7 +509,7 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{6}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeleteRequest) GetKey() []byte {
@@ -435,7 +530,7 @@ type DeleteResponse struct {
 func (x *DeleteResponse) Reset() {
 	*x = DeleteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[7]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -448,7 +543,7 @@ func (x *DeleteResponse) String() string {
 func (*DeleteResponse) ProtoMessage() {}
 
 func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[7]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,7 +556,7 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{7}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeleteResponse) GetErr() Error {
@@ -483,7 +578,7 @@ type CompactRequest struct {
 func (x *CompactRequest) Reset() {
 	*x = CompactRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[8]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -496,7 +591,7 @@ func (x *CompactRequest) String() string {
 func (*CompactRequest) ProtoMessage() {}
 
 func (x *CompactRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[8]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -509,7 +604,7 @@ func (x *CompactRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompactRequest.ProtoReflect.Descriptor instead.
 func (*CompactRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{8}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CompactRequest) GetStart() []byte {
@@ -537,7 +632,7 @@ type CompactResponse struct {
 func (x *CompactResponse) Reset() {
 	*x = CompactResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[9]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -550,7 +645,7 @@ func (x *CompactResponse) String() string {
 func (*CompactResponse) ProtoMessage() {}
 
 func (x *CompactResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[9]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -563,7 +658,7 @@ func (x *CompactResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompactResponse.ProtoReflect.Descriptor instead.
 func (*CompactResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{9}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CompactResponse) GetErr() Error {
@@ -582,7 +677,7 @@ type CloseRequest struct {
 func (x *CloseRequest) Reset() {
 	*x = CloseRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[10]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -595,7 +690,7 @@ func (x *CloseRequest) String() string {
 func (*CloseRequest) ProtoMessage() {}
 
 func (x *CloseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[10]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -608,7 +703,7 @@ func (x *CloseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseRequest.ProtoReflect.Descriptor instead.
 func (*CloseRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{10}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{12}
 }
 
 type CloseResponse struct {
@@ -622,7 +717,7 @@ type CloseResponse struct {
 func (x *CloseResponse) Reset() {
 	*x = CloseResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[11]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -635,7 +730,7 @@ func (x *CloseResponse) String() string {
 func (*CloseResponse) ProtoMessage() {}
 
 func (x *CloseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[11]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,7 +743,7 @@ func (x *CloseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseResponse.ProtoReflect.Descriptor instead.
 func (*CloseResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{11}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CloseResponse) GetErr() Error {
@@ -670,7 +765,7 @@ type WriteBatchRequest struct {
 func (x *WriteBatchRequest) Reset() {
 	*x = WriteBatchRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[12]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -683,7 +778,7 @@ func (x *WriteBatchRequest) String() string {
 func (*WriteBatchRequest) ProtoMessage() {}
 
 func (x *WriteBatchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[12]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -696,7 +791,7 @@ func (x *WriteBatchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteBatchRequest.ProtoReflect.Descriptor instead.
 func (*WriteBatchRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{12}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *WriteBatchRequest) GetPuts() []*PutRequest {
@@ -724,7 +819,7 @@ type WriteBatchResponse struct {
 func (x *WriteBatchResponse) Reset() {
 	*x = WriteBatchResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[13]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -737,7 +832,7 @@ func (x *WriteBatchResponse) String() string {
 func (*WriteBatchResponse) ProtoMessage() {}
 
 func (x *WriteBatchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[13]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,7 +845,7 @@ func (x *WriteBatchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteBatchResponse.ProtoReflect.Descriptor instead.
 func (*WriteBatchResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{13}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *WriteBatchResponse) GetErr() Error {
@@ -769,7 +864,7 @@ type NewIteratorRequest struct {
 func (x *NewIteratorRequest) Reset() {
 	*x = NewIteratorRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[14]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -782,7 +877,7 @@ func (x *NewIteratorRequest) String() string {
 func (*NewIteratorRequest) ProtoMessage() {}
 
 func (x *NewIteratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[14]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -795,7 +890,7 @@ func (x *NewIteratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewIteratorRequest.ProtoReflect.Descriptor instead.
 func (*NewIteratorRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{14}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{16}
 }
 
 type NewIteratorWithStartAndPrefixRequest struct {
@@ -810,7 +905,7 @@ type NewIteratorWithStartAndPrefixRequest struct {
 func (x *NewIteratorWithStartAndPrefixRequest) Reset() {
 	*x = NewIteratorWithStartAndPrefixRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[15]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -823,7 +918,7 @@ func (x *NewIteratorWithStartAndPrefixRequest) String() string {
 func (*NewIteratorWithStartAndPrefixRequest) ProtoMessage() {}
 
 func (x *NewIteratorWithStartAndPrefixRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[15]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -836,7 +931,7 @@ func (x *NewIteratorWithStartAndPrefixRequest) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use NewIteratorWithStartAndPrefixRequest.ProtoReflect.Descriptor instead.
 func (*NewIteratorWithStartAndPrefixRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{15}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *NewIteratorWithStartAndPrefixRequest) GetStart() []byte {
@@ -864,7 +959,7 @@ type NewIteratorWithStartAndPrefixResponse struct {
 func (x *NewIteratorWithStartAndPrefixResponse) Reset() {
 	*x = NewIteratorWithStartAndPrefixResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[16]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -877,7 +972,7 @@ func (x *NewIteratorWithStartAndPrefixResponse) String() string {
 func (*NewIteratorWithStartAndPrefixResponse) ProtoMessage() {}
 
 func (x *NewIteratorWithStartAndPrefixResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[16]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -890,7 +985,7 @@ func (x *NewIteratorWithStartAndPrefixResponse) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use NewIteratorWithStartAndPrefixResponse.ProtoReflect.Descriptor instead.
 func (*NewIteratorWithStartAndPrefixResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{16}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *NewIteratorWithStartAndPrefixResponse) GetId() uint64 {
@@ -911,7 +1006,7 @@ type IteratorNextRequest struct {
 func (x *IteratorNextRequest) Reset() {
 	*x = IteratorNextRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -924,7 +1019,7 @@ func (x *IteratorNextRequest) String() string {
 func (*IteratorNextRequest) ProtoMessage() {}
 
 func (x *IteratorNextRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -937,7 +1032,7 @@ func (x *IteratorNextRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorNextRequest.ProtoReflect.Descriptor instead.
 func (*IteratorNextRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{17}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *IteratorNextRequest) GetId() uint64 {
@@ -958,7 +1053,7 @@ type IteratorNextResponse struct {
 func (x *IteratorNextResponse) Reset() {
 	*x = IteratorNextResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -971,7 +1066,7 @@ func (x *IteratorNextResponse) String() string {
 func (*IteratorNextResponse) ProtoMessage() {}
 
 func (x *IteratorNextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -984,7 +1079,7 @@ func (x *IteratorNextResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorNextResponse.ProtoReflect.Descriptor instead.
 func (*IteratorNextResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{18}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *IteratorNextResponse) GetData() []*PutRequest {
@@ -994,6 +1089,53 @@ func (x *IteratorNextResponse) GetData() []*PutRequest {
 	return nil
 }
 
+type IteratorStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *IteratorStreamRequest) Reset() {
+	*x = IteratorStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorStreamRequest) ProtoMessage() {}
+
+func (x *IteratorStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorStreamRequest.ProtoReflect.Descriptor instead.
+func (*IteratorStreamRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *IteratorStreamRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 type IteratorErrorRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1005,7 +1147,7 @@ type IteratorErrorRequest struct {
 func (x *IteratorErrorRequest) Reset() {
 	*x = IteratorErrorRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1018,7 +1160,7 @@ func (x *IteratorErrorRequest) String() string {
 func (*IteratorErrorRequest) ProtoMessage() {}
 
 func (x *IteratorErrorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1031,7 +1173,7 @@ func (x *IteratorErrorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorErrorRequest.ProtoReflect.Descriptor instead.
 func (*IteratorErrorRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{19}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *IteratorErrorRequest) GetId() uint64 {
@@ -1052,7 +1194,7 @@ type IteratorErrorResponse struct {
 func (x *IteratorErrorResponse) Reset() {
 	*x = IteratorErrorResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1065,7 +1207,7 @@ func (x *IteratorErrorResponse) String() string {
 func (*IteratorErrorResponse) ProtoMessage() {}
 
 func (x *IteratorErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1078,7 +1220,7 @@ func (x *IteratorErrorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorErrorResponse.ProtoReflect.Descriptor instead.
 func (*IteratorErrorResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{20}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *IteratorErrorResponse) GetErr() Error {
@@ -1099,7 +1241,7 @@ type IteratorReleaseRequest struct {
 func (x *IteratorReleaseRequest) Reset() {
 	*x = IteratorReleaseRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1112,7 +1254,7 @@ func (x *IteratorReleaseRequest) String() string {
 func (*IteratorReleaseRequest) ProtoMessage() {}
 
 func (x *IteratorReleaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1125,7 +1267,7 @@ func (x *IteratorReleaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorReleaseRequest.ProtoReflect.Descriptor instead.
 func (*IteratorReleaseRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{21}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *IteratorReleaseRequest) GetId() uint64 {
@@ -1146,7 +1288,7 @@ type IteratorReleaseResponse struct {
 func (x *IteratorReleaseResponse) Reset() {
 	*x = IteratorReleaseResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1159,7 +1301,7 @@ func (x *IteratorReleaseResponse) String() string {
 func (*IteratorReleaseResponse) ProtoMessage() {}
 
 func (x *IteratorReleaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1172,7 +1314,7 @@ func (x *IteratorReleaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorReleaseResponse.ProtoReflect.Descriptor instead.
 func (*IteratorReleaseResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{22}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *IteratorReleaseResponse) GetErr() Error {
@@ -1193,7 +1335,7 @@ type HealthCheckResponse struct {
 func (x *HealthCheckResponse) Reset() {
 	*x = HealthCheckResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1206,7 +1348,7 @@ func (x *HealthCheckResponse) String() string {
 func (*HealthCheckResponse) ProtoMessage() {}
 
 func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1219,7 +1361,7 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{23}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *HealthCheckResponse) GetDetails() []byte {
@@ -1247,131 +1389,148 @@ var file_rpcdb_rpcdb_proto_rawDesc = []byte{
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1e, 0x0a,
 	0x03, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63,
-	0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x34, 0x0a,
-	0x0a, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x22, 0x2d, 0x0a, 0x0b, 0x50, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65,
-	0x72, 0x72, 0x22, 0x21, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x30, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x24, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x6b,
+	0x65, 0x79, 0x73, 0x22, 0x3d, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x22, 0x34, 0x0a, 0x0a, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x2d, 0x0a, 0x0b, 0x50, 0x75, 0x74, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x3c, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
-	0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12,
-	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
-	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x31, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x0e, 0x0a, 0x0c, 0x43, 0x6c, 0x6f, 0x73,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2f, 0x0a, 0x0d, 0x43, 0x6c, 0x6f, 0x73,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45,
-	0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x6a, 0x0a, 0x11, 0x57, 0x72, 0x69,
-	0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25,
-	0x0a, 0x04, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72,
-	0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
-	0x04, 0x70, 0x75, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x64, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x12, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61,
-	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65,
-	0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62,
-	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x14, 0x0a, 0x12, 0x4e,
-	0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x54, 0x0a, 0x24, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
-	0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66,
-	0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12,
-	0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x37, 0x0a, 0x25, 0x4e, 0x65, 0x77, 0x49, 0x74,
-	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41,
-	0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
-	0x22, 0x25, 0x0a, 0x13, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3d, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x25, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x26, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74,
-	0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x37,
-	0x0a, 0x15, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x28, 0x0a, 0x16, 0x49, 0x74, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
-	0x64, 0x22, 0x39, 0x0a, 0x17, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c,
-	0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03,
+	0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x21, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x30, 0x0a, 0x0e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03,
 	0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64,
-	0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x2f, 0x0a, 0x13,
-	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2a, 0x45, 0x0a,
-	0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f,
-	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a,
-	0x0c, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x01, 0x12,
-	0x13, 0x0a, 0x0f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55,
-	0x4e, 0x44, 0x10, 0x02, 0x32, 0xa2, 0x06, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
-	0x65, 0x12, 0x2c, 0x0a, 0x03, 0x48, 0x61, 0x73, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62,
-	0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70,
-	0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2c, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47,
-	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64,
-	0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a,
-	0x03, 0x50, 0x75, 0x74, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
-	0x50, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x70,
-	0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x12, 0x15, 0x2e,
-	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05,
-	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c,
-	0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x70, 0x63,
-	0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x41, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
-	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x12, 0x18, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42,
-	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x70,
-	0x63, 0x64, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7a, 0x0a, 0x1d, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65,
+	0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x3c, 0x0a, 0x0e,
+	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x31, 0x0a, 0x0f, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a,
+	0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63,
+	0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x0e, 0x0a,
+	0x0c, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2f, 0x0a,
+	0x0d, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e,
+	0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70,
+	0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x6a,
+	0x0a, 0x11, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x04, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x70, 0x75, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x70,
+	0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x12, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72,
+	0x22, 0x14, 0x0a, 0x12, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x54, 0x0a, 0x24, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65,
 	0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e,
-	0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x2b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
+	0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x37, 0x0a, 0x25,
 	0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53,
-	0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77,
-	0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65,
-	0x78, 0x74, 0x12, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
-	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e,
-	0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x49,
-	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1b, 0x2e, 0x72,
-	0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x70, 0x63, 0x64,
-	0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0f, 0x49, 0x74, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x2e, 0x72, 0x70, 0x63,
-	0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61,
-	0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x70, 0x63, 0x64,
-	0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x76, 0x61, 0x2d, 0x6c, 0x61, 0x62, 0x73,
-	0x2f, 0x61, 0x76, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x68, 0x65, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f, 0x72, 0x70, 0x63, 0x64, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x25, 0x0a, 0x13, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3d, 0x0a, 0x14,
+	0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x27, 0x0a, 0x15, 0x49,
+	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0x26, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x37, 0x0a, 0x15,
+	0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x28, 0x0a, 0x16, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x39, 0x0a, 0x17, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x2f, 0x0a, 0x13, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2a, 0x45, 0x0a, 0x05, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x01, 0x12, 0x13, 0x0a,
+	0x0f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44,
+	0x10, 0x02, 0x32, 0xab, 0x07, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12,
+	0x2c, 0x0a, 0x03, 0x48, 0x61, 0x73, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48,
+	0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64,
+	0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a,
+	0x03, 0x47, 0x65, 0x74, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x47,
+	0x65, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x12, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x11, 0x2e, 0x72,
+	0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x14, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x63, 0x74, 0x12, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x72,
+	0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x13, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x72, 0x70, 0x63, 0x64,
+	0x62, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7a,
+	0x0a, 0x1d, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74,
+	0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x2b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x72,
+	0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x49, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x2e, 0x72, 0x70, 0x63,
+	0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49,
+	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x30, 0x01, 0x12, 0x4a, 0x0a, 0x0d, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50,
+	0x0a, 0x0f, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x12, 0x1d, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1e, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
+	0x76, 0x61, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x61, 0x76, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x68,
+	0x65, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f, 0x72, 0x70, 0x63,
+	0x64, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1387,77 +1546,85 @@ func file_rpcdb_rpcdb_proto_rawDescGZIP() []byte {
 }
 
 var file_rpcdb_rpcdb_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_rpcdb_rpcdb_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_rpcdb_rpcdb_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_rpcdb_rpcdb_proto_goTypes = []interface{}{
 	(Error)(0),                                    // 0: rpcdb.Error
 	(*HasRequest)(nil),                            // 1: rpcdb.HasRequest
 	(*HasResponse)(nil),                           // 2: rpcdb.HasResponse
 	(*GetRequest)(nil),                            // 3: rpcdb.GetRequest
 	(*GetResponse)(nil),                           // 4: rpcdb.GetResponse
-	(*PutRequest)(nil),                            // 5: rpcdb.PutRequest
-	(*PutResponse)(nil),                           // 6: rpcdb.PutResponse
-	(*DeleteRequest)(nil),                         // 7: rpcdb.DeleteRequest
-	(*DeleteResponse)(nil),                        // 8: rpcdb.DeleteResponse
-	(*CompactRequest)(nil),                        // 9: rpcdb.CompactRequest
-	(*CompactResponse)(nil),                       // 10: rpcdb.CompactResponse
-	(*CloseRequest)(nil),                          // 11: rpcdb.CloseRequest
-	(*CloseResponse)(nil),                         // 12: rpcdb.CloseResponse
-	(*WriteBatchRequest)(nil),                     // 13: rpcdb.WriteBatchRequest
-	(*WriteBatchResponse)(nil),                    // 14: rpcdb.WriteBatchResponse
-	(*NewIteratorRequest)(nil),                    // 15: rpcdb.NewIteratorRequest
-	(*NewIteratorWithStartAndPrefixRequest)(nil),  // 16: rpcdb.NewIteratorWithStartAndPrefixRequest
-	(*NewIteratorWithStartAndPrefixResponse)(nil), // 17: rpcdb.NewIteratorWithStartAndPrefixResponse
-	(*IteratorNextRequest)(nil),                   // 18: rpcdb.IteratorNextRequest
-	(*IteratorNextResponse)(nil),                  // 19: rpcdb.IteratorNextResponse
-	(*IteratorErrorRequest)(nil),                  // 20: rpcdb.IteratorErrorRequest
-	(*IteratorErrorResponse)(nil),                 // 21: rpcdb.IteratorErrorResponse
-	(*IteratorReleaseRequest)(nil),                // 22: rpcdb.IteratorReleaseRequest
-	(*IteratorReleaseResponse)(nil),               // 23: rpcdb.IteratorReleaseResponse
-	(*HealthCheckResponse)(nil),                   // 24: rpcdb.HealthCheckResponse
-	(*emptypb.Empty)(nil),                         // 25: google.protobuf.Empty
+	(*GetManyRequest)(nil),                        // 5: rpcdb.GetManyRequest
+	(*GetManyResponse)(nil),                       // 6: rpcdb.GetManyResponse
+	(*PutRequest)(nil),                            // 7: rpcdb.PutRequest
+	(*PutResponse)(nil),                           // 8: rpcdb.PutResponse
+	(*DeleteRequest)(nil),                         // 9: rpcdb.DeleteRequest
+	(*DeleteResponse)(nil),                        // 10: rpcdb.DeleteResponse
+	(*CompactRequest)(nil),                        // 11: rpcdb.CompactRequest
+	(*CompactResponse)(nil),                       // 12: rpcdb.CompactResponse
+	(*CloseRequest)(nil),                          // 13: rpcdb.CloseRequest
+	(*CloseResponse)(nil),                         // 14: rpcdb.CloseResponse
+	(*WriteBatchRequest)(nil),                     // 15: rpcdb.WriteBatchRequest
+	(*WriteBatchResponse)(nil),                    // 16: rpcdb.WriteBatchResponse
+	(*NewIteratorRequest)(nil),                    // 17: rpcdb.NewIteratorRequest
+	(*NewIteratorWithStartAndPrefixRequest)(nil),  // 18: rpcdb.NewIteratorWithStartAndPrefixRequest
+	(*NewIteratorWithStartAndPrefixResponse)(nil), // 19: rpcdb.NewIteratorWithStartAndPrefixResponse
+	(*IteratorNextRequest)(nil),                   // 20: rpcdb.IteratorNextRequest
+	(*IteratorNextResponse)(nil),                  // 21: rpcdb.IteratorNextResponse
+	(*IteratorStreamRequest)(nil),                 // 22: rpcdb.IteratorStreamRequest
+	(*IteratorErrorRequest)(nil),                  // 23: rpcdb.IteratorErrorRequest
+	(*IteratorErrorResponse)(nil),                 // 24: rpcdb.IteratorErrorResponse
+	(*IteratorReleaseRequest)(nil),                // 25: rpcdb.IteratorReleaseRequest
+	(*IteratorReleaseResponse)(nil),               // 26: rpcdb.IteratorReleaseResponse
+	(*HealthCheckResponse)(nil),                   // 27: rpcdb.HealthCheckResponse
+	(*emptypb.Empty)(nil),                         // 28: google.protobuf.Empty
 }
 var file_rpcdb_rpcdb_proto_depIdxs = []int32{
 	0,  // 0: rpcdb.HasResponse.err:type_name -> rpcdb.Error
 	0,  // 1: rpcdb.GetResponse.err:type_name -> rpcdb.Error
-	0,  // 2: rpcdb.PutResponse.err:type_name -> rpcdb.Error
-	0,  // 3: rpcdb.DeleteResponse.err:type_name -> rpcdb.Error
-	0,  // 4: rpcdb.CompactResponse.err:type_name -> rpcdb.Error
-	0,  // 5: rpcdb.CloseResponse.err:type_name -> rpcdb.Error
-	5,  // 6: rpcdb.WriteBatchRequest.puts:type_name -> rpcdb.PutRequest
-	7,  // 7: rpcdb.WriteBatchRequest.deletes:type_name -> rpcdb.DeleteRequest
-	0,  // 8: rpcdb.WriteBatchResponse.err:type_name -> rpcdb.Error
-	5,  // 9: rpcdb.IteratorNextResponse.data:type_name -> rpcdb.PutRequest
-	0,  // 10: rpcdb.IteratorErrorResponse.err:type_name -> rpcdb.Error
-	0,  // 11: rpcdb.IteratorReleaseResponse.err:type_name -> rpcdb.Error
-	1,  // 12: rpcdb.Database.Has:input_type -> rpcdb.HasRequest
-	3,  // 13: rpcdb.Database.Get:input_type -> rpcdb.GetRequest
-	5,  // 14: rpcdb.Database.Put:input_type -> rpcdb.PutRequest
-	7,  // 15: rpcdb.Database.Delete:input_type -> rpcdb.DeleteRequest
-	9,  // 16: rpcdb.Database.Compact:input_type -> rpcdb.CompactRequest
-	11, // 17: rpcdb.Database.Close:input_type -> rpcdb.CloseRequest
-	25, // 18: rpcdb.Database.HealthCheck:input_type -> google.protobuf.Empty
-	13, // 19: rpcdb.Database.WriteBatch:input_type -> rpcdb.WriteBatchRequest
-	16, // 20: rpcdb.Database.NewIteratorWithStartAndPrefix:input_type -> rpcdb.NewIteratorWithStartAndPrefixRequest
-	18, // 21: rpcdb.Database.IteratorNext:input_type -> rpcdb.IteratorNextRequest
-	20, // 22: rpcdb.Database.IteratorError:input_type -> rpcdb.IteratorErrorRequest
-	22, // 23: rpcdb.Database.IteratorRelease:input_type -> rpcdb.IteratorReleaseRequest
-	2,  // 24: rpcdb.Database.Has:output_type -> rpcdb.HasResponse
-	4,  // 25: rpcdb.Database.Get:output_type -> rpcdb.GetResponse
-	6,  // 26: rpcdb.Database.Put:output_type -> rpcdb.PutResponse
-	8,  // 27: rpcdb.Database.Delete:output_type -> rpcdb.DeleteResponse
-	10, // 28: rpcdb.Database.Compact:output_type -> rpcdb.CompactResponse
-	12, // 29: rpcdb.Database.Close:output_type -> rpcdb.CloseResponse
-	24, // 30: rpcdb.Database.HealthCheck:output_type -> rpcdb.HealthCheckResponse
-	14, // 31: rpcdb.Database.WriteBatch:output_type -> rpcdb.WriteBatchResponse
-	17, // 32: rpcdb.Database.NewIteratorWithStartAndPrefix:output_type -> rpcdb.NewIteratorWithStartAndPrefixResponse
-	19, // 33: rpcdb.Database.IteratorNext:output_type -> rpcdb.IteratorNextResponse
-	21, // 34: rpcdb.Database.IteratorError:output_type -> rpcdb.IteratorErrorResponse
-	23, // 35: rpcdb.Database.IteratorRelease:output_type -> rpcdb.IteratorReleaseResponse
-	24, // [24:36] is the sub-list for method output_type
-	12, // [12:24] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	4,  // 2: rpcdb.GetManyResponse.values:type_name -> rpcdb.GetResponse
+	0,  // 3: rpcdb.PutResponse.err:type_name -> rpcdb.Error
+	0,  // 4: rpcdb.DeleteResponse.err:type_name -> rpcdb.Error
+	0,  // 5: rpcdb.CompactResponse.err:type_name -> rpcdb.Error
+	0,  // 6: rpcdb.CloseResponse.err:type_name -> rpcdb.Error
+	7,  // 7: rpcdb.WriteBatchRequest.puts:type_name -> rpcdb.PutRequest
+	9,  // 8: rpcdb.WriteBatchRequest.deletes:type_name -> rpcdb.DeleteRequest
+	0,  // 9: rpcdb.WriteBatchResponse.err:type_name -> rpcdb.Error
+	7,  // 10: rpcdb.IteratorNextResponse.data:type_name -> rpcdb.PutRequest
+	0,  // 11: rpcdb.IteratorErrorResponse.err:type_name -> rpcdb.Error
+	0,  // 12: rpcdb.IteratorReleaseResponse.err:type_name -> rpcdb.Error
+	1,  // 13: rpcdb.Database.Has:input_type -> rpcdb.HasRequest
+	3,  // 14: rpcdb.Database.Get:input_type -> rpcdb.GetRequest
+	5,  // 15: rpcdb.Database.GetMany:input_type -> rpcdb.GetManyRequest
+	7,  // 16: rpcdb.Database.Put:input_type -> rpcdb.PutRequest
+	9,  // 17: rpcdb.Database.Delete:input_type -> rpcdb.DeleteRequest
+	11, // 18: rpcdb.Database.Compact:input_type -> rpcdb.CompactRequest
+	13, // 19: rpcdb.Database.Close:input_type -> rpcdb.CloseRequest
+	28, // 20: rpcdb.Database.HealthCheck:input_type -> google.protobuf.Empty
+	15, // 21: rpcdb.Database.WriteBatch:input_type -> rpcdb.WriteBatchRequest
+	18, // 22: rpcdb.Database.NewIteratorWithStartAndPrefix:input_type -> rpcdb.NewIteratorWithStartAndPrefixRequest
+	20, // 23: rpcdb.Database.IteratorNext:input_type -> rpcdb.IteratorNextRequest
+	22, // 24: rpcdb.Database.IteratorStream:input_type -> rpcdb.IteratorStreamRequest
+	23, // 25: rpcdb.Database.IteratorError:input_type -> rpcdb.IteratorErrorRequest
+	25, // 26: rpcdb.Database.IteratorRelease:input_type -> rpcdb.IteratorReleaseRequest
+	2,  // 27: rpcdb.Database.Has:output_type -> rpcdb.HasResponse
+	4,  // 28: rpcdb.Database.Get:output_type -> rpcdb.GetResponse
+	6,  // 29: rpcdb.Database.GetMany:output_type -> rpcdb.GetManyResponse
+	8,  // 30: rpcdb.Database.Put:output_type -> rpcdb.PutResponse
+	10, // 31: rpcdb.Database.Delete:output_type -> rpcdb.DeleteResponse
+	12, // 32: rpcdb.Database.Compact:output_type -> rpcdb.CompactResponse
+	14, // 33: rpcdb.Database.Close:output_type -> rpcdb.CloseResponse
+	27, // 34: rpcdb.Database.HealthCheck:output_type -> rpcdb.HealthCheckResponse
+	16, // 35: rpcdb.Database.WriteBatch:output_type -> rpcdb.WriteBatchResponse
+	19, // 36: rpcdb.Database.NewIteratorWithStartAndPrefix:output_type -> rpcdb.NewIteratorWithStartAndPrefixResponse
+	21, // 37: rpcdb.Database.IteratorNext:output_type -> rpcdb.IteratorNextResponse
+	21, // 38: rpcdb.Database.IteratorStream:output_type -> rpcdb.IteratorNextResponse
+	24, // 39: rpcdb.Database.IteratorError:output_type -> rpcdb.IteratorErrorResponse
+	26, // 40: rpcdb.Database.IteratorRelease:output_type -> rpcdb.IteratorReleaseResponse
+	27, // [27:41] is the sub-list for method output_type
+	13, // [13:27] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_rpcdb_rpcdb_proto_init() }
@@ -1515,7 +1682,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PutRequest); i {
+			switch v := v.(*GetManyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1527,7 +1694,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PutResponse); i {
+			switch v := v.(*GetManyResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1539,7 +1706,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteRequest); i {
+			switch v := v.(*PutRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1551,7 +1718,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteResponse); i {
+			switch v := v.(*PutResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1563,7 +1730,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompactRequest); i {
+			switch v := v.(*DeleteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1575,7 +1742,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompactResponse); i {
+			switch v := v.(*DeleteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1587,7 +1754,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CloseRequest); i {
+			switch v := v.(*CompactRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1599,7 +1766,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CloseResponse); i {
+			switch v := v.(*CompactResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1611,7 +1778,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteBatchRequest); i {
+			switch v := v.(*CloseRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1623,7 +1790,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteBatchResponse); i {
+			switch v := v.(*CloseResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1635,7 +1802,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewIteratorRequest); i {
+			switch v := v.(*WriteBatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1647,7 +1814,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewIteratorWithStartAndPrefixRequest); i {
+			switch v := v.(*WriteBatchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1659,7 +1826,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewIteratorWithStartAndPrefixResponse); i {
+			switch v := v.(*NewIteratorRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1671,7 +1838,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorNextRequest); i {
+			switch v := v.(*NewIteratorWithStartAndPrefixRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1683,7 +1850,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorNextResponse); i {
+			switch v := v.(*NewIteratorWithStartAndPrefixResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1695,7 +1862,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorErrorRequest); i {
+			switch v := v.(*IteratorNextRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1707,7 +1874,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorErrorResponse); i {
+			switch v := v.(*IteratorNextResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1719,7 +1886,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorReleaseRequest); i {
+			switch v := v.(*IteratorStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1731,7 +1898,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorReleaseResponse); i {
+			switch v := v.(*IteratorErrorRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1743,6 +1910,42 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorErrorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorReleaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorReleaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HealthCheckResponse); i {
 			case 0:
 				return &v.state
@@ -1761,7 +1964,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rpcdb_rpcdb_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   24,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
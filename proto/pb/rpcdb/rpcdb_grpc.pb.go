@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	Database_Has_FullMethodName                           = "/rpcdb.Database/Has"
 	Database_Get_FullMethodName                           = "/rpcdb.Database/Get"
+	Database_GetMany_FullMethodName                       = "/rpcdb.Database/GetMany"
 	Database_Put_FullMethodName                           = "/rpcdb.Database/Put"
 	Database_Delete_FullMethodName                        = "/rpcdb.Database/Delete"
 	Database_Compact_FullMethodName                       = "/rpcdb.Database/Compact"
@@ -30,6 +31,7 @@ const (
 	Database_WriteBatch_FullMethodName                    = "/rpcdb.Database/WriteBatch"
 	Database_NewIteratorWithStartAndPrefix_FullMethodName = "/rpcdb.Database/NewIteratorWithStartAndPrefix"
 	Database_IteratorNext_FullMethodName                  = "/rpcdb.Database/IteratorNext"
+	Database_IteratorStream_FullMethodName                = "/rpcdb.Database/IteratorStream"
 	Database_IteratorError_FullMethodName                 = "/rpcdb.Database/IteratorError"
 	Database_IteratorRelease_FullMethodName               = "/rpcdb.Database/IteratorRelease"
 )
@@ -40,6 +42,9 @@ const (
 type DatabaseClient interface {
 	Has(ctx context.Context, in *HasRequest, opts ...grpc.CallOption) (*HasResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// GetMany returns the values of all the requested keys in a single round
+	// trip.
+	GetMany(ctx context.Context, in *GetManyRequest, opts ...grpc.CallOption) (*GetManyResponse, error)
 	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 	Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error)
@@ -48,6 +53,9 @@ type DatabaseClient interface {
 	WriteBatch(ctx context.Context, in *WriteBatchRequest, opts ...grpc.CallOption) (*WriteBatchResponse, error)
 	NewIteratorWithStartAndPrefix(ctx context.Context, in *NewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
 	IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error)
+	// IteratorStream sends batches of the iterator's key-value pairs until the
+	// iterator is exhausted or the stream is cancelled.
+	IteratorStream(ctx context.Context, in *IteratorStreamRequest, opts ...grpc.CallOption) (Database_IteratorStreamClient, error)
 	IteratorError(ctx context.Context, in *IteratorErrorRequest, opts ...grpc.CallOption) (*IteratorErrorResponse, error)
 	IteratorRelease(ctx context.Context, in *IteratorReleaseRequest, opts ...grpc.CallOption) (*IteratorReleaseResponse, error)
 }
@@ -78,6 +86,15 @@ func (c *databaseClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *databaseClient) GetMany(ctx context.Context, in *GetManyRequest, opts ...grpc.CallOption) (*GetManyResponse, error) {
+	out := new(GetManyResponse)
+	err := c.cc.Invoke(ctx, Database_GetMany_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error) {
 	out := new(PutResponse)
 	err := c.cc.Invoke(ctx, Database_Put_FullMethodName, in, out, opts...)
@@ -150,6 +167,38 @@ func (c *databaseClient) IteratorNext(ctx context.Context, in *IteratorNextReque
 	return out, nil
 }
 
+func (c *databaseClient) IteratorStream(ctx context.Context, in *IteratorStreamRequest, opts ...grpc.CallOption) (Database_IteratorStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[0], Database_IteratorStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &databaseIteratorStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Database_IteratorStreamClient interface {
+	Recv() (*IteratorNextResponse, error)
+	grpc.ClientStream
+}
+
+type databaseIteratorStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *databaseIteratorStreamClient) Recv() (*IteratorNextResponse, error) {
+	m := new(IteratorNextResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *databaseClient) IteratorError(ctx context.Context, in *IteratorErrorRequest, opts ...grpc.CallOption) (*IteratorErrorResponse, error) {
 	out := new(IteratorErrorResponse)
 	err := c.cc.Invoke(ctx, Database_IteratorError_FullMethodName, in, out, opts...)
@@ -174,6 +223,9 @@ func (c *databaseClient) IteratorRelease(ctx context.Context, in *IteratorReleas
 type DatabaseServer interface {
 	Has(context.Context, *HasRequest) (*HasResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
+	// GetMany returns the values of all the requested keys in a single round
+	// trip.
+	GetMany(context.Context, *GetManyRequest) (*GetManyResponse, error)
 	Put(context.Context, *PutRequest) (*PutResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
 	Compact(context.Context, *CompactRequest) (*CompactResponse, error)
@@ -182,6 +234,9 @@ type DatabaseServer interface {
 	WriteBatch(context.Context, *WriteBatchRequest) (*WriteBatchResponse, error)
 	NewIteratorWithStartAndPrefix(context.Context, *NewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error)
 	IteratorNext(context.Context, *IteratorNextRequest) (*IteratorNextResponse, error)
+	// IteratorStream sends batches of the iterator's key-value pairs until the
+	// iterator is exhausted or the stream is cancelled.
+	IteratorStream(*IteratorStreamRequest, Database_IteratorStreamServer) error
 	IteratorError(context.Context, *IteratorErrorRequest) (*IteratorErrorResponse, error)
 	IteratorRelease(context.Context, *IteratorReleaseRequest) (*IteratorReleaseResponse, error)
 	mustEmbedUnimplementedDatabaseServer()
@@ -197,6 +252,9 @@ func (UnimplementedDatabaseServer) Has(context.Context, *HasRequest) (*HasRespon
 func (UnimplementedDatabaseServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedDatabaseServer) GetMany(context.Context, *GetManyRequest) (*GetManyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMany not implemented")
+}
 func (UnimplementedDatabaseServer) Put(context.Context, *PutRequest) (*PutResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Put not implemented")
 }
@@ -221,6 +279,9 @@ func (UnimplementedDatabaseServer) NewIteratorWithStartAndPrefix(context.Context
 func (UnimplementedDatabaseServer) IteratorNext(context.Context, *IteratorNextRequest) (*IteratorNextResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorNext not implemented")
 }
+func (UnimplementedDatabaseServer) IteratorStream(*IteratorStreamRequest, Database_IteratorStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method IteratorStream not implemented")
+}
 func (UnimplementedDatabaseServer) IteratorError(context.Context, *IteratorErrorRequest) (*IteratorErrorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorError not implemented")
 }
@@ -276,6 +337,24 @@ func _Database_Get_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_GetMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).GetMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_GetMany_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).GetMany(ctx, req.(*GetManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PutRequest)
 	if err := dec(in); err != nil {
@@ -420,6 +499,27 @@ func _Database_IteratorNext_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_IteratorStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(IteratorStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatabaseServer).IteratorStream(m, &databaseIteratorStreamServer{stream})
+}
+
+type Database_IteratorStreamServer interface {
+	Send(*IteratorNextResponse) error
+	grpc.ServerStream
+}
+
+type databaseIteratorStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *databaseIteratorStreamServer) Send(m *IteratorNextResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Database_IteratorError_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IteratorErrorRequest)
 	if err := dec(in); err != nil {
@@ -471,6 +571,10 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _Database_Get_Handler,
 		},
+		{
+			MethodName: "GetMany",
+			Handler:    _Database_GetMany_Handler,
+		},
 		{
 			MethodName: "Put",
 			Handler:    _Database_Put_Handler,
@@ -512,6 +616,12 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Database_IteratorRelease_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "IteratorStream",
+			Handler:       _Database_IteratorStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "rpcdb/rpcdb.proto",
 }
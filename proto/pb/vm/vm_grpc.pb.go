@@ -54,6 +54,8 @@ const (
 	VM_BlockAccept_FullMethodName                = "/vm.VM/BlockAccept"
 	VM_BlockReject_FullMethodName                = "/vm.VM/BlockReject"
 	VM_StateSummaryAccept_FullMethodName         = "/vm.VM/StateSummaryAccept"
+	VM_BlockPreVerify_FullMethodName             = "/vm.VM/BlockPreVerify"
+	VM_LastDurableHeight_FullMethodName          = "/vm.VM/LastDurableHeight"
 )
 
 // VMClient is the client API for VM service.
@@ -131,6 +133,10 @@ type VMClient interface {
 	BlockReject(ctx context.Context, in *BlockRejectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// StateSummary
 	StateSummaryAccept(ctx context.Context, in *StateSummaryAcceptRequest, opts ...grpc.CallOption) (*StateSummaryAcceptResponse, error)
+	// PreVerifier
+	BlockPreVerify(ctx context.Context, in *BlockPreVerifyRequest, opts ...grpc.CallOption) (*BlockPreVerifyResponse, error)
+	// Durable
+	LastDurableHeight(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LastDurableHeightResponse, error)
 }
 
 type vMClient struct {
@@ -447,6 +453,24 @@ func (c *vMClient) StateSummaryAccept(ctx context.Context, in *StateSummaryAccep
 	return out, nil
 }
 
+func (c *vMClient) BlockPreVerify(ctx context.Context, in *BlockPreVerifyRequest, opts ...grpc.CallOption) (*BlockPreVerifyResponse, error) {
+	out := new(BlockPreVerifyResponse)
+	err := c.cc.Invoke(ctx, VM_BlockPreVerify_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) LastDurableHeight(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LastDurableHeightResponse, error) {
+	out := new(LastDurableHeightResponse)
+	err := c.cc.Invoke(ctx, VM_LastDurableHeight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VMServer is the server API for VM service.
 // All implementations must embed UnimplementedVMServer
 // for forward compatibility
@@ -522,6 +546,10 @@ type VMServer interface {
 	BlockReject(context.Context, *BlockRejectRequest) (*emptypb.Empty, error)
 	// StateSummary
 	StateSummaryAccept(context.Context, *StateSummaryAcceptRequest) (*StateSummaryAcceptResponse, error)
+	// PreVerifier
+	BlockPreVerify(context.Context, *BlockPreVerifyRequest) (*BlockPreVerifyResponse, error)
+	// Durable
+	LastDurableHeight(context.Context, *emptypb.Empty) (*LastDurableHeightResponse, error)
 	mustEmbedUnimplementedVMServer()
 }
 
@@ -631,6 +659,12 @@ func (UnimplementedVMServer) BlockReject(context.Context, *BlockRejectRequest) (
 func (UnimplementedVMServer) StateSummaryAccept(context.Context, *StateSummaryAcceptRequest) (*StateSummaryAcceptResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StateSummaryAccept not implemented")
 }
+func (UnimplementedVMServer) BlockPreVerify(context.Context, *BlockPreVerifyRequest) (*BlockPreVerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockPreVerify not implemented")
+}
+func (UnimplementedVMServer) LastDurableHeight(context.Context, *emptypb.Empty) (*LastDurableHeightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LastDurableHeight not implemented")
+}
 func (UnimplementedVMServer) mustEmbedUnimplementedVMServer() {}
 
 // UnsafeVMServer may be embedded to opt out of forward compatibility for this service.
@@ -1256,6 +1290,42 @@ func _VM_StateSummaryAccept_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VM_BlockPreVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockPreVerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).BlockPreVerify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VM_BlockPreVerify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).BlockPreVerify(ctx, req.(*BlockPreVerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_LastDurableHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).LastDurableHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VM_LastDurableHeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).LastDurableHeight(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VM_ServiceDesc is the grpc.ServiceDesc for VM service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1399,6 +1469,14 @@ var VM_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StateSummaryAccept",
 			Handler:    _VM_StateSummaryAccept_Handler,
 		},
+		{
+			MethodName: "BlockPreVerify",
+			Handler:    _VM_BlockPreVerify_Handler,
+		},
+		{
+			MethodName: "LastDurableHeight",
+			Handler:    _VM_LastDurableHeight_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vm/vm.proto",
@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snow
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/puzzle"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// AppRequestPolicy restricts which nodes may send AppRequests to a chain, to
+// protect expensive AppRequest handlers. The zero value allows all
+// AppRequests.
+type AppRequestPolicy struct {
+	// MinValidatorWeight is the weight a node must have in the validator set
+	// of the chain for its AppRequests to be handled unconditionally.
+	MinValidatorWeight uint64 `json:"minValidatorWeight"`
+	// PuzzleDifficulty is the number of leading zero bits of the puzzle
+	// solution that the AppRequests of nodes below [MinValidatorWeight] must
+	// include to be handled. If 0, their AppRequests are dropped.
+	PuzzleDifficulty uint8 `json:"puzzleDifficulty"`
+}
+
+func (p AppRequestPolicy) Verify() error {
+	if p.PuzzleDifficulty > puzzle.MaxDifficulty {
+		return fmt.Errorf("%w: %d > %d", puzzle.ErrInvalidDifficulty, p.PuzzleDifficulty, puzzle.MaxDifficulty)
+	}
+	return nil
+}
+
+// RequiresPuzzle returns true if a node with [weight] must include a puzzle
+// solution in its AppRequests for them to be handled.
+func (p AppRequestPolicy) RequiresPuzzle(weight uint64) bool {
+	return weight < p.MinValidatorWeight && p.PuzzleDifficulty > 0
+}
+
+// AppRequestEpochDuration is the duration of the epochs that puzzle solutions
+// are bound to. To tolerate clock differences between nodes and the time it
+// takes to deliver a request, a solution is accepted from the epoch before the
+// one it was solved for until the epoch after it.
+const AppRequestEpochDuration = 30 * time.Second
+
+// AppRequestEpoch returns the epoch of [t] that puzzle solutions are bound to.
+func AppRequestEpoch(t time.Time) uint64 {
+	return uint64(t.Unix()) / uint64(AppRequestEpochDuration/time.Second)
+}
+
+// AppRequestFilter checks AppRequests against an [AppRequestPolicy] and
+// rejects puzzle solutions that were already used. The zero value is ready to
+// use.
+type AppRequestFilter struct {
+	lock sync.Mutex
+	// epoch -> challenges solved in the epoch
+	solved map[uint64]set.Set[ids.ID]
+}
+
+// Allows returns true if an AppRequest from [sender], which has [weight], to
+// [receiver] should be handled at [now].
+func (f *AppRequestFilter) Allows(
+	policy AppRequestPolicy,
+	chainID ids.ID,
+	sender ids.NodeID,
+	receiver ids.NodeID,
+	weight uint64,
+	requestID uint32,
+	appBytes []byte,
+	puzzleNonce uint64,
+	now time.Time,
+) bool {
+	switch {
+	case weight >= policy.MinValidatorWeight:
+		return true
+	case policy.PuzzleDifficulty == 0:
+		return false
+	}
+
+	epoch := AppRequestEpoch(now)
+	for _, solvedEpoch := range []uint64{epoch, epoch - 1, epoch + 1} {
+		challenge := AppRequestChallenge(chainID, sender, receiver, solvedEpoch, requestID, appBytes)
+		if puzzle.Verify(challenge, policy.PuzzleDifficulty, puzzleNonce) {
+			return f.markSolved(epoch, solvedEpoch, hashing.ComputeHash256Array(challenge))
+		}
+	}
+	return false
+}
+
+// markSolved records that [challengeID] was solved for [solvedEpoch] and
+// returns false if it already was. Solutions for epochs before the previous
+// epoch of [epoch] are no longer accepted, so they are forgotten.
+func (f *AppRequestFilter) markSolved(epoch uint64, solvedEpoch uint64, challengeID ids.ID) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.solved == nil {
+		f.solved = make(map[uint64]set.Set[ids.ID])
+	}
+	for e := range f.solved {
+		if e+1 < epoch {
+			delete(f.solved, e)
+		}
+	}
+
+	solved := f.solved[solvedEpoch]
+	if solved.Contains(challengeID) {
+		return false
+	}
+	solved.Add(challengeID)
+	f.solved[solvedEpoch] = solved
+	return true
+}
+
+// AppRequestChallenge returns the puzzle challenge of an AppRequest from
+// [sender] to [receiver] during [epoch]. The challenge commits to the request
+// so that a solution can't be reused for other requests, by other nodes or
+// with other receivers. Binding it to the epoch limits how long a solution
+// must be remembered to reject replays.
+func AppRequestChallenge(
+	chainID ids.ID,
+	sender ids.NodeID,
+	receiver ids.NodeID,
+	epoch uint64,
+	requestID uint32,
+	appBytes []byte,
+) []byte {
+	appBytesHash := hashing.ComputeHash256Array(appBytes)
+	challenge := make([]byte, 0, ids.IDLen+2*ids.NodeIDLen+wrappers.LongLen+wrappers.IntLen+hashing.HashLen)
+	challenge = append(challenge, chainID[:]...)
+	challenge = append(challenge, sender[:]...)
+	challenge = append(challenge, receiver[:]...)
+	challenge = binary.BigEndian.AppendUint64(challenge, epoch)
+	challenge = binary.BigEndian.AppendUint32(challenge, requestID)
+	return append(challenge, appBytesHash[:]...)
+}
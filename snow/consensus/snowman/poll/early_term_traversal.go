@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
+)
+
+// Ancestry reports which processing blocks a vote supports. Because votes are
+// applied transitively, a vote for a block supports each of its processing
+// ancestors.
+type Ancestry interface {
+	// Root returns the oldest processing block that a vote for [blkID]
+	// supports. If a vote for [blkID] doesn't support any processing block,
+	// for example because [blkID] was already decided, ids.Empty is returned.
+	// If it isn't known which processing blocks a vote for [blkID] supports,
+	// for example because [blkID] hasn't been fetched, false is returned.
+	Root(blkID ids.ID) (ids.ID, bool)
+}
+
+type earlyTermTraversalFactory struct {
+	alpha    int
+	ancestry Ancestry
+}
+
+// NewEarlyTermTraversalFactory returns a factory that returns polls with
+// early termination, which also terminate once no processing block can
+// receive an alpha majority of the votes.
+func NewEarlyTermTraversalFactory(alpha int, ancestry Ancestry) Factory {
+	return &earlyTermTraversalFactory{
+		alpha:    alpha,
+		ancestry: ancestry,
+	}
+}
+
+func (f *earlyTermTraversalFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
+	return &earlyTermTraversalPoll{
+		earlyTermNoTraversalPoll: earlyTermNoTraversalPoll{
+			polled: vdrs,
+			alpha:  f.alpha,
+		},
+		ancestry: f.ancestry,
+	}
+}
+
+// earlyTermTraversalPoll finishes when any remaining validators can't change
+// the result of the poll. In addition to the conditions of
+// earlyTermNoTraversalPoll, it terminates once none of the processing blocks
+// can receive an alpha majority of the votes after applying transitive voting.
+//
+// The oldest processing ancestor of a block is supported by every vote that
+// supports the block, so only the oldest processing ancestors need to be
+// considered. The ancestors are determined when a vote is received. If a
+// block is decided afterwards, the votes for its descendants are still
+// attributed to it, which can only delay termination.
+type earlyTermTraversalPoll struct {
+	earlyTermNoTraversalPoll
+	ancestry Ancestry
+
+	// oldest processing ancestor --> number of votes supporting it
+	rootVotes bag.Bag[ids.ID]
+	// number of votes that may support any processing block
+	unknownVotes int
+}
+
+// Vote registers a response for this poll
+func (p *earlyTermTraversalPoll) Vote(vdr ids.NodeID, vote ids.ID) {
+	count := p.polled.Count(vdr)
+	p.earlyTermNoTraversalPoll.Vote(vdr, vote)
+	if count == 0 {
+		return
+	}
+
+	root, ok := p.ancestry.Root(vote)
+	switch {
+	case !ok:
+		p.unknownVotes += count
+	case root != ids.Empty:
+		p.rootVotes.AddCount(root, count)
+	}
+}
+
+// Finished returns true when one of the following conditions is met.
+//
+//  1. The poll is finished according to earlyTermNoTraversalPoll.
+//  2. It is impossible for any processing block to achieve an alpha majority
+//     after applying transitive voting.
+func (p *earlyTermTraversalPoll) Finished() bool {
+	if p.earlyTermNoTraversalPoll.Finished() {
+		return true // Case 1
+	}
+
+	_, maxRootVotes := p.rootVotes.Mode()
+	maxPossibleVotes := maxRootVotes + p.unknownVotes + p.polled.Len()
+	return maxPossibleVotes < p.alpha // Case 2
+}
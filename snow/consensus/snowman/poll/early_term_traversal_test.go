@@ -0,0 +1,144 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
+)
+
+var _ Ancestry = testAncestry(nil)
+
+// testAncestry maps a block to the oldest processing block that a vote for it
+// supports. Blocks that aren't in the map are unknown.
+type testAncestry map[ids.ID]ids.ID
+
+func (a testAncestry) Root(blkID ids.ID) (ids.ID, bool) {
+	root, ok := a[blkID]
+	return root, ok
+}
+
+func TestEarlyTermTraversalResults(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1) // k = 1
+	alpha := 1
+
+	factory := NewEarlyTermTraversalFactory(alpha, testAncestry{blkID1: blkID1})
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	list := result.List()
+	require.Len(list, 1)
+	require.Equal(blkID1, list[0])
+	require.Equal(1, result.Count(blkID1))
+}
+
+func TestEarlyTermTraversalTerminatesEarlyWithoutAlpha(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5) // k = 5
+	alpha := 4
+
+	// blkID1 and blkID2 are conflicting processing blocks, blkID3 is a
+	// processing child of blkID1 and blkID4 was already decided.
+	ancestry := testAncestry{
+		blkID1: blkID1,
+		blkID2: blkID2,
+		blkID3: blkID1,
+		blkID4: ids.Empty,
+	}
+	factory := NewEarlyTermTraversalFactory(alpha, ancestry)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID2)
+	require.False(poll.Finished())
+
+	// blkID1 could still receive 4 votes.
+	poll.Vote(vdr2, blkID3)
+	require.False(poll.Finished())
+
+	// At most 3 votes can support either blkID1 or blkID2.
+	poll.Vote(vdr3, blkID4)
+	require.True(poll.Finished())
+}
+
+func TestEarlyTermTraversalDoesNotTerminateWithUnknownVotes(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4) // k = 4
+	alpha := 3
+
+	factory := NewEarlyTermTraversalFactory(alpha, testAncestry{
+		blkID1: blkID1,
+		blkID2: blkID2,
+	})
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	// blkID3 may be a descendant of blkID1.
+	poll.Vote(vdr2, blkID3)
+	require.False(poll.Finished())
+
+	// blkID1 could still receive 3 votes if blkID3 is its descendant.
+	poll.Vote(vdr3, blkID2)
+	require.False(poll.Finished())
+}
+
+func TestEarlyTermTraversalWithWeightedResponses(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr2, vdr2) // k = 4
+	alpha := 3
+
+	factory := NewEarlyTermTraversalFactory(alpha, testAncestry{
+		blkID1: blkID1,
+		blkID2: blkID2,
+	})
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr2, blkID1)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal(3, result.Count(blkID1))
+}
+
+func TestEarlyTermTraversalDropsDuplicatedVotes(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3) // k = 3
+	alpha := 2
+
+	factory := NewEarlyTermTraversalFactory(alpha, testAncestry{
+		blkID1: blkID1,
+		blkID2: blkID2,
+	})
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	// A duplicated vote must not be attributed to blkID2 again.
+	poll.Vote(vdr1, blkID2)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr2, blkID2)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr3, blkID2)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal(2, result.Count(blkID2))
+}
@@ -19,14 +19,21 @@ import (
 	"github.com/ava-labs/avalanchego/utils/metric"
 )
 
+// maxTerminatedPolls bounds the number of polls that finished early whose
+// outstanding responses are still tracked to measure the saved wait time.
+const maxTerminatedPolls = 1024
+
 type pollHolder interface {
 	GetPoll() Poll
 	StartTime() time.Time
+	// Outstanding returns the validators that haven't responded yet
+	Outstanding() *bag.Bag[ids.NodeID]
 }
 
 type poll struct {
 	Poll
-	start time.Time
+	start       time.Time
+	outstanding *bag.Bag[ids.NodeID]
 }
 
 func (p poll) GetPoll() Poll {
@@ -37,13 +44,31 @@ func (p poll) StartTime() time.Time {
 	return p.start
 }
 
+func (p poll) Outstanding() *bag.Bag[ids.NodeID] {
+	return p.outstanding
+}
+
+// terminatedPoll is a poll that finished before every polled validator
+// responded.
+type terminatedPoll struct {
+	finished    time.Time
+	outstanding *bag.Bag[ids.NodeID]
+}
+
 type set struct {
 	log      logging.Logger
 	numPolls prometheus.Gauge
 	durPolls metric.Averager
-	factory  Factory
+	// number of polls that finished before every polled validator responded
+	numEarlyTerminations prometheus.Counter
+	// time between a poll finishing early and its last outstanding response
+	// arriving or timing out
+	savedWait metric.Averager
+	factory   Factory
 	// maps requestID -> poll
 	polls linkedhashmap.LinkedHashmap[uint32, pollHolder]
+	// maps requestID -> poll that finished early
+	terminated map[uint32]*terminatedPoll
 }
 
 // NewSet returns a new empty set of polls
@@ -76,12 +101,38 @@ func NewSet(
 		)
 	}
 
+	numEarlyTerminations := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "polls_terminated_early",
+		Help:      "Number of network polls that finished before every polled validator responded",
+	})
+	if err := reg.Register(numEarlyTerminations); err != nil {
+		log.Error("failed to register polls_terminated_early statistics",
+			zap.Error(err),
+		)
+	}
+
+	savedWait, err := metric.NewAverager(
+		namespace,
+		"poll_saved_wait",
+		"time (in ns) between a poll finishing early and its last outstanding response",
+		reg,
+	)
+	if err != nil {
+		log.Error("failed to register poll_saved_wait statistics",
+			zap.Error(err),
+		)
+	}
+
 	return &set{
-		log:      log,
-		numPolls: numPolls,
-		durPolls: durPolls,
-		factory:  factory,
-		polls:    linkedhashmap.New[uint32, pollHolder](),
+		log:                  log,
+		numPolls:             numPolls,
+		durPolls:             durPolls,
+		numEarlyTerminations: numEarlyTerminations,
+		savedWait:            savedWait,
+		factory:              factory,
+		polls:                linkedhashmap.New[uint32, pollHolder](),
+		terminated:           make(map[uint32]*terminatedPoll),
 	}
 }
 
@@ -102,9 +153,11 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 		zap.Stringer("validators", &vdrs),
 	)
 
+	outstanding := bag.Of(vdrs.List()...)
 	s.polls.Put(requestID, poll{
-		Poll:  s.factory.New(vdrs), // create the new poll
-		start: time.Now(),
+		Poll:        s.factory.New(vdrs), // create the new poll
+		start:       time.Now(),
+		outstanding: &outstanding,
 	})
 	s.numPolls.Inc() // increase the metrics
 	return true
@@ -120,9 +173,11 @@ func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.
 			zap.Stringer("validator", vdr),
 			zap.Uint32("requestID", requestID),
 		)
+		s.lateResponse(requestID, vdr)
 		return nil
 	}
 
+	holder.Outstanding().Remove(vdr)
 	p := holder.GetPoll()
 
 	s.log.Verbo("processing vote",
@@ -158,9 +213,20 @@ func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
 			zap.Uint32("requestID", iter.Key()),
 			zap.Stringer("poll", holder.GetPoll()),
 		)
-		s.durPolls.Observe(float64(time.Since(holder.StartTime())))
+		now := time.Now()
+		s.durPolls.Observe(float64(now.Sub(holder.StartTime())))
 		s.numPolls.Dec() // decrease the metrics
 
+		if outstanding := holder.Outstanding(); outstanding.Len() > 0 {
+			s.numEarlyTerminations.Inc()
+			if len(s.terminated) < maxTerminatedPolls {
+				s.terminated[iter.Key()] = &terminatedPoll{
+					finished:    now,
+					outstanding: outstanding,
+				}
+			}
+		}
+
 		results = append(results, p.Result())
 		s.polls.Delete(iter.Key())
 	}
@@ -180,9 +246,12 @@ func (s *set) Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID] {
 			zap.Stringer("validator", vdr),
 			zap.Uint32("requestID", requestID),
 		)
+		s.lateResponse(requestID, vdr)
 		return nil
 	}
 
+	holder.Outstanding().Remove(vdr)
+
 	s.log.Verbo("processing dropped vote",
 		zap.Stringer("validator", vdr),
 		zap.Uint32("requestID", requestID),
@@ -198,6 +267,24 @@ func (s *set) Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID] {
 	return s.processFinishedPolls()
 }
 
+// lateResponse registers a response from [vdr] to a poll that already
+// finished. Once every response to a poll that finished early has arrived or
+// timed out, the time the poll saved by finishing early is recorded.
+func (s *set) lateResponse(requestID uint32, vdr ids.NodeID) {
+	terminated, exists := s.terminated[requestID]
+	if !exists {
+		return
+	}
+
+	terminated.outstanding.Remove(vdr)
+	if terminated.outstanding.Len() > 0 {
+		return
+	}
+
+	s.savedWait.Observe(float64(time.Since(terminated.finished)))
+	delete(s.terminated, requestID)
+}
+
 // Len returns the number of outstanding polls
 func (s *set) Len() int {
 	return s.polls.Len()
@@ -237,3 +237,36 @@ func TestSetString(t *testing.T) {
 	require.True(s.Add(0, vdrs))
 	require.Equal(expected, s.String())
 }
+
+func TestSetTracksEarlyTerminations(t *testing.T) {
+	require := require.New(t)
+
+	factory := NewEarlyTermNoTraversalFactory(2)
+	log := logging.NoLog{}
+	namespace := ""
+	registerer := prometheus.NewRegistry()
+	s := NewSet(factory, log, namespace, registerer)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3) // k = 3
+
+	require.True(s.Add(0, vdrs))
+	require.Empty(s.Vote(0, vdr1, blkID1))
+
+	results := s.Vote(0, vdr2, blkID1)
+	require.Len(results, 1)
+	require.Equal(2, results[0].Count(blkID1))
+	require.Contains(s.(*set).terminated, uint32(0))
+
+	// The late response completes the poll that finished early.
+	require.Empty(s.Drop(0, vdr3))
+	require.NotContains(s.(*set).terminated, uint32(0))
+
+	metrics, err := registerer.Gather()
+	require.NoError(err)
+	values := make(map[string]float64)
+	for _, family := range metrics {
+		values[family.GetName()] = family.GetMetric()[0].GetCounter().GetValue()
+	}
+	require.Equal(float64(1), values["polls_terminated_early"])
+	require.Equal(float64(1), values["poll_saved_wait_count"])
+}
@@ -10,8 +10,10 @@ import (
 
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/cache/budget"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/finality"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
@@ -54,6 +56,26 @@ type Context struct {
 	ValidatorState validators.State // interface for P-Chain validators
 	// Chain-specific directory where arbitrary data can be written
 	ChainDataDir string
+
+	// CacheBudget is the memory budget that the caches of the chain are
+	// allocated from. If nil, the caches are sized independently.
+	CacheBudget *budget.Manager
+
+	// Limits are the limits the Subnet of this chain places on the blocks and
+	// transactions of the chain.
+	Limits Limits
+}
+
+// Limits restrict the size of the blocks and transactions of a chain. A zero
+// value means that the limit isn't configured, in which case the network-wide
+// maximum, or the default of the VM, applies.
+type Limits struct {
+	// MaxBlockSize is the maximum number of bytes of a block.
+	MaxBlockSize int `json:"maxBlockSize" yaml:"maxBlockSize"`
+	// MaxBlockTxs is the maximum number of transactions in a block.
+	MaxBlockTxs int `json:"maxBlockTxs" yaml:"maxBlockTxs"`
+	// MaxTxSize is the maximum number of bytes of a transaction.
+	MaxTxSize int `json:"maxTxSize" yaml:"maxTxSize"`
 }
 
 // Expose gatherer interface for unit testing.
@@ -95,6 +117,15 @@ type ConsensusContext struct {
 
 	// True iff this chain is currently state-syncing
 	StateSyncing utils.Atomic[bool]
+
+	// AppRequestPolicy restricts which nodes may send AppRequests to this
+	// chain. It is set once the VM is initialized.
+	AppRequestPolicy utils.Atomic[AppRequestPolicy]
+
+	// Finality signs the last accepted block reported in this node's chits
+	// and aggregates the signatures in the chits of peers into finality
+	// certificates. Nil if finality certificates aren't enabled.
+	Finality finality.Certifier
 }
 
 func DefaultContextTest() *Context {
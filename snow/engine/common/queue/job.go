@@ -19,3 +19,17 @@ type Job interface {
 	Execute(context.Context) error
 	Bytes() []byte
 }
+
+// PreparableJob is a Job that is able to perform part of its execution ahead
+// of time.
+type PreparableJob interface {
+	Job
+
+	// Prepare may be called concurrently with the execution of other jobs,
+	// including the jobs that this job depends on. Therefore, Prepare must not
+	// depend on the results of executing other jobs.
+	//
+	// If Prepare is called, it is guaranteed to return before Execute is
+	// called on the same job.
+	Prepare(context.Context) error
+}
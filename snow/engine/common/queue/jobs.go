@@ -32,6 +32,13 @@ type Jobs struct {
 	state *state
 	// Measures the ETA until bootstrapping finishes in nanoseconds.
 	etaMetric prometheus.Gauge
+
+	// numPrepareWorkers is the number of goroutines that prepare jobs ahead
+	// of their execution. If 0, jobs aren't prepared ahead of time.
+	numPrepareWorkers int
+	// maxPreparedJobs is the maximum number of jobs that are prepared ahead
+	// of their execution at any time.
+	maxPreparedJobs int
 }
 
 // New attempts to create a new job queue from the provided database.
@@ -65,6 +72,14 @@ func (j *Jobs) SetParser(parser Parser) error {
 	return nil
 }
 
+// EnablePreparation causes jobs that implement PreparableJob to be prepared by
+// [numWorkers] goroutines while the jobs they depend on are executed. At most
+// [maxPrepared] jobs are prepared ahead of their execution at any time.
+func (j *Jobs) EnablePreparation(numWorkers, maxPrepared int) {
+	j.numPrepareWorkers = numWorkers
+	j.maxPreparedJobs = maxPrepared
+}
+
 func (j *Jobs) Has(jobID ids.ID) (bool, error) {
 	return j.state.HasJob(jobID)
 }
@@ -133,6 +148,12 @@ func (j *Jobs) ExecuteAll(
 	// TODO remove DisableCaching when VM provides better interface for freeing
 	// blocks.
 	j.state.DisableCaching()
+
+	var pipeline *pipeline
+	if j.numPrepareWorkers > 0 && j.maxPreparedJobs > 0 {
+		pipeline = newPipeline(ctx, j.state, j.numPrepareWorkers, j.maxPreparedJobs)
+		defer pipeline.stop()
+	}
 	for {
 		if halter.Halted() {
 			chainCtx.Log.Info("interrupted execution",
@@ -149,6 +170,13 @@ func (j *Jobs) ExecuteAll(
 			return 0, fmt.Errorf("failed to removing runnable job with %w", err)
 		}
 
+		if pipeline != nil {
+			job, err = pipeline.prepare(job)
+			if err != nil {
+				return 0, err
+			}
+		}
+
 		jobID := job.ID()
 		chainCtx.Log.Debug("executing",
 			zap.Stringer("jobID", jobID),
@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/buffer"
+)
+
+// preparation is a job that has been handed to the pipeline's workers.
+type preparation struct {
+	job  PreparableJob
+	done chan struct{}
+	err  error
+}
+
+// pipeline prepares the jobs that will be executed after the job currently
+// being executed. Jobs are prepared concurrently by a fixed number of
+// workers, while their execution remains serial and in dependency order.
+type pipeline struct {
+	ctx   context.Context
+	state *state
+
+	// maxPending is the maximum number of jobs that are being, or have been,
+	// prepared but haven't been executed yet.
+	maxPending int
+	// pending maps the IDs of jobs that have been handed to the workers to
+	// their preparation.
+	pending map[ids.ID]*preparation
+	// unexplored contains the pending jobs whose dependents haven't been
+	// looked up yet, in the order they were discovered.
+	unexplored buffer.Deque[ids.ID]
+
+	work chan *preparation
+	wg   sync.WaitGroup
+}
+
+func newPipeline(ctx context.Context, state *state, numWorkers, maxPending int) *pipeline {
+	p := &pipeline{
+		ctx:        ctx,
+		state:      state,
+		maxPending: maxPending,
+		pending:    make(map[ids.ID]*preparation, maxPending),
+		unexplored: buffer.NewUnboundedDeque[ids.ID](maxPending),
+		work:       make(chan *preparation, maxPending),
+	}
+	p.wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go p.runWorker()
+	}
+	return p
+}
+
+func (p *pipeline) runWorker() {
+	defer p.wg.Done()
+
+	for prep := range p.work {
+		prep.err = prep.job.Prepare(p.ctx)
+		close(prep.done)
+	}
+}
+
+// prepare returns [job] once it has been prepared. If [job] was prepared
+// ahead of time, the prepared instance of the job is returned.
+//
+// Before waiting for [job], the jobs that depend on it are handed to the
+// workers so that they are prepared while [job] is executed.
+func (p *pipeline) prepare(job Job) (Job, error) {
+	jobID := job.ID()
+	prep, ok := p.pending[jobID]
+	if ok {
+		delete(p.pending, jobID)
+	} else if preparableJob, ok := job.(PreparableJob); ok {
+		// [job] wasn't discovered by looking ahead, so its dependents haven't
+		// been looked up either.
+		prep = p.start(preparableJob)
+		delete(p.pending, jobID)
+		p.unexplored.PushRight(jobID)
+	}
+
+	if err := p.lookahead(); err != nil {
+		return nil, err
+	}
+
+	if prep == nil {
+		return job, nil
+	}
+	<-prep.done
+	if prep.err != nil {
+		return nil, fmt.Errorf("failed to prepare job %s due to %w", jobID, prep.err)
+	}
+	return prep.job, nil
+}
+
+// lookahead hands the dependents of previously prepared jobs to the workers
+// until [maxPending] jobs are pending.
+func (p *pipeline) lookahead() error {
+	for len(p.pending) < p.maxPending {
+		jobID, ok := p.unexplored.PopLeft()
+		if !ok {
+			return nil
+		}
+
+		dependentIDs, err := p.state.GetDependents(jobID)
+		if err != nil {
+			return fmt.Errorf("failed to get dependents of %s due to %w", jobID, err)
+		}
+		for _, dependentID := range dependentIDs {
+			if _, ok := p.pending[dependentID]; ok {
+				continue
+			}
+
+			job, err := p.state.GetJob(p.ctx, dependentID)
+			if err != nil {
+				return fmt.Errorf("failed to get job %s due to %w", dependentID, err)
+			}
+			preparableJob, ok := job.(PreparableJob)
+			if !ok {
+				continue
+			}
+			p.start(preparableJob)
+			p.unexplored.PushRight(dependentID)
+		}
+	}
+	return nil
+}
+
+func (p *pipeline) start(job PreparableJob) *preparation {
+	prep := &preparation{
+		job:  job,
+		done: make(chan struct{}),
+	}
+	p.pending[job.ID()] = prep
+	p.work <- prep
+	return prep
+}
+
+// stop waits for the workers to finish any outstanding preparations.
+func (p *pipeline) stop() {
+	close(p.work)
+	p.wg.Wait()
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+var errTestPrepare = errors.New("non-nil prepare error")
+
+type testPreparableJob struct {
+	*TestJob
+	PrepareF func(context.Context) error
+}
+
+func (j *testPreparableJob) Prepare(ctx context.Context) error {
+	return j.PrepareF(ctx)
+}
+
+// newPreparableChain returns a queue containing a chain of [numJobs] jobs,
+// where each job depends on the previous one.
+func newPreparableChain(
+	t *testing.T,
+	numJobs int,
+	prepare func(i int) error,
+	execute func(i int) error,
+) *Jobs {
+	require := require.New(t)
+
+	jobs, err := New(memdb.New(), "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	var (
+		jobIDs   = make([]ids.ID, numJobs)
+		executed = make([]bool, numJobs)
+		indices  = make(map[ids.ID]int, numJobs)
+	)
+	for i := range jobIDs {
+		jobIDs[i] = ids.GenerateTestID()
+		indices[jobIDs[i]] = i
+	}
+	newJob := func(i int) Job {
+		parentID := ids.Empty
+		parentExecuted := (*bool)(nil)
+		if i > 0 {
+			parentID = jobIDs[i-1]
+			parentExecuted = &executed[i-1]
+		}
+		job := testJob(t, jobIDs[i], nil, parentID, parentExecuted)
+		job.BytesF = func() []byte {
+			return jobIDs[i][:]
+		}
+		job.ExecuteF = func(context.Context) error {
+			executed[i] = true
+			return execute(i)
+		}
+		return &testPreparableJob{
+			TestJob: job,
+			PrepareF: func(context.Context) error {
+				return prepare(i)
+			},
+		}
+	}
+
+	require.NoError(jobs.SetParser(&TestParser{
+		T: t,
+		ParseF: func(_ context.Context, b []byte) (Job, error) {
+			jobID, err := ids.ToID(b)
+			require.NoError(err)
+			return newJob(indices[jobID]), nil
+		},
+	}))
+	for i := numJobs - 1; i >= 0; i-- {
+		pushed, err := jobs.Push(context.Background(), newJob(i))
+		require.NoError(err)
+		require.True(pushed)
+	}
+	return jobs
+}
+
+func TestExecuteAllPreparesAhead(t *testing.T) {
+	require := require.New(t)
+
+	const numJobs = 10
+	var (
+		lock       sync.Mutex
+		prepared   = make([]bool, numJobs)
+		executions []int
+		// The second job is prepared while the first job is executing.
+		secondPrepared = make(chan struct{})
+	)
+	jobs := newPreparableChain(
+		t,
+		numJobs,
+		func(i int) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			prepared[i] = true
+			if i == 1 {
+				close(secondPrepared)
+			}
+			return nil
+		},
+		func(i int) error {
+			if i == 0 {
+				<-secondPrepared
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			require.True(prepared[i])
+			executions = append(executions, i)
+			return nil
+		},
+	)
+	jobs.EnablePreparation(2, 4)
+
+	count, err := jobs.ExecuteAll(context.Background(), snow.DefaultConsensusContextTest(), &common.Halter{}, false)
+	require.NoError(err)
+	require.Equal(numJobs, count)
+	require.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, executions)
+}
+
+func TestExecuteAllPrepareFails(t *testing.T) {
+	require := require.New(t)
+
+	var executions []int
+	jobs := newPreparableChain(
+		t,
+		5,
+		func(i int) error {
+			if i == 3 {
+				return errTestPrepare
+			}
+			return nil
+		},
+		func(i int) error {
+			executions = append(executions, i)
+			return nil
+		},
+	)
+	jobs.EnablePreparation(2, 4)
+
+	_, err := jobs.ExecuteAll(context.Background(), snow.DefaultConsensusContextTest(), &common.Halter{}, false)
+	require.ErrorIs(err, errTestPrepare)
+
+	// The jobs before the failed job are still executed, in order.
+	require.Equal([]int{0, 1, 2}, executions)
+}
@@ -276,6 +276,24 @@ func (s *state) RemoveDependencies(dependency ids.ID) ([]ids.ID, error) {
 	return dependents, iterator.Error()
 }
 
+// GetDependents returns the jobs that are blocking on [dependency] being
+// completed without removing them.
+func (s *state) GetDependents(dependency ids.ID) ([]ids.ID, error) {
+	dependentsDB := s.getDependentsDB(dependency)
+	iterator := dependentsDB.NewIterator()
+	defer iterator.Release()
+
+	dependents := []ids.ID(nil)
+	for iterator.Next() {
+		dependent, err := ids.ToID(iterator.Key())
+		if err != nil {
+			return nil, err
+		}
+		dependents = append(dependents, dependent)
+	}
+	return dependents, iterator.Error()
+}
+
 func (s *state) DisableCaching() {
 	s.dependentsCache.Flush()
 	s.jobsCache.Flush()
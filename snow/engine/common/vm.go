@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/validators"
 )
@@ -98,3 +99,24 @@ type VM interface {
 	// information about their accounts.
 	CreateHandlers(context.Context) (map[string]*HTTPHandler, error)
 }
+
+// ConcurrentAppHandler may be implemented by a VM to handle the App messages of
+// some ops on dedicated worker pools, so that they aren't delayed by the App
+// messages of other ops. The App messages of the other ops share a worker pool.
+// App messages are never serialized with consensus messages.
+type ConcurrentAppHandler interface {
+	// AppConcurrency returns the maximum number of messages of each App
+	// message op that may be handled concurrently. It is called once, after
+	// the VM is initialized.
+	AppConcurrency() map[message.Op]int
+}
+
+// GatedAppHandler may be implemented by a VM to protect expensive AppRequest
+// handlers by requiring that AppRequests originate from nodes with enough
+// stake, or include the solution to a puzzle. The AppRequests that don't
+// satisfy the policy are dropped before they reach the VM.
+type GatedAppHandler interface {
+	// AppRequestPolicy returns the policy the AppRequests sent to the VM must
+	// satisfy. It is called once, after the VM is initialized.
+	AppRequestPolicy() snow.AppRequestPolicy
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
+)
+
+var _ poll.Ancestry = (*ancestry)(nil)
+
+// ancestry reports which processing blocks the votes of a poll support, so
+// that the poll can terminate once none of them can reach an alpha majority.
+type ancestry struct {
+	t *Transitive
+}
+
+func (a *ancestry) Root(blkID ids.ID) (ids.ID, bool) {
+	// Polls are only evaluated while the engine holds the context lock and
+	// processing blocks are kept in memory by the VM, so this doesn't block.
+	ctx := context.TODO()
+	if !a.t.Consensus.Processing(blkID) {
+		blk, err := a.t.GetBlock(ctx, blkID)
+		if err != nil || !a.t.Consensus.Decided(blk) {
+			// The block hasn't been fetched or issued yet, so it may end up
+			// supporting any processing block.
+			return ids.Empty, false
+		}
+		return ids.Empty, true
+	}
+
+	for {
+		blk, err := a.t.GetBlock(ctx, blkID)
+		if err != nil {
+			return ids.Empty, false
+		}
+		parentID := blk.Parent()
+		if !a.t.Consensus.Processing(parentID) {
+			return blkID, true
+		}
+		blkID = parentID
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrDurableVMNotImplemented = errors.New("vm does not implement DurableChainVM interface")
+
+// DurableChainVM defines the interface a ChainVM that commits accepted blocks
+// to disk asynchronously can implement to report which accepted blocks would
+// survive a crash.
+type DurableChainVM interface {
+	// LastDurableHeight returns the height of the last accepted block whose
+	// acceptance, along with the acceptance of its ancestors, has been durably
+	// committed to disk.
+	//
+	// VMs that commit accepted blocks before returning from Accept may
+	// return the height of the last accepted block. Returns
+	// ErrDurableVMNotImplemented if the VM wraps a VM that doesn't implement
+	// DurableChainVM.
+	LastDurableHeight(ctx context.Context) (uint64, error)
+}
@@ -0,0 +1,25 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import "github.com/ava-labs/avalanchego/snow/consensus/snowman"
+
+// WithNumTxs defines the interface a Block can optionally implement to report
+// the number of transactions it contains.
+type WithNumTxs interface {
+	// NumTxs returns the number of transactions in the block. Returns false if
+	// the number of transactions isn't known, which is the case for blocks
+	// that wrap a block that doesn't implement WithNumTxs.
+	NumTxs() (int, bool)
+}
+
+// NumTxs returns the number of transactions in [blk], and false if [blk]
+// doesn't report it.
+func NumTxs(blk snowman.Block) (int, bool) {
+	blkWithTxs, ok := blk.(WithNumTxs)
+	if !ok {
+		return 0, false
+	}
+	return blkWithTxs.NumTxs()
+}
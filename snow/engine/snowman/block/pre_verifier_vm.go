@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+)
+
+var ErrPreVerifierVMNotImplemented = errors.New("vm does not implement PreVerifierChainVM interface")
+
+// PreVerifierChainVM defines the interface a ChainVM can optionally implement
+// to have blocks partially verified ahead of their execution during
+// bootstrapping.
+type PreVerifierChainVM interface {
+	// PreVerifyBlock performs the checks of [blk] that don't depend on the
+	// state of its ancestors, such as verifying signatures.
+	//
+	// PreVerifyBlock may be called concurrently with other calls to
+	// PreVerifyBlock and with the verification and acceptance of the
+	// ancestors of [blk]. Therefore, it must not read or modify chain state.
+	//
+	// If PreVerifyBlock returns nil, Verify will later be called on the same
+	// [blk] instance, which may skip the checks that were already performed.
+	//
+	// Returns ErrPreVerifierVMNotImplemented if the VM doesn't support
+	// pre-verification, for example because it wraps a VM that doesn't.
+	PreVerifyBlock(ctx context.Context, blk snowman.Block) error
+}
@@ -21,12 +21,21 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
-var errMissingDependenciesOnAccept = errors.New("attempting to accept a block with missing dependencies")
+var (
+	errMissingDependenciesOnAccept = errors.New("attempting to accept a block with missing dependencies")
+
+	_ queue.PreparableJob = (*preVerifyBlockJob)(nil)
+)
 
 type parser struct {
 	log                     logging.Logger
 	numAccepted, numDropped prometheus.Counter
 	vm                      block.ChainVM
+
+	// If non-nil, parsed blocks are pre-verified by [preVerifier] ahead of
+	// their execution.
+	preVerifier    block.PreVerifierChainVM
+	numPreVerified prometheus.Counter
 }
 
 func (p *parser) Parse(ctx context.Context, blkBytes []byte) (queue.Job, error) {
@@ -34,12 +43,20 @@ func (p *parser) Parse(ctx context.Context, blkBytes []byte) (queue.Job, error)
 	if err != nil {
 		return nil, err
 	}
-	return &blockJob{
+	job := &blockJob{
 		log:         p.log,
 		numAccepted: p.numAccepted,
 		numDropped:  p.numDropped,
 		blk:         blk,
 		vm:          p.vm,
+	}
+	if p.preVerifier == nil {
+		return job, nil
+	}
+	return &preVerifyBlockJob{
+		blockJob:       job,
+		numPreVerified: p.numPreVerified,
+		preVerifier:    p.preVerifier,
 	}, nil
 }
 
@@ -114,3 +131,29 @@ func (b *blockJob) Execute(ctx context.Context) error {
 func (b *blockJob) Bytes() []byte {
 	return b.blk.Bytes()
 }
+
+// preVerifyBlockJob is a blockJob whose block is pre-verified while its
+// ancestors are being executed.
+type preVerifyBlockJob struct {
+	*blockJob
+	numPreVerified prometheus.Counter
+	preVerifier    block.PreVerifierChainVM
+}
+
+func (b *preVerifyBlockJob) Prepare(ctx context.Context) error {
+	err := b.preVerifier.PreVerifyBlock(ctx, b.blk)
+	switch {
+	case err == nil:
+		b.numPreVerified.Inc()
+		return nil
+	case errors.Is(err, block.ErrPreVerifierVMNotImplemented):
+		// The block will be fully verified during its execution.
+		return nil
+	default:
+		b.log.Error("block failed pre-verification during bootstrapping",
+			zap.Stringer("blkID", b.blk.ID()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to pre-verify block in bootstrapping: %w", err)
+	}
+}
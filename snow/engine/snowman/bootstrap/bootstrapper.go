@@ -25,8 +25,14 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 )
 
-// Parameters for delaying bootstrapping to avoid potential CPU burns
-const bootstrappingDelay = 10 * time.Second
+const (
+	// Parameters for delaying bootstrapping to avoid potential CPU burns
+	bootstrappingDelay = 10 * time.Second
+
+	// preVerifyAheadPerWorker is the number of blocks per execution worker
+	// that may be pre-verified ahead of the block being executed.
+	preVerifyAheadPerWorker = 8
+)
 
 var (
 	_ common.BootstrapableEngine = (*bootstrapper)(nil)
@@ -129,6 +135,14 @@ func (b *bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 		numDropped:  b.numDropped,
 		vm:          b.VM,
 	}
+	if preVerifier, ok := b.VM.(block.PreVerifierChainVM); ok && b.Config.ExecutionWorkers > 0 {
+		b.parser.preVerifier = preVerifier
+		b.parser.numPreVerified = b.numPreVerified
+		b.Blocked.EnablePreparation(
+			b.Config.ExecutionWorkers,
+			b.Config.ExecutionWorkers*preVerifyAheadPerWorker,
+		)
+	}
 	if err := b.Blocked.SetParser(ctx, b.parser); err != nil {
 		return err
 	}
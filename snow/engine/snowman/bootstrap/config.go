@@ -22,5 +22,11 @@ type Config struct {
 
 	VM block.ChainVM
 
+	// ExecutionWorkers is the number of goroutines that pre-verify blocks
+	// while their ancestors are executed. Blocks are only pre-verified if
+	// [VM] implements block.PreVerifierChainVM. If 0, blocks are executed
+	// without being pre-verified.
+	ExecutionWorkers int
+
 	Bootstrapped func()
 }
@@ -10,8 +10,8 @@ import (
 )
 
 type metrics struct {
-	numFetched, numDropped, numAccepted prometheus.Counter
-	fetchETA                            prometheus.Gauge
+	numFetched, numDropped, numAccepted, numPreVerified prometheus.Counter
+	fetchETA                                            prometheus.Gauge
 }
 
 func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
@@ -31,6 +31,11 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 			Name:      "accepted",
 			Help:      "Number of blocks accepted during bootstrapping",
 		}),
+		numPreVerified: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pre_verified",
+			Help:      "Number of blocks pre-verified ahead of their execution during bootstrapping",
+		}),
 		fetchETA: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "eta_fetching_complete",
@@ -43,6 +48,7 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 		registerer.Register(m.numFetched),
 		registerer.Register(m.numDropped),
 		registerer.Register(m.numAccepted),
+		registerer.Register(m.numPreVerified),
 		registerer.Register(m.fetchETA),
 	)
 	return m, errs.Err
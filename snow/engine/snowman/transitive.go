@@ -110,7 +110,6 @@ func newTransitive(config Config) (*Transitive, error) {
 	acceptedFrontiers := tracker.NewAccepted()
 	config.Validators.RegisterCallbackListener(acceptedFrontiers)
 
-	factory := poll.NewEarlyTermNoTraversalFactory(config.Params.Alpha)
 	t := &Transitive{
 		Config:                      config,
 		StateSummaryFrontierHandler: common.NewNoOpStateSummaryFrontierHandler(config.Ctx.Log),
@@ -124,12 +123,13 @@ func newTransitive(config Config) (*Transitive, error) {
 		nonVerifieds:                NewAncestorTree(),
 		nonVerifiedCache:            nonVerifiedCache,
 		acceptedFrontiers:           acceptedFrontiers,
-		polls: poll.NewSet(factory,
-			config.Ctx.Log,
-			"",
-			config.Ctx.Registerer,
-		),
 	}
+	factory := poll.NewEarlyTermTraversalFactory(config.Params.Alpha, &ancestry{t: t})
+	t.polls = poll.NewSet(factory,
+		config.Ctx.Log,
+		"",
+		config.Ctx.Registerer,
+	)
 
 	return t, t.metrics.Initialize("", config.Ctx.Registerer)
 }
@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package finality
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+const (
+	// QuorumNumerator and QuorumDenominator are the portion of the stake of a
+	// chain's validators that must sign a block for it to be certified.
+	QuorumNumerator   = 67
+	QuorumDenominator = 100
+
+	codecVersion = 0
+)
+
+var (
+	// signaturePrefix is prepended to the unsigned certificates signed by
+	// validators. Warp messages are also signed with the staking BLS key, but
+	// start with their codec version, so a signature of an unsigned
+	// certificate can never be used as the signature of a warp message.
+	signaturePrefix = []byte("avalanche finality")
+
+	// Codec does serialization and deserialization for finality certificates.
+	c codec.Manager
+)
+
+func init() {
+	c = codec.NewManager(math.MaxInt)
+	lc := linearcodec.NewCustomMaxLength(math.MaxInt32)
+	if err := c.RegisterCodec(codecVersion, lc); err != nil {
+		panic(err)
+	}
+}
+
+// UnsignedCertificate states that [BlockID] was accepted on [ChainID].
+type UnsignedCertificate struct {
+	NetworkID uint32 `serialize:"true"`
+	ChainID   ids.ID `serialize:"true"`
+	BlockID   ids.ID `serialize:"true"`
+}
+
+// Bytes returns the bytes that validators sign to certify the block.
+func (u *UnsignedCertificate) Bytes() ([]byte, error) {
+	bytes, err := c.Marshal(codecVersion, u)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal unsigned certificate: %w", err)
+	}
+	return append(signaturePrefix[:len(signaturePrefix):len(signaturePrefix)], bytes...), nil
+}
+
+// Certificate proves that a block was accepted, without re-running consensus,
+// by aggregating the signatures of the validators that accepted it.
+//
+// Because a block is only accepted after all of its ancestors, a certificate
+// also proves that all of the ancestors of the block were accepted.
+type Certificate struct {
+	UnsignedCertificate `serialize:"true"`
+	// PChainHeight is the height of the P-chain whose validator set of the
+	// chain's subnet signed the block.
+	PChainHeight uint64 `serialize:"true"`
+	// Signature is signed by the validators that are set in its bitset,
+	// indexed by their position in the canonical validator set at
+	// [PChainHeight].
+	Signature warp.BitSetSignature `serialize:"true"`
+}
+
+// ParseCertificate parses the bytes of a certificate.
+func ParseCertificate(b []byte) (*Certificate, error) {
+	cert := &Certificate{}
+	if _, err := c.Unmarshal(b, cert); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// Bytes returns the binary representation of the certificate.
+func (cert *Certificate) Bytes() ([]byte, error) {
+	bytes, err := c.Marshal(codecVersion, cert)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal certificate: %w", err)
+	}
+	return bytes, nil
+}
+
+// Verify that the certificate is signed by at least
+// [QuorumNumerator]/[QuorumDenominator] of the stake of the validators of the
+// chain at [PChainHeight].
+func (cert *Certificate) Verify(
+	ctx context.Context,
+	networkID uint32,
+	pChainState validators.State,
+) error {
+	if cert.NetworkID != networkID {
+		return warp.ErrWrongNetworkID
+	}
+
+	subnetID, err := pChainState.GetSubnetID(ctx, cert.ChainID)
+	if err != nil {
+		return err
+	}
+
+	vdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, pChainState, cert.PChainHeight, subnetID)
+	if err != nil {
+		return err
+	}
+
+	// Reject bitsets with unnecessary zero-padding so that every certificate
+	// has a single encoding.
+	signerIndices := set.BitsFromBytes(cert.Signature.Signers)
+	if len(signerIndices.Bytes()) != len(cert.Signature.Signers) {
+		return warp.ErrInvalidBitSet
+	}
+
+	signers, err := warp.FilterValidators(signerIndices, vdrs)
+	if err != nil {
+		return err
+	}
+
+	// Because [signers] is a subset of [vdrs], this can never error.
+	sigWeight, _ := warp.SumWeight(signers)
+	if err := warp.VerifyWeight(sigWeight, totalWeight, QuorumNumerator, QuorumDenominator); err != nil {
+		return err
+	}
+
+	aggSig, err := bls.SignatureFromBytes(cert.Signature.Signature[:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", warp.ErrParseSignature, err)
+	}
+	aggPubKey, err := warp.AggregatePublicKeys(signers)
+	if err != nil {
+		return err
+	}
+
+	unsignedBytes, err := cert.UnsignedCertificate.Bytes()
+	if err != nil {
+		return err
+	}
+	if !bls.Verify(aggPubKey, aggSig, unsignedBytes) {
+		return warp.ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package finality
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+func TestCertificateVerify(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		chainID  = ids.GenerateTestID()
+		subnetID = ids.GenerateTestID()
+		blkID    = ids.GenerateTestID()
+	)
+	vdrs, state := newTestNetwork(t, chainID, subnetID, 3)
+	canonicalVdrs, _, err := warp.GetCanonicalValidatorSet(ctx, state, pChainHeight, subnetID)
+	require.NoError(t, err)
+
+	// public key -> secret key
+	sks := make(map[string]*bls.SecretKey, len(vdrs))
+	for _, vdr := range vdrs {
+		pk := bls.PublicFromSecretKey(vdr.sk)
+		sks[string(pk.Serialize())] = vdr.sk
+	}
+
+	// newCert returns a certificate of [unsignedCert] signed by the
+	// validators at [indices] in the canonical validator set.
+	newCert := func(unsignedCert UnsignedCertificate, indices ...int) *Certificate {
+		unsignedBytes, err := unsignedCert.Bytes()
+		require.NoError(t, err)
+
+		sigs := make([]*bls.Signature, len(indices))
+		for i, index := range indices {
+			sk := sks[string(canonicalVdrs[index].PublicKeyBytes)]
+			sigs[i] = bls.Sign(sk, unsignedBytes)
+		}
+		aggSig, err := bls.AggregateSignatures(sigs)
+		require.NoError(t, err)
+
+		cert := &Certificate{
+			UnsignedCertificate: unsignedCert,
+			PChainHeight:        pChainHeight,
+			Signature: warp.BitSetSignature{
+				Signers: set.NewBits(indices...).Bytes(),
+			},
+		}
+		copy(cert.Signature.Signature[:], bls.SignatureToBytes(aggSig))
+		return cert
+	}
+
+	unsignedCert := UnsignedCertificate{
+		NetworkID: constants.UnitTestID,
+		ChainID:   chainID,
+		BlockID:   blkID,
+	}
+	tests := []struct {
+		name        string
+		cert        func() *Certificate
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			cert: func() *Certificate {
+				return newCert(unsignedCert, 0, 1, 2)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "wrong network",
+			cert: func() *Certificate {
+				cert := newCert(unsignedCert, 0, 1, 2)
+				cert.NetworkID++
+				return cert
+			},
+			expectedErr: warp.ErrWrongNetworkID,
+		},
+		{
+			name: "insufficient weight",
+			cert: func() *Certificate {
+				return newCert(unsignedCert, 0, 1)
+			},
+			expectedErr: warp.ErrInsufficientWeight,
+		},
+		{
+			name: "unknown signer",
+			cert: func() *Certificate {
+				cert := newCert(unsignedCert, 0, 1, 2)
+				cert.Signature.Signers = set.NewBits(0, 1, 2, 3).Bytes()
+				return cert
+			},
+			expectedErr: warp.ErrUnknownValidator,
+		},
+		{
+			name: "padded bitset",
+			cert: func() *Certificate {
+				cert := newCert(unsignedCert, 0, 1, 2)
+				cert.Signature.Signers = append([]byte{0}, cert.Signature.Signers...)
+				return cert
+			},
+			expectedErr: warp.ErrInvalidBitSet,
+		},
+		{
+			name: "wrong block",
+			cert: func() *Certificate {
+				cert := newCert(unsignedCert, 0, 1, 2)
+				cert.BlockID = ids.GenerateTestID()
+				return cert
+			},
+			expectedErr: warp.ErrInvalidSignature,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			cert := test.cert()
+			certBytes, err := cert.Bytes()
+			require.NoError(err)
+
+			parsedCert, err := ParseCertificate(certBytes)
+			require.NoError(err)
+			require.Equal(cert, parsedCert)
+
+			err = parsedCert.Verify(ctx, constants.UnitTestID, state)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
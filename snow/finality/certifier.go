@@ -0,0 +1,270 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package finality
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+// pendingCacheSize is the maximum number of blocks whose signatures are
+// aggregated at the same time. Signatures of blocks that are evicted before
+// reaching the quorum are dropped.
+const pendingCacheSize = 64
+
+var (
+	_ Certifier = (*certifier)(nil)
+
+	errUnknownSigner = errors.New("signer isn't a validator with a BLS key")
+)
+
+// Certifier signs the blocks accepted by this node and aggregates the
+// signatures of the chain's validators into finality certificates.
+type Certifier interface {
+	// Sign returns this node's signature of [blkID].
+	//
+	// Invariant: [blkID] was accepted by this node.
+	Sign(blkID ids.ID) ([]byte, error)
+
+	// AddSignature records [nodeID]'s signature of [blkID]. Once validators
+	// holding enough stake signed [blkID], its certificate is persisted.
+	//
+	// Returns an error if [sig] isn't a valid signature of [blkID] by
+	// [nodeID].
+	AddSignature(ctx context.Context, nodeID ids.NodeID, blkID ids.ID, sig []byte) error
+
+	// GetCertificate returns the certificate of [blkID]. Returns
+	// [database.ErrNotFound] if [blkID] wasn't certified.
+	GetCertificate(blkID ids.ID) (*Certificate, error)
+}
+
+// pendingCertificate is a certificate whose signatures are being aggregated.
+type pendingCertificate struct {
+	unsignedBytes []byte
+	pChainHeight  uint64
+
+	validators  []*warp.Validator
+	totalWeight uint64
+	// nodeID -> index of the validator in [validators]
+	indices map[ids.NodeID]int
+
+	signers    set.Bits
+	signatures []*bls.Signature
+	weight     uint64
+}
+
+type certifier struct {
+	networkID uint32
+	chainID   ids.ID
+	subnetID  ids.ID
+
+	sk          *bls.SecretKey
+	pChainState validators.State
+	// blkID -> certificate bytes
+	db database.Database
+
+	numCertified         prometheus.Counter
+	numInvalidSignatures prometheus.Counter
+
+	lock sync.Mutex
+	// The most recently signed block. Peers are usually queried many times
+	// between two accepted blocks, so its signature is cached.
+	lastSignedID  ids.ID
+	lastSignature []byte
+	pending       cache.LRU[ids.ID, *pendingCertificate]
+}
+
+// NewCertifier returns a certifier of the blocks of [chainID], which signs
+// blocks with [sk] and persists certificates in [db].
+func NewCertifier(
+	networkID uint32,
+	chainID ids.ID,
+	subnetID ids.ID,
+	sk *bls.SecretKey,
+	pChainState validators.State,
+	db database.Database,
+	registerer prometheus.Registerer,
+) (Certifier, error) {
+	c := &certifier{
+		networkID:   networkID,
+		chainID:     chainID,
+		subnetID:    subnetID,
+		sk:          sk,
+		pChainState: pChainState,
+		db:          db,
+		numCertified: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "finality_certificates",
+			Help: "# of finality certificates created",
+		}),
+		numInvalidSignatures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "finality_invalid_signatures",
+			Help: "# of invalid finality signatures received from peers",
+		}),
+		pending: cache.LRU[ids.ID, *pendingCertificate]{Size: pendingCacheSize},
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(c.numCertified),
+		registerer.Register(c.numInvalidSignatures),
+	)
+	return c, errs.Err
+}
+
+func (c *certifier) Sign(blkID ids.ID) ([]byte, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.lastSignature != nil && c.lastSignedID == blkID {
+		return c.lastSignature, nil
+	}
+
+	unsignedBytes, err := c.unsignedBytes(blkID)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := bls.SignatureToBytes(bls.Sign(c.sk, unsignedBytes))
+	c.lastSignedID = blkID
+	c.lastSignature = sig
+	return sig, nil
+}
+
+func (c *certifier) AddSignature(ctx context.Context, nodeID ids.NodeID, blkID ids.ID, sigBytes []byte) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	certified, err := c.db.Has(blkID[:])
+	if err != nil || certified {
+		return err
+	}
+
+	pending, ok := c.pending.Get(blkID)
+	if !ok {
+		pending, err = c.newPendingCertificate(ctx, blkID)
+		if err != nil {
+			return err
+		}
+		c.pending.Put(blkID, pending)
+	}
+
+	index, ok := pending.indices[nodeID]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownSigner, nodeID)
+	}
+	if pending.signers.Contains(index) {
+		return nil
+	}
+
+	sig, err := bls.SignatureFromBytes(sigBytes)
+	if err != nil {
+		c.numInvalidSignatures.Inc()
+		return fmt.Errorf("%w: %w", warp.ErrParseSignature, err)
+	}
+	vdr := pending.validators[index]
+	if !bls.Verify(vdr.PublicKey, sig, pending.unsignedBytes) {
+		c.numInvalidSignatures.Inc()
+		return fmt.Errorf("%w: signed by %s", warp.ErrInvalidSignature, nodeID)
+	}
+
+	pending.signers.Add(index)
+	pending.signatures = append(pending.signatures, sig)
+	pending.weight += vdr.Weight // Can't overflow because it is at most the total weight
+	if err := warp.VerifyWeight(pending.weight, pending.totalWeight, QuorumNumerator, QuorumDenominator); err != nil {
+		// The quorum hasn't been reached yet.
+		return nil
+	}
+
+	aggSig, err := bls.AggregateSignatures(pending.signatures)
+	if err != nil {
+		return err
+	}
+	cert := &Certificate{
+		UnsignedCertificate: UnsignedCertificate{
+			NetworkID: c.networkID,
+			ChainID:   c.chainID,
+			BlockID:   blkID,
+		},
+		PChainHeight: pending.pChainHeight,
+		Signature: warp.BitSetSignature{
+			Signers: pending.signers.Bytes(),
+		},
+	}
+	copy(cert.Signature.Signature[:], bls.SignatureToBytes(aggSig))
+
+	certBytes, err := cert.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := c.db.Put(blkID[:], certBytes); err != nil {
+		return err
+	}
+
+	c.pending.Evict(blkID)
+	c.numCertified.Inc()
+	return nil
+}
+
+func (c *certifier) GetCertificate(blkID ids.ID) (*Certificate, error) {
+	certBytes, err := c.db.Get(blkID[:])
+	if err != nil {
+		return nil, err
+	}
+	return ParseCertificate(certBytes)
+}
+
+// newPendingCertificate starts aggregating the signatures of [blkID] by the
+// current validators of the chain.
+func (c *certifier) newPendingCertificate(ctx context.Context, blkID ids.ID) (*pendingCertificate, error) {
+	unsignedBytes, err := c.unsignedBytes(blkID)
+	if err != nil {
+		return nil, err
+	}
+
+	pChainHeight, err := c.pChainState.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, c.pChainState, pChainHeight, c.subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	indices := make(map[ids.NodeID]int)
+	for i, vdr := range vdrs {
+		for _, nodeID := range vdr.NodeIDs {
+			indices[nodeID] = i
+		}
+	}
+	return &pendingCertificate{
+		unsignedBytes: unsignedBytes,
+		pChainHeight:  pChainHeight,
+		validators:    vdrs,
+		totalWeight:   totalWeight,
+		indices:       indices,
+		signers:       set.NewBits(),
+	}, nil
+}
+
+func (c *certifier) unsignedBytes(blkID ids.ID) ([]byte, error) {
+	unsignedCert := UnsignedCertificate{
+		NetworkID: c.networkID,
+		ChainID:   c.chainID,
+		BlockID:   blkID,
+	}
+	return unsignedCert.Bytes()
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package finality
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+const pChainHeight uint64 = 1337
+
+type testValidator struct {
+	nodeID ids.NodeID
+	sk     *bls.SecretKey
+}
+
+// newTestNetwork returns [numValidators] validators with equal weights and
+// the P-chain state that reports them as the validators of [chainID].
+func newTestNetwork(t *testing.T, chainID ids.ID, subnetID ids.ID, numValidators int) ([]*testValidator, validators.State) {
+	vdrs := make([]*testValidator, numValidators)
+	vdrSet := make(map[ids.NodeID]*validators.GetValidatorOutput, numValidators)
+	for i := range vdrs {
+		sk, err := bls.NewSecretKey()
+		require.NoError(t, err)
+
+		vdr := &testValidator{
+			nodeID: ids.GenerateTestNodeID(),
+			sk:     sk,
+		}
+		vdrs[i] = vdr
+		vdrSet[vdr.nodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdr.nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    10,
+		}
+	}
+
+	state := &validators.TestState{
+		T: t,
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return pChainHeight, nil
+		},
+		GetSubnetIDF: func(_ context.Context, id ids.ID) (ids.ID, error) {
+			require.Equal(t, chainID, id)
+			return subnetID, nil
+		},
+		GetValidatorSetF: func(_ context.Context, height uint64, id ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			require.Equal(t, pChainHeight, height)
+			require.Equal(t, subnetID, id)
+			return vdrSet, nil
+		},
+	}
+	return vdrs, state
+}
+
+func newTestCertifier(t *testing.T, chainID ids.ID, subnetID ids.ID, vdr *testValidator, state validators.State) Certifier {
+	c, err := NewCertifier(
+		constants.UnitTestID,
+		chainID,
+		subnetID,
+		vdr.sk,
+		state,
+		memdb.New(),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	return c
+}
+
+func TestCertifier(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx      = context.Background()
+		chainID  = ids.GenerateTestID()
+		subnetID = ids.GenerateTestID()
+		blkID    = ids.GenerateTestID()
+	)
+	vdrs, state := newTestNetwork(t, chainID, subnetID, 4)
+	c := newTestCertifier(t, chainID, subnetID, vdrs[0], state)
+
+	// 3 of the 4 validators must sign the block to reach the quorum.
+	for _, vdr := range vdrs[:2] {
+		sig, err := newTestCertifier(t, chainID, subnetID, vdr, state).Sign(blkID)
+		require.NoError(err)
+		require.NoError(c.AddSignature(ctx, vdr.nodeID, blkID, sig))
+
+		// Duplicate signatures are ignored.
+		require.NoError(c.AddSignature(ctx, vdr.nodeID, blkID, sig))
+
+		_, err = c.GetCertificate(blkID)
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+
+	sig, err := newTestCertifier(t, chainID, subnetID, vdrs[2], state).Sign(blkID)
+	require.NoError(err)
+	require.NoError(c.AddSignature(ctx, vdrs[2].nodeID, blkID, sig))
+
+	cert, err := c.GetCertificate(blkID)
+	require.NoError(err)
+	require.Equal(
+		UnsignedCertificate{
+			NetworkID: constants.UnitTestID,
+			ChainID:   chainID,
+			BlockID:   blkID,
+		},
+		cert.UnsignedCertificate,
+	)
+	require.Equal(pChainHeight, cert.PChainHeight)
+	require.NoError(cert.Verify(ctx, constants.UnitTestID, state))
+
+	// Signatures of certified blocks are ignored.
+	sig, err = newTestCertifier(t, chainID, subnetID, vdrs[3], state).Sign(blkID)
+	require.NoError(err)
+	require.NoError(c.AddSignature(ctx, vdrs[3].nodeID, blkID, sig))
+
+	certAfter, err := c.GetCertificate(blkID)
+	require.NoError(err)
+	require.Equal(cert, certAfter)
+}
+
+func TestCertifierInvalidSignature(t *testing.T) {
+	var (
+		chainID  = ids.GenerateTestID()
+		subnetID = ids.GenerateTestID()
+		blkID    = ids.GenerateTestID()
+	)
+	vdrs, state := newTestNetwork(t, chainID, subnetID, 2)
+
+	tests := []struct {
+		name        string
+		nodeID      ids.NodeID
+		sig         func(t *testing.T) []byte
+		expectedErr error
+	}{
+		{
+			name:   "unknown signer",
+			nodeID: ids.GenerateTestNodeID(),
+			sig: func(t *testing.T) []byte {
+				sig, err := newTestCertifier(t, chainID, subnetID, vdrs[1], state).Sign(blkID)
+				require.NoError(t, err)
+				return sig
+			},
+			expectedErr: errUnknownSigner,
+		},
+		{
+			name:   "malformed signature",
+			nodeID: vdrs[1].nodeID,
+			sig: func(*testing.T) []byte {
+				return []byte{1, 2, 3}
+			},
+			expectedErr: warp.ErrParseSignature,
+		},
+		{
+			name:   "signed by another validator",
+			nodeID: vdrs[1].nodeID,
+			sig: func(t *testing.T) []byte {
+				sig, err := newTestCertifier(t, chainID, subnetID, vdrs[0], state).Sign(blkID)
+				require.NoError(t, err)
+				return sig
+			},
+			expectedErr: warp.ErrInvalidSignature,
+		},
+		{
+			name:   "signed another block",
+			nodeID: vdrs[1].nodeID,
+			sig: func(t *testing.T) []byte {
+				sig, err := newTestCertifier(t, chainID, subnetID, vdrs[1], state).Sign(ids.GenerateTestID())
+				require.NoError(t, err)
+				return sig
+			},
+			expectedErr: warp.ErrInvalidSignature,
+		},
+		{
+			name:   "signed another chain",
+			nodeID: vdrs[1].nodeID,
+			sig: func(t *testing.T) []byte {
+				sig, err := newTestCertifier(t, ids.GenerateTestID(), subnetID, vdrs[1], state).Sign(blkID)
+				require.NoError(t, err)
+				return sig
+			},
+			expectedErr: warp.ErrInvalidSignature,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCertifier(t, chainID, subnetID, vdrs[0], state)
+			err := c.AddSignature(context.Background(), test.nodeID, blkID, test.sig(t))
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
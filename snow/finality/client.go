@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package finality
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var _ Client = (*client)(nil)
+
+// Client for the finality certificates of a chain
+type Client interface {
+	// GetCertificate returns the finality certificate of [blkID]. The
+	// certificate should be verified before it is trusted.
+	GetCertificate(ctx context.Context, blkID ids.ID, options ...rpc.Option) (*Certificate, error)
+}
+
+type client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a client for the finality certificates of [chain], which
+// is either the ID or an alias of the chain.
+func NewClient(uri, chain string) Client {
+	path := fmt.Sprintf("%s/ext/%s/%s/finality", uri, constants.ChainAliasPrefix, chain)
+	return &client{
+		requester: rpc.NewEndpointRequester(path),
+	}
+}
+
+func (c *client) GetCertificate(ctx context.Context, blkID ids.ID, options ...rpc.Option) (*Certificate, error) {
+	res := &GetCertificateReply{}
+	err := c.requester.SendRequest(ctx, "finality.getCertificate", &GetCertificateArgs{
+		BlockID:  blkID,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	certBytes, err := formatting.Decode(res.Encoding, res.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCertificate(certBytes)
+}
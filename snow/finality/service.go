@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package finality
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/rpc/v2"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// Service serves the finality certificates of a chain.
+type Service struct {
+	log       logging.Logger
+	certifier Certifier
+}
+
+// NewHandler returns a handler that serves the finality certificates of
+// [certifier].
+func NewHandler(log logging.Logger, certifier Certifier) (http.Handler, error) {
+	server := rpc.NewServer()
+	codec := json.NewCodec()
+	server.RegisterCodec(codec, "application/json")
+	server.RegisterCodec(codec, "application/json;charset=UTF-8")
+	return server, server.RegisterService(
+		&Service{
+			log:       log,
+			certifier: certifier,
+		},
+		"finality",
+	)
+}
+
+// GetCertificateArgs are the arguments for GetCertificate
+type GetCertificateArgs struct {
+	BlockID  ids.ID              `json:"blockID"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetCertificateReply is the response from GetCertificate
+type GetCertificateReply struct {
+	Certificate string              `json:"certificate"`
+	Encoding    formatting.Encoding `json:"encoding"`
+}
+
+// GetCertificate returns the finality certificate of a block
+func (s *Service) GetCertificate(_ *http.Request, args *GetCertificateArgs, reply *GetCertificateReply) error {
+	s.log.Debug("API called",
+		zap.String("service", "finality"),
+		zap.String("method", "getCertificate"),
+		zap.Stringer("blkID", args.BlockID),
+		zap.Stringer("encoding", args.Encoding),
+	)
+
+	cert, err := s.certifier.GetCertificate(args.BlockID)
+	if err != nil {
+		return fmt.Errorf("couldn't get certificate of block %s: %w", args.BlockID, err)
+	}
+	certBytes, err := cert.Bytes()
+	if err != nil {
+		return err
+	}
+
+	reply.Certificate, err = formatting.Encode(args.Encoding, certBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't encode certificate as %s: %w", args.Encoding, err)
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
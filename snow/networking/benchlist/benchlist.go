@@ -43,12 +43,19 @@ type Benchlist interface {
 	// IsBenched returns true if messages to [validatorID]
 	// should not be sent over the network and should immediately fail.
 	IsBenched(nodeID ids.NodeID) bool
+	// Bench benches [nodeID] until [until] for [reason], regardless of its
+	// stake. Does nothing if [nodeID] is already benched.
+	Bench(nodeID ids.NodeID, until time.Time, reason string)
+	// Benched returns the nodes that are currently benched
+	Benched() []BenchedPeer
 }
 
 // Data about a validator who is benched
 type benchData struct {
 	benchedUntil time.Time
 	nodeID       ids.NodeID
+	reason       string
+	failures     int
 	index        int
 }
 
@@ -283,13 +290,62 @@ func (b *benchlist) RegisterFailure(nodeID ids.NodeID) {
 	b.streaklock.Unlock()
 
 	if failureStreak.consecutive >= b.threshold && now.After(failureStreak.firstFailure.Add(b.minimumFailingDuration)) {
-		b.bench(nodeID)
+		b.bench(nodeID, failureStreak.consecutive)
 	}
 }
 
+func (b *benchlist) Bench(nodeID ids.NodeID, until time.Time, reason string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.benchlistSet.Contains(nodeID) || !until.After(b.clock.Time()) {
+		return
+	}
+
+	b.benchlistSet.Add(nodeID)
+	b.benchable.Benched(b.chainID, nodeID)
+
+	b.streaklock.Lock()
+	delete(b.failureStreaks, nodeID)
+	b.streaklock.Unlock()
+
+	heap.Push(
+		&b.benchedQueue,
+		&benchData{nodeID: nodeID, benchedUntil: until, reason: reason},
+	)
+	b.log.Debug("benching node",
+		zap.Stringer("nodeID", nodeID),
+		zap.Time("benchedUntil", until),
+		zap.String("reason", reason),
+	)
+
+	b.setNextLeaveTime()
+
+	b.metrics.numBenched.Set(float64(b.benchedQueue.Len()))
+	benchedStake := b.vdrs.SubsetWeight(b.benchlistSet)
+	b.metrics.weightBenched.Set(float64(benchedStake))
+}
+
+func (b *benchlist) Benched() []BenchedPeer {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	benched := make([]BenchedPeer, len(b.benchedQueue))
+	for i, data := range b.benchedQueue {
+		benched[i] = BenchedPeer{
+			NodeID:       data.nodeID,
+			ChainID:      b.chainID,
+			Reason:       data.reason,
+			BenchedUntil: data.benchedUntil,
+			Failures:     data.failures,
+		}
+	}
+	return benched
+}
+
 // Assumes [b.lock] is held
 // Assumes [nodeID] is not already benched
-func (b *benchlist) bench(nodeID ids.NodeID) {
+func (b *benchlist) bench(nodeID ids.NodeID, failures int) {
 	validatorStake := b.vdrs.GetWeight(nodeID)
 	if validatorStake == 0 {
 		// We might want to bench a non-validator because they don't respond to
@@ -338,12 +394,17 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 
 	heap.Push(
 		&b.benchedQueue,
-		&benchData{nodeID: nodeID, benchedUntil: benchedUntil},
+		&benchData{
+			nodeID:       nodeID,
+			benchedUntil: benchedUntil,
+			reason:       ReasonQueryFailures,
+			failures:     failures,
+		},
 	)
 	b.log.Debug("benching validator after consecutive failed queries",
 		zap.Stringer("nodeID", nodeID),
 		zap.Duration("benchDuration", benchedUntil.Sub(now)),
-		zap.Int("numFailedQueries", b.threshold),
+		zap.Int("numFailedQueries", failures),
 	)
 
 	// Set [b.timer] to fire when next validator should leave bench
@@ -11,7 +11,9 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
 var (
@@ -36,11 +38,15 @@ type Manager interface {
 	// IsBenched returns true if messages to [nodeID] regarding chain [chainID]
 	// should not be sent over the network and should immediately fail.
 	// Returns false if such messages should be sent, or if the chain is unknown.
+	// Retiring nodes are treated as benched on every known chain.
 	IsBenched(nodeID ids.NodeID, chainID ids.ID) bool
 	// GetBenched returns an array of chainIDs where the specified
 	// [nodeID] is benched. If called on an id.ShortID that does
 	// not map to a validator, it will return an empty array.
 	GetBenched(nodeID ids.NodeID) []ids.ID
+	// Benched returns every node that is benched, on every chain, along with
+	// why and until when it is benched. Retiring nodes are included.
+	Benched() []BenchedPeer
 }
 
 // Config defines the configuration for a benchlist
@@ -52,6 +58,12 @@ type Config struct {
 	MinimumFailingDuration time.Duration      `json:"minimumFailingDuration"`
 	Duration               time.Duration      `json:"duration"`
 	MaxPortion             float64            `json:"maxPortion"`
+	// If non-nil, requests to the nodes in [Retiring] fail immediately and
+	// their failures are ignored.
+	Retiring *RetiringSet `json:"-"`
+	// StaticBenches are benched on the chains they apply to as soon as the
+	// chains are registered.
+	StaticBenches []StaticBench `json:"staticBenches"`
 }
 
 type manager struct {
@@ -86,8 +98,7 @@ func (m *manager) IsBenched(nodeID ids.NodeID, chainID ids.ID) bool {
 	if !exists {
 		return false
 	}
-	isBenched := benchlist.IsBenched(nodeID)
-	return isBenched
+	return m.isRetiring(nodeID) || benchlist.IsBenched(nodeID)
 }
 
 // GetBenched returns an array of chainIDs where the specified
@@ -107,6 +118,29 @@ func (m *manager) GetBenched(nodeID ids.NodeID) []ids.ID {
 	return benched
 }
 
+func (m *manager) Benched() []BenchedPeer {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	benched := []BenchedPeer{}
+	for _, benchlist := range m.chainBenchlists {
+		benched = append(benched, benchlist.Benched()...)
+	}
+	if m.config.Retiring != nil {
+		for _, nodeID := range m.config.Retiring.List() {
+			for chainID := range m.chainBenchlists {
+				benched = append(benched, BenchedPeer{
+					NodeID:  nodeID,
+					ChainID: chainID,
+					Reason:  ReasonRetiring,
+				})
+			}
+		}
+	}
+	utils.Sort(benched)
+	return benched
+}
+
 func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -144,6 +178,22 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 		return err
 	}
 
+	for _, staticBench := range m.config.StaticBenches {
+		if !staticBench.appliesTo(ctx.ChainID) {
+			continue
+		}
+
+		until := staticBench.Until
+		if until.IsZero() {
+			until = mockable.MaxTime
+		}
+		reason := staticBench.Reason
+		if reason == "" {
+			reason = ReasonStatic
+		}
+		benchlist.Bench(staticBench.NodeID, until, reason)
+	}
+
 	m.chainBenchlists[ctx.ChainID] = benchlist
 	return nil
 }
@@ -164,12 +214,18 @@ func (m *manager) RegisterFailure(chainID ids.ID, nodeID ids.NodeID) {
 	benchlist, exists := m.chainBenchlists[chainID]
 	m.lock.RUnlock()
 
-	if !exists {
+	// A retiring node is expected to stop responding, so it shouldn't be
+	// benched for it.
+	if !exists || m.isRetiring(nodeID) {
 		return
 	}
 	benchlist.RegisterFailure(nodeID)
 }
 
+func (m *manager) isRetiring(nodeID ids.NodeID) bool {
+	return m.config.Retiring != nil && m.config.Retiring.Contains(nodeID)
+}
+
 type noBenchlist struct{}
 
 // NewNoBenchlist returns an empty benchlist that will never stop any queries
@@ -192,3 +248,7 @@ func (noBenchlist) IsBenched(ids.NodeID, ids.ID) bool {
 func (noBenchlist) GetBenched(ids.NodeID) []ids.ID {
 	return []ids.ID{}
 }
+
+func (noBenchlist) Benched() []BenchedPeer {
+	return []BenchedPeer{}
+}
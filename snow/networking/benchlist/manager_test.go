@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchlist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+func TestManagerRetiring(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewSet()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+	require.NoError(vdrs.Add(vdrID0, nil, ids.Empty, 50))
+	require.NoError(vdrs.Add(vdrID1, nil, ids.Empty, 50))
+
+	vdrsManager := validators.NewManager()
+	require.True(vdrsManager.Add(constants.PrimaryNetworkID, vdrs))
+
+	benchable := &TestBenchable{T: t}
+	benchable.Default(true)
+
+	retiring := NewRetiringSet()
+	m := NewManager(&Config{
+		Benchable:              benchable,
+		Validators:             vdrsManager,
+		Threshold:              1,
+		MinimumFailingDuration: time.Minute,
+		Duration:               time.Minute,
+		MaxPortion:             0.5,
+		Retiring:               retiring,
+	})
+
+	ctx := snow.DefaultConsensusContextTest()
+	require.NoError(m.RegisterChain(ctx))
+
+	// Requests to a retiring node fail immediately on known chains.
+	retiring.Add(vdrID0)
+	require.True(m.IsBenched(vdrID0, ctx.ChainID))
+	require.False(m.IsBenched(vdrID0, ids.GenerateTestID()))
+	require.False(m.IsBenched(vdrID1, ctx.ChainID))
+	require.Equal([]ids.NodeID{vdrID0}, retiring.List())
+
+	require.Empty(m.GetBenched(vdrID0))
+
+	// Failures of a retiring node aren't held against it.
+	b := m.(*manager).chainBenchlists[ctx.ChainID].(*benchlist)
+	defer b.timer.Stop()
+	m.RegisterFailure(ctx.ChainID, vdrID0)
+	require.Empty(b.failureStreaks)
+
+	retiring.Remove(vdrID0)
+	require.False(m.IsBenched(vdrID0, ctx.ChainID))
+
+	// Failures of other nodes are still counted.
+	m.RegisterFailure(ctx.ChainID, vdrID1)
+	require.Contains(b.failureStreaks, vdrID1)
+}
+
+func TestManagerStaticBenches(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewSet()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+	require.NoError(vdrs.Add(vdrID0, nil, ids.Empty, 50))
+	require.NoError(vdrs.Add(vdrID1, nil, ids.Empty, 50))
+
+	vdrsManager := validators.NewManager()
+	require.True(vdrsManager.Add(constants.PrimaryNetworkID, vdrs))
+
+	benchable := &TestBenchable{T: t}
+	benchable.Default(false)
+
+	ctx := snow.DefaultConsensusContextTest()
+	badNodeID := ids.GenerateTestNodeID()
+	retiring := NewRetiringSet()
+	m := NewManager(&Config{
+		Benchable:              benchable,
+		Validators:             vdrsManager,
+		Threshold:              1,
+		MinimumFailingDuration: time.Minute,
+		Duration:               time.Minute,
+		MaxPortion:             0.5,
+		Retiring:               retiring,
+		StaticBenches: []StaticBench{
+			{
+				// Static benches aren't limited to validators
+				NodeID: badNodeID,
+			},
+			{
+				NodeID:   vdrID1,
+				ChainIDs: []ids.ID{ids.GenerateTestID()},
+				Reason:   "other chain",
+			},
+		},
+	})
+	require.NoError(m.RegisterChain(ctx))
+
+	b := m.(*manager).chainBenchlists[ctx.ChainID].(*benchlist)
+	defer b.timer.Stop()
+
+	require.True(m.IsBenched(badNodeID, ctx.ChainID))
+	require.False(m.IsBenched(vdrID1, ctx.ChainID))
+
+	// Bench [vdrID0] for failing queries
+	b.clock.Set(time.Now())
+	m.RegisterFailure(ctx.ChainID, vdrID0)
+	b.clock.Set(b.clock.Time().Add(2 * time.Minute))
+	m.RegisterFailure(ctx.ChainID, vdrID0)
+	require.True(m.IsBenched(vdrID0, ctx.ChainID))
+
+	retiring.Add(vdrID1)
+
+	benched := m.Benched()
+	require.Len(benched, 3)
+	for _, peer := range benched {
+		require.Equal(ctx.ChainID, peer.ChainID)
+		switch peer.NodeID {
+		case badNodeID:
+			require.Equal(ReasonStatic, peer.Reason)
+			require.Equal(mockable.MaxTime, peer.BenchedUntil)
+			require.Zero(peer.Failures)
+		case vdrID0:
+			require.Equal(ReasonQueryFailures, peer.Reason)
+			require.Equal(2, peer.Failures)
+		case vdrID1:
+			require.Equal(ReasonRetiring, peer.Reason)
+			require.True(peer.BenchedUntil.IsZero())
+		default:
+			require.FailNow("unexpected benched node", peer.NodeID)
+		}
+	}
+}
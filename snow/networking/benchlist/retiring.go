@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchlist
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// RetiringSet is the set of nodes that announced that they are shutting down.
+// Requests to these nodes fail immediately, and their failures aren't counted
+// towards benching them.
+//
+// It is safe for concurrent access.
+type RetiringSet struct {
+	lock    sync.RWMutex
+	nodeIDs set.Set[ids.NodeID]
+}
+
+func NewRetiringSet() *RetiringSet {
+	return &RetiringSet{}
+}
+
+// Add marks [nodeID] as retiring.
+func (r *RetiringSet) Add(nodeID ids.NodeID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.nodeIDs.Add(nodeID)
+}
+
+// Remove marks [nodeID] as no longer retiring. This should be called once the
+// node reconnects.
+func (r *RetiringSet) Remove(nodeID ids.NodeID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.nodeIDs.Remove(nodeID)
+}
+
+// Contains returns true if [nodeID] announced that it is shutting down.
+func (r *RetiringSet) Contains(nodeID ids.NodeID) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.nodeIDs.Contains(nodeID)
+}
+
+// List returns the nodes that are retiring.
+func (r *RetiringSet) List() []ids.NodeID {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.nodeIDs.List()
+}
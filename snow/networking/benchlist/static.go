@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchlist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const (
+	// ReasonQueryFailures is the reason given for nodes that were benched
+	// because consecutive queries to them failed.
+	ReasonQueryFailures = "consecutive query failures"
+	// ReasonStatic is the reason given for nodes that were benched by the
+	// operator without providing a reason.
+	ReasonStatic = "benched by operator"
+	// ReasonRetiring is the reason given for nodes that announced that they
+	// are shutting down.
+	ReasonRetiring = "retiring"
+)
+
+var (
+	errMissingNodeID    = errors.New("missing nodeID")
+	errDuplicateNodeID  = errors.New("duplicate nodeID")
+	errDuplicateChainID = errors.New("duplicate chainID")
+)
+
+// StaticBench is a bench of a known-bad node that is provided by the operator
+// rather than learned from failed queries. Static benches are not limited by
+// the maximum portion of stake that may be benched.
+type StaticBench struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// ChainIDs that the node is benched on. If empty, the node is benched on
+	// every chain.
+	ChainIDs []ids.ID `json:"chainIDs"`
+	Reason   string   `json:"reason"`
+	// Until is when the bench ends. If zero, the node is benched until this
+	// node restarts.
+	Until time.Time `json:"until"`
+}
+
+// ParseStaticBenches parses a bench file of the form:
+//
+//	{
+//	  "benches": [
+//	    {
+//	      "nodeID": "NodeID-...",
+//	      "chainIDs": ["..."],
+//	      "reason": "...",
+//	      "until": "2023-01-01T00:00:00Z"
+//	    }
+//	  ]
+//	}
+func ParseStaticBenches(benchesBytes []byte) ([]StaticBench, error) {
+	var parsed struct {
+		Benches []StaticBench `json:"benches"`
+	}
+	if err := json.Unmarshal(benchesBytes, &parsed); err != nil {
+		return nil, err
+	}
+	return parsed.Benches, nil
+}
+
+// VerifyStaticBenches verifies that [benches] are well formed.
+func VerifyStaticBenches(benches []StaticBench) error {
+	nodeIDs := set.NewSet[ids.NodeID](len(benches))
+	for _, bench := range benches {
+		if bench.NodeID == ids.EmptyNodeID {
+			return errMissingNodeID
+		}
+		if nodeIDs.Contains(bench.NodeID) {
+			return fmt.Errorf("%w: %s", errDuplicateNodeID, bench.NodeID)
+		}
+		nodeIDs.Add(bench.NodeID)
+
+		chainIDs := set.NewSet[ids.ID](len(bench.ChainIDs))
+		for _, chainID := range bench.ChainIDs {
+			if chainIDs.Contains(chainID) {
+				return fmt.Errorf("%w: %s for %s", errDuplicateChainID, chainID, bench.NodeID)
+			}
+			chainIDs.Add(chainID)
+		}
+	}
+	return nil
+}
+
+// appliesTo returns true if the bench applies to [chainID].
+func (s *StaticBench) appliesTo(chainID ids.ID) bool {
+	if len(s.ChainIDs) == 0 {
+		return true
+	}
+	for _, benchedChainID := range s.ChainIDs {
+		if benchedChainID == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+// BenchedPeer describes a node that is benched on a chain.
+type BenchedPeer struct {
+	NodeID  ids.NodeID `json:"nodeID"`
+	ChainID ids.ID     `json:"chainID"`
+	Reason  string     `json:"reason"`
+	// BenchedUntil is when the bench ends. It is zero for retiring nodes,
+	// which are benched until they reconnect.
+	BenchedUntil time.Time `json:"benchedUntil"`
+	// Failures is the number of consecutive query failures that caused the
+	// node to be benched.
+	Failures int `json:"failures"`
+}
+
+func (p BenchedPeer) Less(other BenchedPeer) bool {
+	if p.ChainID != other.ChainID {
+		return p.ChainID.Less(other.ChainID)
+	}
+	return p.NodeID.Less(other.NodeID)
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchlist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestParseStaticBenches(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+	benchesBytes := []byte(`{
+		"benches": [
+			{
+				"nodeID": "` + nodeID.String() + `",
+				"chainIDs": ["` + chainID.String() + `"],
+				"reason": "spam",
+				"until": "2030-01-01T00:00:00Z"
+			}
+		]
+	}`)
+
+	benches, err := ParseStaticBenches(benchesBytes)
+	require.NoError(err)
+	require.Equal([]StaticBench{
+		{
+			NodeID:   nodeID,
+			ChainIDs: []ids.ID{chainID},
+			Reason:   "spam",
+			Until:    time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, benches)
+	require.NoError(VerifyStaticBenches(benches))
+}
+
+func TestVerifyStaticBenches(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+	tests := []struct {
+		name        string
+		benches     []StaticBench
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			benches: []StaticBench{
+				{NodeID: nodeID},
+				{NodeID: ids.GenerateTestNodeID(), ChainIDs: []ids.ID{chainID}},
+			},
+		},
+		{
+			name:        "missing nodeID",
+			benches:     []StaticBench{{}},
+			expectedErr: errMissingNodeID,
+		},
+		{
+			name: "duplicate nodeID",
+			benches: []StaticBench{
+				{NodeID: nodeID},
+				{NodeID: nodeID},
+			},
+			expectedErr: errDuplicateNodeID,
+		},
+		{
+			name: "duplicate chainID",
+			benches: []StaticBench{
+				{NodeID: nodeID, ChainIDs: []ids.ID{chainID, chainID}},
+			},
+			expectedErr: errDuplicateChainID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyStaticBenches(test.benches)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
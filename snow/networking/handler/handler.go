@@ -30,13 +30,16 @@ import (
 	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 
 	commontracker "github.com/ava-labs/avalanchego/snow/engine/common/tracker"
 )
 
 const (
-	numDispatchersToClose = 3
+	// The sync and chan dispatchers, in addition to one dispatcher per async
+	// message queue.
+	numNonAsyncDispatchers = 2
 	// If a consensus message takes longer than this to process, the handler
 	// will log a warning.
 	syncProcessingTimeWarnLimit = 30 * time.Second
@@ -45,8 +48,9 @@ const (
 var (
 	_ Handler = (*handler)(nil)
 
-	errMissingEngine  = errors.New("missing engine")
-	errNoStartingGear = errors.New("failed to select starting gear")
+	errMissingEngine         = errors.New("missing engine")
+	errNoStartingGear        = errors.New("failed to select starting gear")
+	errInvalidAppConcurrency = errors.New("invalid app concurrency")
 )
 
 type Handler interface {
@@ -58,6 +62,10 @@ type Handler interface {
 	// messages to this chain. If the node is not allowed to send messages to
 	// this chain, the message should be dropped.
 	ShouldHandle(nodeID ids.NodeID) bool
+	// ShouldHandleAppRequest returns true if [request] from the node with the
+	// given ID satisfies the AppRequest policy of this chain and doesn't replay
+	// a puzzle solution. If it doesn't, the request should be dropped.
+	ShouldHandleAppRequest(nodeID ids.NodeID, request *p2p.AppRequest) bool
 
 	SetEngineManager(engineManager *EngineManager)
 	GetEngineManager() *EngineManager
@@ -66,6 +74,9 @@ type Handler interface {
 	Start(ctx context.Context, recoverPanic bool)
 	Push(ctx context.Context, msg Message)
 	Len() int
+	// Progress reports the progress of this handler without grabbing the
+	// context lock, so that it can be inspected while the chain is stalled.
+	Progress() Progress
 
 	Stop(ctx context.Context)
 	StopWithError(ctx context.Context, err error)
@@ -88,6 +99,9 @@ type handler struct {
 	// TODO: consider using peerTracker instead of validators
 	// since peerTracker is already tracking validators
 	validators validators.Set
+	// Rejects AppRequests that don't satisfy the AppRequest policy of this
+	// chain or that replay a puzzle solution
+	appRequestFilter snow.AppRequestFilter
 	// Receives messages from the VM
 	msgFromVMChan   <-chan common.Message
 	preemptTimeouts chan struct{}
@@ -105,42 +119,68 @@ type handler struct {
 	// Holds messages that [engine] hasn't processed yet.
 	// [unprocessedMsgsCond.L] must be held while accessing [syncMessageQueue].
 	syncMessageQueue MessageQueue
-	// Holds messages that [engine] hasn't processed yet.
-	// [unprocessedAsyncMsgsCond.L] must be held while accessing [asyncMessageQueue].
-	asyncMessageQueue MessageQueue
-	// Worker pool for handling asynchronous consensus messages
-	asyncMessagePool errgroup.Group
-	timeouts         chan struct{}
-
-	closeOnce            sync.Once
-	startClosingTime     time.Time
-	totalClosingTime     time.Duration
-	closingChan          chan struct{}
-	numDispatchersClosed int
+	// Holds the asynchronous messages that [engine] hasn't processed yet. The
+	// ops that were given a dedicated concurrency limit have their own queue,
+	// the other ops share a queue.
+	asyncMessageQueues []*asyncMessageQueue
+	// Maps each asynchronous op to the queue holding its messages
+	asyncOpQueues map[message.Op]*asyncMessageQueue
+	timeouts      chan struct{}
+
+	closeOnce             sync.Once
+	startClosingTime      time.Time
+	totalClosingTime      time.Duration
+	closingChan           chan struct{}
+	numDispatchersToClose int
+	numDispatchersClosed  int
 	// Closed when this handler and [engine] are done shutting down
 	closed chan struct{}
 
 	subnetConnector validators.SubnetConnector
 
 	subnet subnets.Subnet
+	// Containers larger than [maxContainerSize] bytes are dropped
+	maxContainerSize int
 
 	// Tracks the peers that are currently connected to this subnet
 	peerTracker commontracker.Peers
+
+	// The sync message that is currently being handled
+	processing utils.Atomic[processingMsg]
+	// The last accepted ID reported by a peer in a Chits message. Only
+	// accessed by the sync dispatcher.
+	lastPeerAcceptedID ids.ID
+	// The time [lastPeerAcceptedID] last changed
+	peerAcceptedTime utils.Atomic[time.Time]
+}
+
+// asyncMessageQueue holds asynchronous messages that are handled by a bounded
+// pool of workers.
+type asyncMessageQueue struct {
+	MessageQueue
+	// Worker pool for handling the messages of this queue
+	pool errgroup.Group
 }
 
 // Initialize this consensus handler
 // [engine] must be initialized before initializing this handler
+//
+// Asynchronous messages are handled by a pool of [threadPoolSize] workers,
+// except for the ops in [appConcurrency], which are each handled by a
+// dedicated pool of the specified size.
 func New(
 	ctx *snow.ConsensusContext,
 	validators validators.Set,
 	msgFromVMChan <-chan common.Message,
 	gossipFrequency time.Duration,
 	threadPoolSize int,
+	appConcurrency map[message.Op]int,
 	resourceTracker tracker.ResourceTracker,
 	subnetConnector validators.SubnetConnector,
 	subnet subnets.Subnet,
 	peerTracker commontracker.Peers,
 ) (Handler, error) {
+	subnetConfig := subnet.Config()
 	h := &handler{
 		ctx:             ctx,
 		validators:      validators,
@@ -154,8 +194,9 @@ func New(
 		subnetConnector: subnetConnector,
 		subnet:          subnet,
 		peerTracker:     peerTracker,
+
+		maxContainerSize: subnetConfig.MaxContainerSize(),
 	}
-	h.asyncMessagePool.SetLimit(threadPoolSize)
 
 	var err error
 
@@ -168,13 +209,85 @@ func New(
 	if err != nil {
 		return nil, fmt.Errorf("initializing sync message queue errored with: %w", err)
 	}
-	h.asyncMessageQueue, err = NewMessageQueue(h.ctx.Log, h.validators, cpuTracker, "handler_async", h.ctx.Registerer, message.AsynchronousOps)
-	if err != nil {
-		return nil, fmt.Errorf("initializing async message queue errored with: %w", err)
+	if err := h.initAsyncMessageQueues(cpuTracker, threadPoolSize, appConcurrency); err != nil {
+		return nil, fmt.Errorf("initializing async message queues errored with: %w", err)
 	}
+	h.numDispatchersToClose = numNonAsyncDispatchers + len(h.asyncMessageQueues)
 	return h, nil
 }
 
+func (h *handler) initAsyncMessageQueues(
+	cpuTracker tracker.Tracker,
+	threadPoolSize int,
+	appConcurrency map[message.Op]int,
+) error {
+	asyncOps := set.Of(message.AsynchronousOps...)
+	for op, limit := range appConcurrency {
+		if !asyncOps.Contains(op) {
+			return fmt.Errorf("%w: %s isn't an app message op", errInvalidAppConcurrency, op)
+		}
+		if limit <= 0 {
+			return fmt.Errorf("%w: %s limit %d <= 0", errInvalidAppConcurrency, op, limit)
+		}
+	}
+
+	sharedOps := make([]message.Op, 0, len(message.AsynchronousOps))
+	for _, op := range message.AsynchronousOps {
+		if _, ok := appConcurrency[op]; !ok {
+			sharedOps = append(sharedOps, op)
+		}
+	}
+
+	h.asyncOpQueues = make(map[message.Op]*asyncMessageQueue, len(message.AsynchronousOps))
+	if len(sharedOps) > 0 {
+		queue, err := h.newAsyncMessageQueue(cpuTracker, "handler_async", threadPoolSize, sharedOps)
+		if err != nil {
+			return err
+		}
+		for _, op := range sharedOps {
+			h.asyncOpQueues[op] = queue
+		}
+	}
+
+	for _, op := range message.AsynchronousOps {
+		limit, ok := appConcurrency[op]
+		if !ok {
+			continue
+		}
+
+		queue, err := h.newAsyncMessageQueue(
+			cpuTracker,
+			fmt.Sprintf("handler_async_%s", op),
+			limit,
+			[]message.Op{op},
+		)
+		if err != nil {
+			return err
+		}
+		h.asyncOpQueues[op] = queue
+	}
+	return nil
+}
+
+func (h *handler) newAsyncMessageQueue(
+	cpuTracker tracker.Tracker,
+	metricsNamespace string,
+	threadPoolSize int,
+	ops []message.Op,
+) (*asyncMessageQueue, error) {
+	queue, err := NewMessageQueue(h.ctx.Log, h.validators, cpuTracker, metricsNamespace, h.ctx.Registerer, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	asyncQueue := &asyncMessageQueue{
+		MessageQueue: queue,
+	}
+	asyncQueue.pool.SetLimit(threadPoolSize)
+	h.asyncMessageQueues = append(h.asyncMessageQueues, asyncQueue)
+	return asyncQueue, nil
+}
+
 func (h *handler) Context() *snow.ConsensusContext {
 	return h.ctx
 }
@@ -183,6 +296,25 @@ func (h *handler) ShouldHandle(nodeID ids.NodeID) bool {
 	return h.subnet.IsAllowed(nodeID, h.validators.Contains(nodeID))
 }
 
+func (h *handler) ShouldHandleAppRequest(nodeID ids.NodeID, request *p2p.AppRequest) bool {
+	// This node doesn't need to prove anything to itself.
+	if nodeID == h.ctx.NodeID {
+		return true
+	}
+
+	return h.appRequestFilter.Allows(
+		h.ctx.AppRequestPolicy.Get(),
+		h.ctx.ChainID,
+		nodeID,
+		h.ctx.NodeID,
+		h.validators.GetWeight(nodeID),
+		request.RequestId,
+		request.AppBytes,
+		request.PuzzleNonce,
+		h.clock.Time(),
+	)
+}
+
 func (h *handler) SetEngineManager(engineManager *EngineManager) {
 	h.engineManager = engineManager
 }
@@ -243,9 +375,6 @@ func (h *handler) Start(ctx context.Context, recoverPanic bool) {
 	dispatchSync := func() {
 		h.dispatchSync(detachedCtx)
 	}
-	dispatchAsync := func() {
-		h.dispatchAsync(detachedCtx)
-	}
 	dispatchChans := func() {
 		h.dispatchChans(detachedCtx)
 	}
@@ -253,32 +382,44 @@ func (h *handler) Start(ctx context.Context, recoverPanic bool) {
 		go h.ctx.Log.RecoverAndExit(dispatchSync, func() {
 			h.ctx.Log.Error("chain was shutdown due to a panic in the sync dispatcher")
 		})
-		go h.ctx.Log.RecoverAndExit(dispatchAsync, func() {
-			h.ctx.Log.Error("chain was shutdown due to a panic in the async dispatcher")
-		})
+		for _, queue := range h.asyncMessageQueues {
+			queue := queue
+			go h.ctx.Log.RecoverAndExit(func() {
+				h.dispatchAsync(detachedCtx, queue)
+			}, func() {
+				h.ctx.Log.Error("chain was shutdown due to a panic in the async dispatcher")
+			})
+		}
 		go h.ctx.Log.RecoverAndExit(dispatchChans, func() {
 			h.ctx.Log.Error("chain was shutdown due to a panic in the chan dispatcher")
 		})
 	} else {
 		go h.ctx.Log.RecoverAndPanic(dispatchSync)
-		go h.ctx.Log.RecoverAndPanic(dispatchAsync)
+		for _, queue := range h.asyncMessageQueues {
+			queue := queue
+			go h.ctx.Log.RecoverAndPanic(func() {
+				h.dispatchAsync(detachedCtx, queue)
+			})
+		}
 		go h.ctx.Log.RecoverAndPanic(dispatchChans)
 	}
 }
 
 // Push the message onto the handler's queue
 func (h *handler) Push(ctx context.Context, msg Message) {
-	switch msg.Op() {
-	case message.AppRequestOp, message.AppRequestFailedOp, message.AppResponseOp, message.AppGossipOp,
-		message.CrossChainAppRequestOp, message.CrossChainAppRequestFailedOp, message.CrossChainAppResponseOp:
-		h.asyncMessageQueue.Push(ctx, msg)
-	default:
-		h.syncMessageQueue.Push(ctx, msg)
+	if queue, ok := h.asyncOpQueues[msg.Op()]; ok {
+		queue.Push(ctx, msg)
+		return
 	}
+	h.syncMessageQueue.Push(ctx, msg)
 }
 
 func (h *handler) Len() int {
-	return h.syncMessageQueue.Len() + h.asyncMessageQueue.Len()
+	l := h.syncMessageQueue.Len()
+	for _, queue := range h.asyncMessageQueues {
+		l += queue.Len()
+	}
+	return l
 }
 
 func (h *handler) RegisterTimeout(d time.Duration) {
@@ -311,7 +452,9 @@ func (h *handler) Stop(ctx context.Context) {
 		// Must hold the locks here to ensure there's no race condition in where
 		// we check the value of [h.closing] after the call to [Signal].
 		h.syncMessageQueue.Shutdown()
-		h.asyncMessageQueue.Shutdown()
+		for _, queue := range h.asyncMessageQueues {
+			queue.Shutdown()
+		}
 		close(h.closingChan)
 
 		// TODO: switch this to use a [context.Context] with a cancel function.
@@ -382,11 +525,11 @@ func (h *handler) dispatchSync(ctx context.Context) {
 	}
 }
 
-func (h *handler) dispatchAsync(ctx context.Context) {
+func (h *handler) dispatchAsync(ctx context.Context, queue *asyncMessageQueue) {
 	defer func() {
 		// We never return an error in any of our functions, so it is safe to
 		// drop any error here.
-		_ = h.asyncMessagePool.Wait()
+		_ = queue.pool.Wait()
 		h.closeDispatcher(ctx)
 	}()
 
@@ -394,12 +537,12 @@ func (h *handler) dispatchAsync(ctx context.Context) {
 	for {
 		// Get the next message we should process. If the handler is shutting
 		// down, we may fail to pop a message.
-		ctx, msg, ok := h.popUnexpiredMsg(h.asyncMessageQueue, h.metrics.asyncExpired)
+		ctx, msg, ok := h.popUnexpiredMsg(queue, h.metrics.asyncExpired)
 		if !ok {
 			return
 		}
 
-		h.handleAsyncMsg(ctx, msg)
+		h.handleAsyncMsg(ctx, &queue.pool, msg)
 	}
 }
 
@@ -462,10 +605,15 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 		)
 	}
 	h.resourceTracker.StartProcessing(nodeID, startTime)
+	h.processing.Set(processingMsg{
+		op:    op,
+		since: startTime,
+	})
 	h.ctx.Lock.Lock()
 	lockAcquiredTime := h.clock.Time()
 	defer func() {
 		h.ctx.Lock.Unlock()
+		h.processing.Set(processingMsg{})
 
 		var (
 			endTime           = h.clock.Time()
@@ -667,7 +815,17 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 		return engine.GetAncestorsFailed(ctx, nodeID, msg.RequestID)
 
 	case *p2p.Ancestors:
-		return engine.Ancestors(ctx, nodeID, msg.RequestId, msg.Containers)
+		// Containers are ordered from the requested container to its oldest
+		// ancestor, so only the containers before the first oversized
+		// container can be processed.
+		containers := msg.Containers
+		for i, container := range containers {
+			if h.isContainerTooLarge(nodeID, message.AncestorsOp, msg.RequestId, container) {
+				containers = containers[:i]
+				break
+			}
+		}
+		return engine.Ancestors(ctx, nodeID, msg.RequestId, containers)
 
 	case *p2p.Get:
 		containerID, err := ids.ToID(msg.ContainerId)
@@ -688,9 +846,15 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 		return engine.GetFailed(ctx, nodeID, msg.RequestID)
 
 	case *p2p.Put:
+		if h.isContainerTooLarge(nodeID, message.PutOp, msg.RequestId, msg.Container) {
+			return engine.GetFailed(ctx, nodeID, msg.RequestId)
+		}
 		return engine.Put(ctx, nodeID, msg.RequestId, msg.Container)
 
 	case *p2p.PushQuery:
+		if h.isContainerTooLarge(nodeID, message.PushQueryOp, msg.RequestId, msg.Container) {
+			return nil
+		}
 		return engine.PushQuery(ctx, nodeID, msg.RequestId, msg.Container)
 
 	case *p2p.PullQuery:
@@ -733,6 +897,23 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 			return engine.QueryFailed(ctx, nodeID, msg.RequestId)
 		}
 
+		if acceptedID != h.lastPeerAcceptedID {
+			h.lastPeerAcceptedID = acceptedID
+			h.peerAcceptedTime.Set(h.clock.Time())
+		}
+
+		// Finality signatures aren't needed for consensus, so the chits are
+		// handled even if the signature is invalid.
+		if h.ctx.Finality != nil && len(msg.AcceptedSignature) != 0 {
+			err := h.ctx.Finality.AddSignature(ctx, nodeID, acceptedID, msg.AcceptedSignature)
+			if err != nil {
+				h.ctx.Log.Debug("dropping finality signature",
+					zap.Stringer("nodeID", nodeID),
+					zap.Stringer("acceptedID", acceptedID),
+					zap.Error(err),
+				)
+			}
+		}
 		return engine.Chits(ctx, nodeID, msg.RequestId, preferredID, acceptedID)
 
 	case *message.QueryFailed:
@@ -764,8 +945,30 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 	}
 }
 
-func (h *handler) handleAsyncMsg(ctx context.Context, msg Message) {
-	h.asyncMessagePool.Go(func() error {
+// isContainerTooLarge returns true, and logs that the message is being dropped,
+// if [container] exceeds the max block size of the Subnet.
+func (h *handler) isContainerTooLarge(
+	nodeID ids.NodeID,
+	op message.Op,
+	requestID uint32,
+	container []byte,
+) bool {
+	if len(container) <= h.maxContainerSize {
+		return false
+	}
+
+	h.ctx.Log.Debug("dropping message with oversized container",
+		zap.Stringer("nodeID", nodeID),
+		zap.Stringer("messageOp", op),
+		zap.Uint32("requestID", requestID),
+		zap.Int("containerSize", len(container)),
+		zap.Int("maxContainerSize", h.maxContainerSize),
+	)
+	return true
+}
+
+func (h *handler) handleAsyncMsg(ctx context.Context, pool *errgroup.Group, msg Message) {
+	pool.Go(func() error {
 		if err := h.executeAsyncMsg(ctx, msg); err != nil {
 			h.StopWithError(ctx, fmt.Errorf(
 				"%w while processing async message: %s",
@@ -989,7 +1192,7 @@ func (h *handler) closeDispatcher(ctx context.Context) {
 	defer h.ctx.Lock.Unlock()
 
 	h.numDispatchersClosed++
-	if h.numDispatchersClosed < numDispatchersToClose {
+	if h.numDispatchersClosed < h.numDispatchersToClose {
 		return
 	}
 
@@ -25,6 +25,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/utils/math/meter"
+	"github.com/ava-labs/avalanchego/utils/puzzle"
 	"github.com/ava-labs/avalanchego/utils/resource"
 
 	commontracker "github.com/ava-labs/avalanchego/snow/engine/common/tracker"
@@ -58,6 +59,7 @@ func TestHandlerDropsTimedOutMessages(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -155,6 +157,7 @@ func TestHandlerClosesOnError(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -248,6 +251,7 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 		nil,
 		1,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -329,6 +333,7 @@ func TestHandlerDispatchInternal(t *testing.T) {
 		msgFromVMChan,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -380,6 +385,100 @@ func TestHandlerDispatchInternal(t *testing.T) {
 	wg.Wait()
 }
 
+func TestHandlerDropsOversizedContainers(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+	require.NoError(vdrs.Add(ids.GenerateTestNodeID(), nil, ids.Empty, 1))
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+	handler, err := New(
+		ctx,
+		vdrs,
+		nil,
+		time.Second,
+		testThreadPoolSize,
+		nil,
+		resourceTracker,
+		validators.UnhandledSubnetConnector,
+		subnets.New(ctx.NodeID, subnets.Config{
+			Limits: snow.Limits{
+				MaxBlockSize: 4,
+			},
+		}),
+		commontracker.NewPeers(),
+	)
+	require.NoError(err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+
+	called := make(chan []byte, 1)
+	engine.PushQueryF = func(_ context.Context, _ ids.NodeID, _ uint32, container []byte) error {
+		called <- container
+		return nil
+	}
+
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    engine,
+		},
+	})
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.NormalOp, // assumed bootstrap is done
+	})
+
+	// Messages are handled in order, so once the second query is handled the
+	// first one must have been dropped.
+	for _, container := range [][]byte{{1, 2, 3, 4, 5}, {1, 2, 3, 4}} {
+		handler.Push(context.Background(), Message{
+			InboundMessage: message.InboundPushQuery(
+				ids.Empty,
+				1,
+				time.Second,
+				container,
+				ids.EmptyNodeID,
+				p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+			),
+			EngineType: p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		})
+	}
+
+	handler.Start(context.Background(), false)
+
+	select {
+	case container := <-called:
+		require.Equal([]byte{1, 2, 3, 4}, container)
+	case <-time.After(time.Second):
+		require.FailNow("Calling engine function timed out")
+	}
+}
+
 func TestHandlerSubnetConnector(t *testing.T) {
 	require := require.New(t)
 
@@ -406,6 +505,7 @@ func TestHandlerSubnetConnector(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		connector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -578,6 +678,7 @@ func TestDynamicEngineTypeDispatch(t *testing.T) {
 				nil,
 				time.Second,
 				testThreadPoolSize,
+				nil,
 				resourceTracker,
 				validators.UnhandledSubnetConnector,
 				subnets.New(ids.EmptyNodeID, subnets.Config{}),
@@ -623,6 +724,7 @@ func TestDynamicEngineTypeDispatch(t *testing.T) {
 					uint32(0),
 					ids.Empty,
 					ids.Empty,
+					nil,
 					ids.EmptyNodeID,
 				),
 				EngineType: test.requestedEngineType,
@@ -632,3 +734,349 @@ func TestDynamicEngineTypeDispatch(t *testing.T) {
 		})
 	}
 }
+
+// Tests that App messages with a dedicated concurrency limit aren't delayed by
+// the App messages handled by the shared worker pool
+func TestHandlerDedicatedAppConcurrency(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+	require.NoError(vdrs.Add(ids.GenerateTestNodeID(), nil, ids.Empty, 1))
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+
+	handler, err := New(
+		ctx,
+		vdrs,
+		nil,
+		time.Second,
+		1,
+		map[message.Op]int{
+			message.AppResponseOp: 1,
+		},
+		resourceTracker,
+		validators.UnhandledSubnetConnector,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+		commontracker.NewPeers(),
+	)
+	require.NoError(err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+
+	var (
+		requested = make(chan struct{})
+		release   = make(chan struct{})
+		responded = make(chan struct{})
+	)
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	engine.AppRequestF = func(context.Context, ids.NodeID, uint32, time.Time, []byte) error {
+		close(requested)
+		<-release
+		return nil
+	}
+	engine.AppResponseF = func(context.Context, ids.NodeID, uint32, []byte) error {
+		close(responded)
+		return nil
+	}
+
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    engine,
+		},
+	})
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.NormalOp, // assumed bootstrap is done
+	})
+
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+
+	handler.Start(context.Background(), false)
+	defer func() {
+		close(release)
+		handler.Stop(context.Background())
+		_, err := handler.AwaitStopped(context.Background())
+		require.NoError(err)
+	}()
+
+	nodeID := ids.GenerateTestNodeID()
+	handler.Push(context.Background(), Message{
+		InboundMessage: message.InboundAppRequest(ids.Empty, 1, time.Hour, nil, nodeID),
+		EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+	})
+	<-requested
+
+	// The shared worker pool is blocked on the AppRequest, so the AppResponse
+	// can only be handled by its dedicated worker pool.
+	handler.Push(context.Background(), Message{
+		InboundMessage: message.InboundAppResponse(ids.Empty, 2, nil, nodeID),
+		EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+	})
+	select {
+	case <-responded:
+	case <-time.After(time.Second):
+		require.FailNow("AppResponse was delayed by the AppRequest")
+	}
+}
+
+func TestHandlerInvalidAppConcurrency(t *testing.T) {
+	tests := []struct {
+		name           string
+		appConcurrency map[message.Op]int
+	}{
+		{
+			name: "consensus op",
+			appConcurrency: map[message.Op]int{
+				message.ChitsOp: 1,
+			},
+		},
+		{
+			name: "non-positive limit",
+			appConcurrency: map[message.Op]int{
+				message.AppGossipOp: 0,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := snow.DefaultConsensusContextTest()
+			resourceTracker, err := tracker.NewResourceTracker(
+				prometheus.NewRegistry(),
+				resource.NoUsage,
+				meter.ContinuousFactory{},
+				time.Second,
+			)
+			require.NoError(err)
+
+			_, err = New(
+				ctx,
+				validators.NewSet(),
+				nil,
+				time.Second,
+				testThreadPoolSize,
+				test.appConcurrency,
+				resourceTracker,
+				validators.UnhandledSubnetConnector,
+				subnets.New(ctx.NodeID, subnets.Config{}),
+				commontracker.NewPeers(),
+			)
+			require.ErrorIs(err, errInvalidAppConcurrency)
+		})
+	}
+}
+
+func TestHandlerShouldHandleAppRequest(t *testing.T) {
+	var (
+		validatorID    = ids.NodeID{1}
+		nonValidatorID = ids.NodeID{2}
+		appBytes       = []byte("request")
+		now            = time.Unix(1_000_000, 0)
+		epoch          = snow.AppRequestEpoch(now)
+	)
+	solve := func(t *testing.T, sender ids.NodeID, receiver ids.NodeID, epoch uint64, requestID uint32) uint64 {
+		challenge := snow.AppRequestChallenge(ids.Empty, sender, receiver, epoch, requestID, appBytes)
+		nonce, err := puzzle.Solve(context.Background(), challenge, 16)
+		require.NoError(t, err)
+		return nonce
+	}
+	puzzlePolicy := snow.AppRequestPolicy{
+		MinValidatorWeight: 10,
+		PuzzleDifficulty:   16,
+	}
+
+	tests := []struct {
+		name     string
+		policy   snow.AppRequestPolicy
+		nodeID   ids.NodeID
+		nonce    func(t *testing.T) uint64
+		expected bool
+		// If true, the request is handled a second time, which must fail
+		replay bool
+	}{
+		{
+			name:     "no policy",
+			nodeID:   nonValidatorID,
+			expected: true,
+		},
+		{
+			name: "enough weight",
+			policy: snow.AppRequestPolicy{
+				MinValidatorWeight: 10,
+			},
+			nodeID:   validatorID,
+			expected: true,
+		},
+		{
+			name: "not enough weight",
+			policy: snow.AppRequestPolicy{
+				MinValidatorWeight: 11,
+			},
+			nodeID:   validatorID,
+			expected: false,
+		},
+		{
+			name: "self",
+			policy: snow.AppRequestPolicy{
+				MinValidatorWeight: 11,
+			},
+			nodeID:   ids.EmptyNodeID,
+			expected: true,
+		},
+		{
+			name:     "missing puzzle solution",
+			policy:   puzzlePolicy,
+			nodeID:   nonValidatorID,
+			expected: false,
+		},
+		{
+			name:   "puzzle solved",
+			policy: puzzlePolicy,
+			nodeID: nonValidatorID,
+			nonce: func(t *testing.T) uint64 {
+				return solve(t, nonValidatorID, ids.EmptyNodeID, epoch, 1)
+			},
+			expected: true,
+		},
+		{
+			name:   "puzzle solved in previous epoch",
+			policy: puzzlePolicy,
+			nodeID: nonValidatorID,
+			nonce: func(t *testing.T) uint64 {
+				return solve(t, nonValidatorID, ids.EmptyNodeID, epoch-1, 1)
+			},
+			expected: true,
+		},
+		{
+			name:   "puzzle solved in next epoch",
+			policy: puzzlePolicy,
+			nodeID: nonValidatorID,
+			nonce: func(t *testing.T) uint64 {
+				return solve(t, nonValidatorID, ids.EmptyNodeID, epoch+1, 1)
+			},
+			expected: true,
+		},
+		{
+			name:   "puzzle solved in expired epoch",
+			policy: puzzlePolicy,
+			nodeID: nonValidatorID,
+			nonce: func(t *testing.T) uint64 {
+				return solve(t, nonValidatorID, ids.EmptyNodeID, epoch-2, 1)
+			},
+			expected: false,
+		},
+		{
+			name:   "puzzle solution replayed",
+			policy: puzzlePolicy,
+			nodeID: nonValidatorID,
+			nonce: func(t *testing.T) uint64 {
+				return solve(t, nonValidatorID, ids.EmptyNodeID, epoch, 1)
+			},
+			expected: true,
+			replay:   true,
+		},
+		{
+			name:   "puzzle solved for another request",
+			policy: puzzlePolicy,
+			nodeID: nonValidatorID,
+			nonce: func(t *testing.T) uint64 {
+				return solve(t, nonValidatorID, ids.EmptyNodeID, epoch, 2)
+			},
+			expected: false,
+		},
+		{
+			name: "puzzle solved by another node",
+			policy: snow.AppRequestPolicy{
+				MinValidatorWeight: 20,
+				PuzzleDifficulty:   16,
+			},
+			nodeID: nonValidatorID,
+			nonce: func(t *testing.T) uint64 {
+				return solve(t, validatorID, ids.EmptyNodeID, epoch, 1)
+			},
+			expected: false,
+		},
+		{
+			name:   "puzzle solved for another receiver",
+			policy: puzzlePolicy,
+			nodeID: nonValidatorID,
+			nonce: func(t *testing.T) uint64 {
+				return solve(t, nonValidatorID, validatorID, epoch, 1)
+			},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := snow.DefaultConsensusContextTest()
+			ctx.AppRequestPolicy.Set(test.policy)
+
+			vdrs := validators.NewSet()
+			require.NoError(vdrs.Add(validatorID, nil, ids.Empty, 10))
+
+			resourceTracker, err := tracker.NewResourceTracker(
+				prometheus.NewRegistry(),
+				resource.NoUsage,
+				meter.ContinuousFactory{},
+				time.Second,
+			)
+			require.NoError(err)
+
+			handlerIntf, err := New(
+				ctx,
+				vdrs,
+				nil,
+				time.Second,
+				testThreadPoolSize,
+				nil,
+				resourceTracker,
+				validators.UnhandledSubnetConnector,
+				subnets.New(ctx.NodeID, subnets.Config{}),
+				commontracker.NewPeers(),
+			)
+			require.NoError(err)
+			handler := handlerIntf.(*handler)
+			handler.clock.Set(now)
+
+			var nonce uint64
+			if test.nonce != nil {
+				nonce = test.nonce(t)
+			}
+			request := &p2p.AppRequest{
+				ChainId:     ids.Empty[:],
+				RequestId:   1,
+				AppBytes:    appBytes,
+				PuzzleNonce: nonce,
+			}
+			require.Equal(test.expected, handler.ShouldHandleAppRequest(test.nodeID, request))
+			if test.replay {
+				require.False(handler.ShouldHandleAppRequest(test.nodeID, request))
+			}
+		})
+	}
+}
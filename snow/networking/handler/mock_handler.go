@@ -13,6 +13,7 @@ import (
 	time "time"
 
 	ids "github.com/ava-labs/avalanchego/ids"
+	p2p "github.com/ava-labs/avalanchego/proto/pb/p2p"
 	snow "github.com/ava-labs/avalanchego/snow"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -112,6 +113,20 @@ func (mr *MockHandlerMockRecorder) Len() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Len", reflect.TypeOf((*MockHandler)(nil).Len))
 }
 
+// Progress mocks base method.
+func (m *MockHandler) Progress() Progress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Progress")
+	ret0, _ := ret[0].(Progress)
+	return ret0
+}
+
+// Progress indicates an expected call of Progress.
+func (mr *MockHandlerMockRecorder) Progress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockHandler)(nil).Progress))
+}
+
 // Push mocks base method.
 func (m *MockHandler) Push(arg0 context.Context, arg1 Message) {
 	m.ctrl.T.Helper()
@@ -174,6 +189,20 @@ func (mr *MockHandlerMockRecorder) ShouldHandle(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldHandle", reflect.TypeOf((*MockHandler)(nil).ShouldHandle), arg0)
 }
 
+// ShouldHandleAppRequest mocks base method.
+func (m *MockHandler) ShouldHandleAppRequest(arg0 ids.NodeID, arg1 *p2p.AppRequest) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShouldHandleAppRequest", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ShouldHandleAppRequest indicates an expected call of ShouldHandleAppRequest.
+func (mr *MockHandlerMockRecorder) ShouldHandleAppRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldHandleAppRequest", reflect.TypeOf((*MockHandler)(nil).ShouldHandleAppRequest), arg0, arg1)
+}
+
 // Start mocks base method.
 func (m *MockHandler) Start(arg0 context.Context, arg1 bool) {
 	m.ctrl.T.Helper()
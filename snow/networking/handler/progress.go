@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handler
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/message"
+)
+
+// Progress describes how far along a handler is in handling messages.
+type Progress struct {
+	// ProcessingOp is the op of the sync message currently being handled.
+	ProcessingOp message.Op
+	// ProcessingSince is when the handler started handling the sync message
+	// currently being handled, including the time spent waiting for the
+	// context lock. Zero if no sync message is being handled.
+	ProcessingSince time.Time
+	// PeerAcceptedTime is the last time a peer reported an accepted container
+	// that differs from the one previously reported. Zero if no peer has
+	// reported an accepted container.
+	PeerAcceptedTime time.Time
+}
+
+type processingMsg struct {
+	op    message.Op
+	since time.Time
+}
+
+func (h *handler) Progress() Progress {
+	processing := h.processing.Get()
+	return Progress{
+		ProcessingOp:     processing.op,
+		ProcessingSince:  processing.since,
+		PeerAcceptedTime: h.peerAcceptedTime.Get(),
+	}
+}
@@ -33,6 +33,8 @@ var (
 	errUnknownChain  = errors.New("received message for unknown chain")
 	errUnallowedNode = errors.New("received message from non-allowed node")
 
+	errUnallowedAppRequest = errors.New("received AppRequest that doesn't satisfy the AppRequest policy")
+
 	_ Router              = (*ChainRouter)(nil)
 	_ benchlist.Benchable = (*ChainRouter)(nil)
 )
@@ -44,6 +46,8 @@ type requestEntry struct {
 	op message.Op
 	// The engine type of the request that was made
 	engineType p2p.EngineType
+	// The message to deliver if the request fails
+	timeoutMsg message.InboundMessage
 }
 
 type peer struct {
@@ -85,6 +89,10 @@ type ChainRouter struct {
 	healthConfig HealthConfig
 	// aggregator of requests based on their time
 	timedRequests linkedhashmap.LinkedHashmap[ids.RequestID, requestEntry]
+	// chainID --> most recent requests routed to the chain since its handler
+	// stopped. The requests are re-delivered if the chain is added again.
+	// Only contains chains whose handler stopped.
+	replayBuffers map[ids.ID]*replayBuffer
 }
 
 // Initialize the router.
@@ -114,6 +122,7 @@ func (cr *ChainRouter) Initialize(
 	cr.sybilProtectionEnabled = sybilProtectionEnabled
 	cr.onFatal = onFatal
 	cr.timedRequests = linkedhashmap.New[ids.RequestID, requestEntry]()
+	cr.replayBuffers = make(map[ids.ID]*replayBuffer)
 	cr.peers = make(map[ids.NodeID]*peer)
 	cr.healthConfig = healthConfig
 
@@ -175,6 +184,7 @@ func (cr *ChainRouter) RegisterRequest(
 		time:       cr.clock.Time(),
 		op:         op,
 		engineType: engineType,
+		timeoutMsg: timeoutMsg,
 	})
 	cr.metrics.outstandingRequests.Set(float64(cr.timedRequests.Len()))
 	cr.lock.Unlock()
@@ -249,6 +259,10 @@ func (cr *ChainRouter) HandleInbound(ctx context.Context, msg message.InboundMes
 	// Get the chain, if it exists
 	chain, exists := cr.chainHandlers[destinationChainID]
 	if !exists {
+		if cr.bufferRequest(destinationChainID, msg) {
+			return
+		}
+
 		cr.log.Debug("dropping message",
 			zap.Stringer("messageOp", op),
 			zap.Stringer("nodeID", nodeID),
@@ -270,6 +284,17 @@ func (cr *ChainRouter) HandleInbound(ctx context.Context, msg message.InboundMes
 		return
 	}
 
+	if appRequest, ok := m.(*p2p.AppRequest); ok && !chain.ShouldHandleAppRequest(nodeID, appRequest) {
+		cr.log.Debug("dropping message",
+			zap.Stringer("messageOp", op),
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("chainID", destinationChainID),
+			zap.Error(errUnallowedAppRequest),
+		)
+		msg.OnFinishedHandling()
+		return
+	}
+
 	chainCtx := chain.Context()
 
 	// TODO: [requestID] can overflow, which means a timeout on the request
@@ -344,7 +369,7 @@ func (cr *ChainRouter) HandleInbound(ctx context.Context, msg message.InboundMes
 	latency := cr.clock.Time().Sub(req.time)
 
 	// Tell the timeout manager we got a response
-	cr.timeoutManager.RegisterResponse(nodeID, destinationChainID, uniqueRequestID, req.op, latency)
+	cr.timeoutManager.RegisterResponse(nodeID, destinationChainID, uniqueRequestID, req.op, latency, msg.NumBytes())
 
 	// Pass the response to the chain
 	chain.Push(
@@ -427,6 +452,8 @@ func (cr *ChainRouter) AddChain(ctx context.Context, chain handler.Handler) {
 		)
 	}
 
+	cr.replayRequests(ctx, chain)
+
 	// When we register the P-chain, we mark ourselves as connected on all of
 	// the subnets that we have tracked.
 	if chainID != constants.PlatformChainID {
@@ -648,8 +675,19 @@ func (cr *ChainRouter) removeChain(ctx context.Context, chainID ids.ID) {
 		return
 	}
 	delete(cr.chainHandlers, chainID)
+	cr.replayBuffers[chainID] = newReplayBuffer()
+	clearedRequests, failedRequests := cr.clearChainRequests(chainID)
 	cr.lock.Unlock()
 
+	for _, uniqueRequestID := range clearedRequests {
+		cr.timeoutManager.RemoveRequest(uniqueRequestID)
+	}
+	// Requests made to this chain by other chains will never be answered, so
+	// they are failed now rather than when they time out.
+	for _, timeoutMsg := range failedRequests {
+		cr.HandleInbound(ctx, timeoutMsg)
+	}
+
 	chain.Stop(ctx)
 
 	ctx, cancel := context.WithTimeout(ctx, cr.closeTimeout)
@@ -672,6 +710,109 @@ func (cr *ChainRouter) removeChain(ctx context.Context, chainID ids.ID) {
 	}
 }
 
+// bufferRequest buffers [msg] if it's a request to a chain whose handler
+// stopped, so that it can be replayed if the chain is restarted. Returns true
+// if [msg] was buffered.
+//
+// Assumes [cr.lock] is held.
+func (cr *ChainRouter) bufferRequest(chainID ids.ID, msg message.InboundMessage) bool {
+	replayBuffer, ok := cr.replayBuffers[chainID]
+	if !ok {
+		return false
+	}
+
+	// Gossip doesn't expect a response, so there is no need to replay it.
+	op := msg.Op()
+	if !message.UnrequestedOps.Contains(op) || op == message.AppGossipOp {
+		return false
+	}
+
+	// Note: engineType is not guaranteed to be one of the explicitly named
+	// enum values. If it was not specified it defaults to UNSPECIFIED.
+	engineType, _ := message.GetEngineType(msg.Message())
+	if !replayBuffer.push(handler.Message{
+		InboundMessage: &replayedMessage{
+			InboundMessage: msg,
+		},
+		EngineType: engineType,
+	}) {
+		return false
+	}
+
+	// The buffered request doesn't hold onto the resources of [msg].
+	msg.OnFinishedHandling()
+	return true
+}
+
+// replayRequests re-delivers the unexpired requests that were routed to
+// [chain]'s chain while its previous handler was stopped.
+//
+// Assumes [cr.lock] is held.
+func (cr *ChainRouter) replayRequests(ctx context.Context, chain handler.Handler) {
+	chainID := chain.Context().ChainID
+	replayBuffer, ok := cr.replayBuffers[chainID]
+	if !ok {
+		return
+	}
+	delete(cr.replayBuffers, chainID)
+
+	now := cr.clock.Time()
+	numReplayed := 0
+	for {
+		msg, ok := replayBuffer.pop()
+		if !ok {
+			break
+		}
+		if now.After(msg.Expiration()) || !chain.ShouldHandle(msg.NodeID()) {
+			continue
+		}
+		if appRequest, ok := msg.Message().(*p2p.AppRequest); ok && !chain.ShouldHandleAppRequest(msg.NodeID(), appRequest) {
+			continue
+		}
+
+		chain.Push(ctx, msg)
+		numReplayed++
+	}
+	cr.metrics.replayedRequests.Add(float64(numReplayed))
+
+	if numReplayed > 0 {
+		cr.log.Info("replayed requests to restarted chain",
+			zap.Stringer("chainID", chainID),
+			zap.Int("numRequests", numReplayed),
+		)
+	}
+}
+
+// clearChainRequests stops tracking the outstanding requests made by
+// [chainID], as they can't be handled once its handler has stopped. Returns
+// the IDs of the cleared requests and the failure messages of the outstanding
+// requests that other chains made to [chainID].
+//
+// Assumes [cr.lock] is held.
+func (cr *ChainRouter) clearChainRequests(chainID ids.ID) ([]ids.RequestID, []message.InboundMessage) {
+	var (
+		clearedRequests []ids.RequestID
+		failedRequests  []message.InboundMessage
+	)
+	it := cr.timedRequests.NewIterator()
+	for it.Next() {
+		uniqueRequestID := it.Key()
+		switch {
+		case uniqueRequestID.DestinationChainID == chainID:
+			clearedRequests = append(clearedRequests, uniqueRequestID)
+		case uniqueRequestID.SourceChainID == chainID && uniqueRequestID.NodeID == cr.myNodeID:
+			failedRequests = append(failedRequests, it.Value().timeoutMsg)
+		}
+	}
+
+	for _, uniqueRequestID := range clearedRequests {
+		cr.timedRequests.Delete(uniqueRequestID)
+	}
+	cr.metrics.outstandingRequests.Set(float64(cr.timedRequests.Len()))
+	cr.metrics.failedRequests.Add(float64(len(clearedRequests) + len(failedRequests)))
+	return clearedRequests, failedRequests
+}
+
 func (cr *ChainRouter) clearRequest(
 	op message.Op,
 	nodeID ids.NodeID,
@@ -14,6 +14,8 @@ type routerMetrics struct {
 	outstandingRequests   prometheus.Gauge
 	longestRunningRequest prometheus.Gauge
 	droppedRequests       prometheus.Counter
+	replayedRequests      prometheus.Counter
+	failedRequests        prometheus.Counter
 }
 
 func newRouterMetrics(namespace string, registerer prometheus.Registerer) (*routerMetrics, error) {
@@ -39,12 +41,28 @@ func newRouterMetrics(namespace string, registerer prometheus.Registerer) (*rout
 			Help:      "Number of dropped requests (all types)",
 		},
 	)
+	rMetrics.replayedRequests = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "replayed",
+			Help:      "Number of requests re-delivered to restarted chains (all types)",
+		},
+	)
+	rMetrics.failedRequests = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "failed_on_stop",
+			Help:      "Number of outstanding requests failed because a chain stopped (all types)",
+		},
+	)
 
 	errs := wrappers.Errs{}
 	errs.Add(
 		registerer.Register(rMetrics.outstandingRequests),
 		registerer.Register(rMetrics.longestRunningRequest),
 		registerer.Register(rMetrics.droppedRequests),
+		registerer.Register(rMetrics.replayedRequests),
+		registerer.Register(rMetrics.failedRequests),
 	)
 	return rMetrics, errs.Err
 }
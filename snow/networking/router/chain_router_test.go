@@ -95,6 +95,7 @@ func TestShutdown(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(chainCtx.NodeID, subnets.Config{}),
@@ -233,6 +234,7 @@ func TestShutdownTimesOut(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -388,6 +390,7 @@ func TestRouterTimeout(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -857,6 +860,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -1031,6 +1035,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 			requestID,
 			ids.Empty,
 			ids.Empty,
+			nil,
 			nodeID,
 		)
 		chainRouter.HandleInbound(context.Background(), msg)
@@ -1149,6 +1154,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		sb,
@@ -1301,6 +1307,7 @@ func TestRouterCrossChainMessages(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(requester.NodeID, subnets.Config{}),
@@ -1320,6 +1327,7 @@ func TestRouterCrossChainMessages(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(responder.NodeID, subnets.Config{}),
@@ -1568,6 +1576,7 @@ func TestValidatorOnlyAllowedNodeMessageDrops(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		sb,
@@ -1670,3 +1679,257 @@ func TestValidatorOnlyAllowedNodeMessageDrops(t *testing.T) {
 	wg.Wait()
 	require.True(calledF) // should be called since this is a validator request
 }
+
+func TestRouterReplaysRequestsAfterRestart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     3 * time.Second,
+			MinimumTimeout:     3 * time.Second,
+			MaximumTimeout:     5 * time.Minute,
+			TimeoutCoefficient: 1,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go tm.Dispatch()
+
+	chainRouter := ChainRouter{}
+	require.NoError(chainRouter.Initialize(
+		ids.EmptyNodeID,
+		logging.NoLog{},
+		tm,
+		time.Millisecond,
+		set.Set[ids.ID]{},
+		true,
+		set.Set[ids.ID]{},
+		nil,
+		HealthConfig{},
+		"",
+		prometheus.NewRegistry(),
+	))
+
+	ctx := snow.DefaultConsensusContextTest()
+
+	var onStopped func()
+	h := handler.NewMockHandler(ctrl)
+	h.EXPECT().Context().Return(ctx).AnyTimes()
+	h.EXPECT().SetOnStopped(gomock.Any()).Do(func(f func()) {
+		onStopped = f
+	})
+	chainRouter.AddChain(context.Background(), h)
+
+	nodeID := ids.GenerateTestNodeID()
+	query := message.InboundPullQuery(
+		ctx.ChainID,
+		1,
+		time.Hour,
+		ids.GenerateTestID(),
+		nodeID,
+		engineType,
+	)
+	// Requests are only buffered once the handler stopped.
+	h.EXPECT().ShouldHandle(nodeID).Return(true)
+	h.EXPECT().Push(gomock.Any(), gomock.Any())
+	chainRouter.HandleInbound(context.Background(), query)
+	require.Empty(chainRouter.replayBuffers)
+
+	// Stop the handler
+	h.EXPECT().Stop(gomock.Any())
+	h.EXPECT().AwaitStopped(gomock.Any())
+	onStopped()
+	require.NotContains(chainRouter.chainHandlers, ctx.ChainID)
+
+	expiredQuery := message.InboundPullQuery(
+		ctx.ChainID,
+		2,
+		time.Second,
+		ids.GenerateTestID(),
+		nodeID,
+		engineType,
+	)
+	chainRouter.HandleInbound(context.Background(), expiredQuery)
+	chainRouter.HandleInbound(context.Background(), query)
+	// Responses aren't replayed, as the request they answer was failed when
+	// the handler stopped.
+	response := message.InboundAppResponse(
+		ctx.ChainID,
+		3,
+		[]byte{1},
+		nodeID,
+	)
+	chainRouter.HandleInbound(context.Background(), response)
+	require.Equal(2, chainRouter.replayBuffers[ctx.ChainID].msgs.Len())
+
+	chainRouter.clock.Set(time.Now().Add(time.Minute))
+
+	// Only the unexpired request should be replayed to the restarted handler
+	var replayed []handler.Message
+	restarted := handler.NewMockHandler(ctrl)
+	restarted.EXPECT().Context().Return(ctx).AnyTimes()
+	restarted.EXPECT().SetOnStopped(gomock.Any()).Times(2)
+	restarted.EXPECT().ShouldHandle(nodeID).Return(true)
+	restarted.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		replayed = append(replayed, msg)
+	})
+	chainRouter.AddChain(context.Background(), restarted)
+
+	require.Len(replayed, 1)
+	require.Equal(message.PullQueryOp, replayed[0].Op())
+	require.Equal(engineType, replayed[0].EngineType)
+	require.Equal(query.Message(), replayed[0].Message())
+	require.IsType(&replayedMessage{}, replayed[0].InboundMessage)
+	require.Empty(chainRouter.replayBuffers)
+
+	// The requests should only be replayed once
+	restarted.EXPECT().Stop(gomock.Any())
+	restarted.EXPECT().AwaitStopped(gomock.Any())
+	chainRouter.removeChain(context.Background(), ctx.ChainID)
+	chainRouter.AddChain(context.Background(), restarted)
+	require.Len(replayed, 1)
+}
+
+// testInboundMessage only implements NumBytes
+type testInboundMessage struct {
+	message.InboundMessage
+	numBytes int
+}
+
+func (m *testInboundMessage) NumBytes() int {
+	return m.numBytes
+}
+
+func TestReplayBuffer(t *testing.T) {
+	require := require.New(t)
+
+	newMsg := func(numBytes int) handler.Message {
+		return handler.Message{
+			InboundMessage: &testInboundMessage{
+				numBytes: numBytes,
+			},
+		}
+	}
+
+	b := newReplayBuffer()
+	require.False(b.push(newMsg(maxReplayedBytes + 1)))
+
+	require.True(b.push(newMsg(maxReplayedBytes / 2)))
+	require.True(b.push(newMsg(maxReplayedBytes / 2)))
+	require.Equal(maxReplayedBytes, b.numBytes)
+
+	// The oldest request is evicted to make room for the new one.
+	require.True(b.push(newMsg(1)))
+	require.Equal(2, b.msgs.Len())
+	require.Equal(maxReplayedBytes/2+1, b.numBytes)
+
+	for i := 0; i < maxReplayedRequests; i++ {
+		require.True(b.push(newMsg(0)))
+	}
+	require.Equal(maxReplayedRequests, b.msgs.Len())
+	require.Zero(b.numBytes)
+}
+
+func TestRouterFailsRequestsWhenChainStops(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     3 * time.Second,
+			MinimumTimeout:     3 * time.Second,
+			MaximumTimeout:     5 * time.Minute,
+			TimeoutCoefficient: 1,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go tm.Dispatch()
+
+	nodeID := ids.GenerateTestNodeID()
+	chainRouter := ChainRouter{}
+	require.NoError(chainRouter.Initialize(
+		nodeID,
+		logging.NoLog{},
+		tm,
+		time.Millisecond,
+		set.Set[ids.ID]{},
+		true,
+		set.Set[ids.ID]{},
+		nil,
+		HealthConfig{},
+		"",
+		prometheus.NewRegistry(),
+	))
+
+	requester := snow.DefaultConsensusContextTest()
+	requester.ChainID = ids.GenerateTestID()
+	requesterHandler := handler.NewMockHandler(ctrl)
+	requesterHandler.EXPECT().Context().Return(requester).AnyTimes()
+	requesterHandler.EXPECT().SetOnStopped(gomock.Any())
+	requesterHandler.EXPECT().ShouldHandle(gomock.Any()).Return(true).AnyTimes()
+	requesterHandler.EXPECT().Push(gomock.Any(), gomock.Any()) // Connected
+	chainRouter.AddChain(context.Background(), requesterHandler)
+
+	responder := snow.DefaultConsensusContextTest()
+	responder.ChainID = ids.GenerateTestID()
+	responderHandler := handler.NewMockHandler(ctrl)
+	responderHandler.EXPECT().Context().Return(responder).AnyTimes()
+	responderHandler.EXPECT().SetOnStopped(gomock.Any())
+	responderHandler.EXPECT().Push(gomock.Any(), gomock.Any()) // Connected
+	chainRouter.AddChain(context.Background(), responderHandler)
+
+	// The requester sends a cross-chain request to the responder
+	chainRouter.RegisterRequest(
+		context.Background(),
+		nodeID,
+		requester.ChainID,
+		responder.ChainID,
+		1,
+		message.CrossChainAppResponseOp,
+		message.InternalCrossChainAppRequestFailed(
+			nodeID,
+			responder.ChainID,
+			requester.ChainID,
+			1,
+		),
+		p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+	)
+
+	// The responder sends a request to a peer
+	peerID := ids.GenerateTestNodeID()
+	chainRouter.RegisterRequest(
+		context.Background(),
+		peerID,
+		responder.ChainID,
+		responder.ChainID,
+		1,
+		message.ChitsOp,
+		message.InternalQueryFailed(
+			peerID,
+			responder.ChainID,
+			1,
+			engineType,
+		),
+		engineType,
+	)
+	require.Equal(2, chainRouter.timedRequests.Len())
+
+	// Stopping the responder should fail the cross-chain request immediately
+	// and stop tracking the responder's own request.
+	requesterHandler.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		require.Equal(message.CrossChainAppRequestFailedOp, msg.Op())
+	})
+	responderHandler.EXPECT().Stop(gomock.Any())
+	responderHandler.EXPECT().AwaitStopped(gomock.Any())
+	chainRouter.removeChain(context.Background(), responder.ChainID)
+
+	require.Zero(chainRouter.timedRequests.Len())
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/utils/buffer"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// maxReplayedRequests is the maximum number of requests that are kept per
+	// stopped chain so that they can be re-delivered if the chain's handler is
+	// restarted.
+	maxReplayedRequests = 256
+	// maxReplayedBytes is the maximum number of bytes of the requests that are
+	// kept per stopped chain.
+	maxReplayedBytes = 4 * units.MiB
+)
+
+var _ message.InboundMessage = (*replayedMessage)(nil)
+
+// replayedMessage is a request that was buffered while the chain's handler was
+// stopped. The resources held for the original message were released when it
+// was buffered, so finishing the handling of the replayed message is a noop.
+type replayedMessage struct {
+	message.InboundMessage
+}
+
+func (*replayedMessage) OnFinishedHandling() {}
+
+// replayBuffer holds the most recent requests routed to a stopped chain,
+// bounded by [maxReplayedRequests] and [maxReplayedBytes]. The oldest requests
+// are evicted first.
+type replayBuffer struct {
+	msgs     buffer.Deque[handler.Message]
+	numBytes int
+}
+
+func newReplayBuffer() *replayBuffer {
+	return &replayBuffer{
+		msgs: buffer.NewUnboundedDeque[handler.Message](0),
+	}
+}
+
+// push adds [msg] to the buffer, evicting older requests if needed. Returns
+// false if [msg] alone exceeds the byte limit.
+func (b *replayBuffer) push(msg handler.Message) bool {
+	msgBytes := msg.NumBytes()
+	if msgBytes > maxReplayedBytes {
+		return false
+	}
+	for b.msgs.Len() >= maxReplayedRequests || b.numBytes+msgBytes > maxReplayedBytes {
+		b.pop()
+	}
+	b.msgs.PushRight(msg)
+	b.numBytes += msgBytes
+	return true
+}
+
+func (b *replayBuffer) pop() (handler.Message, bool) {
+	msg, ok := b.msgs.PopLeft()
+	if ok {
+		b.numBytes -= msg.NumBytes()
+	}
+	return msg, ok
+}
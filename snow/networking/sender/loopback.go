@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sender
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// Loopback delivers the queries that a chain sends to the local node, and the
+// chits sent in response to them, directly to the chain's handler.
+//
+// Queries sent to the local node can't be lost in the network, so the router
+// doesn't need to track them or register timeouts for them. Instead, if the
+// handler finishes handling a query without the chits having been sent, the
+// query is immediately marked as failed.
+type Loopback struct {
+	ctx        *snow.ConsensusContext
+	engineType p2p.EngineType
+
+	// Useful for faking time in tests
+	clock mockable.Clock
+
+	queryLatency  metric.Averager
+	queriesFailed prometheus.Counter
+
+	lock    sync.Mutex
+	handler handler.Handler
+	// requestID -> time the query was sent, for the queries sent to the local
+	// node that haven't been responded to
+	pending map[uint32]time.Time
+}
+
+func NewLoopback(ctx *snow.ConsensusContext, engineType p2p.EngineType) (*Loopback, error) {
+	l := &Loopback{
+		ctx:        ctx,
+		engineType: engineType,
+		queriesFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "loopback_queries_failed",
+			Help: "# of queries sent to the local node that weren't responded to",
+		}),
+		pending: make(map[uint32]time.Time),
+	}
+
+	errs := wrappers.Errs{}
+	l.queryLatency = metric.NewAveragerWithErrs(
+		"",
+		"loopback_query",
+		"time (in ns) from sending a query to the local node until it responded",
+		ctx.Registerer,
+		&errs,
+	)
+	errs.Add(ctx.Registerer.Register(l.queriesFailed))
+	return l, errs.Err
+}
+
+// SetHandler sets the handler that messages are delivered to. Until the
+// handler is set, messages to the local node are routed through the router.
+func (l *Loopback) SetHandler(h handler.Handler) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.handler = h
+}
+
+// enabled returns true if messages can be delivered to the handler.
+func (l *Loopback) enabled() bool {
+	_, ok := l.getHandler()
+	return ok
+}
+
+func (l *Loopback) getHandler() (handler.Handler, bool) {
+	if l == nil {
+		return nil, false
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.handler, l.handler != nil
+}
+
+// sendQuery delivers a query that was sent to the local node.
+//
+// Invariant: [l] must be enabled.
+func (l *Loopback) sendQuery(ctx context.Context, requestID uint32, msg message.InboundMessage) {
+	h, _ := l.getHandler()
+
+	l.lock.Lock()
+	l.pending[requestID] = l.clock.Time()
+	l.lock.Unlock()
+
+	// The lock isn't held while pushing the query, as the query is marked as
+	// handled immediately if the handler is shutting down.
+	h.Push(ctx, handler.Message{
+		InboundMessage: &loopbackMessage{
+			InboundMessage: msg,
+			onFinishedHandling: func() {
+				l.queryHandled(ctx, h, requestID)
+			},
+		},
+		EngineType: l.engineType,
+	})
+}
+
+// sendChits delivers the chits sent by the local node in response to a query
+// from the local node. Returns false if the query wasn't delivered by [l].
+func (l *Loopback) sendChits(ctx context.Context, requestID uint32, preferredID, acceptedID ids.ID, acceptedSignature []byte) bool {
+	h, ok := l.getHandler()
+	if !ok {
+		return false
+	}
+
+	l.lock.Lock()
+	sentTime, ok := l.pending[requestID]
+	delete(l.pending, requestID)
+	l.lock.Unlock()
+	if !ok {
+		return false
+	}
+
+	l.queryLatency.Observe(float64(l.clock.Time().Sub(sentTime)))
+	h.Push(ctx, handler.Message{
+		InboundMessage: message.InboundChits(
+			l.ctx.ChainID,
+			requestID,
+			preferredID,
+			acceptedID,
+			acceptedSignature,
+			l.ctx.NodeID,
+		),
+		EngineType: l.engineType,
+	})
+	return true
+}
+
+// queryHandled marks the query as failed if it wasn't responded to while it
+// was being handled.
+func (l *Loopback) queryHandled(ctx context.Context, h handler.Handler, requestID uint32) {
+	l.lock.Lock()
+	_, ok := l.pending[requestID]
+	delete(l.pending, requestID)
+	l.lock.Unlock()
+	if !ok {
+		return
+	}
+
+	l.queriesFailed.Inc()
+	h.Push(ctx, handler.Message{
+		InboundMessage: message.InternalQueryFailed(
+			l.ctx.NodeID,
+			l.ctx.ChainID,
+			requestID,
+			l.engineType,
+		),
+		EngineType: l.engineType,
+	})
+}
+
+// loopbackMessage is a query delivered by the loopback that notifies the
+// loopback once it has been handled.
+type loopbackMessage struct {
+	message.InboundMessage
+	onFinishedHandling func()
+}
+
+func (m *loopbackMessage) OnFinishedHandling() {
+	m.InboundMessage.OnFinishedHandling()
+	m.onFinishedHandling()
+}
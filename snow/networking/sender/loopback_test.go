@@ -0,0 +1,167 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sender
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/finality"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func newLoopbackSender(t *testing.T) (*sender, *handler.MockHandler) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.Registerer = prometheus.NewRegistry()
+
+	timeoutManager := timeout.NewMockManager(ctrl)
+	timeoutManager.EXPECT().TimeoutDuration().Return(time.Second).AnyTimes()
+
+	loopback, err := NewLoopback(ctx, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	require.NoError(err)
+
+	h := handler.NewMockHandler(ctrl)
+	loopback.SetHandler(h)
+
+	// The queries are built and sent to the remaining, empty, set of nodes.
+	msgCreator := message.NewMockOutboundMsgBuilder(ctrl)
+	msgCreator.EXPECT().PushQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	msgCreator.EXPECT().PullQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	externalSender := NewMockExternalSender(ctrl)
+	externalSender.EXPECT().Send(gomock.Any(), set.Set[ids.NodeID]{}, gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	s, err := New(
+		ctx,
+		msgCreator,
+		externalSender,
+		// The router isn't expected to be called
+		router.NewMockRouter(ctrl),
+		timeoutManager,
+		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+		validators.NewSet(),
+		loopback,
+	)
+	require.NoError(err)
+	return s.(*sender), h
+}
+
+func TestLoopbackQueryResponded(t *testing.T) {
+	require := require.New(t)
+
+	s, h := newLoopbackSender(t)
+	var (
+		requestID = uint32(1)
+		blkID     = ids.GenerateTestID()
+		query     handler.Message
+	)
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		query = msg
+	})
+	s.SendPullQuery(context.Background(), set.Of(s.ctx.NodeID), requestID, blkID)
+	require.Equal(message.PullQueryOp, query.Op())
+	require.Equal(s.ctx.NodeID, query.NodeID())
+
+	// The chits are delivered directly to the handler.
+	var chits handler.Message
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		chits = msg
+	})
+	s.SendChits(context.Background(), s.ctx.NodeID, requestID, blkID, blkID)
+	require.Equal(message.ChitsOp, chits.Op())
+	require.Equal(p2p.EngineType_ENGINE_TYPE_SNOWMAN, chits.EngineType)
+
+	// Once the query has been handled, the query isn't marked as failed.
+	query.OnFinishedHandling()
+	require.Empty(s.loopback.pending)
+}
+
+func TestLoopbackQueryNotResponded(t *testing.T) {
+	require := require.New(t)
+
+	s, h := newLoopbackSender(t)
+	var (
+		requestID = uint32(1)
+		query     handler.Message
+	)
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		query = msg
+	})
+	s.SendPushQuery(context.Background(), set.Of(s.ctx.NodeID), requestID, nil)
+	require.Equal(message.PushQueryOp, query.Op())
+
+	// Handling the query without responding marks the query as failed.
+	var failed handler.Message
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		failed = msg
+	})
+	query.OnFinishedHandling()
+	require.Equal(message.QueryFailedOp, failed.Op())
+	require.Equal(s.ctx.NodeID, failed.NodeID())
+
+	// Chits sent after the query failed aren't delivered by the loopback.
+	require.False(s.loopback.sendChits(context.Background(), requestID, ids.Empty, ids.Empty, nil))
+}
+
+func TestSendChitsSignsAcceptedBlock(t *testing.T) {
+	require := require.New(t)
+
+	s, h := newLoopbackSender(t)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	s.ctx.Finality, err = finality.NewCertifier(
+		s.ctx.NetworkID,
+		s.ctx.ChainID,
+		s.ctx.SubnetID,
+		sk,
+		nil, // The validator state isn't needed to sign blocks
+		memdb.New(),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	var (
+		requestID = uint32(1)
+		blkID     = ids.GenerateTestID()
+		query     handler.Message
+	)
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		query = msg
+	})
+	s.SendPullQuery(context.Background(), set.Of(s.ctx.NodeID), requestID, blkID)
+	require.Equal(message.PullQueryOp, query.Op())
+
+	var chits handler.Message
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		chits = msg
+	})
+	s.SendChits(context.Background(), s.ctx.NodeID, requestID, blkID, blkID)
+	require.Equal(message.ChitsOp, chits.Op())
+
+	expectedSignature, err := s.ctx.Finality.Sign(blkID)
+	require.NoError(err)
+	require.IsType(&p2p.Chits{}, chits.Message())
+	require.Equal(expectedSignature, chits.Message().(*p2p.Chits).AcceptedSignature)
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sender
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// minRedundancySamples is the minimum number of queries that must be sent
+// during a window for the failure rate of the window to change the redundancy
+// level.
+const minRedundancySamples = 10
+
+// redundancy increases the number of validators that containers are gossiped
+// to while the queries sent by a chain are failing.
+//
+// The failure rate of the queries is measured over consecutive windows. After
+// each window, the redundancy level is increased by one if the failure rate
+// reached the high failure rate and decreased by one if the failure rate fell
+// to the low failure rate. The gap between the two rates prevents the level
+// from oscillating.
+type redundancy struct {
+	log    logging.Logger
+	config subnets.GossipConfig
+
+	// Useful for faking time in tests
+	clock mockable.Clock
+
+	levelMetric       prometheus.Gauge
+	failureRateMetric prometheus.Gauge
+
+	lock        sync.Mutex
+	windowStart time.Time
+	// Number of queries sent and failed during the current window
+	sent   uint64
+	failed uint64
+	level  uint
+}
+
+func newRedundancy(
+	log logging.Logger,
+	config subnets.GossipConfig,
+	registerer prometheus.Registerer,
+) (*redundancy, error) {
+	r := &redundancy{
+		log:    log,
+		config: config,
+		levelMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gossip_redundancy_level",
+			Help: "redundancy level that the number of validators gossiped to is increased by",
+		}),
+		failureRateMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "query_failure_rate",
+			Help: "rate of queries that failed during the last redundancy window",
+		}),
+	}
+	r.windowStart = r.clock.Time()
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(r.levelMetric),
+		registerer.Register(r.failureRateMetric),
+	)
+	return r, errs.Err
+}
+
+func (r *redundancy) enabled() bool {
+	return r.config.RedundancyValidatorSize > 0 && r.config.RedundancyMaxLevel > 0
+}
+
+// querySent notes that a query was sent to [numNodes] nodes.
+func (r *redundancy) querySent(numNodes int) {
+	if !r.enabled() {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.update()
+	r.sent += uint64(numNodes)
+}
+
+// queryFailed notes that a query sent to a node failed.
+func (r *redundancy) queryFailed() {
+	if !r.enabled() {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.update()
+	r.failed++
+}
+
+// validatorSize returns the number of validators to gossip a container to if
+// [size] validators should be gossiped to when queries aren't failing.
+func (r *redundancy) validatorSize(size uint) int {
+	if !r.enabled() {
+		return int(size)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.update()
+	return int(size + r.level*r.config.RedundancyValidatorSize)
+}
+
+// wrap returns [msg], which reports a failed query, so that the failure is
+// noted once the message is handled.
+func (r *redundancy) wrap(msg message.InboundMessage) message.InboundMessage {
+	if !r.enabled() {
+		return msg
+	}
+	return &redundancyMessage{
+		InboundMessage: msg,
+		r:              r,
+	}
+}
+
+// update closes the current window if it has ended.
+//
+// Invariant: [r.lock] must be held.
+func (r *redundancy) update() {
+	now := r.clock.Time()
+	if now.Sub(r.windowStart) < r.config.RedundancyWindow {
+		return
+	}
+
+	if r.sent >= minRedundancySamples {
+		// Failures of queries sent during the previous window may be reported
+		// during this window, so the rate is capped.
+		failureRate := math.Min(float64(r.failed)/float64(r.sent), 1)
+		r.failureRateMetric.Set(failureRate)
+
+		level := r.level
+		switch {
+		case failureRate >= r.config.RedundancyHighFailureRate && level < r.config.RedundancyMaxLevel:
+			level++
+		case failureRate <= r.config.RedundancyLowFailureRate && level > 0:
+			level--
+		}
+		if level != r.level {
+			r.log.Info("updating gossip redundancy",
+				zap.Float64("failureRate", failureRate),
+				zap.Uint("previousLevel", r.level),
+				zap.Uint("level", level),
+			)
+			r.level = level
+			r.levelMetric.Set(float64(level))
+		}
+	}
+
+	r.windowStart = now
+	r.sent = 0
+	r.failed = 0
+}
+
+// redundancyMessage is a failed query that notifies the redundancy controller
+// once it has been handled.
+type redundancyMessage struct {
+	message.InboundMessage
+	r *redundancy
+}
+
+func (m *redundancyMessage) OnFinishedHandling() {
+	m.InboundMessage.OnFinishedHandling()
+	m.r.queryFailed()
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sender
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var testRedundancyConfig = subnets.GossipConfig{
+	RedundancyValidatorSize:   5,
+	RedundancyMaxLevel:        2,
+	RedundancyHighFailureRate: .5,
+	RedundancyLowFailureRate:  .1,
+	RedundancyWindow:          time.Second,
+}
+
+func TestRedundancyDisabled(t *testing.T) {
+	require := require.New(t)
+
+	r, err := newRedundancy(logging.NoLog{}, subnets.GossipConfig{}, prometheus.NewRegistry())
+	require.NoError(err)
+
+	msg := message.InternalQueryFailed(ids.EmptyNodeID, ids.Empty, 0, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	require.Equal(msg, r.wrap(msg))
+	require.Equal(3, r.validatorSize(3))
+}
+
+func TestRedundancyLevels(t *testing.T) {
+	require := require.New(t)
+
+	r, err := newRedundancy(logging.NoLog{}, testRedundancyConfig, prometheus.NewRegistry())
+	require.NoError(err)
+
+	now := time.Now()
+	r.clock.Set(now)
+	r.windowStart = now
+
+	// runWindow sends [sent] queries, of which [failed] fail, and then ends
+	// the window.
+	runWindow := func(sent, failed int) {
+		r.querySent(sent)
+		msg := r.wrap(message.InternalQueryFailed(ids.EmptyNodeID, ids.Empty, 0, p2p.EngineType_ENGINE_TYPE_SNOWMAN))
+		for i := 0; i < failed; i++ {
+			msg.OnFinishedHandling()
+		}
+		now = now.Add(testRedundancyConfig.RedundancyWindow)
+		r.clock.Set(now)
+	}
+
+	// Too few samples to update the level
+	runWindow(minRedundancySamples-1, minRedundancySamples-1)
+	require.Equal(3, r.validatorSize(3))
+
+	// The level is increased once per window
+	runWindow(10, 5)
+	require.Equal(8, r.validatorSize(3))
+	runWindow(10, 10)
+	require.Equal(13, r.validatorSize(3))
+
+	// The level is capped
+	runWindow(10, 10)
+	require.Equal(13, r.validatorSize(3))
+
+	// Failure rates between the low and high rates don't change the level
+	runWindow(10, 3)
+	require.Equal(13, r.validatorSize(3))
+
+	// The level is decreased once per window
+	runWindow(10, 1)
+	require.Equal(8, r.validatorSize(3))
+	runWindow(10, 0)
+	require.Equal(3, r.validatorSize(3))
+	runWindow(10, 0)
+	require.Equal(3, r.validatorSize(3))
+}
@@ -5,7 +5,9 @@ package sender
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -18,20 +20,28 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/puzzle"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
-var _ common.Sender = (*sender)(nil)
+var (
+	_ common.Sender = (*sender)(nil)
+
+	errContainerTooLarge = errors.New("container exceeds the max block size of the subnet")
+)
 
 // sender is a wrapper around an ExternalSender.
 // Messages to this node are put directly into [router] rather than
 // being sent over the network via the wrapped ExternalSender.
 // sender registers outbound requests with [router] so that [router]
 // fires a timeout if we don't get a response to the request.
+// Queries to this node, and the chits sent in response, may instead be
+// delivered directly to the chain's handler by a [Loopback].
 type sender struct {
 	ctx        *snow.ConsensusContext
 	msgCreator message.OutboundMsgBuilder
@@ -45,6 +55,18 @@ type sender struct {
 	failedDueToBench map[message.Op]prometheus.Counter
 	engineType       p2p.EngineType
 	subnet           subnets.Subnet
+	// The validator set that validates this chain
+	validators validators.Set
+	// Containers larger than [maxContainerSize] bytes aren't sent
+	maxContainerSize int
+
+	// Delivers queries to this node directly to the chain's handler. Nil if
+	// queries to this node are always routed through [router].
+	loopback *Loopback
+
+	// Increases the number of validators that containers are gossiped to
+	// while queries are failing
+	redundancy *redundancy
 }
 
 func New(
@@ -55,7 +77,10 @@ func New(
 	timeouts timeout.Manager,
 	engineType p2p.EngineType,
 	subnet subnets.Subnet,
+	validators validators.Set,
+	loopback *Loopback,
 ) (common.Sender, error) {
+	subnetConfig := subnet.Config()
 	s := &sender{
 		ctx:              ctx,
 		msgCreator:       msgCreator,
@@ -65,6 +90,19 @@ func New(
 		failedDueToBench: make(map[message.Op]prometheus.Counter, len(message.ConsensusRequestOps)),
 		engineType:       engineType,
 		subnet:           subnet,
+		validators:       validators,
+		maxContainerSize: subnetConfig.MaxContainerSize(),
+		loopback:         loopback,
+	}
+
+	var registerer prometheus.Registerer
+	switch engineType {
+	case p2p.EngineType_ENGINE_TYPE_SNOWMAN:
+		registerer = ctx.Registerer
+	case p2p.EngineType_ENGINE_TYPE_AVALANCHE:
+		registerer = ctx.AvalancheRegisterer
+	default:
+		return nil, fmt.Errorf("unknown engine type %s", engineType)
 	}
 
 	for _, op := range message.ConsensusRequestOps {
@@ -74,22 +112,17 @@ func New(
 				Help: fmt.Sprintf("# of times a %s request was not sent because the node was benched", op),
 			},
 		)
-
-		switch engineType {
-		case p2p.EngineType_ENGINE_TYPE_SNOWMAN:
-			if err := ctx.Registerer.Register(counter); err != nil {
-				return nil, fmt.Errorf("couldn't register metric for %s: %w", op, err)
-			}
-		case p2p.EngineType_ENGINE_TYPE_AVALANCHE:
-			if err := ctx.AvalancheRegisterer.Register(counter); err != nil {
-				return nil, fmt.Errorf("couldn't register metric for %s: %w", op, err)
-			}
-		default:
-			return nil, fmt.Errorf("unknown engine type %s", engineType)
+		if err := registerer.Register(counter); err != nil {
+			return nil, fmt.Errorf("couldn't register metric for %s: %w", op, err)
 		}
-
 		s.failedDueToBench[op] = counter
 	}
+
+	var err error
+	s.redundancy, err = newRedundancy(ctx.Log, subnetConfig.GossipConfig, registerer)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize gossip redundancy: %w", err)
+	}
 	return s, nil
 }
 
@@ -723,6 +756,22 @@ func (s *sender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, reques
 // the specified chain on the specified node.
 // The Ancestors message gives the recipient the contents of several containers.
 func (s *sender) SendAncestors(_ context.Context, nodeID ids.NodeID, requestID uint32, containers [][]byte) {
+	// The recipient drops every container after an oversized container, so
+	// there is no point in sending them.
+	for i, container := range containers {
+		if err := s.verifyContainerSize(container); err != nil {
+			s.ctx.Log.Warn("truncating ancestors",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("chainID", s.ctx.ChainID),
+				zap.Uint32("requestID", requestID),
+				zap.Int("numContainers", i),
+				zap.Error(err),
+			)
+			containers = containers[:i]
+			break
+		}
+	}
+
 	// Create the outbound message.
 	outMsg, err := s.msgCreator.Ancestors(s.ctx.ChainID, requestID, containers)
 	if err != nil {
@@ -849,7 +898,11 @@ func (s *sender) SendGet(ctx context.Context, nodeID ids.NodeID, requestID uint3
 // recipient the contents of the specified container.
 func (s *sender) SendPut(_ context.Context, nodeID ids.NodeID, requestID uint32, container []byte) {
 	// Create the outbound message.
-	outMsg, err := s.msgCreator.Put(s.ctx.ChainID, requestID, container, s.engineType)
+	var outMsg message.OutboundMessage
+	err := s.verifyContainerSize(container)
+	if err == nil {
+		outMsg, err = s.msgCreator.Put(s.ctx.ChainID, requestID, container, s.engineType)
+	}
 	if err != nil {
 		s.ctx.Log.Error("failed to build message",
 			zap.Stringer("messageOp", message.PutOp),
@@ -897,18 +950,26 @@ func (s *sender) SendPut(_ context.Context, nodeID ids.NodeID, requestID uint32,
 func (s *sender) SendPushQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, container []byte) {
 	ctx = utils.Detach(ctx)
 
+	// Sending a query to myself. If possible, deliver it directly to the
+	// chain's handler, which doesn't require the router to track the request.
+	sendToSelf := nodeIDs.Contains(s.ctx.NodeID) && s.loopback.enabled()
+	if sendToSelf {
+		nodeIDs.Remove(s.ctx.NodeID)
+	}
+
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
 	// We register timeouts for all nodes, regardless of whether we fail
 	// to send them a message, to avoid busy looping when disconnected from
 	// the internet.
+	s.redundancy.querySent(nodeIDs.Len())
 	for nodeID := range nodeIDs {
-		inMsg := message.InternalQueryFailed(
+		inMsg := s.redundancy.wrap(message.InternalQueryFailed(
 			nodeID,
 			s.ctx.ChainID,
 			requestID,
 			s.engineType,
-		)
+		))
 		s.router.RegisterRequest(
 			ctx,
 			nodeID,
@@ -925,6 +986,18 @@ func (s *sender) SendPushQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID],
 	// registered. That's OK.
 	deadline := s.timeouts.TimeoutDuration()
 
+	if sendToSelf {
+		inMsg := message.InboundPushQuery(
+			s.ctx.ChainID,
+			requestID,
+			deadline,
+			container,
+			s.ctx.NodeID,
+			s.engineType,
+		)
+		s.loopback.sendQuery(ctx, requestID, inMsg)
+	}
+
 	// Sending a message to myself. No need to send it over the network. Just
 	// put it right into the router. Do so asynchronously to avoid deadlock.
 	if nodeIDs.Contains(s.ctx.NodeID) {
@@ -951,24 +1024,28 @@ func (s *sender) SendPushQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID],
 
 			// Immediately register a failure. Do so asynchronously to avoid
 			// deadlock.
-			inMsg := message.InternalQueryFailed(
+			inMsg := s.redundancy.wrap(message.InternalQueryFailed(
 				nodeID,
 				s.ctx.ChainID,
 				requestID,
 				s.engineType,
-			)
+			))
 			go s.router.HandleInbound(ctx, inMsg)
 		}
 	}
 
 	// Create the outbound message.
-	outMsg, err := s.msgCreator.PushQuery(
-		s.ctx.ChainID,
-		requestID,
-		deadline,
-		container,
-		s.engineType,
-	)
+	var outMsg message.OutboundMessage
+	err := s.verifyContainerSize(container)
+	if err == nil {
+		outMsg, err = s.msgCreator.PushQuery(
+			s.ctx.ChainID,
+			requestID,
+			deadline,
+			container,
+			s.engineType,
+		)
+	}
 
 	// Send the message over the network.
 	// [sentTo] are the IDs of validators who may receive the message.
@@ -1011,12 +1088,12 @@ func (s *sender) SendPushQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID],
 
 			// Register failures for nodes we didn't send a request to.
 			s.timeouts.RegisterRequestToUnreachableValidator()
-			inMsg := message.InternalQueryFailed(
+			inMsg := s.redundancy.wrap(message.InternalQueryFailed(
 				nodeID,
 				s.ctx.ChainID,
 				requestID,
 				s.engineType,
-			)
+			))
 			go s.router.HandleInbound(ctx, inMsg)
 		}
 	}
@@ -1029,18 +1106,26 @@ func (s *sender) SendPushQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID],
 func (s *sender) SendPullQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, containerID ids.ID) {
 	ctx = utils.Detach(ctx)
 
+	// Sending a query to myself. If possible, deliver it directly to the
+	// chain's handler, which doesn't require the router to track the request.
+	sendToSelf := nodeIDs.Contains(s.ctx.NodeID) && s.loopback.enabled()
+	if sendToSelf {
+		nodeIDs.Remove(s.ctx.NodeID)
+	}
+
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
 	// We register timeouts for all nodes, regardless of whether we fail
 	// to send them a message, to avoid busy looping when disconnected from
 	// the internet.
+	s.redundancy.querySent(nodeIDs.Len())
 	for nodeID := range nodeIDs {
-		inMsg := message.InternalQueryFailed(
+		inMsg := s.redundancy.wrap(message.InternalQueryFailed(
 			nodeID,
 			s.ctx.ChainID,
 			requestID,
 			s.engineType,
-		)
+		))
 		s.router.RegisterRequest(
 			ctx,
 			nodeID,
@@ -1057,6 +1142,18 @@ func (s *sender) SendPullQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID],
 	// registered. That's OK.
 	deadline := s.timeouts.TimeoutDuration()
 
+	if sendToSelf {
+		inMsg := message.InboundPullQuery(
+			s.ctx.ChainID,
+			requestID,
+			deadline,
+			containerID,
+			s.ctx.NodeID,
+			s.engineType,
+		)
+		s.loopback.sendQuery(ctx, requestID, inMsg)
+	}
+
 	// Sending a message to myself. No need to send it over the network. Just
 	// put it right into the router. Do so asynchronously to avoid deadlock.
 	if nodeIDs.Contains(s.ctx.NodeID) {
@@ -1082,12 +1179,12 @@ func (s *sender) SendPullQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID],
 			s.timeouts.RegisterRequestToUnreachableValidator()
 			// Immediately register a failure. Do so asynchronously to avoid
 			// deadlock.
-			inMsg := message.InternalQueryFailed(
+			inMsg := s.redundancy.wrap(message.InternalQueryFailed(
 				nodeID,
 				s.ctx.ChainID,
 				requestID,
 				s.engineType,
-			)
+			))
 			go s.router.HandleInbound(ctx, inMsg)
 		}
 	}
@@ -1133,12 +1230,12 @@ func (s *sender) SendPullQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID],
 
 			// Register failures for nodes we didn't send a request to.
 			s.timeouts.RegisterRequestToUnreachableValidator()
-			inMsg := message.InternalQueryFailed(
+			inMsg := s.redundancy.wrap(message.InternalQueryFailed(
 				nodeID,
 				s.ctx.ChainID,
 				requestID,
 				s.engineType,
-			)
+			))
 			go s.router.HandleInbound(ctx, inMsg)
 		}
 	}
@@ -1148,14 +1245,34 @@ func (s *sender) SendPullQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID],
 func (s *sender) SendChits(ctx context.Context, nodeID ids.NodeID, requestID uint32, preferredID, acceptedID ids.ID) {
 	ctx = utils.Detach(ctx)
 
-	// If [nodeID] is myself, send this message directly
-	// to my own router rather than sending it over the network
+	// The chits are still sent if the accepted block couldn't be signed, as
+	// finality signatures aren't needed for consensus.
+	var acceptedSignature []byte
+	if s.ctx.Finality != nil {
+		var err error
+		acceptedSignature, err = s.ctx.Finality.Sign(acceptedID)
+		if err != nil {
+			s.ctx.Log.Warn("failed to sign accepted block",
+				zap.Stringer("chainID", s.ctx.ChainID),
+				zap.Stringer("acceptedID", acceptedID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	// If [nodeID] is myself, send this message directly to my own handler,
+	// or router, rather than sending it over the network
 	if nodeID == s.ctx.NodeID {
+		if s.loopback.sendChits(ctx, requestID, preferredID, acceptedID, acceptedSignature) {
+			return
+		}
+
 		inMsg := message.InboundChits(
 			s.ctx.ChainID,
 			requestID,
 			preferredID,
 			acceptedID,
+			acceptedSignature,
 			nodeID,
 		)
 		go s.router.HandleInbound(ctx, inMsg)
@@ -1163,7 +1280,7 @@ func (s *sender) SendChits(ctx context.Context, nodeID ids.NodeID, requestID uin
 	}
 
 	// Create the outbound message.
-	outMsg, err := s.msgCreator.Chits(s.ctx.ChainID, requestID, preferredID, acceptedID)
+	outMsg, err := s.msgCreator.Chits(s.ctx.ChainID, requestID, preferredID, acceptedID, acceptedSignature)
 	if err != nil {
 		s.ctx.Log.Error("failed to build message",
 			zap.Stringer("messageOp", message.ChitsOp),
@@ -1310,14 +1427,79 @@ func (s *sender) SendAppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID]
 		}
 	}
 
+	// If this node doesn't have enough stake for its AppRequests to be handled
+	// unconditionally, prove that it did some work to send this request.
+	// Solving the puzzles can take a while, so it is done without blocking the
+	// caller.
+	policy := s.ctx.AppRequestPolicy.Get()
+	if policy.RequiresPuzzle(s.validators.GetWeight(s.ctx.NodeID)) {
+		go s.sendAppRequestPuzzles(
+			ctx,
+			set.Of(nodeIDs.List()...),
+			requestID,
+			deadline,
+			appRequestBytes,
+			policy.PuzzleDifficulty,
+		)
+		return nil
+	}
+
 	// Create the outbound message.
 	outMsg, err := s.msgCreator.AppRequest(
 		s.ctx.ChainID,
 		requestID,
 		deadline,
 		appRequestBytes,
+		0,
 	)
+	s.sendAppRequest(ctx, nodeIDs, requestID, appRequestBytes, outMsg, err)
+	return nil
+}
 
+// sendAppRequestPuzzles sends the AppRequest to each of [nodeIDs] with the
+// solution of the puzzle bound to that node. Puzzles that aren't solved before
+// [deadline] passes fail their request.
+func (s *sender) sendAppRequestPuzzles(
+	ctx context.Context,
+	nodeIDs set.Set[ids.NodeID],
+	requestID uint32,
+	deadline time.Duration,
+	appRequestBytes []byte,
+	difficulty uint8,
+) {
+	solveCtx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	epoch := snow.AppRequestEpoch(time.Now())
+	for nodeID := range nodeIDs {
+		challenge := snow.AppRequestChallenge(s.ctx.ChainID, s.ctx.NodeID, nodeID, epoch, requestID, appRequestBytes)
+		puzzleNonce, err := puzzle.Solve(solveCtx, challenge, difficulty)
+
+		var outMsg message.OutboundMessage
+		if err == nil {
+			outMsg, err = s.msgCreator.AppRequest(
+				s.ctx.ChainID,
+				requestID,
+				deadline,
+				appRequestBytes,
+				puzzleNonce,
+			)
+		}
+		s.sendAppRequest(ctx, set.Of(nodeID), requestID, appRequestBytes, outMsg, err)
+	}
+}
+
+// sendAppRequest sends [outMsg] to [nodeIDs] and registers failures for the
+// nodes it couldn't be sent to. If [err] isn't nil, [outMsg] couldn't be built
+// and the request fails for all of [nodeIDs].
+func (s *sender) sendAppRequest(
+	ctx context.Context,
+	nodeIDs set.Set[ids.NodeID],
+	requestID uint32,
+	appRequestBytes []byte,
+	outMsg message.OutboundMessage,
+	err error,
+) {
 	// Send the message over the network.
 	// [sentTo] are the IDs of nodes who may receive the message.
 	var sentTo set.Set[ids.NodeID]
@@ -1367,7 +1549,6 @@ func (s *sender) SendAppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID]
 			go s.router.HandleInbound(ctx, inMsg)
 		}
 	}
-	return nil
 }
 
 // SendAppResponse sends a response to an application-level request from the
@@ -1522,12 +1703,16 @@ func (s *sender) SendAppGossip(_ context.Context, appGossipBytes []byte) error {
 // SendGossip gossips the provided container
 func (s *sender) SendGossip(_ context.Context, container []byte) {
 	// Create the outbound message.
-	outMsg, err := s.msgCreator.Put(
-		s.ctx.ChainID,
-		constants.GossipMsgRequestID,
-		container,
-		s.engineType,
-	)
+	var outMsg message.OutboundMessage
+	err := s.verifyContainerSize(container)
+	if err == nil {
+		outMsg, err = s.msgCreator.Put(
+			s.ctx.ChainID,
+			constants.GossipMsgRequestID,
+			container,
+			s.engineType,
+		)
+	}
 	if err != nil {
 		s.ctx.Log.Error("failed to build message",
 			zap.Stringer("messageOp", message.PutOp),
@@ -1542,7 +1727,7 @@ func (s *sender) SendGossip(_ context.Context, container []byte) {
 	sentTo := s.sender.Gossip(
 		outMsg,
 		s.ctx.SubnetID,
-		int(gossipConfig.AcceptedFrontierValidatorSize),
+		s.redundancy.validatorSize(gossipConfig.AcceptedFrontierValidatorSize),
 		int(gossipConfig.AcceptedFrontierNonValidatorSize),
 		int(gossipConfig.AcceptedFrontierPeerSize),
 		s.subnet,
@@ -1571,12 +1756,16 @@ func (s *sender) Accept(ctx *snow.ConsensusContext, _ ids.ID, container []byte)
 	}
 
 	// Create the outbound message.
-	outMsg, err := s.msgCreator.Put(
-		s.ctx.ChainID,
-		constants.GossipMsgRequestID,
-		container,
-		s.engineType,
-	)
+	var outMsg message.OutboundMessage
+	err := s.verifyContainerSize(container)
+	if err == nil {
+		outMsg, err = s.msgCreator.Put(
+			s.ctx.ChainID,
+			constants.GossipMsgRequestID,
+			container,
+			s.engineType,
+		)
+	}
 	if err != nil {
 		s.ctx.Log.Error("failed to build message",
 			zap.Stringer("messageOp", message.PutOp),
@@ -1591,7 +1780,7 @@ func (s *sender) Accept(ctx *snow.ConsensusContext, _ ids.ID, container []byte)
 	sentTo := s.sender.Gossip(
 		outMsg,
 		s.ctx.SubnetID,
-		int(gossipConfig.OnAcceptValidatorSize),
+		s.redundancy.validatorSize(gossipConfig.OnAcceptValidatorSize),
 		int(gossipConfig.OnAcceptNonValidatorSize),
 		int(gossipConfig.OnAcceptPeerSize),
 		s.subnet,
@@ -1612,3 +1801,12 @@ func (s *sender) Accept(ctx *snow.ConsensusContext, _ ids.ID, container []byte)
 	}
 	return nil
 }
+
+// verifyContainerSize returns an error if [container] exceeds the max block
+// size of the Subnet, in which case peers would drop it.
+func (s *sender) verifyContainerSize(container []byte) error {
+	if len(container) > s.maxContainerSize {
+		return fmt.Errorf("%w: %d > %d", errContainerTooLarge, len(container), s.maxContainerSize)
+	}
+	return nil
+}
@@ -79,6 +79,7 @@ func TestTimeout(t *testing.T) {
 		metrics,
 		"dummyNamespace",
 		constants.DefaultNetworkCompressionType,
+		constants.DefaultNetworkCompressionZstdLevel,
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -109,6 +110,8 @@ func TestTimeout(t *testing.T) {
 		tm,
 		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
 		subnets.New(ctx.NodeID, defaultSubnetConfig),
+		vdrs,
+		nil,
 	)
 	require.NoError(err)
 
@@ -126,6 +129,7 @@ func TestTimeout(t *testing.T) {
 		nil,
 		time.Hour,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -337,6 +341,7 @@ func TestReliableMessages(t *testing.T) {
 		metrics,
 		"dummyNamespace",
 		constants.DefaultNetworkCompressionType,
+		constants.DefaultNetworkCompressionZstdLevel,
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -368,6 +373,8 @@ func TestReliableMessages(t *testing.T) {
 		tm,
 		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
 		subnets.New(ctx.NodeID, defaultSubnetConfig),
+		vdrs,
+		nil,
 	)
 	require.NoError(err)
 
@@ -385,6 +392,7 @@ func TestReliableMessages(t *testing.T) {
 		nil,
 		1,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -487,6 +495,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		metrics,
 		"dummyNamespace",
 		constants.DefaultNetworkCompressionType,
+		constants.DefaultNetworkCompressionZstdLevel,
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -518,6 +527,8 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		tm,
 		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
 		subnets.New(ctx.NodeID, defaultSubnetConfig),
+		vdrs,
+		nil,
 	)
 	require.NoError(err)
 
@@ -535,6 +546,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		nil,
 		time.Second,
 		testThreadPoolSize,
+		nil,
 		resourceTracker,
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -831,6 +843,8 @@ func TestSender_Bootstrap_Requests(t *testing.T) {
 				timeoutManager,
 				engineType,
 				subnets.New(ctx.NodeID, defaultSubnetConfig),
+				validators.NewSet(),
+				nil,
 			)
 			require.NoError(err)
 
@@ -1053,6 +1067,8 @@ func TestSender_Bootstrap_Responses(t *testing.T) {
 				timeoutManager,
 				engineType,
 				subnets.New(ctx.NodeID, defaultSubnetConfig),
+				validators.NewSet(),
+				nil,
 			)
 			require.NoError(err)
 
@@ -1220,6 +1236,8 @@ func TestSender_Single_Request(t *testing.T) {
 				timeoutManager,
 				engineType,
 				subnets.New(ctx.NodeID, defaultSubnetConfig),
+				validators.NewSet(),
+				nil,
 			)
 			require.NoError(err)
 
@@ -1338,3 +1356,58 @@ func TestSender_Single_Request(t *testing.T) {
 		})
 	}
 }
+
+func TestSenderDropsOversizedContainers(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	var (
+		ctx            = snow.DefaultConsensusContextTest()
+		nodeID         = ids.GenerateTestNodeID()
+		requestID      = uint32(1337)
+		msgCreator     = message.NewMockOutboundMsgBuilder(ctrl)
+		externalSender = NewMockExternalSender(ctrl)
+		timeoutManager = timeout.NewMockManager(ctrl)
+		router         = router.NewMockRouter(ctrl)
+	)
+
+	sender, err := New(
+		ctx,
+		msgCreator,
+		externalSender,
+		router,
+		timeoutManager,
+		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		subnets.New(ctx.NodeID, subnets.Config{
+			Limits: snow.Limits{
+				MaxBlockSize: 4,
+			},
+		}),
+		validators.NewSet(),
+		nil,
+	)
+	require.NoError(err)
+
+	// Oversized containers are never passed to the message creator.
+	sender.SendPut(context.Background(), nodeID, requestID, []byte{1, 2, 3, 4, 5})
+	sender.SendGossip(context.Background(), []byte{1, 2, 3, 4, 5})
+
+	// Ancestors are truncated before the first oversized container.
+	msgCreator.EXPECT().Ancestors(
+		ctx.ChainID,
+		requestID,
+		[][]byte{{1, 2, 3, 4}},
+	).Return(nil, nil)
+	externalSender.EXPECT().Send(
+		gomock.Any(),
+		set.Of(nodeID),
+		ctx.SubnetID,
+		gomock.Any(),
+	).Return(set.Of(nodeID))
+	sender.SendAncestors(
+		context.Background(),
+		nodeID,
+		requestID,
+		[][]byte{{1, 2, 3, 4}, {1, 2, 3, 4, 5}, {1}},
+	)
+}
@@ -34,7 +34,8 @@ type Manager interface {
 	RegisterChain(ctx *snow.ConsensusContext) error
 	// RegisterRequest notes that we expect a response of type [op] from
 	// [nodeID] for chain [chainID]. If we don't receive a response in
-	// time, [timeoutHandler] is executed.
+	// time, [timeoutHandler] is executed. The timeout is extended by the
+	// expected time to transfer the response from [nodeID].
 	RegisterRequest(
 		nodeID ids.NodeID,
 		chainID ids.ID,
@@ -51,13 +52,16 @@ type Manager interface {
 	// Registers that [nodeID] sent us a response of type [op]
 	// for the given chain. The response corresponds to the given
 	// requestID we sent them. [latency] is the time between us
-	// sending them the request and receiving their response.
+	// sending them the request and receiving their response. [numBytes] is
+	// the size of the response, which is used to estimate the bandwidth of
+	// [nodeID].
 	RegisterResponse(
 		nodeID ids.NodeID,
 		chainID ids.ID,
 		requestID ids.RequestID,
 		op message.Op,
 		latency time.Duration,
+		numBytes int,
 	)
 	// Mark that we no longer expect a response to this request we sent.
 	// Does not modify the timeout.
@@ -81,6 +85,7 @@ func NewManager(
 	return &manager{
 		benchlistMgr: benchlistMgr,
 		tm:           tm,
+		transfers:    newTransferEstimator(),
 	}, nil
 }
 
@@ -88,6 +93,7 @@ type manager struct {
 	tm           timer.AdaptiveTimeoutManager
 	benchlistMgr benchlist.Manager
 	metrics      metrics
+	transfers    *transferEstimator
 }
 
 func (m *manager) Dispatch() {
@@ -129,7 +135,8 @@ func (m *manager) RegisterRequest(
 		m.benchlistMgr.RegisterFailure(chainID, nodeID)
 		timeoutHandler()
 	}
-	m.tm.Put(requestID, measureLatency, newTimeoutHandler)
+	extension := m.transfers.Estimate(nodeID, message.Op(requestID.Op))
+	m.tm.PutWithExtension(requestID, measureLatency, extension, newTimeoutHandler)
 }
 
 // RegisterResponse registers that we received a response from [nodeID]
@@ -140,8 +147,10 @@ func (m *manager) RegisterResponse(
 	requestID ids.RequestID,
 	op message.Op,
 	latency time.Duration,
+	numBytes int,
 ) {
 	m.metrics.Observe(nodeID, chainID, op, latency)
+	m.transfers.Observe(nodeID, op, numBytes, latency, time.Now())
 	m.benchlistMgr.RegisterResponse(chainID, nodeID)
 	m.tm.Remove(requestID)
 }
@@ -105,15 +105,15 @@ func (mr *MockManagerMockRecorder) RegisterRequestToUnreachableValidator() *gomo
 }
 
 // RegisterResponse mocks base method.
-func (m *MockManager) RegisterResponse(arg0 ids.NodeID, arg1 ids.ID, arg2 ids.RequestID, arg3 message.Op, arg4 time.Duration) {
+func (m *MockManager) RegisterResponse(arg0 ids.NodeID, arg1 ids.ID, arg2 ids.RequestID, arg3 message.Op, arg4 time.Duration, arg5 int) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RegisterResponse", arg0, arg1, arg2, arg3, arg4)
+	m.ctrl.Call(m, "RegisterResponse", arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // RegisterResponse indicates an expected call of RegisterResponse.
-func (mr *MockManagerMockRecorder) RegisterResponse(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockManagerMockRecorder) RegisterResponse(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterResponse", reflect.TypeOf((*MockManager)(nil).RegisterResponse), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterResponse", reflect.TypeOf((*MockManager)(nil).RegisterResponse), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // RemoveRequest mocks base method.
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package timeout
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// Responses smaller than [minBandwidthSampleSize] are dominated by the
+	// round trip latency rather than the transfer time, so they aren't used to
+	// estimate peer bandwidth.
+	minBandwidthSampleSize = 64 * units.KiB
+
+	// maxTrackedPeers bounds the number of peers whose bandwidth is tracked.
+	maxTrackedPeers = 4096
+
+	transferEstimatorHalflife = 5 * time.Minute
+)
+
+// transferEstimator estimates how long it takes to receive a response from a
+// peer, based on the expected size of the response and the bandwidth that was
+// previously measured from the peer.
+type transferEstimator struct {
+	lock sync.Mutex
+	// Op -> average number of bytes in responses of that op
+	responseSizes map[message.Op]math.Averager
+	// NodeID -> average bytes per second received from that peer
+	bandwidths *cache.LRU[ids.NodeID, math.Averager]
+}
+
+func newTransferEstimator() *transferEstimator {
+	return &transferEstimator{
+		responseSizes: make(map[message.Op]math.Averager),
+		bandwidths:    &cache.LRU[ids.NodeID, math.Averager]{Size: maxTrackedPeers},
+	}
+}
+
+// Observe records that a response of type [op] with [numBytes] bytes was
+// received from [nodeID] [latency] after it was requested.
+func (t *transferEstimator) Observe(
+	nodeID ids.NodeID,
+	op message.Op,
+	numBytes int,
+	latency time.Duration,
+	now time.Time,
+) {
+	if numBytes <= 0 {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	responseSize, ok := t.responseSizes[op]
+	if !ok {
+		responseSize = math.NewUninitializedAverager(transferEstimatorHalflife)
+		t.responseSizes[op] = responseSize
+	}
+	responseSize.Observe(float64(numBytes), now)
+
+	if numBytes < minBandwidthSampleSize || latency <= 0 {
+		return
+	}
+
+	bandwidth, ok := t.bandwidths.Get(nodeID)
+	if !ok {
+		bandwidth = math.NewUninitializedAverager(transferEstimatorHalflife)
+		t.bandwidths.Put(nodeID, bandwidth)
+	}
+	bandwidth.Observe(float64(numBytes)/latency.Seconds(), now)
+}
+
+// Estimate returns the expected amount of time it takes to transfer a
+// response of type [op] from [nodeID]. If the response size or the bandwidth
+// of the peer is unknown, 0 is returned.
+func (t *transferEstimator) Estimate(nodeID ids.NodeID, op message.Op) time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	responseSize, ok := t.responseSizes[op]
+	if !ok {
+		return 0
+	}
+	expectedBytes := responseSize.Read()
+	if expectedBytes < minBandwidthSampleSize {
+		return 0
+	}
+
+	bandwidth, ok := t.bandwidths.Get(nodeID)
+	if !ok {
+		return 0
+	}
+	bytesPerSecond := bandwidth.Read()
+	if bytesPerSecond <= 0 {
+		return 0
+	}
+	return time.Duration(expectedBytes / bytesPerSecond * float64(time.Second))
+}
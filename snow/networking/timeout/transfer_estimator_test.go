@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package timeout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func TestTransferEstimator(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		estimator = newTransferEstimator()
+		nodeID    = ids.GenerateTestNodeID()
+		now       = time.Now()
+	)
+
+	// Nothing is known about the peer or the op
+	require.Zero(estimator.Estimate(nodeID, message.AncestorsOp))
+
+	// Small responses don't contribute to the bandwidth estimate
+	estimator.Observe(nodeID, message.PutOp, units.KiB, time.Second, now)
+	require.Zero(estimator.Estimate(nodeID, message.PutOp))
+	require.Zero(estimator.Estimate(nodeID, message.AncestorsOp))
+
+	// 2 MiB received in 2 seconds
+	estimator.Observe(nodeID, message.AncestorsOp, 2*units.MiB, 2*time.Second, now)
+	require.Equal(2*time.Second, estimator.Estimate(nodeID, message.AncestorsOp))
+
+	// Small responses are still expected to be fast
+	require.Zero(estimator.Estimate(nodeID, message.PutOp))
+
+	// Unknown peers don't get an extension
+	require.Zero(estimator.Estimate(ids.GenerateTestNodeID(), message.AncestorsOp))
+
+	// Locally generated messages are ignored
+	estimator.Observe(nodeID, message.AncestorsOp, 0, time.Hour, now)
+	require.Equal(2*time.Second, estimator.Estimate(nodeID, message.AncestorsOp))
+}
@@ -0,0 +1,253 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/profiler"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+const (
+	// Format of the directories that profiles are written to when a stall is
+	// detected.
+	dumpDirFormat = "20060102T150405Z"
+	// On average, 1 in [mutexProfileFraction] mutex contention events is
+	// reported in the lock profile.
+	mutexProfileFraction = 100
+)
+
+var (
+	_ Watchdog = (*watchdog)(nil)
+
+	errStalled = errors.New("chains stalled")
+)
+
+// Config of the watchdog.
+type Config struct {
+	// StallTimeout is how long a chain may go without progressing before it
+	// is considered stalled. If 0, the watchdog is disabled.
+	StallTimeout time.Duration `json:"stallTimeout"`
+	// Frequency is how often chains are checked for stalls.
+	Frequency time.Duration `json:"frequency"`
+	// ProfileDir is the directory that profiles are written to when a stall is
+	// detected.
+	ProfileDir string `json:"profileDir"`
+}
+
+func (c Config) Enabled() bool {
+	return c.StallTimeout > 0
+}
+
+// Watchdog detects chains that have stalled. When a chain stalls, the
+// goroutine and lock profiles of the process are written to disk and the
+// watchdog reports unhealthy until the chain makes progress again.
+//
+// A chain is considered stalled if:
+//   - its handler has been handling a single sync message for longer than the
+//     stall timeout, which is typically caused by a deadlock; or
+//   - it hasn't accepted a container for longer than the stall timeout while
+//     its peers have.
+type Watchdog interface {
+	health.Checker
+
+	// Accept marks that a container was accepted by the chain of [ctx]. It
+	// should be registered as an acceptor of every tracked chain.
+	snow.Acceptor
+
+	// Track starts monitoring the chain handled by [h].
+	Track(h handler.Handler)
+
+	// Dispatch periodically checks the tracked chains until Shutdown is
+	// called.
+	Dispatch()
+	Shutdown()
+}
+
+type chain struct {
+	handler handler.Handler
+	// The last time a container was accepted, or the chain wasn't in normal
+	// operation.
+	lastAccepted time.Time
+	// Reason the chain is considered stalled. Empty if the chain isn't
+	// stalled.
+	stalled string
+}
+
+type watchdog struct {
+	log    logging.Logger
+	config Config
+
+	// Useful for faking time in tests
+	clock mockable.Clock
+
+	lock   sync.Mutex
+	chains map[ids.ID]*chain
+
+	// Dispatch returns when closer is closed
+	closer chan struct{}
+}
+
+func New(log logging.Logger, config Config) Watchdog {
+	return &watchdog{
+		log:    log,
+		config: config,
+		chains: make(map[ids.ID]*chain),
+		closer: make(chan struct{}),
+	}
+}
+
+func (w *watchdog) Accept(ctx *snow.ConsensusContext, _ ids.ID, _ []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if c, ok := w.chains[ctx.ChainID]; ok {
+		c.lastAccepted = w.clock.Time()
+	}
+	return nil
+}
+
+func (w *watchdog) Track(h handler.Handler) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.chains[h.Context().ChainID] = &chain{
+		handler:      h,
+		lastAccepted: w.clock.Time(),
+	}
+}
+
+func (w *watchdog) HealthCheck(context.Context) (interface{}, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	details := make(map[string]string)
+	for chainID, c := range w.chains {
+		if c.stalled != "" {
+			details[chainID.String()] = c.stalled
+		}
+	}
+	if len(details) == 0 {
+		return details, nil
+	}
+	return details, fmt.Errorf("%w: %d chains haven't progressed for %s", errStalled, len(details), w.config.StallTimeout)
+}
+
+func (w *watchdog) Dispatch() {
+	// Contended mutexes are only reported in the lock profile if mutex
+	// profiling is enabled.
+	if runtime.SetMutexProfileFraction(-1) == 0 {
+		runtime.SetMutexProfileFraction(mutexProfileFraction)
+	}
+
+	t := time.NewTicker(w.config.Frequency)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-w.closer:
+			return
+		case <-t.C:
+			if w.check() {
+				w.dump()
+			}
+		}
+	}
+}
+
+func (w *watchdog) Shutdown() {
+	close(w.closer)
+}
+
+// check updates the stall status of the tracked chains. Returns true if a
+// chain stalled since the last check.
+func (w *watchdog) check() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	var (
+		now          = w.clock.Time()
+		newlyStalled bool
+	)
+	for chainID, c := range w.chains {
+		reason := w.stallReason(c, now)
+		switch {
+		case reason == "" && c.stalled != "":
+			w.log.Info("chain is no longer stalled",
+				zap.Stringer("chainID", chainID),
+			)
+		case reason != "" && c.stalled == "":
+			w.log.Warn("chain stalled",
+				zap.Stringer("chainID", chainID),
+				zap.String("reason", reason),
+			)
+			newlyStalled = true
+		}
+		c.stalled = reason
+	}
+	return newlyStalled
+}
+
+// stallReason returns why [c] is stalled. Returns the empty string if [c]
+// isn't stalled.
+func (w *watchdog) stallReason(c *chain, now time.Time) string {
+	progress := c.handler.Progress()
+	if !progress.ProcessingSince.IsZero() {
+		processingTime := now.Sub(progress.ProcessingSince)
+		if processingTime > w.config.StallTimeout {
+			return fmt.Sprintf("handling %s message for %s", progress.ProcessingOp, processingTime)
+		}
+	}
+
+	// Containers are only expected to be accepted at the pace of the network
+	// during normal operation.
+	if c.handler.Context().State.Get().State != snow.NormalOp {
+		c.lastAccepted = now
+		return ""
+	}
+
+	acceptDelay := now.Sub(c.lastAccepted)
+	if acceptDelay > w.config.StallTimeout && progress.PeerAcceptedTime.After(c.lastAccepted) {
+		return fmt.Sprintf("nothing accepted for %s while peers accepted", acceptDelay)
+	}
+	return ""
+}
+
+// dump writes the goroutine and lock profiles of the process to a new
+// directory in the profile directory.
+func (w *watchdog) dump() {
+	dir := filepath.Join(w.config.ProfileDir, w.clock.Time().UTC().Format(dumpDirFormat))
+	p := profiler.New(dir)
+	if err := p.GoroutineProfile(); err != nil {
+		w.log.Error("failed to write goroutine profile",
+			zap.String("dir", dir),
+			zap.Error(err),
+		)
+		return
+	}
+	if err := p.LockProfile(); err != nil {
+		w.log.Error("failed to write lock profile",
+			zap.String("dir", dir),
+			zap.Error(err),
+		)
+		return
+	}
+	w.log.Warn("wrote profiles of stalled process",
+		zap.String("dir", dir),
+	)
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package watchdog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const testStallTimeout = time.Minute
+
+func newTestWatchdog(t *testing.T) (*watchdog, *handler.MockHandler, *snow.ConsensusContext) {
+	ctrl := gomock.NewController(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.NormalOp,
+	})
+	h := handler.NewMockHandler(ctrl)
+	h.EXPECT().Context().Return(ctx).AnyTimes()
+
+	w := New(logging.NoLog{}, Config{
+		StallTimeout: testStallTimeout,
+		Frequency:    time.Second,
+		ProfileDir:   t.TempDir(),
+	}).(*watchdog)
+	w.clock.Set(time.Unix(1000, 0))
+	w.Track(h)
+	return w, h, ctx
+}
+
+func TestWatchdogHandlerStall(t *testing.T) {
+	require := require.New(t)
+
+	w, h, _ := newTestWatchdog(t)
+	start := w.clock.Time()
+
+	h.EXPECT().Progress().Return(handler.Progress{
+		ProcessingOp:    message.ChitsOp,
+		ProcessingSince: start,
+	}).Times(2)
+
+	// Handling a message for less than the stall timeout isn't a stall.
+	w.clock.Set(start.Add(testStallTimeout))
+	require.False(w.check())
+	_, err := w.HealthCheck(context.Background())
+	require.NoError(err)
+
+	w.clock.Set(start.Add(testStallTimeout + time.Second))
+	require.True(w.check())
+	_, err = w.HealthCheck(context.Background())
+	require.ErrorIs(err, errStalled)
+
+	// Once the message has been handled, the chain is no longer stalled.
+	h.EXPECT().Progress().Return(handler.Progress{})
+	require.False(w.check())
+	_, err = w.HealthCheck(context.Background())
+	require.NoError(err)
+}
+
+func TestWatchdogAcceptStall(t *testing.T) {
+	require := require.New(t)
+
+	w, h, ctx := newTestWatchdog(t)
+	start := w.clock.Time()
+
+	// If peers aren't accepting either, the chain isn't stalled.
+	h.EXPECT().Progress().Return(handler.Progress{}).Times(2)
+	w.clock.Set(start.Add(2 * testStallTimeout))
+	require.False(w.check())
+
+	// Accepting resets the timeout.
+	require.NoError(w.Accept(ctx, ids.Empty, nil))
+	accepted := w.clock.Time()
+	w.clock.Set(accepted.Add(testStallTimeout))
+	require.False(w.check())
+
+	w.clock.Set(accepted.Add(testStallTimeout + time.Second))
+	h.EXPECT().Progress().Return(handler.Progress{
+		PeerAcceptedTime: accepted.Add(time.Second),
+	})
+	require.True(w.check())
+	_, err := w.HealthCheck(context.Background())
+	require.ErrorIs(err, errStalled)
+
+	// Chains that aren't in normal operation aren't expected to accept.
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.Bootstrapping,
+	})
+	h.EXPECT().Progress().Return(handler.Progress{
+		PeerAcceptedTime: accepted.Add(time.Second),
+	})
+	require.False(w.check())
+	_, err = w.HealthCheck(context.Background())
+	require.NoError(err)
+}
+
+func TestWatchdogDump(t *testing.T) {
+	require := require.New(t)
+
+	w, _, _ := newTestWatchdog(t)
+	w.dump()
+
+	dir := filepath.Join(w.config.ProfileDir, w.clock.Time().UTC().Format(dumpDirFormat))
+	for _, file := range []string{"goroutine.profile", "lock.profile"} {
+		_, err := os.Stat(filepath.Join(dir, file))
+		require.NoError(err)
+	}
+}
@@ -77,6 +77,20 @@ func (c *lockedCalculator) CalculateUptimePercentFrom(nodeID ids.NodeID, subnetI
 	return c.c.CalculateUptimePercentFrom(nodeID, subnetID, startTime)
 }
 
+func (c *lockedCalculator) CalculateTimeToThreshold(nodeID ids.NodeID, subnetID ids.ID, threshold float64) (time.Duration, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.isBootstrapped == nil || !c.isBootstrapped.Get() {
+		return 0, errStillBootstrapping
+	}
+
+	c.calculatorLock.Lock()
+	defer c.calculatorLock.Unlock()
+
+	return c.c.CalculateTimeToThreshold(nodeID, subnetID, threshold)
+}
+
 func (c *lockedCalculator) SetCalculator(isBootstrapped *utils.Atomic[bool], lock sync.Locker, newC Calculator) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -33,6 +33,11 @@ type Calculator interface {
 	CalculateUptimePercent(nodeID ids.NodeID, subnetID ids.ID) (float64, error)
 	// CalculateUptimePercentFrom expects [startTime] to be truncated (floored) to the nearest second
 	CalculateUptimePercentFrom(nodeID ids.NodeID, subnetID ids.ID, startTime time.Time) (float64, error)
+	// CalculateTimeToThreshold returns how long [nodeID] can be offline,
+	// starting now, before its uptime over its staking period drops below
+	// [threshold]. Returns 0 if it was already offline for too long to reach
+	// [threshold].
+	CalculateTimeToThreshold(nodeID ids.NodeID, subnetID ids.ID, threshold float64) (time.Duration, error)
 }
 
 type TestManager interface {
@@ -206,6 +211,22 @@ func (m *manager) CalculateUptimePercentFrom(nodeID ids.NodeID, subnetID ids.ID,
 	return uptime, nil
 }
 
+func (m *manager) CalculateTimeToThreshold(nodeID ids.NodeID, subnetID ids.ID, threshold float64) (time.Duration, error) {
+	startTime, err := m.state.GetStartTime(nodeID, subnetID)
+	if err != nil {
+		return 0, err
+	}
+	endTime, err := m.state.GetEndTime(nodeID, subnetID)
+	if err != nil {
+		return 0, err
+	}
+	upDuration, now, err := m.CalculateUptime(nodeID, subnetID)
+	if err != nil {
+		return 0, err
+	}
+	return TimeToThreshold(upDuration, now.Sub(startTime), endTime.Sub(startTime), threshold), nil
+}
+
 func (m *manager) SetTime(newTime time.Time) {
 	m.clock.Set(newTime)
 }
@@ -229,3 +250,16 @@ func (m *manager) updateSubnetUptime(nodeID ids.NodeID, subnetID ids.ID) error {
 
 	return m.state.SetUptime(nodeID, subnetID, newDuration, newLastUpdated)
 }
+
+// TimeToThreshold returns how long a node that has been up for [upDuration] out
+// of the [elapsed] part of its [total] staking period can be offline before its
+// uptime over the staking period drops below [threshold].
+func TimeToThreshold(upDuration, elapsed, total time.Duration, threshold float64) time.Duration {
+	// The node may be offline for (1 - threshold) * total over its staking
+	// period, of which it was already offline for elapsed - upDuration.
+	budget := float64(total) - threshold*float64(total) - float64(elapsed-upDuration)
+	if budget <= 0 {
+		return 0
+	}
+	return time.Duration(budget).Truncate(time.Second)
+}
@@ -568,6 +568,84 @@ func TestCalculateUptimePercentage(t *testing.T) {
 	require.Equal(float64(0), uptime)
 }
 
+func TestCalculateTimeToThreshold(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	subnetID := ids.GenerateTestID()
+	startTime := time.Unix(1_000_000, 0)
+
+	s := NewTestState()
+	s.AddNode(nodeID0, subnetID, startTime)
+	s.SetEndTime(nodeID0, subnetID, startTime.Add(1000*time.Second))
+
+	up := NewManager(s).(*manager)
+	up.clock.Set(startTime)
+	require.NoError(up.StartTracking([]ids.NodeID{nodeID0}, subnetID))
+
+	// The node is up for 90 of the first 100 seconds of its 1000 second
+	// staking period.
+	up.clock.Set(startTime.Add(10 * time.Second))
+	require.NoError(up.Connect(nodeID0, subnetID))
+	up.clock.Set(startTime.Add(100 * time.Second))
+
+	timeToThreshold, err := up.CalculateTimeToThreshold(nodeID0, subnetID, .8)
+	require.NoError(err)
+	require.Equal(190*time.Second, timeToThreshold)
+
+	_, err = up.CalculateTimeToThreshold(ids.GenerateTestNodeID(), subnetID, .8)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestTimeToThreshold(t *testing.T) {
+	tests := []struct {
+		name       string
+		upDuration time.Duration
+		elapsed    time.Duration
+		total      time.Duration
+		threshold  float64
+		expected   time.Duration
+	}{
+		{
+			name:       "above threshold",
+			upDuration: 90 * time.Second,
+			elapsed:    100 * time.Second,
+			total:      1000 * time.Second,
+			threshold:  .8,
+			expected:   190 * time.Second,
+		},
+		{
+			name:       "at threshold",
+			upDuration: 800 * time.Second,
+			elapsed:    1000 * time.Second,
+			total:      1000 * time.Second,
+			threshold:  .8,
+			expected:   0,
+		},
+		{
+			name:       "below threshold",
+			upDuration: 50 * time.Second,
+			elapsed:    300 * time.Second,
+			total:      1000 * time.Second,
+			threshold:  .8,
+			expected:   0,
+		},
+		{
+			name:       "no threshold",
+			upDuration: 0,
+			elapsed:    100 * time.Second,
+			total:      1000 * time.Second,
+			threshold:  0,
+			expected:   900 * time.Second,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, TimeToThreshold(test.upDuration, test.elapsed, test.total, test.threshold))
+		})
+	}
+}
+
 func TestStopTrackingUnixTimeRegression(t *testing.T) {
 	require := require.New(t)
 
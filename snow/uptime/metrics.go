@@ -0,0 +1,140 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import (
+	"math"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	_ prometheus.Collector = (*metrics)(nil)
+
+	// quantiles reported by the uptime summary
+	uptimeQuantiles = []float64{0.05, 0.25, 0.5, 0.75, 0.95}
+)
+
+// metrics reports, from this node's perspective, the distribution of the
+// uptimes of the validators of a subnet.
+//
+// The uptimes are calculated when the metrics are gathered, so the reported
+// values are always a snapshot of the current validator set.
+type metrics struct {
+	calculator  Calculator
+	subnetID    ids.ID
+	getNodeIDs  func() []ids.NodeID
+	requirement float64
+
+	uptimes           *prometheus.Desc
+	belowRequirement  *prometheus.Desc
+	uptimeUnavailable *prometheus.Desc
+}
+
+// NewMetrics returns a collector reporting the uptime distribution of the
+// validators returned by [getNodeIDs] on [subnetID], along with the number of
+// those validators whose uptime is below [requirement].
+//
+// [calculator] must be safe to call concurrently with the rest of the node,
+// such as a LockedCalculator.
+func NewMetrics(
+	namespace string,
+	calculator Calculator,
+	subnetID ids.ID,
+	getNodeIDs func() []ids.NodeID,
+	requirement float64,
+) prometheus.Collector {
+	return &metrics{
+		calculator:  calculator,
+		subnetID:    subnetID,
+		getNodeIDs:  getNodeIDs,
+		requirement: requirement,
+		uptimes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "validator_uptimes"),
+			"Distribution of the uptimes of the validators, as observed by this node",
+			nil,
+			nil,
+		),
+		belowRequirement: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "validators_below_uptime_requirement"),
+			"Number of validators with an observed uptime below the reward requirement",
+			nil,
+			nil,
+		),
+		uptimeUnavailable: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "validators_uptime_unavailable"),
+			"Number of validators whose uptime could not be calculated",
+			nil,
+			nil,
+		),
+	}
+}
+
+func (m *metrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.uptimes
+	ch <- m.belowRequirement
+	ch <- m.uptimeUnavailable
+}
+
+func (m *metrics) Collect(ch chan<- prometheus.Metric) {
+	var (
+		nodeIDs          = m.getNodeIDs()
+		uptimes          = make([]float64, 0, len(nodeIDs))
+		sum              float64
+		belowRequirement int
+		unavailable      int
+	)
+	for _, nodeID := range nodeIDs {
+		uptime, err := m.calculator.CalculateUptimePercent(nodeID, m.subnetID)
+		if err != nil {
+			unavailable++
+			continue
+		}
+
+		uptimes = append(uptimes, uptime)
+		sum += uptime
+		if uptime < m.requirement {
+			belowRequirement++
+		}
+	}
+	sort.Float64s(uptimes)
+
+	quantiles := make(map[float64]float64, len(uptimeQuantiles))
+	for _, q := range uptimeQuantiles {
+		quantiles[q] = quantile(uptimes, q)
+	}
+
+	ch <- prometheus.MustNewConstSummary(
+		m.uptimes,
+		uint64(len(uptimes)),
+		sum,
+		quantiles,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		m.belowRequirement,
+		prometheus.GaugeValue,
+		float64(belowRequirement),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		m.uptimeUnavailable,
+		prometheus.GaugeValue,
+		float64(unavailable),
+	)
+}
+
+// quantile returns the [q]th quantile of the sorted [values] using the
+// nearest-rank method. If [values] is empty, 0 is returned.
+func quantile(values []float64, q float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	index := int(math.Ceil(q*float64(len(values)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return values[index]
+}
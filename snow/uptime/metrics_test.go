@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestMetrics(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+	nonValidatorID := ids.GenerateTestNodeID()
+	subnetID := ids.GenerateTestID()
+	startTime := time.Now()
+
+	s := NewTestState()
+	s.AddNode(nodeID0, subnetID, startTime)
+	s.AddNode(nodeID1, subnetID, startTime)
+	s.AddNode(nodeID2, subnetID, startTime)
+
+	up := NewManager(s).(*manager)
+	up.clock.Set(startTime)
+	require.NoError(up.StartTracking([]ids.NodeID{nodeID0, nodeID1, nodeID2}, subnetID))
+
+	// nodeID0 is always connected and nodeID1 is connected for half of the
+	// time. nodeID2 never connects.
+	require.NoError(up.Connect(nodeID0, subnetID))
+	up.clock.Set(startTime.Add(time.Second))
+	require.NoError(up.Connect(nodeID1, subnetID))
+	up.clock.Set(startTime.Add(2 * time.Second))
+
+	registry := prometheus.NewRegistry()
+	require.NoError(registry.Register(NewMetrics(
+		"uptime",
+		up,
+		subnetID,
+		func() []ids.NodeID {
+			return []ids.NodeID{nodeID0, nodeID1, nodeID2, nonValidatorID}
+		},
+		.8,
+	)))
+
+	families, err := registry.Gather()
+	require.NoError(err)
+	require.Len(families, 3)
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		metric := family.GetMetric()[0]
+		switch family.GetName() {
+		case "uptime_validator_uptimes":
+			summary := metric.GetSummary()
+			require.Equal(uint64(3), summary.GetSampleCount())
+			require.InDelta(1.5, summary.GetSampleSum(), .001)
+
+			quantiles := make(map[float64]float64)
+			for _, q := range summary.GetQuantile() {
+				quantiles[q.GetQuantile()] = q.GetValue()
+			}
+			require.Zero(quantiles[.05])
+			require.InDelta(.5, quantiles[.5], .001)
+			require.InDelta(1, quantiles[.95], .001)
+		default:
+			values[family.GetName()] = metric.GetGauge().GetValue()
+		}
+	}
+	require.Equal(map[string]float64{
+		"uptime_validators_below_uptime_requirement": 2,
+		"uptime_validators_uptime_unavailable":       1,
+	}, values)
+}
+
+func TestQuantile(t *testing.T) {
+	require := require.New(t)
+
+	require.Zero(quantile(nil, .5))
+
+	values := []float64{1, 2, 3, 4}
+	require.Equal(float64(1), quantile(values, 0))
+	require.Equal(float64(1), quantile(values, .25))
+	require.Equal(float64(2), quantile(values, .5))
+	require.Equal(float64(4), quantile(values, .95))
+	require.Equal(float64(4), quantile(values, 1))
+}
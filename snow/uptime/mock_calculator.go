@@ -38,6 +38,21 @@ func (m *MockCalculator) EXPECT() *MockCalculatorMockRecorder {
 	return m.recorder
 }
 
+// CalculateTimeToThreshold mocks base method.
+func (m *MockCalculator) CalculateTimeToThreshold(arg0 ids.NodeID, arg1 ids.ID, arg2 float64) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateTimeToThreshold", arg0, arg1, arg2)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateTimeToThreshold indicates an expected call of CalculateTimeToThreshold.
+func (mr *MockCalculatorMockRecorder) CalculateTimeToThreshold(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateTimeToThreshold", reflect.TypeOf((*MockCalculator)(nil).CalculateTimeToThreshold), arg0, arg1, arg2)
+}
+
 // CalculateUptime mocks base method.
 func (m *MockCalculator) CalculateUptime(arg0 ids.NodeID, arg1 ids.ID) (time.Duration, time.Time, error) {
 	m.ctrl.T.Helper()
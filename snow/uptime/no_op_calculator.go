@@ -24,3 +24,7 @@ func (noOpCalculator) CalculateUptimePercent(ids.NodeID, ids.ID) (float64, error
 func (noOpCalculator) CalculateUptimePercentFrom(ids.NodeID, ids.ID, time.Time) (float64, error) {
 	return 0, nil
 }
+
+func (noOpCalculator) CalculateTimeToThreshold(ids.NodeID, ids.ID, float64) (time.Duration, error) {
+	return 0, nil
+}
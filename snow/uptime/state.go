@@ -40,4 +40,13 @@ type State interface {
 		nodeID ids.NodeID,
 		subnetID ids.ID,
 	) (startTime time.Time, err error)
+
+	// GetEndTime returns the time that [nodeID] will stop validating
+	// [subnetID].
+	// Returns [database.ErrNotFound] if [nodeID] isn't currently a validator of
+	// the subnet.
+	GetEndTime(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+	) (endTime time.Time, err error)
 }
@@ -16,6 +16,7 @@ type uptime struct {
 	upDuration  time.Duration
 	lastUpdated time.Time
 	startTime   time.Time
+	endTime     time.Time
 }
 
 type TestState struct {
@@ -43,6 +44,12 @@ func (s *TestState) AddNode(nodeID ids.NodeID, subnetID ids.ID, startTime time.T
 	}
 }
 
+// SetEndTime sets the time that [nodeID] will stop validating [subnetID], which
+// must have been added with AddNode.
+func (s *TestState) SetEndTime(nodeID ids.NodeID, subnetID ids.ID, endTime time.Time) {
+	s.nodes[nodeID][subnetID].endTime = time.Unix(endTime.Unix(), 0)
+}
+
 func (s *TestState) GetUptime(nodeID ids.NodeID, subnetID ids.ID) (time.Duration, time.Time, error) {
 	up, exists := s.nodes[nodeID][subnetID]
 	if !exists {
@@ -68,3 +75,11 @@ func (s *TestState) GetStartTime(nodeID ids.NodeID, subnetID ids.ID) (time.Time,
 	}
 	return up.startTime, s.dbReadError
 }
+
+func (s *TestState) GetEndTime(nodeID ids.NodeID, subnetID ids.ID) (time.Time, error) {
+	up, exists := s.nodes[nodeID][subnetID]
+	if !exists {
+		return time.Time{}, database.ErrNotFound
+	}
+	return up.endTime, s.dbReadError
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var _ validators.SetCallbackListener = (*ValidatorTracker)(nil)
+
+// ValidatorTracker tracks the validators of a validator set, so that their
+// uptimes can be reported without holding the lock of the validator set.
+//
+// The tracker must be registered as a callback listener of the validator set.
+// Changes are applied synchronously with the validator set, so the tracker
+// never misses a change.
+type ValidatorTracker struct {
+	lock    sync.RWMutex
+	nodeIDs set.Set[ids.NodeID]
+}
+
+func NewValidatorTracker() *ValidatorTracker {
+	return &ValidatorTracker{}
+}
+
+func (t *ValidatorTracker) OnValidatorAdded(nodeID ids.NodeID, _ *bls.PublicKey, _ ids.ID, _ uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.nodeIDs.Add(nodeID)
+}
+
+func (t *ValidatorTracker) OnValidatorRemoved(nodeID ids.NodeID, _ uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.nodeIDs.Remove(nodeID)
+}
+
+func (*ValidatorTracker) OnValidatorWeightChanged(ids.NodeID, uint64, uint64) {}
+
+// NodeIDs returns the validators that are currently tracked.
+func (t *ValidatorTracker) NodeIDs() []ids.NodeID {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.nodeIDs.List()
+}
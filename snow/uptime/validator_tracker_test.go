@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+func TestValidatorTracker(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+
+	vdrs := validators.NewSet()
+	require.NoError(vdrs.Add(nodeID0, nil, ids.Empty, 1))
+
+	// Registering the tracker reports the validators already in the set.
+	tracker := NewValidatorTracker()
+	vdrs.RegisterCallbackListener(tracker)
+	require.Equal([]ids.NodeID{nodeID0}, tracker.NodeIDs())
+
+	require.NoError(vdrs.Add(nodeID1, nil, ids.Empty, 1))
+	require.NoError(vdrs.AddWeight(nodeID1, 1))
+	require.NoError(vdrs.Add(nodeID2, nil, ids.Empty, 1))
+	require.NoError(vdrs.RemoveWeight(nodeID0, 1))
+	require.NoError(vdrs.RemoveWeight(nodeID2, 1))
+	require.Equal([]ids.NodeID{nodeID1}, tracker.NodeIDs())
+}
@@ -135,6 +135,34 @@ func RemoveWeight(m Manager, subnetID ids.ID, nodeID ids.NodeID, weight uint64)
 	return vdrs.RemoveWeight(nodeID, weight)
 }
 
+// SetWeight is a helper that fetches the validator set of [subnetID] from [m]
+// and sets the weight of [nodeID] in the validator set to [weight]. If
+// [nodeID] isn't in the validator set, it is added without a BLS public key.
+// If [weight] is 0, [nodeID] is removed from the validator set.
+// Returns an error if:
+// - [subnetID] does not have a registered validator set in [m]
+// - modifying the weight of [nodeID] in the validator set returns an error
+func SetWeight(m Manager, subnetID ids.ID, nodeID ids.NodeID, weight uint64) error {
+	vdrs, ok := m.Get(subnetID)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMissingValidators, subnetID)
+	}
+
+	currentWeight := vdrs.GetWeight(nodeID)
+	switch {
+	case currentWeight == 0 && weight == 0:
+		return nil
+	case currentWeight == 0:
+		return vdrs.Add(nodeID, nil, ids.Empty, weight)
+	case weight > currentWeight:
+		return vdrs.AddWeight(nodeID, weight-currentWeight)
+	case weight < currentWeight:
+		return vdrs.RemoveWeight(nodeID, currentWeight-weight)
+	default:
+		return nil
+	}
+}
+
 // Contains is a helper that fetches the validator set of [subnetID] from [m]
 // and returns if the validator set contains [nodeID]. If [m] does not contain a
 // validator set for [subnetID], false is returned.
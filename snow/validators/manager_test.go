@@ -79,6 +79,37 @@ func TestRemoveWeight(t *testing.T) {
 	require.Zero(s.Weight())
 }
 
+func TestManagerSetWeight(t *testing.T) {
+	require := require.New(t)
+
+	m := NewManager()
+
+	subnetID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+
+	err := SetWeight(m, subnetID, nodeID, 1)
+	require.ErrorIs(err, ErrMissingValidators)
+
+	s := NewSet()
+	m.Add(subnetID, s)
+
+	require.NoError(SetWeight(m, subnetID, nodeID, 0))
+	require.False(s.Contains(nodeID))
+
+	require.NoError(SetWeight(m, subnetID, nodeID, 2))
+	require.Equal(uint64(2), s.GetWeight(nodeID))
+
+	require.NoError(SetWeight(m, subnetID, nodeID, 5))
+	require.Equal(uint64(5), s.GetWeight(nodeID))
+
+	require.NoError(SetWeight(m, subnetID, nodeID, 3))
+	require.Equal(uint64(3), s.GetWeight(nodeID))
+
+	require.NoError(SetWeight(m, subnetID, nodeID, 0))
+	require.False(s.Contains(nodeID))
+	require.Zero(s.Weight())
+}
+
 func TestContains(t *testing.T) {
 	require := require.New(t)
 
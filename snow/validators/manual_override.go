@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var _ State = (*manualOverride)(nil)
+
+type manualOverride struct {
+	State
+
+	// subnetID -> pinned validator set
+	overrides map[ids.ID]map[ids.NodeID]*GetValidatorOutput
+}
+
+// NewManualOverride returns a State that reports the validator sets in
+// [overrides] as the validator sets of their subnets at every height. The
+// validator sets of the other subnets are read from [state].
+//
+// Because the returned State wraps [state], overrides can be layered on top of
+// each other, with the outermost override taking precedence.
+func NewManualOverride(state State, overrides map[ids.ID]map[ids.NodeID]*GetValidatorOutput) State {
+	return &manualOverride{
+		State:     state,
+		overrides: overrides,
+	}
+}
+
+func (m *manualOverride) GetValidatorSet(
+	ctx context.Context,
+	height uint64,
+	subnetID ids.ID,
+) (map[ids.NodeID]*GetValidatorOutput, error) {
+	if vdrs, ok := m.overrides[subnetID]; ok {
+		return vdrs, nil
+	}
+	return m.State.GetValidatorSet(ctx, height, subnetID)
+}
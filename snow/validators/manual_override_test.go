@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestManualOverride(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx              = context.Background()
+		height    uint64 = 1337
+		subnetID0        = ids.GenerateTestID()
+		subnetID1        = ids.GenerateTestID()
+		subnetID2        = ids.GenerateTestID()
+	)
+	newValidatorSet := func() map[ids.NodeID]*GetValidatorOutput {
+		nodeID := ids.GenerateTestNodeID()
+		return map[ids.NodeID]*GetValidatorOutput{
+			nodeID: {
+				NodeID: nodeID,
+				Weight: 1,
+			},
+		}
+	}
+	pChainVdrs := newValidatorSet()
+	override0 := newValidatorSet()
+	override1 := newValidatorSet()
+	override1Replacement := newValidatorSet()
+
+	state := &TestState{
+		T: t,
+		GetValidatorSetF: func(_ context.Context, h uint64, _ ids.ID) (map[ids.NodeID]*GetValidatorOutput, error) {
+			require.Equal(height, h)
+			return pChainVdrs, nil
+		},
+	}
+
+	inner := NewManualOverride(state, map[ids.ID]map[ids.NodeID]*GetValidatorOutput{
+		subnetID0: override0,
+		subnetID1: override1,
+	})
+	outer := NewManualOverride(inner, map[ids.ID]map[ids.NodeID]*GetValidatorOutput{
+		subnetID1: override1Replacement,
+	})
+
+	tests := []struct {
+		subnetID     ids.ID
+		expectedVdrs map[ids.NodeID]*GetValidatorOutput
+	}{
+		{
+			subnetID:     subnetID0,
+			expectedVdrs: override0,
+		},
+		{
+			subnetID:     subnetID1,
+			expectedVdrs: override1Replacement,
+		},
+		{
+			subnetID:     subnetID2,
+			expectedVdrs: pChainVdrs,
+		},
+	}
+	for _, test := range tests {
+		vdrs, err := outer.GetValidatorSet(ctx, height, test.subnetID)
+		require.NoError(err)
+		require.Equal(test.expectedVdrs, vdrs)
+	}
+}
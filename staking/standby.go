@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// pendingSuffix is appended to the path of a key or certificate to get
+	// the path its next contents are staged at during a switch.
+	pendingSuffix = ".next"
+	// switchingSuffix is appended to the path of the active key to get the
+	// path of the file that marks the staged contents as complete.
+	switchingSuffix = ".switching"
+)
+
+var errSameKeyPair = errors.New("standby key pair is the same as the active key pair")
+
+// KeyPairPaths are the paths of a staking key and its certificate.
+type KeyPairPaths struct {
+	KeyPath  string `json:"keyPath"`
+	CertPath string `json:"certPath"`
+}
+
+// Load reads and parses the key pair at [p].
+func (p KeyPairPaths) Load() (*Certificate, error) {
+	cert, err := LoadTLSCertFromFiles(p.KeyPath, p.CertPath)
+	if err != nil {
+		return nil, err
+	}
+	return CertificateFromX509(cert.Leaf), nil
+}
+
+func (p KeyPairPaths) paths() []string {
+	return []string{p.KeyPath, p.CertPath}
+}
+
+// SwitchKeyPairs swaps the contents of the [active] and [standby] key pairs,
+// so that the node uses the standby key pair once it restarts. Calling
+// SwitchKeyPairs again before restarting swaps them back.
+//
+// The new contents are staged next to the files they replace and a marker
+// file is written once all of them are durable. If the switch is interrupted
+// after the marker was written, RecoverKeyPairSwitch completes it. Otherwise,
+// RecoverKeyPairSwitch discards the staged contents.
+func SwitchKeyPairs(active, standby KeyPairPaths) error {
+	activeCert, err := active.Load()
+	if err != nil {
+		return fmt.Errorf("couldn't load active key pair: %w", err)
+	}
+	standbyCert, err := standby.Load()
+	if err != nil {
+		return fmt.Errorf("couldn't load standby key pair: %w", err)
+	}
+	if bytes.Equal(activeCert.Raw, standbyCert.Raw) {
+		return errSameKeyPair
+	}
+
+	// Discard any switch that was interrupted before it was committed.
+	if err := RecoverKeyPairSwitch(active, standby); err != nil {
+		return err
+	}
+
+	// The active files get the standby contents and vice versa.
+	src := append(standby.paths(), active.paths()...)
+	dst := append(active.paths(), standby.paths()...)
+	for i, path := range dst {
+		contents, err := os.ReadFile(src[i])
+		if err != nil {
+			return err
+		}
+		if err := writeDurably(path+pendingSuffix, contents); err != nil {
+			return fmt.Errorf("couldn't stage %s: %w", path, err)
+		}
+	}
+
+	// Once the marker is durable, the switch is committed.
+	markerPath := active.KeyPath + switchingSuffix
+	if err := writeDurably(markerPath, nil); err != nil {
+		return fmt.Errorf("couldn't commit key pair switch: %w", err)
+	}
+	return RecoverKeyPairSwitch(active, standby)
+}
+
+// RecoverKeyPairSwitch completes the switch of the [active] and [standby] key
+// pairs if it was committed but not completed, and discards it if it wasn't
+// committed. It is a no-op if no switch was started.
+func RecoverKeyPairSwitch(active, standby KeyPairPaths) error {
+	markerPath := active.KeyPath + switchingSuffix
+	_, err := os.Stat(markerPath)
+	committed := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	paths := append(active.paths(), standby.paths()...)
+	for _, path := range paths {
+		pendingPath := path + pendingSuffix
+		_, err := os.Stat(pendingPath)
+		if errors.Is(err, os.ErrNotExist) {
+			// Either this file was already replaced or nothing was staged.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if !committed {
+			if err := os.Remove(pendingPath); err != nil {
+				return fmt.Errorf("couldn't discard %s: %w", pendingPath, err)
+			}
+			continue
+		}
+		if err := os.Rename(pendingPath, path); err != nil {
+			return fmt.Errorf("couldn't replace %s: %w", path, err)
+		}
+		if err := syncDir(filepath.Dir(path)); err != nil {
+			return err
+		}
+	}
+
+	if !committed {
+		return nil
+	}
+	if err := os.Remove(markerPath); err != nil {
+		return fmt.Errorf("couldn't complete key pair switch: %w", err)
+	}
+	return syncDir(filepath.Dir(markerPath))
+}
+
+// writeDurably writes [contents] to a new read-only file at [path] and flushes
+// it to disk.
+func writeDurably(path string, contents []byte) error {
+	f, err := perms.Create(path, perms.ReadOnly)
+	if err != nil {
+		return err
+	}
+
+	errs := wrappers.Errs{}
+	_, err = f.Write(contents)
+	errs.Add(err)
+	if !errs.Errored() {
+		errs.Add(f.Sync())
+	}
+	errs.Add(f.Close())
+	if errs.Errored() {
+		return errs.Err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir flushes the entries of the directory at [path] to disk, so that the
+// files created in or renamed into it are durable.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	errs := wrappers.Errs{}
+	errs.Add(dir.Sync(), dir.Close())
+	return errs.Err
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeyPairs(t *testing.T) (KeyPairPaths, KeyPairPaths) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	active := KeyPairPaths{
+		KeyPath:  filepath.Join(dir, "staker.key"),
+		CertPath: filepath.Join(dir, "staker.crt"),
+	}
+	standby := KeyPairPaths{
+		KeyPath:  filepath.Join(dir, "standby", "staker.key"),
+		CertPath: filepath.Join(dir, "standby", "staker.crt"),
+	}
+	require.NoError(InitNodeStakingKeyPair(active.KeyPath, active.CertPath))
+	require.NoError(InitNodeStakingKeyPair(standby.KeyPath, standby.CertPath))
+	return active, standby
+}
+
+// requireNoStagedFiles asserts that no switch is in progress.
+func requireNoStagedFiles(t *testing.T, active, standby KeyPairPaths) {
+	for _, path := range append(active.paths(), standby.paths()...) {
+		require.NoFileExists(t, path+pendingSuffix)
+	}
+	require.NoFileExists(t, active.KeyPath+switchingSuffix)
+}
+
+func TestSwitchKeyPairs(t *testing.T) {
+	require := require.New(t)
+
+	active, standby := newTestKeyPairs(t)
+	activeCert, err := active.Load()
+	require.NoError(err)
+	standbyCert, err := standby.Load()
+	require.NoError(err)
+
+	require.NoError(SwitchKeyPairs(active, standby))
+	requireNoStagedFiles(t, active, standby)
+
+	newActiveCert, err := active.Load()
+	require.NoError(err)
+	require.Equal(standbyCert, newActiveCert)
+	newStandbyCert, err := standby.Load()
+	require.NoError(err)
+	require.Equal(activeCert, newStandbyCert)
+
+	// Switching again restores the original key pairs.
+	require.NoError(SwitchKeyPairs(active, standby))
+	requireNoStagedFiles(t, active, standby)
+
+	newActiveCert, err = active.Load()
+	require.NoError(err)
+	require.Equal(activeCert, newActiveCert)
+}
+
+func TestSwitchKeyPairsSameKeyPair(t *testing.T) {
+	active, _ := newTestKeyPairs(t)
+
+	err := SwitchKeyPairs(active, active)
+	require.ErrorIs(t, err, errSameKeyPair)
+}
+
+func TestSwitchKeyPairsMissingStandby(t *testing.T) {
+	active, _ := newTestKeyPairs(t)
+
+	err := SwitchKeyPairs(active, KeyPairPaths{
+		KeyPath:  filepath.Join(t.TempDir(), "staker.key"),
+		CertPath: filepath.Join(t.TempDir(), "staker.crt"),
+	})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRecoverKeyPairSwitch(t *testing.T) {
+	tests := []struct {
+		name      string
+		committed bool
+	}{
+		{
+			name:      "committed",
+			committed: true,
+		},
+		{
+			name:      "not committed",
+			committed: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			active, standby := newTestKeyPairs(t)
+			activeCert, err := active.Load()
+			require.NoError(err)
+			standbyCert, err := standby.Load()
+			require.NoError(err)
+
+			// Stage the switch as SwitchKeyPairs would.
+			src := append(standby.paths(), active.paths()...)
+			dst := append(active.paths(), standby.paths()...)
+			for i, path := range dst {
+				contents, err := os.ReadFile(src[i])
+				require.NoError(err)
+				require.NoError(writeDurably(path+pendingSuffix, contents))
+			}
+			if test.committed {
+				require.NoError(writeDurably(active.KeyPath+switchingSuffix, nil))
+
+				// Simulate being interrupted after replacing the active key.
+				require.NoError(os.Rename(active.KeyPath+pendingSuffix, active.KeyPath))
+			}
+
+			require.NoError(RecoverKeyPairSwitch(active, standby))
+			requireNoStagedFiles(t, active, standby)
+
+			expectedActiveCert := activeCert
+			if test.committed {
+				expectedActiveCert = standbyCert
+			}
+			newActiveCert, err := active.Load()
+			require.NoError(err)
+			require.Equal(expectedActiveCert, newActiveCert)
+		})
+	}
+}
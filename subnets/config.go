@@ -9,11 +9,23 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
-var errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
+// DefaultBootstrapDependencyTimeout is the default maximum amount of time a
+// chain waits for its bootstrap dependencies.
+const DefaultBootstrapDependencyTimeout = 24 * time.Hour
+
+var (
+	errAllowedNodesWhenNotValidatorOnly  = errors.New("allowedNodes can only be set when ValidatorOnly is true")
+	errSelfBootstrapDependency           = errors.New("chain can't depend on itself")
+	errInvalidBootstrapDependencyTimeout = errors.New("bootstrapDependencyTimeout must be positive")
+	errInvalidGossipRedundancy           = errors.New("invalid gossip redundancy")
+	errInvalidProposerSchedule           = errors.New("invalid proposer schedule")
+	errArchiveWithoutPruning             = errors.New("proposerArchiveURL can only be set when proposerNumHistoricalBlocks is set")
+)
 
 type GossipConfig struct {
 	AcceptedFrontierValidatorSize    uint `json:"gossipAcceptedFrontierValidatorSize" yaml:"gossipAcceptedFrontierValidatorSize"`
@@ -25,6 +37,45 @@ type GossipConfig struct {
 	AppGossipValidatorSize           uint `json:"appGossipValidatorSize" yaml:"appGossipValidatorSize"`
 	AppGossipNonValidatorSize        uint `json:"appGossipNonValidatorSize" yaml:"appGossipNonValidatorSize"`
 	AppGossipPeerSize                uint `json:"appGossipPeerSize" yaml:"appGossipPeerSize"`
+
+	// RedundancyValidatorSize is the number of additional validators that
+	// containers are gossiped to for each redundancy level. The redundancy
+	// level is increased while the queries sent by the chain are failing, which
+	// helps containers propagate during partial network partitions. If 0, the
+	// number of validators gossiped to is never increased.
+	RedundancyValidatorSize uint `json:"gossipRedundancyValidatorSize" yaml:"gossipRedundancyValidatorSize"`
+	// RedundancyMaxLevel is the maximum redundancy level.
+	RedundancyMaxLevel uint `json:"gossipRedundancyMaxLevel" yaml:"gossipRedundancyMaxLevel"`
+	// RedundancyHighFailureRate is the rate of failed queries at or above
+	// which the redundancy level is increased.
+	RedundancyHighFailureRate float64 `json:"gossipRedundancyHighFailureRate" yaml:"gossipRedundancyHighFailureRate"`
+	// RedundancyLowFailureRate is the rate of failed queries at or below which
+	// the redundancy level is decreased. Rates between the low and the high
+	// rates leave the redundancy level unchanged.
+	RedundancyLowFailureRate float64 `json:"gossipRedundancyLowFailureRate" yaml:"gossipRedundancyLowFailureRate"`
+	// RedundancyWindow is the period over which the rate of failed queries is
+	// measured before the redundancy level is updated.
+	RedundancyWindow time.Duration `json:"gossipRedundancyWindow" yaml:"gossipRedundancyWindow"`
+}
+
+// Valid returns an error if the adaptive gossip redundancy is enabled with
+// invalid parameters.
+func (c *GossipConfig) Valid() error {
+	if c.RedundancyValidatorSize == 0 || c.RedundancyMaxLevel == 0 {
+		return nil
+	}
+	switch {
+	case c.RedundancyLowFailureRate < 0:
+		return fmt.Errorf("%w: low failure rate %f < 0", errInvalidGossipRedundancy, c.RedundancyLowFailureRate)
+	case c.RedundancyHighFailureRate > 1:
+		return fmt.Errorf("%w: high failure rate %f > 1", errInvalidGossipRedundancy, c.RedundancyHighFailureRate)
+	case c.RedundancyLowFailureRate >= c.RedundancyHighFailureRate:
+		return fmt.Errorf("%w: low failure rate %f >= high failure rate %f", errInvalidGossipRedundancy, c.RedundancyLowFailureRate, c.RedundancyHighFailureRate)
+	case c.RedundancyWindow <= 0:
+		return fmt.Errorf("%w: window %s <= 0", errInvalidGossipRedundancy, c.RedundancyWindow)
+	default:
+		return nil
+	}
 }
 
 type Config struct {
@@ -63,6 +114,71 @@ type Config struct {
 	// TODO: Move this flag once the proposervm is configurable on a per-chain
 	// basis.
 	ProposerNumHistoricalBlocks uint64 `json:"proposerNumHistoricalBlocks" yaml:"proposerNumHistoricalBlocks"`
+	// ProposerMaxWindows is the number of validators that are given a window,
+	// in order, to build a snowman++ block before any validator may build one.
+	// If 0, the Primary Network's value is used.
+	//
+	// Note: Every validator of this Subnet must use the same value, otherwise
+	// they will disagree on the validity of blocks.
+	ProposerMaxWindows int `json:"proposerMaxWindows" yaml:"proposerMaxWindows"`
+	// ProposerWindowDuration is the length of each proposer window. If 0, the
+	// Primary Network's value is used.
+	//
+	// Note: Every validator of this Subnet must use the same value, otherwise
+	// they will disagree on the validity of blocks.
+	ProposerWindowDuration time.Duration `json:"proposerWindowDuration" yaml:"proposerWindowDuration"`
+	// ProposerArchiveURL is the URL of an object store, such as an S3 bucket,
+	// that snowman++ blocks are moved to rather than deleted when they are
+	// pruned. Archived blocks are fetched from the object store when they are
+	// requested. If empty, pruned blocks are deleted.
+	//
+	// Note: This requires [ProposerNumHistoricalBlocks] to be set.
+	ProposerArchiveURL string `json:"proposerArchiveURL" yaml:"proposerArchiveURL"`
+
+	// BootstrapDependencies maps chains of this Subnet to the chains that must
+	// finish bootstrapping before they are created. The dependencies may be
+	// chains of any tracked Subnet.
+	//
+	// Note: All chains are created after the P-chain has finished
+	// bootstrapping, so it never needs to be declared as a dependency.
+	BootstrapDependencies map[ids.ID][]ids.ID `json:"bootstrapDependencies" yaml:"bootstrapDependencies"`
+
+	// BootstrapDependencyTimeout is the maximum amount of time a chain of
+	// this Subnet waits for its bootstrap dependencies. If the dependencies
+	// haven't finished bootstrapping by then, the chain isn't created.
+	BootstrapDependencyTimeout time.Duration `json:"bootstrapDependencyTimeout" yaml:"bootstrapDependencyTimeout"`
+
+	// BootstrapConcurrency is the maximum number of this Subnet's chains that
+	// bootstrap at the same time. Chains beyond the limit are created once
+	// another chain of this Subnet finishes bootstrapping. If 0, the number of
+	// chains is unlimited.
+	//
+	// Note: The P-chain is always created immediately and doesn't count
+	// towards the limit of the Primary Network.
+	BootstrapConcurrency uint `json:"bootstrapConcurrency" yaml:"bootstrapConcurrency"`
+
+	// TrafficShaping specifies how the messages of this Subnet are padded and
+	// delayed when they are sent to peers.
+	TrafficShaping TrafficShapingConfig `json:"trafficShaping" yaml:"trafficShaping"`
+
+	// Limits restrict the size of the blocks and transactions of this Subnet's
+	// chains. Blocks larger than [Limits.MaxBlockSize] are neither sent nor
+	// accepted from peers. The transaction limits are enforced by the VMs.
+	Limits snow.Limits `json:"limits" yaml:"limits"`
+
+	// FinalityCertificates enables finality certificates on this Subnet's
+	// chains. This node signs the last accepted block it reports to peers with
+	// its BLS key, and aggregates the signatures reported by the validators
+	// into certificates that are served by the chain's finality API.
+	// Certificates are only created if validators holding enough stake enable
+	// this option.
+	FinalityCertificates bool `json:"finalityCertificates" yaml:"finalityCertificates"`
+
+	// ValidatorOverrides pins the validator set of this Subnet, ignoring the
+	// validators registered on the P-chain. This is only intended for local
+	// test and development networks, such as single node networks, and isn't
+	// allowed on public networks.
+	ValidatorOverrides []ValidatorOverride `json:"validatorOverrides" yaml:"validatorOverrides"`
 }
 
 func (c *Config) Valid() error {
@@ -72,5 +188,36 @@ func (c *Config) Valid() error {
 	if !c.ValidatorOnly && c.AllowedNodes.Len() > 0 {
 		return errAllowedNodesWhenNotValidatorOnly
 	}
+	if err := c.GossipConfig.Valid(); err != nil {
+		return fmt.Errorf("gossip %w", err)
+	}
+	if err := c.TrafficShaping.Valid(); err != nil {
+		return fmt.Errorf("traffic shaping %w", err)
+	}
+	if err := verifyLimits(c.Limits); err != nil {
+		return fmt.Errorf("limits %w", err)
+	}
+	if c.ProposerMaxWindows < 0 {
+		return fmt.Errorf("%w: max windows %d < 0", errInvalidProposerSchedule, c.ProposerMaxWindows)
+	}
+	if c.ProposerWindowDuration < 0 {
+		return fmt.Errorf("%w: window duration %s < 0", errInvalidProposerSchedule, c.ProposerWindowDuration)
+	}
+	if c.ProposerArchiveURL != "" && c.ProposerNumHistoricalBlocks == 0 {
+		return errArchiveWithoutPruning
+	}
+	if _, _, err := c.ValidatorOverrideSet(); err != nil {
+		return fmt.Errorf("validator overrides %w", err)
+	}
+	for chainID, dependencies := range c.BootstrapDependencies {
+		for _, dependency := range dependencies {
+			if dependency == chainID {
+				return fmt.Errorf("%w: %s", errSelfBootstrapDependency, chainID)
+			}
+		}
+	}
+	if len(c.BootstrapDependencies) != 0 && c.BootstrapDependencyTimeout <= 0 {
+		return errInvalidBootstrapDependencyTimeout
+	}
 	return nil
 }
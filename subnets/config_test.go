@@ -4,13 +4,19 @@
 package subnets
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 var validParameters = snowball.Parameters{
@@ -49,6 +55,183 @@ func TestValid(t *testing.T) {
 			},
 			expectedErr: errAllowedNodesWhenNotValidatorOnly,
 		},
+		{
+			name: "self bootstrap dependency",
+			s: Config{
+				ConsensusParameters: validParameters,
+				BootstrapDependencies: map[ids.ID][]ids.ID{
+					{1}: {{2}, {1}},
+				},
+			},
+			expectedErr: errSelfBootstrapDependency,
+		},
+		{
+			name: "invalid bootstrap dependency timeout",
+			s: Config{
+				ConsensusParameters: validParameters,
+				BootstrapDependencies: map[ids.ID][]ids.ID{
+					{1}: {{2}},
+				},
+			},
+			expectedErr: errInvalidBootstrapDependencyTimeout,
+		},
+		{
+			name: "unsorted padding buckets",
+			s: Config{
+				ConsensusParameters: validParameters,
+				TrafficShaping: TrafficShapingConfig{
+					PaddingBuckets: []uint32{1024, 512},
+				},
+			},
+			expectedErr: errPaddingBucketsNotSorted,
+		},
+		{
+			name: "zero padding bucket",
+			s: Config{
+				ConsensusParameters: validParameters,
+				TrafficShaping: TrafficShapingConfig{
+					PaddingBuckets: []uint32{0, 512},
+				},
+			},
+			expectedErr: errZeroPaddingBucket,
+		},
+		{
+			name: "padding bucket too large",
+			s: Config{
+				ConsensusParameters: validParameters,
+				TrafficShaping: TrafficShapingConfig{
+					PaddingBuckets: []uint32{math.MaxUint32},
+				},
+			},
+			expectedErr: errPaddingBucketTooLarge,
+		},
+		{
+			name: "negative send jitter",
+			s: Config{
+				ConsensusParameters: validParameters,
+				TrafficShaping: TrafficShapingConfig{
+					MaxSendJitter: -time.Second,
+				},
+			},
+			expectedErr: errNegativeMaxSendJitter,
+		},
+		{
+			name: "invalid gossip redundancy",
+			s: Config{
+				ConsensusParameters: validParameters,
+				GossipConfig: GossipConfig{
+					RedundancyValidatorSize:   1,
+					RedundancyMaxLevel:        1,
+					RedundancyHighFailureRate: .1,
+					RedundancyLowFailureRate:  .2,
+					RedundancyWindow:          time.Second,
+				},
+			},
+			expectedErr: errInvalidGossipRedundancy,
+		},
+		{
+			name: "negative proposer window duration",
+			s: Config{
+				ConsensusParameters:    validParameters,
+				ProposerWindowDuration: -time.Second,
+			},
+			expectedErr: errInvalidProposerSchedule,
+		},
+		{
+			name: "archive without pruning",
+			s: Config{
+				ConsensusParameters: validParameters,
+				ProposerArchiveURL:  "https://archive.example.com",
+			},
+			expectedErr: errArchiveWithoutPruning,
+		},
+		{
+			name: "negative limit",
+			s: Config{
+				ConsensusParameters: validParameters,
+				Limits: snow.Limits{
+					MaxBlockTxs: -1,
+				},
+			},
+			expectedErr: errNegativeLimit,
+		},
+		{
+			name: "block size too large",
+			s: Config{
+				ConsensusParameters: validParameters,
+				Limits: snow.Limits{
+					MaxBlockSize: constants.MaxContainersLen + 1,
+				},
+			},
+			expectedErr: errBlockSizeTooLarge,
+		},
+		{
+			name: "tx size larger than block size",
+			s: Config{
+				ConsensusParameters: validParameters,
+				Limits: snow.Limits{
+					MaxBlockSize: units.MiB,
+					MaxTxSize:    units.MiB + 1,
+				},
+			},
+			expectedErr: errTxSizeTooLarge,
+		},
+		{
+			name: "tx size too large",
+			s: Config{
+				ConsensusParameters: validParameters,
+				Limits: snow.Limits{
+					MaxTxSize: constants.MaxContainersLen + 1,
+				},
+			},
+			expectedErr: errTxSizeTooLarge,
+		},
+		{
+			name: "zero validator override weight",
+			s: Config{
+				ConsensusParameters: validParameters,
+				ValidatorOverrides: []ValidatorOverride{
+					{
+						NodeID: ids.GenerateTestNodeID(),
+					},
+				},
+			},
+			expectedErr: errZeroOverrideWeight,
+		},
+		{
+			name: "duplicate validator override",
+			s: Config{
+				ConsensusParameters: validParameters,
+				ValidatorOverrides: []ValidatorOverride{
+					{
+						NodeID: ids.EmptyNodeID,
+						Weight: 1,
+					},
+					{
+						NodeID: ids.EmptyNodeID,
+						Weight: 1,
+					},
+				},
+			},
+			expectedErr: errDuplicateOverrideNodeID,
+		},
+		{
+			name: "validator override weight overflow",
+			s: Config{
+				ConsensusParameters: validParameters,
+				ValidatorOverrides: []ValidatorOverride{
+					{
+						NodeID: ids.GenerateTestNodeID(),
+						Weight: math.MaxUint64,
+					},
+					{
+						NodeID: ids.GenerateTestNodeID(),
+						Weight: 1,
+					},
+				},
+			},
+			expectedErr: safemath.ErrOverflow,
+		},
 		{
 			name: "valid",
 			s: Config{
@@ -57,6 +240,29 @@ func TestValid(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "valid limits",
+			s: Config{
+				ConsensusParameters: validParameters,
+				Limits: snow.Limits{
+					MaxBlockSize: units.MiB,
+					MaxBlockTxs:  10_000,
+					MaxTxSize:    64 * units.KiB,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "valid traffic shaping",
+			s: Config{
+				ConsensusParameters: validParameters,
+				TrafficShaping: TrafficShapingConfig{
+					PaddingBuckets: []uint32{512, 1024, 4096},
+					MaxSendJitter:  10 * time.Millisecond,
+				},
+			},
+			expectedErr: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -65,3 +271,13 @@ func TestValid(t *testing.T) {
 		})
 	}
 }
+
+func TestMaxContainerSize(t *testing.T) {
+	require := require.New(t)
+
+	c := Config{}
+	require.Equal(constants.MaxContainersLen, c.MaxContainerSize())
+
+	c.Limits.MaxBlockSize = units.MiB
+	require.Equal(units.MiB, c.MaxContainerSize())
+}
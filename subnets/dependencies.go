@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnets
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var errBootstrapDependencyCycle = errors.New("bootstrap dependency cycle")
+
+// VerifyBootstrapDependencies returns an error if the bootstrap dependencies
+// declared across [configs] contain a cycle. Chains that are part of a cycle
+// would never be created.
+func VerifyBootstrapDependencies(configs map[ids.ID]Config) error {
+	dependencies := make(map[ids.ID][]ids.ID)
+	for _, config := range configs {
+		for chainID, chainDependencies := range config.BootstrapDependencies {
+			dependencies[chainID] = append(dependencies[chainID], chainDependencies...)
+		}
+	}
+
+	var (
+		visited  set.Set[ids.ID]
+		visiting set.Set[ids.ID]
+		visit    func(chainID ids.ID) error
+	)
+	visit = func(chainID ids.ID) error {
+		if visited.Contains(chainID) {
+			return nil
+		}
+		if visiting.Contains(chainID) {
+			return fmt.Errorf("%w: includes %s", errBootstrapDependencyCycle, chainID)
+		}
+
+		visiting.Add(chainID)
+		for _, dependency := range dependencies[chainID] {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		visiting.Remove(chainID)
+		visited.Add(chainID)
+		return nil
+	}
+
+	for chainID := range dependencies {
+		if err := visit(chainID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
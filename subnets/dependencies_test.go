@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestVerifyBootstrapDependencies(t *testing.T) {
+	tests := []struct {
+		name        string
+		configs     map[ids.ID]Config
+		expectedErr error
+	}{
+		{
+			name:    "no dependencies",
+			configs: map[ids.ID]Config{{1}: {}},
+		},
+		{
+			name: "dependencies across subnets",
+			configs: map[ids.ID]Config{
+				{1}: {
+					BootstrapDependencies: map[ids.ID][]ids.ID{
+						{10}: {{11}, {20}},
+						{11}: {{20}},
+					},
+				},
+				{2}: {
+					BootstrapDependencies: map[ids.ID][]ids.ID{
+						{20}: {{21}},
+					},
+				},
+			},
+		},
+		{
+			name: "cycle across subnets",
+			configs: map[ids.ID]Config{
+				{1}: {
+					BootstrapDependencies: map[ids.ID][]ids.ID{
+						{10}: {{20}},
+					},
+				},
+				{2}: {
+					BootstrapDependencies: map[ids.ID][]ids.ID{
+						{20}: {{21}},
+						{21}: {{10}},
+					},
+				},
+			},
+			expectedErr: errBootstrapDependencyCycle,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyBootstrapDependencies(test.configs)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
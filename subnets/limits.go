@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnets
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var (
+	errNegativeLimit     = errors.New("limit must be non-negative")
+	errBlockSizeTooLarge = errors.New("max block size exceeds the maximum container size")
+	errTxSizeTooLarge    = errors.New("max tx size exceeds the max block size")
+)
+
+// MaxContainerSize returns the maximum number of bytes of a container of this
+// Subnet's chains.
+func (c *Config) MaxContainerSize() int {
+	if c.Limits.MaxBlockSize == 0 {
+		return constants.MaxContainersLen
+	}
+	return c.Limits.MaxBlockSize
+}
+
+func verifyLimits(limits snow.Limits) error {
+	switch {
+	case limits.MaxBlockSize < 0:
+		return fmt.Errorf("%w: maxBlockSize = %d", errNegativeLimit, limits.MaxBlockSize)
+	case limits.MaxBlockTxs < 0:
+		return fmt.Errorf("%w: maxBlockTxs = %d", errNegativeLimit, limits.MaxBlockTxs)
+	case limits.MaxTxSize < 0:
+		return fmt.Errorf("%w: maxTxSize = %d", errNegativeLimit, limits.MaxTxSize)
+	case limits.MaxBlockSize > constants.MaxContainersLen:
+		return fmt.Errorf("%w: %d > %d", errBlockSizeTooLarge, limits.MaxBlockSize, constants.MaxContainersLen)
+	case limits.MaxBlockSize != 0 && limits.MaxTxSize > limits.MaxBlockSize:
+		return fmt.Errorf("%w: %d > %d", errTxSizeTooLarge, limits.MaxTxSize, limits.MaxBlockSize)
+	case limits.MaxTxSize > constants.MaxContainersLen:
+		return fmt.Errorf("%w: %d > %d", errTxSizeTooLarge, limits.MaxTxSize, constants.MaxContainersLen)
+	default:
+		return nil
+	}
+}
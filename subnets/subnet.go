@@ -27,6 +27,16 @@ type Subnet interface {
 	// AddChain adds a chain to this Subnet
 	AddChain(chainID ids.ID) bool
 
+	// AcquireBootstrapSlot blocks until fewer than [Config.BootstrapConcurrency]
+	// of this Subnet's chains hold a slot, and then gives a slot to [chainID].
+	// The slot is released once the chain finishes bootstrapping.
+	//
+	// Returns false if [done] is closed before a slot is acquired.
+	AcquireBootstrapSlot(chainID ids.ID, done <-chan struct{}) bool
+
+	// ReleaseBootstrapSlot releases the slot held by [chainID], if any.
+	ReleaseBootstrapSlot(chainID ids.ID)
+
 	// Config returns config of this Subnet
 	Config() Config
 
@@ -41,14 +51,23 @@ type subnet struct {
 	bootstrappedSema chan struct{}
 	config           Config
 	myNodeID         ids.NodeID
+
+	// Nil if the number of chains bootstrapping at once isn't limited.
+	bootstrapSlots chan struct{}
+	// Chains that currently hold a slot in [bootstrapSlots].
+	slotHolders set.Set[ids.ID]
 }
 
 func New(myNodeID ids.NodeID, config Config) Subnet {
-	return &subnet{
+	s := &subnet{
 		bootstrappedSema: make(chan struct{}),
 		config:           config,
 		myNodeID:         myNodeID,
 	}
+	if config.BootstrapConcurrency > 0 {
+		s.bootstrapSlots = make(chan struct{}, config.BootstrapConcurrency)
+	}
+	return s
 }
 
 func (s *subnet) IsBootstrapped() bool {
@@ -64,6 +83,7 @@ func (s *subnet) Bootstrapped(chainID ids.ID) {
 
 	s.bootstrapping.Remove(chainID)
 	s.bootstrapped.Add(chainID)
+	s.releaseBootstrapSlot(chainID)
 	if s.bootstrapping.Len() > 0 {
 		return
 	}
@@ -89,6 +109,40 @@ func (s *subnet) AddChain(chainID ids.ID) bool {
 	return true
 }
 
+func (s *subnet) AcquireBootstrapSlot(chainID ids.ID, done <-chan struct{}) bool {
+	if s.bootstrapSlots == nil {
+		return true
+	}
+
+	select {
+	case s.bootstrapSlots <- struct{}{}:
+	case <-done:
+		return false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.slotHolders.Add(chainID)
+	return true
+}
+
+func (s *subnet) ReleaseBootstrapSlot(chainID ids.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.releaseBootstrapSlot(chainID)
+}
+
+// Assumes [s.lock] is held.
+func (s *subnet) releaseBootstrapSlot(chainID ids.ID) {
+	if !s.slotHolders.Contains(chainID) {
+		return
+	}
+	s.slotHolders.Remove(chainID)
+	<-s.bootstrapSlots
+}
+
 func (s *subnet) Config() Config {
 	return s.config
 }
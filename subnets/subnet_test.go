@@ -5,6 +5,7 @@ package subnets
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -68,3 +69,57 @@ func TestIsAllowed(t *testing.T) {
 	require.False(s.IsAllowed(ids.GenerateTestNodeID(), false), "Non-validator should not be allowed with validator only rules and allowed nodes")
 	require.True(s.IsAllowed(allowedNodeID, true), "Non-validator allowed node should be allowed with validator only rules and allowed nodes")
 }
+
+func TestSubnetBootstrapSlots(t *testing.T) {
+	require := require.New(t)
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+	chainID2 := ids.GenerateTestID()
+
+	s := New(ids.GenerateTestNodeID(), Config{
+		BootstrapConcurrency: 1,
+	})
+	s.AddChain(chainID0)
+	s.AddChain(chainID1)
+	s.AddChain(chainID2)
+
+	done := make(chan struct{})
+	require.True(s.AcquireBootstrapSlot(chainID0, done))
+
+	acquired := make(chan bool)
+	go func() {
+		acquired <- s.AcquireBootstrapSlot(chainID1, done)
+	}()
+	select {
+	case <-acquired:
+		require.FailNow("acquired a slot beyond the concurrency limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Finishing bootstrapping releases the slot.
+	s.Bootstrapped(chainID0)
+	require.True(<-acquired)
+
+	// Releasing the slot of a chain that doesn't hold one is a no-op.
+	s.ReleaseBootstrapSlot(chainID2)
+
+	go func() {
+		acquired <- s.AcquireBootstrapSlot(chainID2, done)
+	}()
+	close(done)
+	require.False(<-acquired)
+
+	s.ReleaseBootstrapSlot(chainID1)
+	require.True(s.AcquireBootstrapSlot(chainID2, make(chan struct{})))
+}
+
+func TestSubnetUnlimitedBootstrapSlots(t *testing.T) {
+	require := require.New(t)
+
+	s := New(ids.GenerateTestNodeID(), Config{})
+	done := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		require.True(s.AcquireBootstrapSlot(ids.GenerateTestID(), done))
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnets
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var (
+	errZeroPaddingBucket       = errors.New("padding bucket sizes must be positive")
+	errPaddingBucketTooLarge   = errors.New("padding bucket size exceeds the maximum message size")
+	errPaddingBucketsNotSorted = errors.New("padding bucket sizes must be strictly increasing")
+	errNegativeMaxSendJitter   = errors.New("max send jitter must be non-negative")
+)
+
+// TrafficShapingConfig specifies how the messages of a Subnet are disguised to
+// resist traffic analysis between peers.
+//
+// Padding is sent over the peer's TLS connection, so it is authenticated along
+// with the rest of the message.
+type TrafficShapingConfig struct {
+	// PaddingBuckets are the sizes, in bytes, that outbound messages are
+	// padded to. A message is padded to the smallest bucket that it fits in.
+	// Messages larger than the largest bucket aren't padded.
+	PaddingBuckets []uint32 `json:"paddingBuckets" yaml:"paddingBuckets"`
+	// MaxSendJitter is the maximum random delay before an outbound message is
+	// written to the peer.
+	MaxSendJitter time.Duration `json:"maxSendJitter" yaml:"maxSendJitter"`
+}
+
+// Enabled returns true if messages should be padded or delayed.
+func (c *TrafficShapingConfig) Enabled() bool {
+	return len(c.PaddingBuckets) > 0 || c.MaxSendJitter > 0
+}
+
+func (c *TrafficShapingConfig) Valid() error {
+	for i, bucket := range c.PaddingBuckets {
+		switch {
+		case bucket == 0:
+			return errZeroPaddingBucket
+		case bucket > constants.DefaultMaxMessageSize:
+			return fmt.Errorf("%w: %d > %d", errPaddingBucketTooLarge, bucket, constants.DefaultMaxMessageSize)
+		case i > 0 && bucket <= c.PaddingBuckets[i-1]:
+			return fmt.Errorf("%w: %d after %d", errPaddingBucketsNotSorted, bucket, c.PaddingBuckets[i-1])
+		}
+	}
+	if c.MaxSendJitter < 0 {
+		return fmt.Errorf("%w: %s", errNegativeMaxSendJitter, c.MaxSendJitter)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnets
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errZeroOverrideWeight      = errors.New("validator override weight must be positive")
+	errDuplicateOverrideNodeID = errors.New("duplicate validator override")
+)
+
+// ValidatorOverride is a validator of a Subnet whose validator set is pinned
+// by the Subnet's config.
+type ValidatorOverride struct {
+	NodeID ids.NodeID `json:"nodeID" yaml:"nodeID"`
+	// PublicKey is the hex encoded BLS public key of the validator. It may be
+	// empty if the validator doesn't sign warp messages.
+	PublicKey string `json:"publicKey" yaml:"publicKey"`
+	Weight    uint64 `json:"weight"    yaml:"weight"`
+}
+
+// ValidatorOverrideSet returns the validator set pinned by
+// [ValidatorOverrides]. Returns false if the validator set of this Subnet
+// isn't pinned.
+func (c *Config) ValidatorOverrideSet() (map[ids.NodeID]*validators.GetValidatorOutput, bool, error) {
+	if len(c.ValidatorOverrides) == 0 {
+		return nil, false, nil
+	}
+
+	var (
+		vdrs        = make(map[ids.NodeID]*validators.GetValidatorOutput, len(c.ValidatorOverrides))
+		totalWeight uint64
+	)
+	for _, override := range c.ValidatorOverrides {
+		if override.Weight == 0 {
+			return nil, false, fmt.Errorf("%w: %s", errZeroOverrideWeight, override.NodeID)
+		}
+		if _, ok := vdrs[override.NodeID]; ok {
+			return nil, false, fmt.Errorf("%w: %s", errDuplicateOverrideNodeID, override.NodeID)
+		}
+		var err error
+		totalWeight, err = math.Add64(totalWeight, override.Weight)
+		if err != nil {
+			return nil, false, fmt.Errorf("validator override weight %w", err)
+		}
+
+		pk, err := parsePublicKey(override.PublicKey)
+		if err != nil {
+			return nil, false, fmt.Errorf("couldn't parse public key of %s: %w", override.NodeID, err)
+		}
+
+		vdrs[override.NodeID] = &validators.GetValidatorOutput{
+			NodeID:    override.NodeID,
+			PublicKey: pk,
+			Weight:    override.Weight,
+		}
+	}
+	return vdrs, true, nil
+}
+
+// parsePublicKey parses a hex encoded BLS public key. Returns nil if [pkStr] is
+// empty.
+func parsePublicKey(pkStr string) (*bls.PublicKey, error) {
+	if pkStr == "" {
+		return nil, nil
+	}
+	pkBytes, err := formatting.Decode(formatting.HexNC, pkStr)
+	if err != nil {
+		return nil, err
+	}
+	return bls.PublicKeyFromBytes(pkBytes)
+}
+
+// NewValidatorOverrideState returns [state] with the validator sets of the
+// Subnets in [configs] that pin their validator set replaced by the pinned
+// validator sets.
+func NewValidatorOverrideState(state validators.State, configs map[ids.ID]Config) (validators.State, error) {
+	overrides := make(map[ids.ID]map[ids.NodeID]*validators.GetValidatorOutput)
+	for subnetID, config := range configs {
+		vdrs, ok, err := config.ValidatorOverrideSet()
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator overrides of subnet %s: %w", subnetID, err)
+		}
+		if ok {
+			overrides[subnetID] = vdrs
+		}
+	}
+	if len(overrides) == 0 {
+		return state, nil
+	}
+	return validators.NewManualOverride(state, overrides), nil
+}
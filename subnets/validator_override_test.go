@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+func TestNewValidatorOverrideState(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+	pkStr, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(pk))
+	require.NoError(err)
+
+	var (
+		ctx                = context.Background()
+		overriddenSubnetID = ids.GenerateTestID()
+		subnetID           = ids.GenerateTestID()
+		nodeID0            = ids.GenerateTestNodeID()
+		nodeID1            = ids.GenerateTestNodeID()
+		pChainVdrs         = map[ids.NodeID]*validators.GetValidatorOutput{}
+	)
+	state := &validators.TestState{
+		T: t,
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return pChainVdrs, nil
+		},
+	}
+
+	configs := map[ids.ID]Config{
+		overriddenSubnetID: {
+			ValidatorOverrides: []ValidatorOverride{
+				{
+					NodeID:    nodeID0,
+					PublicKey: pkStr,
+					Weight:    10,
+				},
+				{
+					NodeID: nodeID1,
+					Weight: 20,
+				},
+			},
+		},
+		subnetID: {},
+	}
+	overrideState, err := NewValidatorOverrideState(state, configs)
+	require.NoError(err)
+
+	vdrs, err := overrideState.GetValidatorSet(ctx, 0, overriddenSubnetID)
+	require.NoError(err)
+	require.Equal(
+		map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID0: {
+				NodeID:    nodeID0,
+				PublicKey: pk,
+				Weight:    10,
+			},
+			nodeID1: {
+				NodeID: nodeID1,
+				Weight: 20,
+			},
+		},
+		vdrs,
+	)
+
+	vdrs, err = overrideState.GetValidatorSet(ctx, 0, subnetID)
+	require.NoError(err)
+	require.Equal(pChainVdrs, vdrs)
+
+	// Without overrides, the state isn't wrapped.
+	overrideState, err = NewValidatorOverrideState(state, map[ids.ID]Config{
+		subnetID: {},
+	})
+	require.NoError(err)
+	require.Equal(state, overrideState)
+}
@@ -66,6 +66,10 @@ type TestEnvironment struct {
 	URIs []testnet.NodeURI
 	// The URI used to access the http server that allocates test data
 	TestDataServerURI string
+	// The avalanchego executable path the nodes of the network are
+	// upgraded to by the upgrade tests. The upgrade tests are skipped
+	// if not set.
+	UpgradeExecPath string
 
 	require *require.Assertions
 }
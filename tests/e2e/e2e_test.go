@@ -29,6 +29,7 @@ import (
 	_ "github.com/ava-labs/avalanchego/tests/e2e/faultinjection"
 	_ "github.com/ava-labs/avalanchego/tests/e2e/p"
 	_ "github.com/ava-labs/avalanchego/tests/e2e/static-handlers"
+	_ "github.com/ava-labs/avalanchego/tests/e2e/upgrade"
 	_ "github.com/ava-labs/avalanchego/tests/e2e/x"
 	_ "github.com/ava-labs/avalanchego/tests/e2e/x/transfer"
 )
@@ -39,9 +40,10 @@ func TestE2E(t *testing.T) {
 }
 
 var (
-	avalancheGoExecPath  string
-	persistentNetworkDir string
-	usePersistentNetwork bool
+	avalancheGoExecPath          string
+	avalancheGoExecPathToUpgrade string
+	persistentNetworkDir         string
+	usePersistentNetwork         bool
 )
 
 func init() {
@@ -51,6 +53,12 @@ func init() {
 		os.Getenv(local.AvalancheGoPathEnvName),
 		fmt.Sprintf("avalanchego executable path (required if not using a persistent network). Also possible to configure via the %s env variable.", local.AvalancheGoPathEnvName),
 	)
+	flag.StringVar(
+		&avalancheGoExecPathToUpgrade,
+		"avalanchego-path-to-upgrade-to",
+		"",
+		"[optional] avalanchego executable path to upgrade the nodes of the network to. The upgrade tests are skipped if not provided.",
+	)
 	flag.StringVar(
 		&persistentNetworkDir,
 		"network-dir",
@@ -123,6 +131,7 @@ var _ = ginkgo.SynchronizedBeforeSuite(func() []byte {
 		NetworkDir:        network.Dir,
 		URIs:              uris,
 		TestDataServerURI: testDataServerURI,
+		UpgradeExecPath:   avalancheGoExecPathToUpgrade,
 	}
 	bytes, err := json.Marshal(env)
 	require.NoError(err)
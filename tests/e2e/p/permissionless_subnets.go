@@ -17,7 +17,6 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
-	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
@@ -78,18 +77,12 @@ var _ = e2e.DescribePChain("[Permissionless Subnets]", func() {
 			var subnetAssetID ids.ID
 			ginkgo.By("create a custom asset for the permissionless subnet", func() {
 				ctx, cancel := context.WithTimeout(context.Background(), e2e.DefaultTimeout)
-				subnetAssetTx, err := xWallet.IssueCreateAssetTx(
+				subnetAssetTx, err := xWallet.IssueCreateSubnetAssetTx(
 					"RnM",
 					"RNM",
 					9,
-					map[uint32][]verify.State{
-						0: {
-							&secp256k1fx.TransferOutput{
-								Amt:          100 * units.MegaAvax,
-								OutputOwners: *owner,
-							},
-						},
-					},
+					100*units.MegaAvax,
+					owner,
 					common.WithContext(ctx),
 				)
 				cancel()
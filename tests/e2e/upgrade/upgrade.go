@@ -0,0 +1,176 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Implements tests that upgrade the nodes of a network from the
+// avalanchego binary the network was started with to the binary
+// identified by --avalanchego-path-to-upgrade-to.
+package upgrade
+
+import (
+	"time"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/tests"
+	"github.com/ava-labs/avalanchego/tests/e2e"
+	"github.com/ava-labs/avalanchego/tests/fixture/testnet"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+)
+
+// The uptime, as a percentage, that a node is expected to maintain
+// across a rolling upgrade. Every node is briefly offline while it is
+// upgraded, so this is lower than the default uptime requirement.
+const minUptimePercentage = 50
+
+var _ = ginkgo.Describe("[Upgrade]", ginkgo.Serial, ginkgo.Label("upgrade"), func() {
+	require := require.New(ginkgo.GinkgoT())
+
+	ginkgo.It("should preserve consensus, uptime and wallet operations across a rolling upgrade", func() {
+		if len(e2e.Env.UpgradeExecPath) == 0 {
+			ginkgo.Skip("no avalanchego binary to upgrade to was provided")
+		}
+
+		network := e2e.Env.GetNetwork()
+		nodeURI := e2e.Env.GetRandomNodeURI()
+		keychain := e2e.Env.NewKeychain(1)
+		rewardAddr := keychain.Keys[0].Address()
+		owner := &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardAddr},
+		}
+
+		ginkgo.By("generating state with the binary the network was started with")
+		wallet := e2e.Env.NewWallet(keychain, nodeURI)
+		sendXTransfer(wallet, owner)
+
+		var subnetID ids.ID
+		ginkgo.By("creating a subnet", func() {
+			subnetTx, err := wallet.P().IssueCreateSubnetTx(owner, e2e.WithDefaultContext())
+			require.NoError(err)
+			subnetID = subnetTx.ID()
+		})
+
+		// Use a random node ID to ensure that repeated test runs
+		// will succeed against a persistent network.
+		validatorID, err := ids.ToNodeID(utils.RandomBytes(ids.NodeIDLen))
+		require.NoError(err)
+		ginkgo.By("adding a validator", func() {
+			pChainClient := platformvm.NewClient(nodeURI.URI)
+			minValStake, _, err := pChainClient.GetMinStake(e2e.DefaultContext(), constants.PlatformChainID)
+			require.NoError(err)
+
+			startTime := time.Now().Add(30 * time.Second)
+			_, err = wallet.P().IssueAddValidatorTx(
+				&txs.Validator{
+					NodeID: validatorID,
+					Start:  uint64(startTime.Unix()),
+					End:    uint64(startTime.Add(72 * time.Hour).Unix()),
+					Wght:   minValStake,
+				},
+				owner,
+				20000, // 2% shares
+				e2e.WithDefaultContext(),
+			)
+			require.NoError(err)
+		})
+
+		pHeight, err := platformvm.NewClient(nodeURI.URI).GetHeight(e2e.DefaultContext())
+		require.NoError(err)
+
+		for _, node := range network.GetNodes() {
+			nodeID := node.GetID()
+			ginkgo.By("upgrading node " + nodeID.String())
+			require.NoError(network.RestartNode(
+				e2e.DefaultContext(),
+				ginkgo.GinkgoWriter,
+				nodeID,
+				e2e.Env.UpgradeExecPath,
+			))
+
+			uri := node.GetProcessContext().URI
+			ginkgo.By("checking that node " + nodeID.String() + " preserved the state of the network")
+			checkPChainState(uri, pHeight, subnetID, validatorID)
+
+			ginkgo.By("checking that the network still accepts transactions")
+			sendXTransfer(wallet, owner)
+		}
+
+		ginkgo.By("checking the uptime of the upgraded nodes")
+		for _, node := range network.GetNodes() {
+			checkUptime(node)
+		}
+
+		ginkgo.By("checking that a new wallet can issue transactions against the upgraded network")
+		wallet = e2e.Env.NewWallet(keychain, e2e.Env.GetRandomNodeURI())
+		_, err = wallet.P().IssueCreateSubnetTx(owner, e2e.WithDefaultContext())
+		require.NoError(err)
+		sendXTransfer(wallet, owner)
+	})
+})
+
+// Issues an X-Chain transfer of 1 AVAX to [owner] and waits for its
+// acceptance.
+func sendXTransfer(wallet primary.Wallet, owner *secp256k1fx.OutputOwners) {
+	require := require.New(ginkgo.GinkgoT())
+
+	xWallet := wallet.X()
+	_, err := xWallet.IssueBaseTx(
+		[]*avax.TransferableOutput{{
+			Asset: avax.Asset{
+				ID: xWallet.AVAXAssetID(),
+			},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          units.Avax,
+				OutputOwners: *owner,
+			},
+		}},
+		e2e.WithDefaultContext(),
+	)
+	require.NoError(err)
+}
+
+// Checks that the node at [uri] has accepted the P-Chain up to at
+// least [minHeight], and knows of the subnet and the validator created
+// before the upgrade.
+func checkPChainState(uri string, minHeight uint64, subnetID ids.ID, validatorID ids.NodeID) {
+	require := require.New(ginkgo.GinkgoT())
+
+	pChainClient := platformvm.NewClient(uri)
+	height, err := pChainClient.GetHeight(e2e.DefaultContext())
+	require.NoError(err)
+	require.GreaterOrEqual(height, minHeight)
+
+	subnets, err := pChainClient.GetSubnets(e2e.DefaultContext(), []ids.ID{subnetID})
+	require.NoError(err)
+	require.Len(subnets, 1)
+
+	nodeIDs := []ids.NodeID{validatorID}
+	current, err := pChainClient.GetCurrentValidators(e2e.DefaultContext(), constants.PrimaryNetworkID, nodeIDs)
+	require.NoError(err)
+	pending, _, err := pChainClient.GetPendingValidators(e2e.DefaultContext(), constants.PrimaryNetworkID, nodeIDs)
+	require.NoError(err)
+	require.Equal(1, len(current)+len(pending), "validator added before the upgrade is missing")
+}
+
+// Checks that the network perceives [node] as having maintained
+// sufficient uptime across the upgrade.
+func checkUptime(node testnet.Node) {
+	infoClient := info.NewClient(node.GetProcessContext().URI)
+	e2e.Eventually(func() bool {
+		uptime, err := infoClient.Uptime(e2e.DefaultContext(), constants.PrimaryNetworkID)
+		require.NoError(ginkgo.GinkgoT(), err)
+		tests.Outf(" node %s has a weighted average uptime of %.2f%%\n", node.GetID(), float64(uptime.WeightedAveragePercentage))
+		return float64(uptime.WeightedAveragePercentage) >= minUptimePercentage
+	}, e2e.DefaultTimeout, e2e.DefaultPollingInterval, "node uptime dropped below the minimum across the upgrade")
+}
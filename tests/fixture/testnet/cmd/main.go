@@ -7,22 +7,37 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io/fs"
 	"os"
-	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/tests/fixture/testnet"
 	"github.com/ava-labs/avalanchego/tests/fixture/testnet/local"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
 )
 
-const cliVersion = "0.0.1"
+const (
+	cliVersion = "0.0.1"
+
+	// fundTimeout bounds the time taken to fetch the UTXOs of the funded keys
+	// and to issue the transfer.
+	fundTimeout = time.Minute
+)
 
 var (
 	errAvalancheGoRequired = fmt.Errorf("--avalanchego-path or %s are required", local.AvalancheGoPathEnvName)
 	errNetworkDirRequired  = fmt.Errorf("--network-dir or %s are required", local.NetworkDirEnvName)
+	errNoFundedKeys        = errors.New("network has no funded keys")
+	errNoRunningNodes      = errors.New("network has no running nodes")
+	errUnsupportedChain    = errors.New("only X-Chain and P-Chain addresses can be funded")
 )
 
 func main() {
@@ -74,13 +89,8 @@ func main() {
 			}
 
 			// Symlink the new network to the 'latest' network to simplify usage
-			networkRootDir := filepath.Dir(network.Dir)
-			networkDirName := filepath.Base(network.Dir)
-			latestSymlinkPath := filepath.Join(networkRootDir, "latest")
-			if err := os.Remove(latestSymlinkPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
-				return err
-			}
-			if err := os.Symlink(networkDirName, latestSymlinkPath); err != nil {
+			latestSymlinkPath, err := local.LinkLatestNetwork(network.Dir)
+			if err != nil {
 				return err
 			}
 
@@ -116,6 +126,129 @@ func main() {
 	stopNetworkCmd.PersistentFlags().StringVar(&networkDir, "network-dir", os.Getenv(local.NetworkDirEnvName), "The path to the configuration directory of a local network")
 	rootCmd.AddCommand(stopNetworkCmd)
 
+	var addNodeNetworkDir string
+	addNodeCmd := &cobra.Command{
+		Use:   "add-node",
+		Short: "Add a node to a local network",
+		RunE: func(*cobra.Command, []string) error {
+			if len(addNodeNetworkDir) == 0 {
+				return errNetworkDirRequired
+			}
+			network, err := local.ReadNetwork(addNodeNetworkDir)
+			if err != nil {
+				return err
+			}
+
+			node, err := network.AddLocalNode(os.Stdout, nil, false /* isEphemeral */)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), local.DefaultNetworkStartTimeout)
+			defer cancel()
+			if err := testnet.WaitForHealthy(ctx, node); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Added node %s: %s\n", node.GetID(), node.GetProcessContext().URI)
+			return nil
+		},
+	}
+	addNodeCmd.PersistentFlags().StringVar(&addNodeNetworkDir, "network-dir", os.Getenv(local.NetworkDirEnvName), "The path to the configuration directory of a local network")
+	rootCmd.AddCommand(addNodeCmd)
+
+	var (
+		fundNetworkDir string
+		fundAmount     uint64
+	)
+	fundCmd := &cobra.Command{
+		Use:   "fund <address>",
+		Short: "Send AVAX from the funded keys of a local network to an X-Chain or P-Chain address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(fundNetworkDir) == 0 {
+				return errNetworkDirRequired
+			}
+			network, err := local.ReadNetwork(fundNetworkDir)
+			if err != nil {
+				return err
+			}
+			if len(network.FundedKeys) == 0 {
+				return errNoFundedKeys
+			}
+			uris := network.GetURIs()
+			if len(uris) == 0 {
+				return errNoRunningNodes
+			}
+
+			chainAlias, _, addrBytes, err := address.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("couldn't parse address: %w", err)
+			}
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				return fmt.Errorf("couldn't parse address: %w", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), fundTimeout)
+			defer cancel()
+
+			kc := secp256k1fx.NewKeychain(network.FundedKeys...)
+			wallet, err := primary.MakeWallet(ctx, &primary.WalletConfig{
+				URI:          uris[0].URI,
+				AVAXKeychain: kc,
+				EthKeychain:  kc,
+			})
+			if err != nil {
+				return fmt.Errorf("couldn't initialize wallet: %w", err)
+			}
+
+			out := &secp256k1fx.TransferOutput{
+				Amt: fundAmount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			}
+
+			var txID ids.ID
+			switch chainAlias {
+			case "X":
+				xWallet := wallet.X()
+				tx, err := xWallet.IssueBaseTx(
+					[]*avax.TransferableOutput{{
+						Asset: avax.Asset{ID: xWallet.AVAXAssetID()},
+						Out:   out,
+					}},
+					common.WithContext(ctx),
+				)
+				if err != nil {
+					return err
+				}
+				txID = tx.ID()
+			case "P":
+				pWallet := wallet.P()
+				tx, err := pWallet.IssueBaseTx(
+					[]*avax.TransferableOutput{{
+						Asset: avax.Asset{ID: pWallet.AVAXAssetID()},
+						Out:   out,
+					}},
+					common.WithContext(ctx),
+				)
+				if err != nil {
+					return err
+				}
+				txID = tx.ID()
+			default:
+				return fmt.Errorf("%w: %q", errUnsupportedChain, chainAlias)
+			}
+			fmt.Fprintf(os.Stdout, "Sent %d nAVAX to %s in transaction %s\n", fundAmount, args[0], txID)
+			return nil
+		},
+	}
+	fundCmd.PersistentFlags().StringVar(&fundNetworkDir, "network-dir", os.Getenv(local.NetworkDirEnvName), "The path to the configuration directory of a local network")
+	fundCmd.PersistentFlags().Uint64Var(&fundAmount, "amount", units.KiloAvax, "Amount of nAVAX to send")
+	rootCmd.AddCommand(fundCmd)
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "testnetctl failed: %v\n", err)
 		os.Exit(1)
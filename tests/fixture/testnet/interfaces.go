@@ -16,6 +16,9 @@ type Network interface {
 	GetConfig() NetworkConfig
 	GetNodes() []Node
 	AddEphemeralNode(w io.Writer, flags FlagsMap) (Node, error)
+	// Restarts the identified node with the binary at execPath. If
+	// execPath is empty, the node is restarted with its current binary.
+	RestartNode(ctx context.Context, w io.Writer, nodeID ids.NodeID, execPath string) error
 }
 
 // Defines node capabilities supportable regardless of how a network is orchestrated.
@@ -39,6 +39,7 @@ var (
 	errLocalNetworkDirNotSet = errors.New("local network directory not set - has Create() been called?")
 	errInvalidNetworkDir     = errors.New("failed to write local network: invalid network directory")
 	errMissingBootstrapNodes = errors.New("failed to add node due to missing bootstrap nodes")
+	errUnknownNode           = errors.New("failed to restart node: unknown node")
 )
 
 // Default root dir for storing networks and their configuration.
@@ -209,6 +210,22 @@ func ReadNetwork(dir string) (*LocalNetwork, error) {
 	return network, nil
 }
 
+// Symlink the provided network dir to [parent dir]/latest so that the
+// most recently started network can be targeted without knowing its
+// ID. Returns the path of the symlink.
+func LinkLatestNetwork(networkDir string) (string, error) {
+	networkRootDir := filepath.Dir(networkDir)
+	networkDirName := filepath.Base(networkDir)
+	latestSymlinkPath := filepath.Join(networkRootDir, "latest")
+	if err := os.Remove(latestSymlinkPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	if err := os.Symlink(networkDirName, latestSymlinkPath); err != nil {
+		return "", err
+	}
+	return latestSymlinkPath, nil
+}
+
 // Stop the nodes of the network configured in the provided directory.
 func StopNetwork(dir string) error {
 	network, err := ReadNetwork(dir)
@@ -407,6 +424,49 @@ func (ln *LocalNetwork) WaitForHealthy(ctx context.Context, w io.Writer) error {
 	return nil
 }
 
+// Restarts the identified node with the binary at execPath, or with
+// its current binary if execPath is empty, and waits for the node to
+// report healthy.
+//
+// The node is restarted on the ports it was previously listening on
+// so that the URIs and bootstrap IPs known to the other nodes and to
+// tests remain valid.
+func (ln *LocalNetwork) RestartNode(ctx context.Context, w io.Writer, nodeID ids.NodeID, execPath string) error {
+	var node *LocalNode
+	for _, n := range ln.Nodes {
+		if n.NodeID == nodeID {
+			node = n
+			break
+		}
+	}
+	if node == nil {
+		return fmt.Errorf("%w: %s", errUnknownNode, nodeID)
+	}
+
+	// Ensure the process context is current before its ports are reused.
+	if err := node.ReadProcessContext(); err != nil {
+		return err
+	}
+	if err := node.pinPorts(); err != nil {
+		return err
+	}
+
+	if err := node.Stop(); err != nil {
+		return fmt.Errorf("failed to stop node %s: %w", nodeID, err)
+	}
+	if err := node.WriteConfig(); err != nil {
+		return err
+	}
+
+	if len(execPath) > 0 {
+		node.ExecPath = execPath
+	}
+	if err := node.Start(w, ln.ExecPath); err != nil {
+		return fmt.Errorf("failed to restart node %s: %w", nodeID, err)
+	}
+	return testnet.WaitForHealthy(ctx, node)
+}
+
 // Retrieve API URIs for all running primary validator nodes. URIs for
 // ephemeral nodes are not returned.
 func (ln *LocalNetwork) GetURIs() []testnet.NodeURI {
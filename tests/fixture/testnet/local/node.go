@@ -11,6 +11,7 @@ import (
 	"io"
 	"io/fs"
 	"net"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -320,6 +321,28 @@ func (n *LocalNode) IsHealthy(ctx context.Context) (bool, error) {
 	return false, fmt.Errorf("failed to query node health: %w", err)
 }
 
+// Configures the node to listen on the ports reported by its current
+// process context, so that a restart doesn't change its API URI or
+// staking address.
+func (n *LocalNode) pinPorts() error {
+	if len(n.URI) == 0 {
+		// The node isn't running, so there are no ports to reuse.
+		return nil
+	}
+
+	uri, err := url.Parse(n.URI)
+	if err != nil {
+		return fmt.Errorf("failed to parse node URI: %w", err)
+	}
+	_, stakingPort, err := net.SplitHostPort(n.StakingAddress)
+	if err != nil {
+		return fmt.Errorf("failed to parse node staking address: %w", err)
+	}
+	n.Flags[config.HTTPPortKey] = uri.Port()
+	n.Flags[config.StakingPortKey] = stakingPort
+	return nil
+}
+
 func (n *LocalNode) WaitForProcessContext(ctx context.Context) error {
 	ticker := time.NewTicker(testnet.DefaultNodeTickerInterval)
 	defer ticker.Stop()
@@ -71,6 +71,28 @@ func TestDecompressZipBombs(t *testing.T) {
 	}
 }
 
+func TestZstdCompressorLevel(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewZstdCompressorWithLevel(maxMessageSize, MinZstdLevel-1)
+	require.ErrorIs(err, ErrInvalidZstdLevel)
+	_, err = NewZstdCompressorWithLevel(maxMessageSize, MaxZstdLevel+1)
+	require.ErrorIs(err, ErrInvalidZstdLevel)
+
+	data := make([]byte, 4096)
+	for _, level := range []int{MinZstdLevel, DefaultZstdLevel, MaxZstdLevel} {
+		compressor, err := NewZstdCompressorWithLevel(maxMessageSize, level)
+		require.NoError(err)
+
+		compressed, err := compressor.Compress(data)
+		require.NoError(err)
+
+		decompressed, err := compressor.Decompress(compressed)
+		require.NoError(err)
+		require.Equal(data, decompressed)
+	}
+}
+
 func TestCompressDecompress(t *testing.T) {
 	for compressionType, newCompressorFunc := range newCompressorFuncs {
 		t.Run(compressionType.String(), func(t *testing.T) {
@@ -5,7 +5,10 @@ package compression
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+
+	"golang.org/x/exp/slices"
 )
 
 var errUnknownCompressionType = errors.New("unknown compression type")
@@ -44,6 +47,31 @@ func TypeFromString(s string) (Type, error) {
 	}
 }
 
+// TypesFromStrings parses each of [strs] with TypeFromString.
+func TypesFromStrings(strs []string) ([]Type, error) {
+	types := make([]Type, len(strs))
+	for i, s := range strs {
+		t, err := TypeFromString(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, s)
+		}
+		types[i] = t
+	}
+	return types, nil
+}
+
+// Negotiate returns the first type in [preferred] that is also in [accepted].
+// If there is no such type, TypeNone is returned, as uncompressed messages can
+// always be parsed.
+func Negotiate(preferred, accepted []Type) Type {
+	for _, t := range preferred {
+		if slices.Contains(accepted, t) {
+			return t
+		}
+	}
+	return TypeNone
+}
+
 func (t Type) MarshalJSON() ([]byte, error) {
 	var b strings.Builder
 	if _, err := b.WriteString(`"`); err != nil {
@@ -23,6 +23,56 @@ func TestTypeString(t *testing.T) {
 	require.ErrorIs(err, errUnknownCompressionType)
 }
 
+func TestTypesFromStrings(t *testing.T) {
+	require := require.New(t)
+
+	types, err := TypesFromStrings([]string{"zstd", "none"})
+	require.NoError(err)
+	require.Equal([]Type{TypeZstd, TypeNone}, types)
+
+	_, err = TypesFromStrings([]string{"zstd", "lz4"})
+	require.ErrorIs(err, errUnknownCompressionType)
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred []Type
+		accepted  []Type
+		expected  Type
+	}{
+		{
+			name:      "first preference accepted",
+			preferred: []Type{TypeZstd, TypeGzip},
+			accepted:  []Type{TypeGzip, TypeZstd},
+			expected:  TypeZstd,
+		},
+		{
+			name:      "second preference accepted",
+			preferred: []Type{TypeZstd, TypeGzip},
+			accepted:  []Type{TypeGzip, TypeNone},
+			expected:  TypeGzip,
+		},
+		{
+			name:      "no preference accepted",
+			preferred: []Type{TypeZstd},
+			accepted:  []Type{TypeGzip},
+			expected:  TypeNone,
+		},
+		{
+			name:      "nothing accepted",
+			preferred: []Type{TypeZstd},
+			accepted:  nil,
+			expected:  TypeNone,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, Negotiate(test.preferred, test.accepted))
+		})
+	}
+}
+
 func TestTypeMarshalJSON(t *testing.T) {
 	type test struct {
 		Type     Type
@@ -5,6 +5,7 @@ package compression
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -12,9 +13,26 @@ import (
 	"github.com/DataDog/zstd"
 )
 
-var _ Compressor = (*zstdCompressor)(nil)
+const (
+	MinZstdLevel     = zstd.BestSpeed
+	MaxZstdLevel     = zstd.BestCompression
+	DefaultZstdLevel = zstd.DefaultCompression
+)
+
+var (
+	_ Compressor = (*zstdCompressor)(nil)
+
+	ErrInvalidZstdLevel = errors.New("invalid zstd compression level")
+)
 
 func NewZstdCompressor(maxSize int64) (Compressor, error) {
+	return NewZstdCompressorWithLevel(maxSize, DefaultZstdLevel)
+}
+
+// NewZstdCompressorWithLevel returns a zstd compressor that compresses with
+// [level], which must be in [MinZstdLevel, MaxZstdLevel]. Higher levels
+// compress more, but are slower.
+func NewZstdCompressorWithLevel(maxSize int64, level int) (Compressor, error) {
 	if maxSize == math.MaxInt64 {
 		// "Decompress" creates "io.LimitReader" with max size + 1:
 		// if the max size + 1 overflows, "io.LimitReader" reads nothing
@@ -22,21 +40,26 @@ func NewZstdCompressor(maxSize int64) (Compressor, error) {
 		// require max size < math.MaxInt64 to prevent int64 overflows
 		return nil, ErrInvalidMaxSizeCompressor
 	}
+	if level < MinZstdLevel || level > MaxZstdLevel {
+		return nil, fmt.Errorf("%w: %d not in [%d, %d]", ErrInvalidZstdLevel, level, MinZstdLevel, MaxZstdLevel)
+	}
 
 	return &zstdCompressor{
 		maxSize: maxSize,
+		level:   level,
 	}, nil
 }
 
 type zstdCompressor struct {
 	maxSize int64
+	level   int
 }
 
 func (z *zstdCompressor) Compress(msg []byte) ([]byte, error) {
 	if int64(len(msg)) > z.maxSize {
 		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrMsgTooLarge, len(msg), z.maxSize)
 	}
-	return zstd.Compress(nil, msg)
+	return zstd.CompressLevel(nil, msg, z.level)
 }
 
 func (z *zstdCompressor) Decompress(msg []byte) ([]byte, error) {
@@ -40,6 +40,10 @@ const (
 	// Outbound Connection Throttling
 	DefaultOutboundConnectionThrottlingRps = 50
 	DefaultOutboundConnectionTimeout       = 30 * time.Second
+	DefaultOutboundConnectionMaxConcurrent = 0
+	DefaultDialFailurePenaltyThreshold     = 3
+	DefaultDialFailurePenaltyInitial       = 10 * time.Second
+	DefaultDialFailurePenaltyMax           = 10 * time.Minute
 
 	// Timeouts
 	DefaultNetworkInitialTimeout        = 5 * time.Second
@@ -51,10 +55,23 @@ const (
 	DefaultNetworkReadHandshakeTimeout  = 15 * time.Second
 
 	DefaultNetworkCompressionType           = compression.TypeZstd
+	DefaultNetworkCompressionZstdLevel      = compression.DefaultZstdLevel
 	DefaultNetworkMaxClockDifference        = time.Minute
 	DefaultNetworkRequireValidatorToConnect = false
 	DefaultNetworkPeerReadBufferSize        = 8 * units.KiB
 	DefaultNetworkPeerWriteBufferSize       = 8 * units.KiB
+	DefaultNetworkPeerWorkerPoolSize        = 0
+	DefaultNetworkZeroCopyPayloads          = false
+
+	DefaultNetworkRetiringAnnouncementEnabled = false
+
+	DefaultNetworkReputationEnabled                 = false
+	DefaultNetworkReputationHandshakeFailurePenalty = 1
+	DefaultNetworkReputationInvalidMessagePenalty   = 1
+	DefaultNetworkReputationBenchedPenalty          = .1
+	DefaultNetworkReputationPenaltyHalflife         = time.Hour
+	DefaultNetworkReputationMaxPenalty              = 10
+	DefaultNetworkReputationMaxTrackedPeers         = 10_000
 
 	DefaultNetworkTCPProxyEnabled = false
 
@@ -65,6 +82,11 @@ const (
 	// a timeout of 0 should generally not be provided.
 	DefaultNetworkTCPProxyReadTimeout = 3 * time.Second
 
+	DefaultNetworkTLSSessionResumptionEnabled     = true
+	DefaultNetworkTLSSessionTicketKeyRotationFreq = time.Hour
+	DefaultNetworkTLSSessionTicketKeys            = 24
+	DefaultNetworkTLSMinVersion                   = "1.3"
+
 	// Benchlist
 	DefaultBenchlistFailThreshold      = 10
 	DefaultBenchlistDuration           = 15 * time.Minute
@@ -83,6 +105,11 @@ const (
 	DefaultAppGossipValidatorSize                          = 10
 	DefaultAppGossipNonValidatorSize                       = 0
 	DefaultAppGossipPeerSize                               = 0
+	DefaultConsensusGossipRedundancyValidatorSize          = 5
+	DefaultConsensusGossipRedundancyMaxLevel               = 4
+	DefaultConsensusGossipRedundancyHighFailureRate        = .2
+	DefaultConsensusGossipRedundancyLowFailureRate         = .05
+	DefaultConsensusGossipRedundancyWindow                 = 10 * time.Second
 
 	// Inbound Throttling
 	DefaultInboundThrottlerAtLargeAllocSize         = 6 * units.MiB
@@ -14,6 +14,7 @@ const PublicKeyLen = blst.BLST_P1_COMPRESS_BYTES
 var (
 	ErrNoPublicKeys               = errors.New("no public keys")
 	ErrFailedPublicKeyDecompress  = errors.New("couldn't decompress public key")
+	errFailedPublicKeyDeserialize = errors.New("couldn't deserialize public key")
 	errInvalidPublicKey           = errors.New("invalid public key")
 	errFailedPublicKeyAggregation = errors.New("couldn't aggregate public keys")
 )
@@ -41,6 +42,25 @@ func PublicKeyFromBytes(pkBytes []byte) (*PublicKey, error) {
 	return pk, nil
 }
 
+// PublicKeyToUncompressedBytes returns the uncompressed big-endian format of
+// the public key.
+func PublicKeyToUncompressedBytes(pk *PublicKey) []byte {
+	return pk.Serialize()
+}
+
+// PublicKeyFromUncompressedBytes parses the uncompressed big-endian format of
+// the public key into a public key.
+func PublicKeyFromUncompressedBytes(pkBytes []byte) (*PublicKey, error) {
+	pk := new(PublicKey).Deserialize(pkBytes)
+	if pk == nil {
+		return nil, errFailedPublicKeyDeserialize
+	}
+	if !pk.KeyValidate() {
+		return nil, errInvalidPublicKey
+	}
+	return pk, nil
+}
+
 // AggregatePublicKeys aggregates a non-zero number of public keys into a single
 // aggregated public key.
 // Invariant: all [pks] have been validated.
@@ -36,6 +36,31 @@ func TestPublicKeyBytes(t *testing.T) {
 	require.Equal(pkBytes, pk2Bytes)
 }
 
+func TestPublicKeyFromUncompressedBytesWrongSize(t *testing.T) {
+	require := require.New(t)
+
+	pkBytes := utils.RandomBytes(2*PublicKeyLen + 1)
+	_, err := PublicKeyFromUncompressedBytes(pkBytes)
+	require.ErrorIs(err, errFailedPublicKeyDeserialize)
+}
+
+func TestPublicKeyUncompressedBytes(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := NewSecretKey()
+	require.NoError(err)
+
+	pk := PublicFromSecretKey(sk)
+	pkBytes := PublicKeyToUncompressedBytes(pk)
+
+	pk2, err := PublicKeyFromUncompressedBytes(pkBytes)
+	require.NoError(err)
+	pk2Bytes := PublicKeyToUncompressedBytes(pk2)
+
+	require.Equal(pk, pk2)
+	require.Equal(pkBytes, pk2Bytes)
+}
+
 func TestAggregatePublicKeysNoop(t *testing.T) {
 	require := require.New(t)
 
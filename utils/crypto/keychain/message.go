@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keychain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+)
+
+var ErrUnknownAddress = errors.New("keychain doesn't contain address")
+
+// SignMessage signs [msg] as an off-chain message with the key in [kc] that
+// controls [addr].
+//
+// The signature can be verified with [secp256k1.Factory.RecoverMessagePublicKey].
+func SignMessage(kc Keychain, addr ids.ShortID, msg []byte) ([]byte, error) {
+	signer, ok := kc.Get(addr)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAddress, addr)
+	}
+
+	hash, err := secp256k1.MessageHash(msg)
+	if err != nil {
+		return nil, err
+	}
+	return signer.SignHash(hash)
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// MessagePrefix is prepended to off-chain messages before they are signed so
+// that a signed message can never be a valid transaction signature.
+const MessagePrefix = "\x1AAvalanche Signed Message:\n"
+
+var errMessageTooLarge = errors.New("message too large")
+
+// MessageHash returns the hash that is signed to sign [msg] as an off-chain
+// message. The hash is the SHA256 of:
+//
+//	MessagePrefix || uint32(len(msg)) || msg
+//
+// where the length is big endian encoded.
+func MessageHash(msg []byte) ([]byte, error) {
+	if len(msg) > math.MaxUint32 {
+		return nil, errMessageTooLarge
+	}
+
+	prefixedMsg := make([]byte, len(MessagePrefix)+wrappers.IntLen+len(msg))
+	offset := copy(prefixedMsg, MessagePrefix)
+	binary.BigEndian.PutUint32(prefixedMsg[offset:], uint32(len(msg)))
+	copy(prefixedMsg[offset+wrappers.IntLen:], msg)
+	return hashing.ComputeHash256(prefixedMsg), nil
+}
+
+// SignMessage signs [msg] as an off-chain message.
+func (k *PrivateKey) SignMessage(msg []byte) ([]byte, error) {
+	hash, err := MessageHash(msg)
+	if err != nil {
+		return nil, err
+	}
+	return k.SignHash(hash)
+}
+
+// RecoverMessagePublicKey returns the public key of the key that signed [msg]
+// as an off-chain message.
+func (f *Factory) RecoverMessagePublicKey(msg, sig []byte) (*PublicKey, error) {
+	hash, err := MessageHash(msg)
+	if err != nil {
+		return nil, err
+	}
+	return f.RecoverHashPublicKey(hash, sig)
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMessage(t *testing.T) {
+	require := require.New(t)
+
+	f := Factory{}
+	sk, err := f.NewPrivateKey()
+	require.NoError(err)
+
+	msg := []byte("hello world")
+	sig, err := sk.SignMessage(msg)
+	require.NoError(err)
+
+	pk, err := f.RecoverMessagePublicKey(msg, sig)
+	require.NoError(err)
+	require.Equal(sk.PublicKey().Address(), pk.Address())
+
+	// A different message should recover a different key
+	pk, err = f.RecoverMessagePublicKey([]byte("goodbye world"), sig)
+	require.NoError(err)
+	require.NotEqual(sk.PublicKey().Address(), pk.Address())
+
+	// The signature shouldn't be valid as a signature of the raw message
+	require.False(sk.PublicKey().Verify(msg, sig))
+}
+
+func TestMessageHash(t *testing.T) {
+	require := require.New(t)
+
+	hash, err := MessageHash(nil)
+	require.NoError(err)
+
+	// The length of the message must be committed to by the hash
+	otherHash, err := MessageHash([]byte{0})
+	require.NoError(err)
+	require.NotEqual(hash, otherHash)
+}
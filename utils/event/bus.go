@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package event
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	_ Bus[struct{}]          = (*bus[struct{}])(nil)
+	_ Subscription[struct{}] = (*subscription[struct{}])(nil)
+)
+
+// Bus delivers events of type [T] from the components that publish them to
+// the components that subscribe to them.
+//
+// The bus is bounded: every subscription buffers a fixed number of events and
+// events published to a full subscription are dropped for that subscription.
+// This guarantees that a slow subscriber can never block a publisher.
+type Bus[T any] interface {
+	// Publish delivers [event] to every subscription. Publish never blocks.
+	Publish(event T)
+
+	// Subscribe returns a subscription that buffers up to [size] events. If
+	// the bus is closed, the returned subscription is already closed.
+	Subscribe(size int) Subscription[T]
+
+	// Close closes every subscription. Events published after Close are
+	// dropped.
+	Close()
+}
+
+// Subscription receives the events published to a bus.
+type Subscription[T any] interface {
+	// Events returns the channel the events are delivered on. The channel is
+	// closed once the subscription or its bus is closed.
+	Events() <-chan T
+
+	// Dropped returns the number of events that weren't delivered to this
+	// subscription because its buffer was full.
+	Dropped() uint64
+
+	// Unsubscribe stops delivering events to this subscription and closes its
+	// channel. Repeated calls to Unsubscribe are no-ops.
+	Unsubscribe()
+}
+
+type bus[T any] struct {
+	lock          sync.RWMutex
+	closed        bool
+	subscriptions map[*subscription[T]]struct{}
+}
+
+func NewBus[T any]() Bus[T] {
+	return &bus[T]{
+		subscriptions: make(map[*subscription[T]]struct{}),
+	}
+}
+
+func (b *bus[T]) Publish(event T) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for s := range b.subscriptions {
+		select {
+		case s.events <- event:
+		default:
+			s.dropped.Add(1)
+		}
+	}
+}
+
+func (b *bus[T]) Subscribe(size int) Subscription[T] {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	s := &subscription[T]{
+		bus:    b,
+		events: make(chan T, size),
+	}
+	if b.closed {
+		close(s.events)
+		return s
+	}
+	b.subscriptions[s] = struct{}{}
+	return s
+}
+
+func (b *bus[T]) Close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for s := range b.subscriptions {
+		close(s.events)
+	}
+	b.subscriptions = nil
+}
+
+// unsubscribe removes [s] from the bus and closes its channel if the bus
+// hasn't already done so.
+func (b *bus[T]) unsubscribe(s *subscription[T]) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.subscriptions[s]; !ok {
+		return
+	}
+	delete(b.subscriptions, s)
+	close(s.events)
+}
+
+type subscription[T any] struct {
+	bus     *bus[T]
+	events  chan T
+	dropped atomic.Uint64
+}
+
+func (s *subscription[T]) Events() <-chan T {
+	return s.events
+}
+
+func (s *subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+func (s *subscription[T]) Unsubscribe() {
+	s.bus.unsubscribe(s)
+}
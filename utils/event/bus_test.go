@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusPublish(t *testing.T) {
+	require := require.New(t)
+
+	b := NewBus[int]()
+	sub0 := b.Subscribe(2)
+	sub1 := b.Subscribe(1)
+
+	b.Publish(1)
+	b.Publish(2)
+
+	require.Equal(1, <-sub0.Events())
+	require.Equal(2, <-sub0.Events())
+	require.Zero(sub0.Dropped())
+
+	// [sub1] was full when the second event was published.
+	require.Equal(1, <-sub1.Events())
+	require.Equal(uint64(1), sub1.Dropped())
+}
+
+func TestBusUnsubscribe(t *testing.T) {
+	require := require.New(t)
+
+	b := NewBus[int]()
+	sub := b.Subscribe(1)
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+
+	b.Publish(1)
+
+	_, ok := <-sub.Events()
+	require.False(ok)
+	require.Zero(sub.Dropped())
+}
+
+func TestBusClose(t *testing.T) {
+	require := require.New(t)
+
+	b := NewBus[int]()
+	sub := b.Subscribe(1)
+	b.Publish(1)
+	b.Close()
+
+	// Events published before the bus was closed are still delivered.
+	e, ok := <-sub.Events()
+	require.True(ok)
+	require.Equal(1, e)
+
+	_, ok = <-sub.Events()
+	require.False(ok)
+
+	// Unsubscribing after the bus was closed is a no-op.
+	sub.Unsubscribe()
+
+	b.Publish(2)
+	_, ok = <-b.Subscribe(1).Events()
+	require.False(ok)
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package event
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// PeerConnected is published when a peer connects to this node on a subnet.
+type PeerConnected struct {
+	NodeID   ids.NodeID
+	Version  *version.Application
+	SubnetID ids.ID
+}
+
+// PeerDisconnected is published when a peer disconnects from this node.
+type PeerDisconnected struct {
+	NodeID ids.NodeID
+}
+
+// ChainBootstrapped is published when a chain finishes bootstrapping.
+type ChainBootstrapped struct {
+	// Name is the primary alias of the chain.
+	Name     string
+	ChainID  ids.ID
+	SubnetID ids.ID
+}
+
+// BlockAccepted is published when a chain accepts a block.
+type BlockAccepted struct {
+	ChainID ids.ID
+	BlockID ids.ID
+}
+
+// ValidatorSetChanged is published when the weight of a validator of a subnet
+// changes, including when it is added or removed.
+type ValidatorSetChanged struct {
+	SubnetID ids.ID
+	NodeID   ids.NodeID
+	// Weight is the new weight of the validator. If 0, the validator was
+	// removed.
+	Weight uint64
+}
+
+// Buses are the buses that the components of a node publish their events to.
+type Buses struct {
+	PeerConnected       Bus[PeerConnected]
+	PeerDisconnected    Bus[PeerDisconnected]
+	ChainBootstrapped   Bus[ChainBootstrapped]
+	BlockAccepted       Bus[BlockAccepted]
+	ValidatorSetChanged Bus[ValidatorSetChanged]
+}
+
+func NewBuses() *Buses {
+	return &Buses{
+		PeerConnected:       NewBus[PeerConnected](),
+		PeerDisconnected:    NewBus[PeerDisconnected](),
+		ChainBootstrapped:   NewBus[ChainBootstrapped](),
+		BlockAccepted:       NewBus[BlockAccepted](),
+		ValidatorSetChanged: NewBus[ValidatorSetChanged](),
+	}
+}
+
+// Close closes every bus.
+func (b *Buses) Close() {
+	b.PeerConnected.Close()
+	b.PeerDisconnected.Close()
+	b.ChainBootstrapped.Close()
+	b.BlockAccepted.Close()
+	b.ValidatorSetChanged.Close()
+}
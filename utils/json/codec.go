@@ -4,7 +4,6 @@
 package json
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -13,6 +12,8 @@ import (
 
 	"github.com/gorilla/rpc/v2"
 	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/ava-labs/avalanchego/api/rpcerror"
 )
 
 const (
@@ -21,14 +22,18 @@ const (
 )
 
 var (
-	errUppercaseMethod = errors.New("method must start with a non-uppercase letter")
-	errInvalidArg      = errors.New("couldn't unmarshal an argument. Ensure arguments are valid and properly formatted. See documentation for example calls")
+	errUppercaseMethod = rpcerror.New(rpcerror.InvalidArgument, "method must start with a non-uppercase letter")
+	errInvalidArg      = rpcerror.New(rpcerror.InvalidArgument, "couldn't unmarshal an argument. Ensure arguments are valid and properly formatted. See documentation for example calls")
 )
 
 // NewCodec returns a new json codec that will convert the first character of
-// the method to uppercase
+// the method to uppercase. Errors are reported with their [rpcerror.Code] in
+// the data field.
 func NewCodec() rpc.Codec {
-	return lowercase{json2.NewCodec()}
+	return lowercase{json2.NewCustomCodecWithErrorMapper(
+		rpc.DefaultEncoderSelector,
+		rpcerror.ToJSONError,
+	)}
 }
 
 type lowercase struct{ *json2.Codec }
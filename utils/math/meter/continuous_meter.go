@@ -38,6 +38,17 @@ func NewMeter(halflife time.Duration) Meter {
 	}
 }
 
+// NewMeterFromState returns a new Meter that continues from the provided
+// state.
+func NewMeterFromState(state MeterState) Meter {
+	return &continuousMeter{
+		halflife:        float64(state.Halflife) / convertEToBase2,
+		value:           state.Value,
+		numCoresRunning: state.NumCoresRunning,
+		lastUpdated:     state.LastUpdated,
+	}
+}
+
 func (a *continuousMeter) Inc(now time.Time, numCores float64) {
 	a.Read(now)
 	a.numCoresRunning += numCores
@@ -77,3 +88,12 @@ func (a *continuousMeter) TimeUntil(now time.Time, value float64) time.Duration
 	}
 	return time.Duration(duration)
 }
+
+func (a *continuousMeter) Snapshot() MeterState {
+	return MeterState{
+		Halflife:        time.Duration(math.Round(a.halflife * convertEToBase2)),
+		Value:           a.value,
+		NumCoresRunning: a.numCoresRunning,
+		LastUpdated:     a.lastUpdated,
+	}
+}
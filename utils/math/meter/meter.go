@@ -25,4 +25,16 @@ type Meter interface {
 	// reaches [value], assuming that the number of cores running is always 0.
 	// If the value of this meter is already <= [value], returns the zero duration.
 	TimeUntil(now time.Time, value float64) time.Duration
+
+	// Snapshot returns the current state of the meter. The returned state can
+	// be used to restore an equivalent meter with NewMeterFromState.
+	Snapshot() MeterState
+}
+
+// MeterState is the serializable state of a Meter.
+type MeterState struct {
+	Halflife        time.Duration `json:"halflife"`
+	Value           float64       `json:"value"`
+	NumCoresRunning float64       `json:"numCoresRunning"`
+	LastUpdated     time.Time     `json:"lastUpdated"`
 }
@@ -39,6 +39,10 @@ var (
 			name: "time travel",
 			test: TimeTravelTest,
 		},
+		{
+			name: "snapshot restore",
+			test: SnapshotRestoreTest,
+		},
 	}
 )
 
@@ -135,6 +139,30 @@ func StandardUsageTest(t *testing.T, factory Factory) {
 	require.InDelta(m.Read(now), 1, delta)
 }
 
+func SnapshotRestoreTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	m := factory.New(halflife)
+	now := time.Date(1, 2, 3, 4, 5, 6, 7, time.UTC)
+
+	m.Inc(now, 2)
+	now = now.Add(halflife)
+	m.Dec(now, 1)
+
+	state := m.Snapshot()
+	require.Equal(halflife, state.Halflife)
+	require.Equal(float64(1), state.NumCoresRunning)
+	require.Equal(now, state.LastUpdated)
+
+	restored := NewMeterFromState(state)
+	require.Equal(state, restored.Snapshot())
+
+	// The restored meter should evolve identically to the original meter.
+	now = now.Add(halflife)
+	require.Equal(m.Read(now), restored.Read(now))
+	require.Equal(m.TimeUntil(now, .5), restored.TimeUntil(now, .5))
+}
+
 func TestTimeUntil(t *testing.T) {
 	require := require.New(t)
 
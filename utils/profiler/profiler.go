@@ -20,6 +20,12 @@ const (
 	memProfileFile = "mem.profile"
 	// Name of file that lock profile is written to
 	lockProfileFile = "lock.profile"
+	// Name of file that goroutine profile is written to
+	goroutineProfileFile = "goroutine.profile"
+
+	// Write the goroutine profile with the full stack trace of every
+	// goroutine, including how long blocked goroutines have been waiting.
+	goroutineProfileDebug = 2
 )
 
 var (
@@ -41,13 +47,18 @@ type Profiler interface {
 
 	// LockProfile dumps the current lock statistics of this process
 	LockProfile() error
+
+	// GoroutineProfile dumps the stack traces of all current goroutines of
+	// this process
+	GoroutineProfile() error
 }
 
 type profiler struct {
 	dir,
 	cpuProfileName,
 	memProfileName,
-	lockProfileName string
+	lockProfileName,
+	goroutineProfileName string
 
 	cpuProfileFile *os.File
 }
@@ -58,10 +69,11 @@ func New(dir string) Profiler {
 
 func new(dir string) *profiler {
 	return &profiler{
-		dir:             dir,
-		cpuProfileName:  filepath.Join(dir, cpuProfileFile),
-		memProfileName:  filepath.Join(dir, memProfileFile),
-		lockProfileName: filepath.Join(dir, lockProfileFile),
+		dir:                  dir,
+		cpuProfileName:       filepath.Join(dir, cpuProfileFile),
+		memProfileName:       filepath.Join(dir, memProfileFile),
+		lockProfileName:      filepath.Join(dir, lockProfileFile),
+		goroutineProfileName: filepath.Join(dir, goroutineProfileFile),
 	}
 }
 
@@ -130,3 +142,20 @@ func (p *profiler) LockProfile() error {
 	}
 	return file.Close()
 }
+
+func (p *profiler) GoroutineProfile() error {
+	if err := os.MkdirAll(p.dir, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+	file, err := perms.Create(p.goroutineProfileName, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+
+	profile := pprof.Lookup("goroutine")
+	if err := profile.WriteTo(file, goroutineProfileDebug); err != nil {
+		_ = file.Close() // Return the original error
+		return err
+	}
+	return file.Close()
+}
@@ -41,4 +41,10 @@ func TestProfiler(t *testing.T) {
 
 	_, err = os.Stat(filepath.Join(dir, lockProfileFile))
 	require.NoError(err)
+
+	// Test Goroutine Profiler
+	require.NoError(p.GoroutineProfile())
+
+	_, err = os.Stat(filepath.Join(dir, goroutineProfileFile))
+	require.NoError(err)
 }
@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package puzzle
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// MaxDifficulty is the maximum number of leading zero bits a solution may
+	// be required to have. Solving a puzzle takes 2^[difficulty] hashes on
+	// average, so higher difficulties would stall the solver.
+	MaxDifficulty = 20
+
+	// The number of nonces that are tried between checks for cancellation.
+	solveCheckInterval = 1 << 12
+)
+
+var ErrInvalidDifficulty = errors.New("invalid puzzle difficulty")
+
+// Verify returns true if [nonce] solves the puzzle defined by [challenge] with
+// [difficulty]. That is, if the hash of [challenge] followed by [nonce] has at
+// least [difficulty] leading zero bits.
+func Verify(challenge []byte, difficulty uint8, nonce uint64) bool {
+	if difficulty > MaxDifficulty {
+		return false
+	}
+
+	input := make([]byte, len(challenge)+wrappers.LongLen)
+	copy(input, challenge)
+	return verify(input, len(challenge), difficulty, nonce)
+}
+
+// Solve returns the first nonce that solves the puzzle defined by [challenge]
+// with [difficulty]. Returns an error if [ctx] is done before a solution is
+// found.
+func Solve(ctx context.Context, challenge []byte, difficulty uint8) (uint64, error) {
+	if difficulty > MaxDifficulty {
+		return 0, fmt.Errorf("%w: %d > %d", ErrInvalidDifficulty, difficulty, MaxDifficulty)
+	}
+
+	input := make([]byte, len(challenge)+wrappers.LongLen)
+	copy(input, challenge)
+	nonce := uint64(0)
+	for !verify(input, len(challenge), difficulty, nonce) {
+		nonce++
+		if nonce%solveCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return nonce, nil
+}
+
+// verify writes [nonce] into [input] after the [challengeLen] bytes of the
+// challenge and checks the hash of [input].
+func verify(input []byte, challengeLen int, difficulty uint8, nonce uint64) bool {
+	binary.BigEndian.PutUint64(input[challengeLen:], nonce)
+	return leadingZeros(sha256.Sum256(input)) >= int(difficulty)
+}
+
+func leadingZeros(hash [sha256.Size]byte) int {
+	zeros := 0
+	for _, b := range hash {
+		if b != 0 {
+			return zeros + bits.LeadingZeros8(b)
+		}
+		zeros += 8
+	}
+	return zeros
+}
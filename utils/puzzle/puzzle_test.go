@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package puzzle
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		difficulty uint8
+	}{
+		{
+			name:       "no difficulty",
+			difficulty: 0,
+		},
+		{
+			name:       "partial byte",
+			difficulty: 5,
+		},
+		{
+			name:       "multiple bytes",
+			difficulty: 12,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			challenge := []byte("challenge")
+			nonce, err := Solve(context.Background(), challenge, test.difficulty)
+			require.NoError(err)
+			require.True(Verify(challenge, test.difficulty, nonce))
+
+			// The solution is bound to the challenge.
+			require.False(Verify([]byte("other challenge"), MaxDifficulty, nonce))
+		})
+	}
+}
+
+func TestSolveInvalidDifficulty(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Solve(context.Background(), nil, MaxDifficulty+1)
+	require.ErrorIs(err, ErrInvalidDifficulty)
+	require.False(Verify(nil, MaxDifficulty+1, 0))
+}
+
+func TestSolveCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The solution of this challenge isn't among the nonces that are tried
+	// before the first check for cancellation.
+	_, err := Solve(ctx, []byte("challenge"), MaxDifficulty)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLeadingZeros(t *testing.T) {
+	require := require.New(t)
+
+	var hash [sha256.Size]byte
+	require.Equal(8*sha256.Size, leadingZeros(hash))
+
+	hash[1] = 0x10
+	require.Equal(11, leadingZeros(hash))
+
+	hash[0] = 0x80
+	require.Zero(leadingZeros(hash))
+}
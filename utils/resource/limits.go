@@ -0,0 +1,214 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package resource
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/mem"
+)
+
+const (
+	cgroupV2Root       = "sys/fs/cgroup"
+	cgroupV1CPURoot    = "sys/fs/cgroup/cpu"
+	cgroupV1MemoryRoot = "sys/fs/cgroup/memory"
+
+	// cgroup v1 reports an unlimited memory limit as the largest page aligned
+	// int64. Any limit at least this large is treated as unlimited.
+	maxCgroupV1MemoryLimit = math.MaxInt64 &^ (1<<12 - 1)
+)
+
+// Limits are the CPU and memory that this process may use. Inside a container,
+// the container's cgroup may allow less than the host provides.
+type Limits struct {
+	// CgroupVersion is the version of the cgroup that the limits were read
+	// from. It is 0 if this process isn't in a cgroup, for example because
+	// the host isn't running Linux.
+	CgroupVersion int `json:"cgroupVersion"`
+	// HostCPUs is the number of logical CPUs of the host.
+	HostCPUs int `json:"hostCPUs"`
+	// CPUQuota is the number of CPUs that the cgroup may use. It is 0 if the
+	// CPU usage of the cgroup is unlimited.
+	CPUQuota float64 `json:"cpuQuota"`
+	// HostMemory is the number of bytes of memory of the host. It is 0 if it
+	// couldn't be determined.
+	HostMemory uint64 `json:"hostMemory"`
+	// MemoryLimit is the number of bytes of memory that the cgroup may use.
+	// It is 0 if the memory usage of the cgroup is unlimited.
+	MemoryLimit uint64 `json:"memoryLimit"`
+}
+
+// DetectLimits returns the CPU and memory that this process may use.
+func DetectLimits() Limits {
+	var hostMemory uint64
+	if memory, err := mem.VirtualMemory(); err == nil {
+		hostMemory = memory.Total
+	}
+	return detectLimits(os.DirFS("/"), runtime.NumCPU(), hostMemory)
+}
+
+// CPUs returns the number of CPUs that may be used.
+func (l Limits) CPUs() float64 {
+	hostCPUs := float64(l.HostCPUs)
+	if l.CPUQuota > 0 && l.CPUQuota < hostCPUs {
+		return l.CPUQuota
+	}
+	return hostCPUs
+}
+
+// Memory returns the number of bytes of memory that may be used. It is 0 if
+// it couldn't be determined.
+func (l Limits) Memory() uint64 {
+	if l.MemoryLimit > 0 && (l.HostMemory == 0 || l.MemoryLimit < l.HostMemory) {
+		return l.MemoryLimit
+	}
+	return l.HostMemory
+}
+
+// GOMAXPROCS returns the number of threads that should execute Go code at
+// once to use, but not exceed, the CPUs that may be used.
+func (l Limits) GOMAXPROCS() int {
+	procs := int(math.Ceil(l.CPUs()))
+	if procs < 1 {
+		return 1
+	}
+	return procs
+}
+
+// detectLimits reads the cgroup limits of this process from [fsys], which is
+// expected to be the root of the filesystem.
+func detectLimits(fsys fs.FS, hostCPUs int, hostMemory uint64) Limits {
+	limits := Limits{
+		HostCPUs:   hostCPUs,
+		HostMemory: hostMemory,
+	}
+
+	cgroups, err := fs.ReadFile(fsys, "proc/self/cgroup")
+	if err != nil {
+		return limits
+	}
+
+	// Each line is of the form hierarchy-ID:controller-list:cgroup-path. In
+	// cgroup v2, there is a single hierarchy with ID 0 and no controllers.
+	var (
+		isV2    bool
+		v2Path  string
+		cpuPath string
+		memPath string
+		scanner = bufio.NewScanner(bytes.NewReader(cgroups))
+	)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			isV2 = true
+			v2Path = fields[2]
+			continue
+		}
+		for _, controller := range strings.Split(fields[1], ",") {
+			switch controller {
+			case "cpu":
+				cpuPath = fields[2]
+			case "memory":
+				memPath = fields[2]
+			}
+		}
+	}
+
+	// The cgroup v2 hierarchy is also listed in hybrid mode, where the
+	// controllers are only available in the v1 hierarchies.
+	if _, err := fs.Stat(fsys, path.Join(cgroupV2Root, "cgroup.controllers")); isV2 && err == nil {
+		limits.CgroupVersion = 2
+		dir := cgroupDir(fsys, cgroupV2Root, v2Path)
+		limits.CPUQuota = readCPUMax(fsys, path.Join(dir, "cpu.max"))
+		limits.MemoryLimit = readMemoryMax(fsys, path.Join(dir, "memory.max"))
+		return limits
+	}
+	if cpuPath == "" && memPath == "" {
+		return limits
+	}
+
+	limits.CgroupVersion = 1
+	if cpuPath != "" {
+		dir := cgroupDir(fsys, cgroupV1CPURoot, cpuPath)
+		quota, quotaErr := readInt(fsys, path.Join(dir, "cpu.cfs_quota_us"))
+		period, periodErr := readInt(fsys, path.Join(dir, "cpu.cfs_period_us"))
+		if quotaErr == nil && periodErr == nil && quota > 0 && period > 0 {
+			limits.CPUQuota = float64(quota) / float64(period)
+		}
+	}
+	if memPath != "" {
+		dir := cgroupDir(fsys, cgroupV1MemoryRoot, memPath)
+		limit, err := readInt(fsys, path.Join(dir, "memory.limit_in_bytes"))
+		if err == nil && limit > 0 && limit < maxCgroupV1MemoryLimit {
+			limits.MemoryLimit = uint64(limit)
+		}
+	}
+	return limits
+}
+
+// cgroupDir returns the directory of the cgroup at [cgroupPath] in the
+// hierarchy mounted at [root]. Inside a container, the container's cgroup is
+// typically mounted at [root] rather than at [cgroupPath].
+func cgroupDir(fsys fs.FS, root, cgroupPath string) string {
+	dir := path.Join(root, cgroupPath)
+	if _, err := fs.Stat(fsys, dir); err == nil {
+		return dir
+	}
+	return root
+}
+
+// readCPUMax parses a cgroup v2 cpu.max file of the form "$MAX $PERIOD",
+// where $MAX is "max" if the CPU usage is unlimited.
+func readCPUMax(fsys fs.FS, name string) float64 {
+	contents, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(string(contents))
+	if len(fields) != 2 || fields[0] == "max" {
+		return 0
+	}
+	quota, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0
+	}
+	period, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil || period == 0 {
+		return 0
+	}
+	return float64(quota) / float64(period)
+}
+
+// readMemoryMax parses a cgroup v2 memory.max file, which is "max" if the
+// memory usage is unlimited.
+func readMemoryMax(fsys fs.FS, name string) uint64 {
+	contents, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return 0
+	}
+	limit, err := strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return limit
+}
+
+func readInt(fsys fs.FS, name string) (int64, error) {
+	contents, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(contents)), 10, 64)
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package resource
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func TestDetectLimits(t *testing.T) {
+	const (
+		hostCPUs   = 16
+		hostMemory = 64 * units.GiB
+	)
+	tests := []struct {
+		name           string
+		fsys           fstest.MapFS
+		expectedLimits Limits
+	}{
+		{
+			name: "no cgroup",
+			fsys: fstest.MapFS{},
+			expectedLimits: Limits{
+				HostCPUs:   hostCPUs,
+				HostMemory: hostMemory,
+			},
+		},
+		{
+			name: "v2 limited",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":                 {Data: []byte("0::/\n")},
+				"sys/fs/cgroup/cgroup.controllers": {Data: []byte("cpu memory\n")},
+				"sys/fs/cgroup/cpu.max":            {Data: []byte("150000 100000\n")},
+				"sys/fs/cgroup/memory.max":         {Data: []byte("4294967296\n")},
+			},
+			expectedLimits: Limits{
+				CgroupVersion: 2,
+				HostCPUs:      hostCPUs,
+				CPUQuota:      1.5,
+				HostMemory:    hostMemory,
+				MemoryLimit:   4 * units.GiB,
+			},
+		},
+		{
+			name: "v2 unlimited",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":                 {Data: []byte("0::/\n")},
+				"sys/fs/cgroup/cgroup.controllers": {Data: []byte("cpu memory\n")},
+				"sys/fs/cgroup/cpu.max":            {Data: []byte("max 100000\n")},
+				"sys/fs/cgroup/memory.max":         {Data: []byte("max\n")},
+			},
+			expectedLimits: Limits{
+				CgroupVersion: 2,
+				HostCPUs:      hostCPUs,
+				HostMemory:    hostMemory,
+			},
+		},
+		{
+			name: "v2 nested cgroup",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":                                       {Data: []byte("0::/system.slice/avalanchego.service\n")},
+				"sys/fs/cgroup/cgroup.controllers":                       {Data: []byte("cpu memory\n")},
+				"sys/fs/cgroup/system.slice/avalanchego.service/cpu.max": {Data: []byte("200000 100000\n")},
+			},
+			expectedLimits: Limits{
+				CgroupVersion: 2,
+				HostCPUs:      hostCPUs,
+				CPUQuota:      2,
+				HostMemory:    hostMemory,
+			},
+		},
+		{
+			name: "v1 limited",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup": {Data: []byte(
+					"12:memory:/docker/abc\n" +
+						"4:cpu,cpuacct:/docker/abc\n" +
+						"0::/docker/abc\n",
+				)},
+				"sys/fs/cgroup/cpu/cpu.cfs_quota_us":         {Data: []byte("400000\n")},
+				"sys/fs/cgroup/cpu/cpu.cfs_period_us":        {Data: []byte("100000\n")},
+				"sys/fs/cgroup/memory/memory.limit_in_bytes": {Data: []byte("2147483648\n")},
+			},
+			expectedLimits: Limits{
+				CgroupVersion: 1,
+				HostCPUs:      hostCPUs,
+				CPUQuota:      4,
+				HostMemory:    hostMemory,
+				MemoryLimit:   2 * units.GiB,
+			},
+		},
+		{
+			name: "v1 unlimited",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup": {Data: []byte(
+					"12:memory:/\n" +
+						"4:cpu,cpuacct:/\n",
+				)},
+				"sys/fs/cgroup/cpu/cpu.cfs_quota_us":         {Data: []byte("-1\n")},
+				"sys/fs/cgroup/cpu/cpu.cfs_period_us":        {Data: []byte("100000\n")},
+				"sys/fs/cgroup/memory/memory.limit_in_bytes": {Data: []byte("9223372036854771712\n")},
+			},
+			expectedLimits: Limits{
+				CgroupVersion: 1,
+				HostCPUs:      hostCPUs,
+				HostMemory:    hostMemory,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limits := detectLimits(test.fsys, hostCPUs, hostMemory)
+			require.Equal(t, test.expectedLimits, limits)
+		})
+	}
+}
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		name               string
+		limits             Limits
+		expectedCPUs       float64
+		expectedMemory     uint64
+		expectedGOMAXPROCS int
+	}{
+		{
+			name: "unlimited",
+			limits: Limits{
+				HostCPUs:   8,
+				HostMemory: units.GiB,
+			},
+			expectedCPUs:       8,
+			expectedMemory:     units.GiB,
+			expectedGOMAXPROCS: 8,
+		},
+		{
+			name: "limited",
+			limits: Limits{
+				HostCPUs:    8,
+				CPUQuota:    2.5,
+				HostMemory:  units.GiB,
+				MemoryLimit: units.MiB,
+			},
+			expectedCPUs:       2.5,
+			expectedMemory:     units.MiB,
+			expectedGOMAXPROCS: 3,
+		},
+		{
+			name: "limits above host",
+			limits: Limits{
+				HostCPUs:    8,
+				CPUQuota:    16,
+				HostMemory:  units.GiB,
+				MemoryLimit: 2 * units.GiB,
+			},
+			expectedCPUs:       8,
+			expectedMemory:     units.GiB,
+			expectedGOMAXPROCS: 8,
+		},
+		{
+			name: "small quota",
+			limits: Limits{
+				HostCPUs:    8,
+				CPUQuota:    0.1,
+				MemoryLimit: units.MiB,
+			},
+			expectedCPUs:       0.1,
+			expectedMemory:     units.MiB,
+			expectedGOMAXPROCS: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			require.Equal(test.expectedCPUs, test.limits.CPUs())
+			require.Equal(test.expectedMemory, test.limits.Memory())
+			require.Equal(test.expectedGOMAXPROCS, test.limits.GOMAXPROCS())
+		})
+	}
+}
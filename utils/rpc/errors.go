@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// RequestIDHeader is the header that identifies a request in the logs of
+	// the node.
+	RequestIDHeader = "X-Request-Id"
+	// NodeVersionHeader is the header that reports the version of the node
+	// that responded to a request.
+	NodeVersionHeader = "node-version"
+
+	// NotBootstrappedMessage is the body of the response to a request made to
+	// a chain that isn't done bootstrapping.
+	NotBootstrappedMessage = "API call rejected because chain is not done bootstrapping"
+	// OverloadedMessage is the body of the response to a request that was shed
+	// because the node is overloaded.
+	OverloadedMessage = "server overloaded"
+
+	// Max number of bytes of the body of an unsuccessful response recorded in
+	// a ResponseError
+	maxErrorMessageLen = 1024
+)
+
+var _ error = (*ResponseError)(nil)
+
+// ResponseError is returned when the node responded to a request with an
+// unsuccessful status code or with a response that couldn't be decoded.
+type ResponseError struct {
+	// Method is the JSON-RPC method that was called.
+	Method string
+	// Endpoint is the URI the request was sent to.
+	Endpoint string
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// RequestID is the ID of the request, if the node or the caller set one.
+	RequestID string
+	// NodeVersion is the version the node reported, if any.
+	NodeVersion string
+	// Message is the body of the response, if the status code is
+	// unsuccessful.
+	Message string
+	// Err is the error returned when decoding the response, if the status
+	// code is successful.
+	Err error
+}
+
+func (e *ResponseError) Error() string {
+	sb := strings.Builder{}
+	if e.Err != nil {
+		sb.WriteString(e.Err.Error())
+	} else {
+		fmt.Fprintf(&sb, "received status code: %d", e.StatusCode)
+		if len(e.Message) > 0 {
+			fmt.Fprintf(&sb, ": %s", e.Message)
+		}
+	}
+	fmt.Fprintf(&sb, " (method: %s, endpoint: %s, status code: %d", e.Method, e.Endpoint, e.StatusCode)
+	if len(e.RequestID) > 0 {
+		fmt.Fprintf(&sb, ", request ID: %s", e.RequestID)
+	}
+	if len(e.NodeVersion) > 0 {
+		fmt.Fprintf(&sb, ", node version: %s", e.NodeVersion)
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotBootstrapped returns true if [err] reports that the request was
+// rejected because the chain isn't done bootstrapping.
+func IsNotBootstrapped(err error) bool {
+	return hasStatus(err, http.StatusServiceUnavailable, NotBootstrappedMessage)
+}
+
+// IsOverloaded returns true if [err] reports that the request was rejected
+// because the node is overloaded.
+func IsOverloaded(err error) bool {
+	return hasStatus(err, http.StatusServiceUnavailable, OverloadedMessage)
+}
+
+// IsRateLimited returns true if [err] reports that the request was rejected
+// because the caller exceeded its rate limit.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests, "")
+}
+
+// IsUnauthorized returns true if [err] reports that the request was rejected
+// because it wasn't authorized.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized, "")
+}
+
+// hasStatus returns true if [err] is a ResponseError with [statusCode] and, if
+// [message] is non-empty, with [message].
+func hasStatus(err error, statusCode int, message string) bool {
+	var responseErr *ResponseError
+	if !errors.As(err, &responseErr) || responseErr.StatusCode != statusCode {
+		return false
+	}
+	return len(message) == 0 || responseErr.Message == message
+}
@@ -7,8 +7,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	rpc "github.com/gorilla/rpc/v2/json2"
 )
@@ -47,17 +49,32 @@ func SendJSONRequest(
 		return fmt.Errorf("failed to issue request: %w", err)
 	}
 
+	requestID := resp.Header.Get(RequestIDHeader)
+	if len(requestID) == 0 {
+		requestID = request.Header.Get(RequestIDHeader)
+	}
+	responseErr := &ResponseError{
+		Method:      method,
+		Endpoint:    uri.String(),
+		StatusCode:  resp.StatusCode,
+		RequestID:   requestID,
+		NodeVersion: resp.Header.Get(NodeVersionHeader),
+	}
+
 	// Return an error for any non successful status code
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorMessageLen))
+		responseErr.Message = strings.TrimSpace(string(message))
 		// Drop any error during close to report the original error
 		_ = resp.Body.Close()
-		return fmt.Errorf("received status code: %d", resp.StatusCode)
+		return responseErr
 	}
 
 	if err := rpc.DecodeClientResponse(resp.Body, reply); err != nil {
 		// Drop any error during close to report the original error
 		_ = resp.Body.Close()
-		return fmt.Errorf("failed to decode client response: %w", err)
+		responseErr.Err = fmt.Errorf("failed to decode client response: %w", err)
+		return responseErr
 	}
 	return resp.Body.Close()
 }
@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendJSONRequestUnsuccessfulStatus(t *testing.T) {
+	tests := []struct {
+		name                    string
+		statusCode              int
+		message                 string
+		requestID               string
+		expectedNotBootstrapped bool
+		expectedOverloaded      bool
+		expectedRateLimited     bool
+		expectedUnauthorized    bool
+	}{
+		{
+			name:                    "not bootstrapped",
+			statusCode:              http.StatusServiceUnavailable,
+			message:                 NotBootstrappedMessage,
+			requestID:               "abc",
+			expectedNotBootstrapped: true,
+		},
+		{
+			name:               "overloaded",
+			statusCode:         http.StatusServiceUnavailable,
+			message:            OverloadedMessage,
+			expectedOverloaded: true,
+		},
+		{
+			name:                "rate limited",
+			statusCode:          http.StatusTooManyRequests,
+			message:             "rate limit exceeded for info.getNodeID",
+			expectedRateLimited: true,
+		},
+		{
+			name:                 "unauthorized",
+			statusCode:           http.StatusUnauthorized,
+			message:              "missing or invalid auth token",
+			expectedUnauthorized: true,
+		},
+		{
+			name:       "not found",
+			statusCode: http.StatusNotFound,
+			message:    "404 page not found",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requestID := r.Header.Get(RequestIDHeader); len(requestID) > 0 {
+					w.Header().Set(RequestIDHeader, requestID)
+				}
+				w.Header().Set(NodeVersionHeader, "avalanche/1.2.3")
+				http.Error(w, test.message, test.statusCode)
+			}))
+			defer server.Close()
+
+			uri, err := url.Parse(server.URL + "/ext/info")
+			require.NoError(err)
+
+			var options []Option
+			if len(test.requestID) > 0 {
+				options = append(options, WithHeader(RequestIDHeader, test.requestID))
+			}
+			err = SendJSONRequest(context.Background(), uri, "info.getNodeID", &struct{}{}, &struct{}{}, options...)
+
+			responseErr := &ResponseError{}
+			require.ErrorAs(err, &responseErr)
+			require.Equal(&ResponseError{
+				Method:      "info.getNodeID",
+				Endpoint:    server.URL + "/ext/info",
+				StatusCode:  test.statusCode,
+				RequestID:   test.requestID,
+				NodeVersion: "avalanche/1.2.3",
+				Message:     test.message,
+			}, responseErr)
+
+			require.Equal(test.expectedNotBootstrapped, IsNotBootstrapped(err))
+			require.Equal(test.expectedOverloaded, IsOverloaded(err))
+			require.Equal(test.expectedRateLimited, IsRateLimited(err))
+			require.Equal(test.expectedUnauthorized, IsUnauthorized(err))
+		})
+	}
+}
+
+func TestSendJSONRequestUndecodableResponse(t *testing.T) {
+	require := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("not json"))
+	}))
+	defer server.Close()
+
+	uri, err := url.Parse(server.URL)
+	require.NoError(err)
+
+	err = SendJSONRequest(context.Background(), uri, "info.getNodeID", &struct{}{}, &struct{}{})
+	responseErr := &ResponseError{}
+	require.ErrorAs(err, &responseErr)
+	require.Equal(http.StatusOK, responseErr.StatusCode)
+	require.Empty(responseErr.NodeVersion)
+	require.Error(errors.Unwrap(responseErr)) //nolint:forbidigo // the error is returned by the decoder
+	require.False(IsNotBootstrapped(err))
+}
+
+func TestResponseErrorString(t *testing.T) {
+	require := require.New(t)
+
+	err := &ResponseError{
+		Method:      "info.getNodeID",
+		Endpoint:    "http://localhost:9650/ext/info",
+		StatusCode:  http.StatusServiceUnavailable,
+		RequestID:   "abc",
+		NodeVersion: "avalanche/1.2.3",
+		Message:     NotBootstrappedMessage,
+	}
+	require.Equal(
+		"received status code: 503: API call rejected because chain is not done bootstrapping (method: info.getNodeID, endpoint: http://localhost:9650/ext/info, status code: 503, request ID: abc, node version: avalanche/1.2.3)",
+		err.Error(),
+	)
+}
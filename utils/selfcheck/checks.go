@@ -0,0 +1,251 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package selfcheck
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/ulimit"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	diskCheckFileName = ".selfcheck"
+	diskCheckSize     = 16 * units.MiB
+	diskCheckChunk    = 256 * units.KiB
+
+	// Below this write throughput, bootstrapping and state sync are noticeably
+	// slowed down.
+	minDiskThroughput = 50 * units.MiB
+	// fsync latencies above these values delay block acceptance.
+	maxFsyncWarnLatency = 50 * time.Millisecond
+	maxFsyncFailLatency = time.Second
+
+	cryptoCheckIterations = 100
+	// Average duration of a signature verification above which the node is
+	// unlikely to keep up with transaction verification.
+	maxVerifyDuration = time.Millisecond
+)
+
+var (
+	_ Check = (*diskCheck)(nil)
+	_ Check = (*clockCheck)(nil)
+	_ Check = (*fdLimitCheck)(nil)
+	_ Check = (*cryptoCheck)(nil)
+	_ Check = (*databaseCheck)(nil)
+)
+
+type diskCheck struct {
+	dir string
+}
+
+// NewDiskCheck measures the sequential write throughput and fsync latency of
+// the disk backing [dir].
+func NewDiskCheck(dir string) Check {
+	return &diskCheck{dir: dir}
+}
+
+func (*diskCheck) Name() string {
+	return "disk"
+}
+
+func (c *diskCheck) Run() (Status, string) {
+	if err := os.MkdirAll(c.dir, perms.ReadWriteExecute); err != nil {
+		return Fail, fmt.Sprintf("couldn't create %q: %s; check the permissions of the database directory", c.dir, err)
+	}
+
+	path := filepath.Join(c.dir, diskCheckFileName)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perms.ReadWrite)
+	if err != nil {
+		return Fail, fmt.Sprintf("couldn't write to %q: %s; check the permissions of the database directory", c.dir, err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(path)
+	}()
+
+	chunk := make([]byte, diskCheckChunk)
+	if _, err := rand.Read(chunk); err != nil {
+		return Fail, fmt.Sprintf("couldn't generate test data: %s", err)
+	}
+
+	start := time.Now()
+	for written := 0; written < diskCheckSize; written += len(chunk) {
+		if _, err := f.Write(chunk); err != nil {
+			return Fail, fmt.Sprintf("couldn't write to %q: %s; check that the disk isn't full", c.dir, err)
+		}
+	}
+	syncStart := time.Now()
+	if err := f.Sync(); err != nil {
+		return Fail, fmt.Sprintf("couldn't fsync %q: %s", c.dir, err)
+	}
+	fsyncLatency := time.Since(syncStart)
+	elapsed := time.Since(start)
+
+	throughput := uint64(float64(diskCheckSize) / elapsed.Seconds())
+	msg := fmt.Sprintf("write throughput %d MiB/s, fsync latency %s", throughput/units.MiB, fsyncLatency)
+	switch {
+	case fsyncLatency > maxFsyncFailLatency:
+		return Fail, msg + "; the disk is too slow to run a node, use a local SSD"
+	case fsyncLatency > maxFsyncWarnLatency || throughput < minDiskThroughput:
+		return Warn, msg + "; the node may fall behind the network, consider using a faster disk"
+	default:
+		return Pass, msg
+	}
+}
+
+type clockCheck struct {
+	minTime time.Time
+	now     func() time.Time
+}
+
+// NewClockCheck verifies that the local clock isn't behind [minTime], which
+// should be a time that is known to have already passed, such as the latest
+// activated network upgrade.
+func NewClockCheck(minTime time.Time) Check {
+	return &clockCheck{
+		minTime: minTime,
+		now:     time.Now,
+	}
+}
+
+func (*clockCheck) Name() string {
+	return "clock"
+}
+
+func (c *clockCheck) Run() (Status, string) {
+	now := c.now()
+	if now.Before(c.minTime) {
+		return Fail, fmt.Sprintf(
+			"local time %s is before %s; the clock is wrong, enable time synchronization (e.g. NTP)",
+			now.UTC().Format(time.RFC3339),
+			c.minTime.UTC().Format(time.RFC3339),
+		)
+	}
+	return Pass, fmt.Sprintf("local time %s", now.UTC().Format(time.RFC3339))
+}
+
+type fdLimitCheck struct {
+	recommended uint64
+	get         func() (uint64, error)
+}
+
+// NewFDLimitCheck verifies that the process may open at least [recommended]
+// file descriptors.
+func NewFDLimitCheck(recommended uint64) Check {
+	return &fdLimitCheck{
+		recommended: recommended,
+		get:         ulimit.Get,
+	}
+}
+
+func (*fdLimitCheck) Name() string {
+	return "open-files"
+}
+
+func (c *fdLimitCheck) Run() (Status, string) {
+	limit, err := c.get()
+	if err != nil {
+		return Warn, fmt.Sprintf("couldn't read the open files limit: %s", err)
+	}
+	if limit < c.recommended {
+		return Warn, fmt.Sprintf(
+			"open files limit %d is below the recommended %d; raise it with `ulimit -n` or the service's LimitNOFILE",
+			limit,
+			c.recommended,
+		)
+	}
+	return Pass, fmt.Sprintf("open files limit %d", limit)
+}
+
+type cryptoCheck struct {
+	iterations int
+}
+
+// NewCryptoCheck benchmarks secp256k1 signing and verification and verifies
+// that the produced signatures are valid.
+func NewCryptoCheck() Check {
+	return &cryptoCheck{iterations: cryptoCheckIterations}
+}
+
+func (*cryptoCheck) Name() string {
+	return "crypto"
+}
+
+func (c *cryptoCheck) Run() (Status, string) {
+	factory := secp256k1.Factory{}
+	sk, err := factory.NewPrivateKey()
+	if err != nil {
+		return Fail, fmt.Sprintf("couldn't generate a private key: %s", err)
+	}
+	pk := sk.PublicKey()
+
+	msg := []byte("avalanchego startup self-check")
+	var verifyDuration time.Duration
+	for i := 0; i < c.iterations; i++ {
+		sig, err := sk.Sign(msg)
+		if err != nil {
+			return Fail, fmt.Sprintf("couldn't sign: %s", err)
+		}
+
+		start := time.Now()
+		valid := pk.Verify(msg, sig)
+		verifyDuration += time.Since(start)
+		if !valid {
+			return Fail, "produced an invalid signature; the binary or the CPU is faulty"
+		}
+	}
+
+	avg := verifyDuration / time.Duration(c.iterations)
+	result := fmt.Sprintf("average signature verification %s", avg)
+	if avg > maxVerifyDuration {
+		return Warn, result + "; the CPU may be too slow to verify transactions in time"
+	}
+	return Pass, result
+}
+
+type databaseCheck struct {
+	db      database.Database
+	numKeys int
+}
+
+// NewDatabaseCheck reads the first [numKeys] entries of [db] and runs its
+// health check.
+func NewDatabaseCheck(db database.Database, numKeys int) Check {
+	return &databaseCheck{
+		db:      db,
+		numKeys: numKeys,
+	}
+}
+
+func (*databaseCheck) Name() string {
+	return "database"
+}
+
+func (c *databaseCheck) Run() (Status, string) {
+	if _, err := c.db.HealthCheck(context.Background()); err != nil {
+		return Fail, fmt.Sprintf("database is unhealthy: %s", err)
+	}
+
+	it := c.db.NewIterator()
+	defer it.Release()
+
+	read := 0
+	for read < c.numKeys && it.Next() {
+		_ = it.Value()
+		read++
+	}
+	if err := it.Error(); err != nil {
+		return Fail, fmt.Sprintf("couldn't read the database: %s; the database may be corrupted, restore it from a backup or resync", err)
+	}
+	return Pass, fmt.Sprintf("read %d entries", read)
+}
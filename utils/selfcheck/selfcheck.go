@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package selfcheck
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// Pass means the check found nothing wrong.
+	Pass Status = iota
+	// Warn means the node can run, but is likely to perform poorly.
+	Warn
+	// Fail means the node is unlikely to operate correctly.
+	Fail
+)
+
+// Status is the outcome of a single check.
+type Status uint8
+
+func (s Status) String() string {
+	switch s {
+	case Pass:
+		return "pass"
+	case Warn:
+		return "warn"
+	case Fail:
+		return "fail"
+	default:
+		return "unknown"
+	}
+}
+
+// Config of the startup self-check suite.
+type Config struct {
+	// Enabled runs the self-check suite before the node joins the network.
+	Enabled bool `json:"enabled"`
+	// FailOnError refuses to start the node if any check fails.
+	FailOnError bool `json:"failOnError"`
+}
+
+// Result of running a single check.
+type Result struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	// Message describes what was measured and, if the check didn't pass, what
+	// the operator should do about it.
+	Message string `json:"message"`
+}
+
+// Check is a quick test of the environment the node is running in.
+type Check interface {
+	Name() string
+	Run() (Status, string)
+}
+
+// Run executes [checks] in order, logs each result, and returns the results.
+func Run(log logging.Logger, checks ...Check) []Result {
+	results := make([]Result, len(checks))
+	for i, check := range checks {
+		status, msg := check.Run()
+		results[i] = Result{
+			Name:    check.Name(),
+			Status:  status,
+			Message: msg,
+		}
+
+		fields := []zap.Field{
+			zap.String("check", results[i].Name),
+			zap.Stringer("status", status),
+			zap.String("message", msg),
+		}
+		switch status {
+		case Pass:
+			log.Info("startup self-check", fields...)
+		case Warn:
+			log.Warn("startup self-check", fields...)
+		default:
+			log.Error("startup self-check", fields...)
+		}
+	}
+	return results
+}
+
+// Failed returns the names of the checks in [results] that failed.
+func Failed(results []Result) []string {
+	var failed []string
+	for _, result := range results {
+		if result.Status == Fail {
+			failed = append(failed, result.Name)
+		}
+	}
+	return failed
+}
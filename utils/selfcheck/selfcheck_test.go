@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package selfcheck
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errTest = errors.New("non-nil error")
+
+func TestRun(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	checks := []Check{
+		&clockCheck{
+			minTime: now.Add(-time.Hour),
+			now:     func() time.Time { return now },
+		},
+		&clockCheck{
+			minTime: now.Add(time.Hour),
+			now:     func() time.Time { return now },
+		},
+		&fdLimitCheck{
+			recommended: 1024,
+			get:         func() (uint64, error) { return 512, nil },
+		},
+	}
+
+	results := Run(logging.NoLog{}, checks...)
+	require.Len(results, 3)
+	require.Equal(Pass, results[0].Status)
+	require.Equal(Fail, results[1].Status)
+	require.Equal(Warn, results[2].Status)
+	require.Equal([]string{"clock"}, Failed(results))
+}
+
+func TestDiskCheck(t *testing.T) {
+	require := require.New(t)
+
+	dir := filepath.Join(t.TempDir(), "db")
+	status, msg := NewDiskCheck(dir).Run()
+	require.NotEqual(Fail, status, msg)
+
+	// The test file must be removed once the check finishes.
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.Empty(entries)
+}
+
+func TestFDLimitCheck(t *testing.T) {
+	tests := []struct {
+		name           string
+		limit          uint64
+		err            error
+		expectedStatus Status
+	}{
+		{
+			name:           "above recommended",
+			limit:          2048,
+			expectedStatus: Pass,
+		},
+		{
+			name:           "below recommended",
+			limit:          512,
+			expectedStatus: Warn,
+		},
+		{
+			name:           "unreadable",
+			err:            errTest,
+			expectedStatus: Warn,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := &fdLimitCheck{
+				recommended: 1024,
+				get: func() (uint64, error) {
+					return test.limit, test.err
+				},
+			}
+			status, _ := check.Run()
+			require.Equal(t, test.expectedStatus, status)
+		})
+	}
+}
+
+func TestCryptoCheck(t *testing.T) {
+	check := &cryptoCheck{iterations: 5}
+	status, msg := check.Run()
+	require.NotEqual(t, Fail, status, msg)
+}
+
+func TestDatabaseCheck(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	require.NoError(db.Put([]byte{1}, []byte{2}))
+	require.NoError(db.Put([]byte{3}, []byte{4}))
+
+	status, msg := NewDatabaseCheck(db, 1).Run()
+	require.Equal(Pass, status)
+	require.Equal("read 1 entries", msg)
+
+	require.NoError(db.Close())
+	status, _ = NewDatabaseCheck(db, 1).Run()
+	require.Equal(Fail, status)
+}
@@ -96,6 +96,11 @@ type AdaptiveTimeoutManager interface {
 	// Registers a timeout for the item with the given [id].
 	// If the timeout occurs before the item is Removed, [timeoutHandler] is called.
 	Put(id ids.RequestID, measureLatency bool, timeoutHandler func())
+	// Registers a timeout for the item with the given [id] that expires
+	// [extension] after the current timeout duration. [extension] is capped to
+	// the maximum timeout.
+	// If the timeout occurs before the item is Removed, [timeoutHandler] is called.
+	PutWithExtension(id ids.RequestID, measureLatency bool, extension time.Duration, timeoutHandler func())
 	// Remove the timeout associated with [id].
 	// Its timeout handler will not be called.
 	Remove(id ids.RequestID)
@@ -200,19 +205,28 @@ func (tm *adaptiveTimeoutManager) Put(id ids.RequestID, measureLatency bool, tim
 	tm.lock.Lock()
 	defer tm.lock.Unlock()
 
-	tm.put(id, measureLatency, timeoutHandler)
+	tm.put(id, measureLatency, 0, timeoutHandler)
+}
+
+func (tm *adaptiveTimeoutManager) PutWithExtension(id ids.RequestID, measureLatency bool, extension time.Duration, timeoutHandler func()) {
+	tm.lock.Lock()
+	defer tm.lock.Unlock()
+
+	tm.put(id, measureLatency, extension, timeoutHandler)
 }
 
 // Assumes [tm.lock] is held
-func (tm *adaptiveTimeoutManager) put(id ids.RequestID, measureLatency bool, handler func()) {
+func (tm *adaptiveTimeoutManager) put(id ids.RequestID, measureLatency bool, extension time.Duration, handler func()) {
 	now := tm.clock.Time()
 	tm.remove(id, now)
 
+	extension = math.Min(math.Max(extension, 0), tm.maximumTimeout)
+	duration := tm.currentTimeout + extension
 	timeout := &adaptiveTimeout{
 		id:             id,
 		handler:        handler,
-		duration:       tm.currentTimeout,
-		deadline:       now.Add(tm.currentTimeout),
+		duration:       duration,
+		deadline:       now.Add(duration),
 		measureLatency: measureLatency,
 	}
 	tm.timeoutMap[id] = timeout
@@ -133,3 +133,32 @@ func TestAdaptiveTimeoutManager(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestAdaptiveTimeoutManagerPutWithExtension(t *testing.T) {
+	require := require.New(t)
+
+	tmIntf, err := NewAdaptiveTimeoutManager(
+		&AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Second,
+			MinimumTimeout:     time.Second,
+			MaximumTimeout:     time.Minute,
+			TimeoutHalflife:    5 * time.Minute,
+			TimeoutCoefficient: 1.25,
+		},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	tm := tmIntf.(*adaptiveTimeoutManager)
+
+	id := ids.RequestID{Op: 1}
+	tm.PutWithExtension(id, false, 10*time.Second, func() {})
+	require.Equal(11*time.Second, tm.timeoutMap[id].duration)
+
+	// The extension is capped at the maximum timeout
+	tm.PutWithExtension(id, false, time.Hour, func() {})
+	require.Equal(time.Minute+time.Second, tm.timeoutMap[id].duration)
+
+	tm.Remove(id)
+	require.Empty(tm.timeoutMap)
+}
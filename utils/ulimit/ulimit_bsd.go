@@ -56,3 +56,12 @@ func Set(max uint64, log logging.Logger) error {
 
 	return nil
 }
+
+// Get returns the current soft limit on the number of open file descriptors.
+func Get() (uint64, error) {
+	var rLimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rLimit); err != nil {
+		return 0, fmt.Errorf("error getting rlimit: %w", err)
+	}
+	return uint64(rLimit.Cur), nil
+}
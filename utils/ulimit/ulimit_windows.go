@@ -17,3 +17,9 @@ func Set(max uint64, log logging.Logger) error {
 	}
 	return nil
 }
+
+// Get returns [DefaultFDLimit] as file descriptor limits are not supported for
+// windows.
+func Get() (uint64, error) {
+	return DefaultFDLimit, nil
+}
@@ -14,14 +14,14 @@ import (
 
 // RPCChainVMProtocol should be bumped anytime changes are made which require
 // the plugin vm to upgrade to latest avalanchego release to be compatible.
-const RPCChainVMProtocol uint = 28
+const RPCChainVMProtocol uint = 30
 
 // These are globals that describe network upgrades and node versions
 var (
 	Current = &Semantic{
 		Major: 1,
 		Minor: 10,
-		Patch: 10,
+		Patch: 12,
 	}
 	CurrentApp = &Application{
 		Major: Current.Major,
@@ -100,6 +100,14 @@ var (
 		constants.FujiID:    time.Date(2023, time.April, 6, 15, 0, 0, 0, time.UTC),
 	}
 	CortinaDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// DustPolicyTimes are the times the X-chain starts enforcing the minimum
+	// output amount. The policy isn't scheduled on the public networks yet.
+	DustPolicyTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.FujiID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	DustPolicyDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
 )
 
 func init() {
@@ -172,6 +180,13 @@ func GetCortinaTime(networkID uint32) time.Time {
 	return CortinaDefaultTime
 }
 
+func GetDustPolicyTime(networkID uint32) time.Time {
+	if upgradeTime, exists := DustPolicyTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return DustPolicyDefaultTime
+}
+
 func GetCompatibility(networkID uint32) Compatibility {
 	return NewCompatibility(
 		CurrentApp,
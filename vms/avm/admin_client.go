@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var _ AdminClient = (*adminClient)(nil)
+
+// AdminClient for interacting with the admin API of an AVM instance
+type AdminClient interface {
+	// VerifyLinearization verifies the accepted blocks in
+	// [startHeight, endHeight]. If [endHeight] is 0, blocks are verified up to
+	// the last accepted block.
+	VerifyLinearization(
+		ctx context.Context,
+		startHeight uint64,
+		endHeight uint64,
+		options ...rpc.Option,
+	) (*VerifyLinearizationReply, error)
+	// VerifyLinearizedChain verifies every accepted block of the linearized
+	// chain by repeatedly calling VerifyLinearization.
+	VerifyLinearizedChain(ctx context.Context, options ...rpc.Option) (*VerifyLinearizationReply, error)
+}
+
+type adminClient struct {
+	requester rpc.EndpointRequester
+}
+
+// NewAdminClient returns an AVM admin client for interacting with the admin
+// API of [chain]
+func NewAdminClient(uri, chain string) AdminClient {
+	path := fmt.Sprintf(
+		"%s/ext/%s/%s/admin",
+		uri,
+		constants.ChainAliasPrefix,
+		chain,
+	)
+	return &adminClient{
+		requester: rpc.NewEndpointRequester(path),
+	}
+}
+
+func (c *adminClient) VerifyLinearization(
+	ctx context.Context,
+	startHeight uint64,
+	endHeight uint64,
+	options ...rpc.Option,
+) (*VerifyLinearizationReply, error) {
+	res := &VerifyLinearizationReply{}
+	err := c.requester.SendRequest(ctx, "admin.verifyLinearization", &VerifyLinearizationArgs{
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+	}, res, options...)
+	return res, err
+}
+
+func (c *adminClient) VerifyLinearizedChain(ctx context.Context, options ...rpc.Option) (*VerifyLinearizationReply, error) {
+	var (
+		startHeight uint64
+		numTxs      json.Uint64
+	)
+	for {
+		res, err := c.VerifyLinearization(ctx, startHeight, 0, options...)
+		if err != nil {
+			return nil, err
+		}
+		numTxs += res.NumTxs
+		if res.LastVerifiedHeight >= res.LastAcceptedHeight {
+			res.NumTxs = numTxs
+			return res, nil
+		}
+		startHeight = uint64(res.LastVerifiedHeight) + 1
+	}
+}
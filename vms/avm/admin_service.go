@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// AdminService exposes operator-only functionality of the AVM. It is only
+// served if the admin API is enabled in the chain config.
+type AdminService struct{ vm *VM }
+
+// VerifyLinearizationArgs are the arguments for calling VerifyLinearization
+type VerifyLinearizationArgs struct {
+	StartHeight json.Uint64 `json:"startHeight"`
+	// If EndHeight is 0 or past the last accepted height, blocks are verified
+	// up to the last accepted height.
+	EndHeight json.Uint64 `json:"endHeight"`
+}
+
+// VerifyLinearizationReply is the response from calling VerifyLinearization
+type VerifyLinearizationReply struct {
+	StopVertexID ids.ID `json:"stopVertexID"`
+	// LastVerifiedHeight is the height of the last block that was verified.
+	// If it is less than LastAcceptedHeight, verification should be resumed
+	// from LastVerifiedHeight+1.
+	LastVerifiedHeight json.Uint64 `json:"lastVerifiedHeight"`
+	LastAcceptedHeight json.Uint64 `json:"lastAcceptedHeight"`
+	NumTxs             json.Uint64 `json:"numTxs"`
+}
+
+// VerifyLinearization verifies the integrity of the linearized chain against
+// the state left behind by the DAG. At most 1024 blocks are verified per call.
+func (s *AdminService) VerifyLinearization(_ *http.Request, args *VerifyLinearizationArgs, reply *VerifyLinearizationReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "verifyLinearization"),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+	)
+
+	if s.vm.chainManager == nil {
+		return errNotLinearized
+	}
+
+	status, err := getLinearizationStatus(s.vm.state)
+	if err != nil {
+		return err
+	}
+
+	endHeight := uint64(args.EndHeight)
+	if endHeight == 0 || endHeight > status.LastAcceptedHeight {
+		endHeight = status.LastAcceptedHeight
+	}
+	lastVerifiedHeight, numTxs, err := verifyLinearization(s.vm.state, uint64(args.StartHeight), endHeight)
+	if err != nil {
+		s.vm.ctx.Log.Error("linearized chain verification failed",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	reply.StopVertexID = status.StopVertexID
+	reply.LastVerifiedHeight = json.Uint64(lastVerifiedHeight)
+	reply.LastAcceptedHeight = json.Uint64(status.LastAcceptedHeight)
+	reply.NumTxs = json.Uint64(numTxs)
+	return nil
+}
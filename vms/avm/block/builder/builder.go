@@ -21,8 +21,15 @@ import (
 	txexecutor "github.com/ava-labs/avalanchego/vms/avm/txs/executor"
 )
 
-// targetBlockSize is the max block size we aim to produce
-const targetBlockSize = 128 * units.KiB
+const (
+	// targetBlockSize is the max block size we aim to produce
+	targetBlockSize = 128 * units.KiB
+
+	// blockOverhead is the number of bytes of the max block size of the Subnet
+	// that are reserved for the headers of the block and of its snowman++
+	// wrapper.
+	blockOverhead = 4 * units.KiB
+)
 
 var (
 	_ Builder = (*builder)(nil)
@@ -91,8 +98,12 @@ func (b *builder) BuildBlock(context.Context) (snowman.Block, error) {
 		blockTxs      []*txs.Tx
 		inputs        set.Set[ids.ID]
 		remainingSize = targetBlockSize
+		maxBlockTxs   = ctx.Limits.MaxBlockTxs
 	)
-	for {
+	if ctx.Limits.MaxBlockSize != 0 {
+		remainingSize = ctx.Limits.MaxBlockSize - blockOverhead
+	}
+	for maxBlockTxs == 0 || len(blockTxs) < maxBlockTxs {
 		tx := b.mempool.Peek(remainingSize)
 		if tx == nil {
 			break
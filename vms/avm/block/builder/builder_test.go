@@ -26,6 +26,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
@@ -223,6 +224,7 @@ func TestBuilderBuildBlock(t *testing.T) {
 				unsignedTx := txs.NewMockUnsignedTx(ctrl)
 				unsignedTx.EXPECT().Visit(gomock.Any()).Return(nil) // Pass semantic verification
 				unsignedTx.EXPECT().Visit(gomock.Any()).Return(nil) // Pass execution
+				unsignedTx.EXPECT().SetBytes(gomock.Any()).AnyTimes()
 				tx := &txs.Tx{Unsigned: unsignedTx}
 
 				mempool := mempool.NewMockMempool(ctrl)
@@ -339,6 +341,70 @@ func TestBuilderBuildBlock(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "subnet limits",
+			builderFunc: func(ctrl *gomock.Controller) Builder {
+				preferredID := ids.GenerateTestID()
+				preferredHeight := uint64(1337)
+				preferredTimestamp := time.Now()
+				preferredBlock := block.NewMockBlock(ctrl)
+				preferredBlock.EXPECT().Height().Return(preferredHeight)
+				preferredBlock.EXPECT().Timestamp().Return(preferredTimestamp)
+
+				preferredState := states.NewMockChain(ctrl)
+				preferredState.EXPECT().GetLastAccepted().Return(preferredID)
+				preferredState.EXPECT().GetTimestamp().Return(preferredTimestamp)
+
+				unsignedTx := txs.NewMockUnsignedTx(ctrl)
+				unsignedTx.EXPECT().Visit(gomock.Any()).Return(nil) // Pass semantic verification
+				unsignedTx.EXPECT().Visit(gomock.Any()).Return(nil) // Pass execution
+				unsignedTx.EXPECT().SetBytes(gomock.Any()).AnyTimes()
+				tx := &txs.Tx{Unsigned: unsignedTx}
+
+				manager := blkexecutor.NewMockManager(ctrl)
+				manager.EXPECT().Preferred().Return(preferredID)
+				manager.EXPECT().GetStatelessBlock(preferredID).Return(preferredBlock, nil)
+				manager.EXPECT().GetState(preferredID).Return(preferredState, true)
+				manager.EXPECT().VerifyUniqueInputs(preferredID, gomock.Any()).Return(nil)
+				manager.EXPECT().NewBlock(gomock.Any()).DoAndReturn(
+					func(block *block.StandardBlock) snowman.Block {
+						require.Len(t, block.Transactions, 1)
+						require.Equal(t, tx, block.Transactions[0])
+						return nil
+					},
+				)
+
+				const maxBlockSize = 64 * units.KiB
+				mempool := mempool.NewMockMempool(ctrl)
+				// The block is full after the first tx, so the mempool
+				// shouldn't be peeked again.
+				mempool.EXPECT().Peek(maxBlockSize - blockOverhead).Return(tx)
+				mempool.EXPECT().Remove([]*txs.Tx{tx})
+				mempool.EXPECT().RequestBuildBlock()
+
+				// To marshal the tx/block
+				codec := codec.NewMockManager(ctrl)
+				codec.EXPECT().Marshal(gomock.Any(), gomock.Any()).Return([]byte{1, 2, 3}, nil).AnyTimes()
+				codec.EXPECT().Size(gomock.Any(), gomock.Any()).Return(2, nil).AnyTimes()
+
+				return New(
+					&txexecutor.Backend{
+						Codec: codec,
+						Ctx: &snow.Context{
+							Log: logging.NoLog{},
+							Limits: snow.Limits{
+								MaxBlockSize: maxBlockSize,
+								MaxBlockTxs:  1,
+							},
+						},
+					},
+					manager,
+					&mockable.Clock{},
+					mempool,
+				)
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "preferred timestamp after now",
 			builderFunc: func(ctrl *gomock.Controller) Builder {
@@ -505,7 +571,7 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 
 	registerer := prometheus.NewRegistry()
 	toEngine := make(chan common.Message, 100)
-	mempool, err := mempool.New("mempool", registerer, toEngine)
+	mempool, err := mempool.New("mempool", registerer, toEngine, 0)
 	require.NoError(err)
 	// add a tx to the mempool
 	tx := transactions[0]
@@ -528,7 +594,7 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 	baseDBManager := manager.NewMemDB(version.Semantic1_0_0)
 	baseDB := versiondb.New(baseDBManager.Current().Database)
 
-	state, err := states.New(baseDB, parser, registerer, trackChecksums)
+	state, err := states.New(baseDB, parser, registerer, trackChecksums, nil, ids.Empty)
 	require.NoError(err)
 
 	clk := &mockable.Clock{}
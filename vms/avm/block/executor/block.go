@@ -19,12 +19,15 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/states"
 	"github.com/ava-labs/avalanchego/vms/avm/txs/executor"
+
+	smblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 )
 
 const SyncBound = 10 * time.Second
 
 var (
-	_ snowman.Block = (*Block)(nil)
+	_ snowman.Block      = (*Block)(nil)
+	_ smblock.WithNumTxs = (*Block)(nil)
 
 	ErrUnexpectedMerkleRoot        = errors.New("unexpected merkle root")
 	ErrTimestampBeyondSyncBound    = errors.New("proposed timestamp is too far in the future relative to local time")
@@ -40,6 +43,29 @@ type Block struct {
 	block.Block
 	manager  *manager
 	rejected bool
+
+	// syntacticallyVerified is true if the txs of the block passed syntactic
+	// verification in PreVerify.
+	syntacticallyVerified bool
+}
+
+// PreVerify syntactically verifies the txs of the block. Syntactic
+// verification doesn't depend on the chain state, so PreVerify may be called
+// concurrently with the verification and acceptance of other blocks. If
+// PreVerify returns nil, the txs aren't syntactically verified again by
+// Verify.
+func (b *Block) PreVerify() error {
+	for _, tx := range b.Txs() {
+		err := tx.Unsigned.Visit(&executor.SyntacticVerifier{
+			Backend: b.manager.backend,
+			Tx:      tx,
+		})
+		if err != nil {
+			return fmt.Errorf("tx %s failed syntactic verification: %w", tx.ID(), err)
+		}
+	}
+	b.syntacticallyVerified = true
+	return nil
 }
 
 func (b *Block) Verify(context.Context) error {
@@ -74,16 +100,19 @@ func (b *Block) Verify(context.Context) error {
 	}
 
 	// Syntactic verification is generally pretty fast, so we verify this first
-	// before performing any possible DB reads.
-	for _, tx := range txs {
-		err := tx.Unsigned.Visit(&executor.SyntacticVerifier{
-			Backend: b.manager.backend,
-			Tx:      tx,
-		})
-		if err != nil {
-			txID := tx.ID()
-			b.manager.mempool.MarkDropped(txID, err)
-			return err
+	// before performing any possible DB reads. It is skipped if it was already
+	// performed by PreVerify.
+	if !b.syntacticallyVerified {
+		for _, tx := range txs {
+			err := tx.Unsigned.Visit(&executor.SyntacticVerifier{
+				Backend: b.manager.backend,
+				Tx:      tx,
+			})
+			if err != nil {
+				txID := tx.ID()
+				b.manager.mempool.MarkDropped(txID, err)
+				return err
+			}
 		}
 	}
 
@@ -130,6 +159,17 @@ func (b *Block) Verify(context.Context) error {
 		atomicRequests: make(map[ids.ID]*atomic.Requests),
 	}
 
+	// Signature verification is deferred until all the txs have been
+	// executed so that the signatures can be verified in parallel.
+	for _, batcher := range b.manager.batchers {
+		batcher.StartBatch()
+	}
+	defer func() {
+		for _, batcher := range b.manager.batchers {
+			batcher.AbortBatch()
+		}
+	}()
+
 	for _, tx := range txs {
 		// Verify that the tx is valid according to the current state of the
 		// chain.
@@ -194,6 +234,12 @@ func (b *Block) Verify(context.Context) error {
 		return err
 	}
 
+	for _, batcher := range b.manager.batchers {
+		if err := batcher.FinishBatch(); err != nil {
+			return err
+		}
+	}
+
 	// Now that the block has been executed, we can add the block data to the
 	// state diff.
 	stateDiff.SetLastAccepted(blkID)
@@ -294,6 +340,10 @@ func (b *Block) Reject(context.Context) error {
 	return nil
 }
 
+func (b *Block) NumTxs() (int, bool) {
+	return len(b.Txs()), true
+}
+
 func (b *Block) Status() choices.Status {
 	// If this block's reference was rejected, we should report it as rejected.
 	//
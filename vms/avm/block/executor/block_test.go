@@ -572,6 +572,51 @@ func TestBlockVerify(t *testing.T) {
 	}
 }
 
+func TestBlockPreVerify(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	mockBlock := block.NewMockBlock(ctrl)
+	mockBlock.EXPECT().ID().Return(ids.Empty).AnyTimes()
+	mockBlock.EXPECT().MerkleRoot().Return(ids.Empty).AnyTimes()
+	mockBlock.EXPECT().Timestamp().Return(time.Now()).AnyTimes()
+	mockBlock.EXPECT().Parent().Return(ids.GenerateTestID()).AnyTimes()
+
+	// The tx is only syntactically verified once.
+	mockUnsignedTx := txs.NewMockUnsignedTx(ctrl)
+	mockUnsignedTx.EXPECT().Visit(gomock.Any()).Return(nil).Times(1)
+	tx := &txs.Tx{
+		Unsigned: mockUnsignedTx,
+	}
+	mockBlock.EXPECT().Txs().Return([]*txs.Tx{tx}).AnyTimes()
+
+	mockState := states.NewMockState(ctrl)
+	mockState.EXPECT().GetBlock(gomock.Any()).Return(nil, errTest)
+	b := &Block{
+		Block: mockBlock,
+		manager: &manager{
+			state:        mockState,
+			blkIDToState: map[ids.ID]*blockState{},
+			clk:          &mockable.Clock{},
+		},
+	}
+	require.NoError(b.PreVerify())
+
+	// Verification continues past the syntactic checks.
+	err := b.Verify(context.Background())
+	require.ErrorIs(err, errTest)
+
+	// Txs that fail syntactic verification are reported by PreVerify.
+	mockUnsignedTx.EXPECT().Visit(gomock.Any()).Return(errTest).Times(1)
+	b = &Block{
+		Block:   mockBlock,
+		manager: &manager{},
+	}
+	err = b.PreVerify()
+	require.ErrorIs(err, errTest)
+	require.False(b.syntacticallyVerified)
+}
+
 func TestBlockAccept(t *testing.T) {
 	type test struct {
 		name        string
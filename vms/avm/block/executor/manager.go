@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/avm/txs/executor"
 	"github.com/ava-labs/avalanchego/vms/avm/txs/mempool"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var (
@@ -57,8 +58,15 @@ func NewManager(
 	onAccept func(*txs.Tx) error,
 ) Manager {
 	lastAccepted := state.GetLastAccepted()
+	var batchers []secp256k1fx.Batcher
+	for _, fx := range backend.Fxs {
+		if batcher, ok := fx.Fx.(secp256k1fx.Batcher); ok {
+			batchers = append(batchers, batcher)
+		}
+	}
 	return &manager{
 		backend:      backend,
+		batchers:     batchers,
 		state:        state,
 		metrics:      metrics,
 		mempool:      mempool,
@@ -76,6 +84,9 @@ type manager struct {
 	metrics metrics.Metrics
 	mempool mempool.Mempool
 	clk     *mockable.Clock
+	// batchers are the fxs that support deferring signature verification
+	// during block verification.
+	batchers []secp256k1fx.Batcher
 	// Invariant: onAccept is called when [tx] is being marked as accepted, but
 	// before its state changes are applied.
 	// Invariant: any error returned by onAccept should be considered fatal.
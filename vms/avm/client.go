@@ -30,6 +30,9 @@ type Client interface {
 	GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error)
 	// GetHeight returns the height of the last accepted block.
 	GetHeight(ctx context.Context, options ...rpc.Option) (uint64, error)
+	// GetLinearizationStatus returns whether the chain has been linearized and
+	// the stop vertex that the linearized chain was built on.
+	GetLinearizationStatus(ctx context.Context, options ...rpc.Option) (*GetLinearizationStatusReply, error)
 	// GetTxStatus returns the status of [txID]
 	//
 	// Deprecated: GetTxStatus only returns Accepted or Unknown, GetTx should be
@@ -65,6 +68,10 @@ type Client interface {
 	) ([][]byte, ids.ShortID, ids.ID, error)
 	// GetAssetDescription returns a description of [assetID]
 	GetAssetDescription(ctx context.Context, assetID string, options ...rpc.Option) (*GetAssetDescriptionReply, error)
+	// VerifyMessage returns true if [sig] is a signature of the off-chain
+	// message [msg] by [addr]. The address that signed the message is also
+	// returned.
+	VerifyMessage(ctx context.Context, msg []byte, sig []byte, addr ids.ShortID, options ...rpc.Option) (bool, ids.ShortID, error)
 	// GetBalance returns the balance of [assetID] held by [addr].
 	// If [includePartial], balance includes partial owned (i.e. in a multisig) funds.
 	//
@@ -264,6 +271,12 @@ func (c *client) GetHeight(ctx context.Context, options ...rpc.Option) (uint64,
 	return uint64(res.Height), err
 }
 
+func (c *client) GetLinearizationStatus(ctx context.Context, options ...rpc.Option) (*GetLinearizationStatusReply, error) {
+	res := &GetLinearizationStatusReply{}
+	err := c.requester.SendRequest(ctx, "avm.getLinearizationStatus", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error) {
 	txStr, err := formatting.Encode(formatting.Hex, txBytes)
 	if err != nil {
@@ -376,6 +389,35 @@ func (c *client) GetAssetDescription(ctx context.Context, assetID string, option
 	return res, err
 }
 
+func (c *client) VerifyMessage(
+	ctx context.Context,
+	msg []byte,
+	sig []byte,
+	addr ids.ShortID,
+	options ...rpc.Option,
+) (bool, ids.ShortID, error) {
+	msgStr, err := formatting.Encode(formatting.Hex, msg)
+	if err != nil {
+		return false, ids.ShortID{}, err
+	}
+	sigStr, err := formatting.Encode(formatting.Hex, sig)
+	if err != nil {
+		return false, ids.ShortID{}, err
+	}
+	res := &VerifyMessageReply{}
+	err = c.requester.SendRequest(ctx, "avm.verifyMessage", &VerifyMessageArgs{
+		Message:   msgStr,
+		Signature: sigStr,
+		Encoding:  formatting.Hex,
+		Address:   addr.String(),
+	}, res, options...)
+	if err != nil {
+		return false, ids.ShortID{}, err
+	}
+	signer, err := address.ParseToID(res.Address)
+	return res.Valid, signer, err
+}
+
 func (c *client) GetBalance(
 	ctx context.Context,
 	addr ids.ShortID,
@@ -3,6 +3,8 @@
 
 package config
 
+import "time"
+
 // Struct collecting all the foundational parameters of the AVM
 type Config struct {
 	// Fee that is burned by every non-asset creating transaction
@@ -10,4 +12,16 @@ type Config struct {
 
 	// Fee that must be burned by every asset creating transaction
 	CreateAssetTxFee uint64
+
+	// Minimum amount of the fee asset that every output of the fee asset must
+	// hold once the dust policy is activated. If 0, outputs of any amount are
+	// allowed.
+	MinOutputAmount uint64
+
+	// Time of the dust policy activation
+	DustPolicyTime time.Time
+}
+
+func (c *Config) IsDustPolicyActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.DustPolicyTime)
 }
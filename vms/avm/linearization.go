@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/avm/block"
+	"github.com/ava-labs/avalanchego/vms/avm/states"
+)
+
+// maxVerifyLinearizationBlocks is the maximum number of blocks that are
+// verified by a single call to verifyLinearization.
+const maxVerifyLinearizationBlocks = 1024
+
+var (
+	errMissingStopVertex    = errors.New("linearized genesis block doesn't reference a stop vertex")
+	errInvalidHeightRange   = errors.New("start height is greater than end height")
+	errUnexpectedHeight     = errors.New("block has unexpected height")
+	errUnexpectedParent     = errors.New("block doesn't reference the previously accepted block")
+	errTimestampDecreased   = errors.New("block timestamp is before its parent's timestamp")
+	errMissingAcceptedTx    = errors.New("accepted block contains a tx that isn't accepted")
+	errConsumedUTXONotSpent = errors.New("utxo consumed by an accepted tx is still spendable")
+)
+
+// linearizationStatus describes the linearized chain that was created after
+// the DAG was stopped.
+type linearizationStatus struct {
+	// StopVertexID is the last vertex accepted by the DAG. The linearized
+	// genesis block uses it as its parent.
+	StopVertexID        ids.ID
+	GenesisBlockID      ids.ID
+	LastAcceptedBlockID ids.ID
+	LastAcceptedHeight  uint64
+}
+
+func getLinearizationStatus(chain states.ReadOnlyChain) (*linearizationStatus, error) {
+	genesisID, err := chain.GetBlockIDAtHeight(0)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get linearized genesis block ID: %w", err)
+	}
+	genesis, err := chain.GetBlock(genesisID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get linearized genesis block %s: %w", genesisID, err)
+	}
+
+	lastAcceptedID := chain.GetLastAccepted()
+	lastAccepted, err := chain.GetBlock(lastAcceptedID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get last accepted block %s: %w", lastAcceptedID, err)
+	}
+
+	return &linearizationStatus{
+		StopVertexID:        genesis.Parent(),
+		GenesisBlockID:      genesisID,
+		LastAcceptedBlockID: lastAcceptedID,
+		LastAcceptedHeight:  lastAccepted.Height(),
+	}, nil
+}
+
+// verifyLinearization verifies the integrity of the accepted blocks in
+// [startHeight, endHeight]. At most [maxVerifyLinearizationBlocks] blocks are
+// verified, and the height of the last verified block is returned along with
+// the number of verified txs.
+//
+// The linearized chain is expected to:
+//   - Be rooted at the stop vertex of the DAG.
+//   - Have contiguous heights, with each block referencing its parent.
+//   - Have non-decreasing timestamps.
+//   - Only contain txs that are marked as accepted.
+//   - Only contain txs whose consumed UTXOs were removed from the UTXO set,
+//     including UTXOs that were produced by txs accepted in the DAG.
+func verifyLinearization(
+	chain states.ReadOnlyChain,
+	startHeight uint64,
+	endHeight uint64,
+) (uint64, int, error) {
+	if startHeight > endHeight {
+		return 0, 0, fmt.Errorf("%w: %d > %d", errInvalidHeightRange, startHeight, endHeight)
+	}
+	if endHeight-startHeight >= maxVerifyLinearizationBlocks {
+		endHeight = startHeight + maxVerifyLinearizationBlocks - 1
+	}
+
+	var (
+		parentID        ids.ID
+		parentTimestamp time.Time
+		numTxs          int
+	)
+	if startHeight > 0 {
+		parent, err := getBlockAtHeight(chain, startHeight-1)
+		if err != nil {
+			return 0, 0, err
+		}
+		parentID = parent.ID()
+		parentTimestamp = parent.Timestamp()
+	}
+
+	for height := startHeight; height <= endHeight; height++ {
+		blk, err := getBlockAtHeight(chain, height)
+		if err != nil {
+			return 0, 0, err
+		}
+		blkID := blk.ID()
+
+		if blk.Height() != height {
+			return 0, 0, fmt.Errorf("%w: block %s has height %d but is indexed at %d",
+				errUnexpectedHeight,
+				blkID,
+				blk.Height(),
+				height,
+			)
+		}
+		if height == 0 {
+			if blk.Parent() == ids.Empty {
+				return 0, 0, errMissingStopVertex
+			}
+		} else if blk.Parent() != parentID {
+			return 0, 0, fmt.Errorf("%w: block %s at height %d has parent %s, expected %s",
+				errUnexpectedParent,
+				blkID,
+				height,
+				blk.Parent(),
+				parentID,
+			)
+		}
+		timestamp := blk.Timestamp()
+		if height != 0 && timestamp.Before(parentTimestamp) {
+			return 0, 0, fmt.Errorf("%w: block %s at height %d",
+				errTimestampDecreased,
+				blkID,
+				height,
+			)
+		}
+
+		for _, tx := range blk.Txs() {
+			txID := tx.ID()
+			if _, err := chain.GetTx(txID); err != nil {
+				return 0, 0, fmt.Errorf("%w: tx %s in block %s: %w",
+					errMissingAcceptedTx,
+					txID,
+					blkID,
+					err,
+				)
+			}
+
+			for _, utxoID := range tx.Unsigned.InputUTXOs() {
+				// Imported UTXOs are stored in shared memory.
+				if utxoID.Symbolic() {
+					continue
+				}
+
+				inputID := utxoID.InputID()
+				_, err := chain.GetUTXO(inputID)
+				if err == nil {
+					return 0, 0, fmt.Errorf("%w: utxo %s consumed by tx %s in block %s",
+						errConsumedUTXONotSpent,
+						inputID,
+						txID,
+						blkID,
+					)
+				}
+				if err != database.ErrNotFound {
+					return 0, 0, fmt.Errorf("couldn't get utxo %s: %w", inputID, err)
+				}
+			}
+			numTxs++
+		}
+
+		parentID = blkID
+		parentTimestamp = timestamp
+	}
+	return endHeight, numTxs, nil
+}
+
+func getBlockAtHeight(chain states.ReadOnlyChain, height uint64) (block.Block, error) {
+	blkID, err := chain.GetBlockIDAtHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block ID at height %d: %w", height, err)
+	}
+	blk, err := chain.GetBlock(blkID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get block %s at height %d: %w", blkID, height, err)
+	}
+	return blk, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestGetLinearizationStatusNotLinearized(t *testing.T) {
+	require := require.New(t)
+
+	service := &Service{
+		vm: &VM{
+			ctx: &snow.Context{
+				Log: logging.NoLog{},
+			},
+		},
+	}
+
+	reply := GetLinearizationStatusReply{}
+	require.NoError(service.GetLinearizationStatus(nil, nil, &reply))
+	require.False(reply.Linearized)
+}
+
+func TestVerifyLinearization(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	defer func() {
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	tx := newTx(t, env.genesisBytes, env.vm, "AVAX")
+	issueAndAccept(require, env.vm, env.issuer, tx)
+
+	status := GetLinearizationStatusReply{}
+	require.NoError(env.service.GetLinearizationStatus(nil, nil, &status))
+	require.True(status.Linearized)
+	require.NotEqual(ids.Empty, status.StopVertexID)
+	require.Equal(env.vm.state.GetLastAccepted(), status.LastAcceptedBlockID)
+	require.Equal(uint64(1), uint64(status.Height))
+
+	admin := &AdminService{vm: env.vm}
+	reply := VerifyLinearizationReply{}
+	require.NoError(admin.VerifyLinearization(nil, &VerifyLinearizationArgs{}, &reply))
+	require.Equal(status.StopVertexID, reply.StopVertexID)
+	require.Equal(uint64(1), uint64(reply.LastVerifiedHeight))
+	require.Equal(uint64(1), uint64(reply.LastAcceptedHeight))
+	require.Equal(uint64(1), uint64(reply.NumTxs))
+
+	_, _, err := verifyLinearization(env.vm.state, 1, 0)
+	require.ErrorIs(err, errInvalidHeightRange)
+
+	// Re-adding a UTXO consumed by an accepted tx should be detected.
+	input := tx.Unsigned.(*txs.BaseTx).Ins[0]
+	env.vm.state.AddUTXO(&avax.UTXO{
+		UTXOID: input.UTXOID,
+		Asset:  input.Asset,
+		Out: &secp256k1fx.TransferOutput{
+			Amt: input.Input().Amount(),
+		},
+	})
+	err = admin.VerifyLinearization(nil, &VerifyLinearizationArgs{}, &reply)
+	require.ErrorIs(err, errConsumedUTXONotSpent)
+}
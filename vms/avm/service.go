@@ -4,7 +4,6 @@
 package avm
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -12,10 +11,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/rpcerror"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -40,17 +41,17 @@ const (
 )
 
 var (
-	errTxNotCreateAsset   = errors.New("transaction doesn't create an asset")
-	errNoMinters          = errors.New("no minters provided")
-	errNoHoldersOrMinters = errors.New("no minters or initialHolders provided")
-	errZeroAmount         = errors.New("amount must be positive")
-	errNoOutputs          = errors.New("no outputs to send")
-	errInvalidMintAmount  = errors.New("amount minted must be positive")
-	errNilTxID            = errors.New("nil transaction ID")
-	errNoAddresses        = errors.New("no addresses provided")
-	errNoKeys             = errors.New("from addresses have no keys or funds")
-	errMissingPrivateKey  = errors.New("argument 'privateKey' not given")
-	errNotLinearized      = errors.New("chain is not linearized")
+	errTxNotCreateAsset   = rpcerror.New(rpcerror.InvalidArgument, "transaction doesn't create an asset")
+	errNoMinters          = rpcerror.New(rpcerror.InvalidArgument, "no minters provided")
+	errNoHoldersOrMinters = rpcerror.New(rpcerror.InvalidArgument, "no minters or initialHolders provided")
+	errZeroAmount         = rpcerror.New(rpcerror.InvalidArgument, "amount must be positive")
+	errNoOutputs          = rpcerror.New(rpcerror.InvalidArgument, "no outputs to send")
+	errInvalidMintAmount  = rpcerror.New(rpcerror.InvalidArgument, "amount minted must be positive")
+	errNilTxID            = rpcerror.New(rpcerror.InvalidArgument, "nil transaction ID")
+	errNoAddresses        = rpcerror.New(rpcerror.InvalidArgument, "no addresses provided")
+	errNoKeys             = rpcerror.New(rpcerror.InsufficientFunds, "from addresses have no keys or funds")
+	errMissingPrivateKey  = rpcerror.New(rpcerror.InvalidArgument, "argument 'privateKey' not given")
+	errNotLinearized      = rpcerror.New(rpcerror.FailedPrecondition, "chain is not linearized")
 )
 
 // FormattedAssetID defines a JSON formatted struct containing an assetID as a string
@@ -180,6 +181,40 @@ func (s *Service) GetHeight(_ *http.Request, _ *struct{}, reply *api.GetHeightRe
 	return nil
 }
 
+// GetLinearizationStatusReply is the response from calling
+// GetLinearizationStatus
+type GetLinearizationStatusReply struct {
+	Linearized          bool        `json:"linearized"`
+	StopVertexID        ids.ID      `json:"stopVertexID"`
+	GenesisBlockID      ids.ID      `json:"genesisBlockID"`
+	LastAcceptedBlockID ids.ID      `json:"lastAcceptedBlockID"`
+	Height              json.Uint64 `json:"height"`
+}
+
+// GetLinearizationStatus returns whether the chain has been linearized and,
+// if it has, the stop vertex that the linearized chain was built on.
+func (s *Service) GetLinearizationStatus(_ *http.Request, _ *struct{}, reply *GetLinearizationStatusReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getLinearizationStatus"),
+	)
+
+	if s.vm.chainManager == nil {
+		return nil
+	}
+
+	status, err := getLinearizationStatus(s.vm.state)
+	if err != nil {
+		return err
+	}
+	reply.Linearized = true
+	reply.StopVertexID = status.StopVertexID
+	reply.GenesisBlockID = status.GenesisBlockID
+	reply.LastAcceptedBlockID = status.LastAcceptedBlockID
+	reply.Height = json.Uint64(status.LastAcceptedHeight)
+	return nil
+}
+
 // IssueTx attempts to issue a transaction into consensus
 func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, reply *api.JSONTxID) error {
 	s.vm.ctx.Log.Debug("API called",
@@ -481,6 +516,60 @@ func (s *Service) GetAssetDescription(_ *http.Request, args *GetAssetDescription
 	return nil
 }
 
+// VerifyMessageArgs are arguments for passing into VerifyMessage requests
+type VerifyMessageArgs struct {
+	// Message that was signed, encoded with [Encoding]
+	Message string `json:"message"`
+	// Signature of [Message], encoded with [Encoding]
+	Signature string              `json:"signature"`
+	Encoding  formatting.Encoding `json:"encoding"`
+	// Address that is expected to have signed [Message]
+	Address string `json:"address"`
+}
+
+// VerifyMessageReply defines the VerifyMessage replies returned from the API
+type VerifyMessageReply struct {
+	// True iff [Address] signed the message
+	Valid bool `json:"valid"`
+	// Address that signed the message
+	Address string `json:"address"`
+}
+
+// VerifyMessage verifies that an off-chain message was signed by the provided
+// address. The message must have been signed using the scheme defined by
+// [secp256k1.MessageHash].
+func (s *Service) VerifyMessage(_ *http.Request, args *VerifyMessageArgs, reply *VerifyMessageReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "verifyMessage"),
+		logging.UserString("address", args.Address),
+	)
+
+	addr, err := avax.ParseServiceAddress(s.vm, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+	}
+	msg, err := formatting.Decode(args.Encoding, args.Message)
+	if err != nil {
+		return fmt.Errorf("couldn't decode message: %w", err)
+	}
+	sig, err := formatting.Decode(args.Encoding, args.Signature)
+	if err != nil {
+		return fmt.Errorf("couldn't decode signature: %w", err)
+	}
+
+	factory := secp256k1.Factory{}
+	pk, err := factory.RecoverMessagePublicKey(msg, sig)
+	if err != nil {
+		return err
+	}
+
+	signer := pk.Address()
+	reply.Valid = signer == addr
+	reply.Address, err = s.vm.FormatLocalAddress(signer)
+	return err
+}
+
 // GetBalanceArgs are arguments for passing into GetBalance requests
 type GetBalanceArgs struct {
 	Address        string `json:"address"`
@@ -767,7 +856,7 @@ func (s *Service) CreateAsset(_ *http.Request, args *CreateAssetArgs, reply *Ass
 		Denomination: args.Denomination,
 		States:       []*txs.InitialState{initialState},
 	}}
-	if err := tx.SignSECP256K1Fx(s.vm.parser.Codec(), keys); err != nil {
+	if err := tx.SignSECP256K1FxWithKeychainSigners(s.vm.parser.Codec(), keys); err != nil {
 		return err
 	}
 
@@ -909,7 +998,7 @@ func (s *Service) CreateNFTAsset(_ *http.Request, args *CreateNFTAssetArgs, repl
 		Denomination: 0, // NFTs are non-fungible
 		States:       []*txs.InitialState{initialState},
 	}}
-	if err := tx.SignSECP256K1Fx(s.vm.parser.Codec(), keys); err != nil {
+	if err := tx.SignSECP256K1FxWithKeychainSigners(s.vm.parser.Codec(), keys); err != nil {
 		return err
 	}
 
@@ -1245,7 +1334,7 @@ func (s *Service) SendMultiple(_ *http.Request, args *SendMultipleArgs, reply *a
 		Ins:          ins,
 		Memo:         memoBytes,
 	}}}
-	if err := tx.SignSECP256K1Fx(s.vm.parser.Codec(), keys); err != nil {
+	if err := tx.SignSECP256K1FxWithKeychainSigners(s.vm.parser.Codec(), keys); err != nil {
 		return err
 	}
 
@@ -1364,7 +1453,7 @@ func (s *Service) Mint(_ *http.Request, args *MintArgs, reply *api.JSONTxIDChang
 		}},
 		Ops: ops,
 	}}
-	if err := tx.SignSECP256K1Fx(s.vm.parser.Codec(), keys); err != nil {
+	if err := tx.SignSECP256K1FxWithKeychainSigners(s.vm.parser.Codec(), keys); err != nil {
 		return err
 	}
 
@@ -1473,10 +1562,10 @@ func (s *Service) SendNFT(_ *http.Request, args *SendNFTArgs, reply *api.JSONTxI
 		}},
 		Ops: ops,
 	}}
-	if err := tx.SignSECP256K1Fx(s.vm.parser.Codec(), secpKeys); err != nil {
+	if err := tx.SignSECP256K1FxWithKeychainSigners(s.vm.parser.Codec(), secpKeys); err != nil {
 		return err
 	}
-	if err := tx.SignNFTFx(s.vm.parser.Codec(), nftKeys); err != nil {
+	if err := tx.SignNFTFxWithKeychainSigners(s.vm.parser.Codec(), nftKeys); err != nil {
 		return err
 	}
 
@@ -1595,10 +1684,10 @@ func (s *Service) MintNFT(_ *http.Request, args *MintNFTArgs, reply *api.JSONTxI
 		}},
 		Ops: ops,
 	}}
-	if err := tx.SignSECP256K1Fx(s.vm.parser.Codec(), secpKeys); err != nil {
+	if err := tx.SignSECP256K1FxWithKeychainSigners(s.vm.parser.Codec(), secpKeys); err != nil {
 		return err
 	}
-	if err := tx.SignNFTFx(s.vm.parser.Codec(), nftKeys); err != nil {
+	if err := tx.SignNFTFxWithKeychainSigners(s.vm.parser.Codec(), nftKeys); err != nil {
 		return err
 	}
 
@@ -1660,7 +1749,7 @@ func (s *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSONTxID)
 	}
 
 	ins := []*avax.TransferableInput{}
-	keys := [][]*secp256k1.PrivateKey{}
+	keys := [][]keychain.Signer{}
 
 	if amountSpent := amountsSpent[s.vm.feeAssetID]; amountSpent < s.vm.TxFee {
 		var localAmountsSpent map[ids.ID]uint64
@@ -1717,7 +1806,7 @@ func (s *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSONTxID)
 		SourceChain: chainID,
 		ImportedIns: importInputs,
 	}}
-	if err := tx.SignSECP256K1Fx(s.vm.parser.Codec(), keys); err != nil {
+	if err := tx.SignSECP256K1FxWithKeychainSigners(s.vm.parser.Codec(), keys); err != nil {
 		return err
 	}
 
@@ -1859,7 +1948,7 @@ func (s *Service) Export(_ *http.Request, args *ExportArgs, reply *api.JSONTxIDC
 		DestinationChain: chainID,
 		ExportedOuts:     exportOuts,
 	}}
-	if err := tx.SignSECP256K1Fx(s.vm.parser.Codec(), keys); err != nil {
+	if err := tx.SignSECP256K1FxWithKeychainSigners(s.vm.parser.Codec(), keys); err != nil {
 		return err
 	}
 
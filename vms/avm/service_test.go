@@ -2404,3 +2404,47 @@ func TestServiceGetHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceVerifyMessage(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	defer func() {
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	// The message isn't valid UTF-8, so it must be encoded to be sent as JSON.
+	msg := []byte{0xff, 0xfe, 0x00, 'h', 'i'}
+	sig, err := keys[0].SignMessage(msg)
+	require.NoError(err)
+	msgStr, err := formatting.Encode(formatting.Hex, msg)
+	require.NoError(err)
+	sigStr, err := formatting.Encode(formatting.Hex, sig)
+	require.NoError(err)
+
+	signerStr, err := env.vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	require.NoError(err)
+	otherStr, err := env.vm.FormatLocalAddress(keys[1].PublicKey().Address())
+	require.NoError(err)
+
+	reply := VerifyMessageReply{}
+	require.NoError(env.service.VerifyMessage(nil, &VerifyMessageArgs{
+		Message:   msgStr,
+		Signature: sigStr,
+		Encoding:  formatting.Hex,
+		Address:   signerStr,
+	}, &reply))
+	require.True(reply.Valid)
+	require.Equal(signerStr, reply.Address)
+
+	reply = VerifyMessageReply{}
+	require.NoError(env.service.VerifyMessage(nil, &VerifyMessageArgs{
+		Message:   msgStr,
+		Signature: sigStr,
+		Encoding:  formatting.Hex,
+		Address:   otherStr,
+	}, &reply))
+	require.False(reply.Valid)
+	require.Equal(signerStr, reply.Address)
+}
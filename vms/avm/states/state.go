@@ -17,15 +17,18 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/budget"
 	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
@@ -36,7 +39,7 @@ const (
 	statusCacheSize  = 8192
 	txCacheSize      = 8192
 	blockIDCacheSize = 8192
-	blockCacheSize   = 2048
+	blockCacheSize   = 8 * units.MiB
 
 	pruneCommitLimit           = 1024
 	pruneCommitSleepMultiplier = 5
@@ -174,15 +177,31 @@ type state struct {
 	timestamp, persistedTimestamp       time.Time
 	singletonDB                         database.Database
 
+	// allocates the capacity of the caches named [cacheNames], if not nil
+	cacheBudget *budget.Manager
+	cacheNames  []string
+
 	trackChecksum bool
 	txChecksum    ids.ID
 }
 
+func blockSize(_ ids.ID, blk block.Block) int {
+	if blk == nil {
+		return ids.IDLen + constants.PointerOverhead
+	}
+	return ids.IDLen + len(blk.Bytes()) + constants.PointerOverhead
+}
+
+// New returns the state of the chain [chainID] stored in [db]. If
+// [cacheBudget] is non-nil, the capacity of the block and UTXO caches is
+// allocated from it until the state is closed.
 func New(
 	db *versiondb.Database,
 	parser block.Parser,
 	metrics prometheus.Registerer,
 	trackChecksums bool,
+	cacheBudget *budget.Manager,
+	chainID ids.ID,
 ) (State, error) {
 	utxoDB := prefixdb.New(utxoPrefix, db)
 	statusDB := prefixdb.New(statusPrefix, db)
@@ -218,16 +237,36 @@ func New(
 		return nil, err
 	}
 
+	cacheNames := []string{
+		fmt.Sprintf("%s_block_cache", chainID),
+		fmt.Sprintf("%s_utxo_cache", chainID),
+	}
+	blockSizedCache, err := budget.NewSizedLRU[ids.ID, block.Block](
+		cacheBudget,
+		cacheNames[0],
+		blockCacheSize,
+		blockSize,
+	)
+	if err != nil {
+		return nil, err
+	}
 	blockCache, err := metercacher.New[ids.ID, block.Block](
 		"block_cache",
 		metrics,
-		&cache.LRU[ids.ID, block.Block]{Size: blockCacheSize},
+		blockSizedCache,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	utxoState, err := avax.NewMeteredUTXOState(utxoDB, parser.Codec(), metrics, trackChecksums)
+	utxoState, err := avax.NewMeteredUTXOState(
+		utxoDB,
+		parser.Codec(),
+		metrics,
+		trackChecksums,
+		cacheBudget,
+		cacheNames[1],
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -257,6 +296,9 @@ func New(
 
 		singletonDB: singletonDB,
 
+		cacheBudget: cacheBudget,
+		cacheNames:  cacheNames,
+
 		trackChecksum: trackChecksums,
 	}
 	return s, s.initTxChecksum()
@@ -522,6 +564,12 @@ func (s *state) CommitBatch() (database.Batch, error) {
 }
 
 func (s *state) Close() error {
+	if s.cacheBudget != nil {
+		for _, name := range s.cacheNames {
+			s.cacheBudget.Unregister(name)
+		}
+	}
+
 	errs := wrappers.Errs{}
 	errs.Add(
 		s.utxoDB.Close(),
@@ -101,7 +101,7 @@ func TestState(t *testing.T) {
 
 	db := memdb.New()
 	vdb := versiondb.New(db)
-	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums, nil, ids.Empty)
 	require.NoError(err)
 
 	s.AddUTXO(populatedUTXO)
@@ -109,7 +109,7 @@ func TestState(t *testing.T) {
 	s.AddBlock(populatedBlk)
 	require.NoError(s.Commit())
 
-	s, err = New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	s, err = New(vdb, parser, prometheus.NewRegistry(), trackChecksums, nil, ids.Empty)
 	require.NoError(err)
 
 	ChainUTXOTest(t, s)
@@ -122,7 +122,7 @@ func TestDiff(t *testing.T) {
 
 	db := memdb.New()
 	vdb := versiondb.New(db)
-	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums, nil, ids.Empty)
 	require.NoError(err)
 
 	s.AddUTXO(populatedUTXO)
@@ -282,7 +282,7 @@ func TestInitializeChainState(t *testing.T) {
 
 	db := memdb.New()
 	vdb := versiondb.New(db)
-	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums, nil, ids.Empty)
 	require.NoError(err)
 
 	stopVertexID := ids.GenerateTestID()
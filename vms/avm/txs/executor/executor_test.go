@@ -44,7 +44,7 @@ func TestBaseTxExecutor(t *testing.T) {
 	db := memdb.New()
 	vdb := versiondb.New(db)
 	registerer := prometheus.NewRegistry()
-	state, err := states.New(vdb, parser, registerer, trackChecksums)
+	state, err := states.New(vdb, parser, registerer, trackChecksums, nil, ids.Empty)
 	require.NoError(err)
 
 	utxoID := avax.UTXOID{
@@ -149,7 +149,7 @@ func TestCreateAssetTxExecutor(t *testing.T) {
 	db := memdb.New()
 	vdb := versiondb.New(db)
 	registerer := prometheus.NewRegistry()
-	state, err := states.New(vdb, parser, registerer, trackChecksums)
+	state, err := states.New(vdb, parser, registerer, trackChecksums, nil, ids.Empty)
 	require.NoError(err)
 
 	utxoID := avax.UTXOID{
@@ -292,7 +292,7 @@ func TestOperationTxExecutor(t *testing.T) {
 	db := memdb.New()
 	vdb := versiondb.New(db)
 	registerer := prometheus.NewRegistry()
-	state, err := states.New(vdb, parser, registerer, trackChecksums)
+	state, err := states.New(vdb, parser, registerer, trackChecksums, nil, ids.Empty)
 	require.NoError(err)
 
 	outputOwners := secp256k1fx.OutputOwners{
@@ -6,6 +6,7 @@ package executor
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -22,6 +23,7 @@ var (
 	errNotAnAsset      = errors.New("not an asset")
 	errIncompatibleFx  = errors.New("incompatible feature extension")
 	errUnknownFx       = errors.New("unknown feature extension")
+	errDustOutput      = errors.New("output amount is below the minimum output amount")
 )
 
 type SemanticVerifier struct {
@@ -50,6 +52,10 @@ func (v *SemanticVerifier) BaseTx(tx *txs.BaseTx) error {
 		if err := v.verifyFxUsage(fxIndex, assetID); err != nil {
 			return err
 		}
+
+		if err := v.verifyOutputAmount(out); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -142,6 +148,10 @@ func (v *SemanticVerifier) ExportTx(tx *txs.ExportTx) error {
 		if err := v.verifyFxUsage(fxIndex, assetID); err != nil {
 			return err
 		}
+
+		if err := v.verifyOutputAmount(out); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -242,6 +252,23 @@ func (v *SemanticVerifier) verifyFxUsage(
 	return errIncompatibleFx
 }
 
+// verifyOutputAmount verifies that [out] isn't dust. Once the dust policy is
+// activated, outputs of the fee asset must hold at least the minimum output
+// amount.
+func (v *SemanticVerifier) verifyOutputAmount(out *avax.TransferableOutput) error {
+	minOutputAmount := v.Config.MinOutputAmount
+	if minOutputAmount == 0 ||
+		out.AssetID() != v.FeeAssetID ||
+		!v.Config.IsDustPolicyActivated(v.State.GetTimestamp()) {
+		return nil
+	}
+
+	if amount := out.Out.Amount(); amount < minOutputAmount {
+		return fmt.Errorf("%w: %d < %d", errDustOutput, amount, minOutputAmount)
+	}
+	return nil
+}
+
 func (v *SemanticVerifier) getFx(val interface{}) (int, error) {
 	valType := reflect.TypeOf(val)
 	fx, exists := v.TypeToFxIndex[valType]
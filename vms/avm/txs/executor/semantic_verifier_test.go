@@ -6,6 +6,7 @@ package executor
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/avm/config"
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 	"github.com/ava-labs/avalanchego/vms/avm/states"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
@@ -1133,3 +1135,121 @@ func TestSemanticVerifierImportTx(t *testing.T) {
 		})
 	}
 }
+
+func TestSemanticVerifierDustOutput(t *testing.T) {
+	typeToFxIndex := make(map[reflect.Type]int)
+	secpFx := &secp256k1fx.Fx{}
+	parser, err := txs.NewCustomParser(
+		typeToFxIndex,
+		new(mockable.Clock),
+		logging.NoWarn{},
+		[]fxs.Fx{
+			secpFx,
+		},
+	)
+	require.NoError(t, err)
+
+	var (
+		activationTime = time.Unix(1000, 0)
+		feeAssetID     = ids.GenerateTestID()
+		otherAssetID   = ids.GenerateTestID()
+		createAssetTx  = &txs.Tx{
+			Unsigned: &txs.CreateAssetTx{
+				States: []*txs.InitialState{{
+					FxIndex: 0,
+				}},
+			},
+		}
+	)
+
+	backend := &Backend{
+		Ctx: newContext(t),
+		Config: &config.Config{
+			MinOutputAmount: 100,
+			DustPolicyTime:  activationTime,
+		},
+		Fxs: []*fxs.ParsedFx{
+			{
+				ID: secp256k1fx.ID,
+				Fx: secpFx,
+			},
+		},
+		TypeToFxIndex: typeToFxIndex,
+		Codec:         parser.Codec(),
+		FeeAssetID:    feeAssetID,
+		Bootstrapped:  true,
+	}
+
+	tests := []struct {
+		name      string
+		assetID   ids.ID
+		amount    uint64
+		timestamp time.Time
+		err       error
+	}{
+		{
+			name:      "dust before activation",
+			assetID:   feeAssetID,
+			amount:    99,
+			timestamp: activationTime.Add(-time.Second),
+			err:       nil,
+		},
+		{
+			name:      "dust after activation",
+			assetID:   feeAssetID,
+			amount:    99,
+			timestamp: activationTime,
+			err:       errDustOutput,
+		},
+		{
+			name:      "minimum amount after activation",
+			assetID:   feeAssetID,
+			amount:    100,
+			timestamp: activationTime,
+			err:       nil,
+		},
+		{
+			name:      "other asset after activation",
+			assetID:   otherAssetID,
+			amount:    1,
+			timestamp: activationTime,
+			err:       nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			state := states.NewMockChain(ctrl)
+			state.EXPECT().GetTx(test.assetID).Return(createAssetTx, nil)
+			state.EXPECT().GetTimestamp().Return(test.timestamp).AnyTimes()
+
+			tx := &txs.Tx{
+				Unsigned: &txs.BaseTx{
+					BaseTx: avax.BaseTx{
+						Outs: []*avax.TransferableOutput{{
+							Asset: avax.Asset{ID: test.assetID},
+							Out: &secp256k1fx.TransferOutput{
+								Amt: test.amount,
+								OutputOwners: secp256k1fx.OutputOwners{
+									Threshold: 1,
+									Addrs: []ids.ShortID{
+										keys[0].Address(),
+									},
+								},
+							},
+						}},
+					},
+				},
+			}
+
+			err := tx.Unsigned.Visit(&SemanticVerifier{
+				Backend: backend,
+				State:   state,
+				Tx:      tx,
+			})
+			require.ErrorIs(err, test.err)
+		})
+	}
+}
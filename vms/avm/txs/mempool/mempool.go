@@ -71,6 +71,9 @@ type mempool struct {
 
 	toEngine chan<- common.Message
 
+	// Txs larger than [maxTxSize] bytes aren't added to the mempool
+	maxTxSize int
+
 	// Key: Tx ID
 	// Value: Verification error
 	droppedTxIDs *cache.LRU[ids.ID, error]
@@ -78,10 +81,13 @@ type mempool struct {
 	consumedUTXOs set.Set[ids.ID]
 }
 
+// New returns a mempool that only accepts txs of at most [maxTxSize] bytes. If
+// [maxTxSize] is 0, [MaxTxSize] is used.
 func New(
 	namespace string,
 	registerer prometheus.Registerer,
 	toEngine chan<- common.Message,
+	maxTxSize int,
 ) (Mempool, error) {
 	bytesAvailableMetric := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
@@ -101,6 +107,10 @@ func New(
 		return nil, err
 	}
 
+	if maxTxSize == 0 {
+		maxTxSize = MaxTxSize
+	}
+
 	bytesAvailableMetric.Set(maxMempoolSize)
 	return &mempool{
 		bytesAvailableMetric: bytesAvailableMetric,
@@ -108,6 +118,7 @@ func New(
 		unissuedTxs:          linkedhashmap.New[ids.ID, *txs.Tx](),
 		numTxs:               numTxsMetric,
 		toEngine:             toEngine,
+		maxTxSize:            maxTxSize,
 		droppedTxIDs:         &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
 		consumedUTXOs:        set.NewSet[ids.ID](initialConsumedUTXOsSize),
 	}, nil
@@ -121,12 +132,12 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	}
 
 	txSize := len(tx.Bytes())
-	if txSize > MaxTxSize {
+	if txSize > m.maxTxSize {
 		return fmt.Errorf("%w: %s size (%d) > max size (%d)",
 			errTxTooLarge,
 			txID,
 			txSize,
-			MaxTxSize,
+			m.maxTxSize,
 		)
 	}
 	if txSize > m.bytesAvailable {
@@ -33,7 +33,7 @@ func TestBlockBuilderMaxMempoolSizeHandling(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mempoolIntf, err := New("mempool", registerer, nil)
+	mempoolIntf, err := New("mempool", registerer, nil, 0)
 	require.NoError(err)
 
 	mempool := mempoolIntf.(*mempool)
@@ -53,12 +53,28 @@ func TestBlockBuilderMaxMempoolSizeHandling(t *testing.T) {
 	require.NoError(mempool.Add(tx))
 }
 
+func TestMempoolMaxTxSize(t *testing.T) {
+	require := require.New(t)
+
+	tx := createTestTxs(1)[0]
+	txSize := len(tx.Bytes())
+
+	mempool, err := New("mempool", prometheus.NewRegistry(), nil, txSize-1)
+	require.NoError(err)
+	err = mempool.Add(tx)
+	require.ErrorIs(err, errTxTooLarge)
+
+	mempool, err = New("mempool", prometheus.NewRegistry(), nil, txSize)
+	require.NoError(err)
+	require.NoError(mempool.Add(tx))
+}
+
 func TestTxsInMempool(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
 	toEngine := make(chan common.Message, 100)
-	mempool, err := New("mempool", registerer, toEngine)
+	mempool, err := New("mempool", registerer, toEngine, 0)
 	require.NoError(err)
 
 	testTxs := createTestTxs(2)
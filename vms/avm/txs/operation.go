@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -86,13 +87,13 @@ func IsSortedAndUniqueOperations(ops []*Operation, c codec.Manager) bool {
 	return utils.IsSortedAndUnique(sortableOps)
 }
 
-type innerSortOperationsWithSigners struct {
+type innerSortOperationsWithSigners[T any] struct {
 	ops     []*Operation
-	signers [][]*secp256k1.PrivateKey
+	signers [][]T
 	codec   codec.Manager
 }
 
-func (ops *innerSortOperationsWithSigners) Less(i, j int) bool {
+func (ops *innerSortOperationsWithSigners[_]) Less(i, j int) bool {
 	iOp := ops.ops[i]
 	jOp := ops.ops[j]
 
@@ -107,15 +108,19 @@ func (ops *innerSortOperationsWithSigners) Less(i, j int) bool {
 	return bytes.Compare(iBytes, jBytes) == -1
 }
 
-func (ops *innerSortOperationsWithSigners) Len() int {
+func (ops *innerSortOperationsWithSigners[_]) Len() int {
 	return len(ops.ops)
 }
 
-func (ops *innerSortOperationsWithSigners) Swap(i, j int) {
+func (ops *innerSortOperationsWithSigners[_]) Swap(i, j int) {
 	ops.ops[j], ops.ops[i] = ops.ops[i], ops.ops[j]
 	ops.signers[j], ops.signers[i] = ops.signers[i], ops.signers[j]
 }
 
 func SortOperationsWithSigners(ops []*Operation, signers [][]*secp256k1.PrivateKey, codec codec.Manager) {
-	sort.Sort(&innerSortOperationsWithSigners{ops: ops, signers: signers, codec: codec})
+	sort.Sort(&innerSortOperationsWithSigners[*secp256k1.PrivateKey]{ops: ops, signers: signers, codec: codec})
+}
+
+func SortOperationsWithKeychainSigners(ops []*Operation, signers [][]keychain.Signer, codec codec.Manager) {
+	sort.Sort(&innerSortOperationsWithSigners[keychain.Signer]{ops: ops, signers: signers, codec: codec})
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/set"
@@ -90,6 +91,10 @@ func (t *Tx) UTXOs() []*avax.UTXO {
 }
 
 func (t *Tx) SignSECP256K1Fx(c codec.Manager, signers [][]*secp256k1.PrivateKey) error {
+	return t.SignSECP256K1FxWithKeychainSigners(c, toKeychainSigners(signers))
+}
+
+func (t *Tx) SignSECP256K1FxWithKeychainSigners(c codec.Manager, signers [][]keychain.Signer) error {
 	unsignedBytes, err := c.Marshal(CodecVersion, &t.Unsigned)
 	if err != nil {
 		return fmt.Errorf("problem creating transaction: %w", err)
@@ -119,6 +124,10 @@ func (t *Tx) SignSECP256K1Fx(c codec.Manager, signers [][]*secp256k1.PrivateKey)
 }
 
 func (t *Tx) SignPropertyFx(c codec.Manager, signers [][]*secp256k1.PrivateKey) error {
+	return t.SignPropertyFxWithKeychainSigners(c, toKeychainSigners(signers))
+}
+
+func (t *Tx) SignPropertyFxWithKeychainSigners(c codec.Manager, signers [][]keychain.Signer) error {
 	unsignedBytes, err := c.Marshal(CodecVersion, &t.Unsigned)
 	if err != nil {
 		return fmt.Errorf("problem creating transaction: %w", err)
@@ -148,6 +157,10 @@ func (t *Tx) SignPropertyFx(c codec.Manager, signers [][]*secp256k1.PrivateKey)
 }
 
 func (t *Tx) SignNFTFx(c codec.Manager, signers [][]*secp256k1.PrivateKey) error {
+	return t.SignNFTFxWithKeychainSigners(c, toKeychainSigners(signers))
+}
+
+func (t *Tx) SignNFTFxWithKeychainSigners(c codec.Manager, signers [][]keychain.Signer) error {
 	unsignedBytes, err := c.Marshal(CodecVersion, &t.Unsigned)
 	if err != nil {
 		return fmt.Errorf("problem creating transaction: %w", err)
@@ -175,3 +188,14 @@ func (t *Tx) SignNFTFx(c codec.Manager, signers [][]*secp256k1.PrivateKey) error
 	t.SetBytes(unsignedBytes, signedBytes)
 	return nil
 }
+
+func toKeychainSigners(keys [][]*secp256k1.PrivateKey) [][]keychain.Signer {
+	signers := make([][]keychain.Signer, len(keys))
+	for i, inputKeys := range keys {
+		signers[i] = make([]keychain.Signer, len(inputKeys))
+		for j, key := range inputKeys {
+			signers[i][j] = key
+		}
+	}
+	return signers
+}
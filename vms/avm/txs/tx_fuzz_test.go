@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func FuzzParseTx(f *testing.F) {
+	parser, err := NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	})
+	require.NoError(f, err)
+
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+	}
+	baseTx := BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: chainID,
+		Outs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          12345,
+				OutputOwners: owners,
+			},
+		}},
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{
+				TxID:        ids.ID{'t', 'x', 'I', 'D'},
+				OutputIndex: 1,
+			},
+			Asset: avax.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt:   54321,
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		}},
+		Memo: []byte{0x00, 0x01, 0x02, 0x03},
+	}}
+
+	// Seed the corpus with the most commonly issued transaction types.
+	for _, utx := range []UnsignedTx{
+		&baseTx,
+		&CreateAssetTx{
+			BaseTx:       baseTx,
+			Name:         "Volatility Index",
+			Symbol:       "VIX",
+			Denomination: 2,
+			States: []*InitialState{{
+				FxIndex: 0,
+				Outs: []verify.State{
+					&secp256k1fx.MintOutput{OutputOwners: owners},
+					&secp256k1fx.TransferOutput{
+						Amt:          1000,
+						OutputOwners: owners,
+					},
+				},
+			}},
+		},
+		&ExportTx{
+			BaseTx:           baseTx,
+			DestinationChain: constants.PlatformChainID,
+			ExportedOuts:     baseTx.Outs,
+		},
+	} {
+		tx := &Tx{Unsigned: utx}
+		require.NoError(f, tx.SignSECP256K1Fx(parser.Codec(), [][]*secp256k1.PrivateKey{{keys[0]}}))
+		f.Add(tx.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, txBytes []byte) {
+		require := require.New(t)
+
+		tx, err := parser.ParseTx(txBytes)
+		if err != nil {
+			return
+		}
+
+		// Parsing is expected to only succeed on canonically encoded
+		// transactions.
+		reencodedBytes, err := parser.Codec().Marshal(CodecVersion, tx)
+		require.NoError(err)
+		require.Equal(txBytes, reencodedBytes)
+
+		_ = tx.Unsigned.InputIDs()
+		_ = tx.Unsigned.InputUTXOs()
+		_ = tx.UTXOs()
+	})
+}
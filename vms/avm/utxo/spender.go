@@ -9,7 +9,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
@@ -41,7 +41,7 @@ type Spender interface {
 	) (
 		map[ids.ID]uint64, // amountsSpent
 		[]*avax.TransferableInput, // inputs
-		[][]*secp256k1.PrivateKey, // signers
+		[][]keychain.Signer, // signers
 		error,
 	)
 
@@ -53,7 +53,7 @@ type Spender interface {
 		to ids.ShortID,
 	) (
 		[]*txs.Operation,
-		[][]*secp256k1.PrivateKey,
+		[][]keychain.Signer,
 		error,
 	)
 
@@ -63,7 +63,7 @@ type Spender interface {
 	) (
 		map[ids.ID]uint64,
 		[]*avax.TransferableInput,
-		[][]*secp256k1.PrivateKey,
+		[][]keychain.Signer,
 		error,
 	)
 
@@ -74,7 +74,7 @@ type Spender interface {
 		to ids.ShortID,
 	) (
 		[]*txs.Operation,
-		[][]*secp256k1.PrivateKey,
+		[][]keychain.Signer,
 		error,
 	)
 
@@ -86,7 +86,7 @@ type Spender interface {
 		to ids.ShortID,
 	) (
 		[]*txs.Operation,
-		[][]*secp256k1.PrivateKey,
+		[][]keychain.Signer,
 		error,
 	)
 }
@@ -113,14 +113,14 @@ func (s *spender) Spend(
 ) (
 	map[ids.ID]uint64, // amountsSpent
 	[]*avax.TransferableInput, // inputs
-	[][]*secp256k1.PrivateKey, // signers
+	[][]keychain.Signer, // signers
 	error,
 ) {
 	amountsSpent := make(map[ids.ID]uint64, len(amounts))
 	time := s.clock.Unix()
 
 	ins := []*avax.TransferableInput{}
-	keys := [][]*secp256k1.PrivateKey{}
+	keys := [][]keychain.Signer{}
 	for _, utxo := range utxos {
 		assetID := utxo.AssetID()
 		amount := amounts[assetID]
@@ -131,7 +131,7 @@ func (s *spender) Spend(
 			continue
 		}
 
-		inputIntf, signers, err := kc.Spend(utxo.Out, time)
+		inputIntf, signers, err := kc.SpendSigners(utxo.Out, time)
 		if err != nil {
 			// this utxo can't be spent with the current keys right now
 			continue
@@ -168,7 +168,7 @@ func (s *spender) Spend(
 		}
 	}
 
-	avax.SortTransferableInputsWithSigners(ins, keys)
+	avax.SortTransferableInputsWithKeychainSigners(ins, keys)
 	return amountsSpent, ins, keys, nil
 }
 
@@ -180,13 +180,13 @@ func (s *spender) SpendNFT(
 	to ids.ShortID,
 ) (
 	[]*txs.Operation,
-	[][]*secp256k1.PrivateKey,
+	[][]keychain.Signer,
 	error,
 ) {
 	time := s.clock.Unix()
 
 	ops := []*txs.Operation{}
-	keys := [][]*secp256k1.PrivateKey{}
+	keys := [][]keychain.Signer{}
 
 	for _, utxo := range utxos {
 		// makes sure that the variable isn't overwritten with the next iteration
@@ -210,7 +210,7 @@ func (s *spender) SpendNFT(
 			// wrong group id
 			continue
 		}
-		indices, signers, ok := kc.Match(&out.OutputOwners, time)
+		indices, signers, ok := kc.MatchSigners(&out.OutputOwners, time)
 		if !ok {
 			// unable to spend the output
 			continue
@@ -242,7 +242,7 @@ func (s *spender) SpendNFT(
 		return nil, nil, errInsufficientFunds
 	}
 
-	txs.SortOperationsWithSigners(ops, keys, s.codec)
+	txs.SortOperationsWithKeychainSigners(ops, keys, s.codec)
 	return ops, keys, nil
 }
 
@@ -252,19 +252,19 @@ func (s *spender) SpendAll(
 ) (
 	map[ids.ID]uint64,
 	[]*avax.TransferableInput,
-	[][]*secp256k1.PrivateKey,
+	[][]keychain.Signer,
 	error,
 ) {
 	amountsSpent := make(map[ids.ID]uint64)
 	time := s.clock.Unix()
 
 	ins := []*avax.TransferableInput{}
-	keys := [][]*secp256k1.PrivateKey{}
+	keys := [][]keychain.Signer{}
 	for _, utxo := range utxos {
 		assetID := utxo.AssetID()
 		amountSpent := amountsSpent[assetID]
 
-		inputIntf, signers, err := kc.Spend(utxo.Out, time)
+		inputIntf, signers, err := kc.SpendSigners(utxo.Out, time)
 		if err != nil {
 			// this utxo can't be spent with the current keys right now
 			continue
@@ -291,7 +291,7 @@ func (s *spender) SpendAll(
 		keys = append(keys, signers)
 	}
 
-	avax.SortTransferableInputsWithSigners(ins, keys)
+	avax.SortTransferableInputsWithKeychainSigners(ins, keys)
 	return amountsSpent, ins, keys, nil
 }
 
@@ -302,13 +302,13 @@ func (s *spender) Mint(
 	to ids.ShortID,
 ) (
 	[]*txs.Operation,
-	[][]*secp256k1.PrivateKey,
+	[][]keychain.Signer,
 	error,
 ) {
 	time := s.clock.Unix()
 
 	ops := []*txs.Operation{}
-	keys := [][]*secp256k1.PrivateKey{}
+	keys := [][]keychain.Signer{}
 
 	for _, utxo := range utxos {
 		// makes sure that the variable isn't overwritten with the next iteration
@@ -325,7 +325,7 @@ func (s *spender) Mint(
 			continue
 		}
 
-		inIntf, signers, err := kc.Spend(out, time)
+		inIntf, signers, err := kc.SpendSigners(out, time)
 		if err != nil {
 			continue
 		}
@@ -364,7 +364,7 @@ func (s *spender) Mint(
 		}
 	}
 
-	txs.SortOperationsWithSigners(ops, keys, s.codec)
+	txs.SortOperationsWithKeychainSigners(ops, keys, s.codec)
 	return ops, keys, nil
 }
 
@@ -376,13 +376,13 @@ func (s *spender) MintNFT(
 	to ids.ShortID,
 ) (
 	[]*txs.Operation,
-	[][]*secp256k1.PrivateKey,
+	[][]keychain.Signer,
 	error,
 ) {
 	time := s.clock.Unix()
 
 	ops := []*txs.Operation{}
-	keys := [][]*secp256k1.PrivateKey{}
+	keys := [][]keychain.Signer{}
 
 	for _, utxo := range utxos {
 		// makes sure that the variable isn't overwritten with the next iteration
@@ -403,7 +403,7 @@ func (s *spender) MintNFT(
 			continue
 		}
 
-		indices, signers, ok := kc.Match(&out.OutputOwners, time)
+		indices, signers, ok := kc.MatchSigners(&out.OutputOwners, time)
 		if !ok {
 			// unable to spend the output
 			continue
@@ -435,6 +435,6 @@ func (s *spender) MintNFT(
 		return nil, nil, errAddressesCantMintAsset
 	}
 
-	txs.SortOperationsWithSigners(ops, keys, s.codec)
+	txs.SortOperationsWithKeychainSigners(ops, keys, s.codec)
 	return ops, keys, nil
 }
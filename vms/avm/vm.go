@@ -47,6 +47,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
+	smblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	blockbuilder "github.com/ava-labs/avalanchego/vms/avm/block/builder"
 	blockexecutor "github.com/ava-labs/avalanchego/vms/avm/block/executor"
 	extensions "github.com/ava-labs/avalanchego/vms/avm/fxs"
@@ -60,8 +61,10 @@ var (
 	errUnknownFx                 = errors.New("unknown feature extension")
 	errGenesisAssetMustHaveState = errors.New("genesis asset must have non-empty state")
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
+	errUnexpectedBlockType       = errors.New("unexpected block type")
 
 	_ vertex.LinearizableVMWithEngine = (*VM)(nil)
+	_ smblock.PreVerifierChainVM      = (*VM)(nil)
 )
 
 type VM struct {
@@ -110,6 +113,8 @@ type VM struct {
 
 	walletService WalletService
 
+	adminAPIEnabled bool
+
 	addressTxsIndexer index.AddressTxsIndexer
 
 	txBackend *txexecutor.Backend
@@ -138,6 +143,7 @@ type Config struct {
 	IndexTransactions    bool `json:"index-transactions"`
 	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
 	ChecksumsEnabled     bool `json:"checksums-enabled"`
+	AdminAPIEnabled      bool `json:"admin-api-enabled"`
 }
 
 func (vm *VM) Initialize(
@@ -206,6 +212,18 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	// Signatures verified during mempool admission are cached so that they
+	// aren't re-verified during block verification.
+	sigCache, err := secp256k1fx.NewSigCache(secp256k1fx.DefaultSigCacheSize, "", registerer)
+	if err != nil {
+		return err
+	}
+	for _, fx := range vm.fxs {
+		if sigCacher, ok := fx.Fx.(secp256k1fx.SigCacher); ok {
+			sigCacher.SetSigCache(sigCache)
+		}
+	}
+
 	vm.typeToFxIndex = map[reflect.Type]int{}
 	vm.parser, err = block.NewCustomParser(
 		vm.typeToFxIndex,
@@ -226,6 +244,8 @@ func (vm *VM) Initialize(
 		vm.parser,
 		vm.registerer,
 		avmConfig.ChecksumsEnabled,
+		ctx.CacheBudget,
+		ctx.ChainID,
 	)
 	if err != nil {
 		return err
@@ -238,6 +258,7 @@ func (vm *VM) Initialize(
 	}
 
 	vm.walletService.vm = vm
+	vm.adminAPIEnabled = avmConfig.AdminAPIEnabled
 	vm.walletService.pendingTxs = linkedhashmap.New[ids.ID, *txs.Tx]()
 
 	// use no op impl when disabled in config
@@ -338,13 +359,30 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 	walletServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
 	walletServer.RegisterAfterFunc(vm.metrics.AfterRequest)
 	// name this service "wallet"
-	err := walletServer.RegisterService(&vm.walletService, "wallet")
+	if err := walletServer.RegisterService(&vm.walletService, "wallet"); err != nil {
+		return nil, err
+	}
 
-	return map[string]*common.HTTPHandler{
+	handlers := map[string]*common.HTTPHandler{
 		"":        {Handler: rpcServer},
 		"/wallet": {Handler: walletServer},
 		"/events": {LockOptions: common.NoLock, Handler: vm.pubsub},
-	}, err
+	}
+	if !vm.adminAPIEnabled {
+		return handlers, nil
+	}
+
+	adminServer := rpc.NewServer()
+	adminServer.RegisterCodec(codec, "application/json")
+	adminServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	adminServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
+	adminServer.RegisterAfterFunc(vm.metrics.AfterRequest)
+	// name this service "admin"
+	if err := adminServer.RegisterService(&AdminService{vm: vm}, "admin"); err != nil {
+		return nil, err
+	}
+	handlers["/admin"] = &common.HTTPHandler{Handler: adminServer}
+	return handlers, nil
 }
 
 func (*VM) CreateStaticHandlers(context.Context) (map[string]*common.HTTPHandler, error) {
@@ -370,6 +408,17 @@ func (vm *VM) GetBlock(_ context.Context, blkID ids.ID) (snowman.Block, error) {
 	return vm.chainManager.GetBlock(blkID)
 }
 
+// PreVerifyBlock syntactically verifies the txs of [blk]. Blocks are only
+// pre-verified while bootstrapping, when signatures aren't verified, so there
+// are no other checks that can be performed without the chain state.
+func (vm *VM) PreVerifyBlock(_ context.Context, blk snowman.Block) error {
+	b, ok := blk.(*blockexecutor.Block)
+	if !ok {
+		return fmt.Errorf("%w: %T", errUnexpectedBlockType, blk)
+	}
+	return b.PreVerify()
+}
+
 func (vm *VM) ParseBlock(_ context.Context, blkBytes []byte) (snowman.Block, error) {
 	blk, err := vm.parser.ParseBlock(blkBytes)
 	if err != nil {
@@ -408,7 +457,7 @@ func (vm *VM) Linearize(_ context.Context, stopVertexID ids.ID, toEngine chan<-
 		return err
 	}
 
-	mempool, err := mempool.New("mempool", vm.registerer, toEngine)
+	mempool, err := mempool.New("mempool", vm.registerer, toEngine, vm.ctx.Limits.MaxTxSize)
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
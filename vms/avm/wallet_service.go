@@ -291,7 +291,7 @@ func (w *WalletService) SendMultiple(_ *http.Request, args *SendMultipleArgs, re
 		Ins:          ins,
 		Memo:         memoBytes,
 	}}}
-	if err := tx.SignSECP256K1Fx(codec, keys); err != nil {
+	if err := tx.SignSECP256K1FxWithKeychainSigners(codec, keys); err != nil {
 		return err
 	}
 
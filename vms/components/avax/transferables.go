@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 )
@@ -165,12 +166,12 @@ func (in *TransferableInput) Less(other *TransferableInput) bool {
 	return in.UTXOID.Less(&other.UTXOID)
 }
 
-type innerSortTransferableInputsWithSigners struct {
+type innerSortTransferableInputsWithSigners[T any] struct {
 	ins     []*TransferableInput
-	signers [][]*secp256k1.PrivateKey
+	signers [][]T
 }
 
-func (ins *innerSortTransferableInputsWithSigners) Less(i, j int) bool {
+func (ins *innerSortTransferableInputsWithSigners[_]) Less(i, j int) bool {
 	iID, iIndex := ins.ins[i].InputSource()
 	jID, jIndex := ins.ins[j].InputSource()
 
@@ -184,11 +185,11 @@ func (ins *innerSortTransferableInputsWithSigners) Less(i, j int) bool {
 	}
 }
 
-func (ins *innerSortTransferableInputsWithSigners) Len() int {
+func (ins *innerSortTransferableInputsWithSigners[_]) Len() int {
 	return len(ins.ins)
 }
 
-func (ins *innerSortTransferableInputsWithSigners) Swap(i, j int) {
+func (ins *innerSortTransferableInputsWithSigners[_]) Swap(i, j int) {
 	ins.ins[j], ins.ins[i] = ins.ins[i], ins.ins[j]
 	ins.signers[j], ins.signers[i] = ins.signers[i], ins.signers[j]
 }
@@ -196,7 +197,13 @@ func (ins *innerSortTransferableInputsWithSigners) Swap(i, j int) {
 // SortTransferableInputsWithSigners sorts the inputs and signers based on the
 // input's utxo ID
 func SortTransferableInputsWithSigners(ins []*TransferableInput, signers [][]*secp256k1.PrivateKey) {
-	sort.Sort(&innerSortTransferableInputsWithSigners{ins: ins, signers: signers})
+	sort.Sort(&innerSortTransferableInputsWithSigners[*secp256k1.PrivateKey]{ins: ins, signers: signers})
+}
+
+// SortTransferableInputsWithKeychainSigners sorts the inputs and signers based
+// on the input's utxo ID
+func SortTransferableInputsWithKeychainSigners(ins []*TransferableInput, signers [][]keychain.Signer) {
+	sort.Sort(&innerSortTransferableInputsWithSigners[keychain.Signer]{ins: ins, signers: signers})
 }
 
 // VerifyTx verifies that the inputs and outputs flowcheck, including a fee.
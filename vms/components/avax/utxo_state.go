@@ -7,17 +7,23 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/budget"
 	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/linkeddb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
 )
 
 const (
 	utxoCacheSize  = 8192
 	indexCacheSize = 64
+
+	// estimatedUTXOSize is the number of bytes a cached UTXO is assumed to
+	// take, as UTXOs are cached without their serialized form.
+	estimatedUTXOSize = 256
 )
 
 var (
@@ -103,16 +109,30 @@ func NewUTXOState(
 	return s, s.initChecksum()
 }
 
+// NewMeteredUTXOState returns a UTXOState whose caches report metrics to
+// [metrics]. If [cacheBudget] is non-nil, the capacity of the UTXO cache is
+// allocated from it under [utxoCacheName].
 func NewMeteredUTXOState(
 	db database.Database,
 	codec codec.Manager,
 	metrics prometheus.Registerer,
 	trackChecksum bool,
+	cacheBudget *budget.Manager,
+	utxoCacheName string,
 ) (UTXOState, error) {
+	utxoSizedCache, err := budget.NewSizedLRU[ids.ID, *UTXO](
+		cacheBudget,
+		utxoCacheName,
+		utxoCacheSize*utxoSize(ids.Empty, &UTXO{}),
+		utxoSize,
+	)
+	if err != nil {
+		return nil, err
+	}
 	utxoCache, err := metercacher.New[ids.ID, *UTXO](
 		"utxo_cache",
 		metrics,
-		&cache.LRU[ids.ID, *UTXO]{Size: utxoCacheSize},
+		utxoSizedCache,
 	)
 	if err != nil {
 		return nil, err
@@ -143,6 +163,13 @@ func NewMeteredUTXOState(
 	return s, s.initChecksum()
 }
 
+func utxoSize(_ ids.ID, utxo *UTXO) int {
+	if utxo == nil {
+		return ids.IDLen + constants.PointerOverhead
+	}
+	return ids.IDLen + estimatedUTXOSize + constants.PointerOverhead
+}
+
 func (s *utxoState) GetUTXO(utxoID ids.ID) (*UTXO, error) {
 	if utxo, found := s.utxoCache.Get(utxoID); found {
 		if utxo == nil {
@@ -15,6 +15,7 @@ import (
 var (
 	_ snowman.Block           = (*BlockWrapper)(nil)
 	_ block.WithVerifyContext = (*BlockWrapper)(nil)
+	_ block.WithNumTxs        = (*BlockWrapper)(nil)
 
 	errExpectedBlockWithVerifyContext = errors.New("expected block.WithVerifyContext")
 )
@@ -46,6 +47,12 @@ func (bw *BlockWrapper) Verify(ctx context.Context) error {
 	return nil
 }
 
+// NumTxs returns the number of transactions in the underlying block, and false
+// if the underlying block doesn't report it.
+func (bw *BlockWrapper) NumTxs() (int, bool) {
+	return block.NumTxs(bw.Block)
+}
+
 // ShouldVerifyWithContext checks if the underlying block should be verified
 // with a block context. If the underlying block does not implement the
 // block.WithVerifyContext interface, returns false without an error. Does not
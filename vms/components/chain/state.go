@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/budget"
 	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
@@ -69,6 +70,12 @@ type Config struct {
 	// Cache configuration:
 	DecidedCacheSize, MissingCacheSize, UnverifiedCacheSize, BytesToIDCacheSize int
 
+	// If non-nil, NewMeteredState allocates the capacity of the decided block
+	// cache from [CacheBudget] under [DecidedCacheName]. [DecidedCacheSize] is
+	// then the weight of the cache when splitting the budget.
+	CacheBudget      *budget.Manager
+	DecidedCacheName string
+
 	LastAcceptedBlock     snowman.Block
 	GetBlock              func(context.Context, ids.ID) (snowman.Block, error)
 	UnmarshalBlock        func(context.Context, []byte) (snowman.Block, error)
@@ -170,13 +177,19 @@ func NewMeteredState(
 	registerer prometheus.Registerer,
 	config *Config,
 ) (*State, error) {
+	decidedSizedCache, err := budget.NewSizedLRU[ids.ID, *BlockWrapper](
+		config.CacheBudget,
+		config.DecidedCacheName,
+		config.DecidedCacheSize,
+		cachedBlockSize,
+	)
+	if err != nil {
+		return nil, err
+	}
 	decidedCache, err := metercacher.New[ids.ID, *BlockWrapper](
 		"decided_cache",
 		registerer,
-		cache.NewSizedLRU[ids.ID, *BlockWrapper](
-			config.DecidedCacheSize,
-			cachedBlockSize,
-		),
+		decidedSizedCache,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// Max duration of a single request to the signing plugin
+const signerTimeout = 30 * time.Second
+
+var (
+	errWrongSigner = errors.New("signing plugin signed with an unexpected key")
+
+	_ keychain.Signer = (*remoteSigner)(nil)
+)
+
+// remoteSigner signs with the key of a keystore user that is held by the
+// signing plugin.
+type remoteSigner struct {
+	factory  secp256k1.Factory
+	signer   keystore.Signer
+	username string
+	password string
+	address  ids.ShortID
+}
+
+func (s *remoteSigner) SignHash(hash []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), signerTimeout)
+	defer cancel()
+
+	sig, err := s.signer.SignHash(ctx, s.username, s.password, s.address, hash)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't sign with %s: %w", s.address, err)
+	}
+
+	// The signature is checked here so that a misbehaving plugin is reported
+	// before the tx is issued.
+	pk, err := s.factory.RecoverHashPublicKey(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	if addr := pk.Address(); addr != s.address {
+		return nil, fmt.Errorf("%w: expected %s but got %s",
+			errWrongSigner,
+			s.address,
+			addr,
+		)
+	}
+	return sig, nil
+}
+
+func (s *remoteSigner) Sign(msg []byte) ([]byte, error) {
+	return s.SignHash(hashing.ComputeHash256(msg))
+}
+
+func (s *remoteSigner) Address() ids.ShortID {
+	return s.address
+}
@@ -4,6 +4,7 @@
 package keystore
 
 import (
+	"context"
 	"fmt"
 	"io"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/encdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -40,11 +42,20 @@ type User interface {
 
 	// GetKey returns the private key that controls the given address
 	GetKey(address ids.ShortID) (*secp256k1.PrivateKey, error)
+
+	// GetSigners returns the signers of the keys that the signing plugin holds
+	// for this user. Returns nil if there is no signing plugin.
+	GetSigners() ([]keychain.Signer, error)
 }
 
 type user struct {
 	factory secp256k1.Factory
 	db      *encdb.Database
+
+	// If non-nil, holds keys of this user outside of the keystore.
+	signer   keystore.Signer
+	username string
+	password string
 }
 
 // NewUserFromKeystore tracks a keystore user from the provided keystore
@@ -53,7 +64,12 @@ func NewUserFromKeystore(ks keystore.BlockchainKeystore, username, password stri
 	if err != nil {
 		return nil, fmt.Errorf("problem retrieving user %q: %w", username, err)
 	}
-	return NewUserFromDB(db), nil
+	return &user{
+		db:       db,
+		signer:   ks.Signer(),
+		username: username,
+		password: password,
+	}, nil
 }
 
 // NewUserFromDB tracks a keystore user from a database
@@ -128,6 +144,31 @@ func (u *user) GetKey(address ids.ShortID) (*secp256k1.PrivateKey, error) {
 	return u.factory.ToPrivateKey(bytes)
 }
 
+func (u *user) GetSigners() ([]keychain.Signer, error) {
+	if u.signer == nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), signerTimeout)
+	defer cancel()
+
+	addresses, err := u.signer.Addresses(ctx, u.username, u.password)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch addresses from signing plugin: %w", err)
+	}
+
+	signers := make([]keychain.Signer, len(addresses))
+	for i, address := range addresses {
+		signers[i] = &remoteSigner{
+			signer:   u.signer,
+			username: u.username,
+			password: u.password,
+			address:  address,
+		}
+	}
+	return signers, nil
+}
+
 func (u *user) Close() error {
 	return u.db.Close()
 }
@@ -161,18 +202,33 @@ func NewKeys(u User, numKeys int) ([]*secp256k1.PrivateKey, error) {
 // is missing, it will be ignored.
 // If [addresses] is empty, then it will create a keychain using every address
 // in the provided [user].
+// Keys held by the signing plugin are preferred over keys held by the
+// keystore, so that the keys of migrated users are never read out of the
+// keystore.
 func GetKeychain(u User, addresses set.Set[ids.ShortID]) (*secp256k1fx.Keychain, error) {
+	kc := secp256k1fx.NewKeychain()
+	signers, err := u.GetSigners()
+	if err != nil {
+		return nil, err
+	}
+	for _, signer := range signers {
+		if addresses.Len() == 0 || addresses.Contains(signer.Address()) {
+			kc.AddSigner(signer)
+		}
+	}
+
 	addrsList := addresses.List()
 	if len(addrsList) == 0 {
-		var err error
 		addrsList, err = u.GetAddresses()
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	kc := secp256k1fx.NewKeychain()
 	for _, addr := range addrsList {
+		if kc.Addrs.Contains(addr) {
+			continue
+		}
 		sk, err := u.GetKey(addr)
 		if err == database.ErrNotFound {
 			continue
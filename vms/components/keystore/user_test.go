@@ -4,6 +4,7 @@
 package keystore
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,11 +14,43 @@ import (
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	apikeystore "github.com/ava-labs/avalanchego/api/keystore"
 )
 
 // Test user password, must meet minimum complexity/length requirements
 const testPassword = "ShaggyPassword1Zoinks!"
 
+var _ apikeystore.Signer = (*testSigner)(nil)
+
+// testSigner holds keys in memory. If [signWith] is non-nil, every hash is
+// signed with it rather than with the requested key.
+type testSigner struct {
+	keys     map[ids.ShortID]*secp256k1.PrivateKey
+	signWith *secp256k1.PrivateKey
+}
+
+func (s *testSigner) Addresses(context.Context, string, string) ([]ids.ShortID, error) {
+	addrs := make([]ids.ShortID, 0, len(s.keys))
+	for addr := range s.keys {
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+func (s *testSigner) SignHash(_ context.Context, _, _ string, address ids.ShortID, hash []byte) ([]byte, error) {
+	if s.signWith != nil {
+		return s.signWith.SignHash(hash)
+	}
+	return s.keys[address].SignHash(hash)
+}
+
+func (*testSigner) ImportKey(context.Context, string, string, []byte) (ids.ShortID, error) {
+	return ids.ShortEmpty, nil
+}
+
 func TestUserClosedDB(t *testing.T) {
 	require := require.New(t)
 
@@ -84,3 +117,61 @@ func TestUser(t *testing.T) {
 	require.Len(savedKeychain.Keys, 1, "key should have been added")
 	require.Equal(sk.Bytes(), savedKeychain.Keys[0].Bytes(), "wrong key returned")
 }
+
+func TestUserSigners(t *testing.T) {
+	require := require.New(t)
+
+	db, err := encdb.New([]byte(testPassword), memdb.New())
+	require.NoError(err)
+
+	factory := secp256k1.Factory{}
+	localSk, err := factory.NewPrivateKey()
+	require.NoError(err)
+	remoteSk, err := factory.NewPrivateKey()
+	require.NoError(err)
+	otherSk, err := factory.NewPrivateKey()
+	require.NoError(err)
+
+	signer := &testSigner{
+		keys: map[ids.ShortID]*secp256k1.PrivateKey{
+			remoteSk.Address(): remoteSk,
+		},
+	}
+	u := &user{
+		db:       db,
+		signer:   signer,
+		username: "bob",
+		password: testPassword,
+	}
+	// [remoteSk] was migrated to the signer but is still in the keystore.
+	require.NoError(u.PutKeys(localSk, remoteSk))
+
+	kc, err := GetKeychain(u, nil)
+	require.NoError(err)
+	require.Equal(2, kc.Addrs.Len())
+	require.Len(kc.Keys, 1)
+	require.Equal(localSk.Bytes(), kc.Keys[0].Bytes())
+
+	// The migrated key is only used through the signer.
+	rs, ok := kc.Get(remoteSk.Address())
+	require.True(ok)
+	require.IsType(&remoteSigner{}, rs)
+
+	msg := []byte("hello")
+	sig, err := rs.Sign(msg)
+	require.NoError(err)
+	pk, err := factory.RecoverHashPublicKey(hashing.ComputeHash256(msg), sig)
+	require.NoError(err)
+	require.Equal(remoteSk.Address(), pk.Address())
+
+	// Only the requested addresses are added.
+	kc, err = GetKeychain(u, set.Of(localSk.Address()))
+	require.NoError(err)
+	require.Equal(1, kc.Addrs.Len())
+	require.True(kc.Addrs.Contains(localSk.Address()))
+
+	// Signatures from the wrong key are rejected.
+	signer.signWith = otherSk
+	_, err = rs.Sign(msg)
+	require.ErrorIs(err, errWrongSigner)
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/message"
+)
+
+// recentCacheSize is the number of tx IDs that are remembered to avoid
+// gossiping a tx more than once.
+const recentCacheSize = 512
+
+// ParseFunc parses the bytes of a tx received from a peer.
+type ParseFunc func(txBytes []byte) (*Tx, error)
+
+// Gossiper gossips the txs of a Mempool. It lets the node handle gossip while
+// the Mempool, which may be a remote service, handles admission and ordering.
+type Gossiper struct {
+	log       logging.Logger
+	mempool   Mempool
+	appSender common.AppSender
+	parse     ParseFunc
+	recentTxs *cache.LRU[ids.ID, struct{}]
+}
+
+// NewGossiper returns a Gossiper that adds txs to [mempool] and gossips them
+// with [appSender]. [parse] is used to parse the txs gossiped by peers.
+func NewGossiper(
+	log logging.Logger,
+	mempool Mempool,
+	appSender common.AppSender,
+	parse ParseFunc,
+) *Gossiper {
+	return &Gossiper{
+		log:       log,
+		mempool:   mempool,
+		appSender: appSender,
+		parse:     parse,
+		recentTxs: &cache.LRU[ids.ID, struct{}]{Size: recentCacheSize},
+	}
+}
+
+// Issue adds [tx] to the mempool and gossips it if it was admitted.
+func (g *Gossiper) Issue(ctx context.Context, tx *Tx) error {
+	if err := g.mempool.Add(ctx, tx); err != nil {
+		return err
+	}
+	return g.gossip(ctx, tx)
+}
+
+// AppGossip adds the tx gossiped by [nodeID] to the mempool and gossips it
+// further if it was admitted. Invalid and unadmitted txs are dropped.
+func (g *Gossiper) AppGossip(ctx context.Context, nodeID ids.NodeID, msgBytes []byte) error {
+	msgIntf, err := message.Parse(msgBytes)
+	if err != nil {
+		g.log.Debug("dropping AppGossip message",
+			zap.String("reason", "failed to parse message"),
+		)
+		return nil
+	}
+
+	msg, ok := msgIntf.(*message.Tx)
+	if !ok {
+		g.log.Debug("dropping unexpected message",
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+
+	tx, err := g.parse(msg.Tx)
+	if err != nil {
+		g.log.Verbo("received invalid tx",
+			zap.Stringer("nodeID", nodeID),
+			zap.Binary("tx", msg.Tx),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if _, recent := g.recentTxs.Get(tx.ID); recent {
+		return nil
+	}
+
+	// Failing to reach the mempool isn't fatal to the chain, so the tx is
+	// dropped rather than returning an error.
+	if err := g.mempool.Add(ctx, tx); err != nil {
+		log := g.log.Warn
+		if errors.Is(err, ErrNotAdmitted) {
+			log = g.log.Debug
+		}
+		log("dropping gossiped tx",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("txID", tx.ID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if err := g.gossip(ctx, tx); err != nil {
+		g.log.Warn("failed to gossip tx",
+			zap.Stringer("txID", tx.ID),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+func (g *Gossiper) gossip(ctx context.Context, tx *Tx) error {
+	// Don't gossip a transaction if it has been recently gossiped.
+	if _, recent := g.recentTxs.Get(tx.ID); recent {
+		return nil
+	}
+	g.recentTxs.Put(tx.ID, struct{}{})
+
+	g.log.Debug("gossiping tx",
+		zap.Stringer("txID", tx.ID),
+	)
+
+	msgBytes, err := message.Build(&message.Tx{Tx: tx.Bytes})
+	if err != nil {
+		return fmt.Errorf("failed to build Tx message: %w", err)
+	}
+	return g.appSender.SendAppGossip(ctx, msgBytes)
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/message"
+)
+
+var _ Mempool = (*testMempool)(nil)
+
+// testMempool only admits txs whose bytes aren't empty.
+type testMempool struct {
+	txs map[ids.ID]*Tx
+}
+
+func (m *testMempool) Add(_ context.Context, tx *Tx) error {
+	if len(tx.Bytes) == 0 {
+		return fmt.Errorf("%w: empty tx", ErrNotAdmitted)
+	}
+	m.txs[tx.ID] = tx
+	return nil
+}
+
+func (m *testMempool) Has(_ context.Context, txID ids.ID) (bool, error) {
+	_, has := m.txs[txID]
+	return has, nil
+}
+
+func (m *testMempool) Get(_ context.Context, txID ids.ID) (*Tx, error) {
+	tx, ok := m.txs[txID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return tx, nil
+}
+
+func (*testMempool) Peek(context.Context, int) ([]*Tx, error) {
+	return nil, nil
+}
+
+func (m *testMempool) Remove(_ context.Context, txIDs []ids.ID) error {
+	for _, txID := range txIDs {
+		delete(m.txs, txID)
+	}
+	return nil
+}
+
+func (m *testMempool) Len(context.Context) (int, error) {
+	return len(m.txs), nil
+}
+
+func parseTestTx(txBytes []byte) (*Tx, error) {
+	return &Tx{
+		ID:    hashing.ComputeHash256Array(txBytes),
+		Bytes: txBytes,
+	}, nil
+}
+
+func TestGossiper(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	mempool := &testMempool{
+		txs: make(map[ids.ID]*Tx),
+	}
+	sender := &common.SenderTest{T: t}
+	var gossiped [][]byte
+	sender.SendAppGossipF = func(_ context.Context, msgBytes []byte) error {
+		gossiped = append(gossiped, msgBytes)
+		return nil
+	}
+	gossiper := NewGossiper(logging.NoLog{}, mempool, sender, parseTestTx)
+
+	// Issued txs are gossiped once.
+	tx, err := parseTestTx([]byte{1})
+	require.NoError(err)
+	require.NoError(gossiper.Issue(ctx, tx))
+	require.NoError(gossiper.Issue(ctx, tx))
+	require.Len(gossiped, 1)
+
+	// Txs that aren't admitted aren't gossiped.
+	emptyTx, err := parseTestTx(nil)
+	require.NoError(err)
+	require.ErrorIs(gossiper.Issue(ctx, emptyTx), ErrNotAdmitted)
+	require.Len(gossiped, 1)
+
+	// Txs received from peers are added and gossiped further.
+	msgBytes, err := message.Build(&message.Tx{Tx: []byte{2}})
+	require.NoError(err)
+	require.NoError(gossiper.AppGossip(ctx, ids.GenerateTestNodeID(), msgBytes))
+	require.Len(gossiped, 2)
+	require.Equal(msgBytes, gossiped[1])
+
+	l, err := mempool.Len(ctx)
+	require.NoError(err)
+	require.Equal(2, l)
+
+	// Txs received from peers that aren't admitted are dropped.
+	msgBytes, err = message.Build(&message.Tx{Tx: []byte{}})
+	require.NoError(err)
+	require.NoError(gossiper.AppGossip(ctx, ids.GenerateTestNodeID(), msgBytes))
+	require.Len(gossiped, 2)
+}
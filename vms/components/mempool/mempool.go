@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	// ErrNotAdmitted is returned when a Mempool declines to add a tx.
+	ErrNotAdmitted = errors.New("tx not admitted")
+	// ErrNotFound is returned when a tx isn't in a Mempool.
+	ErrNotFound = errors.New("tx not found")
+)
+
+// Tx is a tx held by a Mempool. The Mempool doesn't need to understand the
+// format of the tx.
+type Tx struct {
+	ID    ids.ID
+	Bytes []byte
+}
+
+// Mempool holds the txs that a VM may include in its blocks. It decides which
+// txs are admitted and the order in which they should be included, which allows
+// a VM to delegate these decisions to another service, such as a shared
+// sequencer.
+type Mempool interface {
+	// Add adds [tx] to the mempool. If the mempool declines to add [tx], an
+	// error wrapping ErrNotAdmitted is returned.
+	Add(ctx context.Context, tx *Tx) error
+	// Has returns true if the tx with ID [txID] is in the mempool.
+	Has(ctx context.Context, txID ids.ID) (bool, error)
+	// Get returns the tx with ID [txID]. If it isn't in the mempool,
+	// ErrNotFound is returned.
+	Get(ctx context.Context, txID ids.ID) (*Tx, error)
+	// Peek returns the txs that should be included in the next block, in the
+	// order they should be included. The txs total at most [maxSize] bytes.
+	// The txs aren't removed from the mempool.
+	Peek(ctx context.Context, maxSize int) ([]*Tx, error)
+	// Remove removes the txs with IDs [txIDs] from the mempool, if they are
+	// in it.
+	Remove(ctx context.Context, txIDs []ids.ID) error
+	// Len returns the number of txs in the mempool.
+	Len(ctx context.Context) (int, error)
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcmempool
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/mempool"
+
+	mempoolpb "github.com/ava-labs/avalanchego/proto/pb/mempool"
+)
+
+var _ mempool.Mempool = (*Client)(nil)
+
+// Client is a mempool that delegates admission and ordering to a remote
+// service over gRPC.
+type Client struct {
+	client mempoolpb.MempoolClient
+}
+
+// NewClient returns a mempool connected to a remote mempool
+func NewClient(client mempoolpb.MempoolClient) *Client {
+	return &Client{client: client}
+}
+
+func (c *Client) Add(ctx context.Context, tx *mempool.Tx) error {
+	resp, err := c.client.Add(ctx, &mempoolpb.AddRequest{
+		Tx: txToProto(tx),
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Admitted {
+		return notAdmittedError(resp.Reason)
+	}
+	return nil
+}
+
+func (c *Client) Has(ctx context.Context, txID ids.ID) (bool, error) {
+	resp, err := c.client.Has(ctx, &mempoolpb.HasRequest{
+		Id: txID[:],
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Has, nil
+}
+
+func (c *Client) Get(ctx context.Context, txID ids.ID) (*mempool.Tx, error) {
+	resp, err := c.client.Get(ctx, &mempoolpb.GetRequest{
+		Id: txID[:],
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Tx == nil {
+		return nil, mempool.ErrNotFound
+	}
+	return txFromProto(resp.Tx)
+}
+
+func (c *Client) Peek(ctx context.Context, maxSize int) ([]*mempool.Tx, error) {
+	resp, err := c.client.Peek(ctx, &mempoolpb.PeekRequest{
+		MaxSize: uint64(maxSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*mempool.Tx, len(resp.Txs))
+	for i, tx := range resp.Txs {
+		txs[i], err = txFromProto(tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
+
+func (c *Client) Remove(ctx context.Context, txIDs []ids.ID) error {
+	txIDBytes := make([][]byte, len(txIDs))
+	for i, txID := range txIDs {
+		txID := txID
+		txIDBytes[i] = txID[:]
+	}
+	_, err := c.client.Remove(ctx, &mempoolpb.RemoveRequest{
+		Ids: txIDBytes,
+	})
+	return err
+}
+
+func (c *Client) Len(ctx context.Context) (int, error) {
+	resp, err := c.client.Len(ctx, &mempoolpb.LenRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.Len), nil
+}
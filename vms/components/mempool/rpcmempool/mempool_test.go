@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcmempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
+	"github.com/ava-labs/avalanchego/vms/components/mempool"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	mempoolpb "github.com/ava-labs/avalanchego/proto/pb/mempool"
+)
+
+var (
+	_ mempool.Mempool = (*testMempool)(nil)
+
+	errUnavailable = errors.New("unavailable")
+)
+
+// testMempool admits txs in the order they are added, up to [maxTxs] txs.
+type testMempool struct {
+	maxTxs      int
+	unavailable bool
+	txs         linkedhashmap.LinkedHashmap[ids.ID, *mempool.Tx]
+}
+
+func (m *testMempool) Add(_ context.Context, tx *mempool.Tx) error {
+	if m.unavailable {
+		return errUnavailable
+	}
+	if m.txs.Len() >= m.maxTxs {
+		return fmt.Errorf("%w: mempool is full", mempool.ErrNotAdmitted)
+	}
+	m.txs.Put(tx.ID, tx)
+	return nil
+}
+
+func (m *testMempool) Has(_ context.Context, txID ids.ID) (bool, error) {
+	_, has := m.txs.Get(txID)
+	return has, nil
+}
+
+func (m *testMempool) Get(_ context.Context, txID ids.ID) (*mempool.Tx, error) {
+	tx, ok := m.txs.Get(txID)
+	if !ok {
+		return nil, mempool.ErrNotFound
+	}
+	return tx, nil
+}
+
+func (m *testMempool) Peek(_ context.Context, maxSize int) ([]*mempool.Tx, error) {
+	var (
+		txs  []*mempool.Tx
+		size int
+		iter = m.txs.NewIterator()
+	)
+	for iter.Next() {
+		tx := iter.Value()
+		size += len(tx.Bytes)
+		if size > maxSize {
+			break
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+func (m *testMempool) Remove(_ context.Context, txIDs []ids.ID) error {
+	for _, txID := range txIDs {
+		m.txs.Delete(txID)
+	}
+	return nil
+}
+
+func (m *testMempool) Len(context.Context) (int, error) {
+	return m.txs.Len(), nil
+}
+
+func setupMempool(t *testing.T, server *testMempool) *Client {
+	require := require.New(t)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	serverCloser := grpcutils.ServerCloser{}
+
+	grpcServer := grpcutils.NewServer()
+	mempoolpb.RegisterMempoolServer(grpcServer, NewServer(server))
+	serverCloser.Add(grpcServer)
+
+	go grpcutils.Serve(listener, grpcServer)
+
+	conn, err := grpcutils.Dial(listener.Addr().String())
+	require.NoError(err)
+
+	t.Cleanup(func() {
+		serverCloser.Stop()
+		_ = conn.Close()
+		_ = listener.Close()
+	})
+	return NewClient(mempoolpb.NewMempoolClient(conn))
+}
+
+func TestRPCMempool(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	server := &testMempool{
+		maxTxs: 2,
+		txs:    linkedhashmap.New[ids.ID, *mempool.Tx](),
+	}
+	client := setupMempool(t, server)
+
+	tx0 := &mempool.Tx{ID: ids.GenerateTestID(), Bytes: []byte{0, 1}}
+	tx1 := &mempool.Tx{ID: ids.GenerateTestID(), Bytes: []byte{2, 3}}
+	tx2 := &mempool.Tx{ID: ids.GenerateTestID(), Bytes: []byte{4, 5}}
+
+	require.NoError(client.Add(ctx, tx0))
+	require.NoError(client.Add(ctx, tx1))
+
+	err := client.Add(ctx, tx2)
+	require.ErrorIs(err, mempool.ErrNotAdmitted)
+	require.Equal("tx not admitted: mempool is full", err.Error())
+
+	server.unavailable = true
+	err = client.Add(ctx, tx2)
+	require.Error(err) //nolint:forbidigo // currently returns grpc errors
+	require.NotErrorIs(err, mempool.ErrNotAdmitted)
+	server.unavailable = false
+
+	has, err := client.Has(ctx, tx0.ID)
+	require.NoError(err)
+	require.True(has)
+
+	has, err = client.Has(ctx, tx2.ID)
+	require.NoError(err)
+	require.False(has)
+
+	tx, err := client.Get(ctx, tx1.ID)
+	require.NoError(err)
+	require.Equal(tx1, tx)
+
+	_, err = client.Get(ctx, tx2.ID)
+	require.ErrorIs(err, mempool.ErrNotFound)
+
+	txs, err := client.Peek(ctx, 3)
+	require.NoError(err)
+	require.Equal([]*mempool.Tx{tx0}, txs)
+
+	txs, err = client.Peek(ctx, 4)
+	require.NoError(err)
+	require.Equal([]*mempool.Tx{tx0, tx1}, txs)
+
+	require.NoError(client.Remove(ctx, []ids.ID{tx0.ID, tx2.ID}))
+
+	l, err := client.Len(ctx)
+	require.NoError(err)
+	require.Equal(1, l)
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcmempool
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/mempool"
+
+	mempoolpb "github.com/ava-labs/avalanchego/proto/pb/mempool"
+)
+
+var _ mempoolpb.MempoolServer = (*Server)(nil)
+
+// Server serves a mempool over gRPC, which allows a mempool implemented in Go
+// to be run as a service shared by VMs.
+type Server struct {
+	mempoolpb.UnsafeMempoolServer
+	mempool mempool.Mempool
+}
+
+// NewServer returns a mempool that can be connected to remotely
+func NewServer(mempool mempool.Mempool) *Server {
+	return &Server{mempool: mempool}
+}
+
+func (s *Server) Add(ctx context.Context, req *mempoolpb.AddRequest) (*mempoolpb.AddResponse, error) {
+	tx, err := txFromProto(req.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.mempool.Add(ctx, tx)
+	switch {
+	case err == nil:
+		return &mempoolpb.AddResponse{Admitted: true}, nil
+	case errors.Is(err, mempool.ErrNotAdmitted):
+		return &mempoolpb.AddResponse{Reason: err.Error()}, nil
+	default:
+		return nil, err
+	}
+}
+
+func (s *Server) Has(ctx context.Context, req *mempoolpb.HasRequest) (*mempoolpb.HasResponse, error) {
+	txID, err := ids.ToID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	has, err := s.mempool.Has(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	return &mempoolpb.HasResponse{Has: has}, nil
+}
+
+func (s *Server) Get(ctx context.Context, req *mempoolpb.GetRequest) (*mempoolpb.GetResponse, error) {
+	txID, err := ids.ToID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.mempool.Get(ctx, txID)
+	switch {
+	case err == nil:
+		return &mempoolpb.GetResponse{Tx: txToProto(tx)}, nil
+	case errors.Is(err, mempool.ErrNotFound):
+		return &mempoolpb.GetResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+func (s *Server) Peek(ctx context.Context, req *mempoolpb.PeekRequest) (*mempoolpb.PeekResponse, error) {
+	txs, err := s.mempool.Peek(ctx, int(req.MaxSize))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &mempoolpb.PeekResponse{
+		Txs: make([]*mempoolpb.Tx, len(txs)),
+	}
+	for i, tx := range txs {
+		resp.Txs[i] = txToProto(tx)
+	}
+	return resp, nil
+}
+
+func (s *Server) Remove(ctx context.Context, req *mempoolpb.RemoveRequest) (*mempoolpb.RemoveResponse, error) {
+	txIDs := make([]ids.ID, len(req.Ids))
+	for i, txIDBytes := range req.Ids {
+		txID, err := ids.ToID(txIDBytes)
+		if err != nil {
+			return nil, err
+		}
+		txIDs[i] = txID
+	}
+	return &mempoolpb.RemoveResponse{}, s.mempool.Remove(ctx, txIDs)
+}
+
+func (s *Server) Len(ctx context.Context, _ *mempoolpb.LenRequest) (*mempoolpb.LenResponse, error) {
+	l, err := s.mempool.Len(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &mempoolpb.LenResponse{Len: uint64(l)}, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcmempool
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/mempool"
+
+	mempoolpb "github.com/ava-labs/avalanchego/proto/pb/mempool"
+)
+
+var errMissingTx = errors.New("missing tx")
+
+// notAdmittedError is the reason a remote mempool gave for not admitting a
+// tx. It reports the reason as is, while still matching ErrNotAdmitted.
+type notAdmittedError string
+
+func (e notAdmittedError) Error() string {
+	return string(e)
+}
+
+func (notAdmittedError) Is(target error) bool {
+	return target == mempool.ErrNotAdmitted
+}
+
+func txToProto(tx *mempool.Tx) *mempoolpb.Tx {
+	return &mempoolpb.Tx{
+		Id:    tx.ID[:],
+		Bytes: tx.Bytes,
+	}
+}
+
+func txFromProto(tx *mempoolpb.Tx) (*mempool.Tx, error) {
+	if tx == nil {
+		return nil, errMissingTx
+	}
+	txID, err := ids.ToID(tx.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mempool.Tx{
+		ID:    txID,
+		Bytes: tx.Bytes,
+	}, nil
+}
@@ -26,6 +26,9 @@ func init() {
 	errs := wrappers.Errs{}
 	errs.Add(
 		lc.RegisterType(&Tx{}),
+		lc.RegisterType(&TxAnnouncement{}),
+		lc.RegisterType(&TxRequest{}),
+		lc.RegisterType(&TxResponse{}),
 		c.RegisterCodec(codecVersion, lc),
 	)
 	if errs.Errored() {
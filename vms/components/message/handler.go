@@ -14,6 +14,9 @@ var _ Handler = NoopHandler{}
 
 type Handler interface {
 	HandleTx(nodeID ids.NodeID, requestID uint32, msg *Tx) error
+	HandleTxAnnouncement(nodeID ids.NodeID, requestID uint32, msg *TxAnnouncement) error
+	HandleTxRequest(nodeID ids.NodeID, requestID uint32, msg *TxRequest) error
+	HandleTxResponse(nodeID ids.NodeID, requestID uint32, msg *TxResponse) error
 }
 
 type NoopHandler struct {
@@ -27,3 +30,27 @@ func (h NoopHandler) HandleTx(nodeID ids.NodeID, requestID uint32, _ *Tx) error
 	)
 	return nil
 }
+
+func (h NoopHandler) HandleTxAnnouncement(nodeID ids.NodeID, requestID uint32, _ *TxAnnouncement) error {
+	h.Log.Debug("dropping unexpected TxAnnouncement message",
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint32("requestID", requestID),
+	)
+	return nil
+}
+
+func (h NoopHandler) HandleTxRequest(nodeID ids.NodeID, requestID uint32, _ *TxRequest) error {
+	h.Log.Debug("dropping unexpected TxRequest message",
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint32("requestID", requestID),
+	)
+	return nil
+}
+
+func (h NoopHandler) HandleTxResponse(nodeID ids.NodeID, requestID uint32, _ *TxResponse) error {
+	h.Log.Debug("dropping unexpected TxResponse message",
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint32("requestID", requestID),
+	)
+	return nil
+}
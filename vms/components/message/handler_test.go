@@ -13,7 +13,10 @@ import (
 )
 
 type CounterHandler struct {
-	Tx int
+	Tx             int
+	TxAnnouncement int
+	TxRequest      int
+	TxResponse     int
 }
 
 func (h *CounterHandler) HandleTx(ids.NodeID, uint32, *Tx) error {
@@ -21,6 +24,21 @@ func (h *CounterHandler) HandleTx(ids.NodeID, uint32, *Tx) error {
 	return nil
 }
 
+func (h *CounterHandler) HandleTxAnnouncement(ids.NodeID, uint32, *TxAnnouncement) error {
+	h.TxAnnouncement++
+	return nil
+}
+
+func (h *CounterHandler) HandleTxRequest(ids.NodeID, uint32, *TxRequest) error {
+	h.TxRequest++
+	return nil
+}
+
+func (h *CounterHandler) HandleTxResponse(ids.NodeID, uint32, *TxResponse) error {
+	h.TxResponse++
+	return nil
+}
+
 func TestHandleTx(t *testing.T) {
 	require := require.New(t)
 
@@ -31,10 +49,25 @@ func TestHandleTx(t *testing.T) {
 	require.Equal(1, handler.Tx)
 }
 
+func TestHandleTxAnnouncementMessages(t *testing.T) {
+	require := require.New(t)
+
+	handler := CounterHandler{}
+	require.NoError((&TxAnnouncement{}).Handle(&handler, ids.EmptyNodeID, 0))
+	require.NoError((&TxRequest{}).Handle(&handler, ids.EmptyNodeID, 0))
+	require.NoError((&TxResponse{}).Handle(&handler, ids.EmptyNodeID, 0))
+	require.Equal(1, handler.TxAnnouncement)
+	require.Equal(1, handler.TxRequest)
+	require.Equal(1, handler.TxResponse)
+}
+
 func TestNoopHandler(t *testing.T) {
 	handler := NoopHandler{
 		Log: logging.NoLog{},
 	}
 
 	require.NoError(t, handler.HandleTx(ids.EmptyNodeID, 0, nil))
+	require.NoError(t, handler.HandleTxAnnouncement(ids.EmptyNodeID, 0, nil))
+	require.NoError(t, handler.HandleTxRequest(ids.EmptyNodeID, 0, nil))
+	require.NoError(t, handler.HandleTxResponse(ids.EmptyNodeID, 0, nil))
 }
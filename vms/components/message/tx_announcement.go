@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import "github.com/ava-labs/avalanchego/ids"
+
+var (
+	_ Message = (*TxAnnouncement)(nil)
+	_ Message = (*TxRequest)(nil)
+	_ Message = (*TxResponse)(nil)
+)
+
+// TxAnnouncement is gossiped to advertise transactions by ID. Peers that do
+// not know about an announced transaction can fetch it with a TxRequest.
+type TxAnnouncement struct {
+	message
+
+	TxIDs []ids.ID `serialize:"true"`
+}
+
+func (msg *TxAnnouncement) Handle(handler Handler, nodeID ids.NodeID, requestID uint32) error {
+	return handler.HandleTxAnnouncement(nodeID, requestID, msg)
+}
+
+// TxRequest asks a peer for the bytes of previously announced transactions.
+type TxRequest struct {
+	message
+
+	TxIDs []ids.ID `serialize:"true"`
+}
+
+func (msg *TxRequest) Handle(handler Handler, nodeID ids.NodeID, requestID uint32) error {
+	return handler.HandleTxRequest(nodeID, requestID, msg)
+}
+
+// TxResponse contains the bytes of the requested transactions that the
+// responding peer knew about.
+type TxResponse struct {
+	message
+
+	Txs [][]byte `serialize:"true"`
+}
+
+func (msg *TxResponse) Handle(handler Handler, nodeID ids.NodeID, requestID uint32) error {
+	return handler.HandleTxResponse(nodeID, requestID, msg)
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func TestTxAnnouncement(t *testing.T) {
+	require := require.New(t)
+
+	txIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	builtMsg := TxAnnouncement{
+		TxIDs: txIDs,
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, builtMsg.Bytes())
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	require.IsType(&TxAnnouncement{}, parsedMsgIntf)
+	parsedMsg := parsedMsgIntf.(*TxAnnouncement)
+
+	require.Equal(txIDs, parsedMsg.TxIDs)
+}
+
+func TestTxRequest(t *testing.T) {
+	require := require.New(t)
+
+	txIDs := []ids.ID{ids.GenerateTestID()}
+	builtMsg := TxRequest{
+		TxIDs: txIDs,
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	require.NoError(err)
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	require.IsType(&TxRequest{}, parsedMsgIntf)
+	parsedMsg := parsedMsgIntf.(*TxRequest)
+
+	require.Equal(txIDs, parsedMsg.TxIDs)
+}
+
+func TestTxResponse(t *testing.T) {
+	require := require.New(t)
+
+	txs := [][]byte{
+		utils.RandomBytes(units.KiB),
+		utils.RandomBytes(32),
+	}
+	builtMsg := TxResponse{
+		Txs: txs,
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	require.NoError(err)
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	require.IsType(&TxResponse{}, parsedMsgIntf)
+	parsedMsg := parsedMsgIntf.(*TxResponse)
+
+	require.Equal(txs, parsedMsg.Txs)
+}
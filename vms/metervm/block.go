@@ -16,6 +16,7 @@ var (
 	_ snowman.Block           = (*meterBlock)(nil)
 	_ snowman.OracleBlock     = (*meterBlock)(nil)
 	_ block.WithVerifyContext = (*meterBlock)(nil)
+	_ block.WithNumTxs        = (*meterBlock)(nil)
 
 	errExpectedBlockWithVerifyContext = errors.New("expected block.WithVerifyContext")
 )
@@ -79,6 +80,10 @@ func (mb *meterBlock) Options(ctx context.Context) ([2]snowman.Block, error) {
 	}, nil
 }
 
+func (mb *meterBlock) NumTxs() (int, bool) {
+	return block.NumTxs(mb.Block)
+}
+
 func (mb *meterBlock) ShouldVerifyWithContext(ctx context.Context) (bool, error) {
 	blkWithCtx, ok := mb.Block.(block.WithVerifyContext)
 	if !ok {
@@ -43,13 +43,17 @@ type blockMetrics struct {
 	parseStateSummary,
 	parseStateSummaryErr,
 	getStateSummary,
-	getStateSummaryErr metric.Averager
+	getStateSummaryErr,
+	// Pre-verification metrics
+	preVerifyBlock,
+	preVerifyBlockErr metric.Averager
 }
 
 func (m *blockMetrics) Initialize(
 	supportsBlockBuildingWithContext bool,
 	supportsBatchedFetching bool,
 	supportsStateSync bool,
+	supportsPreVerification bool,
 	namespace string,
 	reg prometheus.Registerer,
 ) error {
@@ -89,5 +93,9 @@ func (m *blockMetrics) Initialize(
 		m.getStateSummary = newAverager(namespace, "get_state_summary", reg, &errs)
 		m.getStateSummaryErr = newAverager(namespace, "get_state_summary_err", reg, &errs)
 	}
+	if supportsPreVerification {
+		m.preVerifyBlock = newAverager(namespace, "pre_verify_block", reg, &errs)
+		m.preVerifyBlockErr = newAverager(namespace, "pre_verify_block_err", reg, &errs)
+	}
 	return errs.Err
 }
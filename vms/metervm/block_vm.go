@@ -23,13 +23,15 @@ var (
 	_ block.BuildBlockWithContextChainVM = (*blockVM)(nil)
 	_ block.BatchedChainVM               = (*blockVM)(nil)
 	_ block.StateSyncableVM              = (*blockVM)(nil)
+	_ block.PreVerifierChainVM           = (*blockVM)(nil)
 )
 
 type blockVM struct {
 	block.ChainVM
-	buildBlockVM block.BuildBlockWithContextChainVM
-	batchedVM    block.BatchedChainVM
-	ssVM         block.StateSyncableVM
+	buildBlockVM  block.BuildBlockWithContextChainVM
+	batchedVM     block.BatchedChainVM
+	ssVM          block.StateSyncableVM
+	preVerifierVM block.PreVerifierChainVM
 
 	blockMetrics
 	clock mockable.Clock
@@ -39,11 +41,13 @@ func NewBlockVM(vm block.ChainVM) block.ChainVM {
 	buildBlockVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	preVerifierVM, _ := vm.(block.PreVerifierChainVM)
 	return &blockVM{
-		ChainVM:      vm,
-		buildBlockVM: buildBlockVM,
-		batchedVM:    batchedVM,
-		ssVM:         ssVM,
+		ChainVM:       vm,
+		buildBlockVM:  buildBlockVM,
+		batchedVM:     batchedVM,
+		ssVM:          ssVM,
+		preVerifierVM: preVerifierVM,
 	}
 }
 
@@ -63,6 +67,7 @@ func (vm *blockVM) Initialize(
 		vm.buildBlockVM != nil,
 		vm.batchedVM != nil,
 		vm.ssVM != nil,
+		vm.preVerifierVM != nil,
 		"",
 		registerer,
 	)
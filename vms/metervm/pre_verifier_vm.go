@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metervm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) PreVerifyBlock(ctx context.Context, blk snowman.Block) error {
+	if vm.preVerifierVM == nil {
+		return block.ErrPreVerifierVMNotImplemented
+	}
+
+	if mb, ok := blk.(*meterBlock); ok {
+		blk = mb.Block
+	}
+
+	start := vm.clock.Time()
+	err := vm.preVerifierVM.PreVerifyBlock(ctx, blk)
+	end := vm.clock.Time()
+	duration := float64(end.Sub(start))
+	if err != nil {
+		vm.blockMetrics.preVerifyBlockErr.Observe(duration)
+	} else {
+		vm.blockMetrics.preVerifyBlock.Observe(duration)
+	}
+	return err
+}
@@ -194,6 +194,18 @@ func (mr *MockManagerMockRecorder) RegisterFactory(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFactory", reflect.TypeOf((*MockManager)(nil).RegisterFactory), arg0, arg1, arg2)
 }
 
+// RemoveAlias mocks base method.
+func (m *MockManager) RemoveAlias(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveAlias", arg0)
+}
+
+// RemoveAlias indicates an expected call of RemoveAlias.
+func (mr *MockManagerMockRecorder) RemoveAlias(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAlias", reflect.TypeOf((*MockManager)(nil).RemoveAlias), arg0)
+}
+
 // RemoveAliases mocks base method.
 func (m *MockManager) RemoveAliases(arg0 ids.ID) {
 	m.ctrl.T.Helper()
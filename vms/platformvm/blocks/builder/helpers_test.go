@@ -169,6 +169,7 @@ func newEnvironment(t *testing.T) *environment {
 		res.state,
 		&res.backend,
 		pvalidators.TestManager,
+		nil,
 	)
 
 	res.Builder = New(
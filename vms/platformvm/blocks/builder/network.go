@@ -16,6 +16,9 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/message"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
@@ -24,13 +27,38 @@ const (
 	// We allow [recentCacheSize] to be fairly large because we only store hashes
 	// in the cache, not entire transactions.
 	recentCacheSize = 512
+
+	// Txs larger than [maxPushGossipTxSize] are announced by ID rather than
+	// gossiped with their full bytes.
+	maxPushGossipTxSize = units.KiB
+
+	// maxTxRequestSize is the maximum number of txs that will be handled from
+	// a single announcement or request.
+	maxTxRequestSize = 64
 )
 
-var _ Network = (*network)(nil)
+var (
+	_ Network = (*network)(nil)
+
+	// minTxAnnouncementVersion is the first version that handles
+	// TxAnnouncement messages. Older peers drop them.
+	minTxAnnouncementVersion = &version.Application{
+		Major: 1,
+		Minor: 10,
+		Patch: 12,
+	}
+)
 
 type Network interface {
 	common.AppHandler
 
+	// Connected and Disconnected track the versions of the connected peers,
+	// so that messages are only sent to peers that support them.
+	//
+	// Assumes the context lock is held.
+	Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error
+	Disconnected(ctx context.Context, nodeID ids.NodeID) error
+
 	// GossipTx gossips the transaction to some of the connected peers
 	GossipTx(tx *txs.Tx) error
 }
@@ -42,6 +70,14 @@ type network struct {
 	// gossip related attributes
 	appSender common.AppSender
 	recentTxs *cache.LRU[ids.ID, struct{}]
+
+	// announcement related attributes, protected by the context lock
+	requestID           uint32
+	requestedTxs        set.Set[ids.ID]
+	outstandingRequests map[uint32]set.Set[ids.ID]
+	// connected peers that don't support TxAnnouncement messages, protected by
+	// the context lock
+	announcementsUnsupported set.Set[ids.NodeID]
 }
 
 func NewNetwork(
@@ -50,46 +86,147 @@ func NewNetwork(
 	appSender common.AppSender,
 ) Network {
 	return &network{
-		ctx:        ctx,
-		blkBuilder: blkBuilder,
-		appSender:  appSender,
-		recentTxs:  &cache.LRU[ids.ID, struct{}]{Size: recentCacheSize},
+		ctx:                 ctx,
+		blkBuilder:          blkBuilder,
+		appSender:           appSender,
+		recentTxs:           &cache.LRU[ids.ID, struct{}]{Size: recentCacheSize},
+		outstandingRequests: make(map[uint32]set.Set[ids.ID]),
 	}
 }
 
+func (n *network) Connected(_ context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
+	if nodeVersion.Before(minTxAnnouncementVersion) {
+		n.announcementsUnsupported.Add(nodeID)
+	}
+	return nil
+}
+
+func (n *network) Disconnected(_ context.Context, nodeID ids.NodeID) error {
+	n.announcementsUnsupported.Remove(nodeID)
+	return nil
+}
+
 func (*network) CrossChainAppRequestFailed(context.Context, ids.ID, uint32) error {
 	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
+	// cross-chain requests.
 	return nil
 }
 
 func (*network) CrossChainAppRequest(context.Context, ids.ID, uint32, time.Time, []byte) error {
 	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
+	// cross-chain requests.
 	return nil
 }
 
 func (*network) CrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error {
 	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
+	// cross-chain requests.
 	return nil
 }
 
-func (*network) AppRequestFailed(context.Context, ids.NodeID, uint32) error {
-	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
+func (n *network) AppRequestFailed(_ context.Context, nodeID ids.NodeID, requestID uint32) error {
+	n.ctx.Log.Debug("tx request failed",
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint32("requestID", requestID),
+	)
+
+	n.ctx.Lock.Lock()
+	defer n.ctx.Lock.Unlock()
+
+	n.clearRequest(requestID)
 	return nil
 }
 
-func (*network) AppRequest(context.Context, ids.NodeID, uint32, time.Time, []byte) error {
-	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
-	return nil
+func (n *network) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, _ time.Time, msgBytes []byte) error {
+	msgIntf, err := message.Parse(msgBytes)
+	if err != nil {
+		n.ctx.Log.Debug("dropping AppRequest message",
+			zap.String("reason", "failed to parse message"),
+		)
+		return nil
+	}
+
+	msg, ok := msgIntf.(*message.TxRequest)
+	if !ok {
+		n.ctx.Log.Debug("dropping unexpected message",
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+	if len(msg.TxIDs) > maxTxRequestSize {
+		n.ctx.Log.Debug("dropping AppRequest message",
+			zap.String("reason", "too many txs requested"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Int("numTxs", len(msg.TxIDs)),
+		)
+		return nil
+	}
+
+	response := &message.TxResponse{}
+	n.ctx.Lock.Lock()
+	for _, txID := range msg.TxIDs {
+		if tx := n.blkBuilder.Get(txID); tx != nil {
+			response.Txs = append(response.Txs, tx.Bytes())
+		}
+	}
+	n.ctx.Lock.Unlock()
+
+	responseBytes, err := message.Build(response)
+	if err != nil {
+		return fmt.Errorf("AppRequest: failed to build TxResponse message: %w", err)
+	}
+	return n.appSender.SendAppResponse(ctx, nodeID, requestID, responseBytes)
 }
 
-func (*network) AppResponse(context.Context, ids.NodeID, uint32, []byte) error {
-	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
+func (n *network) AppResponse(_ context.Context, nodeID ids.NodeID, requestID uint32, msgBytes []byte) error {
+	n.ctx.Lock.Lock()
+	defer n.ctx.Lock.Unlock()
+
+	requested, ok := n.clearRequest(requestID)
+	if !ok {
+		n.ctx.Log.Debug("dropping unexpected AppResponse message",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+
+	msgIntf, err := message.Parse(msgBytes)
+	if err != nil {
+		n.ctx.Log.Debug("dropping AppResponse message",
+			zap.String("reason", "failed to parse message"),
+		)
+		return nil
+	}
+
+	msg, ok := msgIntf.(*message.TxResponse)
+	if !ok {
+		n.ctx.Log.Debug("dropping unexpected message",
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+
+	for _, txBytes := range msg.Txs {
+		tx, err := txs.Parse(txs.Codec, txBytes)
+		if err != nil {
+			n.ctx.Log.Verbo("received invalid tx",
+				zap.Stringer("nodeID", nodeID),
+				zap.Binary("tx", txBytes),
+				zap.Error(err),
+			)
+			return nil
+		}
+
+		if txID := tx.ID(); !requested.Contains(txID) {
+			n.ctx.Log.Debug("dropping unrequested tx",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("txID", txID),
+			)
+			continue
+		}
+		n.addTx(nodeID, tx)
+	}
 	return nil
 }
 
@@ -114,14 +251,20 @@ func (n *network) AppGossip(_ context.Context, nodeID ids.NodeID, msgBytes []byt
 		return nil
 	}
 
-	msg, ok := msgIntf.(*message.Tx)
-	if !ok {
+	switch msg := msgIntf.(type) {
+	case *message.Tx:
+		n.handleTx(nodeID, msg)
+	case *message.TxAnnouncement:
+		n.handleTxAnnouncement(nodeID, msg)
+	default:
 		n.ctx.Log.Debug("dropping unexpected message",
 			zap.Stringer("nodeID", nodeID),
 		)
-		return nil
 	}
+	return nil
+}
 
+func (n *network) handleTx(nodeID ids.NodeID, msg *message.Tx) {
 	tx, err := txs.Parse(txs.Codec, msg.Tx)
 	if err != nil {
 		n.ctx.Log.Verbo("received invalid tx",
@@ -129,19 +272,84 @@ func (n *network) AppGossip(_ context.Context, nodeID ids.NodeID, msgBytes []byt
 			zap.Binary("tx", msg.Tx),
 			zap.Error(err),
 		)
-		return nil
+		return
 	}
 
-	txID := tx.ID()
-
 	// We need to grab the context lock here to avoid racy behavior with
 	// transaction verification + mempool modifications.
 	n.ctx.Lock.Lock()
 	defer n.ctx.Lock.Unlock()
 
+	n.addTx(nodeID, tx)
+}
+
+// handleTxAnnouncement requests the announced txs that aren't already known
+// from [nodeID].
+func (n *network) handleTxAnnouncement(nodeID ids.NodeID, msg *message.TxAnnouncement) {
+	if len(msg.TxIDs) > maxTxRequestSize {
+		n.ctx.Log.Debug("dropping AppGossip message",
+			zap.String("reason", "too many txs announced"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Int("numTxs", len(msg.TxIDs)),
+		)
+		return
+	}
+
+	n.ctx.Lock.Lock()
+	defer n.ctx.Lock.Unlock()
+
+	unknown := set.NewSet[ids.ID](len(msg.TxIDs))
+	for _, txID := range msg.TxIDs {
+		// Don't request txs that are already known or that are already being
+		// fetched from another peer.
+		if n.requestedTxs.Contains(txID) ||
+			n.blkBuilder.Has(txID) ||
+			n.blkBuilder.GetDropReason(txID) != nil {
+			continue
+		}
+		unknown.Add(txID)
+	}
+	if unknown.Len() == 0 {
+		return
+	}
+
+	msgBytes, err := message.Build(&message.TxRequest{
+		TxIDs: unknown.List(),
+	})
+	if err != nil {
+		n.ctx.Log.Error("failed to build TxRequest message",
+			zap.Error(err),
+		)
+		return
+	}
+
+	requestID := n.requestID
+	n.requestID++
+	n.outstandingRequests[requestID] = unknown
+	n.requestedTxs.Union(unknown)
+
+	n.ctx.Log.Debug("requesting announced txs",
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint32("requestID", requestID),
+		zap.Int("numTxs", unknown.Len()),
+	)
+	if err := n.appSender.SendAppRequest(context.TODO(), set.Of(nodeID), requestID, msgBytes); err != nil {
+		n.ctx.Log.Debug("failed to send TxRequest message",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		n.clearRequest(requestID)
+	}
+}
+
+// addTx attempts to add a tx received from [nodeID] to the mempool.
+//
+// Assumes the context lock is held.
+func (n *network) addTx(nodeID ids.NodeID, tx *txs.Tx) {
+	txID := tx.ID()
 	if reason := n.blkBuilder.GetDropReason(txID); reason != nil {
 		// If the tx is being dropped - just ignore it
-		return nil
+		return
 	}
 
 	// add to mempool
@@ -151,7 +359,20 @@ func (n *network) AppGossip(_ context.Context, nodeID ids.NodeID, msgBytes []byt
 			zap.Error(err),
 		)
 	}
-	return nil
+}
+
+// clearRequest removes the outstanding request [requestID] and returns the
+// txs that were requested by it.
+//
+// Assumes the context lock is held.
+func (n *network) clearRequest(requestID uint32) (set.Set[ids.ID], bool) {
+	requested, ok := n.outstandingRequests[requestID]
+	if !ok {
+		return nil, false
+	}
+	delete(n.outstandingRequests, requestID)
+	n.requestedTxs.Difference(requested)
+	return requested, true
 }
 
 func (n *network) GossipTx(tx *txs.Tx) error {
@@ -166,10 +387,17 @@ func (n *network) GossipTx(tx *txs.Tx) error {
 		zap.Stringer("txID", txID),
 	)
 
-	msg := &message.Tx{Tx: tx.Bytes()}
+	// Pushing small txs is cheaper than an announcement followed by a request
+	// and response, so only large txs are announced. Gossip is sent to a
+	// sample of the connected peers, so txs are only announced if every
+	// connected peer handles announcements.
+	var msg message.Message = &message.Tx{Tx: tx.Bytes()}
+	if len(tx.Bytes()) > maxPushGossipTxSize && n.announcementsUnsupported.Len() == 0 {
+		msg = &message.TxAnnouncement{TxIDs: []ids.ID{txID}}
+	}
 	msgBytes, err := message.Build(msg)
 	if err != nil {
-		return fmt.Errorf("GossipTx: failed to build Tx message: %w", err)
+		return fmt.Errorf("GossipTx: failed to build %T message: %w", msg, err)
 	}
 	return n.appSender.SendAppGossip(context.TODO(), msgBytes)
 }
@@ -6,12 +6,15 @@ package builder
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/message"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 
@@ -145,3 +148,238 @@ func TestMempoolNewLocaTxIsGossiped(t *testing.T) {
 
 	require.Nil(gossipedBytes)
 }
+
+func getLargeValidTx(txBuilder txbuilder.Builder, t *testing.T) *txs.Tx {
+	tx, err := txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		make([]byte, 2*maxPushGossipTxSize),
+		constants.AVMID,
+		nil,
+		"chain name",
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(t, err)
+	return tx
+}
+
+// show that large locally generated txs are announced rather than pushed
+func TestMempoolNewLargeLocalTxIsAnnounced(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	var gossipedBytes []byte
+	env.sender.SendAppGossipF = func(_ context.Context, b []byte) error {
+		gossipedBytes = b
+		return nil
+	}
+
+	tx := getLargeValidTx(env.txBuilder, t)
+	require.Greater(len(tx.Bytes()), maxPushGossipTxSize)
+
+	require.NoError(env.Builder.AddUnverifiedTx(tx))
+	require.NotNil(gossipedBytes)
+
+	replyIntf, err := message.Parse(gossipedBytes)
+	require.NoError(err)
+	require.IsType(&message.TxAnnouncement{}, replyIntf)
+
+	reply := replyIntf.(*message.TxAnnouncement)
+	require.Equal([]ids.ID{tx.ID()}, reply.TxIDs)
+}
+
+// show that large txs are pushed while a connected peer doesn't support
+// announcements
+func TestMempoolNewLargeLocalTxIsPushedToOldPeers(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	var gossipedBytes []byte
+	env.sender.SendAppGossipF = func(_ context.Context, b []byte) error {
+		gossipedBytes = b
+		return nil
+	}
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(env.Builder.Connected(context.Background(), nodeID, &version.Application{
+		Major: minTxAnnouncementVersion.Major,
+		Minor: minTxAnnouncementVersion.Minor,
+		Patch: minTxAnnouncementVersion.Patch - 1,
+	}))
+
+	tx := getLargeValidTx(env.txBuilder, t)
+	require.NoError(env.Builder.AddUnverifiedTx(tx))
+
+	replyIntf, err := message.Parse(gossipedBytes)
+	require.NoError(err)
+	require.IsType(&message.Tx{}, replyIntf)
+
+	reply := replyIntf.(*message.Tx)
+	require.Equal(tx.Bytes(), reply.Tx)
+
+	// Once the old peer disconnects, large txs are announced again.
+	require.NoError(env.Builder.Disconnected(context.Background(), nodeID))
+
+	tx, err = env.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		make([]byte, 2*maxPushGossipTxSize),
+		constants.AVMID,
+		nil,
+		"other chain name",
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	require.NoError(env.Builder.GossipTx(tx))
+
+	replyIntf, err = message.Parse(gossipedBytes)
+	require.NoError(err)
+	require.IsType(&message.TxAnnouncement{}, replyIntf)
+}
+
+// show that unknown announced txs are requested once and added to the mempool
+// when they are received
+func TestMempoolAnnouncedTxIsRequested(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	var (
+		requestedNodeIDs set.Set[ids.NodeID]
+		requestID        uint32
+		requestBytes     []byte
+		numRequests      int
+	)
+	env.sender.SendAppRequestF = func(_ context.Context, nodeIDs set.Set[ids.NodeID], reqID uint32, b []byte) error {
+		requestedNodeIDs = nodeIDs
+		requestID = reqID
+		requestBytes = b
+		numRequests++
+		return nil
+	}
+	env.sender.SendAppGossipF = func(context.Context, []byte) error {
+		return nil
+	}
+
+	nodeID := ids.GenerateTestNodeID()
+	tx := getLargeValidTx(env.txBuilder, t)
+	txID := tx.ID()
+
+	msgBytes, err := message.Build(&message.TxAnnouncement{
+		TxIDs: []ids.ID{txID},
+	})
+	require.NoError(err)
+
+	env.ctx.Lock.Unlock()
+	require.NoError(env.AppGossip(context.Background(), nodeID, msgBytes))
+	// an announcement from a second peer shouldn't cause a duplicate request
+	require.NoError(env.AppGossip(context.Background(), ids.GenerateTestNodeID(), msgBytes))
+	env.ctx.Lock.Lock()
+
+	require.Equal(1, numRequests)
+	require.Equal(set.Of(nodeID), requestedNodeIDs)
+
+	requestIntf, err := message.Parse(requestBytes)
+	require.NoError(err)
+	require.IsType(&message.TxRequest{}, requestIntf)
+	require.Equal([]ids.ID{txID}, requestIntf.(*message.TxRequest).TxIDs)
+
+	responseBytes, err := message.Build(&message.TxResponse{
+		Txs: [][]byte{tx.Bytes()},
+	})
+	require.NoError(err)
+
+	env.ctx.Lock.Unlock()
+	require.NoError(env.AppResponse(context.Background(), nodeID, requestID, responseBytes))
+	env.ctx.Lock.Lock()
+
+	require.True(env.Builder.Has(txID))
+}
+
+// show that txs that weren't requested are dropped from responses
+func TestMempoolUnrequestedTxIsDropped(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	var requestID uint32
+	env.sender.SendAppRequestF = func(_ context.Context, _ set.Set[ids.NodeID], reqID uint32, _ []byte) error {
+		requestID = reqID
+		return nil
+	}
+
+	nodeID := ids.GenerateTestNodeID()
+	msgBytes, err := message.Build(&message.TxAnnouncement{
+		TxIDs: []ids.ID{ids.GenerateTestID()},
+	})
+	require.NoError(err)
+
+	tx := getValidTx(env.txBuilder, t)
+	responseBytes, err := message.Build(&message.TxResponse{
+		Txs: [][]byte{tx.Bytes()},
+	})
+	require.NoError(err)
+
+	env.ctx.Lock.Unlock()
+	require.NoError(env.AppGossip(context.Background(), nodeID, msgBytes))
+	require.NoError(env.AppResponse(context.Background(), nodeID, requestID, responseBytes))
+	env.ctx.Lock.Lock()
+
+	require.False(env.Builder.Has(tx.ID()))
+}
+
+// show that requests for txs are served from the mempool
+func TestMempoolTxRequestIsServed(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	env.sender.SendAppGossipF = func(context.Context, []byte) error {
+		return nil
+	}
+
+	var responseBytes []byte
+	env.sender.SendAppResponseF = func(_ context.Context, _ ids.NodeID, _ uint32, b []byte) error {
+		responseBytes = b
+		return nil
+	}
+
+	tx := getLargeValidTx(env.txBuilder, t)
+	require.NoError(env.Builder.AddUnverifiedTx(tx))
+
+	msgBytes, err := message.Build(&message.TxRequest{
+		TxIDs: []ids.ID{tx.ID(), ids.GenerateTestID()},
+	})
+	require.NoError(err)
+
+	env.ctx.Lock.Unlock()
+	require.NoError(env.AppRequest(context.Background(), ids.GenerateTestNodeID(), 0, time.Time{}, msgBytes))
+	env.ctx.Lock.Lock()
+
+	responseIntf, err := message.Parse(responseBytes)
+	require.NoError(err)
+	require.IsType(&message.TxResponse{}, responseIntf)
+	require.Equal([][]byte{tx.Bytes()}, responseIntf.(*message.TxResponse).Txs)
+}
@@ -9,10 +9,16 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/validators"
 )
 
@@ -30,6 +36,9 @@ type acceptor struct {
 	metrics      metrics.Metrics
 	validators   validators.Manager
 	bootstrapped *utils.Atomic[bool]
+
+	// addressTxs is nil if transactions aren't indexed by address
+	addressTxs index.AddressTxsIndexer
 }
 
 func (a *acceptor) BanffAbortBlock(b *blocks.BanffAbortBlock) error {
@@ -79,13 +88,17 @@ func (a *acceptor) ApricotAtomicBlock(b *blocks.ApricotAtomicBlock) error {
 		return fmt.Errorf("%w %s", errMissingBlockState, blkID)
 	}
 
+	if err := a.indexTxs(b, b); err != nil {
+		return err
+	}
+
 	// Update the state to reflect the changes made in [onAcceptState].
 	if err := blkState.onAcceptState.Apply(a.state); err != nil {
 		return err
 	}
 
-	defer a.state.Abort()
-	batch, err := a.state.CommitBatch()
+	defer a.abort()
+	batches, err := a.commitBatches()
 	if err != nil {
 		return fmt.Errorf(
 			"failed to commit VM's database for block %s: %w",
@@ -94,8 +107,8 @@ func (a *acceptor) ApricotAtomicBlock(b *blocks.ApricotAtomicBlock) error {
 		)
 	}
 
-	// Note that this method writes [batch] to the database.
-	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, batch); err != nil {
+	// Note that this method writes [batches] to the database.
+	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, batches...); err != nil {
 		return fmt.Errorf(
 			"failed to atomically accept tx %s in block %s: %w",
 			b.Tx.ID(),
@@ -131,7 +144,7 @@ func (a *acceptor) abortBlock(b blocks.Block, blockType string) error {
 		}
 	}
 
-	return a.optionBlock(b, parentState.statelessBlock, blockType)
+	return a.optionBlock(b, parentState.statelessBlock, false /*=committed*/, blockType)
 }
 
 func (a *acceptor) commitBlock(b blocks.Block, blockType string) error {
@@ -149,10 +162,12 @@ func (a *acceptor) commitBlock(b blocks.Block, blockType string) error {
 		}
 	}
 
-	return a.optionBlock(b, parentState.statelessBlock, blockType)
+	return a.optionBlock(b, parentState.statelessBlock, true /*=committed*/, blockType)
 }
 
-func (a *acceptor) optionBlock(b, parent blocks.Block, blockType string) error {
+// optionBlock accepts [b] and its parent, [parent]. The transactions of
+// [parent] are only accepted if [committed] is true.
+func (a *acceptor) optionBlock(b, parent blocks.Block, committed bool, blockType string) error {
 	blkID := b.ID()
 	parentID := parent.ID()
 
@@ -176,11 +191,18 @@ func (a *acceptor) optionBlock(b, parent blocks.Block, blockType string) error {
 	if !ok {
 		return fmt.Errorf("%w %s", errMissingBlockState, blkID)
 	}
+	acceptedTxs := parent
+	if !committed {
+		acceptedTxs = nil
+	}
+	if err := a.indexTxs(b, acceptedTxs); err != nil {
+		return err
+	}
 	if err := blkState.onAcceptState.Apply(a.state); err != nil {
 		return err
 	}
 
-	if err := a.state.Commit(); err != nil {
+	if err := a.commit(); err != nil {
 		return err
 	}
 
@@ -239,13 +261,17 @@ func (a *acceptor) standardBlock(b blocks.Block, blockType string) error {
 		return fmt.Errorf("%w %s", errMissingBlockState, blkID)
 	}
 
+	if err := a.indexTxs(b, b); err != nil {
+		return err
+	}
+
 	// Update the state to reflect the changes made in [onAcceptState].
 	if err := blkState.onAcceptState.Apply(a.state); err != nil {
 		return err
 	}
 
-	defer a.state.Abort()
-	batch, err := a.state.CommitBatch()
+	defer a.abort()
+	batches, err := a.commitBatches()
 	if err != nil {
 		return fmt.Errorf(
 			"failed to commit VM's database for block %s: %w",
@@ -254,8 +280,8 @@ func (a *acceptor) standardBlock(b blocks.Block, blockType string) error {
 		)
 	}
 
-	// Note that this method writes [batch] to the database.
-	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, batch); err != nil {
+	// Note that this method writes [batches] to the database.
+	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, batches...); err != nil {
 		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
 	}
 
@@ -289,3 +315,72 @@ func (a *acceptor) commonAccept(b blocks.Block) error {
 	a.validators.OnAcceptedBlockID(blkID)
 	return nil
 }
+
+// indexTxs indexes the transactions of [accepted] by address, if enabled, as
+// part of the acceptance of [b]. [accepted] is nil if no transactions were
+// accepted. It must be called before the state is updated so that the UTXOs
+// consumed by the transactions can be looked up.
+func (a *acceptor) indexTxs(b, accepted blocks.Block) error {
+	if a.addressTxs == nil {
+		return nil
+	}
+
+	var acceptedTxs []*txs.Tx
+	if accepted != nil {
+		acceptedTxs = accepted.Txs()
+	}
+	utxos := make(map[ids.ID]*avax.UTXO)
+	for _, tx := range acceptedTxs {
+		for inputID := range tx.Unsigned.InputIDs() {
+			utxo, err := a.state.GetUTXO(inputID)
+			switch err {
+			case nil:
+				utxos[inputID] = utxo
+			case database.ErrNotFound:
+				// The UTXO was imported or produced in the same block.
+			default:
+				return fmt.Errorf("failed to get UTXO %s: %w", inputID, err)
+			}
+		}
+	}
+	return a.addressTxs.Accept(b.Height(), acceptedTxs, utxos)
+}
+
+// commit persists the accepted state.
+func (a *acceptor) commit() error {
+	if a.addressTxs == nil {
+		return a.state.Commit()
+	}
+
+	defer a.abort()
+	batches, err := a.commitBatches()
+	if err != nil {
+		return err
+	}
+	return atomic.WriteAll(batches[0], batches[1:]...)
+}
+
+// commitBatches returns the batches that must be written atomically to
+// persist the accepted state.
+func (a *acceptor) commitBatches() ([]database.Batch, error) {
+	batch, err := a.state.CommitBatch()
+	if err != nil {
+		return nil, err
+	}
+	if a.addressTxs == nil {
+		return []database.Batch{batch}, nil
+	}
+
+	indexBatch, err := a.addressTxs.CommitBatch()
+	if err != nil {
+		return nil, err
+	}
+	return []database.Batch{batch, indexBatch}, nil
+}
+
+func (a *acceptor) abort() {
+	a.state.Abort()
+	if a.addressTxs != nil {
+		a.addressTxs.Abort()
+	}
+}
@@ -13,12 +13,14 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 )
 
 var (
 	_ snowman.Block       = (*Block)(nil)
 	_ snowman.OracleBlock = (*Block)(nil)
+	_ block.WithNumTxs    = (*Block)(nil)
 )
 
 // Exported for testing in platformvm package.
@@ -37,6 +39,19 @@ func (b *Block) Verify(context.Context) error {
 	return b.Visit(b.manager.verifier)
 }
 
+// PreVerify syntactically verifies the txs of the block. Syntactic
+// verification doesn't depend on the chain state, so PreVerify may be called
+// concurrently with the verification and acceptance of other blocks. Txs are
+// marked as syntactically verified, so they aren't verified again by Verify.
+func (b *Block) PreVerify() error {
+	for _, tx := range b.Txs() {
+		if err := tx.SyntacticVerify(b.manager.ctx); err != nil {
+			return fmt.Errorf("tx %s failed syntactic verification: %w", tx.ID(), err)
+		}
+	}
+	return nil
+}
+
 func (b *Block) Accept(context.Context) error {
 	return b.Visit(b.manager.acceptor)
 }
@@ -78,6 +93,10 @@ func (b *Block) Status() choices.Status {
 	}
 }
 
+func (b *Block) NumTxs() (int, bool) {
+	return len(b.Txs()), true
+}
+
 func (b *Block) Timestamp() time.Time {
 	return b.manager.getTimestamp(b.ID())
 }
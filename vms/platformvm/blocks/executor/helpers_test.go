@@ -204,6 +204,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 			res.state,
 			res.backend,
 			pvalidators.TestManager,
+			nil,
 		)
 		addSubnet(res)
 	} else {
@@ -213,6 +214,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 			res.mockedState,
 			res.backend,
 			pvalidators.TestManager,
+			nil,
 		)
 		// we do not add any subnet to state, since we can mock
 		// whatever we need
@@ -7,6 +7,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
@@ -32,6 +33,7 @@ func NewManager(
 	s state.State,
 	txExecutorBackend *executor.Backend,
 	validatorManager validators.Manager,
+	addressTxs index.AddressTxsIndexer,
 ) Manager {
 	backend := &backend{
 		Mempool:      mempool,
@@ -52,6 +54,7 @@ func NewManager(
 			metrics:      metrics,
 			validators:   validatorManager,
 			bootstrapped: txExecutorBackend.Bootstrapped,
+			addressTxs:   addressTxs,
 		},
 		rejector: &rejector{
 			backend:         backend,
@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var (
@@ -403,6 +404,14 @@ func (v *verifier) standardBlock(
 		atomicRequests: make(map[ids.ID]*atomic.Requests),
 	}
 
+	// Signature verification is deferred until all the txs have been
+	// executed so that the signatures can be verified in parallel.
+	batcher, batching := v.txExecutorBackend.Fx.(secp256k1fx.Batcher)
+	if batching {
+		batcher.StartBatch()
+		defer batcher.AbortBatch()
+	}
+
 	// Finally we process the transactions
 	funcs := make([]func(), 0, len(b.Transactions))
 	for _, tx := range b.Transactions {
@@ -445,6 +454,12 @@ func (v *verifier) standardBlock(
 		return err
 	}
 
+	if batching {
+		if err := batcher.FinishBatch(); err != nil {
+			return err
+		}
+	}
+
 	if numFuncs := len(funcs); numFuncs == 1 {
 		blkState.onAcceptFunc = funcs[0]
 	} else if numFuncs > 1 {
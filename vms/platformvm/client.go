@@ -71,9 +71,19 @@ type Client interface {
 	//
 	// Deprecated: Subnets should be fetched from a dedicated indexer.
 	GetSubnets(ctx context.Context, subnetIDs []ids.ID, options ...rpc.Option) ([]ClientSubnet, error)
+	// GetSubnetOwner returns the owner of the subnet with ID [subnetID] and
+	// whether the subnet has been transformed into a permissionless subnet
+	GetSubnetOwner(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (*ClientOwner, bool, error)
+	// VerifySubnetAuth returns whether signatures from the keys controlling
+	// [addrs] can authorize transactions on the subnet with ID [subnetID]. If
+	// they can, the signature indices to use in the subnet auth are returned.
+	VerifySubnetAuth(ctx context.Context, subnetID ids.ID, addrs []ids.ShortID, options ...rpc.Option) ([]uint32, bool, error)
 	// GetStakingAssetID returns the assetID of the asset used for staking on
 	// subnet corresponding to [subnetID]
 	GetStakingAssetID(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ids.ID, error)
+	// GetSubnetTransformation returns the transformation status of the subnet
+	// with ID [subnetID]
+	GetSubnetTransformation(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (*GetSubnetTransformationReply, error)
 	// GetCurrentValidators returns the list of current validators for subnet with ID [subnetID]
 	GetCurrentValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]ClientPermissionlessValidator, error)
 	// GetPendingValidators returns the list of pending validators for subnet with ID [subnetID]
@@ -216,6 +226,18 @@ type Client interface {
 		freq time.Duration,
 		options ...rpc.Option,
 	) (*GetTxStatusResponse, error)
+	// GetTxsByAddress returns at most [pageSize] IDs of the accepted txs that
+	// involved [addr], in order of acceptance and starting at [cursor], along
+	// with the cursor to read the next page from. If [txTypes] is non-empty,
+	// only txs of those types, e.g. "AddValidatorTx", are returned.
+	GetTxsByAddress(
+		ctx context.Context,
+		addr ids.ShortID,
+		txTypes []string,
+		cursor uint64,
+		pageSize uint64,
+		options ...rpc.Option,
+	) ([]ids.ID, uint64, error)
 	// GetStake returns the amount of nAVAX that [addrs] have cumulatively
 	// staked on the Primary Network.
 	//
@@ -227,6 +249,17 @@ type Client interface {
 		validatorsOnly bool,
 		options ...rpc.Option,
 	) (map[ids.ID]uint64, [][]byte, error)
+	// GetStakedUTXOs returns at most [limit] of the UTXOs staked by [addrs],
+	// sorted by UTXO ID and starting after [startUTXOID], along with the
+	// UTXO ID to start the next page from.
+	GetStakedUTXOs(
+		ctx context.Context,
+		addrs []ids.ShortID,
+		validatorsOnly bool,
+		startUTXOID ids.ID,
+		limit uint32,
+		options ...rpc.Option,
+	) ([]StakedUTXO, ids.ID, error)
 	// GetMinStake returns the minimum staking amount in nAVAX for validators
 	// and delegators respectively
 	GetMinStake(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error)
@@ -245,10 +278,21 @@ type Client interface {
 		endTime uint64,
 		options ...rpc.Option,
 	) (uint64, error)
+	// GetDelegationStats returns how much more stake can be delegated to the
+	// current validator [nodeID] of [subnetID]
+	GetDelegationStats(ctx context.Context, subnetID ids.ID, nodeID ids.NodeID, options ...rpc.Option) (*DelegationStats, error)
+	// ListDelegationStats returns the delegation stats of at most [limit]
+	// current permissionless validators of [subnetID], sorted by decreasing
+	// remaining capacity. If [limit] is 0, all the validators are returned.
+	ListDelegationStats(ctx context.Context, subnetID ids.ID, limit uint32, options ...rpc.Option) ([]DelegationStats, error)
 	// GetRewardUTXOs returns the reward UTXOs for a transaction
 	//
 	// Deprecated: GetRewardUTXOs should be fetched from a dedicated indexer.
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
+	// GetRewardUTXOsBatch returns the reward UTXOs of each of the provided
+	// transactions, along with their decoded amounts and owners. The UTXOs
+	// are hex encoded.
+	GetRewardUTXOsBatch(ctx context.Context, txIDs []ids.ID, options ...rpc.Option) ([]TxRewardUTXOs, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided
@@ -259,6 +303,15 @@ type Client interface {
 		height uint64,
 		options ...rpc.Option,
 	) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+	// GetCanonicalValidatorSet returns the BLS public keys, their aggregate,
+	// and the total weight of the validator set of a provided subnet at the
+	// specified height.
+	GetCanonicalValidatorSet(
+		ctx context.Context,
+		subnetID ids.ID,
+		height uint64,
+		options ...rpc.Option,
+	) (*GetCanonicalValidatorSetReply, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetBlockByHeight returns the block at the given [height].
@@ -416,6 +469,34 @@ func (c *client) GetSubnets(ctx context.Context, ids []ids.ID, options ...rpc.Op
 	return subnets, nil
 }
 
+func (c *client) GetSubnetOwner(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (*ClientOwner, bool, error) {
+	res := &GetSubnetOwnerReply{}
+	err := c.requester.SendRequest(ctx, "platform.getSubnetOwner", &GetSubnetOwnerArgs{
+		SubnetID: subnetID,
+	}, res, options...)
+	if err != nil {
+		return nil, false, err
+	}
+	owner, err := apiOwnerToClientOwner(&res.Owner)
+	return owner, res.Permissionless, err
+}
+
+func (c *client) VerifySubnetAuth(ctx context.Context, subnetID ids.ID, addrs []ids.ShortID, options ...rpc.Option) ([]uint32, bool, error) {
+	res := &VerifySubnetAuthReply{}
+	err := c.requester.SendRequest(ctx, "platform.verifySubnetAuth", &VerifySubnetAuthArgs{
+		SubnetID:  subnetID,
+		Addresses: ids.ShortIDsToStrings(addrs),
+	}, res, options...)
+	if err != nil {
+		return nil, false, err
+	}
+	sigIndices := make([]uint32, len(res.SigIndices))
+	for i, sigIndex := range res.SigIndices {
+		sigIndices[i] = uint32(sigIndex)
+	}
+	return sigIndices, res.Authorized, nil
+}
+
 func (c *client) GetStakingAssetID(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ids.ID, error) {
 	res := &GetStakingAssetIDResponse{}
 	err := c.requester.SendRequest(ctx, "platform.getStakingAssetID", &GetStakingAssetIDArgs{
@@ -424,6 +505,14 @@ func (c *client) GetStakingAssetID(ctx context.Context, subnetID ids.ID, options
 	return res.AssetID, err
 }
 
+func (c *client) GetSubnetTransformation(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (*GetSubnetTransformationReply, error) {
+	res := &GetSubnetTransformationReply{}
+	err := c.requester.SendRequest(ctx, "platform.getSubnetTransformation", &GetSubnetTransformationArgs{
+		SubnetID: subnetID,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetCurrentValidators(
 	ctx context.Context,
 	subnetID ids.ID,
@@ -744,6 +833,26 @@ func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Op
 	return res, err
 }
 
+func (c *client) GetTxsByAddress(
+	ctx context.Context,
+	addr ids.ShortID,
+	txTypes []string,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) ([]ids.ID, uint64, error) {
+	res := &GetTxsByAddressReply{}
+	err := c.requester.SendRequest(ctx, "platform.getTxsByAddress", &GetTxsByAddressArgs{
+		JSONAddress: api.JSONAddress{
+			Address: addr.String(),
+		},
+		TxTypes:  txTypes,
+		Cursor:   json.Uint64(cursor),
+		PageSize: json.Uint64(pageSize),
+	}, res, options...)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
 func (c *client) AwaitTxDecided(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (*GetTxStatusResponse, error) {
 	ticker := time.NewTicker(freq)
 	defer ticker.Stop()
@@ -799,6 +908,28 @@ func (c *client) GetStake(
 	return staked, outputs, err
 }
 
+func (c *client) GetStakedUTXOs(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	validatorsOnly bool,
+	startUTXOID ids.ID,
+	limit uint32,
+	options ...rpc.Option,
+) ([]StakedUTXO, ids.ID, error) {
+	res := &GetStakeReply{}
+	err := c.requester.SendRequest(ctx, "platform.getStake", &GetStakeArgs{
+		JSONAddresses: api.JSONAddresses{
+			Addresses: ids.ShortIDsToStrings(addrs),
+		},
+		ValidatorsOnly: validatorsOnly,
+		Encoding:       formatting.Hex,
+		IncludeUTXOs:   true,
+		Limit:          json.Uint32(limit),
+		StartUTXOID:    startUTXOID,
+	}, res, options...)
+	return res.StakedUTXOs, res.EndUTXOID, err
+}
+
 func (c *client) GetMinStake(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error) {
 	res := &GetMinStakeReply{}
 	err := c.requester.SendRequest(ctx, "platform.getMinStake", &GetMinStakeArgs{
@@ -832,6 +963,24 @@ func (c *client) GetMaxStakeAmount(ctx context.Context, subnetID ids.ID, nodeID
 	return uint64(res.Amount), err
 }
 
+func (c *client) GetDelegationStats(ctx context.Context, subnetID ids.ID, nodeID ids.NodeID, options ...rpc.Option) (*DelegationStats, error) {
+	res := &GetDelegationStatsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getDelegationStats", &GetDelegationStatsArgs{
+		SubnetID: subnetID,
+		NodeID:   nodeID,
+	}, res, options...)
+	return &res.DelegationStats, err
+}
+
+func (c *client) ListDelegationStats(ctx context.Context, subnetID ids.ID, limit uint32, options ...rpc.Option) ([]DelegationStats, error) {
+	res := &ListDelegationStatsReply{}
+	err := c.requester.SendRequest(ctx, "platform.listDelegationStats", &ListDelegationStatsArgs{
+		SubnetID: subnetID,
+		Limit:    json.Uint32(limit),
+	}, res, options...)
+	return res.Validators, err
+}
+
 func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, options ...rpc.Option) ([][]byte, error) {
 	res := &GetRewardUTXOsReply{}
 	err := c.requester.SendRequest(ctx, "platform.getRewardUTXOs", args, res, options...)
@@ -849,6 +998,15 @@ func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, option
 	return utxos, err
 }
 
+func (c *client) GetRewardUTXOsBatch(ctx context.Context, txIDs []ids.ID, options ...rpc.Option) ([]TxRewardUTXOs, error) {
+	res := &GetRewardUTXOsBatchReply{}
+	err := c.requester.SendRequest(ctx, "platform.getRewardUTXOsBatch", &GetRewardUTXOsBatchArgs{
+		TxIDs:    txIDs,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res.Results, err
+}
+
 func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error) {
 	res := &GetTimestampReply{}
 	err := c.requester.SendRequest(ctx, "platform.getTimestamp", struct{}{}, res, options...)
@@ -869,6 +1027,20 @@ func (c *client) GetValidatorsAt(
 	return res.Validators, err
 }
 
+func (c *client) GetCanonicalValidatorSet(
+	ctx context.Context,
+	subnetID ids.ID,
+	height uint64,
+	options ...rpc.Option,
+) (*GetCanonicalValidatorSetReply, error) {
+	res := &GetCanonicalValidatorSetReply{}
+	err := c.requester.SendRequest(ctx, "platform.getCanonicalValidatorSet", &GetCanonicalValidatorSetArgs{
+		SubnetID: subnetID,
+		Height:   json.Uint64(height),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "platform.getBlock", &api.GetBlockArgs{
@@ -18,6 +18,8 @@ var DefaultExecutionConfig = ExecutionConfig{
 	ChainDBCacheSize:             2048,
 	BlockIDCacheSize:             8192,
 	ChecksumsEnabled:             false,
+	IndexTransactions:            false,
+	IndexAllowIncomplete:         false,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
@@ -30,6 +32,8 @@ type ExecutionConfig struct {
 	ChainDBCacheSize             int  `json:"chain-db-cache-size"`
 	BlockIDCacheSize             int  `json:"block-id-cache-size"`
 	ChecksumsEnabled             bool `json:"checksums-enabled"`
+	IndexTransactions            bool `json:"index-transactions"`
+	IndexAllowIncomplete         bool `json:"index-allow-incomplete"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
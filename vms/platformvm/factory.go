@@ -14,9 +14,16 @@ var _ vms.Factory = (*Factory)(nil)
 // Factory can create new instances of the Platform Chain
 type Factory struct {
 	config.Config
+
+	// If non-nil, reads the validators of the created Platform Chain.
+	ValidatorReader *LockedValidatorReader
 }
 
 // New returns a new instance of the Platform Chain
 func (f *Factory) New(logging.Logger) (interface{}, error) {
-	return &VM{Config: f.Config}, nil
+	vm := &VM{Config: f.Config}
+	if f.ValidatorReader != nil {
+		f.ValidatorReader.setVM(vm)
+	}
+	return vm, nil
 }
@@ -0,0 +1,255 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	_ AddressTxsIndexer = (*indexer)(nil)
+
+	ErrIncompleteIndex = errors.New("running would create incomplete index. Allow incomplete indices or re-sync from genesis with indexing enabled")
+
+	errInvalidEntry = errors.New("invalid index entry")
+
+	addressPrefix = []byte("address")
+	// heightKey maps to the height of the last block whose txs were indexed.
+	heightKey = []byte("height")
+	// nextIndexKey is stored under each address and maps to the index of the
+	// next tx that will be indexed for the address.
+	nextIndexKey = []byte("idx")
+)
+
+// AddressTxsIndexer maintains which accepted transactions involved which
+// addresses.
+//
+// A transaction is said to involve an address if the address is an owner of:
+//  1. A UTXO that the transaction consumes from the P-chain.
+//  2. An output, stake output, or exported output of the transaction.
+//  3. A rewards owner or subnet owner defined by the transaction.
+//
+// UTXOs that are imported from other chains are only indexed by the owners of
+// the outputs that they are imported to.
+type AddressTxsIndexer interface {
+	// Accept indexes the transactions accepted in the block at [height].
+	// [utxos] contains the UTXOs consumed by [txs] that are still in the
+	// P-chain state.
+	//
+	// Changes aren't persisted until the batch returned by CommitBatch is
+	// written.
+	Accept(height uint64, txs []*txs.Tx, utxos map[ids.ID]*avax.UTXO) error
+
+	// Read returns the IDs of the transactions that involved [addr], in order
+	// of acceptance, starting at [cursor]. If [txTypes] is non-empty, only
+	// transactions of those types, e.g. "AddValidatorTx", are returned.
+	//
+	// At most [pageSize] IDs are returned, along with the cursor to continue
+	// reading from.
+	Read(addr ids.ShortID, txTypes set.Set[string], cursor, pageSize uint64) ([]ids.ID, uint64, error)
+
+	// CommitBatch returns a batch containing all changes since the last call
+	// to Abort. The batch must be written with the state's batch.
+	CommitBatch() (database.Batch, error)
+	Abort()
+}
+
+type indexer struct {
+	db        *versiondb.Database
+	addressDB database.Database
+
+	numTxsIndexed prometheus.Counter
+}
+
+// NewIndexer returns a new AddressTxsIndexer that stores its index in [db].
+//
+// [lastAcceptedHeight] is the height of the last accepted block. If
+// transactions were accepted that weren't indexed, ErrIncompleteIndex is
+// returned unless [allowIncomplete] is true.
+func NewIndexer(
+	db database.Database,
+	lastAcceptedHeight uint64,
+	allowIncomplete bool,
+	metricsNamespace string,
+	metricsRegisterer prometheus.Registerer,
+) (AddressTxsIndexer, error) {
+	vdb := versiondb.New(db)
+	i := &indexer{
+		db:        vdb,
+		addressDB: prefixdb.New(addressPrefix, vdb),
+		numTxsIndexed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "txs_indexed",
+			Help:      "Number of transactions indexed by address",
+		}),
+	}
+	if err := metricsRegisterer.Register(i.numTxsIndexed); err != nil {
+		return nil, err
+	}
+
+	// If the index has never been written to, it is only complete if no
+	// blocks have been accepted after genesis.
+	indexedHeight, err := database.GetUInt64(vdb, heightKey)
+	if err == database.ErrNotFound {
+		indexedHeight = 0
+	} else if err != nil {
+		return nil, err
+	}
+	if indexedHeight != lastAcceptedHeight && !allowIncomplete {
+		return nil, fmt.Errorf("%w: indexed height %d != last accepted height %d",
+			ErrIncompleteIndex,
+			indexedHeight,
+			lastAcceptedHeight,
+		)
+	}
+	return i, nil
+}
+
+// Accept associates each transaction with the addresses it involved.
+// The database structure is:
+// [address]
+// |  "idx" => 2           Next index to use
+// |  "0"   => txID1 + txType1
+// |  "1"   => txID2 + txType2
+func (i *indexer) Accept(height uint64, accepted []*txs.Tx, utxos map[ids.ID]*avax.UTXO) error {
+	for _, tx := range accepted {
+		txID := tx.ID()
+		entry := make([]byte, 0, ids.IDLen+len(TxType(tx)))
+		entry = append(entry, txID[:]...)
+		entry = append(entry, TxType(tx)...)
+
+		for addr := range Addresses(tx, utxos) {
+			if err := i.append(addr, entry); err != nil {
+				return fmt.Errorf("failed to index tx %s: %w", txID, err)
+			}
+		}
+		i.numTxsIndexed.Inc()
+	}
+	return database.PutUInt64(i.db, heightKey, height)
+}
+
+func (i *indexer) append(addr ids.ShortID, entry []byte) error {
+	addrDB := prefixdb.New(addr[:], i.addressDB)
+
+	idx, err := database.GetUInt64(addrDB, nextIndexKey)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+	if err := addrDB.Put(database.PackUInt64(idx), entry); err != nil {
+		return err
+	}
+	return database.PutUInt64(addrDB, nextIndexKey, idx+1)
+}
+
+func (i *indexer) Read(addr ids.ShortID, txTypes set.Set[string], cursor, pageSize uint64) ([]ids.ID, uint64, error) {
+	addrDB := prefixdb.New(addr[:], i.addressDB)
+
+	// Numeric keys are big endian, so iteration is in order of acceptance.
+	iter := addrDB.NewIteratorWithStart(database.PackUInt64(cursor))
+	defer iter.Release()
+
+	var txIDs []ids.ID
+	for uint64(len(txIDs)) < pageSize && iter.Next() {
+		key := iter.Key()
+		if len(key) != wrappers.LongLen {
+			// This key has the next index to use, not a tx.
+			continue
+		}
+		cursor = binary.BigEndian.Uint64(key) + 1
+
+		value := iter.Value()
+		if len(value) < ids.IDLen {
+			return nil, 0, fmt.Errorf("%w: %x", errInvalidEntry, value)
+		}
+		if txTypes.Len() > 0 && !txTypes.Contains(string(value[ids.IDLen:])) {
+			continue
+		}
+
+		txID, err := ids.ToID(value[:ids.IDLen])
+		if err != nil {
+			return nil, 0, err
+		}
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, cursor, iter.Error()
+}
+
+func (i *indexer) CommitBatch() (database.Batch, error) {
+	return i.db.CommitBatch()
+}
+
+func (i *indexer) Abort() {
+	i.db.Abort()
+}
+
+// TxType returns the name of the type of [tx], e.g. "AddValidatorTx".
+func TxType(tx *txs.Tx) string {
+	t := reflect.TypeOf(tx.Unsigned)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// Addresses returns the addresses involved in [tx]. [utxos] contains the UTXOs
+// consumed by [tx] that are still in the P-chain state.
+func Addresses(tx *txs.Tx, utxos map[ids.ID]*avax.UTXO) set.Set[ids.ShortID] {
+	addrs := set.Set[ids.ShortID]{}
+	for inputID := range tx.Unsigned.InputIDs() {
+		if utxo, ok := utxos[inputID]; ok {
+			addOwners(addrs, utxo.Out)
+		}
+	}
+	for _, out := range tx.Unsigned.Outputs() {
+		addOwners(addrs, out.Out)
+	}
+
+	switch utx := tx.Unsigned.(type) {
+	case txs.ValidatorTx:
+		addOutputs(addrs, utx.Stake())
+		addOwners(addrs, utx.ValidationRewardsOwner())
+		addOwners(addrs, utx.DelegationRewardsOwner())
+	case txs.DelegatorTx:
+		addOutputs(addrs, utx.Stake())
+		addOwners(addrs, utx.RewardsOwner())
+	case *txs.CreateSubnetTx:
+		addOwners(addrs, utx.Owner)
+	case *txs.ExportTx:
+		addOutputs(addrs, utx.ExportedOutputs)
+	}
+	return addrs
+}
+
+func addOutputs(addrs set.Set[ids.ShortID], outs []*avax.TransferableOutput) {
+	for _, out := range outs {
+		addOwners(addrs, out.Out)
+	}
+}
+
+func addOwners(addrs set.Set[ids.ShortID], owner interface{}) {
+	switch owner := owner.(type) {
+	case *stakeable.LockOut:
+		addOwners(addrs, owner.TransferableOut)
+	case *secp256k1fx.TransferOutput:
+		addrs.Add(owner.Addrs...)
+	case *secp256k1fx.OutputOwners:
+		addrs.Add(owner.Addrs...)
+	}
+}
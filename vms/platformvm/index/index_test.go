@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newOutput(addr ids.ShortID) *avax.TransferableOutput {
+	return &avax.TransferableOutput{
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+}
+
+func newTx(t *testing.T, utx txs.UnsignedTx) *txs.Tx {
+	tx := &txs.Tx{Unsigned: utx}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx
+}
+
+func writeBatch(t *testing.T, i AddressTxsIndexer) {
+	require := require.New(t)
+
+	batch, err := i.CommitBatch()
+	require.NoError(err)
+	require.NoError(batch.Write())
+	i.Abort()
+}
+
+func TestAddresses(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		inputAddr   = ids.GenerateTestShortID()
+		outputAddr  = ids.GenerateTestShortID()
+		stakeAddr   = ids.GenerateTestShortID()
+		rewardsAddr = ids.GenerateTestShortID()
+		inputID     = ids.GenerateTestID()
+	)
+	tx := newTx(t, &txs.AddValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{TxID: inputID},
+				In:     &secp256k1fx.TransferInput{Amt: 1},
+			}},
+			Outs: []*avax.TransferableOutput{newOutput(outputAddr)},
+		}},
+		StakeOuts: []*avax.TransferableOutput{{
+			Out: &stakeable.LockOut{
+				TransferableOut: newOutput(stakeAddr).Out,
+			},
+		}},
+		RewardsOwner: &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardsAddr},
+		},
+	})
+	utxos := map[ids.ID]*avax.UTXO{
+		tx.Unsigned.(*txs.AddValidatorTx).Ins[0].InputID(): {
+			Out: newOutput(inputAddr).Out,
+		},
+	}
+
+	require.Equal("AddValidatorTx", TxType(tx))
+	require.Equal(
+		set.Of(inputAddr, outputAddr, stakeAddr, rewardsAddr),
+		Addresses(tx, utxos),
+	)
+}
+
+func TestIndexer(t *testing.T) {
+	require := require.New(t)
+
+	addr := ids.GenerateTestShortID()
+	i, err := NewIndexer(memdb.New(), 0, false, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	createSubnetTx := newTx(t, &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			Outs: []*avax.TransferableOutput{newOutput(addr)},
+		}},
+		Owner: &secp256k1fx.OutputOwners{},
+	})
+	exportTx := newTx(t, &txs.ExportTx{
+		ExportedOutputs: []*avax.TransferableOutput{newOutput(addr)},
+	})
+	otherTx := newTx(t, &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			Outs: []*avax.TransferableOutput{newOutput(ids.GenerateTestShortID())},
+		}},
+		Owner: &secp256k1fx.OutputOwners{},
+	})
+	require.NoError(i.Accept(1, []*txs.Tx{createSubnetTx, otherTx}, nil))
+	require.NoError(i.Accept(2, []*txs.Tx{exportTx}, nil))
+	writeBatch(t, i)
+
+	txIDs, cursor, err := i.Read(addr, nil, 0, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{createSubnetTx.ID(), exportTx.ID()}, txIDs)
+	require.Equal(uint64(2), cursor)
+
+	// Pagination
+	txIDs, cursor, err = i.Read(addr, nil, 0, 1)
+	require.NoError(err)
+	require.Equal([]ids.ID{createSubnetTx.ID()}, txIDs)
+	txIDs, cursor, err = i.Read(addr, nil, cursor, 1)
+	require.NoError(err)
+	require.Equal([]ids.ID{exportTx.ID()}, txIDs)
+	txIDs, _, err = i.Read(addr, nil, cursor, 1)
+	require.NoError(err)
+	require.Empty(txIDs)
+
+	// Type filter
+	txIDs, cursor, err = i.Read(addr, set.Of("ExportTx"), 0, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{exportTx.ID()}, txIDs)
+	require.Equal(uint64(2), cursor)
+}
+
+func TestIndexerIncomplete(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	_, err := NewIndexer(db, 1, false, "", prometheus.NewRegistry())
+	require.ErrorIs(err, ErrIncompleteIndex)
+
+	i, err := NewIndexer(db, 1, true, "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(i.Accept(2, nil, nil))
+	writeBatch(t, i)
+
+	_, err = NewIndexer(db, 2, false, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	// Uncommitted changes aren't persisted.
+	require.NoError(i.Accept(3, nil, nil))
+	i.Abort()
+	height, err := database.GetUInt64(db, heightKey)
+	require.NoError(err)
+	require.Equal(uint64(2), height)
+}
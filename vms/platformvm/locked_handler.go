@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+
+	stdjson "encoding/json"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// snapshotMethods are the API methods that are answered from the state
+// snapshot, so they are served without holding the chain's lock.
+//
+// The snapshot only holds the metadata of the state, so every other method is
+// served while holding the lock, even if it doesn't modify the state.
+var snapshotMethods = set.Of(
+	"platform.getHeight",
+	"platform.getCurrentSupply",
+	"platform.getTimestamp",
+)
+
+var _ http.Handler = (*lockedHandler)(nil)
+
+// lockedHandler serves API requests while holding [lock], except for the
+// requests to [snapshotMethods].
+type lockedHandler struct {
+	lock    sync.Locker
+	handler http.Handler
+}
+
+func (h *lockedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	// If the body isn't a valid request, the lock is held while the handler
+	// reports the error.
+	var request struct {
+		Method string `json:"method"`
+	}
+	if err := stdjson.Unmarshal(body, &request); err != nil || !snapshotMethods.Contains(request.Method) {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+	}
+	h.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockedHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		expectedLock bool
+	}{
+		{
+			name:         "snapshot method",
+			body:         `{"jsonrpc":"2.0","id":1,"method":"platform.getHeight","params":{}}`,
+			expectedLock: false,
+		},
+		{
+			name:         "state method",
+			body:         `{"jsonrpc":"2.0","id":1,"method":"platform.getBalance","params":{}}`,
+			expectedLock: true,
+		},
+		{
+			name:         "invalid request",
+			body:         `{`,
+			expectedLock: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			lock := &sync.Mutex{}
+			h := &lockedHandler{
+				lock: lock,
+				handler: http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+					// The body is still readable by the wrapped handler.
+					body, err := io.ReadAll(r.Body)
+					require.NoError(err)
+					require.Equal(test.body, string(body))
+
+					locked := !lock.TryLock()
+					if !locked {
+						lock.Unlock()
+					}
+					require.Equal(test.expectedLock, locked)
+				}),
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			h.ServeHTTP(httptest.NewRecorder(), r)
+		})
+	}
+}
@@ -5,9 +5,9 @@ package platformvm
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	stdjson "encoding/json"
@@ -16,8 +16,10 @@ import (
 	"go.uber.org/zap"
 
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/rpcerror"
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
@@ -55,6 +57,10 @@ const (
 	// Max number of addresses that can be passed in as argument to GetStake
 	maxGetStakeAddrs = 256
 
+	// Max number of transaction IDs that can be passed in as argument to
+	// GetRewardUTXOsBatch
+	maxGetRewardUTXOsTxIDs = 256
+
 	// Minimum amount of delay to allow a transaction to be issued through the
 	// API
 	minAddStakerDelay = 2 * executor.SyncBound
@@ -65,22 +71,27 @@ const (
 )
 
 var (
-	errMissingDecisionBlock     = errors.New("should have a decision block within the past two blocks")
-	errNoSubnetID               = errors.New("argument 'subnetID' not provided")
-	errNoRewardAddress          = errors.New("argument 'rewardAddress' not provided")
-	errInvalidDelegationRate    = errors.New("argument 'delegationFeeRate' must be between 0 and 100, inclusive")
-	errNoAddresses              = errors.New("no addresses provided")
-	errNoKeys                   = errors.New("user has no keys or funds")
-	errStartTimeTooSoon         = fmt.Errorf("start time must be at least %s in the future", minAddStakerDelay)
-	errStartTimeTooLate         = errors.New("start time is too far in the future")
-	errNamedSubnetCantBePrimary = errors.New("subnet validator attempts to validate primary network")
-	errNoAmount                 = errors.New("argument 'amount' must be > 0")
-	errMissingName              = errors.New("argument 'name' not given")
-	errMissingVMID              = errors.New("argument 'vmID' not given")
-	errMissingBlockchainID      = errors.New("argument 'blockchainID' not given")
-	errMissingPrivateKey        = errors.New("argument 'privateKey' not given")
-	errStartAfterEndTime        = errors.New("start time must be before end time")
-	errStartTimeInThePast       = errors.New("start time in the past")
+	errMissingDecisionBlock     = rpcerror.New(rpcerror.Internal, "should have a decision block within the past two blocks")
+	errNoSubnetID               = rpcerror.New(rpcerror.InvalidArgument, "argument 'subnetID' not provided")
+	errNoRewardAddress          = rpcerror.New(rpcerror.InvalidArgument, "argument 'rewardAddress' not provided")
+	errInvalidDelegationRate    = rpcerror.New(rpcerror.InvalidArgument, "argument 'delegationFeeRate' must be between 0 and 100, inclusive")
+	errNoAddresses              = rpcerror.New(rpcerror.InvalidArgument, "no addresses provided")
+	errNoTxIDs                  = rpcerror.New(rpcerror.InvalidArgument, "no txIDs provided")
+	errTooManyTxIDs             = rpcerror.New(rpcerror.InvalidArgument, fmt.Sprintf("at most %d txIDs can be provided", maxGetRewardUTXOsTxIDs))
+	errNoKeys                   = rpcerror.New(rpcerror.InsufficientFunds, "user has no keys or funds")
+	errStartTimeTooSoon         = rpcerror.New(rpcerror.InvalidArgument, fmt.Sprintf("start time must be at least %s in the future", minAddStakerDelay))
+	errStartTimeTooLate         = rpcerror.New(rpcerror.InvalidArgument, "start time is too far in the future")
+	errNamedSubnetCantBePrimary = rpcerror.New(rpcerror.InvalidArgument, "subnet validator attempts to validate primary network")
+	errNoAmount                 = rpcerror.New(rpcerror.InvalidArgument, "argument 'amount' must be > 0")
+	errMissingName              = rpcerror.New(rpcerror.InvalidArgument, "argument 'name' not given")
+	errMissingVMID              = rpcerror.New(rpcerror.InvalidArgument, "argument 'vmID' not given")
+	errMissingBlockchainID      = rpcerror.New(rpcerror.InvalidArgument, "argument 'blockchainID' not given")
+	errMissingPrivateKey        = rpcerror.New(rpcerror.InvalidArgument, "argument 'privateKey' not given")
+	errStartAfterEndTime        = rpcerror.New(rpcerror.InvalidArgument, "start time must be before end time")
+	errStartTimeInThePast       = rpcerror.New(rpcerror.InvalidArgument, "start time in the past")
+	errPrimaryNetworkNotSubnet  = rpcerror.New(rpcerror.InvalidArgument, "the primary network doesn't have a subnet owner")
+	errAddressTxsIndexDisabled  = rpcerror.New(rpcerror.FailedPrecondition, "address transaction indexing is disabled")
+	errPermissionedValidator    = rpcerror.New(rpcerror.FailedPrecondition, "permissioned validators don't accept delegations")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -106,6 +117,14 @@ func (s *Service) GetHeight(r *http.Request, _ *struct{}, response *api.GetHeigh
 		zap.String("method", "getHeight"),
 	)
 
+	if snapshot := s.vm.state.Snapshot(); snapshot != nil {
+		response.Height = json.Uint64(snapshot.Height)
+		return nil
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
 	ctx := r.Context()
 	height, err := s.vm.GetCurrentHeight(ctx)
 	response.Height = json.Uint64(height)
@@ -633,6 +652,140 @@ func (s *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, response *Ge
 	return nil
 }
 
+// GetSubnetOwnerArgs are the arguments to GetSubnetOwner
+type GetSubnetOwnerArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// GetSubnetOwnerReply is the response from calling GetSubnetOwner
+type GetSubnetOwnerReply struct {
+	// Owner of the subnet. Signatures from [Owner.Threshold] of
+	// [Owner.Addresses] are required to authorize subnet transactions once
+	// [Owner.Locktime] has passed.
+	Owner platformapi.Owner `json:"owner"`
+	// Permissionless is true if the subnet was transformed into a
+	// permissionless subnet. The owner of a permissionless subnet can no
+	// longer manage its validator set.
+	Permissionless bool `json:"permissionless"`
+}
+
+// GetSubnetOwner returns the owner of the subnet with ID [args.SubnetID]
+func (s *Service) GetSubnetOwner(_ *http.Request, args *GetSubnetOwnerArgs, reply *GetSubnetOwnerReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getSubnetOwner"),
+		logging.UserString("subnetID", args.SubnetID.String()),
+	)
+
+	owner, err := s.getSubnetOwner(args.SubnetID)
+	if err != nil {
+		return err
+	}
+
+	reply.Owner = platformapi.Owner{
+		Locktime:  json.Uint64(owner.Locktime),
+		Threshold: json.Uint32(owner.Threshold),
+		Addresses: make([]string, len(owner.Addrs)),
+	}
+	for i, addr := range owner.Addrs {
+		reply.Owner.Addresses[i], err = s.addrManager.FormatLocalAddress(addr)
+		if err != nil {
+			return fmt.Errorf("problem formatting address: %w", err)
+		}
+	}
+
+	_, err = s.vm.state.GetSubnetTransformation(args.SubnetID)
+	switch err {
+	case nil:
+		reply.Permissionless = true
+	case database.ErrNotFound:
+	default:
+		return fmt.Errorf("failed fetching subnet transformation for %s: %w", args.SubnetID, err)
+	}
+	return nil
+}
+
+// VerifySubnetAuthArgs are the arguments to VerifySubnetAuth
+type VerifySubnetAuthArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+	// Addresses of the keys that are available to sign
+	Addresses []string `json:"addresses"`
+}
+
+// VerifySubnetAuthReply is the response from calling VerifySubnetAuth
+type VerifySubnetAuthReply struct {
+	// Authorized is true if the provided addresses can authorize subnet
+	// transactions at the current chain time.
+	Authorized bool `json:"authorized"`
+	// SigIndices are the indices into the subnet owner's addresses of the
+	// keys that should sign the subnet auth. Only populated if [Authorized].
+	SigIndices []json.Uint32 `json:"sigIndices"`
+	// Threshold is the number of signatures that are required.
+	Threshold json.Uint32 `json:"threshold"`
+	// NumMatched is the number of subnet owner addresses that were provided.
+	NumMatched json.Uint32 `json:"numMatched"`
+	// Locktime of the subnet owner. The subnet can't be authorized before
+	// this time.
+	Locktime json.Uint64 `json:"locktime"`
+}
+
+// VerifySubnetAuth checks whether signatures from the keys controlling
+// [args.Addresses] are sufficient to authorize transactions on the subnet with
+// ID [args.SubnetID].
+func (s *Service) VerifySubnetAuth(_ *http.Request, args *VerifySubnetAuthArgs, reply *VerifySubnetAuthReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "verifySubnetAuth"),
+		logging.UserString("subnetID", args.SubnetID.String()),
+	)
+
+	addrs, err := avax.ParseLocalAddresses(s.addrManager, args.Addresses)
+	if err != nil {
+		return err
+	}
+
+	owner, err := s.getSubnetOwner(args.SubnetID)
+	if err != nil {
+		return err
+	}
+
+	sigIndices := make([]json.Uint32, 0, owner.Threshold)
+	for i, addr := range owner.Addrs {
+		if addrs.Contains(addr) {
+			sigIndices = append(sigIndices, json.Uint32(i))
+		}
+	}
+
+	reply.Threshold = json.Uint32(owner.Threshold)
+	reply.NumMatched = json.Uint32(len(sigIndices))
+	reply.Locktime = json.Uint64(owner.Locktime)
+
+	currentTime := uint64(s.vm.state.GetTimestamp().Unix())
+	reply.Authorized = owner.Locktime <= currentTime && uint32(len(sigIndices)) >= owner.Threshold
+	if reply.Authorized {
+		reply.SigIndices = sigIndices[:owner.Threshold]
+	}
+	return nil
+}
+
+// getSubnetOwner returns the owner of [subnetID].
+func (s *Service) getSubnetOwner(subnetID ids.ID) (*secp256k1fx.OutputOwners, error) {
+	if subnetID == constants.PrimaryNetworkID {
+		return nil, errPrimaryNetworkNotSubnet
+	}
+
+	subnetOwner, err := s.vm.state.GetSubnetOwner(subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnetOwner)
+	}
+	return owner, nil
+}
+
 // GetStakingAssetIDArgs are the arguments to GetStakingAssetID
 type GetStakingAssetIDArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -676,6 +829,138 @@ func (s *Service) GetStakingAssetID(_ *http.Request, args *GetStakingAssetIDArgs
 	return nil
 }
 
+// GetSubnetTransformationArgs are the arguments to GetSubnetTransformation
+type GetSubnetTransformationArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// SubnetTransformation describes the transformation of a subnet into a
+// permissionless subnet.
+type SubnetTransformation struct {
+	// TxID is the ID of the TransformSubnetTx that transformed the subnet.
+	TxID                     ids.ID      `json:"txID"`
+	AssetID                  ids.ID      `json:"assetID"`
+	InitialSupply            json.Uint64 `json:"initialSupply"`
+	MaximumSupply            json.Uint64 `json:"maximumSupply"`
+	CurrentSupply            json.Uint64 `json:"currentSupply"`
+	MinConsumptionRate       json.Uint64 `json:"minConsumptionRate"`
+	MaxConsumptionRate       json.Uint64 `json:"maxConsumptionRate"`
+	MinValidatorStake        json.Uint64 `json:"minValidatorStake"`
+	MaxValidatorStake        json.Uint64 `json:"maxValidatorStake"`
+	MinStakeDuration         json.Uint32 `json:"minStakeDuration"`
+	MaxStakeDuration         json.Uint32 `json:"maxStakeDuration"`
+	MinDelegationFee         json.Uint32 `json:"minDelegationFee"`
+	MinDelegatorStake        json.Uint64 `json:"minDelegatorStake"`
+	MaxValidatorWeightFactor json.Uint8  `json:"maxValidatorWeightFactor"`
+	UptimeRequirement        json.Uint32 `json:"uptimeRequirement"`
+}
+
+// GetSubnetTransformationReply is the response from calling
+// GetSubnetTransformation
+type GetSubnetTransformationReply struct {
+	// Owner of the subnet. Once the subnet is transformed, the owner can no
+	// longer add validators, but can still remove the permissioned ones.
+	Owner platformapi.Owner `json:"owner"`
+	// Transformation is nil if the subnet wasn't transformed.
+	Transformation *SubnetTransformation `json:"transformation,omitempty"`
+	// PermissionedValidators is the number of current and pending validators
+	// that were added by the owner. After the transformation, they keep
+	// validating until their end time unless the owner removes them.
+	PermissionedValidators json.Uint32 `json:"permissionedValidators"`
+	// PermissionlessValidators is the number of current and pending validators
+	// that staked the subnet's asset.
+	PermissionlessValidators json.Uint32 `json:"permissionlessValidators"`
+}
+
+// GetSubnetTransformation returns the transformation status of the subnet with
+// ID [args.SubnetID], so that the migration of its validator set can be
+// tracked.
+func (s *Service) GetSubnetTransformation(_ *http.Request, args *GetSubnetTransformationArgs, reply *GetSubnetTransformationReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getSubnetTransformation"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	owner, err := s.getSubnetOwner(args.SubnetID)
+	if err != nil {
+		return err
+	}
+	apiOwner, err := s.getAPIOwner(owner)
+	if err != nil {
+		return fmt.Errorf("problem formatting address: %w", err)
+	}
+	reply.Owner = *apiOwner
+
+	transformSubnetIntf, err := s.vm.state.GetSubnetTransformation(args.SubnetID)
+	switch err {
+	case nil:
+		transformSubnet, ok := transformSubnetIntf.Unsigned.(*txs.TransformSubnetTx)
+		if !ok {
+			return fmt.Errorf(
+				"unexpected subnet transformation tx type fetched %T",
+				transformSubnetIntf.Unsigned,
+			)
+		}
+		currentSupply, err := s.vm.state.GetCurrentSupply(args.SubnetID)
+		if err != nil {
+			return err
+		}
+		reply.Transformation = &SubnetTransformation{
+			TxID:                     transformSubnetIntf.ID(),
+			AssetID:                  transformSubnet.AssetID,
+			InitialSupply:            json.Uint64(transformSubnet.InitialSupply),
+			MaximumSupply:            json.Uint64(transformSubnet.MaximumSupply),
+			CurrentSupply:            json.Uint64(currentSupply),
+			MinConsumptionRate:       json.Uint64(transformSubnet.MinConsumptionRate),
+			MaxConsumptionRate:       json.Uint64(transformSubnet.MaxConsumptionRate),
+			MinValidatorStake:        json.Uint64(transformSubnet.MinValidatorStake),
+			MaxValidatorStake:        json.Uint64(transformSubnet.MaxValidatorStake),
+			MinStakeDuration:         json.Uint32(transformSubnet.MinStakeDuration),
+			MaxStakeDuration:         json.Uint32(transformSubnet.MaxStakeDuration),
+			MinDelegationFee:         json.Uint32(transformSubnet.MinDelegationFee),
+			MinDelegatorStake:        json.Uint64(transformSubnet.MinDelegatorStake),
+			MaxValidatorWeightFactor: json.Uint8(transformSubnet.MaxValidatorWeightFactor),
+			UptimeRequirement:        json.Uint32(transformSubnet.UptimeRequirement),
+		}
+	case database.ErrNotFound:
+	default:
+		return fmt.Errorf("failed fetching subnet transformation for %s: %w", args.SubnetID, err)
+	}
+
+	currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
+	if err != nil {
+		return err
+	}
+	s.countSubnetValidators(args.SubnetID, currentStakerIterator, reply)
+
+	pendingStakerIterator, err := s.vm.state.GetPendingStakerIterator()
+	if err != nil {
+		return err
+	}
+	s.countSubnetValidators(args.SubnetID, pendingStakerIterator, reply)
+	return nil
+}
+
+// countSubnetValidators adds the validators of [subnetID] in [stakers] to
+// [reply] and releases [stakers].
+func (*Service) countSubnetValidators(subnetID ids.ID, stakers state.StakerIterator, reply *GetSubnetTransformationReply) {
+	defer stakers.Release()
+
+	for stakers.Next() {
+		staker := stakers.Value()
+		if staker.SubnetID != subnetID {
+			continue
+		}
+		switch {
+		case staker.Priority.IsPermissionedValidator():
+			reply.PermissionedValidators++
+		case staker.Priority.IsValidator():
+			reply.PermissionlessValidators++
+		}
+	}
+}
+
 /*
  ******************************************************
  **************** Get/Sample Validators ***************
@@ -1081,6 +1366,16 @@ func (s *Service) GetCurrentSupply(r *http.Request, args *GetCurrentSupplyArgs,
 		zap.String("method", "getCurrentSupply"),
 	)
 
+	// The supplies of subnets aren't included in the snapshot.
+	if snapshot := s.vm.state.Snapshot(); snapshot != nil && args.SubnetID == constants.PrimaryNetworkID {
+		reply.Supply = json.Uint64(snapshot.CurrentSupply)
+		reply.Height = json.Uint64(snapshot.Height)
+		return nil
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
 	supply, err := s.vm.state.GetCurrentSupply(args.SubnetID)
 	if err != nil {
 		return fmt.Errorf("fetching current supply failed: %w", err)
@@ -1237,14 +1532,14 @@ func (s *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, reply *a
 	}
 
 	// Create the transaction
-	tx, err := s.vm.txBuilder.NewAddValidatorTx(
+	tx, err := s.vm.txBuilder.NewAddValidatorTxWithKeychain(
 		uint64(args.Weight),                  // Stake amount
 		uint64(args.StartTime),               // Start time
 		uint64(args.EndTime),                 // End time
 		nodeID,                               // Node ID
 		rewardAddress,                        // Reward Address
 		uint32(10000*args.DelegationFeeRate), // Shares
-		privKeys.Keys,                        // Keys providing the staked tokens
+		privKeys,                             // Keys providing the staked tokens
 		changeAddr,
 	)
 	if err != nil {
@@ -1347,13 +1642,13 @@ func (s *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, reply *a
 	}
 
 	// Create the transaction
-	tx, err := s.vm.txBuilder.NewAddDelegatorTx(
+	tx, err := s.vm.txBuilder.NewAddDelegatorTxWithKeychain(
 		uint64(args.Weight),    // Stake amount
 		uint64(args.StartTime), // Start time
 		uint64(args.EndTime),   // End time
 		nodeID,                 // Node ID
 		rewardAddress,          // Reward Address
-		privKeys.Keys,          // Private keys
+		privKeys,               // Private keys
 		changeAddr,             // Change address
 	)
 	if err != nil {
@@ -1452,13 +1747,13 @@ func (s *Service) AddSubnetValidator(_ *http.Request, args *AddSubnetValidatorAr
 	}
 
 	// Create the transaction
-	tx, err := s.vm.txBuilder.NewAddSubnetValidatorTx(
+	tx, err := s.vm.txBuilder.NewAddSubnetValidatorTxWithKeychain(
 		uint64(args.Weight),    // Stake amount
 		uint64(args.StartTime), // Start time
 		uint64(args.EndTime),   // End time
 		args.NodeID,            // Node ID
 		subnetID,               // Subnet ID
-		keys.Keys,
+		keys,
 		changeAddr,
 	)
 	if err != nil {
@@ -1530,10 +1825,10 @@ func (s *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, response
 	}
 
 	// Create the transaction
-	tx, err := s.vm.txBuilder.NewCreateSubnetTx(
+	tx, err := s.vm.txBuilder.NewCreateSubnetTxWithKeychain(
 		uint32(args.Threshold), // Threshold
 		controlKeys.List(),     // Control Addresses
-		privKeys.Keys,          // Private keys
+		privKeys,               // Private keys
 		changeAddr,
 	)
 	if err != nil {
@@ -1624,11 +1919,11 @@ func (s *Service) ExportAVAX(_ *http.Request, args *ExportAVAXArgs, response *ap
 	}
 
 	// Create the transaction
-	tx, err := s.vm.txBuilder.NewExportTx(
+	tx, err := s.vm.txBuilder.NewExportTxWithKeychain(
 		uint64(args.Amount), // Amount
 		chainID,             // ID of the chain to send the funds to
 		to,                  // Address
-		privKeys.Keys,       // Private keys
+		privKeys,            // Private keys
 		changeAddr,          // Change address
 	)
 	if err != nil {
@@ -1709,10 +2004,10 @@ func (s *Service) ImportAVAX(_ *http.Request, args *ImportAVAXArgs, response *ap
 		}
 	}
 
-	tx, err := s.vm.txBuilder.NewImportTx(
+	tx, err := s.vm.txBuilder.NewImportTxWithKeychain(
 		chainID,
 		to,
-		privKeys.Keys,
+		privKeys,
 		changeAddr,
 	)
 	if err != nil {
@@ -1830,13 +2125,13 @@ func (s *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchainArgs,
 	}
 
 	// Create the transaction
-	tx, err := s.vm.txBuilder.NewCreateChainTx(
+	tx, err := s.vm.txBuilder.NewCreateChainTxWithKeychain(
 		args.SubnetID,
 		genesisBytes,
 		vmID,
 		fxIDs,
 		args.Name,
-		keys.Keys,
+		keys,
 		changeAddr, // Change address
 	)
 	if err != nil {
@@ -2240,10 +2535,99 @@ func (s *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, response *
 	return nil
 }
 
+type GetTxsByAddressArgs struct {
+	api.JSONAddress
+	// TxTypes to return, e.g. "AddValidatorTx". If empty, all types are
+	// returned.
+	TxTypes []string `json:"txTypes"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+type GetTxsByAddressReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor to provide to read the next page
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetTxsByAddress returns the IDs of the accepted txs that involved an
+// address, in order of acceptance
+func (s *Service) GetTxsByAddress(_ *http.Request, args *GetTxsByAddressArgs, reply *GetTxsByAddressReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getTxsByAddress"),
+		logging.UserString("address", args.Address),
+	)
+
+	if s.vm.addressTxsIndexer == nil {
+		return errAddressTxsIndexDisabled
+	}
+
+	addr, err := avax.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+
+	pageSize := uint64(args.PageSize)
+	if pageSize == 0 || pageSize > builder.MaxPageSize {
+		pageSize = builder.MaxPageSize
+	}
+
+	txIDs, cursor, err := s.vm.addressTxsIndexer.Read(
+		addr,
+		set.Of(args.TxTypes...),
+		uint64(args.Cursor),
+		pageSize,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't read txs of %s: %w", args.Address, err)
+	}
+
+	reply.TxIDs = txIDs
+	reply.Cursor = json.Uint64(cursor)
+	return nil
+}
+
 type GetStakeArgs struct {
 	api.JSONAddresses
 	ValidatorsOnly bool                `json:"validatorsOnly"`
 	Encoding       formatting.Encoding `json:"encoding"`
+	// If true, the reply includes a per-UTXO breakdown of the staked outputs.
+	IncludeUTXOs bool `json:"includeUTXOs"`
+	// Maximum number of staked UTXOs to return. If 0 or greater than the
+	// maximum page size, the maximum page size is used.
+	Limit json.Uint32 `json:"limit"`
+	// If non-empty, only staked UTXOs with an ID greater than [StartUTXOID]
+	// are returned. This should be set to the [EndUTXOID] of the previous
+	// page.
+	StartUTXOID ids.ID `json:"startUTXOID"`
+}
+
+// StakedUTXO describes a staked output that will be returned to its owners
+// once the staking period ends.
+type StakedUTXO struct {
+	// ID of the UTXO that is created when the stake is returned
+	UTXOID      ids.ID      `json:"utxoID"`
+	TxID        ids.ID      `json:"txID"`
+	OutputIndex json.Uint32 `json:"outputIndex"`
+	AssetID     ids.ID      `json:"assetID"`
+	Amount      json.Uint64 `json:"amount"`
+	// Time until which the output could only be used for staking. 0 if the
+	// output wasn't stakeable locked.
+	StakeableLocktime json.Uint64 `json:"stakeableLocktime"`
+	// Time until which the returned output can't be spent.
+	Locktime  json.Uint64 `json:"locktime"`
+	Threshold json.Uint32 `json:"threshold"`
+	Addresses []string    `json:"addresses"`
+
+	NodeID      ids.NodeID  `json:"nodeID"`
+	SubnetID    ids.ID      `json:"subnetID"`
+	IsValidator bool        `json:"isValidator"`
+	Pending     bool        `json:"pending"`
+	StartTime   json.Uint64 `json:"startTime"`
+	EndTime     json.Uint64 `json:"endTime"`
 }
 
 // GetStakeReply is the response from calling GetStake.
@@ -2255,6 +2639,12 @@ type GetStakeReply struct {
 	Outputs []string `json:"stakedOutputs"`
 	// Encoding of [Outputs]
 	Encoding formatting.Encoding `json:"encoding"`
+	// Staked UTXOs sorted by their UTXO ID. Only populated if
+	// [GetStakeArgs.IncludeUTXOs] is true.
+	StakedUTXOs []StakedUTXO `json:"stakedUTXOs,omitempty"`
+	// UTXO ID of the last element of [StakedUTXOs]. If fewer than the
+	// requested number of UTXOs were returned, there are no more pages.
+	EndUTXOID ids.ID `json:"endUTXOID"`
 }
 
 // GetStake returns the amount of nAVAX that [args.Addresses] have cumulatively
@@ -2289,6 +2679,7 @@ func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetSta
 	var (
 		totalAmountStaked = make(map[ids.ID]uint64)
 		stakedOuts        []avax.TransferableOutput
+		stakedUTXOs       []StakedUTXO
 	)
 	for currentStakerIterator.Next() { // Iterates over current stakers
 		staker := currentStakerIterator.Value()
@@ -2303,6 +2694,13 @@ func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetSta
 		}
 
 		stakedOuts = append(stakedOuts, getStakeHelper(tx, addrs, totalAmountStaked)...)
+		if args.IncludeUTXOs {
+			utxos, err := s.getStakedUTXOs(staker, tx, addrs, false)
+			if err != nil {
+				return err
+			}
+			stakedUTXOs = append(stakedUTXOs, utxos...)
+		}
 	}
 
 	pendingStakerIterator, err := s.vm.state.GetPendingStakerIterator()
@@ -2324,6 +2722,25 @@ func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetSta
 		}
 
 		stakedOuts = append(stakedOuts, getStakeHelper(tx, addrs, totalAmountStaked)...)
+		if args.IncludeUTXOs {
+			utxos, err := s.getStakedUTXOs(staker, tx, addrs, true)
+			if err != nil {
+				return err
+			}
+			stakedUTXOs = append(stakedUTXOs, utxos...)
+		}
+	}
+
+	if args.IncludeUTXOs {
+		limit := int(args.Limit)
+		if limit <= 0 || builder.MaxPageSize < limit {
+			limit = builder.MaxPageSize
+		}
+		response.StakedUTXOs = paginateStakedUTXOs(stakedUTXOs, args.StartUTXOID, limit)
+		response.EndUTXOID = args.StartUTXOID
+		if numUTXOs := len(response.StakedUTXOs); numUTXOs > 0 {
+			response.EndUTXOID = response.StakedUTXOs[numUTXOs-1].UTXOID
+		}
 	}
 
 	response.Stakeds = newJSONBalanceMap(totalAmountStaked)
@@ -2476,6 +2893,203 @@ func (s *Service) GetMaxStakeAmount(_ *http.Request, args *GetMaxStakeAmountArgs
 	return err
 }
 
+// GetDelegationStatsArgs are the arguments for calling GetDelegationStats
+type GetDelegationStatsArgs struct {
+	// Subnet the node validates
+	// If omitted, defaults to primary network
+	SubnetID ids.ID     `json:"subnetID"`
+	NodeID   ids.NodeID `json:"nodeID"`
+}
+
+// DelegationStats describes how much more stake can be delegated to a
+// validator.
+type DelegationStats struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Weight is the stake of the validator, excluding its delegations.
+	Weight json.Uint64 `json:"weight"`
+	// DelegatedWeight is the total stake of the current delegators.
+	DelegatedWeight json.Uint64 `json:"delegatedWeight"`
+	// DelegatorCount is the number of current delegators.
+	DelegatorCount json.Uint64 `json:"delegatorCount"`
+	// MaxWeight is the maximum total weight, including its own weight, that
+	// delegations are allowed to bring the validator to.
+	MaxWeight json.Uint64 `json:"maxWeight"`
+	// RemainingCapacity is the maximum weight of a delegation that lasts until
+	// the end of the validation period, taking pending delegators into
+	// account.
+	RemainingCapacity json.Uint64 `json:"remainingCapacity"`
+	// DelegationFee is the percentage of the delegators' rewards kept by the
+	// validator.
+	DelegationFee json.Float32 `json:"delegationFee"`
+	EndTime       json.Uint64  `json:"endTime"`
+	// TimeRemaining is the number of seconds until the validation period ends.
+	TimeRemaining json.Uint64 `json:"timeRemaining"`
+}
+
+// GetDelegationStatsReply is the response from calling GetDelegationStats.
+type GetDelegationStatsReply struct {
+	DelegationStats
+}
+
+// GetDelegationStats returns how much more stake can be delegated to the
+// current validator [args.NodeID].
+func (s *Service) GetDelegationStats(_ *http.Request, args *GetDelegationStatsArgs, reply *GetDelegationStatsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getDelegationStats"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	validator, err := s.vm.state.GetCurrentValidator(args.SubnetID, args.NodeID)
+	if err == database.ErrNotFound {
+		return rpcerror.New(
+			rpcerror.NotFound,
+			fmt.Sprintf("%s isn't a current validator of %s", args.NodeID, args.SubnetID),
+		)
+	}
+	if err != nil {
+		return err
+	}
+	if validator.Priority.IsPermissionedValidator() {
+		return errPermissionedValidator
+	}
+
+	stats, err := s.getDelegationStats(validator)
+	if err != nil {
+		return err
+	}
+	reply.DelegationStats = *stats
+	return nil
+}
+
+// ListDelegationStatsArgs are the arguments for calling ListDelegationStats
+type ListDelegationStatsArgs struct {
+	// Subnet we're listing the validators of
+	// If omitted, defaults to primary network
+	SubnetID ids.ID `json:"subnetID"`
+	// Limit is the maximum number of validators to return. If 0, all the
+	// validators are returned.
+	Limit json.Uint32 `json:"limit"`
+}
+
+// ListDelegationStatsReply is the response from calling ListDelegationStats.
+type ListDelegationStatsReply struct {
+	Validators []DelegationStats `json:"validators"`
+}
+
+// ListDelegationStats returns the delegation stats of the current
+// permissionless validators of [args.SubnetID], sorted by decreasing remaining
+// capacity.
+func (s *Service) ListDelegationStats(_ *http.Request, args *ListDelegationStatsArgs, reply *ListDelegationStatsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "listDelegationStats"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
+	if err != nil {
+		return err
+	}
+	var validators []*state.Staker
+	for currentStakerIterator.Next() {
+		staker := currentStakerIterator.Value()
+		if staker.SubnetID != args.SubnetID ||
+			!staker.Priority.IsCurrentValidator() ||
+			staker.Priority.IsPermissionedValidator() {
+			continue
+		}
+		validators = append(validators, staker)
+	}
+	currentStakerIterator.Release()
+
+	reply.Validators = make([]DelegationStats, 0, len(validators))
+	for _, validator := range validators {
+		stats, err := s.getDelegationStats(validator)
+		if err != nil {
+			return err
+		}
+		reply.Validators = append(reply.Validators, *stats)
+	}
+
+	slices.SortFunc(reply.Validators, func(a, b DelegationStats) int {
+		switch {
+		case a.RemainingCapacity > b.RemainingCapacity:
+			return -1
+		case a.RemainingCapacity < b.RemainingCapacity:
+			return 1
+		case a.NodeID.Less(b.NodeID):
+			return -1
+		case b.NodeID.Less(a.NodeID):
+			return 1
+		default:
+			return 0
+		}
+	})
+	if args.Limit > 0 && int(args.Limit) < len(reply.Validators) {
+		reply.Validators = reply.Validators[:args.Limit]
+	}
+	return nil
+}
+
+// Invariant: [validator] is a current permissionless validator.
+func (s *Service) getDelegationStats(validator *state.Staker) (*DelegationStats, error) {
+	attr, err := s.loadStakerTxAttributes(validator.TxID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &DelegationStats{
+		NodeID:        validator.NodeID,
+		Weight:        json.Uint64(validator.Weight),
+		DelegationFee: json.Float32(100 * float32(attr.shares) / float32(reward.PercentDenominator)),
+		EndTime:       json.Uint64(validator.EndTime.Unix()),
+	}
+
+	delegatorIterator, err := s.vm.state.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	var delegatedWeight uint64
+	for delegatorIterator.Next() {
+		delegatedWeight, err = math.Add64(delegatedWeight, delegatorIterator.Value().Weight)
+		if err != nil {
+			delegatorIterator.Release()
+			return nil, err
+		}
+		stats.DelegatorCount++
+	}
+	delegatorIterator.Release()
+	stats.DelegatedWeight = json.Uint64(delegatedWeight)
+
+	maxWeight, err := executor.GetMaxValidatorWeight(&s.vm.Config, s.vm.state, validator)
+	if err != nil {
+		return nil, err
+	}
+	stats.MaxWeight = json.Uint64(maxWeight)
+
+	now := s.vm.state.GetTimestamp()
+	if !now.Before(validator.EndTime) {
+		// The validation period is over, so delegations are no longer
+		// accepted.
+		return stats, nil
+	}
+	stats.TimeRemaining = json.Uint64(validator.EndTime.Sub(now) / time.Second)
+
+	// Any delegation must be bounded by the validation period, so the weight
+	// between now and the end of the period bounds the weight that can be
+	// added.
+	weight, err := executor.GetMaxWeight(s.vm.state, validator, now, validator.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if weight < maxWeight {
+		stats.RemainingCapacity = json.Uint64(maxWeight - weight)
+	}
+	return stats, nil
+}
+
 // GetRewardUTXOsReply defines the GetRewardUTXOs replies returned from the API
 type GetRewardUTXOsReply struct {
 	// Number of UTXOs returned
@@ -2517,6 +3131,111 @@ func (s *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *Ge
 	return nil
 }
 
+// GetRewardUTXOsBatchArgs are the arguments for GetRewardUTXOsBatch
+type GetRewardUTXOsBatchArgs struct {
+	TxIDs    []ids.ID            `json:"txIDs"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// RewardUTXO is a UTXO that was rewarded to a staker, along with its decoded
+// amount and owners.
+type RewardUTXO struct {
+	// The UTXO in the requested encoding
+	UTXO    string      `json:"utxo"`
+	AssetID ids.ID      `json:"assetID"`
+	Amount  json.Uint64 `json:"amount"`
+	// Owner is nil if the UTXO isn't a secp256k1fx transfer output
+	Owner *platformapi.Owner `json:"owner,omitempty"`
+}
+
+// TxRewardUTXOs are the UTXOs that were rewarded after the staking period of
+// a transaction ended.
+type TxRewardUTXOs struct {
+	TxID  ids.ID       `json:"txID"`
+	UTXOs []RewardUTXO `json:"utxos"`
+	// Error is set if the reward UTXOs of the transaction couldn't be fetched
+	Error string `json:"error,omitempty"`
+}
+
+// GetRewardUTXOsBatchReply defines the GetRewardUTXOsBatch replies returned
+// from the API
+type GetRewardUTXOsBatchReply struct {
+	// Results are in the order of the provided transaction IDs
+	Results []TxRewardUTXOs `json:"results"`
+	// Encoding specifies the encoding format the UTXOs are returned in
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetRewardUTXOsBatch returns the UTXOs that were rewarded after the staking
+// periods of the provided transactions ended. The reward UTXOs of each
+// transaction are fetched independently, so a failure to fetch the UTXOs of
+// one transaction is reported in its result rather than failing the request.
+func (s *Service) GetRewardUTXOsBatch(_ *http.Request, args *GetRewardUTXOsBatchArgs, reply *GetRewardUTXOsBatchReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getRewardUTXOsBatch"),
+		zap.Int("numTxIDs", len(args.TxIDs)),
+	)
+
+	switch {
+	case len(args.TxIDs) == 0:
+		return errNoTxIDs
+	case len(args.TxIDs) > maxGetRewardUTXOsTxIDs:
+		return errTooManyTxIDs
+	}
+
+	reply.Results = make([]TxRewardUTXOs, len(args.TxIDs))
+	for i, txID := range args.TxIDs {
+		result := &reply.Results[i]
+		result.TxID = txID
+
+		utxos, err := s.getRewardUTXOs(txID, args.Encoding)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		result.UTXOs = utxos
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+func (s *Service) getRewardUTXOs(txID ids.ID, encoding formatting.Encoding) ([]RewardUTXO, error) {
+	utxos, err := s.vm.state.GetRewardUTXOs(txID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get reward UTXOs: %w", err)
+	}
+
+	rewardUTXOs := make([]RewardUTXO, len(utxos))
+	for i, utxo := range utxos {
+		utxoBytes, err := txs.GenesisCodec.Marshal(txs.Version, utxo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode UTXO to bytes: %w", err)
+		}
+
+		utxoStr, err := formatting.Encode(encoding, utxoBytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't encode utxo as %s: %w", encoding, err)
+		}
+
+		rewardUTXO := RewardUTXO{
+			UTXO:    utxoStr,
+			AssetID: utxo.AssetID(),
+		}
+		if out, ok := utxo.Out.(avax.Amounter); ok {
+			rewardUTXO.Amount = json.Uint64(out.Amount())
+		}
+		if out, ok := utxo.Out.(*secp256k1fx.TransferOutput); ok {
+			rewardUTXO.Owner, err = s.getAPIOwner(&out.OutputOwners)
+			if err != nil {
+				return nil, err
+			}
+		}
+		rewardUTXOs[i] = rewardUTXO
+	}
+	return rewardUTXOs, nil
+}
+
 // GetTimestampReply is the response from GetTimestamp
 type GetTimestampReply struct {
 	// Current timestamp
@@ -2530,6 +3249,14 @@ func (s *Service) GetTimestamp(_ *http.Request, _ *struct{}, reply *GetTimestamp
 		zap.String("method", "getTimestamp"),
 	)
 
+	if snapshot := s.vm.state.Snapshot(); snapshot != nil {
+		reply.Timestamp = snapshot.Timestamp
+		return nil
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
 	reply.Timestamp = s.vm.state.GetTimestamp()
 	return nil
 }
@@ -2624,6 +3351,68 @@ func (s *Service) GetValidatorsAt(r *http.Request, args *GetValidatorsAtArgs, re
 	return nil
 }
 
+// GetCanonicalValidatorSetArgs are the arguments for GetCanonicalValidatorSet
+type GetCanonicalValidatorSetArgs struct {
+	Height   json.Uint64 `json:"height"`
+	SubnetID ids.ID      `json:"subnetID"`
+}
+
+// CanonicalValidator is a unique BLS public key in a canonical validator set
+type CanonicalValidator struct {
+	PublicKey string       `json:"publicKey"`
+	Weight    json.Uint64  `json:"weight"`
+	NodeIDs   []ids.NodeID `json:"nodeIDs"`
+}
+
+// GetCanonicalValidatorSetReply is the response from GetCanonicalValidatorSet
+type GetCanonicalValidatorSetReply struct {
+	// Validators with BLS public keys, in the order used by warp signatures
+	Validators []CanonicalValidator `json:"validators"`
+	// TotalWeight of the subnet, including validators without BLS public keys
+	TotalWeight json.Uint64 `json:"totalWeight"`
+	// AggregatePublicKey of [Validators], or nil if [Validators] is empty
+	AggregatePublicKey *string `json:"aggregatePublicKey"`
+}
+
+// GetCanonicalValidatorSet returns the validator set of a provided subnet at
+// the specified height in the form used to verify warp messages.
+func (s *Service) GetCanonicalValidatorSet(r *http.Request, args *GetCanonicalValidatorSetArgs, reply *GetCanonicalValidatorSetReply) error {
+	height := uint64(args.Height)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getCanonicalValidatorSet"),
+		zap.Uint64("height", height),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	vdrSet, err := s.vm.warpValidators.GetCanonicalValidatorSet(r.Context(), height, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("failed to get canonical validator set: %w", err)
+	}
+
+	reply.Validators = make([]CanonicalValidator, len(vdrSet.Validators))
+	for i, vdr := range vdrSet.Validators {
+		pk, err := formatting.Encode(formatting.HexNC, vdr.PublicKeyBytes)
+		if err != nil {
+			return err
+		}
+		reply.Validators[i] = CanonicalValidator{
+			PublicKey: pk,
+			Weight:    json.Uint64(vdr.Weight),
+			NodeIDs:   vdr.NodeIDs,
+		}
+	}
+	reply.TotalWeight = json.Uint64(vdrSet.TotalWeight)
+	if vdrSet.AggregatePublicKey != nil {
+		pk, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(vdrSet.AggregatePublicKey))
+		if err != nil {
+			return err
+		}
+		reply.AggregatePublicKey = &pk
+	}
+	return nil
+}
+
 func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -2773,3 +3562,92 @@ func getStakeHelper(tx *txs.Tx, addrs set.Set[ids.ShortID], totalAmountStaked ma
 	}
 	return stakedOuts
 }
+
+// getStakedUTXOs returns the stake outputs of [tx] that are owned by one of
+// [addrs].
+func (s *Service) getStakedUTXOs(
+	staker *state.Staker,
+	tx *txs.Tx,
+	addrs set.Set[ids.ShortID],
+	pending bool,
+) ([]StakedUTXO, error) {
+	stakerTx, ok := tx.Unsigned.(txs.PermissionlessStaker)
+	if !ok {
+		return nil, nil
+	}
+
+	// When the staking period ends, the stake outputs are returned as UTXOs
+	// that are indexed after the outputs of the tx.
+	numOutputs := len(stakerTx.Outputs())
+	var stakedUTXOs []StakedUTXO
+	for i, output := range stakerTx.Stake() {
+		out := output.Out
+		var stakeableLocktime uint64
+		if lockedOut, ok := out.(*stakeable.LockOut); ok {
+			stakeableLocktime = lockedOut.Locktime
+			out = lockedOut.TransferableOut
+		}
+		secpOut, ok := out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+
+		owned := false
+		formattedAddrs := make([]string, len(secpOut.Addrs))
+		for j, addr := range secpOut.Addrs {
+			owned = owned || addrs.Contains(addr)
+
+			var err error
+			formattedAddrs[j], err = s.addrManager.FormatLocalAddress(addr)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't format address %s: %w", addr, err)
+			}
+		}
+		if !owned {
+			continue
+		}
+
+		utxoID := avax.UTXOID{
+			TxID:        staker.TxID,
+			OutputIndex: uint32(numOutputs + i),
+		}
+		stakedUTXOs = append(stakedUTXOs, StakedUTXO{
+			UTXOID:            utxoID.InputID(),
+			TxID:              staker.TxID,
+			OutputIndex:       json.Uint32(utxoID.OutputIndex),
+			AssetID:           output.AssetID(),
+			Amount:            json.Uint64(secpOut.Amt),
+			StakeableLocktime: json.Uint64(stakeableLocktime),
+			Locktime:          json.Uint64(secpOut.Locktime),
+			Threshold:         json.Uint32(secpOut.Threshold),
+			Addresses:         formattedAddrs,
+			NodeID:            staker.NodeID,
+			SubnetID:          staker.SubnetID,
+			IsValidator:       staker.Priority.IsValidator(),
+			Pending:           pending,
+			StartTime:         json.Uint64(staker.StartTime.Unix()),
+			EndTime:           json.Uint64(staker.EndTime.Unix()),
+		})
+	}
+	return stakedUTXOs, nil
+}
+
+// paginateStakedUTXOs sorts [utxos] by their UTXO ID and returns at most
+// [limit] of the UTXOs whose ID is greater than [startUTXOID].
+func paginateStakedUTXOs(utxos []StakedUTXO, startUTXOID ids.ID, limit int) []StakedUTXO {
+	sort.Slice(utxos, func(i, j int) bool {
+		return utxos[i].UTXOID.Less(utxos[j].UTXOID)
+	})
+
+	start := 0
+	if startUTXOID != ids.Empty {
+		start = sort.Search(len(utxos), func(i int) bool {
+			return startUTXOID.Less(utxos[i].UTXOID)
+		})
+	}
+	utxos = utxos[start:]
+	if len(utxos) > limit {
+		utxos = utxos[:limit]
+	}
+	return utxos
+}
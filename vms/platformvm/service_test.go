@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net/http"
 	"testing"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/api/rpcerror"
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
@@ -568,6 +570,96 @@ func TestGetStake(t *testing.T) {
 	require.Equal(stakeAmount+oldStake, outputs[0].Out.Amount()+outputs[1].Out.Amount()+outputs[2].Out.Amount())
 }
 
+func TestGetStakeUTXOs(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	// Add a delegator
+	stakeAmount := service.vm.MinDelegatorStake + 12345
+	delegatorNodeID := ids.NodeID(keys[0].PublicKey().Address())
+	delegatorEndTime := uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix())
+	tx, err := service.vm.txBuilder.NewAddDelegatorTx(
+		stakeAmount,
+		uint64(defaultGenesisTime.Unix()),
+		delegatorEndTime,
+		delegatorNodeID,
+		ids.GenerateTestShortID(),
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+
+	staker, err := state.NewCurrentStaker(
+		tx.ID(),
+		tx.Unsigned.(*txs.AddDelegatorTx),
+		0,
+	)
+	require.NoError(err)
+
+	service.vm.state.PutCurrentDelegator(staker)
+	service.vm.state.AddTx(tx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+
+	addr, err := service.addrManager.FormatLocalAddress(keys[0].PublicKey().Address())
+	require.NoError(err)
+	args := GetStakeArgs{
+		JSONAddresses: api.JSONAddresses{
+			Addresses: []string{addr},
+		},
+		Encoding:     formatting.Hex,
+		IncludeUTXOs: true,
+	}
+	response := GetStakeReply{}
+	require.NoError(service.GetStake(nil, &args, &response))
+	require.Len(response.StakedUTXOs, 2)
+	require.True(response.StakedUTXOs[0].UTXOID.Less(response.StakedUTXOs[1].UTXOID))
+	require.Equal(response.StakedUTXOs[1].UTXOID, response.EndUTXOID)
+
+	var delegatorUTXO *StakedUTXO
+	for i, utxo := range response.StakedUTXOs {
+		if utxo.TxID == tx.ID() {
+			delegatorUTXO = &response.StakedUTXOs[i]
+		}
+	}
+	require.NotNil(delegatorUTXO)
+
+	delegatorTx := tx.Unsigned.(*txs.AddDelegatorTx)
+	utxoID := avax.UTXOID{
+		TxID:        tx.ID(),
+		OutputIndex: uint32(len(delegatorTx.Outs)),
+	}
+	require.Equal(utxoID.InputID(), delegatorUTXO.UTXOID)
+	require.Equal(service.vm.ctx.AVAXAssetID, delegatorUTXO.AssetID)
+	require.Equal(stakeAmount, uint64(delegatorUTXO.Amount))
+	require.Equal([]string{addr}, delegatorUTXO.Addresses)
+	require.Equal(delegatorNodeID, delegatorUTXO.NodeID)
+	require.Equal(constants.PrimaryNetworkID, delegatorUTXO.SubnetID)
+	require.False(delegatorUTXO.IsValidator)
+	require.False(delegatorUTXO.Pending)
+	require.Equal(delegatorEndTime, uint64(delegatorUTXO.EndTime))
+
+	// Page through the UTXOs one at a time
+	args.Limit = 1
+	for _, expectedUTXO := range response.StakedUTXOs {
+		page := GetStakeReply{}
+		require.NoError(service.GetStake(nil, &args, &page))
+		require.Equal([]StakedUTXO{expectedUTXO}, page.StakedUTXOs)
+		require.Equal(expectedUTXO.UTXOID, page.EndUTXOID)
+		args.StartUTXOID = page.EndUTXOID
+	}
+
+	page := GetStakeReply{}
+	require.NoError(service.GetStake(nil, &args, &page))
+	require.Empty(page.StakedUTXOs)
+	require.Equal(args.StartUTXOID, page.EndUTXOID)
+}
+
 // Test method GetCurrentValidators
 func TestGetCurrentValidators(t *testing.T) {
 	require := require.New(t)
@@ -690,7 +782,7 @@ func TestGetCurrentValidators(t *testing.T) {
 	}
 }
 
-func TestGetTimestamp(t *testing.T) {
+func TestGetDelegationStats(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t)
 	service.vm.ctx.Lock.Lock()
@@ -699,17 +791,431 @@ func TestGetTimestamp(t *testing.T) {
 		service.vm.ctx.Lock.Unlock()
 	}()
 
+	validatorNodeID := ids.NodeID(keys[1].PublicKey().Address())
+	validator, err := service.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, validatorNodeID)
+	require.NoError(err)
+
+	maxWeight := txexecutor.MaxValidatorWeightFactor * validator.Weight
+	if maxWeight > service.vm.MaxValidatorStake {
+		maxWeight = service.vm.MaxValidatorStake
+	}
+	now := service.vm.state.GetTimestamp()
+	timeRemaining := uint64(validator.EndTime.Sub(now) / time.Second)
+
+	args := GetDelegationStatsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		NodeID:   validatorNodeID,
+	}
+	reply := GetDelegationStatsReply{}
+	require.NoError(service.GetDelegationStats(nil, &args, &reply))
+	require.Equal(validatorNodeID, reply.NodeID)
+	require.Equal(validator.Weight, uint64(reply.Weight))
+	require.Zero(reply.DelegatedWeight)
+	require.Zero(reply.DelegatorCount)
+	require.Equal(maxWeight, uint64(reply.MaxWeight))
+	require.Equal(maxWeight-validator.Weight, uint64(reply.RemainingCapacity))
+	require.Equal(timeRemaining, uint64(reply.TimeRemaining))
+
+	// Add a delegator
+	stakeAmount := uint64(reply.RemainingCapacity) / 2
+	delTx, err := service.vm.txBuilder.NewAddDelegatorTx(
+		stakeAmount,
+		uint64(now.Unix()),
+		uint64(now.Add(defaultMinStakingDuration).Unix()),
+		validatorNodeID,
+		ids.GenerateTestShortID(),
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+
+	staker, err := state.NewCurrentStaker(
+		delTx.ID(),
+		delTx.Unsigned.(*txs.AddDelegatorTx),
+		0,
+	)
+	require.NoError(err)
+
+	service.vm.state.PutCurrentDelegator(staker)
+	service.vm.state.AddTx(delTx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+
+	require.NoError(service.GetDelegationStats(nil, &args, &reply))
+	require.Equal(stakeAmount, uint64(reply.DelegatedWeight))
+	require.Equal(uint64(1), uint64(reply.DelegatorCount))
+	require.Equal(maxWeight-validator.Weight-stakeAmount, uint64(reply.RemainingCapacity))
+
+	// The validator with a delegator has the least remaining capacity.
+	listArgs := ListDelegationStatsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}
+	listReply := ListDelegationStatsReply{}
+	require.NoError(service.ListDelegationStats(nil, &listArgs, &listReply))
+	genesis, _ := defaultGenesis(t)
+	require.Len(listReply.Validators, len(genesis.Validators))
+	require.Equal(reply.DelegationStats, listReply.Validators[len(listReply.Validators)-1])
+	for i := 1; i < len(listReply.Validators); i++ {
+		require.GreaterOrEqual(listReply.Validators[i-1].RemainingCapacity, listReply.Validators[i].RemainingCapacity)
+	}
+
+	listArgs.Limit = 1
+	require.NoError(service.ListDelegationStats(nil, &listArgs, &listReply))
+	require.Len(listReply.Validators, 1)
+
+	args.NodeID = ids.GenerateTestNodeID()
+	err = service.GetDelegationStats(nil, &args, &reply)
+	require.True(rpcerror.Is(err, rpcerror.NotFound))
+}
+
+func TestGetRewardUTXOsBatch(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	rewardedTxID := ids.GenerateTestID()
+	rewardAddr := keys[0].PublicKey().Address()
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: rewardedTxID,
+		},
+		Asset: avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1234,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{rewardAddr},
+			},
+		},
+	}
+
+	service.vm.ctx.Lock.Lock()
+	service.vm.state.AddRewardUTXO(rewardedTxID, utxo)
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
+	unrewardedTxID := ids.GenerateTestID()
+	args := GetRewardUTXOsBatchArgs{
+		TxIDs:    []ids.ID{rewardedTxID, unrewardedTxID},
+		Encoding: formatting.Hex,
+	}
+	reply := GetRewardUTXOsBatchReply{}
+	require.NoError(service.GetRewardUTXOsBatch(nil, &args, &reply))
+	require.Len(reply.Results, 2)
+
+	rewarded := reply.Results[0]
+	require.Equal(rewardedTxID, rewarded.TxID)
+	require.Empty(rewarded.Error)
+	require.Len(rewarded.UTXOs, 1)
+
+	rewardUTXO := rewarded.UTXOs[0]
+	require.Equal(service.vm.ctx.AVAXAssetID, rewardUTXO.AssetID)
+	require.Equal(json.Uint64(1234), rewardUTXO.Amount)
+	rewardAddrStr, err := service.addrManager.FormatLocalAddress(rewardAddr)
+	require.NoError(err)
+	require.NotNil(rewardUTXO.Owner)
+	require.Equal([]string{rewardAddrStr}, rewardUTXO.Owner.Addresses)
+
+	utxoBytes, err := formatting.Decode(formatting.Hex, rewardUTXO.UTXO)
+	require.NoError(err)
+	parsedUTXO := &avax.UTXO{}
+	_, err = txs.Codec.Unmarshal(utxoBytes, parsedUTXO)
+	require.NoError(err)
+	require.Equal(utxo.InputID(), parsedUTXO.InputID())
+
+	unrewarded := reply.Results[1]
+	require.Equal(unrewardedTxID, unrewarded.TxID)
+	require.Empty(unrewarded.Error)
+	require.Empty(unrewarded.UTXOs)
+
+	args.TxIDs = nil
+	err = service.GetRewardUTXOsBatch(nil, &args, &reply)
+	require.ErrorIs(err, errNoTxIDs)
+
+	args.TxIDs = make([]ids.ID, maxGetRewardUTXOsTxIDs+1)
+	err = service.GetRewardUTXOsBatch(nil, &args, &reply)
+	require.ErrorIs(err, errTooManyTxIDs)
+}
+
+func TestGetTimestamp(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
 	reply := GetTimestampReply{}
 	require.NoError(service.GetTimestamp(nil, nil, &reply))
+
+	service.vm.ctx.Lock.Lock()
+
 	require.Equal(service.vm.state.GetTimestamp(), reply.Timestamp)
 
 	newTimestamp := reply.Timestamp.Add(time.Second)
 	service.vm.state.SetTimestamp(newTimestamp)
 
+	service.vm.ctx.Lock.Unlock()
+
+	// The timestamp is only reported once it is committed
+	require.NoError(service.GetTimestamp(nil, nil, &reply))
+	require.Equal(newTimestamp.Add(-time.Second), reply.Timestamp)
+
+	service.vm.ctx.Lock.Lock()
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
 	require.NoError(service.GetTimestamp(nil, nil, &reply))
 	require.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestGetCurrentSupply(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	supply, err := service.vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	height, err := service.vm.GetCurrentHeight(context.Background())
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	args := GetCurrentSupplyArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}
+	reply := GetCurrentSupplyReply{}
+	require.NoError(service.GetCurrentSupply(&http.Request{}, &args, &reply))
+	require.Equal(supply, uint64(reply.Supply))
+	require.Equal(height, uint64(reply.Height))
+
+	heightReply := api.GetHeightResponse{}
+	require.NoError(service.GetHeight(&http.Request{}, nil, &heightReply))
+	require.Equal(height, uint64(heightReply.Height))
+
+	// The supply is only reported once it is committed
+	service.vm.ctx.Lock.Lock()
+	service.vm.state.SetCurrentSupply(constants.PrimaryNetworkID, supply+1)
+	service.vm.ctx.Lock.Unlock()
+
+	require.NoError(service.GetCurrentSupply(&http.Request{}, &args, &reply))
+	require.Equal(supply, uint64(reply.Supply))
+
+	service.vm.ctx.Lock.Lock()
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
+	require.NoError(service.GetCurrentSupply(&http.Request{}, &args, &reply))
+	require.Equal(supply+1, uint64(reply.Supply))
+}
+
+func TestGetCanonicalValidatorSet(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	vdrs, err := service.vm.GetValidatorSet(context.Background(), 0, constants.PrimaryNetworkID)
+	require.NoError(err)
+	var expectedWeight uint64
+	for _, vdr := range vdrs {
+		expectedWeight += vdr.Weight
+	}
+
+	args := GetCanonicalValidatorSetArgs{
+		Height:   0,
+		SubnetID: constants.PrimaryNetworkID,
+	}
+	for i := 0; i < 2; i++ {
+		reply := GetCanonicalValidatorSetReply{}
+		require.NoError(service.GetCanonicalValidatorSet(&http.Request{}, &args, &reply))
+
+		// The genesis validators don't have BLS keys.
+		require.Empty(reply.Validators)
+		require.Nil(reply.AggregatePublicKey)
+		require.Equal(expectedWeight, uint64(reply.TotalWeight))
+	}
+}
+
+func TestGetSubnetOwner(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	reply := GetSubnetOwnerReply{}
+	require.NoError(service.GetSubnetOwner(nil, &GetSubnetOwnerArgs{
+		SubnetID: testSubnet1.ID(),
+	}, &reply))
+
+	owner := testSubnet1.Unsigned.(*txs.CreateSubnetTx).Owner.(*secp256k1fx.OutputOwners)
+	require.Equal(json.Uint32(owner.Threshold), reply.Owner.Threshold)
+	require.Equal(json.Uint64(owner.Locktime), reply.Owner.Locktime)
+	require.Len(reply.Owner.Addresses, len(owner.Addrs))
+	for i, addr := range owner.Addrs {
+		expectedAddr, err := service.addrManager.FormatLocalAddress(addr)
+		require.NoError(err)
+		require.Equal(expectedAddr, reply.Owner.Addresses[i])
+	}
+	require.False(reply.Permissionless)
+
+	err := service.GetSubnetOwner(nil, &GetSubnetOwnerArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply)
+	require.ErrorIs(err, errPrimaryNetworkNotSubnet)
+
+	err = service.GetSubnetOwner(nil, &GetSubnetOwnerArgs{
+		SubnetID: ids.GenerateTestID(),
+	}, &reply)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestVerifySubnetAuth(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	owner := testSubnet1.Unsigned.(*txs.CreateSubnetTx).Owner.(*secp256k1fx.OutputOwners)
+	formatAddrs := func(keys ...*secp256k1.PrivateKey) []string {
+		addrs := make([]string, len(keys))
+		for i, key := range keys {
+			addr, err := service.addrManager.FormatLocalAddress(key.PublicKey().Address())
+			require.NoError(err)
+			addrs[i] = addr
+		}
+		return addrs
+	}
+
+	reply := VerifySubnetAuthReply{}
+	require.NoError(service.VerifySubnetAuth(nil, &VerifySubnetAuthArgs{
+		SubnetID:  testSubnet1.ID(),
+		Addresses: formatAddrs(testSubnet1ControlKeys[0], keys[4]),
+	}, &reply))
+	require.False(reply.Authorized)
+	require.Empty(reply.SigIndices)
+	require.Equal(json.Uint32(owner.Threshold), reply.Threshold)
+	require.Equal(json.Uint32(1), reply.NumMatched)
+
+	reply = VerifySubnetAuthReply{}
+	require.NoError(service.VerifySubnetAuth(nil, &VerifySubnetAuthArgs{
+		SubnetID:  testSubnet1.ID(),
+		Addresses: formatAddrs(testSubnet1ControlKeys...),
+	}, &reply))
+	require.True(reply.Authorized)
+	require.Len(reply.SigIndices, int(owner.Threshold))
+	require.Equal(json.Uint32(len(testSubnet1ControlKeys)), reply.NumMatched)
+
+	// The returned indices must reference the subnet owner's addresses in
+	// increasing order
+	for i, sigIndex := range reply.SigIndices {
+		if i > 0 {
+			require.Greater(sigIndex, reply.SigIndices[i-1])
+		}
+		require.Less(int(sigIndex), len(owner.Addrs))
+	}
+}
+
+func TestGetSubnetTransformation(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	subnetID := testSubnet1.ID()
+	now := service.vm.state.GetTimestamp()
+	service.vm.state.PutCurrentValidator(&state.Staker{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    ids.GenerateTestNodeID(),
+		SubnetID:  subnetID,
+		Weight:    1,
+		StartTime: now,
+		EndTime:   now.Add(defaultMinStakingDuration),
+		NextTime:  now.Add(defaultMinStakingDuration),
+		Priority:  txs.SubnetPermissionedValidatorCurrentPriority,
+	})
+
+	args := GetSubnetTransformationArgs{
+		SubnetID: subnetID,
+	}
+	reply := GetSubnetTransformationReply{}
+	require.NoError(service.GetSubnetTransformation(nil, &args, &reply))
+	owner := testSubnet1.Unsigned.(*txs.CreateSubnetTx).Owner.(*secp256k1fx.OutputOwners)
+	require.Equal(json.Uint32(owner.Threshold), reply.Owner.Threshold)
+	require.Len(reply.Owner.Addresses, len(owner.Addrs))
+	require.Nil(reply.Transformation)
+	require.Equal(json.Uint32(1), reply.PermissionedValidators)
+	require.Zero(reply.PermissionlessValidators)
+
+	transformSubnetTx := &txs.TransformSubnetTx{
+		Subnet:                   subnetID,
+		AssetID:                  ids.GenerateTestID(),
+		InitialSupply:            10,
+		MaximumSupply:            20,
+		MinConsumptionRate:       1,
+		MaxConsumptionRate:       2,
+		MinValidatorStake:        1,
+		MaxValidatorStake:        10,
+		MinStakeDuration:         1,
+		MaxStakeDuration:         2,
+		MinDelegationFee:         3,
+		MinDelegatorStake:        1,
+		MaxValidatorWeightFactor: 5,
+		UptimeRequirement:        4,
+		SubnetAuth:               &secp256k1fx.Input{},
+	}
+	transformTx, err := txs.NewSigned(transformSubnetTx, txs.Codec, nil)
+	require.NoError(err)
+	service.vm.state.AddSubnetTransformation(transformTx)
+	service.vm.state.SetCurrentSupply(subnetID, 15)
+
+	reply = GetSubnetTransformationReply{}
+	require.NoError(service.GetSubnetTransformation(nil, &args, &reply))
+	require.Equal(&SubnetTransformation{
+		TxID:                     transformTx.ID(),
+		AssetID:                  transformSubnetTx.AssetID,
+		InitialSupply:            10,
+		MaximumSupply:            20,
+		CurrentSupply:            15,
+		MinConsumptionRate:       1,
+		MaxConsumptionRate:       2,
+		MinValidatorStake:        1,
+		MaxValidatorStake:        10,
+		MinStakeDuration:         1,
+		MaxStakeDuration:         2,
+		MinDelegationFee:         3,
+		MinDelegatorStake:        1,
+		MaxValidatorWeightFactor: 5,
+		UptimeRequirement:        4,
+	}, reply.Transformation)
+	require.Equal(json.Uint32(1), reply.PermissionedValidators)
+
+	err = service.GetSubnetTransformation(nil, &GetSubnetTransformationArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply)
+	require.ErrorIs(err, errPrimaryNetworkNotSubnet)
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// Snapshot is an immutable view of the metadata of the last committed state.
+// Unlike [State], it can be read without holding the chain's lock, so the API
+// methods that only need this metadata are served without contending with
+// block execution.
+//
+// A new snapshot is created every time the state is committed, rather than
+// modifying the existing one. To keep commits cheap, the snapshot only holds
+// values that are copied in constant time. The validator sets, UTXOs, subnets
+// and chains aren't included, so the API methods that read them still hold
+// the chain's lock.
+type Snapshot struct {
+	// ID of the last accepted block
+	LastAccepted ids.ID
+	// Height of the last accepted block
+	Height uint64
+	// Chain timestamp
+	Timestamp time.Time
+	// Current supply of the primary network
+	CurrentSupply uint64
+}
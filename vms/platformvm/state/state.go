@@ -19,6 +19,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/budget"
 	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/linkeddb"
@@ -185,6 +186,11 @@ type State interface {
 
 	SetHeight(height uint64)
 
+	// Snapshot returns a view of the metadata of the last committed state that
+	// can be read concurrently with modifications to the state. Returns nil if
+	// the state hasn't been committed yet.
+	Snapshot() *Snapshot
+
 	// Discard uncommitted changes to the database.
 	Abort()
 
@@ -296,6 +302,9 @@ type state struct {
 	rewards      reward.Calculator
 	bootstrapped *utils.Atomic[bool]
 
+	// names of the caches allocated from [ctx.CacheBudget], if not nil
+	cacheNames []string
+
 	baseDB *versiondb.Database
 
 	currentStakers *baseStakers
@@ -303,6 +312,9 @@ type state struct {
 
 	currentHeight uint64
 
+	// Replaced with a new snapshot every time the state is committed
+	snapshot utils.Atomic[*Snapshot]
+
 	addedBlockIDs map[uint64]ids.ID            // map of height -> blockID
 	blockIDCache  cache.Cacher[uint64, ids.ID] // cache of height -> blockID. If the entry is ids.Empty, it is not in the database
 	blockIDDB     database.Database
@@ -517,10 +529,23 @@ func newState(
 		return nil, err
 	}
 
+	cacheNames := []string{
+		fmt.Sprintf("%s_block_cache", ctx.ChainID),
+		fmt.Sprintf("%s_utxo_cache", ctx.ChainID),
+	}
+	blockSizedCache, err := budget.NewSizedLRU[ids.ID, blocks.Block](
+		ctx.CacheBudget,
+		cacheNames[0],
+		execCfg.BlockCacheSize,
+		blockSize,
+	)
+	if err != nil {
+		return nil, err
+	}
 	blockCache, err := metercacher.New[ids.ID, blocks.Block](
 		"block_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, blocks.Block](execCfg.BlockCacheSize, blockSize),
+		blockSizedCache,
 	)
 	if err != nil {
 		return nil, err
@@ -567,7 +592,14 @@ func newState(
 	}
 
 	utxoDB := prefixdb.New(utxoPrefix, baseDB)
-	utxoState, err := avax.NewMeteredUTXOState(utxoDB, txs.GenesisCodec, metricsReg, execCfg.ChecksumsEnabled)
+	utxoState, err := avax.NewMeteredUTXOState(
+		utxoDB,
+		txs.GenesisCodec,
+		metricsReg,
+		execCfg.ChecksumsEnabled,
+		ctx.CacheBudget,
+		cacheNames[1],
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -618,6 +650,7 @@ func newState(
 		metrics:      metrics,
 		rewards:      rewards,
 		bootstrapped: bootstrapped,
+		cacheNames:   cacheNames,
 		baseDB:       baseDB,
 
 		addedBlockIDs: make(map[uint64]ids.ID),
@@ -1028,6 +1061,14 @@ func (s *state) GetStartTime(nodeID ids.NodeID, subnetID ids.ID) (time.Time, err
 	return staker.StartTime, nil
 }
 
+func (s *state) GetEndTime(nodeID ids.NodeID, subnetID ids.ID) (time.Time, error) {
+	staker, err := s.currentStakers.GetValidator(subnetID, nodeID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return staker.EndTime, nil
+}
+
 func (s *state) GetTimestamp() time.Time {
 	return s.timestamp
 }
@@ -1354,7 +1395,12 @@ func (s *state) load() error {
 		s.loadPendingValidators(),
 		s.initValidatorSets(),
 	)
-	return errs.Err
+	if errs.Errored() {
+		return errs.Err
+	}
+
+	s.refreshSnapshot()
+	return nil
 }
 
 func (s *state) loadMetadata() error {
@@ -1688,10 +1734,21 @@ func (s *state) write(updateValidators bool, height uint64) error {
 		s.writeChains(),
 		s.writeMetadata(),
 	)
-	return errs.Err
+	if errs.Errored() {
+		return errs.Err
+	}
+
+	s.refreshSnapshot()
+	return nil
 }
 
 func (s *state) Close() error {
+	if s.ctx.CacheBudget != nil {
+		for _, name := range s.cacheNames {
+			s.ctx.CacheBudget.Unregister(name)
+		}
+	}
+
 	errs := wrappers.Errs{}
 	errs.Add(
 		s.pendingSubnetValidatorBaseDB.Close(),
@@ -1802,6 +1859,26 @@ func (s *state) Commit() error {
 	return batch.Write()
 }
 
+func (s *state) Snapshot() *Snapshot {
+	return s.snapshot.Get()
+}
+
+// refreshSnapshot replaces the snapshot with a view of the current state. If
+// the last accepted block can't be loaded, the previous snapshot is kept.
+func (s *state) refreshSnapshot() {
+	lastAccepted, err := s.GetStatelessBlock(s.lastAccepted)
+	if err != nil {
+		return
+	}
+
+	s.snapshot.Set(&Snapshot{
+		LastAccepted:  s.lastAccepted,
+		Height:        lastAccepted.Height(),
+		Timestamp:     s.timestamp,
+		CurrentSupply: s.currentSupply,
+	})
+}
+
 func (s *state) Abort() {
 	s.baseDB.Abort()
 }
@@ -86,6 +86,42 @@ func TestStateSyncGenesis(t *testing.T) {
 	assertIteratorsEqual(t, EmptyIterator, delegatorIterator)
 }
 
+func TestStateSnapshot(t *testing.T) {
+	require := require.New(t)
+	s, db := newInitializedState(require)
+
+	require.NoError(s.Commit())
+	supply, err := s.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+
+	snapshot := s.Snapshot()
+	require.NotNil(snapshot)
+	require.Equal(s.GetLastAccepted(), snapshot.LastAccepted)
+	require.Zero(snapshot.Height)
+	require.Equal(initialTime.Unix(), snapshot.Timestamp.Unix())
+	require.Equal(supply, snapshot.CurrentSupply)
+
+	// Uncommitted changes aren't visible in the snapshot
+	newTimestamp := initialTime.Add(time.Second)
+	s.SetTimestamp(newTimestamp)
+	s.SetCurrentSupply(constants.PrimaryNetworkID, units.Avax)
+	require.Equal(snapshot, s.Snapshot())
+
+	// Committing replaces the snapshot rather than modifying it
+	require.NoError(s.Commit())
+	newSnapshot := s.Snapshot()
+	require.Equal(newTimestamp.Unix(), newSnapshot.Timestamp.Unix())
+	require.Equal(units.Avax, newSnapshot.CurrentSupply)
+	require.Equal(initialTime.Unix(), snapshot.Timestamp.Unix())
+	require.Equal(supply, snapshot.CurrentSupply)
+
+	// The snapshot is available as soon as the state is loaded
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).load())
+	require.Equal(newSnapshot.LastAccepted, s.Snapshot().LastAccepted)
+	require.Equal(newSnapshot.CurrentSupply, s.Snapshot().CurrentSupply)
+}
+
 func newInitializedState(require *require.Assertions) (State, database.Database) {
 	s, db := newUninitializedState(require)
 
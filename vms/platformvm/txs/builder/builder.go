@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -50,6 +51,14 @@ type AtomicTxBuilder interface {
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
 
+	// Like NewImportTx, but signed by the signers held by [kc].
+	NewImportTxWithKeychain(
+		chainID ids.ID,
+		to ids.ShortID,
+		kc *secp256k1fx.Keychain,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
 	// amount: amount of tokens to export
 	// chainID: chain to send the UTXOs to
 	// to: address of recipient
@@ -62,6 +71,15 @@ type AtomicTxBuilder interface {
 		keys []*secp256k1.PrivateKey,
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
+
+	// Like NewExportTx, but signed by the signers held by [kc].
+	NewExportTxWithKeychain(
+		amount uint64,
+		chainID ids.ID,
+		to ids.ShortID,
+		kc *secp256k1fx.Keychain,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
 }
 
 type DecisionTxBuilder interface {
@@ -82,6 +100,17 @@ type DecisionTxBuilder interface {
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
 
+	// Like NewCreateChainTx, but signed by the signers held by [kc].
+	NewCreateChainTxWithKeychain(
+		subnetID ids.ID,
+		genesisData []byte,
+		vmID ids.ID,
+		fxIDs []ids.ID,
+		chainName string,
+		kc *secp256k1fx.Keychain,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
 	// threshold: [threshold] of [ownerAddrs] needed to manage this subnet
 	// ownerAddrs: control addresses for the new subnet
 	// keys: keys to pay the fee
@@ -92,6 +121,14 @@ type DecisionTxBuilder interface {
 		keys []*secp256k1.PrivateKey,
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
+
+	// Like NewCreateSubnetTx, but signed by the signers held by [kc].
+	NewCreateSubnetTxWithKeychain(
+		threshold uint32,
+		ownerAddrs []ids.ShortID,
+		kc *secp256k1fx.Keychain,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
 }
 
 type ProposalTxBuilder interface {
@@ -114,6 +151,18 @@ type ProposalTxBuilder interface {
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
 
+	// Like NewAddValidatorTx, but signed by the signers held by [kc].
+	NewAddValidatorTxWithKeychain(
+		stakeAmount,
+		startTime,
+		endTime uint64,
+		nodeID ids.NodeID,
+		rewardAddress ids.ShortID,
+		shares uint32,
+		kc *secp256k1fx.Keychain,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
 	// stakeAmount: amount the delegator stakes
 	// startTime: unix time they start delegating
 	// endTime: unix time they stop delegating
@@ -131,6 +180,17 @@ type ProposalTxBuilder interface {
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
 
+	// Like NewAddDelegatorTx, but signed by the signers held by [kc].
+	NewAddDelegatorTxWithKeychain(
+		stakeAmount,
+		startTime,
+		endTime uint64,
+		nodeID ids.NodeID,
+		rewardAddress ids.ShortID,
+		kc *secp256k1fx.Keychain,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
 	// weight: sampling weight of the new validator
 	// startTime: unix time they start delegating
 	// endTime:  unix time they top delegating
@@ -148,6 +208,17 @@ type ProposalTxBuilder interface {
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
 
+	// Like NewAddSubnetValidatorTx, but signed by the signers held by [kc].
+	NewAddSubnetValidatorTxWithKeychain(
+		weight,
+		startTime,
+		endTime uint64,
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		kc *secp256k1fx.Keychain,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
 	// Creates a transaction that removes [nodeID]
 	// as a validator from [subnetID]
 	// keys: keys to use for removing the validator
@@ -159,6 +230,14 @@ type ProposalTxBuilder interface {
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
 
+	// Like NewRemoveSubnetValidatorTx, but signed by the signers held by [kc].
+	NewRemoveSubnetValidatorTxWithKeychain(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		kc *secp256k1fx.Keychain,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
 	// newAdvanceTimeTx creates a new tx that, if it is accepted and followed by a
 	// Commit block, will set the chain's timestamp to [timestamp].
 	NewAdvanceTimeTx(timestamp time.Time) (*txs.Tx, error)
@@ -205,20 +284,32 @@ func (b *builder) NewImportTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	kc := secp256k1fx.NewKeychain(keys...)
+	return b.NewImportTxWithKeychain(
+		from,
+		to,
+		secp256k1fx.NewKeychain(keys...),
+		changeAddr,
+	)
+}
 
+func (b *builder) NewImportTxWithKeychain(
+	from ids.ID,
+	to ids.ShortID,
+	kc *secp256k1fx.Keychain,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
 	atomicUTXOs, _, _, err := b.GetAtomicUTXOs(from, kc.Addresses(), ids.ShortEmpty, ids.Empty, MaxPageSize)
 	if err != nil {
 		return nil, fmt.Errorf("problem retrieving atomic UTXOs: %w", err)
 	}
 
 	importedInputs := []*avax.TransferableInput{}
-	signers := [][]*secp256k1.PrivateKey{}
+	signers := [][]keychain.Signer{}
 
 	importedAmounts := make(map[ids.ID]uint64)
 	now := b.clk.Unix()
 	for _, utxo := range atomicUTXOs {
-		inputIntf, utxoSigners, err := kc.Spend(utxo.Out, now)
+		inputIntf, utxoSigners, err := kc.SpendSigners(utxo.Out, now)
 		if err != nil {
 			continue
 		}
@@ -238,7 +329,7 @@ func (b *builder) NewImportTx(
 		})
 		signers = append(signers, utxoSigners)
 	}
-	avax.SortTransferableInputsWithSigners(importedInputs, signers)
+	avax.SortTransferableInputsWithKeychainSigners(importedInputs, signers)
 
 	if len(importedAmounts) == 0 {
 		return nil, ErrNoFunds // No imported UTXOs were spendable
@@ -250,8 +341,8 @@ func (b *builder) NewImportTx(
 	outs := []*avax.TransferableOutput{}
 	switch {
 	case importedAVAX < b.cfg.TxFee: // imported amount goes toward paying tx fee
-		var baseSigners [][]*secp256k1.PrivateKey
-		ins, outs, _, baseSigners, err = b.Spend(b.state, keys, 0, b.cfg.TxFee-importedAVAX, changeAddr)
+		var baseSigners [][]keychain.Signer
+		ins, outs, _, baseSigners, err = b.SpendWithKeychain(b.state, kc, 0, b.cfg.TxFee-importedAVAX, changeAddr)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 		}
@@ -290,7 +381,7 @@ func (b *builder) NewImportTx(
 		SourceChain:    from,
 		ImportedInputs: importedInputs,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWithKeychainSigners(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -304,12 +395,28 @@ func (b *builder) NewExportTx(
 	to ids.ShortID,
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	return b.NewExportTxWithKeychain(
+		amount,
+		chainID,
+		to,
+		secp256k1fx.NewKeychain(keys...),
+		changeAddr,
+	)
+}
+
+func (b *builder) NewExportTxWithKeychain(
+	amount uint64,
+	chainID ids.ID,
+	to ids.ShortID,
+	kc *secp256k1fx.Keychain,
+	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
 	toBurn, err := math.Add64(amount, b.cfg.TxFee)
 	if err != nil {
 		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, b.cfg.TxFee)
 	}
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, toBurn, changeAddr)
+	ins, outs, _, signers, err := b.SpendWithKeychain(b.state, kc, 0, toBurn, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -335,7 +442,7 @@ func (b *builder) NewExportTx(
 			},
 		}},
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWithKeychainSigners(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -350,15 +457,35 @@ func (b *builder) NewCreateChainTx(
 	chainName string,
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	return b.NewCreateChainTxWithKeychain(
+		subnetID,
+		genesisData,
+		vmID,
+		fxIDs,
+		chainName,
+		secp256k1fx.NewKeychain(keys...),
+		changeAddr,
+	)
+}
+
+func (b *builder) NewCreateChainTxWithKeychain(
+	subnetID ids.ID,
+	genesisData []byte,
+	vmID ids.ID,
+	fxIDs []ids.ID,
+	chainName string,
+	kc *secp256k1fx.Keychain,
+	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
 	timestamp := b.state.GetTimestamp()
 	createBlockchainTxFee := b.cfg.GetCreateBlockchainTxFee(timestamp)
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, createBlockchainTxFee, changeAddr)
+	ins, outs, _, signers, err := b.SpendWithKeychain(b.state, kc, 0, createBlockchainTxFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	subnetAuth, subnetSigners, err := b.AuthorizeWithKeychain(b.state, subnetID, kc)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
 	}
@@ -382,7 +509,7 @@ func (b *builder) NewCreateChainTx(
 		GenesisData: genesisData,
 		SubnetAuth:  subnetAuth,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWithKeychainSigners(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -394,10 +521,24 @@ func (b *builder) NewCreateSubnetTx(
 	ownerAddrs []ids.ShortID,
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	return b.NewCreateSubnetTxWithKeychain(
+		threshold,
+		ownerAddrs,
+		secp256k1fx.NewKeychain(keys...),
+		changeAddr,
+	)
+}
+
+func (b *builder) NewCreateSubnetTxWithKeychain(
+	threshold uint32,
+	ownerAddrs []ids.ShortID,
+	kc *secp256k1fx.Keychain,
+	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
 	timestamp := b.state.GetTimestamp()
 	createSubnetTxFee := b.cfg.GetCreateSubnetTxFee(timestamp)
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, createSubnetTxFee, changeAddr)
+	ins, outs, _, signers, err := b.SpendWithKeychain(b.state, kc, 0, createSubnetTxFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -418,7 +559,7 @@ func (b *builder) NewCreateSubnetTx(
 			Addrs:     ownerAddrs,
 		},
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWithKeychainSigners(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -435,7 +576,29 @@ func (b *builder) NewAddValidatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, unstakedOuts, stakedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, b.cfg.AddPrimaryNetworkValidatorFee, changeAddr)
+	return b.NewAddValidatorTxWithKeychain(
+		stakeAmount,
+		startTime,
+		endTime,
+		nodeID,
+		rewardAddress,
+		shares,
+		secp256k1fx.NewKeychain(keys...),
+		changeAddr,
+	)
+}
+
+func (b *builder) NewAddValidatorTxWithKeychain(
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	rewardAddress ids.ShortID,
+	shares uint32,
+	kc *secp256k1fx.Keychain,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, unstakedOuts, stakedOuts, signers, err := b.SpendWithKeychain(b.state, kc, stakeAmount, b.cfg.AddPrimaryNetworkValidatorFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -461,7 +624,7 @@ func (b *builder) NewAddValidatorTx(
 		},
 		DelegationShares: shares,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWithKeychainSigners(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -477,7 +640,27 @@ func (b *builder) NewAddDelegatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, b.cfg.AddPrimaryNetworkDelegatorFee, changeAddr)
+	return b.NewAddDelegatorTxWithKeychain(
+		stakeAmount,
+		startTime,
+		endTime,
+		nodeID,
+		rewardAddress,
+		secp256k1fx.NewKeychain(keys...),
+		changeAddr,
+	)
+}
+
+func (b *builder) NewAddDelegatorTxWithKeychain(
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	rewardAddress ids.ShortID,
+	kc *secp256k1fx.Keychain,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, unlockedOuts, lockedOuts, signers, err := b.SpendWithKeychain(b.state, kc, stakeAmount, b.cfg.AddPrimaryNetworkDelegatorFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -502,7 +685,7 @@ func (b *builder) NewAddDelegatorTx(
 			Addrs:     []ids.ShortID{rewardAddress},
 		},
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWithKeychainSigners(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -518,12 +701,32 @@ func (b *builder) NewAddSubnetValidatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	return b.NewAddSubnetValidatorTxWithKeychain(
+		weight,
+		startTime,
+		endTime,
+		nodeID,
+		subnetID,
+		secp256k1fx.NewKeychain(keys...),
+		changeAddr,
+	)
+}
+
+func (b *builder) NewAddSubnetValidatorTxWithKeychain(
+	weight,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	kc *secp256k1fx.Keychain,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, outs, _, signers, err := b.SpendWithKeychain(b.state, kc, 0, b.cfg.TxFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	subnetAuth, subnetSigners, err := b.AuthorizeWithKeychain(b.state, subnetID, kc)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
 	}
@@ -548,7 +751,7 @@ func (b *builder) NewAddSubnetValidatorTx(
 		},
 		SubnetAuth: subnetAuth,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWithKeychainSigners(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -561,12 +764,26 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	return b.NewRemoveSubnetValidatorTxWithKeychain(
+		nodeID,
+		subnetID,
+		secp256k1fx.NewKeychain(keys...),
+		changeAddr,
+	)
+}
+
+func (b *builder) NewRemoveSubnetValidatorTxWithKeychain(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	kc *secp256k1fx.Keychain,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, outs, _, signers, err := b.SpendWithKeychain(b.state, kc, 0, b.cfg.TxFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+	subnetAuth, subnetSigners, err := b.AuthorizeWithKeychain(b.state, subnetID, kc)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
 	}
@@ -584,7 +801,7 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 		NodeID:     nodeID,
 		SubnetAuth: subnetAuth,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	tx, err := txs.NewSignedWithKeychainSigners(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -14,6 +14,7 @@ import (
 	ids "github.com/ava-labs/avalanchego/ids"
 	secp256k1 "github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	txs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	secp256k1fx "github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -55,6 +56,21 @@ func (mr *MockBuilderMockRecorder) NewAddDelegatorTx(arg0, arg1, arg2, arg3, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAddDelegatorTx", reflect.TypeOf((*MockBuilder)(nil).NewAddDelegatorTx), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// NewAddDelegatorTxWithKeychain mocks base method.
+func (m *MockBuilder) NewAddDelegatorTxWithKeychain(arg0, arg1, arg2 uint64, arg3 ids.NodeID, arg4 ids.ShortID, arg5 *secp256k1fx.Keychain, arg6 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAddDelegatorTxWithKeychain", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewAddDelegatorTxWithKeychain indicates an expected call of NewAddDelegatorTxWithKeychain.
+func (mr *MockBuilderMockRecorder) NewAddDelegatorTxWithKeychain(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAddDelegatorTxWithKeychain", reflect.TypeOf((*MockBuilder)(nil).NewAddDelegatorTxWithKeychain), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // NewAddSubnetValidatorTx mocks base method.
 func (m *MockBuilder) NewAddSubnetValidatorTx(arg0, arg1, arg2 uint64, arg3 ids.NodeID, arg4 ids.ID, arg5 []*secp256k1.PrivateKey, arg6 ids.ShortID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +86,21 @@ func (mr *MockBuilderMockRecorder) NewAddSubnetValidatorTx(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAddSubnetValidatorTx", reflect.TypeOf((*MockBuilder)(nil).NewAddSubnetValidatorTx), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// NewAddSubnetValidatorTxWithKeychain mocks base method.
+func (m *MockBuilder) NewAddSubnetValidatorTxWithKeychain(arg0, arg1, arg2 uint64, arg3 ids.NodeID, arg4 ids.ID, arg5 *secp256k1fx.Keychain, arg6 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAddSubnetValidatorTxWithKeychain", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewAddSubnetValidatorTxWithKeychain indicates an expected call of NewAddSubnetValidatorTxWithKeychain.
+func (mr *MockBuilderMockRecorder) NewAddSubnetValidatorTxWithKeychain(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAddSubnetValidatorTxWithKeychain", reflect.TypeOf((*MockBuilder)(nil).NewAddSubnetValidatorTxWithKeychain), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // NewAddValidatorTx mocks base method.
 func (m *MockBuilder) NewAddValidatorTx(arg0, arg1, arg2 uint64, arg3 ids.NodeID, arg4 ids.ShortID, arg5 uint32, arg6 []*secp256k1.PrivateKey, arg7 ids.ShortID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +116,21 @@ func (mr *MockBuilderMockRecorder) NewAddValidatorTx(arg0, arg1, arg2, arg3, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAddValidatorTx", reflect.TypeOf((*MockBuilder)(nil).NewAddValidatorTx), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 }
 
+// NewAddValidatorTxWithKeychain mocks base method.
+func (m *MockBuilder) NewAddValidatorTxWithKeychain(arg0, arg1, arg2 uint64, arg3 ids.NodeID, arg4 ids.ShortID, arg5 uint32, arg6 *secp256k1fx.Keychain, arg7 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAddValidatorTxWithKeychain", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewAddValidatorTxWithKeychain indicates an expected call of NewAddValidatorTxWithKeychain.
+func (mr *MockBuilderMockRecorder) NewAddValidatorTxWithKeychain(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAddValidatorTxWithKeychain", reflect.TypeOf((*MockBuilder)(nil).NewAddValidatorTxWithKeychain), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
 // NewAdvanceTimeTx mocks base method.
 func (m *MockBuilder) NewAdvanceTimeTx(arg0 time.Time) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +161,21 @@ func (mr *MockBuilderMockRecorder) NewCreateChainTx(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewCreateChainTx", reflect.TypeOf((*MockBuilder)(nil).NewCreateChainTx), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// NewCreateChainTxWithKeychain mocks base method.
+func (m *MockBuilder) NewCreateChainTxWithKeychain(arg0 ids.ID, arg1 []byte, arg2 ids.ID, arg3 []ids.ID, arg4 string, arg5 *secp256k1fx.Keychain, arg6 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewCreateChainTxWithKeychain", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewCreateChainTxWithKeychain indicates an expected call of NewCreateChainTxWithKeychain.
+func (mr *MockBuilderMockRecorder) NewCreateChainTxWithKeychain(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewCreateChainTxWithKeychain", reflect.TypeOf((*MockBuilder)(nil).NewCreateChainTxWithKeychain), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // NewCreateSubnetTx mocks base method.
 func (m *MockBuilder) NewCreateSubnetTx(arg0 uint32, arg1 []ids.ShortID, arg2 []*secp256k1.PrivateKey, arg3 ids.ShortID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +191,21 @@ func (mr *MockBuilderMockRecorder) NewCreateSubnetTx(arg0, arg1, arg2, arg3 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewCreateSubnetTx", reflect.TypeOf((*MockBuilder)(nil).NewCreateSubnetTx), arg0, arg1, arg2, arg3)
 }
 
+// NewCreateSubnetTxWithKeychain mocks base method.
+func (m *MockBuilder) NewCreateSubnetTxWithKeychain(arg0 uint32, arg1 []ids.ShortID, arg2 *secp256k1fx.Keychain, arg3 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewCreateSubnetTxWithKeychain", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewCreateSubnetTxWithKeychain indicates an expected call of NewCreateSubnetTxWithKeychain.
+func (mr *MockBuilderMockRecorder) NewCreateSubnetTxWithKeychain(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewCreateSubnetTxWithKeychain", reflect.TypeOf((*MockBuilder)(nil).NewCreateSubnetTxWithKeychain), arg0, arg1, arg2, arg3)
+}
+
 // NewExportTx mocks base method.
 func (m *MockBuilder) NewExportTx(arg0 uint64, arg1 ids.ID, arg2 ids.ShortID, arg3 []*secp256k1.PrivateKey, arg4 ids.ShortID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +221,21 @@ func (mr *MockBuilderMockRecorder) NewExportTx(arg0, arg1, arg2, arg3, arg4 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewExportTx", reflect.TypeOf((*MockBuilder)(nil).NewExportTx), arg0, arg1, arg2, arg3, arg4)
 }
 
+// NewExportTxWithKeychain mocks base method.
+func (m *MockBuilder) NewExportTxWithKeychain(arg0 uint64, arg1 ids.ID, arg2 ids.ShortID, arg3 *secp256k1fx.Keychain, arg4 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewExportTxWithKeychain", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewExportTxWithKeychain indicates an expected call of NewExportTxWithKeychain.
+func (mr *MockBuilderMockRecorder) NewExportTxWithKeychain(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewExportTxWithKeychain", reflect.TypeOf((*MockBuilder)(nil).NewExportTxWithKeychain), arg0, arg1, arg2, arg3, arg4)
+}
+
 // NewImportTx mocks base method.
 func (m *MockBuilder) NewImportTx(arg0 ids.ID, arg1 ids.ShortID, arg2 []*secp256k1.PrivateKey, arg3 ids.ShortID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +251,21 @@ func (mr *MockBuilderMockRecorder) NewImportTx(arg0, arg1, arg2, arg3 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewImportTx", reflect.TypeOf((*MockBuilder)(nil).NewImportTx), arg0, arg1, arg2, arg3)
 }
 
+// NewImportTxWithKeychain mocks base method.
+func (m *MockBuilder) NewImportTxWithKeychain(arg0 ids.ID, arg1 ids.ShortID, arg2 *secp256k1fx.Keychain, arg3 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewImportTxWithKeychain", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewImportTxWithKeychain indicates an expected call of NewImportTxWithKeychain.
+func (mr *MockBuilderMockRecorder) NewImportTxWithKeychain(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewImportTxWithKeychain", reflect.TypeOf((*MockBuilder)(nil).NewImportTxWithKeychain), arg0, arg1, arg2, arg3)
+}
+
 // NewRemoveSubnetValidatorTx mocks base method.
 func (m *MockBuilder) NewRemoveSubnetValidatorTx(arg0 ids.NodeID, arg1 ids.ID, arg2 []*secp256k1.PrivateKey, arg3 ids.ShortID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -175,6 +281,21 @@ func (mr *MockBuilderMockRecorder) NewRemoveSubnetValidatorTx(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRemoveSubnetValidatorTx", reflect.TypeOf((*MockBuilder)(nil).NewRemoveSubnetValidatorTx), arg0, arg1, arg2, arg3)
 }
 
+// NewRemoveSubnetValidatorTxWithKeychain mocks base method.
+func (m *MockBuilder) NewRemoveSubnetValidatorTxWithKeychain(arg0 ids.NodeID, arg1 ids.ID, arg2 *secp256k1fx.Keychain, arg3 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewRemoveSubnetValidatorTxWithKeychain", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewRemoveSubnetValidatorTxWithKeychain indicates an expected call of NewRemoveSubnetValidatorTxWithKeychain.
+func (mr *MockBuilderMockRecorder) NewRemoveSubnetValidatorTxWithKeychain(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRemoveSubnetValidatorTxWithKeychain", reflect.TypeOf((*MockBuilder)(nil).NewRemoveSubnetValidatorTxWithKeychain), arg0, arg1, arg2, arg3)
+}
+
 // NewRewardValidatorTx mocks base method.
 func (m *MockBuilder) NewRewardValidatorTx(arg0 ids.ID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
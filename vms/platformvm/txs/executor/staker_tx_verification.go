@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
@@ -684,14 +685,11 @@ func verifyAddPermissionlessDelegatorTx(
 		)
 	}
 
-	maximumWeight, err := math.Mul64(
-		uint64(delegatorRules.maxValidatorWeightFactor),
+	maximumWeight := maxValidatorWeight(
+		delegatorRules.maxValidatorWeightFactor,
+		delegatorRules.maxValidatorStake,
 		validator.Weight,
 	)
-	if err != nil {
-		maximumWeight = stdmath.MaxUint64
-	}
-	maximumWeight = math.Min(maximumWeight, delegatorRules.maxValidatorStake)
 
 	txID := sTx.ID()
 	newStaker, err := state.NewPendingStaker(txID, tx)
@@ -760,6 +758,50 @@ func verifyAddPermissionlessDelegatorTx(
 	return nil
 }
 
+// GetMaxValidatorWeight returns the maximum total weight, including its own
+// weight, that delegations are allowed to bring [validator] to.
+//
+// Invariant: [validator] is a permissionless validator.
+func GetMaxValidatorWeight(
+	cfg *config.Config,
+	chainState state.Chain,
+	validator *state.Staker,
+) (uint64, error) {
+	if validator.SubnetID == constants.PrimaryNetworkID {
+		return maxValidatorWeight(
+			MaxValidatorWeightFactor,
+			cfg.MaxValidatorStake,
+			validator.Weight,
+		), nil
+	}
+
+	transformSubnetIntf, err := chainState.GetSubnetTransformation(validator.SubnetID)
+	if err != nil {
+		return 0, err
+	}
+	transformSubnet, ok := transformSubnetIntf.Unsigned.(*txs.TransformSubnetTx)
+	if !ok {
+		return 0, ErrIsNotTransformSubnetTx
+	}
+	return maxValidatorWeight(
+		transformSubnet.MaxValidatorWeightFactor,
+		transformSubnet.MaxValidatorStake,
+		validator.Weight,
+	), nil
+}
+
+func maxValidatorWeight(
+	maxValidatorWeightFactor byte,
+	maxValidatorStake uint64,
+	validatorWeight uint64,
+) uint64 {
+	maximumWeight, err := math.Mul64(uint64(maxValidatorWeightFactor), validatorWeight)
+	if err != nil {
+		maximumWeight = stdmath.MaxUint64
+	}
+	return math.Min(maximumWeight, maxValidatorStake)
+}
+
 type addDelegatorRules struct {
 	assetID                  ids.ID
 	minDelegatorStake        uint64
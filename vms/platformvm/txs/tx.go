@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -44,6 +45,15 @@ func NewSigned(
 	return res, res.Sign(c, signers)
 }
 
+func NewSignedWithKeychainSigners(
+	unsigned UnsignedTx,
+	c codec.Manager,
+	signers [][]keychain.Signer,
+) (*Tx, error) {
+	res := &Tx{Unsigned: unsigned}
+	return res, res.SignWithKeychainSigners(c, signers)
+}
+
 func (tx *Tx) Initialize(c codec.Manager) error {
 	signedBytes, err := c.Marshal(Version, tx)
 	if err != nil {
@@ -125,6 +135,19 @@ func (tx *Tx) SyntacticVerify(ctx *snow.Context) error {
 // Note: We explicitly pass the codec in Sign since we may need to sign P-Chain
 // genesis txs whose length exceed the max length of txs.Codec.
 func (tx *Tx) Sign(c codec.Manager, signers [][]*secp256k1.PrivateKey) error {
+	keychainSigners := make([][]keychain.Signer, len(signers))
+	for i, keys := range signers {
+		keychainSigners[i] = make([]keychain.Signer, len(keys))
+		for j, key := range keys {
+			keychainSigners[i][j] = key
+		}
+	}
+	return tx.SignWithKeychainSigners(c, keychainSigners)
+}
+
+// SignWithKeychainSigners signs this transaction with the provided signers,
+// whose keys may be held outside of this process.
+func (tx *Tx) SignWithKeychainSigners(c codec.Manager, signers [][]keychain.Signer) error {
 	unsignedBytes, err := c.Marshal(Version, &tx.Unsigned)
 	if err != nil {
 		return fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
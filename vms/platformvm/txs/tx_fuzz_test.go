@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func FuzzParse(f *testing.F) {
+	ctx := snow.DefaultContextTest()
+	signers := [][]*secp256k1.PrivateKey{{preFundedKeys[0]}}
+
+	baseTx := BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    ctx.NetworkID,
+		BlockchainID: ctx.ChainID,
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{
+				TxID:        ids.ID{'t', 'x', 'I', 'D'},
+				OutputIndex: 2,
+			},
+			Asset: avax.Asset{ID: ctx.AVAXAssetID},
+			In: &secp256k1fx.TransferInput{
+				Amt:   5678,
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		}},
+		Outs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1234,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
+				},
+			},
+		}},
+		Memo: []byte{1, 2, 3},
+	}}
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{preFundedKeys[1].PublicKey().Address()},
+	}
+
+	// Seed the corpus with the most commonly issued transaction types.
+	for _, utx := range []UnsignedTx{
+		&CreateSubnetTx{
+			BaseTx: baseTx,
+			Owner:  owner,
+		},
+		&AddSubnetValidatorTx{
+			BaseTx: baseTx,
+			SubnetValidator: SubnetValidator{
+				Validator: Validator{
+					NodeID: ctx.NodeID,
+					Start:  uint64(time.Unix(1, 0).Unix()),
+					End:    uint64(time.Unix(1, 0).Add(time.Hour).Unix()),
+					Wght:   2022,
+				},
+				Subnet: ids.ID{'s', 'u', 'b', 'n', 'e', 't'},
+			},
+			SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+		},
+		&AddDelegatorTx{
+			BaseTx: baseTx,
+			Validator: Validator{
+				NodeID: ctx.NodeID,
+				Start:  uint64(time.Unix(1, 0).Unix()),
+				End:    uint64(time.Unix(1, 0).Add(time.Hour).Unix()),
+				Wght:   1234,
+			},
+			StakeOuts:              baseTx.Outs,
+			DelegationRewardsOwner: owner,
+		},
+		&ExportTx{
+			BaseTx:           baseTx,
+			DestinationChain: ctx.XChainID,
+			ExportedOutputs:  baseTx.Outs,
+		},
+	} {
+		tx, err := NewSigned(utx, Codec, signers)
+		require.NoError(f, err)
+		f.Add(tx.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, txBytes []byte) {
+		require := require.New(t)
+
+		tx, err := Parse(Codec, txBytes)
+		if err != nil {
+			return
+		}
+
+		// Parsing is expected to only succeed on canonically encoded
+		// transactions.
+		reencodedBytes, err := Codec.Marshal(Version, tx)
+		require.NoError(err)
+		require.Equal(txBytes, reencodedBytes)
+
+		_ = tx.SyntacticVerify(ctx)
+	})
+}
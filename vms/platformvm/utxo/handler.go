@@ -11,10 +11,10 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/math"
-	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
@@ -69,6 +69,22 @@ type Spender interface {
 		error,
 	)
 
+	// SpendWithKeychain is Spend, where the owners of the funds are the
+	// signers held by [kc].
+	SpendWithKeychain(
+		utxoReader avax.UTXOReader,
+		kc *secp256k1fx.Keychain,
+		amount uint64,
+		fee uint64,
+		changeAddr ids.ShortID,
+	) (
+		[]*avax.TransferableInput, // inputs
+		[]*avax.TransferableOutput, // returnedOutputs
+		[]*avax.TransferableOutput, // stakedOutputs
+		[][]keychain.Signer, // signers
+		error,
+	)
+
 	// Authorize an operation on behalf of the named subnet with the provided
 	// keys.
 	Authorize(
@@ -80,6 +96,18 @@ type Spender interface {
 		[]*secp256k1.PrivateKey, // Keys that prove ownership
 		error,
 	)
+
+	// Authorize an operation on behalf of the named subnet with the signers
+	// held by [kc].
+	AuthorizeWithKeychain(
+		state state.Chain,
+		subnetID ids.ID,
+		kc *secp256k1fx.Keychain,
+	) (
+		verify.Verifiable, // Input that names owners
+		[]keychain.Signer, // Keys that prove ownership
+		error,
+	)
 }
 
 type Verifier interface {
@@ -159,24 +187,43 @@ func (h *handler) Spend(
 	[][]*secp256k1.PrivateKey, // signers
 	error,
 ) {
-	addrs := set.NewSet[ids.ShortID](len(keys)) // The addresses controlled by [keys]
-	for _, key := range keys {
-		addrs.Add(key.PublicKey().Address())
+	kc := secp256k1fx.NewKeychain(keys...)
+	ins, returnedOuts, stakedOuts, signers, err := h.SpendWithKeychain(utxoReader, kc, amount, fee, changeAddr)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	keySigners := make([][]*secp256k1.PrivateKey, len(signers))
+	for i, inputSigners := range signers {
+		keySigners[i] = toPrivateKeys(inputSigners)
 	}
-	utxos, err := avax.GetAllUTXOs(utxoReader, addrs) // The UTXOs controlled by [keys]
+	return ins, returnedOuts, stakedOuts, keySigners, nil
+}
+
+func (h *handler) SpendWithKeychain(
+	utxoReader avax.UTXOReader,
+	kc *secp256k1fx.Keychain,
+	amount uint64,
+	fee uint64,
+	changeAddr ids.ShortID,
+) (
+	[]*avax.TransferableInput, // inputs
+	[]*avax.TransferableOutput, // returnedOutputs
+	[]*avax.TransferableOutput, // stakedOutputs
+	[][]keychain.Signer, // signers
+	error,
+) {
+	utxos, err := avax.GetAllUTXOs(utxoReader, kc.Addresses()) // The UTXOs controlled by [kc]
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
 	}
 
-	kc := secp256k1fx.NewKeychain(keys...) // Keychain consumes UTXOs and creates new ones
-
 	// Minimum time this transaction will be issued at
 	now := uint64(h.clk.Time().Unix())
 
 	ins := []*avax.TransferableInput{}
 	returnedOuts := []*avax.TransferableOutput{}
 	stakedOuts := []*avax.TransferableOutput{}
-	signers := [][]*secp256k1.PrivateKey{}
+	signers := [][]keychain.Signer{}
 
 	// Amount of AVAX that has been staked
 	amountStaked := uint64(0)
@@ -211,7 +258,7 @@ func (h *handler) Spend(
 			continue
 		}
 
-		inIntf, inSigners, err := kc.Spend(out.TransferableOut, now)
+		inIntf, inSigners, err := kc.SpendSigners(out.TransferableOut, now)
 		if err != nil {
 			// We couldn't spend the output, so move on to the next one
 			continue
@@ -304,7 +351,7 @@ func (h *handler) Spend(
 			out = inner.TransferableOut
 		}
 
-		inIntf, inSigners, err := kc.Spend(out, now)
+		inIntf, inSigners, err := kc.SpendSigners(out, now)
 		if err != nil {
 			// We couldn't spend this UTXO, so we skip to the next one
 			continue
@@ -383,9 +430,9 @@ func (h *handler) Spend(
 		)
 	}
 
-	avax.SortTransferableInputsWithSigners(ins, signers)  // sort inputs and keys
-	avax.SortTransferableOutputs(returnedOuts, txs.Codec) // sort outputs
-	avax.SortTransferableOutputs(stakedOuts, txs.Codec)   // sort outputs
+	avax.SortTransferableInputsWithKeychainSigners(ins, signers) // sort inputs and keys
+	avax.SortTransferableOutputs(returnedOuts, txs.Codec)        // sort outputs
+	avax.SortTransferableOutputs(stakedOuts, txs.Codec)          // sort outputs
 
 	return ins, returnedOuts, stakedOuts, signers, nil
 }
@@ -398,6 +445,23 @@ func (h *handler) Authorize(
 	verify.Verifiable, // Input that names owners
 	[]*secp256k1.PrivateKey, // Keys that prove ownership
 	error,
+) {
+	kc := secp256k1fx.NewKeychain(keys...)
+	input, signers, err := h.AuthorizeWithKeychain(state, subnetID, kc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return input, toPrivateKeys(signers), nil
+}
+
+func (h *handler) AuthorizeWithKeychain(
+	state state.Chain,
+	subnetID ids.ID,
+	kc *secp256k1fx.Keychain,
+) (
+	verify.Verifiable, // Input that names owners
+	[]keychain.Signer, // Keys that prove ownership
+	error,
 ) {
 	subnetOwner, err := state.GetSubnetOwner(subnetID)
 	if err != nil {
@@ -414,14 +478,11 @@ func (h *handler) Authorize(
 		return nil, nil, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnetOwner)
 	}
 
-	// Add the keys to a keychain
-	kc := secp256k1fx.NewKeychain(keys...)
-
 	// Make sure that the operation is valid after a minimum time
 	now := uint64(h.clk.Time().Unix())
 
 	// Attempt to prove ownership of the subnet
-	indices, signers, matches := kc.Match(owner, now)
+	indices, signers, matches := kc.MatchSigners(owner, now)
 	if !matches {
 		return nil, nil, errCantSign
 	}
@@ -669,3 +730,13 @@ func (h *handler) VerifySpendUTXOs(
 	}
 	return nil
 }
+
+// toPrivateKeys returns the private keys of [signers]. A keychain that was
+// created from private keys only returns those keys as signers.
+func toPrivateKeys(signers []keychain.Signer) []*secp256k1.PrivateKey {
+	keys := make([]*secp256k1.PrivateKey, len(signers))
+	for i, signer := range signers {
+		keys[i] = signer.(*secp256k1.PrivateKey)
+	}
+	return keys
+}
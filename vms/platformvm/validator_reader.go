@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+var errStillBootstrapping = errors.New("still bootstrapping")
+
+// LockedValidatorReader reads the current validators of the P-chain from
+// within the node, rather than through the P-chain API. Validators can only
+// be read once the P-chain has bootstrapped.
+type LockedValidatorReader struct {
+	lock sync.RWMutex
+	vm   *VM
+}
+
+func NewLockedValidatorReader() *LockedValidatorReader {
+	return &LockedValidatorReader{}
+}
+
+// GetCurrentValidator returns the current validator [nodeID] of [subnetID].
+// Returns database.ErrNotFound if [nodeID] isn't a current validator of
+// [subnetID].
+func (r *LockedValidatorReader) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*state.Staker, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if r.vm == nil || !r.vm.bootstrapped.Get() {
+		return nil, errStillBootstrapping
+	}
+
+	r.vm.ctx.Lock.Lock()
+	defer r.vm.ctx.Lock.Unlock()
+
+	return r.vm.state.GetCurrentValidator(subnetID, nodeID)
+}
+
+func (r *LockedValidatorReader) setVM(vm *VM) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.vm = vm
+}
@@ -18,7 +18,9 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -37,12 +39,14 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/index"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	blockbuilder "github.com/ava-labs/avalanchego/vms/platformvm/blocks/builder"
@@ -53,12 +57,18 @@ import (
 )
 
 var (
-	_ block.ChainVM              = (*VM)(nil)
-	_ secp256k1fx.VM             = (*VM)(nil)
-	_ validators.State           = (*VM)(nil)
-	_ validators.SubnetConnector = (*VM)(nil)
+	_ block.ChainVM               = (*VM)(nil)
+	_ block.PreVerifierChainVM    = (*VM)(nil)
+	_ block.DurableChainVM        = (*VM)(nil)
+	_ common.ConcurrentAppHandler = (*VM)(nil)
+	_ secp256k1fx.VM              = (*VM)(nil)
+	_ validators.State            = (*VM)(nil)
+	_ validators.SubnetConnector  = (*VM)(nil)
 
 	errMissingValidatorSet = errors.New("missing validator set")
+	errUnexpectedBlockType = errors.New("unexpected block type")
+
+	addressTxsPrefix = []byte("addressTxs")
 )
 
 type VM struct {
@@ -89,6 +99,12 @@ type VM struct {
 	txBuilder txbuilder.Builder
 	manager   blockexecutor.Manager
 
+	// Indexes accepted txs by address. Nil if indexing is disabled.
+	addressTxsIndexer index.AddressTxsIndexer
+
+	// Caches the canonical validator sets used to verify warp messages
+	warpValidators *warp.CachedValidatorState
+
 	// TODO: Remove after v1.11.x is activated
 	pruned utils.Atomic[bool]
 }
@@ -129,10 +145,18 @@ func (vm *VM) Initialize(
 	vm.dbManager = dbManager
 
 	vm.codecRegistry = linearcodec.NewDefault()
-	vm.fx = &secp256k1fx.Fx{}
-	if err := vm.fx.Initialize(vm); err != nil {
+	secpFx := &secp256k1fx.Fx{}
+	if err := secpFx.Initialize(vm); err != nil {
+		return err
+	}
+	// Signatures verified during mempool admission are cached so that they
+	// aren't re-verified during block verification.
+	sigCache, err := secp256k1fx.NewSigCache(secp256k1fx.DefaultSigCacheSize, "", registerer)
+	if err != nil {
 		return err
 	}
+	secpFx.SetSigCache(sigCache)
+	vm.fx = secpFx
 
 	rewards := reward.NewCalculator(vm.RewardConfig)
 
@@ -151,8 +175,23 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	if execConfig.IndexTransactions {
+		if err := vm.initAddressTxsIndexer(execConfig.IndexAllowIncomplete, registerer); err != nil {
+			return fmt.Errorf("failed to initialize address transaction indexer: %w", err)
+		}
+	}
+
 	validatorManager := pvalidators.NewManager(chainCtx.Log, vm.Config, vm.state, vm.metrics, &vm.clock)
 	vm.State = validatorManager
+	vm.warpValidators, err = warp.NewCachedValidatorState(
+		validatorManager,
+		warp.DefaultValidatorSetCacheSize,
+		"",
+		registerer,
+	)
+	if err != nil {
+		return err
+	}
 	vm.atomicUtxosManager = avax.NewAtomicUTXOManager(chainCtx.SharedMemory, txs.Codec)
 	utxoHandler := utxo.NewHandler(vm.ctx, &vm.clock, vm.fx)
 	vm.uptimeManager = uptime.NewManager(vm.state)
@@ -192,6 +231,7 @@ func (vm *VM) Initialize(
 		vm.state,
 		txExecutorBackend,
 		validatorManager,
+		vm.addressTxsIndexer,
 	)
 	vm.Builder = blockbuilder.New(
 		mempool,
@@ -245,6 +285,25 @@ func (vm *VM) Initialize(
 	return nil
 }
 
+// initAddressTxsIndexer initializes the index of accepted txs by address.
+func (vm *VM) initAddressTxsIndexer(allowIncomplete bool, registerer prometheus.Registerer) error {
+	lastAcceptedID := vm.state.GetLastAccepted()
+	lastAccepted, err := vm.state.GetStatelessBlock(lastAcceptedID)
+	if err != nil {
+		return err
+	}
+
+	vm.ctx.Log.Info("address transaction indexing is enabled")
+	vm.addressTxsIndexer, err = index.NewIndexer(
+		prefixdb.New(addressTxsPrefix, vm.dbManager.Current().Database),
+		lastAccepted.Height(),
+		allowIncomplete,
+		"",
+		registerer,
+	)
+	return err
+}
+
 // Create all chains that exist that this node validates.
 func (vm *VM) initBlockchains() error {
 	if vm.Config.PartialSyncPrimaryNetwork {
@@ -384,6 +443,17 @@ func (vm *VM) Shutdown(context.Context) error {
 	return errs.Err
 }
 
+// PreVerifyBlock syntactically verifies the txs of [blk]. Blocks are only
+// pre-verified while bootstrapping, when signatures aren't verified, so there
+// are no other checks that can be performed without the chain state.
+func (vm *VM) PreVerifyBlock(_ context.Context, blk snowman.Block) error {
+	b, ok := blk.(*blockexecutor.Block)
+	if !ok {
+		return fmt.Errorf("%w: %T", errUnexpectedBlockType, blk)
+	}
+	return b.PreVerify()
+}
+
 func (vm *VM) ParseBlock(_ context.Context, b []byte) (snowman.Block, error) {
 	// Note: blocks to be parsed are not verified, so we must used blocks.Codec
 	// rather than blocks.GenesisCodec
@@ -403,6 +473,17 @@ func (vm *VM) LastAccepted(context.Context) (ids.ID, error) {
 	return vm.manager.LastAccepted(), nil
 }
 
+// LastDurableHeight returns the height of the block most recently accepted.
+// Blocks are committed to the database before Accept returns, so every
+// accepted block is durable.
+func (vm *VM) LastDurableHeight(context.Context) (uint64, error) {
+	blk, err := vm.manager.GetBlock(vm.manager.LastAccepted())
+	if err != nil {
+		return 0, err
+	}
+	return blk.Height(), nil
+}
+
 // SetPreference sets the preferred block to be the one with ID [blkID]
 func (vm *VM) SetPreference(_ context.Context, blkID ids.ID) error {
 	vm.Builder.SetPreference(blkID)
@@ -435,9 +516,16 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 		return nil, err
 	}
 
+	// Requests that are served from the state snapshot don't contend with
+	// block execution for the chain's lock. Every other request holds the
+	// lock.
 	return map[string]*common.HTTPHandler{
 		"": {
-			Handler: server,
+			LockOptions: common.NoLock,
+			Handler: &lockedHandler{
+				lock:    &vm.ctx.Lock,
+				handler: server,
+			},
 		},
 	}, nil
 }
@@ -461,7 +549,19 @@ func (*VM) CreateStaticHandlers(context.Context) (map[string]*common.HTTPHandler
 	}, nil
 }
 
-func (vm *VM) Connected(_ context.Context, nodeID ids.NodeID, _ *version.Application) error {
+// AppConcurrency handles gossiped txs on a dedicated goroutine. Gossiped txs
+// are verified while holding the context lock, so this keeps bursts of gossip
+// from delaying the responses to tx requests.
+func (*VM) AppConcurrency() map[message.Op]int {
+	return map[message.Op]int{
+		message.AppGossipOp: 1,
+	}
+}
+
+func (vm *VM) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
+	if err := vm.Builder.Connected(ctx, nodeID, nodeVersion); err != nil {
+		return err
+	}
 	return vm.uptimeManager.Connect(nodeID, constants.PrimaryNetworkID)
 }
 
@@ -469,7 +569,10 @@ func (vm *VM) ConnectedSubnet(_ context.Context, nodeID ids.NodeID, subnetID ids
 	return vm.uptimeManager.Connect(nodeID, subnetID)
 }
 
-func (vm *VM) Disconnected(_ context.Context, nodeID ids.NodeID) error {
+func (vm *VM) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	if err := vm.Builder.Disconnected(ctx, nodeID); err != nil {
+		return err
+	}
 	if err := vm.uptimeManager.Disconnect(nodeID); err != nil {
 		return err
 	}
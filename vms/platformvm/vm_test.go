@@ -476,6 +476,10 @@ func TestAddValidatorCommit(t *testing.T) {
 	require.NoError(blk.Verify(context.Background()))
 	require.NoError(blk.Accept(context.Background()))
 
+	durableHeight, err := vm.LastDurableHeight(context.Background())
+	require.NoError(err)
+	require.Equal(blk.Height(), durableHeight)
+
 	_, txStatus, err := vm.state.GetTx(tx.ID())
 	require.NoError(err)
 	require.Equal(status.Committed, txStatus)
@@ -1563,7 +1567,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 	chainRouter := &router.ChainRouter{}
 
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(logging.NoLog{}, metrics, "dummyNamespace", constants.DefaultNetworkCompressionType, 10*time.Second)
+	mc, err := message.NewCreator(logging.NoLog{}, metrics, "dummyNamespace", constants.DefaultNetworkCompressionType, constants.DefaultNetworkCompressionZstdLevel, 10*time.Second)
 	require.NoError(err)
 
 	require.NoError(chainRouter.Initialize(
@@ -1598,6 +1602,8 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		timeoutManager,
 		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
 		subnets.New(consensusCtx.NodeID, subnets.Config{GossipConfig: gossipConfig}),
+		beacons,
+		nil,
 	)
 	require.NoError(err)
 
@@ -1669,6 +1675,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		msgChan,
 		time.Hour,
 		2,
+		nil,
 		cpuTracker,
 		vm,
 		subnets.New(ctx.NodeID, subnets.Config{}),
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package payload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestAddressedCall(t *testing.T) {
+	require := require.New(t)
+	shortID := ids.GenerateTestShortID()
+
+	addressedPayload, err := NewAddressedCall(
+		shortID[:],
+		[]byte{1, 2, 3},
+	)
+	require.NoError(err)
+
+	addressedPayloadBytes := addressedPayload.Bytes()
+	parsedAddressedPayload, err := ParseAddressedCall(addressedPayloadBytes)
+	require.NoError(err)
+	require.Equal(addressedPayload, parsedAddressedPayload)
+}
+
+func TestParseAddressedCallJunk(t *testing.T) {
+	_, err := ParseAddressedCall([]byte{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	require.ErrorIs(t, err, codec.ErrUnknownVersion)
+}
+
+func TestAddressedCallBytes(t *testing.T) {
+	require := require.New(t)
+
+	addressedPayload, err := NewAddressedCall(
+		[]byte{1, 2},
+		[]byte{3},
+	)
+	require.NoError(err)
+	require.Equal(
+		[]byte{
+			// codec version
+			0x00, 0x00,
+			// type ID
+			0x00, 0x00, 0x00, 0x01,
+			// source address length
+			0x00, 0x00, 0x00, 0x02,
+			// source address
+			0x01, 0x02,
+			// payload length
+			0x00, 0x00, 0x00, 0x01,
+			// payload
+			0x03,
+		},
+		addressedPayload.Bytes(),
+	)
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package payload
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var _ Payload = (*Hash)(nil)
+
+// Hash defines the format for delivering the hash of a value across VMs.
+type Hash struct {
+	Hash ids.ID `serialize:"true"`
+
+	bytes []byte
+}
+
+// NewHash creates a new *Hash and initializes it.
+func NewHash(hash ids.ID) (*Hash, error) {
+	h := &Hash{
+		Hash: hash,
+	}
+	return h, initialize(h)
+}
+
+// ParseHash converts a slice of bytes into an initialized *Hash.
+func ParseHash(b []byte) (*Hash, error) {
+	payloadIntf, err := Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	payload, ok := payloadIntf.(*Hash)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errWrongType, payloadIntf)
+	}
+	return payload, nil
+}
+
+// Bytes returns the binary representation of this payload. It assumes that the
+// payload is initialized from either NewHash or Parse.
+func (h *Hash) Bytes() []byte {
+	return h.bytes
+}
+
+func (h *Hash) initialize(bytes []byte) {
+	h.bytes = bytes
+}
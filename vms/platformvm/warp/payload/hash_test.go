@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package payload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestHash(t *testing.T) {
+	require := require.New(t)
+
+	hashPayload, err := NewHash(ids.GenerateTestID())
+	require.NoError(err)
+
+	hashPayloadBytes := hashPayload.Bytes()
+	parsedHashPayload, err := ParseHash(hashPayloadBytes)
+	require.NoError(err)
+	require.Equal(hashPayload, parsedHashPayload)
+}
+
+func TestParseHashJunk(t *testing.T) {
+	_, err := ParseHash([]byte{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	require.ErrorIs(t, err, codec.ErrUnknownVersion)
+}
+
+func TestParseWrongPayloadType(t *testing.T) {
+	require := require.New(t)
+
+	hashPayload, err := NewHash(ids.GenerateTestID())
+	require.NoError(err)
+
+	_, err = ParseAddressedCall(hashPayload.Bytes())
+	require.ErrorIs(err, errWrongType)
+
+	shortID := ids.GenerateTestShortID()
+	addressedPayload, err := NewAddressedCall(
+		shortID[:],
+		[]byte{1, 2, 3},
+	)
+	require.NoError(err)
+
+	_, err = ParseHash(addressedPayload.Bytes())
+	require.ErrorIs(err, errWrongType)
+}
@@ -83,7 +83,7 @@ func (s *BitSetSignature) Verify(
 		return err
 	}
 
-	vdrs, totalWeight, err := GetCanonicalValidatorSet(ctx, pChainState, pChainHeight, subnetID)
+	vdrSet, err := getCanonicalValidatorSet(ctx, pChainState, pChainHeight, subnetID)
 	if err != nil {
 		return err
 	}
@@ -99,7 +99,7 @@ func (s *BitSetSignature) Verify(
 	}
 
 	// Get the validators that (allegedly) signed the message.
-	signers, err := FilterValidators(signerIndices, vdrs)
+	signers, err := FilterValidators(signerIndices, vdrSet.Validators)
 	if err != nil {
 		return err
 	}
@@ -110,7 +110,7 @@ func (s *BitSetSignature) Verify(
 	// Make sure the signature's weight is sufficient.
 	err = VerifyWeight(
 		sigWeight,
-		totalWeight,
+		vdrSet.TotalWeight,
 		quorumNum,
 		quorumDen,
 	)
@@ -124,10 +124,14 @@ func (s *BitSetSignature) Verify(
 		return fmt.Errorf("%w: %w", ErrParseSignature, err)
 	}
 
-	// Create the aggregate public key
-	aggPubKey, err := AggregatePublicKeys(signers)
-	if err != nil {
-		return err
+	// Create the aggregate public key. If every validator signed the message,
+	// the aggregate public key of the validator set can be used directly.
+	aggPubKey := vdrSet.AggregatePublicKey
+	if len(signers) != len(vdrSet.Validators) || aggPubKey == nil {
+		aggPubKey, err = AggregatePublicKeys(signers)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Verify the signature
@@ -46,11 +46,28 @@ func (v *Validator) Less(o *Validator) bool {
 // GetCanonicalValidatorSet returns the validator set of [subnetID] at
 // [pChcainHeight] in a canonical ordering. Also returns the total weight on
 // [subnetID].
+//
+// If [pChainState] implements [CanonicalValidatorState], it is used to provide
+// the validator set. In this case the returned validators may be shared and
+// must not be modified.
 func GetCanonicalValidatorSet(
 	ctx context.Context,
 	pChainState ValidatorState,
 	pChainHeight uint64,
 	subnetID ids.ID,
+) ([]*Validator, uint64, error) {
+	vdrSet, err := getCanonicalValidatorSet(ctx, pChainState, pChainHeight, subnetID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return vdrSet.Validators, vdrSet.TotalWeight, nil
+}
+
+func calculateCanonicalValidatorSet(
+	ctx context.Context,
+	pChainState ValidatorState,
+	pChainHeight uint64,
+	subnetID ids.ID,
 ) ([]*Validator, uint64, error) {
 	// Get the validator set at the given height.
 	vdrSet, err := pChainState.GetValidatorSet(ctx, pChainHeight, subnetID)
@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// DefaultValidatorSetCacheSize is the default number of canonical validator
+// sets that are remembered by a CachedValidatorState.
+const DefaultValidatorSetCacheSize = 128
+
+var (
+	_ validators.State        = (*CachedValidatorState)(nil)
+	_ CanonicalValidatorState = (*CachedValidatorState)(nil)
+)
+
+// CanonicalValidatorSet is the validator set of a subnet at a P-chain height
+// in the form required to verify warp signatures.
+//
+// A CanonicalValidatorSet may be shared between callers, so it must not be
+// modified.
+type CanonicalValidatorSet struct {
+	// Validators with public keys, in canonical ordering.
+	Validators []*Validator
+	// TotalWeight of the subnet, including the validators without public
+	// keys.
+	TotalWeight uint64
+	// AggregatePublicKey of all of [Validators]. It is nil if there are no
+	// validators with public keys.
+	AggregatePublicKey *bls.PublicKey
+}
+
+// CanonicalValidatorState is implemented by validator states that are able to
+// provide canonical validator sets directly, for example by caching them.
+type CanonicalValidatorState interface {
+	// GetCanonicalValidatorSet returns the canonical validator set of
+	// [subnetID] at [pChainHeight].
+	GetCanonicalValidatorSet(
+		ctx context.Context,
+		pChainHeight uint64,
+		subnetID ids.ID,
+	) (*CanonicalValidatorSet, error)
+}
+
+type validatorSetKey struct {
+	pChainHeight uint64
+	subnetID     ids.ID
+}
+
+// CachedValidatorState caches the canonical validator sets of the wrapped
+// validator state. Because the validator set of a subnet can't change at a
+// height that has already been accepted, cached sets never need to be
+// invalidated.
+//
+// CachedValidatorState is safe for concurrent use if the wrapped validator
+// state is.
+type CachedValidatorState struct {
+	validators.State
+
+	validatorSets cache.Cacher[validatorSetKey, *CanonicalValidatorSet]
+
+	hits   prometheus.Counter
+	misses prometheus.Counter
+}
+
+func NewCachedValidatorState(
+	state validators.State,
+	size int,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*CachedValidatorState, error) {
+	s := &CachedValidatorState{
+		State:         state,
+		validatorSets: &cache.LRU[validatorSetKey, *CanonicalValidatorSet]{Size: size},
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "warp_validator_set_cache_hits",
+			Help:      "Number of canonical validator sets that were served from the cache",
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "warp_validator_set_cache_misses",
+			Help:      "Number of canonical validator sets that needed to be calculated",
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(s.hits),
+		registerer.Register(s.misses),
+	)
+	return s, errs.Err
+}
+
+func (s *CachedValidatorState) GetCanonicalValidatorSet(
+	ctx context.Context,
+	pChainHeight uint64,
+	subnetID ids.ID,
+) (*CanonicalValidatorSet, error) {
+	key := validatorSetKey{
+		pChainHeight: pChainHeight,
+		subnetID:     subnetID,
+	}
+	if vdrSet, ok := s.validatorSets.Get(key); ok {
+		s.hits.Inc()
+		return vdrSet, nil
+	}
+	s.misses.Inc()
+
+	vdrSet, err := newCanonicalValidatorSet(ctx, s.State, pChainHeight, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	s.validatorSets.Put(key, vdrSet)
+	return vdrSet, nil
+}
+
+// getCanonicalValidatorSet returns the canonical validator set of [subnetID]
+// at [pChainHeight], using [pChainState] to provide it if possible.
+func getCanonicalValidatorSet(
+	ctx context.Context,
+	pChainState ValidatorState,
+	pChainHeight uint64,
+	subnetID ids.ID,
+) (*CanonicalValidatorSet, error) {
+	if state, ok := pChainState.(CanonicalValidatorState); ok {
+		return state.GetCanonicalValidatorSet(ctx, pChainHeight, subnetID)
+	}
+	return newCanonicalValidatorSet(ctx, pChainState, pChainHeight, subnetID)
+}
+
+func newCanonicalValidatorSet(
+	ctx context.Context,
+	pChainState ValidatorState,
+	pChainHeight uint64,
+	subnetID ids.ID,
+) (*CanonicalValidatorSet, error) {
+	vdrs, totalWeight, err := calculateCanonicalValidatorSet(ctx, pChainState, pChainHeight, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	vdrSet := &CanonicalValidatorSet{
+		Validators:  vdrs,
+		TotalWeight: totalWeight,
+	}
+	if len(vdrs) == 0 {
+		return vdrSet, nil
+	}
+
+	vdrSet.AggregatePublicKey, err = AggregatePublicKeys(vdrs)
+	return vdrSet, err
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestCachedValidatorState(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	nodeIDWithoutKey := ids.GenerateTestNodeID()
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+		testVdrs[0].nodeID: {
+			NodeID:    testVdrs[0].nodeID,
+			PublicKey: testVdrs[0].vdr.PublicKey,
+			Weight:    testVdrs[0].vdr.Weight,
+		},
+		testVdrs[1].nodeID: {
+			NodeID:    testVdrs[1].nodeID,
+			PublicKey: testVdrs[1].vdr.PublicKey,
+			Weight:    testVdrs[1].vdr.Weight,
+		},
+		nodeIDWithoutKey: {
+			NodeID: nodeIDWithoutKey,
+			Weight: 5,
+		},
+	}
+
+	state := validators.NewMockState(ctrl)
+	state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight, subnetID).Return(nil, errTest)
+	state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight, subnetID).Return(vdrs, nil)
+
+	cachedState, err := NewCachedValidatorState(state, DefaultValidatorSetCacheSize, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	// Errors aren't cached.
+	_, err = cachedState.GetCanonicalValidatorSet(context.Background(), pChainHeight, subnetID)
+	require.ErrorIs(err, errTest)
+
+	vdrSet, err := cachedState.GetCanonicalValidatorSet(context.Background(), pChainHeight, subnetID)
+	require.NoError(err)
+	require.Equal([]*Validator{testVdrs[0].vdr, testVdrs[1].vdr}, vdrSet.Validators)
+	require.Equal(testVdrs[0].vdr.Weight+testVdrs[1].vdr.Weight+5, vdrSet.TotalWeight)
+
+	expectedPK, err := bls.AggregatePublicKeys([]*bls.PublicKey{
+		testVdrs[0].vdr.PublicKey,
+		testVdrs[1].vdr.PublicKey,
+	})
+	require.NoError(err)
+	require.Equal(bls.PublicKeyToBytes(expectedPK), bls.PublicKeyToBytes(vdrSet.AggregatePublicKey))
+
+	// The second lookup is served from the cache, so the mock isn't called.
+	canonicalVdrs, totalWeight, err := GetCanonicalValidatorSet(context.Background(), cachedState, pChainHeight, subnetID)
+	require.NoError(err)
+	require.Equal(vdrSet.Validators, canonicalVdrs)
+	require.Equal(vdrSet.TotalWeight, totalWeight)
+
+	require.Equal(float64(1), testutil.ToFloat64(cachedState.hits))
+	require.Equal(float64(2), testutil.ToFloat64(cachedState.misses))
+}
+
+func TestCachedValidatorStateNoPublicKeys(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	state := validators.NewMockState(ctrl)
+	state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight, subnetID).Return(
+		map[ids.NodeID]*validators.GetValidatorOutput{
+			nodeID: {
+				NodeID: nodeID,
+				Weight: 5,
+			},
+		},
+		nil,
+	)
+
+	cachedState, err := NewCachedValidatorState(state, DefaultValidatorSetCacheSize, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	vdrSet, err := cachedState.GetCanonicalValidatorSet(context.Background(), pChainHeight, subnetID)
+	require.NoError(err)
+	require.Empty(vdrSet.Validators)
+	require.Equal(uint64(5), vdrSet.TotalWeight)
+	require.Nil(vdrSet.AggregatePublicKey)
+}
+
+func TestSignatureVerificationCachedState(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput, len(testVdrs))
+	for _, vdr := range testVdrs {
+		vdrs[vdr.nodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdr.nodeID,
+			PublicKey: vdr.vdr.PublicKey,
+			Weight:    vdr.vdr.Weight,
+		}
+	}
+
+	state := validators.NewMockState(ctrl)
+	state.EXPECT().GetSubnetID(gomock.Any(), sourceChainID).Return(subnetID, nil).Times(3)
+	state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight, subnetID).Return(vdrs, nil)
+
+	cachedState, err := NewCachedValidatorState(state, DefaultValidatorSetCacheSize, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		sourceChainID,
+		[]byte{1, 2, 3},
+	)
+	require.NoError(err)
+	unsignedBytes := unsignedMsg.Bytes()
+
+	// Every validator signs, so the cached aggregate public key is used.
+	signers := set.NewBits()
+	sigs := make([]*bls.Signature, len(testVdrs))
+	for i, vdr := range testVdrs {
+		signers.Add(i)
+		sigs[i] = bls.Sign(vdr.sk, unsignedBytes)
+	}
+	aggSig, err := bls.AggregateSignatures(sigs)
+	require.NoError(err)
+
+	sig := &BitSetSignature{
+		Signers: signers.Bytes(),
+	}
+	copy(sig.Signature[:], bls.SignatureToBytes(aggSig))
+
+	for i := 0; i < 2; i++ {
+		require.NoError(sig.Verify(
+			context.Background(),
+			unsignedMsg,
+			constants.UnitTestID,
+			cachedState,
+			pChainHeight,
+			1,
+			1,
+		))
+	}
+
+	// Removing a signer still fails verification.
+	sig.Signers = set.NewBits(0, 1).Bytes()
+	err = sig.Verify(
+		context.Background(),
+		unsignedMsg,
+		constants.UnitTestID,
+		cachedState,
+		pChainHeight,
+		1,
+		3,
+	)
+	require.ErrorIs(err, ErrInvalidSignature)
+}
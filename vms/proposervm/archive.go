@@ -0,0 +1,231 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+// archivedBlkCacheSize is the number of blocks fetched from the archive that
+// are kept in memory so that they can be fetched by ID after their ID was
+// fetched by height.
+const archivedBlkCacheSize = 64
+
+// archiveReadTimeout bounds the time spent fetching a block from the archive.
+// Archived blocks are fetched while holding vm.ctx.Lock, so a slow archive
+// must not be able to stall consensus for long.
+const archiveReadTimeout = 2 * time.Second
+
+var errArchivedBlockMismatch = errors.New("archived block doesn't match the requested block")
+
+// notifyArchiver wakes up the archiver, if it isn't already going to check for
+// blocks to archive.
+func (vm *VM) notifyArchiver() {
+	select {
+	case vm.archiveSignal <- struct{}{}:
+	default:
+	}
+}
+
+// runArchiver moves the blocks that fall out of the retention window to the
+// archive until the VM is shutdown. Blocks are uploaded without holding
+// vm.ctx.Lock, so that a slow archive never stalls consensus. If a block
+// can't be archived, it is kept locally and archiving is retried when the
+// next block is accepted.
+func (vm *VM) runArchiver() {
+	for {
+		select {
+		case <-vm.archiveSignal:
+		case <-vm.context.Done():
+			return
+		}
+
+		for {
+			archived, err := vm.archiveNextBlock()
+			if err != nil {
+				vm.ctx.Log.Warn("failed to archive block",
+					zap.Error(err),
+				)
+				break
+			}
+			if !archived {
+				break
+			}
+		}
+	}
+}
+
+// archiveNextBlock moves the lowest block that is stored locally to the
+// archive, if it is outside of the retention window. Returns true if a block
+// was archived.
+func (vm *VM) archiveNextBlock() (bool, error) {
+	height, blkID, blkBytes, ok, err := vm.nextBlockToArchive()
+	if err != nil || !ok {
+		return false, err
+	}
+
+	// The block is uploaded before its height entry so that the archive never
+	// indexes a block that it doesn't store.
+	if err := vm.archive.Put(vm.context, vm.archivedBlockKey(blkID), blkBytes); err != nil {
+		return false, fmt.Errorf("couldn't upload block %s at height %d: %w", blkID, height, err)
+	}
+	if err := vm.archive.Put(vm.context, vm.archivedHeightKey(height), blkID[:]); err != nil {
+		return false, fmt.Errorf("couldn't upload height entry of block %s at height %d: %w", blkID, height, err)
+	}
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	if vm.context.Err() != nil {
+		// The VM was shutdown while the block was being uploaded.
+		return false, nil
+	}
+
+	// The height index may have been modified while the block was being
+	// uploaded, for example by a rollback of the proposervm.
+	currentBlkID, err := vm.State.GetBlockIDAtHeight(height)
+	if err == database.ErrNotFound || (err == nil && currentBlkID != blkID) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := vm.deleteBlock(height, blkID); err != nil {
+		return false, err
+	}
+	return true, vm.db.Commit()
+}
+
+// nextBlockToArchive returns the lowest block that is stored locally, if it
+// is outside of the retention window.
+func (vm *VM) nextBlockToArchive() (uint64, ids.ID, []byte, bool, error) {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	if vm.context.Err() != nil {
+		return 0, ids.Empty, nil, false, nil
+	}
+
+	height, err := vm.State.GetMinimumHeight()
+	if err == database.ErrNotFound {
+		// Chain hasn't forked yet
+		return 0, ids.Empty, nil, false, nil
+	}
+	if err != nil {
+		return 0, ids.Empty, nil, false, err
+	}
+	// Note: The last accepted block is not considered a historical block.
+	if height > vm.lastAcceptedHeight || vm.lastAcceptedHeight-height <= vm.numHistoricalBlocks {
+		return 0, ids.Empty, nil, false, nil
+	}
+
+	blkID, err := vm.State.GetBlockIDAtHeight(height)
+	if err != nil {
+		return 0, ids.Empty, nil, false, err
+	}
+	blk, _, err := vm.State.GetBlock(blkID)
+	if err != nil {
+		return 0, ids.Empty, nil, false, err
+	}
+	return height, blkID, blk.Bytes(), true, nil
+}
+
+// deleteBlock removes the block [blkID] at [height] from the local database.
+//
+// vm.ctx.Lock should be held
+func (vm *VM) deleteBlock(height uint64, blkID ids.ID) error {
+	if err := vm.State.DeleteBlockIDAtHeight(height); err != nil {
+		return err
+	}
+	if err := vm.State.DeleteBlock(blkID); err != nil {
+		return err
+	}
+
+	vm.ctx.Log.Debug("deleted block",
+		zap.Stringer("blkID", blkID),
+		zap.Uint64("height", height),
+	)
+	return nil
+}
+
+// getArchivedBlockIDAtHeight fetches the ID of the block at [height] from the
+// archive. [height] must be below the lowest height that is stored locally.
+// The archive isn't trusted, so the ID is only returned if the archived block
+// has the requested height. The block is then cached, so that it can be
+// fetched by its ID without querying the archive again.
+//
+// vm.ctx.Lock should be held
+func (vm *VM) getArchivedBlockIDAtHeight(ctx context.Context, height uint64) (ids.ID, error) {
+	ctx, cancel := context.WithTimeout(ctx, archiveReadTimeout)
+	defer cancel()
+
+	blkIDBytes, err := vm.archive.Get(ctx, vm.archivedHeightKey(height))
+	if err == database.ErrNotFound {
+		return ids.Empty, vm.prunedError(height)
+	}
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	blkID, err := ids.ToID(blkIDBytes)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if _, ok := vm.archivedBlocks.Get(blkID); ok {
+		return blkID, nil
+	}
+
+	statelessBlock, err := vm.getArchivedBlock(ctx, blkID)
+	if err != nil {
+		return ids.Empty, err
+	}
+	// Only accepted blocks are archived.
+	blk, err := vm.newPostForkBlock(ctx, blkID, statelessBlock, choices.Accepted)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if blkHeight := blk.Height(); blkHeight != height {
+		return ids.Empty, fmt.Errorf("%w: height %d != %d", errArchivedBlockMismatch, blkHeight, height)
+	}
+	vm.archivedBlocks.Put(blkID, blk)
+	return blkID, nil
+}
+
+// getArchivedBlock fetches the block [blkID] from the archive. The archive
+// isn't trusted, so the block is only returned if its ID matches [blkID].
+func (vm *VM) getArchivedBlock(ctx context.Context, blkID ids.ID) (statelessblock.Block, error) {
+	blkBytes, err := vm.archive.Get(ctx, vm.archivedBlockKey(blkID))
+	if err != nil {
+		return nil, err
+	}
+
+	blk, err := statelessblock.Parse(blkBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse archived block %s: %w", blkID, err)
+	}
+	if parsedID := blk.ID(); parsedID != blkID {
+		return nil, fmt.Errorf("%w: %s != %s", errArchivedBlockMismatch, parsedID, blkID)
+	}
+	return blk, nil
+}
+
+func (vm *VM) archivedBlockKey(blkID ids.ID) string {
+	return vm.ctx.ChainID.String() + "/blocks/" + blkID.String()
+}
+
+func (vm *VM) archivedHeightKey(height uint64) string {
+	return vm.ctx.ChainID.String() + "/heights/" + strconv.FormatUint(height, 10)
+}
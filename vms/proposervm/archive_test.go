@@ -0,0 +1,336 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/objectstore"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+var (
+	_ objectstore.Store = (*testArchive)(nil)
+
+	errArchiveUnavailable = errors.New("archive unavailable")
+)
+
+type testArchive struct {
+	lock         sync.Mutex
+	objects      map[string][]byte
+	unavailable  bool
+	unresponsive bool
+	failedPuts   int
+}
+
+func (a *testArchive) Get(ctx context.Context, key string) ([]byte, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.unresponsive {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if a.unavailable {
+		return nil, errArchiveUnavailable
+	}
+	value, ok := a.objects[key]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return value, nil
+}
+
+func (a *testArchive) Put(_ context.Context, key string, value []byte) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.unavailable {
+		a.failedPuts++
+		return errArchiveUnavailable
+	}
+	a.objects[key] = value
+	return nil
+}
+
+func (a *testArchive) setUnavailable(unavailable bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.unavailable = unavailable
+}
+
+func (a *testArchive) setUnresponsive(unresponsive bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.unresponsive = unresponsive
+}
+
+func (a *testArchive) getFailedPuts() int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.failedPuts
+}
+
+func (a *testArchive) set(key string, value []byte) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.objects[key] = value
+}
+
+func (a *testArchive) get(key string) []byte {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.objects[key]
+}
+
+func TestHistoricalBlockArchive(t *testing.T) {
+	require := require.New(t)
+
+	coreGenBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV:    0,
+		TimestampV: genesisTimestamp,
+		BytesV:     utils.RandomBytes(1024),
+	}
+	acceptedBlocks := []snowman.Block{coreGenBlk}
+	currentHeight := uint64(0)
+
+	coreVM := &block.TestVM{
+		TestVM: common.TestVM{
+			T: t,
+			InitializeF: func(context.Context, *snow.Context, manager.Manager, []byte, []byte, []byte, chan<- common.Message, []*common.Fx, common.AppSender) error {
+				return nil
+			},
+		},
+		LastAcceptedF: func(context.Context) (ids.ID, error) {
+			return acceptedBlocks[currentHeight].ID(), nil
+		},
+		GetBlockF: func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+			for _, blk := range acceptedBlocks {
+				if blkID == blk.ID() {
+					return blk, nil
+				}
+			}
+			return nil, errUnknownBlock
+		},
+		ParseBlockF: func(_ context.Context, b []byte) (snowman.Block, error) {
+			for _, blk := range acceptedBlocks {
+				if bytes.Equal(b, blk.Bytes()) {
+					return blk, nil
+				}
+			}
+			return nil, errUnknownBlock
+		},
+		VerifyHeightIndexF: func(context.Context) error {
+			return nil
+		},
+		GetBlockIDAtHeightF: func(_ context.Context, height uint64) (ids.ID, error) {
+			if height >= uint64(len(acceptedBlocks)) {
+				return ids.ID{}, errTooHigh
+			}
+			return acceptedBlocks[height].ID(), nil
+		},
+	}
+
+	ctx := snow.DefaultContextTest()
+	ctx.NodeID = ids.NodeIDFromCert(pTestCert)
+	ctx.ValidatorState = &validators.TestState{
+		T: t,
+		GetMinimumHeightF: func(context.Context) (uint64, error) {
+			return coreGenBlk.HeightV, nil
+		},
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return defaultPChainHeight, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return nil, nil
+		},
+	}
+
+	archive := &testArchive{
+		objects: make(map[string][]byte),
+	}
+	numHistoricalBlocks := uint64(2)
+	proVM := New(
+		coreVM,
+		time.Time{},
+		0,
+		DefaultMinBlockDelay,
+		numHistoricalBlocks,
+		proposer.DefaultConfig,
+		archive,
+		pTestSigner,
+		pTestCert,
+		nil,
+	)
+
+	// The archiver runs concurrently with the test, so the test must hold the
+	// lock while using the VM.
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	require.NoError(proVM.Initialize(
+		context.Background(),
+		ctx,
+		manager.NewMemDB(version.Semantic1_0_0),
+		[]byte("genesis state"),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	))
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	lastAcceptedID, err := proVM.LastAccepted(context.Background())
+	require.NoError(err)
+
+	require.NoError(proVM.SetState(context.Background(), snow.NormalOp))
+	require.NoError(proVM.SetPreference(context.Background(), lastAcceptedID))
+	require.NoError(proVM.VerifyHeightIndex(context.Background()))
+
+	// Height --> ID of the accepted post-fork block
+	proBlockIDs := map[uint64]ids.ID{}
+	issueBlock := func() {
+		lastAcceptedBlock := acceptedBlocks[currentHeight]
+		innerBlock := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			ParentV:    lastAcceptedBlock.ID(),
+			HeightV:    lastAcceptedBlock.Height() + 1,
+			TimestampV: lastAcceptedBlock.Timestamp(),
+			BytesV:     utils.RandomBytes(1024),
+		}
+
+		coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+			return innerBlock, nil
+		}
+		proBlock, err := proVM.BuildBlock(context.Background())
+		require.NoError(err)
+
+		require.NoError(proBlock.Verify(context.Background()))
+		require.NoError(proVM.SetPreference(context.Background(), proBlock.ID()))
+		require.NoError(proBlock.Accept(context.Background()))
+
+		acceptedBlocks = append(acceptedBlocks, innerBlock)
+		currentHeight++
+		proBlockIDs[currentHeight] = proBlock.ID()
+	}
+
+	getMinLocalHeight := func() uint64 {
+		ctx.Lock.Lock()
+		defer ctx.Lock.Unlock()
+
+		minHeight, err := proVM.State.GetMinimumHeight()
+		require.NoError(err)
+		return minHeight
+	}
+
+	// requireMinLocalHeight checks that the blocks below [minHeight] were
+	// moved to the archive and that every block can still be fetched.
+	requireMinLocalHeight := func(minHeight uint64) {
+		ctx.Lock.Unlock()
+		require.Eventually(func() bool {
+			return getMinLocalHeight() == minHeight
+		}, time.Second, time.Millisecond)
+		ctx.Lock.Lock()
+
+		for height := uint64(1); height <= currentHeight; height++ {
+			blkID, err := proVM.GetBlockIDAtHeight(context.Background(), height)
+			require.NoError(err)
+			require.Equal(proBlockIDs[height], blkID)
+
+			blk, err := proVM.GetBlock(context.Background(), blkID)
+			require.NoError(err)
+			require.Equal(height, blk.Height())
+			require.Equal(choices.Accepted, blk.Status())
+
+			_, _, err = proVM.State.GetBlock(blkID)
+			if height < minHeight {
+				require.ErrorIs(err, database.ErrNotFound)
+			} else {
+				require.NoError(err)
+			}
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		issueBlock()
+	}
+	requireMinLocalHeight(currentHeight - numHistoricalBlocks)
+
+	// While the archive is unavailable, blocks are kept locally.
+	archive.setUnavailable(true)
+	for i := 1; i <= 2; i++ {
+		issueBlock()
+
+		ctx.Lock.Unlock()
+		require.Eventually(func() bool {
+			return archive.getFailedPuts() >= i
+		}, time.Second, time.Millisecond)
+		ctx.Lock.Lock()
+	}
+	minHeight, err := proVM.State.GetMinimumHeight()
+	require.NoError(err)
+	require.Equal(currentHeight-numHistoricalBlocks-2, minHeight)
+
+	// Once the archive is available again, the backlog is archived.
+	archive.setUnavailable(false)
+	issueBlock()
+	requireMinLocalHeight(currentHeight - numHistoricalBlocks)
+
+	// Archived blocks are only fetched by ID once their ID was fetched by
+	// height.
+	proVM.archivedBlocks.Flush()
+	_, err = proVM.getPostForkBlock(context.Background(), proBlockIDs[1])
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Archived blocks that don't match the requested block are rejected.
+	archivedBlkID := proBlockIDs[1]
+	otherBlkID := proBlockIDs[2]
+	archive.set(proVM.archivedHeightKey(1), otherBlkID[:])
+	_, err = proVM.GetBlockIDAtHeight(context.Background(), 1)
+	require.ErrorIs(err, errArchivedBlockMismatch)
+
+	archive.set(proVM.archivedHeightKey(1), archivedBlkID[:])
+	archive.set(proVM.archivedBlockKey(archivedBlkID), archive.get(proVM.archivedBlockKey(otherBlkID)))
+	_, err = proVM.GetBlockIDAtHeight(context.Background(), 1)
+	require.ErrorIs(err, errArchivedBlockMismatch)
+
+	// Fetching from an unresponsive archive times out, rather than holding
+	// the context lock indefinitely.
+	archive.setUnresponsive(true)
+	_, err = proVM.GetBlockIDAtHeight(context.Background(), 1)
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
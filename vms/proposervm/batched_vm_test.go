@@ -1023,8 +1023,11 @@ func initTestRemoteProposerVM(
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	valState := &validators.TestState{
@@ -16,7 +16,6 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/vms/proposervm/block"
-	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 
 	smblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 )
@@ -80,6 +79,11 @@ func (p *postForkCommonComponents) Height() uint64 {
 	return p.innerBlk.Height()
 }
 
+// Return the number of transactions in the inner block
+func (p *postForkCommonComponents) NumTxs() (int, bool) {
+	return smblock.NumTxs(p.innerBlk)
+}
+
 // Verify returns nil if:
 // 1) [p]'s inner block is not an oracle block
 // 2) [child]'s P-Chain height >= [parentPChainHeight]
@@ -151,7 +155,7 @@ func (p *postForkCommonComponents) Verify(
 		}
 
 		// Verify the signature of the node
-		shouldHaveProposer := delay < proposer.MaxDelay
+		shouldHaveProposer := delay < p.vm.Windower.MaxDelay()
 		if err := child.SignedBlock.Verify(shouldHaveProposer, p.vm.ctx.ChainID); err != nil {
 			return err
 		}
@@ -199,7 +203,8 @@ func (p *postForkCommonComponents) buildChild(
 	}
 
 	delay := newTimestamp.Sub(parentTimestamp)
-	if delay < proposer.MaxDelay {
+	maxDelay := p.vm.Windower.MaxDelay()
+	if delay < maxDelay {
 		parentHeight := p.innerBlk.Height()
 		proposerID := p.vm.ctx.NodeID
 		minDelay, err := p.vm.Windower.Delay(ctx, parentHeight+1, parentPChainHeight, proposerID)
@@ -245,7 +250,7 @@ func (p *postForkCommonComponents) buildChild(
 
 	// Build the child
 	var statelessChild block.SignedBlock
-	if delay >= proposer.MaxDelay {
+	if delay >= maxDelay {
 		statelessChild, err = block.BuildUnsigned(
 			parentID,
 			newTimestamp,
@@ -54,6 +54,7 @@ func TestPostForkCommonComponents_buildChild(t *testing.T) {
 	vdrState.EXPECT().GetMinimumHeight(context.Background()).Return(pChainHeight, nil).AnyTimes()
 	windower := proposer.NewMockWindower(ctrl)
 	windower.EXPECT().Delay(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).AnyTimes()
+	windower.EXPECT().MaxDelay().Return(proposer.MaxDelay).AnyTimes()
 
 	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(err)
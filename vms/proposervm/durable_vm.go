@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+// LastDurableHeight forwards to the inner VM. The proposervm commits its own
+// state before returning from Accept, and its blocks have the same heights as
+// the inner blocks they wrap.
+func (vm *VM) LastDurableHeight(ctx context.Context) (uint64, error) {
+	if vm.durableVM == nil {
+		return 0, block.ErrDurableVMNotImplemented
+	}
+	return vm.durableVM.LastDurableHeight(ctx)
+}
@@ -9,6 +9,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/api/rpcerror"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
@@ -74,7 +75,14 @@ func (vm *VM) GetBlockIDAtHeight(ctx context.Context, height uint64) (ids.ID, er
 		if height < forkHeight {
 			return vm.ChainVM.GetBlockIDAtHeight(ctx, height)
 		}
-		return vm.State.GetBlockIDAtHeight(height)
+		blkID, err := vm.State.GetBlockIDAtHeight(height)
+		if err == database.ErrNotFound {
+			if vm.archive != nil && vm.isPruned(height) {
+				return vm.getArchivedBlockIDAtHeight(ctx, height)
+			}
+			return ids.Empty, vm.prunedError(height)
+		}
+		return blkID, err
 
 	case database.ErrNotFound:
 		// fork not reached yet. Block must be pre-fork
@@ -85,6 +93,37 @@ func (vm *VM) GetBlockIDAtHeight(ctx context.Context, height uint64) (ids.ID, er
 	}
 }
 
+// isPruned returns true if [height] is below the lowest height that is stored
+// locally.
+//
+// vm.ctx.Lock should be held
+func (vm *VM) isPruned(height uint64) bool {
+	minHeight, err := vm.State.GetMinimumHeight()
+	return err == nil && height < minHeight
+}
+
+// prunedError returns a [rpcerror.PrunedError] if the block at [height] is
+// missing because it was pruned. Otherwise, returns database.ErrNotFound.
+//
+// vm.ctx.Lock should be held
+func (vm *VM) prunedError(height uint64) error {
+	if vm.numHistoricalBlocks == 0 {
+		return database.ErrNotFound
+	}
+	minHeight, err := vm.State.GetMinimumHeight()
+	if err != nil || height >= minHeight {
+		return database.ErrNotFound
+	}
+	return &rpcerror.PrunedError{
+		Height: height,
+		Retention: rpcerror.Retention{
+			MinHeight:           minHeight,
+			NumHistoricalBlocks: vm.numHistoricalBlocks,
+		},
+		Err: database.ErrNotFound,
+	}
+}
+
 // As postFork blocks/options are accepted, height index is updated even if its
 // repairing is ongoing. vm.ctx.Lock should be held
 func (vm *VM) updateHeightIndex(height uint64, blkID ids.ID) error {
@@ -152,6 +191,12 @@ func (vm *VM) storeHeightEntry(height uint64, blkID ids.ID) error {
 	// Note: heightToDelete is >= forkHeight, so it is guaranteed not to
 	// underflow.
 	heightToDelete := height - vm.numHistoricalBlocks - 1
+	if vm.archive != nil {
+		// Blocks are only deleted once they are archived.
+		vm.notifyArchiver()
+		return nil
+	}
+
 	blockToDelete, err := vm.State.GetBlockIDAtHeight(heightToDelete)
 	if err == database.ErrNotFound {
 		// Block may have already been deleted. This can happen due to a
@@ -164,18 +209,7 @@ func (vm *VM) storeHeightEntry(height uint64, blkID ids.ID) error {
 		return err
 	}
 
-	if err := vm.State.DeleteBlockIDAtHeight(heightToDelete); err != nil {
-		return err
-	}
-	if err := vm.State.DeleteBlock(blockToDelete); err != nil {
-		return err
-	}
-
-	vm.ctx.Log.Debug("deleted block",
-		zap.Stringer("blkID", blockToDelete),
-		zap.Uint64("height", heightToDelete),
-	)
-	return nil
+	return vm.deleteBlock(heightToDelete, blockToDelete)
 }
 
 // TODO: Support async deletion of old blocks.
@@ -183,6 +217,11 @@ func (vm *VM) pruneOldBlocks() error {
 	if vm.numHistoricalBlocks == 0 {
 		return nil
 	}
+	if vm.archive != nil {
+		// Blocks are only deleted once they are archived.
+		vm.notifyArchiver()
+		return nil
+	}
 
 	height, err := vm.State.GetMinimumHeight()
 	if err == database.ErrNotFound {
@@ -200,17 +239,9 @@ func (vm *VM) pruneOldBlocks() error {
 			return err
 		}
 
-		if err := vm.State.DeleteBlockIDAtHeight(height); err != nil {
+		if err := vm.deleteBlock(height, blockToDelete); err != nil {
 			return err
 		}
-		if err := vm.State.DeleteBlock(blockToDelete); err != nil {
-			return err
-		}
-
-		vm.ctx.Log.Debug("deleted block",
-			zap.Stringer("blkID", blockToDelete),
-			zap.Uint64("height", height),
-		)
 
 		// Note: height is < vm.lastAcceptedHeight, so it is guaranteed not to
 		// overflow.
@@ -665,8 +665,11 @@ func TestOptionTimestampValidity(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	coreVM.InitializeF = func(
@@ -13,9 +13,14 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/vms/proposervm/block"
+
+	smblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 )
 
-var _ Block = (*preForkBlock)(nil)
+var (
+	_ Block              = (*preForkBlock)(nil)
+	_ smblock.WithNumTxs = (*preForkBlock)(nil)
+)
 
 type preForkBlock struct {
 	snowman.Block
@@ -37,6 +42,10 @@ func (b *preForkBlock) acceptInnerBlk(ctx context.Context) error {
 	return b.Block.Accept(ctx)
 }
 
+func (b *preForkBlock) NumTxs() (int, bool) {
+	return smblock.NumTxs(b.Block)
+}
+
 func (b *preForkBlock) Status() choices.Status {
 	forkHeight, err := b.vm.getForkHeight()
 	if err == database.ErrNotFound {
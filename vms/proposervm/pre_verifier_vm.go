@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+// PreVerifyBlock forwards the pre-verification of [blk] to the inner VM. The
+// proposer specific checks depend on the P-chain state and the parent block,
+// so they are performed when [blk] is verified.
+func (vm *VM) PreVerifyBlock(ctx context.Context, blk snowman.Block) error {
+	if vm.preVerifierVM == nil {
+		return block.ErrPreVerifierVMNotImplemented
+	}
+
+	switch blk := blk.(type) {
+	case *preForkBlock:
+		return vm.preVerifierVM.PreVerifyBlock(ctx, blk.Block)
+	case PostForkBlock:
+		return vm.preVerifierVM.PreVerifyBlock(ctx, blk.getInnerBlk())
+	default:
+		return fmt.Errorf("%w: %T", errUnexpectedBlockType, blk)
+	}
+}
@@ -54,6 +54,20 @@ func (mr *MockWindowerMockRecorder) Delay(arg0, arg1, arg2, arg3 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delay", reflect.TypeOf((*MockWindower)(nil).Delay), arg0, arg1, arg2, arg3)
 }
 
+// MaxDelay mocks base method.
+func (m *MockWindower) MaxDelay() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxDelay")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// MaxDelay indicates an expected call of MaxDelay.
+func (mr *MockWindowerMockRecorder) MaxDelay() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxDelay", reflect.TypeOf((*MockWindower)(nil).MaxDelay))
+}
+
 // Proposers mocks base method.
 func (m *MockWindower) Proposers(arg0 context.Context, arg1, arg2 uint64) ([]ids.NodeID, error) {
 	m.ctrl.T.Helper()
@@ -68,3 +82,17 @@ func (mr *MockWindowerMockRecorder) Proposers(arg0, arg1, arg2 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Proposers", reflect.TypeOf((*MockWindower)(nil).Proposers), arg0, arg1, arg2)
 }
+
+// WindowDuration mocks base method.
+func (m *MockWindower) WindowDuration() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WindowDuration")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// WindowDuration indicates an expected call of WindowDuration.
+func (mr *MockWindowerMockRecorder) WindowDuration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WindowDuration", reflect.TypeOf((*MockWindower)(nil).WindowDuration))
+}
@@ -5,6 +5,8 @@ package proposer
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -22,13 +24,51 @@ const (
 	MaxDelay       = MaxWindows * WindowDuration
 )
 
-var _ Windower = (*windower)(nil)
+var (
+	// DefaultConfig is the proposer schedule of the Primary Network.
+	DefaultConfig = Config{
+		MaxWindows:     MaxWindows,
+		WindowDuration: WindowDuration,
+	}
+
+	errInvalidConfig = errors.New("invalid proposer config")
+
+	_ Windower = (*windower)(nil)
+)
+
+// Config describes the proposer schedule of a chain.
+//
+// Note: Every validator of a chain must use the same config, otherwise they
+// will disagree on the validity of blocks.
+type Config struct {
+	// MaxWindows is the number of validators that are given a window, in
+	// order, to propose a block before any validator may propose one.
+	MaxWindows int `json:"maxWindows" yaml:"maxWindows"`
+	// WindowDuration is the length of each window.
+	WindowDuration time.Duration `json:"windowDuration" yaml:"windowDuration"`
+}
+
+// MaxDelay returns the delay after which any validator may propose a block.
+func (c Config) MaxDelay() time.Duration {
+	return time.Duration(c.MaxWindows) * c.WindowDuration
+}
+
+func (c Config) Verify() error {
+	switch {
+	case c.MaxWindows <= 0:
+		return fmt.Errorf("%w: max windows %d <= 0", errInvalidConfig, c.MaxWindows)
+	case c.WindowDuration <= 0:
+		return fmt.Errorf("%w: window duration %s <= 0", errInvalidConfig, c.WindowDuration)
+	default:
+		return nil
+	}
+}
 
 type Windower interface {
 	// Proposers returns the proposer list for building a block at [chainHeight]
 	// when the validator set is defined at [pChainHeight]. The list is returned
 	// in order. The minimum delay of a validator is the index they appear times
-	// [WindowDuration].
+	// the window duration.
 	Proposers(
 		ctx context.Context,
 		chainHeight,
@@ -43,6 +83,10 @@ type Windower interface {
 		pChainHeight uint64,
 		validatorID ids.NodeID,
 	) (time.Duration, error)
+	// WindowDuration returns the length of each proposer window.
+	WindowDuration() time.Duration
+	// MaxDelay returns the delay after which any validator may build a block.
+	MaxDelay() time.Duration
 }
 
 // windower interfaces with P-Chain and it is responsible for calculating the
@@ -52,15 +96,17 @@ type windower struct {
 	subnetID    ids.ID
 	chainSource uint64
 	sampler     sampler.WeightedWithoutReplacement
+	config      Config
 }
 
-func New(state validators.State, subnetID, chainID ids.ID) Windower {
+func New(state validators.State, subnetID, chainID ids.ID, config Config) Windower {
 	w := wrappers.Packer{Bytes: chainID[:]}
 	return &windower{
 		state:       state,
 		subnetID:    subnetID,
 		chainSource: w.UnpackLong(),
 		sampler:     sampler.NewDeterministicWeightedWithoutReplacement(),
+		config:      config,
 	}
 }
 
@@ -101,7 +147,7 @@ func (w *windower) Proposers(ctx context.Context, chainHeight, pChainHeight uint
 		return nil, err
 	}
 
-	numToSample := MaxWindows
+	numToSample := w.config.MaxWindows
 	if weight < uint64(numToSample) {
 		numToSample = int(weight)
 	}
@@ -123,7 +169,7 @@ func (w *windower) Proposers(ctx context.Context, chainHeight, pChainHeight uint
 
 func (w *windower) Delay(ctx context.Context, chainHeight, pChainHeight uint64, validatorID ids.NodeID) (time.Duration, error) {
 	if validatorID == ids.EmptyNodeID {
-		return MaxDelay, nil
+		return w.config.MaxDelay(), nil
 	}
 
 	proposers, err := w.Proposers(ctx, chainHeight, pChainHeight)
@@ -136,7 +182,15 @@ func (w *windower) Delay(ctx context.Context, chainHeight, pChainHeight uint64,
 		if nodeID == validatorID {
 			return delay, nil
 		}
-		delay += WindowDuration
+		delay += w.config.WindowDuration
 	}
 	return delay, nil
 }
+
+func (w *windower) WindowDuration() time.Duration {
+	return w.config.WindowDuration
+}
+
+func (w *windower) MaxDelay() time.Duration {
+	return w.config.MaxDelay()
+}
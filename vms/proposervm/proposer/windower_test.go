@@ -28,7 +28,7 @@ func TestWindowerNoValidators(t *testing.T) {
 		},
 	}
 
-	w := New(vdrState, subnetID, chainID)
+	w := New(vdrState, subnetID, chainID, DefaultConfig)
 
 	delay, err := w.Delay(context.Background(), 1, 0, nodeID)
 	require.NoError(err)
@@ -54,7 +54,7 @@ func TestWindowerRepeatedValidator(t *testing.T) {
 		},
 	}
 
-	w := New(vdrState, subnetID, chainID)
+	w := New(vdrState, subnetID, chainID, DefaultConfig)
 
 	validatorDelay, err := w.Delay(context.Background(), 1, 0, validatorID)
 	require.NoError(err)
@@ -88,7 +88,7 @@ func TestWindowerChangeByHeight(t *testing.T) {
 		},
 	}
 
-	w := New(vdrState, subnetID, chainID)
+	w := New(vdrState, subnetID, chainID, DefaultConfig)
 
 	expectedDelays1 := []time.Duration{
 		2 * WindowDuration,
@@ -150,8 +150,8 @@ func TestWindowerChangeByChain(t *testing.T) {
 		},
 	}
 
-	w0 := New(vdrState, subnetID, chainID0)
-	w1 := New(vdrState, subnetID, chainID1)
+	w0 := New(vdrState, subnetID, chainID0, DefaultConfig)
+	w1 := New(vdrState, subnetID, chainID1, DefaultConfig)
 
 	expectedDelays0 := []time.Duration{
 		5 * WindowDuration,
@@ -183,3 +183,89 @@ func TestWindowerChangeByChain(t *testing.T) {
 		require.Equal(expectedDelay, validatorDelay)
 	}
 }
+
+func TestWindowerConfig(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.ID{0, 1}
+	chainID := ids.ID{0, 2}
+	validatorIDs := make([]ids.NodeID, MaxWindows)
+	for i := range validatorIDs {
+		validatorIDs[i] = ids.NodeID{byte(i + 1)}
+	}
+	vdrState := &validators.TestState{
+		T: t,
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput, MaxWindows)
+			for _, id := range validatorIDs {
+				vdrs[id] = &validators.GetValidatorOutput{
+					NodeID: id,
+					Weight: 1,
+				}
+			}
+			return vdrs, nil
+		},
+	}
+
+	config := Config{
+		MaxWindows:     2,
+		WindowDuration: time.Second,
+	}
+	require.NoError(config.Verify())
+
+	w := New(vdrState, subnetID, chainID, config)
+	require.Equal(time.Second, w.WindowDuration())
+	require.Equal(2*time.Second, w.MaxDelay())
+
+	proposers, err := w.Proposers(context.Background(), 1, 0)
+	require.NoError(err)
+	require.Len(proposers, config.MaxWindows)
+
+	for i, proposer := range proposers {
+		delay, err := w.Delay(context.Background(), 1, 0, proposer)
+		require.NoError(err)
+		require.Equal(time.Duration(i)*config.WindowDuration, delay)
+	}
+
+	// Validators outside of the proposer list wait for the max delay
+	for _, vdrID := range validatorIDs {
+		if vdrID == proposers[0] || vdrID == proposers[1] {
+			continue
+		}
+		delay, err := w.Delay(context.Background(), 1, 0, vdrID)
+		require.NoError(err)
+		require.Equal(config.MaxDelay(), delay)
+	}
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name:   "default",
+			config: DefaultConfig,
+		},
+		{
+			name: "no windows",
+			config: Config{
+				WindowDuration: time.Second,
+			},
+			expectedErr: errInvalidConfig,
+		},
+		{
+			name: "no window duration",
+			config: Config{
+				MaxWindows: 1,
+			},
+			expectedErr: errInvalidConfig,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.config.Verify(), test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/rpc/v2"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+var (
+	errHeightNotAfterPreferred = errors.New("height must be after the preferred block")
+	errJSONEncodingUnsupported = errors.New("blocks can't be JSON encoded")
+)
+
+// Service exposes the proposer schedule and the blocks of a chain.
+type Service struct {
+	vm *VM
+}
+
+func newHandler(vm *VM) (http.Handler, error) {
+	server := rpc.NewServer()
+	codec := json.NewCodec()
+	server.RegisterCodec(codec, "application/json")
+	server.RegisterCodec(codec, "application/json;charset=UTF-8")
+	return server, server.RegisterService(&Service{vm: vm}, "proposervm")
+}
+
+// GetProposerScheduleArgs are the arguments for GetProposerSchedule
+type GetProposerScheduleArgs struct {
+	// Height of the block to return the schedule of. If 0, the height after
+	// the preferred block is used.
+	Height json.Uint64 `json:"height"`
+}
+
+// ProposerWindow is the window in which a validator may propose a block.
+type ProposerWindow struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Delay after the timestamp of the parent block at which the window
+	// starts.
+	Delay time.Duration `json:"delay"`
+	// StartTime is when the window starts. It is only known if the parent
+	// block is the preferred block.
+	StartTime *time.Time `json:"startTime,omitempty"`
+}
+
+// GetProposerScheduleReply is the response from GetProposerSchedule
+type GetProposerScheduleReply struct {
+	Height json.Uint64 `json:"height"`
+	// PChainHeight is the P-chain height that the proposers were sampled at.
+	PChainHeight json.Uint64 `json:"pChainHeight"`
+	// Proposers are the windows of the validators that may propose the block,
+	// in order.
+	Proposers []ProposerWindow `json:"proposers"`
+	// MaxDelay is the delay after the timestamp of the parent block after
+	// which any validator may propose the block.
+	MaxDelay time.Duration `json:"maxDelay"`
+	// UnrestrictedTime is when any validator may propose the block. It is only
+	// known if the parent block is the preferred block.
+	UnrestrictedTime *time.Time `json:"unrestrictedTime,omitempty"`
+}
+
+// GetProposerSchedule returns which validators may propose the block at a
+// height and when.
+//
+// The proposers are sampled from the validator set at the P-chain height of
+// the preferred block. Blocks after the next block may reference a later
+// P-chain height, so their schedule may change as the chain progresses.
+func (s *Service) GetProposerSchedule(r *http.Request, args *GetProposerScheduleArgs, reply *GetProposerScheduleReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "proposervm"),
+		zap.String("method", "getProposerSchedule"),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	ctx := r.Context()
+	preferred, err := s.vm.getPostForkBlock(ctx, s.vm.preferred)
+	if err != nil {
+		return fmt.Errorf("couldn't get preferred post-fork block: %w", err)
+	}
+
+	nextHeight := preferred.Height() + 1
+	height := uint64(args.Height)
+	switch {
+	case height == 0:
+		height = nextHeight
+	case height < nextHeight:
+		return fmt.Errorf("%w: %d < %d", errHeightNotAfterPreferred, height, nextHeight)
+	}
+
+	pChainHeight, err := preferred.pChainHeight(ctx)
+	if err != nil {
+		return err
+	}
+	proposers, err := s.vm.Windower.Proposers(ctx, height, pChainHeight)
+	if err != nil {
+		return fmt.Errorf("couldn't get proposers: %w", err)
+	}
+
+	var (
+		parentTimestamp = preferred.Timestamp()
+		windowDuration  = s.vm.Windower.WindowDuration()
+		maxDelay        = s.vm.Windower.MaxDelay()
+	)
+	reply.Height = json.Uint64(height)
+	reply.PChainHeight = json.Uint64(pChainHeight)
+	reply.Proposers = make([]ProposerWindow, len(proposers))
+	for i, nodeID := range proposers {
+		window := ProposerWindow{
+			NodeID: nodeID,
+			Delay:  time.Duration(i) * windowDuration,
+		}
+		if height == nextHeight {
+			startTime := parentTimestamp.Add(window.Delay)
+			window.StartTime = &startTime
+		}
+		reply.Proposers[i] = window
+	}
+	reply.MaxDelay = maxDelay
+	if height == nextHeight {
+		unrestrictedTime := parentTimestamp.Add(maxDelay)
+		reply.UnrestrictedTime = &unrestrictedTime
+	}
+	return nil
+}
+
+// GetBlockByHeight returns the accepted block at the given height, as wrapped
+// by the proposervm.
+//
+// If the block was deleted because of the node's retention policy, the error
+// is classified as [rpcerror.Pruned] and reports the retention policy.
+func (s *Service) GetBlockByHeight(r *http.Request, args *api.GetBlockByHeightArgs, reply *api.GetBlockResponse) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "proposervm"),
+		zap.String("method", "getBlockByHeight"),
+		zap.Uint64("height", uint64(args.Height)),
+		zap.Stringer("encoding", args.Encoding),
+	)
+
+	if args.Encoding == formatting.JSON {
+		return errJSONEncodingUnsupported
+	}
+
+	ctx := r.Context()
+	blkID, err := s.vm.GetBlockIDAtHeight(ctx, uint64(args.Height))
+	if err != nil {
+		return fmt.Errorf("couldn't get block at height %d: %w", args.Height, err)
+	}
+	blk, err := s.vm.GetBlock(ctx, blkID)
+	if err != nil {
+		return fmt.Errorf("couldn't get block with id %s: %w", blkID, err)
+	}
+
+	reply.Encoding = args.Encoding
+	reply.Block, err = formatting.Encode(args.Encoding, blk.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode block %s as %s: %w", blkID, args.Encoding, err)
+	}
+	return nil
+}
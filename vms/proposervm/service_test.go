@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+func TestServiceGetProposerSchedule(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	s := &Service{vm: proVM}
+
+	// There is no schedule until a post-fork block is preferred
+	reply := GetProposerScheduleReply{}
+	err := s.GetProposerSchedule(&http.Request{}, &GetProposerScheduleArgs{}, &reply)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk, nil
+	}
+	coreVM.SetPreferenceF = func(context.Context, ids.ID) error {
+		return nil
+	}
+	proBlk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(proBlk.Verify(context.Background()))
+	require.NoError(proVM.SetPreference(context.Background(), proBlk.ID()))
+
+	nextHeight := proBlk.Height() + 1
+	expectedProposers, err := proVM.Windower.Proposers(context.Background(), nextHeight, defaultPChainHeight)
+	require.NoError(err)
+
+	require.NoError(s.GetProposerSchedule(&http.Request{}, &GetProposerScheduleArgs{}, &reply))
+	require.Equal(json.Uint64(nextHeight), reply.Height)
+	require.Equal(json.Uint64(defaultPChainHeight), reply.PChainHeight)
+	require.Equal(proposer.MaxDelay, reply.MaxDelay)
+	require.Len(reply.Proposers, len(expectedProposers))
+	for i, window := range reply.Proposers {
+		expectedDelay := time.Duration(i) * proposer.WindowDuration
+		require.Equal(expectedProposers[i], window.NodeID)
+		require.Equal(expectedDelay, window.Delay)
+		require.NotNil(window.StartTime)
+		require.Equal(proBlk.Timestamp().Add(expectedDelay), *window.StartTime)
+	}
+	require.NotNil(reply.UnrestrictedTime)
+	require.Equal(proBlk.Timestamp().Add(proposer.MaxDelay), *reply.UnrestrictedTime)
+
+	// The start times of later heights aren't known
+	reply = GetProposerScheduleReply{}
+	require.NoError(s.GetProposerSchedule(&http.Request{}, &GetProposerScheduleArgs{
+		Height: json.Uint64(nextHeight + 1),
+	}, &reply))
+	require.Equal(json.Uint64(nextHeight+1), reply.Height)
+	require.NotEmpty(reply.Proposers)
+	for _, window := range reply.Proposers {
+		require.Nil(window.StartTime)
+	}
+	require.Nil(reply.UnrestrictedTime)
+
+	err = s.GetProposerSchedule(&http.Request{}, &GetProposerScheduleArgs{
+		Height: json.Uint64(proBlk.Height()),
+	}, &reply)
+	require.ErrorIs(err, errHeightNotAfterPreferred)
+}
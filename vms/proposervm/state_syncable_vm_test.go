@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 	"github.com/ava-labs/avalanchego/vms/proposervm/summary"
 
 	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
@@ -77,8 +78,11 @@ func helperBuildStateSyncTestObjects(t *testing.T) (*fullVM, *VM) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	ctx := snow.DefaultContextTest()
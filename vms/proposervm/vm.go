@@ -16,9 +16,11 @@ import (
 
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/budget"
 	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/objectstore"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
@@ -52,12 +54,17 @@ const (
 
 	checkIndexedFrequency = 10 * time.Second
 	innerBlkCacheSize     = 64 * units.MiB
+
+	// proposerEndpoint serves the proposer schedule of the chain
+	proposerEndpoint = "/proposervm"
 )
 
 var (
-	_ block.ChainVM         = (*VM)(nil)
-	_ block.BatchedChainVM  = (*VM)(nil)
-	_ block.StateSyncableVM = (*VM)(nil)
+	_ block.ChainVM            = (*VM)(nil)
+	_ block.BatchedChainVM     = (*VM)(nil)
+	_ block.StateSyncableVM    = (*VM)(nil)
+	_ block.PreVerifierChainVM = (*VM)(nil)
+	_ block.DurableChainVM     = (*VM)(nil)
 
 	// TODO: remove after the X-chain supports height indexing.
 	mainnetXChainID ids.ID
@@ -90,15 +97,28 @@ type VM struct {
 	blockBuilderVM block.BuildBlockWithContextChainVM
 	batchedVM      block.BatchedChainVM
 	ssVM           block.StateSyncableVM
+	preVerifierVM  block.PreVerifierChainVM
+	durableVM      block.DurableChainVM
 
 	activationTime      time.Time
 	minimumPChainHeight uint64
 	minBlkDelay         time.Duration
 	numHistoricalBlocks uint64
+	windowerConfig      proposer.Config
+	// stores the blocks that are pruned from the local database, if not nil
+	archive objectstore.Store
+	// notifies the archiver that blocks may need to be archived
+	archiveSignal chan struct{}
+	// Stateless block ID --> block fetched from [archive].
+	// Only contains blocks whose IDs were fetched by height, so that peers
+	// can't cause the archive to be queried for arbitrary block IDs.
+	archivedBlocks cache.Cacher[ids.ID, PostForkBlock]
 	// block signer
 	stakingLeafSigner crypto.Signer
 	// block certificate
 	stakingCertLeaf *staking.Certificate
+	// allocates the capacity of [innerBlkCache], if not nil
+	cacheBudget *budget.Manager
 
 	state.State
 	hIndexer indexer.HeightIndexer
@@ -143,24 +163,34 @@ func New(
 	minimumPChainHeight uint64,
 	minBlkDelay time.Duration,
 	numHistoricalBlocks uint64,
+	windowerConfig proposer.Config,
+	archive objectstore.Store,
 	stakingLeafSigner crypto.Signer,
 	stakingCertLeaf *staking.Certificate,
+	cacheBudget *budget.Manager,
 ) *VM {
 	blockBuilderVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	preVerifierVM, _ := vm.(block.PreVerifierChainVM)
+	durableVM, _ := vm.(block.DurableChainVM)
 	return &VM{
 		ChainVM:        vm,
 		blockBuilderVM: blockBuilderVM,
 		batchedVM:      batchedVM,
 		ssVM:           ssVM,
+		preVerifierVM:  preVerifierVM,
+		durableVM:      durableVM,
 
 		activationTime:      activationTime,
 		minimumPChainHeight: minimumPChainHeight,
 		minBlkDelay:         minBlkDelay,
 		numHistoricalBlocks: numHistoricalBlocks,
+		windowerConfig:      windowerConfig,
+		archive:             archive,
 		stakingLeafSigner:   stakingLeafSigner,
 		stakingCertLeaf:     stakingCertLeaf,
+		cacheBudget:         cacheBudget,
 	}
 }
 
@@ -201,15 +231,21 @@ func (vm *VM) Initialize(
 		return err
 	}
 	vm.State = baseState
-	vm.Windower = proposer.New(chainCtx.ValidatorState, chainCtx.SubnetID, chainCtx.ChainID)
+	vm.Windower = proposer.New(chainCtx.ValidatorState, chainCtx.SubnetID, chainCtx.ChainID, vm.windowerConfig)
 	vm.Tree = tree.New()
+	innerBlkSizedCache, err := budget.NewSizedLRU[ids.ID, snowman.Block](
+		vm.cacheBudget,
+		vm.innerBlkCacheName(),
+		innerBlkCacheSize,
+		cachedBlockSize,
+	)
+	if err != nil {
+		return err
+	}
 	innerBlkCache, err := metercacher.New[ids.ID, snowman.Block](
 		"inner_block_cache",
 		registerer,
-		cache.NewSizedLRU[ids.ID, snowman.Block](
-			innerBlkCacheSize,
-			cachedBlockSize,
-		),
+		innerBlkSizedCache,
 	)
 	if err != nil {
 		return err
@@ -234,6 +270,12 @@ func (vm *VM) Initialize(
 	vm.context = context
 	vm.onShutdown = cancel
 
+	if vm.archive != nil {
+		vm.archiveSignal = make(chan struct{}, 1)
+		vm.archivedBlocks = &cache.LRU[ids.ID, PostForkBlock]{Size: archivedBlkCacheSize}
+		go chainCtx.Log.RecoverAndPanic(vm.runArchiver)
+	}
+
 	err = vm.ChainVM.Initialize(
 		ctx,
 		chainCtx,
@@ -279,18 +321,57 @@ func (vm *VM) Initialize(
 	return nil
 }
 
+// CreateHandlers adds the proposer schedule endpoint to the handlers of the
+// inner VM.
+func (vm *VM) CreateHandlers(ctx context.Context) (map[string]*common.HTTPHandler, error) {
+	handlers, err := vm.ChainVM.CreateHandlers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := handlers[proposerEndpoint]; ok {
+		vm.ctx.Log.Warn("not serving the proposer schedule",
+			zap.String("reason", "endpoint is used by the inner VM"),
+			zap.String("endpoint", proposerEndpoint),
+		)
+		return handlers, nil
+	}
+
+	handler, err := newHandler(vm)
+	if err != nil {
+		return nil, err
+	}
+	if handlers == nil {
+		handlers = make(map[string]*common.HTTPHandler, 1)
+	}
+	handlers[proposerEndpoint] = &common.HTTPHandler{
+		LockOptions: common.ReadLock,
+		Handler:     handler,
+	}
+	return handlers, nil
+}
+
 // shutdown ops then propagate shutdown to innerVM
 func (vm *VM) Shutdown(ctx context.Context) error {
 	vm.onShutdown()
 
 	vm.Scheduler.Close()
 
+	if vm.cacheBudget != nil {
+		vm.cacheBudget.Unregister(vm.innerBlkCacheName())
+	}
+
 	if err := vm.db.Commit(); err != nil {
 		return err
 	}
 	return vm.ChainVM.Shutdown(ctx)
 }
 
+// innerBlkCacheName returns the name that [innerBlkCache] is allocated from
+// [cacheBudget] under.
+func (vm *VM) innerBlkCacheName() string {
+	return fmt.Sprintf("%s_inner_block_cache", vm.ctx.ChainID)
+}
+
 func (vm *VM) SetState(ctx context.Context, newState snow.State) error {
 	if err := vm.ChainVM.SetState(ctx, newState); err != nil {
 		return err
@@ -757,10 +838,23 @@ func (vm *VM) getPostForkBlock(ctx context.Context, blkID ids.ID) (PostForkBlock
 	}
 
 	statelessBlock, status, err := vm.State.GetBlock(blkID)
+	if err == database.ErrNotFound && vm.archive != nil {
+		if blk, ok := vm.archivedBlocks.Get(blkID); ok {
+			return blk, nil
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
+	return vm.newPostForkBlock(ctx, blkID, statelessBlock, status)
+}
 
+func (vm *VM) newPostForkBlock(
+	ctx context.Context,
+	blkID ids.ID,
+	statelessBlock statelessblock.Block,
+	status choices.Status,
+) (PostForkBlock, error) {
 	innerBlkBytes := statelessBlock.Block()
 	innerBlk, err := vm.parseInnerBlock(ctx, blkID, innerBlkBytes)
 	if err != nil {
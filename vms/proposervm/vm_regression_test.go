@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 )
 
 func TestProposerVMInitializeShouldFailIfInnerVMCantVerifyItsHeightIndex(t *testing.T) {
@@ -49,8 +50,11 @@ func TestProposerVMInitializeShouldFailIfInnerVMCantVerifyItsHeightIndex(t *test
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 	defer func() {
 		// avoids leaking goroutines
@@ -9,6 +9,7 @@ import (
 	"crypto"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -16,6 +17,8 @@ import (
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/rpcerror"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/ids"
@@ -28,6 +31,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
@@ -138,8 +142,11 @@ func initTestProposerVM(
 		minPChainHeight,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	valState := &validators.TestState{
@@ -877,8 +884,11 @@ func TestExpiredBuildBlock(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	valState := &validators.TestState{
@@ -1220,8 +1230,11 @@ func TestInnerVMRollback(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	require.NoError(proVM.Initialize(
@@ -1307,8 +1320,11 @@ func TestInnerVMRollback(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	require.NoError(proVM.Initialize(
@@ -1799,8 +1815,11 @@ func TestRejectedHeightNotIndexed(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	valState := &validators.TestState{
@@ -2003,8 +2022,11 @@ func TestRejectedOptionHeightNotIndexed(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	valState := &validators.TestState{
@@ -2163,8 +2185,11 @@ func TestVMInnerBlkCache(t *testing.T) {
 		0,           // minimum P-Chain height
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	dummyDBManager := manager.NewMemDB(version.Semantic1_0_0)
@@ -2396,8 +2421,11 @@ func TestVM_VerifyBlockWithContext(t *testing.T) {
 		0,           // minimum P-Chain height
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	dummyDBManager := manager.NewMemDB(version.Semantic1_0_0)
@@ -2609,8 +2637,11 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	require.NoError(proVM.Initialize(
@@ -2659,10 +2690,20 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 		currentHeight++
 	}
 
+	getBlockByHeight := func(height uint64) error {
+		s := &Service{vm: proVM}
+		return s.GetBlockByHeight(
+			&http.Request{},
+			&api.GetBlockByHeightArgs{Height: json.Uint64(height)},
+			&api.GetBlockResponse{},
+		)
+	}
+
 	requireHeights := func(start, end uint64) {
 		for i := start; i <= end; i++ {
 			_, err := proVM.GetBlockIDAtHeight(context.Background(), i)
 			require.NoError(err)
+			require.NoError(getBlockByHeight(i))
 		}
 	}
 
@@ -2670,6 +2711,20 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 		for i := start; i <= end; i++ {
 			_, err := proVM.GetBlockIDAtHeight(context.Background(), i)
 			require.ErrorIs(err, database.ErrNotFound)
+
+			// The missing heights are reported as pruned below the first
+			// height that is kept.
+			require.True(rpcerror.Is(err, rpcerror.Pruned))
+			retention, ok := rpcerror.RetentionOf(err)
+			require.True(ok)
+			require.Equal(end+1, retention.MinHeight)
+
+			// The API reports the retention policy of the node.
+			err = getBlockByHeight(i)
+			require.True(rpcerror.Is(err, rpcerror.Pruned))
+			retention, ok = rpcerror.RetentionOf(err)
+			require.True(ok)
+			require.Equal(end+1, retention.MinHeight)
 		}
 	}
 
@@ -2707,8 +2762,11 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		numHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	require.NoError(proVM.Initialize(
@@ -2749,8 +2807,11 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		newNumHistoricalBlocks,
+		proposer.DefaultConfig,
+		nil,
 		pTestSigner,
 		pTestCert,
+		nil,
 	)
 
 	require.NoError(proVM.Initialize(
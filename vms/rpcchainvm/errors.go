@@ -12,16 +12,20 @@ import (
 
 var (
 	errEnumToError = map[vmpb.Error]error{
-		vmpb.Error_ERROR_CLOSED:                     database.ErrClosed,
-		vmpb.Error_ERROR_NOT_FOUND:                  database.ErrNotFound,
-		vmpb.Error_ERROR_HEIGHT_INDEX_INCOMPLETE:    block.ErrIndexIncomplete,
-		vmpb.Error_ERROR_STATE_SYNC_NOT_IMPLEMENTED: block.ErrStateSyncableVMNotImplemented,
+		vmpb.Error_ERROR_CLOSED:                       database.ErrClosed,
+		vmpb.Error_ERROR_NOT_FOUND:                    database.ErrNotFound,
+		vmpb.Error_ERROR_HEIGHT_INDEX_INCOMPLETE:      block.ErrIndexIncomplete,
+		vmpb.Error_ERROR_STATE_SYNC_NOT_IMPLEMENTED:   block.ErrStateSyncableVMNotImplemented,
+		vmpb.Error_ERROR_PRE_VERIFIER_NOT_IMPLEMENTED: block.ErrPreVerifierVMNotImplemented,
+		vmpb.Error_ERROR_DURABLE_VM_NOT_IMPLEMENTED:   block.ErrDurableVMNotImplemented,
 	}
 	errorToErrEnum = map[error]vmpb.Error{
 		database.ErrClosed:                     vmpb.Error_ERROR_CLOSED,
 		database.ErrNotFound:                   vmpb.Error_ERROR_NOT_FOUND,
 		block.ErrIndexIncomplete:               vmpb.Error_ERROR_HEIGHT_INDEX_INCOMPLETE,
 		block.ErrStateSyncableVMNotImplemented: vmpb.Error_ERROR_STATE_SYNC_NOT_IMPLEMENTED,
+		block.ErrPreVerifierVMNotImplemented:   vmpb.Error_ERROR_PRE_VERIFIER_NOT_IMPLEMENTED,
+		block.ErrDurableVMNotImplemented:       vmpb.Error_ERROR_DURABLE_VM_NOT_IMPLEMENTED,
 	}
 )
 
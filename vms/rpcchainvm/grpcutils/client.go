@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 )
@@ -103,3 +104,11 @@ func WithChainStreamInterceptor(interceptors ...grpc.StreamClientInterceptor) Di
 		d.opts = append(d.opts, grpc.WithChainStreamInterceptor(interceptors...))
 	}
 }
+
+// WithTransportCredentials overrides the insecure transport credentials that
+// are set by DefaultDialOptions.
+func WithTransportCredentials(creds credentials.TransportCredentials) DialOption {
+	return func(d *DialOptions) {
+		d.opts = append(d.opts, grpc.WithTransportCredentials(creds))
+	}
+}
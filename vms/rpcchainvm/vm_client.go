@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
@@ -19,19 +20,24 @@ import (
 	"go.uber.org/zap"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/ava-labs/avalanchego/api/keystore/gkeystore"
+	"github.com/ava-labs/avalanchego/api/keystore/gsigner"
 	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/cache/budget"
 	"github.com/ava-labs/avalanchego/chains/atomic/gsharedmemory"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ids/galiasreader"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
@@ -58,6 +64,7 @@ import (
 	messengerpb "github.com/ava-labs/avalanchego/proto/pb/messenger"
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
 	sharedmemorypb "github.com/ava-labs/avalanchego/proto/pb/sharedmemory"
+	signerpb "github.com/ava-labs/avalanchego/proto/pb/signer"
 	validatorstatepb "github.com/ava-labs/avalanchego/proto/pb/validatorstate"
 	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
 	warppb "github.com/ava-labs/avalanchego/proto/pb/warp"
@@ -74,11 +81,15 @@ const (
 var (
 	errUnsupportedFXs                       = errors.New("unsupported feature extensions")
 	errBatchedParseBlockWrongNumberOfBlocks = errors.New("BatchedParseBlock returned different number of blocks than expected")
+	errUnknownAppOp                         = errors.New("unknown app message op")
 
 	_ block.ChainVM                      = (*VMClient)(nil)
 	_ block.BuildBlockWithContextChainVM = (*VMClient)(nil)
 	_ block.BatchedChainVM               = (*VMClient)(nil)
 	_ block.StateSyncableVM              = (*VMClient)(nil)
+	_ block.PreVerifierChainVM           = (*VMClient)(nil)
+	_ block.DurableChainVM               = (*VMClient)(nil)
+	_ common.ConcurrentAppHandler        = (*VMClient)(nil)
 	_ prometheus.Gatherer                = (*VMClient)(nil)
 
 	_ snowman.Block           = (*blockClient)(nil)
@@ -97,6 +108,7 @@ type VMClient struct {
 
 	messenger            *messenger.Server
 	keystore             *gkeystore.Server
+	keystoreSigner       *gsigner.Server
 	sharedMemory         *gsharedmemory.Server
 	bcLookup             *galiasreader.Server
 	appSender            *appsender.Server
@@ -107,6 +119,12 @@ type VMClient struct {
 	conns        []*grpc.ClientConn
 
 	grpcServerMetrics *grpc_prometheus.ServerMetrics
+
+	appConcurrency map[message.Op]int
+
+	// allocates the capacity of the decided block cache, if not nil
+	cacheBudget      *budget.Manager
+	decidedCacheName string
 }
 
 // NewClient returns a VM connected to a remote VM
@@ -179,6 +197,9 @@ func (vm *VMClient) Initialize(
 
 	vm.messenger = messenger.NewServer(toEngine)
 	vm.keystore = gkeystore.NewServer(chainCtx.Keystore)
+	if signer := chainCtx.Keystore.Signer(); signer != nil {
+		vm.keystoreSigner = gsigner.NewServer(signer)
+	}
 	vm.sharedMemory = gsharedmemory.NewServer(chainCtx.SharedMemory, dbManager.Current().Database)
 	vm.bcLookup = galiasreader.NewServer(chainCtx.BCLookup)
 	vm.appSender = appsender.NewServer(appSender)
@@ -211,6 +232,9 @@ func (vm *VMClient) Initialize(
 		ConfigBytes:  configBytes,
 		DbServers:    versionedDBServers,
 		ServerAddr:   serverAddr,
+		MaxBlockSize: uint64(chainCtx.Limits.MaxBlockSize),
+		MaxBlockTxs:  uint64(chainCtx.Limits.MaxBlockTxs),
+		MaxTxSize:    uint64(chainCtx.Limits.MaxTxSize),
 	})
 	if err != nil {
 		return err
@@ -230,6 +254,16 @@ func (vm *VMClient) Initialize(
 		return err
 	}
 
+	if len(resp.AppConcurrency) > 0 {
+		vm.appConcurrency = make(map[message.Op]int, len(resp.AppConcurrency))
+		for op, limit := range resp.AppConcurrency {
+			if op > math.MaxUint8 {
+				return fmt.Errorf("%w: %d", errUnknownAppOp, op)
+			}
+			vm.appConcurrency[message.Op(op)] = int(limit)
+		}
+	}
+
 	// We don't need to check whether this is a block.WithVerifyContext because
 	// we'll never Verify this block.
 	lastAcceptedBlk := &blockClient{
@@ -242,6 +276,8 @@ func (vm *VMClient) Initialize(
 		time:     time,
 	}
 
+	vm.cacheBudget = chainCtx.CacheBudget
+	vm.decidedCacheName = fmt.Sprintf("%s_decided_block_cache", chainCtx.ChainID)
 	chainState, err := chain.NewMeteredState(
 		registerer,
 		&chain.Config{
@@ -249,6 +285,8 @@ func (vm *VMClient) Initialize(
 			MissingCacheSize:      missingCacheSize,
 			UnverifiedCacheSize:   unverifiedCacheSize,
 			BytesToIDCacheSize:    bytesToIDCacheSize,
+			CacheBudget:           chainCtx.CacheBudget,
+			DecidedCacheName:      vm.decidedCacheName,
 			LastAcceptedBlock:     lastAcceptedBlk,
 			GetBlock:              vm.getBlock,
 			UnmarshalBlock:        vm.parseBlock,
@@ -308,6 +346,12 @@ func (vm *VMClient) newInitServer() *grpc.Server {
 	healthpb.RegisterHealthServer(server, grpcHealth)
 	validatorstatepb.RegisterValidatorStateServer(server, vm.validatorStateServer)
 	warppb.RegisterSignerServer(server, vm.warpSignerServer)
+	if vm.keystoreSigner != nil {
+		signerpb.RegisterSignerServer(server, vm.keystoreSigner)
+		// The VM checks the serving status to find out whether the keystore
+		// has a signing plugin.
+		grpcHealth.SetServingStatus(signerpb.Signer_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	}
 
 	// Ensure metric counters are zeroed on restart
 	grpc_prometheus.Register(server)
@@ -364,6 +408,10 @@ func (vm *VMClient) Shutdown(ctx context.Context) error {
 	vm.runtime.Stop(ctx)
 
 	vm.processTracker.UntrackProcess(vm.pid)
+
+	if vm.cacheBudget != nil {
+		vm.cacheBudget.Unregister(vm.decidedCacheName)
+	}
 	return errs.Err
 }
 
@@ -857,6 +905,32 @@ func (b *blockClient) ID() ids.ID {
 	return b.id
 }
 
+func (vm *VMClient) AppConcurrency() map[message.Op]int {
+	return vm.appConcurrency
+}
+
+func (vm *VMClient) PreVerifyBlock(ctx context.Context, blk snowman.Block) error {
+	resp, err := vm.client.BlockPreVerify(ctx, &vmpb.BlockPreVerifyRequest{
+		Bytes: blk.Bytes(),
+	})
+	// Plugins built before BlockPreVerify was added don't serve it.
+	if status.Code(err) == codes.Unimplemented {
+		return block.ErrPreVerifierVMNotImplemented
+	}
+	if err != nil {
+		return err
+	}
+	return errEnumToError[resp.Err]
+}
+
+func (vm *VMClient) LastDurableHeight(ctx context.Context) (uint64, error) {
+	resp, err := vm.client.LastDurableHeight(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Height, errEnumToError[resp.Err]
+}
+
 func (b *blockClient) Accept(ctx context.Context) error {
 	b.status = choices.Accepted
 	_, err := b.vm.client.BlockAccept(ctx, &vmpb.BlockAcceptRequest{
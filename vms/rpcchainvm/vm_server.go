@@ -16,9 +16,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/api/keystore/gkeystore"
+	"github.com/ava-labs/avalanchego/api/keystore/gsigner"
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/chains/atomic/gsharedmemory"
 	"github.com/ava-labs/avalanchego/database/corruptabledb"
@@ -49,6 +54,7 @@ import (
 	messengerpb "github.com/ava-labs/avalanchego/proto/pb/messenger"
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
 	sharedmemorypb "github.com/ava-labs/avalanchego/proto/pb/sharedmemory"
+	signerpb "github.com/ava-labs/avalanchego/proto/pb/signer"
 	validatorstatepb "github.com/ava-labs/avalanchego/proto/pb/validatorstate"
 	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
 	warppb "github.com/ava-labs/avalanchego/proto/pb/warp"
@@ -60,6 +66,7 @@ var (
 	originalStderr = os.Stderr
 
 	errExpectedBlockWithVerifyContext = errors.New("expected block.WithVerifyContext")
+	errInvalidAppConcurrency          = errors.New("invalid app concurrency")
 )
 
 // VMServer is a VM that is managed over RPC.
@@ -71,6 +78,10 @@ type VMServer struct {
 	bVM block.BuildBlockWithContextChainVM
 	// If nil, the underlying VM doesn't implement the interface.
 	ssVM block.StateSyncableVM
+	// If nil, the underlying VM doesn't implement the interface.
+	pVM block.PreVerifierChainVM
+	// If nil, the underlying VM doesn't implement the interface.
+	dVM block.DurableChainVM
 
 	allowShutdown *utils.Atomic[bool]
 
@@ -89,10 +100,14 @@ type VMServer struct {
 func NewServer(vm block.ChainVM, allowShutdown *utils.Atomic[bool]) *VMServer {
 	bVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	pVM, _ := vm.(block.PreVerifierChainVM)
+	dVM, _ := vm.(block.DurableChainVM)
 	return &VMServer{
 		vm:            vm,
 		bVM:           bVM,
 		ssVM:          ssVM,
+		pVM:           pVM,
+		dVM:           dVM,
 		allowShutdown: allowShutdown,
 	}
 }
@@ -209,7 +224,11 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 	vm.connCloser.Add(clientConn)
 
 	msgClient := messenger.NewClient(messengerpb.NewMessengerClient(clientConn))
-	keystoreClient := gkeystore.NewClient(keystorepb.NewKeystoreClient(clientConn))
+	var keystoreSigner keystore.Signer
+	if servesKeystoreSigner(ctx, clientConn) {
+		keystoreSigner = gsigner.NewClient(signerpb.NewSignerClient(clientConn))
+	}
+	keystoreClient := gkeystore.NewClientWithSigner(keystorepb.NewKeystoreClient(clientConn), keystoreSigner)
 	sharedMemoryClient := gsharedmemory.NewClient(sharedmemorypb.NewSharedMemoryClient(clientConn))
 	bcLookupClient := galiasreader.NewClient(aliasreaderpb.NewAliasReaderClient(clientConn))
 	appSenderClient := appsender.NewClient(appsenderpb.NewAppSenderClient(clientConn))
@@ -257,6 +276,12 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 		// TODO: support remaining snowman++ fields
 
 		ChainDataDir: req.ChainDataDir,
+
+		Limits: snow.Limits{
+			MaxBlockSize: int(req.MaxBlockSize),
+			MaxBlockTxs:  int(req.MaxBlockTxs),
+			MaxTxSize:    int(req.MaxTxSize),
+		},
 	}
 
 	if err := vm.vm.Initialize(ctx, vm.ctx, dbManager, req.GenesisBytes, req.UpgradeBytes, req.ConfigBytes, toEngine, nil, appSenderClient); err != nil {
@@ -283,6 +308,15 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 		close(vm.closed)
 		return nil, err
 	}
+	appConcurrency, err := vm.appConcurrency()
+	if err != nil {
+		// Ignore errors closing resources to return the original error
+		_ = vm.vm.Shutdown(ctx)
+		_ = vm.connCloser.Close()
+		close(vm.closed)
+		return nil, err
+	}
+
 	parentID := blk.Parent()
 	return &vmpb.InitializeResponse{
 		LastAcceptedId:       lastAccepted[:],
@@ -290,9 +324,29 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 		Height:               blk.Height(),
 		Bytes:                blk.Bytes(),
 		Timestamp:            grpcutils.TimestampFromTime(blk.Timestamp()),
+		AppConcurrency:       appConcurrency,
 	}, nil
 }
 
+// appConcurrency returns the App message concurrency limits that the VM
+// requested, if any.
+func (vm *VMServer) appConcurrency() (map[uint32]uint32, error) {
+	concurrentVM, ok := vm.vm.(common.ConcurrentAppHandler)
+	if !ok {
+		return nil, nil
+	}
+
+	limits := concurrentVM.AppConcurrency()
+	appConcurrency := make(map[uint32]uint32, len(limits))
+	for op, limit := range limits {
+		if limit <= 0 {
+			return nil, fmt.Errorf("%w: %s limit %d <= 0", errInvalidAppConcurrency, op, limit)
+		}
+		appConcurrency[uint32(op)] = uint32(limit)
+	}
+	return appConcurrency, nil
+}
+
 func (vm *VMServer) SetState(ctx context.Context, stateReq *vmpb.SetStateRequest) (*vmpb.SetStateResponse, error) {
 	err := vm.vm.SetState(ctx, snow.State(stateReq.State))
 	if err != nil {
@@ -854,6 +908,40 @@ func (vm *VMServer) BlockVerify(ctx context.Context, req *vmpb.BlockVerifyReques
 	}, nil
 }
 
+// BlockPreVerify may be called concurrently with the other calls to the VM,
+// so VMs that implement block.PreVerifierChainVM must support parsing blocks
+// concurrently. The block is parsed again when it is verified, so the
+// pre-verified checks are only skipped if the VM caches parsed blocks.
+func (vm *VMServer) BlockPreVerify(ctx context.Context, req *vmpb.BlockPreVerifyRequest) (*vmpb.BlockPreVerifyResponse, error) {
+	err := block.ErrPreVerifierVMNotImplemented
+	if vm.pVM != nil {
+		var blk snowman.Block
+		blk, err = vm.vm.ParseBlock(ctx, req.Bytes)
+		if err == nil {
+			err = vm.pVM.PreVerifyBlock(ctx, blk)
+		}
+	}
+
+	return &vmpb.BlockPreVerifyResponse{
+		Err: errorToErrEnum[err],
+	}, errorToRPCError(err)
+}
+
+func (vm *VMServer) LastDurableHeight(ctx context.Context, _ *emptypb.Empty) (*vmpb.LastDurableHeightResponse, error) {
+	var (
+		height uint64
+		err    = block.ErrDurableVMNotImplemented
+	)
+	if vm.dVM != nil {
+		height, err = vm.dVM.LastDurableHeight(ctx)
+	}
+
+	return &vmpb.LastDurableHeightResponse{
+		Height: height,
+		Err:    errorToErrEnum[err],
+	}, errorToRPCError(err)
+}
+
 func (vm *VMServer) BlockAccept(ctx context.Context, req *vmpb.BlockAcceptRequest) (*emptypb.Empty, error) {
 	id, err := ids.ToID(req.Id)
 	if err != nil {
@@ -907,3 +995,12 @@ func (vm *VMServer) StateSummaryAccept(
 		Err:  errorToErrEnum[err],
 	}, errorToRPCError(err)
 }
+
+// servesKeystoreSigner returns true if the node serves the signing plugin of
+// its keystore over [conn].
+func servesKeystoreSigner(ctx context.Context, conn grpc.ClientConnInterface) bool {
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: signerpb.Signer_ServiceDesc.ServiceName,
+	})
+	return err == nil && resp.Status == healthpb.HealthCheckResponse_SERVING
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+)
+
+var _ Batcher = (*Fx)(nil)
+
+// Batcher is implemented by feature extensions that are able to defer
+// signature verification so that all the signatures of a block can be verified
+// in parallel.
+type Batcher interface {
+	// StartBatch causes subsequent credential verifications to only perform
+	// the stateful checks. Signature verification is deferred until
+	// FinishBatch is called.
+	StartBatch()
+
+	// FinishBatch verifies all the signatures deferred since StartBatch was
+	// called and stops batching.
+	FinishBatch() error
+
+	// AbortBatch drops all the signatures deferred since StartBatch was called
+	// and stops batching.
+	AbortBatch()
+}
+
+// sigVerification is a deferred check that [sig] is a signature of [hash]
+// produced by the private key controlling [addr].
+type sigVerification struct {
+	hash []byte
+	sig  [secp256k1.SignatureLen]byte
+	addr ids.ShortID
+}
+
+func (fx *Fx) StartBatch() {
+	fx.batching = true
+	fx.batch = fx.batch[:0]
+}
+
+func (fx *Fx) FinishBatch() error {
+	batch := fx.batch
+	fx.AbortBatch()
+	return verifySignatures(&fx.SECPFactory, fx.sigCache, batch, runtime.NumCPU())
+}
+
+func (fx *Fx) AbortBatch() {
+	fx.batching = false
+	fx.batch = nil
+}
+
+// verifySignatures verifies [sigs] using at most [numWorkers] goroutines. As
+// soon as an invalid signature is found, the remaining signatures are skipped
+// and the error is returned.
+func verifySignatures(factory *secp256k1.Factory, sigCache *SigCache, sigs []sigVerification, numWorkers int) error {
+	if numWorkers > len(sigs) {
+		numWorkers = len(sigs)
+	}
+	if numWorkers <= 1 {
+		for i := range sigs {
+			if err := verifySignature(factory, sigCache, &sigs[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var (
+		next    atomic.Int64
+		failed  atomic.Bool
+		errOnce sync.Once
+		err     error
+		wg      sync.WaitGroup
+	)
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for !failed.Load() {
+				index := int(next.Add(1) - 1)
+				if index >= len(sigs) {
+					return
+				}
+
+				if sigErr := verifySignature(factory, sigCache, &sigs[index]); sigErr != nil {
+					errOnce.Do(func() {
+						err = sigErr
+					})
+					failed.Store(true)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return err
+}
+
+// verifySignature verifies [s]. If [sigCache] is non-nil, it is used to skip
+// signatures that were previously verified and to remember the signature if it
+// is valid.
+func verifySignature(factory *secp256k1.Factory, sigCache *SigCache, s *sigVerification) error {
+	if sigCache != nil && sigCache.contains(s) {
+		return nil
+	}
+
+	pk, err := factory.RecoverHashPublicKey(s.hash, s.sig[:])
+	if err != nil {
+		return err
+	}
+	if addr := pk.Address(); addr != s.addr {
+		return fmt.Errorf("%w: expected signature from %s but got from %s",
+			ErrWrongSig,
+			s.addr,
+			addr,
+		)
+	}
+
+	if sigCache != nil {
+		sigCache.add(s)
+	}
+	return nil
+}
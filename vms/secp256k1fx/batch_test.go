@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newBatchTestFx(require *require.Assertions) *Fx {
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := &Fx{}
+	require.NoError(fx.Initialize(&vm))
+	require.NoError(fx.Bootstrapping())
+	require.NoError(fx.Bootstrapped())
+	return fx
+}
+
+// newSignedTransfers returns [numTxs] transactions, each spending a single
+// output owned by a fresh key.
+func newSignedTransfers(require *require.Assertions, numTxs int) ([]*TestTx, []*TransferInput, []*Credential, []*TransferOutput) {
+	factory := secp256k1.Factory{}
+
+	txs := make([]*TestTx, numTxs)
+	ins := make([]*TransferInput, numTxs)
+	creds := make([]*Credential, numTxs)
+	outs := make([]*TransferOutput, numTxs)
+	for i := 0; i < numTxs; i++ {
+		key, err := factory.NewPrivateKey()
+		require.NoError(err)
+
+		txs[i] = &TestTx{UnsignedBytes: []byte(fmt.Sprintf("tx %d", i))}
+		sig, err := key.SignHash(hashing.ComputeHash256(txs[i].UnsignedBytes))
+		require.NoError(err)
+
+		cred := &Credential{
+			Sigs: make([][secp256k1.SignatureLen]byte, 1),
+		}
+		copy(cred.Sigs[0][:], sig)
+		creds[i] = cred
+
+		ins[i] = &TransferInput{
+			Amt: 1,
+			Input: Input{
+				SigIndices: []uint32{0},
+			},
+		}
+		outs[i] = &TransferOutput{
+			Amt: 1,
+			OutputOwners: OutputOwners{
+				Threshold: 1,
+				Addrs: []ids.ShortID{
+					key.PublicKey().Address(),
+				},
+			},
+		}
+	}
+	return txs, ins, creds, outs
+}
+
+func TestFxBatchVerifyTransfer(t *testing.T) {
+	require := require.New(t)
+
+	fx := newBatchTestFx(require)
+	txs, ins, creds, outs := newSignedTransfers(require, 64)
+
+	fx.StartBatch()
+	for i := range txs {
+		require.NoError(fx.VerifyTransfer(txs[i], ins[i], creds[i], outs[i]))
+	}
+	require.Len(fx.batch, len(txs))
+	require.NoError(fx.FinishBatch())
+	require.False(fx.batching)
+	require.Empty(fx.batch)
+}
+
+func TestFxBatchVerifyTransferWrongSig(t *testing.T) {
+	require := require.New(t)
+
+	fx := newBatchTestFx(require)
+	txs, ins, creds, outs := newSignedTransfers(require, 64)
+
+	// Swap the signatures of two of the transactions
+	creds[10], creds[20] = creds[20], creds[10]
+
+	fx.StartBatch()
+	for i := range txs {
+		// The signatures aren't checked until the batch is finished
+		require.NoError(fx.VerifyTransfer(txs[i], ins[i], creds[i], outs[i]))
+	}
+	err := fx.FinishBatch()
+	require.ErrorIs(err, ErrWrongSig)
+	require.False(fx.batching)
+}
+
+func TestFxBatchVerifyTransferStatefulChecks(t *testing.T) {
+	require := require.New(t)
+
+	fx := newBatchTestFx(require)
+	txs, ins, creds, outs := newSignedTransfers(require, 1)
+	ins[0].SigIndices = []uint32{1}
+
+	// Checks that don't require signature verification must not be deferred
+	fx.StartBatch()
+	err := fx.VerifyTransfer(txs[0], ins[0], creds[0], outs[0])
+	require.ErrorIs(err, ErrInputOutputIndexOutOfBounds)
+	fx.AbortBatch()
+}
+
+func TestFxAbortBatch(t *testing.T) {
+	require := require.New(t)
+
+	fx := newBatchTestFx(require)
+	txs, ins, creds, outs := newSignedTransfers(require, 2)
+	creds[0], creds[1] = creds[1], creds[0]
+
+	fx.StartBatch()
+	require.NoError(fx.VerifyTransfer(txs[0], ins[0], creds[0], outs[0]))
+	fx.AbortBatch()
+
+	// After aborting, signatures are verified immediately again
+	err := fx.VerifyTransfer(txs[1], ins[1], creds[1], outs[1])
+	require.ErrorIs(err, ErrWrongSig)
+	require.NoError(fx.FinishBatch())
+}
+
+func BenchmarkVerifyCredentials(b *testing.B) {
+	require := require.New(b)
+
+	for _, numTxs := range []int{100, 250, 500, 1000} {
+		txs, ins, creds, outs := newSignedTransfers(require, numTxs)
+
+		b.Run(fmt.Sprintf("sequential_%d", numTxs), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				fx := newBatchTestFx(require)
+				for i := range txs {
+					require.NoError(fx.VerifyTransfer(txs[i], ins[i], creds[i], outs[i]))
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("batched_%d_workers_%d", numTxs, runtime.NumCPU()), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				fx := newBatchTestFx(require)
+				fx.StartBatch()
+				for i := range txs {
+					require.NoError(fx.VerifyTransfer(txs[i], ins[i], creds[i], outs[i]))
+				}
+				require.NoError(fx.FinishBatch())
+			}
+		})
+	}
+}
@@ -43,6 +43,15 @@ type Fx struct {
 	VM           VM
 	SECPFactory  secp256k1.Factory
 	bootstrapped bool
+
+	// batching is true if signature verification is currently being deferred
+	// until FinishBatch is called.
+	batching bool
+	batch    []sigVerification
+
+	// sigCache, if non-nil, is used to skip verifying signatures that were
+	// previously verified.
+	sigCache *SigCache
 }
 
 func (fx *Fx) Initialize(vmIntf interface{}) error {
@@ -201,16 +210,17 @@ func (fx *Fx) VerifyCredentials(utx UnsignedTx, in *Input, cred *Credential, out
 		}
 		// Make sure each signature in the signature list is from an owner of
 		// the output being consumed
-		sig := cred.Sigs[i]
-		pk, err := fx.SECPFactory.RecoverHashPublicKey(txHash, sig[:])
-		if err != nil {
-			return err
+		sig := sigVerification{
+			hash: txHash,
+			sig:  cred.Sigs[i],
+			addr: out.Addrs[index],
 		}
-		if expectedAddress := out.Addrs[index]; expectedAddress != pk.Address() {
-			return fmt.Errorf("%w: expected signature from %s but got from %s",
-				ErrWrongSig,
-				expectedAddress,
-				pk.Address())
+		if fx.batching {
+			fx.batch = append(fx.batch, sig)
+			continue
+		}
+		if err := verifySignature(&fx.SECPFactory, fx.sigCache, &sig); err != nil {
+			return err
 		}
 	}
 
@@ -30,6 +30,8 @@ type Keychain struct {
 	factory            *secp256k1.Factory
 	avaxAddrToKeyIndex map[ids.ShortID]int
 	ethAddrToKeyIndex  map[common.Address]int
+	// Address --> signer whose key isn't held by this keychain
+	avaxAddrToSigner map[ids.ShortID]keychain.Signer
 
 	// These can be used to iterate over. However, they should not be modified
 	// externally.
@@ -44,6 +46,7 @@ func NewKeychain(keys ...*secp256k1.PrivateKey) *Keychain {
 		factory:            &secp256k1.Factory{},
 		avaxAddrToKeyIndex: make(map[ids.ShortID]int),
 		ethAddrToKeyIndex:  make(map[common.Address]int),
+		avaxAddrToSigner:   make(map[ids.ShortID]keychain.Signer),
 	}
 	for _, key := range keys {
 		kc.Add(key)
@@ -65,9 +68,20 @@ func (kc *Keychain) Add(key *secp256k1.PrivateKey) {
 	}
 }
 
+// AddSigner adds a signer whose key isn't held by the keychain, such as a key
+// held by a remote signing service, to the key chain. The signer can be used
+// to spend outputs, but it isn't included in [Keys] or [EthAddrs].
+func (kc *Keychain) AddSigner(signer keychain.Signer) {
+	avaxAddr := signer.Address()
+	if _, ok := kc.getSigner(avaxAddr); !ok {
+		kc.avaxAddrToSigner[avaxAddr] = signer
+		kc.Addrs.Add(avaxAddr)
+	}
+}
+
 // Get a key from the keychain and return whether the key existed.
 func (kc Keychain) Get(id ids.ShortID) (keychain.Signer, bool) {
-	return kc.get(id)
+	return kc.getSigner(id)
 }
 
 // Get a key from the keychain and return whether the key existed.
@@ -101,42 +115,29 @@ func (kc *Keychain) New() (*secp256k1.PrivateKey, error) {
 
 // Spend attempts to create an input
 func (kc *Keychain) Spend(out verify.Verifiable, time uint64) (verify.Verifiable, []*secp256k1.PrivateKey, error) {
-	switch out := out.(type) {
-	case *MintOutput:
-		if sigIndices, keys, able := kc.Match(&out.OutputOwners, time); able {
-			return &Input{
-				SigIndices: sigIndices,
-			}, keys, nil
-		}
-		return nil, nil, errCantSpend
-	case *TransferOutput:
-		if sigIndices, keys, able := kc.Match(&out.OutputOwners, time); able {
-			return &TransferInput{
-				Amt: out.Amt,
-				Input: Input{
-					SigIndices: sigIndices,
-				},
-			}, keys, nil
-		}
-		return nil, nil, errCantSpend
-	}
-	return nil, nil, fmt.Errorf("can't spend UTXO because it is unexpected type %T", out)
+	return spend(out, func(owners *OutputOwners) ([]uint32, []*secp256k1.PrivateKey, bool) {
+		return kc.Match(owners, time)
+	})
+}
+
+// SpendSigners attempts to create an input. Unlike Spend, the input may be
+// signed by signers whose keys aren't held by this keychain.
+func (kc *Keychain) SpendSigners(out verify.Verifiable, time uint64) (verify.Verifiable, []keychain.Signer, error) {
+	return spend(out, func(owners *OutputOwners) ([]uint32, []keychain.Signer, bool) {
+		return kc.MatchSigners(owners, time)
+	})
 }
 
 // Match attempts to match a list of addresses up to the provided threshold
 func (kc *Keychain) Match(owners *OutputOwners, time uint64) ([]uint32, []*secp256k1.PrivateKey, bool) {
-	if time < owners.Locktime {
-		return nil, nil, false
-	}
-	sigs := make([]uint32, 0, owners.Threshold)
-	keys := make([]*secp256k1.PrivateKey, 0, owners.Threshold)
-	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(keys)) < owners.Threshold; i++ {
-		if key, exists := kc.get(owners.Addrs[i]); exists {
-			sigs = append(sigs, i)
-			keys = append(keys, key)
-		}
-	}
-	return sigs, keys, uint32(len(keys)) == owners.Threshold
+	return match(owners, time, kc.get)
+}
+
+// MatchSigners attempts to match a list of addresses up to the provided
+// threshold. Unlike Match, addresses may be matched by signers whose keys
+// aren't held by this keychain.
+func (kc *Keychain) MatchSigners(owners *OutputOwners, time uint64) ([]uint32, []keychain.Signer, bool) {
+	return match(owners, time, kc.getSigner)
 }
 
 // PrefixedString returns the key chain as a string representation with [prefix]
@@ -172,6 +173,59 @@ func (kc Keychain) get(id ids.ShortID) (*secp256k1.PrivateKey, bool) {
 	return nil, false
 }
 
+func (kc Keychain) getSigner(id ids.ShortID) (keychain.Signer, bool) {
+	if key, ok := kc.get(id); ok {
+		return key, true
+	}
+	signer, ok := kc.avaxAddrToSigner[id]
+	return signer, ok
+}
+
+func spend[T any](
+	out verify.Verifiable,
+	match func(*OutputOwners) ([]uint32, []T, bool),
+) (verify.Verifiable, []T, error) {
+	switch out := out.(type) {
+	case *MintOutput:
+		if sigIndices, keys, able := match(&out.OutputOwners); able {
+			return &Input{
+				SigIndices: sigIndices,
+			}, keys, nil
+		}
+		return nil, nil, errCantSpend
+	case *TransferOutput:
+		if sigIndices, keys, able := match(&out.OutputOwners); able {
+			return &TransferInput{
+				Amt: out.Amt,
+				Input: Input{
+					SigIndices: sigIndices,
+				},
+			}, keys, nil
+		}
+		return nil, nil, errCantSpend
+	}
+	return nil, nil, fmt.Errorf("can't spend UTXO because it is unexpected type %T", out)
+}
+
+func match[T any](
+	owners *OutputOwners,
+	time uint64,
+	get func(ids.ShortID) (T, bool),
+) ([]uint32, []T, bool) {
+	if time < owners.Locktime {
+		return nil, nil, false
+	}
+	sigs := make([]uint32, 0, owners.Threshold)
+	keys := make([]T, 0, owners.Threshold)
+	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(keys)) < owners.Threshold; i++ {
+		if key, exists := get(owners.Addrs[i]); exists {
+			sigs = append(sigs, i)
+			keys = append(keys, key)
+		}
+	}
+	return sigs, keys, uint32(len(keys)) == owners.Threshold
+}
+
 func publicKeyToEthAddress(pk *secp256k1.PublicKey) common.Address {
 	return crypto.PubkeyToAddress(*(pk.ToECDSA()))
 }
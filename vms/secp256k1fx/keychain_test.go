@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 )
@@ -62,6 +63,49 @@ func TestKeychainAdd(t *testing.T) {
 	require.True(addrs.Contains(addr))
 }
 
+func TestKeychainAddSigner(t *testing.T) {
+	require := require.New(t)
+	kc := NewKeychain()
+
+	skBytes, err := formatting.Decode(formatting.HexNC, keys[0])
+	require.NoError(err)
+
+	sk, err := kc.factory.ToPrivateKey(skBytes)
+	require.NoError(err)
+
+	// Wrapping the key hides it from the keychain, as if it were held by a
+	// remote signer.
+	signer := &struct{ *secp256k1.PrivateKey }{sk}
+	kc.AddSigner(signer)
+
+	addr, _ := ids.ShortFromString(addrs[0])
+	rsigner, exists := kc.Get(addr)
+	require.True(exists)
+	require.Equal(signer, rsigner)
+	require.Empty(kc.Keys)
+	require.True(kc.Addrs.Contains(addr))
+
+	owners := OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+	_, signers, ok := kc.MatchSigners(&owners, 0)
+	require.True(ok)
+	require.Equal([]keychain.Signer{signer}, signers)
+
+	// The signer can't be returned as a private key.
+	_, _, ok = kc.Match(&owners, 0)
+	require.False(ok)
+
+	// A key that is held by the keychain isn't replaced by the signer.
+	kc = NewKeychain()
+	kc.Add(sk)
+	kc.AddSigner(signer)
+	rsigner, exists = kc.Get(addr)
+	require.True(exists)
+	require.Equal(sk, rsigner)
+}
+
 func TestKeychainNew(t *testing.T) {
 	require := require.New(t)
 	kc := NewKeychain()
@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// DefaultSigCacheSize is the default number of verified signatures that are
+// remembered by a SigCache.
+const DefaultSigCacheSize = 16384
+
+var _ SigCacher = (*Fx)(nil)
+
+// SigCacher is implemented by feature extensions that are able to skip
+// verifying signatures that were previously verified.
+type SigCacher interface {
+	// SetSigCache causes subsequent signature verifications to consult and
+	// populate [sigCache].
+	SetSigCache(sigCache *SigCache)
+}
+
+// SigCache remembers signatures that were successfully verified so that a tx
+// that was verified when it was added to the mempool isn't re-verified when
+// the block containing it is verified.
+//
+// An entry commits to the signed tx hash, the signature, and the address that
+// produced the signature. Because signatures are only cached after they were
+// verified, a cache hit is as strong as verifying the signature.
+//
+// SigCache is safe for concurrent use, so it can be shared between the
+// feature extensions of a VM.
+type SigCache struct {
+	verified cache.Cacher[ids.ID, struct{}]
+
+	hits   prometheus.Counter
+	misses prometheus.Counter
+}
+
+func NewSigCache(
+	size int,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*SigCache, error) {
+	c := &SigCache{
+		verified: &cache.LRU[ids.ID, struct{}]{Size: size},
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sig_cache_hits",
+			Help:      "Number of signature verifications that were skipped because the signature was previously verified",
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sig_cache_misses",
+			Help:      "Number of signature verifications that weren't previously verified",
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(c.hits),
+		registerer.Register(c.misses),
+	)
+	return c, errs.Err
+}
+
+func (c *SigCache) contains(s *sigVerification) bool {
+	_, ok := c.verified.Get(s.key())
+	if ok {
+		c.hits.Inc()
+	} else {
+		c.misses.Inc()
+	}
+	return ok
+}
+
+func (c *SigCache) add(s *sigVerification) {
+	c.verified.Put(s.key(), struct{}{})
+}
+
+func (fx *Fx) SetSigCache(sigCache *SigCache) {
+	fx.sigCache = sigCache
+}
+
+// key returns a unique identifier of the (hash, sig, addr) tuple.
+func (s *sigVerification) key() ids.ID {
+	bytes := make([]byte, 0, len(s.hash)+len(s.sig)+len(s.addr))
+	bytes = append(bytes, s.hash...)
+	bytes = append(bytes, s.sig[:]...)
+	bytes = append(bytes, s.addr[:]...)
+	return hashing.ComputeHash256Array(bytes)
+}
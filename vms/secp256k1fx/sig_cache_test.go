@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigCache(t *testing.T) {
+	require := require.New(t)
+
+	sigCache, err := NewSigCache(DefaultSigCacheSize, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	fx := newBatchTestFx(require)
+	fx.SetSigCache(sigCache)
+	txs, ins, creds, outs := newSignedTransfers(require, 8)
+
+	// Mempool admission verifies the signatures immediately.
+	for i := range txs {
+		require.NoError(fx.VerifyTransfer(txs[i], ins[i], creds[i], outs[i]))
+	}
+	require.Zero(testutil.ToFloat64(sigCache.hits))
+	require.Equal(float64(len(txs)), testutil.ToFloat64(sigCache.misses))
+
+	// Block verification doesn't need to re-verify the signatures.
+	fx.StartBatch()
+	for i := range txs {
+		require.NoError(fx.VerifyTransfer(txs[i], ins[i], creds[i], outs[i]))
+	}
+	require.NoError(fx.FinishBatch())
+	require.Equal(float64(len(txs)), testutil.ToFloat64(sigCache.hits))
+	require.Equal(float64(len(txs)), testutil.ToFloat64(sigCache.misses))
+
+	// Previously verified signatures aren't valid for other txs.
+	err = fx.VerifyTransfer(txs[0], ins[0], creds[1], outs[0])
+	require.ErrorIs(err, ErrWrongSig)
+	err = fx.VerifyTransfer(txs[0], ins[0], creds[0], outs[1])
+	require.ErrorIs(err, ErrWrongSig)
+}
+
+func TestSigCacheSkipsInvalidSignatures(t *testing.T) {
+	require := require.New(t)
+
+	sigCache, err := NewSigCache(DefaultSigCacheSize, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	fx := newBatchTestFx(require)
+	fx.SetSigCache(sigCache)
+	txs, ins, creds, outs := newSignedTransfers(require, 2)
+
+	// Invalid signatures must not be cached.
+	for i := 0; i < 2; i++ {
+		err := fx.VerifyTransfer(txs[0], ins[0], creds[1], outs[0])
+		require.ErrorIs(err, ErrWrongSig)
+	}
+	require.Zero(testutil.ToFloat64(sigCache.hits))
+}
@@ -20,6 +20,7 @@ var (
 	_ snowman.Block           = (*tracedBlock)(nil)
 	_ snowman.OracleBlock     = (*tracedBlock)(nil)
 	_ block.WithVerifyContext = (*tracedBlock)(nil)
+	_ block.WithNumTxs        = (*tracedBlock)(nil)
 
 	errExpectedBlockWithVerifyContext = errors.New("expected block.WithVerifyContext")
 )
@@ -88,6 +89,10 @@ func (b *tracedBlock) Options(ctx context.Context) ([2]snowman.Block, error) {
 	}, nil
 }
 
+func (b *tracedBlock) NumTxs() (int, bool) {
+	return block.NumTxs(b.Block)
+}
+
 func (b *tracedBlock) ShouldVerifyWithContext(ctx context.Context) (bool, error) {
 	blkWithCtx, ok := b.Block.(block.WithVerifyContext)
 	if !ok {
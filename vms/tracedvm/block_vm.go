@@ -25,13 +25,17 @@ var (
 	_ block.BuildBlockWithContextChainVM = (*blockVM)(nil)
 	_ block.BatchedChainVM               = (*blockVM)(nil)
 	_ block.StateSyncableVM              = (*blockVM)(nil)
+	_ block.PreVerifierChainVM           = (*blockVM)(nil)
+	_ block.DurableChainVM               = (*blockVM)(nil)
 )
 
 type blockVM struct {
 	block.ChainVM
-	buildBlockVM block.BuildBlockWithContextChainVM
-	batchedVM    block.BatchedChainVM
-	ssVM         block.StateSyncableVM
+	buildBlockVM  block.BuildBlockWithContextChainVM
+	batchedVM     block.BatchedChainVM
+	ssVM          block.StateSyncableVM
+	preVerifierVM block.PreVerifierChainVM
+	durableVM     block.DurableChainVM
 	// ChainVM tags
 	initializeTag              string
 	buildBlockTag              string
@@ -59,18 +63,26 @@ type blockVM struct {
 	getLastStateSummaryTag        string
 	parseStateSummaryTag          string
 	getStateSummaryTag            string
-	tracer                        trace.Tracer
+	// PreVerifierChainVM tags
+	preVerifyBlockTag string
+	// DurableChainVM tags
+	lastDurableHeightTag string
+	tracer               trace.Tracer
 }
 
 func NewBlockVM(vm block.ChainVM, name string, tracer trace.Tracer) block.ChainVM {
 	buildBlockVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	preVerifierVM, _ := vm.(block.PreVerifierChainVM)
+	durableVM, _ := vm.(block.DurableChainVM)
 	return &blockVM{
 		ChainVM:                       vm,
 		buildBlockVM:                  buildBlockVM,
 		batchedVM:                     batchedVM,
 		ssVM:                          ssVM,
+		preVerifierVM:                 preVerifierVM,
+		durableVM:                     durableVM,
 		initializeTag:                 fmt.Sprintf("%s.initialize", name),
 		buildBlockTag:                 fmt.Sprintf("%s.buildBlock", name),
 		parseBlockTag:                 fmt.Sprintf("%s.parseBlock", name),
@@ -93,6 +105,8 @@ func NewBlockVM(vm block.ChainVM, name string, tracer trace.Tracer) block.ChainV
 		getLastStateSummaryTag:        fmt.Sprintf("%s.getLastStateSummary", name),
 		parseStateSummaryTag:          fmt.Sprintf("%s.parseStateSummary", name),
 		getStateSummaryTag:            fmt.Sprintf("%s.getStateSummary", name),
+		preVerifyBlockTag:             fmt.Sprintf("%s.preVerifyBlock", name),
+		lastDurableHeightTag:          fmt.Sprintf("%s.lastDurableHeight", name),
 		tracer:                        tracer,
 	}
 }
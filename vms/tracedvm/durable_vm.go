@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracedvm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) LastDurableHeight(ctx context.Context) (uint64, error) {
+	if vm.durableVM == nil {
+		return 0, block.ErrDurableVMNotImplemented
+	}
+
+	ctx, span := vm.tracer.Start(ctx, vm.lastDurableHeightTag)
+	defer span.End()
+
+	return vm.durableVM.LastDurableHeight(ctx)
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracedvm
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) PreVerifyBlock(ctx context.Context, blk snowman.Block) error {
+	if vm.preVerifierVM == nil {
+		return block.ErrPreVerifierVMNotImplemented
+	}
+
+	ctx, span := vm.tracer.Start(ctx, vm.preVerifyBlockTag, oteltrace.WithAttributes(
+		attribute.Stringer("blkID", blk.ID()),
+		attribute.Int64("height", int64(blk.Height())),
+	))
+	defer span.End()
+
+	if tb, ok := blk.(*tracedBlock); ok {
+		blk = tb.Block
+	}
+	return vm.preVerifierVM.PreVerifyBlock(ctx, blk)
+}
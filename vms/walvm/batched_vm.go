@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package walvm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) GetAncestors(
+	ctx context.Context,
+	blkID ids.ID,
+	maxBlocksNum int,
+	maxBlocksSize int,
+	maxBlocksRetrivalTime time.Duration,
+) ([][]byte, error) {
+	if vm.batchedVM == nil {
+		return nil, block.ErrRemoteVMNotImplemented
+	}
+
+	return vm.batchedVM.GetAncestors(
+		ctx,
+		blkID,
+		maxBlocksNum,
+		maxBlocksSize,
+		maxBlocksRetrivalTime,
+	)
+}
+
+func (vm *blockVM) BatchedParseBlock(ctx context.Context, blks [][]byte) ([]snowman.Block, error) {
+	if vm.batchedVM == nil {
+		return nil, block.ErrRemoteVMNotImplemented
+	}
+
+	blocks, err := vm.batchedVM.BatchedParseBlock(ctx, blks)
+	wrappedBlocks := make([]snowman.Block, len(blocks))
+	for i, block := range blocks {
+		wrappedBlocks[i] = &walBlock{
+			Block: block,
+			vm:    vm,
+		}
+	}
+	return wrappedBlocks, err
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package walvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var (
+	_ snowman.Block           = (*walBlock)(nil)
+	_ snowman.OracleBlock     = (*walBlock)(nil)
+	_ block.WithVerifyContext = (*walBlock)(nil)
+	_ block.WithNumTxs        = (*walBlock)(nil)
+
+	errExpectedBlockWithVerifyContext = errors.New("expected block.WithVerifyContext")
+)
+
+type walBlock struct {
+	snowman.Block
+
+	vm *blockVM
+}
+
+func (b *walBlock) Accept(ctx context.Context) error {
+	blkBytes := b.Bytes()
+	if err := b.vm.wal.append(blkBytes); err != nil {
+		return fmt.Errorf("failed to write block %s to the write-ahead log: %w", b.ID(), err)
+	}
+	if err := b.Block.Accept(ctx); err != nil {
+		return err
+	}
+
+	b.vm.logged = append(b.vm.logged, loggedBlock{
+		height: b.Height(),
+		bytes:  blkBytes,
+	})
+	if err := b.vm.pruneWAL(ctx); err != nil {
+		return fmt.Errorf("failed to prune the write-ahead log: %w", err)
+	}
+	return nil
+}
+
+func (b *walBlock) Options(ctx context.Context) ([2]snowman.Block, error) {
+	oracleBlock, ok := b.Block.(snowman.OracleBlock)
+	if !ok {
+		return [2]snowman.Block{}, snowman.ErrNotOracle
+	}
+
+	blks, err := oracleBlock.Options(ctx)
+	if err != nil {
+		return [2]snowman.Block{}, err
+	}
+	return [2]snowman.Block{
+		&walBlock{
+			Block: blks[0],
+			vm:    b.vm,
+		},
+		&walBlock{
+			Block: blks[1],
+			vm:    b.vm,
+		},
+	}, nil
+}
+
+func (b *walBlock) NumTxs() (int, bool) {
+	return block.NumTxs(b.Block)
+}
+
+func (b *walBlock) ShouldVerifyWithContext(ctx context.Context) (bool, error) {
+	blkWithCtx, ok := b.Block.(block.WithVerifyContext)
+	if !ok {
+		return false, nil
+	}
+	return blkWithCtx.ShouldVerifyWithContext(ctx)
+}
+
+func (b *walBlock) VerifyWithContext(ctx context.Context, blockCtx *block.Context) error {
+	blkWithCtx, ok := b.Block.(block.WithVerifyContext)
+	if !ok {
+		return fmt.Errorf("%w but got %T", errExpectedBlockWithVerifyContext, b.Block)
+	}
+	return blkWithCtx.VerifyWithContext(ctx, blockCtx)
+}
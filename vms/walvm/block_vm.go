@@ -0,0 +1,266 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package walvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// WALFileName is the name of the file, in the data directory of the chain,
+// holding the write-ahead log of the chain.
+const WALFileName = "accept.wal"
+
+var (
+	_ block.ChainVM                      = (*blockVM)(nil)
+	_ block.BuildBlockWithContextChainVM = (*blockVM)(nil)
+	_ block.BatchedChainVM               = (*blockVM)(nil)
+	_ block.StateSyncableVM              = (*blockVM)(nil)
+	_ block.PreVerifierChainVM           = (*blockVM)(nil)
+	_ block.DurableChainVM               = (*blockVM)(nil)
+)
+
+// loggedBlock is a block in the write-ahead log whose acceptance may not have
+// been durably committed by the VM.
+type loggedBlock struct {
+	height uint64
+	bytes  []byte
+}
+
+type blockVM struct {
+	block.ChainVM
+	buildBlockVM  block.BuildBlockWithContextChainVM
+	batchedVM     block.BatchedChainVM
+	ssVM          block.StateSyncableVM
+	preVerifierVM block.PreVerifierChainVM
+	durableVM     block.DurableChainVM
+
+	log logging.Logger
+	wal *wal
+	// Blocks in [wal] that were accepted, in order of acceptance
+	logged []loggedBlock
+}
+
+// NewBlockVM returns a VM that writes each block to a write-ahead log before
+// [vm] accepts it. When initialized, the returned VM accepts the blocks left
+// in the log by a crash that [vm] didn't commit as accepted, so that the chain
+// resumes from the last block accepted by consensus rather than having to
+// fetch and decide those blocks again.
+//
+// If [vm] implements block.DurableChainVM, blocks are kept in the log until
+// [vm] reports that their acceptance is durable. Otherwise, [vm] is assumed to
+// commit a block before returning from Accept.
+func NewBlockVM(vm block.ChainVM) block.ChainVM {
+	buildBlockVM, _ := vm.(block.BuildBlockWithContextChainVM)
+	batchedVM, _ := vm.(block.BatchedChainVM)
+	ssVM, _ := vm.(block.StateSyncableVM)
+	preVerifierVM, _ := vm.(block.PreVerifierChainVM)
+	durableVM, _ := vm.(block.DurableChainVM)
+	return &blockVM{
+		ChainVM:       vm,
+		buildBlockVM:  buildBlockVM,
+		batchedVM:     batchedVM,
+		ssVM:          ssVM,
+		preVerifierVM: preVerifierVM,
+		durableVM:     durableVM,
+	}
+}
+
+func (vm *blockVM) Initialize(
+	ctx context.Context,
+	chainCtx *snow.Context,
+	db manager.Manager,
+	genesisBytes,
+	upgradeBytes,
+	configBytes []byte,
+	toEngine chan<- common.Message,
+	fxs []*common.Fx,
+	appSender common.AppSender,
+) error {
+	err := vm.ChainVM.Initialize(ctx, chainCtx, db, genesisBytes, upgradeBytes, configBytes, toEngine, fxs, appSender)
+	if err != nil {
+		return err
+	}
+
+	vm.log = chainCtx.Log
+	wal, blks, err := openWAL(filepath.Join(chainCtx.ChainDataDir, WALFileName))
+	if err != nil {
+		return fmt.Errorf("failed to open the write-ahead log: %w", err)
+	}
+	vm.wal = wal
+
+	if len(blks) == 0 {
+		return nil
+	}
+
+	vm.logged, err = vm.recover(ctx, blks)
+	if err != nil {
+		return err
+	}
+	// The recovered blocks may not be durable yet, so they are kept in the
+	// log, without the blocks that were dropped.
+	if err := vm.wal.rewrite(loggedBytes(vm.logged)); err != nil {
+		return fmt.Errorf("failed to rewrite the write-ahead log: %w", err)
+	}
+	return vm.pruneWAL(ctx)
+}
+
+// recover accepts the blocks in [blks] that the VM didn't commit as accepted.
+// Returns the blocks of [blks] that are accepted.
+func (vm *blockVM) recover(ctx context.Context, blks [][]byte) ([]loggedBlock, error) {
+	var accepted []loggedBlock
+	for _, blkBytes := range blks {
+		blk, err := vm.ChainVM.ParseBlock(ctx, blkBytes)
+		if err != nil {
+			vm.log.Warn("dropping unparsable block from the write-ahead log",
+				zap.Error(err),
+			)
+			continue
+		}
+
+		blkID := blk.ID()
+		logged := loggedBlock{
+			height: blk.Height(),
+			bytes:  blkBytes,
+		}
+		if blk.Status() == choices.Accepted {
+			vm.log.Debug("block from the write-ahead log was already accepted",
+				zap.Stringer("blkID", blkID),
+			)
+			accepted = append(accepted, logged)
+			continue
+		}
+
+		lastAcceptedID, err := vm.ChainVM.LastAccepted(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if parentID := blk.Parent(); parentID != lastAcceptedID {
+			vm.log.Warn("dropping block from the write-ahead log",
+				zap.String("reason", "parent isn't the last accepted block"),
+				zap.Stringer("blkID", blkID),
+				zap.Stringer("parentID", parentID),
+				zap.Stringer("lastAcceptedID", lastAcceptedID),
+			)
+			continue
+		}
+
+		if err := blk.Verify(ctx); err != nil {
+			vm.log.Warn("dropping block from the write-ahead log",
+				zap.String("reason", "failed verification"),
+				zap.Stringer("blkID", blkID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if err := blk.Accept(ctx); err != nil {
+			return nil, fmt.Errorf("failed to accept block %s from the write-ahead log: %w", blkID, err)
+		}
+		accepted = append(accepted, logged)
+
+		vm.log.Info("accepted block from the write-ahead log",
+			zap.Stringer("blkID", blkID),
+			zap.Uint64("height", logged.height),
+		)
+	}
+	return accepted, nil
+}
+
+// pruneWAL removes the accepted blocks from the log whose acceptance the VM
+// durably committed.
+func (vm *blockVM) pruneWAL(ctx context.Context) error {
+	numDurable := len(vm.logged)
+	durableHeight, err := vm.LastDurableHeight(ctx)
+	switch {
+	case errors.Is(err, block.ErrDurableVMNotImplemented):
+		// The VM committed the blocks before returning from Accept.
+	case err != nil:
+		return err
+	default:
+		numDurable = 0
+		for numDurable < len(vm.logged) && vm.logged[numDurable].height <= durableHeight {
+			numDurable++
+		}
+	}
+	if numDurable == 0 {
+		return nil
+	}
+
+	vm.logged = vm.logged[numDurable:]
+	if len(vm.logged) == 0 {
+		return vm.wal.truncate()
+	}
+	return vm.wal.rewrite(loggedBytes(vm.logged))
+}
+
+func (vm *blockVM) LastDurableHeight(ctx context.Context) (uint64, error) {
+	if vm.durableVM == nil {
+		return 0, block.ErrDurableVMNotImplemented
+	}
+	return vm.durableVM.LastDurableHeight(ctx)
+}
+
+func loggedBytes(blks []loggedBlock) [][]byte {
+	blksBytes := make([][]byte, len(blks))
+	for i, blk := range blks {
+		blksBytes[i] = blk.bytes
+	}
+	return blksBytes
+}
+
+func (vm *blockVM) Shutdown(ctx context.Context) error {
+	errs := wrappers.Errs{}
+	errs.Add(vm.ChainVM.Shutdown(ctx))
+	if vm.wal != nil {
+		errs.Add(vm.wal.close())
+	}
+	return errs.Err
+}
+
+func (vm *blockVM) BuildBlock(ctx context.Context) (snowman.Block, error) {
+	blk, err := vm.ChainVM.BuildBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &walBlock{
+		Block: blk,
+		vm:    vm,
+	}, nil
+}
+
+func (vm *blockVM) ParseBlock(ctx context.Context, b []byte) (snowman.Block, error) {
+	blk, err := vm.ChainVM.ParseBlock(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	return &walBlock{
+		Block: blk,
+		vm:    vm,
+	}, nil
+}
+
+func (vm *blockVM) GetBlock(ctx context.Context, id ids.ID) (snowman.Block, error) {
+	blk, err := vm.ChainVM.GetBlock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &walBlock{
+		Block: blk,
+		vm:    vm,
+	}, nil
+}
@@ -0,0 +1,240 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package walvm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var (
+	_ block.DurableChainVM = (*durableTestVM)(nil)
+
+	errTest = errors.New("non-nil error")
+)
+
+// durableTestVM is a VM that commits accepted blocks asynchronously
+type durableTestVM struct {
+	*block.TestVM
+	durableHeight uint64
+}
+
+func (vm *durableTestVM) LastDurableHeight(context.Context) (uint64, error) {
+	return vm.durableHeight, nil
+}
+
+func newTestBlock(parent *snowman.TestBlock, status choices.Status) *snowman.TestBlock {
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: status,
+		},
+	}
+	if parent != nil {
+		blk.ParentV = parent.ID()
+		blk.HeightV = parent.Height() + 1
+	}
+	blk.BytesV = blk.IDV[:]
+	return blk
+}
+
+// newTestVM returns a VM whose blocks are [blks] and whose last accepted
+// block is the highest accepted block of [blks].
+func newTestVM(t *testing.T, blks ...*snowman.TestBlock) *block.TestVM {
+	vm := &block.TestVM{
+		TestVM: common.TestVM{
+			T: t,
+		},
+	}
+	vm.InitializeF = func(
+		context.Context,
+		*snow.Context,
+		manager.Manager,
+		[]byte,
+		[]byte,
+		[]byte,
+		chan<- common.Message,
+		[]*common.Fx,
+		common.AppSender,
+	) error {
+		return nil
+	}
+	vm.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(b, blk.Bytes()) {
+				return blk, nil
+			}
+		}
+		return nil, errTest
+	}
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		var lastAccepted *snowman.TestBlock
+		for _, blk := range blks {
+			if blk.Status() == choices.Accepted && (lastAccepted == nil || blk.Height() > lastAccepted.Height()) {
+				lastAccepted = blk
+			}
+		}
+		return lastAccepted.ID(), nil
+	}
+	return vm
+}
+
+func initializeVM(t *testing.T, vm block.ChainVM, dataDir string) {
+	chainCtx := snow.DefaultContextTest()
+	chainCtx.ChainDataDir = dataDir
+	require.NoError(t, vm.Initialize(
+		context.Background(),
+		chainCtx,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	))
+}
+
+func TestBlockVMRecover(t *testing.T) {
+	require := require.New(t)
+
+	genesis := newTestBlock(nil, choices.Accepted)
+	blk1 := newTestBlock(genesis, choices.Processing)
+	blk2 := newTestBlock(blk1, choices.Processing)
+	conflict := newTestBlock(genesis, choices.Processing)
+	invalid := newTestBlock(blk2, choices.Processing)
+	invalid.VerifyV = errTest
+
+	// Simulate a crash that left blocks in the log that the VM didn't commit
+	// as accepted.
+	dataDir := t.TempDir()
+	w, _, err := openWAL(filepath.Join(dataDir, WALFileName))
+	require.NoError(err)
+	for _, blk := range []*snowman.TestBlock{genesis, blk1, blk2, conflict, invalid} {
+		require.NoError(w.append(blk.Bytes()))
+	}
+	require.NoError(w.append([]byte("unparsable")))
+	require.NoError(w.close())
+
+	vm := NewBlockVM(newTestVM(t, genesis, blk1, blk2, conflict, invalid))
+	initializeVM(t, vm, dataDir)
+
+	require.Equal(choices.Accepted, blk1.Status())
+	require.Equal(choices.Accepted, blk2.Status())
+	require.Equal(choices.Processing, conflict.Status())
+	require.Equal(choices.Processing, invalid.Status())
+
+	// The recovered blocks were removed from the log.
+	w, blks, err := openWAL(filepath.Join(dataDir, WALFileName))
+	require.NoError(err)
+	require.Empty(blks)
+	require.NoError(w.close())
+
+	require.NoError(vm.Shutdown(context.Background()))
+}
+
+func TestBlockAccept(t *testing.T) {
+	require := require.New(t)
+
+	genesis := newTestBlock(nil, choices.Accepted)
+	blk1 := newTestBlock(genesis, choices.Processing)
+	blk2 := newTestBlock(blk1, choices.Processing)
+	blk2.AcceptV = errTest
+
+	dataDir := t.TempDir()
+	vm := NewBlockVM(newTestVM(t, genesis, blk1, blk2))
+	initializeVM(t, vm, dataDir)
+
+	parsedBlk1, err := vm.ParseBlock(context.Background(), blk1.Bytes())
+	require.NoError(err)
+	require.NoError(parsedBlk1.Accept(context.Background()))
+	require.Equal(choices.Accepted, blk1.Status())
+
+	// The log only holds the blocks whose acceptance failed.
+	parsedBlk2, err := vm.ParseBlock(context.Background(), blk2.Bytes())
+	require.NoError(err)
+	err = parsedBlk2.Accept(context.Background())
+	require.ErrorIs(err, errTest)
+
+	w, blks, err := openWAL(filepath.Join(dataDir, WALFileName))
+	require.NoError(err)
+	require.Equal([][]byte{blk2.Bytes()}, blks)
+	require.NoError(w.close())
+
+	require.NoError(vm.Shutdown(context.Background()))
+}
+
+func TestBlockAcceptDurable(t *testing.T) {
+	require := require.New(t)
+
+	genesis := newTestBlock(nil, choices.Accepted)
+	blk1 := newTestBlock(genesis, choices.Processing)
+	blk2 := newTestBlock(blk1, choices.Processing)
+	blk3 := newTestBlock(blk2, choices.Processing)
+
+	dataDir := t.TempDir()
+	innerVM := &durableTestVM{
+		TestVM: newTestVM(t, genesis, blk1, blk2, blk3),
+	}
+	vm := NewBlockVM(innerVM)
+	initializeVM(t, vm, dataDir)
+
+	requireLogged := func(expected ...*snowman.TestBlock) {
+		w, blks, err := openWAL(filepath.Join(dataDir, WALFileName))
+		require.NoError(err)
+		require.NoError(w.close())
+
+		var expectedBlks [][]byte
+		for _, blk := range expected {
+			expectedBlks = append(expectedBlks, blk.Bytes())
+		}
+		require.Equal(expectedBlks, blks)
+	}
+
+	// The blocks are kept in the log until the VM flushes them.
+	for _, blk := range []*snowman.TestBlock{blk1, blk2} {
+		parsedBlk, err := vm.ParseBlock(context.Background(), blk.Bytes())
+		require.NoError(err)
+		require.NoError(parsedBlk.Accept(context.Background()))
+	}
+	requireLogged(blk1, blk2)
+
+	innerVM.durableHeight = blk1.Height()
+	parsedBlk3, err := vm.ParseBlock(context.Background(), blk3.Bytes())
+	require.NoError(err)
+	require.NoError(parsedBlk3.Accept(context.Background()))
+	requireLogged(blk2, blk3)
+
+	require.NoError(vm.Shutdown(context.Background()))
+
+	// After a crash, the blocks that weren't flushed are accepted again and
+	// kept in the log.
+	blk2.StatusV = choices.Processing
+	blk3.StatusV = choices.Processing
+	vm = NewBlockVM(innerVM)
+	initializeVM(t, vm, dataDir)
+	require.Equal(choices.Accepted, blk2.Status())
+	require.Equal(choices.Accepted, blk3.Status())
+	requireLogged(blk2, blk3)
+	require.NoError(vm.Shutdown(context.Background()))
+
+	innerVM.durableHeight = blk3.Height()
+	vm = NewBlockVM(innerVM)
+	initializeVM(t, vm, dataDir)
+	requireLogged()
+	require.NoError(vm.Shutdown(context.Background()))
+}
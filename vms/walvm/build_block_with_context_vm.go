@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package walvm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) BuildBlockWithContext(ctx context.Context, blockCtx *block.Context) (snowman.Block, error) {
+	if vm.buildBlockVM == nil {
+		return vm.BuildBlock(ctx)
+	}
+
+	blk, err := vm.buildBlockVM.BuildBlockWithContext(ctx, blockCtx)
+	if err != nil {
+		return nil, err
+	}
+	return &walBlock{
+		Block: blk,
+		vm:    vm,
+	}, nil
+}
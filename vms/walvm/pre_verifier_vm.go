@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package walvm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) PreVerifyBlock(ctx context.Context, blk snowman.Block) error {
+	if vm.preVerifierVM == nil {
+		return block.ErrPreVerifierVMNotImplemented
+	}
+
+	if wb, ok := blk.(*walBlock); ok {
+		blk = wb.Block
+	}
+	return vm.preVerifierVM.PreVerifyBlock(ctx, blk)
+}
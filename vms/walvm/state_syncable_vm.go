@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package walvm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) StateSyncEnabled(ctx context.Context) (bool, error) {
+	if vm.ssVM == nil {
+		return false, nil
+	}
+	return vm.ssVM.StateSyncEnabled(ctx)
+}
+
+func (vm *blockVM) GetOngoingSyncStateSummary(ctx context.Context) (block.StateSummary, error) {
+	if vm.ssVM == nil {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+	return vm.ssVM.GetOngoingSyncStateSummary(ctx)
+}
+
+func (vm *blockVM) GetLastStateSummary(ctx context.Context) (block.StateSummary, error) {
+	if vm.ssVM == nil {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+	return vm.ssVM.GetLastStateSummary(ctx)
+}
+
+func (vm *blockVM) ParseStateSummary(ctx context.Context, summaryBytes []byte) (block.StateSummary, error) {
+	if vm.ssVM == nil {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+	return vm.ssVM.ParseStateSummary(ctx, summaryBytes)
+}
+
+func (vm *blockVM) GetStateSummary(ctx context.Context, height uint64) (block.StateSummary, error) {
+	if vm.ssVM == nil {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+	return vm.ssVM.GetStateSummary(ctx, height)
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package walvm
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+const (
+	// Each entry is prefixed by the length of the block and the checksum of
+	// the block.
+	entryHeaderLen = 2 * 4
+
+	perms = 0o600
+
+	// tmpSuffix is appended to the path of the log when it's rewritten
+	tmpSuffix = ".tmp"
+)
+
+// wal is a write-ahead log of the blocks that are being accepted. A block is
+// appended, and synced to disk, before it's accepted, and is removed from the
+// log once the VM durably committed its acceptance. Any entry left in the log
+// after a crash is a block whose acceptance may not have been committed by the
+// VM.
+//
+// If the process crashes while an entry is being appended, the entry may be
+// partially written. Such an entry fails its checksum and is ignored, as the
+// block it holds wasn't accepted yet.
+type wal struct {
+	path string
+	file *os.File
+}
+
+// openWAL opens the log at [path], creating it if it doesn't exist, and
+// returns the blocks it holds.
+func openWAL(path string) (*wal, [][]byte, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, nil, err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, perms)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blks, err := readEntries(bufio.NewReader(file))
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+	return &wal{
+		path: path,
+		file: file,
+	}, blks, nil
+}
+
+// readEntries returns the blocks held by the complete entries of [r], in the
+// order they were appended.
+func readEntries(r io.Reader) ([][]byte, error) {
+	var (
+		blks   [][]byte
+		header [entryHeaderLen]byte
+	)
+	for {
+		_, err := io.ReadFull(r, header[:])
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return blks, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		blkLen := binary.BigEndian.Uint32(header[:4])
+		if blkLen > uint32(constants.MaxContainersLen) {
+			// No block is this large, so the header is torn.
+			return blks, nil
+		}
+		checksum := binary.BigEndian.Uint32(header[4:])
+		blk := make([]byte, blkLen)
+		_, err = io.ReadFull(r, blk)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return blks, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if crc32.ChecksumIEEE(blk) != checksum {
+			return blks, nil
+		}
+		blks = append(blks, blk)
+	}
+}
+
+func newEntry(blk []byte) []byte {
+	entry := make([]byte, entryHeaderLen, entryHeaderLen+len(blk))
+	binary.BigEndian.PutUint32(entry[:4], uint32(len(blk)))
+	binary.BigEndian.PutUint32(entry[4:], crc32.ChecksumIEEE(blk))
+	return append(entry, blk...)
+}
+
+// append durably writes [blk] to the end of the log.
+func (w *wal) append(blk []byte) error {
+	if _, err := w.file.Write(newEntry(blk)); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+// truncate removes all the entries of the log. The removal isn't synced to
+// disk, as it's only performed once the blocks in the log were committed, so
+// accepting them again after a crash is a noop.
+func (w *wal) truncate() error {
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	_, err := w.file.Seek(0, io.SeekStart)
+	return err
+}
+
+// rewrite atomically replaces the entries of the log with [blks].
+func (w *wal) rewrite(blks [][]byte) error {
+	tmpPath := w.path + tmpSuffix
+	file, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, perms)
+	if err != nil {
+		return err
+	}
+	if err := writeEntries(file, blks); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := os.Rename(tmpPath, w.path); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	oldFile := w.file
+	w.file = file
+	return oldFile.Close()
+}
+
+// writeEntries durably writes [blks] to [file].
+func writeEntries(file *os.File, blks [][]byte) error {
+	writer := bufio.NewWriter(file)
+	for _, blk := range blks {
+		if _, err := writer.Write(newEntry(blk)); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+func (w *wal) close() error {
+	return w.file.Close()
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package walvm
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWAL(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "chain", WALFileName)
+	w, blks, err := openWAL(path)
+	require.NoError(err)
+	require.Empty(blks)
+
+	require.NoError(w.append([]byte{1, 2, 3}))
+	require.NoError(w.append(nil))
+	require.NoError(w.append([]byte{4}))
+	require.NoError(w.close())
+
+	w, blks, err = openWAL(path)
+	require.NoError(err)
+	require.Equal([][]byte{{1, 2, 3}, {}, {4}}, blks)
+
+	require.NoError(w.truncate())
+	require.NoError(w.append([]byte{5}))
+	require.NoError(w.close())
+
+	w, blks, err = openWAL(path)
+	require.NoError(err)
+	require.Equal([][]byte{{5}}, blks)
+
+	require.NoError(w.rewrite([][]byte{{6}, {7, 8}}))
+	require.NoError(w.append([]byte{9}))
+	require.NoError(w.close())
+
+	w, blks, err = openWAL(path)
+	require.NoError(err)
+	require.Equal([][]byte{{6}, {7, 8}, {9}}, blks)
+	require.NoError(w.close())
+}
+
+func TestWALIgnoresTornEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(entry []byte) []byte
+	}{
+		{
+			name: "partial header",
+			corrupt: func(entry []byte) []byte {
+				return entry[:entryHeaderLen-1]
+			},
+		},
+		{
+			name: "partial block",
+			corrupt: func(entry []byte) []byte {
+				return entry[:len(entry)-1]
+			},
+		},
+		{
+			name: "oversized length",
+			corrupt: func(entry []byte) []byte {
+				binary.BigEndian.PutUint32(entry, math.MaxUint32)
+				return entry
+			},
+		},
+		{
+			name: "invalid checksum",
+			corrupt: func(entry []byte) []byte {
+				entry[len(entry)-1]++
+				return entry
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			path := filepath.Join(t.TempDir(), WALFileName)
+			w, _, err := openWAL(path)
+			require.NoError(err)
+			require.NoError(w.append([]byte{1, 2, 3}))
+			require.NoError(w.append([]byte{4, 5, 6}))
+			require.NoError(w.close())
+
+			fileBytes, err := os.ReadFile(path)
+			require.NoError(err)
+			entryLen := entryHeaderLen + 3
+			lastEntry := test.corrupt(fileBytes[entryLen:])
+			fileBytes = append(fileBytes[:entryLen], lastEntry...)
+			require.NoError(os.WriteFile(path, fileBytes, perms))
+
+			w, blks, err := openWAL(path)
+			require.NoError(err)
+			require.Equal([][]byte{{1, 2, 3}}, blks)
+			require.NoError(w.close())
+		})
+	}
+}
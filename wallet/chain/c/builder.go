@@ -193,6 +193,10 @@ func (b *builder) NewImportTx(
 		importedAmount uint64
 	)
 	for _, utxo := range utxos {
+		if !ops.CanImport(utxo.InputID()) {
+			continue
+		}
+
 		amount, inputSigIndices, ok := getSpendableAmount(utxo, addrs, minIssuanceTime, avaxAssetID)
 		if !ok {
 			continue
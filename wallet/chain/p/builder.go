@@ -51,6 +51,16 @@ type Builder interface {
 		options ...common.Option,
 	) (map[ids.ID]uint64, error)
 
+	// PlanImportTxs splits the UTXOs that this builder could import from the
+	// provided chain into batches that can each be imported by one ImportTx.
+	// The batches should be imported in order.
+	//
+	// - [chainID] specifies the chain the funds are from.
+	PlanImportTxs(
+		chainID ids.ID,
+		options ...common.Option,
+	) ([][]ids.ID, error)
+
 	// NewBaseTx creates a new simple value transfer. Because the P-chain
 	// doesn't intend for balance transfers to occur, this method is expensive
 	// and abuses the creation of subnets.
@@ -286,6 +296,31 @@ func (b *builder) GetImportableBalance(
 	return b.getBalance(chainID, ops)
 }
 
+func (b *builder) PlanImportTxs(
+	chainID ids.ID,
+	options ...common.Option,
+) ([][]ids.ID, error) {
+	ops := common.NewOptions(options)
+	utxos, err := b.backend.UTXOs(ops.Context(), chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	importable := make([]*avax.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		if ops.CanImport(utxo.InputID()) {
+			importable = append(importable, utxo)
+		}
+	}
+	return common.PlanImports(
+		importable,
+		ops.Addresses(b.addrs),
+		ops.MinIssuanceTime(),
+		b.backend.AVAXAssetID(),
+		common.MaxImportTxSize,
+	), nil
+}
+
 func (b *builder) NewBaseTx(
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
@@ -547,6 +582,10 @@ func (b *builder) NewImportTx(
 	)
 	// Iterate over the unlocked UTXOs
 	for _, utxo := range utxos {
+		if !ops.CanImport(utxo.InputID()) {
+			continue
+		}
+
 		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
 		if !ok {
 			continue
@@ -1106,7 +1145,7 @@ func (b *builder) authorizeSubnet(subnetID ids.ID, options *common.Options) (*se
 		return nil, errUnknownOwnerType
 	}
 
-	addrs := options.Addresses(b.addrs)
+	addrs := options.SubnetAuthAddresses(b.addrs)
 	minIssuanceTime := options.MinIssuanceTime()
 	inputSigIndices, ok := common.MatchOwners(owner, addrs, minIssuanceTime)
 	if !ok {
@@ -53,6 +53,16 @@ func (b *builderWithOptions) GetImportableBalance(
 	)
 }
 
+func (b *builderWithOptions) PlanImportTxs(
+	chainID ids.ID,
+	options ...common.Option,
+) ([][]ids.ID, error) {
+	return b.Builder.PlanImportTxs(
+		chainID,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewAddValidatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p
+
+import (
+	stdcontext "context"
+	"reflect"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+var _ Signer = (*policySigner)(nil)
+
+// stakerTx is implemented by the txs that lock funds for staking.
+type stakerTx interface {
+	Stake() []*avax.TransferableOutput
+}
+
+type policySigner struct {
+	signer   Signer
+	kc       keychain.Keychain
+	enforcer *common.PolicyEnforcer
+}
+
+// NewPolicySigner returns a signer that refuses to sign txs that violate the
+// policy of [enforcer]. Funds sent to addresses of [kc] are treated as change.
+func NewPolicySigner(
+	signer Signer,
+	kc keychain.Keychain,
+	enforcer *common.PolicyEnforcer,
+) Signer {
+	return &policySigner{
+		signer:   signer,
+		kc:       kc,
+		enforcer: enforcer,
+	}
+}
+
+func (s *policySigner) SignUnsigned(ctx stdcontext.Context, utx txs.UnsignedTx) (*txs.Tx, error) {
+	tx := &txs.Tx{Unsigned: utx}
+	return tx, s.Sign(ctx, tx)
+}
+
+func (s *policySigner) Sign(ctx stdcontext.Context, tx *txs.Tx) error {
+	spend, err := NewSpend(tx.Unsigned, s.kc)
+	if err != nil {
+		return err
+	}
+	if err := s.enforcer.Verify(spend); err != nil {
+		return err
+	}
+	if err := s.signer.Sign(ctx, tx); err != nil {
+		return err
+	}
+	return s.enforcer.Record(tx.ID(), spend)
+}
+
+// NewSpend returns the value that [utx] sends to addresses outside of [kc].
+// The tx type is the name of the concrete type of [utx], e.g. "BaseTx".
+func NewSpend(utx txs.UnsignedTx, kc keychain.Keychain) (*common.Spend, error) {
+	outs := slices.Clone(utx.Outputs())
+	switch utx := utx.(type) {
+	case *txs.ExportTx:
+		outs = append(outs, utx.ExportedOutputs...)
+	case stakerTx:
+		outs = append(outs, utx.Stake()...)
+	}
+	txType := reflect.TypeOf(utx).Elem().Name()
+	return common.NewSpend(txType, outs, kc.Addresses())
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p
+
+import (
+	"errors"
+	"fmt"
+
+	stdcontext "context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errNoSubnetAuth          = errors.New("tx doesn't require subnet authorization")
+	errMissingSubnetAuthCred = errors.New("missing subnet auth credential")
+	errUnexpectedSubnetAuth  = errors.New("signature isn't from an expected subnet auth address")
+
+	secpFactory secp256k1.Factory
+)
+
+// SubnetAuthSigningPayload returns the bytes that the subnet owners must sign
+// to authorize [tx].
+func SubnetAuthSigningPayload(tx *txs.Tx) ([]byte, error) {
+	if _, _, err := subnetAuth(tx.Unsigned); err != nil {
+		return nil, err
+	}
+	unsignedBytes, err := txs.Codec.Marshal(txs.Version, &tx.Unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal unsigned tx: %w", err)
+	}
+	return unsignedBytes, nil
+}
+
+// MergeSubnetAuthSignatures adds the externally produced subnet owner
+// signatures [sigs] into the subnet auth credential of [tx].
+//
+// [tx] is expected to have been built with placeholder subnet authorization,
+// for example by using [common.WithSubnetAuthAddresses], and then signed by
+// the wallet so that the subnet auth credential exists.
+func MergeSubnetAuthSignatures(
+	ctx stdcontext.Context,
+	backend SignerBackend,
+	tx *txs.Tx,
+	sigs [][secp256k1.SignatureLen]byte,
+) error {
+	subnetID, auth, err := subnetAuth(tx.Unsigned)
+	if err != nil {
+		return err
+	}
+	subnetInput, ok := auth.(*secp256k1fx.Input)
+	if !ok {
+		return errUnknownSubnetAuthType
+	}
+
+	owner, err := getSubnetOwner(ctx, backend, subnetID)
+	if err != nil {
+		return err
+	}
+
+	// The subnet auth credential is always the last credential.
+	if len(tx.Creds) == 0 {
+		return errMissingSubnetAuthCred
+	}
+	cred, ok := tx.Creds[len(tx.Creds)-1].(*secp256k1fx.Credential)
+	if !ok {
+		return errUnknownCredentialType
+	}
+	if len(cred.Sigs) != len(subnetInput.SigIndices) {
+		return errMissingSubnetAuthCred
+	}
+
+	unsignedBytes, err := txs.Codec.Marshal(txs.Version, &tx.Unsigned)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal unsigned tx: %w", err)
+	}
+
+	for _, sig := range sigs {
+		pk, err := secpFactory.RecoverPublicKey(unsignedBytes, sig[:])
+		if err != nil {
+			return fmt.Errorf("couldn't recover subnet auth signer: %w", err)
+		}
+		addr := pk.Address()
+
+		merged := false
+		for sigIndex, addrIndex := range subnetInput.SigIndices {
+			if addrIndex >= uint32(len(owner.Addrs)) {
+				return errInvalidUTXOSigIndex
+			}
+			if owner.Addrs[addrIndex] == addr {
+				cred.Sigs[sigIndex] = sig
+				merged = true
+			}
+		}
+		if !merged {
+			return fmt.Errorf("%w: %s", errUnexpectedSubnetAuth, addr)
+		}
+	}
+
+	signedBytes, err := txs.Codec.Marshal(txs.Version, tx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal tx: %w", err)
+	}
+	tx.SetBytes(unsignedBytes, signedBytes)
+	return nil
+}
+
+// subnetAuth returns the subnet that [utx] modifies and the authorization
+// provided for the modification.
+func subnetAuth(utx txs.UnsignedTx) (ids.ID, verify.Verifiable, error) {
+	switch utx := utx.(type) {
+	case *txs.AddSubnetValidatorTx:
+		return utx.SubnetValidator.Subnet, utx.SubnetAuth, nil
+	case *txs.CreateChainTx:
+		return utx.SubnetID, utx.SubnetAuth, nil
+	case *txs.RemoveSubnetValidatorTx:
+		return utx.Subnet, utx.SubnetAuth, nil
+	case *txs.TransformSubnetTx:
+		return utx.Subnet, utx.SubnetAuth, nil
+	default:
+		return ids.Empty, nil, fmt.Errorf("%w: %T", errNoSubnetAuth, utx)
+	}
+}
+
+func getSubnetOwner(
+	ctx stdcontext.Context,
+	backend SignerBackend,
+	subnetID ids.ID,
+) (*secp256k1fx.OutputOwners, error) {
+	subnetTx, err := backend.GetTx(ctx, subnetID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch subnet %q: %w",
+			subnetID,
+			err,
+		)
+	}
+	subnet, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx)
+	if !ok {
+		return nil, errWrongTxType
+	}
+
+	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, errUnknownOwnerType
+	}
+	return owner, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	stdcontext "context"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+type testSignerBackend struct {
+	txs map[ids.ID]*txs.Tx
+}
+
+func (*testSignerBackend) GetUTXO(stdcontext.Context, ids.ID, ids.ID) (*avax.UTXO, error) {
+	return nil, database.ErrNotFound
+}
+
+func (b *testSignerBackend) GetTx(_ stdcontext.Context, txID ids.ID) (*txs.Tx, error) {
+	tx, ok := b.txs[txID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return tx, nil
+}
+
+func TestMergeSubnetAuthSignatures(t *testing.T) {
+	require := require.New(t)
+
+	var factory secp256k1.Factory
+	localKey, err := factory.NewPrivateKey()
+	require.NoError(err)
+	externalKey, err := factory.NewPrivateKey()
+	require.NoError(err)
+	unknownKey, err := factory.NewPrivateKey()
+	require.NoError(err)
+
+	subnetID := ids.GenerateTestID()
+	backend := &testSignerBackend{
+		txs: map[ids.ID]*txs.Tx{
+			subnetID: {
+				Unsigned: &txs.CreateSubnetTx{
+					Owner: &secp256k1fx.OutputOwners{
+						Threshold: 2,
+						Addrs: []ids.ShortID{
+							localKey.Address(),
+							externalKey.Address(),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	utx := &txs.CreateChainTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+		}},
+		SubnetID:  subnetID,
+		ChainName: "chain",
+		SubnetAuth: &secp256k1fx.Input{
+			SigIndices: []uint32{0, 1},
+		},
+	}
+
+	// The wallet only knows the local key, so it can only partially sign the
+	// subnet auth.
+	signer := NewSigner(secp256k1fx.NewKeychain(localKey), backend)
+	tx, err := signer.SignUnsigned(stdcontext.Background(), utx)
+	require.NoError(err)
+
+	payload, err := SubnetAuthSigningPayload(tx)
+	require.NoError(err)
+
+	unknownSig, err := unknownKey.Sign(payload)
+	require.NoError(err)
+	var sig [secp256k1.SignatureLen]byte
+	copy(sig[:], unknownSig)
+	err = MergeSubnetAuthSignatures(stdcontext.Background(), backend, tx, [][secp256k1.SignatureLen]byte{sig})
+	require.ErrorIs(err, errUnexpectedSubnetAuth)
+
+	externalSig, err := externalKey.Sign(payload)
+	require.NoError(err)
+	copy(sig[:], externalSig)
+	require.NoError(MergeSubnetAuthSignatures(stdcontext.Background(), backend, tx, [][secp256k1.SignatureLen]byte{sig}))
+
+	require.Len(tx.Creds, 1)
+	cred := tx.Creds[0].(*secp256k1fx.Credential)
+	require.Len(cred.Sigs, 2)
+	require.NotEqual(emptySig, cred.Sigs[0])
+	require.Equal(sig, cred.Sigs[1])
+
+	parsedTx, err := txs.Parse(txs.Codec, tx.Bytes())
+	require.NoError(err)
+	require.Equal(tx.ID(), parsedTx.ID())
+}
+
+func TestSubnetAuthSigningPayloadNoSubnetAuth(t *testing.T) {
+	tx := &txs.Tx{
+		Unsigned: &txs.CreateSubnetTx{},
+	}
+	_, err := SubnetAuthSigningPayload(tx)
+	require.ErrorIs(t, err, errNoSubnetAuth)
+}
@@ -7,7 +7,11 @@ import (
 	"errors"
 	"time"
 
+	stdcontext "context"
+
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
@@ -21,8 +25,21 @@ var (
 	errNotCommitted = errors.New("not committed")
 
 	_ Wallet = (*wallet)(nil)
+	_ Client = platformvm.Client(nil)
 )
 
+// Client is the subset of the P-chain API that the wallet uses to issue txs.
+type Client interface {
+	IssueTx(ctx stdcontext.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	AwaitTxDecided(
+		ctx stdcontext.Context,
+		txID ids.ID,
+		freq time.Duration,
+		options ...rpc.Option,
+	) (*platformvm.GetTxStatusResponse, error)
+	GetTxStatus(ctx stdcontext.Context, txID ids.ID, options ...rpc.Option) (*platformvm.GetTxStatusResponse, error)
+}
+
 type Wallet interface {
 	Context
 
@@ -80,6 +97,22 @@ type Wallet interface {
 		options ...common.Option,
 	) (*txs.Tx, error)
 
+	// IssueRemoveSubnetValidatorTxs creates, signs, and issues a transaction
+	// that removes a validator of a subnet for each of [nodeIDs]. This
+	// completes the transformation of a subnet into a permissionless subnet by
+	// removing its remaining permissioned validators. The transactions are
+	// issued in order, each one once the previous one was accepted. If a
+	// transaction fails, the remaining ones aren't issued.
+	//
+	// The transactions that were issued are returned, even if one failed.
+	//
+	// - [nodeIDs] are the validators being removed from [subnetID].
+	IssueRemoveSubnetValidatorTxs(
+		nodeIDs []ids.NodeID,
+		subnetID ids.ID,
+		options ...common.Option,
+	) ([]*txs.Tx, error)
+
 	// IssueAddDelegatorTx creates, signs, and issues a new delegator to a
 	// validator on the primary network.
 	//
@@ -132,6 +165,21 @@ type Wallet interface {
 		options ...common.Option,
 	) (*txs.Tx, error)
 
+	// IssueImportTxs creates, signs, and issues as many import transactions as
+	// needed to consume all the available UTXOs and import the funds to [to].
+	// The transactions are issued in order, each one once the previous one was
+	// accepted. If a transaction fails, the remaining ones aren't issued.
+	//
+	// The result of each transaction is returned, even if one failed.
+	//
+	// - [chainID] specifies the chain to be importing funds from.
+	// - [to] specifies where to send the imported funds to.
+	IssueImportTxs(
+		chainID ids.ID,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) ([]*common.ImportResult, error)
+
 	// IssueExportTx creates, signs, and issues an export transaction that
 	// attempts to send all the provided [outputs] to the requested [chainID].
 	//
@@ -242,12 +290,20 @@ type Wallet interface {
 		tx *txs.Tx,
 		options ...common.Option,
 	) error
+
+	// IssueTxAsync issues the signed tx and returns without waiting for it to
+	// be decided. The returned handle reports the status of the tx until it is
+	// decided or the context of the options is done.
+	IssueTxAsync(
+		tx *txs.Tx,
+		options ...common.Option,
+	) (*common.TxHandle, error)
 }
 
 func NewWallet(
 	builder Builder,
 	signer Signer,
-	client platformvm.Client,
+	client Client,
 	backend Backend,
 ) Wallet {
 	return &wallet{
@@ -262,7 +318,7 @@ type wallet struct {
 	Backend
 	builder Builder
 	signer  Signer
-	client  platformvm.Client
+	client  Client
 }
 
 func (w *wallet) Builder() Builder {
@@ -320,6 +376,24 @@ func (w *wallet) IssueRemoveSubnetValidatorTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueRemoveSubnetValidatorTxs(
+	nodeIDs []ids.NodeID,
+	subnetID ids.ID,
+	options ...common.Option,
+) ([]*txs.Tx, error) {
+	issued := make([]*txs.Tx, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		tx, err := w.IssueRemoveSubnetValidatorTx(nodeID, subnetID, options...)
+		if tx != nil {
+			issued = append(issued, tx)
+		}
+		if err != nil {
+			return issued, err
+		}
+	}
+	return issued, nil
+}
+
 func (w *wallet) IssueAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -370,6 +444,42 @@ func (w *wallet) IssueImportTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueImportTxs(
+	chainID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) ([]*common.ImportResult, error) {
+	batches, err := w.builder.PlanImportTxs(chainID, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*common.ImportResult, len(batches))
+	for i, batch := range batches {
+		results[i] = &common.ImportResult{
+			UTXOIDs: batch,
+		}
+	}
+	for i, result := range results {
+		batchOptions := common.UnionOptions(
+			options,
+			[]common.Option{common.WithImportedUTXOs(set.Of(result.UTXOIDs...))},
+		)
+		tx, err := w.IssueImportTx(chainID, to, batchOptions...)
+		if tx != nil {
+			result.TxID = tx.ID()
+		}
+		if err != nil {
+			result.Err = err
+			for _, skipped := range results[i+1:] {
+				skipped.Err = common.ErrImportSkipped
+			}
+			return results, err
+		}
+	}
+	return results, nil
+}
+
 func (w *wallet) IssueExportTx(
 	chainID ids.ID,
 	outputs []*avax.TransferableOutput,
@@ -511,3 +621,55 @@ func (w *wallet) IssueTx(
 	}
 	return nil
 }
+
+func (w *wallet) IssueTxAsync(
+	tx *txs.Tx,
+	options ...common.Option,
+) (*common.TxHandle, error) {
+	ops := common.NewOptions(options)
+	ctx := ops.Context()
+	txID, err := w.client.IssueTx(ctx, tx.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	if f := ops.PostIssuanceFunc(); f != nil {
+		f(txID)
+	}
+
+	onDecided := func(ctx stdcontext.Context, _ common.TxStatus) error {
+		return w.Backend.AcceptTx(ctx, tx)
+	}
+	if ops.AssumeDecided() {
+		if err := w.Backend.AcceptTx(ctx, tx); err != nil {
+			return nil, err
+		}
+		onDecided = nil
+	}
+	return common.NewTxHandle(txID, ops, w.getTxStatus, onDecided), nil
+}
+
+func (w *wallet) getTxStatus(ctx stdcontext.Context, txID ids.ID) (common.TxStatusUpdate, error) {
+	res, err := w.client.GetTxStatus(ctx, txID)
+	if err != nil {
+		return common.TxStatusUpdate{}, err
+	}
+
+	update := common.TxStatusUpdate{
+		TxID:   txID,
+		Reason: res.Reason,
+	}
+	switch res.Status {
+	case status.Processing:
+		update.Status = common.TxStatusProcessing
+	case status.Committed:
+		update.Status = common.TxStatusAccepted
+	case status.Aborted:
+		update.Status = common.TxStatusRejected
+	case status.Dropped:
+		update.Status = common.TxStatusDropped
+	default:
+		update.Status = common.TxStatusUnknown
+	}
+	return update, nil
+}
@@ -84,6 +84,18 @@ func (w *walletWithOptions) IssueRemoveSubnetValidatorTx(
 	)
 }
 
+func (w *walletWithOptions) IssueRemoveSubnetValidatorTxs(
+	nodeIDs []ids.NodeID,
+	subnetID ids.ID,
+	options ...common.Option,
+) ([]*txs.Tx, error) {
+	return w.Wallet.IssueRemoveSubnetValidatorTxs(
+		nodeIDs,
+		subnetID,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -136,6 +148,18 @@ func (w *walletWithOptions) IssueImportTx(
 	)
 }
 
+func (w *walletWithOptions) IssueImportTxs(
+	chainID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) ([]*common.ImportResult, error) {
+	return w.Wallet.IssueImportTxs(
+		chainID,
+		to,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueExportTx(
 	chainID ids.ID,
 	outputs []*avax.TransferableOutput,
@@ -237,3 +261,13 @@ func (w *walletWithOptions) IssueTx(
 		common.UnionOptions(w.options, options)...,
 	)
 }
+
+func (w *walletWithOptions) IssueTxAsync(
+	tx *txs.Tx,
+	options ...common.Option,
+) (*common.TxHandle, error) {
+	return w.Wallet.IssueTxAsync(
+		tx,
+		common.UnionOptions(w.options, options)...,
+	)
+}
@@ -9,6 +9,8 @@ import (
 
 	stdcontext "context"
 
+	"golang.org/x/exp/maps"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/math"
@@ -25,6 +27,8 @@ import (
 var (
 	errNoChangeAddress   = errors.New("no possible change address")
 	errInsufficientFunds = errors.New("insufficient funds")
+	errDustOutput        = errors.New("output amount is below the minimum output amount")
+	errNoDust            = errors.New("no dust UTXOs to sweep")
 
 	_ Builder = (*builder)(nil)
 )
@@ -47,6 +51,16 @@ type Builder interface {
 		options ...common.Option,
 	) (map[ids.ID]uint64, error)
 
+	// PlanImportTxs splits the UTXOs that this builder could import from the
+	// provided chain into batches that can each be imported by one ImportTx.
+	// The batches should be imported in order.
+	//
+	// - [chainID] specifies the chain the funds are from.
+	PlanImportTxs(
+		chainID ids.ID,
+		options ...common.Option,
+	) ([][]ids.ID, error)
+
 	// NewBaseTx creates a new simple value transfer.
 	//
 	// - [outputs] specifies all the recipients and amounts that should be sent
@@ -56,6 +70,28 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.BaseTx, error)
 
+	// PlanBaseTxs splits [outputs] into batches that can each be sent by one
+	// BaseTx. The batches should be sent in order. An error is returned if
+	// this builder doesn't control enough funds to send every batch, including
+	// the fee of each BaseTx.
+	//
+	// - [outputs] specifies all the recipients and amounts that should be sent.
+	PlanBaseTxs(
+		outputs []*avax.TransferableOutput,
+		options ...common.Option,
+	) (*common.SendPlan, error)
+
+	// NewSweepTx creates a simple value transfer that consolidates the dust
+	// AVAX UTXOs of this builder into a single output. A UTXO is dust if it
+	// holds less than the dust threshold, which defaults to the minimum output
+	// amount. At most [common.MaxSweepInputs] UTXOs are consumed.
+	//
+	// - [to] specifies where to send the consolidated funds to.
+	NewSweepTx(
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.BaseTx, error)
+
 	// NewCreateAssetTx creates a new asset.
 	//
 	// - [name] specifies a human readable name for this asset.
@@ -73,6 +109,26 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.CreateAssetTx, error)
 
+	// NewCreateSubnetAssetTx creates a new fungible asset that can be used to
+	// transform a permissioned subnet into a permissionless subnet. The entire
+	// [maxSupply] is minted to [owner], so that the part of it that will be
+	// converted to staking rewards can be exported to the P-chain.
+	//
+	// - [name] specifies a human readable name for this asset.
+	// - [symbol] specifies a human readable abbreviation for this asset.
+	// - [denomination] specifies how many times the asset can be split.
+	// - [maxSupply] is the maximum total amount of the asset that should ever
+	//   exist.
+	// - [owner] specifies who will own the minted supply.
+	NewCreateSubnetAssetTx(
+		name string,
+		symbol string,
+		denomination byte,
+		maxSupply uint64,
+		owner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.CreateAssetTx, error)
+
 	// NewOperationTx performs state changes on the UTXO set. These state
 	// changes may be more complex than simple value transfers.
 	//
@@ -189,10 +245,39 @@ func (b *builder) GetImportableBalance(
 	return b.getBalance(chainID, ops)
 }
 
+func (b *builder) PlanImportTxs(
+	chainID ids.ID,
+	options ...common.Option,
+) ([][]ids.ID, error) {
+	ops := common.NewOptions(options)
+	utxos, err := b.backend.UTXOs(ops.Context(), chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	importable := make([]*avax.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		if ops.CanImport(utxo.InputID()) {
+			importable = append(importable, utxo)
+		}
+	}
+	return common.PlanImports(
+		importable,
+		ops.Addresses(b.addrs),
+		ops.MinIssuanceTime(),
+		b.backend.AVAXAssetID(),
+		common.MaxImportTxSize,
+	), nil
+}
+
 func (b *builder) NewBaseTx(
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
 ) (*txs.BaseTx, error) {
+	if err := b.verifyOutputAmounts(outputs); err != nil {
+		return nil, err
+	}
+
 	toBurn := map[ids.ID]uint64{
 		b.backend.AVAXAssetID(): b.backend.BaseTxFee(),
 	}
@@ -222,6 +307,176 @@ func (b *builder) NewBaseTx(
 	}}, nil
 }
 
+func (b *builder) PlanBaseTxs(
+	outputs []*avax.TransferableOutput,
+	options ...common.Option,
+) (*common.SendPlan, error) {
+	if err := b.verifyOutputAmounts(outputs); err != nil {
+		return nil, err
+	}
+
+	var (
+		codec   = Parser.Codec()
+		sizes   = make([]int, len(outputs))
+		amounts = make(map[ids.ID]uint64)
+	)
+	for i, out := range outputs {
+		size, err := codec.Size(txs.CodecVersion, out)
+		if err != nil {
+			return nil, err
+		}
+		sizes[i] = size
+
+		assetID := out.AssetID()
+		amount, err := math.Add64(amounts[assetID], out.Out.Amount())
+		if err != nil {
+			return nil, err
+		}
+		amounts[assetID] = amount
+	}
+
+	batches := common.PlanSends(outputs, sizes, common.MaxSendTxSize)
+	fee, err := math.Mul64(b.backend.BaseTxFee(), uint64(len(batches)))
+	if err != nil {
+		return nil, err
+	}
+
+	toBurn := maps.Clone(amounts)
+	avaxAssetID := b.backend.AVAXAssetID()
+	toBurn[avaxAssetID], err = math.Add64(toBurn[avaxAssetID], fee)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := common.NewOptions(options)
+	balance, err := b.getBalance(b.backend.BlockchainID(), ops)
+	if err != nil {
+		return nil, err
+	}
+	for assetID, amount := range toBurn {
+		if available := balance[assetID]; available < amount {
+			return nil, fmt.Errorf(
+				"%w: sending %d txs needs %d more units of asset %q",
+				errInsufficientFunds,
+				len(batches),
+				amount-available,
+				assetID,
+			)
+		}
+	}
+
+	return &common.SendPlan{
+		Batches: batches,
+		Amounts: amounts,
+		Fee:     fee,
+	}, nil
+}
+
+func (b *builder) NewSweepTx(
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	ops := common.NewOptions(options)
+	utxos, err := b.backend.UTXOs(ops.Context(), b.backend.BlockchainID())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		addrs           = ops.Addresses(b.addrs)
+		minIssuanceTime = ops.MinIssuanceTime()
+		avaxAssetID     = b.backend.AVAXAssetID()
+		dust            = common.SelectDust(
+			utxos,
+			avaxAssetID,
+			ops.DustThreshold(b.backend.MinOutputAmount()),
+			addrs,
+			minIssuanceTime,
+			common.MaxSweepInputs,
+		)
+	)
+	if len(dust) == 0 {
+		return nil, errNoDust
+	}
+
+	var (
+		inputs = make([]*avax.TransferableInput, 0, len(dust))
+		amount uint64
+	)
+	for _, utxo := range dust {
+		out := utxo.Out.(*secp256k1fx.TransferOutput)
+		inputSigIndices, _ := common.MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		inputs = append(inputs, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  utxo.Asset,
+			In: &secp256k1fx.TransferInput{
+				Amt: out.Amt,
+				Input: secp256k1fx.Input{
+					SigIndices: inputSigIndices,
+				},
+			},
+		})
+
+		amount, err = math.Add64(amount, out.Amt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	txFee := b.backend.BaseTxFee()
+	if amount <= txFee {
+		return nil, fmt.Errorf(
+			"%w: dust UTXOs hold %d, which doesn't exceed the fee of %d",
+			errInsufficientFunds,
+			amount,
+			txFee,
+		)
+	}
+	outputs := []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: avaxAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          amount - txFee,
+			OutputOwners: *to,
+		},
+	}}
+	if err := b.verifyOutputAmounts(outputs); err != nil {
+		return nil, err
+	}
+
+	utils.Sort(inputs) // sort inputs
+	return &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    b.backend.NetworkID(),
+		BlockchainID: b.backend.BlockchainID(),
+		Ins:          inputs,
+		Outs:         outputs,
+		Memo:         ops.Memo(),
+	}}, nil
+}
+
+func (b *builder) NewCreateSubnetAssetTx(
+	name string,
+	symbol string,
+	denomination byte,
+	maxSupply uint64,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.CreateAssetTx, error) {
+	return b.NewCreateAssetTx(
+		name,
+		symbol,
+		denomination,
+		map[uint32][]verify.State{
+			0: {
+				&secp256k1fx.TransferOutput{
+					Amt:          maxSupply,
+					OutputOwners: *owner,
+				},
+			},
+		},
+		options...,
+	)
+}
+
 func (b *builder) NewCreateAssetTx(
 	name string,
 	symbol string,
@@ -365,6 +620,10 @@ func (b *builder) NewImportTx(
 	)
 	// Iterate over the unlocked UTXOs
 	for _, utxo := range utxos {
+		if !ops.CanImport(utxo.InputID()) {
+			continue
+		}
+
 		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
 		if !ok {
 			// Can't import an unknown transfer output type
@@ -425,8 +684,9 @@ func (b *builder) NewImportTx(
 		delete(importedAmounts, avaxAssetID)
 	}
 
+	importedOutputs := make([]*avax.TransferableOutput, 0, len(importedAmounts))
 	for assetID, amount := range importedAmounts {
-		outputs = append(outputs, &avax.TransferableOutput{
+		importedOutputs = append(importedOutputs, &avax.TransferableOutput{
 			Asset: avax.Asset{ID: assetID},
 			Out: &secp256k1fx.TransferOutput{
 				Amt:          amount,
@@ -434,6 +694,10 @@ func (b *builder) NewImportTx(
 			},
 		})
 	}
+	if err := b.verifyOutputAmounts(importedOutputs); err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, importedOutputs...)
 
 	avax.SortTransferableOutputs(outputs, Parser.Codec())
 	return &txs.ImportTx{
@@ -454,6 +718,10 @@ func (b *builder) NewExportTx(
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
 ) (*txs.ExportTx, error) {
+	if err := b.verifyOutputAmounts(outputs); err != nil {
+		return nil, err
+	}
+
 	toBurn := map[ids.ID]uint64{
 		b.backend.AVAXAssetID(): b.backend.BaseTxFee(),
 	}
@@ -551,6 +819,20 @@ func (b *builder) spend(
 		Addrs:     []ids.ShortID{addr},
 	})
 
+	utxos = common.SortUTXOs(
+		options.UTXOSelection(),
+		utxos,
+		amountsToBurn,
+		addrs,
+		minIssuanceTime,
+	)
+
+	var (
+		avaxAssetID     = b.backend.AVAXAssetID()
+		dustThreshold   = options.DustThreshold(0)
+		minOutputAmount = b.backend.MinOutputAmount()
+	)
+
 	// Iterate over the UTXOs
 	for _, utxo := range utxos {
 		assetID := utxo.AssetID()
@@ -562,6 +844,11 @@ func (b *builder) spend(
 			continue
 		}
 
+		// Dust is left to be swept rather than spent.
+		if common.IsDust(utxo, avaxAssetID, dustThreshold) {
+			continue
+		}
+
 		outIntf := utxo.Out
 		out, ok := outIntf.(*secp256k1fx.TransferOutput)
 		if !ok {
@@ -592,7 +879,12 @@ func (b *builder) spend(
 			out.Amt,               // Amount available to burn
 		)
 		amountsToBurn[assetID] -= amountToBurn
-		if remainingAmount := out.Amt - amountToBurn; remainingAmount > 0 {
+		remainingAmount := out.Amt - amountToBurn
+		if assetID == avaxAssetID && remainingAmount < minOutputAmount {
+			// The change would be dust, so it is burned instead.
+			continue
+		}
+		if remainingAmount > 0 {
 			// This input had extra value, so some of it must be returned
 			outputs = append(outputs, &avax.TransferableOutput{
 				Asset: utxo.Asset,
@@ -620,6 +912,23 @@ func (b *builder) spend(
 	return inputs, outputs, nil
 }
 
+// verifyOutputAmounts verifies that none of the AVAX [outputs] are dust.
+func (b *builder) verifyOutputAmounts(outputs []*avax.TransferableOutput) error {
+	var (
+		avaxAssetID     = b.backend.AVAXAssetID()
+		minOutputAmount = b.backend.MinOutputAmount()
+	)
+	for _, out := range outputs {
+		if out.AssetID() != avaxAssetID {
+			continue
+		}
+		if amount := out.Out.Amount(); amount < minOutputAmount {
+			return fmt.Errorf("%w: %d < %d", errDustOutput, amount, minOutputAmount)
+		}
+	}
+	return nil
+}
+
 func (b *builder) mintFTs(
 	outputs map[ids.ID]*secp256k1fx.TransferOutput,
 	options *common.Options,
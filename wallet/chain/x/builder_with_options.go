@@ -51,6 +51,16 @@ func (b *builderWithOptions) GetImportableBalance(
 	)
 }
 
+func (b *builderWithOptions) PlanImportTxs(
+	chainID ids.ID,
+	options ...common.Option,
+) ([][]ids.ID, error) {
+	return b.Builder.PlanImportTxs(
+		chainID,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewBaseTx(
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
@@ -61,6 +71,26 @@ func (b *builderWithOptions) NewBaseTx(
 	)
 }
 
+func (b *builderWithOptions) PlanBaseTxs(
+	outputs []*avax.TransferableOutput,
+	options ...common.Option,
+) (*common.SendPlan, error) {
+	return b.Builder.PlanBaseTxs(
+		outputs,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
+func (b *builderWithOptions) NewSweepTx(
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.BaseTx, error) {
+	return b.Builder.NewSweepTx(
+		to,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewCreateAssetTx(
 	name string,
 	symbol string,
@@ -77,6 +107,24 @@ func (b *builderWithOptions) NewCreateAssetTx(
 	)
 }
 
+func (b *builderWithOptions) NewCreateSubnetAssetTx(
+	name string,
+	symbol string,
+	denomination byte,
+	maxSupply uint64,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.CreateAssetTx, error) {
+	return b.Builder.NewCreateSubnetAssetTx(
+		name,
+		symbol,
+		denomination,
+		maxSupply,
+		owner,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewOperationTx(
 	operations []*txs.Operation,
 	options ...common.Option,
@@ -19,6 +19,9 @@ type Context interface {
 	AVAXAssetID() ids.ID
 	BaseTxFee() uint64
 	CreateAssetTxFee() uint64
+	// MinOutputAmount is the minimum amount of AVAX that the outputs built
+	// by the wallet hold. If 0, outputs of any amount are built.
+	MinOutputAmount() uint64
 }
 
 type context struct {
@@ -27,6 +30,7 @@ type context struct {
 	avaxAssetID      ids.ID
 	baseTxFee        uint64
 	createAssetTxFee uint64
+	minOutputAmount  uint64
 }
 
 func NewContextFromURI(ctx stdcontext.Context, uri string) (Context, error) {
@@ -66,6 +70,7 @@ func NewContextFromClients(
 		asset.AssetID,
 		uint64(txFees.TxFee),
 		uint64(txFees.CreateAssetTxFee),
+		uint64(txFees.MinOutputAmount),
 	), nil
 }
 
@@ -75,6 +80,7 @@ func NewContext(
 	avaxAssetID ids.ID,
 	baseTxFee uint64,
 	createAssetTxFee uint64,
+	minOutputAmount uint64,
 ) Context {
 	return &context{
 		networkID:        networkID,
@@ -82,6 +88,7 @@ func NewContext(
 		avaxAssetID:      avaxAssetID,
 		baseTxFee:        baseTxFee,
 		createAssetTxFee: createAssetTxFee,
+		minOutputAmount:  minOutputAmount,
 	}
 }
 
@@ -104,3 +111,7 @@ func (c *context) BaseTxFee() uint64 {
 func (c *context) CreateAssetTxFee() uint64 {
 	return c.createAssetTxFee
 }
+
+func (c *context) MinOutputAmount() uint64 {
+	return c.minOutputAmount
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package x
+
+import (
+	stdcontext "context"
+	"reflect"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+var (
+	_ Signer      = (*policySigner)(nil)
+	_ txs.Visitor = (*outputsVisitor)(nil)
+)
+
+type policySigner struct {
+	signer   Signer
+	kc       keychain.Keychain
+	enforcer *common.PolicyEnforcer
+}
+
+// NewPolicySigner returns a signer that refuses to sign txs that violate the
+// policy of [enforcer]. Funds sent to addresses of [kc] are treated as change.
+func NewPolicySigner(
+	signer Signer,
+	kc keychain.Keychain,
+	enforcer *common.PolicyEnforcer,
+) Signer {
+	return &policySigner{
+		signer:   signer,
+		kc:       kc,
+		enforcer: enforcer,
+	}
+}
+
+func (s *policySigner) SignUnsigned(ctx stdcontext.Context, utx txs.UnsignedTx) (*txs.Tx, error) {
+	tx := &txs.Tx{Unsigned: utx}
+	return tx, s.Sign(ctx, tx)
+}
+
+func (s *policySigner) Sign(ctx stdcontext.Context, tx *txs.Tx) error {
+	spend, err := NewSpend(tx.Unsigned, s.kc)
+	if err != nil {
+		return err
+	}
+	if err := s.enforcer.Verify(spend); err != nil {
+		return err
+	}
+	if err := s.signer.Sign(ctx, tx); err != nil {
+		return err
+	}
+	return s.enforcer.Record(tx.ID(), spend)
+}
+
+// NewSpend returns the value that [utx] sends to addresses outside of [kc].
+// The tx type is the name of the concrete type of [utx], e.g. "BaseTx".
+func NewSpend(utx txs.UnsignedTx, kc keychain.Keychain) (*common.Spend, error) {
+	visitor := &outputsVisitor{}
+	if err := utx.Visit(visitor); err != nil {
+		return nil, err
+	}
+	txType := reflect.TypeOf(utx).Elem().Name()
+	return common.NewSpend(txType, visitor.outs, kc.Addresses())
+}
+
+// outputsVisitor collects the transferable outputs produced by a tx
+type outputsVisitor struct {
+	outs []*avax.TransferableOutput
+}
+
+func (v *outputsVisitor) BaseTx(tx *txs.BaseTx) error {
+	v.outs = append(v.outs, tx.Outs...)
+	return nil
+}
+
+func (v *outputsVisitor) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *outputsVisitor) OperationTx(tx *txs.OperationTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *outputsVisitor) ImportTx(tx *txs.ImportTx) error {
+	return v.BaseTx(&tx.BaseTx)
+}
+
+func (v *outputsVisitor) ExportTx(tx *txs.ExportTx) error {
+	if err := v.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	v.outs = append(v.outs, tx.ExportedOuts...)
+	return nil
+}
@@ -5,9 +5,14 @@ package x
 
 import (
 	"errors"
+	"time"
+
+	stdcontext "context"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -20,8 +25,16 @@ var (
 	errNotAccepted = errors.New("not accepted")
 
 	_ Wallet = (*wallet)(nil)
+	_ Client = avm.Client(nil)
 )
 
+// Client is the subset of the X-chain API that the wallet uses to issue txs.
+type Client interface {
+	IssueTx(ctx stdcontext.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error)
+	ConfirmTx(ctx stdcontext.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (choices.Status, error)
+	GetTxStatus(ctx stdcontext.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error)
+}
+
 type Wallet interface {
 	Context
 
@@ -40,6 +53,29 @@ type Wallet interface {
 		options ...common.Option,
 	) (*txs.Tx, error)
 
+	// IssueBaseTxs creates, signs, and issues as many simple value transfers
+	// as needed to send all the provided [outputs]. The transactions are
+	// issued in order, each one once the previous one was accepted. If a
+	// transaction fails, the remaining ones aren't issued.
+	//
+	// The result of each transaction is returned, even if one failed.
+	//
+	// - [outputs] specifies all the recipients and amounts that should be sent.
+	IssueBaseTxs(
+		outputs []*avax.TransferableOutput,
+		options ...common.Option,
+	) ([]*common.SendResult, error)
+
+	// IssueSweepTx creates, signs, and issues a new simple value transfer
+	// that consolidates the dust AVAX UTXOs of the wallet into a single
+	// output.
+	//
+	// - [to] specifies where to send the consolidated funds to.
+	IssueSweepTx(
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.Tx, error)
+
 	// IssueCreateAssetTx creates, signs, and issues a new asset.
 	//
 	// - [name] specifies a human readable name for this asset.
@@ -57,6 +93,25 @@ type Wallet interface {
 		options ...common.Option,
 	) (*txs.Tx, error)
 
+	// IssueCreateSubnetAssetTx creates, signs, and issues a new fungible asset
+	// that can be used to transform a permissioned subnet into a
+	// permissionless subnet. The entire [maxSupply] is minted to [owner].
+	//
+	// - [name] specifies a human readable name for this asset.
+	// - [symbol] specifies a human readable abbreviation for this asset.
+	// - [denomination] specifies how many times the asset can be split.
+	// - [maxSupply] is the maximum total amount of the asset that should ever
+	//   exist.
+	// - [owner] specifies who will own the minted supply.
+	IssueCreateSubnetAssetTx(
+		name string,
+		symbol string,
+		denomination byte,
+		maxSupply uint64,
+		owner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.Tx, error)
+
 	// IssueOperationTx creates, signs, and issues state changes on the UTXO
 	// set. These state changes may be more complex than simple value transfers.
 	//
@@ -120,6 +175,21 @@ type Wallet interface {
 		options ...common.Option,
 	) (*txs.Tx, error)
 
+	// IssueImportTxs creates, signs, and issues as many import transactions as
+	// needed to consume all the available UTXOs and import the funds to [to].
+	// The transactions are issued in order, each one once the previous one was
+	// accepted. If a transaction fails, the remaining ones aren't issued.
+	//
+	// The result of each transaction is returned, even if one failed.
+	//
+	// - [chainID] specifies the chain to be importing funds from.
+	// - [to] specifies where to send the imported funds to.
+	IssueImportTxs(
+		chainID ids.ID,
+		to *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) ([]*common.ImportResult, error)
+
 	// IssueExportTx creates, signs, and issues an export transaction that
 	// attempts to send all the provided [outputs] to the requested [chainID].
 	//
@@ -142,12 +212,20 @@ type Wallet interface {
 		tx *txs.Tx,
 		options ...common.Option,
 	) error
+
+	// IssueTxAsync issues the signed tx and returns without waiting for it to
+	// be decided. The returned handle reports the status of the tx until it is
+	// decided or the context of the options is done.
+	IssueTxAsync(
+		tx *txs.Tx,
+		options ...common.Option,
+	) (*common.TxHandle, error)
 }
 
 func NewWallet(
 	builder Builder,
 	signer Signer,
-	client avm.Client,
+	client Client,
 	backend Backend,
 ) Wallet {
 	return &wallet{
@@ -162,7 +240,7 @@ type wallet struct {
 	Backend
 	builder Builder
 	signer  Signer
-	client  avm.Client
+	client  Client
 }
 
 func (w *wallet) Builder() Builder {
@@ -184,6 +262,48 @@ func (w *wallet) IssueBaseTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueBaseTxs(
+	outputs []*avax.TransferableOutput,
+	options ...common.Option,
+) ([]*common.SendResult, error) {
+	plan, err := w.builder.PlanBaseTxs(outputs, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*common.SendResult, len(plan.Batches))
+	for i, batch := range plan.Batches {
+		results[i] = &common.SendResult{
+			Outputs: batch,
+		}
+	}
+	for i, result := range results {
+		tx, err := w.IssueBaseTx(result.Outputs, options...)
+		if tx != nil {
+			result.TxID = tx.ID()
+		}
+		if err != nil {
+			result.Err = err
+			for _, skipped := range results[i+1:] {
+				skipped.Err = common.ErrSendSkipped
+			}
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+func (w *wallet) IssueSweepTx(
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	utx, err := w.builder.NewSweepTx(to, options...)
+	if err != nil {
+		return nil, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueCreateAssetTx(
 	name string,
 	symbol string,
@@ -198,6 +318,21 @@ func (w *wallet) IssueCreateAssetTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueCreateSubnetAssetTx(
+	name string,
+	symbol string,
+	denomination byte,
+	maxSupply uint64,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	utx, err := w.builder.NewCreateSubnetAssetTx(name, symbol, denomination, maxSupply, owner, options...)
+	if err != nil {
+		return nil, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueOperationTx(
 	operations []*txs.Operation,
 	options ...common.Option,
@@ -268,6 +403,42 @@ func (w *wallet) IssueImportTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueImportTxs(
+	chainID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) ([]*common.ImportResult, error) {
+	batches, err := w.builder.PlanImportTxs(chainID, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*common.ImportResult, len(batches))
+	for i, batch := range batches {
+		results[i] = &common.ImportResult{
+			UTXOIDs: batch,
+		}
+	}
+	for i, result := range results {
+		batchOptions := common.UnionOptions(
+			options,
+			[]common.Option{common.WithImportedUTXOs(set.Of(result.UTXOIDs...))},
+		)
+		tx, err := w.IssueImportTx(chainID, to, batchOptions...)
+		if tx != nil {
+			result.TxID = tx.ID()
+		}
+		if err != nil {
+			result.Err = err
+			for _, skipped := range results[i+1:] {
+				skipped.Err = common.ErrImportSkipped
+			}
+			return results, err
+		}
+	}
+	return results, nil
+}
+
 func (w *wallet) IssueExportTx(
 	chainID ids.ID,
 	outputs []*avax.TransferableOutput,
@@ -327,3 +498,52 @@ func (w *wallet) IssueTx(
 	}
 	return nil
 }
+
+func (w *wallet) IssueTxAsync(
+	tx *txs.Tx,
+	options ...common.Option,
+) (*common.TxHandle, error) {
+	ops := common.NewOptions(options)
+	ctx := ops.Context()
+	txID, err := w.client.IssueTx(ctx, tx.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	if f := ops.PostIssuanceFunc(); f != nil {
+		f(txID)
+	}
+
+	onDecided := func(ctx stdcontext.Context, _ common.TxStatus) error {
+		return w.Backend.AcceptTx(ctx, tx)
+	}
+	if ops.AssumeDecided() {
+		if err := w.Backend.AcceptTx(ctx, tx); err != nil {
+			return nil, err
+		}
+		onDecided = nil
+	}
+	return common.NewTxHandle(txID, ops, w.getTxStatus, onDecided), nil
+}
+
+func (w *wallet) getTxStatus(ctx stdcontext.Context, txID ids.ID) (common.TxStatusUpdate, error) {
+	txStatus, err := w.client.GetTxStatus(ctx, txID)
+	if err != nil {
+		return common.TxStatusUpdate{}, err
+	}
+
+	update := common.TxStatusUpdate{
+		TxID: txID,
+	}
+	switch txStatus {
+	case choices.Processing:
+		update.Status = common.TxStatusProcessing
+	case choices.Accepted:
+		update.Status = common.TxStatusAccepted
+	case choices.Rejected:
+		update.Status = common.TxStatusRejected
+	default:
+		update.Status = common.TxStatusUnknown
+	}
+	return update, nil
+}
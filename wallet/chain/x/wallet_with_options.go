@@ -46,6 +46,26 @@ func (w *walletWithOptions) IssueBaseTx(
 	)
 }
 
+func (w *walletWithOptions) IssueBaseTxs(
+	outputs []*avax.TransferableOutput,
+	options ...common.Option,
+) ([]*common.SendResult, error) {
+	return w.Wallet.IssueBaseTxs(
+		outputs,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *walletWithOptions) IssueSweepTx(
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.Wallet.IssueSweepTx(
+		to,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueCreateAssetTx(
 	name string,
 	symbol string,
@@ -62,6 +82,24 @@ func (w *walletWithOptions) IssueCreateAssetTx(
 	)
 }
 
+func (w *walletWithOptions) IssueCreateSubnetAssetTx(
+	name string,
+	symbol string,
+	denomination byte,
+	maxSupply uint64,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.Wallet.IssueCreateSubnetAssetTx(
+		name,
+		symbol,
+		denomination,
+		maxSupply,
+		owner,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueOperationTx(
 	operations []*txs.Operation,
 	options ...common.Option,
@@ -130,6 +168,18 @@ func (w *walletWithOptions) IssueImportTx(
 	)
 }
 
+func (w *walletWithOptions) IssueImportTxs(
+	chainID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) ([]*common.ImportResult, error) {
+	return w.Wallet.IssueImportTxs(
+		chainID,
+		to,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueExportTx(
 	chainID ids.ID,
 	outputs []*avax.TransferableOutput,
@@ -161,3 +211,13 @@ func (w *walletWithOptions) IssueTx(
 		common.UnionOptions(w.options, options)...,
 	)
 }
+
+func (w *walletWithOptions) IssueTxAsync(
+	tx *txs.Tx,
+	options ...common.Option,
+) (*common.TxHandle, error) {
+	return w.Wallet.IssueTxAsync(
+		tx,
+		common.UnionOptions(w.options, options)...,
+	)
+}
@@ -12,7 +12,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
-	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -72,25 +71,32 @@ func FetchState(
 	*AVAXState,
 	error,
 ) {
-	infoClient := info.NewClient(uri)
-	pClient := platformvm.NewClient(uri)
-	xClient := avm.NewClient(uri, "X")
-	cClient := evm.NewCChainClient(uri)
-
-	pCTX, err := p.NewContextFromClients(ctx, infoClient, xClient)
-	if err != nil {
-		return nil, err
-	}
-
-	xCTX, err := x.NewContextFromClients(ctx, infoClient, xClient)
+	contexts, err := FetchContexts(ctx, uri)
 	if err != nil {
 		return nil, err
 	}
+	return FetchStateWithContexts(ctx, uri, contexts, addrs)
+}
 
-	cCTX, err := c.NewContextFromClients(ctx, infoClient, xClient)
-	if err != nil {
-		return nil, err
-	}
+// FetchStateWithContexts fetches the UTXOs referenced by [addrs] from the node
+// at [uri], using the already known chain [contexts].
+func FetchStateWithContexts(
+	ctx context.Context,
+	uri string,
+	contexts *Contexts,
+	addrs set.Set[ids.ShortID],
+) (
+	*AVAXState,
+	error,
+) {
+	var (
+		pClient = platformvm.NewClient(uri)
+		xClient = avm.NewClient(uri, "X")
+		cClient = evm.NewCChainClient(uri)
+		pCTX    = contexts.P
+		xCTX    = contexts.X
+		cCTX    = contexts.C
+	)
 
 	utxos := NewUTXOs()
 	addrList := addrs.List()
@@ -117,7 +123,7 @@ func FetchState(
 	}
 	for _, destinationChain := range chains {
 		for _, sourceChain := range chains {
-			err = AddAllUTXOs(
+			err := AddAllUTXOs(
 				ctx,
 				utxos,
 				destinationChain.client,
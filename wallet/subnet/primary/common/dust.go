@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// MaxSweepInputs is the maximum number of UTXOs consumed by a sweep tx. It
+// keeps the tx well under the maximum size of a tx accepted into the mempool.
+// Wallets with more dust UTXOs can sweep them with multiple txs.
+const MaxSweepInputs = 256
+
+// IsDust returns true if [utxo] is a [secp256k1fx.TransferOutput] of
+// [assetID] that holds less than [threshold].
+func IsDust(utxo *avax.UTXO, assetID ids.ID, threshold uint64) bool {
+	if utxo.AssetID() != assetID {
+		return false
+	}
+	out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+	return ok && out.Amt < threshold
+}
+
+// SelectDust returns the dust UTXOs of [assetID], as defined by [IsDust], that
+// [addrs] can spend at [minIssuanceTime]. At most [maxUTXOs] UTXOs are
+// returned, in the order they were provided.
+func SelectDust(
+	utxos []*avax.UTXO,
+	assetID ids.ID,
+	threshold uint64,
+	addrs set.Set[ids.ShortID],
+	minIssuanceTime uint64,
+	maxUTXOs int,
+) []*avax.UTXO {
+	var dust []*avax.UTXO
+	for _, utxo := range utxos {
+		if len(dust) >= maxUTXOs {
+			break
+		}
+		if !IsDust(utxo, assetID, threshold) {
+			continue
+		}
+
+		out := utxo.Out.(*secp256k1fx.TransferOutput)
+		if _, ok := MatchOwners(&out.OutputOwners, addrs, minIssuanceTime); !ok {
+			continue
+		}
+		dust = append(dust, utxo)
+	}
+	return dust
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+func TestSelectDust(t *testing.T) {
+	var (
+		assetID      = ids.GenerateTestID()
+		otherAssetID = ids.GenerateTestID()
+		addr         = ids.GenerateTestShortID()
+		other        = ids.GenerateTestShortID()
+
+		dust0      = newTestUTXO(assetID, 5, addr)
+		notDust    = newTestUTXO(assetID, 10, addr)
+		otherAsset = newTestUTXO(otherAssetID, 5, addr)
+		otherOwner = newTestUTXO(assetID, 5, other)
+		dust1      = newTestUTXO(assetID, 9, addr)
+
+		utxos = []*avax.UTXO{dust0, notDust, otherAsset, otherOwner, dust1}
+		addrs = set.Of(addr)
+	)
+
+	tests := []struct {
+		name          string
+		threshold     uint64
+		maxUTXOs      int
+		expectedUTXOs []*avax.UTXO
+	}{
+		{
+			name:          "all dust",
+			threshold:     10,
+			maxUTXOs:      MaxSweepInputs,
+			expectedUTXOs: []*avax.UTXO{dust0, dust1},
+		},
+		{
+			name:          "limited",
+			threshold:     10,
+			maxUTXOs:      1,
+			expectedUTXOs: []*avax.UTXO{dust0},
+		},
+		{
+			name:          "higher threshold",
+			threshold:     11,
+			maxUTXOs:      MaxSweepInputs,
+			expectedUTXOs: []*avax.UTXO{dust0, notDust, dust1},
+		},
+		{
+			name:          "no threshold",
+			threshold:     0,
+			maxUTXOs:      MaxSweepInputs,
+			expectedUTXOs: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dust := SelectDust(utxos, assetID, test.threshold, addrs, 0, test.maxUTXOs)
+			require.Equal(t, test.expectedUTXOs, dust)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"errors"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	// MaxImportTxSize is the size, in bytes, that each ImportTx of an import
+	// plan is kept under. It matches the maximum size of a tx accepted into
+	// the mempools of the P-chain and the X-chain.
+	MaxImportTxSize = 64 * units.KiB
+
+	// importTxOverhead bounds the size of an ImportTx, excluding its imported
+	// inputs, their credentials and its outputs. It leaves room for the
+	// inputs and change output needed if the imported AVAX doesn't cover the
+	// fee.
+	importTxOverhead = 2 * units.KiB
+
+	// importedInputSize is the size of an imported secp256k1fx input, excluding
+	// its signature indices:
+	// txID + outputIndex + assetID + typeID + amount + len(sigIndices)
+	importedInputSize = 32 + 4 + 32 + 4 + 8 + 4
+	// credentialSize is the size of a secp256k1fx credential, excluding its
+	// signatures: typeID + len(sigs)
+	credentialSize = 4 + 4
+	// sigSize is the size of a signature index and its signature.
+	sigSize = 4 + 65
+	// outputSize bounds the size of the secp256k1fx output created for each
+	// imported asset, assuming the recipient is a single address.
+	outputSize = 32 + 4 + 8 + 8 + 4 + 4 + 20
+)
+
+// ErrImportSkipped is reported for the batches of an import plan that weren't
+// issued because an earlier batch failed.
+var ErrImportSkipped = errors.New("skipped because an earlier import failed")
+
+// ImportResult is the outcome of issuing one of the ImportTxs of an import
+// plan.
+type ImportResult struct {
+	// UTXOIDs are the IDs of the UTXOs the ImportTx attempted to import.
+	UTXOIDs []ids.ID
+	// TxID is the ID of the ImportTx. Empty if the tx wasn't issued.
+	TxID ids.ID
+	// Err is the reason the ImportTx failed, if it did.
+	Err error
+}
+
+// PlanImports splits the [utxos] that can be imported by [addrs] into batches
+// that can each be imported by an ImportTx of at most [maxTxSize] bytes.
+//
+// The batches are meant to be issued in order: UTXOs of [avaxAssetID] are
+// placed first, largest first, so that the earliest ImportTxs pay their fees
+// with the AVAX they import and later ImportTxs can pay their fees with the
+// AVAX imported by earlier ones. The remaining UTXOs are grouped by asset to
+// minimize the number of outputs of each ImportTx.
+func PlanImports(
+	utxos []*avax.UTXO,
+	addrs set.Set[ids.ShortID],
+	minIssuanceTime uint64,
+	avaxAssetID ids.ID,
+	maxTxSize int,
+) [][]ids.ID {
+	type importableUTXO struct {
+		utxoID  ids.ID
+		assetID ids.ID
+		amount  uint64
+		size    int
+	}
+
+	importable := make([]importableUTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+
+		sigIndices, ok := MatchOwners(&out.OutputOwners, addrs, minIssuanceTime)
+		if !ok {
+			continue
+		}
+
+		importable = append(importable, importableUTXO{
+			utxoID:  utxo.InputID(),
+			assetID: utxo.AssetID(),
+			amount:  out.Amt,
+			size:    importedInputSize + credentialSize + len(sigIndices)*sigSize,
+		})
+	}
+
+	slices.SortFunc(importable, func(a, b importableUTXO) int {
+		aIsAVAX := a.assetID == avaxAssetID
+		bIsAVAX := b.assetID == avaxAssetID
+		switch {
+		case aIsAVAX && !bIsAVAX:
+			return -1
+		case !aIsAVAX && bIsAVAX:
+			return 1
+		case a.assetID.Less(b.assetID):
+			return -1
+		case b.assetID.Less(a.assetID):
+			return 1
+		case a.amount > b.amount:
+			return -1
+		case a.amount < b.amount:
+			return 1
+		}
+
+		switch {
+		case a.utxoID.Less(b.utxoID):
+			return -1
+		case b.utxoID.Less(a.utxoID):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var (
+		batches     [][]ids.ID
+		batch       []ids.ID
+		batchSize   = importTxOverhead
+		batchAssets = set.Set[ids.ID]{}
+	)
+	for _, utxo := range importable {
+		size := utxo.size
+		if !batchAssets.Contains(utxo.assetID) {
+			size += outputSize
+		}
+
+		// A UTXO that doesn't fit in an empty batch is still given its own
+		// batch, so that the failure is reported when it is imported.
+		if len(batch) > 0 && batchSize+size > maxTxSize {
+			batches = append(batches, batch)
+			batch = nil
+			batchSize = importTxOverhead
+			batchAssets.Clear()
+			size = utxo.size + outputSize
+		}
+
+		batch = append(batch, utxo.utxoID)
+		batchSize += size
+		batchAssets.Add(utxo.assetID)
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
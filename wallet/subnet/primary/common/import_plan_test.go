@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+func TestPlanImports(t *testing.T) {
+	var (
+		avaxAssetID = ids.GenerateTestID()
+		assetID     = ids.GenerateTestID()
+		addr        = ids.GenerateTestShortID()
+		other       = ids.GenerateTestShortID()
+		addrs       = set.Of(addr)
+
+		avax0    = newTestUTXO(avaxAssetID, 5, addr)
+		avax1    = newTestUTXO(avaxAssetID, 50, addr)
+		asset0   = newTestUTXO(assetID, 10, addr)
+		asset1   = newTestUTXO(assetID, 20, addr)
+		notOwned = newTestUTXO(avaxAssetID, 100, other)
+
+		utxos = []*avax.UTXO{asset0, avax0, notOwned, asset1, avax1}
+
+		// Each UTXO has a single signature.
+		utxoSize = importedInputSize + credentialSize + sigSize
+	)
+
+	tests := []struct {
+		name            string
+		maxTxSize       int
+		expectedBatches [][]*avax.UTXO
+	}{
+		{
+			name:      "single batch",
+			maxTxSize: MaxImportTxSize,
+			expectedBatches: [][]*avax.UTXO{
+				{avax1, avax0, asset1, asset0},
+			},
+		},
+		{
+			name:      "two UTXOs per batch",
+			maxTxSize: importTxOverhead + 2*utxoSize + outputSize,
+			expectedBatches: [][]*avax.UTXO{
+				{avax1, avax0},
+				{asset1, asset0},
+			},
+		},
+		{
+			name:      "new asset requires an output",
+			maxTxSize: importTxOverhead + 3*utxoSize + outputSize,
+			expectedBatches: [][]*avax.UTXO{
+				{avax1, avax0},
+				{asset1, asset0},
+			},
+		},
+		{
+			name:      "one UTXO per batch",
+			maxTxSize: importTxOverhead + utxoSize + outputSize,
+			expectedBatches: [][]*avax.UTXO{
+				{avax1},
+				{avax0},
+				{asset1},
+				{asset0},
+			},
+		},
+		{
+			name:      "oversized UTXOs are given their own batch",
+			maxTxSize: 0,
+			expectedBatches: [][]*avax.UTXO{
+				{avax1},
+				{avax0},
+				{asset1},
+				{asset0},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expectedBatches := make([][]ids.ID, len(test.expectedBatches))
+			for i, batch := range test.expectedBatches {
+				for _, utxo := range batch {
+					expectedBatches[i] = append(expectedBatches[i], utxo.InputID())
+				}
+			}
+
+			batches := PlanImports(utxos, addrs, 0, avaxAssetID, test.maxTxSize)
+			require.Equal(t, expectedBatches, batches)
+		})
+	}
+}
+
+func TestCanImport(t *testing.T) {
+	require := require.New(t)
+
+	utxoID := ids.GenerateTestID()
+
+	ops := NewOptions(nil)
+	require.True(ops.CanImport(utxoID))
+
+	ops = NewOptions([]Option{WithImportedUTXOs(set.Of(utxoID))})
+	require.True(ops.CanImport(utxoID))
+	require.False(ops.CanImport(ids.GenerateTestID()))
+}
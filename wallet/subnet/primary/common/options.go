@@ -32,6 +32,9 @@ type Options struct {
 	customEthAddressesSet bool
 	customEthAddresses    set.Set[ethcommon.Address]
 
+	subnetAuthAddressesSet bool
+	subnetAuthAddresses    set.Set[ids.ShortID]
+
 	baseFee *big.Int
 
 	minIssuanceTimeSet bool
@@ -41,6 +44,14 @@ type Options struct {
 
 	changeOwner *secp256k1fx.OutputOwners
 
+	utxoSelection UTXOSelection
+
+	dustThresholdSet bool
+	dustThreshold    uint64
+
+	importedUTXOIDsSet bool
+	importedUTXOIDs    set.Set[ids.ID]
+
 	memo []byte
 
 	assumeDecided bool
@@ -49,6 +60,8 @@ type Options struct {
 	pollFrequency    time.Duration
 
 	postIssuanceFunc PostIssuanceFunc
+
+	txStatusFunc TxStatusFunc
 }
 
 func NewOptions(ops []Option) *Options {
@@ -92,6 +105,16 @@ func (o *Options) EthAddresses(defaultAddresses set.Set[ethcommon.Address]) set.
 	return defaultAddresses
 }
 
+// SubnetAuthAddresses returns the addresses that are expected to authorize
+// subnet operations. If not overridden, the addresses used to authorize subnet
+// operations are the same as the addresses used to spend UTXOs.
+func (o *Options) SubnetAuthAddresses(defaultAddresses set.Set[ids.ShortID]) set.Set[ids.ShortID] {
+	if o.subnetAuthAddressesSet {
+		return o.subnetAuthAddresses
+	}
+	return o.Addresses(defaultAddresses)
+}
+
 func (o *Options) BaseFee(defaultBaseFee *big.Int) *big.Int {
 	if o.baseFee != nil {
 		return o.baseFee
@@ -117,6 +140,25 @@ func (o *Options) ChangeOwner(defaultOwner *secp256k1fx.OutputOwners) *secp256k1
 	return defaultOwner
 }
 
+func (o *Options) UTXOSelection() UTXOSelection {
+	return o.utxoSelection
+}
+
+// DustThreshold returns the amount of AVAX below which a UTXO is considered to
+// be dust.
+func (o *Options) DustThreshold(defaultThreshold uint64) uint64 {
+	if o.dustThresholdSet {
+		return o.dustThreshold
+	}
+	return defaultThreshold
+}
+
+// CanImport returns true if the UTXO with ID [utxoID] may be consumed by an
+// ImportTx.
+func (o *Options) CanImport(utxoID ids.ID) bool {
+	return !o.importedUTXOIDsSet || o.importedUTXOIDs.Contains(utxoID)
+}
+
 func (o *Options) Memo() []byte {
 	return o.memo
 }
@@ -136,6 +178,10 @@ func (o *Options) PostIssuanceFunc() PostIssuanceFunc {
 	return o.postIssuanceFunc
 }
 
+func (o *Options) TxStatusFunc() TxStatusFunc {
+	return o.txStatusFunc
+}
+
 func WithContext(ctx context.Context) Option {
 	return func(o *Options) {
 		o.ctx = ctx
@@ -156,6 +202,17 @@ func WithCustomEthAddresses(addrs set.Set[ethcommon.Address]) Option {
 	}
 }
 
+// WithSubnetAuthAddresses specifies the subnet owner addresses that will
+// authorize subnet operations. The keys for these addresses don't need to be
+// known to the wallet; their signatures can be merged into the tx after it is
+// built.
+func WithSubnetAuthAddresses(addrs set.Set[ids.ShortID]) Option {
+	return func(o *Options) {
+		o.subnetAuthAddressesSet = true
+		o.subnetAuthAddresses = addrs
+	}
+}
+
 func WithBaseFee(baseFee *big.Int) Option {
 	return func(o *Options) {
 		o.baseFee = baseFee
@@ -181,6 +238,34 @@ func WithChangeOwner(changeOwner *secp256k1fx.OutputOwners) Option {
 	}
 }
 
+// WithUTXOSelection specifies which UTXOs are spent first when funds need to
+// be burned. If not specified, UTXOs are spent oldest first.
+func WithUTXOSelection(selection UTXOSelection) Option {
+	return func(o *Options) {
+		o.utxoSelection = selection
+	}
+}
+
+// WithDustThreshold specifies that UTXOs holding less than [threshold] AVAX are
+// dust. Dust UTXOs aren't spent when funds need to be burned, so that they
+// don't bloat the txs, and are consolidated by sweep txs.
+func WithDustThreshold(threshold uint64) Option {
+	return func(o *Options) {
+		o.dustThresholdSet = true
+		o.dustThreshold = threshold
+	}
+}
+
+// WithImportedUTXOs restricts the UTXOs consumed by an ImportTx to the ones
+// with an ID in [utxoIDs]. If not specified, all the available UTXOs are
+// consumed.
+func WithImportedUTXOs(utxoIDs set.Set[ids.ID]) Option {
+	return func(o *Options) {
+		o.importedUTXOIDsSet = true
+		o.importedUTXOIDs = utxoIDs
+	}
+}
+
 func WithMemo(memo []byte) Option {
 	return func(o *Options) {
 		o.memo = memo
@@ -205,3 +290,11 @@ func WithPostIssuanceFunc(f PostIssuanceFunc) Option {
 		o.postIssuanceFunc = f
 	}
 }
+
+// WithTxStatusFunc sets the function that is called with each status update
+// of a tx that is issued asynchronously.
+func WithTxStatusFunc(f TxStatusFunc) Option {
+	return func(o *Options) {
+		o.txStatusFunc = f
+	}
+}
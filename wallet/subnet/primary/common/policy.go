@@ -0,0 +1,279 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	stdmath "math"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// PolicyWindow is the duration over which the daily spend limits of a
+// [Policy] are enforced.
+const PolicyWindow = 24 * time.Hour
+
+var (
+	ErrTxTypeNotAllowed      = errors.New("tx type not allowed")
+	ErrDestinationNotAllowed = errors.New("destination not allowed")
+	ErrTxLimitExceeded       = errors.New("per tx limit exceeded")
+	ErrDailyLimitExceeded    = errors.New("daily limit exceeded")
+)
+
+// Policy restricts the txs that a wallet is allowed to sign.
+//
+// Spend limits are applied per asset to the value sent to addresses that are
+// not controlled by the wallet. Empty allow lists permit everything.
+type Policy struct {
+	// MaxAmountPerTx is the maximum amount of each asset that a single tx may
+	// send.
+	MaxAmountPerTx map[ids.ID]uint64
+	// MaxAmountPerDay is the maximum amount of each asset that may be sent by
+	// all txs signed in the last [PolicyWindow].
+	MaxAmountPerDay map[ids.ID]uint64
+	// AllowedDestinations are the only addresses, other than the addresses of
+	// the wallet, that txs may send funds to.
+	AllowedDestinations set.Set[ids.ShortID]
+	// AllowedTxTypes are the only tx types, as reported by [Spend.TxType],
+	// that may be signed.
+	AllowedTxTypes set.Set[string]
+}
+
+// PolicyViolationError is returned when signing a tx would violate a
+// [Policy]. It wraps one of the ErrXXX errors of this file, so it can be
+// matched with [errors.Is] as well as inspected with [errors.As].
+type PolicyViolationError struct {
+	Err         error
+	TxType      string
+	Asset       ids.ID
+	Destination ids.ShortID
+	Amount      uint64
+	Limit       uint64
+}
+
+func (e *PolicyViolationError) Error() string {
+	switch {
+	case errors.Is(e.Err, ErrTxTypeNotAllowed):
+		return fmt.Sprintf("%s: %s", e.Err, e.TxType)
+	case errors.Is(e.Err, ErrDestinationNotAllowed):
+		return fmt.Sprintf("%s: %s", e.Err, e.Destination)
+	default:
+		return fmt.Sprintf("%s: %s sending %d of %s with a limit of %d",
+			e.Err,
+			e.TxType,
+			e.Amount,
+			e.Asset,
+			e.Limit,
+		)
+	}
+}
+
+func (e *PolicyViolationError) Unwrap() error {
+	return e.Err
+}
+
+// Spend describes the value that a tx sends out of a wallet.
+type Spend struct {
+	TxType       string
+	Destinations set.Set[ids.ShortID]
+	Amounts      map[ids.ID]uint64
+}
+
+// NewSpend returns the [Spend] of a tx of [txType] that produces [outs].
+// Outputs that are only owned by [owned] addresses are treated as change and
+// ignored.
+func NewSpend(
+	txType string,
+	outs []*avax.TransferableOutput,
+	owned set.Set[ids.ShortID],
+) (*Spend, error) {
+	spend := &Spend{
+		TxType:  txType,
+		Amounts: make(map[ids.ID]uint64),
+	}
+	for _, out := range outs {
+		addressable, ok := out.Out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+
+		external := false
+		for _, addrBytes := range addressable.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				return nil, err
+			}
+			if owned.Contains(addr) {
+				continue
+			}
+			external = true
+			spend.Destinations.Add(addr)
+		}
+		if !external {
+			continue
+		}
+
+		assetID := out.AssetID()
+		amount, err := math.Add64(spend.Amounts[assetID], out.Out.Amount())
+		if err != nil {
+			return nil, err
+		}
+		spend.Amounts[assetID] = amount
+	}
+	return spend, nil
+}
+
+// AuditRecord describes a tx signed under a [Policy].
+type AuditRecord struct {
+	Time         time.Time
+	TxID         ids.ID
+	TxType       string
+	Destinations []ids.ShortID
+	Amounts      map[ids.ID]uint64
+}
+
+// Signature of the function that will be called with every tx signed under a
+// [Policy].
+type AuditFunc func(AuditRecord)
+
+// PolicyEnforcer checks txs against a [Policy] and tracks the value sent by
+// the txs it approved. It is safe for concurrent use and can be shared by the
+// signers of multiple chains so that the daily limits are enforced across
+// them.
+type PolicyEnforcer struct {
+	policy    *Policy
+	auditFunc AuditFunc
+
+	lock    sync.Mutex
+	clock   mockable.Clock
+	records []AuditRecord
+}
+
+// NewPolicyEnforcer returns an enforcer of [policy]. If [auditFunc] is
+// non-nil, it is called with every tx that is signed.
+func NewPolicyEnforcer(policy *Policy, auditFunc AuditFunc) *PolicyEnforcer {
+	return &PolicyEnforcer{
+		policy:    policy,
+		auditFunc: auditFunc,
+	}
+}
+
+// Verify returns a [*PolicyViolationError] if signing a tx with [spend] would
+// violate the policy.
+func (p *PolicyEnforcer) Verify(spend *Spend) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.verify(spend)
+}
+
+// Record verifies [spend] and, if it is allowed, adds it to the audit log
+// under [txID].
+func (p *PolicyEnforcer) Record(txID ids.ID, spend *Spend) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err := p.verify(spend); err != nil {
+		return err
+	}
+
+	record := AuditRecord{
+		Time:         p.clock.Time(),
+		TxID:         txID,
+		TxType:       spend.TxType,
+		Destinations: spend.Destinations.List(),
+		Amounts:      spend.Amounts,
+	}
+	p.records = append(p.records, record)
+	if p.auditFunc != nil {
+		p.auditFunc(record)
+	}
+	return nil
+}
+
+// AuditLog returns the txs signed in the last [PolicyWindow], oldest first.
+func (p *PolicyEnforcer) AuditLog() []AuditRecord {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.prune()
+	records := make([]AuditRecord, len(p.records))
+	copy(records, p.records)
+	return records
+}
+
+func (p *PolicyEnforcer) verify(spend *Spend) error {
+	if p.policy.AllowedTxTypes.Len() > 0 && !p.policy.AllowedTxTypes.Contains(spend.TxType) {
+		return &PolicyViolationError{
+			Err:    ErrTxTypeNotAllowed,
+			TxType: spend.TxType,
+		}
+	}
+	if p.policy.AllowedDestinations.Len() > 0 {
+		for addr := range spend.Destinations {
+			if !p.policy.AllowedDestinations.Contains(addr) {
+				return &PolicyViolationError{
+					Err:         ErrDestinationNotAllowed,
+					TxType:      spend.TxType,
+					Destination: addr,
+				}
+			}
+		}
+	}
+
+	p.prune()
+	for assetID, amount := range spend.Amounts {
+		if limit, ok := p.policy.MaxAmountPerTx[assetID]; ok && amount > limit {
+			return &PolicyViolationError{
+				Err:    ErrTxLimitExceeded,
+				TxType: spend.TxType,
+				Asset:  assetID,
+				Amount: amount,
+				Limit:  limit,
+			}
+		}
+
+		limit, ok := p.policy.MaxAmountPerDay[assetID]
+		if !ok {
+			continue
+		}
+		total := amount
+		for _, record := range p.records {
+			var err error
+			total, err = math.Add64(total, record.Amounts[assetID])
+			if err != nil {
+				total = stdmath.MaxUint64
+				break
+			}
+		}
+		if total > limit {
+			return &PolicyViolationError{
+				Err:    ErrDailyLimitExceeded,
+				TxType: spend.TxType,
+				Asset:  assetID,
+				Amount: total,
+				Limit:  limit,
+			}
+		}
+	}
+	return nil
+}
+
+// prune removes the records that are older than [PolicyWindow].
+func (p *PolicyEnforcer) prune() {
+	cutoff := p.clock.Time().Add(-PolicyWindow)
+	i := 0
+	for i < len(p.records) && !p.records[i].Time.After(cutoff) {
+		i++
+	}
+	p.records = p.records[i:]
+}
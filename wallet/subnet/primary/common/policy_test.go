@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newTestOutput(assetID ids.ID, amount uint64, owner ids.ShortID) *avax.TransferableOutput {
+	return &avax.TransferableOutput{
+		Asset: avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{owner},
+			},
+		},
+	}
+}
+
+func TestNewSpend(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		assetID = ids.GenerateTestID()
+		owned   = ids.GenerateTestShortID()
+		dest0   = ids.GenerateTestShortID()
+		dest1   = ids.GenerateTestShortID()
+	)
+	spend, err := NewSpend(
+		"BaseTx",
+		[]*avax.TransferableOutput{
+			newTestOutput(assetID, 1, owned),
+			newTestOutput(assetID, 2, dest0),
+			newTestOutput(assetID, 3, dest1),
+		},
+		set.Of(owned),
+	)
+	require.NoError(err)
+	require.Equal("BaseTx", spend.TxType)
+	require.Equal(set.Of(dest0, dest1), spend.Destinations)
+	require.Equal(map[ids.ID]uint64{assetID: 5}, spend.Amounts)
+}
+
+func TestPolicyEnforcer(t *testing.T) {
+	var (
+		assetID = ids.GenerateTestID()
+		allowed = ids.GenerateTestShortID()
+		other   = ids.GenerateTestShortID()
+	)
+	newSpend := func(txType string, dest ids.ShortID, amount uint64) *Spend {
+		return &Spend{
+			TxType:       txType,
+			Destinations: set.Of(dest),
+			Amounts:      map[ids.ID]uint64{assetID: amount},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		recorded    []*Spend
+		spend       *Spend
+		expectedErr error
+	}{
+		{
+			name:        "allowed",
+			spend:       newSpend("BaseTx", allowed, 10),
+			expectedErr: nil,
+		},
+		{
+			name:        "tx type not allowed",
+			spend:       newSpend("CreateSubnetTx", allowed, 0),
+			expectedErr: ErrTxTypeNotAllowed,
+		},
+		{
+			name:        "destination not allowed",
+			spend:       newSpend("BaseTx", other, 1),
+			expectedErr: ErrDestinationNotAllowed,
+		},
+		{
+			name:        "per tx limit",
+			spend:       newSpend("BaseTx", allowed, 11),
+			expectedErr: ErrTxLimitExceeded,
+		},
+		{
+			name: "daily limit",
+			recorded: []*Spend{
+				newSpend("BaseTx", allowed, 10),
+				newSpend("BaseTx", allowed, 10),
+			},
+			spend:       newSpend("BaseTx", allowed, 6),
+			expectedErr: ErrDailyLimitExceeded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var audited []AuditRecord
+			enforcer := NewPolicyEnforcer(
+				&Policy{
+					MaxAmountPerTx:      map[ids.ID]uint64{assetID: 10},
+					MaxAmountPerDay:     map[ids.ID]uint64{assetID: 25},
+					AllowedDestinations: set.Of(allowed),
+					AllowedTxTypes:      set.Of("BaseTx"),
+				},
+				func(record AuditRecord) {
+					audited = append(audited, record)
+				},
+			)
+			for _, spend := range test.recorded {
+				require.NoError(enforcer.Record(ids.GenerateTestID(), spend))
+			}
+
+			err := enforcer.Verify(test.spend)
+			require.ErrorIs(err, test.expectedErr)
+			if err != nil {
+				var violation *PolicyViolationError
+				require.True(errors.As(err, &violation))
+				require.Equal(test.spend.TxType, violation.TxType)
+			}
+			require.Len(audited, len(test.recorded))
+		})
+	}
+}
+
+func TestPolicyEnforcerWindow(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		assetID = ids.GenerateTestID()
+		spend   = &Spend{
+			TxType:  "BaseTx",
+			Amounts: map[ids.ID]uint64{assetID: 10},
+		}
+	)
+	enforcer := NewPolicyEnforcer(
+		&Policy{
+			MaxAmountPerDay: map[ids.ID]uint64{assetID: 10},
+		},
+		nil,
+	)
+	now := time.Now()
+	enforcer.clock.Set(now)
+
+	txID := ids.GenerateTestID()
+	require.NoError(enforcer.Record(txID, spend))
+	require.ErrorIs(enforcer.Verify(spend), ErrDailyLimitExceeded)
+
+	auditLog := enforcer.AuditLog()
+	require.Len(auditLog, 1)
+	require.Equal(txID, auditLog[0].TxID)
+
+	enforcer.clock.Set(now.Add(PolicyWindow))
+	require.NoError(enforcer.Verify(spend))
+	require.Empty(enforcer.AuditLog())
+}
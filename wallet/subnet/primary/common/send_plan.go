@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"errors"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+const (
+	// MaxSendTxSize is the size, in bytes, that each BaseTx of a send plan is
+	// kept under. It matches the maximum size of a tx accepted into the
+	// mempool of the X-chain.
+	MaxSendTxSize = 64 * units.KiB
+
+	// sendTxOverhead bounds the size of a BaseTx, excluding the outputs to the
+	// recipients. It leaves room for the inputs, credentials and change
+	// outputs needed to fund the outputs, which aren't known until the BaseTx
+	// is built.
+	sendTxOverhead = 16 * units.KiB
+)
+
+// ErrSendSkipped is reported for the batches of a send plan that weren't
+// issued because an earlier batch failed.
+var ErrSendSkipped = errors.New("skipped because an earlier send failed")
+
+// SendPlan describes the BaseTxs needed to send a set of outputs.
+type SendPlan struct {
+	// Batches are the outputs sent by each BaseTx, in the order the BaseTxs
+	// should be issued.
+	Batches [][]*avax.TransferableOutput
+	// Amounts is the total amount of each asset sent by the BaseTxs,
+	// excluding fees.
+	Amounts map[ids.ID]uint64
+	// Fee is the total amount of AVAX burned by the BaseTxs.
+	Fee uint64
+}
+
+// SendResult is the outcome of issuing one of the BaseTxs of a send plan.
+type SendResult struct {
+	// Outputs are the outputs the BaseTx attempted to send.
+	Outputs []*avax.TransferableOutput
+	// TxID is the ID of the BaseTx. Empty if the tx wasn't issued.
+	TxID ids.ID
+	// Err is the reason the BaseTx failed, if it did.
+	Err error
+}
+
+// PlanSends splits [outputs] into batches that can each be sent by a BaseTx of
+// at most [maxTxSize] bytes. [sizes] are the serialized sizes of [outputs].
+//
+// The outputs are grouped by asset, keeping their relative order, so that
+// each BaseTx consumes and returns change for as few assets as possible.
+func PlanSends(
+	outputs []*avax.TransferableOutput,
+	sizes []int,
+	maxTxSize int,
+) [][]*avax.TransferableOutput {
+	type sizedOutput struct {
+		output *avax.TransferableOutput
+		size   int
+	}
+
+	sized := make([]sizedOutput, len(outputs))
+	for i, output := range outputs {
+		sized[i] = sizedOutput{
+			output: output,
+			size:   sizes[i],
+		}
+	}
+	slices.SortStableFunc(sized, func(a, b sizedOutput) int {
+		aAssetID := a.output.AssetID()
+		bAssetID := b.output.AssetID()
+		switch {
+		case aAssetID.Less(bAssetID):
+			return -1
+		case bAssetID.Less(aAssetID):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var (
+		batches   [][]*avax.TransferableOutput
+		batch     []*avax.TransferableOutput
+		batchSize = sendTxOverhead
+	)
+	for _, output := range sized {
+		// An output that doesn't fit in an empty batch is still given its own
+		// batch, so that the failure is reported when it is sent.
+		if len(batch) > 0 && batchSize+output.size > maxTxSize {
+			batches = append(batches, batch)
+			batch = nil
+			batchSize = sendTxOverhead
+		}
+
+		batch = append(batch, output.output)
+		batchSize += output.size
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
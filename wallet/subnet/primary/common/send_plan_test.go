@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+func TestPlanSends(t *testing.T) {
+	var (
+		assetID0 = ids.ID{0}
+		assetID1 = ids.ID{1}
+		owner    = ids.GenerateTestShortID()
+
+		asset0Out0 = newTestOutput(assetID0, 10, owner)
+		asset0Out1 = newTestOutput(assetID0, 20, owner)
+		asset1Out0 = newTestOutput(assetID1, 30, owner)
+		asset1Out1 = newTestOutput(assetID1, 40, owner)
+
+		outputs = []*avax.TransferableOutput{asset1Out0, asset0Out0, asset1Out1, asset0Out1}
+
+		outputSize = 100
+		sizes      = []int{outputSize, outputSize, outputSize, outputSize}
+	)
+
+	tests := []struct {
+		name            string
+		maxTxSize       int
+		expectedBatches [][]*avax.TransferableOutput
+	}{
+		{
+			name:      "single batch",
+			maxTxSize: MaxSendTxSize,
+			expectedBatches: [][]*avax.TransferableOutput{
+				{asset0Out0, asset0Out1, asset1Out0, asset1Out1},
+			},
+		},
+		{
+			name:      "two outputs per batch",
+			maxTxSize: sendTxOverhead + 2*outputSize,
+			expectedBatches: [][]*avax.TransferableOutput{
+				{asset0Out0, asset0Out1},
+				{asset1Out0, asset1Out1},
+			},
+		},
+		{
+			name:      "three outputs per batch",
+			maxTxSize: sendTxOverhead + 3*outputSize,
+			expectedBatches: [][]*avax.TransferableOutput{
+				{asset0Out0, asset0Out1, asset1Out0},
+				{asset1Out1},
+			},
+		},
+		{
+			name:      "oversized outputs are given their own batch",
+			maxTxSize: 0,
+			expectedBatches: [][]*avax.TransferableOutput{
+				{asset0Out0},
+				{asset0Out1},
+				{asset1Out0},
+				{asset1Out1},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			batches := PlanSends(outputs, sizes, test.maxTxSize)
+			require.Equal(t, test.expectedBatches, batches)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	TxStatusUnknown TxStatus = iota
+	// TxStatusProcessing means that the tx is in the mempool of the node or in
+	// a block that hasn't been decided.
+	TxStatusProcessing
+	TxStatusAccepted
+	// TxStatusRejected means that the tx was decided but didn't take effect,
+	// such as an aborted proposal tx.
+	TxStatusRejected
+	// TxStatusDropped means that the node removed the tx from its mempool
+	// without deciding it.
+	TxStatusDropped
+
+	// maxTxStatusUpdates is the maximum number of updates sent for a tx: one
+	// when it starts processing and one when it is decided.
+	maxTxStatusUpdates = 2
+)
+
+// TxStatus is the status of an issued tx, as reported by the node it was
+// issued to.
+type TxStatus uint8
+
+func (s TxStatus) String() string {
+	switch s {
+	case TxStatusUnknown:
+		return "Unknown"
+	case TxStatusProcessing:
+		return "Processing"
+	case TxStatusAccepted:
+		return "Accepted"
+	case TxStatusRejected:
+		return "Rejected"
+	case TxStatusDropped:
+		return "Dropped"
+	default:
+		return "Invalid status"
+	}
+}
+
+// Decided returns true if the status can no longer change.
+func (s TxStatus) Decided() bool {
+	return s == TxStatusAccepted || s == TxStatusRejected || s == TxStatusDropped
+}
+
+// TxStatusUpdate is a change in the status of an issued tx.
+type TxStatusUpdate struct {
+	TxID   ids.ID
+	Status TxStatus
+	// Reason the tx was dropped. Only set if [Status] is TxStatusDropped.
+	Reason string
+}
+
+// Signature of the function that will be called with each status update of a
+// tx that was issued asynchronously.
+type TxStatusFunc func(TxStatusUpdate)
+
+// TxStatusGetter returns the current status of the tx with ID [txID].
+type TxStatusGetter func(ctx context.Context, txID ids.ID) (TxStatusUpdate, error)
+
+// TxDecidedFunc is called once when the tx is accepted or rejected, before the
+// decision is reported.
+type TxDecidedFunc func(ctx context.Context, status TxStatus) error
+
+// TxHandle tracks the status of a tx that was issued asynchronously.
+type TxHandle struct {
+	txID    ids.ID
+	updates chan TxStatusUpdate
+	done    chan struct{}
+
+	// Set before [done] is closed.
+	status TxStatusUpdate
+	err    error
+}
+
+// NewTxHandle starts tracking the status of the issued tx with ID [txID]. The
+// status is polled with [getStatus] at the poll frequency of [ops] until the
+// tx is decided or the context of [ops] is done. If the tx is accepted or
+// rejected, [onDecided] is called before the decision is reported.
+func NewTxHandle(
+	txID ids.ID,
+	ops *Options,
+	getStatus TxStatusGetter,
+	onDecided TxDecidedFunc,
+) *TxHandle {
+	h := &TxHandle{
+		txID:    txID,
+		updates: make(chan TxStatusUpdate, maxTxStatusUpdates),
+		done:    make(chan struct{}),
+	}
+	go h.track(ops.Context(), ops.PollFrequency(), ops.TxStatusFunc(), getStatus, onDecided)
+	return h
+}
+
+// ID returns the ID of the tx.
+func (h *TxHandle) ID() ids.ID {
+	return h.txID
+}
+
+// Updates returns the status updates of the tx. An update is sent when the tx
+// starts processing and when it is decided, and the channel is closed once no
+// more updates will be sent. Status updates that happen between two polls may
+// be skipped. Updates are buffered, so the channel doesn't need to be read.
+func (h *TxHandle) Updates() <-chan TxStatusUpdate {
+	return h.updates
+}
+
+// Done returns a channel that is closed once the tx is decided or tracking
+// stopped.
+func (h *TxHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait blocks until the tx is decided and returns its final status. An error
+// is returned if tracking stopped before the tx was decided or if the wallet
+// failed to apply the decision.
+func (h *TxHandle) Wait(ctx context.Context) (TxStatusUpdate, error) {
+	select {
+	case <-h.done:
+		return h.status, h.err
+	case <-ctx.Done():
+		return TxStatusUpdate{}, ctx.Err()
+	}
+}
+
+func (h *TxHandle) track(
+	ctx context.Context,
+	pollFrequency time.Duration,
+	onStatus TxStatusFunc,
+	getStatus TxStatusGetter,
+	onDecided TxDecidedFunc,
+) {
+	defer close(h.done)
+	defer close(h.updates)
+
+	ticker := time.NewTicker(pollFrequency)
+	defer ticker.Stop()
+
+	h.status = TxStatusUpdate{
+		TxID:   h.txID,
+		Status: TxStatusUnknown,
+	}
+	for {
+		// Errors are treated like an unchanged status, so that polling
+		// continues until the context is done. Statuses only move forward,
+		// so a tx that is temporarily unknown isn't reported twice.
+		update, err := getStatus(ctx, h.txID)
+		if err == nil && update.Status > h.status.Status {
+			update.TxID = h.txID
+			if update.Status.Decided() && update.Status != TxStatusDropped && onDecided != nil {
+				h.err = onDecided(ctx, update.Status)
+			}
+
+			h.status = update
+			h.updates <- update
+			if onStatus != nil {
+				onStatus(update)
+			}
+			if update.Status.Decided() {
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			h.err = ctx.Err()
+			return
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var errTest = errors.New("non-nil error")
+
+// newTestTxStatusGetter returns a getter that reports [statuses] in order and
+// then keeps reporting the last one.
+func newTestTxStatusGetter(statuses ...TxStatusUpdate) TxStatusGetter {
+	return func(context.Context, ids.ID) (TxStatusUpdate, error) {
+		if len(statuses) == 0 {
+			return TxStatusUpdate{}, errTest
+		}
+		update := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		return update, nil
+	}
+}
+
+func TestTxHandle(t *testing.T) {
+	tests := []struct {
+		name              string
+		statuses          []TxStatusUpdate
+		expectedUpdates   []TxStatusUpdate
+		expectedDecisions []TxStatus
+	}{
+		{
+			name: "accepted",
+			statuses: []TxStatusUpdate{
+				{Status: TxStatusUnknown},
+				{Status: TxStatusProcessing},
+				{Status: TxStatusProcessing},
+				{Status: TxStatusUnknown},
+				{Status: TxStatusAccepted},
+			},
+			expectedUpdates: []TxStatusUpdate{
+				{Status: TxStatusProcessing},
+				{Status: TxStatusAccepted},
+			},
+			expectedDecisions: []TxStatus{TxStatusAccepted},
+		},
+		{
+			name: "rejected",
+			statuses: []TxStatusUpdate{
+				{Status: TxStatusRejected},
+			},
+			expectedUpdates: []TxStatusUpdate{
+				{Status: TxStatusRejected},
+			},
+			expectedDecisions: []TxStatus{TxStatusRejected},
+		},
+		{
+			name: "dropped",
+			statuses: []TxStatusUpdate{
+				{Status: TxStatusProcessing},
+				{Status: TxStatusDropped, Reason: "insufficient funds"},
+			},
+			expectedUpdates: []TxStatusUpdate{
+				{Status: TxStatusProcessing},
+				{Status: TxStatusDropped, Reason: "insufficient funds"},
+			},
+			expectedDecisions: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			txID := ids.GenerateTestID()
+			for i := range test.expectedUpdates {
+				test.expectedUpdates[i].TxID = txID
+			}
+
+			var (
+				decisions       []TxStatus
+				callbackUpdates []TxStatusUpdate
+			)
+			ops := NewOptions([]Option{
+				WithPollFrequency(time.Millisecond),
+				WithTxStatusFunc(func(update TxStatusUpdate) {
+					callbackUpdates = append(callbackUpdates, update)
+				}),
+			})
+			h := NewTxHandle(
+				txID,
+				ops,
+				newTestTxStatusGetter(test.statuses...),
+				func(_ context.Context, status TxStatus) error {
+					decisions = append(decisions, status)
+					return nil
+				},
+			)
+			require.Equal(txID, h.ID())
+
+			updates := []TxStatusUpdate{}
+			for update := range h.Updates() {
+				updates = append(updates, update)
+			}
+			require.Equal(test.expectedUpdates, updates)
+
+			finalStatus, err := h.Wait(context.Background())
+			require.NoError(err)
+			require.Equal(test.expectedUpdates[len(test.expectedUpdates)-1], finalStatus)
+			require.Equal(test.expectedDecisions, decisions)
+			require.Equal(test.expectedUpdates, callbackUpdates)
+		})
+	}
+}
+
+func TestTxHandleDecisionFailed(t *testing.T) {
+	ops := NewOptions([]Option{
+		WithPollFrequency(time.Millisecond),
+	})
+	h := NewTxHandle(
+		ids.GenerateTestID(),
+		ops,
+		newTestTxStatusGetter(TxStatusUpdate{Status: TxStatusAccepted}),
+		func(context.Context, TxStatus) error {
+			return errTest
+		},
+	)
+
+	finalStatus, err := h.Wait(context.Background())
+	require.ErrorIs(t, err, errTest)
+	require.Equal(t, TxStatusAccepted, finalStatus.Status)
+}
+
+func TestTxHandleContextCanceled(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ops := NewOptions([]Option{
+		WithContext(ctx),
+		WithPollFrequency(time.Millisecond),
+	})
+	h := NewTxHandle(
+		ids.GenerateTestID(),
+		ops,
+		newTestTxStatusGetter(TxStatusUpdate{Status: TxStatusProcessing}),
+		nil,
+	)
+
+	update := <-h.Updates()
+	require.Equal(TxStatusProcessing, update.Status)
+
+	cancel()
+	<-h.Done()
+
+	finalStatus, err := h.Wait(context.Background())
+	require.ErrorIs(err, context.Canceled)
+	require.False(finalStatus.Status.Decided())
+}
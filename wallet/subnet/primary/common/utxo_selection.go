@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"encoding/binary"
+	"sort"
+
+	stdmath "math"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// UTXOSelection specifies which UTXOs are spent first when a transaction needs
+// to burn funds.
+//
+// Every strategy is deterministic for a given ordering of the UTXOs provided
+// by the wallet's backend.
+type UTXOSelection byte
+
+const (
+	// OldestFirst spends UTXOs in the order they are provided by the wallet's
+	// backend, which is the order they were added to the wallet. Spending old
+	// UTXOs first avoids the accumulation of small UTXOs.
+	OldestFirst UTXOSelection = iota
+	// LargestFirst spends the UTXOs with the largest amounts first, which
+	// minimizes the number of inputs.
+	LargestFirst
+	// MinimizeChange spends the smallest UTXO that covers the remaining amount
+	// if one exists. Otherwise, the largest UTXO is spent and the process is
+	// repeated. This minimizes the amount returned as change.
+	MinimizeChange
+	// Privacy spends UTXOs that are owned by a single set of owners if they
+	// are able to cover every amount, to avoid linking the owners on-chain.
+	// If no set of owners is able to, UTXOs are spent oldest first.
+	Privacy
+)
+
+func (s UTXOSelection) String() string {
+	switch s {
+	case OldestFirst:
+		return "oldestFirst"
+	case LargestFirst:
+		return "largestFirst"
+	case MinimizeChange:
+		return "minimizeChange"
+	case Privacy:
+		return "privacy"
+	default:
+		return "unknown"
+	}
+}
+
+// spendableUTXO is a UTXO that can be spent by the wallet.
+type spendableUTXO struct {
+	utxo   *avax.UTXO
+	amount uint64
+}
+
+// SortUTXOs returns [utxos] in the order they should be spent to burn
+// [amountsToBurn]. UTXOs that can't be spent by [addrs] at [minIssuanceTime]
+// are placed last.
+func SortUTXOs(
+	selection UTXOSelection,
+	utxos []*avax.UTXO,
+	amountsToBurn map[ids.ID]uint64,
+	addrs set.Set[ids.ShortID],
+	minIssuanceTime uint64,
+) []*avax.UTXO {
+	var (
+		spendable   = make([]spendableUTXO, 0, len(utxos))
+		unspendable = make([]*avax.UTXO, 0, len(utxos))
+	)
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			unspendable = append(unspendable, utxo)
+			continue
+		}
+		if _, ok := MatchOwners(&out.OutputOwners, addrs, minIssuanceTime); !ok {
+			unspendable = append(unspendable, utxo)
+			continue
+		}
+		spendable = append(spendable, spendableUTXO{
+			utxo:   utxo,
+			amount: out.Amt,
+		})
+	}
+
+	switch selection {
+	case LargestFirst:
+		sortLargestFirst(spendable)
+	case MinimizeChange:
+		spendable = sortMinimizeChange(spendable, amountsToBurn)
+	case Privacy:
+		spendable = sortPrivacy(spendable, amountsToBurn)
+	}
+
+	sorted := make([]*avax.UTXO, 0, len(utxos))
+	for _, utxo := range spendable {
+		sorted = append(sorted, utxo.utxo)
+	}
+	return append(sorted, unspendable...)
+}
+
+func sortLargestFirst(utxos []spendableUTXO) {
+	sort.SliceStable(utxos, func(i, j int) bool {
+		return utxos[i].amount > utxos[j].amount
+	})
+}
+
+func sortMinimizeChange(utxos []spendableUTXO, amountsToBurn map[ids.ID]uint64) []spendableUTXO {
+	var (
+		assetIDs = make([]ids.ID, 0, len(amountsToBurn))
+		byAsset  = make(map[ids.ID][]spendableUTXO, len(amountsToBurn))
+		sorted   = make([]spendableUTXO, 0, len(utxos))
+		unneeded = make([]spendableUTXO, 0, len(utxos))
+	)
+	for assetID, amount := range amountsToBurn {
+		if amount > 0 {
+			assetIDs = append(assetIDs, assetID)
+		}
+	}
+	sort.Slice(assetIDs, func(i, j int) bool {
+		return assetIDs[i].Less(assetIDs[j])
+	})
+	for _, utxo := range utxos {
+		assetID := utxo.utxo.AssetID()
+		if amountsToBurn[assetID] == 0 {
+			unneeded = append(unneeded, utxo)
+			continue
+		}
+		byAsset[assetID] = append(byAsset[assetID], utxo)
+	}
+
+	for _, assetID := range assetIDs {
+		// Sort the candidates from smallest to largest.
+		candidates := byAsset[assetID]
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].amount < candidates[j].amount
+		})
+
+		remaining := amountsToBurn[assetID]
+		for len(candidates) > 0 && remaining > 0 {
+			i := sort.Search(len(candidates), func(i int) bool {
+				return candidates[i].amount >= remaining
+			})
+			if i < len(candidates) {
+				// The smallest UTXO that covers the remaining amount is spent.
+				sorted = append(sorted, candidates[i])
+				candidates = append(candidates[:i], candidates[i+1:]...)
+				break
+			}
+
+			// No UTXO covers the remaining amount, so the largest UTXO is
+			// spent.
+			largest := candidates[len(candidates)-1]
+			sorted = append(sorted, largest)
+			candidates = candidates[:len(candidates)-1]
+			remaining -= largest.amount
+		}
+		unneeded = append(unneeded, candidates...)
+	}
+	return append(sorted, unneeded...)
+}
+
+func sortPrivacy(utxos []spendableUTXO, amountsToBurn map[ids.ID]uint64) []spendableUTXO {
+	// Group the UTXOs by their owners, in the order the owners are first
+	// seen.
+	var (
+		ownerKeys     []string
+		ownerToUTXOs  = make(map[string][]spendableUTXO)
+		ownerToTotals = make(map[string]map[ids.ID]uint64)
+	)
+	for _, utxo := range utxos {
+		out := utxo.utxo.Out.(*secp256k1fx.TransferOutput)
+		key := ownersKey(&out.OutputOwners)
+		if _, ok := ownerToUTXOs[key]; !ok {
+			ownerKeys = append(ownerKeys, key)
+			ownerToTotals[key] = make(map[ids.ID]uint64)
+		}
+		ownerToUTXOs[key] = append(ownerToUTXOs[key], utxo)
+
+		// If the total overflows, the owners are able to cover any amount.
+		assetID := utxo.utxo.AssetID()
+		total, err := math.Add64(ownerToTotals[key][assetID], utxo.amount)
+		if err != nil {
+			total = stdmath.MaxUint64
+		}
+		ownerToTotals[key][assetID] = total
+	}
+
+	for _, key := range ownerKeys {
+		if !covers(ownerToTotals[key], amountsToBurn) {
+			continue
+		}
+
+		sorted := make([]spendableUTXO, 0, len(utxos))
+		sorted = append(sorted, ownerToUTXOs[key]...)
+		for _, otherKey := range ownerKeys {
+			if otherKey != key {
+				sorted = append(sorted, ownerToUTXOs[otherKey]...)
+			}
+		}
+		return sorted
+	}
+	return utxos
+}
+
+// ownersKey returns a key that uniquely identifies [owners].
+func ownersKey(owners *secp256k1fx.OutputOwners) string {
+	key := make([]byte, 0, wrappers.IntLen+ids.ShortIDLen*len(owners.Addrs))
+	key = binary.BigEndian.AppendUint32(key, owners.Threshold)
+	for _, addr := range owners.Addrs {
+		key = append(key, addr[:]...)
+	}
+	return string(key)
+}
+
+func covers(totals map[ids.ID]uint64, amountsToBurn map[ids.ID]uint64) bool {
+	for assetID, amount := range amountsToBurn {
+		if totals[assetID] < amount {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newTestUTXO(assetID ids.ID, amount uint64, owner ids.ShortID) *avax.UTXO {
+	return &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{owner},
+			},
+		},
+	}
+}
+
+func TestSortUTXOs(t *testing.T) {
+	var (
+		assetID = ids.GenerateTestID()
+		addr0   = ids.GenerateTestShortID()
+		addr1   = ids.GenerateTestShortID()
+		other   = ids.GenerateTestShortID()
+
+		utxo0 = newTestUTXO(assetID, 5, addr0)
+		utxo1 = newTestUTXO(assetID, 20, addr1)
+		utxo2 = newTestUTXO(assetID, 100, other)
+		utxo3 = newTestUTXO(assetID, 30, addr0)
+		utxo4 = newTestUTXO(assetID, 10, addr1)
+		utxo5 = newTestUTXO(assetID, 12, addr1)
+
+		utxos = []*avax.UTXO{utxo0, utxo1, utxo2, utxo3, utxo4, utxo5}
+		addrs = set.Of(addr0, addr1)
+	)
+
+	tests := []struct {
+		selection     UTXOSelection
+		amountToBurn  uint64
+		expectedUTXOs []*avax.UTXO
+	}{
+		{
+			selection:     OldestFirst,
+			amountToBurn:  25,
+			expectedUTXOs: []*avax.UTXO{utxo0, utxo1, utxo3, utxo4, utxo5, utxo2},
+		},
+		{
+			selection:     LargestFirst,
+			amountToBurn:  25,
+			expectedUTXOs: []*avax.UTXO{utxo3, utxo1, utxo5, utxo4, utxo0, utxo2},
+		},
+		{
+			selection:     MinimizeChange,
+			amountToBurn:  11,
+			expectedUTXOs: []*avax.UTXO{utxo5, utxo0, utxo4, utxo1, utxo3, utxo2},
+		},
+		{
+			selection:     MinimizeChange,
+			amountToBurn:  40,
+			expectedUTXOs: []*avax.UTXO{utxo3, utxo4, utxo0, utxo5, utxo1, utxo2},
+		},
+		{
+			selection:     Privacy,
+			amountToBurn:  40,
+			expectedUTXOs: []*avax.UTXO{utxo1, utxo4, utxo5, utxo0, utxo3, utxo2},
+		},
+		{
+			selection:     Privacy,
+			amountToBurn:  80,
+			expectedUTXOs: []*avax.UTXO{utxo0, utxo1, utxo3, utxo4, utxo5, utxo2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.selection.String(), func(t *testing.T) {
+			sorted := SortUTXOs(
+				test.selection,
+				utxos,
+				map[ids.ID]uint64{
+					assetID: test.amountToBurn,
+				},
+				addrs,
+				0,
+			)
+			require.Equal(t, test.expectedUTXOs, sorted)
+		})
+	}
+}
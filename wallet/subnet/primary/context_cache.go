@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/wallet/chain/c"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+)
+
+// DefaultContextTTL is the default amount of time cached chain contexts are
+// used before being refreshed.
+const DefaultContextTTL = time.Hour
+
+// Contexts are the chain contexts of the primary network.
+type Contexts struct {
+	P p.Context
+	X x.Context
+	C c.Context
+}
+
+// ContextCache lazily fetches the chain contexts of the primary network and
+// refreshes them once they are older than the configured TTL.
+//
+// ContextCache is safe to share between wallets.
+type ContextCache struct {
+	endpoints *Endpoints
+	ttl       time.Duration
+	fetch     func(ctx context.Context, uri string) (*Contexts, error)
+
+	lock        sync.Mutex
+	contexts    *Contexts
+	lastFetched time.Time
+}
+
+func NewContextCache(endpoints *Endpoints, ttl time.Duration) *ContextCache {
+	return &ContextCache{
+		endpoints: endpoints,
+		ttl:       ttl,
+		fetch:     FetchContexts,
+	}
+}
+
+// Get returns the cached contexts, fetching them if they are missing or
+// expired. If the contexts can't be refreshed, the expired contexts are
+// returned so that issuance can continue while the nodes are unavailable.
+func (cc *ContextCache) Get(ctx context.Context) (*Contexts, error) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	if cc.contexts != nil && time.Since(cc.lastFetched) < cc.ttl {
+		return cc.contexts, nil
+	}
+
+	var contexts *Contexts
+	err := cc.endpoints.Do(ctx, func(uri string) error {
+		var err error
+		contexts, err = cc.fetch(ctx, uri)
+		return err
+	})
+	if err != nil {
+		if cc.contexts != nil {
+			return cc.contexts, nil
+		}
+		return nil, err
+	}
+
+	cc.contexts = contexts
+	cc.lastFetched = time.Now()
+	return contexts, nil
+}
+
+// Invalidate forces the contexts to be refreshed on the next call to Get.
+func (cc *ContextCache) Invalidate() {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	cc.lastFetched = time.Time{}
+}
+
+// FetchContexts fetches the chain contexts of the primary network from the
+// node at [uri].
+func FetchContexts(ctx context.Context, uri string) (*Contexts, error) {
+	infoClient := info.NewClient(uri)
+	xClient := avm.NewClient(uri, "X")
+
+	pCTX, err := p.NewContextFromClients(ctx, infoClient, xClient)
+	if err != nil {
+		return nil, err
+	}
+
+	xCTX, err := x.NewContextFromClients(ctx, infoClient, xClient)
+	if err != nil {
+		return nil, err
+	}
+
+	cCTX, err := c.NewContextFromClients(ctx, infoClient, xClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Contexts{
+		P: pCTX,
+		X: xCTX,
+		C: cCTX,
+	}, nil
+}
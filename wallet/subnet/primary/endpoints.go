@@ -0,0 +1,221 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+)
+
+// DefaultHealthCheckFrequency is the default amount of time an endpoint is
+// assumed to remain healthy after a successful health check.
+const DefaultHealthCheckFrequency = 30 * time.Second
+
+var (
+	_ p.Client = (*failoverPClient)(nil)
+	_ x.Client = (*failoverXClient)(nil)
+
+	errNoURIs         = errors.New("no URIs provided")
+	errNoHealthyURI   = errors.New("no healthy URI")
+	errNodeNotHealthy = errors.New("node is not healthy")
+)
+
+// Endpoints tracks a set of node URIs and fails over to the next healthy URI
+// when the current one becomes unreachable.
+type Endpoints struct {
+	// checkFrequency is the amount of time the current URI is assumed to be
+	// healthy after a successful health check.
+	checkFrequency time.Duration
+	// checkHealth returns nil if the node at the provided URI is healthy.
+	checkHealth func(ctx context.Context, uri string) error
+
+	lock    sync.Mutex
+	uris    []string
+	current int
+	// lastHealthy is the last time the current URI was known to be healthy.
+	lastHealthy time.Time
+}
+
+// NewEndpoints returns a set of endpoints that starts with the first URI and
+// fails over to the others in order.
+func NewEndpoints(uris []string, checkFrequency time.Duration) (*Endpoints, error) {
+	if len(uris) == 0 {
+		return nil, errNoURIs
+	}
+	return &Endpoints{
+		checkFrequency: checkFrequency,
+		checkHealth:    checkReadiness,
+		uris:           uris,
+	}, nil
+}
+
+// URI returns a URI that was recently reported as healthy.
+func (e *Endpoints) URI(ctx context.Context) (string, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if time.Since(e.lastHealthy) < e.checkFrequency {
+		return e.uris[e.current], nil
+	}
+
+	var errs []error
+	for i := 0; i < len(e.uris); i++ {
+		index := (e.current + i) % len(e.uris)
+		uri := e.uris[index]
+		if err := e.checkHealth(ctx, uri); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", uri, err))
+			continue
+		}
+
+		e.current = index
+		e.lastHealthy = time.Now()
+		return uri, nil
+	}
+	return "", fmt.Errorf("%w: %w", errNoHealthyURI, errors.Join(errs...))
+}
+
+// MarkUnhealthy reports that [uri] couldn't be reached. If [uri] is the
+// current URI, the next call to URI will fail over to the next healthy URI.
+func (e *Endpoints) MarkUnhealthy(uri string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.uris[e.current] != uri {
+		return
+	}
+	e.current = (e.current + 1) % len(e.uris)
+	e.lastHealthy = time.Time{}
+}
+
+// Do calls [f] with a healthy URI. If [f] fails because the node couldn't be
+// reached, [f] is retried against the next healthy URI. Any other error is
+// returned immediately.
+func (e *Endpoints) Do(ctx context.Context, f func(uri string) error) error {
+	var err error
+	for i := 0; i < len(e.uris); i++ {
+		var uri string
+		uri, err = e.URI(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = f(uri)
+		if !isUnreachable(ctx, err) {
+			return err
+		}
+		e.MarkUnhealthy(uri)
+	}
+	return err
+}
+
+// isUnreachable returns true if [err] was caused by failing to communicate
+// with the node, rather than by the node rejecting the request.
+func isUnreachable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+func checkReadiness(ctx context.Context, uri string) error {
+	reply, err := health.NewClient(uri).Readiness(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if !reply.Healthy {
+		return errNodeNotHealthy
+	}
+	return nil
+}
+
+type failoverPClient struct {
+	endpoints *Endpoints
+}
+
+func (c *failoverPClient) IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error) {
+	var txID ids.ID
+	err := c.endpoints.Do(ctx, func(uri string) error {
+		var err error
+		txID, err = platformvm.NewClient(uri).IssueTx(ctx, tx, options...)
+		return err
+	})
+	return txID, err
+}
+
+func (c *failoverPClient) AwaitTxDecided(
+	ctx context.Context,
+	txID ids.ID,
+	freq time.Duration,
+	options ...rpc.Option,
+) (*platformvm.GetTxStatusResponse, error) {
+	var resp *platformvm.GetTxStatusResponse
+	err := c.endpoints.Do(ctx, func(uri string) error {
+		var err error
+		resp, err = platformvm.NewClient(uri).AwaitTxDecided(ctx, txID, freq, options...)
+		return err
+	})
+	return resp, err
+}
+
+func (c *failoverPClient) GetTxStatus(
+	ctx context.Context,
+	txID ids.ID,
+	options ...rpc.Option,
+) (*platformvm.GetTxStatusResponse, error) {
+	var resp *platformvm.GetTxStatusResponse
+	err := c.endpoints.Do(ctx, func(uri string) error {
+		var err error
+		resp, err = platformvm.NewClient(uri).GetTxStatus(ctx, txID, options...)
+		return err
+	})
+	return resp, err
+}
+
+type failoverXClient struct {
+	endpoints *Endpoints
+}
+
+func (c *failoverXClient) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error) {
+	var txID ids.ID
+	err := c.endpoints.Do(ctx, func(uri string) error {
+		var err error
+		txID, err = avm.NewClient(uri, "X").IssueTx(ctx, txBytes, options...)
+		return err
+	})
+	return txID, err
+}
+
+func (c *failoverXClient) ConfirmTx(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (choices.Status, error) {
+	var status choices.Status
+	err := c.endpoints.Do(ctx, func(uri string) error {
+		var err error
+		status, err = avm.NewClient(uri, "X").ConfirmTx(ctx, txID, freq, options...)
+		return err
+	})
+	return status, err
+}
+
+func (c *failoverXClient) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error) {
+	var status choices.Status
+	err := c.endpoints.Do(ctx, func(uri string) error {
+		var err error
+		status, err = avm.NewClient(uri, "X").GetTxStatus(ctx, txID, options...)
+		return err
+	})
+	return status, err
+}
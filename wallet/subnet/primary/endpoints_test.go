@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var errTest = errors.New("non-nil error")
+
+func newTestEndpoints(t *testing.T, uris []string, unhealthy set.Set[string]) *Endpoints {
+	endpoints, err := NewEndpoints(uris, time.Hour)
+	require.NoError(t, err)
+	endpoints.checkHealth = func(_ context.Context, uri string) error {
+		if unhealthy.Contains(uri) {
+			return errNodeNotHealthy
+		}
+		return nil
+	}
+	return endpoints
+}
+
+func TestNewEndpointsNoURIs(t *testing.T) {
+	_, err := NewEndpoints(nil, time.Hour)
+	require.ErrorIs(t, err, errNoURIs)
+}
+
+func TestEndpointsURI(t *testing.T) {
+	require := require.New(t)
+
+	unhealthy := set.Of("a")
+	endpoints := newTestEndpoints(t, []string{"a", "b", "c"}, unhealthy)
+
+	uri, err := endpoints.URI(context.Background())
+	require.NoError(err)
+	require.Equal("b", uri)
+
+	// The healthy URI is cached until it is marked as unhealthy.
+	unhealthy.Add("b")
+	uri, err = endpoints.URI(context.Background())
+	require.NoError(err)
+	require.Equal("b", uri)
+
+	// Marking a URI other than the current one is a noop.
+	endpoints.MarkUnhealthy("a")
+	uri, err = endpoints.URI(context.Background())
+	require.NoError(err)
+	require.Equal("b", uri)
+
+	endpoints.MarkUnhealthy("b")
+	uri, err = endpoints.URI(context.Background())
+	require.NoError(err)
+	require.Equal("c", uri)
+
+	unhealthy.Add("c")
+	endpoints.MarkUnhealthy("c")
+	_, err = endpoints.URI(context.Background())
+	require.ErrorIs(err, errNoHealthyURI)
+}
+
+func TestEndpointsDo(t *testing.T) {
+	require := require.New(t)
+
+	endpoints := newTestEndpoints(t, []string{"a", "b"}, nil)
+
+	// Unreachable nodes are failed over.
+	var called []string
+	err := endpoints.Do(context.Background(), func(uri string) error {
+		called = append(called, uri)
+		if uri == "a" {
+			return &url.Error{Op: "Post", URL: uri, Err: errTest}
+		}
+		return nil
+	})
+	require.NoError(err)
+	require.Equal([]string{"a", "b"}, called)
+
+	// Errors reported by a reachable node are returned immediately.
+	called = nil
+	err = endpoints.Do(context.Background(), func(uri string) error {
+		called = append(called, uri)
+		return errTest
+	})
+	require.ErrorIs(err, errTest)
+	require.Equal([]string{"b"}, called)
+}
+
+func TestContextCache(t *testing.T) {
+	require := require.New(t)
+
+	endpoints := newTestEndpoints(t, []string{"a"}, nil)
+	cache := NewContextCache(endpoints, time.Hour)
+
+	var (
+		numFetches int
+		fetchErr   error
+	)
+	cache.fetch = func(context.Context, string) (*Contexts, error) {
+		numFetches++
+		return &Contexts{}, fetchErr
+	}
+
+	contexts, err := cache.Get(context.Background())
+	require.NoError(err)
+	require.NotNil(contexts)
+	require.Equal(1, numFetches)
+
+	// Contexts are served from the cache until they expire.
+	cachedContexts, err := cache.Get(context.Background())
+	require.NoError(err)
+	require.Same(contexts, cachedContexts)
+	require.Equal(1, numFetches)
+
+	// Expired contexts are still served if they can't be refreshed.
+	cache.Invalidate()
+	fetchErr = errTest
+	cachedContexts, err = cache.Get(context.Background())
+	require.NoError(err)
+	require.Same(contexts, cachedContexts)
+	require.Equal(2, numFetches)
+
+	fetchErr = nil
+	refreshedContexts, err := cache.Get(context.Background())
+	require.NoError(err)
+	require.NotSame(contexts, refreshedContexts)
+	require.Equal(3, numFetches)
+}
@@ -0,0 +1,291 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/tyler-smith/go-bip39"
+
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	// HardenedKeyStart is the index of the first hardened child key.
+	HardenedKeyStart uint32 = 1 << 31
+
+	// DefaultGapLimit is the number of consecutive unused addresses after
+	// which standard wallets stop looking for used addresses.
+	DefaultGapLimit = 20
+
+	// Purpose and coin type of the BIP44 derivation path used by Avalanche
+	// wallets.
+	bip44Purpose           = 44
+	avalancheBIP44CoinType = 9000
+
+	// Index of the chain of external (receiving) addresses of an account.
+	externalChain = 0
+)
+
+var (
+	errInvalidMnemonic = errors.New("invalid mnemonic")
+	errInvalidKey      = errors.New("derived key is invalid")
+	errZeroGapLimit    = errors.New("gap limit must be positive")
+
+	masterKeyHMACKey = []byte("Bitcoin seed")
+)
+
+// HDKeychain derives the keys of a BIP44 account from a BIP39 mnemonic. The
+// keys are derived along the path m/44'/9000'/account'/0/index, which is the
+// path used by standard Avalanche wallets.
+type HDKeychain struct {
+	account extendedKey
+}
+
+// NewHDKeychain returns the keychain of [account] of the wallet described by
+// [mnemonic]. [passphrase] is the optional BIP39 passphrase.
+func NewHDKeychain(mnemonic, passphrase string, account uint32) (*HDKeychain, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errInvalidMnemonic
+	}
+	seed := bip39.NewSeed(mnemonic, passphrase)
+	return newHDKeychainFromSeed(seed, account)
+}
+
+func newHDKeychainFromSeed(seed []byte, account uint32) (*HDKeychain, error) {
+	accountKey, err := deriveKey(seed, []uint32{
+		HardenedKeyStart + bip44Purpose,
+		HardenedKeyStart + avalancheBIP44CoinType,
+		HardenedKeyStart + account,
+		externalChain,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &HDKeychain{account: accountKey}, nil
+}
+
+// Key returns the key at [index] of the account's external chain.
+func (k *HDKeychain) Key(index uint32) (*secp256k1.PrivateKey, error) {
+	child, err := k.account.child(index)
+	if err != nil {
+		return nil, err
+	}
+	return child.privateKey()
+}
+
+// Keys returns the [count] keys of the account's external chain starting at
+// [start].
+func (k *HDKeychain) Keys(start, count uint32) ([]*secp256k1.PrivateKey, error) {
+	keys := make([]*secp256k1.PrivateKey, count)
+	for i := range keys {
+		key, err := k.Key(start + uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// ScanHDKeychain returns a keychain containing the keys of [hd] up to and
+// including the last key whose address owns a UTXO on the P-chain, X-chain, or
+// in the atomic memory of the C-chain. The search stops once [gapLimit]
+// consecutive addresses own no UTXOs.
+//
+// The returned keychain always contains at least the first key of [hd].
+func ScanHDKeychain(
+	ctx context.Context,
+	uri string,
+	contexts *Contexts,
+	hd *HDKeychain,
+	gapLimit uint32,
+) (*secp256k1fx.Keychain, error) {
+	return scanHDKeychain(ctx, hd, gapLimit, func(ctx context.Context, addrs set.Set[ids.ShortID]) (set.Set[ids.ShortID], error) {
+		state, err := FetchStateWithContexts(ctx, uri, contexts, addrs)
+		if err != nil {
+			return nil, err
+		}
+		chainIDs := []ids.ID{
+			constants.PlatformChainID,
+			contexts.X.BlockchainID(),
+			contexts.C.BlockchainID(),
+		}
+		return usedAddresses(ctx, state.UTXOs, chainIDs, addrs)
+	})
+}
+
+// scanHDKeychain derives [gapLimit] keys of [hd] at a time, and uses [used] to
+// find which of their addresses have been used.
+func scanHDKeychain(
+	ctx context.Context,
+	hd *HDKeychain,
+	gapLimit uint32,
+	used func(context.Context, set.Set[ids.ShortID]) (set.Set[ids.ShortID], error),
+) (*secp256k1fx.Keychain, error) {
+	if gapLimit == 0 {
+		return nil, errZeroGapLimit
+	}
+
+	var (
+		keys []*secp256k1.PrivateKey
+		// Always include the first key so that the wallet has an address to
+		// receive funds with.
+		numKeys   = 1
+		numUnused uint32
+	)
+	for numUnused < gapLimit {
+		batch, err := hd.Keys(uint32(len(keys)), gapLimit)
+		if err != nil {
+			return nil, err
+		}
+		addrs := set.NewSet[ids.ShortID](len(batch))
+		for _, key := range batch {
+			addrs.Add(key.Address())
+		}
+		usedAddrs, err := used(ctx, addrs)
+		if err != nil {
+			return nil, err
+		}
+
+		// Addresses after the gap aren't considered, even if they were used,
+		// to match the behavior of standard wallets.
+		for _, key := range batch {
+			if numUnused == gapLimit {
+				break
+			}
+			if usedAddrs.Contains(key.Address()) {
+				numKeys = len(keys) + 1
+				numUnused = 0
+			} else {
+				numUnused++
+			}
+			keys = append(keys, key)
+		}
+	}
+	return secp256k1fx.NewKeychain(keys[:numKeys]...), nil
+}
+
+// usedAddresses returns the addresses in [addrs] that own any of the UTXOs
+// sent between [chainIDs].
+func usedAddresses(
+	ctx context.Context,
+	utxos UTXOs,
+	chainIDs []ids.ID,
+	addrs set.Set[ids.ShortID],
+) (set.Set[ids.ShortID], error) {
+	used := set.Set[ids.ShortID]{}
+	for _, sourceChainID := range chainIDs {
+		for _, destinationChainID := range chainIDs {
+			chainUTXOs, err := utxos.UTXOs(ctx, sourceChainID, destinationChainID)
+			if err != nil {
+				return nil, err
+			}
+			for _, utxo := range chainUTXOs {
+				out, ok := utxo.Out.(avax.Addressable)
+				if !ok {
+					continue
+				}
+				for _, addrBytes := range out.Addresses() {
+					addr, err := ids.ToShortID(addrBytes)
+					if err != nil {
+						return nil, err
+					}
+					if addrs.Contains(addr) {
+						used.Add(addr)
+					}
+				}
+			}
+		}
+	}
+	return used, nil
+}
+
+// extendedKey is a BIP32 extended private key.
+type extendedKey struct {
+	key       []byte
+	chainCode []byte
+}
+
+// deriveKey derives the extended private key at [path] from [seed], as
+// specified by BIP32.
+func deriveKey(seed []byte, path []uint32) (extendedKey, error) {
+	mac := hmac.New(sha512.New, masterKeyHMACKey)
+	_, _ = mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := extendedKey{
+		key:       sum[:32],
+		chainCode: sum[32:],
+	}
+	if err := verifyKey(key.key); err != nil {
+		return extendedKey{}, err
+	}
+	for _, index := range path {
+		var err error
+		key, err = key.child(index)
+		if err != nil {
+			return extendedKey{}, err
+		}
+	}
+	return key, nil
+}
+
+// child returns the child private key at [index].
+func (k extendedKey) child(index uint32) (extendedKey, error) {
+	data := make([]byte, 0, secp256k1.PublicKeyLen+4)
+	if index >= HardenedKeyStart {
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		data = append(data, secp256k1ecdsa.PrivKeyFromBytes(k.key).PubKey().SerializeCompressed()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	_, _ = mac.Write(data)
+	sum := mac.Sum(nil)
+
+	var tweak secp256k1ecdsa.ModNScalar
+	if overflow := tweak.SetByteSlice(sum[:32]); overflow {
+		return extendedKey{}, fmt.Errorf("%w: index %d", errInvalidKey, index)
+	}
+	var parent secp256k1ecdsa.ModNScalar
+	parent.SetByteSlice(k.key)
+	tweak.Add(&parent)
+	if tweak.IsZero() {
+		return extendedKey{}, fmt.Errorf("%w: index %d", errInvalidKey, index)
+	}
+
+	childKey := tweak.Bytes()
+	return extendedKey{
+		key:       childKey[:],
+		chainCode: sum[32:],
+	}, nil
+}
+
+func (k extendedKey) privateKey() (*secp256k1.PrivateKey, error) {
+	return new(secp256k1.Factory).ToPrivateKey(k.key)
+}
+
+// verifyKey returns an error if [key] isn't a valid secp256k1 private key.
+func verifyKey(key []byte) error {
+	var scalar secp256k1ecdsa.ModNScalar
+	if overflow := scalar.SetByteSlice(key); overflow || scalar.IsZero() {
+		return errInvalidKey
+	}
+	return nil
+}
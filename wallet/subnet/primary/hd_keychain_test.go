@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// Test vector 1 of BIP32.
+func TestDeriveKey(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	tests := []struct {
+		path        []uint32
+		expectedKey string
+	}{
+		{
+			path:        nil,
+			expectedKey: "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+		},
+		{
+			path:        []uint32{HardenedKeyStart},
+			expectedKey: "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		},
+		{
+			path:        []uint32{HardenedKeyStart, 1},
+			expectedKey: "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		},
+		{
+			path:        []uint32{HardenedKeyStart, 1, HardenedKeyStart + 2},
+			expectedKey: "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+		},
+		{
+			path:        []uint32{HardenedKeyStart, 1, HardenedKeyStart + 2, 2},
+			expectedKey: "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4",
+		},
+		{
+			path:        []uint32{HardenedKeyStart, 1, HardenedKeyStart + 2, 2, 1000000000},
+			expectedKey: "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8",
+		},
+	}
+	for _, test := range tests {
+		key, err := deriveKey(seed, test.path)
+		require.NoError(t, err)
+		require.Equal(t, test.expectedKey, hex.EncodeToString(key.key))
+	}
+}
+
+func TestNewHDKeychain(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewHDKeychain("abandon abandon abandon", "", 0)
+	require.ErrorIs(err, errInvalidMnemonic)
+
+	hd, err := NewHDKeychain(testMnemonic, "", 0)
+	require.NoError(err)
+	keys, err := hd.Keys(0, 2)
+	require.NoError(err)
+	require.Len(keys, 2)
+	require.NotEqual(keys[0].Address(), keys[1].Address())
+
+	key, err := hd.Key(1)
+	require.NoError(err)
+	require.Equal(keys[1].Address(), key.Address())
+
+	// The passphrase and the account change the derived keys.
+	otherHD, err := NewHDKeychain(testMnemonic, "passphrase", 0)
+	require.NoError(err)
+	otherKey, err := otherHD.Key(0)
+	require.NoError(err)
+	require.NotEqual(keys[0].Address(), otherKey.Address())
+
+	otherHD, err = NewHDKeychain(testMnemonic, "", 1)
+	require.NoError(err)
+	otherKey, err = otherHD.Key(0)
+	require.NoError(err)
+	require.NotEqual(keys[0].Address(), otherKey.Address())
+}
+
+func TestScanHDKeychain(t *testing.T) {
+	hd, err := NewHDKeychain(testMnemonic, "", 0)
+	require.NoError(t, err)
+	keys, err := hd.Keys(0, 10)
+	require.NoError(t, err)
+
+	_, err = scanHDKeychain(context.Background(), hd, 0, nil)
+	require.ErrorIs(t, err, errZeroGapLimit)
+
+	tests := []struct {
+		name            string
+		usedIndices     []int
+		gapLimit        uint32
+		expectedNumKeys int
+	}{
+		{
+			name:            "unused",
+			gapLimit:        3,
+			expectedNumKeys: 1,
+		},
+		{
+			name:            "used within first batch",
+			usedIndices:     []int{0, 2},
+			gapLimit:        3,
+			expectedNumKeys: 3,
+		},
+		{
+			name:            "used in later batch",
+			usedIndices:     []int{1, 4},
+			gapLimit:        3,
+			expectedNumKeys: 5,
+		},
+		{
+			name:            "used beyond gap",
+			usedIndices:     []int{0, 5},
+			gapLimit:        3,
+			expectedNumKeys: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			usedAddrs := set.Set[ids.ShortID]{}
+			for _, i := range test.usedIndices {
+				usedAddrs.Add(keys[i].Address())
+			}
+			kc, err := scanHDKeychain(
+				context.Background(),
+				hd,
+				test.gapLimit,
+				func(_ context.Context, addrs set.Set[ids.ShortID]) (set.Set[ids.ShortID], error) {
+					used := set.Set[ids.ShortID]{}
+					for addr := range addrs {
+						if usedAddrs.Contains(addr) {
+							used.Add(addr)
+						}
+					}
+					return used, nil
+				},
+			)
+			require.NoError(err)
+
+			expectedAddrs := set.Set[ids.ShortID]{}
+			for _, key := range keys[:test.expectedNumKeys] {
+				expectedAddrs.Add(key.Address())
+			}
+			require.Equal(expectedAddrs, kc.Addresses())
+		})
+	}
+}
@@ -5,6 +5,7 @@ package primary
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"golang.org/x/exp/maps"
@@ -28,9 +29,12 @@ type UTXOs interface {
 	GetUTXO(ctx context.Context, sourceChainID, destinationChainID, utxoID ids.ID) (*avax.UTXO, error)
 }
 
+// NewUTXOs returns an in-memory UTXO set. UTXOs are returned in the order they
+// were added.
 func NewUTXOs() UTXOs {
 	return &utxos{
 		sourceToDestToUTXOIDToUTXO: make(map[ids.ID]map[ids.ID]map[ids.ID]*avax.UTXO),
+		utxoIDToIndex:              make(map[ids.ID]uint64),
 	}
 }
 
@@ -45,6 +49,9 @@ type utxos struct {
 	lock sync.RWMutex
 	// sourceChainID -> destinationChainID -> utxoID -> utxo
 	sourceToDestToUTXOIDToUTXO map[ids.ID]map[ids.ID]map[ids.ID]*avax.UTXO
+	// utxoIDToIndex records the order the UTXOs were added in
+	utxoIDToIndex map[ids.ID]uint64
+	nextIndex     uint64
 }
 
 func (u *utxos) AddUTXO(_ context.Context, sourceChainID, destinationChainID ids.ID, utxo *avax.UTXO) error {
@@ -63,7 +70,12 @@ func (u *utxos) AddUTXO(_ context.Context, sourceChainID, destinationChainID ids
 		destToUTXOIDToUTXO[destinationChainID] = utxoIDToUTXO
 	}
 
-	utxoIDToUTXO[utxo.InputID()] = utxo
+	utxoID := utxo.InputID()
+	utxoIDToUTXO[utxoID] = utxo
+	if _, ok := u.utxoIDToIndex[utxoID]; !ok {
+		u.utxoIDToIndex[utxoID] = u.nextIndex
+		u.nextIndex++
+	}
 	return nil
 }
 
@@ -79,6 +91,7 @@ func (u *utxos) RemoveUTXO(_ context.Context, sourceChainID, destinationChainID,
 	}
 
 	delete(utxoIDToUTXO, utxoID)
+	delete(u.utxoIDToIndex, utxoID)
 	if len(utxoIDToUTXO) != 0 {
 		return nil
 	}
@@ -98,7 +111,11 @@ func (u *utxos) UTXOs(_ context.Context, sourceChainID, destinationChainID ids.I
 
 	destToUTXOIDToUTXO := u.sourceToDestToUTXOIDToUTXO[sourceChainID]
 	utxoIDToUTXO := destToUTXOIDToUTXO[destinationChainID]
-	return maps.Values(utxoIDToUTXO), nil
+	utxos := maps.Values(utxoIDToUTXO)
+	sort.Slice(utxos, func(i, j int) bool {
+		return u.utxoIDToIndex[utxos[i].InputID()] < u.utxoIDToIndex[utxos[j].InputID()]
+	})
+	return utxos, nil
 }
 
 func (u *utxos) GetUTXO(_ context.Context, sourceChainID, destinationChainID, utxoID ids.ID) (*avax.UTXO, error) {
@@ -65,6 +65,12 @@ func NewWalletWithOptions(w Wallet, options ...common.Option) Wallet {
 type WalletConfig struct {
 	// Base URI to use for all node requests.
 	URI string // required
+	// Base URIs to fail over to, in order, if the node at [URI] becomes
+	// unhealthy.
+	FallbackURIs []string // optional
+	// Cache of the primary network chain contexts. If not provided, the
+	// contexts are fetched from the provided URIs.
+	ContextCache *ContextCache // optional
 	// Keys to use for signing all transactions.
 	AVAXKeychain keychain.Keychain // required
 	EthKeychain  c.EthKeychain     // required
@@ -74,27 +80,57 @@ type WalletConfig struct {
 	// Set of P-chain transactions that the wallet should fetch to be able to
 	// generate transactions.
 	PChainTxsToFetch set.Set[ids.ID] // optional
+	// Policy that P-chain and X-chain transactions must satisfy to be signed.
+	// Violations are reported as [*common.PolicyViolationError]s.
+	PolicyEnforcer *common.PolicyEnforcer // optional
 }
 
 // MakeWallet returns a wallet that supports issuing transactions to the chains
 // living in the primary network.
 //
-// On creation, the wallet attaches to the provided uri and fetches all UTXOs
-// that reference any of the provided keys. If the UTXOs are modified through an
-// external issuance process, such as another instance of the wallet, the UTXOs
-// may become out of sync. The wallet will also fetch all requested P-chain
-// transactions.
+// On creation, the wallet attaches to the first healthy URI and fetches all
+// UTXOs that reference any of the provided keys. If the UTXOs are modified
+// through an external issuance process, such as another instance of the
+// wallet, the UTXOs may become out of sync. The wallet will also fetch all
+// requested P-chain transactions.
+//
+// P-chain and X-chain transactions are issued to the first healthy URI, failing
+// over to the next URI if a node can't be reached. C-chain transactions are
+// always issued to the URI the wallet was created with.
 //
 // The wallet manages all state locally, and performs all tx signing locally.
 func MakeWallet(ctx context.Context, config *WalletConfig) (Wallet, error) {
-	avaxAddrs := config.AVAXKeychain.Addresses()
-	avaxState, err := FetchState(ctx, config.URI, avaxAddrs)
+	uris := append([]string{config.URI}, config.FallbackURIs...)
+	endpoints, err := NewEndpoints(uris, DefaultHealthCheckFrequency)
+	if err != nil {
+		return nil, err
+	}
+
+	contextCache := config.ContextCache
+	if contextCache == nil {
+		contextCache = NewContextCache(endpoints, DefaultContextTTL)
+	}
+	contexts, err := contextCache.Get(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	ethAddrs := config.EthKeychain.EthAddresses()
-	ethState, err := FetchEthState(ctx, config.URI, ethAddrs)
+	var (
+		avaxAddrs = config.AVAXKeychain.Addresses()
+		ethAddrs  = config.EthKeychain.EthAddresses()
+		avaxState *AVAXState
+		ethState  *EthState
+	)
+	err = endpoints.Do(ctx, func(uri string) error {
+		var err error
+		avaxState, err = FetchStateWithContexts(ctx, uri, contexts, avaxAddrs)
+		if err != nil {
+			return err
+		}
+
+		ethState, err = FetchEthState(ctx, uri, ethAddrs)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +163,11 @@ func MakeWallet(ctx context.Context, config *WalletConfig) (Wallet, error) {
 	xBuilder := x.NewBuilder(avaxAddrs, xBackend)
 	xSigner := x.NewSigner(config.AVAXKeychain, xBackend)
 
+	if config.PolicyEnforcer != nil {
+		pSigner = p.NewPolicySigner(pSigner, config.AVAXKeychain, config.PolicyEnforcer)
+		xSigner = x.NewPolicySigner(xSigner, config.AVAXKeychain, config.PolicyEnforcer)
+	}
+
 	cChainID := avaxState.CCTX.BlockchainID()
 	cUTXOs := NewChainUTXOs(cChainID, avaxState.UTXOs)
 	cBackend := c.NewBackend(avaxState.CCTX, cUTXOs, ethState.Accounts)
@@ -134,8 +175,8 @@ func MakeWallet(ctx context.Context, config *WalletConfig) (Wallet, error) {
 	cSigner := c.NewSigner(config.AVAXKeychain, config.EthKeychain, cBackend)
 
 	return NewWallet(
-		p.NewWallet(pBuilder, pSigner, avaxState.PClient, pBackend),
-		x.NewWallet(xBuilder, xSigner, avaxState.XClient, xBackend),
+		p.NewWallet(pBuilder, pSigner, &failoverPClient{endpoints: endpoints}, pBackend),
+		x.NewWallet(xBuilder, xSigner, &failoverXClient{endpoints: endpoints}, xBackend),
 		c.NewWallet(cBuilder, cSigner, avaxState.CClient, ethState.Client, cBackend),
 	), nil
 }
@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+var _ SignatureGetter = SignatureGetterFunc(nil)
+
+// SignatureGetter requests the signature of a warp message from a validator.
+// How the signature is requested, for example through an API exposed by the
+// source chain's VM, is left to the application.
+type SignatureGetter interface {
+	// GetSignature returns [nodeID]'s BLS signature of [msg].
+	GetSignature(ctx context.Context, nodeID ids.NodeID, msg *warp.UnsignedMessage) (*bls.Signature, error)
+}
+
+// SignatureGetterFunc is a SignatureGetter implemented by a function.
+type SignatureGetterFunc func(ctx context.Context, nodeID ids.NodeID, msg *warp.UnsignedMessage) (*bls.Signature, error)
+
+func (f SignatureGetterFunc) GetSignature(ctx context.Context, nodeID ids.NodeID, msg *warp.UnsignedMessage) (*bls.Signature, error) {
+	return f(ctx, nodeID, msg)
+}
+
+// Aggregator collects the signatures of a warp message from the validators of
+// the message's source chain and aggregates them into a signed message.
+type Aggregator struct {
+	pChainClient platformvm.Client
+	getter       SignatureGetter
+}
+
+// NewAggregator returns an aggregator that reads the validator sets from
+// [pChainClient] and requests the signatures from [getter].
+func NewAggregator(pChainClient platformvm.Client, getter SignatureGetter) *Aggregator {
+	return &Aggregator{
+		pChainClient: pChainClient,
+		getter:       getter,
+	}
+}
+
+// signatureResult is the signature of the validator at [index] in the
+// canonical validator set, or nil if it couldn't be fetched.
+type signatureResult struct {
+	index     int
+	signature *bls.Signature
+}
+
+// Aggregate requests the signatures of [msg] from the validators of its source
+// chain at [pChainHeight] and returns [msg] signed by at least
+// [quorumNum]/[quorumDen] of their stake.
+//
+// Signatures are requested from every validator concurrently. Requests that
+// are still outstanding once the quorum is reached are canceled.
+func (a *Aggregator) Aggregate(
+	ctx context.Context,
+	msg *warp.UnsignedMessage,
+	pChainHeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, error) {
+	subnetID, err := a.pChainClient.ValidatedBy(ctx, msg.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch the subnet of chain %s: %w", msg.SourceChainID, err)
+	}
+	vdrSet, err := a.pChainClient.GetCanonicalValidatorSet(ctx, subnetID, pChainHeight)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch the validators of subnet %s: %w", subnetID, err)
+	}
+
+	publicKeys := make([]*bls.PublicKey, len(vdrSet.Validators))
+	for i, vdr := range vdrSet.Validators {
+		pkBytes, err := formatting.Decode(formatting.HexNC, vdr.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		publicKeys[i], err = bls.PublicKeyFromUncompressedBytes(pkBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan signatureResult, len(vdrSet.Validators))
+	for i, vdr := range vdrSet.Validators {
+		go func(index int, nodeIDs []ids.NodeID, pk *bls.PublicKey) {
+			results <- signatureResult{
+				index:     index,
+				signature: a.getSignature(ctx, nodeIDs, pk, msg),
+			}
+		}(i, vdr.NodeIDs, publicKeys[i])
+	}
+
+	var (
+		totalWeight = uint64(vdrSet.TotalWeight)
+		signers     = set.NewBits()
+		signatures  []*bls.Signature
+		sigWeight   uint64
+		weightErr   = warp.VerifyWeight(sigWeight, totalWeight, quorumNum, quorumDen)
+	)
+	for range vdrSet.Validators {
+		result := <-results
+		if result.signature == nil {
+			continue
+		}
+
+		signers.Add(result.index)
+		signatures = append(signatures, result.signature)
+		sigWeight += uint64(vdrSet.Validators[result.index].Weight)
+
+		weightErr = warp.VerifyWeight(sigWeight, totalWeight, quorumNum, quorumDen)
+		if weightErr == nil {
+			break
+		}
+	}
+	if weightErr != nil {
+		return nil, weightErr
+	}
+
+	aggSig, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+	sig := &warp.BitSetSignature{
+		Signers: signers.Bytes(),
+	}
+	copy(sig.Signature[:], bls.SignatureToBytes(aggSig))
+	return warp.NewMessage(msg, sig)
+}
+
+// getSignature returns the first valid signature of [msg] by [pk] returned by
+// one of [nodeIDs], or nil if none of them returned one.
+func (a *Aggregator) getSignature(
+	ctx context.Context,
+	nodeIDs []ids.NodeID,
+	pk *bls.PublicKey,
+	msg *warp.UnsignedMessage,
+) *bls.Signature {
+	unsignedBytes := msg.Bytes()
+	for _, nodeID := range nodeIDs {
+		sig, err := a.getter.GetSignature(ctx, nodeID, msg)
+		if err == nil && sig != nil && bls.Verify(pk, sig, unsignedBytes) {
+			return sig
+		}
+	}
+	return nil
+}
+
+// Verify checks that [msg] is signed by at least [quorumNum]/[quorumDen] of the
+// stake of the validators of its source chain at [pChainHeight], as reported
+// by [pChainClient].
+func Verify(
+	ctx context.Context,
+	pChainClient platformvm.Client,
+	msg *warp.Message,
+	networkID uint32,
+	pChainHeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) error {
+	return msg.Signature.Verify(
+		ctx,
+		&msg.UnsignedMessage,
+		networkID,
+		NewPChainState(pChainClient),
+		pChainHeight,
+		quorumNum,
+		quorumDen,
+	)
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+const (
+	networkID    = 5
+	pChainHeight = 10
+)
+
+var errOffline = errors.New("validator is offline")
+
+type testValidator struct {
+	nodeID ids.NodeID
+	sk     *bls.SecretKey
+	pk     *bls.PublicKey
+	weight uint64
+}
+
+// testPChainClient serves a fixed validator set of a single subnet.
+type testPChainClient struct {
+	platformvm.Client
+
+	subnetID   ids.ID
+	validators []*testValidator
+}
+
+func (c *testPChainClient) ValidatedBy(context.Context, ids.ID, ...rpc.Option) (ids.ID, error) {
+	return c.subnetID, nil
+}
+
+func (c *testPChainClient) GetValidatorsAt(
+	context.Context,
+	ids.ID,
+	uint64,
+	...rpc.Option,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput, len(c.validators))
+	for _, vdr := range c.validators {
+		vdrs[vdr.nodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdr.nodeID,
+			PublicKey: vdr.pk,
+			Weight:    vdr.weight,
+		}
+	}
+	return vdrs, nil
+}
+
+func (c *testPChainClient) GetCanonicalValidatorSet(
+	context.Context,
+	ids.ID,
+	uint64,
+	...rpc.Option,
+) (*platformvm.GetCanonicalValidatorSetReply, error) {
+	reply := &platformvm.GetCanonicalValidatorSetReply{}
+	for _, vdr := range c.validators {
+		pk, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToUncompressedBytes(vdr.pk))
+		if err != nil {
+			return nil, err
+		}
+		reply.Validators = append(reply.Validators, platformvm.CanonicalValidator{
+			PublicKey: pk,
+			Weight:    json.Uint64(vdr.weight),
+			NodeIDs:   []ids.NodeID{vdr.nodeID},
+		})
+		reply.TotalWeight += json.Uint64(vdr.weight)
+	}
+	return reply, nil
+}
+
+func newTestPChainClient(t *testing.T, weights ...uint64) *testPChainClient {
+	client := &testPChainClient{
+		subnetID: ids.GenerateTestID(),
+	}
+	for _, weight := range weights {
+		sk, err := bls.NewSecretKey()
+		require.NoError(t, err)
+		client.validators = append(client.validators, &testValidator{
+			nodeID: ids.GenerateTestNodeID(),
+			sk:     sk,
+			pk:     bls.PublicFromSecretKey(sk),
+			weight: weight,
+		})
+	}
+	// The canonical validator set is ordered by uncompressed public key.
+	slices.SortFunc(client.validators, func(a, b *testValidator) int {
+		return bytes.Compare(bls.PublicKeyToUncompressedBytes(a.pk), bls.PublicKeyToUncompressedBytes(b.pk))
+	})
+	return client
+}
+
+// lightestValidator returns the nodeID of the validator with the least stake.
+func (c *testPChainClient) lightestValidator() ids.NodeID {
+	lightest := c.validators[0]
+	for _, vdr := range c.validators[1:] {
+		if vdr.weight < lightest.weight {
+			lightest = vdr
+		}
+	}
+	return lightest.nodeID
+}
+
+// signatureGetter returns the signatures of the validators of [client], except
+// for the ones in [offline].
+func signatureGetter(client *testPChainClient, offline ...ids.NodeID) SignatureGetter {
+	return SignatureGetterFunc(func(_ context.Context, nodeID ids.NodeID, msg *warp.UnsignedMessage) (*bls.Signature, error) {
+		if slices.Contains(offline, nodeID) {
+			return nil, errOffline
+		}
+		for _, vdr := range client.validators {
+			if vdr.nodeID == nodeID {
+				return bls.Sign(vdr.sk, msg.Bytes()), nil
+			}
+		}
+		return nil, errOffline
+	})
+}
+
+func TestAggregateAndVerify(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestPChainClient(t, 10, 20, 30)
+	msg, err := NewAddressedCall(networkID, ids.GenerateTestID(), []byte{1}, []byte{2})
+	require.NoError(err)
+
+	addressedCall, err := ParseAddressedCall(msg)
+	require.NoError(err)
+	require.Equal([]byte{1}, addressedCall.SourceAddress)
+	require.Equal([]byte{2}, addressedCall.Payload)
+
+	// The validator with the least stake being offline doesn't prevent
+	// reaching a quorum of 67%.
+	aggregator := NewAggregator(client, signatureGetter(client, client.lightestValidator()))
+	signedMsg, err := aggregator.Aggregate(context.Background(), msg, pChainHeight, 67, 100)
+	require.NoError(err)
+	require.Equal(msg.Bytes(), signedMsg.UnsignedMessage.Bytes())
+
+	require.NoError(Verify(context.Background(), client, signedMsg, networkID, pChainHeight, 67, 100))
+
+	parsedMsg, err := warp.ParseMessage(signedMsg.Bytes())
+	require.NoError(err)
+	require.NoError(Verify(context.Background(), client, parsedMsg, networkID, pChainHeight, 67, 100))
+
+	err = Verify(context.Background(), client, signedMsg, networkID+1, pChainHeight, 67, 100)
+	require.ErrorIs(err, warp.ErrWrongNetworkID)
+}
+
+func TestAggregateInsufficientWeight(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestPChainClient(t, 10, 20, 30)
+	msg, err := NewAddressedCall(networkID, ids.GenerateTestID(), []byte{1}, []byte{2})
+	require.NoError(err)
+
+	aggregator := NewAggregator(client, signatureGetter(client, client.validators[0].nodeID))
+	_, err = aggregator.Aggregate(context.Background(), msg, pChainHeight, 1, 1)
+	require.ErrorIs(err, warp.ErrInsufficientWeight)
+}
+
+func TestAggregateIgnoresInvalidSignatures(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestPChainClient(t, 10, 20)
+	msg, err := NewAddressedCall(networkID, ids.GenerateTestID(), []byte{1}, []byte{2})
+	require.NoError(err)
+
+	// Every validator returns the signature of the first validator.
+	getter := SignatureGetterFunc(func(_ context.Context, _ ids.NodeID, msg *warp.UnsignedMessage) (*bls.Signature, error) {
+		return bls.Sign(client.validators[0].sk, msg.Bytes()), nil
+	})
+	aggregator := NewAggregator(client, getter)
+	_, err = aggregator.Aggregate(context.Background(), msg, pChainHeight, 1, 1)
+	require.ErrorIs(err, warp.ErrInsufficientWeight)
+}
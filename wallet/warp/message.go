@@ -0,0 +1,33 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+// NewAddressedCall returns an unsigned warp message, sent by [sourceAddress] on
+// [sourceChainID], that carries [callPayload].
+//
+// If the call is meant for a specific address on the destination chain, the
+// destination address should be encoded in [callPayload].
+func NewAddressedCall(
+	networkID uint32,
+	sourceChainID ids.ID,
+	sourceAddress []byte,
+	callPayload []byte,
+) (*warp.UnsignedMessage, error) {
+	addressedCall, err := payload.NewAddressedCall(sourceAddress, callPayload)
+	if err != nil {
+		return nil, err
+	}
+	return warp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+}
+
+// ParseAddressedCall returns the addressed call carried by [msg].
+func ParseAddressedCall(msg *warp.UnsignedMessage) (*payload.AddressedCall, error) {
+	return payload.ParseAddressedCall(msg.Payload)
+}
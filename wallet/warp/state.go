@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+var _ validators.State = (*pChainState)(nil)
+
+// pChainState reads the validator sets from the P-chain API of a node.
+type pChainState struct {
+	client platformvm.Client
+}
+
+// NewPChainState returns a [validators.State] that is served by the P-chain
+// API of the node [client] connects to.
+//
+// The API doesn't expose the proposal window, so the minimum height is the
+// current height of the P-chain.
+func NewPChainState(client platformvm.Client) validators.State {
+	return &pChainState{client: client}
+}
+
+func (s *pChainState) GetMinimumHeight(ctx context.Context) (uint64, error) {
+	return s.client.GetHeight(ctx)
+}
+
+func (s *pChainState) GetCurrentHeight(ctx context.Context) (uint64, error) {
+	return s.client.GetHeight(ctx)
+}
+
+func (s *pChainState) GetSubnetID(ctx context.Context, chainID ids.ID) (ids.ID, error) {
+	return s.client.ValidatedBy(ctx, chainID)
+}
+
+func (s *pChainState) GetValidatorSet(
+	ctx context.Context,
+	height uint64,
+	subnetID ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	return s.client.GetValidatorsAt(ctx, subnetID, height)
+}
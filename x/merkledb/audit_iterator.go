@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+)
+
+var _ database.Iterator = (*AuditIterator)(nil)
+
+// AuditProof proves the key-value pairs in [Start, End] as of a root.
+//
+// If [Proof] holds as many key-value pairs as the proof interval of the
+// iterator that emitted it, it only proves the pairs up to its last key.
+type AuditProof struct {
+	// If Nothing, there's no lower bound on the range.
+	Start maybe.Maybe[[]byte]
+	// If Nothing, there's no upper bound on the range.
+	End   maybe.Maybe[[]byte]
+	Proof *RangeProof
+}
+
+// Verify returns nil iff [p] proves its key-value pairs against [rootID].
+func (p *AuditProof) Verify(ctx context.Context, rootID ids.ID) error {
+	return p.Proof.Verify(ctx, p.Start, p.End, rootID)
+}
+
+// AuditIterator iterates over the key-value pairs with a given prefix as of
+// the root the database had when the iterator was created. Every
+// [proofInterval] key-value pairs, it emits a range proof of the pairs it
+// returned since the previous proof, which allows a dump of the pairs to be
+// verified against the root without trusting the node that exported it.
+//
+// The pairs are read from the history of the database, so the iterator fails
+// with [ErrInsufficientHistory] if the database changes too much before the
+// iteration is done.
+type AuditIterator struct {
+	ctx           context.Context
+	db            RangeProofer
+	rootID        ids.ID
+	prefix        []byte
+	proofInterval int
+
+	// The range of the next chunk to fetch.
+	start maybe.Maybe[[]byte]
+	end   maybe.Maybe[[]byte]
+	// True iff the last chunk has been fetched.
+	exhausted bool
+
+	// The chunk being iterated over and the index of its next key-value pair.
+	chunk *AuditProof
+	index int
+
+	key, value []byte
+	proof      *AuditProof
+	err        error
+}
+
+// NewAuditIterator returns an iterator over the key-value pairs of [db] with
+// [prefix], which emits a proof every [proofInterval] key-value pairs.
+func NewAuditIterator(
+	ctx context.Context,
+	db MerkleDB,
+	prefix []byte,
+	proofInterval int,
+) (*AuditIterator, error) {
+	if proofInterval <= 0 {
+		return nil, fmt.Errorf("%w but was %d", ErrInvalidMaxLength, proofInterval)
+	}
+
+	rootID, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	it := &AuditIterator{
+		ctx:           ctx,
+		db:            db,
+		rootID:        rootID,
+		prefix:        slices.Clone(prefix),
+		proofInterval: proofInterval,
+		start:         maybe.Nothing[[]byte](),
+		end:           maybe.Nothing[[]byte](),
+	}
+	if len(prefix) > 0 {
+		it.start = maybe.Some(it.prefix)
+	}
+	if end, ok := prefixUpperBound(prefix); ok {
+		it.end = maybe.Some(end)
+	}
+	return it, nil
+}
+
+// RootID returns the root the key-value pairs and proofs are relative to.
+func (it *AuditIterator) RootID() ids.ID {
+	return it.rootID
+}
+
+// Next moves the iterator to the next key-value pair with the prefix.
+//
+// Once Next returns false, Proof must still be checked, as the end of the
+// prefix may not have been proven yet.
+func (it *AuditIterator) Next() bool {
+	it.key = nil
+	it.value = nil
+	it.proof = nil
+
+	for it.err == nil {
+		if it.chunk == nil {
+			if it.exhausted {
+				return false
+			}
+			if err := it.fetchChunk(); err != nil {
+				it.err = err
+				return false
+			}
+		}
+
+		found := false
+		keyValues := it.chunk.Proof.KeyValues
+		for !found && it.index < len(keyValues) {
+			keyValue := keyValues[it.index]
+			it.index++
+			if !bytes.HasPrefix(keyValue.Key, it.prefix) {
+				// Only the upper bound of the range can lack the prefix.
+				continue
+			}
+			it.key = keyValue.Key
+			it.value = keyValue.Value
+			found = true
+		}
+		if it.index == len(keyValues) {
+			it.proof = it.chunk
+			it.chunk = nil
+		}
+		if found {
+			return true
+		}
+		if it.proof != nil && it.exhausted {
+			return false
+		}
+	}
+	return false
+}
+
+// fetchChunk fetches the proof of the next [it.proofInterval] key-value
+// pairs.
+func (it *AuditIterator) fetchChunk() error {
+	proof, err := it.db.GetRangeProofAtRoot(it.ctx, it.rootID, it.start, it.end, it.proofInterval)
+	if err != nil {
+		return err
+	}
+
+	it.chunk = &AuditProof{
+		Start: it.start,
+		End:   it.end,
+		Proof: proof,
+	}
+	it.index = 0
+
+	keyValues := proof.KeyValues
+	if len(keyValues) < it.proofInterval {
+		it.exhausted = true
+		return nil
+	}
+	lastKey := keyValues[len(keyValues)-1].Key
+	if it.end.HasValue() && bytes.Equal(lastKey, it.end.Value()) {
+		it.exhausted = true
+		return nil
+	}
+	// The next chunk starts at the smallest key greater than [lastKey].
+	it.start = maybe.Some(append(slices.Clone(lastKey), 0))
+	return nil
+}
+
+// Proof returns the proof of the key-value pairs returned since the previous
+// proof, if the last call to Next completed them. Otherwise, returns nil.
+func (it *AuditIterator) Proof() *AuditProof {
+	return it.proof
+}
+
+func (it *AuditIterator) Error() error {
+	return it.err
+}
+
+func (it *AuditIterator) Key() []byte {
+	return it.key
+}
+
+func (it *AuditIterator) Value() []byte {
+	return it.value
+}
+
+func (it *AuditIterator) Release() {
+	it.key = nil
+	it.value = nil
+	it.proof = nil
+	it.chunk = nil
+	it.exhausted = true
+}
+
+// prefixUpperBound returns the smallest key that is greater than every key
+// with [prefix]. Returns false if there is no such key.
+func prefixUpperBound(prefix []byte) ([]byte, bool) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			upperBound := slices.Clone(prefix[:i+1])
+			upperBound[i]++
+			return upperBound, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestAuditIterator(t *testing.T) {
+	keys := [][]byte{
+		{0x00},
+		{0x01},
+		{0x01, 0x00},
+		{0x01, 0x01},
+		{0x01, 0x02},
+		{0x01, 0x03},
+		{0x01, 0xff},
+		{0x02},
+		{0xff},
+		{0xff, 0xff},
+	}
+
+	tests := []struct {
+		name          string
+		prefix        []byte
+		proofInterval int
+		expectedKeys  [][]byte
+	}{
+		{
+			name:          "no prefix",
+			prefix:        nil,
+			proofInterval: 3,
+			expectedKeys:  keys,
+		},
+		{
+			name:          "prefix",
+			prefix:        []byte{0x01},
+			proofInterval: 2,
+			expectedKeys:  keys[1:7],
+		},
+		{
+			name:          "prefix with upper bound in the last chunk",
+			prefix:        []byte{0x01},
+			proofInterval: 5,
+			expectedKeys:  keys[1:7],
+		},
+		{
+			name:          "prefix without upper bound",
+			prefix:        []byte{0xff},
+			proofInterval: 1,
+			expectedKeys:  keys[8:],
+		},
+		{
+			name:          "no keys with prefix",
+			prefix:        []byte{0x03},
+			proofInterval: 1,
+			expectedKeys:  nil,
+		},
+		{
+			name:          "single chunk",
+			prefix:        nil,
+			proofInterval: 100,
+			expectedKeys:  keys,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db, err := getBasicDB()
+			require.NoError(err)
+			for _, key := range keys {
+				require.NoError(db.Put(key, key))
+			}
+
+			it, err := NewAuditIterator(context.Background(), db, tt.prefix, tt.proofInterval)
+			require.NoError(err)
+			defer it.Release()
+
+			rootID, err := db.GetMerkleRoot(context.Background())
+			require.NoError(err)
+			require.Equal(rootID, it.RootID())
+
+			// Changes made after the iterator is created aren't iterated over.
+			require.NoError(db.Put(append(tt.prefix, 0x00, 0x00), []byte{0}))
+
+			var (
+				iteratedKeys [][]byte
+				provenKeys   [][]byte
+				proofs       []*AuditProof
+			)
+			addProof := func() {
+				proof := it.Proof()
+				if proof == nil {
+					return
+				}
+				require.NoError(proof.Verify(context.Background(), rootID))
+				for _, keyValue := range proof.Proof.KeyValues {
+					if bytes.HasPrefix(keyValue.Key, tt.prefix) {
+						provenKeys = append(provenKeys, keyValue.Key)
+					}
+				}
+				proofs = append(proofs, proof)
+			}
+			for it.Next() {
+				require.Equal(it.Key(), it.Value())
+				iteratedKeys = append(iteratedKeys, it.Key())
+				addProof()
+			}
+			addProof()
+			require.NoError(it.Error())
+
+			require.Equal(tt.expectedKeys, iteratedKeys)
+			// Every iterated key is proven exactly once.
+			require.Equal(tt.expectedKeys, provenKeys)
+			require.NotEmpty(proofs)
+		})
+	}
+}
+
+func TestAuditIteratorWrongRoot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	writeBasicBatch(t, db)
+
+	it, err := NewAuditIterator(context.Background(), db, nil, 2)
+	require.NoError(err)
+	defer it.Release()
+
+	require.True(it.Next())
+	require.True(it.Next())
+	proof := it.Proof()
+	require.NotNil(proof)
+
+	err = proof.Verify(context.Background(), ids.GenerateTestID())
+	require.ErrorIs(err, ErrInvalidProof)
+}
+
+func TestAuditIteratorInvalidProofInterval(t *testing.T) {
+	db, err := getBasicDB()
+	require.NoError(t, err)
+
+	_, err = NewAuditIterator(context.Background(), db, nil, 0)
+	require.ErrorIs(t, err, ErrInvalidMaxLength)
+}
@@ -46,6 +46,7 @@ var (
 	ErrNilProofNode                = errors.New("proof node is nil")
 	ErrNilValueOrHash              = errors.New("proof node's valueOrHash field is nil")
 	ErrNilSerializedPath           = errors.New("serialized path is nil")
+	ErrInvalidSerializedPath       = errors.New("serialized path's nibble length doesn't match its value")
 	ErrNilRangeProof               = errors.New("range proof is nil")
 	ErrNilChangeProof              = errors.New("change proof is nil")
 	ErrNilMaybeBytes               = errors.New("maybe bytes is nil")
@@ -97,8 +98,11 @@ func (node *ProofNode) UnmarshalProto(pbNode *pb.ProofNode) error {
 		return ErrNilSerializedPath
 	}
 
-	node.KeyPath.NibbleLength = int(pbNode.Key.NibbleLength)
-	node.KeyPath.Value = pbNode.Key.Value
+	keyPath, err := serializedPathFromProto(pbNode.Key)
+	if err != nil {
+		return err
+	}
+	node.KeyPath = keyPath
 
 	node.Children = make(map[byte]ids.ID, len(pbNode.Children))
 	for childIndex, childIDBytes := range pbNode.Children {
@@ -119,6 +123,24 @@ func (node *ProofNode) UnmarshalProto(pbNode *pb.ProofNode) error {
 	return nil
 }
 
+// serializedPathFromProto returns the path represented by [pbPath], or an error
+// if the nibble length doesn't match the length of the value.
+func serializedPathFromProto(pbPath *pb.SerializedPath) (SerializedPath, error) {
+	numBytes := uint64(len(pbPath.Value))
+	if pbPath.NibbleLength > 2*numBytes || pbPath.NibbleLength+1 < 2*numBytes {
+		return SerializedPath{}, ErrInvalidSerializedPath
+	}
+
+	path := SerializedPath{
+		NibbleLength: int(pbPath.NibbleLength),
+		Value:        pbPath.Value,
+	}
+	if path.hasOddLength() && path.Value[len(path.Value)-1]&0x0F != 0 {
+		return SerializedPath{}, errNonZeroNibblePadding
+	}
+	return path, nil
+}
+
 // An inclusion/exclustion proof of a key.
 type Proof struct {
 	// Nodes in the proof path from root --> target key
@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+// newFuzzSeedDB returns a database with a handful of keys, along with its
+// root before and after the final batch of changes, which is used to generate
+// proofs to seed the fuzzers below.
+func newFuzzSeedDB(t testing.TB) (*merkleDB, ids.ID, ids.ID) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	ctx := context.Background()
+	for _, key := range [][]byte{{}, {0}, {1}, {1, 2}, {1, 2, 3}, {3, 4}} {
+		require.NoError(db.PutContext(ctx, key, key))
+	}
+	startRoot, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	require.NoError(db.PutContext(ctx, []byte{1, 2}, []byte{5}))
+	require.NoError(db.DeleteContext(ctx, []byte{3, 4}))
+	endRoot, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	return db, startRoot, endRoot
+}
+
+func FuzzProofUnmarshalVerify(f *testing.F) {
+	db, _, root := newFuzzSeedDB(f)
+	for _, key := range [][]byte{{}, {1, 2}, {1, 3}, {5}} {
+		proof, err := db.GetProof(context.Background(), key)
+		require.NoError(f, err)
+		proofBytes, err := proto.Marshal(proof.ToProto())
+		require.NoError(f, err)
+		f.Add(proofBytes)
+	}
+
+	f.Fuzz(func(t *testing.T, proofBytes []byte) {
+		var pbProof pb.Proof
+		if err := proto.Unmarshal(proofBytes, &pbProof); err != nil {
+			t.SkipNow()
+		}
+
+		var proof Proof
+		if err := proof.UnmarshalProto(&pbProof); err != nil {
+			return
+		}
+		_ = proof.Verify(context.Background(), root)
+	})
+}
+
+func FuzzRangeProofUnmarshalVerify(f *testing.F) {
+	db, _, root := newFuzzSeedDB(f)
+	for _, maxLength := range []int{1, 2, 10} {
+		proof, err := db.GetRangeProof(
+			context.Background(),
+			maybe.Some([]byte{0}),
+			maybe.Some([]byte{2}),
+			maxLength,
+		)
+		require.NoError(f, err)
+		proofBytes, err := proto.Marshal(proof.ToProto())
+		require.NoError(f, err)
+		f.Add(proofBytes, []byte{0}, []byte{2})
+	}
+
+	f.Fuzz(func(t *testing.T, proofBytes []byte, start []byte, end []byte) {
+		var pbProof pb.RangeProof
+		if err := proto.Unmarshal(proofBytes, &pbProof); err != nil {
+			t.SkipNow()
+		}
+
+		var proof RangeProof
+		if err := proof.UnmarshalProto(&pbProof); err != nil {
+			return
+		}
+		_ = proof.Verify(
+			context.Background(),
+			maybe.Some(start),
+			maybe.Some(end),
+			root,
+		)
+	})
+}
+
+func FuzzChangeProofUnmarshalVerify(f *testing.F) {
+	db, startRoot, endRoot := newFuzzSeedDB(f)
+	for _, maxLength := range []int{1, 2, 10} {
+		proof, err := db.GetChangeProof(
+			context.Background(),
+			startRoot,
+			endRoot,
+			maybe.Nothing[[]byte](),
+			maybe.Nothing[[]byte](),
+			maxLength,
+		)
+		require.NoError(f, err)
+		proofBytes, err := proto.Marshal(proof.ToProto())
+		require.NoError(f, err)
+		f.Add(proofBytes)
+	}
+
+	f.Fuzz(func(t *testing.T, proofBytes []byte) {
+		var pbProof pb.ChangeProof
+		if err := proto.Unmarshal(proofBytes, &pbProof); err != nil {
+			t.SkipNow()
+		}
+
+		var proof ChangeProof
+		if err := proof.UnmarshalProto(&pbProof); err != nil {
+			return
+		}
+		_ = db.VerifyChangeProof(
+			context.Background(),
+			&proof,
+			maybe.Nothing[[]byte](),
+			maybe.Nothing[[]byte](),
+			endRoot,
+		)
+	})
+}
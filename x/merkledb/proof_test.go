@@ -6,6 +6,7 @@ package merkledb
 import (
 	"bytes"
 	"context"
+	"math"
 	"math/rand"
 	"testing"
 	"time"
@@ -1246,6 +1247,65 @@ func TestProofNodeUnmarshalProtoInvalidChildIndex(t *testing.T) {
 	require.ErrorIs(t, err, ErrInvalidChildIndex)
 }
 
+func TestProofNodeUnmarshalProtoInvalidKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         *pb.SerializedPath
+		expectedErr error
+	}{
+		{
+			name: "nibble length too long",
+			key: &pb.SerializedPath{
+				NibbleLength: 1,
+			},
+			expectedErr: ErrInvalidSerializedPath,
+		},
+		{
+			name: "nibble length too short",
+			key: &pb.SerializedPath{
+				NibbleLength: 1,
+				Value:        []byte{0x10, 0x00},
+			},
+			expectedErr: ErrInvalidSerializedPath,
+		},
+		{
+			name: "nibble length overflow",
+			key: &pb.SerializedPath{
+				NibbleLength: math.MaxUint64,
+				Value:        []byte{0x10},
+			},
+			expectedErr: ErrInvalidSerializedPath,
+		},
+		{
+			name: "non-zero padding",
+			key: &pb.SerializedPath{
+				NibbleLength: 1,
+				Value:        []byte{0x11},
+			},
+			expectedErr: errNonZeroNibblePadding,
+		},
+		{
+			name: "odd length",
+			key: &pb.SerializedPath{
+				NibbleLength: 3,
+				Value:        []byte{0x11, 0x10},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			protoNode := &pb.ProofNode{
+				Key:         tt.key,
+				ValueOrHash: &pb.MaybeBytes{IsNothing: true},
+			}
+
+			var node ProofNode
+			err := node.UnmarshalProto(protoNode)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
 func TestProofNodeUnmarshalProtoMissingFields(t *testing.T) {
 	now := time.Now().UnixNano()
 	t.Logf("seed: %d", now)
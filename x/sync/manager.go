@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/maybe"
@@ -112,6 +113,9 @@ type ManagerConfig struct {
 	SimultaneousWorkLimit int
 	Log                   logging.Logger
 	TargetRoot            ids.ID
+	// If non-nil, the ranges that have been synced are persisted into
+	// [ProgressDB] so that syncing can be resumed after a restart.
+	ProgressDB database.Database
 }
 
 func NewManager(config ManagerConfig) (*Manager, error) {
@@ -147,9 +151,45 @@ func (m *Manager) Start(ctx context.Context) error {
 
 	m.config.Log.Info("starting sync", zap.Stringer("target root", m.config.TargetRoot))
 
-	// Add work item to fetch the entire key range.
-	// Note that this will be the first work item to be processed.
-	m.unprocessedWork.Insert(newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority))
+	var completedWork []*workItem
+	if m.config.ProgressDB != nil {
+		var err error
+		completedWork, err = getProgress(m.config.ProgressDB)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Ranges that were synced to the current target don't need to be fetched
+	// again. Ranges that were synced to a previous target only need to be
+	// updated with a change proof.
+	for _, work := range completedWork {
+		if work.localRootID == m.config.TargetRoot {
+			m.processedWork.MergeInsert(work)
+			continue
+		}
+
+		// Ranges that are being updated with a change proof may be partially
+		// modified, so they must not be considered completed if we restart
+		// again before they are processed.
+		if err := deleteProgress(m.config.ProgressDB, work.start); err != nil {
+			return err
+		}
+		work.priority = highPriority
+		m.unprocessedWork.Insert(work)
+	}
+
+	// Add work items to fetch the remaining key ranges. If there wasn't any
+	// previous progress, this is a single work item for the entire key range.
+	for _, work := range progressGaps(completedWork) {
+		m.unprocessedWork.Insert(work)
+	}
+	if len(completedWork) > 0 {
+		m.config.Log.Info("resuming sync",
+			zap.Int("numCompletedRanges", len(completedWork)),
+			zap.Float64("completed", completedFraction(completedWork)),
+		)
+	}
 
 	m.syncing = true
 	ctx, m.cancelCtx = context.WithCancel(ctx)
@@ -183,6 +223,11 @@ func (m *Manager) sync(ctx context.Context) {
 			if m.processingWorkItems == 0 {
 				// There's no work to do, and there are no work items being processed
 				// which could cause work to be added, so we're done.
+				if m.config.ProgressDB != nil {
+					if err := deleteAllProgress(m.config.ProgressDB); err != nil {
+						m.setError(err)
+					}
+				}
 				return // [m.workLock] released by defer.
 			}
 			// There's no work to do.
@@ -516,6 +561,21 @@ func (m *Manager) findNextKey(
 	return nextKey, nil
 }
 
+// Progress returns how much of the key space has been synced to the current
+// target root, including the ranges that were restored from
+// [m.config.ProgressDB] when syncing started.
+func (m *Manager) Progress() Progress {
+	m.workLock.Lock()
+	defer m.workLock.Unlock()
+
+	completedWork := m.processedWork.Items()
+	return Progress{
+		CompletedRanges: len(completedWork),
+		RemainingRanges: m.unprocessedWork.Len() + m.processingWorkItems,
+		Completed:       completedFraction(completedWork),
+	}
+}
+
 func (m *Manager) Error() error {
 	m.errLock.Lock()
 	defer m.errLock.Unlock()
@@ -585,12 +645,39 @@ func (m *Manager) UpdateSyncTarget(syncTargetRoot ids.ID) error {
 		currentItem.priority = highPriority
 		m.unprocessedWork.Insert(currentItem)
 	}
-	if shouldSignal {
-		// Only signal once because we only have 1 goroutine
-		// waiting on [m.unprocessedWorkCond].
-		m.unprocessedWorkCond.Signal()
+	if !shouldSignal {
+		return nil
 	}
-	return nil
+
+	// Only signal once because we only have 1 goroutine
+	// waiting on [m.unprocessedWorkCond].
+	m.unprocessedWorkCond.Signal()
+
+	// The completed ranges are about to be modified by change proofs, so they
+	// must be synced again if we restart.
+	if m.config.ProgressDB == nil {
+		return nil
+	}
+	return deleteAllProgress(m.config.ProgressDB)
+}
+
+// persistCompletedWork persists [merged], the range of [m.processedWork] that
+// the completed range [work] was merged into, if [m.config.ProgressDB] was
+// provided. Only [merged] is written, rather than every completed range.
+// Assumes [m.workLock] is held.
+func (m *Manager) persistCompletedWork(work *workItem, merged *workItem) error {
+	if m.config.ProgressDB == nil || merged == nil {
+		return nil
+	}
+
+	// If [work] was merged with the range that started at its end, that range
+	// is now persisted as part of [merged].
+	if !maybe.Equal(work.end, merged.end, bytes.Equal) {
+		if err := deleteProgress(m.config.ProgressDB, work.end); err != nil {
+			return err
+		}
+	}
+	return putProgress(m.config.ProgressDB, merged)
 }
 
 func (m *Manager) getTargetRoot() ids.ID {
@@ -663,7 +750,12 @@ func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestH
 		m.workLock.Lock()
 		defer m.workLock.Unlock()
 
-		m.processedWork.MergeInsert(newWorkItem(rootID, work.start, largestHandledKey, work.priority))
+		completedWork := newWorkItem(rootID, work.start, largestHandledKey, work.priority)
+		merged := m.processedWork.MergeInsert(completedWork)
+		if err := m.persistCompletedWork(completedWork, merged); err != nil {
+			m.setError(err)
+			return
+		}
 	}
 
 	// completed the range [work.start, lastKey], log and record in the completed work heap
@@ -0,0 +1,219 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	progressPrefix = []byte("progress")
+
+	errInvalidProgress = errors.New("invalid sync progress")
+)
+
+// Each completed range is persisted under its own key, so that completing a
+// range only writes that range. The key is [progressPrefix] followed by the
+// start of the range, so iterating over the keys yields the ranges sorted by
+// their start key.
+const (
+	nothingStartKeyPrefix byte = iota
+	someStartKeyPrefix
+)
+
+// Progress describes how much of the key space has been synced.
+type Progress struct {
+	// CompletedRanges is the number of disjoint ranges that were synced to the
+	// target root.
+	CompletedRanges int
+	// RemainingRanges is the number of ranges that still need to be synced,
+	// including the ranges that are currently being fetched.
+	RemainingRanges int
+	// Completed estimates the fraction of the key space, between 0 and 1,
+	// that was synced to the target root. Keys are assumed to be uniformly
+	// distributed.
+	Completed float64
+}
+
+// getProgress returns the ranges that were previously persisted by
+// putProgress. The returned ranges are sorted by their start key.
+//
+// If no progress was persisted, nil is returned.
+func getProgress(db database.Iteratee) ([]*workItem, error) {
+	it := db.NewIteratorWithPrefix(progressPrefix)
+	defer it.Release()
+
+	var items []*workItem
+	for it.Next() {
+		start, err := parseProgressKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		value := slices.Clone(it.Value())
+		p := wrappers.Packer{Bytes: value}
+		end := unpackMaybeBytes(&p)
+		rootIDBytes := p.UnpackFixedBytes(hashing.HashLen)
+		if p.Err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidProgress, p.Err)
+		}
+		if p.Offset != len(value) {
+			return nil, fmt.Errorf("%w: %d trailing bytes", errInvalidProgress, len(value)-p.Offset)
+		}
+
+		rootID, err := ids.ToID(rootIDBytes)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, newWorkItem(rootID, start, end, lowPriority))
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	for i := 1; i < len(items); i++ {
+		prevEnd := items[i-1].end
+		start := items[i].start
+		if prevEnd.IsNothing() || start.IsNothing() || bytes.Compare(prevEnd.Value(), start.Value()) > 0 {
+			return nil, fmt.Errorf("%w: overlapping ranges", errInvalidProgress)
+		}
+	}
+	return items, nil
+}
+
+// putProgress persists the completed range [item], replacing any range that
+// was persisted with the same start key.
+func putProgress(db database.KeyValueWriter, item *workItem) error {
+	size := maybeBytesLen(item.end) + hashing.HashLen
+	p := wrappers.Packer{Bytes: make([]byte, 0, size), MaxSize: size}
+	packMaybeBytes(&p, item.end)
+	p.PackFixedBytes(item.localRootID[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	return db.Put(progressKey(item.start), p.Bytes)
+}
+
+// deleteProgress removes the range starting at [start] that was persisted by
+// putProgress, if any.
+func deleteProgress(db database.KeyValueDeleter, start maybe.Maybe[[]byte]) error {
+	return db.Delete(progressKey(start))
+}
+
+// deleteAllProgress removes every range persisted by putProgress.
+func deleteAllProgress(db database.Database) error {
+	it := db.NewIteratorWithPrefix(progressPrefix)
+	defer it.Release()
+
+	batch := db.NewBatch()
+	for it.Next() {
+		if err := batch.Delete(slices.Clone(it.Key())); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func progressKey(start maybe.Maybe[[]byte]) []byte {
+	key := make([]byte, len(progressPrefix), len(progressPrefix)+1+len(start.Value()))
+	copy(key, progressPrefix)
+	if start.IsNothing() {
+		return append(key, nothingStartKeyPrefix)
+	}
+	key = append(key, someStartKeyPrefix)
+	return append(key, start.Value()...)
+}
+
+func parseProgressKey(key []byte) (maybe.Maybe[[]byte], error) {
+	startBytes := key[len(progressPrefix):]
+	switch {
+	case len(startBytes) == 1 && startBytes[0] == nothingStartKeyPrefix:
+		return maybe.Nothing[[]byte](), nil
+	case len(startBytes) >= 1 && startBytes[0] == someStartKeyPrefix:
+		return maybe.Some(slices.Clone(startBytes[1:])), nil
+	default:
+		return maybe.Nothing[[]byte](), fmt.Errorf("%w: malformed key %x", errInvalidProgress, key)
+	}
+}
+
+// progressGaps returns the ranges that aren't covered by [items], which must be
+// sorted by their start key and non-overlapping.
+func progressGaps(items []*workItem) []*workItem {
+	var (
+		gaps      []*workItem
+		nextStart = maybe.Nothing[[]byte]()
+	)
+	for i, item := range items {
+		if (i != 0 || item.start.HasValue()) && !maybe.Equal(nextStart, item.start, bytes.Equal) {
+			gaps = append(gaps, newWorkItem(ids.Empty, nextStart, item.start, lowPriority))
+		}
+		nextStart = item.end
+	}
+	if len(items) == 0 || nextStart.HasValue() {
+		gaps = append(gaps, newWorkItem(ids.Empty, nextStart, maybe.Nothing[[]byte](), lowPriority))
+	}
+	return gaps
+}
+
+// completedFraction estimates the fraction of the key space that is covered by
+// [items], which must be non-overlapping.
+func completedFraction(items []*workItem) float64 {
+	completed := 0.0
+	for _, item := range items {
+		end := 1.0
+		if item.end.HasValue() {
+			end = keyPosition(item.end.Value())
+		}
+		start := 0.0
+		if item.start.HasValue() {
+			start = keyPosition(item.start.Value())
+		}
+		completed += end - start
+	}
+	return math.Max(0, math.Min(1, completed))
+}
+
+// keyPosition estimates the position of [key] in the key space, between 0 and
+// 1, from its first 8 bytes.
+func keyPosition(key []byte) float64 {
+	var prefix [8]byte
+	copy(prefix[:], key)
+	return float64(binary.BigEndian.Uint64(prefix[:])) / math.Exp2(64)
+}
+
+func maybeBytesLen(value maybe.Maybe[[]byte]) int {
+	if value.IsNothing() {
+		return wrappers.BoolLen
+	}
+	return wrappers.BoolLen + wrappers.IntLen + len(value.Value())
+}
+
+func packMaybeBytes(p *wrappers.Packer, value maybe.Maybe[[]byte]) {
+	p.PackBool(value.HasValue())
+	if value.HasValue() {
+		p.PackBytes(value.Value())
+	}
+}
+
+func unpackMaybeBytes(p *wrappers.Packer) maybe.Maybe[[]byte] {
+	if !p.UnpackBool() {
+		return maybe.Nothing[[]byte]()
+	}
+	return maybe.Some(p.UnpackBytes())
+}
@@ -0,0 +1,195 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+)
+
+func TestProgressRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	items, err := getProgress(db)
+	require.NoError(err)
+	require.Empty(items)
+
+	rootID := ids.GenerateTestID()
+	expected := []*workItem{
+		newWorkItem(rootID, maybe.Nothing[[]byte](), maybe.Some([]byte{1}), lowPriority),
+		newWorkItem(ids.GenerateTestID(), maybe.Some([]byte{1}), maybe.Some([]byte{5, 1}), lowPriority),
+		newWorkItem(rootID, maybe.Some([]byte{6}), maybe.Nothing[[]byte](), lowPriority),
+	}
+	// The ranges are returned sorted regardless of the order they were
+	// persisted in.
+	for i := len(expected) - 1; i >= 0; i-- {
+		require.NoError(putProgress(db, expected[i]))
+	}
+
+	items, err = getProgress(db)
+	require.NoError(err)
+	require.Equal(expected, items)
+
+	// Persisting a range with the same start replaces it.
+	expected[1] = newWorkItem(rootID, maybe.Some([]byte{1}), maybe.Some([]byte{6}), lowPriority)
+	require.NoError(putProgress(db, expected[1]))
+	require.NoError(deleteProgress(db, maybe.Some([]byte{6})))
+
+	items, err = getProgress(db)
+	require.NoError(err)
+	require.Equal(expected[:2], items)
+
+	require.NoError(deleteAllProgress(db))
+	items, err = getProgress(db)
+	require.NoError(err)
+	require.Empty(items)
+}
+
+func TestProgressInvalid(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	require.NoError(db.Put(progressKey(maybe.Nothing[[]byte]()), []byte{1}))
+	_, err := getProgress(db)
+	require.ErrorIs(err, errInvalidProgress)
+
+	require.NoError(deleteAllProgress(db))
+	require.NoError(db.Put(append(progressPrefix, 2), nil))
+	_, err = getProgress(db)
+	require.ErrorIs(err, errInvalidProgress)
+
+	require.NoError(deleteAllProgress(db))
+	require.NoError(putProgress(db, newWorkItem(ids.Empty, maybe.Some([]byte{1}), maybe.Some([]byte{5}), lowPriority)))
+	require.NoError(putProgress(db, newWorkItem(ids.Empty, maybe.Some([]byte{3}), maybe.Some([]byte{7}), lowPriority)))
+	_, err = getProgress(db)
+	require.ErrorIs(err, errInvalidProgress)
+}
+
+func TestManagerPersistCompletedWork(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	m := &Manager{
+		config: ManagerConfig{
+			ProgressDB: db,
+		},
+		processedWork: newWorkHeap(),
+	}
+
+	rootID := ids.GenerateTestID()
+	complete := func(start, end maybe.Maybe[[]byte]) {
+		work := newWorkItem(rootID, start, end, lowPriority)
+		merged := m.processedWork.MergeInsert(work)
+		require.NoError(m.persistCompletedWork(work, merged))
+	}
+
+	complete(maybe.Some([]byte{5}), maybe.Nothing[[]byte]())
+	complete(maybe.Nothing[[]byte](), maybe.Some([]byte{1}))
+	items, err := getProgress(db)
+	require.NoError(err)
+	require.Equal(m.processedWork.Items(), items)
+	require.Len(items, 2)
+
+	// Completing the gap merges all the ranges into a single one, which
+	// replaces the ranges that were merged into it.
+	complete(maybe.Some([]byte{1}), maybe.Some([]byte{5}))
+	items, err = getProgress(db)
+	require.NoError(err)
+	require.Equal([]*workItem{
+		newWorkItem(rootID, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority),
+	}, items)
+}
+
+func TestProgressGaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []*workItem
+		expected []*workItem
+	}{
+		{
+			name: "no progress",
+			expected: []*workItem{
+				newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority),
+			},
+		},
+		{
+			name: "entire range",
+			items: []*workItem{
+				newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority),
+			},
+		},
+		{
+			name: "unbounded ends",
+			items: []*workItem{
+				newWorkItem(ids.Empty, maybe.Some([]byte{1}), maybe.Some([]byte{2}), lowPriority),
+			},
+			expected: []*workItem{
+				newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Some([]byte{1}), lowPriority),
+				newWorkItem(ids.Empty, maybe.Some([]byte{2}), maybe.Nothing[[]byte](), lowPriority),
+			},
+		},
+		{
+			name: "adjacent and disjoint ranges",
+			items: []*workItem{
+				newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Some([]byte{1}), lowPriority),
+				newWorkItem(ids.Empty, maybe.Some([]byte{1}), maybe.Some([]byte{2}), lowPriority),
+				newWorkItem(ids.Empty, maybe.Some([]byte{3}), maybe.Nothing[[]byte](), lowPriority),
+			},
+			expected: []*workItem{
+				newWorkItem(ids.Empty, maybe.Some([]byte{2}), maybe.Some([]byte{3}), lowPriority),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, progressGaps(test.items))
+		})
+	}
+}
+
+func TestCompletedFraction(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []*workItem
+		expected float64
+	}{
+		{
+			name:     "nothing completed",
+			expected: 0,
+		},
+		{
+			name: "everything completed",
+			items: []*workItem{
+				newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority),
+			},
+			expected: 1,
+		},
+		{
+			name: "first half completed",
+			items: []*workItem{
+				newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Some([]byte{0x80}), lowPriority),
+			},
+			expected: 0.5,
+		},
+		{
+			name: "disjoint ranges",
+			items: []*workItem{
+				newWorkItem(ids.Empty, maybe.Some([]byte{0x40}), maybe.Some([]byte{0x80}), lowPriority),
+				newWorkItem(ids.Empty, maybe.Some([]byte{0xc0}), maybe.Nothing[[]byte](), lowPriority),
+			},
+			expected: 0.5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, completedFraction(test.items))
+		})
+	}
+}
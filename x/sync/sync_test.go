@@ -916,6 +916,85 @@ func Test_Sync_Result_Correct_Root_With_Sync_Restart(t *testing.T) {
 	require.Equal(syncRoot, newRoot)
 }
 
+func Test_Sync_Result_Correct_Root_With_Persisted_Progress(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+	dbToSync, err := generateTrie(t, r, 3*maxKeyValuesLimit)
+	require.NoError(err)
+	syncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+	progressDB := memdb.New()
+
+	syncer, err := NewManager(ManagerConfig{
+		DB:                    db,
+		Client:                newCallthroughSyncClient(ctrl, dbToSync),
+		TargetRoot:            syncRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		ProgressDB:            progressDB,
+	})
+	require.NoError(err)
+	require.NoError(syncer.Start(context.Background()))
+
+	// Wait until we've processed some work before stopping the sync.
+	require.Eventually(
+		func() bool {
+			syncer.workLock.Lock()
+			defer syncer.workLock.Unlock()
+
+			return syncer.processedWork.Len() > 0
+		},
+		5*time.Second,
+		5*time.Millisecond,
+	)
+	syncer.Close()
+
+	syncer.workLock.Lock()
+	completedWork := syncer.processedWork.Items()
+	syncer.workLock.Unlock()
+	require.Len(completedWork, syncer.Progress().CompletedRanges)
+
+	persistedWork, err := getProgress(progressDB)
+	require.NoError(err)
+	require.Equal(completedWork, persistedWork)
+
+	newSyncer, err := NewManager(ManagerConfig{
+		DB:                    db,
+		Client:                newCallthroughSyncClient(ctrl, dbToSync),
+		TargetRoot:            syncRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		ProgressDB:            progressDB,
+	})
+	require.NoError(err)
+	require.NoError(newSyncer.Start(context.Background()))
+	require.NoError(newSyncer.Wait(context.Background()))
+	require.Equal(Progress{
+		CompletedRanges: 1,
+		Completed:       1,
+	}, newSyncer.Progress())
+
+	newRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(syncRoot, newRoot)
+
+	// The progress is removed once the sync completes.
+	persistedWork, err = getProgress(progressDB)
+	require.NoError(err)
+	require.Empty(persistedWork)
+}
+
 func Test_Sync_Error_During_Sync(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -94,9 +94,12 @@ func (wh *workHeap) GetWork() *workItem {
 // into a single work item with range [0,20].
 // e.g. if the heap contains work items [0,10] and [20,30],
 // and we add [10,20], we will merge them into [0,30].
-func (wh *workHeap) MergeInsert(item *workItem) {
+//
+// Returns the work item that [item] was merged into, or [item] if it wasn't
+// merged. Returns nil if the heap is closed.
+func (wh *workHeap) MergeInsert(item *workItem) *workItem {
 	if wh.closed {
-		return
+		return nil
 	}
 
 	var mergedBefore, mergedAfter *heapItem
@@ -152,10 +155,15 @@ func (wh *workHeap) MergeInsert(item *workItem) {
 		heap.Fix(&wh.innerHeap, mergedBefore.heapIndex)
 	}
 
-	// nothing was merged, so add new item to the heap
-	if mergedBefore == nil && mergedAfter == nil {
+	switch {
+	case mergedBefore != nil:
+		return mergedBefore.workItem
+	case mergedAfter != nil:
+		return mergedAfter.workItem
+	default:
 		// We didn't merge [item] with an existing one; put it in the heap.
 		wh.Insert(item)
+		return item
 	}
 }
 
@@ -166,6 +174,16 @@ func (wh *workHeap) remove(item *heapItem) {
 	wh.sortedItems.Delete(item)
 }
 
+// Items returns the work items in the heap sorted by their range start.
+func (wh *workHeap) Items() []*workItem {
+	items := make([]*workItem, 0, wh.Len())
+	wh.sortedItems.Ascend(func(item *heapItem) bool {
+		items = append(items, item.workItem)
+		return true
+	})
+	return items
+}
+
 func (wh *workHeap) Len() int {
 	return wh.innerHeap.Len()
 }